@@ -6,6 +6,8 @@
 //   - worker: Processing pipeline for enrichment, dedup, and scoring
 //   - digest: Scheduled digest generation and posting
 //   - http: Standalone web server for research UI and expanded views
+//   - maintenance: Scheduled view refreshes, derived table rebuilds and vacuums
+//   - all: Reader, worker, bot and digest scheduler in one process
 //
 // Example:
 //
@@ -16,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -25,6 +28,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/lueurxax/telegram-digest-bot/internal/app"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/synonyms"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
@@ -35,7 +39,7 @@ const (
 )
 
 func main() {
-	mode := flag.String(flagMode, "", "Service mode (bot, reader, worker, digest)")
+	mode := flag.String(flagMode, "", "Service mode (bot, reader, worker, digest, http, maintenance, all)")
 	once := flag.Bool("once", false, "Run once and exit (for digest mode)")
 
 	flag.Parse()
@@ -51,11 +55,14 @@ func main() {
 	defer stop()
 
 	poolOpts := db.PoolOptions{
-		MaxConns:          cfg.DBMaxConnections,
-		MinConns:          cfg.DBMinConnections,
-		MaxConnIdleTime:   cfg.DBMaxConnIdleTime,
-		MaxConnLifetime:   cfg.DBMaxConnLifetime,
-		HealthCheckPeriod: cfg.DBHealthCheckPeriod,
+		MaxConns:           cfg.DBMaxConnections,
+		MinConns:           cfg.DBMinConnections,
+		MaxConnIdleTime:    cfg.DBMaxConnIdleTime,
+		MaxConnLifetime:    cfg.DBMaxConnLifetime,
+		HealthCheckPeriod:  cfg.DBHealthCheckPeriod,
+		StatementTimeout:   cfg.DBStatementTimeout,
+		QueryTimeout:       cfg.DBQueryTimeout,
+		SlowQueryThreshold: cfg.DBSlowQueryThreshold,
 	}
 
 	database, err := db.NewWithOptions(ctx, cfg.PostgresDSN, poolOpts, &logger)
@@ -68,6 +75,10 @@ func main() {
 		logger.Fatal().Err(err).Msg("failed to run migrations")
 	}
 
+	if err := setupSearchExpander(cfg, database); err != nil {
+		logger.Fatal().Err(err).Msg("failed to load search synonyms")
+	}
+
 	application := app.New(cfg, database, &logger)
 
 	// Start health server in background for all modes except http (which IS the health server)
@@ -89,6 +100,25 @@ func main() {
 	}
 }
 
+// setupSearchExpander configures synonym and transliteration expansion of
+// research search queries.
+func setupSearchExpander(cfg *config.Config, database *db.DB) error {
+	var groups [][]string
+
+	if cfg.ResearchSearchSynonymsFile != "" {
+		loaded, err := synonyms.LoadDictionary(cfg.ResearchSearchSynonymsFile)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", cfg.ResearchSearchSynonymsFile, err)
+		}
+
+		groups = loaded
+	}
+
+	database.SetSearchExpander(synonyms.New(groups, cfg.ResearchSearchTranslit))
+
+	return nil
+}
+
 func newLogger(appEnv string) zerolog.Logger {
 	if appEnv == "local" {
 		return zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}).With().Timestamp().Logger()
@@ -109,6 +139,10 @@ func runMode(ctx context.Context, application *app.App, mode string, once bool,
 		return application.RunDigest(ctx, once)
 	case modeHTTP:
 		return application.RunHTTP(ctx)
+	case "maintenance":
+		return application.RunMaintenance(ctx)
+	case "all":
+		return application.RunAll(ctx)
 	default:
 		logger.Fatal().Str(flagMode, mode).Msg("invalid service mode")
 
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// dbnamePattern matches the dbname setting of a keyword/value DSN.
+var dbnamePattern = regexp.MustCompile(`(^|\s)dbname=('(?:[^'\\]|\\.)*'|\S*)`)
+
+// withDatabase returns dsn pointing at database name instead, for both URL
+// and keyword/value DSNs.
+func withDatabase(dsn, name string) (string, error) {
+	if name == "" {
+		return "", errDatabaseNameReq
+	}
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("parse dsn: %w", err)
+		}
+
+		u.Path = "/" + name
+		u.RawPath = ""
+
+		return u.String(), nil
+	}
+
+	setting := "dbname=" + name
+
+	// loc[3] is the end of the leading separator group.
+	if loc := dbnamePattern.FindStringSubmatchIndex(dsn); loc != nil {
+		return dsn[:loc[3]] + setting + dsn[loc[1]:], nil
+	}
+
+	return strings.TrimSpace(dsn + " " + setting), nil
+}
+
+func createDatabase(ctx context.Context, dsn, name string) error {
+	return execAdmin(ctx, dsn, "CREATE DATABASE "+pgx.Identifier{name}.Sanitize())
+}
+
+func dropDatabase(ctx context.Context, dsn, name string) error {
+	return execAdmin(ctx, dsn, "DROP DATABASE IF EXISTS "+pgx.Identifier{name}.Sanitize())
+}
+
+// execAdmin runs a database-level statement over a connection to dsn's
+// database; CREATE/DROP DATABASE cannot run in a pooled transaction.
+func execAdmin(ctx context.Context, dsn, statement string) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	if _, err := conn.Exec(ctx, statement); err != nil {
+		return fmt.Errorf("%s: %w", statement, err)
+	}
+
+	return nil
+}
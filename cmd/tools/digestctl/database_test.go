@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestWithDatabase(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{"url", "postgres://u:p@localhost:5432/digest?sslmode=disable", "postgres://u:p@localhost:5432/drill?sslmode=disable"},
+		{"url without database", "postgresql://localhost", "postgresql://localhost/drill"},
+		{"keyword first", "dbname=digest host=localhost", "dbname=drill host=localhost"},
+		{"keyword middle", "host=localhost dbname=digest user=u", "host=localhost dbname=drill user=u"},
+		{"keyword quoted", "host=localhost dbname='my db'", "host=localhost dbname=drill"},
+		{"keyword missing", "host=localhost user=u", "host=localhost user=u dbname=drill"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withDatabase(tt.dsn, "drill")
+			if err != nil {
+				t.Fatalf("withDatabase() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("withDatabase() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := withDatabase("host=localhost", ""); err == nil {
+		t.Error("expected error for empty database name")
+	}
+}
@@ -0,0 +1,419 @@
+// Package main provides digestctl, the operator tool for the bot database.
+//
+// Subcommands:
+//
+//	backup  -out DIR [-exclude-media]    take a consistent logical backup
+//	restore -from DIR [-create-db NAME]  restore a backup into a fresh database
+//	verify  -from DIR                    check backup checksums offline
+//	drill   -from DIR                    restore into a scratch database, verify, drop it
+//	provenance -item ID [-out FILE]      export a signed JSON bundle of an item for audits
+//	provenance-verify -from FILE         check the signature of a provenance bundle
+//	provenance-keygen                    print a new provenance signing key pair
+//	replay -window START/END [-out FILE] rebuild a past digest from recorded LLM responses
+//
+// Database subcommands take -dsn, defaulting to POSTGRES_DSN. Provenance
+// bundles are signed with the Ed25519 private key in PROVENANCE_SIGNING_KEY
+// and verified with the public key in -public-key, defaulting to
+// PROVENANCE_PUBLIC_KEY; -key-id, defaulting to PROVENANCE_KEY_ID, names the
+// key pair in the bundle. replay also loads the bot configuration from the
+// environment.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/backup"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/provenance"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	cmdBackup  = "backup"
+	cmdRestore = "restore"
+	cmdVerify  = "verify"
+	cmdDrill   = "drill"
+
+	cmdProvenance       = "provenance"
+	cmdProvenanceVerify = "provenance-verify"
+	cmdProvenanceKeygen = "provenance-keygen"
+	cmdReplay           = "replay"
+
+	bundleFilePerm = 0o600
+
+	drillDatabasePrefix = "digest_drill_"
+
+	errFmt = "%v\n"
+)
+
+var (
+	errDSNRequired     = errors.New("POSTGRES_DSN is required (or provide -dsn)")
+	errDirRequired     = errors.New("backup directory is required")
+	errUnknownCommand  = errors.New("unknown command")
+	errMissingCommand  = errors.New("missing command (backup, restore, verify, drill, provenance, provenance-verify, provenance-keygen, replay)")
+	errNoArgsExpected  = errors.New("unexpected arguments")
+	errDatabaseNameReq = errors.New("database name is required")
+	errItemRequired    = errors.New("item ID is required")
+	errFileRequired    = errors.New("bundle file is required")
+	errSigningKeyReq   = errors.New("PROVENANCE_SIGNING_KEY is required")
+	errPublicKeyReq    = errors.New("PROVENANCE_PUBLIC_KEY is required (or provide -public-key)")
+	errWindowRequired  = errors.New("window is required")
+)
+
+type ctlConfig struct {
+	dsn          string
+	dir          string
+	excludeMedia bool
+	createDB     string
+	itemID       string
+	file         string
+	keyID        string
+	signingKey   string
+	publicKey    string
+	window       string
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, errFmt, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errMissingCommand
+	}
+
+	cmd := args[0]
+
+	cfg, err := parseFlags(cmd, args[1:])
+	if err != nil {
+		return err
+	}
+
+	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
+
+	switch cmd {
+	case cmdBackup:
+		return runBackup(ctx, cfg, &logger)
+	case cmdRestore:
+		return runRestore(ctx, cfg, &logger)
+	case cmdVerify:
+		return runVerify(cfg, &logger)
+	case cmdProvenance:
+		return runProvenance(ctx, cfg, &logger)
+	case cmdProvenanceVerify:
+		return runProvenanceVerify(cfg, &logger)
+	case cmdProvenanceKeygen:
+		return runProvenanceKeygen()
+	case cmdReplay:
+		return runReplay(ctx, cfg, &logger)
+	default:
+		return runDrill(ctx, cfg, &logger)
+	}
+}
+
+func parseFlags(cmd string, args []string) (ctlConfig, error) {
+	if !slices.Contains([]string{cmdBackup, cmdRestore, cmdVerify, cmdDrill, cmdProvenance, cmdProvenanceVerify, cmdProvenanceKeygen, cmdReplay}, cmd) {
+		return ctlConfig{}, fmt.Errorf("%w: %s", errUnknownCommand, cmd)
+	}
+
+	cfg := ctlConfig{signingKey: os.Getenv("PROVENANCE_SIGNING_KEY")}
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+
+	if cmd != cmdVerify && cmd != cmdProvenanceVerify && cmd != cmdProvenanceKeygen {
+		fs.StringVar(&cfg.dsn, "dsn", os.Getenv("POSTGRES_DSN"), "Postgres DSN")
+	}
+
+	switch cmd {
+	case cmdBackup:
+		fs.StringVar(&cfg.dir, "out", "", "Backup directory to create")
+		fs.BoolVar(&cfg.excludeMedia, "exclude-media", false, "Dump downloaded media as NULL")
+	case cmdRestore:
+		fs.StringVar(&cfg.dir, "from", "", "Backup directory")
+		fs.StringVar(&cfg.createDB, "create-db", "", "Create this database on the DSN's server and restore into it")
+	case cmdProvenance:
+		fs.StringVar(&cfg.itemID, "item", "", "Item ID")
+		fs.StringVar(&cfg.file, "out", "", "Bundle file to write (default stdout)")
+		fs.StringVar(&cfg.keyID, "key-id", os.Getenv("PROVENANCE_KEY_ID"), "Signing key ID recorded in the bundle")
+	case cmdProvenanceVerify:
+		fs.StringVar(&cfg.file, "from", "", "Bundle file")
+		fs.StringVar(&cfg.publicKey, "public-key", os.Getenv("PROVENANCE_PUBLIC_KEY"), "Base64 Ed25519 public key")
+	case cmdProvenanceKeygen:
+	case cmdReplay:
+		fs.StringVar(&cfg.window, "window", "", "Digest window as START/END in RFC 3339")
+		fs.StringVar(&cfg.file, "out", "", "File to write the digest to (default stdout)")
+	default:
+		fs.StringVar(&cfg.dir, "from", "", "Backup directory")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, fmt.Errorf("parse %s flags: %w", cmd, err)
+	}
+
+	return cfg, validateConfig(cmd, cfg, fs.Args())
+}
+
+func validateConfig(cmd string, cfg ctlConfig, rest []string) error {
+	if len(rest) > 0 {
+		return fmt.Errorf("%w: %v", errNoArgsExpected, rest)
+	}
+
+	if cmd == cmdProvenance || cmd == cmdProvenanceVerify {
+		return validateProvenanceConfig(cmd, cfg)
+	}
+
+	if cmd == cmdProvenanceKeygen {
+		return nil
+	}
+
+	if cmd == cmdReplay {
+		return validateReplayConfig(cfg)
+	}
+
+	if cfg.dir == "" {
+		return errDirRequired
+	}
+
+	if cmd != cmdVerify && cfg.dsn == "" {
+		return errDSNRequired
+	}
+
+	return nil
+}
+
+func runBackup(ctx context.Context, cfg ctlConfig, logger *zerolog.Logger) error {
+	database, err := db.New(ctx, cfg.dsn, logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close()
+
+	started := time.Now()
+
+	m, err := backup.Backup(ctx, database, backup.Options{Dir: cfg.dir, ExcludeMedia: cfg.excludeMedia})
+	if err != nil {
+		return err
+	}
+
+	logger.Info().
+		Str("dir", cfg.dir).
+		Int64("schema_version", m.SchemaVersion).
+		Int("tables", len(m.Tables)).
+		Int64("rows", m.Rows()).
+		Bool("exclude_media", m.ExcludeMedia).
+		Dur("took", time.Since(started)).
+		Msg("Backup complete")
+
+	return nil
+}
+
+func runVerify(cfg ctlConfig, logger *zerolog.Logger) error {
+	m, err := backup.Verify(cfg.dir)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().
+		Str("dir", cfg.dir).
+		Int64("schema_version", m.SchemaVersion).
+		Int("tables", len(m.Tables)).
+		Int64("rows", m.Rows()).
+		Time("created_at", m.CreatedAt).
+		Msg("Backup checksums OK")
+
+	return nil
+}
+
+func runRestore(ctx context.Context, cfg ctlConfig, logger *zerolog.Logger) error {
+	dsn := cfg.dsn
+
+	if cfg.createDB != "" {
+		if err := createDatabase(ctx, cfg.dsn, cfg.createDB); err != nil {
+			return err
+		}
+
+		var err error
+		if dsn, err = withDatabase(cfg.dsn, cfg.createDB); err != nil {
+			return err
+		}
+	}
+
+	return restoreInto(ctx, dsn, cfg.dir, logger)
+}
+
+// runDrill restores the backup into a scratch database on the DSN's server
+// and drops it again, proving the backup restores.
+func runDrill(ctx context.Context, cfg ctlConfig, logger *zerolog.Logger) error {
+	name := fmt.Sprintf("%s%d", drillDatabasePrefix, time.Now().Unix())
+
+	dsn, err := withDatabase(cfg.dsn, name)
+	if err != nil {
+		return err
+	}
+
+	if err := createDatabase(ctx, cfg.dsn, name); err != nil {
+		return err
+	}
+
+	restoreErr := restoreInto(ctx, dsn, cfg.dir, logger)
+
+	if err := dropDatabase(ctx, cfg.dsn, name); err != nil {
+		return errors.Join(restoreErr, err)
+	}
+
+	if restoreErr != nil {
+		return restoreErr
+	}
+
+	logger.Info().Str("database", name).Msg("Restore drill passed")
+
+	return nil
+}
+
+func restoreInto(ctx context.Context, dsn, dir string, logger *zerolog.Logger) error {
+	database, err := db.New(ctx, dsn, logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close()
+
+	started := time.Now()
+
+	report, err := backup.Restore(ctx, database, dir)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().
+		Int64("schema_version", report.SchemaVersion).
+		Int64("migrated_to", report.MigratedTo).
+		Int("tables", report.Tables).
+		Int64("rows", report.Rows).
+		Int("vector_columns", report.VectorColumns).
+		Interface("materialized_views", report.Views).
+		Dur("took", time.Since(started)).
+		Msg("Restore complete")
+
+	return nil
+}
+
+func validateProvenanceConfig(cmd string, cfg ctlConfig) error {
+	switch {
+	case cmd == cmdProvenance && cfg.itemID == "":
+		return errItemRequired
+	case cmd == cmdProvenance && cfg.dsn == "":
+		return errDSNRequired
+	case cmd == cmdProvenance && cfg.signingKey == "":
+		return errSigningKeyReq
+	case cmd == cmdProvenanceVerify && cfg.file == "":
+		return errFileRequired
+	case cmd == cmdProvenanceVerify && cfg.publicKey == "":
+		return errPublicKeyReq
+	}
+
+	return nil
+}
+
+// runProvenance exports the signed provenance bundle of an item to the
+// output file, or stdout when none is given.
+func runProvenance(ctx context.Context, cfg ctlConfig, logger *zerolog.Logger) error {
+	key, err := provenance.ParsePrivateKey(cfg.signingKey)
+	if err != nil {
+		return fmt.Errorf("parse PROVENANCE_SIGNING_KEY: %w", err)
+	}
+
+	database, err := db.New(ctx, cfg.dsn, logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close()
+
+	bundle, err := provenance.Export(ctx, database, cfg.itemID, time.Now())
+	if err != nil {
+		return fmt.Errorf("export provenance: %w", err)
+	}
+
+	signed, err := provenance.Sign(bundle, key, cfg.keyID)
+	if err != nil {
+		return fmt.Errorf("sign provenance bundle: %w", err)
+	}
+
+	data, err := json.MarshalIndent(signed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal provenance bundle: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if cfg.file == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return fmt.Errorf("write provenance bundle: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(cfg.file, data, bundleFilePerm); err != nil {
+		return fmt.Errorf("write provenance bundle: %w", err)
+	}
+
+	logger.Info().
+		Str("item", cfg.itemID).
+		Str("file", cfg.file).
+		Int("evidence", len(bundle.Evidence)).
+		Int("ratings", len(bundle.Ratings)).
+		Msg("Provenance bundle exported")
+
+	return nil
+}
+
+func runProvenanceVerify(cfg ctlConfig, logger *zerolog.Logger) error {
+	key, err := provenance.ParsePublicKey(cfg.publicKey)
+	if err != nil {
+		return fmt.Errorf("parse public key: %w", err)
+	}
+
+	data, err := os.ReadFile(cfg.file)
+	if err != nil {
+		return fmt.Errorf("read provenance bundle: %w", err)
+	}
+
+	bundle, signed, err := provenance.Verify(data, key)
+	if err != nil {
+		return fmt.Errorf("verify provenance bundle: %w", err)
+	}
+
+	logger.Info().
+		Str("item", bundle.Item.ID).
+		Str("key_id", signed.Signature.KeyID).
+		Time("exported_at", bundle.ExportedAt).
+		Msg("Provenance bundle signature OK")
+
+	return nil
+}
+
+// runProvenanceKeygen prints a new key pair in the environment variable
+// format. The private key stays with the exporter; the public key is handed
+// to whoever verifies bundles.
+func runProvenanceKeygen() error {
+	privateKey, publicKey, err := provenance.GenerateKey()
+	if err != nil {
+		return fmt.Errorf("generate provenance key: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(os.Stdout, "PROVENANCE_SIGNING_KEY=%s\nPROVENANCE_PUBLIC_KEY=%s\n", privateKey, publicKey); err != nil {
+		return fmt.Errorf("write provenance key: %w", err)
+	}
+
+	return nil
+}
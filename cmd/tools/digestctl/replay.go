@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const replayFilePerm = 0o600
+
+var errInvalidWindow = errors.New("window must be START/END in RFC 3339 with START before END")
+
+func validateReplayConfig(cfg ctlConfig) error {
+	if cfg.window == "" {
+		return errWindowRequired
+	}
+
+	if _, _, err := parseWindow(cfg.window); err != nil {
+		return err
+	}
+
+	if cfg.dsn == "" {
+		return errDSNRequired
+	}
+
+	return nil
+}
+
+// parseWindow parses a digest window given as START/END in RFC 3339.
+func parseWindow(window string) (time.Time, time.Time, error) {
+	startStr, endStr, ok := strings.Cut(window, "/")
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %q", errInvalidWindow, window)
+	}
+
+	start, err := time.Parse(time.RFC3339, strings.TrimSpace(startStr))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %w", errInvalidWindow, err)
+	}
+
+	end, err := time.Parse(time.RFC3339, strings.TrimSpace(endStr))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %w", errInvalidWindow, err)
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %q", errInvalidWindow, window)
+	}
+
+	return start.UTC(), end.UTC(), nil
+}
+
+// runReplay rebuilds the digest of a past window as the current target chat
+// would receive it, answering every LLM request from the recorded cassettes.
+// Nothing is posted; the digest text goes to the output file or stdout.
+func runReplay(ctx context.Context, cfg ctlConfig, logger *zerolog.Logger) error {
+	start, end, err := parseWindow(cfg.window)
+	if err != nil {
+		return err
+	}
+
+	appCfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	appCfg.PostgresDSN = cfg.dsn
+
+	database, err := db.New(ctx, cfg.dsn, logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close()
+
+	client := llm.NewReplayClient(database, logger)
+	scheduler := digest.New(appCfg, database, nil, client, logger)
+
+	threshold := appCfg.ImportanceThreshold
+	if err := database.GetSetting(ctx, digest.SettingImportanceThreshold, &threshold); err != nil {
+		logger.Debug().Err(err).Msg("could not get importance_threshold from DB, using default")
+	}
+
+	text, items, clusters, _, err := scheduler.BuildDigest(ctx, start, end, threshold, logger)
+	if err != nil {
+		return fmt.Errorf("build digest: %w", err)
+	}
+
+	if err := writeReplay(cfg.file, text); err != nil {
+		return err
+	}
+
+	hits, misses := client.ReplayStats()
+
+	event := logger.Info()
+	if misses > 0 {
+		event = logger.Warn()
+	}
+
+	event.
+		Time("start", start).
+		Time("end", end).
+		Int("items", len(items)).
+		Int("clusters", len(clusters)).
+		Int64("llm_hits", hits).
+		Int64("llm_misses", misses).
+		Msg("Digest replayed")
+
+	return nil
+}
+
+func writeReplay(file, text string) error {
+	data := []byte(text + "\n")
+
+	if file == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return fmt.Errorf("write replayed digest: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(file, data, replayFilePerm); err != nil {
+		return fmt.Errorf("write replayed digest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	start, end, err := parseWindow("2026-10-01T08:00:00Z/2026-10-01T12:00:00+02:00")
+	if err != nil {
+		t.Fatalf("parseWindow() error = %v", err)
+	}
+
+	if want := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+
+	if want := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %v, want %v", end, want)
+	}
+
+	for _, window := range []string{
+		"2026-10-01T08:00:00Z",
+		"2026-10-01/2026-10-02",
+		"2026-10-01T12:00:00Z/2026-10-01T08:00:00Z",
+	} {
+		if _, _, err := parseWindow(window); !errors.Is(err, errInvalidWindow) {
+			t.Errorf("parseWindow(%q) error = %v, want errInvalidWindow", window, err)
+		}
+	}
+}
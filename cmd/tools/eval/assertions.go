@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var (
+	errAssertionsFailed = errors.New("assertions failed")
+	errAssertionMetric  = errors.New("assertion has no metric")
+	errAssertionBounds  = errors.New("assertion has neither min nor max")
+	errUnknownMetric    = errors.New("unknown metric")
+)
+
+// assertionConfig is the file passed with -assertions.
+type assertionConfig struct {
+	Assertions []assertion `json:"assertions"`
+}
+
+// assertion is a named bound on a reported metric. Min and max are inclusive.
+type assertion struct {
+	Name   string   `json:"name"`
+	Metric string   `json:"metric"`
+	Min    *float64 `json:"min,omitempty"`
+	Max    *float64 `json:"max,omitempty"`
+}
+
+type assertionResult struct {
+	assertion
+
+	Value   float64 `json:"value"`
+	Passed  bool    `json:"passed"`
+	Message string  `json:"message,omitempty"`
+}
+
+func loadAssertions(path string) ([]assertion, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read assertions: %w", err)
+	}
+
+	var cfg assertionConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode assertions: %w", err)
+	}
+
+	for i := range cfg.Assertions {
+		if err := validateAssertion(&cfg.Assertions[i]); err != nil {
+			return nil, fmt.Errorf("assertion %d: %w", i+1, err)
+		}
+	}
+
+	return cfg.Assertions, nil
+}
+
+func validateAssertion(a *assertion) error {
+	if a.Metric == "" {
+		return errAssertionMetric
+	}
+
+	if !isKnownMetric(a.Metric) {
+		return fmt.Errorf("%w: %s", errUnknownMetric, a.Metric)
+	}
+
+	if a.Min == nil && a.Max == nil {
+		return fmt.Errorf("%w: %s", errAssertionBounds, a.Metric)
+	}
+
+	if a.Name == "" {
+		a.Name = a.Metric
+	}
+
+	return nil
+}
+
+// configuredAssertions combines the assertions file with the threshold flags.
+func configuredAssertions(cfg evalConfig) ([]assertion, error) {
+	var assertions []assertion
+
+	if cfg.assertionsPath != "" {
+		loaded, err := loadAssertions(cfg.assertionsPath)
+		if err != nil {
+			return nil, err
+		}
+
+		assertions = loaded
+	}
+
+	if cfg.minPrecision >= 0 {
+		assertions = append(assertions, assertion{Name: "min-precision", Metric: metricPrecision, Min: &cfg.minPrecision})
+	}
+
+	if cfg.maxNoiseRate >= 0 {
+		assertions = append(assertions, assertion{Name: "max-noise-rate", Metric: metricNoiseRate, Max: &cfg.maxNoiseRate})
+	}
+
+	if cfg.minNDCG >= 0 {
+		assertions = append(assertions, assertion{Name: "min-ndcg", Metric: metricNDCG, Min: &cfg.minNDCG})
+	}
+
+	return assertions, nil
+}
+
+// checkAssertions evaluates each assertion against the reported metrics. A
+// metric that was not reported, such as a ranking metric without -rankings,
+// fails its assertion.
+func checkAssertions(assertions []assertion, metrics map[string]float64) []assertionResult {
+	results := make([]assertionResult, 0, len(assertions))
+
+	for _, a := range assertions {
+		res := assertionResult{assertion: a, Passed: true}
+
+		value, ok := metrics[a.Metric]
+
+		switch {
+		case !ok:
+			res.Passed = false
+			res.Message = fmt.Sprintf("%s was not reported", a.Metric)
+		case a.Min != nil && value < *a.Min:
+			res.Passed = false
+			res.Message = fmt.Sprintf("%s %.3f < %.3f", a.Metric, value, *a.Min)
+		case a.Max != nil && value > *a.Max:
+			res.Passed = false
+			res.Message = fmt.Sprintf("%s %.3f > %.3f", a.Metric, value, *a.Max)
+		}
+
+		res.Value = value
+		results = append(results, res)
+	}
+
+	return results
+}
+
+func failedAssertions(results []assertionResult) int {
+	failed := 0
+
+	for _, res := range results {
+		if !res.Passed {
+			failed++
+		}
+	}
+
+	return failed
+}
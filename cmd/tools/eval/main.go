@@ -1,15 +1,18 @@
 // Package main provides evaluation tools for measuring digest quality.
 //
 // The eval tool compares labeled data (golden set) against system outputs
-// to calculate precision, recall, and noise rate metrics.
+// to calculate precision, recall, and noise rate metrics. Given the system's
+// ranked output per digest window, it also computes nDCG@k, MAP and
+// Kendall's tau against the labels. Reports are written as text, JSON,
+// Markdown or JUnit XML, and named metric assertions gate the exit code.
 package main
 
 import (
 	"bufio"
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
@@ -27,11 +30,6 @@ const (
 	errFmt = "%v\n"
 )
 
-var (
-	errPrecisionBelowThreshold = errors.New("precision below threshold")
-	errNoiseRateAboveThreshold = errors.New("noise rate above threshold")
-)
-
 type evalRecord struct {
 	ID              string  `json:"id"`
 	Label           string  `json:"label"`
@@ -50,6 +48,8 @@ type evalStats struct {
 	goodCount       int
 	badCount        int
 	irrelevantCount int
+	// labels maps record IDs to their normalized labels, for ranking metrics.
+	labels map[string]string
 }
 
 type evalConfig struct {
@@ -59,23 +59,61 @@ type evalConfig struct {
 	ignoreImportance    bool
 	minPrecision        float64
 	maxNoiseRate        float64
+	rankingsPath        string
+	rankingK            int
+	minNDCG             float64
+	format              string
+	assertionsPath      string
 }
 
 func main() {
 	cfg := parseFlags()
 
-	stats, err := processInputFile(cfg)
-	if err != nil {
+	if err := run(cfg, os.Stdout); err != nil {
 		fmt.Fprintf(os.Stderr, errFmt, err)
 		os.Exit(1)
 	}
+}
 
-	printSummary(stats, cfg)
+func run(cfg evalConfig, w io.Writer) error {
+	if !isKnownFormat(cfg.format) {
+		return fmt.Errorf("%w: %s", errUnknownFormat, cfg.format)
+	}
 
-	if err := checkThresholds(stats, cfg); err != nil {
-		fmt.Fprintf(os.Stderr, errFmt, err)
-		os.Exit(1)
+	assertions, err := configuredAssertions(cfg)
+	if err != nil {
+		return err
+	}
+
+	stats, err := processInputFile(cfg)
+	if err != nil {
+		return err
+	}
+
+	report := newReport(stats, cfg)
+
+	if cfg.rankingsPath != "" {
+		windows, err := loadRankings(cfg.rankingsPath)
+		if err != nil {
+			return err
+		}
+
+		report.Ranking = newRankingReport(evaluateRankings(windows, stats.labels, cfg.rankingK), cfg.rankingK)
+	}
+
+	report.Assertions = checkAssertions(assertions, report.metrics())
+	failed := failedAssertions(report.Assertions)
+	report.Passed = failed == 0
+
+	if err := writeReport(w, report, cfg.format); err != nil {
+		return err
 	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errAssertionsFailed, failed, len(report.Assertions))
+	}
+
+	return nil
 }
 
 func parseFlags() evalConfig {
@@ -87,6 +125,11 @@ func parseFlags() evalConfig {
 	flag.BoolVar(&cfg.ignoreImportance, "ignore-importance", false, "Ignore importance score threshold")
 	flag.Float64Var(&cfg.minPrecision, "min-precision", -1, "Fail if precision is below this value (disabled if <0)")
 	flag.Float64Var(&cfg.maxNoiseRate, "max-noise-rate", -1, "Fail if noise rate is above this value (disabled if <0)")
+	flag.StringVar(&cfg.rankingsPath, "rankings", "", "Path to JSONL ranked system output per window (enables ranking metrics)")
+	flag.IntVar(&cfg.rankingK, "k", defaultRankingK, "Cutoff for nDCG@k")
+	flag.Float64Var(&cfg.minNDCG, "min-ndcg", -1, "Fail if mean nDCG@k is below this value (disabled if <0)")
+	flag.StringVar(&cfg.format, "format", formatText, "Report format: text, json, markdown or junit")
+	flag.StringVar(&cfg.assertionsPath, "assertions", "", "Path to JSON file of named metric assertions")
 
 	flag.Parse()
 
@@ -104,7 +147,7 @@ func processInputFile(cfg evalConfig) (evalStats, error) {
 }
 
 func scanRecords(f *os.File, cfg evalConfig) (evalStats, error) {
-	stats := evalStats{}
+	stats := evalStats{labels: make(map[string]string)}
 	scanner := bufio.NewScanner(f)
 	scanner.Buffer(make([]byte, 0, scannerBufferMultiplier*maxScannerBufferSize), maxScannerBufferSize*maxScannerBufferSize)
 
@@ -140,6 +183,11 @@ func processLine(line string, cfg evalConfig, stats *evalStats) {
 	}
 
 	stats.total++
+
+	if rec.ID != "" {
+		stats.labels[rec.ID] = label
+	}
+
 	updateLabelCounts(label, stats)
 	updateConfusionMatrix(rec, label, cfg, stats)
 }
@@ -175,21 +223,6 @@ func updateConfusionMatrix(rec evalRecord, label string, cfg evalConfig, stats *
 	}
 }
 
-func checkThresholds(stats evalStats, cfg evalConfig) error {
-	precision := ratio(stats.tp, stats.tp+stats.fp)
-	noiseRate := ratio(stats.fp, stats.tp+stats.fp)
-
-	if cfg.minPrecision >= 0 && precision < cfg.minPrecision {
-		return fmt.Errorf("%w: %.3f < %.3f", errPrecisionBelowThreshold, precision, cfg.minPrecision)
-	}
-
-	if cfg.maxNoiseRate >= 0 && noiseRate > cfg.maxNoiseRate {
-		return fmt.Errorf("%w: %.3f > %.3f", errNoiseRateAboveThreshold, noiseRate, cfg.maxNoiseRate)
-	}
-
-	return nil
-}
-
 func normalizeLabel(label string, rating string) string {
 	val := strings.ToLower(strings.TrimSpace(label))
 	if val == "" {
@@ -204,30 +237,6 @@ func normalizeLabel(label string, rating string) string {
 	}
 }
 
-func printSummary(stats evalStats, cfg evalConfig) {
-	predictedTotal := stats.tp + stats.fp
-	precision := ratio(stats.tp, predictedTotal)
-	recall := ratio(stats.tp, stats.tp+stats.fn)
-	noiseRate := ratio(stats.fp, predictedTotal)
-	coverage := ratio(predictedTotal, stats.total)
-
-	fmt.Printf("Evaluation Summary\n")
-	fmt.Printf("  Records: %d (skipped: %d)\n", stats.total, stats.skipped)
-	fmt.Printf("  Labels: good=%d bad=%d irrelevant=%d\n", stats.goodCount, stats.badCount, stats.irrelevantCount)
-
-	if cfg.ignoreImportance {
-		fmt.Printf("  Thresholds: relevance>=%.2f (importance ignored)\n", cfg.relevanceThreshold)
-	} else {
-		fmt.Printf("  Thresholds: relevance>=%.2f importance>=%.2f\n", cfg.relevanceThreshold, cfg.importanceThreshold)
-	}
-
-	fmt.Printf("  Confusion: TP=%d FP=%d FN=%d TN=%d\n", stats.tp, stats.fp, stats.fn, stats.tn)
-	fmt.Printf("  Precision: %.3f\n", precision)
-	fmt.Printf("  Recall: %.3f\n", recall)
-	fmt.Printf("  NoiseRate: %.3f\n", noiseRate)
-	fmt.Printf("  Coverage: %.3f\n", coverage)
-}
-
 func ratio(numerator, denominator int) float64 {
 	if denominator == 0 {
 		return 0
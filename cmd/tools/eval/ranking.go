@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Relevance grades of the labels for graded ranking metrics.
+const (
+	gradeGood       = 2
+	gradeBad        = 1
+	gradeIrrelevant = 0
+
+	defaultRankingK = 10
+)
+
+// rankedWindow is the system's ranking of one digest window, best first.
+type rankedWindow struct {
+	Window string   `json:"window"`
+	Items  []string `json:"items"`
+}
+
+// rankingStats aggregates ranking metrics over windows. Unlabeled items are
+// dropped from each ranking before scoring (condensed lists).
+type rankingStats struct {
+	windows     int
+	skipped     int
+	rankedItems int
+	judgedItems int
+	ndcgSum     float64
+	apSum       float64
+	apWindows   int
+	tauSum      float64
+	tauWindows  int
+}
+
+func (s rankingStats) meanNDCG() float64 {
+	if s.windows == 0 {
+		return 0
+	}
+
+	return s.ndcgSum / float64(s.windows)
+}
+
+func (s rankingStats) meanAP() float64 {
+	if s.apWindows == 0 {
+		return 0
+	}
+
+	return s.apSum / float64(s.apWindows)
+}
+
+func (s rankingStats) meanTau() float64 {
+	if s.tauWindows == 0 {
+		return 0
+	}
+
+	return s.tauSum / float64(s.tauWindows)
+}
+
+func labelGrade(label string) int {
+	switch label {
+	case labelGood:
+		return gradeGood
+	case labelBad:
+		return gradeBad
+	default:
+		return gradeIrrelevant
+	}
+}
+
+func loadRankings(path string) ([]rankedWindow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rankings: %w", err)
+	}
+	defer f.Close()
+
+	var windows []rankedWindow
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, scannerBufferMultiplier*maxScannerBufferSize), maxScannerBufferSize*maxScannerBufferSize)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var w rankedWindow
+		if err := json.Unmarshal([]byte(line), &w); err != nil {
+			return nil, fmt.Errorf("failed to decode ranking %d: %w", len(windows)+1, err)
+		}
+
+		windows = append(windows, w)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rankings: %w", err)
+	}
+
+	return windows, nil
+}
+
+// evaluateRankings scores each window's ranking against the labels.
+// Windows without labeled items are skipped.
+func evaluateRankings(windows []rankedWindow, labels map[string]string, k int) rankingStats {
+	stats := rankingStats{}
+
+	for _, w := range windows {
+		grades := judgedGrades(w.Items, labels)
+
+		stats.rankedItems += len(w.Items)
+		stats.judgedItems += len(grades)
+
+		if len(grades) == 0 {
+			stats.skipped++
+			continue
+		}
+
+		stats.windows++
+		stats.ndcgSum += ndcgAtK(grades, k)
+
+		if ap, ok := averagePrecision(grades); ok {
+			stats.apSum += ap
+			stats.apWindows++
+		}
+
+		if tau, ok := kendallTauB(grades); ok {
+			stats.tauSum += tau
+			stats.tauWindows++
+		}
+	}
+
+	return stats
+}
+
+// judgedGrades returns the grades of the labeled items in ranking order.
+func judgedGrades(items []string, labels map[string]string) []int {
+	grades := make([]int, 0, len(items))
+
+	for _, id := range items {
+		if label, ok := labels[id]; ok {
+			grades = append(grades, labelGrade(label))
+		}
+	}
+
+	return grades
+}
+
+// ndcgAtK is DCG@k of the ranking divided by DCG@k of the ideal ordering of
+// the same grades, with gain 2^grade-1. A ranking without any gain scores 1:
+// no ordering could do better.
+func ndcgAtK(grades []int, k int) float64 {
+	ideal := slices.Clone(grades)
+	slices.SortFunc(ideal, func(a, b int) int { return b - a })
+
+	idcg := dcgAtK(ideal, k)
+	if idcg == 0 {
+		return 1
+	}
+
+	return dcgAtK(grades, k) / idcg
+}
+
+func dcgAtK(grades []int, k int) float64 {
+	var dcg float64
+
+	for i, g := range grades {
+		if i == k {
+			break
+		}
+
+		dcg += (math.Pow(2, float64(g)) - 1) / math.Log2(float64(i)+2)
+	}
+
+	return dcg
+}
+
+// averagePrecision treats good items as relevant. It reports false when the
+// ranking has no relevant item.
+func averagePrecision(grades []int) (float64, bool) {
+	var (
+		hits int
+		sum  float64
+	)
+
+	for i, g := range grades {
+		if g == gradeGood {
+			hits++
+			sum += float64(hits) / float64(i+1)
+		}
+	}
+
+	if hits == 0 {
+		return 0, false
+	}
+
+	return sum / float64(hits), true
+}
+
+// kendallTauB compares the system order with the label grades. The system
+// ranks have no ties; ties in the grades are corrected for (tau-b). It
+// reports false when all grades are equal.
+func kendallTauB(grades []int) (float64, bool) {
+	var concordant, discordant, tiedGrades int
+
+	for i := range grades {
+		for j := i + 1; j < len(grades); j++ {
+			// Item i is ranked above item j.
+			switch {
+			case grades[i] > grades[j]:
+				concordant++
+			case grades[i] < grades[j]:
+				discordant++
+			default:
+				tiedGrades++
+			}
+		}
+	}
+
+	pairs := concordant + discordant + tiedGrades
+	denominator := math.Sqrt(float64(pairs) * float64(pairs-tiedGrades))
+
+	if denominator == 0 {
+		return 0, false
+	}
+
+	return float64(concordant-discordant) / denominator, true
+}
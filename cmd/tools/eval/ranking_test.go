@@ -0,0 +1,128 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func TestNDCGAtK(t *testing.T) {
+	if got := ndcgAtK([]int{2, 1, 0}, 10); math.Abs(got-1) > epsilon {
+		t.Errorf("ndcgAtK(ideal) = %v, want 1", got)
+	}
+
+	// DCG = 1 + 3/log2(3), IDCG = 3 + 1/log2(3).
+	want := (1 + 3/math.Log2(3)) / (3 + 1/math.Log2(3))
+	if got := ndcgAtK([]int{1, 2}, 10); math.Abs(got-want) > epsilon {
+		t.Errorf("ndcgAtK(swapped) = %v, want %v", got, want)
+	}
+
+	if got := ndcgAtK([]int{0, 2}, 1); got != 0 {
+		t.Errorf("ndcgAtK(k=1) = %v, want 0", got)
+	}
+
+	if got := ndcgAtK([]int{0, 0}, 10); got != 1 {
+		t.Errorf("ndcgAtK(no gain) = %v, want 1", got)
+	}
+}
+
+func TestAveragePrecision(t *testing.T) {
+	// Relevant at ranks 1 and 3: (1/1 + 2/3) / 2.
+	got, ok := averagePrecision([]int{2, 0, 2, 1})
+	if want := (1 + 2.0/3) / 2; !ok || math.Abs(got-want) > epsilon {
+		t.Errorf("averagePrecision() = %v, %v, want %v, true", got, ok, want)
+	}
+
+	if _, ok := averagePrecision([]int{1, 0}); ok {
+		t.Error("averagePrecision(no good items) ok = true, want false")
+	}
+}
+
+func TestKendallTauB(t *testing.T) {
+	tests := []struct {
+		name   string
+		grades []int
+		want   float64
+		ok     bool
+	}{
+		{name: "same order", grades: []int{2, 1, 0}, want: 1, ok: true},
+		{name: "reversed", grades: []int{0, 1, 2}, want: -1, ok: true},
+		// Pairs: 2 concordant, 0 discordant, 1 tied; 2 / sqrt(3 * 2).
+		{name: "ties", grades: []int{2, 2, 0}, want: 2 / math.Sqrt(6), ok: true},
+		{name: "all tied", grades: []int{1, 1, 1}, ok: false},
+		{name: "single item", grades: []int{2}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := kendallTauB(tt.grades)
+			if ok != tt.ok || math.Abs(got-tt.want) > epsilon {
+				t.Errorf("kendallTauB(%v) = %v, %v, want %v, %v", tt.grades, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestEvaluateRankings(t *testing.T) {
+	labels := map[string]string{"a": labelGood, "b": labelBad, "c": labelIrrelevant}
+	windows := []rankedWindow{
+		{Window: "w1", Items: []string{"a", "x", "b", "c"}},
+		{Window: "w2", Items: []string{"x", "y"}},
+		{Window: "w3", Items: []string{"c", "b"}},
+	}
+
+	stats := evaluateRankings(windows, labels, defaultRankingK)
+
+	if stats.windows != 2 || stats.skipped != 1 {
+		t.Errorf("windows = %d, skipped = %d, want 2, 1", stats.windows, stats.skipped)
+	}
+
+	if stats.rankedItems != 8 || stats.judgedItems != 5 {
+		t.Errorf("ranked = %d, judged = %d, want 8, 5", stats.rankedItems, stats.judgedItems)
+	}
+
+	if stats.apWindows != 1 || stats.meanAP() != 1 {
+		t.Errorf("MAP = %v over %d windows, want 1 over 1", stats.meanAP(), stats.apWindows)
+	}
+
+	if stats.tauWindows != 2 || stats.meanTau() != 0 {
+		t.Errorf("tau = %v over %d windows, want 0 over 2", stats.meanTau(), stats.tauWindows)
+	}
+
+	report := evalReport{Ranking: newRankingReport(stats, defaultRankingK)}
+	minNDCG := 0.99
+
+	results := checkAssertions([]assertion{{Name: "min-ndcg", Metric: metricNDCG, Min: &minNDCG}}, report.metrics())
+	if failedAssertions(results) != 1 {
+		t.Errorf("checkAssertions() = %+v, want nDCG below threshold", results)
+	}
+}
+
+func TestLoadRankings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rankings.jsonl")
+	data := "{\"window\": \"w1\", \"items\": [\"a\", \"b\"]}\n\n{\"window\": \"w2\", \"items\": []}\n"
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	windows, err := loadRankings(path)
+	if err != nil {
+		t.Fatalf("loadRankings() error = %v", err)
+	}
+
+	if len(windows) != 2 || windows[0].Window != "w1" || len(windows[0].Items) != 2 {
+		t.Errorf("loadRankings() = %+v", windows)
+	}
+
+	if err := os.WriteFile(path, []byte("not json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadRankings(path); err == nil {
+		t.Error("loadRankings(invalid) error = nil, want error")
+	}
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Report formats.
+const (
+	formatText     = "text"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+	formatJUnit    = "junit"
+)
+
+// Metric names used in reports and assertions.
+const (
+	metricPrecision  = "precision"
+	metricRecall     = "recall"
+	metricNoiseRate  = "noise_rate"
+	metricCoverage   = "coverage"
+	metricNDCG       = "ndcg"
+	metricMAP        = "map"
+	metricKendallTau = "kendall_tau"
+
+	junitSuiteName = "eval"
+)
+
+var errUnknownFormat = errors.New("unknown format")
+
+var knownMetrics = []string{
+	metricPrecision, metricRecall, metricNoiseRate, metricCoverage,
+	metricNDCG, metricMAP, metricKendallTau,
+}
+
+func isKnownMetric(name string) bool {
+	return slices.Contains(knownMetrics, name)
+}
+
+func isKnownFormat(format string) bool {
+	switch format {
+	case formatText, formatJSON, formatMarkdown, formatJUnit:
+		return true
+	default:
+		return false
+	}
+}
+
+// evalReport is the result of one run, as written in every format.
+type evalReport struct {
+	Records    int               `json:"records"`
+	Skipped    int               `json:"skipped"`
+	Labels     labelCounts       `json:"labels"`
+	Thresholds reportThresholds  `json:"thresholds"`
+	Confusion  confusionMatrix   `json:"confusion"`
+	Precision  float64           `json:"precision"`
+	Recall     float64           `json:"recall"`
+	NoiseRate  float64           `json:"noise_rate"`
+	Coverage   float64           `json:"coverage"`
+	Ranking    *rankingReport    `json:"ranking,omitempty"`
+	Assertions []assertionResult `json:"assertions"`
+	Passed     bool              `json:"passed"`
+}
+
+type labelCounts struct {
+	Good       int `json:"good"`
+	Bad        int `json:"bad"`
+	Irrelevant int `json:"irrelevant"`
+}
+
+type reportThresholds struct {
+	Relevance        float64 `json:"relevance"`
+	Importance       float64 `json:"importance"`
+	IgnoreImportance bool    `json:"ignore_importance"`
+}
+
+type confusionMatrix struct {
+	TP int `json:"tp"`
+	FP int `json:"fp"`
+	FN int `json:"fn"`
+	TN int `json:"tn"`
+}
+
+type rankingReport struct {
+	K           int     `json:"k"`
+	Windows     int     `json:"windows"`
+	Skipped     int     `json:"skipped"`
+	RankedItems int     `json:"ranked_items"`
+	JudgedItems int     `json:"judged_items"`
+	NDCG        float64 `json:"ndcg"`
+	MAP         float64 `json:"map"`
+	APWindows   int     `json:"map_windows"`
+	KendallTau  float64 `json:"kendall_tau"`
+	TauWindows  int     `json:"kendall_tau_windows"`
+}
+
+func newReport(stats evalStats, cfg evalConfig) evalReport {
+	predictedTotal := stats.tp + stats.fp
+
+	return evalReport{
+		Records: stats.total,
+		Skipped: stats.skipped,
+		Labels:  labelCounts{Good: stats.goodCount, Bad: stats.badCount, Irrelevant: stats.irrelevantCount},
+		Thresholds: reportThresholds{
+			Relevance:        cfg.relevanceThreshold,
+			Importance:       cfg.importanceThreshold,
+			IgnoreImportance: cfg.ignoreImportance,
+		},
+		Confusion: confusionMatrix{TP: stats.tp, FP: stats.fp, FN: stats.fn, TN: stats.tn},
+		Precision: ratio(stats.tp, predictedTotal),
+		Recall:    ratio(stats.tp, stats.tp+stats.fn),
+		NoiseRate: ratio(stats.fp, predictedTotal),
+		Coverage:  ratio(predictedTotal, stats.total),
+		Passed:    true,
+	}
+}
+
+func newRankingReport(stats rankingStats, k int) *rankingReport {
+	return &rankingReport{
+		K:           k,
+		Windows:     stats.windows,
+		Skipped:     stats.skipped,
+		RankedItems: stats.rankedItems,
+		JudgedItems: stats.judgedItems,
+		NDCG:        stats.meanNDCG(),
+		MAP:         stats.meanAP(),
+		APWindows:   stats.apWindows,
+		KendallTau:  stats.meanTau(),
+		TauWindows:  stats.tauWindows,
+	}
+}
+
+// metrics returns the reported metrics by name. Ranking metrics are only
+// present when rankings were evaluated.
+func (r evalReport) metrics() map[string]float64 {
+	metrics := map[string]float64{
+		metricPrecision: r.Precision,
+		metricRecall:    r.Recall,
+		metricNoiseRate: r.NoiseRate,
+		metricCoverage:  r.Coverage,
+	}
+
+	if r.Ranking != nil {
+		metrics[metricNDCG] = r.Ranking.NDCG
+		metrics[metricMAP] = r.Ranking.MAP
+		metrics[metricKendallTau] = r.Ranking.KendallTau
+	}
+
+	return metrics
+}
+
+func writeReport(w io.Writer, report evalReport, format string) error {
+	switch format {
+	case formatJSON:
+		return writeJSONReport(w, report)
+	case formatMarkdown:
+		return writeMarkdownReport(w, report)
+	case formatJUnit:
+		return writeJUnitReport(w, report)
+	case formatText:
+		return writeTextReport(w, report)
+	default:
+		return fmt.Errorf("%w: %s", errUnknownFormat, format)
+	}
+}
+
+func writeJSONReport(w io.Writer, report evalReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	return nil
+}
+
+// reportWriter accumulates output so that each format reports a single
+// write error.
+type reportWriter struct {
+	sb strings.Builder
+}
+
+func (rw *reportWriter) printf(format string, args ...any) {
+	fmt.Fprintf(&rw.sb, format, args...)
+}
+
+func (rw *reportWriter) flush(w io.Writer) error {
+	if _, err := io.WriteString(w, rw.sb.String()); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+func writeTextReport(w io.Writer, report evalReport) error {
+	var rw reportWriter
+
+	rw.printf("Evaluation Summary\n")
+	rw.printf("  Records: %d (skipped: %d)\n", report.Records, report.Skipped)
+	rw.printf("  Labels: good=%d bad=%d irrelevant=%d\n", report.Labels.Good, report.Labels.Bad, report.Labels.Irrelevant)
+
+	if report.Thresholds.IgnoreImportance {
+		rw.printf("  Thresholds: relevance>=%.2f (importance ignored)\n", report.Thresholds.Relevance)
+	} else {
+		rw.printf("  Thresholds: relevance>=%.2f importance>=%.2f\n", report.Thresholds.Relevance, report.Thresholds.Importance)
+	}
+
+	c := report.Confusion
+	rw.printf("  Confusion: TP=%d FP=%d FN=%d TN=%d\n", c.TP, c.FP, c.FN, c.TN)
+	rw.printf("  Precision: %.3f\n", report.Precision)
+	rw.printf("  Recall: %.3f\n", report.Recall)
+	rw.printf("  NoiseRate: %.3f\n", report.NoiseRate)
+	rw.printf("  Coverage: %.3f\n", report.Coverage)
+
+	if r := report.Ranking; r != nil {
+		rw.printf("Ranking Summary\n")
+		rw.printf("  Windows: %d (skipped without labels: %d)\n", r.Windows, r.Skipped)
+		rw.printf("  Judged items: %d of %d ranked\n", r.JudgedItems, r.RankedItems)
+		rw.printf("  nDCG@%d: %.3f\n", r.K, r.NDCG)
+		rw.printf("  MAP: %.3f (%d windows with good items)\n", r.MAP, r.APWindows)
+		rw.printf("  Kendall tau-b: %.3f (%d windows)\n", r.KendallTau, r.TauWindows)
+	}
+
+	if len(report.Assertions) > 0 {
+		rw.printf("Assertions\n")
+
+		for _, res := range report.Assertions {
+			if res.Passed {
+				rw.printf("  PASS %s\n", res.Name)
+			} else {
+				rw.printf("  FAIL %s: %s\n", res.Name, res.Message)
+			}
+		}
+	}
+
+	return rw.flush(w)
+}
+
+func writeMarkdownReport(w io.Writer, report evalReport) error {
+	var rw reportWriter
+
+	rw.printf("## Evaluation Summary\n\n")
+	rw.printf("Records: %d (skipped: %d). Labels: good=%d, bad=%d, irrelevant=%d.\n\n",
+		report.Records, report.Skipped, report.Labels.Good, report.Labels.Bad, report.Labels.Irrelevant)
+
+	c := report.Confusion
+	rw.printf("| Metric | Value |\n|--------|-------|\n")
+	rw.printf("| Precision | %.3f |\n", report.Precision)
+	rw.printf("| Recall | %.3f |\n", report.Recall)
+	rw.printf("| Noise rate | %.3f |\n", report.NoiseRate)
+	rw.printf("| Coverage | %.3f |\n", report.Coverage)
+	rw.printf("| Confusion | TP=%d FP=%d FN=%d TN=%d |\n", c.TP, c.FP, c.FN, c.TN)
+
+	if r := report.Ranking; r != nil {
+		rw.printf("\n## Ranking Summary\n\n")
+		rw.printf("Windows: %d (skipped without labels: %d). Judged items: %d of %d ranked.\n\n",
+			r.Windows, r.Skipped, r.JudgedItems, r.RankedItems)
+		rw.printf("| Metric | Value |\n|--------|-------|\n")
+		rw.printf("| nDCG@%d | %.3f |\n", r.K, r.NDCG)
+		rw.printf("| MAP | %.3f |\n", r.MAP)
+		rw.printf("| Kendall tau-b | %.3f |\n", r.KendallTau)
+	}
+
+	if len(report.Assertions) > 0 {
+		rw.printf("\n## Assertions\n\n")
+		rw.printf("| Assertion | Metric | Value | Result |\n|-----------|--------|-------|--------|\n")
+
+		for _, res := range report.Assertions {
+			result := "pass"
+			if !res.Passed {
+				result = "**fail**: " + res.Message
+			}
+
+			rw.printf("| %s | %s | %.3f | %s |\n", res.Name, res.Metric, res.Value, result)
+		}
+	}
+
+	return rw.flush(w)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeJUnitReport writes one test case per assertion, with the reported
+// metrics as suite properties.
+func writeJUnitReport(w io.Writer, report evalReport) error {
+	suite := junitTestSuite{
+		Name:     junitSuiteName,
+		Tests:    len(report.Assertions),
+		Failures: failedAssertions(report.Assertions),
+	}
+
+	metrics := report.metrics()
+	for _, name := range knownMetrics {
+		if value, ok := metrics[name]; ok {
+			suite.Properties = append(suite.Properties, junitProperty{Name: name, Value: fmt.Sprintf("%.3f", value)})
+		}
+	}
+
+	for _, res := range report.Assertions {
+		tc := junitTestCase{Name: res.Name, ClassName: junitSuiteName}
+		if !res.Passed {
+			tc.Failure = &junitFailure{Message: res.Message}
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	var rw reportWriter
+
+	rw.printf("%s%s\n", xml.Header, data)
+
+	return rw.flush(w)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadAssertions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "assertions.json")
+	data := `{"assertions": [{"name": "precision floor", "metric": "precision", "min": 0.8}, {"metric": "noise_rate", "max": 0.2}]}`
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadAssertions(path)
+	if err != nil {
+		t.Fatalf("loadAssertions() error = %v", err)
+	}
+
+	if len(got) != 2 || got[0].Name != "precision floor" || got[1].Name != metricNoiseRate || *got[1].Max != 0.2 {
+		t.Errorf("loadAssertions() = %+v", got)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{name: "unknown metric", data: `{"assertions": [{"metric": "f1", "min": 0.5}]}`, want: errUnknownMetric},
+		{name: "no bounds", data: `{"assertions": [{"metric": "recall"}]}`, want: errAssertionBounds},
+		{name: "no metric", data: `{"assertions": [{"name": "x", "min": 0.5}]}`, want: errAssertionMetric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := loadAssertions(path); !errors.Is(err, tt.want) {
+				t.Errorf("loadAssertions() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAssertions(t *testing.T) {
+	minPrecision, maxNoise, minNDCG := 0.5, 0.2, 0.5
+	assertions := []assertion{
+		{Name: "precision", Metric: metricPrecision, Min: &minPrecision},
+		{Name: "noise", Metric: metricNoiseRate, Max: &maxNoise},
+		{Name: "ndcg", Metric: metricNDCG, Min: &minNDCG},
+	}
+
+	report := evalReport{Precision: 0.75, NoiseRate: 0.25}
+	results := checkAssertions(assertions, report.metrics())
+
+	if !results[0].Passed || results[1].Passed || results[2].Passed {
+		t.Errorf("checkAssertions() = %+v, want pass, fail, fail", results)
+	}
+
+	if results[2].Message != "ndcg was not reported" {
+		t.Errorf("unreported message = %q", results[2].Message)
+	}
+
+	if failedAssertions(results) != 2 {
+		t.Errorf("failedAssertions() = %d, want 2", failedAssertions(results))
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	minPrecision := 0.9
+	report := newReport(evalStats{total: 4, tp: 2, fp: 1, fn: 1, goodCount: 3, badCount: 1}, evalConfig{relevanceThreshold: 0.5})
+	report.Assertions = checkAssertions([]assertion{{Name: "precision floor", Metric: metricPrecision, Min: &minPrecision}}, report.metrics())
+	report.Passed = false
+
+	var buf bytes.Buffer
+
+	if err := writeReport(&buf, report, formatJSON); err != nil {
+		t.Fatalf("writeReport(json) error = %v", err)
+	}
+
+	var decoded evalReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("json output does not decode: %v", err)
+	}
+
+	if decoded.Confusion.TP != 2 || decoded.Passed || len(decoded.Assertions) != 1 || decoded.Assertions[0].Passed {
+		t.Errorf("json report = %+v", decoded)
+	}
+
+	buf.Reset()
+
+	if err := writeReport(&buf, report, formatJUnit); err != nil {
+		t.Fatalf("writeReport(junit) error = %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("junit output does not decode: %v", err)
+	}
+
+	if len(suites.Suites) != 1 || suites.Suites[0].Failures != 1 || suites.Suites[0].Cases[0].Failure == nil {
+		t.Errorf("junit report = %+v", suites)
+	}
+
+	buf.Reset()
+
+	if err := writeReport(&buf, report, formatMarkdown); err != nil {
+		t.Fatalf("writeReport(markdown) error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "| precision floor | precision | 0.667 | **fail**") {
+		t.Errorf("markdown report = %s", buf.String())
+	}
+
+	if err := writeReport(&buf, report, "yaml"); !errors.Is(err, errUnknownFormat) {
+		t.Errorf("writeReport(yaml) error = %v, want %v", err, errUnknownFormat)
+	}
+}
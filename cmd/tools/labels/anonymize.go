@@ -0,0 +1,107 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	channelAliasFmt = "channel_%d"
+	messageHashLen  = 16
+	randomSaltLen   = 16
+	privateLinkText = "[link]"
+)
+
+// privateLinkPattern matches links to posts of private channels, which carry
+// the channel's peer ID.
+var privateLinkPattern = regexp.MustCompile(`(?i)(?:https?://)?(?:t\.me|telegram\.me)/c/\d+(?:/\d+)?`)
+
+// anonymizer replaces channel identifiers with stable aliases and message
+// IDs with salted hashes, so a dataset can be shared without revealing its
+// sources.
+type anonymizer struct {
+	salt     []byte
+	aliases  map[int64]string
+	byName   map[string]string
+	mentions *regexp.Regexp
+}
+
+// newAnonymizer assigns aliases to the channels of records in order of first
+// appearance.
+func newAnonymizer(salt []byte, records []db.AnnotationExport) *anonymizer {
+	a := &anonymizer{salt: salt, aliases: make(map[int64]string), byName: make(map[string]string)}
+
+	var names []string
+
+	for _, rec := range records {
+		if _, ok := a.aliases[rec.ChannelPeerID]; ok {
+			continue
+		}
+
+		alias := fmt.Sprintf(channelAliasFmt, len(a.aliases)+1)
+		a.aliases[rec.ChannelPeerID] = alias
+
+		if name := strings.ToLower(rec.ChannelUsername); name != "" {
+			a.byName[name] = alias
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+
+	if len(names) > 0 {
+		a.mentions = regexp.MustCompile(`(?i)(?:https?://)?(?:t\.me/|telegram\.me/|@)(` + strings.Join(names, "|") + `)(?:/\d+)?\b`)
+	}
+
+	return a
+}
+
+// randomSalt returns a salt for one export; message hashes then differ
+// between exports.
+func randomSalt() ([]byte, error) {
+	salt := make([]byte, randomSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return salt, nil
+}
+
+// apply anonymizes out, which was built from rec.
+func (a *anonymizer) apply(out exportRecord, rec db.AnnotationExport) exportRecord {
+	out.Channel = a.aliases[rec.ChannelPeerID]
+	out.ChannelPeerID = 0
+	out.MessageID = a.hashMessage(rec.ChannelPeerID, rec.MessageID)
+	out.Summary = a.scrub(out.Summary)
+	out.Text = a.scrub(out.Text)
+
+	return out
+}
+
+func (a *anonymizer) hashMessage(peerID, messageID int64) string {
+	mac := hmac.New(sha256.New, a.salt)
+	fmt.Fprintf(mac, "%d/%d", peerID, messageID)
+
+	return hex.EncodeToString(mac.Sum(nil))[:messageHashLen]
+}
+
+// scrub replaces mentions of and links to exported channels with their
+// aliases and drops links to private channel posts.
+func (a *anonymizer) scrub(text string) string {
+	text = privateLinkPattern.ReplaceAllString(text, privateLinkText)
+
+	if a.mentions == nil {
+		return text
+	}
+
+	return a.mentions.ReplaceAllStringFunc(text, func(match string) string {
+		name := a.mentions.FindStringSubmatch(match)[1]
+
+		return a.byName[strings.ToLower(name)]
+	})
+}
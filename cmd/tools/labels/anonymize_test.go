@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestAnonymizerApply(t *testing.T) {
+	records := []db.AnnotationExport{
+		{ID: "a", ChannelUsername: "NewsDaily", ChannelTitle: "News Daily", ChannelPeerID: 100, MessageID: 7,
+			Text: "Via @newsdaily and https://t.me/NewsDaily/42, see also t.me/c/123456/9 and @otherchan"},
+		{ID: "b", ChannelTitle: "Private", ChannelPeerID: 200, MessageID: 7, Summary: "Reposted from @NewsDaily"},
+		{ID: "c", ChannelUsername: "NewsDaily", ChannelPeerID: 100, MessageID: 8},
+	}
+
+	anon := newAnonymizer([]byte("salt"), records)
+
+	outs := make([]exportRecord, 0, len(records))
+	for _, rec := range records {
+		outs = append(outs, toExportRecord(rec, anon))
+	}
+
+	if outs[0].Channel != "channel_1" || outs[1].Channel != "channel_2" || outs[2].Channel != "channel_1" {
+		t.Errorf("channels = %q, %q, %q, want channel_1, channel_2, channel_1", outs[0].Channel, outs[1].Channel, outs[2].Channel)
+	}
+
+	for _, out := range outs {
+		if out.ChannelPeerID != 0 {
+			t.Errorf("channel_peer_id = %d, want stripped", out.ChannelPeerID)
+		}
+
+		if len(out.MessageID) != messageHashLen {
+			t.Errorf("message_id = %q, want %d-char hash", out.MessageID, messageHashLen)
+		}
+	}
+
+	if outs[0].MessageID == outs[1].MessageID {
+		t.Error("same message ID in different channels hashed to the same value")
+	}
+
+	wantText := "Via channel_1 and channel_1, see also [link] and @otherchan"
+	if outs[0].Text != wantText {
+		t.Errorf("text = %q, want %q", outs[0].Text, wantText)
+	}
+
+	if strings.Contains(strings.ToLower(outs[1].Summary), "newsdaily") {
+		t.Errorf("summary = %q, still mentions the channel", outs[1].Summary)
+	}
+}
+
+func TestAnonymizerHashDependsOnSalt(t *testing.T) {
+	records := []db.AnnotationExport{{ChannelPeerID: 1, MessageID: 2}}
+
+	first := newAnonymizer([]byte("one"), records).hashMessage(1, 2)
+	again := newAnonymizer([]byte("one"), records).hashMessage(1, 2)
+	other := newAnonymizer([]byte("two"), records).hashMessage(1, 2)
+
+	if first != again {
+		t.Errorf("hash not stable for the same salt: %s != %s", first, again)
+	}
+
+	if first == other {
+		t.Error("hash did not change with the salt")
+	}
+}
+
+func TestToExportRecordPlain(t *testing.T) {
+	rec := db.AnnotationExport{ID: "a", Label: "good", ChannelUsername: "news", ChannelPeerID: 5, MessageID: 9, Text: "@news"}
+
+	out := toExportRecord(rec, nil)
+	if out.Channel != "@news" || out.ChannelPeerID != 5 || out.MessageID != "9" || out.Text != "@news" {
+		t.Errorf("toExportRecord() = %+v, want identifiers kept", out)
+	}
+}
@@ -1,7 +1,9 @@
 // Package main provides tools for exporting labeled data for evaluation.
 //
 // The labels tool exports items from the database in JSONL format,
-// suitable for building golden datasets for quality evaluation.
+// suitable for building golden datasets for quality evaluation. With
+// -anonymize, channel identifiers are replaced by aliases and message IDs by
+// salted hashes, so datasets can be shared with external annotators.
 package main
 
 import (
@@ -13,6 +15,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/rs/zerolog"
 
@@ -32,9 +35,11 @@ var (
 )
 
 type exportConfig struct {
-	outPath string
-	limit   int
-	dsn     string
+	outPath   string
+	limit     int
+	dsn       string
+	anonymize bool
+	salt      string
 }
 
 type exportRecord struct {
@@ -42,6 +47,11 @@ type exportRecord struct {
 	Label           string  `json:"label"`
 	RelevanceScore  float32 `json:"relevance_score"`
 	ImportanceScore float32 `json:"importance_score"`
+	Summary         string  `json:"summary,omitempty"`
+	Text            string  `json:"text,omitempty"`
+	Channel         string  `json:"channel,omitempty"`
+	ChannelPeerID   int64   `json:"channel_peer_id,omitempty"`
+	MessageID       string  `json:"message_id,omitempty"`
 }
 
 func main() {
@@ -64,6 +74,8 @@ func parseFlags() exportConfig {
 	flag.StringVar(&cfg.outPath, "out", defaultExportPath, "Output JSONL path")
 	flag.IntVar(&cfg.limit, "limit", defaultExportLimit, "Max labeled items to export")
 	flag.StringVar(&cfg.dsn, "dsn", os.Getenv("POSTGRES_DSN"), "Postgres DSN")
+	flag.BoolVar(&cfg.anonymize, "anonymize", false, "Replace channel identifiers with aliases and hash message IDs")
+	flag.StringVar(&cfg.salt, "salt", os.Getenv("LABELS_ANON_SALT"), "Salt for message ID hashes (random per export if empty)")
 
 	flag.Parse()
 
@@ -97,16 +109,39 @@ func runExport(cfg exportConfig) error {
 		return fmt.Errorf("failed to load labeled annotations: %w", err)
 	}
 
-	if err := writeRecords(records, cfg.outPath); err != nil {
+	var anon *anonymizer
+
+	if cfg.anonymize {
+		if anon, err = buildAnonymizer(cfg.salt, records); err != nil {
+			return err
+		}
+	}
+
+	if err := writeRecords(records, anon, cfg.outPath); err != nil {
 		return err
 	}
 
-	logger.Info().Int("count", len(records)).Str("path", cfg.outPath).Msg("Exported labeled items")
+	logger.Info().Int("count", len(records)).Str("path", cfg.outPath).Bool("anonymized", anon != nil).Msg("Exported labeled items")
 
 	return nil
 }
 
-func writeRecords(records []db.AnnotationExport, outPath string) error {
+// buildAnonymizer creates the anonymizer for an export, with a random salt
+// when none is configured.
+func buildAnonymizer(configuredSalt string, records []db.AnnotationExport) (*anonymizer, error) {
+	salt := []byte(configuredSalt)
+
+	if len(salt) == 0 {
+		var err error
+		if salt, err = randomSalt(); err != nil {
+			return nil, err
+		}
+	}
+
+	return newAnonymizer(salt, records), nil
+}
+
+func writeRecords(records []db.AnnotationExport, anon *anonymizer, outPath string) error {
 	cleanPath := filepath.Clean(outPath)
 
 	if err := os.MkdirAll(filepath.Dir(cleanPath), outputDirPerm); err != nil {
@@ -125,7 +160,7 @@ func writeRecords(records []db.AnnotationExport, outPath string) error {
 	writer := bufio.NewWriter(f)
 
 	for _, rec := range records {
-		if err := writeRecord(writer, rec); err != nil {
+		if err := writeRecord(writer, toExportRecord(rec, anon)); err != nil {
 			return err
 		}
 	}
@@ -137,14 +172,35 @@ func writeRecords(records []db.AnnotationExport, outPath string) error {
 	return nil
 }
 
-func writeRecord(writer *bufio.Writer, rec db.AnnotationExport) error {
+func toExportRecord(rec db.AnnotationExport, anon *anonymizer) exportRecord {
 	out := exportRecord{
 		ID:              rec.ID,
 		Label:           rec.Label,
 		RelevanceScore:  rec.RelevanceScore,
 		ImportanceScore: rec.ImportanceScore,
+		Summary:         rec.Summary,
+		Text:            rec.Text,
+		Channel:         channelName(rec),
+		ChannelPeerID:   rec.ChannelPeerID,
+		MessageID:       strconv.FormatInt(rec.MessageID, 10),
+	}
+
+	if anon != nil {
+		return anon.apply(out, rec)
 	}
 
+	return out
+}
+
+func channelName(rec db.AnnotationExport) string {
+	if rec.ChannelUsername != "" {
+		return "@" + rec.ChannelUsername
+	}
+
+	return rec.ChannelTitle
+}
+
+func writeRecord(writer *bufio.Writer, out exportRecord) error {
 	line, err := json.Marshal(out)
 	if err != nil {
 		return fmt.Errorf("failed to encode record: %w", err)
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/ingest/reader"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// allModeStopTimeout bounds how long shutdown waits for each service to stop
+// before moving on to the next one.
+const allModeStopTimeout = 30 * time.Second
+
+// service is one component of the all-in-one mode.
+type service struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// RunAll runs the reader, worker, bot and digest scheduler in one process for
+// small deployments. The services share the database pool and the LLM and
+// embedding clients, including their caches, and a single bot both handles
+// commands and posts digests.
+//
+// On shutdown the services stop in pipeline order: the reader stops ingesting
+// first, then the worker finishes processing, then the digest scheduler, and
+// the bot stops last so that notifications sent while stopping still go out.
+func (a *App) RunAll(ctx context.Context) error {
+	a.logger.Info().Msg("Starting all-in-one mode")
+
+	llmClient := a.newLLMClient(ctx)
+	embeddingClient := a.newEmbeddingClient(ctx)
+
+	b, err := a.newBot(ctx, llmClient, embeddingClient)
+	if err != nil {
+		return err
+	}
+
+	// The event publisher keeps draining its queue after ctx is canceled,
+	// until closeEvents runs once every service has stopped.
+	s, closeEvents := a.newDigestScheduler(ctx, b, llmClient)
+	defer closeEvents()
+
+	channelRepo := db.NewChannelRepoAdapter(a.database)
+	r := reader.New(a.cfg, a.database, a.database, channelRepo, a.logger)
+
+	observability.RegisterReadinessCheck(r.AuthHealthCheck)
+
+	services := []service{
+		{name: "reader", run: func(ctx context.Context) error {
+			if err := r.Run(ctx); err != nil {
+				return fmt.Errorf("reader run: %w", err)
+			}
+
+			return nil
+		}},
+		{name: "worker", run: func(ctx context.Context) error {
+			return a.runWorker(ctx, llmClient, embeddingClient)
+		}},
+		{name: "digest", run: func(ctx context.Context) error {
+			if a.cfg.MaintenanceEnabled {
+				go a.runMaintenanceScheduler(ctx)
+			}
+
+			if err := s.Run(ctx); err != nil {
+				return fmt.Errorf("digest run: %w", err)
+			}
+
+			return nil
+		}},
+		{name: "bot", run: func(ctx context.Context) error {
+			if err := b.Run(ctx); err != nil {
+				return fmt.Errorf("bot run: %w", err)
+			}
+
+			return nil
+		}},
+	}
+
+	return runServices(ctx, services, allModeStopTimeout, a.logger)
+}
+
+// runServices starts every service and waits until ctx is canceled or a
+// service fails. Services are then stopped one at a time in slice order,
+// waiting up to stopTimeout for each. It returns the first service error other
+// than a cancellation.
+func runServices(ctx context.Context, services []service, stopTimeout time.Duration, logger *zerolog.Logger) error {
+	type result struct {
+		name string
+		err  error
+	}
+
+	var (
+		cancels = make([]context.CancelFunc, len(services))
+		done    = make([]chan struct{}, len(services))
+		results = make(chan result, len(services))
+	)
+
+	// Services outlive ctx so that they can be stopped in order; they share a
+	// parent that keeps ctx values and is canceled once runServices returns.
+	parent, cancelAll := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelAll()
+
+	for i, svc := range services {
+		svcCtx, cancel := context.WithCancel(parent)
+		cancels[i] = cancel
+		done[i] = make(chan struct{})
+
+		go func(svc service, done chan struct{}) {
+			defer close(done)
+
+			results <- result{name: svc.name, err: svc.run(svcCtx)}
+		}(svc, done[i])
+	}
+
+	var firstErr error
+
+	select {
+	case <-ctx.Done():
+	case res := <-results:
+		// A service stopped on its own: stop the others as well.
+		firstErr = serviceError(res.name, res.err)
+		logger.Warn().Err(res.err).Str("service", res.name).Msg("service stopped, shutting down")
+	}
+
+	for i, svc := range services {
+		cancels[i]()
+
+		select {
+		case <-done[i]:
+			logger.Info().Str("service", svc.name).Msg("service stopped")
+		case <-time.After(stopTimeout):
+			logger.Warn().Str("service", svc.name).Dur("timeout", stopTimeout).Msg("service did not stop in time")
+		}
+	}
+
+	// Services that did not stop in time are abandoned; their results are
+	// never read, which the buffered channel allows.
+	for len(results) > 0 {
+		if res := <-results; firstErr == nil {
+			firstErr = serviceError(res.name, res.err)
+		}
+	}
+
+	if firstErr == nil && ctx.Err() != nil {
+		return fmt.Errorf("run services: %w", ctx.Err())
+	}
+
+	return firstErr
+}
+
+// serviceError returns err unless the service merely stopped on cancellation.
+func serviceError(name string, err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", name, err)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var errServiceFailed = errors.New("service failed")
+
+// stopRecorder records the order in which services observe cancellation.
+type stopRecorder struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (r *stopRecorder) service(name string) service {
+	return service{name: name, run: func(ctx context.Context) error {
+		<-ctx.Done()
+
+		r.mu.Lock()
+		r.stopped = append(r.stopped, name)
+		r.mu.Unlock()
+
+		return ctx.Err()
+	}}
+}
+
+func TestRunServicesStopsInOrder(t *testing.T) {
+	logger := zerolog.Nop()
+	rec := &stopRecorder{}
+
+	services := []service{rec.service("reader"), rec.service("worker"), rec.service("digest"), rec.service("bot")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := runServices(ctx, services, time.Second, &logger)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	want := []string{"reader", "worker", "digest", "bot"}
+	if !reflect.DeepEqual(rec.stopped, want) {
+		t.Errorf("stop order = %v, want %v", rec.stopped, want)
+	}
+}
+
+func TestRunServicesStopsAllOnFailure(t *testing.T) {
+	logger := zerolog.Nop()
+	rec := &stopRecorder{}
+
+	services := []service{
+		rec.service("reader"),
+		{name: "worker", run: func(context.Context) error { return errServiceFailed }},
+		rec.service("bot"),
+	}
+
+	err := runServices(context.Background(), services, time.Second, &logger)
+	if !errors.Is(err, errServiceFailed) {
+		t.Fatalf("expected worker error, got %v", err)
+	}
+
+	want := []string{"reader", "bot"}
+	if !reflect.DeepEqual(rec.stopped, want) {
+		t.Errorf("stop order = %v, want %v", rec.stopped, want)
+	}
+}
+
+func TestRunServicesAbandonsStuckService(t *testing.T) {
+	logger := zerolog.Nop()
+	rec := &stopRecorder{}
+	block := make(chan struct{})
+
+	defer close(block)
+
+	services := []service{
+		{name: "reader", run: func(context.Context) error {
+			<-block
+
+			return nil
+		}},
+		rec.service("bot"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := runServices(ctx, services, 10*time.Millisecond, &logger); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if want := []string{"bot"}; !reflect.DeepEqual(rec.stopped, want) {
+		t.Errorf("stopped = %v, want %v", rec.stopped, want)
+	}
+}
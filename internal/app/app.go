@@ -8,6 +8,8 @@
 //   - Worker mode: Processing pipeline for enrichment, dedup, and fact-checking
 //   - Digest mode: Scheduled digest generation and posting
 //   - HTTP mode: Standalone web server for research UI and expanded views
+//   - Maintenance mode: Scheduled view refreshes, derived table rebuilds and vacuums
+//   - All mode: Reader, worker, bot and digest scheduler in one process
 //
 // Each mode can be run independently or combined based on deployment needs.
 package app
@@ -28,8 +30,14 @@ import (
 	"github.com/lueurxax/telegram-digest-bot/internal/core/solr"
 	"github.com/lueurxax/telegram-digest-bot/internal/expandedview"
 	"github.com/lueurxax/telegram-digest-bot/internal/ingest/reader"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/calendar"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/delivery"
 	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/export"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/tickets"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/events"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/maintenance"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
 	"github.com/lueurxax/telegram-digest-bot/internal/process/enrichment"
 	"github.com/lueurxax/telegram-digest-bot/internal/process/factcheck"
@@ -46,6 +54,7 @@ const (
 	discoveryMinEngagementSettingKey = "discovery_min_engagement"
 	discoveryAllowSettingKey         = "discovery_description_allow"
 	discoveryDenySettingKey          = "discovery_description_deny"
+	discoveryAutoPolicySettingKey    = "discovery_auto_policy"
 	discoveryMinSeenDefault          = 2
 	discoveryMinEngagementDefault    = float32(50)
 	msgFactCheckWorkerStopped        = "fact check worker stopped"
@@ -87,6 +96,10 @@ func (noopDigestPoster) SendNotification(_ context.Context, _ string) error {
 	return nil
 }
 
+func (noopDigestPoster) SendChannelTrialReport(_ context.Context, _ db.ChannelTrialReport) error {
+	return nil
+}
+
 // New creates a new App instance with the given dependencies.
 func New(cfg *config.Config, database *db.DB, logger *zerolog.Logger) *App {
 	return &App{
@@ -101,6 +114,7 @@ func (a *App) StartHealthServer(ctx context.Context) error {
 	var (
 		expandedHandler http.Handler
 		researchHandler http.Handler
+		clickHandler    http.Handler
 	)
 
 	if a.cfg.ExpandedViewSigningSecret != "" && a.cfg.ExpandedViewBaseURL != "" {
@@ -115,6 +129,7 @@ func (a *App) StartHealthServer(ctx context.Context) error {
 		}
 
 		expandedHandler = handler
+		clickHandler = handler.ClickHandler()
 
 		a.logger.Info().Str(logFieldBaseURL, a.cfg.ExpandedViewBaseURL).Msg("Expanded view handler enabled")
 
@@ -129,6 +144,12 @@ func (a *App) StartHealthServer(ctx context.Context) error {
 	}
 
 	srv := observability.NewServerWithHandlers(a.database, a.cfg.HealthPort, expandedHandler, researchHandler, a.logger)
+	srv.SetClickHandler(clickHandler)
+
+	if a.cfg.CalendarFeedToken != "" {
+		srv.SetCalendarHandler(calendar.NewHandler(a.database, a.cfg.CalendarFeedToken, a.calendarLocation(), a.cfg.CalendarFeedDays, a.logger))
+		a.logger.Info().Msg("Calendar feed enabled at /calendar.ics")
+	}
 
 	if err := srv.Start(ctx); err != nil {
 		return fmt.Errorf("health server start: %w", err)
@@ -151,6 +172,20 @@ func (a *App) RunBot(ctx context.Context) error {
 
 	llmClient := a.newLLMClient(ctx)
 
+	b, err := a.newBot(ctx, llmClient, a.newEmbeddingClient(ctx))
+	if err != nil {
+		return err
+	}
+
+	if err := b.Run(ctx); err != nil {
+		return fmt.Errorf("bot run: %w", err)
+	}
+
+	return nil
+}
+
+// newBot creates the admin bot with a digest builder for preview commands.
+func (a *App) newBot(ctx context.Context, llmClient llm.Client, embeddingClient embeddings.Client) (*bot.Bot, error) {
 	// Create a digest scheduler for preview commands (nil poster since we only need BuildDigest)
 	digestBuilder := digest.New(a.cfg, a.database, nil, llmClient, a.logger)
 
@@ -166,14 +201,16 @@ func (a *App) RunBot(ctx context.Context) error {
 	//nolint:contextcheck // Budget alert callback fires async with no request context
 	b, err := bot.New(a.cfg, a.database, digestBuilder, llmClient, a.logger)
 	if err != nil {
-		return fmt.Errorf(errBotInit, err)
+		return nil, fmt.Errorf(errBotInit, err)
 	}
 
-	if err := b.Run(ctx); err != nil {
-		return fmt.Errorf("bot run: %w", err)
+	b.SetEmbeddingClient(embeddingClient)
+
+	if tracker := a.newTicketTracker(); tracker != nil {
+		b.SetTicketTracker(tracker)
 	}
 
-	return nil
+	return b, nil
 }
 
 // RunReader runs the reader mode.
@@ -196,12 +233,21 @@ func (a *App) RunReader(ctx context.Context) error {
 func (a *App) RunWorker(ctx context.Context) error {
 	a.logger.Info().Msg("Starting worker mode")
 
-	llmClient := a.newLLMClient(ctx)
-	embeddingClient := a.newEmbeddingClient(ctx)
+	return a.runWorker(ctx, a.newLLMClient(ctx), a.newEmbeddingClient(ctx))
+}
+
+// runWorker runs the processing pipeline and the background workers that
+// accompany it until ctx is canceled.
+func (a *App) runWorker(ctx context.Context, llmClient llm.Client, embeddingClient embeddings.Client) error {
 	resolver := a.newLinkResolver()
 	seeder := a.newLinkSeeder()
 
+	publisher, closeEvents := a.newEventPublisher(ctx)
+	defer closeEvents()
+
 	p := pipeline.New(a.cfg, a.database, llmClient, embeddingClient, resolver, seeder, a.logger)
+	p.SetEventPublisher(publisher)
+
 	go a.runDiscoveryReconciliation(ctx)
 	go a.runFactCheckWorker(ctx)
 	go a.runEnrichmentWorker(ctx, embeddingClient)
@@ -289,6 +335,10 @@ func (a *App) refreshResearchOnce(ctx context.Context) {
 
 	a.runResearchAnalytics(refreshCtx)
 
+	if a.cfg.MaintenanceEnabled {
+		return
+	}
+
 	// Run maintenance with its own context so a slow analytics phase
 	// (e.g. cluster_language_links timeout) does not cancel cleanup operations.
 	maintenanceCtx, maintenanceCancel := context.WithTimeout(ctx, researchMaintenanceTimeout)
@@ -313,9 +363,13 @@ func (a *App) runResearchAnalytics(ctx context.Context) {
 
 	a.runResearchClustering(ctx)
 
-	if err := a.database.RefreshResearchMaterializedViews(ctx); err != nil {
-		a.logger.Warn().Err(err).Msg("research refresh failed")
-		// Continue to heuristic claims population even if views fail
+	// With maintenance enabled, views and derived tables are refreshed by the
+	// maintenance scheduler on its own schedule.
+	if !a.cfg.MaintenanceEnabled {
+		if err := a.database.RefreshResearchMaterializedViews(ctx); err != nil {
+			a.logger.Warn().Err(err).Msg("research refresh failed")
+			// Continue to heuristic claims population even if views fail
+		}
 	}
 
 	// Populate heuristic claims for items without evidence
@@ -325,6 +379,10 @@ func (a *App) runResearchAnalytics(ctx context.Context) {
 func (a *App) rebuildResearch(ctx context.Context) error {
 	a.runResearchClustering(ctx)
 
+	if err := a.database.ResetRebuildState(ctx); err != nil {
+		return fmt.Errorf("reset rebuild state: %w", err)
+	}
+
 	if err := a.database.RefreshResearchMaterializedViews(ctx); err != nil {
 		return fmt.Errorf("refresh research views: %w", err)
 	}
@@ -362,6 +420,140 @@ func (a *App) runResearchClustering(ctx context.Context) {
 		Msg("research clustering completed")
 }
 
+// newDeliveryTargets creates the Slack, Discord and Matrix targets that are
+// configured.
+func (a *App) newDeliveryTargets() []digest.DeliveryTarget {
+	var targets []digest.DeliveryTarget
+
+	if a.cfg.SlackWebhookURL != "" {
+		targets = append(targets, delivery.NewSlack(a.cfg.SlackWebhookURL, a.cfg.DeliveryTimeout))
+	}
+
+	if a.cfg.DiscordWebhookURL != "" {
+		targets = append(targets, delivery.NewDiscord(a.cfg.DiscordWebhookURL, a.cfg.DeliveryTimeout))
+	}
+
+	if a.cfg.MatrixHomeserverURL != "" && a.cfg.MatrixAccessToken != "" && a.cfg.MatrixRoomID != "" {
+		targets = append(targets, delivery.NewMatrix(a.cfg.MatrixHomeserverURL, a.cfg.MatrixAccessToken, a.cfg.MatrixRoomID, a.cfg.DeliveryTimeout))
+	}
+
+	for _, target := range targets {
+		a.logger.Info().Str("platform", target.Platform()).Msg("Digest delivery target enabled")
+	}
+
+	return targets
+}
+
+// calendarLocation returns the time zone of announced events without one,
+// falling back to UTC when CALENDAR_TIMEZONE is invalid.
+func (a *App) calendarLocation() *time.Location {
+	loc, err := time.LoadLocation(a.cfg.CalendarTimezone)
+	if err != nil {
+		a.logger.Warn().Err(err).Str("timezone", a.cfg.CalendarTimezone).Msg("invalid calendar timezone, using UTC")
+
+		return time.UTC
+	}
+
+	return loc
+}
+
+// newTicketTracker creates the tracker for /item track: Jira when configured,
+// otherwise Linear, or nil when neither is configured.
+func (a *App) newTicketTracker() bot.TicketTracker {
+	var tracker bot.TicketTracker
+
+	switch {
+	case a.cfg.JiraBaseURL != "" && a.cfg.JiraAPIToken != "" && a.cfg.JiraProjectKey != "":
+		tracker = tickets.NewJira(a.cfg.JiraBaseURL, a.cfg.JiraUser, a.cfg.JiraAPIToken, a.cfg.JiraProjectKey, a.cfg.JiraIssueType, a.cfg.TicketTimeout)
+	case a.cfg.LinearAPIKey != "" && a.cfg.LinearTeamID != "":
+		tracker = tickets.NewLinear(a.cfg.LinearAPIKey, a.cfg.LinearTeamID, a.cfg.TicketTimeout)
+	default:
+		return nil
+	}
+
+	a.logger.Info().Str("tracker", tracker.Name()).Msg("Issue tracker enabled for /item track")
+
+	return tracker
+}
+
+// newExporter creates the exporter for the configured Notion database and
+// Obsidian vault, or returns nil when neither is configured.
+func (a *App) newExporter() *export.Exporter {
+	var targets []export.Target
+
+	if a.cfg.ObsidianVaultDir != "" {
+		targets = append(targets, export.NewObsidianVault(a.cfg.ObsidianVaultDir))
+	}
+
+	if a.cfg.NotionAPIToken != "" && a.cfg.NotionDatabaseID != "" {
+		targets = append(targets, export.NewNotion(a.cfg.NotionAPIToken, a.cfg.NotionDatabaseID, a.cfg.ExportTimeout))
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	for _, target := range targets {
+		a.logger.Info().Str("target", target.Name()).Msg("Digest export target enabled")
+	}
+
+	return export.New(a.database, a.logger, targets...)
+}
+
+// newEventPublisher creates and starts the pipeline event publisher for the
+// configured sinks, or returns nil when none is configured. The returned
+// function sends the queued events and closes the sinks.
+func (a *App) newEventPublisher(ctx context.Context) (*events.Publisher, func()) {
+	var (
+		sinks   []events.Sink
+		closers []func()
+	)
+
+	if a.cfg.EventsWebhookURL != "" {
+		sinks = append(sinks, events.NewWebhookSink(a.cfg.EventsWebhookURL, a.cfg.EventsTimeout))
+	}
+
+	if a.cfg.EventsNATSURL != "" {
+		natsSink, err := events.NewNATSSink(a.cfg.EventsNATSURL, a.cfg.EventsNATSSubject, a.cfg.EventsTimeout)
+		if err != nil {
+			a.logger.Error().Err(err).Msg("NATS event sink disabled")
+		} else {
+			sinks = append(sinks, natsSink)
+			closers = append(closers, natsSink.Close)
+		}
+	}
+
+	if a.cfg.EventsKafkaRESTURL != "" && a.cfg.EventsKafkaClusterID != "" && a.cfg.EventsKafkaTopic != "" {
+		sinks = append(sinks, events.NewKafkaRESTSink(a.cfg.EventsKafkaRESTURL, a.cfg.EventsKafkaClusterID, a.cfg.EventsKafkaTopic, a.cfg.EventsTimeout))
+	}
+
+	if len(sinks) == 0 {
+		return nil, func() {}
+	}
+
+	publisher := events.NewPublisher(events.Config{
+		Types:         a.cfg.EventsTypes,
+		SigningSecret: a.cfg.EventsSigningSecret,
+		QueueSize:     a.cfg.EventsQueueSize,
+		MaxAttempts:   a.cfg.EventsMaxAttempts,
+		RetryBackoff:  a.cfg.EventsRetryBackoff,
+	}, sinks, a.logger)
+
+	go publisher.Run(ctx)
+
+	for _, sink := range sinks {
+		a.logger.Info().Str("sink", sink.Name()).Msg("Pipeline event sink enabled")
+	}
+
+	return publisher, func() {
+		publisher.Close()
+
+		for _, closeSink := range closers {
+			closeSink()
+		}
+	}
+}
+
 func (a *App) runResearchMaintenance(ctx context.Context) {
 	if err := a.database.DeleteExpiredResearchSessions(ctx); err != nil {
 		a.logger.Warn().Err(err).Msg("cleanup research sessions failed")
@@ -413,6 +605,10 @@ func (a *App) configureEnrichmentWorker(worker *enrichment.Worker, llmClient llm
 		}
 
 		worker.EnableLLMQueryGeneration(llmClient, queryModel)
+
+		if a.cfg.EnrichmentStanceEnabled {
+			worker.EnableStanceClassification(llmClient, a.cfg.EnrichmentStanceLLMModel)
+		}
 	}
 
 	if a.cfg.EnrichmentQueryTranslate {
@@ -473,6 +669,7 @@ func (a *App) runDiscoveryReconciliation(ctx context.Context) {
 	)
 
 	a.runDiscoveryCleanupOnce(ctx, cleanupBatchSize, cleanupBatchLimit, systemAdminUserID)
+	a.runDiscoveryAutoPolicyOnce(ctx)
 
 	ticker := time.NewTicker(reconcileInterval)
 	defer ticker.Stop()
@@ -483,6 +680,7 @@ func (a *App) runDiscoveryReconciliation(ctx context.Context) {
 			return
 		case <-ticker.C:
 			a.runDiscoveryCleanupOnce(ctx, cleanupBatchSize, cleanupBatchLimit, systemAdminUserID)
+			a.runDiscoveryAutoPolicyOnce(ctx)
 		}
 	}
 }
@@ -515,6 +713,75 @@ func (a *App) runDiscoveryCleanupOnce(ctx context.Context, batchSize int, maxBat
 	a.updateDiscoveryMetrics(ctx)
 }
 
+// runDiscoveryAutoPolicyOnce ends expired auto-approval trials and applies the
+// discovery auto-policy to pending discoveries.
+func (a *App) runDiscoveryAutoPolicyOnce(ctx context.Context) {
+	now := time.Now()
+
+	ended, err := a.database.EndExpiredChannelTrials(ctx, now)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		a.logger.Warn().Err(err).Msg("failed to end channel trials")
+	}
+
+	if len(ended) > 0 {
+		a.logger.Info().Strs("channels", ended).Msg("auto-approval trials ended")
+	}
+
+	policy := db.DefaultDiscoveryAutoPolicy()
+	if err := a.database.GetSetting(ctx, discoveryAutoPolicySettingKey, &policy); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			a.logger.Warn().Err(err).Msg("failed to read discovery_auto_policy")
+		}
+
+		return
+	}
+
+	if !policy.Enabled {
+		return
+	}
+
+	candidates, err := a.database.GetDiscoveryAutoCandidates(ctx)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			a.logger.Warn().Err(err).Msg("failed to fetch discovery auto candidates")
+		}
+
+		return
+	}
+
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			return
+		}
+
+		a.applyDiscoveryAutoPolicy(ctx, policy, candidate, now)
+	}
+}
+
+func (a *App) applyDiscoveryAutoPolicy(ctx context.Context, policy db.DiscoveryAutoPolicy, candidate db.DiscoveryAutoCandidate, now time.Time) {
+	decision := db.EvaluateDiscoveryAutoPolicy(policy, candidate)
+	if decision.Action == db.DiscoveryAutoActionHold {
+		return
+	}
+
+	for _, rule := range decision.Rules {
+		a.logger.Debug().
+			Str("username", decision.Username).
+			Str("rule", rule.Rule).
+			Bool("passed", rule.Passed).
+			Str("detail", rule.Detail).
+			Msg("discovery auto-policy rule")
+	}
+
+	if err := a.database.ApplyDiscoveryAutoDecision(ctx, policy, decision, now); err != nil {
+		a.logger.Warn().Err(err).Str("username", decision.Username).Str("action", decision.Action).Msg("failed to apply discovery auto-policy")
+
+		return
+	}
+
+	a.logger.Info().Str("username", decision.Username).Str("action", decision.Action).Msg("discovery auto-policy applied")
+}
+
 func (a *App) updateDiscoveryMetrics(ctx context.Context) {
 	stats, err := a.database.GetDiscoveryStats(ctx)
 	if err != nil {
@@ -626,7 +893,32 @@ func (a *App) RunDigest(ctx context.Context, once bool) error {
 		return fmt.Errorf(errBotInit, err)
 	}
 
-	s := digest.New(a.cfg, a.database, b, llmClient, a.logger)
+	if !once && a.cfg.MaintenanceEnabled {
+		go a.runMaintenanceScheduler(ctx)
+	}
+
+	s, closeEvents := a.newDigestScheduler(ctx, b, llmClient)
+	defer closeEvents()
+
+	if once {
+		if err := s.RunOnce(ctx); err != nil {
+			return fmt.Errorf("digest run once: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := s.Run(ctx); err != nil {
+		return fmt.Errorf("digest run: %w", err)
+	}
+
+	return nil
+}
+
+// newDigestScheduler creates the digest scheduler that posts through poster.
+// The returned function flushes and closes its event publisher.
+func (a *App) newDigestScheduler(ctx context.Context, poster digest.DigestPoster, llmClient llm.Client) (*digest.Scheduler, func()) {
+	s := digest.New(a.cfg, a.database, poster, llmClient, a.logger)
 
 	// Set up expand link generator if signing secret and base URL are configured
 	if a.cfg.ExpandedViewSigningSecret != "" && a.cfg.ExpandedViewBaseURL != "" {
@@ -638,24 +930,46 @@ func (a *App) RunDigest(ctx context.Context, once bool) error {
 		a.logger.Info().Str(logFieldBaseURL, a.cfg.ExpandedViewBaseURL).Msg("Expanded view links enabled in digest")
 	}
 
-	if once {
-		if err := s.RunOnce(ctx); err != nil {
-			return fmt.Errorf("digest run once: %w", err)
-		}
+	s.SetDeliveryTargets(a.newDeliveryTargets()...)
 
-		return nil
+	if exporter := a.newExporter(); exporter != nil {
+		s.SetExporter(exporter)
 	}
 
-	if err := s.Run(ctx); err != nil {
-		return fmt.Errorf("digest run: %w", err)
+	publisher, closeEvents := a.newEventPublisher(ctx)
+	s.SetEventPublisher(publisher)
+
+	return s, closeEvents
+}
+
+// RunMaintenance runs the maintenance mode. It runs every configured
+// maintenance task regardless of MAINTENANCE_ENABLED, which only controls
+// whether digest mode runs them too.
+func (a *App) RunMaintenance(ctx context.Context) error {
+	a.logger.Info().Msg("Starting maintenance mode")
+
+	if err := maintenance.New(a.cfg, a.database, a.logger).Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("maintenance run: %w", err)
 	}
 
 	return nil
 }
 
-// newLLMClient creates a new LLM client with multi-provider fallback.
+func (a *App) runMaintenanceScheduler(ctx context.Context) {
+	if err := maintenance.New(a.cfg, a.database, a.logger).Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		a.logger.Warn().Err(err).Msg("maintenance scheduler stopped")
+	}
+}
+
+// newLLMClient creates a new LLM client with multi-provider fallback. With
+// LLM_RECORD_CASSETTES set, its responses are recorded for digestctl replay.
 func (a *App) newLLMClient(ctx context.Context) llm.Client {
-	return llm.New(ctx, a.cfg, a.database, a.database, a.logger)
+	client := llm.New(ctx, a.cfg, a.database, a.database, a.database, a.logger)
+	if a.cfg.LLMRecordCassettes {
+		return llm.NewRecordingClient(client, a.database, a.logger)
+	}
+
+	return client
 }
 
 // newEmbeddingClient creates a new embedding client with multi-provider support.
@@ -13,6 +13,7 @@ package bot
 import (
 	"context"
 	"fmt"
+	"html"
 	"net/http"
 	"strings"
 	"time"
@@ -20,23 +21,27 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/rs/zerolog"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/embeddings"
 	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
 	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/markup"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
 )
 
-// Message size and delay constants.
+// Message size constants.
 const (
 	// MaxMessageSize is the maximum size for a single Telegram message part.
 	MaxMessageSize = 4000
-	// SleepBetweenParts is the delay between sending message parts to avoid rate limits.
-	SleepBetweenParts = 500 * time.Millisecond
-	// SleepAfterImage is the delay after sending an image before sending text.
-	SleepAfterImage = 300 * time.Millisecond
-	// SleepBetweenRichItems is the delay between sending rich digest items.
-	SleepBetweenRichItems = 200 * time.Millisecond
+	// maxMarkdownV2SourceSize is the HTML size of a message part rendered as
+	// MarkdownV2, leaving room for the escapes it adds.
+	maxMarkdownV2SourceSize = 3200
 	// SummaryTruncateLength is the max length for summary in log messages.
 	SummaryTruncateLength = 50
+	// maxCaptionAltTextRunes caps the image description in an item caption,
+	// which Telegram limits to 1024 characters.
+	maxCaptionAltTextRunes = 400
 	// percentageMultiplier converts decimal percentage to display percentage.
 	percentageMultiplier = 100
 )
@@ -97,6 +102,28 @@ const (
 	CmdInlineImagesAlt    = "inlineimages"
 	CmdOthersNarrative    = "others_narrative"
 	CmdOthersNarrativeAlt = "othersnarrative"
+	CmdStanceBadges       = "stance_badges"
+	CmdStanceBadgesAlt    = "stancebadges"
+	CmdNumbersBlock       = "numbers_block"
+	CmdNumbersBlockAlt    = "numbersblock"
+	CmdQuotesBlock        = "quotes_block"
+	CmdQuotesBlockAlt     = "quotesblock"
+	CmdUpcomingBlock      = "upcoming_block"
+	CmdUpcomingBlockAlt   = "upcomingblock"
+	CmdRumorWatch         = "rumor_watch"
+	CmdRumorWatchAlt      = "rumorwatch"
+	CmdWhatsNew           = "whats_new"
+	CmdWhatsNewAlt        = "whatsnew"
+	CmdSourceFooter       = "source_footer"
+	CmdSourceFooterAlt    = "sourcefooter"
+	CmdClickTracking      = "click_tracking"
+	CmdClickTrackingAlt   = "clicktracking"
+	CmdSlackDelivery      = "slack_delivery"
+	CmdSlackDeliveryAlt   = "slackdelivery"
+	CmdDiscordDelivery    = "discord_delivery"
+	CmdDiscordDeliveryAlt = "discorddelivery"
+	CmdMatrixDelivery     = "matrix_delivery"
+	CmdMatrixDeliveryAlt  = "matrixdelivery"
 	CmdLLM                = "llm"
 	CmdResearch           = "research"
 )
@@ -104,6 +131,7 @@ const (
 // Setting keys.
 const (
 	SettingFiltersSkipForwards         = "filters_skip_forwards"
+	SettingFiltersMedia                = "filters_media"
 	SettingRelevanceThreshold          = "relevance_threshold"
 	SettingImportanceThreshold         = "importance_threshold"
 	SettingEditorEnabled               = "editor_enabled"
@@ -115,6 +143,17 @@ const (
 	SettingDigestAICover               = "digest_ai_cover"
 	SettingDigestInlineImages          = "digest_inline_images"
 	SettingOthersAsNarrative           = "others_as_narrative"
+	SettingDigestStanceBadges          = "digest_stance_badges"
+	SettingDigestNumbersBlock          = "digest_numbers_block"
+	SettingDigestQuotesBlock           = "digest_quotes_block"
+	SettingDigestUpcomingBlock         = "digest_upcoming_block"
+	SettingDigestRumorWatch            = "digest_rumor_watch"
+	SettingDigestWhatsNew              = "digest_whats_new"
+	SettingDigestSourceFooter          = "digest_source_footer"
+	SettingDigestClickTracking         = "digest_click_tracking"
+	SettingDeliverySlackEnabled        = "delivery_slack_enabled"
+	SettingDeliveryDiscordEnabled      = "delivery_discord_enabled"
+	SettingDeliveryMatrixEnabled       = "delivery_matrix_enabled"
 )
 
 // Log field names.
@@ -148,8 +187,12 @@ type Bot struct {
 	database      Repository
 	digestBuilder DigestBuilder
 	llmClient     llm.Client
+	embedder      embeddings.Client
+	tickets       TicketTracker
 	api           *tgbotapi.BotAPI
+	sender        *sendQueue
 	logger        *zerolog.Logger
+	previews      previewRuns
 }
 
 // New creates a new Bot instance with the given dependencies.
@@ -166,15 +209,23 @@ func New(cfg *config.Config, database Repository, digestBuilder DigestBuilder, l
 		digestBuilder: digestBuilder,
 		llmClient:     llmClient,
 		api:           api,
+		sender:        newSendQueue(api, sendQueueConfigFromEnv(cfg), logger),
 		logger:        logger,
 	}
 
 	// Initialize budget tracking
 	bot.initBudgetTracking()
 
+	bot.initFailoverAlerts()
+
 	return bot, nil
 }
 
+// SetEmbeddingClient enables commands that embed free text, such as /similar.
+func (b *Bot) SetEmbeddingClient(client embeddings.Client) {
+	b.embedder = client
+}
+
 // initBudgetTracking loads budget settings and sets up alert callbacks.
 func (b *Bot) initBudgetTracking() {
 	// Load budget limit from settings
@@ -208,9 +259,45 @@ func (b *Bot) initBudgetTracking() {
 	})
 }
 
+// initFailoverAlerts notifies admins when an LLM task fails over to its
+// secondary provider or back. Like budget alerts, the callback fires
+// asynchronously with no request context.
+func (b *Bot) initFailoverAlerts() {
+	b.llmClient.SetFailoverCallback(func(event llm.FailoverEvent) {
+		if err := b.SendNotification(context.Background(), formatLLMFailoverAlert(event)); err != nil {
+			b.logger.Error().Err(err).Msg("failed to send LLM failover notification")
+		}
+	})
+}
+
+// formatLLMFailoverAlert renders a failover or failback event as an HTML admin notification.
+func formatLLMFailoverAlert(event llm.FailoverEvent) string {
+	primary := html.EscapeString(llm.FormatProviderModel(event.Primary))
+	secondary := html.EscapeString(llm.FormatProviderModel(event.Secondary))
+
+	if event.Direction == llm.FailoverDirectionBack {
+		return fmt.Sprintf("✅ <b>LLM Failback</b>\n\nTask <code>%s</code> is back on <code>%s</code> after successful probes.",
+			event.Task, primary)
+	}
+
+	return fmt.Sprintf("🔀 <b>LLM Failover</b>\n\nTask <code>%s</code> failed over from <code>%s</code> to <code>%s</code> after %d consecutive failures.\nLast error: <i>%s</i>",
+		event.Task, primary, secondary, event.Failures, html.EscapeString(event.LastError))
+}
+
 // Run starts the bot's main event loop, processing updates from Telegram.
 // It blocks until the context is canceled.
 func (b *Bot) Run(ctx context.Context) error {
+	if admins, err := b.syncCommandMenu(ctx); err != nil {
+		b.logger.Warn().Err(err).Msg("failed to register command menu")
+	} else {
+		b.logger.Info().Int("admins", admins).Msg("Registered command menu for admin chats")
+	}
+
+	// Scheduled recaps need the digest builder, as /catchup does
+	if b.digestBuilder != nil {
+		go b.runCatchupSchedules(ctx)
+	}
+
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
@@ -267,7 +354,15 @@ func (b *Bot) getAdmins(ctx context.Context) []int64 {
 }
 
 func (b *Bot) handleMessage(ctx context.Context, msg *tgbotapi.Message) {
+	ctx = b.withBotLanguage(ctx)
+
 	if !msg.IsCommand() {
+		if b.handleChannelImportCaption(ctx, msg) {
+			return
+		}
+
+		b.handleSetupInput(ctx, msg)
+
 		return
 	}
 
@@ -275,7 +370,7 @@ func (b *Bot) handleMessage(ctx context.Context, msg *tgbotapi.Message) {
 
 	registry := b.newCommandRegistry()
 	if !registry.route(ctx, b, msg) {
-		b.reply(msg, "Unknown command")
+		b.reply(msg, tr(ctx, "Unknown command"))
 	}
 }
 
@@ -284,6 +379,7 @@ func (b *Bot) handleCallback(ctx context.Context, query *tgbotapi.CallbackQuery)
 		return
 	}
 
+	ctx = b.withBotLanguage(ctx)
 	data := query.Data
 
 	switch {
@@ -291,6 +387,20 @@ func (b *Bot) handleCallback(ctx context.Context, query *tgbotapi.CallbackQuery)
 		b.handleRateCallback(ctx, query, data)
 	case strings.HasPrefix(data, CallbackPrefixDiscover):
 		b.handleDiscoverCallback(ctx, query)
+	case strings.HasPrefix(data, CallbackPrefixSearch):
+		b.handleSearchCallback(ctx, query)
+	case strings.HasPrefix(data, CallbackPrefixChannelList):
+		b.handleChannelListCallback(ctx, query)
+	case strings.HasPrefix(data, CallbackPrefixRollback):
+		b.handleRollbackCallback(ctx, query)
+	case strings.HasPrefix(data, CallbackPrefixConfigApply):
+		b.handleConfigApplyCallback(ctx, query)
+	case strings.HasPrefix(data, CallbackPrefixSetup):
+		b.handleSetupCallback(ctx, query)
+	case strings.HasPrefix(data, CallbackPrefixTrial):
+		b.handleChannelTrialCallback(ctx, query)
+	case strings.HasPrefix(data, CallbackPrefixPreview):
+		b.handlePreviewCallback(ctx, query)
 	}
 }
 
@@ -307,16 +417,38 @@ func (b *Bot) handleRateCallback(ctx context.Context, query *tgbotapi.CallbackQu
 		return
 	}
 
-	if err := b.database.SaveRating(ctx, digestID, query.From.ID, rating, ""); err != nil {
+	if err := b.saveDigestRating(ctx, digestID, query.From.ID, rating); err != nil {
 		b.logger.Error().Err(err).Msg("failed to save rating")
+	} else {
+		// Rating a digest means the user has read it.
+		b.markCatchupRead(ctx, query.From.ID, time.Now())
 	}
 
 	callback := tgbotapi.NewCallback(query.ID, "Feedback recorded. Thanks!")
-	if _, err := b.api.Request(callback); err != nil {
+	if _, err := b.sender.Request(callback); err != nil {
 		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
 	}
 }
 
+// saveDigestRating records the rating against the shadow digest variant when
+// digestID is a shadow digest, otherwise against the digest.
+func (b *Bot) saveDigestRating(ctx context.Context, digestID string, userID int64, rating int16) error {
+	saved, err := b.database.SaveShadowRating(ctx, digestID, userID, rating)
+	if err != nil {
+		return fmt.Errorf("save shadow rating: %w", err)
+	}
+
+	if saved {
+		return nil
+	}
+
+	if err := b.database.SaveRating(ctx, digestID, userID, rating, ""); err != nil {
+		return fmt.Errorf("save rating: %w", err)
+	}
+
+	return nil
+}
+
 func parseRatingValue(val string) int16 {
 	switch val {
 	case "up":
@@ -336,7 +468,7 @@ func (b *Bot) SendNotification(ctx context.Context, text string) error {
 		msg := tgbotapi.NewMessage(adminID, text)
 
 		msg.ParseMode = tgbotapi.ModeHTML
-		if _, err := b.api.Send(msg); err != nil {
+		if _, err := b.sender.Send(msg); err != nil {
 			b.logger.Error().Err(err).Int64("admin_id", adminID).Msg("failed to send notification to admin")
 		}
 	}
@@ -347,63 +479,58 @@ func (b *Bot) SendNotification(ctx context.Context, text string) error {
 // SendDigest sends a text digest to the specified chat, splitting into multiple
 // messages if needed. Returns the first message ID for tracking.
 func (b *Bot) SendDigest(ctx context.Context, chatID int64, text string, digestID string) (int64, error) {
-	parts := SplitHTML(text, MaxMessageSize)
-
-	var firstMsgID int64
+	msgIDs, err := b.sendDigestText(ctx, chatID, text, digestID)
+	if err != nil {
+		return 0, err
+	}
 
-	for i, part := range parts {
-		msg := tgbotapi.NewMessage(chatID, part)
-		msg.ParseMode = tgbotapi.ModeHTML
-		msg.DisableWebPagePreview = true
-
-		// Add rating buttons to the last part of the digest
-		if i == len(parts)-1 && digestID != "" {
-			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
-				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData(ButtonUseful, CallbackPrefixRate+digestID+CallbackSuffixUp),
-					tgbotapi.NewInlineKeyboardButtonData(ButtonNotUseful, CallbackPrefixRate+digestID+CallbackSuffixDown),
-				),
-			)
-		}
+	return msgIDs[0], nil
+}
 
-		sent, err := b.api.Send(msg)
-		if err != nil {
-			return 0, fmt.Errorf(ErrSendDigestPart, i+1, chatID, err)
-		}
+// SendDigestWithImage sends a digest with a cover image to the specified chat.
+// The image is sent first, followed by the text content split into messages.
+func (b *Bot) SendDigestWithImage(ctx context.Context, chatID int64, text string, digestID string, imageData []byte) (int64, error) {
+	firstMsgID := b.sendCoverImage(chatID, imageData)
 
-		if i == 0 {
-			firstMsgID = int64(sent.MessageID)
-		}
+	msgIDs, err := b.sendDigestText(ctx, chatID, text, digestID)
+	if err != nil {
+		return 0, err
+	}
 
-		// Small delay between parts to avoid rate limits if many parts
-		if len(parts) > 1 && i < len(parts)-1 {
-			time.Sleep(SleepBetweenParts)
-		}
+	if firstMsgID == 0 {
+		firstMsgID = msgIDs[0]
 	}
 
 	return firstMsgID, nil
 }
 
-// SendDigestWithImage sends a digest with a cover image to the specified chat.
-// The image is sent first, followed by the text content split into messages.
-func (b *Bot) SendDigestWithImage(ctx context.Context, chatID int64, text string, digestID string, imageData []byte) (int64, error) {
-	firstMsgID := b.sendCoverImage(chatID, imageData)
+// sendDigestText splits digest text into messages, sends them in the output
+// mode of the chat and links the table of contents to the messages holding
+// each topic. It returns the ID of every message sent, in order.
+func (b *Bot) sendDigestText(ctx context.Context, chatID int64, text, digestID string) ([]int64, error) {
+	renderer := b.digestRenderer(ctx, chatID)
 
-	// Send text parts
-	parts := SplitHTML(text, MaxMessageSize)
+	limit := MaxMessageSize
+	if renderer.Format() == markup.FormatMarkdownV2 {
+		limit = maxMarkdownV2SourceSize
+	}
+
+	// Split before stripping markers so topic anchors can be traced to their message.
+	parts := htmlutils.SplitHTML(text, limit)
+	msgIDs := make([]int64, 0, len(parts))
 
 	for i, part := range parts {
-		msgID, err := b.sendDigestPart(chatID, part, digestID, i, len(parts))
+		msgID, err := b.sendDigestPart(chatID, renderer, htmlutils.StripItemMarkers(part), digestID, i, len(parts))
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 
-		if firstMsgID == 0 {
-			firstMsgID = msgID
-		}
+		msgIDs = append(msgIDs, msgID)
 	}
 
-	return firstMsgID, nil
+	b.backfillDigestTOC(chatID, renderer, parts, msgIDs)
+
+	return msgIDs, nil
 }
 
 // sendCoverImage sends the cover image and returns the message ID (0 if not sent).
@@ -426,22 +553,20 @@ func (b *Bot) sendCoverImage(chatID int64, imageData []byte) int64 {
 		Bytes: imageData,
 	})
 
-	sent, err := b.api.Send(photoMsg)
+	sent, err := b.sender.Send(photoMsg)
 	if err != nil {
 		b.logger.Warn().Err(err).Str(logFieldMimeType, mimeType).Msg("failed to send digest cover image, continuing with text only")
 
 		return 0
 	}
 
-	time.Sleep(SleepAfterImage)
-
 	return int64(sent.MessageID)
 }
 
 // sendDigestPart sends a single part of the digest text.
-func (b *Bot) sendDigestPart(chatID int64, part, digestID string, index, total int) (int64, error) {
-	msg := tgbotapi.NewMessage(chatID, part)
-	msg.ParseMode = tgbotapi.ModeHTML
+func (b *Bot) sendDigestPart(chatID int64, renderer markup.Renderer, part, digestID string, index, total int) (int64, error) {
+	msg := tgbotapi.NewMessage(chatID, renderer.Render(part))
+	msg.ParseMode = renderer.ParseMode()
 	msg.DisableWebPagePreview = true
 
 	// Add rating buttons to the last part
@@ -454,15 +579,11 @@ func (b *Bot) sendDigestPart(chatID int64, part, digestID string, index, total i
 		)
 	}
 
-	sent, err := b.api.Send(msg)
+	sent, err := b.sender.Send(msg)
 	if err != nil {
 		return 0, fmt.Errorf(ErrSendDigestPart, index+1, chatID, err)
 	}
 
-	if total > 1 && index < total-1 {
-		time.Sleep(SleepBetweenParts)
-	}
-
 	return int64(sent.MessageID), nil
 }
 
@@ -490,12 +611,14 @@ func getImageFileName(mimeType string) string {
 func (b *Bot) SendRichDigest(ctx context.Context, chatID int64, content digest.RichDigestContent) (int64, error) {
 	var firstMsgID int64
 
+	renderer := b.digestRenderer(ctx, chatID)
+
 	// Send header as text
-	headerMsg := tgbotapi.NewMessage(chatID, content.Header)
-	headerMsg.ParseMode = tgbotapi.ModeHTML
+	headerMsg := tgbotapi.NewMessage(chatID, renderer.Render(content.Header))
+	headerMsg.ParseMode = renderer.ParseMode()
 	headerMsg.DisableWebPagePreview = true
 
-	sent, err := b.api.Send(headerMsg)
+	sent, err := b.sender.Send(headerMsg)
 	if err != nil {
 		return 0, fmt.Errorf("failed to send digest header: %w", err)
 	}
@@ -504,12 +627,10 @@ func (b *Bot) SendRichDigest(ctx context.Context, chatID int64, content digest.R
 
 	// Send each item
 	for _, item := range content.Items {
-		if err := b.sendDigestItem(chatID, item); err != nil {
+		if err := b.sendDigestItem(chatID, renderer, item, newCaptionOptions(content)); err != nil {
 			truncatedSummary := item.Summary[:min(SummaryTruncateLength, len(item.Summary))]
 			b.logger.Warn().Err(err).Str("summary", truncatedSummary).Msg("failed to send digest item")
 		}
-
-		time.Sleep(SleepBetweenRichItems)
 	}
 
 	// Send rating buttons
@@ -522,7 +643,7 @@ func (b *Bot) SendRichDigest(ctx context.Context, chatID int64, content digest.R
 			),
 		)
 
-		if _, err := b.api.Send(ratingMsg); err != nil {
+		if _, err := b.sender.Send(ratingMsg); err != nil {
 			b.logger.Warn().Err(err).Msg("failed to send rating buttons")
 		}
 	}
@@ -531,12 +652,12 @@ func (b *Bot) SendRichDigest(ctx context.Context, chatID int64, content digest.R
 }
 
 // sendDigestItem sends a single digest item as photo with caption or text.
-func (b *Bot) sendDigestItem(chatID int64, item digest.RichDigestItem) error {
+func (b *Bot) sendDigestItem(chatID int64, renderer markup.Renderer, item digest.RichDigestItem, opts captionOptions) error {
 	// Format the item caption/text
-	caption := formatDigestItemCaption(item)
+	caption := renderer.Render(formatDigestItemCaption(item, opts))
 
-	// Check if we have valid image data
-	if len(item.MediaData) > 0 {
+	// Check if we have valid image data; blurred entries are sent without it
+	if len(item.MediaData) > 0 && !opts.hidesImage(item) {
 		mimeType := http.DetectContentType(item.MediaData)
 		fileName := getImageFileName(mimeType)
 
@@ -547,9 +668,9 @@ func (b *Bot) sendDigestItem(chatID int64, item digest.RichDigestItem) error {
 				Bytes: item.MediaData,
 			})
 			photo.Caption = caption
-			photo.ParseMode = tgbotapi.ModeHTML
+			photo.ParseMode = renderer.ParseMode()
 
-			_, err := b.api.Send(photo)
+			_, err := b.sender.Send(photo)
 			if err == nil {
 				return nil
 			}
@@ -561,10 +682,10 @@ func (b *Bot) sendDigestItem(chatID int64, item digest.RichDigestItem) error {
 
 	// Send as text message
 	msg := tgbotapi.NewMessage(chatID, caption)
-	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ParseMode = renderer.ParseMode()
 	msg.DisableWebPagePreview = true
 
-	_, err := b.api.Send(msg)
+	_, err := b.sender.Send(msg)
 	if err != nil {
 		return fmt.Errorf("failed to send digest item: %w", err)
 	}
@@ -572,8 +693,25 @@ func (b *Bot) sendDigestItem(chatID int64, item digest.RichDigestItem) error {
 	return nil
 }
 
+// captionOptions are the rich digest settings that shape item captions.
+type captionOptions struct {
+	altTextMode  string
+	safetyPolicy string
+}
+
+func newCaptionOptions(content digest.RichDigestContent) captionOptions {
+	return captionOptions{altTextMode: content.AltTextMode, safetyPolicy: content.SafetyPolicy}
+}
+
+// hidesImage reports whether the image of a sensitive entry is withheld
+// under the blur policy. Telegram's media spoiler is not available, so the
+// entry is sent as text.
+func (o captionOptions) hidesImage(item digest.RichDigestItem) bool {
+	return item.Sensitive && o.safetyPolicy == filters.SafetyPolicyBlur
+}
+
 // formatDigestItemCaption formats a digest item for display.
-func formatDigestItemCaption(item digest.RichDigestItem) string {
+func formatDigestItemCaption(item digest.RichDigestItem, opts captionOptions) string {
 	var sb strings.Builder
 
 	// Add topic emoji if available
@@ -589,8 +727,18 @@ func formatDigestItemCaption(item digest.RichDigestItem) string {
 		sb.WriteString("📌 ")
 	}
 
-	// Add summary
-	sb.WriteString(item.Summary)
+	// Mark video posts, whose image is a thumbnail
+	if item.VideoDuration > 0 {
+		sb.WriteString("▶️ " + formatVideoDuration(item.VideoDuration) + " ")
+	}
+
+	// Add summary, marked per the safety policy when sensitive
+	summary := item.Summary
+	if item.Sensitive {
+		summary = digest.MarkSensitive(summary, opts.safetyPolicy)
+	}
+
+	sb.WriteString(summary)
 	sb.WriteString("\n")
 
 	// Add source link
@@ -607,9 +755,70 @@ func formatDigestItemCaption(item digest.RichDigestItem) string {
 		sb.WriteString(htmlItalicClose)
 	}
 
+	// Note the other posts of the item's cluster
+	if item.Related > 0 {
+		if item.Channel != "" {
+			sb.WriteString("\n")
+		}
+
+		fmt.Fprintf(&sb, "   ↳ <i>+%d related</i>", item.Related)
+	}
+
+	if !opts.hidesImage(item) {
+		writeAltTextLine(&sb, item, opts.altTextMode)
+	}
+
 	return sb.String()
 }
 
+// writeAltTextLine appends the description of an item's image, shown in
+// italics or behind a spoiler depending on the digest_alt_text mode.
+func writeAltTextLine(sb *strings.Builder, item digest.RichDigestItem, altTextMode string) {
+	if len(item.MediaData) == 0 || item.AltText == "" {
+		return
+	}
+
+	if altTextMode != digest.AltTextCaption && altTextMode != digest.AltTextSpoiler {
+		return
+	}
+
+	if !strings.HasSuffix(sb.String(), "\n") {
+		sb.WriteString("\n")
+	}
+
+	altText := html.EscapeString(truncateAltText(item.AltText))
+
+	if altTextMode == digest.AltTextSpoiler {
+		sb.WriteString("🖼 <tg-spoiler>" + altText + "</tg-spoiler>")
+
+		return
+	}
+
+	sb.WriteString("🖼 <i>" + altText + htmlItalicClose)
+}
+
+// truncateAltText keeps the alt text of collages within the photo caption limit.
+func truncateAltText(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxCaptionAltTextRunes {
+		return text
+	}
+
+	return strings.TrimSpace(string(runes[:maxCaptionAltTextRunes-1])) + "…"
+}
+
+// formatVideoDuration formats seconds as m:ss, or h:mm:ss for long videos.
+func formatVideoDuration(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	hours, minutes, secs := int(d.Hours()), int(d.Minutes())%60, seconds%60
+
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, secs)
+	}
+
+	return fmt.Sprintf("%d:%02d", minutes, secs)
+}
+
 // getTopicEmoji returns emoji for a topic.
 func getTopicEmoji(topic string) string {
 	topicEmojis := map[string]string{
@@ -646,7 +855,7 @@ func (b *Bot) sendMessage(chatID int64, text string) {
 		reply := tgbotapi.NewMessage(chatID, part)
 		reply.ParseMode = tgbotapi.ModeHTML
 
-		if _, err := b.api.Send(reply); err != nil {
+		if _, err := b.sender.Send(reply); err != nil {
 			b.logger.Error().Err(err).Msg("failed to send reply")
 		}
 	}
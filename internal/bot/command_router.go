@@ -32,14 +32,15 @@ func (b *Bot) newCommandRegistry() *commandRegistry {
 
 func (b *Bot) registerCoreCommands(r *commandRegistry) {
 	// Basic commands
-	r.handlers["start"] = b.handleHelp
+	r.handlers["start"] = b.handleStart
 	r.handlers["help"] = b.handleHelp
 	r.handlers["botfather"] = b.handleBotFather
-	r.handlers["commands"] = b.handleBotFather
+	r.handlers[CmdCommands] = b.handleCommands
 	r.handlers["setup"] = b.handleSetup
 	r.handlers[CmdStatus] = b.handleStatus
 	r.handlers["preview"] = b.handlePreview
 	r.handlers[CmdResearch] = b.handleResearch
+	r.handlers[CmdAPIKey] = b.handleAPIKey
 
 	// Namespace commands
 	r.handlers[CmdChannel] = b.handleChannelNamespace
@@ -63,7 +64,20 @@ func (b *Bot) registerSettingCommands(r *commandRegistry) {
 	r.handlers[CmdFactCheck] = b.handleFactCheck
 	r.handlers[CmdEnrichment] = b.handleEnrichmentNamespace
 	r.handlers[CmdPrompt] = b.handlePrompt
+	r.handlers[CmdTemplate] = b.handleTemplate
+	r.handlers[CmdRollup] = b.handleRollup
+	r.handlers[CmdCatchup] = b.handleCatchup
+	r.handlers[CmdWatch] = b.handleWatch
+	r.handlers[CmdWatchlist] = b.handleWatchlist
+	r.handlers[CmdSearch] = b.handleSearch
+	r.handlers[CmdSimilar] = b.handleSimilar
+	r.handlers[CmdStory] = b.handleStory
+	r.handlers[CmdWhatIf] = b.handleWhatIf
+	r.handlers[CmdQuality] = b.handleQuality
+	r.handlers[CmdStats] = b.handleStats
+	r.handlers[CmdItem] = b.handleItem
 	r.handlers["filters"] = b.handleFilters
+	r.handlers[CmdRules] = b.handleRules
 	r.handlers[CmdTarget] = b.handleTarget
 	r.handlers[CmdWindow] = b.handleWindow
 	r.handlers[CmdSchedule] = b.handleSchedule
@@ -108,6 +122,28 @@ func (b *Bot) registerToggleCommands(r *commandRegistry) {
 	r.toggleSettings[CmdInlineImagesAlt] = SettingDigestInlineImages
 	r.toggleSettings[CmdOthersNarrative] = SettingOthersAsNarrative
 	r.toggleSettings[CmdOthersNarrativeAlt] = SettingOthersAsNarrative
+	r.toggleSettings[CmdStanceBadges] = SettingDigestStanceBadges
+	r.toggleSettings[CmdStanceBadgesAlt] = SettingDigestStanceBadges
+	r.toggleSettings[CmdNumbersBlock] = SettingDigestNumbersBlock
+	r.toggleSettings[CmdNumbersBlockAlt] = SettingDigestNumbersBlock
+	r.toggleSettings[CmdQuotesBlock] = SettingDigestQuotesBlock
+	r.toggleSettings[CmdQuotesBlockAlt] = SettingDigestQuotesBlock
+	r.toggleSettings[CmdUpcomingBlock] = SettingDigestUpcomingBlock
+	r.toggleSettings[CmdUpcomingBlockAlt] = SettingDigestUpcomingBlock
+	r.toggleSettings[CmdRumorWatch] = SettingDigestRumorWatch
+	r.toggleSettings[CmdRumorWatchAlt] = SettingDigestRumorWatch
+	r.toggleSettings[CmdWhatsNew] = SettingDigestWhatsNew
+	r.toggleSettings[CmdWhatsNewAlt] = SettingDigestWhatsNew
+	r.toggleSettings[CmdSourceFooter] = SettingDigestSourceFooter
+	r.toggleSettings[CmdSourceFooterAlt] = SettingDigestSourceFooter
+	r.toggleSettings[CmdClickTracking] = SettingDigestClickTracking
+	r.toggleSettings[CmdClickTrackingAlt] = SettingDigestClickTracking
+	r.toggleSettings[CmdSlackDelivery] = SettingDeliverySlackEnabled
+	r.toggleSettings[CmdSlackDeliveryAlt] = SettingDeliverySlackEnabled
+	r.toggleSettings[CmdDiscordDelivery] = SettingDeliveryDiscordEnabled
+	r.toggleSettings[CmdDiscordDeliveryAlt] = SettingDeliveryDiscordEnabled
+	r.toggleSettings[CmdMatrixDelivery] = SettingDeliveryMatrixEnabled
+	r.toggleSettings[CmdMatrixDeliveryAlt] = SettingDeliveryMatrixEnabled
 }
 
 // route handles the command routing for a message.
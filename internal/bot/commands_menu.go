@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CmdCommands shows the command menu and re-registers it with "sync".
+const CmdCommands = "commands"
+
+const commandsSubSync = "sync"
+
+// commandMenu is the command list shown in Telegram's autocomplete. Every
+// command must have a handler in the command registry.
+var commandMenu = []tgbotapi.BotCommand{
+	{Command: "start", Description: "Show help"},
+	{Command: "help", Description: "Command overview"},
+	{Command: "setup", Description: "Setup wizard: target, channels, language, schedule"},
+	{Command: CmdStatus, Description: "System status"},
+	{Command: "preview", Description: "Preview next digest"},
+	{Command: CmdChannel, Description: "Channels: add, remove, list, weight, relevance, quota, stats"},
+	{Command: "filter", Description: "Filters: add, remove, ads, mode"},
+	{Command: CmdRules, Description: "Pre-filter rules"},
+	{Command: "config", Description: "Settings: target, window, language, tone, thresholds"},
+	{Command: CmdSchedule, Description: "Digest schedule"},
+	{Command: "ai", Description: "AI features: editor, tiered, vision, prompts"},
+	{Command: CmdLLM, Description: "LLM providers, models and budget"},
+	{Command: "system", Description: "System tools: status, settings, errors, retry"},
+	{Command: CmdEnrichment, Description: "Source enrichment"},
+	{Command: CmdResearch, Description: "Research dashboard"},
+	{Command: CmdScores, Description: "Score stats"},
+	{Command: CmdFactCheck, Description: "Fact check status"},
+	{Command: CmdRatings, Description: "Rating stats"},
+	{Command: CmdQuality, Description: "Precision and noise trend from ratings"},
+	{Command: CmdStats, Description: "Audience growth and digest views"},
+	{Command: "discover", Description: "Channel discovery"},
+	{Command: "feedback", Description: "Rate an item"},
+	{Command: CmdSettings, Description: "Show current settings"},
+	{Command: CmdHistory, Description: "Recent setting changes"},
+	{Command: CmdTemplate, Description: "Digest layout templates"},
+	{Command: CmdRollup, Description: "Weekly and monthly roll-ups"},
+	{Command: CmdCatchup, Description: "Recap since your last read"},
+	{Command: CmdSearch, Description: "Search items"},
+	{Command: CmdSimilar, Description: "Find similar items"},
+	{Command: CmdStory, Description: "Ongoing story timelines"},
+	{Command: CmdWhatIf, Description: "Simulate thresholds and weights"},
+	{Command: CmdItem, Description: "Item details and dedup decisions"},
+	{Command: CmdWatch, Description: "Saved search notifications"},
+	{Command: CmdWatchlist, Description: "People and organizations to watch"},
+	{Command: CmdCommands, Description: "Command menu for BotFather"},
+}
+
+// syncCommandMenu registers the command menu for the private chat of every
+// admin and clears the default menu, so only admins get autocomplete.
+func (b *Bot) syncCommandMenu(ctx context.Context) (int, error) {
+	if _, err := b.sender.Request(tgbotapi.NewDeleteMyCommands()); err != nil {
+		return 0, fmt.Errorf("delete default commands: %w", err)
+	}
+
+	admins := b.getAdmins(ctx)
+
+	for _, adminID := range admins {
+		if _, err := b.sender.Request(tgbotapi.NewSetMyCommandsWithScope(tgbotapi.NewBotCommandScopeChat(adminID), commandMenu...)); err != nil {
+			return 0, fmt.Errorf("set commands for admin %d: %w", adminID, err)
+		}
+	}
+
+	return len(admins), nil
+}
+
+func (b *Bot) handleCommands(ctx context.Context, msg *tgbotapi.Message) {
+	if strings.TrimSpace(msg.CommandArguments()) != commandsSubSync {
+		b.reply(msg, botFatherCommandsMessage())
+
+		return
+	}
+
+	admins, err := b.syncCommandMenu(ctx)
+	if err != nil {
+		b.reply(msg, tr(ctx, ErrGenericFmt, err.Error()))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Command menu registered for <code>%d</code> admin chats.", admins))
+}
+
+// botFatherCommandsMessage returns the command menu in BotFather's /setcommands format.
+func botFatherCommandsMessage() string {
+	var sb strings.Builder
+
+	sb.WriteString("The command menu is registered for admin chats on startup; " +
+		"<code>/commands sync</code> refreshes it after adding admins.\n\n" +
+		"To set it manually, use <code>/setcommands</code> in BotFather with:\n\n<code>")
+
+	for i, cmd := range commandMenu {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		fmt.Fprintf(&sb, "%s - %s", cmd.Command, cmd.Description)
+	}
+
+	sb.WriteString("</code>")
+
+	return sb.String()
+}
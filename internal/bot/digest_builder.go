@@ -16,4 +16,6 @@ type DigestBuilder interface {
 	// Returns the formatted text, items included, clusters, and any error.
 	// The fourth return value is package-private anomaly info (ignored by bot).
 	BuildDigest(ctx context.Context, start, end time.Time, importanceThreshold float32, logger *zerolog.Logger) (string, []db.Item, []db.ClusterWithItems, any, error)
+	// BuildRollup renders the weekly or monthly roll-up for the last complete period before now.
+	BuildRollup(ctx context.Context, kind string, now time.Time, logger *zerolog.Logger) (string, error)
 }
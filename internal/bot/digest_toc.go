@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/markup"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+)
+
+const (
+	// CmdTOC is the /config subcommand for the digest table of contents.
+	CmdTOC = "toc"
+
+	// channelChatIDOffset is subtracted from supergroup and channel chat IDs
+	// (-100xxxxxxxxxx) to get the internal ID used in t.me/c links.
+	channelChatIDOffset = 1000000000000
+)
+
+// backfillDigestTOC edits the digest messages that carry the table of contents
+// so each entry links to the message where its topic starts. It only applies
+// when the digest was split, and only in chats that support message links.
+func (b *Bot) backfillDigestTOC(chatID int64, renderer markup.Renderer, parts []string, msgIDs []int64) {
+	if len(parts) < 2 || len(parts) != len(msgIDs) {
+		return
+	}
+
+	anchorMsgs := make(map[int]int64)
+
+	for i, part := range parts {
+		for _, n := range htmlutils.FindTopicAnchors(part) {
+			if _, ok := anchorMsgs[n]; !ok {
+				anchorMsgs[n] = msgIDs[i]
+			}
+		}
+	}
+
+	if len(anchorMsgs) == 0 {
+		return
+	}
+
+	// The last part carries the rating keyboard, which an edit would drop.
+	for i, part := range parts[:len(parts)-1] {
+		linked, count := htmlutils.LinkTOCEntries(part, func(n int) string {
+			msgID, ok := anchorMsgs[n]
+			if !ok || msgID == msgIDs[i] {
+				return ""
+			}
+
+			return digestMessageLink(chatID, msgID)
+		})
+		if count == 0 {
+			continue
+		}
+
+		edit := tgbotapi.NewEditMessageText(chatID, int(msgIDs[i]), renderer.Render(htmlutils.StripItemMarkers(linked)))
+		edit.ParseMode = renderer.ParseMode()
+		edit.DisableWebPagePreview = true
+
+		if _, err := b.sender.Send(edit); err != nil {
+			b.logger.Warn().Err(err).Int64("chat_id", chatID).Int64("message_id", msgIDs[i]).Msg("failed to link digest table of contents")
+		}
+	}
+}
+
+// digestMessageLink returns a t.me link to a message in a supergroup or
+// channel, or "" for chats without message links.
+func digestMessageLink(chatID, msgID int64) string {
+	if chatID > -channelChatIDOffset {
+		return ""
+	}
+
+	return fmt.Sprintf("https://t.me/c/%d/%d", -chatID-channelChatIDOffset, msgID)
+}
+
+func (b *Bot) handleTOC(ctx context.Context, msg *tgbotapi.Message) {
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	val, err := strconv.Atoi(arg)
+	if arg == ToggleOff {
+		val, err = 0, nil
+	}
+
+	if err != nil || val < 0 {
+		b.reply(msg, fmt.Sprintf("Usage: <code>/config toc &lt;min topics|off&gt;</code>\n\n"+
+			"Adds a table of contents to digests with at least that many topics (default %d).", digest.DefaultTOCMinTopics))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestTOCMinTopics, val, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if val == 0 {
+		b.reply(msg, "✅ Digest table of contents disabled.")
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Digests with at least <code>%d</code> topics now start with a table of contents.", val))
+}
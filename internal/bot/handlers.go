@@ -14,10 +14,13 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
 	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/markup"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/schedule"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
 	"github.com/lueurxax/telegram-digest-bot/internal/research"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
@@ -32,8 +35,8 @@ func (b *Bot) handleThreshold(ctx context.Context, msg *tgbotapi.Message, key st
 	_ = b.database.GetSetting(ctx, key, &current) //nolint:errcheck // best-effort read
 
 	if args == "" {
-		hint := "higher = stricter filtering"
-		b.reply(msg, fmt.Sprintf(`📊 <b>%s</b>
+		hint := tr(ctx, "higher = stricter filtering")
+		b.reply(msg, tr(ctx, `📊 <b>%s</b>
 
 Current value: <code>%.2f</code>
 Range: 0.0 - 1.0 (%s)
@@ -46,23 +49,12 @@ Usage: <code>/%s &lt;0.0-1.0&gt;</code>`, html.EscapeString(label), current, hin
 	val, err := strconv.ParseFloat(args, 32)
 
 	if err != nil || val < 0 || val > 1 {
-		b.reply(msg, "❌ Invalid value. Please provide a number between 0.0 and 1.0.")
+		b.reply(msg, tr(ctx, "❌ Invalid value. Please provide a number between 0.0 and 1.0."))
 
 		return
 	}
 
-	if err := b.database.SaveSettingWithHistory(ctx, key, float32(val), msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf(ErrSavingFmt, html.EscapeString(key), html.EscapeString(err.Error())))
-
-		return
-	}
-
-	direction := "more items will pass"
-	if val > float64(current) {
-		direction = "fewer items will pass"
-	}
-
-	b.reply(msg, fmt.Sprintf("✅ <b>%s</b> updated: <code>%.2f</code> → <code>%.2f</code>\n\n💡 %s", html.EscapeString(label), current, val, direction))
+	b.previewThresholdChange(ctx, msg, key, label, float32(val))
 }
 
 func (b *Bot) handleStatus(ctx context.Context, msg *tgbotapi.Message) {
@@ -72,10 +64,20 @@ func (b *Bot) handleStatus(ctx context.Context, msg *tgbotapi.Message) {
 	readyItems, _ := b.database.CountReadyItems(ctx)                 //nolint:errcheck // best-effort read
 	lastDigest, _ := b.database.GetLastPostedDigest(ctx)             //nolint:errcheck // best-effort read
 
+	deadChannels, err := b.database.CountDeadChannels(ctx)
+	if err != nil {
+		b.logger.Debug().Err(err).Msg("failed to count dead channels")
+	}
+
 	var sb strings.Builder
 
 	sb.WriteString("📊 <b>System Status</b>\n\n")
 	sb.WriteString(fmt.Sprintf("• <b>Active Channels:</b> <code>%d</code>\n", activeChannels))
+
+	if deadChannels > 0 {
+		sb.WriteString(fmt.Sprintf("• <b>Dead Channels (no posts 30d, excluded):</b> <code>%d</code>\n", deadChannels))
+	}
+
 	sb.WriteString(fmt.Sprintf("• <b>Channels with messages (24h):</b> <code>%d</code>\n", recentChannels))
 	sb.WriteString(fmt.Sprintf("• <b>Message Backlog:</b> <code>%d</code>\n", backlog))
 	sb.WriteString(fmt.Sprintf("• <b>Items ready for digest:</b> <code>%d</code>\n", readyItems))
@@ -94,16 +96,23 @@ func (b *Bot) handleChannelNamespace(ctx context.Context, msg *tgbotapi.Message)
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) == 0 {
-		b.reply(msg, `📺 <b>Channel Management</b>
+		b.reply(msg, tr(ctx, `📺 <b>Channel Management</b>
 
 <b>Commands:</b>
 • <code>/channel add @user</code> - Add channel to tracking
 • <code>/channel remove @user</code> - Remove channel
-• <code>/channel list</code> - List tracked channels
+• <code>/channel list</code> - List channels (filters, sort, compact)
+• <code>/channel export [json|csv]</code> - Export channels with settings
+• <code>/channel import [apply]</code> - Import channels from a file (dry run first)
+• <code>/channel group</code> - Channel groups with own thresholds and digests
+• <code>/channel snooze @user 6h</code> - Pause a channel for a while
+• <code>/channel trial</code> - Trials of new channels (report, promote, reject)
+• <code>/channel purge @user</code> - Delete a channel and all its data
 • <code>/channel metadata @user ...</code> - Set category/tone
 • <code>/channel weight @user</code> - View/set importance weight
 • <code>/channel relevance @user</code> - View/set auto-relevance
-• <code>/channel stats</code> - Channel quality metrics`)
+• <code>/channel quota @user</code> - View/set ingestion quota
+• <code>/channel stats</code> - Channel quality metrics`))
 
 		return
 	}
@@ -118,6 +127,18 @@ func (b *Bot) handleChannelNamespace(ctx context.Context, msg *tgbotapi.Message)
 		b.handleRemoveChannel(ctx, &newMsg)
 	case CmdList:
 		b.handleListChannels(ctx, &newMsg)
+	case SubCmdExport:
+		b.handleChannelExport(ctx, &newMsg)
+	case SubCmdImport:
+		b.handleChannelImport(ctx, &newMsg)
+	case SubCmdGroup:
+		b.handleChannelGroup(ctx, &newMsg)
+	case SubCmdSnooze:
+		b.handleChannelSnooze(ctx, &newMsg)
+	case SubCmdTrial:
+		b.handleChannelTrial(ctx, &newMsg)
+	case SubCmdPurge:
+		b.handleChannelPurge(ctx, &newMsg)
 	case "metadata":
 		b.handleChannelMetadata(ctx, &newMsg)
 	case SubCmdStats:
@@ -126,8 +147,10 @@ func (b *Bot) handleChannelNamespace(ctx context.Context, msg *tgbotapi.Message)
 		b.handleChannelWeight(ctx, &newMsg)
 	case CmdRelevance:
 		b.handleChannelRelevance(ctx, &newMsg)
+	case CmdQuota:
+		b.handleChannelQuota(ctx, &newMsg)
 	default:
-		b.reply(msg, fmt.Sprintf("❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/channel</code> to see available commands.", html.EscapeString(subcommand)))
+		b.reply(msg, tr(ctx, "❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/channel</code> to see available commands.", html.EscapeString(subcommand)))
 	}
 }
 
@@ -154,8 +177,10 @@ func (b *Bot) handleFilterNamespace(ctx context.Context, msg *tgbotapi.Message)
 		b.handleMinLength(ctx, &newMsg)
 	case "skip_forwards", "skipforwards":
 		b.handleToggleSetting(ctx, &newMsg, "filters_skip_forwards")
+	case "media":
+		b.handleMediaFilter(ctx, &newMsg)
 	default:
-		b.reply(msg, fmt.Sprintf("❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/filter</code> to see current filters, or use <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code>.", html.EscapeString(subcommand)))
+		b.reply(msg, tr(ctx, "❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/filter</code> to see current filters, or use <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code>.", html.EscapeString(subcommand)))
 	}
 }
 
@@ -163,13 +188,29 @@ func (b *Bot) handleConfigNamespace(ctx context.Context, msg *tgbotapi.Message)
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) == 0 {
-		b.reply(msg, `⚙️ <b>Configuration</b>
+		b.reply(msg, tr(ctx, `⚙️ <b>Configuration</b>
 
 <b>Output:</b>
 • <code>/config target @channel</code> - Set digest target
 • <code>/config window 6h</code> - Set digest interval
 • <code>/config language en</code> - Set language
+• <code>/config bot_language ru</code> - Language of bot replies (en/ru)
 • <code>/config tone casual</code> - Set tone
+• <code>/config item_links bot</code> - Item detail links (off/bot/research)
+• <code>/config toc 5</code> - Table of contents from N topics (or off)
+• <code>/config stale section</code> - Carry over missed items (off/skip/section)
+• <code>/config diversity channel 3</code> - Max items per channel/topic in a digest
+• <code>/config mmr 0.7</code> - Trade importance for novelty among digest items (or off)
+• <code>/config tier_sort corroboration</code> - Order stories within tiers by importance or channel count
+• <code>/config prebuild 15</code> - Build digests N minutes before their slot (or off)
+• <code>/config cover style flat</code> - AI cover style, prompt template and local cover
+• <code>/config verbosity compact</code> - Compact/standard/detailed (optionally per target)
+• <code>/config format markdownv2</code> - HTML or MarkdownV2 output (optionally per target)
+• <code>/config alt_text caption</code> - Image descriptions in rich digests (off/caption/spoiler)
+• <code>/config safety blur</code> - NSFW/graphic/profane items: off/warn/blur/block (optionally per target)
+• <code>/config regions ua,pl</code> - Only items about these countries (or off)
+• <code>/config shadow</code> - Shadow target for testing experimental settings
+• <code>/config stages</code> - Turn optional pipeline stages on or off
 
 <b>Thresholds:</b>
 • <code>/config relevance 0.5</code> - Min relevance (0-1, higher = stricter)
@@ -180,7 +221,7 @@ func (b *Bot) handleConfigNamespace(ctx context.Context, msg *tgbotapi.Message)
 • <code>/config maxlinks 3</code> - Max links per message
 
 <b>Reset:</b>
-• <code>/config reset &lt;key&gt;</code> - Reset setting to default`)
+• <code>/config reset &lt;key&gt;</code> - Reset setting to default`))
 
 		return
 	}
@@ -189,24 +230,40 @@ func (b *Bot) handleConfigNamespace(ctx context.Context, msg *tgbotapi.Message)
 	newMsg := prepareSubcommandMessage(msg, subcommand, args)
 
 	if !b.routeConfigSubcommand(ctx, &newMsg, subcommand) {
-		b.reply(msg, fmt.Sprintf("❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/config</code> to see available settings.", html.EscapeString(subcommand)))
+		b.reply(msg, tr(ctx, "❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/config</code> to see available settings.", html.EscapeString(subcommand)))
 	}
 }
 
 func (b *Bot) routeConfigSubcommand(ctx context.Context, msg *tgbotapi.Message, subcommand string) bool {
 	handlers := map[string]func(){
-		"links":      func() { b.handleToggleSetting(ctx, msg, "link_enrichment_enabled") },
-		"max_links":  func() { b.handleMaxLinks(ctx, msg) },
-		"maxlinks":   func() { b.handleMaxLinks(ctx, msg) },
-		"link_cache": func() { b.handleLinkCache(ctx, msg) },
-		"linkcache":  func() { b.handleLinkCache(ctx, msg) },
-		"target":     func() { b.handleTarget(ctx, msg) },
-		"window":     func() { b.handleWindow(ctx, msg) },
-		"schedule":   func() { b.handleSchedule(ctx, msg) },
-		"language":   func() { b.handleLanguage(ctx, msg) },
-		CmdTone:      func() { b.handleTone(ctx, msg) },
-		"relevance":  func() { b.handleThreshold(ctx, msg, SettingRelevanceThreshold) },
-		"importance": func() { b.handleThreshold(ctx, msg, SettingImportanceThreshold) },
+		"links":        func() { b.handleToggleSetting(ctx, msg, "link_enrichment_enabled") },
+		"max_links":    func() { b.handleMaxLinks(ctx, msg) },
+		"maxlinks":     func() { b.handleMaxLinks(ctx, msg) },
+		"link_cache":   func() { b.handleLinkCache(ctx, msg) },
+		"linkcache":    func() { b.handleLinkCache(ctx, msg) },
+		"target":       func() { b.handleTarget(ctx, msg) },
+		"window":       func() { b.handleWindow(ctx, msg) },
+		"schedule":     func() { b.handleSchedule(ctx, msg) },
+		"language":     func() { b.handleLanguage(ctx, msg) },
+		CmdTone:        func() { b.handleTone(ctx, msg) },
+		CmdItemLinks:   func() { b.handleItemLinks(ctx, msg) },
+		CmdTOC:         func() { b.handleTOC(ctx, msg) },
+		CmdStale:       func() { b.handleStale(ctx, msg) },
+		CmdDiversity:   func() { b.handleDiversity(ctx, msg) },
+		CmdMMR:         func() { b.handleMMR(ctx, msg) },
+		CmdTierSort:    func() { b.handleTierSort(ctx, msg) },
+		CmdPrebuild:    func() { b.handlePrebuild(ctx, msg) },
+		CmdCover:       func() { b.handleCover(ctx, msg) },
+		CmdBotLanguage: func() { b.handleBotLanguage(ctx, msg) },
+		CmdVerbosity:   func() { b.handleVerbosity(ctx, msg) },
+		CmdFormat:      func() { b.handleFormat(ctx, msg) },
+		CmdAltText:     func() { b.handleAltText(ctx, msg) },
+		CmdSafety:      func() { b.handleSafety(ctx, msg) },
+		CmdRegions:     func() { b.handleRegions(ctx, msg) },
+		CmdShadow:      func() { b.handleShadow(ctx, msg) },
+		CmdStages:      func() { b.handleStages(ctx, msg) },
+		"relevance":    func() { b.handleThreshold(ctx, msg, SettingRelevanceThreshold) },
+		"importance":   func() { b.handleThreshold(ctx, msg, SettingImportanceThreshold) },
 		"discovery_min_seen": func() {
 			args := strings.Fields(msg.Text)
 			b.handleDiscoverMinSeen(ctx, msg, args)
@@ -231,7 +288,7 @@ func (b *Bot) handleAINamespace(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) == 0 {
-		b.reply(msg, `🤖 <b>AI Settings</b>
+		b.reply(msg, tr(ctx, `🤖 <b>AI Settings</b>
 
 <b>Features (on/off):</b>
 • <code>/ai editor on</code> - Editor-in-chief
@@ -240,11 +297,14 @@ func (b *Bot) handleAINamespace(ctx context.Context, msg *tgbotapi.Message) {
 • <code>/ai topics on</code> - Topic grouping
 • <code>/ai consolidated on</code> - Cluster consolidation
 • <code>/ai details on</code> - Detailed items
+• <code>/ai twopass on</code> - Two-pass summarization
+• <code>/ai sections</code> - Editor overview sections
 
 <b>Other:</b>
 • <code>/ai tone casual</code> - Set digest tone
 • <code>/ai dedup semantic</code> - Dedup mode (strict/semantic)
-• <code>/ai prompt list</code> - Manage prompts`)
+• <code>/ai ensemble</code> - Ensemble scoring
+• <code>/ai prompt list</code> - Manage prompts`))
 
 		return
 	}
@@ -253,16 +313,18 @@ func (b *Bot) handleAINamespace(ctx context.Context, msg *tgbotapi.Message) {
 	newMsg := prepareSubcommandMessage(msg, subcommand, args)
 
 	if !b.routeAISubcommand(ctx, &newMsg, subcommand) {
-		b.reply(msg, fmt.Sprintf("❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/ai</code> to see available AI settings.", html.EscapeString(subcommand)))
+		b.reply(msg, tr(ctx, "❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/ai</code> to see available AI settings.", html.EscapeString(subcommand)))
 	}
 }
 
 func (b *Bot) routeAISubcommand(ctx context.Context, msg *tgbotapi.Message, subcommand string) bool {
 	handlers := map[string]func(){
-		"prompt": func() { b.handlePrompt(ctx, msg) },
-		CmdTone:  func() { b.handleTone(ctx, msg) },
-		"topics": func() { b.handleTopics(ctx, msg) },
-		"dedup":  func() { b.handleDedup(ctx, msg) },
+		"prompt":    func() { b.handlePrompt(ctx, msg) },
+		CmdTone:     func() { b.handleTone(ctx, msg) },
+		"topics":    func() { b.handleTopics(ctx, msg) },
+		"dedup":     func() { b.handleDedup(ctx, msg) },
+		CmdSections: func() { b.handleEditorSections(ctx, msg) },
+		"ensemble":  func() { b.handleEnsemble(ctx, msg) },
 	}
 
 	if handler, ok := handlers[subcommand]; ok {
@@ -280,6 +342,7 @@ func (b *Bot) routeAISubcommand(ctx context.Context, msg *tgbotapi.Message, subc
 		"normalize":     "normalize_scores",
 		"details":       "editor_detailed_items",
 		"editordetails": "editor_detailed_items",
+		"twopass":       digest.SettingSummarizeTwoPass,
 	}
 
 	if settingKey, ok := toggleSettings[subcommand]; ok {
@@ -295,16 +358,17 @@ func (b *Bot) handleSystemNamespace(ctx context.Context, msg *tgbotapi.Message)
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) == 0 {
-		b.reply(msg, `🔧 <b>System Diagnostics</b>
+		b.reply(msg, tr(ctx, `🔧 <b>System Diagnostics</b>
 
 <b>Commands:</b>
 • <code>/system status</code> - System health dashboard
 • <code>/system settings</code> - Show all settings
 • <code>/system history</code> - Recent setting changes
+• <code>/system settings rollback &lt;key&gt; [steps|timestamp]</code> - Restore a setting from history
 • <code>/system errors</code> - Recent processing errors
 • <code>/system retry</code> - Retry failed items
 • <code>/system scores</code> - Item importance scores
-• <code>/system factcheck</code> - Fact check status`)
+• <code>/system factcheck</code> - Fact check status`))
 
 		return
 	}
@@ -328,7 +392,7 @@ func (b *Bot) handleSystemNamespace(ctx context.Context, msg *tgbotapi.Message)
 	case CmdFactCheck:
 		b.handleFactCheck(ctx, &newMsg)
 	default:
-		b.reply(msg, fmt.Sprintf("❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/system</code> to see available diagnostics.", html.EscapeString(subcommand)))
+		b.reply(msg, tr(ctx, "❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/system</code> to see available diagnostics.", html.EscapeString(subcommand)))
 	}
 }
 
@@ -336,28 +400,33 @@ func (b *Bot) handleTarget(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args == "" {
-		b.reply(msg, "Usage: <code>/target &lt;channel_id or @username&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/target &lt;channel_id or @username&gt;</code>"))
 
 		return
 	}
 
-	chatID, chat, errMsg := b.resolveTargetChat(args)
+	b.setTargetChat(ctx, msg, args)
+}
+
+// setTargetChat verifies and saves the digest target chat and reports whether it was set.
+func (b *Bot) setTargetChat(ctx context.Context, msg *tgbotapi.Message, args string) bool {
+	chatID, chat, errMsg := b.resolveTargetChat(ctx, args)
 	if errMsg != "" {
 		b.reply(msg, errMsg)
 
-		return
+		return false
 	}
 
-	if errMsg := b.verifyTargetChatPermissions(chatID, chat); errMsg != "" {
+	if errMsg := b.verifyTargetChatPermissions(ctx, chatID, chat, tr(ctx, "✅ This channel has been set as the target for digest posts.")); errMsg != "" {
 		b.reply(msg, errMsg)
 
-		return
+		return false
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, SettingTargetChatID, chatID, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving target chat ID: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving target chat ID: %s", html.EscapeString(err.Error())))
 
-		return
+		return false
 	}
 
 	// Clear any previous digest errors so the scheduler can retry with the new target
@@ -365,35 +434,37 @@ func (b *Bot) handleTarget(ctx context.Context, msg *tgbotapi.Message) {
 		b.logger.Warn().Err(err).Msg("failed to clear digest errors after target update")
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Target chat updated to <code>%d</code> (<b>%s</b>). A confirmation message has been sent to that channel.", chatID, html.EscapeString(chat.Title)))
+	b.reply(msg, tr(ctx, "✅ Target chat updated to <code>%d</code> (<b>%s</b>). A confirmation message has been sent to that channel.", chatID, html.EscapeString(chat.Title)))
+
+	return true
 }
 
-func (b *Bot) resolveTargetChat(args string) (int64, tgbotapi.Chat, string) {
+func (b *Bot) resolveTargetChat(ctx context.Context, args string) (int64, tgbotapi.Chat, string) {
 	if strings.HasPrefix(args, "@") {
-		return b.resolveTargetChatByUsername(args)
+		return b.resolveTargetChatByUsername(ctx, args)
 	}
 
-	return b.resolveTargetChatByID(args)
+	return b.resolveTargetChatByID(ctx, args)
 }
 
-func (b *Bot) resolveTargetChatByUsername(args string) (int64, tgbotapi.Chat, string) {
+func (b *Bot) resolveTargetChatByUsername(ctx context.Context, args string) (int64, tgbotapi.Chat, string) {
 	chat, err := b.api.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: tgbotapi.ChatConfig{SuperGroupUsername: args}})
 	if err != nil {
 		username := strings.TrimPrefix(args, "@")
 
 		chat, err = b.api.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: tgbotapi.ChatConfig{SuperGroupUsername: username}})
 		if err != nil {
-			return 0, tgbotapi.Chat{}, fmt.Sprintf("❌ Could not find chat %s: %s. Make sure the bot is an administrator in the channel.", html.EscapeString(args), html.EscapeString(err.Error()))
+			return 0, tgbotapi.Chat{}, tr(ctx, "❌ Could not find chat %s: %s. Make sure the bot is an administrator in the channel.", html.EscapeString(args), html.EscapeString(err.Error()))
 		}
 	}
 
 	return chat.ID, chat, ""
 }
 
-func (b *Bot) resolveTargetChatByID(args string) (int64, tgbotapi.Chat, string) {
+func (b *Bot) resolveTargetChatByID(ctx context.Context, args string) (int64, tgbotapi.Chat, string) {
 	chatID, err := strconv.ParseInt(args, 10, 64)
 	if err != nil {
-		return 0, tgbotapi.Chat{}, "❌ Invalid channel ID. It should be a number (don't forget the <code>-100</code> prefix for channels) or a <code>@username</code>."
+		return 0, tgbotapi.Chat{}, tr(ctx, "❌ Invalid channel ID. It should be a number (don't forget the <code>-100</code> prefix for channels) or a <code>@username</code>.")
 	}
 
 	chat, err := b.api.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: tgbotapi.ChatConfig{ChatID: chatID}})
@@ -402,24 +473,26 @@ func (b *Bot) resolveTargetChatByID(args string) (int64, tgbotapi.Chat, string)
 	}
 
 	if chatID <= 0 {
-		return 0, tgbotapi.Chat{}, fmt.Sprintf("❌ Could not find chat %d: %s. Make sure the bot is added to the chat.", chatID, html.EscapeString(err.Error()))
+		return 0, tgbotapi.Chat{}, tr(ctx, "❌ Could not find chat %d: %s. Make sure the bot is added to the chat.", chatID, html.EscapeString(err.Error()))
 	}
 
 	altID, _ := strconv.ParseInt("-100"+strconv.FormatInt(chatID, 10), 10, 64) //nolint:errcheck // concatenation always valid
 
 	chat, errAlt := b.api.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: tgbotapi.ChatConfig{ChatID: altID}})
 	if errAlt != nil {
-		return 0, tgbotapi.Chat{}, fmt.Sprintf("❌ Could not find chat %d (nor %d): %s. Make sure the bot is added to the chat.", chatID, altID, html.EscapeString(errAlt.Error()))
+		return 0, tgbotapi.Chat{}, tr(ctx, "❌ Could not find chat %d (nor %d): %s. Make sure the bot is added to the chat.", chatID, altID, html.EscapeString(errAlt.Error()))
 	}
 
 	return altID, chat, ""
 }
 
-func (b *Bot) verifyTargetChatPermissions(chatID int64, chat tgbotapi.Chat) string {
-	testMsg := tgbotapi.NewMessage(chatID, "✅ This channel has been set as the target for digest posts.")
+// verifyTargetChatPermissions sends the confirmation text to the chat to check
+// that the bot can post there.
+func (b *Bot) verifyTargetChatPermissions(ctx context.Context, chatID int64, chat tgbotapi.Chat, confirmation string) string {
+	testMsg := tgbotapi.NewMessage(chatID, confirmation)
 
-	if _, err := b.api.Send(testMsg); err != nil {
-		return fmt.Sprintf("❌ Found chat <b>%s</b> but could not send a message to it: %s. Make sure the bot is an administrator with permission to post messages.", html.EscapeString(chat.Title), html.EscapeString(err.Error()))
+	if _, err := b.sender.Send(testMsg); err != nil {
+		return tr(ctx, "❌ Found chat <b>%s</b> but could not send a message to it: %s. Make sure the bot is an administrator with permission to post messages.", html.EscapeString(chat.Title), html.EscapeString(err.Error()))
 	}
 
 	return ""
@@ -429,31 +502,25 @@ func (b *Bot) handleWindow(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args == "" {
-		b.reply(msg, "Usage: <code>/window &lt;duration&gt;</code> (e.g. <code>60m</code>, <code>6h</code>, <code>24h</code>)")
-
-		return
-	}
-
-	_, err := time.ParseDuration(args)
-	if err != nil {
-		b.reply(msg, "❌ Invalid duration format. Use something like <code>60m</code>, <code>6h</code>, <code>24h</code>.")
+		b.reply(msg, tr(ctx, "Usage: <code>/window &lt;duration&gt;</code> (e.g. <code>60m</code>, <code>6h</code>, <code>24h</code>)"))
 
 		return
 	}
 
-	if err := b.database.SaveSettingWithHistory(ctx, SettingDigestWindow, args, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving digest window: %s", html.EscapeString(err.Error())))
+	window, err := time.ParseDuration(args)
+	if err != nil || window <= 0 {
+		b.reply(msg, tr(ctx, "❌ Invalid duration format. Use something like <code>60m</code>, <code>6h</code>, <code>24h</code>."))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Digest window updated to <code>%s</code>.", html.EscapeString(args)))
+	b.previewWindowChange(ctx, msg, args, window)
 }
 
 func (b *Bot) handleSchedule(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
 	if len(args) == 0 {
-		b.reply(msg, "Usage: <code>/schedule timezone &lt;IANA&gt;</code> | <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule weekends hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule preview [count]</code> | <code>/schedule clear</code> | <code>/schedule show</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/schedule timezone &lt;IANA&gt;</code> | <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule weekends hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule preview [count]</code> | <code>/schedule clear</code> | <code>/schedule show</code>"))
 
 		return
 	}
@@ -472,13 +539,13 @@ func (b *Bot) handleSchedule(ctx context.Context, msg *tgbotapi.Message) {
 	case SubCmdWeekdays, SubCmdWeekends:
 		b.handleScheduleDayGroup(ctx, msg, subcommand, args)
 	default:
-		b.reply(msg, fmt.Sprintf("❓ Unknown subcommand: <code>%s</code>\n\n💡 Use <code>/schedule show</code> to see current schedule, or <code>weekdays</code>, <code>weekends</code>, <code>timezone</code>.", html.EscapeString(subcommand)))
+		b.reply(msg, tr(ctx, "❓ Unknown subcommand: <code>%s</code>\n\n💡 Use <code>/schedule show</code> to see current schedule, or <code>weekdays</code>, <code>weekends</code>, <code>timezone</code>.", html.EscapeString(subcommand)))
 	}
 }
 
 func (b *Bot) handleScheduleTimezone(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/schedule timezone &lt;IANA&gt;</code> (e.g. <code>Europe/Kyiv</code>)")
+		b.reply(msg, tr(ctx, "Usage: <code>/schedule timezone &lt;IANA&gt;</code> (e.g. <code>Europe/Kyiv</code>)"))
 
 		return
 	}
@@ -491,7 +558,7 @@ func (b *Bot) handleScheduleTimezone(ctx context.Context, msg *tgbotapi.Message,
 
 func (b *Bot) handleScheduleDayGroup(ctx context.Context, msg *tgbotapi.Message, dayTarget string, args []string) {
 	if len(args) < 3 {
-		b.reply(msg, "Usage: <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code>"))
 
 		return
 	}
@@ -514,13 +581,13 @@ func (b *Bot) handleScheduleDayGroup(ctx context.Context, msg *tgbotapi.Message,
 	case SubCmdHourly:
 		b.handleScheduleHourly(ctx, msg, day, value, &sched)
 	default:
-		b.reply(msg, "❌ Unknown schedule mode. Use <code>times</code> or <code>hourly</code>.")
+		b.reply(msg, tr(ctx, "❌ Unknown schedule mode. Use <code>times</code> or <code>hourly</code>."))
 	}
 }
 
 func (b *Bot) handleScheduleClear(ctx context.Context, msg *tgbotapi.Message) {
 	if err := b.database.DeleteSettingWithHistory(ctx, schedule.SettingDigestSchedule, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error clearing schedule: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error clearing schedule: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -529,20 +596,20 @@ func (b *Bot) handleScheduleClear(ctx context.Context, msg *tgbotapi.Message) {
 		b.logger.Debug().Err(err).Msg("failed to clear digest_schedule_anchor")
 	}
 
-	b.reply(msg, "✅ Digest schedule cleared. Configure a new schedule with <code>/schedule set</code>.")
+	b.reply(msg, tr(ctx, "✅ Digest schedule cleared. Configure a new schedule with <code>/schedule set</code>."))
 }
 
 func (b *Bot) handleSchedulePreview(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	count, err := parseSchedulePreviewCount(args)
 	if err != nil {
-		b.reply(msg, "Usage: <code>/schedule preview [count]</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/schedule preview [count]</code>"))
 
 		return
 	}
 
 	message, err := b.formatSchedulePreview(ctx, count)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error computing schedule preview: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error computing schedule preview: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -570,16 +637,16 @@ func parseSchedulePreviewCount(args []string) (int, error) {
 func (b *Bot) formatSchedulePreview(ctx context.Context, count int) (string, error) {
 	sched := b.loadDigestSchedule(ctx)
 	if sched.IsEmpty() {
-		return "ℹ️ No digest schedule configured.", nil
+		return tr(ctx, "ℹ️ No digest schedule configured."), nil
 	}
 
 	if err := sched.Validate(); err != nil {
-		return fmt.Sprintf(errInvalidScheduleFmt, html.EscapeString(err.Error())), nil
+		return tr(ctx, errInvalidScheduleFmt, html.EscapeString(err.Error())), nil
 	}
 
 	loc, err := sched.Location()
 	if err != nil {
-		return fmt.Sprintf("❌ Invalid timezone: %s", html.EscapeString(err.Error())), nil
+		return tr(ctx, "❌ Invalid timezone: %s", html.EscapeString(err.Error())), nil
 	}
 
 	times, err := sched.NextTimes(time.Now(), count)
@@ -588,7 +655,7 @@ func (b *Bot) formatSchedulePreview(ctx context.Context, count int) (string, err
 	}
 
 	if len(times) == 0 {
-		return "ℹ️ No upcoming scheduled times found.", nil
+		return tr(ctx, "ℹ️ No upcoming scheduled times found."), nil
 	}
 
 	var sb strings.Builder
@@ -613,12 +680,12 @@ func (b *Bot) handleScheduleTimes(ctx context.Context, msg *tgbotapi.Message, da
 
 	times, err := parseScheduleTimes(value)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Invalid time: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Invalid time: %s", html.EscapeString(err.Error())))
 		return
 	}
 
 	if len(times) == 0 {
-		b.reply(msg, "❌ Provide a list of times, e.g. <code>09:00,13:00,18:00</code>.")
+		b.reply(msg, tr(ctx, "❌ Provide a list of times, e.g. <code>09:00,13:00,18:00</code>."))
 
 		return
 	}
@@ -639,7 +706,7 @@ func (b *Bot) handleScheduleHourly(ctx context.Context, msg *tgbotapi.Message, d
 
 	start, end, err := parseHourlyRange(value)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Invalid hourly range: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Invalid hourly range: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -662,12 +729,12 @@ func (b *Bot) saveDigestSchedule(ctx context.Context, msg *tgbotapi.Message, sch
 	sched.Timezone = schedule.NormalizeTimezone(sched.Timezone)
 
 	if err := sched.Validate(); err != nil {
-		b.reply(msg, fmt.Sprintf(errInvalidScheduleFmt, html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, errInvalidScheduleFmt, html.EscapeString(err.Error())))
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, schedule.SettingDigestSchedule, sched, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving schedule: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving schedule: %s", html.EscapeString(err.Error())))
 		return
 	}
 
@@ -675,7 +742,7 @@ func (b *Bot) saveDigestSchedule(ctx context.Context, msg *tgbotapi.Message, sch
 		b.logger.Debug().Err(err).Msg("failed to save digest_schedule_anchor")
 	}
 
-	b.reply(msg, "✅ Digest schedule updated.")
+	b.reply(msg, tr(ctx, "✅ Digest schedule updated."))
 }
 
 func (b *Bot) formatDigestSchedule(ctx context.Context) string {
@@ -784,25 +851,25 @@ func (b *Bot) handleLanguage(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args == "" {
-		b.reply(msg, "Usage: <code>/language &lt;lang_code&gt;</code> (e.g. <code>en</code>, <code>ru</code>, <code>de</code>)")
+		b.reply(msg, tr(ctx, "Usage: <code>/language &lt;lang_code&gt;</code> (e.g. <code>en</code>, <code>ru</code>, <code>de</code>)"))
 
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, "digest_language", args, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving digest language: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving digest language: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Digest language updated to <code>%s</code>.", html.EscapeString(args)))
+	b.reply(msg, tr(ctx, "✅ Digest language updated to <code>%s</code>.", html.EscapeString(args)))
 }
 
 func (b *Bot) handleMinLength(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args == "" {
-		b.reply(msg, "Usage: <code>/minlength &lt;number&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/minlength &lt;number&gt;</code>"))
 
 		return
 	}
@@ -810,25 +877,49 @@ func (b *Bot) handleMinLength(ctx context.Context, msg *tgbotapi.Message) {
 	val, err := strconv.Atoi(args)
 
 	if err != nil || val < 0 {
-		b.reply(msg, "❌ Invalid value. Please provide a positive number.")
+		b.reply(msg, tr(ctx, "❌ Invalid value. Please provide a positive number."))
 
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, "filters_min_length", val, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving min length: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving min length: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, tr(ctx, "✅ Minimum message length updated to <code>%d</code>.", val))
+}
+
+func (b *Bot) handleMediaFilter(ctx context.Context, msg *tgbotapi.Message) {
+	mode := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	if mode == "" {
+		b.reply(msg, tr(ctx, "Usage: <code>/filter media &lt;off|downweight|drop&gt;</code>\n\nImage-only stickers and memes are detected before summarization and either kept, downweighted or dropped."))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Minimum message length updated to <code>%d</code>.", val))
+	if mode != ToggleOff && mode != MediaFilterDownweight && mode != MediaFilterDrop {
+		b.reply(msg, tr(ctx, "❌ Invalid mode. Use <code>off</code>, <code>downweight</code> or <code>drop</code>."))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, SettingFiltersMedia, mode, msg.From.ID); err != nil {
+		b.reply(msg, tr(ctx, "❌ Error saving media filter: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, tr(ctx, "✅ Media filter set to <code>%s</code>.", mode))
 }
 
 func (b *Bot) handleMaxLinks(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args == "" {
-		b.reply(msg, "Usage: <code>/max_links &lt;1-5&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/max_links &lt;1-5&gt;</code>"))
 
 		return
 	}
@@ -836,25 +927,25 @@ func (b *Bot) handleMaxLinks(ctx context.Context, msg *tgbotapi.Message) {
 	val, err := strconv.Atoi(args)
 
 	if err != nil || val < 1 || val > 5 {
-		b.reply(msg, "❌ Invalid value. Please provide a number between 1 and 5.")
+		b.reply(msg, tr(ctx, "❌ Invalid value. Please provide a number between 1 and 5."))
 
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, "max_links_per_message", val, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving max links: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving max links: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Max links per message updated to <code>%d</code>.", val))
+	b.reply(msg, tr(ctx, "✅ Max links per message updated to <code>%d</code>.", val))
 }
 
 func (b *Bot) handleLinkCache(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args == "" {
-		b.reply(msg, "Usage: <code>/link_cache &lt;duration&gt;</code> (e.g. <code>12h</code>, <code>24h</code>, <code>7d</code>)")
+		b.reply(msg, tr(ctx, "Usage: <code>/link_cache &lt;duration&gt;</code> (e.g. <code>12h</code>, <code>24h</code>, <code>7d</code>)"))
 
 		return
 	}
@@ -870,18 +961,18 @@ func (b *Bot) handleLinkCache(ctx context.Context, msg *tgbotapi.Message) {
 
 	_, err := time.ParseDuration(durationStr)
 	if err != nil {
-		b.reply(msg, "❌ Invalid duration format. Use something like <code>12h</code>, <code>24h</code>.")
+		b.reply(msg, tr(ctx, "❌ Invalid duration format. Use something like <code>12h</code>, <code>24h</code>."))
 
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, "link_cache_ttl", args, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving link cache TTL: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving link cache TTL: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Link cache TTL updated to <code>%s</code>.", html.EscapeString(args)))
+	b.reply(msg, tr(ctx, "✅ Link cache TTL updated to <code>%s</code>.", html.EscapeString(args)))
 }
 
 func (b *Bot) handleAdsKeywords(ctx context.Context, msg *tgbotapi.Message) {
@@ -889,29 +980,29 @@ func (b *Bot) handleAdsKeywords(ctx context.Context, msg *tgbotapi.Message) {
 
 	keywords, err := b.getAdsKeywords(ctx)
 	if err != nil {
-		b.reply(msg, ErrFetchingAdsKeywords)
+		b.reply(msg, tr(ctx, ErrFetchingAdsKeywords))
 
 		return
 	}
 
 	if len(args) == 0 {
-		b.reply(msg, fmt.Sprintf("📋 <b>Ads Keywords:</b>\n<code>%s</code>\n\nUsage: <code>/adskeywords add &lt;word&gt;</code> or <code>/adskeywords remove &lt;word&gt;</code> or <code>/adskeywords clear</code>", html.EscapeString(strings.Join(keywords, ", "))))
+		b.reply(msg, tr(ctx, "📋 <b>Ads Keywords:</b>\n<code>%s</code>\n\nUsage: <code>/adskeywords add &lt;word&gt;</code> or <code>/adskeywords remove &lt;word&gt;</code> or <code>/adskeywords clear</code>", html.EscapeString(strings.Join(keywords, ", "))))
 
 		return
 	}
 
-	newKeywords, ok := b.processAdsKeywordAction(msg, args, keywords)
+	newKeywords, ok := b.processAdsKeywordAction(ctx, msg, args, keywords)
 	if !ok {
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, SettingFiltersAdsKeywords, newKeywords, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving ads keywords: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving ads keywords: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Ads keywords updated. Total: <code>%d</code>", len(newKeywords)))
+	b.reply(msg, tr(ctx, "✅ Ads keywords updated. Total: <code>%d</code>", len(newKeywords)))
 }
 
 func (b *Bot) getAdsKeywords(ctx context.Context) ([]string, error) {
@@ -928,24 +1019,24 @@ func (b *Bot) getAdsKeywords(ctx context.Context) ([]string, error) {
 	return keywords, nil
 }
 
-func (b *Bot) processAdsKeywordAction(msg *tgbotapi.Message, args []string, keywords []string) ([]string, bool) {
+func (b *Bot) processAdsKeywordAction(ctx context.Context, msg *tgbotapi.Message, args []string, keywords []string) ([]string, bool) {
 	switch args[0] {
 	case CmdAdd:
-		return b.addAdsKeyword(msg, args, keywords)
+		return b.addAdsKeyword(ctx, msg, args, keywords)
 	case CmdRemove:
-		return b.removeAdsKeyword(msg, args, keywords)
+		return b.removeAdsKeyword(ctx, msg, args, keywords)
 	case SubCmdClear:
 		return []string{}, true
 	default:
-		b.reply(msg, "❓ Unknown command. Use <code>add</code>, <code>remove</code>, <code>clear</code> or no arguments to list.")
+		b.reply(msg, tr(ctx, "❓ Unknown command. Use <code>add</code>, <code>remove</code>, <code>clear</code> or no arguments to list."))
 
 		return nil, false
 	}
 }
 
-func (b *Bot) addAdsKeyword(msg *tgbotapi.Message, args []string, keywords []string) ([]string, bool) {
+func (b *Bot) addAdsKeyword(ctx context.Context, msg *tgbotapi.Message, args []string, keywords []string) ([]string, bool) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/adskeywords add &lt;word&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/adskeywords add &lt;word&gt;</code>"))
 
 		return nil, false
 	}
@@ -954,7 +1045,7 @@ func (b *Bot) addAdsKeyword(msg *tgbotapi.Message, args []string, keywords []str
 
 	for _, k := range keywords {
 		if k == word {
-			b.reply(msg, errKeywordAlreadyExists)
+			b.reply(msg, tr(ctx, errKeywordAlreadyExists))
 
 			return nil, false
 		}
@@ -963,9 +1054,9 @@ func (b *Bot) addAdsKeyword(msg *tgbotapi.Message, args []string, keywords []str
 	return append(keywords, word), true
 }
 
-func (b *Bot) removeAdsKeyword(msg *tgbotapi.Message, args []string, keywords []string) ([]string, bool) {
+func (b *Bot) removeAdsKeyword(ctx context.Context, msg *tgbotapi.Message, args []string, keywords []string) ([]string, bool) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/adskeywords remove &lt;word&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/adskeywords remove &lt;word&gt;</code>"))
 
 		return nil, false
 	}
@@ -983,7 +1074,7 @@ func (b *Bot) removeAdsKeyword(msg *tgbotapi.Message, args []string, keywords []
 	}
 
 	if !found {
-		b.reply(msg, errKeywordNotFound)
+		b.reply(msg, tr(ctx, errKeywordNotFound))
 
 		return nil, false
 	}
@@ -991,32 +1082,6 @@ func (b *Bot) removeAdsKeyword(msg *tgbotapi.Message, args []string, keywords []
 	return newKeywords, true
 }
 
-func (b *Bot) handleListChannels(ctx context.Context, msg *tgbotapi.Message) {
-	channels, err := b.database.GetActiveChannels(ctx)
-	if err != nil {
-		b.reply(msg, fmt.Sprintf(ErrFetchingChannelsFmt, html.EscapeString(err.Error())))
-
-		return
-	}
-
-	if len(channels) == 0 {
-		b.reply(msg, "No active channels tracked.")
-
-		return
-	}
-
-	var sb strings.Builder
-
-	sb.WriteString("📋 <b>Active Tracked Channels:</b>\n\n")
-
-	for _, ch := range channels {
-		formatChannelEntry(&sb, ch)
-	}
-
-	sb.WriteString("\n💡 <i>Use <code>/channel weight</code> or <code>/channel relevance</code> to manage channel quality controls.</i>")
-	b.reply(msg, sb.String())
-}
-
 func formatChannelEntry(sb *strings.Builder, ch db.Channel) {
 	identifier := fmt.Sprintf("@%s", html.EscapeString(ch.Username))
 	if ch.Username == "" {
@@ -1084,14 +1149,14 @@ func formatChannelMetadata(sb *strings.Builder, ch db.Channel) {
 func (b *Bot) handleChannelStats(ctx context.Context, msg *tgbotapi.Message) {
 	stats, err := b.database.GetChannelStats(ctx)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching channel stats: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching channel stats: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	channels, err := b.database.GetActiveChannels(ctx)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf(ErrFetchingChannelsFmt, html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, ErrFetchingChannelsFmt, html.EscapeString(err.Error())))
 
 		return
 	}
@@ -1102,13 +1167,19 @@ func (b *Bot) handleChannelStats(ctx context.Context, msg *tgbotapi.Message) {
 	}
 
 	if len(stats) == 0 {
-		b.reply(msg, "No stats available yet. Statistics are calculated over the last 7 days.")
+		b.reply(msg, tr(ctx, "No stats available yet. Statistics are calculated over the last 7 days."))
 
 		return
 	}
 
 	var sb strings.Builder
 
+	groupStats, err := b.database.GetChannelGroupStats(ctx, time.Now().Add(-channelGroupStatsPeriod))
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to get channel group stats")
+	}
+
+	sb.WriteString(formatChannelGroupStats(ctx, groupStats))
 	sb.WriteString("📈 <b>Channel Quality Metrics (Last 7 Days)</b>\n\n")
 
 	for id, s := range stats {
@@ -1140,6 +1211,11 @@ func (b *Bot) handleRatings(ctx context.Context, msg *tgbotapi.Message) {
 		return
 	}
 
+	if len(args) > 0 && strings.EqualFold(args[0], "clicks") {
+		b.handleRatingsClicks(ctx, msg, args[1:])
+		return
+	}
+
 	b.handleRatingsSummary(ctx, msg, args)
 }
 
@@ -1149,13 +1225,13 @@ func (b *Bot) handleRatingsSummary(ctx context.Context, msg *tgbotapi.Message, a
 
 	summaries, err := b.database.GetItemRatingSummary(ctx, since)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching ratings: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching ratings: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	if len(summaries) == 0 {
-		b.reply(msg, fmt.Sprintf("No item ratings in the last %d days.", days))
+		b.reply(msg, tr(ctx, "No item ratings in the last %d days.", days))
 
 		return
 	}
@@ -1198,7 +1274,7 @@ func (b *Bot) handleRatingsStats(ctx context.Context, msg *tgbotapi.Message, arg
 		if v, err := strconv.Atoi(args[0]); err == nil && v > 0 {
 			limit = v
 		} else {
-			b.reply(msg, "Usage: <code>/ratings stats [limit]</code>")
+			b.reply(msg, tr(ctx, "Usage: <code>/ratings stats [limit]</code>"))
 
 			return
 		}
@@ -1206,20 +1282,20 @@ func (b *Bot) handleRatingsStats(ctx context.Context, msg *tgbotapi.Message, arg
 
 	entries, err := b.database.GetLatestChannelRatingStats(ctx, limit)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching rating stats: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching rating stats: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	if len(entries) == 0 {
-		b.reply(msg, "No aggregated rating stats yet. The weekly job updates these automatically.")
+		b.reply(msg, tr(ctx, "No aggregated rating stats yet. The weekly job updates these automatically."))
 
 		return
 	}
 
 	global, err := b.database.GetLatestGlobalRatingStats(ctx)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching global rating stats: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching global rating stats: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -1286,15 +1362,14 @@ func formatGlobalStats(sb *strings.Builder, global *db.GlobalRatingStats) {
 
 func (b *Bot) handleScores(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
-	if len(args) > 0 && strings.EqualFold(args[0], "debug") {
-		b.handleScoresDebug(ctx, msg, args[1:])
+	if b.routeScoresSubcommand(ctx, msg, args) {
 		return
 	}
 
 	hours, limit := parseScoresArgs(args)
 
 	if hours <= 0 || limit <= 0 {
-		b.reply(msg, "Usage: <code>/scores [hours] [limit]</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/scores [hours] [limit]</code>"))
 
 		return
 	}
@@ -1308,20 +1383,20 @@ func (b *Bot) handleScores(ctx context.Context, msg *tgbotapi.Message) {
 
 	stats, err := b.database.GetImportanceStats(ctx, since, importanceThreshold)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching scores: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching scores: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	if stats.Total == 0 {
-		b.reply(msg, fmt.Sprintf("No ready items in the last %d hours.", hours))
+		b.reply(msg, tr(ctx, "No ready items in the last %d hours.", hours))
 
 		return
 	}
 
 	items, err := b.database.GetTopItemScores(ctx, since, limit)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching items: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching items: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -1329,6 +1404,28 @@ func (b *Bot) handleScores(ctx context.Context, msg *tgbotapi.Message) {
 	b.reply(msg, formatScoresOutput(hours, importanceThreshold, &stats, items))
 }
 
+// routeScoresSubcommand handles /scores subcommands and reports whether args named one.
+func (b *Bot) routeScoresSubcommand(ctx context.Context, msg *tgbotapi.Message, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "debug":
+		b.handleScoresDebug(ctx, msg, args[1:])
+	case scoresSubCmdVersions:
+		b.handleScoresVersions(ctx, msg)
+	case scoresSubCmdReference:
+		b.handleScoresReference(ctx, msg, args[1:])
+	case scoresSubCmdNormalize:
+		b.handleScoresNormalize(ctx, msg, args[1:])
+	default:
+		return false
+	}
+
+	return true
+}
+
 func (b *Bot) handleScoresDebug(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) > 0 && strings.EqualFold(args[0], "reasons") {
 		b.handleScoresDebugReasons(ctx, msg, args[1:])
@@ -1337,7 +1434,7 @@ func (b *Bot) handleScoresDebug(ctx context.Context, msg *tgbotapi.Message, args
 
 	hours, valid := parseScoresDebugArgs(args)
 	if !valid {
-		b.reply(msg, MsgScoresDebugUsage)
+		b.reply(msg, tr(ctx, MsgScoresDebugUsage))
 
 		return
 	}
@@ -1346,20 +1443,20 @@ func (b *Bot) handleScoresDebug(ctx context.Context, msg *tgbotapi.Message, args
 
 	debugStats, err := b.database.GetScoreDebugStats(ctx, since)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching score stats: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching score stats: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	itemStats, err := b.database.GetItemStatusStats(ctx, since)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching item stats: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching item stats: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	if debugStats.RawTotal == 0 && itemStats.Total == 0 {
-		b.reply(msg, fmt.Sprintf("No messages in the last %d hours.", hours))
+		b.reply(msg, tr(ctx, "No messages in the last %d hours.", hours))
 
 		return
 	}
@@ -1367,45 +1464,6 @@ func (b *Bot) handleScoresDebug(ctx context.Context, msg *tgbotapi.Message, args
 	b.reply(msg, formatScoresDebugOutput(hours, debugStats, itemStats))
 }
 
-func (b *Bot) handleScoresDebugReasons(ctx context.Context, msg *tgbotapi.Message, args []string) {
-	hours, valid := parseScoresDebugArgs(args)
-	if !valid {
-		b.reply(msg, MsgScoresDebugReasonsUsage)
-
-		return
-	}
-
-	since := time.Now().Add(-time.Duration(hours) * time.Hour)
-
-	reasons, err := b.database.GetDropReasonStats(ctx, since, DefaultScoresLimit)
-	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching drop reasons: %s", html.EscapeString(err.Error())))
-
-		return
-	}
-
-	if len(reasons) == 0 {
-		b.reply(msg, fmt.Sprintf("No drop reasons logged in the last %d hours.", hours))
-
-		return
-	}
-
-	var sb strings.Builder
-
-	total := 0
-
-	sb.WriteString(fmt.Sprintf("📊 <b>Drop Reasons (last %d hours)</b>\n\n", hours))
-
-	for _, entry := range reasons {
-		sb.WriteString(fmt.Sprintf(statsItemFormat, html.EscapeString(entry.Reason), entry.Count))
-		total += entry.Count
-	}
-
-	sb.WriteString(fmt.Sprintf("\nTotal logged: <code>%d</code>\n", total))
-
-	b.reply(msg, sb.String())
-}
-
 func parseScoresDebugArgs(args []string) (int, bool) {
 	if len(args) == 0 {
 		return DefaultScoresHours, true
@@ -1577,7 +1635,7 @@ func formatRatingsChannelName(channelID, username, title string) string {
 func (b *Bot) handlePrompt(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
 	if len(args) == 0 {
-		b.replyPromptUsage(msg)
+		b.replyPromptUsage(ctx, msg)
 
 		return
 	}
@@ -1591,17 +1649,20 @@ func (b *Bot) handlePrompt(ctx context.Context, msg *tgbotapi.Message) {
 		b.handlePromptSet(ctx, msg, args)
 	case "activate", "active":
 		b.handlePromptActivate(ctx, msg, args)
+	case SubCmdRollback:
+		b.handlePromptRollback(ctx, msg, args)
 	default:
-		b.replyPromptUsage(msg)
+		b.replyPromptUsage(ctx, msg)
 	}
 }
 
-func (b *Bot) replyPromptUsage(msg *tgbotapi.Message) {
-	b.reply(msg, "Usage:\n"+
+func (b *Bot) replyPromptUsage(ctx context.Context, msg *tgbotapi.Message) {
+	b.reply(msg, tr(ctx, "Usage:\n"+
 		"<code>/prompt list</code>\n"+
 		"<code>/prompt show &lt;summarize|narrative|cluster_summary|cluster_topic|relevance_gate&gt; [version]</code>\n"+
 		"<code>/prompt set &lt;base&gt; &lt;version&gt; &lt;text...&gt;</code>\n"+
-		"<code>/prompt activate &lt;base&gt; &lt;version&gt;</code>")
+		"<code>/prompt activate &lt;base&gt; &lt;version&gt;</code>\n"+
+		"<code>/prompt rollback &lt;base&gt; [steps|timestamp]</code>"))
 }
 
 func (b *Bot) isValidPromptBase(v string) bool {
@@ -1631,14 +1692,14 @@ func (b *Bot) handlePromptList(ctx context.Context, msg *tgbotapi.Message) {
 
 func (b *Bot) handlePromptShow(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/prompt show &lt;base&gt; [version]</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/prompt show &lt;base&gt; [version]</code>"))
 
 		return
 	}
 
 	baseName := strings.ToLower(args[1])
 	if !b.isValidPromptBase(baseName) {
-		b.reply(msg, fmt.Sprintf(ErrUnknownBaseFmt, html.EscapeString(strings.Join(promptBases, ", "))))
+		b.reply(msg, tr(ctx, ErrUnknownBaseFmt, html.EscapeString(strings.Join(promptBases, ", "))))
 
 		return
 	}
@@ -1661,26 +1722,26 @@ func (b *Bot) handlePromptShow(ctx context.Context, msg *tgbotapi.Message, args
 
 	_ = b.database.GetSetting(ctx, promptKey, &prompt) //nolint:errcheck // best-effort read
 	if prompt == "" {
-		b.reply(msg, fmt.Sprintf("No override found for <code>%s</code> (version <code>%s</code>). Using built-in default.", html.EscapeString(baseName), html.EscapeString(version)))
+		b.reply(msg, tr(ctx, "No override found for <code>%s</code> (version <code>%s</code>). Using built-in default.", html.EscapeString(baseName), html.EscapeString(version)))
 
 		return
 	}
 
 	escaped := html.EscapeString(prompt)
 
-	b.reply(msg, fmt.Sprintf("Prompt <b>%s</b> (<code>%s</code>):\n<pre>%s</pre>", html.EscapeString(baseName), html.EscapeString(version), escaped))
+	b.reply(msg, tr(ctx, "Prompt <b>%s</b> (<code>%s</code>):\n<pre>%s</pre>", html.EscapeString(baseName), html.EscapeString(version), escaped))
 }
 
 func (b *Bot) handlePromptSet(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 4 {
-		b.reply(msg, "Usage: <code>/prompt set &lt;base&gt; &lt;version&gt; &lt;text...&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/prompt set &lt;base&gt; &lt;version&gt; &lt;text...&gt;</code>"))
 
 		return
 	}
 
 	baseName := strings.ToLower(args[1])
 	if !b.isValidPromptBase(baseName) {
-		b.reply(msg, fmt.Sprintf(ErrUnknownBaseFmt, html.EscapeString(strings.Join(promptBases, ", "))))
+		b.reply(msg, tr(ctx, ErrUnknownBaseFmt, html.EscapeString(strings.Join(promptBases, ", "))))
 
 		return
 	}
@@ -1690,24 +1751,24 @@ func (b *Bot) handlePromptSet(ctx context.Context, msg *tgbotapi.Message, args [
 
 	key := fmt.Sprintf(PromptKeyFmt, baseName, version)
 	if err := b.database.SaveSettingWithHistory(ctx, key, text, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving prompt: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving prompt: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Prompt <b>%s</b> saved as <code>%s</code>.", html.EscapeString(baseName), html.EscapeString(version)))
+	b.reply(msg, tr(ctx, "✅ Prompt <b>%s</b> saved as <code>%s</code>.", html.EscapeString(baseName), html.EscapeString(version)))
 }
 
 func (b *Bot) handlePromptActivate(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 3 {
-		b.reply(msg, "Usage: <code>/prompt activate &lt;base&gt; &lt;version&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/prompt activate &lt;base&gt; &lt;version&gt;</code>"))
 
 		return
 	}
 
 	baseName := strings.ToLower(args[1])
 	if !b.isValidPromptBase(baseName) {
-		b.reply(msg, fmt.Sprintf(ErrUnknownBaseFmt, html.EscapeString(strings.Join(promptBases, ", "))))
+		b.reply(msg, tr(ctx, ErrUnknownBaseFmt, html.EscapeString(strings.Join(promptBases, ", "))))
 
 		return
 	}
@@ -1716,12 +1777,12 @@ func (b *Bot) handlePromptActivate(ctx context.Context, msg *tgbotapi.Message, a
 
 	key := fmt.Sprintf(PromptActiveKeyFmt, baseName)
 	if err := b.database.SaveSettingWithHistory(ctx, key, version, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving active version: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving active version: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Active prompt for <b>%s</b> set to <code>%s</code>.", html.EscapeString(baseName), html.EscapeString(version)))
+	b.reply(msg, tr(ctx, "✅ Active prompt for <b>%s</b> set to <code>%s</code>.", html.EscapeString(baseName), html.EscapeString(version)))
 }
 
 func (b *Bot) handleChannelWeight(ctx context.Context, msg *tgbotapi.Message) {
@@ -1729,11 +1790,11 @@ func (b *Bot) handleChannelWeight(ctx context.Context, msg *tgbotapi.Message) {
 
 	// No args - show usage
 	if len(args) == 0 {
-		b.reply(msg, "Usage:\n"+
+		b.reply(msg, tr(ctx, "Usage:\n"+
 			"<code>/channel weight @username</code> - Show current weight\n"+
 			"<code>/channel weight @username 1.5</code> - Set weight (0.1-2.0)\n"+
 			"<code>/channel weight @username auto</code> - Enable auto-calculation\n"+
-			"<code>/channel weight @username 1.5 reason text</code> - Set weight with reason")
+			"<code>/channel weight @username 1.5 reason text</code> - Set weight with reason"))
 
 		return
 	}
@@ -1742,7 +1803,7 @@ func (b *Bot) handleChannelWeight(ctx context.Context, msg *tgbotapi.Message) {
 
 	// Check if user forgot to specify channel (e.g., "/channel weight auto" instead of "/channel weight @chan auto")
 	if len(args) == 1 && (identifier == SubCmdAuto || isNumericWeight(identifier)) {
-		b.reply(msg, "Missing channel identifier.\nUsage: <code>/channel weight @username</code> or <code>/channel weight @username 1.5</code>")
+		b.reply(msg, tr(ctx, "Missing channel identifier.\nUsage: <code>/channel weight @username</code> or <code>/channel weight @username 1.5</code>"))
 
 		return
 	}
@@ -1763,9 +1824,9 @@ func (b *Bot) showChannelWeight(ctx context.Context, msg *tgbotapi.Message, iden
 	weight, err := b.database.GetChannelWeight(ctx, identifier)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrNoRows) {
-			b.reply(msg, fmt.Sprintf("Channel <code>@%s</code> not found.", html.EscapeString(identifier)))
+			b.reply(msg, tr(ctx, "Channel <code>@%s</code> not found.", html.EscapeString(identifier)))
 		} else {
-			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+			b.reply(msg, tr(ctx, ErrGenericFmt, html.EscapeString(err.Error())))
 		}
 
 		return
@@ -1813,7 +1874,7 @@ func (b *Bot) setChannelWeight(ctx context.Context, msg *tgbotapi.Message, ident
 
 	weight, err := strconv.ParseFloat(weightArg, 32)
 	if err != nil || weight < 0.1 || weight > 2.0 {
-		b.reply(msg, "Invalid weight. Use a number between 0.1 and 2.0, or 'auto' to reset to default.")
+		b.reply(msg, tr(ctx, "Invalid weight. Use a number between 0.1 and 2.0, or 'auto' to reset to default."))
 
 		return
 	}
@@ -1826,7 +1887,7 @@ func (b *Bot) setChannelWeight(ctx context.Context, msg *tgbotapi.Message, ident
 	// Manual weight: autoEnabled=false, override=true
 	result, err := b.database.UpdateChannelWeight(ctx, identifier, float32(weight), false, true, reason, msg.From.ID)
 	if err != nil {
-		b.replyChannelUpdateError(msg, err, identifier)
+		b.replyChannelUpdateError(ctx, msg, err, identifier)
 
 		return
 	}
@@ -1845,21 +1906,21 @@ func (b *Bot) setChannelWeight(ctx context.Context, msg *tgbotapi.Message, ident
 func (b *Bot) enableAutoWeight(ctx context.Context, msg *tgbotapi.Message, identifier string) {
 	result, err := b.database.UpdateChannelWeight(ctx, identifier, 1.0, true, false, "", msg.From.ID)
 	if err != nil {
-		b.replyChannelUpdateError(msg, err, identifier)
+		b.replyChannelUpdateError(ctx, msg, err, identifier)
 
 		return
 	}
 
 	chanDisplay := formatChannelDisplay(result.Username, result.Title, identifier)
-	b.reply(msg, fmt.Sprintf("Auto-weight enabled for %s. Weight reset to 1.0.", chanDisplay))
+	b.reply(msg, tr(ctx, "Auto-weight enabled for %s. Weight reset to 1.0.", chanDisplay))
 }
 
 // replyChannelUpdateError sends an appropriate error message for channel update failures.
-func (b *Bot) replyChannelUpdateError(msg *tgbotapi.Message, err error, identifier string) {
+func (b *Bot) replyChannelUpdateError(ctx context.Context, msg *tgbotapi.Message, err error, identifier string) {
 	if strings.Contains(err.Error(), ErrNoRows) {
-		b.reply(msg, fmt.Sprintf(ErrChannelNotFoundFmt, html.EscapeString(identifier)))
+		b.reply(msg, tr(ctx, ErrChannelNotFoundFmt, html.EscapeString(identifier)))
 	} else {
-		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, ErrGenericFmt, html.EscapeString(err.Error())))
 	}
 }
 
@@ -1867,7 +1928,7 @@ func (b *Bot) handleChannelRelevance(ctx context.Context, msg *tgbotapi.Message)
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) == 0 {
-		b.replyChannelRelevanceUsage(msg)
+		b.replyChannelRelevanceUsage(ctx, msg)
 
 		return
 	}
@@ -1875,7 +1936,7 @@ func (b *Bot) handleChannelRelevance(ctx context.Context, msg *tgbotapi.Message)
 	identifier := strings.TrimPrefix(args[0], "@")
 
 	if isRelevanceKeyword(identifier) && len(args) == 1 {
-		b.reply(msg, "Missing channel identifier.\nUsage: <code>/channel relevance @username</code>")
+		b.reply(msg, tr(ctx, "Missing channel identifier.\nUsage: <code>/channel relevance @username</code>"))
 
 		return
 	}
@@ -1903,12 +1964,12 @@ func isRelevanceKeyword(s string) bool {
 func (b *Bot) lookupChannel(ctx context.Context, identifier string) (*db.Channel, string) {
 	channels, err := b.database.GetActiveChannels(ctx)
 	if err != nil {
-		return nil, fmt.Sprintf(ErrFetchingChannelsFmt, html.EscapeString(err.Error()))
+		return nil, tr(ctx, ErrFetchingChannelsFmt, html.EscapeString(err.Error()))
 	}
 
 	channel := findChannelByIdentifier(channels, identifier)
 	if channel == nil {
-		return nil, fmt.Sprintf(ErrChannelNotFoundFmt, html.EscapeString(identifier))
+		return nil, tr(ctx, ErrChannelNotFoundFmt, html.EscapeString(identifier))
 	}
 
 	return channel, ""
@@ -1921,16 +1982,16 @@ func (b *Bot) dispatchRelevanceAction(ctx context.Context, msg *tgbotapi.Message
 	case WeightOverrideManual, ToggleOff, ToggleDisable:
 		b.setChannelAutoRelevance(ctx, msg, channel, identifier, false)
 	default:
-		b.replyChannelRelevanceUsage(msg)
+		b.replyChannelRelevanceUsage(ctx, msg)
 	}
 }
 
 // replyChannelRelevanceUsage sends the usage help for the channel relevance command.
-func (b *Bot) replyChannelRelevanceUsage(msg *tgbotapi.Message) {
-	b.reply(msg, "Usage:\n"+
+func (b *Bot) replyChannelRelevanceUsage(ctx context.Context, msg *tgbotapi.Message) {
+	b.reply(msg, tr(ctx, "Usage:\n"+
 		"<code>/channel relevance @username</code> - Show current auto relevance\n"+
 		"<code>/channel relevance @username auto</code> - Enable auto relevance\n"+
-		"<code>/channel relevance @username manual</code> - Disable auto relevance")
+		"<code>/channel relevance @username manual</code> - Disable auto relevance"))
 }
 
 // showChannelRelevanceStatus displays the current relevance settings for a channel.
@@ -1971,7 +2032,7 @@ func (b *Bot) setChannelAutoRelevance(ctx context.Context, msg *tgbotapi.Message
 			action = "disabling"
 		}
 
-		b.reply(msg, fmt.Sprintf("❌ Error %s auto relevance: %s", action, html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error %s auto relevance: %s", action, html.EscapeString(err.Error())))
 
 		return
 	}
@@ -1983,7 +2044,7 @@ func (b *Bot) setChannelAutoRelevance(ctx context.Context, msg *tgbotapi.Message
 		action = "disabled"
 	}
 
-	b.reply(msg, fmt.Sprintf("Auto relevance %s for %s. Delta reset to 0.", action, chanDisplay))
+	b.reply(msg, tr(ctx, "Auto relevance %s for %s. Delta reset to 0.", action, chanDisplay))
 }
 
 // isNumericWeight checks if a string looks like a weight value (number between 0.1 and 2.0)
@@ -2044,7 +2105,7 @@ func (b *Bot) handleFeedback(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/feedback &lt;item_id&gt; &lt;good|bad|irrelevant&gt; [comment]</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/feedback &lt;item_id&gt; &lt;good|bad|irrelevant&gt; [comment]</code>"))
 
 		return
 	}
@@ -2053,7 +2114,7 @@ func (b *Bot) handleFeedback(ctx context.Context, msg *tgbotapi.Message) {
 	rating := strings.ToLower(args[1])
 
 	if rating != RatingGood && rating != RatingBad && rating != RatingIrrelevant {
-		b.reply(msg, fmt.Sprintf("❌ Invalid rating. Use <code>%s</code>, <code>%s</code>, or <code>%s</code>.", RatingGood, RatingBad, RatingIrrelevant))
+		b.reply(msg, tr(ctx, "❌ Invalid rating. Use <code>%s</code>, <code>%s</code>, or <code>%s</code>.", RatingGood, RatingBad, RatingIrrelevant))
 
 		return
 	}
@@ -2065,20 +2126,20 @@ func (b *Bot) handleFeedback(ctx context.Context, msg *tgbotapi.Message) {
 	}
 
 	if err := b.database.SaveItemRating(ctx, itemID, msg.From.ID, rating, feedback, "bot-feedback"); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving feedback: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving feedback: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	observability.ItemRatingsTotal.WithLabelValues(rating).Inc()
-	b.reply(msg, fmt.Sprintf("✅ Feedback for item <code>%s</code> recorded as <b>%s</b>.", html.EscapeString(itemID), html.EscapeString(rating)))
+	b.reply(msg, tr(ctx, "✅ Feedback for item <code>%s</code> recorded as <b>%s</b>.", html.EscapeString(itemID), html.EscapeString(rating)))
 }
 
 func (b *Bot) handleChannelMetadata(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) < 4 {
-		b.reply(msg, "Usage: <code>/channel metadata &lt;@username|ID&gt; &lt;category&gt; &lt;tone&gt; &lt;freq&gt; [relevance] [importance]</code>\nUse <code>-</code> to skip a field.")
+		b.reply(msg, tr(ctx, "Usage: <code>/channel metadata &lt;@username|ID&gt; &lt;category&gt; &lt;tone&gt; &lt;freq&gt; [relevance] [importance]</code>\nUse <code>-</code> to skip a field."))
 
 		return
 	}
@@ -2113,66 +2174,67 @@ func (b *Bot) handleChannelMetadata(ctx context.Context, msg *tgbotapi.Message)
 	username := strings.TrimPrefix(identifier, "@")
 
 	if err := b.database.UpdateChannelMetadata(ctx, username, category, tone, freq, float32(rel), float32(imp)); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error updating channel metadata: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error updating channel metadata: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Metadata updated for channel <code>%s</code>.", html.EscapeString(identifier)))
+	b.reply(msg, tr(ctx, "✅ Metadata updated for channel <code>%s</code>.", html.EscapeString(identifier)))
 }
 
 func (b *Bot) handleAddChannel(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args == "" {
-		b.reply(msg, "Usage: <code>/add &lt;@username|ID|invite_link&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/add &lt;@username|ID|invite_link&gt;</code>"))
 
 		return
 	}
 
+	text, added := b.addChannel(ctx, args)
+	if added {
+		text += b.startChannelTrial(ctx, args, b.channelTrialDays(ctx))
+	}
+
+	b.reply(msg, text)
+}
+
+// addChannel adds a channel by invite link, numeric ID or username and
+// returns the message describing the result and whether it was added.
+func (b *Bot) addChannel(ctx context.Context, args string) (string, bool) {
 	// 1. Check if it's an invite link
 	if strings.Contains(args, "t.me/") {
 		if err := b.database.AddChannelByInviteLink(ctx, args); err != nil {
-			b.reply(msg, fmt.Sprintf("❌ Error adding channel by invite link: %s", html.EscapeString(err.Error())))
-
-			return
+			return tr(ctx, "❌ Error adding channel by invite link: %s", html.EscapeString(err.Error())), false
 		}
 
-		b.reply(msg, "✅ Channel added by invite link. Reader will attempt to join and track it soon.")
-
-		return
+		return tr(ctx, "✅ Channel added by invite link. Reader will attempt to join and track it soon."), true
 	}
 
 	// 2. Check if it's a numeric ID
 	if id, err := strconv.ParseInt(args, 10, 64); err == nil {
 		if err := b.database.AddChannelByID(ctx, id); err != nil {
-			b.reply(msg, fmt.Sprintf("❌ Error adding channel by ID: %s", html.EscapeString(err.Error())))
-
-			return
+			return tr(ctx, "❌ Error adding channel by ID: %s", html.EscapeString(err.Error())), false
 		}
 
-		b.reply(msg, fmt.Sprintf("✅ Channel ID <code>%d</code> added. Reader will start tracking it soon.", id))
-
-		return
+		return tr(ctx, "✅ Channel ID <code>%d</code> added. Reader will start tracking it soon.", id), true
 	}
 
 	// 3. Fallback to username
 	username := strings.TrimPrefix(args, "@")
 
 	if err := b.database.AddChannelByUsername(ctx, username); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error adding channel by username: %s", html.EscapeString(err.Error())))
-
-		return
+		return tr(ctx, "❌ Error adding channel by username: %s", html.EscapeString(err.Error())), false
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Channel <code>@%s</code> added. Reader will start tracking it soon.", html.EscapeString(username)))
+	return tr(ctx, "✅ Channel <code>@%s</code> added. Reader will start tracking it soon.", html.EscapeString(username)), true
 }
 
 func (b *Bot) handleRemoveChannel(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
 
 	if len(args) == 0 {
-		b.reply(msg, "Usage: <code>/remove &lt;@username|ID&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/remove &lt;@username|ID&gt;</code>"))
 
 		return
 	}
@@ -2180,23 +2242,23 @@ func (b *Bot) handleRemoveChannel(ctx context.Context, msg *tgbotapi.Message) {
 	identifier := args[0]
 
 	if len(args) < 2 || args[1] != SubCmdConfirm {
-		b.reply(msg, fmt.Sprintf("⚠️ Are you sure you want to stop tracking channel <code>%s</code>?\nUse <code>/remove %s confirm</code> to proceed.", html.EscapeString(identifier), html.EscapeString(identifier)))
+		b.reply(msg, tr(ctx, "⚠️ Are you sure you want to stop tracking channel <code>%s</code>?\nUse <code>/remove %s confirm</code> to proceed.", html.EscapeString(identifier), html.EscapeString(identifier)))
 
 		return
 	}
 
 	if err := b.database.DeactivateChannel(ctx, identifier); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error removing channel: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error removing channel: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Channel <code>%s</code> removed.", html.EscapeString(identifier)))
+	b.reply(msg, tr(ctx, "✅ Channel <code>%s</code> removed.", html.EscapeString(identifier)))
 }
 
 func (b *Bot) handleFiltersAdd(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 3 {
-		b.reply(msg, "Usage: <code>/filters add &lt;allow|deny&gt; &lt;pattern&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/filters add &lt;allow|deny&gt; &lt;pattern&gt;</code>"))
 
 		return
 	}
@@ -2205,17 +2267,17 @@ func (b *Bot) handleFiltersAdd(ctx context.Context, msg *tgbotapi.Message, args
 	pattern := strings.Join(args[2:], " ")
 
 	if err := b.database.AddFilter(ctx, fType, pattern); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error adding filter: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error adding filter: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Filter added: [%s] <code>%s</code>", strings.ToUpper(fType), html.EscapeString(pattern)))
+	b.reply(msg, tr(ctx, "✅ Filter added: [%s] <code>%s</code>", strings.ToUpper(fType), html.EscapeString(pattern)))
 }
 
 func (b *Bot) handleFiltersRemove(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/filters remove &lt;pattern&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/filters remove &lt;pattern&gt;</code>"))
 
 		return
 	}
@@ -2223,17 +2285,17 @@ func (b *Bot) handleFiltersRemove(ctx context.Context, msg *tgbotapi.Message, ar
 	pattern := strings.Join(args[1:], " ")
 
 	if err := b.database.DeactivateFilter(ctx, pattern); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error removing filter: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error removing filter: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Filter removed: <code>%s</code>", html.EscapeString(pattern)))
+	b.reply(msg, tr(ctx, "✅ Filter removed: <code>%s</code>", html.EscapeString(pattern)))
 }
 
 func (b *Bot) handleFiltersAds(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/filters ads &lt;on|off&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/filters ads &lt;on|off&gt;</code>"))
 
 		return
 	}
@@ -2241,17 +2303,17 @@ func (b *Bot) handleFiltersAds(ctx context.Context, msg *tgbotapi.Message, args
 	enabled := args[1] == "on"
 
 	if err := b.database.SaveSettingWithHistory(ctx, SettingFiltersAds, enabled, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving ads filter setting: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving ads filter setting: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Ads filter turned <code>%s</code>.", strings.ToUpper(args[1])))
+	b.reply(msg, tr(ctx, "✅ Ads filter turned <code>%s</code>.", strings.ToUpper(args[1])))
 }
 
 func (b *Bot) handleFiltersMode(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/filters mode &lt;mixed|allowlist|denylist&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/filters mode &lt;mixed|allowlist|denylist&gt;</code>"))
 
 		return
 	}
@@ -2260,24 +2322,24 @@ func (b *Bot) handleFiltersMode(ctx context.Context, msg *tgbotapi.Message, args
 	validModes := map[string]bool{"mixed": true, "allowlist": true, "denylist": true}
 
 	if !validModes[mode] {
-		b.reply(msg, "❌ Invalid mode. Use <code>mixed</code>, <code>allowlist</code> or <code>denylist</code>.")
+		b.reply(msg, tr(ctx, "❌ Invalid mode. Use <code>mixed</code>, <code>allowlist</code> or <code>denylist</code>."))
 
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, "filters_mode", mode, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving filters mode: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving filters mode: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Filters mode set to <code>%s</code>.", html.EscapeString(mode)))
+	b.reply(msg, tr(ctx, "✅ Filters mode set to <code>%s</code>.", html.EscapeString(mode)))
 }
 
 func (b *Bot) handleFiltersList(ctx context.Context, msg *tgbotapi.Message) {
 	filters, err := b.database.GetActiveFilters(ctx)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching filters: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching filters: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -2325,7 +2387,7 @@ func (b *Bot) handleFilters(ctx context.Context, msg *tgbotapi.Message) {
 	if handler, ok := handlers[args[0]]; ok {
 		handler(ctx, msg, args)
 	} else {
-		b.reply(msg, "❓ Unknown filters command. Use <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code> or no arguments to list.")
+		b.reply(msg, tr(ctx, "❓ Unknown filters command. Use <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code> or no arguments to list."))
 	}
 }
 
@@ -2339,7 +2401,7 @@ func (b *Bot) handleToggleSetting(ctx context.Context, msg *tgbotapi.Message, ke
 	if args != "on" && args != ToggleOff {
 		cmdName := strings.TrimSuffix(key, "_enabled")
 		cmdName = strings.ReplaceAll(cmdName, "_", " ")
-		b.reply(msg, fmt.Sprintf("Usage: <code>/%s &lt;on|off&gt;</code>", html.EscapeString(cmdName)))
+		b.reply(msg, tr(ctx, "Usage: <code>/%s &lt;on|off&gt;</code>", html.EscapeString(cmdName)))
 
 		return
 	}
@@ -2351,7 +2413,7 @@ func (b *Bot) handleToggleSetting(ctx context.Context, msg *tgbotapi.Message, ke
 	_ = b.database.GetSetting(ctx, key, &current) //nolint:errcheck // best-effort read
 
 	if err := b.database.SaveSettingWithHistory(ctx, key, enabled, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf(ErrSavingFmt, html.EscapeString(key), html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, ErrSavingFmt, html.EscapeString(key), html.EscapeString(err.Error())))
 
 		return
 	}
@@ -2368,73 +2430,32 @@ func (b *Bot) handleToggleSetting(ctx context.Context, msg *tgbotapi.Message, ke
 		oldStatus = StatusDisabled
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ <b>%s</b>\nOld status: <code>%s</code>\nNew status: <code>%s</code>", html.EscapeString(label), oldStatus, status))
-}
-
-func (b *Bot) handleSetup(ctx context.Context, msg *tgbotapi.Message) {
-	var targetID int64
-
-	_ = b.database.GetSetting(ctx, SettingTargetChatID, &targetID) //nolint:errcheck // best-effort read
-
-	channels, _ := b.database.GetActiveChannels(ctx) //nolint:errcheck // best-effort read
-
-	var sb strings.Builder
-
-	sb.WriteString("🚀 <b>Getting Started with Digest Bot</b>\n\n")
-
-	if targetID == 0 {
-		sb.WriteString("1️⃣ <b>Set Target Channel</b>\n")
-		sb.WriteString("First, set the channel where the bot will post digests.\n")
-		sb.WriteString("Usage: <code>/target @your_channel_username</code>\n\n")
-	} else {
-		sb.WriteString("1️⃣ <b>Target Channel:</b> ✅ Set\n\n")
-	}
-
-	if len(channels) == 0 {
-		sb.WriteString("2️⃣ <b>Add Source Channels</b>\n")
-		sb.WriteString("Add some channels to track news from.\n")
-		sb.WriteString("Usage: <code>/add @source_channel</code>\n\n")
-	} else {
-		sb.WriteString(fmt.Sprintf("2️⃣ <b>Source Channels:</b> ✅ %d added\n\n", len(channels)))
-	}
-
-	sb.WriteString("3️⃣ <b>Basic Configuration</b>\n")
-	sb.WriteString("• <code>/window 60m</code> - Set fallback digest interval\n")
-	sb.WriteString("• <code>/schedule show</code> - View digest schedule\n")
-	sb.WriteString("• <code>/schedule preview [count]</code> - Preview upcoming digest times\n")
-	sb.WriteString("• <code>/schedule clear</code> - Clear current schedule\n")
-	sb.WriteString("• <code>/language ru</code> - Set digest language\n\n")
-
-	sb.WriteString("💡 <i>Tip: Use /settings to see all current values.</i>")
-
-	b.reply(msg, sb.String())
+	b.reply(msg, tr(ctx, "✅ <b>%s</b>\nOld status: <code>%s</code>\nNew status: <code>%s</code>", html.EscapeString(label), oldStatus, status))
 }
 
 func (b *Bot) handlePreview(ctx context.Context, msg *tgbotapi.Message) {
 	if b.digestBuilder == nil {
-		b.reply(msg, "❌ Digest preview is not available in this mode.")
+		b.reply(msg, tr(ctx, "❌ Digest preview is not available in this mode."))
 
 		return
 	}
 
-	window, threshold := b.getPreviewParams(ctx)
-	start, end := time.Now().Add(-window), time.Now()
+	send := b.sendPreview
 
-	text, items, clusters, err := b.buildPreviewDigest(ctx, start, end, threshold)
-	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error building digest preview: %s", html.EscapeString(err.Error())))
+	switch arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments())); arg {
+	case "":
+	case previewSubDiff:
+		send = b.sendPreviewDiff
+	default:
+		b.reply(msg, tr(ctx, "Usage: <code>/preview [diff]</code>"))
 
 		return
 	}
 
-	if text == "" {
-		b.reply(msg, "ℹ️ No items found for the current window to include in a digest.")
-
-		return
-	}
+	window, threshold := b.getPreviewParams(ctx)
+	start, end := time.Now().Add(-window), time.Now()
 
-	header := fmt.Sprintf("📝 <b>Digest Preview</b> (%d items)\n<i>This has not been posted to the target channel.</i>\n\n", len(items))
-	b.sendPreviewWithSettings(ctx, msg, header, text, items, clusters, start, end, threshold)
+	b.startPreview(ctx, msg, start, end, threshold, send)
 }
 
 // getPreviewParams retrieves window and threshold settings for preview.
@@ -2478,7 +2499,7 @@ func (b *Bot) sendPreviewWithSettings(ctx context.Context, msg *tgbotapi.Message
 	}
 
 	if inlineImagesEnabled {
-		b.sendPreviewRichDigest(ctx, msg, header, text, items, start, end, threshold)
+		b.sendPreviewRichDigest(ctx, msg, header, text, items, clusters, start, end, threshold)
 
 		return
 	}
@@ -2503,7 +2524,7 @@ func (b *Bot) sendPreviewWithCover(ctx context.Context, msg *tgbotapi.Message, h
 }
 
 // sendPreviewRichDigest sends preview with inline images per item.
-func (b *Bot) sendPreviewRichDigest(ctx context.Context, msg *tgbotapi.Message, header, text string, items []db.Item, start, end time.Time, threshold float32) {
+func (b *Bot) sendPreviewRichDigest(ctx context.Context, msg *tgbotapi.Message, header, text string, items []db.Item, clusters []db.ClusterWithItems, start, end time.Time, threshold float32) {
 	// Fetch items with media
 	itemsWithMedia, err := b.database.GetItemsForWindowWithMedia(ctx, start, end, threshold, len(items))
 	if err != nil {
@@ -2513,20 +2534,8 @@ func (b *Bot) sendPreviewRichDigest(ctx context.Context, msg *tgbotapi.Message,
 		return
 	}
 
-	// Build rich content
-	richItems := make([]digest.RichDigestItem, 0, len(itemsWithMedia))
-
-	for _, item := range itemsWithMedia {
-		richItems = append(richItems, digest.RichDigestItem{
-			Summary:    item.Summary,
-			Topic:      item.Topic,
-			Importance: item.ImportanceScore,
-			Channel:    item.SourceChannel,
-			ChannelID:  item.SourceChannelID,
-			MsgID:      item.SourceMsgID,
-			MediaData:  item.MediaData,
-		})
-	}
+	// Build rich content, one entry per cluster
+	richItems := digest.BuildRichItems(ctx, b.database, itemsWithMedia, clusters, b.logger)
 
 	content := digest.RichDigestContent{
 		Header:   header,
@@ -2551,9 +2560,10 @@ func (b *Bot) fetchPreviewCoverImage(ctx context.Context, items []db.Item, clust
 	// Try AI cover first if enabled (independent of cover_image setting)
 	if aiCoverEnabled && b.llmClient != nil {
 		topics := extractTopicsForPreview(items, clusters)
-		narrative := b.prepareNarrativeForPreview(ctx, items, clusters)
 
-		coverImage, err := b.llmClient.GenerateDigestCover(ctx, topics, narrative)
+		coverImage, _, err := digest.GenerateCover(ctx, b.llmClient, b.database, items, topics, time.Now(), func() string {
+			return b.prepareNarrativeForPreview(ctx, items, clusters)
+		}, b.logger)
 		if err != nil {
 			b.logger.Warn().Err(err).Msg("failed to generate AI cover for preview")
 		} else {
@@ -2561,6 +2571,15 @@ func (b *Bot) fetchPreviewCoverImage(ctx context.Context, items []db.Item, clust
 		}
 	}
 
+	if digest.LocalCoverEnabled(ctx, b.database, b.logger) {
+		coverImage, err := digest.ComposeLocalCover(ctx, b.database, items, clusters, start, end, b.logger)
+		if err != nil {
+			b.logger.Warn().Err(err).Msg("failed to compose local cover for preview")
+		} else {
+			return coverImage
+		}
+	}
+
 	// Check if regular cover image is enabled
 	var coverImageEnabled = true
 
@@ -2677,36 +2696,36 @@ func (b *Bot) handleTone(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.ToLower(msg.CommandArguments())
 
 	if args != "professional" && args != "casual" && args != "brief" {
-		b.reply(msg, "Usage: <code>/tone &lt;professional|casual|brief&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/tone &lt;professional|casual|brief&gt;</code>"))
 
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, "digest_tone", args, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving tone: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving tone: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Digest tone set to <code>%s</code>.", html.EscapeString(args)))
+	b.reply(msg, tr(ctx, "✅ Digest tone set to <code>%s</code>.", html.EscapeString(args)))
 }
 
 func (b *Bot) handleDedup(ctx context.Context, msg *tgbotapi.Message) {
 	args := msg.CommandArguments()
 
 	if args != "strict" && args != "semantic" {
-		b.reply(msg, "Usage: <code>/dedup &lt;strict|semantic&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/dedup &lt;strict|semantic&gt;</code>"))
 
 		return
 	}
 
 	if err := b.database.SaveSettingWithHistory(ctx, "dedup_mode", args, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error saving dedup mode: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error saving dedup mode: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Deduplication mode set to <code>%s</code>.", html.EscapeString(args)))
+	b.reply(msg, tr(ctx, "✅ Deduplication mode set to <code>%s</code>.", html.EscapeString(args)))
 }
 
 func (b *Bot) handleSettings(ctx context.Context, msg *tgbotapi.Message) {
@@ -2718,9 +2737,15 @@ func (b *Bot) handleSettings(ctx context.Context, msg *tgbotapi.Message) {
 		return
 	}
 
+	if len(args) > 0 && args[0] == SubCmdRollback {
+		b.handleSettingsRollback(ctx, msg, args)
+
+		return
+	}
+
 	dbSettings, err := b.database.GetAllSettings(ctx)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("Error fetching settings: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "Error fetching settings: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -2748,12 +2773,14 @@ func (b *Bot) handleSettings(ctx context.Context, msg *tgbotapi.Message) {
 		{"topics_enabled", "Topics Grouping", true},
 		{"editor_enabled", "Editor-in-Chief", false},
 		{"tiered_importance_enabled", "Tiered Importance", false},
+		{digest.SettingSummarizeTwoPass, "Two-pass Summarization", false},
 		{"vision_routing_enabled", "Vision Routing", false},
 		{"consolidated_clusters_enabled", "Consolidated Clusters", false},
 		{"editor_detailed_items", "Editor Detailed Items", true},
 		{SettingFiltersAds, "Ads Filter", false},
 		{"filters_min_length", "Min Message Length", 20},
 		{"filters_skip_forwards", "Skip Forwards", false},
+		{SettingFiltersMedia, "Media Filter", ToggleOff},
 		{SettingFiltersAdsKeywords, "Ads Keywords Count", 0},
 		{SettingDiscoveryMinSeen, "Discovery Min Seen", DefaultDiscoveryMinSeen},
 		{SettingDiscoveryMinScore, "Discovery Min Engagement", DefaultDiscoveryMinEngagement},
@@ -2761,7 +2788,30 @@ func (b *Bot) handleSettings(ctx context.Context, msg *tgbotapi.Message) {
 		{SettingDiscoveryDeny, "Discovery Deny Keywords Count", 0},
 		{SettingDigestCoverImage, "Cover Image", true},
 		{SettingDigestAICover, "AI Cover (DALL-E)", false},
+		{digest.SettingDigestCoverStyle, "AI Cover Style", llm.CoverStyleEditorial},
+		{digest.SettingDigestLocalCover, "Local Cover", false},
 		{SettingDigestInlineImages, "Inline Images", false},
+		{SettingDigestStanceBadges, "Evidence Stance Badges", false},
+		{SettingDigestNumbersBlock, "By the Numbers Block", false},
+		{SettingDigestQuotesBlock, "Quotes Block", false},
+		{SettingDigestUpcomingBlock, "Upcoming Block", false},
+		{SettingDigestRumorWatch, "Rumor Watch", false},
+		{SettingDigestWhatsNew, "What's New Summaries", true},
+		{SettingDigestSourceFooter, "Source Footer", false},
+		{SettingDigestClickTracking, "Click Tracking", false},
+		{SettingDeliverySlackEnabled, "Slack Delivery", true},
+		{SettingDeliveryDiscordEnabled, "Discord Delivery", true},
+		{SettingDeliveryMatrixEnabled, "Matrix Delivery", true},
+		{SettingDigestItemLinks, "Item Detail Links", db.ItemLinksOff},
+		{digest.SettingDigestTOCMinTopics, "TOC Min Topics", digest.DefaultTOCMinTopics},
+		{digest.SettingDigestMMRLambda, "MMR Lambda", "off"},
+		{digest.SettingDigestTierSort, "Tier Sort", digest.TierSortImportance},
+		{digest.SettingDigestPrebuildMinutes, "Digest Pre-build Minutes", "off"},
+		{SettingBotLanguage, "Bot Language", botLanguageDefault},
+		{digest.SettingDigestVerbosity, "Digest Verbosity", llm.VerbosityStandard},
+		{digest.SettingDigestFormat, "Digest Output Format", markup.FormatHTML},
+		{digest.SettingDigestAltText, "Image Alt Text", digest.AltTextOff},
+		{filters.SettingContentSafety, "Content Safety Policy", filters.SafetyPolicyOff},
 		{"admin_ids", "Additional Admins", "none"},
 	}
 
@@ -2808,7 +2858,7 @@ func countKeywordArray(val interface{}) int {
 
 func (b *Bot) handleSettingsReset(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/settings reset &lt;key&gt;</code>")
+		b.reply(msg, tr(ctx, "Usage: <code>/settings reset &lt;key&gt;</code>"))
 
 		return
 	}
@@ -2816,15 +2866,15 @@ func (b *Bot) handleSettingsReset(ctx context.Context, msg *tgbotapi.Message, ar
 	key := args[1]
 
 	if err := b.database.DeleteSettingWithHistory(ctx, key, msg.From.ID); err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error resetting setting: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error resetting setting: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Setting <code>%s</code> has been reset to default (env var value).", html.EscapeString(key)))
+	b.reply(msg, tr(ctx, "✅ Setting <code>%s</code> has been reset to default (env var value).", html.EscapeString(key)))
 }
 
-func (b *Bot) handleHelp(_ context.Context, msg *tgbotapi.Message) {
+func (b *Bot) handleHelp(ctx context.Context, msg *tgbotapi.Message) {
 	args := strings.Fields(msg.CommandArguments())
 	if len(args) == 0 {
 		b.reply(msg, helpSummaryMessage())
@@ -2840,6 +2890,7 @@ func (b *Bot) handleHelp(_ context.Context, msg *tgbotapi.Message) {
 		"discover":   helpDiscoverMessage(),
 		"filters":    helpFiltersMessage(),
 		"filter":     helpFiltersMessage(),
+		CmdRules:     helpFiltersMessage(),
 		"schedule":   helpScheduleMessage(),
 		"config":     helpConfigMessage(),
 		"ai":         helpAIMessage(),
@@ -2855,7 +2906,7 @@ func (b *Bot) handleHelp(_ context.Context, msg *tgbotapi.Message) {
 	if m, ok := helpMsgs[topic]; ok {
 		b.reply(msg, m)
 	} else {
-		b.reply(msg, fmt.Sprintf("❓ Unknown help topic: <code>%s</code>\n\n%s", html.EscapeString(topic), helpSummaryMessage()))
+		b.reply(msg, tr(ctx, "❓ Unknown help topic: <code>%s</code>\n\n%s", html.EscapeString(topic), helpSummaryMessage()))
 	}
 }
 
@@ -2873,7 +2924,7 @@ func (b *Bot) handleResearch(ctx context.Context, msg *tgbotapi.Message) {
 	switch {
 	case strings.EqualFold(args[0], "login"):
 		if b.cfg.ExpandedViewSigningSecret == "" || b.cfg.ExpandedViewBaseURL == "" {
-			b.reply(msg, "❌ Research dashboard is not configured. Set EXPANDED_VIEW_SIGNING_SECRET and EXPANDED_VIEW_BASE_URL.")
+			b.reply(msg, tr(ctx, "❌ Research dashboard is not configured. Set EXPANDED_VIEW_SIGNING_SECRET and EXPANDED_VIEW_BASE_URL."))
 			return
 		}
 
@@ -2881,20 +2932,20 @@ func (b *Bot) handleResearch(ctx context.Context, msg *tgbotapi.Message) {
 
 		token, err := tokenService.Generate(msg.From.ID)
 		if err != nil {
-			b.reply(msg, fmt.Sprintf("❌ Failed to generate login token: %s", html.EscapeString(err.Error())))
+			b.reply(msg, tr(ctx, "❌ Failed to generate login token: %s", html.EscapeString(err.Error())))
 			return
 		}
 
 		baseURL := strings.TrimRight(b.cfg.ExpandedViewBaseURL, "/")
 		loginURL := fmt.Sprintf("%s/research/login?token=%s", baseURL, url.QueryEscape(token))
-		b.reply(msg, fmt.Sprintf("🔐 <b>Research Login</b>\n%s", html.EscapeString(loginURL)))
+		b.reply(msg, tr(ctx, "🔐 <b>Research Login</b>\n%s", html.EscapeString(loginURL)))
 	case strings.EqualFold(args[0], "rebuild"):
 		if err := b.rebuildResearch(ctx); err != nil {
-			b.reply(msg, fmt.Sprintf("❌ Research rebuild failed: %s", html.EscapeString(err.Error())))
+			b.reply(msg, tr(ctx, "❌ Research rebuild failed: %s", html.EscapeString(err.Error())))
 			return
 		}
 
-		b.reply(msg, "✅ Research rebuild complete.")
+		b.reply(msg, tr(ctx, "✅ Research rebuild complete."))
 	default:
 		b.reply(msg, helpResearchMessage())
 	}
@@ -2958,6 +3009,10 @@ func (b *Bot) rebuildResearch(ctx context.Context) error {
 		return fmt.Errorf("cluster research items: %w", err)
 	}
 
+	if err := dbConn.ResetRebuildState(rebuildCtx); err != nil {
+		return fmt.Errorf("reset rebuild state: %w", err)
+	}
+
 	if err := dbConn.RefreshResearchMaterializedViews(rebuildCtx); err != nil {
 		return fmt.Errorf(errFmtRefreshResearchViews, err)
 	}
@@ -2978,7 +3033,7 @@ func (b *Bot) handleErrors(ctx context.Context, msg *tgbotapi.Message) {
 	// Pipeline processing errors
 	errors, err := b.database.GetRecentErrors(ctx, RecentErrorsLimit)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching errors: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching errors: %s", html.EscapeString(err.Error())))
 
 		return
 	}
@@ -3010,7 +3065,7 @@ func (b *Bot) handleErrors(ctx context.Context, msg *tgbotapi.Message) {
 	}
 
 	if !hasErrors {
-		b.reply(msg, "✅ No recent errors found.")
+		b.reply(msg, tr(ctx, "✅ No recent errors found."))
 
 		return
 	}
@@ -3044,13 +3099,13 @@ func (b *Bot) humanizeError(errJSON []byte) string {
 func (b *Bot) handleHistory(ctx context.Context, msg *tgbotapi.Message) {
 	history, err := b.database.GetRecentSettingHistory(ctx, SettingHistoryLimit)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error fetching history: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error fetching history: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	if len(history) == 0 {
-		b.reply(msg, "📋 No setting history found.")
+		b.reply(msg, tr(ctx, "📋 No setting history found."))
 
 		return
 	}
@@ -3060,7 +3115,11 @@ func (b *Bot) handleHistory(ctx context.Context, msg *tgbotapi.Message) {
 	for _, h := range history {
 		text += fmt.Sprintf("• <b>%s</b> changed by <code>%d</code>\n", html.EscapeString(h.Key), h.ChangedBy)
 
-		text += fmt.Sprintf("  🕒 %s\n", h.ChangedAt.Format(DateTimeFormat))
+		text += fmt.Sprintf("  🕒 %s · #%d\n", h.ChangedAt.Format(DateTimeFormat), h.ID)
+		if h.RollbackOf != 0 {
+			text += fmt.Sprintf("  ↩️ <i>Rollback of #%d</i>\n", h.RollbackOf)
+		}
+
 		if h.NewValue == "" {
 			text += "  🗑️ <i>Deleted/Reset</i>\n"
 		} else {
@@ -3077,6 +3136,7 @@ func (b *Bot) handleHistory(ctx context.Context, msg *tgbotapi.Message) {
 	}
 
 	text += TipSettingsReset
+	text += TipSettingsRollback
 	b.reply(msg, text)
 }
 
@@ -3099,12 +3159,12 @@ func (b *Bot) handleRetry(ctx context.Context, msg *tgbotapi.Message) {
 		id := strings.TrimPrefix(args[0], "_")
 
 		if err := b.database.RetryItem(ctx, id); err != nil {
-			b.reply(msg, fmt.Sprintf("❌ Error retrying item %s: %s", html.EscapeString(id), html.EscapeString(err.Error())))
+			b.reply(msg, tr(ctx, "❌ Error retrying item %s: %s", html.EscapeString(id), html.EscapeString(err.Error())))
 
 			return
 		}
 
-		b.reply(msg, fmt.Sprintf("✅ Item <code>%s</code> has been requeued.", html.EscapeString(id)))
+		b.reply(msg, tr(ctx, "✅ Item <code>%s</code> has been requeued.", html.EscapeString(id)))
 	}
 }
 
@@ -3115,7 +3175,7 @@ func (b *Bot) showRetryStatus(ctx context.Context, msg *tgbotapi.Message) {
 	enrichmentErrors, _ := b.database.CountEnrichmentErrors(ctx)           //nolint:errcheck // best-effort
 
 	if len(pipelineErrors) == 0 && enrichmentErrors == 0 {
-		b.reply(msg, "✅ No failed items found to retry.")
+		b.reply(msg, tr(ctx, "✅ No failed items found to retry."))
 
 		return
 	}
@@ -3137,46 +3197,46 @@ func (b *Bot) showRetryStatus(ctx context.Context, msg *tgbotapi.Message) {
 
 func (b *Bot) retryPipelineItems(ctx context.Context, msg *tgbotapi.Message) {
 	if err := b.database.RetryFailedItems(ctx); err != nil {
-		b.reply(msg, fmt.Sprintf(fmtErrRetryingItems, html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, fmtErrRetryingItems, html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, "✅ All failed pipeline items have been requeued for processing.")
+	b.reply(msg, tr(ctx, "✅ All failed pipeline items have been requeued for processing."))
 }
 
 func (b *Bot) handleRetryEnrichment(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	errorCount, err := b.database.CountEnrichmentErrors(ctx)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf("❌ Error counting failed items: %s", html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, "❌ Error counting failed items: %s", html.EscapeString(err.Error())))
 
 		return
 	}
 
 	if len(args) == 0 || strings.ToLower(args[0]) != SubCmdConfirm {
 		if errorCount == 0 {
-			b.reply(msg, "✅ No failed enrichment items found.")
+			b.reply(msg, tr(ctx, "✅ No failed enrichment items found."))
 
 			return
 		}
 
-		b.reply(msg, fmt.Sprintf("⚠️ <code>%d</code> failed enrichment items found.\n\nUse <code>/retry enrichment confirm</code> to requeue all.", errorCount))
+		b.reply(msg, tr(ctx, "⚠️ <code>%d</code> failed enrichment items found.\n\nUse <code>/retry enrichment confirm</code> to requeue all.", errorCount))
 
 		return
 	}
 
 	if errorCount == 0 {
-		b.reply(msg, "✅ No failed enrichment items to retry.")
+		b.reply(msg, tr(ctx, "✅ No failed enrichment items to retry."))
 
 		return
 	}
 
 	requeued, err := b.database.RetryFailedEnrichmentItems(ctx)
 	if err != nil {
-		b.reply(msg, fmt.Sprintf(fmtErrRetryingItems, html.EscapeString(err.Error())))
+		b.reply(msg, tr(ctx, fmtErrRetryingItems, html.EscapeString(err.Error())))
 
 		return
 	}
 
-	b.reply(msg, fmt.Sprintf("✅ Requeued <code>%d</code> enrichment items for processing.", requeued))
+	b.reply(msg, tr(ctx, "✅ Requeued <code>%d</code> enrichment items for processing.", requeued))
 }
@@ -0,0 +1,45 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdAltText is the /config subcommand for image descriptions in rich digests.
+const CmdAltText = "alt_text"
+
+const altTextUsage = "Usage: <code>/config alt_text &lt;off|caption|spoiler&gt;</code>\n\n" +
+	"Adds the vision model's description of each image to rich digest captions, " +
+	"in italics (<code>caption</code>) or hidden behind a spoiler (<code>spoiler</code>)."
+
+func (b *Bot) handleAltText(ctx context.Context, msg *tgbotapi.Message) {
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	if arg == "" {
+		mode := digest.LoadAltTextMode(ctx, b.database)
+
+		b.reply(msg, fmt.Sprintf("Image alt text is <b>%s</b>.\n\n%s", html.EscapeString(mode), altTextUsage))
+
+		return
+	}
+
+	if !digest.IsValidAltTextMode(arg) {
+		b.reply(msg, altTextUsage)
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestAltText, arg, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestAltText, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Image alt text is now <b>%s</b>.", arg))
+}
@@ -0,0 +1,218 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/research"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdAPIKey manages named research API keys.
+	CmdAPIKey = "apikey"
+
+	apiKeyOptScope = "scope:"
+	apiKeyOptRate  = "rate:"
+
+	defaultAPIKeyRatePerMinute = 60
+	maxAPIKeyRatePerMinute     = 6000
+)
+
+var (
+	errEmptyAPIKeyName   = errors.New("API key name is empty")
+	errInvalidAPIKeyName = errors.New("API key name must be a single word")
+	errInvalidAPIKeyOpt  = errors.New("invalid API key option")
+)
+
+// apiKeySpec is a parsed /apikey create request.
+type apiKeySpec struct {
+	Name          string
+	Scope         string
+	RatePerMinute int
+}
+
+// parseAPIKeySpec parses `<name> [scope:read|label] [rate:<per minute>]`.
+func parseAPIKeySpec(args string) (apiKeySpec, error) {
+	spec := apiKeySpec{Scope: db.ResearchAPIKeyScopeRead, RatePerMinute: defaultAPIKeyRatePerMinute}
+
+	var names []string
+
+	for _, tok := range strings.Fields(args) {
+		lower := strings.ToLower(tok)
+
+		switch {
+		case strings.HasPrefix(lower, apiKeyOptScope):
+			scope := lower[len(apiKeyOptScope):]
+			if scope != db.ResearchAPIKeyScopeRead && scope != db.ResearchAPIKeyScopeLabel {
+				return apiKeySpec{}, fmt.Errorf("%w: %s", errInvalidAPIKeyOpt, tok)
+			}
+
+			spec.Scope = scope
+		case strings.HasPrefix(lower, apiKeyOptRate):
+			rate, err := strconv.Atoi(lower[len(apiKeyOptRate):])
+			if err != nil || rate < 1 || rate > maxAPIKeyRatePerMinute {
+				return apiKeySpec{}, fmt.Errorf("%w: %s", errInvalidAPIKeyOpt, tok)
+			}
+
+			spec.RatePerMinute = rate
+		default:
+			names = append(names, tok)
+		}
+	}
+
+	switch len(names) {
+	case 0:
+		return apiKeySpec{}, errEmptyAPIKeyName
+	case 1:
+		spec.Name = names[0]
+	default:
+		return apiKeySpec{}, errInvalidAPIKeyName
+	}
+
+	return spec, nil
+}
+
+func (b *Bot) handleAPIKey(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.TrimSpace(msg.CommandArguments())
+	sub, rest, _ := strings.Cut(args, " ")
+
+	switch strings.ToLower(sub) {
+	case "create", "add":
+		b.handleAPIKeyCreate(ctx, msg, strings.TrimSpace(rest))
+	case "list", "":
+		b.handleAPIKeyList(ctx, msg)
+	case "revoke", "remove", "rm":
+		b.handleAPIKeyRevoke(ctx, msg, strings.TrimSpace(rest))
+	case "rate":
+		b.handleAPIKeyRate(ctx, msg, strings.TrimSpace(rest))
+	default:
+		b.replyAPIKeyUsage(msg)
+	}
+}
+
+func (b *Bot) replyAPIKeyUsage(msg *tgbotapi.Message) {
+	b.reply(msg, "🔑 <b>Research API Keys</b>\n"+
+		"• <code>/apikey create &lt;name&gt; [scope:read|label] [rate:&lt;per minute&gt;]</code>\n"+
+		"• <code>/apikey list</code>\n"+
+		"• <code>/apikey rate &lt;name&gt; &lt;per minute&gt;</code>\n"+
+		"• <code>/apikey revoke &lt;name&gt;</code>\n\n"+
+		"Send the key in the <code>X-API-Key</code> header or as a bearer token. "+
+		"<code>read</code> keys can only read; <code>label</code> keys can also annotate and label items.")
+}
+
+func (b *Bot) handleAPIKeyCreate(ctx context.Context, msg *tgbotapi.Message, args string) {
+	spec, err := parseAPIKeySpec(args)
+	if err != nil {
+		b.replyAPIKeyUsage(msg)
+
+		return
+	}
+
+	key, err := research.GenerateAPIKey()
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if _, err := b.database.CreateResearchAPIKey(ctx, spec.Name, research.HashAPIKey(key), spec.Scope, spec.RatePerMinute, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ API key <b>%s</b> created (%s, %d requests/min):\n<code>%s</code>\n\n"+
+		"Store it now — it cannot be shown again.", html.EscapeString(spec.Name), spec.Scope, spec.RatePerMinute, key))
+}
+
+func (b *Bot) handleAPIKeyList(ctx context.Context, msg *tgbotapi.Message) {
+	keys, err := b.database.ListResearchAPIKeys(ctx)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(keys) == 0 {
+		b.reply(msg, "No API keys. Create one with <code>/apikey create &lt;name&gt;</code>.")
+
+		return
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("🔑 <b>Research API Keys</b>\n\n")
+
+	for _, k := range keys {
+		lastUsed := "never"
+		if k.LastUsedAt != nil {
+			lastUsed = k.LastUsedAt.Format(time.DateTime)
+		}
+
+		sb.WriteString(fmt.Sprintf("<b>%s</b> %s, %d/min\n<i>last used: %s</i>\n", html.EscapeString(k.Name), k.Scope, k.RatePerMinute, lastUsed))
+	}
+
+	b.reply(msg, sb.String())
+}
+
+func (b *Bot) handleAPIKeyRevoke(ctx context.Context, msg *tgbotapi.Message, name string) {
+	if name == "" {
+		b.reply(msg, "Usage: <code>/apikey revoke &lt;name&gt;</code>")
+
+		return
+	}
+
+	if err := b.database.DeleteResearchAPIKey(ctx, name); err != nil {
+		if errors.Is(err, db.ErrResearchAPIKeyNotFound) {
+			b.reply(msg, fmt.Sprintf("❌ API key <b>%s</b> not found.", html.EscapeString(name)))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ API key <b>%s</b> revoked.", html.EscapeString(name)))
+}
+
+func (b *Bot) handleAPIKeyRate(ctx context.Context, msg *tgbotapi.Message, args string) {
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		b.reply(msg, "Usage: <code>/apikey rate &lt;name&gt; &lt;per minute&gt;</code>")
+
+		return
+	}
+
+	name := fields[0]
+
+	rate, err := strconv.Atoi(fields[1])
+	if err != nil || rate < 1 || rate > maxAPIKeyRatePerMinute {
+		b.reply(msg, fmt.Sprintf("❌ Rate must be between 1 and %d requests per minute.", maxAPIKeyRatePerMinute))
+
+		return
+	}
+
+	if err := b.database.SetResearchAPIKeyRate(ctx, name, rate); err != nil {
+		if errors.Is(err, db.ErrResearchAPIKeyNotFound) {
+			b.reply(msg, fmt.Sprintf("❌ API key <b>%s</b> not found.", html.EscapeString(name)))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ API key <b>%s</b> now allows %d requests/min.", html.EscapeString(name), rate))
+}
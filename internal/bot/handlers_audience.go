@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdStats shows statistics of the target channel.
+	CmdStats = "stats"
+
+	statsSubCmdAudience = "audience"
+	audienceDefaultDays = 30
+	audienceMaxDays     = 365
+	audienceTopicsShown = 8
+)
+
+const statsUsage = "Usage: <code>/stats audience [days]</code>\n\n" +
+	"Shows the member count of the target channel and the views of recent digests by posting hour, size and topic. " +
+	"The reader records them every few hours; its account must have joined the target channel."
+
+func (b *Bot) handleStats(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 || len(args) > 2 || !strings.EqualFold(args[0], statsSubCmdAudience) {
+		b.reply(msg, statsUsage)
+
+		return
+	}
+
+	days := audienceDefaultDays
+
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 || n > audienceMaxDays {
+			b.reply(msg, statsUsage)
+
+			return
+		}
+
+		days = n
+	}
+
+	now := time.Now()
+	since := now.AddDate(0, 0, -days)
+
+	snapshots, err := b.database.GetAudienceSnapshots(ctx, since, now)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	digests, err := b.database.GetDigestEngagement(ctx, since, now)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(snapshots) == 0 && len(digests) == 0 {
+		b.reply(msg, "ℹ️ No audience stats recorded yet.\n\n"+statsUsage)
+
+		return
+	}
+
+	loc := time.UTC
+
+	if sched := b.loadDigestSchedule(ctx); !sched.IsEmpty() {
+		if schedLoc, err := sched.Location(); err == nil {
+			loc = schedLoc
+		}
+	}
+
+	b.reply(msg, formatAudienceReport(days, db.BuildAudienceReport(snapshots, digests, loc), loc))
+}
+
+// formatAudienceReport renders the member growth and the digest views by
+// posting hour, size and topic.
+func formatAudienceReport(days int, report db.AudienceReport, loc *time.Location) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "👥 <b>Audience</b> (last %d days)\n", days)
+
+	if n := len(report.Snapshots); n > 0 {
+		latest := report.Snapshots[n-1].MemberCount
+		fmt.Fprintf(&sb, "Members: <code>%d</code> (%+d)\n", latest, latest-report.Snapshots[0].MemberCount)
+	}
+
+	if len(report.Digests) == 0 {
+		return sb.String()
+	}
+
+	views, reach, reachDigests := 0, 0.0, 0
+
+	for _, d := range report.Digests {
+		views += d.Views
+
+		if d.Members > 0 {
+			reach += d.ViewRate()
+			reachDigests++
+		}
+	}
+
+	fmt.Fprintf(&sb, "Digests: <code>%d</code> · avg views <code>%.0f</code>", len(report.Digests), float64(views)/float64(len(report.Digests)))
+
+	if reachDigests > 0 {
+		fmt.Fprintf(&sb, " · reach <code>%.0f%%</code>", reach/float64(reachDigests)*percentScale)
+	}
+
+	sb.WriteString("\n")
+
+	writeEngagementBuckets(&sb, fmt.Sprintf("By posting hour (%s)", html.EscapeString(loc.String())), report.ByHour, len(report.ByHour))
+	writeEngagementBuckets(&sb, "By size", report.BySize, len(report.BySize))
+	writeEngagementBuckets(&sb, "By topic", report.ByTopic, audienceTopicsShown)
+
+	return sb.String()
+}
+
+func writeEngagementBuckets(sb *strings.Builder, title string, buckets []db.EngagementBucket, limit int) {
+	if len(buckets) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "\n<b>%s</b>\n", title)
+
+	for i, bucket := range buckets {
+		if i >= limit {
+			break
+		}
+
+		fmt.Fprintf(sb, "• %s: %d digests · %.0f views", html.EscapeString(bucket.Label), bucket.Digests, bucket.AvgViews)
+
+		if bucket.AvgViewRate > 0 {
+			fmt.Fprintf(sb, " · %.0f%%", bucket.AvgViewRate*percentScale)
+		}
+
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// CmdCatchup DMs a recap of important items since the user's last read.
+	CmdCatchup = "catchup"
+
+	// catchupReadKeyFmt stores each user's digest read marker.
+	catchupReadKeyFmt = "catchup_read:%d"
+
+	// catchupDefaultLookback is used when a user has no read marker yet.
+	catchupDefaultLookback = 24 * time.Hour
+	// catchupMaxLookback bounds the recap window to keep builds fast.
+	catchupMaxLookback = 7 * HoursPerDay * time.Hour
+
+	catchupDateLayout = "2006-01-02"
+)
+
+var errInvalidCatchupSince = errors.New("invalid catchup start")
+
+// parseCatchupSince parses an explicit recap start: a duration (12h, 3d),
+// a date (2006-01-02) or an RFC 3339 timestamp.
+func parseCatchupSince(arg string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(arg, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.Add(-time.Duration(n) * HoursPerDay * time.Hour), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(arg); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+
+	if t, err := time.ParseInLocation(catchupDateLayout, arg, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, arg); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %s", errInvalidCatchupSince, arg)
+}
+
+// clampCatchupSince keeps the recap window within catchupMaxLookback and
+// before now.
+func clampCatchupSince(since, now time.Time) time.Time {
+	if earliest := now.Add(-catchupMaxLookback); since.Before(earliest) {
+		return earliest
+	}
+
+	return since
+}
+
+// catchupSince resolves the recap start from the argument or the user's read marker.
+func (b *Bot) catchupSince(ctx context.Context, userID int64, arg string, now time.Time) (time.Time, error) {
+	if arg != "" {
+		return parseCatchupSince(arg, now)
+	}
+
+	var lastRead time.Time
+	if err := b.database.GetSetting(ctx, fmt.Sprintf(catchupReadKeyFmt, userID), &lastRead); err != nil {
+		b.logger.Debug().Err(err).Int64(LogFieldUserID, userID).Msg("no catchup read marker, using default lookback")
+	}
+
+	if lastRead.IsZero() {
+		return now.Add(-catchupDefaultLookback), nil
+	}
+
+	return lastRead, nil
+}
+
+// markCatchupRead moves the user's read marker forward.
+func (b *Bot) markCatchupRead(ctx context.Context, userID int64, at time.Time) {
+	if err := b.database.SaveSetting(ctx, fmt.Sprintf(catchupReadKeyFmt, userID), at); err != nil {
+		b.logger.Warn().Err(err).Int64(LogFieldUserID, userID).Msg("failed to save catchup read marker")
+	}
+}
+
+func (b *Bot) handleCatchup(ctx context.Context, msg *tgbotapi.Message) {
+	if args := strings.Fields(msg.CommandArguments()); len(args) > 0 && strings.EqualFold(args[0], catchupSubSchedule) {
+		b.handleCatchupSchedule(ctx, msg, args[1:])
+
+		return
+	}
+
+	if b.digestBuilder == nil {
+		b.reply(msg, "❌ Catch-up is not available in this mode.")
+
+		return
+	}
+
+	now := time.Now()
+
+	since, err := b.catchupSince(ctx, msg.From.ID, strings.TrimSpace(msg.CommandArguments()), now)
+	if err != nil || !since.Before(now) {
+		b.reply(msg, "Usage: <code>/catchup [since]</code>\n\n"+
+			"Without an argument, recaps everything since your last read (rating a digest or running /catchup). "+
+			"<code>since</code> can be a duration (<code>12h</code>, <code>3d</code>), a date (<code>2026-01-31</code>) or an RFC 3339 time.")
+
+		return
+	}
+
+	since = clampCatchupSince(since, now)
+
+	text, err := b.catchupRecap(ctx, since, now)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error building recap: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if text == "" {
+		b.reply(msg, fmt.Sprintf("✅ You're all caught up: nothing important since %s.", since.Format(time.DateTime)))
+		b.markCatchupRead(ctx, msg.From.ID, now)
+
+		return
+	}
+
+	// Recaps are personal: always deliver them privately, never to a group.
+	if _, err := b.SendDigest(ctx, msg.From.ID, text, ""); err != nil {
+		b.reply(msg, "❌ Could not send you a private message. Start a chat with the bot first.")
+
+		return
+	}
+
+	b.markCatchupRead(ctx, msg.From.ID, now)
+
+	if msg.Chat.ID != msg.From.ID {
+		b.reply(msg, "📬 Sent your catch-up in a private message.")
+	}
+}
+
+// catchupRecap builds the recap of items between since and now, or "" when
+// nothing important is new. The header shows since in its location.
+func (b *Bot) catchupRecap(ctx context.Context, since, now time.Time) (string, error) {
+	_, threshold := b.getPreviewParams(ctx)
+
+	text, items, _, err := b.buildPreviewDigest(ctx, since, now, threshold)
+	if err != nil || text == "" {
+		return "", err
+	}
+
+	header := fmt.Sprintf("📬 <b>Catch-up</b> since %s (%d items)\n\n", since.Format(time.DateTime), len(items))
+
+	return header + text, nil
+}
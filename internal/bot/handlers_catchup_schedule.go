@@ -0,0 +1,225 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/schedule"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	catchupSubSchedule = "schedule"
+	catchupScheduleOff = "off"
+
+	// catchupScheduleInterval is how often scheduled recaps are checked.
+	catchupScheduleInterval = time.Minute
+
+	catchupScheduleUsage = "Usage:\n" +
+		"<code>/catchup schedule</code> - Show your schedule\n" +
+		"<code>/catchup schedule &lt;timezone&gt; &lt;HH:MM-HH:MM&gt;[,&lt;HH:MM-HH:MM&gt;...]</code> - Receive a recap in each window\n" +
+		"<code>/catchup schedule off</code> - Stop scheduled recaps\n\n" +
+		"Example: <code>/catchup schedule Europe/Berlin 07:30-09:00,18:00-20:00</code>"
+)
+
+// handleCatchupSchedule shows, sets or removes the user's scheduled recap
+// delivery windows.
+func (b *Bot) handleCatchupSchedule(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	userID := msg.From.ID
+
+	switch {
+	case len(args) == 0:
+		b.replyCatchupSchedule(ctx, msg, userID)
+	case len(args) == 1 && strings.EqualFold(args[0], catchupScheduleOff):
+		deleted, err := b.database.DeleteCatchupSchedule(ctx, userID)
+		if err != nil {
+			b.reply(msg, fmt.Sprintf("❌ Error removing schedule: %s", html.EscapeString(err.Error())))
+
+			return
+		}
+
+		if !deleted {
+			b.reply(msg, "You have no scheduled catch-up.")
+
+			return
+		}
+
+		b.reply(msg, "✅ Scheduled catch-up turned off.")
+	case len(args) == 2:
+		b.saveCatchupSchedule(ctx, msg, userID, args[0], args[1])
+	default:
+		b.reply(msg, catchupScheduleUsage)
+	}
+}
+
+func (b *Bot) saveCatchupSchedule(ctx context.Context, msg *tgbotapi.Message, userID int64, timezone, value string) {
+	timezone = schedule.NormalizeTimezone(timezone)
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Unknown timezone <code>%s</code>. Use an IANA name such as <code>Europe/Berlin</code>.", html.EscapeString(timezone)))
+
+		return
+	}
+
+	windows, err := schedule.ParseDeliveryWindows(value)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Invalid windows: %s\n\n%s", html.EscapeString(err.Error()), catchupScheduleUsage))
+
+		return
+	}
+
+	s := db.CatchupSchedule{UserID: userID, Timezone: timezone, Windows: schedule.FormatDeliveryWindows(windows)}
+	if err := b.database.SaveCatchupSchedule(ctx, s); err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error saving schedule: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ "+formatCatchupSchedule(s, windows, loc, time.Now())+
+		"\n\nRecaps are sent privately once per window and only when something important is new.")
+}
+
+func (b *Bot) replyCatchupSchedule(ctx context.Context, msg *tgbotapi.Message, userID int64) {
+	s, err := b.database.GetCatchupSchedule(ctx, userID)
+	if errors.Is(err, db.ErrCatchupScheduleNotFound) {
+		b.reply(msg, "You have no scheduled catch-up.\n\n"+catchupScheduleUsage)
+
+		return
+	}
+
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error loading schedule: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	loc, windows, err := parseCatchupSchedule(*s)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Stored schedule is invalid: %s\n\n%s", html.EscapeString(err.Error()), catchupScheduleUsage))
+
+		return
+	}
+
+	b.reply(msg, formatCatchupSchedule(*s, windows, loc, time.Now()))
+}
+
+// formatCatchupSchedule describes a schedule and its next window.
+func formatCatchupSchedule(s db.CatchupSchedule, windows []schedule.DeliveryWindow, loc *time.Location, now time.Time) string {
+	text := fmt.Sprintf("🗓 <b>Scheduled catch-up</b>: %s (%s)", html.EscapeString(s.Windows), html.EscapeString(s.Timezone))
+
+	if next, ok := schedule.NextWindowStart(windows, now, loc); ok {
+		text += fmt.Sprintf("\nNext window: %s", next.Format(time.DateTime))
+	}
+
+	return text
+}
+
+// parseCatchupSchedule resolves the stored time zone and windows.
+func parseCatchupSchedule(s db.CatchupSchedule) (*time.Location, []schedule.DeliveryWindow, error) {
+	loc, err := time.LoadLocation(schedule.NormalizeTimezone(s.Timezone))
+	if err != nil {
+		return nil, nil, fmt.Errorf("load timezone: %w", err)
+	}
+
+	windows, err := schedule.ParseDeliveryWindows(s.Windows)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse windows: %w", err)
+	}
+
+	return loc, windows, nil
+}
+
+// catchupDue returns the start of the window now falls in if no recap was
+// delivered in it yet.
+func catchupDue(s db.CatchupSchedule, loc *time.Location, windows []schedule.DeliveryWindow, now time.Time) (time.Time, bool) {
+	start, ok := schedule.CurrentWindowStart(windows, now, loc)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if s.LastDeliveredAt != nil && !s.LastDeliveredAt.Before(start) {
+		return time.Time{}, false
+	}
+
+	return start, true
+}
+
+// runCatchupSchedules delivers scheduled recaps until ctx is canceled.
+func (b *Bot) runCatchupSchedules(ctx context.Context) {
+	ticker := time.NewTicker(catchupScheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			b.deliverScheduledCatchups(ctx, now)
+		}
+	}
+}
+
+func (b *Bot) deliverScheduledCatchups(ctx context.Context, now time.Time) {
+	schedules, err := b.database.ListCatchupSchedules(ctx)
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to list catchup schedules")
+
+		return
+	}
+
+	for _, s := range schedules {
+		loc, windows, err := parseCatchupSchedule(s)
+		if err != nil {
+			b.logger.Warn().Err(err).Int64(LogFieldUserID, s.UserID).Msg("skipping invalid catchup schedule")
+
+			continue
+		}
+
+		windowStart, due := catchupDue(s, loc, windows, now)
+		if !due || !b.isAdmin(ctx, s.UserID) {
+			continue
+		}
+
+		b.deliverScheduledCatchup(ctx, s.UserID, loc, windowStart, now)
+	}
+}
+
+// deliverScheduledCatchup sends the user's recap for the current window.
+// Windows without anything new are marked delivered without a message, and
+// a failed send is not retried until the next window.
+func (b *Bot) deliverScheduledCatchup(ctx context.Context, userID int64, loc *time.Location, windowStart, now time.Time) {
+	since, err := b.catchupSince(ctx, userID, "", now)
+	if err != nil {
+		b.logger.Warn().Err(err).Int64(LogFieldUserID, userID).Msg("failed to resolve catchup start")
+
+		return
+	}
+
+	since = clampCatchupSince(since, now)
+
+	text, err := b.catchupRecap(ctx, since.In(loc), now)
+	if err != nil {
+		b.logger.Warn().Err(err).Int64(LogFieldUserID, userID).Msg("failed to build scheduled catchup")
+
+		return
+	}
+
+	if text != "" {
+		if _, err := b.SendDigest(ctx, userID, text, ""); err != nil {
+			b.logger.Warn().Err(err).Int64(LogFieldUserID, userID).Msg("failed to send scheduled catchup")
+		} else {
+			b.markCatchupRead(ctx, userID, now)
+		}
+	}
+
+	if err := b.database.MarkCatchupScheduleDelivered(ctx, userID, windowStart); err != nil {
+		b.logger.Warn().Err(err).Int64(LogFieldUserID, userID).Msg("failed to mark catchup schedule delivered")
+	}
+}
@@ -0,0 +1,270 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	SubCmdGroup = "group"
+
+	channelGroupStatsPeriod = 7 * 24 * time.Hour
+	channelGroupOff         = "off"
+
+	channelGroupUsage = "📁 <b>Channel Groups</b>\n" +
+		"• <code>/channel group list</code>\n" +
+		"• <code>/channel group create &lt;name&gt;</code>\n" +
+		"• <code>/channel group delete &lt;name&gt;</code>\n" +
+		"• <code>/channel group add &lt;name&gt; @chan1 @chan2 ...</code>\n" +
+		"• <code>/channel group remove @chan1 @chan2 ...</code>\n" +
+		"• <code>/channel group threshold &lt;name&gt; &lt;relevance&gt; [importance]</code> (0 = global)\n" +
+		"• <code>/channel group digest &lt;name&gt; &lt;@chat|ID|off&gt; [window]</code>\n\n" +
+		"Groups with a digest target get their own digest and are left out of the main digest."
+)
+
+var (
+	errInvalidGroupThreshold = errors.New("invalid group threshold")
+
+	channelGroupNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_-]{1,32}$`)
+)
+
+func (b *Bot) handleChannelGroup(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		b.handleChannelGroupList(ctx, msg)
+
+		return
+	}
+
+	sub, rest := strings.ToLower(args[0]), args[1:]
+
+	switch {
+	case sub == CmdList:
+		b.handleChannelGroupList(ctx, msg)
+	case sub == "create" && len(rest) == 1:
+		b.handleChannelGroupCreate(ctx, msg, rest[0])
+	case sub == "delete" && len(rest) == 1:
+		b.replyChannelGroupResult(ctx, msg, rest[0], b.database.DeleteChannelGroup(ctx, rest[0]), tr(ctx, "✅ Group <b>%s</b> deleted; its channels are ungrouped.", html.EscapeString(rest[0])))
+	case sub == CmdAdd && len(rest) >= 2:
+		b.reply(msg, b.assignChannelGroup(ctx, rest[0], rest[1:]))
+	case sub == CmdRemove && len(rest) >= 1:
+		b.reply(msg, b.assignChannelGroup(ctx, "", rest))
+	case sub == "threshold" && (len(rest) == 2 || len(rest) == 3):
+		b.handleChannelGroupThreshold(ctx, msg, rest[0], rest[1:])
+	case sub == "digest" && (len(rest) == 2 || len(rest) == 3):
+		b.handleChannelGroupDigest(ctx, msg, rest[0], rest[1:])
+	default:
+		b.reply(msg, tr(ctx, channelGroupUsage))
+	}
+}
+
+func (b *Bot) handleChannelGroupList(ctx context.Context, msg *tgbotapi.Message) {
+	groups, err := b.database.ListChannelGroups(ctx)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error fetching channel groups: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(groups) == 0 {
+		b.reply(msg, tr(ctx, "No channel groups yet.")+"\n\n"+tr(ctx, channelGroupUsage))
+
+		return
+	}
+
+	b.reply(msg, formatChannelGroups(ctx, groups))
+}
+
+func formatChannelGroups(ctx context.Context, groups []db.ChannelGroup) string {
+	var sb strings.Builder
+
+	sb.WriteString(tr(ctx, "📁 <b>Channel Groups</b>\n\n"))
+
+	for _, g := range groups {
+		fmt.Fprintf(&sb, "• <b>%s</b> · %s\n", html.EscapeString(g.Name), tr(ctx, "%d channels", g.ChannelCount))
+		fmt.Fprintf(&sb, "  ├ %s\n", tr(ctx, "Thresholds: relevance <code>%s</code>, importance <code>%s</code>",
+			formatGroupThreshold(g.RelevanceThreshold), formatGroupThreshold(g.ImportanceThreshold)))
+
+		if g.TargetChatID == 0 {
+			fmt.Fprintf(&sb, "  └ %s\n", tr(ctx, "Digest: main"))
+
+			continue
+		}
+
+		fmt.Fprintf(&sb, "  └ %s\n", tr(ctx, "Digest: <code>%d</code> every <code>%s</code>", g.TargetChatID, html.EscapeString(groupDigestWindowLabel(g.DigestWindow))))
+	}
+
+	return sb.String()
+}
+
+// groupDigestWindowLabel shows an unset window as the default window.
+func groupDigestWindowLabel(window string) string {
+	if window != "" {
+		return window
+	}
+
+	return fmt.Sprintf("%.0fh", digest.DefaultGroupDigestWindow.Hours())
+}
+
+func formatGroupThreshold(v float32) string {
+	if v == 0 {
+		return "global"
+	}
+
+	return strconv.FormatFloat(float64(v), 'f', -1, 32)
+}
+
+func (b *Bot) handleChannelGroupCreate(ctx context.Context, msg *tgbotapi.Message, name string) {
+	if !channelGroupNamePattern.MatchString(name) {
+		b.reply(msg, tr(ctx, "❌ Group names are one word of up to 32 letters, digits, <code>-</code> or <code>_</code>."))
+
+		return
+	}
+
+	if err := b.database.CreateChannelGroup(ctx, name); err != nil {
+		b.reply(msg, tr(ctx, "❌ Error creating group: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, tr(ctx, "✅ Group <b>%s</b> created. Add channels with <code>/channel group add %s @channel</code>.", html.EscapeString(name), html.EscapeString(name)))
+}
+
+// assignChannelGroup moves the channels into the group, or out of their group
+// when name is empty, and returns one result line per channel.
+func (b *Bot) assignChannelGroup(ctx context.Context, name string, identifiers []string) string {
+	lines := make([]string, 0, len(identifiers))
+
+	for _, id := range identifiers {
+		found, err := b.database.SetChannelGroup(ctx, id, name)
+
+		switch {
+		case errors.Is(err, db.ErrChannelGroupNotFound):
+			return tr(ctx, "❌ Group <b>%s</b> not found.", html.EscapeString(name))
+		case err != nil:
+			lines = append(lines, fmt.Sprintf("❌ %s: %s", html.EscapeString(id), html.EscapeString(err.Error())))
+		case !found:
+			lines = append(lines, tr(ctx, "❓ %s: channel not tracked", html.EscapeString(id)))
+		case name == "":
+			lines = append(lines, tr(ctx, "✅ %s: ungrouped", html.EscapeString(id)))
+		default:
+			lines = append(lines, fmt.Sprintf("✅ %s → %s", html.EscapeString(id), html.EscapeString(name)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (b *Bot) handleChannelGroupThreshold(ctx context.Context, msg *tgbotapi.Message, name string, args []string) {
+	values := make([]float32, 2)
+
+	for i, arg := range args {
+		v, err := parseGroupThreshold(arg)
+		if err != nil {
+			b.reply(msg, tr(ctx, "❌ Thresholds must be numbers between 0 and 1 (0 = global)."))
+
+			return
+		}
+
+		values[i] = v
+	}
+
+	err := b.database.SetChannelGroupThresholds(ctx, name, values[0], values[1])
+	b.replyChannelGroupResult(ctx, msg, name, err, tr(ctx, "✅ Thresholds of <b>%s</b>: relevance <code>%s</code>, importance <code>%s</code>.",
+		html.EscapeString(name), formatGroupThreshold(values[0]), formatGroupThreshold(values[1])))
+}
+
+func parseGroupThreshold(arg string) (float32, error) {
+	v, err := strconv.ParseFloat(arg, 32)
+	if err != nil || v < 0 || v > 1 {
+		return 0, fmt.Errorf("%w: %s", errInvalidGroupThreshold, arg)
+	}
+
+	return float32(v), nil
+}
+
+func (b *Bot) handleChannelGroupDigest(ctx context.Context, msg *tgbotapi.Message, name string, args []string) {
+	if strings.EqualFold(args[0], channelGroupOff) {
+		err := b.database.SetChannelGroupDigest(ctx, name, 0, "")
+		b.replyChannelGroupResult(ctx, msg, name, err, tr(ctx, "✅ Group <b>%s</b> is back in the main digest.", html.EscapeString(name)))
+
+		return
+	}
+
+	window := ""
+	if len(args) > 1 {
+		if d, err := time.ParseDuration(args[1]); err != nil || d < time.Hour {
+			b.reply(msg, tr(ctx, "❌ Invalid window. Use a duration of at least 1h, e.g. <code>6h</code>."))
+
+			return
+		}
+
+		window = args[1]
+	}
+
+	chatID, chat, errMsg := b.resolveTargetChat(ctx, args[0])
+	if errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	confirmation := tr(ctx, "✅ This chat will receive the <b>%s</b> group digest.", html.EscapeString(name))
+	if errMsg := b.verifyTargetChatPermissions(ctx, chatID, chat, confirmation); errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	err := b.database.SetChannelGroupDigest(ctx, name, chatID, window)
+	b.replyChannelGroupResult(ctx, msg, name, err, tr(ctx, "✅ Group <b>%s</b> digest goes to <b>%s</b> every <code>%s</code>.",
+		html.EscapeString(name), html.EscapeString(chat.Title), html.EscapeString(groupDigestWindowLabel(window))))
+}
+
+func (b *Bot) replyChannelGroupResult(ctx context.Context, msg *tgbotapi.Message, name string, err error, success string) {
+	switch {
+	case errors.Is(err, db.ErrChannelGroupNotFound):
+		b.reply(msg, tr(ctx, "❌ Group <b>%s</b> not found.", html.EscapeString(name)))
+	case err != nil:
+		b.reply(msg, tr(ctx, "❌ Error updating group: %s", html.EscapeString(err.Error())))
+	default:
+		b.reply(msg, success)
+	}
+}
+
+// formatChannelGroupStats renders the per-group breakdown of /channel stats.
+// It is empty when no groups exist.
+func formatChannelGroupStats(ctx context.Context, stats []db.ChannelGroupStats) string {
+	if len(stats) == 0 || (len(stats) == 1 && stats[0].GroupID == "") {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(tr(ctx, "📁 <b>Groups (Last 7 Days)</b>\n"))
+
+	for _, s := range stats {
+		name := html.EscapeString(s.Name)
+		if s.GroupID == "" {
+			name = tr(ctx, "<i>ungrouped</i>")
+		}
+
+		fmt.Fprintf(&sb, "• <b>%s</b> · %s\n", name, tr(ctx, "%d channels · %d items · %d digested (%.0f%%) · avg importance <code>%.2f</code>",
+			s.Channels, s.ItemsCreated, s.ItemsDigested, s.ConversionRate*percentageMultiplier, s.AvgImportance))
+	}
+
+	sb.WriteString("\n")
+
+	return sb.String()
+}
@@ -0,0 +1,749 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	SubCmdExport = "export"
+	SubCmdImport = "import"
+
+	channelFormatJSON     = "json"
+	channelFormatCSV      = "csv"
+	channelImportApplyArg = "apply"
+
+	channelImportMaxSize    = 1 << 20
+	channelImportTimeout    = 30 * time.Second
+	channelImportMinWeight  = 0.1
+	channelImportMaxWeight  = 2.0
+	channelImportValueLimit = 40
+
+	channelExportDateLayout = "20060102"
+
+	channelImportUsage = "Usage: send a JSON or CSV file with the caption <code>/channel import</code>, " +
+		"or reply to one with <code>/channel import [apply]</code>.\n\n" +
+		"Without <code>apply</code> only the diff is shown. <code>/channel export</code> produces the file format."
+)
+
+var (
+	errChannelImportTooLarge = errors.New("import file too large")
+	errChannelImportFormat   = errors.New("unsupported import file")
+	errChannelImportDownload = errors.New("download import file")
+	errChannelRecordInvalid  = errors.New("invalid channel record")
+
+	channelUsernamePattern = regexp.MustCompile(`^@?[A-Za-z][A-Za-z0-9_]{3,31}$`)
+)
+
+// channelRecord is one channel in an export file. Username, peer_id or
+// invite_link identifies the channel on import; title is informational.
+type channelRecord struct {
+	Username            string  `json:"username,omitempty"`
+	PeerID              int64   `json:"peer_id,omitempty"`
+	InviteLink          string  `json:"invite_link,omitempty"`
+	Title               string  `json:"title,omitempty"`
+	Context             string  `json:"context,omitempty"`
+	Category            string  `json:"category,omitempty"`
+	Tone                string  `json:"tone,omitempty"`
+	UpdateFreq          string  `json:"update_freq,omitempty"`
+	RelevanceThreshold  float32 `json:"relevance_threshold"`
+	ImportanceThreshold float32 `json:"importance_threshold"`
+	ImportanceWeight    float32 `json:"importance_weight"`
+	AutoWeight          bool    `json:"auto_weight"`
+	WeightOverride      bool    `json:"weight_override"`
+	AutoRelevance       bool    `json:"auto_relevance"`
+	RelevanceDelta      float32 `json:"relevance_delta"`
+}
+
+// channelRecordField is one column of the CSV format. Setting fields are
+// compared in the import diff and written on apply.
+type channelRecordField struct {
+	name    string
+	setting bool
+	get     func(r *channelRecord) string
+	set     func(r *channelRecord, v string) error
+}
+
+func textField(name string, setting bool, ptr func(r *channelRecord) *string) channelRecordField {
+	return channelRecordField{
+		name:    name,
+		setting: setting,
+		get:     func(r *channelRecord) string { return *ptr(r) },
+		set: func(r *channelRecord, v string) error {
+			*ptr(r) = strings.TrimSpace(v)
+
+			return nil
+		},
+	}
+}
+
+func floatField(name string, ptr func(r *channelRecord) *float32) channelRecordField {
+	return channelRecordField{
+		name:    name,
+		setting: true,
+		get:     func(r *channelRecord) string { return strconv.FormatFloat(float64(*ptr(r)), 'f', -1, 32) },
+		set: func(r *channelRecord, v string) error {
+			if strings.TrimSpace(v) == "" {
+				*ptr(r) = 0
+
+				return nil
+			}
+
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 32)
+			if err != nil {
+				return fmt.Errorf("%w: %s %q", errChannelRecordInvalid, name, v)
+			}
+
+			*ptr(r) = float32(f)
+
+			return nil
+		},
+	}
+}
+
+func boolField(name string, ptr func(r *channelRecord) *bool) channelRecordField {
+	return channelRecordField{
+		name:    name,
+		setting: true,
+		get:     func(r *channelRecord) string { return strconv.FormatBool(*ptr(r)) },
+		set: func(r *channelRecord, v string) error {
+			if strings.TrimSpace(v) == "" {
+				*ptr(r) = false
+
+				return nil
+			}
+
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("%w: %s %q", errChannelRecordInvalid, name, v)
+			}
+
+			*ptr(r) = b
+
+			return nil
+		},
+	}
+}
+
+var channelRecordFields = []channelRecordField{
+	textField("username", false, func(r *channelRecord) *string { return &r.Username }),
+	{
+		name: "peer_id",
+		get: func(r *channelRecord) string {
+			if r.PeerID == 0 {
+				return ""
+			}
+
+			return strconv.FormatInt(r.PeerID, 10)
+		},
+		set: func(r *channelRecord, v string) error {
+			if strings.TrimSpace(v) == "" {
+				return nil
+			}
+
+			id, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: peer_id %q", errChannelRecordInvalid, v)
+			}
+
+			r.PeerID = id
+
+			return nil
+		},
+	},
+	textField("invite_link", false, func(r *channelRecord) *string { return &r.InviteLink }),
+	textField("title", false, func(r *channelRecord) *string { return &r.Title }),
+	textField("context", true, func(r *channelRecord) *string { return &r.Context }),
+	textField("category", true, func(r *channelRecord) *string { return &r.Category }),
+	textField("tone", true, func(r *channelRecord) *string { return &r.Tone }),
+	textField("update_freq", true, func(r *channelRecord) *string { return &r.UpdateFreq }),
+	floatField("relevance_threshold", func(r *channelRecord) *float32 { return &r.RelevanceThreshold }),
+	floatField("importance_threshold", func(r *channelRecord) *float32 { return &r.ImportanceThreshold }),
+	floatField("importance_weight", func(r *channelRecord) *float32 { return &r.ImportanceWeight }),
+	boolField("auto_weight", func(r *channelRecord) *bool { return &r.AutoWeight }),
+	boolField("weight_override", func(r *channelRecord) *bool { return &r.WeightOverride }),
+	boolField("auto_relevance", func(r *channelRecord) *bool { return &r.AutoRelevance }),
+	floatField("relevance_delta", func(r *channelRecord) *float32 { return &r.RelevanceDelta }),
+}
+
+func channelRecordFromChannel(c db.Channel) channelRecord {
+	return channelRecord{
+		Username:            c.Username,
+		PeerID:              c.TGPeerID,
+		InviteLink:          c.InviteLink,
+		Title:               c.Title,
+		Context:             c.Context,
+		Category:            c.Category,
+		Tone:                c.Tone,
+		UpdateFreq:          c.UpdateFreq,
+		RelevanceThreshold:  c.RelevanceThreshold,
+		ImportanceThreshold: c.ImportanceThreshold,
+		ImportanceWeight:    c.ImportanceWeight,
+		AutoWeight:          c.AutoWeightEnabled,
+		WeightOverride:      c.WeightOverride,
+		AutoRelevance:       c.AutoRelevanceEnabled,
+		RelevanceDelta:      c.RelevanceThresholdDelta,
+	}
+}
+
+func (r channelRecord) toImport() db.ChannelImport {
+	return db.ChannelImport{
+		Username:                r.Username,
+		PeerID:                  r.PeerID,
+		InviteLink:              r.InviteLink,
+		Context:                 r.Context,
+		Category:                r.Category,
+		Tone:                    r.Tone,
+		UpdateFreq:              r.UpdateFreq,
+		RelevanceThreshold:      r.RelevanceThreshold,
+		ImportanceThreshold:     r.ImportanceThreshold,
+		ImportanceWeight:        r.ImportanceWeight,
+		AutoWeightEnabled:       r.AutoWeight,
+		WeightOverride:          r.WeightOverride,
+		AutoRelevanceEnabled:    r.AutoRelevance,
+		RelevanceThresholdDelta: r.RelevanceDelta,
+	}
+}
+
+// key identifies the channel the same way ImportChannel does: username first,
+// then peer ID, then invite link.
+func (r channelRecord) key() string {
+	switch {
+	case r.Username != "":
+		return "u:" + strings.ToLower(strings.TrimPrefix(r.Username, "@"))
+	case r.PeerID != 0:
+		return "p:" + strconv.FormatInt(r.PeerID, 10)
+	default:
+		return "l:" + r.InviteLink
+	}
+}
+
+// display is the record's name in import reports.
+func (r channelRecord) display() string {
+	switch {
+	case r.Username != "":
+		return "@" + strings.TrimPrefix(r.Username, "@")
+	case r.PeerID != 0:
+		return strconv.FormatInt(r.PeerID, 10)
+	default:
+		return r.InviteLink
+	}
+}
+
+// validate checks identifiers and value ranges. A zero importance weight means
+// the default weight.
+func (r *channelRecord) validate() error {
+	if r.ImportanceWeight == 0 {
+		r.ImportanceWeight = db.DefaultImportanceWeight
+	}
+
+	if err := r.validateIdentifier(); err != nil {
+		return err
+	}
+
+	switch {
+	case r.ImportanceWeight < channelImportMinWeight || r.ImportanceWeight > channelImportMaxWeight:
+		return fmt.Errorf("%w: importance_weight %.2f is outside 0.1-2.0", errChannelRecordInvalid, r.ImportanceWeight)
+	case !inUnitRange(r.RelevanceThreshold) || !inUnitRange(r.ImportanceThreshold):
+		return fmt.Errorf("%w: thresholds must be between 0 and 1", errChannelRecordInvalid)
+	case r.RelevanceDelta < -1 || r.RelevanceDelta > 1:
+		return fmt.Errorf("%w: relevance_delta must be between -1 and 1", errChannelRecordInvalid)
+	}
+
+	return nil
+}
+
+func (r *channelRecord) validateIdentifier() error {
+	switch {
+	case r.Username == "" && r.PeerID == 0 && r.InviteLink == "":
+		return fmt.Errorf("%w: username, peer_id or invite_link is required", errChannelRecordInvalid)
+	case r.Username != "" && !channelUsernamePattern.MatchString(r.Username):
+		return fmt.Errorf("%w: username %q", errChannelRecordInvalid, r.Username)
+	case r.InviteLink != "" && !strings.Contains(r.InviteLink, "t.me/"):
+		return fmt.Errorf("%w: invite_link %q", errChannelRecordInvalid, r.InviteLink)
+	}
+
+	return nil
+}
+
+func inUnitRange(v float32) bool {
+	return v >= 0 && v <= 1
+}
+
+// encodeChannelRecords renders records as an indented JSON array or as CSV
+// with a header row.
+func encodeChannelRecords(records []channelRecord, format string) ([]byte, error) {
+	if format == channelFormatJSON {
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encode channels: %w", err)
+		}
+
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	row := make([]string, len(channelRecordFields))
+
+	for i, f := range channelRecordFields {
+		row[i] = f.name
+	}
+
+	_ = w.Write(row)
+
+	for i := range records {
+		for j, f := range channelRecordFields {
+			row[j] = f.get(&records[i])
+		}
+
+		_ = w.Write(row)
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("encode channels: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// channelImportRow is one decoded record. Line is the CSV line or the JSON
+// array position; err is set when the record cannot be imported.
+type channelImportRow struct {
+	line   int
+	record channelRecord
+	err    error
+}
+
+// decodeChannelRecords parses an import file. The format comes from the file
+// extension, falling back to JSON when the content starts with "[".
+func decodeChannelRecords(fileName string, data []byte) ([]channelImportRow, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case "." + channelFormatJSON:
+		return decodeChannelJSON(data)
+	case "." + channelFormatCSV:
+		return decodeChannelCSV(data)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return decodeChannelJSON(data)
+	}
+
+	return decodeChannelCSV(data)
+}
+
+func decodeChannelJSON(data []byte) ([]channelImportRow, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var records []channelRecord
+	if err := dec.Decode(&records); err != nil {
+		return nil, fmt.Errorf("%w: %s", errChannelImportFormat, err.Error())
+	}
+
+	rows := make([]channelImportRow, len(records))
+	for i, r := range records {
+		rows[i] = channelImportRow{line: i + 1, record: r}
+	}
+
+	return rows, nil
+}
+
+func decodeChannelCSV(data []byte) ([]channelImportRow, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errChannelImportFormat, err.Error())
+	}
+
+	columns := make([]channelRecordField, len(header))
+
+	for i, name := range header {
+		name = strings.TrimSpace(strings.ToLower(name))
+
+		idx := slices.IndexFunc(channelRecordFields, func(f channelRecordField) bool { return f.name == name })
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: unknown column %s", errChannelImportFormat, name)
+		}
+
+		columns[i] = channelRecordFields[idx]
+	}
+
+	var rows []channelImportRow
+
+	for line := 2; ; line++ {
+		values, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errChannelImportFormat, err.Error())
+		}
+
+		rows = append(rows, decodeChannelCSVRow(line, columns, values))
+	}
+}
+
+func decodeChannelCSVRow(line int, columns []channelRecordField, values []string) channelImportRow {
+	row := channelImportRow{line: line}
+
+	if len(values) != len(columns) {
+		row.err = fmt.Errorf("%w: expected %d columns, got %d", errChannelRecordInvalid, len(columns), len(values))
+
+		return row
+	}
+
+	for i, col := range columns {
+		if err := col.set(&row.record, values[i]); err != nil {
+			row.err = err
+
+			return row
+		}
+	}
+
+	return row
+}
+
+// channelImportChange is an existing channel whose settings differ from the
+// imported record.
+type channelImportChange struct {
+	record  channelRecord
+	changes []string
+}
+
+// channelImportPlan is the dry-run diff of an import against tracked channels.
+type channelImportPlan struct {
+	added     []channelRecord
+	updated   []channelImportChange
+	unchanged int
+	invalid   []string
+}
+
+// planChannelImport validates the rows and compares them with the active
+// channels. Channels not in the file are left alone.
+func planChannelImport(existing []db.Channel, rows []channelImportRow) channelImportPlan {
+	byKey := channelRecordsByKey(existing)
+	seen := make(map[string]bool, len(rows))
+
+	var plan channelImportPlan
+
+	for _, row := range rows {
+		if err := checkChannelImportRow(&row, seen); err != nil {
+			plan.invalid = append(plan.invalid, fmt.Sprintf("line %d: %s", row.line, err.Error()))
+
+			continue
+		}
+
+		current, ok := byKey[row.record.key()]
+
+		switch changes := diffChannelRecords(current, row.record); {
+		case !ok:
+			plan.added = append(plan.added, row.record)
+		case len(changes) > 0:
+			plan.updated = append(plan.updated, channelImportChange{record: row.record, changes: changes})
+		default:
+			plan.unchanged++
+		}
+	}
+
+	return plan
+}
+
+// channelRecordsByKey indexes channels by every identifier they have, so a
+// record matches whichever identifier it uses.
+func channelRecordsByKey(channels []db.Channel) map[string]channelRecord {
+	byKey := make(map[string]channelRecord, len(channels))
+
+	for _, c := range channels {
+		rec := channelRecordFromChannel(c)
+
+		for _, key := range []channelRecord{{Username: c.Username}, {PeerID: c.TGPeerID}, {InviteLink: c.InviteLink}} {
+			if key != (channelRecord{}) {
+				byKey[key.key()] = rec
+			}
+		}
+	}
+
+	return byKey
+}
+
+// checkChannelImportRow validates the row and rejects repeated channels.
+func checkChannelImportRow(row *channelImportRow, seen map[string]bool) error {
+	if row.err != nil {
+		return row.err
+	}
+
+	if err := row.record.validate(); err != nil {
+		return err
+	}
+
+	key := row.record.key()
+	if seen[key] {
+		return fmt.Errorf("%w: duplicate of an earlier entry", errChannelRecordInvalid)
+	}
+
+	seen[key] = true
+
+	return nil
+}
+
+// diffChannelRecords lists the setting fields that differ as "field: old → new".
+func diffChannelRecords(current, next channelRecord) []string {
+	var changes []string
+
+	for _, f := range channelRecordFields {
+		if !f.setting {
+			continue
+		}
+
+		before, after := f.get(&current), f.get(&next)
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", f.name,
+				truncateAnnotationText(before, channelImportValueLimit), truncateAnnotationText(after, channelImportValueLimit)))
+		}
+	}
+
+	return changes
+}
+
+// handleChannelExport sends the active channels as a JSON or CSV file.
+func (b *Bot) handleChannelExport(ctx context.Context, msg *tgbotapi.Message) {
+	format := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+	if format == "" {
+		format = channelFormatJSON
+	}
+
+	if format != channelFormatJSON && format != channelFormatCSV {
+		b.reply(msg, tr(ctx, "Usage: <code>/channel export [json|csv]</code>"))
+
+		return
+	}
+
+	channels, err := b.database.GetActiveChannels(ctx)
+	if err != nil {
+		b.reply(msg, tr(ctx, ErrFetchingChannelsFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	records := make([]channelRecord, len(channels))
+	for i, c := range channels {
+		records[i] = channelRecordFromChannel(c)
+	}
+
+	data, err := encodeChannelRecords(records, format)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error exporting channels: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	doc := tgbotapi.NewDocument(msg.Chat.ID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("channels-%s.%s", time.Now().Format(channelExportDateLayout), format),
+		Bytes: data,
+	})
+	doc.Caption = tr(ctx, "📤 %d channels", len(records))
+
+	if _, err := b.sender.Send(doc); err != nil {
+		b.logger.Error().Err(err).Msg("failed to send channel export")
+	}
+}
+
+// handleChannelImport imports the file replied to, or attached to the message.
+func (b *Bot) handleChannelImport(ctx context.Context, msg *tgbotapi.Message) {
+	apply := strings.EqualFold(strings.TrimSpace(msg.CommandArguments()), channelImportApplyArg)
+
+	doc := msg.Document
+	if doc == nil && msg.ReplyToMessage != nil {
+		doc = msg.ReplyToMessage.Document
+	}
+
+	if doc == nil {
+		b.reply(msg, tr(ctx, channelImportUsage))
+
+		return
+	}
+
+	b.importChannels(ctx, msg, doc, apply)
+}
+
+// handleChannelImportCaption handles a file sent with "/channel import" as its
+// caption. Captions are not parsed as commands, so this is checked for every
+// non-command message; it reports whether the message was an import.
+func (b *Bot) handleChannelImportCaption(ctx context.Context, msg *tgbotapi.Message) bool {
+	fields := strings.Fields(msg.Caption)
+	if msg.Document == nil || len(fields) < 2 || fields[1] != SubCmdImport {
+		return false
+	}
+
+	if command, _, _ := strings.Cut(fields[0], "@"); command != "/"+CmdChannel {
+		return false
+	}
+
+	apply := len(fields) > 2 && strings.EqualFold(fields[2], channelImportApplyArg)
+	b.importChannels(ctx, msg, msg.Document, apply)
+
+	return true
+}
+
+func (b *Bot) importChannels(ctx context.Context, msg *tgbotapi.Message, doc *tgbotapi.Document, apply bool) {
+	data, err := b.downloadImportFile(ctx, doc)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error reading import file: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	rows, err := decodeChannelRecords(doc.FileName, data)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error reading import file: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	existing, err := b.database.GetActiveChannels(ctx)
+	if err != nil {
+		b.reply(msg, tr(ctx, ErrFetchingChannelsFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	plan := planChannelImport(existing, rows)
+
+	if !apply {
+		b.reply(msg, formatChannelImportPlan(ctx, plan, doc.FileName))
+
+		return
+	}
+
+	b.reply(msg, b.applyChannelImport(ctx, plan))
+}
+
+func (b *Bot) downloadImportFile(ctx context.Context, doc *tgbotapi.Document) ([]byte, error) {
+	if doc.FileSize > channelImportMaxSize {
+		return nil, fmt.Errorf("%w: %d bytes", errChannelImportTooLarge, doc.FileSize)
+	}
+
+	url, err := b.api.GetFileDirectURL(doc.FileID)
+	if err != nil {
+		return nil, fmt.Errorf("get file url: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, channelImportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errChannelImportDownload, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", errChannelImportDownload, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, channelImportMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read import file: %w", err)
+	}
+
+	if len(data) > channelImportMaxSize {
+		return nil, fmt.Errorf("%w: over %d bytes", errChannelImportTooLarge, channelImportMaxSize)
+	}
+
+	return data, nil
+}
+
+func (b *Bot) applyChannelImport(ctx context.Context, plan channelImportPlan) string {
+	records := append([]channelRecord{}, plan.added...)
+	for _, u := range plan.updated {
+		records = append(records, u.record)
+	}
+
+	var failed []string
+
+	for _, r := range records {
+		if err := b.database.ImportChannel(ctx, r.toImport()); err != nil {
+			failed = append(failed, fmt.Sprintf("• %s: %s", html.EscapeString(r.display()), html.EscapeString(err.Error())))
+		}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(tr(ctx, "📥 <b>Channel import applied</b>\nAdded: %d · Updated: %d · Unchanged: %d · Invalid: %d\n",
+		len(plan.added), len(plan.updated), plan.unchanged, len(plan.invalid)))
+
+	if len(failed) > 0 {
+		sb.WriteString(tr(ctx, "\n❌ <b>Failed (%d):</b>\n", len(failed)))
+		sb.WriteString(strings.Join(failed, "\n"))
+	}
+
+	return sb.String()
+}
+
+func formatChannelImportPlan(ctx context.Context, plan channelImportPlan, fileName string) string {
+	var sb strings.Builder
+
+	sb.WriteString(tr(ctx, "📥 <b>Channel import (dry run)</b> · <code>%s</code>\n\n", html.EscapeString(fileName)))
+
+	if len(plan.added) > 0 {
+		names := make([]string, len(plan.added))
+		for i, r := range plan.added {
+			names[i] = html.EscapeString(r.display())
+		}
+
+		sb.WriteString(tr(ctx, "➕ <b>Add (%d):</b> %s\n", len(plan.added), strings.Join(names, ", ")))
+	}
+
+	if len(plan.updated) > 0 {
+		sb.WriteString(tr(ctx, "✏️ <b>Update (%d):</b>\n", len(plan.updated)))
+
+		for _, u := range plan.updated {
+			fmt.Fprintf(&sb, "• %s: <i>%s</i>\n", html.EscapeString(u.record.display()), html.EscapeString(strings.Join(u.changes, "; ")))
+		}
+	}
+
+	sb.WriteString(tr(ctx, "= Unchanged: %d\n", plan.unchanged))
+
+	if len(plan.invalid) > 0 {
+		sb.WriteString(tr(ctx, "⚠️ <b>Invalid (%d):</b>\n", len(plan.invalid)))
+
+		for _, line := range plan.invalid {
+			fmt.Fprintf(&sb, "• %s\n", html.EscapeString(line))
+		}
+	}
+
+	if len(plan.added)+len(plan.updated) > 0 {
+		sb.WriteString(tr(ctx, "\n💡 Reply to the file with <code>/channel import apply</code> to apply."))
+	}
+
+	return sb.String()
+}
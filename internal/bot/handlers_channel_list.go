@@ -0,0 +1,434 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CallbackPrefixChannelList is the callback data prefix for /channel list pagination.
+	// Data format: chl:<page>:<compact>:<sort>:<state>:<min weight>:<max weight>:<min noise>:<max noise>.
+	CallbackPrefixChannelList = "chl:"
+
+	channelListPageSize        = 10
+	channelListCompactPageSize = 30
+	channelListCallbackFields  = 9
+	channelListBoundsField     = 5
+	channelListCompactArg      = "compact"
+	channelListCompactFlag     = "1"
+
+	channelListUsage = "Usage: <code>/channel list [state=&lt;health&gt;] [weight=&lt;min-max&gt;] [noise=&lt;min-max&gt;] " +
+		"[sort=name|weight|noise|added] [compact] [page=&lt;n&gt;]</code>\n\n" +
+		"Example: <code>/channel list state=healthy weight=0.5-1 sort=noise compact</code>"
+)
+
+var errInvalidChannelListArg = errors.New("invalid channel list argument")
+
+var (
+	channelHealthStates = []string{
+		db.ChannelHealthHealthy, db.ChannelHealthStale, db.ChannelHealthDead,
+		db.ChannelHealthPrivate, db.ChannelHealthDeleted, db.ChannelHealthRenamed,
+	}
+	channelListSorts = []string{db.ChannelSortName, db.ChannelSortWeight, db.ChannelSortNoise, db.ChannelSortAdded}
+)
+
+// channelListQuery is a page of /channel list with its filters.
+type channelListQuery struct {
+	filter  db.ChannelListFilter
+	compact bool
+	page    int
+}
+
+// channelListSetters maps /channel list argument names to query updates.
+var channelListSetters = map[string]func(*channelListQuery, string) error{
+	"state": func(q *channelListQuery, v string) error {
+		if !slices.Contains(channelHealthStates, v) {
+			return fmt.Errorf("%w: state %s", errInvalidChannelListArg, v)
+		}
+
+		q.filter.HealthStatus = v
+
+		return nil
+	},
+	"weight": func(q *channelListQuery, v string) error {
+		lo, hi, err := parseFloatRange(v)
+		q.filter.MinWeight, q.filter.MaxWeight = float32(lo), float32(hi)
+
+		return err
+	},
+	"noise": func(q *channelListQuery, v string) error {
+		lo, hi, err := parseFloatRange(v)
+		q.filter.MinNoise, q.filter.MaxNoise = lo, hi
+
+		return err
+	},
+	"sort": func(q *channelListQuery, v string) error {
+		if !slices.Contains(channelListSorts, v) {
+			return fmt.Errorf("%w: sort %s", errInvalidChannelListArg, v)
+		}
+
+		q.filter.Sort = v
+
+		return nil
+	},
+	"page": func(q *channelListQuery, v string) error {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return fmt.Errorf("%w: page %s", errInvalidChannelListArg, v)
+		}
+
+		q.page = page - 1
+
+		return nil
+	},
+}
+
+// parseChannelListArgs parses "/channel list" arguments of the form key=value
+// plus the "compact" flag.
+func parseChannelListArgs(args string) (channelListQuery, error) {
+	q := channelListQuery{filter: db.ChannelListFilter{Sort: db.ChannelSortName}}
+
+	for _, field := range strings.Fields(strings.ToLower(args)) {
+		if field == channelListCompactArg {
+			q.compact = true
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(field, "=")
+
+		setter, known := channelListSetters[key]
+		if !ok || !known || value == "" {
+			return channelListQuery{}, fmt.Errorf("%w: %s", errInvalidChannelListArg, field)
+		}
+
+		if err := setter(&q, value); err != nil {
+			return channelListQuery{}, err
+		}
+	}
+
+	return q, nil
+}
+
+// parseFloatRange parses "min-max", "min-" or "-max"; a missing bound is 0.
+func parseFloatRange(v string) (float64, float64, error) {
+	loStr, hiStr, _ := strings.Cut(v, "-")
+
+	lo, errLo := parseOptionalFloat(loStr)
+	hi, errHi := parseOptionalFloat(hiStr)
+
+	if errLo != nil || errHi != nil || lo < 0 || hi < 0 || (hi > 0 && lo > hi) {
+		return 0, 0, fmt.Errorf("%w: range %s", errInvalidChannelListArg, v)
+	}
+
+	return lo, hi, nil
+}
+
+func parseOptionalFloat(v string) (float64, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse float: %w", err)
+	}
+
+	return f, nil
+}
+
+// channelListCallbackData encodes a list page into callback data.
+func channelListCallbackData(q channelListQuery) (string, bool) {
+	compact := "0"
+	if q.compact {
+		compact = channelListCompactFlag
+	}
+
+	f := q.filter
+	data := strings.Join([]string{
+		CallbackPrefixChannelList + strconv.Itoa(q.page), compact, f.Sort, f.HealthStatus,
+		strconv.FormatFloat(float64(f.MinWeight), 'g', -1, 32), strconv.FormatFloat(float64(f.MaxWeight), 'g', -1, 32),
+		strconv.FormatFloat(f.MinNoise, 'g', -1, 64), strconv.FormatFloat(f.MaxNoise, 'g', -1, 64),
+	}, ":")
+
+	return data, len(data) <= maxCallbackDataLen
+}
+
+// parseChannelListCallbackData decodes callback data built by channelListCallbackData.
+func parseChannelListCallbackData(data string) (channelListQuery, bool) {
+	parts := strings.Split(data, ":")
+	if len(parts) != channelListCallbackFields {
+		return channelListQuery{}, false
+	}
+
+	page, err := strconv.Atoi(parts[1])
+	if err != nil || page < 0 {
+		return channelListQuery{}, false
+	}
+
+	bounds := make([]float64, 0, len(parts)-channelListBoundsField)
+
+	for _, part := range parts[channelListBoundsField:] {
+		f, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return channelListQuery{}, false
+		}
+
+		bounds = append(bounds, f)
+	}
+
+	return channelListQuery{
+		filter: db.ChannelListFilter{
+			Sort:         parts[3],
+			HealthStatus: parts[4],
+			MinWeight:    float32(bounds[0]),
+			MaxWeight:    float32(bounds[1]),
+			MinNoise:     bounds[2],
+			MaxNoise:     bounds[3],
+		},
+		compact: parts[2] == channelListCompactFlag,
+		page:    page,
+	}, true
+}
+
+func (q channelListQuery) pageSize() int {
+	if q.compact {
+		return channelListCompactPageSize
+	}
+
+	return channelListPageSize
+}
+
+// filtered reports whether any filter narrows the list.
+func (q channelListQuery) filtered() bool {
+	f := q.filter
+
+	return f.HealthStatus != "" || f.MinWeight > 0 || f.MaxWeight > 0 || f.MinNoise > 0 || f.MaxNoise > 0
+}
+
+func (b *Bot) handleListChannels(ctx context.Context, msg *tgbotapi.Message) {
+	q, err := parseChannelListArgs(msg.CommandArguments())
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), channelListUsage))
+
+		return
+	}
+
+	text, markup, err := b.renderChannelListPage(ctx, q)
+	if err != nil {
+		b.reply(msg, tr(ctx, ErrFetchingChannelsFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.DisableWebPagePreview = true
+
+	if markup != nil {
+		reply.ReplyMarkup = *markup
+	}
+
+	if _, err := b.sender.Send(reply); err != nil {
+		b.logger.Error().Err(err).Msg("failed to send channel list")
+	}
+}
+
+func (b *Bot) handleChannelListCallback(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	if _, err := b.sender.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
+	}
+
+	q, ok := parseChannelListCallbackData(query.Data)
+	if !ok || query.Message == nil {
+		return
+	}
+
+	text, markup, err := b.renderChannelListPage(ctx, q)
+	if err != nil {
+		b.logger.Error().Err(err).Msg("failed to render channel list page")
+
+		return
+	}
+
+	var edit tgbotapi.EditMessageTextConfig
+	if markup != nil {
+		edit = tgbotapi.NewEditMessageTextAndMarkup(query.Message.Chat.ID, query.Message.MessageID, text, *markup)
+	} else {
+		edit = tgbotapi.NewEditMessageText(query.Message.Chat.ID, query.Message.MessageID, text)
+	}
+
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.DisableWebPagePreview = true
+
+	if _, err := b.sender.Send(edit); err != nil {
+		b.logger.Error().Err(err).Msg("failed to update channel list")
+	}
+}
+
+// renderChannelListPage loads one page of channels and returns the message
+// text with its navigation keyboard.
+func (b *Bot) renderChannelListPage(ctx context.Context, q channelListQuery) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	entries, total, err := b.database.ListChannelsPage(ctx, q.filter, q.page*q.pageSize(), q.pageSize())
+	if err != nil {
+		return "", nil, fmt.Errorf("list channels: %w", err)
+	}
+
+	// The total comes from the page rows, so a page past the end reports zero;
+	// fall back to the first page.
+	if total == 0 && q.page > 0 {
+		q.page = 0
+
+		return b.renderChannelListPage(ctx, q)
+	}
+
+	if total == 0 && !q.filtered() {
+		return tr(ctx, "No active channels tracked."), nil, nil
+	}
+
+	return formatChannelListPage(q, entries, total), channelListKeyboard(q, total), nil
+}
+
+// formatChannelListPage renders one page of the channel list.
+func formatChannelListPage(q channelListQuery, entries []db.ChannelListEntry, total int) string {
+	var sb strings.Builder
+
+	pages := max((total+q.pageSize()-1)/q.pageSize(), 1)
+	fmt.Fprintf(&sb, "📋 <b>Channels</b> · %d · page %d/%d\n", total, q.page+1, pages)
+
+	if summary := formatChannelListFilter(q.filter); summary != "" {
+		fmt.Fprintf(&sb, "<i>%s</i>\n", html.EscapeString(summary))
+	}
+
+	sb.WriteString("\n")
+
+	if len(entries) == 0 {
+		sb.WriteString("No channels match the filters.\n")
+	}
+
+	for _, e := range entries {
+		snooze := formatChannelSnooze(e.SnoozedUntil, e.SnoozeIngestion)
+
+		if q.compact {
+			fmt.Fprintf(&sb, "• %s · <code>%.1fx</code> · noise <code>%s</code>",
+				html.EscapeString(formatChannelName(e.Username, e.Title)), e.ImportanceWeight, formatChannelNoise(e))
+
+			if snooze != "" {
+				sb.WriteString(" · 😴")
+			}
+
+			sb.WriteString("\n")
+
+			continue
+		}
+
+		formatChannelEntry(&sb, e.Channel)
+		fmt.Fprintf(&sb, "  Health: <code>%s</code> · Noise: <code>%s</code>\n", html.EscapeString(e.HealthStatus), formatChannelNoise(e))
+
+		if snooze != "" {
+			fmt.Fprintf(&sb, "  %s\n", snooze)
+		}
+	}
+
+	if !q.compact {
+		sb.WriteString("\n💡 <i>Use <code>/channel weight</code> or <code>/channel relevance</code> to manage channel quality controls.</i>")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func formatChannelNoise(e db.ChannelListEntry) string {
+	if !e.HasNoiseRate {
+		return "n/a"
+	}
+
+	return fmt.Sprintf("%.0f%%", e.NoiseRate*percentageMultiplier)
+}
+
+// formatChannelListFilter describes the active filters and sort order.
+func formatChannelListFilter(f db.ChannelListFilter) string {
+	var parts []string
+
+	if f.HealthStatus != "" {
+		parts = append(parts, "state="+f.HealthStatus)
+	}
+
+	if f.MinWeight > 0 || f.MaxWeight > 0 {
+		parts = append(parts, fmt.Sprintf("weight=%s", formatFloatRange(float64(f.MinWeight), float64(f.MaxWeight))))
+	}
+
+	if f.MinNoise > 0 || f.MaxNoise > 0 {
+		parts = append(parts, fmt.Sprintf("noise=%s", formatFloatRange(f.MinNoise, f.MaxNoise)))
+	}
+
+	if f.Sort != "" && f.Sort != db.ChannelSortName {
+		parts = append(parts, "sort="+f.Sort)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func formatFloatRange(lo, hi float64) string {
+	var sb strings.Builder
+
+	if lo > 0 {
+		sb.WriteString(strconv.FormatFloat(lo, 'g', -1, 32))
+	}
+
+	sb.WriteString("-")
+
+	if hi > 0 {
+		sb.WriteString(strconv.FormatFloat(hi, 'g', -1, 32))
+	}
+
+	return sb.String()
+}
+
+// channelListKeyboard builds prev/next buttons and the compact/full toggle.
+func channelListKeyboard(q channelListQuery, total int) *tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	var nav []tgbotapi.InlineKeyboardButton
+
+	if q.page > 0 {
+		if data, ok := channelListCallbackData(channelListQuery{filter: q.filter, compact: q.compact, page: q.page - 1}); ok {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("◀ Prev", data))
+		}
+	}
+
+	if (q.page+1)*q.pageSize() < total {
+		if data, ok := channelListCallbackData(channelListQuery{filter: q.filter, compact: q.compact, page: q.page + 1}); ok {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("Next ▶", data))
+		}
+	}
+
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	label := "🗜 Compact"
+	if q.compact {
+		label = "📄 Full"
+	}
+
+	if data, ok := channelListCallbackData(channelListQuery{filter: q.filter, compact: !q.compact}); ok && total > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(label, data)))
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	return &markup
+}
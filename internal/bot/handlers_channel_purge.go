@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	SubCmdPurge = "purge"
+
+	purgeConfirm = "confirm"
+
+	channelPurgeUsage = "Usage: <code>/channel purge @user [confirm]</code>\n" +
+		"Deletes the channel and everything derived from it: messages, items, embeddings, cluster memberships, " +
+		"ratings, evidence links and research data. Without <code>confirm</code> it only reports what would be deleted."
+)
+
+func (b *Bot) handleChannelPurge(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 || len(args) > 2 || (len(args) == 2 && !strings.EqualFold(args[1], purgeConfirm)) {
+		b.reply(msg, tr(ctx, channelPurgeUsage))
+
+		return
+	}
+
+	identifier := args[0]
+	dryRun := len(args) == 1
+
+	report, err := b.database.PurgeChannel(ctx, identifier, msg.From.ID, dryRun)
+
+	switch {
+	case errors.Is(err, db.ErrChannelNotFound):
+		b.reply(msg, tr(ctx, "❓ %s: channel not tracked", html.EscapeString(identifier)))
+	case err != nil:
+		b.reply(msg, tr(ctx, "❌ Error purging channel: %s", html.EscapeString(err.Error())))
+	default:
+		if !dryRun {
+			b.logger.Info().Str("channel", report.Username).Int64("peer_id", report.PeerID).Int64("rows", report.Total()).
+				Int64(LogFieldUserID, msg.From.ID).Msg("channel purged")
+		}
+
+		b.reply(msg, formatChannelPurgeReport(ctx, report, identifier))
+	}
+}
+
+// formatChannelPurgeReport lists the non-empty tables of a purge report.
+func formatChannelPurgeReport(ctx context.Context, report *db.ChannelPurgeReport, identifier string) string {
+	var sb strings.Builder
+
+	name := html.EscapeString(channelPurgeName(report))
+	if report.DryRun {
+		sb.WriteString(tr(ctx, "🗑 <b>Purge preview: %s</b>\n\n", name))
+	} else {
+		sb.WriteString(tr(ctx, "🗑 <b>Purged %s</b>\n\n", name))
+	}
+
+	for _, c := range report.Counts {
+		if c.Rows > 0 {
+			sb.WriteString(fmt.Sprintf("• <code>%s</code>: %d\n", c.Table, c.Rows))
+		}
+	}
+
+	sb.WriteString(tr(ctx, "\nTotal: <b>%d</b> rows", report.Total()))
+
+	if report.DryRun {
+		sb.WriteString(tr(ctx, "\n\n⚠️ This cannot be undone. Run <code>/channel purge %s confirm</code> to delete.", html.EscapeString(identifier)))
+	}
+
+	return sb.String()
+}
+
+func channelPurgeName(report *db.ChannelPurgeReport) string {
+	switch {
+	case report.Username != "":
+		return "@" + report.Username
+	case report.Title != "":
+		return report.Title
+	default:
+		return fmt.Sprintf("%d", report.PeerID)
+	}
+}
@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdQuota is the /channel quota subcommand.
+	CmdQuota = "quota"
+
+	channelQuotaStatsWindow = 24 * time.Hour
+)
+
+var errInvalidChannelQuota = errors.New("invalid channel quota")
+
+func (b *Bot) handleChannelQuota(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+
+	if len(args) == 0 {
+		b.reply(msg, channelQuotaUsage())
+
+		return
+	}
+
+	identifier := strings.TrimPrefix(args[0], "@")
+
+	channel, errMsg := b.lookupChannel(ctx, identifier)
+	if errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	chanDisplay := formatChannelDisplay(channel.Username, channel.Title, identifier)
+
+	if len(args) == 1 {
+		b.showChannelQuota(ctx, msg, channel.ID, chanDisplay)
+
+		return
+	}
+
+	if strings.EqualFold(args[1], ToggleOff) {
+		if err := b.database.ClearChannelQuota(ctx, channel.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf("Quota removed for %s. Deferred messages were released.", chanDisplay))
+
+		return
+	}
+
+	limit, period, policy, err := parseChannelQuotaArgs(args[1:])
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), channelQuotaUsage()))
+
+		return
+	}
+
+	if err := b.database.SetChannelQuota(ctx, channel.ID, limit, period, policy); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("Quota for %s set to <code>%d/%s</code>, overflow policy <code>%s</code>.", chanDisplay, limit, period, policy))
+}
+
+func (b *Bot) showChannelQuota(ctx context.Context, msg *tgbotapi.Message, channelID, chanDisplay string) {
+	quota, err := b.database.GetChannelQuota(ctx, channelID)
+	if err != nil {
+		if errors.Is(err, db.ErrChannelQuotaNotSet) {
+			b.reply(msg, fmt.Sprintf("No quota set for %s.\n\n%s", chanDisplay, channelQuotaUsage()))
+		} else {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+		}
+
+		return
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "<b>Channel Quota: %s</b>\n\n", chanDisplay)
+	fmt.Fprintf(&sb, "Limit: <code>%d/%s</code>\n", quota.Limit, quota.Period)
+	fmt.Fprintf(&sb, "Overflow policy: <code>%s</code>\n", quota.Policy)
+
+	dropped, deferred, err := b.database.CountChannelQuotaOverflow(ctx, channelID, time.Now().Add(-channelQuotaStatsWindow))
+	if err != nil {
+		b.logger.Warn().Err(err).Str("channel_id", channelID).Msg("failed to count channel quota overflow")
+	} else {
+		fmt.Fprintf(&sb, "Dropped (24h): <code>%d</code>\n", dropped)
+		fmt.Fprintf(&sb, "Deferred now: <code>%d</code>\n", deferred)
+	}
+
+	b.reply(msg, sb.String())
+}
+
+// parseChannelQuotaArgs parses "<n>[/day|/hour] [sample|drop|defer]". The period
+// defaults to day and the policy to sample.
+func parseChannelQuotaArgs(args []string) (limit int, period, policy string, err error) {
+	limitArg, period, hasPeriod := strings.Cut(strings.ToLower(args[0]), "/")
+	if !hasPeriod {
+		period = db.QuotaPeriodDay
+	}
+
+	if period != db.QuotaPeriodDay && period != db.QuotaPeriodHour {
+		return 0, "", "", fmt.Errorf("%w: unknown period %s", errInvalidChannelQuota, period)
+	}
+
+	limit, err = strconv.Atoi(limitArg)
+	if err != nil || limit < 1 {
+		return 0, "", "", fmt.Errorf("%w: limit must be a positive number, got %s", errInvalidChannelQuota, limitArg)
+	}
+
+	policy = db.QuotaPolicySample
+
+	if len(args) > 1 {
+		policy = strings.ToLower(args[1])
+	}
+
+	switch policy {
+	case db.QuotaPolicySample, db.QuotaPolicyDrop, db.QuotaPolicyDefer:
+	default:
+		return 0, "", "", fmt.Errorf("%w: unknown policy %s", errInvalidChannelQuota, policy)
+	}
+
+	return limit, period, policy, nil
+}
+
+func channelQuotaUsage() string {
+	return "Usage:\n" +
+		"<code>/channel quota @username</code> - Show quota and overflow\n" +
+		"<code>/channel quota @username 200/day</code> - Cap messages per day\n" +
+		"<code>/channel quota @username 20/hour drop</code> - Cap per hour with a policy\n" +
+		"<code>/channel quota @username off</code> - Remove the quota\n\n" +
+		"Policies: <code>sample</code> (keep top engagement, default), <code>drop</code> (keep earliest), <code>defer</code> (process later)"
+}
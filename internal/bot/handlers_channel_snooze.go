@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	SubCmdSnooze = "snooze"
+
+	snoozeOff         = "off"
+	snoozeModeDigest  = "digest"
+	snoozeMaxDuration = 30 * 24 * time.Hour
+	snoozeTimeLayout  = "2006-01-02 15:04 UTC"
+
+	channelSnoozeUsage = "Usage: <code>/channel snooze @user &lt;duration|off&gt; [digest]</code>\n" +
+		"Durations like <code>6h</code> or <code>3d</code>, up to 30d. Snoozing pauses ingestion; " +
+		"with <code>digest</code> the channel is still read but left out of digests. " +
+		"Messages posted while snoozed never reach a digest."
+)
+
+var errInvalidSnoozeDuration = errors.New("invalid snooze duration")
+
+func (b *Bot) handleChannelSnooze(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) < 2 || len(args) > 3 || (len(args) == 3 && !strings.EqualFold(args[2], snoozeModeDigest)) {
+		b.reply(msg, tr(ctx, channelSnoozeUsage))
+
+		return
+	}
+
+	identifier := args[0]
+
+	if strings.EqualFold(args[1], snoozeOff) {
+		found, err := b.database.UnsnoozeChannel(ctx, identifier)
+		b.replyChannelSnoozeResult(ctx, msg, identifier, found, err, tr(ctx, "✅ %s is no longer snoozed.", html.EscapeString(identifier)))
+
+		return
+	}
+
+	d, err := parseSnoozeDuration(args[1])
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Invalid duration. Use something like <code>6h</code> or <code>3d</code>, up to 30d."))
+
+		return
+	}
+
+	until := time.Now().Add(d).UTC()
+	ingestion := len(args) == 2
+
+	found, err := b.database.SnoozeChannel(ctx, identifier, until, ingestion)
+
+	success := tr(ctx, "😴 %s is left out of digests until <code>%s</code>.", html.EscapeString(identifier), until.Format(snoozeTimeLayout))
+	if ingestion {
+		success = tr(ctx, "😴 %s ingestion is paused until <code>%s</code>.", html.EscapeString(identifier), until.Format(snoozeTimeLayout))
+	}
+
+	b.replyChannelSnoozeResult(ctx, msg, identifier, found, err, success)
+}
+
+func (b *Bot) replyChannelSnoozeResult(ctx context.Context, msg *tgbotapi.Message, identifier string, found bool, err error, success string) {
+	switch {
+	case err != nil:
+		b.reply(msg, tr(ctx, "❌ Error snoozing channel: %s", html.EscapeString(err.Error())))
+	case !found:
+		b.reply(msg, tr(ctx, "❓ %s: channel not tracked", html.EscapeString(identifier)))
+	default:
+		b.reply(msg, success)
+	}
+}
+
+// parseSnoozeDuration parses a Go duration or a number of days such as "3d".
+func parseSnoozeDuration(arg string) (time.Duration, error) {
+	value := arg
+	if days, err := strconv.Atoi(strings.TrimSuffix(arg, "d")); err == nil && strings.HasSuffix(arg, "d") {
+		value = fmt.Sprintf("%dh", days*HoursPerDay)
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 || d > snoozeMaxDuration {
+		return 0, fmt.Errorf("%w: %s", errInvalidSnoozeDuration, arg)
+	}
+
+	return d, nil
+}
+
+// formatChannelSnooze describes a channel's snooze state for /channel list,
+// or returns an empty string when it is not snoozed.
+func formatChannelSnooze(until time.Time, ingestion bool) string {
+	if until.IsZero() {
+		return ""
+	}
+
+	mode := snoozeModeDigest
+	if ingestion {
+		mode = "ingestion"
+	}
+
+	return fmt.Sprintf("😴 snoozed (%s) until %s", mode, until.UTC().Format(snoozeTimeLayout))
+}
@@ -0,0 +1,352 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	SubCmdTrial = "trial"
+
+	// CallbackPrefixTrial prefixes the promote/reject buttons of trial reports.
+	CallbackPrefixTrial = "trial:"
+
+	// SettingChannelTrialDays overrides the trial length of new channels.
+	SettingChannelTrialDays = "channel_trial_days"
+
+	trialActionPromote = "promote"
+	trialActionReject  = "reject"
+	trialOff           = "off"
+	trialReportSamples = 5
+	trialSampleRunes   = 160
+	trialMaxDays       = 90
+	trialTimeLayout    = "2006-01-02 15:04 UTC"
+
+	channelTrialUsage = "🧪 <b>Channel Trials</b>\n" +
+		"New channels are scored but left out of digests during their trial.\n" +
+		"• <code>/channel trial list</code>\n" +
+		"• <code>/channel trial start @user [days]</code>\n" +
+		"• <code>/channel trial report @user</code>\n" +
+		"• <code>/channel trial promote @user</code>\n" +
+		"• <code>/channel trial reject @user</code>\n" +
+		"• <code>/channel trial days &lt;n|off&gt;</code> - trial length of new channels"
+)
+
+var errInvalidTrialDays = errors.New("invalid trial days")
+
+func (b *Bot) handleChannelTrial(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		b.handleChannelTrialList(ctx, msg)
+
+		return
+	}
+
+	sub, rest := strings.ToLower(args[0]), args[1:]
+
+	switch {
+	case sub == CmdList:
+		b.handleChannelTrialList(ctx, msg)
+	case sub == "start" && (len(rest) == 1 || len(rest) == 2):
+		b.handleChannelTrialStart(ctx, msg, rest)
+	case sub == "report" && len(rest) == 1:
+		b.handleChannelTrialReport(ctx, msg, rest[0])
+	case (sub == trialActionPromote || sub == trialActionReject) && len(rest) == 1:
+		b.reply(msg, b.endChannelTrial(ctx, rest[0], sub == trialActionPromote))
+	case sub == "days" && len(rest) == 1:
+		b.handleChannelTrialDays(ctx, msg, rest[0])
+	default:
+		b.reply(msg, tr(ctx, channelTrialUsage))
+	}
+}
+
+func (b *Bot) handleChannelTrialList(ctx context.Context, msg *tgbotapi.Message) {
+	trials, err := b.database.ListChannelTrials(ctx)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error fetching channel trials: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	header := tr(ctx, "🧪 <b>Channel Trials</b> · new channels: <code>%s</code>\n\n", formatTrialDays(b.channelTrialDays(ctx)))
+
+	if len(trials) == 0 {
+		b.reply(msg, header+tr(ctx, "No channels on trial."))
+
+		return
+	}
+
+	b.reply(msg, header+formatChannelTrials(ctx, trials, time.Now()))
+}
+
+func formatChannelTrials(ctx context.Context, trials []db.ChannelTrial, now time.Time) string {
+	lines := make([]string, 0, len(trials))
+
+	for _, t := range trials {
+		name := html.EscapeString(formatChannelName(t.Username, t.Title))
+
+		if t.Until.After(now) {
+			lines = append(lines, tr(ctx, "• %s · until <code>%s</code>", name, t.Until.UTC().Format(trialTimeLayout)))
+
+			continue
+		}
+
+		lines = append(lines, tr(ctx, "• %s · ended, awaiting promote/reject", name))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// channelTrialDays returns the trial length of new channels; zero disables
+// trials.
+func (b *Bot) channelTrialDays(ctx context.Context) int {
+	days := b.cfg.ChannelTrialDays
+
+	if err := b.database.GetSetting(ctx, SettingChannelTrialDays, &days); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get channel_trial_days from DB, using default")
+	}
+
+	return days
+}
+
+func formatTrialDays(days int) string {
+	if days <= 0 {
+		return trialOff
+	}
+
+	return fmt.Sprintf("%dd", days)
+}
+
+func parseTrialDays(arg string) (int, error) {
+	if strings.EqualFold(arg, trialOff) {
+		return 0, nil
+	}
+
+	days, err := strconv.Atoi(strings.TrimSuffix(arg, "d"))
+	if err != nil || days < 0 || days > trialMaxDays {
+		return 0, fmt.Errorf("%w: %s", errInvalidTrialDays, arg)
+	}
+
+	return days, nil
+}
+
+func (b *Bot) handleChannelTrialDays(ctx context.Context, msg *tgbotapi.Message, arg string) {
+	days, err := parseTrialDays(arg)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Trial length must be a number of days up to 90, or <code>off</code>."))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, SettingChannelTrialDays, days, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, SettingChannelTrialDays, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, tr(ctx, "✅ Trial length of new channels: <code>%s</code>.", formatTrialDays(days)))
+}
+
+func (b *Bot) handleChannelTrialStart(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	days := b.channelTrialDays(ctx)
+
+	if len(args) > 1 {
+		var err error
+		if days, err = parseTrialDays(args[1]); err != nil {
+			b.reply(msg, tr(ctx, "❌ Trial length must be a number of days up to 90, or <code>off</code>."))
+
+			return
+		}
+	}
+
+	if days == 0 {
+		b.reply(msg, tr(ctx, channelTrialUsage))
+
+		return
+	}
+
+	b.reply(msg, strings.TrimSpace(b.startChannelTrial(ctx, args[0], days)))
+}
+
+// startChannelTrial puts a channel on trial for the given number of days and
+// returns a line for the reply, or an empty string when trials are off.
+func (b *Bot) startChannelTrial(ctx context.Context, identifier string, days int) string {
+	if days <= 0 {
+		return ""
+	}
+
+	until := time.Now().AddDate(0, 0, days).UTC()
+
+	found, err := b.database.StartChannelTrial(ctx, identifier, until)
+
+	switch {
+	case err != nil:
+		return "\n" + tr(ctx, "⚠️ Could not start the trial: %s", html.EscapeString(err.Error()))
+	case !found:
+		return "\n" + tr(ctx, "❓ %s: channel not tracked", html.EscapeString(identifier))
+	default:
+		return "\n" + tr(ctx, "🧪 On trial until <code>%s</code>: scored but left out of digests. You will get a report with promote/reject buttons.",
+			until.Format(trialTimeLayout))
+	}
+}
+
+func (b *Bot) handleChannelTrialReport(ctx context.Context, msg *tgbotapi.Message, identifier string) {
+	trial, err := b.database.GetChannelTrial(ctx, identifier)
+	if err != nil {
+		b.reply(msg, b.channelTrialError(ctx, identifier, err))
+
+		return
+	}
+
+	report, err := b.buildChannelTrialReport(ctx, trial)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error building trial report: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.sendChannelTrialReport(ctx, msg.Chat.ID, report)
+}
+
+func (b *Bot) buildChannelTrialReport(ctx context.Context, trial db.ChannelTrial) (db.ChannelTrialReport, error) {
+	importanceThreshold := b.cfg.ImportanceThreshold
+	if err := b.database.GetSetting(ctx, SettingImportanceThreshold, &importanceThreshold); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get importance_threshold from DB, using default")
+	}
+
+	report, err := b.database.GetChannelTrialReport(ctx, trial, importanceThreshold, trialReportSamples)
+	if err != nil {
+		return report, fmt.Errorf("get channel trial report: %w", err)
+	}
+
+	return report, nil
+}
+
+// SendChannelTrialReport sends the end-of-trial report with promote/reject
+// buttons to all admin users.
+func (b *Bot) SendChannelTrialReport(ctx context.Context, report db.ChannelTrialReport) error {
+	ctx = b.withBotLanguage(ctx)
+
+	for _, adminID := range b.getAdmins(ctx) {
+		b.sendChannelTrialReport(ctx, adminID, report)
+	}
+
+	return nil
+}
+
+func (b *Bot) sendChannelTrialReport(ctx context.Context, chatID int64, report db.ChannelTrialReport) {
+	msg := tgbotapi.NewMessage(chatID, formatChannelTrialReport(ctx, report))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	msg.ReplyMarkup = channelTrialKeyboard(ctx, report.ChannelID)
+
+	if _, err := b.sender.Send(msg); err != nil {
+		b.logger.Error().Err(err).Int64("chat_id", chatID).Msg("failed to send channel trial report")
+	}
+}
+
+func formatChannelTrialReport(ctx context.Context, r db.ChannelTrialReport) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s\n", tr(ctx, "🧪 <b>Trial report: %s</b>", html.EscapeString(formatChannelName(r.Username, r.Title))))
+	fmt.Fprintf(&sb, "%s\n", tr(ctx, "<code>%s</code> → <code>%s</code>", r.StartedAt.UTC().Format(trialTimeLayout), r.Until.UTC().Format(trialTimeLayout)))
+	fmt.Fprintf(&sb, "%s\n", tr(ctx, "Messages: %d · Relevant items: %d · Would reach digests: %d", r.Messages, r.Items, r.Eligible))
+	fmt.Fprintf(&sb, "%s\n", tr(ctx, "Projected noise rate: <b>%.0f%%</b>", r.ProjectedNoiseRate()*percentageMultiplier))
+
+	if len(r.Samples) == 0 {
+		fmt.Fprintf(&sb, "\n%s", tr(ctx, "<i>No relevant items during the trial.</i>"))
+
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "\n%s\n", tr(ctx, "<b>Sample items:</b>"))
+
+	for _, s := range r.Samples {
+		label := fmt.Sprintf("%.2f", s.ImportanceScore)
+		fmt.Fprintf(&sb, "• %s %s\n", FormatLink(r.Username, r.PeerID, s.TGMessageID, label),
+			html.EscapeString(truncateAnnotationText(s.Summary, trialSampleRunes)))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func channelTrialKeyboard(ctx context.Context, channelID string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(tr(ctx, "✅ Promote"), CallbackPrefixTrial+trialActionPromote+":"+channelID),
+		tgbotapi.NewInlineKeyboardButtonData(tr(ctx, "🗑 Reject"), CallbackPrefixTrial+trialActionReject+":"+channelID),
+	))
+}
+
+// parseChannelTrialCallbackData returns the channel ID and whether the
+// button promotes the channel.
+func parseChannelTrialCallbackData(data string) (string, bool, bool) {
+	action, channelID, ok := strings.Cut(strings.TrimPrefix(data, CallbackPrefixTrial), ":")
+	if !ok || channelID == "" || (action != trialActionPromote && action != trialActionReject) {
+		return "", false, false
+	}
+
+	return channelID, action == trialActionPromote, true
+}
+
+func (b *Bot) handleChannelTrialCallback(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	if _, err := b.sender.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
+	}
+
+	channelID, promote, ok := parseChannelTrialCallbackData(query.Data)
+	if !ok || query.Message == nil {
+		return
+	}
+
+	text := b.endChannelTrial(ctx, channelID, promote)
+
+	markup := tgbotapi.NewEditMessageReplyMarkup(query.Message.Chat.ID, query.Message.MessageID,
+		tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+	if _, err := b.sender.Request(markup); err != nil {
+		b.logger.Error().Err(err).Msg("failed to clear trial report buttons")
+	}
+
+	reply := tgbotapi.NewMessage(query.Message.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+
+	if _, err := b.sender.Send(reply); err != nil {
+		b.logger.Error().Err(err).Msg("failed to send trial decision")
+	}
+}
+
+// endChannelTrial promotes or rejects the channel on trial and returns the
+// result message.
+func (b *Bot) endChannelTrial(ctx context.Context, identifier string, promote bool) string {
+	trial, err := b.database.GetChannelTrial(ctx, identifier)
+	if err == nil {
+		err = b.database.EndChannelTrial(ctx, trial.ChannelID, promote)
+	}
+
+	if err != nil {
+		return b.channelTrialError(ctx, identifier, err)
+	}
+
+	name := html.EscapeString(formatChannelName(trial.Username, trial.Title))
+	if promote {
+		return tr(ctx, "✅ %s promoted: its items now reach digests.", name)
+	}
+
+	return tr(ctx, "🗑 %s rejected and no longer tracked.", name)
+}
+
+func (b *Bot) channelTrialError(ctx context.Context, identifier string, err error) string {
+	if errors.Is(err, db.ErrChannelTrialNotFound) {
+		return tr(ctx, "❓ %s is not on trial (already promoted or rejected?).", html.EscapeString(identifier))
+	}
+
+	return tr(ctx, "❌ Error updating channel trial: %s", html.EscapeString(err.Error()))
+}
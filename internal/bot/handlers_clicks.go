@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// percentScale converts a rate to a percentage.
+const percentScale = 100
+
+// handleRatingsClicks reports how often tracked digest links were clicked,
+// per importance band and per channel.
+func (b *Bot) handleRatingsClicks(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	days, limit := parseRatingsDaysLimit(args)
+	since := time.Now().AddDate(0, 0, -days)
+
+	channels, err := b.database.GetChannelClickStats(ctx, since)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error fetching click stats: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	bands, err := b.database.GetClickThroughByImportance(ctx, since)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error fetching click stats: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if totalClickedItems(channels) == 0 {
+		b.reply(msg, tr(ctx, "No tracked clicks in the last %d days. Enable tracking with <code>/click_tracking on</code>.", days))
+
+		return
+	}
+
+	b.reply(msg, formatClickStatsOutput(days, limit, channels, bands))
+}
+
+func totalClickedItems(channels []db.ChannelClickStats) int {
+	total := 0
+
+	for _, ch := range channels {
+		total += ch.ClickedItems
+	}
+
+	return total
+}
+
+func formatClickStatsOutput(days, limit int, channels []db.ChannelClickStats, bands []db.ImportanceClickStats) string {
+	var sb strings.Builder
+
+	digested := 0
+	for _, ch := range channels {
+		digested += ch.DigestedItems
+	}
+
+	clicked := totalClickedItems(channels)
+
+	fmt.Fprintf(&sb, "🖱 <b>Digest Clicks (last %d days)</b>\n\n", days)
+	fmt.Fprintf(&sb, "Clicked items: %s\n", formatClickRate(clicked, digested))
+
+	if len(bands) > 0 {
+		sb.WriteString("\n<b>By importance</b>\n")
+
+		for _, band := range bands {
+			fmt.Fprintf(&sb, "• <code>%.1f+</code>: %s\n", band.MinImportance, formatClickRate(band.ClickedItems, band.DigestedItems))
+		}
+	}
+
+	sb.WriteString("\n<b>Channels</b>\n")
+
+	for i, ch := range channels {
+		if i >= limit {
+			break
+		}
+
+		fmt.Fprintf(&sb, "• %s: %s · %d clicks\n",
+			html.EscapeString(formatRatingsChannelName(ch.ChannelID, ch.Username, ch.Title)), formatClickRate(ch.ClickedItems, ch.DigestedItems), ch.Clicks)
+	}
+
+	return sb.String()
+}
+
+// formatClickRate formats clicked out of digested items, e.g. "<code>3</code>/<code>12</code> (25%)".
+func formatClickRate(clicked, digested int) string {
+	rate := 0.0
+	if digested > 0 {
+		rate = float64(clicked) / float64(digested) * percentScale
+	}
+
+	return fmt.Sprintf("<code>%d</code>/<code>%d</code> (%.0f%%)", clicked, digested, rate)
+}
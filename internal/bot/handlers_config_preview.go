@@ -0,0 +1,293 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CallbackPrefixConfigApply is the callback data prefix for confirming a
+	// previewed setting change. Data format: cfgapply:<key>:<value> or cfgapply:cancel.
+	CallbackPrefixConfigApply = "cfgapply:"
+
+	configApplyCancel     = "cancel"
+	configApplyFields     = 3
+	configPreviewLookback = 24 * time.Hour
+	thresholdStrictLimit  = 0.9
+	thresholdLenientLimit = 0.1
+	thresholdLargeJump    = 0.3
+	windowShortLimit      = 10 * time.Minute
+	windowLongLimit       = 72 * time.Hour
+)
+
+var (
+	errUnsupportedConfigKey  = errors.New("setting does not support preview")
+	errInvalidThresholdValue = errors.New("threshold must be between 0.0 and 1.0")
+)
+
+// configApplyCallbackData builds the confirmation button data for a change.
+func configApplyCallbackData(key, value string) (string, bool) {
+	data := CallbackPrefixConfigApply + key + ":" + value
+
+	return data, len(data) <= maxCallbackDataLen
+}
+
+// parseConfigApplyCallbackData decodes data built by configApplyCallbackData.
+func parseConfigApplyCallbackData(data string) (key, value string, ok bool) {
+	parts := strings.SplitN(data, ":", configApplyFields)
+	if len(parts) != configApplyFields || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
+
+// lintThresholdChange returns warnings about a relevance or importance threshold change.
+func lintThresholdChange(current, proposed float32, impact db.WhatIfResult) []string {
+	var warnings []string
+
+	switch {
+	case proposed >= thresholdStrictLimit:
+		warnings = append(warnings, "Very strict: almost nothing will pass.")
+	case proposed <= thresholdLenientLimit:
+		warnings = append(warnings, "Very lenient: most noise will pass.")
+	}
+
+	if math.Abs(float64(proposed-current)) >= thresholdLargeJump {
+		warnings = append(warnings, fmt.Sprintf("Large jump from %.2f; consider smaller steps.", current))
+	}
+
+	if impact.Scored > 0 && impact.Included == 0 {
+		warnings = append(warnings, "No item from the last 24h would pass.")
+	}
+
+	return warnings
+}
+
+// lintWindowChange returns warnings about a digest window change.
+func lintWindowChange(proposed time.Duration) []string {
+	switch {
+	case proposed < windowShortLimit:
+		return []string{"Very short window: digests will be tiny and frequent."}
+	case proposed > windowLongLimit:
+		return []string{"Very long window: items may be stale and exceed the digest item limit."}
+	}
+
+	return nil
+}
+
+// formatThresholdImpact describes how many recent items would reach the
+// digest with the proposed threshold.
+func formatThresholdImpact(label string, proposed float32, current, next db.WhatIfResult) string {
+	if next.Scored == 0 {
+		return "No items were scored in the last 24h, so the impact cannot be estimated."
+	}
+
+	return fmt.Sprintf("With %s <code>%.2f</code>, the last 24h would have had <b>%d</b> digest items instead of <b>%d</b> (of %d scored).",
+		html.EscapeString(label), proposed, next.Included, current.Included, next.Scored)
+}
+
+// formatWindowImpact estimates the items per digest for a window from the
+// number of items that passed the filters in the last 24h.
+func formatWindowImpact(current, proposed time.Duration, passedPerDay int) string {
+	perDigest := func(window time.Duration) int {
+		return int(math.Round(float64(passedPerDay) * window.Hours() / configPreviewLookback.Hours()))
+	}
+
+	return fmt.Sprintf("At the last 24h rate (%d items passed), a <code>%s</code> window holds about <b>%d</b> items per digest instead of <b>%d</b> with <code>%s</code>.",
+		passedPerDay, proposed, perDigest(proposed), perDigest(current), current)
+}
+
+// formatConfigPreview renders the preview of a setting change.
+func formatConfigPreview(key, from, to, impact string, warnings []string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🔍 <b>Preview:</b> <code>%s</code> %s → %s\n\n", html.EscapeString(key), html.EscapeString(from), html.EscapeString(to))
+	sb.WriteString(impact)
+	sb.WriteString("\n")
+
+	if len(warnings) > 0 {
+		sb.WriteString("\n")
+
+		for _, w := range warnings {
+			fmt.Fprintf(&sb, "⚠️ %s\n", html.EscapeString(w))
+		}
+	}
+
+	sb.WriteString("\nApply this change?")
+
+	return sb.String()
+}
+
+// currentThresholds returns the stored global thresholds, falling back to the config.
+func (b *Bot) currentThresholds(ctx context.Context) db.WhatIfThresholds {
+	t := db.WhatIfThresholds{Relevance: b.cfg.RelevanceThreshold, Importance: b.cfg.ImportanceThreshold}
+
+	if err := b.database.GetSetting(ctx, SettingRelevanceThreshold, &t.Relevance); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get relevance_threshold from DB")
+	}
+
+	if err := b.database.GetSetting(ctx, SettingImportanceThreshold, &t.Importance); err != nil {
+		b.logger.Debug().Err(err).Msg(MsgCouldNotGetImportanceThreshold)
+	}
+
+	return t
+}
+
+// recentWhatIfItems returns the items scored in the preview lookback.
+func (b *Bot) recentWhatIfItems(ctx context.Context) ([]db.WhatIfItem, error) {
+	now := time.Now()
+
+	items, err := b.database.GetWhatIfItems(ctx, now.Add(-configPreviewLookback), now)
+	if err != nil {
+		return nil, fmt.Errorf("load recent items: %w", err)
+	}
+
+	return items, nil
+}
+
+// previewThresholdChange shows the estimated impact of a threshold change and asks for confirmation.
+func (b *Bot) previewThresholdChange(ctx context.Context, msg *tgbotapi.Message, key, label string, proposed float32) {
+	items, err := b.recentWhatIfItems(ctx)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	current := b.currentThresholds(ctx)
+	next := current
+
+	previous := current.Relevance
+	if key == SettingImportanceThreshold {
+		previous = current.Importance
+		next.Importance = proposed
+	} else {
+		next.Relevance = proposed
+	}
+
+	sim := db.SimulateWhatIf(items, current, next)
+
+	impact := formatThresholdImpact(strings.ToLower(label), proposed, sim.Baseline, sim.Scenario)
+	if sim.Baseline.Rated > 0 || sim.Scenario.Rated > 0 {
+		impact += fmt.Sprintf("\nProjected noise: %s → %s.", formatWhatIfNoise(sim.Baseline), formatWhatIfNoise(sim.Scenario))
+	}
+
+	text := formatConfigPreview(key, fmt.Sprintf("%.2f", previous), fmt.Sprintf("%.2f", proposed),
+		impact, lintThresholdChange(previous, proposed, sim.Scenario))
+
+	b.sendConfigPreview(msg, key, strconv.FormatFloat(float64(proposed), 'f', -1, 32), text)
+}
+
+// previewWindowChange shows the estimated digest size for a new window and asks for confirmation.
+func (b *Bot) previewWindowChange(ctx context.Context, msg *tgbotapi.Message, value string, proposed time.Duration) {
+	items, err := b.recentWhatIfItems(ctx)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	current, _ := b.getPreviewParams(ctx)
+	passed := db.EvaluateWhatIf(items, b.currentThresholds(ctx)).Included
+
+	text := formatConfigPreview(SettingDigestWindow, current.String(), value,
+		formatWindowImpact(current, proposed, passed), lintWindowChange(proposed))
+
+	b.sendConfigPreview(msg, SettingDigestWindow, value, text)
+}
+
+func (b *Bot) sendConfigPreview(msg *tgbotapi.Message, key, value, text string) {
+	data, ok := configApplyCallbackData(key, value)
+	if !ok {
+		b.reply(msg, "❌ Value is too long to confirm.")
+
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Apply", data),
+			tgbotapi.NewInlineKeyboardButtonData("✖️ Cancel", CallbackPrefixConfigApply+configApplyCancel),
+		),
+	)
+
+	if _, err := b.sender.Send(reply); err != nil {
+		b.logger.Error().Err(err).Msg("failed to send config preview")
+	}
+}
+
+func (b *Bot) handleConfigApplyCallback(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	if _, err := b.sender.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
+	}
+
+	if query.Message == nil {
+		return
+	}
+
+	text := "✖️ Change cancelled."
+
+	if query.Data != CallbackPrefixConfigApply+configApplyCancel {
+		text = b.applyConfigChange(ctx, query.Data, query.From.ID)
+	}
+
+	edit := tgbotapi.NewEditMessageText(query.Message.Chat.ID, query.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+
+	if _, err := b.sender.Send(edit); err != nil {
+		b.logger.Error().Err(err).Msg("failed to update config preview")
+	}
+}
+
+// applyConfigChange saves a confirmed change and returns the result message.
+func (b *Bot) applyConfigChange(ctx context.Context, data string, userID int64) string {
+	key, value, ok := parseConfigApplyCallbackData(data)
+	if !ok {
+		return "❌ Invalid change request."
+	}
+
+	stored, err := parseConfigPreviewValue(key, value)
+	if err != nil {
+		return fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error()))
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, key, stored, userID); err != nil {
+		return fmt.Sprintf(ErrSavingFmt, html.EscapeString(key), html.EscapeString(err.Error()))
+	}
+
+	return fmt.Sprintf("✅ <code>%s</code> updated to <code>%s</code>.", html.EscapeString(key), html.EscapeString(value))
+}
+
+// parseConfigPreviewValue validates a confirmed value and returns it in the stored type.
+func parseConfigPreviewValue(key, value string) (interface{}, error) {
+	switch key {
+	case SettingRelevanceThreshold, SettingImportanceThreshold:
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil || v < 0 || v > 1 {
+			return nil, fmt.Errorf("%w: %s", errInvalidThresholdValue, value)
+		}
+
+		return float32(v), nil
+	case SettingDigestWindow:
+		if _, err := time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("parse window: %w", err)
+		}
+
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUnsupportedConfigKey, key)
+}
@@ -24,6 +24,8 @@ const (
 	SettingHistoryLimit = 20
 	// DiscoveriesLimit is the limit for fetching pending discoveries.
 	DiscoveriesLimit = 15
+	// RecommendationsLimit is the number of channel recommendations shown.
+	RecommendationsLimit = 10
 	// DiscoveryCleanupBatchSize is the number of rows to process per cleanup batch.
 	DiscoveryCleanupBatchSize = 100
 	// DefaultDiscoveryMinSeen is the default minimum discovery count for pending list.
@@ -47,30 +49,34 @@ const (
 
 // Subcommand names.
 const (
-	SubCmdStats    = "stats"
-	SubCmdAds      = "ads"
-	SubCmdReset    = "reset"
-	SubCmdClear    = "clear"
-	SubCmdPreview  = "preview"
-	SubCmdApprove  = "approve"
-	SubCmdReject   = "reject"
-	SubCmdCleanup  = "cleanup"
-	SubCmdRejected = "show-rejected"
-	SubCmdConfirm  = "confirm"
-	SubCmdAuto     = "auto"
-	SubCmdMode     = "mode"
-	SubCmdShow     = "show"
-	SubCmdWeekdays = "weekdays"
-	SubCmdWeekends = "weekends"
-	SubCmdTimes    = "times"
-	SubCmdHourly   = "hourly"
+	SubCmdStats     = "stats"
+	SubCmdAds       = "ads"
+	SubCmdReset     = "reset"
+	SubCmdClear     = "clear"
+	SubCmdPreview   = "preview"
+	SubCmdApprove   = "approve"
+	SubCmdReject    = "reject"
+	SubCmdCleanup   = "cleanup"
+	SubCmdRejected  = "show-rejected"
+	SubCmdConfirm   = "confirm"
+	SubCmdAuto      = "auto"
+	SubCmdMode      = "mode"
+	SubCmdShow      = "show"
+	SubCmdWeekdays  = "weekdays"
+	SubCmdWeekends  = "weekends"
+	SubCmdTimes     = "times"
+	SubCmdHourly    = "hourly"
+	SubCmdRecommend = "recommend"
 )
 
 // Weight override mode and toggle values.
 const (
-	WeightOverrideManual = "manual"
-	ToggleOff            = "off"
-	ToggleDisable        = "disable"
+	WeightOverrideManual  = "manual"
+	ToggleOn              = "on"
+	ToggleOff             = "off"
+	ToggleDisable         = "disable"
+	MediaFilterDownweight = "downweight"
+	MediaFilterDrop       = "drop"
 )
 
 // Error message templates for handlers.
@@ -96,6 +102,7 @@ const (
 	SettingLLMOverrideCluster   = "llm_override_cluster"
 	SettingLLMOverrideNarrative = "llm_override_narrative"
 	SettingLLMOverrideTopic     = "llm_override_topic"
+	SettingLLMOverrideRefine    = "llm_override_refine"
 	SettingLLMDailyBudget       = "llm_daily_budget"
 )
 
@@ -148,7 +155,7 @@ const (
 	ErrNoRows                         = "no rows"
 	MsgCouldNotGetImportanceThreshold = "could not get importance threshold from DB"
 	MsgScoresDebugUsage               = "Usage: <code>/scores debug [hours]</code>"
-	MsgScoresDebugReasonsUsage        = "Usage: <code>/scores debug reasons [hours]</code>"
+	MsgScoresDebugReasonsUsage        = "Usage: <code>/scores debug reasons [channel|day] [hours]</code>"
 )
 
 // Status strings.
@@ -165,7 +172,8 @@ const (
 
 // Help messages.
 const (
-	TipSettingsReset = "\n💡 <i>Use <code>/settings reset &lt;key&gt;</code> to return a setting to its default environment value.</i>"
+	TipSettingsReset    = "\n💡 <i>Use <code>/settings reset &lt;key&gt;</code> to return a setting to its default environment value.</i>"
+	TipSettingsRollback = "\n💡 <i>Use <code>/settings rollback &lt;key&gt; [steps|timestamp]</code> to restore an earlier value.</i>"
 )
 
 // Discovery keyword filter messages.
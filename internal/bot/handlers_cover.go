@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdCover is the /config subcommand for the AI cover style and prompt
+// template and the local cover fallback.
+const CmdCover = "cover"
+
+const (
+	coverSubStyle    = "style"
+	coverSubTemplate = "template"
+	coverSubLocal    = "local"
+	coverReset       = "reset"
+)
+
+const coverUsage = "Usage:\n" +
+	"<code>/config cover style &lt;editorial|flat|collage|minimal&gt;</code>\n" +
+	"<code>/config cover template &lt;text|reset&gt;</code>\n" +
+	"<code>/config cover local &lt;on|off&gt;</code>\n\n" +
+	"Templates may use <code>{{TOPICS}}</code>, <code>{{NARRATIVE}}</code>, <code>{{DATE}}</code>, " +
+	"<code>{{TONE}}</code> and <code>{{STYLE}}</code>. The no-text rule is always appended. " +
+	"Covers are cached per digest, so changing the style or template draws a new one. " +
+	"The local cover is composed without an image model when no AI cover is available."
+
+func (b *Bot) handleCover(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+
+	if len(args) == 0 {
+		var style, template string
+
+		local := digest.LocalCoverEnabled(ctx, b.database, b.logger)
+
+		if err := b.database.GetSetting(ctx, digest.SettingDigestCoverStyle, &style); err != nil {
+			b.logger.Debug().Err(err).Msg("could not get digest_cover_style")
+		}
+
+		if err := b.database.GetSetting(ctx, digest.SettingDigestCoverPrompt, &template); err != nil {
+			b.logger.Debug().Err(err).Msg("could not get digest_cover_prompt")
+		}
+
+		b.reply(msg, formatCoverSettings(style, template, local)+"\n\n"+coverUsage)
+
+		return
+	}
+
+	switch strings.ToLower(args[0]) {
+	case coverSubStyle:
+		b.handleCoverStyle(ctx, msg, args[1:])
+	case coverSubTemplate:
+		b.handleCoverTemplate(ctx, msg, args[1:])
+	case coverSubLocal:
+		b.handleCoverLocal(ctx, msg, args[1:])
+	default:
+		b.reply(msg, coverUsage)
+	}
+}
+
+func (b *Bot) handleCoverStyle(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) != 1 || !llm.IsCoverStyle(strings.ToLower(args[0])) {
+		b.reply(msg, fmt.Sprintf("❌ Unknown cover style. Use one of: <code>%s</code>", strings.Join(llm.CoverStyles(), "</code>, <code>")))
+
+		return
+	}
+
+	style := strings.ToLower(args[0])
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestCoverStyle, style, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestCoverStyle, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ AI covers now use the <code>%s</code> style.", style))
+}
+
+func (b *Bot) handleCoverTemplate(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) == 0 {
+		b.reply(msg, coverUsage)
+
+		return
+	}
+
+	template := strings.Join(args, " ")
+	if strings.EqualFold(template, coverReset) {
+		template = ""
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestCoverPrompt, template, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestCoverPrompt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if template == "" {
+		b.reply(msg, "✅ AI covers use the built-in prompt of the style again.")
+
+		return
+	}
+
+	b.reply(msg, "✅ AI cover prompt template saved.")
+}
+
+func (b *Bot) handleCoverLocal(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) != 1 {
+		b.reply(msg, coverUsage)
+
+		return
+	}
+
+	var enabled bool
+
+	switch strings.ToLower(args[0]) {
+	case ToggleOn:
+		enabled = true
+	case ToggleOff:
+		enabled = false
+	default:
+		b.reply(msg, coverUsage)
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestLocalCover, enabled, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestLocalCover, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if enabled {
+		b.reply(msg, "✅ Digests without an AI cover now get a locally composed cover.")
+
+		return
+	}
+
+	b.reply(msg, "✅ Local covers disabled.")
+}
+
+// formatCoverSettings describes the current cover style, template and local
+// cover fallback.
+func formatCoverSettings(style, template string, local bool) string {
+	if style == "" {
+		style = llm.CoverStyleEditorial
+	}
+
+	text := fmt.Sprintf("🎨 <b>AI Cover</b>\nStyle: <code>%s</code>\n", html.EscapeString(style))
+
+	if strings.TrimSpace(template) == "" {
+		text += "Prompt: <i>built-in</i>"
+	} else {
+		text += "Prompt template: <code>" + html.EscapeString(template) + "</code>"
+	}
+
+	if local {
+		return text + "\nLocal cover: <code>on</code>"
+	}
+
+	return text + "\nLocal cover: <code>off</code>"
+}
@@ -43,10 +43,11 @@ func (b *Bot) dispatchDiscoverSubcommand(ctx context.Context, msg *tgbotapi.Mess
 
 func normalizeDiscoverSubcommand(subcommand string) string {
 	aliases := map[string]string{
-		"ignore":        SubCmdReject,
-		"rejected":      SubCmdRejected,
-		"minseen":       "min_seen",
-		"minengagement": "min_engagement",
+		"ignore":          SubCmdReject,
+		"rejected":        SubCmdRejected,
+		"recommendations": SubCmdRecommend,
+		"minseen":         "min_seen",
+		"minengagement":   "min_engagement",
 	}
 
 	if canonical, ok := aliases[subcommand]; ok {
@@ -70,6 +71,8 @@ func (b *Bot) executeDiscoverSimpleCmd(ctx context.Context, msg *tgbotapi.Messag
 		b.handleDiscoverCleanup(ctx, msg)
 	case SubCmdStats:
 		b.handleDiscoverStats(ctx, msg)
+	case SubCmdRecommend:
+		b.handleDiscoverRecommend(ctx, msg)
 	case subCmdHelp:
 		b.reply(msg, discoverHelpMessage())
 	default:
@@ -83,7 +86,8 @@ func discoverHelpMessage() string {
 	return "\U0001F4D6 <b>Discovery Commands</b>\n\n" +
 		"<b>Browse discoveries:</b>\n" +
 		"\u2022 <code>/discover</code> - List pending discoveries\n" +
-		"\u2022 <code>/discover stats</code> - Show statistics\n\n" +
+		"\u2022 <code>/discover stats</code> - Show statistics\n" +
+		"\u2022 <code>/discover recommend</code> - Channels similar to your best performers\n\n" +
 		"<b>Manage channels:</b>\n" +
 		"\u2022 <code>/discover approve @user</code> - Add to tracking\n" +
 		"\u2022 <code>/discover reject @user</code> - Mark as not useful\n" +
@@ -97,6 +101,8 @@ func discoverHelpMessage() string {
 		"\u2022 <code>/discover allow remove &lt;word&gt;</code> - Remove keyword\n" +
 		"\u2022 <code>/discover deny</code> - List deny keywords\n" +
 		"\u2022 <code>/discover deny &lt;word&gt;</code> - Add deny keyword\n\n" +
+		"<b>Automation:</b>\n" +
+		"\u2022 <code>/discover auto</code> - Auto-approval policy and decision log\n\n" +
 		"<b>Maintenance:</b>\n" +
 		"\u2022 <code>/discover cleanup</code> - Backfill matched channels\n" +
 		"\u2022 <code>/discover rejected</code> - Show rejected list"
@@ -120,6 +126,8 @@ func (b *Bot) executeDiscoverArgsCmd(ctx context.Context, msg *tgbotapi.Message,
 		b.handleDiscoverKeywordCmd(ctx, msg, args, SettingDiscoveryAllow, filterTypeAllow)
 	case filterTypeDeny:
 		b.handleDiscoverKeywordCmd(ctx, msg, args, SettingDiscoveryDeny, filterTypeDeny)
+	case SubCmdAuto:
+		b.handleDiscoverAuto(ctx, msg, args)
 	default:
 		return false
 	}
@@ -138,6 +146,8 @@ func discoverUnknownSubcommandMsg(subcommand string) string {
 		"\u2022 <code>allow</code> - Manage allow keywords\n" +
 		"\u2022 <code>deny</code> - Manage deny keywords\n" +
 		"\u2022 <code>stats</code> - Discovery statistics\n" +
+		"\u2022 <code>recommend</code> - Recommended channels\n" +
+		"\u2022 <code>auto</code> - Auto-approval policy\n" +
 		"\u2022 <code>cleanup</code> - Backfill matched channels"
 }
 
@@ -371,7 +381,7 @@ func (b *Bot) handleDiscoverList(ctx context.Context, msg *tgbotapi.Message) {
 		reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
 	}
 
-	if _, err := b.api.Send(reply); err != nil {
+	if _, err := b.sender.Send(reply); err != nil {
 		b.logger.Error().Err(err).Msg("failed to send discover list")
 	}
 }
@@ -911,7 +921,96 @@ func (b *Bot) handleDiscoverCallback(ctx context.Context, query *tgbotapi.Callba
 	callback := tgbotapi.NewCallback(query.ID, callbackText)
 	callback.ShowAlert = true
 
-	if _, err := b.api.Request(callback); err != nil {
+	if _, err := b.sender.Request(callback); err != nil {
 		b.logger.Error().Err(err).Msg("failed to send callback response")
 	}
 }
+
+func (b *Bot) handleDiscoverRecommend(ctx context.Context, msg *tgbotapi.Message) {
+	recs, err := b.database.GetChannelRecommendations(ctx, RecommendationsLimit)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("\u274C Error fetching recommendations: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(recs) == 0 {
+		b.reply(msg, "\U0001F4CB No channel recommendations yet.\n\n\U0001F4A1 Recommendations need pending discoveries from tracked channels and channel quality history.")
+
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, formatChannelRecommendations(recs))
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buildRecommendationKeyboard(recs)...)
+
+	if _, err := b.sender.Send(reply); err != nil {
+		b.logger.Error().Err(err).Msg("failed to send discover recommendations")
+	}
+}
+
+func formatChannelRecommendations(recs []db.ChannelRecommendation) string {
+	var sb strings.Builder
+
+	sb.WriteString("\u2B50 <b>Recommended Channels</b>\n")
+	sb.WriteString("<i>Channels similar to your best performers that you don't follow</i>\n\n")
+
+	for i, r := range recs {
+		fmt.Fprintf(&sb, "%d. <b>@%s</b> (score <code>%.2f</code>)", i+1, html.EscapeString(r.Username), r.Score)
+
+		if r.Title != "" {
+			fmt.Fprintf(&sb, " \u2014 %s", html.EscapeString(r.Title))
+		}
+
+		sb.WriteString("\n")
+
+		for _, reason := range recommendationReasons(r) {
+			fmt.Fprintf(&sb, "  \u2022 %s\n", reason)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\U0001F4A1 <i>Tap a button to approve, or use <code>/discover reject @username</code></i>")
+
+	return sb.String()
+}
+
+// recommendationReasons explains a recommendation score in HTML-safe phrases.
+func recommendationReasons(r db.ChannelRecommendation) []string {
+	source := "@" + html.EscapeString(r.SourceUsername)
+	if r.SourceUsername == "" {
+		source = html.EscapeString(r.SourceTitle)
+	}
+
+	reasons := []string{fmt.Sprintf("Surfaced <code>%d</code>\u00D7 via %s", r.DiscoveryCount, source)}
+
+	if r.SourceInclusionRate > 0 {
+		reasons = append(reasons, fmt.Sprintf("%s lands <code>%.0f%%</code> of its posts in digests", source, r.SourceInclusionRate*percentageMultiplier))
+	}
+
+	switch {
+	case r.SourceIsTopPerformer:
+		reasons = append(reasons, fmt.Sprintf("%s is one of your best performers", source))
+	case r.SourceOverlap > 0:
+		reasons = append(reasons, fmt.Sprintf("%s overlaps with your best performers (Jaccard <code>%.2f</code>)", source, r.SourceOverlap))
+	}
+
+	if r.EngagementScore > 0 {
+		reasons = append(reasons, fmt.Sprintf("Engagement score <code>%.0f</code>", r.EngagementScore))
+	}
+
+	return reasons
+}
+
+func buildRecommendationKeyboard(recs []db.ChannelRecommendation) [][]tgbotapi.InlineKeyboardButton {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(recs))
+
+	for _, r := range recs {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("\u2705 Track @"+r.Username, "discover:approve:"+r.Username),
+		))
+	}
+
+	return rows
+}
@@ -0,0 +1,283 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// SettingDiscoveryAutoPolicy stores the discovery auto-approval policy as JSON.
+const SettingDiscoveryAutoPolicy = "discovery_auto_policy"
+
+const (
+	discoverAutoLogLimit = 10
+	toggleOn             = "on"
+)
+
+var errInvalidAutoPolicyValue = errors.New("invalid auto-policy value")
+
+// discoverAutoPolicySetters maps /discover auto field names to policy updates.
+var discoverAutoPolicySetters = map[string]func(*db.DiscoveryAutoPolicy, string) error{
+	"min_seen": func(p *db.DiscoveryAutoPolicy, v string) error {
+		return parseAutoPolicyInt(v, &p.ApproveMinSeen)
+	},
+	"min_engagement": func(p *db.DiscoveryAutoPolicy, v string) error {
+		return parseAutoPolicyFloat(v, &p.ApproveMinEngagement)
+	},
+	"categories": func(p *db.DiscoveryAutoPolicy, v string) error {
+		p.Categories = parseAutoPolicyList(v)
+
+		return nil
+	},
+	"languages": func(p *db.DiscoveryAutoPolicy, v string) error {
+		p.Languages = parseAutoPolicyList(v)
+
+		return nil
+	},
+	"reject_below": func(p *db.DiscoveryAutoPolicy, v string) error {
+		return parseAutoPolicyFloat(v, &p.RejectBelowEngagement)
+	},
+	"reject_min_seen": func(p *db.DiscoveryAutoPolicy, v string) error {
+		return parseAutoPolicyInt(v, &p.RejectMinSeen)
+	},
+	"reject_mismatched": func(p *db.DiscoveryAutoPolicy, v string) error {
+		return parseAutoPolicyToggle(v, &p.RejectMismatched)
+	},
+	"trial_days": func(p *db.DiscoveryAutoPolicy, v string) error {
+		return parseAutoPolicyInt(v, &p.TrialDays)
+	},
+	"trial_weight": func(p *db.DiscoveryAutoPolicy, v string) error {
+		val, err := strconv.ParseFloat(v, 32)
+		if err != nil || val < 0 || val > 1 {
+			return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+		}
+
+		p.TrialWeight = float32(val)
+
+		return nil
+	},
+}
+
+func (b *Bot) handleDiscoverAuto(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	policy, err := b.getDiscoveryAutoPolicy(ctx)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("\u274C Error reading auto-policy: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(args) < 2 {
+		b.replyDiscoverAutoStatus(ctx, msg, policy)
+
+		return
+	}
+
+	if err := applyDiscoverAutoArgs(&policy, args[1:]); err != nil {
+		b.reply(msg, fmt.Sprintf("\u274C %s\n\n%s", html.EscapeString(err.Error()), discoverAutoUsage()))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, SettingDiscoveryAutoPolicy, policy, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf("\u274C Error saving auto-policy: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "\u2705 Discovery auto-policy updated.\n\n"+formatDiscoveryAutoPolicy(policy))
+}
+
+func (b *Bot) getDiscoveryAutoPolicy(ctx context.Context) (db.DiscoveryAutoPolicy, error) {
+	policy := db.DefaultDiscoveryAutoPolicy()
+
+	if err := b.database.GetSetting(ctx, SettingDiscoveryAutoPolicy, &policy); err != nil {
+		return policy, fmt.Errorf("get discovery auto policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (b *Bot) replyDiscoverAutoStatus(ctx context.Context, msg *tgbotapi.Message, policy db.DiscoveryAutoPolicy) {
+	decisions, err := b.database.GetDiscoveryAutoDecisions(ctx, discoverAutoLogLimit)
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to fetch discovery auto decisions")
+	}
+
+	b.reply(msg, formatDiscoveryAutoPolicy(policy)+"\n"+formatDiscoveryAutoDecisions(decisions)+"\n"+discoverAutoUsage())
+}
+
+// applyDiscoverAutoArgs updates the policy from "/discover auto on|off" or
+// "/discover auto <field> <value>" arguments.
+func applyDiscoverAutoArgs(policy *db.DiscoveryAutoPolicy, args []string) error {
+	field := strings.ToLower(args[0])
+
+	if field == toggleOn || field == ToggleOff {
+		policy.Enabled = field == toggleOn
+
+		return nil
+	}
+
+	setter, ok := discoverAutoPolicySetters[field]
+	if !ok {
+		return fmt.Errorf("%w: unknown field %s", errInvalidAutoPolicyValue, field)
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("%w: missing value for %s", errInvalidAutoPolicyValue, field)
+	}
+
+	return setter(policy, strings.Join(args[1:], " "))
+}
+
+func parseAutoPolicyInt(v string, target *int) error {
+	val, err := strconv.Atoi(v)
+	if err != nil || val < 0 {
+		return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+	}
+
+	*target = val
+
+	return nil
+}
+
+func parseAutoPolicyFloat(v string, target *float64) error {
+	val, err := strconv.ParseFloat(v, 64)
+	if err != nil || val < 0 {
+		return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+	}
+
+	*target = val
+
+	return nil
+}
+
+func parseAutoPolicyToggle(v string, target *bool) error {
+	switch strings.ToLower(v) {
+	case toggleOn:
+		*target = true
+	case ToggleOff:
+		*target = false
+	default:
+		return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+	}
+
+	return nil
+}
+
+// parseAutoPolicyList splits a comma-separated list; "clear" empties it.
+func parseAutoPolicyList(v string) []string {
+	if strings.EqualFold(strings.TrimSpace(v), SubCmdClear) {
+		return nil
+	}
+
+	var values []string
+
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.ToLower(strings.TrimSpace(part)); part != "" {
+			values = append(values, part)
+		}
+	}
+
+	return values
+}
+
+func formatDiscoveryAutoPolicy(p db.DiscoveryAutoPolicy) string {
+	var sb strings.Builder
+
+	state := "\U0001F534 off"
+	if p.Enabled {
+		state = "\U0001F7E2 on"
+	}
+
+	fmt.Fprintf(&sb, "\U0001F916 <b>Discovery Auto-Policy</b> (%s)\n\n", state)
+	sb.WriteString("<b>Approve when:</b>\n")
+	fmt.Fprintf(&sb, "\u2022 seen \u2265 <code>%d</code>, engagement \u2265 <code>%.0f</code>\n", p.ApproveMinSeen, p.ApproveMinEngagement)
+	fmt.Fprintf(&sb, "\u2022 source category: <code>%s</code>\n", html.EscapeString(formatAutoPolicyList(p.Categories)))
+	fmt.Fprintf(&sb, "\u2022 language: <code>%s</code>\n", html.EscapeString(formatAutoPolicyList(p.Languages)))
+
+	sb.WriteString("<b>Reject when:</b>\n")
+
+	if p.RejectBelowEngagement > 0 {
+		fmt.Fprintf(&sb, "\u2022 engagement &lt; <code>%.0f</code> after <code>%d</code> sightings\n", p.RejectBelowEngagement, p.RejectMinSeen)
+	}
+
+	if p.RejectMismatched {
+		sb.WriteString("\u2022 category or language does not match\n")
+	}
+
+	if p.RejectBelowEngagement <= 0 && !p.RejectMismatched {
+		sb.WriteString("\u2022 never (left for manual review)\n")
+	}
+
+	if p.TrialDays > 0 && p.TrialWeight > 0 {
+		fmt.Fprintf(&sb, "<b>Trial:</b> weight <code>%.2f</code> for <code>%d</code> days\n", p.TrialWeight, p.TrialDays)
+	} else {
+		sb.WriteString("<b>Trial:</b> disabled\n")
+	}
+
+	return sb.String()
+}
+
+func formatAutoPolicyList(values []string) string {
+	if len(values) == 0 {
+		return "any"
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func formatDiscoveryAutoDecisions(decisions []db.DiscoveryAutoDecisionEntry) string {
+	if len(decisions) == 0 {
+		return "<i>No automatic decisions yet.</i>\n"
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("<b>Recent decisions:</b>\n")
+
+	for _, d := range decisions {
+		icon := "\u274C"
+		if d.Action == db.DiscoveryAutoActionApprove {
+			icon = "\u2705"
+		}
+
+		fmt.Fprintf(&sb, "%s @%s \u2014 %s", icon, html.EscapeString(d.Username), d.DecidedAt.Format(DateFormatShort))
+
+		if failed := failedAutoPolicyRules(d.Rules); failed != "" {
+			fmt.Fprintf(&sb, " <i>(%s)</i>", html.EscapeString(failed))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func failedAutoPolicyRules(rules []db.DiscoveryRuleResult) string {
+	var failed []string
+
+	for _, r := range rules {
+		if !r.Passed {
+			failed = append(failed, r.Detail)
+		}
+	}
+
+	return strings.Join(failed, "; ")
+}
+
+func discoverAutoUsage() string {
+	return "Usage:\n" +
+		"<code>/discover auto on|off</code>\n" +
+		"<code>/discover auto min_seen|min_engagement &lt;n&gt;</code>\n" +
+		"<code>/discover auto categories|languages &lt;a,b|clear&gt;</code>\n" +
+		"<code>/discover auto reject_below|reject_min_seen &lt;n&gt;</code>\n" +
+		"<code>/discover auto reject_mismatched on|off</code>\n" +
+		"<code>/discover auto trial_days &lt;n&gt;</code> | <code>trial_weight &lt;0-1&gt;</code>"
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdDiversity is the /config subcommand for per-digest channel and topic caps.
+	CmdDiversity = "diversity"
+
+	diversityFieldChannel = "channel"
+	diversityFieldTopic   = "topic"
+	diversityArgs         = 2
+)
+
+func (b *Bot) handleDiversity(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(strings.ToLower(msg.CommandArguments()))
+
+	var caps db.DiversityCaps
+	if err := b.database.GetSetting(ctx, digest.SettingDigestDiversity, &caps); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get digest_diversity")
+	}
+
+	if len(args) == 0 {
+		b.reply(msg, formatDiversityCaps(caps)+"\n"+diversityUsage)
+
+		return
+	}
+
+	if err := applyDiversityArgs(&caps, args); err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), diversityUsage))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestDiversity, caps, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestDiversity, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ Digest diversity caps updated.\n\n"+formatDiversityCaps(caps))
+}
+
+// applyDiversityArgs updates the caps from "/config diversity off" or
+// "/config diversity channel|topic <n|off>" arguments.
+func applyDiversityArgs(caps *db.DiversityCaps, args []string) error {
+	if len(args) == 1 && args[0] == ToggleOff {
+		*caps = db.DiversityCaps{}
+
+		return nil
+	}
+
+	if len(args) != diversityArgs {
+		return fmt.Errorf("%w: expected a field and a value", errInvalidAutoPolicyValue)
+	}
+
+	limit := 0
+
+	if args[1] != ToggleOff {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, args[1])
+		}
+
+		limit = n
+	}
+
+	switch args[0] {
+	case diversityFieldChannel:
+		caps.MaxPerChannel = limit
+	case diversityFieldTopic:
+		caps.MaxPerTopic = limit
+	default:
+		return fmt.Errorf("%w: unknown field %s", errInvalidAutoPolicyValue, args[0])
+	}
+
+	return nil
+}
+
+func formatDiversityCaps(caps db.DiversityCaps) string {
+	return "⚖️ <b>Digest Diversity</b>\n\n" +
+		"• max items per channel: " + formatDiversityCap(caps.MaxPerChannel) + "\n" +
+		"• max items per topic: " + formatDiversityCap(caps.MaxPerTopic) + "\n" +
+		"<i>Items over a cap are deferred and stay undigested.</i>\n"
+}
+
+func formatDiversityCap(n int) string {
+	if n <= 0 {
+		return "<i>no cap</i>"
+	}
+
+	return fmt.Sprintf("<code>%d</code>", n)
+}
+
+const diversityUsage = "Usage:\n" +
+	"<code>/config diversity channel &lt;n|off&gt;</code>\n" +
+	"<code>/config diversity topic &lt;n|off&gt;</code>\n" +
+	"<code>/config diversity off</code> - remove both caps"
@@ -0,0 +1,177 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// dropCategoryCounts is the drop count of a category and of each of its reasons.
+type dropCategoryCounts struct {
+	total   int
+	reasons []db.DropReasonBreakdownRow
+}
+
+func (b *Bot) handleScoresDebugReasons(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	group := db.DropGroupNone
+	if len(args) > 0 && (strings.EqualFold(args[0], db.DropGroupChannel) || strings.EqualFold(args[0], db.DropGroupDay)) {
+		group = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	hours, valid := parseScoresDebugArgs(args)
+	if !valid {
+		b.reply(msg, tr(ctx, MsgScoresDebugReasonsUsage))
+
+		return
+	}
+
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+
+	rows, err := b.database.GetDropReasonBreakdown(ctx, since, group)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error fetching drop reasons: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	var safetyFlags []db.DropReasonStat
+	if group == db.DropGroupNone {
+		safetyFlags, err = b.database.GetSafetyFlagStats(ctx, since)
+		if err != nil {
+			b.logger.Warn().Err(err).Msg("failed to fetch safety flag stats")
+		}
+	}
+
+	if len(rows) == 0 && len(safetyFlags) == 0 {
+		b.reply(msg, tr(ctx, "No drop reasons logged in the last %d hours.", hours))
+
+		return
+	}
+
+	if group != db.DropGroupNone {
+		b.reply(msg, formatDropReasonGroups(hours, group, rows))
+
+		return
+	}
+
+	b.reply(msg, formatDropReasons(hours, rows, safetyFlags))
+}
+
+// formatDropReasons lists the drop categories with the reasons under each.
+func formatDropReasons(hours int, rows []db.DropReasonBreakdownRow, safetyFlags []db.DropReasonStat) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "📊 <b>Drop Reasons (last %d hours)</b>\n\n", hours)
+
+	categories := categorizeDrops(rows)
+	total := 0
+
+	for _, category := range domain.DropCategories {
+		counts, ok := categories[category]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "<b>%s</b>: <code>%d</code>\n", category, counts.total)
+
+		for _, row := range counts.reasons {
+			fmt.Fprintf(&sb, "   "+statsItemFormat, html.EscapeString(row.Reason), row.Count)
+		}
+
+		total += counts.total
+	}
+
+	fmt.Fprintf(&sb, "\nTotal logged: <code>%d</code>\n", total)
+
+	// Flagged items that were kept are handled per target when digests render
+	if len(safetyFlags) > 0 {
+		sb.WriteString("\n🛡 <b>Flagged items (per-target safety policy)</b>\n")
+
+		for _, entry := range safetyFlags {
+			fmt.Fprintf(&sb, statsItemFormat, html.EscapeString(entry.Reason), entry.Count)
+		}
+	}
+
+	return sb.String()
+}
+
+// formatDropReasonGroups lists the drop categories of each channel, busiest
+// first, or of each day, latest first. Only the first DefaultScoresLimit
+// groups are shown.
+func formatDropReasonGroups(hours int, group string, rows []db.DropReasonBreakdownRow) string {
+	var (
+		labels []string
+		byKey  = make(map[string][]db.DropReasonBreakdownRow)
+		totals = make(map[string]int)
+	)
+
+	for _, row := range rows {
+		if _, ok := byKey[row.Group]; !ok {
+			labels = append(labels, row.Group)
+		}
+
+		byKey[row.Group] = append(byKey[row.Group], row)
+		totals[row.Group] += row.Count
+	}
+
+	if group == db.DropGroupDay {
+		slices.Reverse(labels)
+	} else {
+		slices.SortStableFunc(labels, func(a, b string) int { return totals[b] - totals[a] })
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "📊 <b>Drop Reasons by %s (last %d hours)</b>\n", group, hours)
+
+	for i, label := range labels {
+		if i == DefaultScoresLimit {
+			fmt.Fprintf(&sb, "\n…and %d more\n", len(labels)-DefaultScoresLimit)
+
+			break
+		}
+
+		fmt.Fprintf(&sb, "\n<b>%s</b>: <code>%d</code>\n", html.EscapeString(label), totals[label])
+
+		categories := categorizeDrops(byKey[label])
+
+		var parts []string
+
+		for _, category := range domain.DropCategories {
+			if counts, ok := categories[category]; ok {
+				parts = append(parts, fmt.Sprintf("%s <code>%d</code>", category, counts.total))
+			}
+		}
+
+		sb.WriteString("   " + strings.Join(parts, " · ") + "\n")
+	}
+
+	return sb.String()
+}
+
+// categorizeDrops sums the drop reasons by category, keeping the reasons in
+// their original order.
+func categorizeDrops(rows []db.DropReasonBreakdownRow) map[domain.DropCategory]*dropCategoryCounts {
+	categories := make(map[domain.DropCategory]*dropCategoryCounts)
+
+	for _, row := range rows {
+		category := domain.CategorizeDropReason(row.Reason)
+		if categories[category] == nil {
+			categories[category] = &dropCategoryCounts{}
+		}
+
+		categories[category].total += row.Count
+		categories[category].reasons = append(categories[category].reasons, row)
+	}
+
+	return categories
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdSections is the /ai subcommand for the editor overview section plan.
+const CmdSections = "sections"
+
+// toggleEditorSection enables or disables one section in the plan and returns
+// the plan in render order.
+func toggleEditorSection(current []string, section string, enabled bool) []string {
+	next := make([]string, 0, len(current)+1)
+
+	for _, s := range current {
+		if s != section {
+			next = append(next, s)
+		}
+	}
+
+	if enabled {
+		next = append(next, section)
+	}
+
+	return digest.NormalizeEditorSections(next)
+}
+
+func (b *Bot) handleEditorSections(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(strings.ToLower(msg.CommandArguments()))
+
+	switch {
+	case len(args) == 0:
+		b.replyEditorSections(msg, b.loadEditorSections(ctx))
+	case len(args) == 1 && args[0] == SubCmdReset:
+		if err := b.database.DeleteSettingWithHistory(ctx, digest.SettingEditorSections, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.replyEditorSections(msg, llm.EditorSections)
+	case len(args) == 2 && llm.IsEditorSection(args[0]) && (args[1] == "on" || args[1] == ToggleOff):
+		sections := toggleEditorSection(b.loadEditorSections(ctx), args[0], args[1] == "on")
+
+		if err := b.database.SaveSettingWithHistory(ctx, digest.SettingEditorSections, sections, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.replyEditorSections(msg, sections)
+	default:
+		b.reply(msg, "Usage: <code>/ai sections [&lt;section&gt; on|off | reset]</code>\n\n"+
+			"Sections: <code>"+strings.Join(llm.EditorSections, "</code>, <code>")+"</code>")
+	}
+}
+
+// loadEditorSections returns the enabled sections; an unset setting enables all.
+func (b *Bot) loadEditorSections(ctx context.Context) []string {
+	var sections []string
+
+	if err := b.database.GetSetting(ctx, digest.SettingEditorSections, &sections); err != nil {
+		b.logger.Warn().Err(err).Msg("could not get editor_sections from DB")
+	}
+
+	if sections == nil {
+		return llm.EditorSections
+	}
+
+	return digest.NormalizeEditorSections(sections)
+}
+
+func (b *Bot) replyEditorSections(msg *tgbotapi.Message, enabled []string) {
+	var sb strings.Builder
+
+	sb.WriteString("📝 <b>Editor Overview Sections</b>\n\n")
+
+	for _, section := range llm.EditorSections {
+		status := "❌"
+
+		for _, s := range enabled {
+			if s == section {
+				status = "✅"
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("%s <code>%s</code>\n", status, section))
+	}
+
+	if len(enabled) == 0 {
+		sb.WriteString("\nAll sections are off: the editor writes a free-form overview.")
+	}
+
+	sb.WriteString("\nToggle with <code>/ai sections &lt;section&gt; on|off</code>. Applies when <code>/ai editor</code> is on.")
+
+	b.reply(msg, sb.String())
+}
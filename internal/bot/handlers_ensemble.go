@@ -0,0 +1,270 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingEnsembleScoringPolicy stores the ensemble scoring policy as JSON.
+	SettingEnsembleScoringPolicy = "ensemble_scoring_policy"
+
+	ensembleFieldChannels = "channels"
+	ensembleDefaultModel  = "default"
+	ensembleStatsWindow   = 24 * time.Hour
+)
+
+// ensemblePolicySetters maps /ai ensemble field names to policy updates.
+var ensemblePolicySetters = map[string]func(*db.EnsembleScoringPolicy, string) error{
+	"variants": func(p *db.EnsembleScoringPolicy, v string) error {
+		variants, err := parseEnsembleVariants(v)
+		if err != nil {
+			return err
+		}
+
+		p.Variants = variants
+
+		return nil
+	},
+	"max_disagreement": func(p *db.EnsembleScoringPolicy, v string) error {
+		val, err := strconv.ParseFloat(v, 32)
+		if err != nil || val <= 0 || val > 1 {
+			return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+		}
+
+		p.MaxDisagreement = float32(val)
+
+		return nil
+	},
+	"route": func(p *db.EnsembleScoringPolicy, v string) error {
+		switch route := strings.ToLower(v); route {
+		case db.EnsembleRouteSmart, db.EnsembleRouteAnnotate, db.EnsembleRouteBoth, db.EnsembleRouteNone:
+			p.Route = route
+		default:
+			return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+		}
+
+		return nil
+	},
+	"smart_model": func(p *db.EnsembleScoringPolicy, v string) error {
+		p.SmartModel = ""
+		if !strings.EqualFold(v, ensembleDefaultModel) {
+			p.SmartModel = v
+		}
+
+		return nil
+	},
+}
+
+func (b *Bot) handleEnsemble(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+
+	policy := db.DefaultEnsembleScoringPolicy()
+	if err := b.database.GetSetting(ctx, SettingEnsembleScoringPolicy, &policy); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get ensemble_scoring_policy")
+	}
+
+	channels, err := b.database.GetActiveChannels(ctx)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrFetchingChannelsFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(args) == 0 {
+		b.replyEnsembleStatus(ctx, msg, policy, channels)
+
+		return
+	}
+
+	if err := applyEnsembleArgs(&policy, args, channels); err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), ensembleUsage()))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, SettingEnsembleScoringPolicy, policy, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, SettingEnsembleScoringPolicy, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ Ensemble scoring updated. Applies to newly scored items.\n\n"+formatEnsemblePolicy(policy, channels))
+}
+
+func (b *Bot) replyEnsembleStatus(ctx context.Context, msg *tgbotapi.Message, policy db.EnsembleScoringPolicy, channels []db.Channel) {
+	stats, err := b.database.GetEnsembleStats(ctx, time.Now().Add(-ensembleStatsWindow))
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to fetch ensemble stats")
+	}
+
+	b.reply(msg, formatEnsemblePolicy(policy, channels)+"\n"+formatEnsembleStats(stats)+"\n"+ensembleUsage())
+}
+
+// applyEnsembleArgs updates the policy from "/ai ensemble on|off" or
+// "/ai ensemble <field> <value>" arguments. Channels are resolved to IDs.
+func applyEnsembleArgs(policy *db.EnsembleScoringPolicy, args []string, channels []db.Channel) error {
+	field := strings.ToLower(args[0])
+
+	if field == toggleOn || field == ToggleOff {
+		policy.Enabled = field == toggleOn
+
+		return nil
+	}
+
+	setter, ok := ensemblePolicySetters[field]
+	if !ok && field != ensembleFieldChannels {
+		return fmt.Errorf("%w: unknown field %s", errInvalidAutoPolicyValue, field)
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("%w: missing value for %s", errInvalidAutoPolicyValue, field)
+	}
+
+	value := strings.Join(args[1:], " ")
+
+	if field == ensembleFieldChannels {
+		ids, err := resolveEnsembleChannels(value, channels)
+		if err != nil {
+			return err
+		}
+
+		policy.Channels = ids
+
+		return nil
+	}
+
+	return setter(policy, value)
+}
+
+// parseEnsembleVariants parses "model[@prompt],..."; "default" stands for the
+// summarize task's model and "clear" removes all variants.
+func parseEnsembleVariants(v string) ([]db.EnsembleVariant, error) {
+	if strings.EqualFold(strings.TrimSpace(v), SubCmdClear) {
+		return nil, nil
+	}
+
+	var variants []db.EnsembleVariant
+
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		model, prompt, _ := strings.Cut(part, "@")
+		if strings.EqualFold(model, ensembleDefaultModel) {
+			model = ""
+		}
+
+		if model == "" && prompt == "" {
+			return nil, fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, part)
+		}
+
+		variants = append(variants, db.EnsembleVariant{Model: model, Prompt: prompt})
+	}
+
+	return variants, nil
+}
+
+// resolveEnsembleChannels maps "@a,@b" to channel IDs; "clear" removes the scope.
+func resolveEnsembleChannels(v string, channels []db.Channel) ([]string, error) {
+	var ids []string
+
+	for _, identifier := range parseAutoPolicyList(v) {
+		channel := findChannelByIdentifier(channels, identifier)
+		if channel == nil {
+			return nil, fmt.Errorf("%w: unknown channel %s", errInvalidAutoPolicyValue, identifier)
+		}
+
+		ids = append(ids, channel.ID)
+	}
+
+	return ids, nil
+}
+
+func formatEnsemblePolicy(p db.EnsembleScoringPolicy, channels []db.Channel) string {
+	var sb strings.Builder
+
+	state := "🔴 off"
+	if p.Enabled {
+		state = "🟢 on"
+	}
+
+	fmt.Fprintf(&sb, "🎲 <b>Ensemble Scoring</b> (%s)\n\n", state)
+
+	variants := make([]string, 0, len(p.Variants))
+	for _, v := range p.Variants {
+		variants = append(variants, v.String())
+	}
+
+	if len(variants) == 0 {
+		sb.WriteString("• extra scorers: <i>none, ensemble scoring is inactive</i>\n")
+	} else {
+		fmt.Fprintf(&sb, "• extra scorers: <code>%s</code>\n", html.EscapeString(strings.Join(variants, ", ")))
+	}
+
+	fmt.Fprintf(&sb, "• low confidence at disagreement ≥ <code>%.2f</code>\n", p.MaxDisagreement)
+	fmt.Fprintf(&sb, "• low-confidence route: <code>%s</code>\n", html.EscapeString(p.Route))
+
+	if p.RoutesToSmart() {
+		smartModel := p.SmartModel
+		if smartModel == "" {
+			smartModel = ensembleDefaultModel
+		}
+
+		fmt.Fprintf(&sb, "• smart model: <code>%s</code>\n", html.EscapeString(smartModel))
+	}
+
+	fmt.Fprintf(&sb, "• channels: <code>%s</code>\n", html.EscapeString(formatAutoPolicyList(ensembleChannelNames(p.Channels, channels))))
+
+	return sb.String()
+}
+
+// ensembleChannelNames renders scoped channel IDs as @usernames where known.
+func ensembleChannelNames(ids []string, channels []db.Channel) []string {
+	names := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		name := id
+
+		for i := range channels {
+			if channels[i].ID == id && channels[i].Username != "" {
+				name = "@" + channels[i].Username
+
+				break
+			}
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func formatEnsembleStats(s db.EnsembleStats) string {
+	if s.Items == 0 {
+		return "<i>No items ensemble scored in the last 24h.</i>\n"
+	}
+
+	return fmt.Sprintf("<b>Last 24h:</b> %d items, %d low confidence (%d re-scored, %d queued for annotation), avg disagreement <code>%.2f</code>\n",
+		s.Items, s.LowConfidence, s.Rescored, s.Annotated, s.AvgDisagreement)
+}
+
+func ensembleUsage() string {
+	return "Usage:\n" +
+		"<code>/ai ensemble on|off</code>\n" +
+		"<code>/ai ensemble variants &lt;model[@prompt],...|clear&gt;</code>\n" +
+		"<code>/ai ensemble max_disagreement &lt;0-1&gt;</code>\n" +
+		"<code>/ai ensemble route smart|annotate|both|none</code>\n" +
+		"<code>/ai ensemble smart_model &lt;model|default&gt;</code>\n" +
+		"<code>/ai ensemble channels &lt;@a,@b|clear&gt;</code>"
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/markup"
+)
+
+const (
+	// CmdFormat is the /config subcommand for the digest output mode.
+	CmdFormat = "format"
+
+	// formatInherit clears a per-target override.
+	formatInherit = "default"
+)
+
+// digestRenderer returns the renderer of the output mode configured for a chat.
+func (b *Bot) digestRenderer(ctx context.Context, chatID int64) markup.Renderer {
+	return markup.NewRenderer(digest.LoadOutputFormat(ctx, b.database, chatID))
+}
+
+func (b *Bot) handleFormat(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 || len(args) > 2 {
+		b.replyFormatUsage(msg)
+
+		return
+	}
+
+	name := strings.ToLower(args[0])
+	if len(args) == 1 {
+		b.setGlobalFormat(ctx, msg, name)
+
+		return
+	}
+
+	b.setTargetFormat(ctx, msg, name, args[1])
+}
+
+func (b *Bot) replyFormatUsage(msg *tgbotapi.Message) {
+	b.reply(msg, "Usage: <code>/config format &lt;html|markdownv2&gt; [chat_id|@channel]</code>\n\n"+
+		"Without a target, sets the default for all targets. "+
+		"Use <code>/config format default &lt;target&gt;</code> to remove a target override.")
+}
+
+func (b *Bot) setGlobalFormat(ctx context.Context, msg *tgbotapi.Message, name string) {
+	format, ok := markup.ParseFormat(name)
+	if !ok {
+		b.replyFormatUsage(msg)
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestFormat, string(format), msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Digest output format set to <code>%s</code>.", format))
+}
+
+func (b *Bot) setTargetFormat(ctx context.Context, msg *tgbotapi.Message, name, target string) {
+	format, ok := markup.ParseFormat(name)
+	if name != formatInherit && !ok {
+		b.replyFormatUsage(msg)
+
+		return
+	}
+
+	chatID, chat, errMsg := b.resolveTargetChat(ctx, target)
+	if errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	key := fmt.Sprintf(digest.FormatTargetKeyFmt, chatID)
+
+	if name == formatInherit {
+		if err := b.database.DeleteSettingWithHistory(ctx, key, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf("✅ <b>%s</b> now uses the default digest output format.", html.EscapeString(chat.Title)))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, key, string(format), msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Digest output format for <b>%s</b> set to <code>%s</code>.", html.EscapeString(chat.Title), format))
+}
@@ -4,18 +4,29 @@ package bot
 func helpSummaryMessage() string {
 	return "\U0001F44B <b>Telegram Digest Bot</b>\n\n" +
 		"Quick start:\n" +
-		"\u2022 <code>/setup</code> - Guided setup\n" +
+		"\u2022 <code>/setup</code> - Step-by-step setup wizard (<code>/setup cancel</code> to stop)\n" +
 		"\u2022 <code>/status</code> - System status\n" +
-		"\u2022 <code>/preview</code> - Preview next digest\n\n" +
+		"\u2022 <code>/preview [diff]</code> - Preview next digest, or compare it with the last posted one\n" +
+		"\u2022 <code>/catchup [since]</code> - Private recap since your last read\n" +
+		"\u2022 <code>/catchup schedule</code> - Recaps in your own time zone and delivery windows\n\n" +
 		"Core areas:\n" +
 		"\u2022 <code>/channel</code> - Manage sources\n" +
 		"\u2022 <code>/filter</code> - Filter rules\n" +
+		"\u2022 <code>/rules</code> - Pre-filter rules before LLM scoring\n" +
 		"\u2022 <code>/discover</code> - Channel discovery\n" +
 		"\u2022 <code>/schedule</code> - Digest timing\n" +
 		"\u2022 <code>/config</code> - Settings\n" +
 		"\u2022 <code>/ai</code> - AI features\n" +
 		"\u2022 <code>/system</code> - Diagnostics\n" +
-		"\u2022 <code>/research</code> - Research dashboard\n\n" +
+		"\u2022 <code>/research</code> - Research dashboard\n" +
+		"\u2022 <code>/search &lt;query&gt; [days]</code> - Search items\n" +
+		"\u2022 <code>/similar &lt;item_id|text&gt;</code> - Nearest items by embedding\n" +
+		"\u2022 <code>/story [timeline &lt;id&gt;]</code> - Ongoing stories and their timelines\n" +
+		"\u2022 <code>/whatif relevance=&lt;v&gt; importance=&lt;v&gt;</code> - Simulate thresholds on recent items\n" +
+		"\u2022 <code>/item &lt;id&gt;</code> - Item details and dedup decisions\n" +
+		"\u2022 <code>/item track &lt;id&gt;</code> - Create an issue tracker ticket for an item\n" +
+		"\u2022 <code>/watch</code> - Saved search notifications\n" +
+		"\u2022 <code>/watchlist</code> - People and organizations to watch\n\n" +
 		"Data & feedback:\n" +
 		"\u2022 <code>/scores</code> <code>/factcheck</code> <code>/enrichment</code> <code>/ratings</code> <code>/feedback</code>\n\n" +
 		"More: <code>/help &lt;topic&gt;</code> (channels, discover, filters, schedule, config, ai, enrichment, system, research, scores, factcheck, ratings)\n" +
@@ -28,9 +39,16 @@ func helpChannelsMessage() string {
 	return "\U0001F4CB <b>Channel Management</b>\n" +
 		"\u2022 <code>/channel add &lt;id|@user|link&gt;</code>\n" +
 		"\u2022 <code>/channel remove &lt;id|@user&gt;</code>\n" +
-		"\u2022 <code>/channel list</code>\n" +
+		"\u2022 <code>/channel list [state=|weight=|noise=|sort=] [compact]</code>\n" +
+		"\u2022 <code>/channel export [json|csv]</code>\n" +
+		"\u2022 <code>/channel import [apply]</code> (file or reply to a file)\n" +
+		"\u2022 <code>/channel group [list|create|delete|add|remove|threshold|digest]</code>\n" +
+		"\u2022 <code>/channel snooze &lt;@user&gt; &lt;duration|off&gt; [digest]</code>\n" +
+		"\u2022 <code>/channel trial [list|start|report|promote|reject|days]</code>\n" +
+		"\u2022 <code>/channel purge &lt;@user&gt; [confirm]</code>\n" +
 		"\u2022 <code>/channel weight &lt;@user&gt; [0.1-2.0|auto]</code>\n" +
 		"\u2022 <code>/channel relevance &lt;@user&gt; [auto|manual]</code>\n" +
+		"\u2022 <code>/channel quota &lt;@user&gt; [n/day|n/hour [sample|drop|defer]|off]</code>\n" +
 		"\u2022 <code>/channel stats</code>"
 }
 
@@ -44,7 +62,9 @@ func helpFiltersMessage() string {
 		"\u2022 <code>/filter mode &lt;mixed|allow|deny&gt;</code>\n" +
 		"\u2022 <code>/filter keywords</code>\n" +
 		"\u2022 <code>/filter min_length &lt;n&gt;</code>\n" +
-		"\u2022 <code>/filter skip_forwards &lt;on|off&gt;</code>"
+		"\u2022 <code>/filter skip_forwards &lt;on|off&gt;</code>\n" +
+		"\u2022 <code>/filter media &lt;off|downweight|drop&gt;</code> - Image-only stickers and memes\n" +
+		"\u2022 <code>/rules [add|remove|move|test|reset]</code> - Pre-filter rules with hit counts"
 }
 
 // helpDiscoverMessage returns the help message for discovery commands.
@@ -82,7 +102,25 @@ func helpConfigMessage() string {
 		"\u2022 <code>/config target &lt;id|@user&gt;</code>\n" +
 		"\u2022 <code>/config window &lt;duration&gt;</code>\n" +
 		"\u2022 <code>/config language &lt;code&gt;</code>\n" +
+		"\u2022 <code>/config bot_language &lt;en|ru&gt;</code>\n" +
 		"\u2022 <code>/config tone &lt;professional|casual|brief&gt;</code>\n" +
+		"\u2022 <code>/config item_links &lt;off|bot|research&gt;</code>\n" +
+		"\u2022 <code>/config toc &lt;n|off&gt;</code>\n" +
+		"\u2022 <code>/config stale [off|skip|section|misses|lookback|decay]</code>\n" +
+		"\u2022 <code>/config diversity channel|topic &lt;n|off&gt;</code>\n" +
+		"\u2022 <code>/config mmr &lt;0-1|off&gt;</code>\n" +
+		"\u2022 <code>/config tier_sort &lt;importance|corroboration&gt;</code>\n" +
+		"\u2022 <code>/config prebuild &lt;minutes|off&gt;</code>\n" +
+		"\u2022 <code>/config cover style|template|local</code>\n" +
+		"\u2022 <code>/config verbosity &lt;compact|standard|detailed&gt; [target]</code>\n" +
+		"\u2022 <code>/config format &lt;html|markdownv2&gt; [target]</code>\n" +
+		"\u2022 <code>/config alt_text &lt;off|caption|spoiler&gt;</code>\n" +
+		"\u2022 <code>/config safety &lt;off|warn|blur|block&gt; [target]</code>\n" +
+		"\u2022 <code>/config regions &lt;ua,pl|off&gt;</code>\n" +
+		"\u2022 <code>/config shadow [target|set|unset|promote|off]</code>\n" +
+		"\u2022 <code>/config stages [&lt;stage&gt; on|off|shadow|shadow_model]</code>\n" +
+		"\u2022 <code>/template show|set|activate</code>\n" +
+		"\u2022 <code>/rollup weekly|monthly on|off</code>\n" +
 		"\u2022 <code>/config relevance &lt;0-1&gt;</code>\n" +
 		"\u2022 <code>/config importance &lt;0-1&gt;</code>\n" +
 		"\u2022 <code>/config links &lt;on|off&gt;</code>\n" +
@@ -98,12 +136,15 @@ func helpAIMessage() string {
 		"\u2022 <code>/ai tone &lt;professional|casual|brief&gt;</code>\n" +
 		"\u2022 <code>/ai prompt</code>\n" +
 		"\u2022 <code>/ai editor &lt;on|off&gt;</code>\n" +
+		"\u2022 <code>/ai sections [&lt;section&gt; on|off]</code>\n" +
 		"\u2022 <code>/ai tiered &lt;on|off&gt;</code>\n" +
 		"\u2022 <code>/ai vision &lt;on|off&gt;</code>\n" +
 		"\u2022 <code>/ai consolidated &lt;on|off&gt;</code>\n" +
 		"\u2022 <code>/ai details &lt;on|off&gt;</code>\n" +
 		"\u2022 <code>/ai topics &lt;on|off&gt;</code>\n" +
-		"\u2022 <code>/ai dedup &lt;mode&gt;</code>"
+		"\u2022 <code>/ai twopass &lt;on|off&gt;</code>\n" +
+		"\u2022 <code>/ai dedup &lt;mode&gt;</code>\n" +
+		"\u2022 <code>/ai ensemble [on|off|&lt;field&gt; &lt;value&gt;]</code>"
 }
 
 // helpSystemMessage returns the help message for system commands.
@@ -111,6 +152,7 @@ func helpSystemMessage() string {
 	return "\U0001F6E0 <b>System</b>\n" +
 		"\u2022 <code>/system status</code>\n" +
 		"\u2022 <code>/system settings</code>\n" +
+		"\u2022 <code>/system settings rollback &lt;key&gt; [steps|timestamp]</code>\n" +
 		"\u2022 <code>/system errors</code>\n" +
 		"\u2022 <code>/system retry</code>\n" +
 		"\u2022 <code>/system factcheck</code>"
@@ -121,7 +163,10 @@ func helpScoresMessage() string {
 	return "\U0001F4CA <b>Scores</b>\n" +
 		"\u2022 <code>/scores [hours] [limit]</code>\n" +
 		"\u2022 <code>/scores debug [hours]</code>\n" +
-		"\u2022 <code>/scores debug reasons [hours]</code>"
+		"\u2022 <code>/scores debug reasons [channel|day] [hours]</code>\n" +
+		"\u2022 <code>/scores versions</code>\n" +
+		"\u2022 <code>/scores reference &lt;version&gt;</code>\n" +
+		"\u2022 <code>/scores normalize &lt;off|zscore|quantile&gt;</code>"
 }
 
 // helpFactCheckMessage returns the help message for fact check commands.
@@ -134,14 +179,27 @@ func helpFactCheckMessage() string {
 func helpRatingsMessage() string {
 	return "\u2B50 <b>Ratings</b>\n" +
 		"\u2022 <code>/ratings [days] [limit]</code>\n" +
-		"\u2022 <code>/ratings stats [limit]</code>"
+		"\u2022 <code>/ratings stats [limit]</code>\n" +
+		"\u2022 <code>/ratings clicks [days] [limit]</code> - click-through of tracked digest links\n" +
+		"\u2022 <code>/quality trend [days]</code> - daily precision, recall and noise of live scores against ratings\n" +
+		"\u2022 <code>/stats audience [days]</code> - member growth and digest views by hour, size and topic"
 }
 
 // helpResearchMessage returns the help message for research commands.
 func helpResearchMessage() string {
 	return "\U0001F50E <b>Research Dashboard</b>\n" +
 		"\u2022 <code>/research login</code> - generate a login link for the research UI\n" +
-		"\u2022 <code>/research rebuild</code> - refresh research materialized views"
+		"\u2022 <code>/research rebuild</code> - refresh research materialized views\n" +
+		"\u2022 <code>/apikey create &lt;name&gt; [scope:read|label] [rate:&lt;n&gt;]</code> - named research API key with its own rate limit\n" +
+		"\u2022 <code>/apikey list</code> / <code>/apikey rate &lt;name&gt; &lt;n&gt;</code> / <code>/apikey revoke &lt;name&gt;</code>\n" +
+		"\u2022 <code>/search &lt;query&gt; [days]</code> - full-text search with paging\n" +
+		"\u2022 <code>/similar &lt;item_id|text&gt;</code> - nearest items and their clusters (or reply to a forwarded message)\n" +
+		"\u2022 <code>/story</code> / <code>/story timeline &lt;id&gt;</code> - stories linked across digests and their event timelines\n" +
+		"\u2022 <code>/whatif [relevance=&lt;v&gt;] [importance=&lt;v&gt;] [weight=&lt;channel&gt;:&lt;w&gt;] [hours=&lt;n&gt;]</code> - which recent items new thresholds and weights would include, with projected noise\n" +
+		"\u2022 <code>/watch add \"query\" [channel:@name] [topic:name]</code> - notify on new matches\n" +
+		"\u2022 <code>/watch list</code> / <code>/watch remove &lt;id&gt;</code>\n" +
+		"\u2022 <code>/watchlist add \"Name\" [alias:\"Other name\"] [type:person|org]</code> - alert on mentions of a person or organization\n" +
+		"\u2022 <code>/watchlist list</code> / <code>/watchlist remove &lt;id&gt;</code>"
 }
 
 // helpAllMessage returns the combined help message for all commands.
@@ -160,28 +218,3 @@ func helpAllMessage() string {
 		helpFactCheckMessage() + "\n\n" +
 		helpRatingsMessage()
 }
-
-// botFatherCommandsMessage returns the message for BotFather commands setup.
-func botFatherCommandsMessage() string {
-	return "Use <code>/setcommands</code> in BotFather with:\n\n" +
-		"<code>" +
-		"start - Show help\n" +
-		"help - Command overview\n" +
-		"setup - Guided setup\n" +
-		"status - System status\n" +
-		"preview - Preview next digest\n" +
-		"channel - Manage channels\n" +
-		"filter - Manage filters\n" +
-		"config - Configure settings\n" +
-		"schedule - Digest schedule\n" +
-		"ai - AI features\n" +
-		"system - System tools\n" +
-		"research - Research dashboard\n" +
-		"scores - Score stats\n" +
-		"factcheck - Fact check status\n" +
-		"ratings - Rating stats\n" +
-		"discover - Channel discovery\n" +
-		"feedback - Rate an item\n" +
-		"settings - Show current settings" +
-		"</code>"
-}
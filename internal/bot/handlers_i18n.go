@@ -0,0 +1,42 @@
+package bot
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CmdBotLanguage is the /config subcommand for the admin interface language.
+const CmdBotLanguage = "bot_language"
+
+const botLanguageUsageFmt = "Usage: <code>/config bot_language &lt;%s&gt;</code>\n\nChanges the language of bot replies. The digest language is set with <code>/config language</code>."
+
+func (b *Bot) handleBotLanguage(ctx context.Context, msg *tgbotapi.Message) {
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+	langs := botLanguages()
+
+	if arg == "" {
+		b.reply(msg, tr(ctx, "🌐 Bot language: <code>%s</code>", html.EscapeString(botLanguage(ctx)))+"\n\n"+
+			tr(ctx, botLanguageUsageFmt, strings.Join(langs, "|")))
+
+		return
+	}
+
+	if !slices.Contains(langs, arg) {
+		b.reply(msg, tr(ctx, "❌ Unsupported language <code>%s</code>.", html.EscapeString(arg))+"\n\n"+
+			tr(ctx, botLanguageUsageFmt, strings.Join(langs, "|")))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, SettingBotLanguage, arg, msg.From.ID); err != nil {
+		b.reply(msg, tr(ctx, ErrSavingFmt, SettingBotLanguage, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, tr(withLanguage(ctx, arg), "✅ Bot language set to <code>%s</code>.", arg))
+}
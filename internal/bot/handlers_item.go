@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdItem shows an item card with its dedup decisions.
+	CmdItem = "item"
+
+	itemDedupDecisionLimit = 10
+	itemDedupSnippetLimit  = 120
+)
+
+const itemUsage = "Usage: <code>/item &lt;item_id|message_id&gt;</code> - item details\n" +
+	"<code>/item track &lt;item_id&gt;</code> - create an issue tracker ticket"
+
+// handleItem shows an item by ID together with the duplicates folded into it.
+// The ID may also be a dropped raw message, in which case only its dedup
+// decision is shown. "/item track <id>" files a ticket for the item instead.
+func (b *Bot) handleItem(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 2 && strings.EqualFold(args[0], itemSubTrack) && isUUIDString(args[1]) {
+		b.handleItemTrack(ctx, msg, args[1])
+
+		return
+	}
+
+	if len(args) != 1 || !isUUIDString(args[0]) {
+		b.reply(msg, itemUsage)
+
+		return
+	}
+
+	id := args[0]
+
+	decisions, err := b.database.GetDedupDecisions(ctx, db.DedupDecisionFilter{ID: id, Limit: itemDedupDecisionLimit})
+	if err != nil {
+		b.logger.Warn().Err(err).Str("id", id).Msg("item: dedup decision lookup failed")
+	}
+
+	item, err := b.database.GetItemDebugDetail(ctx, id)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error fetching item: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if item == nil {
+		if len(decisions) == 0 {
+			b.reply(msg, "Item not found and no dedup decisions recorded for this ID.")
+
+			return
+		}
+
+		b.reply(msg, formatDedupDecisions(decisions))
+
+		return
+	}
+
+	text := b.itemDetailText(ctx, item)
+	if len(decisions) > 0 {
+		text += "\n" + formatDedupDecisions(decisions)
+	}
+
+	b.reply(msg, text)
+}
+
+// formatDedupDecisions renders dedup decisions: which message was dropped,
+// which neighbor it matched, and the score against the threshold.
+func formatDedupDecisions(decisions []db.DedupDecision) string {
+	var sb strings.Builder
+
+	sb.WriteString("<b>Dedup decisions</b>\n")
+
+	for _, d := range decisions {
+		fmt.Fprintf(&sb, "• <code>%s/%s</code> similarity <code>%.3f</code> (threshold <code>%.2f</code>) · %s\n",
+			html.EscapeString(d.Mode), html.EscapeString(d.Scope), d.Similarity, d.Threshold, d.CreatedAt.Format(DateTimeFormat))
+		fmt.Fprintf(&sb, "  dropped <code>%s</code>: %s\n", d.RawMessageID,
+			html.EscapeString(truncateAnnotationText(strings.TrimSpace(d.Text), itemDedupSnippetLimit)))
+
+		switch {
+		case d.MatchedItemID != "":
+			fmt.Fprintf(&sb, "  matched item <code>%s</code>", d.MatchedItemID)
+		case d.MatchedRawMessageID != "":
+			fmt.Fprintf(&sb, "  matched message <code>%s</code>", d.MatchedRawMessageID)
+		default:
+			sb.WriteString("  matched neighbor unknown\n")
+
+			continue
+		}
+
+		fmt.Fprintf(&sb, ": %s\n", html.EscapeString(truncateAnnotationText(strings.TrimSpace(d.MatchedSummary), itemDedupSnippetLimit)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdItemLinks is the /config subcommand for digest item deep links.
+	CmdItemLinks = "item_links"
+
+	// SettingDigestItemLinks selects how digest items link to their detail view.
+	SettingDigestItemLinks = "digest_item_links"
+
+	itemDetailSummaryLimit = 600
+	itemDetailMaxEvidence  = 3
+	itemDetailMaxRelated   = 5
+)
+
+// handleStart routes /start deep-link payloads and falls back to help.
+func (b *Bot) handleStart(ctx context.Context, msg *tgbotapi.Message) {
+	payload := strings.TrimSpace(msg.CommandArguments())
+
+	if code, ok := strings.CutPrefix(payload, db.ItemDeepLinkStartPrefix); ok {
+		b.handleItemDeepLink(ctx, msg, code)
+
+		return
+	}
+
+	b.handleHelp(ctx, msg)
+}
+
+func (b *Bot) handleItemDeepLink(ctx context.Context, msg *tgbotapi.Message, code string) {
+	itemID, err := b.database.ResolveItemDeepLink(ctx, code)
+	if err != nil {
+		if errors.Is(err, db.ErrDeepLinkNotFound) {
+			b.reply(msg, "❓ This item link is invalid or has expired.")
+		} else {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+		}
+
+		return
+	}
+
+	item, err := b.database.GetItemDebugDetail(ctx, itemID)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error fetching item: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if item == nil {
+		b.reply(msg, "Item not found.")
+
+		return
+	}
+
+	b.reply(msg, b.itemDetailText(ctx, item))
+}
+
+// itemDetailText loads evidence and cluster context and renders the item card.
+func (b *Bot) itemDetailText(ctx context.Context, item *db.ItemDebugDetail) string {
+	var evidence []db.ItemEvidenceWithSource
+
+	if evidenceMap, err := b.database.GetEvidenceForItems(ctx, []string{item.ID}); err != nil {
+		b.logger.Debug().Err(err).Msg("item detail: evidence lookup failed")
+	} else {
+		evidence = evidenceMap[item.ID]
+	}
+
+	cluster, related, err := b.database.GetClusterForItem(ctx, item.ID)
+	if err != nil {
+		b.logger.Debug().Err(err).Msg("item detail: cluster lookup failed")
+	}
+
+	return formatItemDetail(item, evidence, cluster, related)
+}
+
+// formatItemDetail renders the full record of a digest item: scores, source,
+// evidence and cluster.
+func formatItemDetail(item *db.ItemDebugDetail, evidence []db.ItemEvidenceWithSource, cluster *db.ClusterWithItems, related []db.ClusterItemInfo) string {
+	var sb strings.Builder
+
+	sb.WriteString("🔎 <b>Item Details</b>\n\n")
+
+	if item.Summary != "" {
+		fmt.Fprintf(&sb, "%s\n\n", html.EscapeString(truncateAnnotationText(item.Summary, itemDetailSummaryLimit)))
+	}
+
+	if item.Topic != "" {
+		fmt.Fprintf(&sb, "Topic: <code>%s</code>\n", html.EscapeString(item.Topic))
+	}
+
+	fmt.Fprintf(&sb, "Relevance: <code>%.2f</code> | Importance: <code>%.2f</code>\n", item.RelevanceScore, item.ImportanceScore)
+	fmt.Fprintf(&sb, "Status: <code>%s</code>\n", html.EscapeString(item.Status))
+	fmt.Fprintf(&sb, fmtTimeCode, item.TGDate.Format(DateTimeFormat))
+	fmt.Fprintf(&sb, "Source: %s (%s)\n", html.EscapeString(formatChannelName(item.ChannelUsername, item.ChannelTitle)),
+		FormatLink(item.ChannelUsername, item.ChannelPeerID, item.MessageID, fmtOpenMessage))
+
+	if item.TicketKey != "" {
+		fmt.Fprintf(&sb, "Ticket: %s\n", ticketLink(item.TicketKey, item.TicketURL))
+	}
+
+	writeItemDetailEvidence(&sb, item.EvidenceVerdict, evidence)
+	writeItemDetailCluster(&sb, cluster, related)
+
+	return sb.String()
+}
+
+func writeItemDetailEvidence(sb *strings.Builder, verdict string, evidence []db.ItemEvidenceWithSource) {
+	if len(evidence) == 0 && verdict == "" {
+		return
+	}
+
+	sb.WriteString("\n<b>Evidence</b>")
+
+	if verdict != "" {
+		fmt.Fprintf(sb, " (<code>%s</code>)", html.EscapeString(verdict))
+	}
+
+	sb.WriteString("\n")
+
+	for i, ev := range evidence {
+		if i == itemDetailMaxEvidence {
+			fmt.Fprintf(sb, "• <i>+%d more</i>\n", len(evidence)-itemDetailMaxEvidence)
+
+			break
+		}
+
+		title := ev.Source.Title
+		if title == "" {
+			title = ev.Source.Domain
+		}
+
+		fmt.Fprintf(sb, "• <a href=\"%s\">%s</a> <code>%.2f</code>\n", html.EscapeString(ev.Source.URL), html.EscapeString(title), ev.AgreementScore)
+	}
+}
+
+func writeItemDetailCluster(sb *strings.Builder, cluster *db.ClusterWithItems, related []db.ClusterItemInfo) {
+	if cluster == nil {
+		return
+	}
+
+	fmt.Fprintf(sb, "\n<b>Cluster</b>: %s (%d related)\n", html.EscapeString(cluster.Topic), len(related))
+
+	for i, r := range related {
+		if i == itemDetailMaxRelated {
+			break
+		}
+
+		fmt.Fprintf(sb, "• %s\n", FormatLink(r.ChannelUsername, r.ChannelPeerID, r.MessageID, formatChannelName(r.ChannelUsername, "")))
+	}
+}
+
+func (b *Bot) handleItemLinks(ctx context.Context, msg *tgbotapi.Message) {
+	mode := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	if !db.IsValidItemLinkMode(mode) {
+		b.reply(msg, "Usage: <code>/config item_links &lt;off|bot|research&gt;</code>\n\n"+
+			"<code>bot</code> links each digest item to <code>/start</code> in this bot (requires TELEGRAM_BOT_USERNAME).\n"+
+			"<code>research</code> links to the research dashboard (requires EXPANDED_VIEW_BASE_URL).")
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, SettingDigestItemLinks, mode, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Digest item links set to <code>%s</code>.", html.EscapeString(mode)))
+}
@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/tickets"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	itemSubTrack          = "track"
+	itemTicketMaxEvidence = 10
+)
+
+// TicketTracker creates issue tracker tickets for items flagged with
+// /item track.
+type TicketTracker interface {
+	// Name names the tracker, e.g. "jira".
+	Name() string
+	CreateTicket(ctx context.Context, ticket tickets.Ticket) (tickets.Issue, error)
+}
+
+// SetTicketTracker enables /item track.
+func (b *Bot) SetTicketTracker(tracker TicketTracker) {
+	b.tickets = tracker
+}
+
+// handleItemTrack creates a ticket for an item and stores its reference on
+// the item. An item is tracked at most once.
+func (b *Bot) handleItemTrack(ctx context.Context, msg *tgbotapi.Message, id string) {
+	if b.tickets == nil {
+		b.reply(msg, "❌ No issue tracker configured. Set JIRA_BASE_URL or LINEAR_API_KEY.")
+
+		return
+	}
+
+	item, err := b.database.GetItemDebugDetail(ctx, id)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error fetching item: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if item == nil {
+		b.reply(msg, "Item not found.")
+
+		return
+	}
+
+	if item.TicketKey != "" {
+		b.reply(msg, "ℹ️ Item already tracked in "+ticketLink(item.TicketKey, item.TicketURL)+".")
+
+		return
+	}
+
+	issue, err := b.tickets.CreateTicket(ctx, b.itemTicket(ctx, item))
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Failed to create ticket: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if err := b.database.SaveItemTicket(ctx, db.ItemTicket{
+		ItemID:    item.ID,
+		Tracker:   b.tickets.Name(),
+		Key:       issue.Key,
+		URL:       issue.URL,
+		CreatedBy: msg.From.ID,
+	}); err != nil {
+		b.logger.Warn().Err(err).Str("item_id", item.ID).Str("ticket", issue.Key).Msg("item track: failed to save ticket reference")
+		b.reply(msg, fmt.Sprintf("⚠️ Created %s, but could not save it on the item: %s", ticketLink(issue.Key, issue.URL), html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Created %s in <code>%s</code>.", ticketLink(issue.Key, issue.URL), html.EscapeString(b.tickets.Name())))
+}
+
+// itemTicket builds the ticket content from the item and its evidence.
+func (b *Bot) itemTicket(ctx context.Context, item *db.ItemDebugDetail) tickets.Ticket {
+	ticket := tickets.Ticket{
+		ItemID:     item.ID,
+		Summary:    item.Summary,
+		Topic:      item.Topic,
+		Channel:    formatChannelName(item.ChannelUsername, item.ChannelTitle),
+		SourceURL:  itemMessageURL(item),
+		Date:       item.TGDate,
+		Importance: item.ImportanceScore,
+		Verdict:    item.EvidenceVerdict,
+	}
+
+	evidenceMap, err := b.database.GetEvidenceForItems(ctx, []string{item.ID})
+	if err != nil {
+		b.logger.Debug().Err(err).Msg("item track: evidence lookup failed")
+
+		return ticket
+	}
+
+	for i, ev := range evidenceMap[item.ID] {
+		if i == itemTicketMaxEvidence {
+			break
+		}
+
+		title := ev.Source.Title
+		if title == "" {
+			title = ev.Source.Domain
+		}
+
+		ticket.Evidence = append(ticket.Evidence, tickets.Evidence{
+			Title:         title,
+			URL:           ev.Source.URL,
+			Agreement:     ev.AgreementScore,
+			Contradiction: ev.IsContradiction,
+		})
+	}
+
+	return ticket
+}
+
+// itemMessageURL links to the source message of an item.
+func itemMessageURL(item *db.ItemDebugDetail) string {
+	if item.ChannelUsername != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", item.ChannelUsername, item.MessageID)
+	}
+
+	return fmt.Sprintf("https://t.me/c/%d/%d", item.ChannelPeerID, item.MessageID)
+}
+
+func ticketLink(key, url string) string {
+	if url == "" {
+		return "<code>" + html.EscapeString(key) + "</code>"
+	}
+
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(url), html.EscapeString(key))
+}
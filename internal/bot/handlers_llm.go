@@ -6,6 +6,7 @@ import (
 	"html"
 	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
@@ -19,6 +20,7 @@ var llmTaskSettings = map[string]string{
 	"cluster":   SettingLLMOverrideCluster,
 	"narrative": SettingLLMOverrideNarrative,
 	"topic":     SettingLLMOverrideTopic,
+	"refine":    SettingLLMOverrideRefine,
 }
 
 // handleLLMNamespace handles /llm commands.
@@ -58,6 +60,7 @@ func (b *Bot) handleLLMStatus(ctx context.Context, msg *tgbotapi.Message) {
 
 	sb.WriteString("\U0001F916 <b>LLM Provider Status</b>\n\n")
 	b.writeLLMProviderStatuses(&sb, statuses)
+	writeLLMFailovers(&sb, b.llmClient.GetFailoverStatuses())
 	b.writeLLMModelOverrides(ctx, &sb)
 	sb.WriteString("\n<b>Legend:</b>\n")
 	sb.WriteString("\u2705 healthy | \u26A0\uFE0F circuit open | \u274C unavailable")
@@ -116,6 +119,20 @@ func llmProviderStatusDetail(s llm.ProviderStatus) string {
 	return ""
 }
 
+// writeLLMFailovers writes the tasks that are failed over to their secondary.
+func writeLLMFailovers(sb *strings.Builder, failovers []llm.FailoverStatus) {
+	if len(failovers) == 0 {
+		return
+	}
+
+	sb.WriteString("\n<b>Failovers:</b>\n")
+
+	for _, f := range failovers {
+		fmt.Fprintf(sb, "\U0001F500 %s: <code>%s</code> \u2192 <code>%s</code> since %s\n",
+			f.Task, html.EscapeString(llm.FormatProviderModel(f.Primary)), html.EscapeString(llm.FormatProviderModel(f.Secondary)), f.Since.UTC().Format(time.DateTime))
+	}
+}
+
 // writeLLMModelOverrides writes model override lines to the builder.
 func (b *Bot) writeLLMModelOverrides(ctx context.Context, sb *strings.Builder) {
 	sb.WriteString("\n<b>Model Overrides:</b>\n")
@@ -139,7 +156,7 @@ func (b *Bot) writeLLMModelOverrides(ctx context.Context, sb *strings.Builder) {
 // handleLLMSet sets a model override for a specific task.
 func (b *Bot) handleLLMSet(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) < 2 {
-		b.reply(msg, "Usage: <code>/llm set &lt;task&gt; &lt;model&gt;</code>\n\nTasks: summarize, cluster, narrative, topic")
+		b.reply(msg, "Usage: <code>/llm set &lt;task&gt; &lt;model&gt;</code>\n\nTasks: summarize, cluster, narrative, topic, refine")
 
 		return
 	}
@@ -149,7 +166,7 @@ func (b *Bot) handleLLMSet(ctx context.Context, msg *tgbotapi.Message, args []st
 
 	settingKey, ok := llmTaskSettings[task]
 	if !ok {
-		b.reply(msg, fmt.Sprintf("\u274C Unknown task: <code>%s</code>\n\nValid tasks: summarize, cluster, narrative, topic", html.EscapeString(task)))
+		b.reply(msg, fmt.Sprintf("\u274C Unknown task: <code>%s</code>\n\nValid tasks: summarize, cluster, narrative, topic, refine", html.EscapeString(task)))
 
 		return
 	}
@@ -169,7 +186,7 @@ func (b *Bot) handleLLMSet(ctx context.Context, msg *tgbotapi.Message, args []st
 // handleLLMReset resets model override(s) to default.
 func (b *Bot) handleLLMReset(ctx context.Context, msg *tgbotapi.Message, args []string) {
 	if len(args) == 0 {
-		b.reply(msg, "Usage: <code>/llm reset &lt;task&gt;</code> or <code>/llm reset all</code>\n\nTasks: summarize, cluster, narrative, topic")
+		b.reply(msg, "Usage: <code>/llm reset &lt;task&gt;</code> or <code>/llm reset all</code>\n\nTasks: summarize, cluster, narrative, topic, refine")
 
 		return
 	}
@@ -193,7 +210,7 @@ func (b *Bot) handleLLMReset(ctx context.Context, msg *tgbotapi.Message, args []
 
 	settingKey, ok := llmTaskSettings[task]
 	if !ok {
-		b.reply(msg, fmt.Sprintf("\u274C Unknown task: <code>%s</code>\n\nValid tasks: summarize, cluster, narrative, topic, all", html.EscapeString(task)))
+		b.reply(msg, fmt.Sprintf("\u274C Unknown task: <code>%s</code>\n\nValid tasks: summarize, cluster, narrative, topic, refine, all", html.EscapeString(task)))
 
 		return
 	}
@@ -245,6 +262,8 @@ func (b *Bot) handleLLMCosts(ctx context.Context, msg *tgbotapi.Message) {
 		sb.WriteString("No usage recorded this month.\n")
 	}
 
+	b.writeTwoPassSavings(ctx, &sb)
+
 	// Add Prometheus/Grafana info
 	sb.WriteString("\n<b>Real-time Metrics:</b>\n")
 	sb.WriteString("View detailed metrics in Grafana dashboard.\n")
@@ -253,6 +272,44 @@ func (b *Bot) handleLLMCosts(ctx context.Context, msg *tgbotapi.Message) {
 	b.reply(msg, sb.String())
 }
 
+// writeTwoPassSavings writes this month's two-pass summarization savings
+// when a refine model is configured.
+func (b *Bot) writeTwoPassSavings(ctx context.Context, sb *strings.Builder) {
+	var model string
+	if err := b.database.GetSetting(ctx, SettingLLMOverrideRefine, &model); err != nil || strings.TrimSpace(model) == "" {
+		return
+	}
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	stats, err := b.database.GetSummaryRefinementStats(ctx, monthStart, strings.TrimSpace(model))
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to fetch summary refinement stats")
+
+		return
+	}
+
+	sb.WriteString("\n<b>Two-pass Summarization (this month):</b>\n")
+	sb.WriteString(formatSummaryRefinementStats(stats))
+}
+
+// formatSummaryRefinementStats renders two-pass summarization counts and savings.
+func formatSummaryRefinementStats(stats db.SummaryRefinementStats) string {
+	if stats.Refined == 0 {
+		return "No items refined yet.\n"
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "\u2022 Refined: <code>%d</code> of <code>%d</code> drafted items\n", stats.Refined, stats.Drafted)
+	fmt.Fprintf(&sb, "\u2022 Draft cost: <code>$%.4f</code>, refine cost: <code>$%.4f</code> (<code>$%.5f</code>/item)\n",
+		stats.DraftCostUSD, stats.RefineCostUSD, stats.RefineCostPerItem())
+	fmt.Fprintf(&sb, "\u2022 Est. savings vs refine model only: <code>$%.4f</code>\n", stats.EstimatedSavingsUSD())
+
+	return sb.String()
+}
+
 // writeLLMUsageSummary writes LLM usage summary to the builder.
 func (b *Bot) writeLLMUsageSummary(sb *strings.Builder, usage *db.LLMUsageSummary) {
 	const tokensPerK = 1000
@@ -405,7 +462,7 @@ func llmHelpMessage() string {
 		"\u2022 <code>/llm budget</code> - View daily token budget status\n" +
 		"\u2022 <code>/llm budget set &lt;tokens&gt;</code> - Set daily limit\n" +
 		"\u2022 <code>/llm budget off</code> - Disable budget alerts\n\n" +
-		"<b>Tasks:</b> summarize, cluster, narrative, topic\n\n" +
+		"<b>Tasks:</b> summarize, cluster, narrative, topic, refine\n\n" +
 		"<b>Example:</b>\n" +
 		"<code>/llm set narrative claude-haiku-4.5</code>\n\n" +
 		"<b>Current Priority:</b>\n" +
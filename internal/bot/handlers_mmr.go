@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdMMR is the /config subcommand for MMR-based digest item selection.
+const CmdMMR = "mmr"
+
+const mmrUsage = "Usage: <code>/config mmr &lt;0-1|off&gt;</code>\n\n" +
+	"Reorders digest candidates by Maximal Marginal Relevance before the item limit. " +
+	"Lambda weighs importance against novelty: <code>0.7</code> mostly ranks by importance " +
+	"but skips items close to ones already picked, lower values favor novelty more."
+
+func (b *Bot) handleMMR(ctx context.Context, msg *tgbotapi.Message) {
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	if arg == "" {
+		var lambda float32
+		if err := b.database.GetSetting(ctx, digest.SettingDigestMMRLambda, &lambda); err != nil {
+			b.logger.Debug().Err(err).Msg("could not get digest_mmr_lambda")
+		}
+
+		b.reply(msg, formatMMRLambda(lambda)+"\n\n"+mmrUsage)
+
+		return
+	}
+
+	lambda, err := parseMMRLambda(arg)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), mmrUsage))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestMMRLambda, lambda, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestMMRLambda, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ "+formatMMRLambda(lambda))
+}
+
+// parseMMRLambda parses a lambda in (0, 1); "off" is stored as 0.
+func parseMMRLambda(arg string) (float32, error) {
+	if arg == ToggleOff {
+		return 0, nil
+	}
+
+	val, err := strconv.ParseFloat(arg, 32)
+	if err != nil || val <= 0 || val >= 1 {
+		return 0, fmt.Errorf("%w: lambda must be between 0 and 1, got %s", errInvalidAutoPolicyValue, arg)
+	}
+
+	return float32(val), nil
+}
+
+func formatMMRLambda(lambda float32) string {
+	if lambda <= 0 || lambda >= 1 {
+		return "MMR selection is <b>off</b>: digest items are ranked by importance."
+	}
+
+	return fmt.Sprintf("MMR selection is <b>on</b> with lambda <code>%.2f</code>.", lambda)
+}
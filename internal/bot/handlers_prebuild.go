@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdPrebuild is the /config subcommand for pre-building digests ahead of their slot.
+const CmdPrebuild = "prebuild"
+
+const prebuildUsage = "Usage: <code>/config prebuild &lt;minutes|off&gt;</code>\n\n" +
+	"Builds the next digest that many minutes before its slot and only adds late items at post time. " +
+	"Use at least the scheduler tick interval so a tick falls inside the lead time."
+
+func (b *Bot) handlePrebuild(ctx context.Context, msg *tgbotapi.Message) {
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	if arg == "" {
+		var minutes int
+		if err := b.database.GetSetting(ctx, digest.SettingDigestPrebuildMinutes, &minutes); err != nil {
+			b.logger.Debug().Err(err).Msg("could not get digest_prebuild_minutes")
+		}
+
+		b.reply(msg, formatPrebuildMinutes(minutes)+"\n\n"+prebuildUsage)
+
+		return
+	}
+
+	minutes, err := strconv.Atoi(arg)
+	if arg == ToggleOff {
+		minutes, err = 0, nil
+	}
+
+	if err != nil || minutes < 0 {
+		b.reply(msg, prebuildUsage)
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestPrebuildMinutes, minutes, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestPrebuildMinutes, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ "+formatPrebuildMinutes(minutes))
+}
+
+func formatPrebuildMinutes(minutes int) string {
+	if minutes <= 0 {
+		return "Digest pre-building is <b>off</b>: digests are built at post time."
+	}
+
+	return fmt.Sprintf("Digests are pre-built <code>%d</code> minutes before their slot.", minutes)
+}
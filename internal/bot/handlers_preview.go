@@ -0,0 +1,242 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CallbackPrefixPreview is the callback data prefix of the preview
+	// progress message. Data format: preview:cancel.
+	CallbackPrefixPreview = "preview:"
+
+	previewCancel = "cancel"
+
+	// previewSubDiff compares the preview with the last posted digest.
+	previewSubDiff = "diff"
+
+	// previewProgressInterval throttles progress edits; Telegram rate-limits
+	// edits of the same message.
+	previewProgressInterval = 2 * time.Second
+)
+
+// previewSendFunc sends a built preview digest.
+type previewSendFunc func(ctx context.Context, msg *tgbotapi.Message, text string, items []db.Item, clusters []db.ClusterWithItems, start, end time.Time, threshold float32)
+
+// previewKey identifies a running preview by its progress message.
+type previewKey struct {
+	chatID int64
+	msgID  int
+}
+
+// previewRuns tracks the previews being built, so the cancel button of a
+// progress message can stop its build. Previews run outside the update loop,
+// so access is locked.
+type previewRuns struct {
+	mu      sync.Mutex
+	cancels map[previewKey]context.CancelFunc
+}
+
+func (r *previewRuns) start(key previewKey, cancel context.CancelFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancels == nil {
+		r.cancels = make(map[previewKey]context.CancelFunc)
+	}
+
+	r.cancels[key] = cancel
+}
+
+// finish forgets a preview whose build ended; later cancel clicks are no-ops.
+func (r *previewRuns) finish(key previewKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.cancels, key)
+}
+
+// cancel stops a running preview and reports whether it was still running.
+func (r *previewRuns) cancel(key previewKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancel, ok := r.cancels[key]
+	if !ok {
+		return false
+	}
+
+	cancel()
+	delete(r.cancels, key)
+
+	return true
+}
+
+// formatPreviewProgress renders the progress message of a preview build.
+func formatPreviewProgress(ctx context.Context, stage string, done, total int, elapsed time.Duration) string {
+	label := previewStageLabel(ctx, stage)
+	if total > 0 {
+		label = fmt.Sprintf("%s %d/%d", label, done, total)
+	}
+
+	return tr(ctx, "⏳ <b>Building digest preview…</b>\n<i>%s…</i> (%ds)", html.EscapeString(label), int(elapsed.Seconds()))
+}
+
+// previewStageLabel names a digest build stage in progress updates.
+func previewStageLabel(ctx context.Context, stage string) string {
+	switch stage {
+	case digest.ProgressSelecting:
+		return tr(ctx, "selecting items")
+	case digest.ProgressRefining:
+		return tr(ctx, "refining summaries")
+	case digest.ProgressClustering:
+		return tr(ctx, "clustering")
+	case digest.ProgressSummaries:
+		return tr(ctx, "summaries")
+	case digest.ProgressRendering:
+		return tr(ctx, "rendering")
+	default:
+		return stage
+	}
+}
+
+func previewCancelKeyboard(ctx context.Context) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(tr(ctx, "✖️ Cancel"), CallbackPrefixPreview+previewCancel),
+	))
+}
+
+// startPreview sends the progress message of a preview and builds the preview
+// in the background, so the update loop stays free to handle its cancel
+// button. Without a progress message the preview is built in place.
+func (b *Bot) startPreview(ctx context.Context, msg *tgbotapi.Message, start, end time.Time, threshold float32, send previewSendFunc) {
+	progress := tgbotapi.NewMessage(msg.Chat.ID, formatPreviewProgress(ctx, digest.ProgressSelecting, 0, 0, 0))
+	progress.ParseMode = tgbotapi.ModeHTML
+	progress.ReplyMarkup = previewCancelKeyboard(ctx)
+
+	sent, err := b.sender.Send(progress)
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to send preview progress message")
+		b.runPreview(ctx, msg, nil, start, end, threshold, send)
+
+		return
+	}
+
+	key := previewKey{chatID: sent.Chat.ID, msgID: sent.MessageID}
+	previewCtx, cancel := context.WithCancel(ctx)
+	b.previews.start(key, cancel)
+
+	go func() {
+		defer cancel()
+
+		b.runPreview(previewCtx, msg, &key, start, end, threshold, send)
+	}()
+}
+
+// runPreview builds and sends a preview, reporting progress to the progress
+// message at key when there is one.
+func (b *Bot) runPreview(ctx context.Context, msg *tgbotapi.Message, key *previewKey, start, end time.Time, threshold float32, send previewSendFunc) {
+	began := time.Now()
+	buildCtx := ctx
+
+	if key != nil {
+		buildCtx = digest.WithProgress(ctx, b.previewProgressFunc(ctx, *key, began))
+	}
+
+	text, items, clusters, err := b.buildPreviewDigest(buildCtx, start, end, threshold)
+
+	if key != nil {
+		b.previews.finish(*key)
+	}
+
+	var result string
+
+	switch {
+	case ctx.Err() != nil:
+		result = tr(ctx, "✖️ Preview cancelled.")
+	case err != nil:
+		result = tr(ctx, "❌ Error building digest preview: %s", html.EscapeString(err.Error()))
+	case text == "":
+		result = tr(ctx, "ℹ️ No items found for the current window to include in a digest.")
+	}
+
+	if key != nil {
+		done := result
+		if done == "" {
+			done = tr(ctx, "✅ Preview built in %ds.", int(time.Since(began).Seconds()))
+		}
+
+		b.editPreviewProgress(*key, done, nil)
+	} else if result != "" {
+		b.reply(msg, result)
+	}
+
+	if result != "" {
+		return
+	}
+
+	send(ctx, msg, text, items, clusters, start, end, threshold)
+}
+
+// sendPreview sends the preview digest as it would be posted.
+func (b *Bot) sendPreview(ctx context.Context, msg *tgbotapi.Message, text string, items []db.Item, clusters []db.ClusterWithItems, start, end time.Time, threshold float32) {
+	header := fmt.Sprintf("📝 <b>Digest Preview</b> (%d items)\n<i>This has not been posted to the target channel.</i>\n\n", len(items))
+	b.sendPreviewWithSettings(ctx, msg, header, text, items, clusters, start, end, threshold)
+}
+
+// previewProgressFunc edits the progress message as the build advances, at
+// most once per previewProgressInterval.
+func (b *Bot) previewProgressFunc(ctx context.Context, key previewKey, began time.Time) digest.ProgressFunc {
+	var (
+		mu       sync.Mutex
+		lastEdit time.Time
+	)
+
+	keyboard := previewCancelKeyboard(ctx)
+
+	return func(stage string, done, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if ctx.Err() != nil || time.Since(lastEdit) < previewProgressInterval {
+			return
+		}
+
+		lastEdit = time.Now()
+		b.editPreviewProgress(key, formatPreviewProgress(ctx, stage, done, total, time.Since(began)), &keyboard)
+	}
+}
+
+func (b *Bot) editPreviewProgress(key previewKey, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageText(key.chatID, key.msgID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.ReplyMarkup = keyboard
+
+	if _, err := b.sender.Send(edit); err != nil {
+		b.logger.Debug().Err(err).Msg("failed to update preview progress")
+	}
+}
+
+func (b *Bot) handlePreviewCallback(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	answer := ""
+
+	if query.Data == CallbackPrefixPreview+previewCancel && query.Message != nil {
+		answer = tr(ctx, "Preview already finished.")
+
+		if b.previews.cancel(previewKey{chatID: query.Message.Chat.ID, msgID: query.Message.MessageID}) {
+			answer = tr(ctx, "Cancelling preview…")
+		}
+	}
+
+	if _, err := b.sender.Request(tgbotapi.NewCallback(query.ID, answer)); err != nil {
+		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
+	}
+}
@@ -0,0 +1,190 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	previewDiffMaxLines   = 10
+	previewDiffSummaryLen = 90
+	previewDiffTimeLayout = "2006-01-02 15:04"
+)
+
+// clusterRepeat is a preview cluster that overlaps the last posted digest.
+type clusterRepeat struct {
+	cluster       db.ClusterWithItems
+	sameTopic     bool
+	repeatedItems int
+}
+
+// previewDiff splits a preview into what is new and what would repeat the
+// last posted digest.
+type previewDiff struct {
+	newItems         []db.Item
+	repeatedItems    []db.Item
+	newClusters      []db.ClusterWithItems
+	repeatedClusters []clusterRepeat
+}
+
+// diffPreview classifies preview items by their matches in the last posted
+// digest. A cluster repeats when it has a repeated item or the topic of a
+// posted cluster.
+func diffPreview(items []db.Item, clusters []db.ClusterWithItems, matches map[string]db.PostedItemMatch, postedTopics []string) previewDiff {
+	var d previewDiff
+
+	for _, item := range items {
+		if _, ok := matches[item.ID]; ok {
+			d.repeatedItems = append(d.repeatedItems, item)
+		} else {
+			d.newItems = append(d.newItems, item)
+		}
+	}
+
+	topics := make(map[string]bool, len(postedTopics))
+
+	for _, topic := range postedTopics {
+		if key := strings.ToLower(strings.TrimSpace(topic)); key != "" {
+			topics[key] = true
+		}
+	}
+
+	for _, c := range clusters {
+		r := clusterRepeat{cluster: c, sameTopic: topics[strings.ToLower(strings.TrimSpace(c.Topic))]}
+
+		for _, item := range c.Items {
+			if _, ok := matches[item.ID]; ok {
+				r.repeatedItems++
+			}
+		}
+
+		if r.sameTopic || r.repeatedItems > 0 {
+			d.repeatedClusters = append(d.repeatedClusters, r)
+		} else {
+			d.newClusters = append(d.newClusters, c)
+		}
+	}
+
+	return d
+}
+
+// sendPreviewDiff compares the built preview with the last posted digest
+// instead of sending it.
+func (b *Bot) sendPreviewDiff(ctx context.Context, msg *tgbotapi.Message, _ string, items []db.Item, clusters []db.ClusterWithItems, _, _ time.Time, _ float32) {
+	posted, err := b.database.GetLastPostedDigestContent(ctx)
+	if errors.Is(err, db.ErrNoPostedDigest) {
+		b.reply(msg, tr(ctx, "ℹ️ No digest has been posted yet, so everything in the preview is new."))
+
+		return
+	}
+
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error loading the last posted digest: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+	}
+
+	matches, err := b.database.MatchPostedDigestItems(ctx, posted.ID, itemIDs, b.cfg.ClusterSimilarityThreshold)
+	if err != nil {
+		b.reply(msg, tr(ctx, "❌ Error loading the last posted digest: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, formatPreviewDiff(ctx, posted, diffPreview(items, clusters, matches, posted.ClusterTopics), matches))
+}
+
+// formatPreviewDiff renders the diff of a preview against a posted digest.
+func formatPreviewDiff(ctx context.Context, posted *db.PostedDigest, d previewDiff, matches map[string]db.PostedItemMatch) string {
+	var sb strings.Builder
+
+	sb.WriteString(tr(ctx, "🔀 <b>Preview diff</b> against the digest posted %s\nLast digest: %s – %s, %d items, %d clusters\nPreview: %d items, %d clusters",
+		posted.PostedAt.Format(previewDiffTimeLayout), posted.Start.Format(previewDiffTimeLayout), posted.End.Format(previewDiffTimeLayout),
+		posted.ItemCount, len(posted.ClusterTopics),
+		len(d.newItems)+len(d.repeatedItems), len(d.newClusters)+len(d.repeatedClusters)))
+
+	if len(d.repeatedItems) == 0 && len(d.repeatedClusters) == 0 {
+		sb.WriteString("\n\n" + tr(ctx, "✅ Nothing in the preview repeats the last posted digest."))
+	}
+
+	if len(d.repeatedItems) > 0 {
+		sb.WriteString("\n\n" + tr(ctx, "🔁 <b>Would repeat (%d)</b>", len(d.repeatedItems)))
+
+		for i, item := range d.repeatedItems {
+			if i == previewDiffMaxLines {
+				sb.WriteString("\n" + tr(ctx, "…and %d more", len(d.repeatedItems)-i))
+
+				break
+			}
+
+			sb.WriteString("\n• " + previewDiffSummary(item.Summary) + "\n  " + formatPostedMatch(ctx, item.ID, matches[item.ID]))
+		}
+	}
+
+	if len(d.newItems) > 0 {
+		sb.WriteString("\n\n" + tr(ctx, "🆕 <b>New items (%d)</b>", len(d.newItems)))
+
+		for i, item := range d.newItems {
+			if i == previewDiffMaxLines {
+				sb.WriteString("\n" + tr(ctx, "…and %d more", len(d.newItems)-i))
+
+				break
+			}
+
+			sb.WriteString("\n• " + previewDiffSummary(item.Summary))
+		}
+	}
+
+	if len(d.newClusters)+len(d.repeatedClusters) > 0 {
+		sb.WriteString("\n\n" + tr(ctx, "🧩 <b>Clusters</b>: %d new, %d would repeat", len(d.newClusters), len(d.repeatedClusters)))
+
+		for _, r := range d.repeatedClusters {
+			sb.WriteString(fmt.Sprintf("\n🔁 %s — %s", html.EscapeString(r.cluster.Topic), formatClusterRepeat(ctx, r)))
+		}
+
+		for _, c := range d.newClusters {
+			sb.WriteString(fmt.Sprintf("\n🆕 %s (%d)", html.EscapeString(c.Topic), len(c.Items)))
+		}
+	}
+
+	return sb.String()
+}
+
+func formatPostedMatch(ctx context.Context, itemID string, m db.PostedItemMatch) string {
+	if m.PostedItemID == itemID {
+		return tr(ctx, "↳ already posted as the same item")
+	}
+
+	return tr(ctx, "↳ already posted: %s (similarity %.2f)", previewDiffSummary(m.Summary), m.Similarity)
+}
+
+func formatClusterRepeat(ctx context.Context, r clusterRepeat) string {
+	var reasons []string
+
+	if r.sameTopic {
+		reasons = append(reasons, tr(ctx, "same topic"))
+	}
+
+	if r.repeatedItems > 0 {
+		reasons = append(reasons, tr(ctx, "%d of %d items repeat", r.repeatedItems, len(r.cluster.Items)))
+	}
+
+	return strings.Join(reasons, ", ")
+}
+
+func previewDiffSummary(summary string) string {
+	return html.EscapeString(truncateAnnotationText(strings.TrimSpace(summary), previewDiffSummaryLen))
+}
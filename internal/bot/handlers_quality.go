@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdQuality shows the continuous evaluation of live scores against ratings.
+	CmdQuality = "quality"
+
+	qualitySubCmdTrend     = "trend"
+	qualityDefaultDays     = 30
+	qualityMaxDays         = 365
+	qualityEvalsShown      = 14
+	qualityTrendDateFormat = "2006-01-02"
+)
+
+const qualityUsage = "Usage: <code>/quality trend [days]</code>\n\n" +
+	"Shows the daily evaluation of rated items against the current thresholds: precision, recall and noise rate " +
+	"over a rolling 7-day window. Disable it with <code>quality_eval_enabled</code>."
+
+func (b *Bot) handleQuality(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 || len(args) > 2 || !strings.EqualFold(args[0], qualitySubCmdTrend) {
+		b.reply(msg, qualityUsage)
+
+		return
+	}
+
+	days := qualityDefaultDays
+
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 || n > qualityMaxDays {
+			b.reply(msg, qualityUsage)
+
+			return
+		}
+
+		days = n
+	}
+
+	now := time.Now()
+	since := now.AddDate(0, 0, -days)
+
+	evals, err := b.database.GetQualityEvaluations(ctx, since, now)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	events, err := b.database.GetModelPromptEvents(ctx, since, now)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	pending := db.AnnotateQualityEvaluations(evals, events)
+
+	b.reply(msg, formatQualityTrend(days, evals, pending))
+}
+
+// formatQualityTrend renders the latest evaluations, newest first, with the
+// model and prompt activations each one is the first to reflect, and the
+// change since the first evaluation in the period.
+func formatQualityTrend(days int, evals []db.QualityEvaluation, pending []db.ModelPromptEvent) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "📈 <b>Quality Trend</b> (last %d days)\n", days)
+
+	if len(evals) == 0 {
+		sb.WriteString("\nNo evaluations yet. They run daily once items have ratings.")
+
+		return sb.String()
+	}
+
+	first, last := evals[0], evals[len(evals)-1]
+
+	fmt.Fprintf(&sb, "Thresholds: relevance %.2f, importance %.2f\n\n", last.Relevance, last.Importance)
+
+	for i := len(evals) - 1; i >= 0 && len(evals)-i <= qualityEvalsShown; i-- {
+		e := evals[i]
+		fmt.Fprintf(&sb, "• <code>%s</code> precision <code>%.2f</code> · recall <code>%.2f</code> · noise <code>%.2f</code> (%d rated)\n",
+			e.EvaluatedAt.Format(qualityTrendDateFormat), e.Precision, e.Recall, e.NoiseRate, e.Rated)
+
+		if len(e.Events) > 0 {
+			sb.WriteString(formatQualityEvents(evals, i))
+		}
+	}
+
+	if len(evals) > qualityEvalsShown {
+		fmt.Fprintf(&sb, "… and %d earlier\n", len(evals)-qualityEvalsShown)
+	}
+
+	if len(pending) > 0 {
+		fmt.Fprintf(&sb, "Not yet evaluated: %s\n", qualityEventLabels(pending))
+	}
+
+	if len(evals) > 1 {
+		fmt.Fprintf(&sb, "\nSince %s: precision %+.2f, recall %+.2f, noise %+.2f",
+			first.EvaluatedAt.Format(qualityTrendDateFormat),
+			last.Precision-first.Precision, last.Recall-first.Recall, last.NoiseRate-first.NoiseRate)
+	}
+
+	return sb.String()
+}
+
+// formatQualityEvents renders the activations annotated on evals[i] with the
+// change in the metrics from the evaluation before.
+func formatQualityEvents(evals []db.QualityEvaluation, i int) string {
+	labels := qualityEventLabels(evals[i].Events)
+	if i == 0 {
+		return fmt.Sprintf("  ↳ after %s\n", labels)
+	}
+
+	prev, e := evals[i-1], evals[i]
+
+	return fmt.Sprintf("  ↳ after %s: precision %+.2f, recall %+.2f, noise %+.2f\n",
+		labels, e.Precision-prev.Precision, e.Recall-prev.Recall, e.NoiseRate-prev.NoiseRate)
+}
+
+func qualityEventLabels(events []db.ModelPromptEvent) string {
+	labels := make([]string, 0, len(events))
+	for _, ev := range events {
+		labels = append(labels, "<code>"+html.EscapeString(ev.Label())+"</code>")
+	}
+
+	return strings.Join(labels, ", ")
+}
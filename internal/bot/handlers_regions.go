@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/geo"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdRegions is the /config subcommand for the digest region filter.
+const CmdRegions = "regions"
+
+func (b *Bot) handleRegions(ctx context.Context, msg *tgbotapi.Message) {
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	switch arg {
+	case "":
+		b.replyRegions(msg, b.loadRegions(ctx))
+	case ToggleOff, SubCmdReset:
+		if err := b.database.DeleteSettingWithHistory(ctx, digest.SettingDigestRegions, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.reply(msg, "✅ Digest region filter disabled.")
+	default:
+		b.saveRegions(ctx, msg, arg)
+	}
+}
+
+func (b *Bot) saveRegions(ctx context.Context, msg *tgbotapi.Message, arg string) {
+	codes, unknown := geo.ParseList(arg)
+	if len(unknown) > 0 || len(codes) == 0 {
+		b.reply(msg, fmt.Sprintf("❌ Unknown region: <code>%s</code>\n\n"+
+			"Usage: <code>/config regions ua,pl</code> (ISO country codes) or <code>/config regions off</code>",
+			html.EscapeString(strings.Join(unknown, ", "))))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestRegions, codes, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ Digests now include only items about: "+formatRegions(codes))
+}
+
+// loadRegions returns the configured region filter; nil means no filter.
+func (b *Bot) loadRegions(ctx context.Context) []string {
+	var regions []string
+
+	if err := b.database.GetSetting(ctx, digest.SettingDigestRegions, &regions); err != nil {
+		b.logger.Warn().Err(err).Msg("could not get digest_regions from DB")
+	}
+
+	return regions
+}
+
+func (b *Bot) replyRegions(msg *tgbotapi.Message, regions []string) {
+	status := "off (all regions)"
+	if len(regions) > 0 {
+		status = formatRegions(regions)
+	}
+
+	b.reply(msg, "🌍 <b>Digest Region Filter</b>: "+status+"\n\n"+
+		"Set with <code>/config regions ua,pl</code> (ISO country codes), clear with <code>/config regions off</code>.\n"+
+		"While a filter is set, items without a detected region are left out.")
+}
+
+// formatRegions renders region codes with their names, e.g. "<code>ua</code> Ukraine".
+func formatRegions(codes []string) string {
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("<code>%s</code> %s", html.EscapeString(code), html.EscapeString(geo.Name(code))))
+	}
+
+	return strings.Join(parts, ", ")
+}
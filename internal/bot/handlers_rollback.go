@@ -0,0 +1,275 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SubCmdRollback is the /settings and /prompt subcommand that restores
+	// values from setting history.
+	SubCmdRollback = "rollback"
+
+	// CallbackPrefixRollback is the callback data prefix for rollback confirmation.
+	// Data format: rollback:<kind>:<history entry id> or rollback:cancel.
+	CallbackPrefixRollback = "rollback:"
+
+	rollbackKindSetting = "s"
+	rollbackKindPrompt  = "p"
+	rollbackCancel      = "cancel"
+	rollbackDateFormat  = "2006-01-02"
+	rollbackValueLimit  = 200
+	rollbackCallbackLen = 3
+	promptKeyPrefixFmt  = "prompt:%s:"
+)
+
+var errInvalidRollbackPoint = errors.New("invalid rollback point")
+
+// rollbackPoint is where to roll back to: a number of changes, or a time.
+type rollbackPoint struct {
+	steps int
+	at    time.Time
+}
+
+// parseRollbackPoint parses the optional `[steps|timestamp]` argument. No
+// argument means one step back.
+func parseRollbackPoint(args []string) (rollbackPoint, error) {
+	arg := strings.Join(args, " ")
+	if arg == "" {
+		return rollbackPoint{steps: 1}, nil
+	}
+
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 {
+			return rollbackPoint{}, fmt.Errorf("%w: %s", errInvalidRollbackPoint, arg)
+		}
+
+		return rollbackPoint{steps: n}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, DateTimeFormat, "2006-01-02 15:04", rollbackDateFormat} {
+		if at, err := time.ParseInLocation(layout, arg, time.UTC); err == nil {
+			return rollbackPoint{at: at}, nil
+		}
+	}
+
+	return rollbackPoint{}, fmt.Errorf("%w: %s", errInvalidRollbackPoint, arg)
+}
+
+// rollbackCallbackData builds the confirmation button data for a rollback.
+func rollbackCallbackData(kind string, entryID int64) string {
+	return fmt.Sprintf("%s%s:%d", CallbackPrefixRollback, kind, entryID)
+}
+
+// parseRollbackCallbackData decodes data built by rollbackCallbackData.
+func parseRollbackCallbackData(data string) (kind string, entryID int64, ok bool) {
+	parts := strings.Split(data, ":")
+	if len(parts) != rollbackCallbackLen || (parts[1] != rollbackKindSetting && parts[1] != rollbackKindPrompt) {
+		return "", 0, false
+	}
+
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || id <= 0 {
+		return "", 0, false
+	}
+
+	return parts[1], id, true
+}
+
+// rollbackScopeForEntry returns the scope a confirmed rollback applies to:
+// the entry's key for settings, or every version of the entry's prompt.
+func rollbackScopeForEntry(kind, key string) (db.RollbackScope, bool) {
+	if kind == rollbackKindSetting {
+		return db.RollbackScope{Key: key}, true
+	}
+
+	parts := strings.SplitN(key, ":", rollbackCallbackLen)
+	if len(parts) != rollbackCallbackLen || parts[0] != "prompt" {
+		return db.RollbackScope{}, false
+	}
+
+	return db.RollbackScope{Key: fmt.Sprintf(promptKeyPrefixFmt, parts[1]), Prefix: true}, true
+}
+
+// formatRollbackValue renders a stored JSON value for display.
+func formatRollbackValue(value string) string {
+	if value == "" {
+		return "<i>(default)</i>"
+	}
+
+	runes := []rune(value)
+	if len(runes) > rollbackValueLimit {
+		value = string(runes[:rollbackValueLimit]) + "…"
+	}
+
+	return "<code>" + html.EscapeString(value) + "</code>"
+}
+
+// formatRollbackPlan renders the changes a rollback makes (or made).
+func formatRollbackPlan(title string, plan []db.SettingRollback) string {
+	var sb strings.Builder
+
+	sb.WriteString(title)
+	sb.WriteString("\n\n")
+
+	for _, r := range plan {
+		fmt.Fprintf(&sb, "• <b>%s</b> (undoes #%d from %s)\n", html.EscapeString(r.Key), r.EntryID, r.ChangedAt.Format(DateTimeFormat))
+		fmt.Fprintf(&sb, "  📤 Current: %s\n", formatRollbackValue(r.Current))
+		fmt.Fprintf(&sb, "  📥 Restore: %s\n", formatRollbackValue(r.Restored))
+	}
+
+	return sb.String()
+}
+
+func (b *Bot) handleSettingsRollback(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) < 2 {
+		b.reply(msg, "Usage: <code>/settings rollback &lt;key&gt; [steps|timestamp]</code>")
+
+		return
+	}
+
+	b.previewRollback(ctx, msg, db.RollbackScope{Key: args[1]}, rollbackKindSetting, args[2:])
+}
+
+func (b *Bot) handlePromptRollback(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) < 2 {
+		b.reply(msg, "Usage: <code>/prompt rollback &lt;base&gt; [steps|timestamp]</code>")
+
+		return
+	}
+
+	baseName := strings.ToLower(args[1])
+	if !b.isValidPromptBase(baseName) {
+		b.reply(msg, fmt.Sprintf(ErrUnknownBaseFmt, html.EscapeString(strings.Join(promptBases, ", "))))
+
+		return
+	}
+
+	scope := db.RollbackScope{Key: fmt.Sprintf(promptKeyPrefixFmt, baseName), Prefix: true}
+	b.previewRollback(ctx, msg, scope, rollbackKindPrompt, args[2:])
+}
+
+// previewRollback shows what a rollback would change and asks for confirmation.
+func (b *Bot) previewRollback(ctx context.Context, msg *tgbotapi.Message, scope db.RollbackScope, kind string, pointArgs []string) {
+	point, err := parseRollbackPoint(pointArgs)
+	if err != nil {
+		b.reply(msg, "❌ Expected a number of steps or a timestamp like <code>2026-01-02 15:04</code> (UTC).")
+
+		return
+	}
+
+	entryID, err := b.resolveRollbackPoint(ctx, scope, point)
+	if errors.Is(err, db.ErrSettingHistoryNotFound) {
+		b.reply(msg, fmt.Sprintf("📋 Nothing to roll back for <code>%s</code>.", html.EscapeString(scope.Key)))
+
+		return
+	}
+
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	plan, err := b.database.GetRollbackPlan(ctx, scope, entryID)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, formatRollbackPlan("↩️ <b>Rollback preview</b>", plan))
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Confirm", rollbackCallbackData(kind, entryID)),
+			tgbotapi.NewInlineKeyboardButtonData("✖️ Cancel", CallbackPrefixRollback+rollbackCancel),
+		),
+	)
+
+	if _, err := b.sender.Send(reply); err != nil {
+		b.logger.Error().Err(err).Msg("failed to send rollback preview")
+	}
+}
+
+func (b *Bot) resolveRollbackPoint(ctx context.Context, scope db.RollbackScope, point rollbackPoint) (int64, error) {
+	if point.steps > 0 {
+		id, err := b.database.GetRollbackPointBySteps(ctx, scope, point.steps)
+		if err != nil {
+			return 0, fmt.Errorf("resolve rollback steps: %w", err)
+		}
+
+		return id, nil
+	}
+
+	id, err := b.database.GetRollbackPointAt(ctx, scope, point.at)
+	if err != nil {
+		return 0, fmt.Errorf("resolve rollback time: %w", err)
+	}
+
+	return id, nil
+}
+
+func (b *Bot) handleRollbackCallback(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	if _, err := b.sender.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
+	}
+
+	if query.Message == nil {
+		return
+	}
+
+	text := b.applyRollback(ctx, query.Data, query.From.ID)
+
+	edit := tgbotapi.NewEditMessageText(query.Message.Chat.ID, query.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeHTML
+
+	if _, err := b.sender.Send(edit); err != nil {
+		b.logger.Error().Err(err).Msg("failed to update rollback message")
+	}
+}
+
+// applyRollback performs a confirmed rollback and returns the result message.
+func (b *Bot) applyRollback(ctx context.Context, data string, userID int64) string {
+	if data == CallbackPrefixRollback+rollbackCancel {
+		return "✖️ Rollback cancelled."
+	}
+
+	kind, entryID, ok := parseRollbackCallbackData(data)
+	if !ok {
+		return "❌ Invalid rollback request."
+	}
+
+	entry, err := b.database.GetSettingHistoryEntry(ctx, entryID)
+	if err != nil {
+		return fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error()))
+	}
+
+	scope, ok := rollbackScopeForEntry(kind, entry.Key)
+	if !ok {
+		return "❌ Invalid rollback request."
+	}
+
+	changes, err := b.database.RollbackSettings(ctx, scope, entryID, userID)
+	if err != nil {
+		return fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error()))
+	}
+
+	if b.llmClient != nil {
+		for _, c := range changes {
+			// Model overrides take effect in the LLM registry immediately
+			b.llmClient.RefreshOverride(ctx, b.database, c.Key)
+		}
+	}
+
+	return formatRollbackPlan("✅ <b>Rolled back</b>", changes)
+}
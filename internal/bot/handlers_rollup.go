@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdRollup manages weekly and monthly roll-up digests.
+const CmdRollup = "rollup"
+
+func (b *Bot) handleRollup(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(strings.ToLower(msg.CommandArguments()))
+	if len(args) == 0 {
+		b.replyRollupStatus(ctx, msg)
+
+		return
+	}
+
+	switch {
+	case args[0] == "preview" && len(args) == 2:
+		b.handleRollupPreview(ctx, msg, args[1])
+	case args[0] == "hour" && len(args) == 2:
+		b.handleRollupHour(ctx, msg, args[1])
+	case (args[0] == digest.RollupWeekly || args[0] == digest.RollupMonthly) && len(args) == 2:
+		b.handleRollupToggle(ctx, msg, args[0], args[1])
+	default:
+		b.replyRollupStatus(ctx, msg)
+	}
+}
+
+func (b *Bot) replyRollupStatus(ctx context.Context, msg *tgbotapi.Message) {
+	var weekly, monthly bool
+
+	hour := digest.DefaultRollupHour
+
+	if err := b.database.GetSetting(ctx, digest.SettingRollupWeeklyEnabled, &weekly); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get rollup_weekly_enabled from DB")
+	}
+
+	if err := b.database.GetSetting(ctx, digest.SettingRollupMonthlyEnabled, &monthly); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get rollup_monthly_enabled from DB")
+	}
+
+	if err := b.database.GetSetting(ctx, digest.SettingRollupHour, &hour); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get rollup_hour from DB")
+	}
+
+	b.reply(msg, fmt.Sprintf("📆 <b>Roll-up Digests</b>\n"+
+		"Weekly: <code>%s</code> · Monthly: <code>%s</code> · Hour: <code>%02d:00</code>\n\n"+
+		"• <code>/rollup weekly on|off</code>\n"+
+		"• <code>/rollup monthly on|off</code>\n"+
+		"• <code>/rollup hour &lt;0-23&gt;</code> - Post after this hour (schedule timezone)\n"+
+		"• <code>/rollup preview weekly|monthly</code> - Preview the last complete period",
+		formatToggle(weekly), formatToggle(monthly), hour))
+}
+
+func formatToggle(enabled bool) string {
+	if enabled {
+		return ToggleOn
+	}
+
+	return ToggleOff
+}
+
+func (b *Bot) handleRollupToggle(ctx context.Context, msg *tgbotapi.Message, kind, value string) {
+	if value != ToggleOn && value != ToggleOff {
+		b.reply(msg, fmt.Sprintf("Usage: <code>/rollup %s on|off</code>", kind))
+
+		return
+	}
+
+	key := digest.SettingRollupWeeklyEnabled
+	if kind == digest.RollupMonthly {
+		key = digest.SettingRollupMonthlyEnabled
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, key, value == ToggleOn, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ %s roll-ups turned <code>%s</code>.", strings.ToUpper(kind[:1])+kind[1:], value))
+}
+
+func (b *Bot) handleRollupHour(ctx context.Context, msg *tgbotapi.Message, value string) {
+	hour, err := strconv.Atoi(value)
+	if err != nil || hour < 0 || hour >= HoursPerDay {
+		b.reply(msg, "Usage: <code>/rollup hour &lt;0-23&gt;</code>")
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingRollupHour, hour, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Roll-ups will be posted after <code>%02d:00</code>.", hour))
+}
+
+func (b *Bot) handleRollupPreview(ctx context.Context, msg *tgbotapi.Message, kind string) {
+	if b.digestBuilder == nil {
+		b.reply(msg, "❌ Roll-up preview is not available in this mode.")
+
+		return
+	}
+
+	text, err := b.digestBuilder.BuildRollup(ctx, kind, time.Now(), b.logger)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error building roll-up preview: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if text == "" {
+		b.reply(msg, "ℹ️ No stories or ratings in the last complete period.")
+
+		return
+	}
+
+	b.reply(msg, "📝 <b>Roll-up Preview</b>\n<i>This has not been posted to the target channel.</i>\n\n"+text)
+}
@@ -0,0 +1,282 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdRules manages the pre-filter rules evaluated before LLM scoring.
+	CmdRules = "rules"
+
+	// SettingPrefilterRules stores the pre-filter rules as a list of DSL strings.
+	SettingPrefilterRules = "prefilter_rules"
+
+	rulesSubCmdAdd  = "add"
+	rulesSubCmdMove = "move"
+	rulesSubCmdTest = "test"
+	rulesMoveArgs   = 2
+)
+
+var errRuleIndex = errors.New("no rule with that number")
+
+const rulesUsage = "Usage:\n" +
+	"<code>/rules</code> - list rules with hit counts\n" +
+	"<code>/rules add &lt;rule&gt;</code>\n" +
+	"<code>/rules remove &lt;n&gt;</code>\n" +
+	"<code>/rules move &lt;n&gt; &lt;position&gt;</code>\n" +
+	"<code>/rules test &lt;text&gt;</code>\n" +
+	"<code>/rules reset</code> - reset hit counters\n\n" +
+	"Syntax: <code>allow|deny|boost &lt;-1..1&gt; if &lt;condition&gt; [and &lt;condition&gt;]...</code>\n" +
+	"Conditions (prefix with <code>not</code> to negate): <code>regex \"pattern\"</code>, <code>keyword \"word\"</code>, " +
+	"<code>length &lt;|&lt;=|&gt;|&gt;=|= n</code>, <code>channel @username</code>, <code>forward</code>\n" +
+	"Example: <code>deny if regex \"casino|betting\" and not channel @sportsnews</code>"
+
+func (b *Bot) handleRules(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 || strings.EqualFold(args[0], CmdList) {
+		b.replyRulesList(ctx, msg)
+
+		return
+	}
+
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(msg.CommandArguments()), args[0]))
+
+	switch strings.ToLower(args[0]) {
+	case rulesSubCmdAdd:
+		b.handleRulesAdd(ctx, msg, rest)
+	case CmdRemove:
+		b.handleRulesRemove(ctx, msg, args[1:])
+	case rulesSubCmdMove:
+		b.handleRulesMove(ctx, msg, args[1:])
+	case rulesSubCmdTest:
+		b.handleRulesTest(ctx, msg, rest)
+	case SubCmdReset:
+		b.handleRulesReset(ctx, msg)
+	default:
+		b.reply(msg, rulesUsage)
+	}
+}
+
+func (b *Bot) loadPrefilterRules(ctx context.Context) []string {
+	var rules []string
+	if err := b.database.GetSetting(ctx, SettingPrefilterRules, &rules); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get prefilter_rules")
+	}
+
+	return rules
+}
+
+func (b *Bot) savePrefilterRules(ctx context.Context, msg *tgbotapi.Message, rules []string) bool {
+	if err := b.database.SaveSettingWithHistory(ctx, SettingPrefilterRules, rules, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, SettingPrefilterRules, html.EscapeString(err.Error())))
+
+		return false
+	}
+
+	return true
+}
+
+func (b *Bot) replyRulesList(ctx context.Context, msg *tgbotapi.Message) {
+	hits, err := b.database.GetPrefilterRuleHits(ctx)
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to fetch prefilter rule hits")
+	}
+
+	b.reply(msg, formatPrefilterRules(b.loadPrefilterRules(ctx), hits)+"\n"+rulesUsage)
+}
+
+func (b *Bot) handleRulesAdd(ctx context.Context, msg *tgbotapi.Message, src string) {
+	rule, err := filters.ParseRule(src)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), rulesUsage))
+
+		return
+	}
+
+	rules := b.loadPrefilterRules(ctx)
+	for _, existing := range rules {
+		if existing == rule.Source {
+			b.reply(msg, "ℹ️ This rule already exists.")
+
+			return
+		}
+	}
+
+	if !b.savePrefilterRules(ctx, msg, append(rules, rule.Source)) {
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Rule %d added: <code>%s</code>", len(rules)+1, html.EscapeString(rule.Source)))
+}
+
+func (b *Bot) handleRulesRemove(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	rules := b.loadPrefilterRules(ctx)
+
+	idx, err := parseRuleNumber(args, rules)
+	if err != nil {
+		b.replyRuleNumberError(msg, err)
+
+		return
+	}
+
+	removed := rules[idx]
+
+	if !b.savePrefilterRules(ctx, msg, append(rules[:idx:idx], rules[idx+1:]...)) {
+		return
+	}
+
+	if err := b.database.ResetPrefilterRuleHits(ctx, []string{removed}); err != nil {
+		b.logger.Warn().Err(err).Msg("failed to reset hits of removed prefilter rule")
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Rule removed: <code>%s</code>", html.EscapeString(removed)))
+}
+
+func (b *Bot) handleRulesMove(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) != rulesMoveArgs {
+		b.reply(msg, rulesUsage)
+
+		return
+	}
+
+	rules := b.loadPrefilterRules(ctx)
+
+	from, err := parseRuleNumber(args[:1], rules)
+	if err != nil {
+		b.replyRuleNumberError(msg, err)
+
+		return
+	}
+
+	to, err := parseRuleNumber(args[1:], rules)
+	if err != nil {
+		b.replyRuleNumberError(msg, err)
+
+		return
+	}
+
+	rules = moveRule(rules, from, to)
+
+	if !b.savePrefilterRules(ctx, msg, rules) {
+		return
+	}
+
+	b.reply(msg, "✅ Rules reordered.\n\n"+formatPrefilterRules(rules, nil))
+}
+
+func (b *Bot) replyRuleNumberError(msg *tgbotapi.Message, err error) {
+	b.reply(msg, fmt.Sprintf("❌ %s\n\n💡 See rule numbers with <code>/rules</code>.", html.EscapeString(err.Error())))
+}
+
+func (b *Bot) handleRulesTest(ctx context.Context, msg *tgbotapi.Message, text string) {
+	if text == "" {
+		b.reply(msg, rulesUsage)
+
+		return
+	}
+
+	var rules []filters.Rule
+
+	for _, src := range b.loadPrefilterRules(ctx) {
+		if rule, err := filters.ParseRule(src); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	b.reply(msg, formatRuleVerdict(filters.EvaluateRules(rules, filters.RuleInput{Text: text})))
+}
+
+func (b *Bot) handleRulesReset(ctx context.Context, msg *tgbotapi.Message) {
+	if err := b.database.ResetPrefilterRuleHits(ctx, nil); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ Rule hit counters reset.")
+}
+
+// parseRuleNumber parses a 1-based rule number into an index of rules.
+func parseRuleNumber(args []string, rules []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errRuleIndex
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(rules) {
+		return 0, fmt.Errorf("%w: %s", errRuleIndex, args[0])
+	}
+
+	return n - 1, nil
+}
+
+// moveRule moves the rule at index from to index to.
+func moveRule(rules []string, from, to int) []string {
+	rule := rules[from]
+	rest := append(rules[:from:from], rules[from+1:]...)
+
+	moved := make([]string, 0, len(rules))
+	moved = append(moved, rest[:to]...)
+	moved = append(moved, rule)
+
+	return append(moved, rest[to:]...)
+}
+
+func formatPrefilterRules(rules []string, hits map[string]db.PrefilterRuleHit) string {
+	var sb strings.Builder
+
+	sb.WriteString("🧹 <b>Pre-filter Rules</b>\n")
+
+	if len(rules) == 0 {
+		sb.WriteString("<i>No rules. Messages go straight to the regular filters.</i>\n")
+
+		return sb.String()
+	}
+
+	sb.WriteString("Evaluated in order before any LLM call; the first allow or deny wins.\n\n")
+
+	for i, src := range rules {
+		fmt.Fprintf(&sb, "%d. <code>%s</code>", i+1, html.EscapeString(src))
+
+		if _, err := filters.ParseRule(src); err != nil {
+			sb.WriteString(" ⚠️ invalid, skipped")
+		} else if hit, ok := hits[src]; ok {
+			fmt.Fprintf(&sb, " — %d hits, last %s", hit.Hits, hit.LastHitAt.Format(DateTimeFormat))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func formatRuleVerdict(v filters.RuleVerdict) string {
+	var sb strings.Builder
+
+	switch v.Action {
+	case filters.RuleActionDeny:
+		fmt.Fprintf(&sb, "🚫 Denied by <code>%s</code>\n", html.EscapeString(v.Rule))
+	case filters.RuleActionAllow:
+		fmt.Fprintf(&sb, "✅ Allowed by <code>%s</code> (skips heuristic filters and the relevance gate)\n", html.EscapeString(v.Rule))
+	default:
+		sb.WriteString("➡️ No allow or deny rule matched; regular filters apply.\n")
+	}
+
+	if v.Boost != 0 {
+		fmt.Fprintf(&sb, "Importance boost: <code>%+.2f</code>\n", v.Boost)
+	}
+
+	sb.WriteString("<i>Tests run without a source channel, so channel and forward conditions do not match.</i>")
+
+	return sb.String()
+}
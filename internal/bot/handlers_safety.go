@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+)
+
+const (
+	// CmdSafety is the /config subcommand for the content safety policy.
+	CmdSafety = "safety"
+
+	// safetyInherit clears a per-target override.
+	safetyInherit = "default"
+)
+
+const safetyUsage = "Usage: <code>/config safety &lt;off|warn|blur|block&gt; [chat_id|@channel]</code>\n\n" +
+	"Sets what happens to items flagged as NSFW, graphic or profane: <code>warn</code> labels them, " +
+	"<code>blur</code> hides them behind a spoiler, <code>block</code> leaves them out. " +
+	"A global <code>block</code> drops flagged messages in the pipeline. " +
+	"Use <code>/config safety default &lt;target&gt;</code> to remove a target override."
+
+func (b *Bot) handleSafety(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(strings.ToLower(msg.CommandArguments()))
+
+	switch len(args) {
+	case 0:
+		policy := digest.LoadSafetyPolicy(ctx, b.database, 0)
+		b.reply(msg, fmt.Sprintf("Content safety policy is <b>%s</b>.\n\n%s", html.EscapeString(policy), safetyUsage))
+	case 1:
+		b.setGlobalSafety(ctx, msg, args[0])
+	case 2:
+		b.setTargetSafety(ctx, msg, args[0], args[1])
+	default:
+		b.reply(msg, safetyUsage)
+	}
+}
+
+func (b *Bot) setGlobalSafety(ctx context.Context, msg *tgbotapi.Message, policy string) {
+	if !filters.IsValidSafetyPolicy(policy) {
+		b.reply(msg, safetyUsage)
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, filters.SettingContentSafety, policy, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, filters.SettingContentSafety, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Content safety policy set to <b>%s</b>.", policy))
+}
+
+func (b *Bot) setTargetSafety(ctx context.Context, msg *tgbotapi.Message, policy, target string) {
+	if policy != safetyInherit && !filters.IsValidSafetyPolicy(policy) {
+		b.reply(msg, safetyUsage)
+
+		return
+	}
+
+	chatID, chat, errMsg := b.resolveTargetChat(ctx, target)
+	if errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	key := fmt.Sprintf(filters.SafetyTargetKeyFmt, chatID)
+
+	if policy == safetyInherit {
+		if err := b.database.DeleteSettingWithHistory(ctx, key, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf("✅ <b>%s</b> now uses the default content safety policy.", html.EscapeString(chat.Title)))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, key, policy, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, key, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Content safety policy for <b>%s</b> set to <b>%s</b>.", html.EscapeString(chat.Title), policy))
+}
@@ -0,0 +1,207 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	scoresSubCmdVersions  = "versions"
+	scoresSubCmdReference = "reference"
+	scoresSubCmdNormalize = "normalize"
+
+	// SettingNormalizeScores and SettingNormalizeScoresMode control score normalization.
+	SettingNormalizeScores     = "normalize_scores"
+	SettingNormalizeScoresMode = "normalize_scores_mode"
+
+	normalizeModeOff      = "off"
+	normalizeModeZScore   = "zscore"
+	normalizeModeQuantile = "quantile"
+
+	scoreQuantileMedian = 4
+	scoreQuantileP90    = 6
+	scoresVersionsShown = 10
+	promptBaseSummarize = "summarize"
+	defaultPromptVer    = "v1"
+	scoreVersionDateFmt = "2006-01-02"
+)
+
+// currentScoringVersion returns the scoring version the pipeline uses for new items.
+func (b *Bot) currentScoringVersion(ctx context.Context) string {
+	model := b.cfg.LLMSummarizeModel
+	if err := b.database.GetSetting(ctx, SettingLLMOverrideSummarize, &model); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get summarize model override")
+	}
+
+	promptVersion := defaultPromptVer
+	if err := b.database.GetSetting(ctx, fmt.Sprintf(PromptActiveKeyFmt, promptBaseSummarize), &promptVersion); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get active summarize prompt")
+	}
+
+	if strings.TrimSpace(promptVersion) == "" {
+		promptVersion = defaultPromptVer
+	}
+
+	return db.ScoringVersion(strings.TrimSpace(model), promptVersion)
+}
+
+// normalizeScoresMode describes the active normalization: off, zscore or quantile.
+func (b *Bot) normalizeScoresMode(ctx context.Context) string {
+	var enabled bool
+	if err := b.database.GetSetting(ctx, SettingNormalizeScores, &enabled); err != nil || !enabled {
+		return normalizeModeOff
+	}
+
+	mode := normalizeModeZScore
+	if err := b.database.GetSetting(ctx, SettingNormalizeScoresMode, &mode); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get normalize_scores_mode")
+	}
+
+	if mode != normalizeModeQuantile {
+		return normalizeModeZScore
+	}
+
+	return mode
+}
+
+func (b *Bot) handleScoresVersions(ctx context.Context, msg *tgbotapi.Message) {
+	dists, err := b.database.GetScoreDistributions(ctx, nil)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error fetching score distributions: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	var reference string
+	if err := b.database.GetSetting(ctx, digest.SettingScoreReferenceVersion, &reference); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get score_reference_version")
+	}
+
+	b.reply(msg, formatScoreVersions(dists, reference, b.currentScoringVersion(ctx), b.normalizeScoresMode(ctx)))
+}
+
+// formatScoreVersions renders the score distribution of each scoring version
+// and its drift from the reference, newest first.
+func formatScoreVersions(dists []db.ScoreDistribution, reference, current, mode string) string {
+	var sb strings.Builder
+
+	sb.WriteString("📊 <b>Score Distributions by Version</b>\n")
+	fmt.Fprintf(&sb, "Current: <code>%s</code> · Normalization: <code>%s</code>\n", html.EscapeString(current), mode)
+
+	ref, hasRef := db.ReferenceScoreDistribution(dists, reference)
+	if hasRef {
+		fmt.Fprintf(&sb, "Reference: <code>%s</code>\n", html.EscapeString(ref.Version))
+	}
+
+	if len(dists) == 0 {
+		sb.WriteString("\nNo raw scores recorded yet.")
+
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+
+	for i := len(dists) - 1; i >= 0 && len(dists)-i <= scoresVersionsShown; i-- {
+		writeScoreVersion(&sb, dists[i], ref, hasRef)
+	}
+
+	fmt.Fprintf(&sb, "\nDrift is flagged at a quantile shift of %.2f; versions need %d items. ", digest.ScoreDriftBound, db.MinScoreDistributionSamples)
+	sb.WriteString("Set the reference with <code>/scores reference &lt;version&gt;</code>.")
+
+	return sb.String()
+}
+
+func writeScoreVersion(sb *strings.Builder, d db.ScoreDistribution, ref db.ScoreDistribution, hasRef bool) {
+	fmt.Fprintf(sb, "• <code>%s</code> — %d items, %s to %s\n",
+		html.EscapeString(d.Version), d.Count, d.FirstSeen.Format(scoreVersionDateFmt), d.LastSeen.Format(scoreVersionDateFmt))
+
+	if len(d.Relevance) == len(db.ScoreQuantileGrid) && len(d.Importance) == len(db.ScoreQuantileGrid) {
+		fmt.Fprintf(sb, "  relevance p50 %.2f / p90 %.2f · importance p50 %.2f / p90 %.2f\n",
+			d.Relevance[scoreQuantileMedian], d.Relevance[scoreQuantileP90], d.Importance[scoreQuantileMedian], d.Importance[scoreQuantileP90])
+	}
+
+	switch {
+	case !hasRef:
+		return
+	case d.Version == ref.Version:
+		sb.WriteString("  📌 reference\n")
+	case !d.HasEnoughSamples() || !ref.HasEnoughSamples():
+		sb.WriteString("  too few items to compare\n")
+	default:
+		drift := digest.CompareScoreDistributions(d, ref)
+
+		marker := "✅"
+		if drift.Shift() >= digest.ScoreDriftBound {
+			marker = "⚠️"
+		}
+
+		fmt.Fprintf(sb, "  %s shift relevance %.2f · importance %.2f\n", marker, drift.RelevanceShift, drift.ImportanceShift)
+	}
+}
+
+func (b *Bot) handleScoresReference(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) != 1 {
+		b.reply(msg, "Usage: <code>/scores reference &lt;version&gt;</code>\n\n💡 See versions with <code>/scores versions</code>.")
+
+		return
+	}
+
+	dists, err := b.database.GetScoreDistributions(ctx, args)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error fetching score distributions: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if _, ok := db.FindScoreDistribution(dists, args[0]); !ok {
+		b.reply(msg, fmt.Sprintf("❌ No scores recorded for <code>%s</code>.", html.EscapeString(args[0])))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingScoreReferenceVersion, args[0], msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingScoreReferenceVersion, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Score reference set to <code>%s</code>. Drift and quantile normalization now compare with it.", html.EscapeString(args[0])))
+}
+
+func (b *Bot) handleScoresNormalize(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) != 1 {
+		b.reply(msg, fmt.Sprintf("Usage: <code>/scores normalize &lt;off|zscore|quantile&gt;</code>\n\nCurrent: <code>%s</code>", b.normalizeScoresMode(ctx)))
+
+		return
+	}
+
+	mode := strings.ToLower(args[0])
+	if mode != normalizeModeOff && mode != normalizeModeZScore && mode != normalizeModeQuantile {
+		b.reply(msg, "❌ Mode must be <code>off</code>, <code>zscore</code> or <code>quantile</code>.")
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, SettingNormalizeScores, mode != normalizeModeOff, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, SettingNormalizeScores, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if mode != normalizeModeOff {
+		if err := b.database.SaveSettingWithHistory(ctx, SettingNormalizeScoresMode, mode, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrSavingFmt, SettingNormalizeScoresMode, html.EscapeString(err.Error())))
+
+			return
+		}
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Score normalization set to <code>%s</code>. Applies to newly scored items.", mode))
+}
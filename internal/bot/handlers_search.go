@@ -0,0 +1,227 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdSearch runs a full-text search over processed items.
+	CmdSearch = "search"
+
+	// CallbackPrefixSearch is the callback data prefix for search pagination.
+	// Data format: search:<page>:<days>:<query>.
+	CallbackPrefixSearch = "search:"
+
+	searchPageSize       = 5
+	searchDefaultDays    = 30
+	searchMaxDays        = 365
+	searchSnippetLimit   = 200
+	searchCallbackFields = 4
+	// maxCallbackDataLen is Telegram's limit on inline button callback data.
+	maxCallbackDataLen = 64
+)
+
+var errEmptySearchQuery = errors.New("search query is empty")
+
+// parseSearchArgs splits `/search <query> [days]` into the query and lookback days.
+func parseSearchArgs(args string) (string, int, error) {
+	fields := strings.Fields(args)
+	days := searchDefaultDays
+
+	if len(fields) > 1 {
+		if n, err := strconv.Atoi(fields[len(fields)-1]); err == nil && n > 0 {
+			days = min(n, searchMaxDays)
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	query := strings.Join(fields, " ")
+	if query == "" {
+		return "", 0, errEmptySearchQuery
+	}
+
+	return query, days, nil
+}
+
+// searchCallbackData encodes a results page into callback data. It returns
+// false when the query is too long to fit Telegram's callback data limit.
+func searchCallbackData(query string, days, page int) (string, bool) {
+	data := fmt.Sprintf("%s%d:%d:%s", CallbackPrefixSearch, page, days, query)
+
+	return data, len(data) <= maxCallbackDataLen
+}
+
+// parseSearchCallbackData decodes callback data built by searchCallbackData.
+func parseSearchCallbackData(data string) (query string, days, page int, ok bool) {
+	parts := strings.SplitN(data, ":", searchCallbackFields)
+	if len(parts) != searchCallbackFields || parts[3] == "" {
+		return "", 0, 0, false
+	}
+
+	page, errPage := strconv.Atoi(parts[1])
+	days, errDays := strconv.Atoi(parts[2])
+
+	if errPage != nil || errDays != nil || page < 0 || days <= 0 {
+		return "", 0, 0, false
+	}
+
+	return parts[3], days, page, true
+}
+
+func (b *Bot) handleSearch(ctx context.Context, msg *tgbotapi.Message) {
+	query, days, err := parseSearchArgs(msg.CommandArguments())
+	if err != nil {
+		b.reply(msg, "Usage: <code>/search &lt;query&gt; [days]</code>\n\n"+
+			fmt.Sprintf("Searches items from the last %d days by default (up to %d).", searchDefaultDays, searchMaxDays))
+
+		return
+	}
+
+	text, markup, err := b.renderSearchPage(ctx, query, days, 0)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error searching items: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.DisableWebPagePreview = true
+
+	if markup != nil {
+		reply.ReplyMarkup = *markup
+	}
+
+	if _, err := b.sender.Send(reply); err != nil {
+		b.logger.Error().Err(err).Msg("failed to send search results")
+	}
+}
+
+func (b *Bot) handleSearchCallback(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	if _, err := b.sender.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
+	}
+
+	searchQuery, days, page, ok := parseSearchCallbackData(query.Data)
+	if !ok || query.Message == nil {
+		return
+	}
+
+	text, markup, err := b.renderSearchPage(ctx, searchQuery, days, page)
+	if err != nil {
+		b.logger.Error().Err(err).Str("query", searchQuery).Msg("failed to render search page")
+
+		return
+	}
+
+	var edit tgbotapi.EditMessageTextConfig
+	if markup != nil {
+		edit = tgbotapi.NewEditMessageTextAndMarkup(query.Message.Chat.ID, query.Message.MessageID, text, *markup)
+	} else {
+		edit = tgbotapi.NewEditMessageText(query.Message.Chat.ID, query.Message.MessageID, text)
+	}
+
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.DisableWebPagePreview = true
+
+	if _, err := b.sender.Send(edit); err != nil {
+		b.logger.Error().Err(err).Msg("failed to update search results")
+	}
+}
+
+// renderSearchPage runs the search for one page and returns the message text
+// with its navigation keyboard (nil when there is nothing to page through).
+func (b *Bot) renderSearchPage(ctx context.Context, query string, days, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	now := time.Now()
+	from := now.AddDate(0, 0, -days)
+
+	results, count, err := b.database.SearchResearchItems(ctx, db.ResearchSearchParams{
+		Query:        query,
+		From:         &from,
+		SearchAt:     now,
+		Limit:        searchPageSize,
+		Offset:       page * searchPageSize,
+		IncludeCount: true,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("search items: %w", err)
+	}
+
+	total := len(results)
+	if count != nil {
+		total = count.Total
+	}
+
+	text := formatSearchPage(query, days, page, total, results)
+
+	return text, searchPageKeyboard(query, days, page, total), nil
+}
+
+// formatSearchPage renders one page of search results.
+func formatSearchPage(query string, days, page, total int, results []db.ResearchItemSearchResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🔎 <b>Search</b> <code>%s</code> · last %d days\n", html.EscapeString(query), days))
+
+	if total == 0 || len(results) == 0 {
+		sb.WriteString("\nNo matching items found.")
+
+		return sb.String()
+	}
+
+	pages := (total + searchPageSize - 1) / searchPageSize
+	sb.WriteString(fmt.Sprintf("%d matches · page %d/%d\n\n", total, page+1, pages))
+
+	for i, r := range results {
+		name := formatChannelName(r.ChannelUsername, r.ChannelTitle)
+		snippet := buildEnrichmentSnippet(r.Summary, r.Text, searchSnippetLimit)
+
+		sb.WriteString(fmt.Sprintf("%d. %s · <code>%s</code>\n", page*searchPageSize+i+1,
+			FormatLink(r.ChannelUsername, r.ChannelPeerID, r.MessageID, name), r.TGDate.Format(DateTimeFormat)))
+		sb.WriteString(fmt.Sprintf("   score <code>%.2f</code> · importance <code>%.2f</code> · %s\n",
+			r.Score, r.ImportanceScore, html.EscapeString(r.Status)))
+
+		if snippet != "" {
+			sb.WriteString(fmt.Sprintf("   %s\n", html.EscapeString(snippet)))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// searchPageKeyboard builds prev/next buttons for the current page.
+func searchPageKeyboard(query string, days, page, total int) *tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+
+	if page > 0 {
+		if data, ok := searchCallbackData(query, days, page-1); ok {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData("◀ Prev", data))
+		}
+	}
+
+	if (page+1)*searchPageSize < total {
+		if data, ok := searchCallbackData(query, days, page+1); ok {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData("Next ▶", data))
+		}
+	}
+
+	if len(row) == 0 {
+		return nil
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(row)
+
+	return &markup
+}
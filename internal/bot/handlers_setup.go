@@ -0,0 +1,403 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CallbackPrefixSetup is the callback data prefix for /setup wizard buttons.
+	// Data format: setup:<step>:<action|choice>.
+	CallbackPrefixSetup = "setup:"
+
+	setupStepTarget   = "target"
+	setupStepChannels = "channels"
+	setupStepLanguage = "language"
+	setupStepTone     = "tone"
+	setupStepSchedule = "schedule"
+	setupStepTest     = "test"
+
+	setupActionNext   = "next"
+	setupActionCheck  = "check"
+	setupActionRun    = "run"
+	setupActionCancel = "cancel"
+
+	setupCallbackFields = 3
+	setupMaxChannels    = 15
+
+	// setupInputTTL is how long after the last wizard step plain messages are
+	// read as wizard answers.
+	setupInputTTL = time.Hour
+)
+
+// setupSteps are the wizard steps in order.
+var setupSteps = []string{setupStepTarget, setupStepChannels, setupStepLanguage, setupStepTone, setupStepSchedule, setupStepTest}
+
+// setupChoice is a wizard step answered with a button.
+type setupChoice struct {
+	key     string
+	options []string
+}
+
+var setupChoices = map[string]setupChoice{
+	setupStepLanguage: {key: "digest_language", options: []string{"en", "ru", "de", "es", "fr", "it"}},
+	setupStepTone:     {key: "digest_tone", options: []string{"professional", "casual", "brief"}},
+	setupStepSchedule: {key: SettingDigestWindow, options: []string{"1h", "3h", "6h", "12h", "24h"}},
+}
+
+// setupStatus is the current configuration shown in the wizard steps.
+type setupStatus struct {
+	targetID int64
+	channels []db.Channel
+	values   map[string]string
+}
+
+func (b *Bot) handleSetup(ctx context.Context, msg *tgbotapi.Message) {
+	if strings.EqualFold(strings.TrimSpace(msg.CommandArguments()), setupActionCancel) {
+		b.cancelSetup(ctx, msg)
+
+		return
+	}
+
+	b.reply(msg, "🚀 <b>Setup Wizard</b>\n\n"+
+		"Six short steps: target chat, source channels, language, tone, schedule and a test digest. "+
+		"Answer by sending a message or tapping a button; <code>/setup cancel</code> stops the wizard.")
+
+	b.goToSetupStep(ctx, msg, setupStepTarget)
+}
+
+func (b *Bot) cancelSetup(ctx context.Context, msg *tgbotapi.Message) {
+	if err := b.database.DeleteSetupWizardState(ctx, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✖️ Setup wizard stopped. Run <code>/setup</code> to start again.")
+}
+
+// goToSetupStep records the admin's step and sends its prompt.
+func (b *Bot) goToSetupStep(ctx context.Context, msg *tgbotapi.Message, step string) {
+	if err := b.database.SaveSetupWizardStep(ctx, msg.From.ID, step); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.sendSetupStep(ctx, msg.Chat.ID, step)
+}
+
+func (b *Bot) sendSetupStep(ctx context.Context, chatID int64, step string) {
+	text, markup := setupStepMessage(step, b.loadSetupStatus(ctx))
+
+	reply := tgbotapi.NewMessage(chatID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = markup
+
+	if _, err := b.sender.Send(reply); err != nil {
+		b.logger.Error().Err(err).Str("step", step).Msg("failed to send setup step")
+	}
+}
+
+// advanceSetup moves past step, finishing the wizard after the last one.
+func (b *Bot) advanceSetup(ctx context.Context, msg *tgbotapi.Message, step string) {
+	next := nextSetupStep(step)
+	if next != "" {
+		b.goToSetupStep(ctx, msg, next)
+
+		return
+	}
+
+	if err := b.database.DeleteSetupWizardState(ctx, msg.From.ID); err != nil {
+		b.logger.Warn().Err(err).Msg("failed to clear setup wizard state")
+	}
+
+	b.reply(msg, "🎉 <b>Setup complete!</b>\n\n"+
+		"Digests will be posted on the chosen schedule. Next steps:\n"+
+		"• <code>/schedule</code> - fixed posting times and timezone\n"+
+		"• <code>/config importance 0.3</code> - how strict digest selection is\n"+
+		"• <code>/settings</code> - all current values")
+}
+
+// nextSetupStep returns the step after step, or "" after the last step.
+func nextSetupStep(step string) string {
+	i := slices.Index(setupSteps, step)
+	if i < 0 || i+1 >= len(setupSteps) {
+		return ""
+	}
+
+	return setupSteps[i+1]
+}
+
+func (b *Bot) loadSetupStatus(ctx context.Context) setupStatus {
+	status := setupStatus{values: make(map[string]string)}
+
+	if err := b.database.GetSetting(ctx, SettingTargetChatID, &status.targetID); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get target_chat_id")
+	}
+
+	channels, err := b.database.GetActiveChannels(ctx)
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to get channels for setup wizard")
+	}
+
+	status.channels = channels
+
+	for step, choice := range setupChoices {
+		var value string
+		if err := b.database.GetSetting(ctx, choice.key, &value); err == nil {
+			status.values[step] = value
+		}
+	}
+
+	return status
+}
+
+// setupStepMessage renders a wizard step prompt and its buttons.
+func setupStepMessage(step string, status setupStatus) (string, tgbotapi.InlineKeyboardMarkup) {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "<b>Step %d/%d</b> · ", slices.Index(setupSteps, step)+1, len(setupSteps))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	switch step {
+	case setupStepTarget:
+		sb.WriteString("🎯 <b>Target chat</b>\n\nAdd this bot as an admin of the channel or group where digests should be posted, then send its @username or chat ID here.\n\n")
+
+		if status.targetID != 0 {
+			fmt.Fprintf(&sb, "Current target: <code>%d</code>", status.targetID)
+		} else {
+			sb.WriteString("Current target: <i>not set</i>")
+		}
+	case setupStepChannels:
+		sb.WriteString("📡 <b>Source channels</b>\n\nSend channels to read news from: @usernames, IDs or invite links, separated by spaces. " +
+			"Usernames are checked to be public channels the reader can join.\n\n")
+		sb.WriteString(formatSetupChannels(status.channels))
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(setupButton("🔄 Check status", step, setupActionCheck)))
+	case setupStepTest:
+		sb.WriteString("🧪 <b>Test digest</b>\n\nBuild a digest from the current window and send it only to you. " +
+			"Right after adding channels it may be empty until the reader has fetched and processed some messages.")
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(setupButton("▶️ Run test digest", step, setupActionRun)))
+	default:
+		rows = append(rows, writeSetupChoice(&sb, step, status.values[step])...)
+	}
+
+	next := "Next ➡️"
+	if nextSetupStep(step) == "" {
+		next = "✅ Finish"
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		setupButton(next, step, setupActionNext),
+		setupButton("✖️ Cancel", step, setupActionCancel),
+	))
+
+	return sb.String(), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// writeSetupChoice renders a button-answered step and returns its option rows.
+func writeSetupChoice(sb *strings.Builder, step, current string) [][]tgbotapi.InlineKeyboardButton {
+	switch step {
+	case setupStepLanguage:
+		sb.WriteString("🌐 <b>Digest language</b>\n\nOther languages: <code>/config language &lt;code&gt;</code>.")
+	case setupStepTone:
+		sb.WriteString("🎙 <b>Digest tone</b>")
+	case setupStepSchedule:
+		sb.WriteString("⏰ <b>Digest interval</b>\n\nFixed times and a timezone can be set later with <code>/schedule</code>.")
+	}
+
+	if current != "" {
+		fmt.Fprintf(sb, "\n\nCurrent: <code>%s</code>", html.EscapeString(current))
+	}
+
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(setupChoices[step].options))
+
+	for _, option := range setupChoices[step].options {
+		label := option
+		if option == current {
+			label = "• " + option + " •"
+		}
+
+		row = append(row, setupButton(label, step, option))
+	}
+
+	return [][]tgbotapi.InlineKeyboardButton{row}
+}
+
+func setupButton(label, step, arg string) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(label, CallbackPrefixSetup+step+":"+arg)
+}
+
+// parseSetupCallbackData decodes data built by setupButton.
+func parseSetupCallbackData(data string) (step, arg string, ok bool) {
+	parts := strings.SplitN(data, ":", setupCallbackFields)
+	if len(parts) != setupCallbackFields || !slices.Contains(setupSteps, parts[1]) || parts[2] == "" {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
+
+// formatSetupChannels lists the tracked channels with whether the reader has joined them.
+func formatSetupChannels(channels []db.Channel) string {
+	if len(channels) == 0 {
+		return "<i>No channels yet.</i>"
+	}
+
+	var sb strings.Builder
+
+	for i, c := range channels {
+		if i == setupMaxChannels {
+			fmt.Fprintf(&sb, "… and %d more\n", len(channels)-setupMaxChannels)
+
+			break
+		}
+
+		if c.TGPeerID != 0 {
+			fmt.Fprintf(&sb, "✅ %s\n", html.EscapeString(setupChannelLabel(c)))
+		} else {
+			fmt.Fprintf(&sb, "⏳ %s - waiting for the reader to join\n", html.EscapeString(setupChannelLabel(c)))
+		}
+	}
+
+	return sb.String()
+}
+
+func setupChannelLabel(c db.Channel) string {
+	switch {
+	case c.Username != "":
+		return "@" + c.Username
+	case c.Title != "":
+		return c.Title
+	case c.InviteLink != "":
+		return c.InviteLink
+	}
+
+	return c.ID
+}
+
+func (b *Bot) handleSetupCallback(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	if _, err := b.sender.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		b.logger.Error().Err(err).Msg(ErrSendCallbackResp)
+	}
+
+	step, arg, ok := parseSetupCallbackData(query.Data)
+	if !ok || query.Message == nil {
+		return
+	}
+
+	msg := &tgbotapi.Message{Chat: query.Message.Chat, From: query.From}
+
+	switch arg {
+	case setupActionCancel:
+		b.cancelSetup(ctx, msg)
+	case setupActionNext:
+		b.advanceSetup(ctx, msg, step)
+	case setupActionCheck:
+		b.sendSetupStep(ctx, msg.Chat.ID, step)
+	case setupActionRun:
+		b.handlePreview(ctx, msg)
+	default:
+		b.saveSetupChoice(ctx, msg, step, arg)
+	}
+}
+
+func (b *Bot) saveSetupChoice(ctx context.Context, msg *tgbotapi.Message, step, value string) {
+	choice, ok := setupChoices[step]
+	if !ok || !slices.Contains(choice.options, value) {
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, choice.key, value, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, choice.key, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ <code>%s</code> set to <code>%s</code>.", choice.key, html.EscapeString(value)))
+	b.advanceSetup(ctx, msg, step)
+}
+
+// handleSetupInput treats a plain message as the answer to the admin's
+// current wizard step. Messages outside a recent wizard are ignored.
+func (b *Bot) handleSetupInput(ctx context.Context, msg *tgbotapi.Message) {
+	state, err := b.database.GetSetupWizardState(ctx, msg.From.ID)
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to get setup wizard state")
+
+		return
+	}
+
+	if state.Step == "" || time.Since(state.UpdatedAt) > setupInputTTL {
+		return
+	}
+
+	text := strings.TrimSpace(msg.Text)
+
+	switch state.Step {
+	case setupStepTarget:
+		if b.setTargetChat(ctx, msg, text) {
+			b.advanceSetup(ctx, msg, state.Step)
+		}
+	case setupStepChannels:
+		b.reply(msg, b.addSetupChannels(ctx, text))
+		b.goToSetupStep(ctx, msg, state.Step)
+	default:
+		b.reply(msg, "💡 Use the buttons above, or <code>/setup cancel</code> to stop the wizard.")
+	}
+}
+
+// addSetupChannels adds every channel in text. Usernames must resolve to a
+// public chat, which the reader can join without an invite.
+func (b *Bot) addSetupChannels(ctx context.Context, text string) string {
+	identifiers := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' })
+	results := make([]string, 0, len(identifiers))
+
+	for _, identifier := range identifiers {
+		if isSetupUsername(identifier) && !b.isPublicChat(identifier) {
+			results = append(results, fmt.Sprintf("❌ <code>%s</code> was not found as a public channel. Use an invite link for private channels.",
+				html.EscapeString(identifier)))
+
+			continue
+		}
+
+		text, _ := b.addChannel(ctx, identifier)
+		results = append(results, text)
+	}
+
+	if len(results) == 0 {
+		return "💡 Send channel @usernames, IDs or invite links."
+	}
+
+	return strings.Join(results, "\n")
+}
+
+// isSetupUsername reports whether the identifier is a username rather than an
+// invite link or a numeric ID.
+func isSetupUsername(identifier string) bool {
+	if strings.Contains(identifier, "t.me/") {
+		return false
+	}
+
+	_, err := strconv.ParseInt(identifier, 10, 64)
+
+	return err != nil
+}
+
+func (b *Bot) isPublicChat(username string) bool {
+	_, err := b.api.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: tgbotapi.ChatConfig{SuperGroupUsername: "@" + strings.TrimPrefix(username, "@")}})
+
+	return err == nil
+}
@@ -0,0 +1,255 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdShadow is the /config subcommand for the shadow digest target.
+	CmdShadow = "shadow"
+
+	shadowSubTarget  = "target"
+	shadowSubSet     = "set"
+	shadowSubUnset   = "unset"
+	shadowSubPromote = "promote"
+
+	shadowStatsDays  = 30
+	shadowKeyPrefix  = "shadow_"
+	shadowMinSetArgs = 3
+)
+
+const shadowUsage = "Usage:\n" +
+	"<code>/config shadow</code> - status and rating comparison\n" +
+	"<code>/config shadow target &lt;channel_id or @username&gt;</code> - post shadow digests there\n" +
+	"<code>/config shadow off</code> - stop shadow digests\n" +
+	"<code>/config shadow set &lt;setting&gt; &lt;value&gt;</code> - experimental setting override\n" +
+	"<code>/config shadow unset &lt;setting&gt;</code> / <code>/config shadow reset</code> - drop one or all overrides\n" +
+	"<code>/config shadow promote</code> - apply the overrides to production digests"
+
+func (b *Bot) handleShadow(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch {
+	case sub == "":
+		b.replyShadowStatus(ctx, msg)
+	case sub == shadowSubTarget && len(args) == 2:
+		b.setShadowTarget(ctx, msg, args[1])
+	case sub == ToggleOff:
+		b.updateShadowSetting(ctx, msg, digest.SettingShadowTargetChatID, nil, "✅ Shadow digests disabled.")
+	case sub == shadowSubSet && len(args) >= shadowMinSetArgs:
+		b.setShadowOverride(ctx, msg, args[1], strings.Join(args[2:], " "))
+	case sub == shadowSubUnset && len(args) == 2:
+		b.unsetShadowOverride(ctx, msg, args[1])
+	case sub == SubCmdReset:
+		b.updateShadowSetting(ctx, msg, digest.SettingShadowOverrides, nil, "✅ Shadow overrides cleared.")
+	case sub == shadowSubPromote:
+		b.promoteShadowOverrides(ctx, msg)
+	default:
+		b.reply(msg, shadowUsage)
+	}
+}
+
+func (b *Bot) setShadowTarget(ctx context.Context, msg *tgbotapi.Message, target string) {
+	chatID, chat, errMsg := b.resolveTargetChat(ctx, target)
+	if errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	if errMsg := b.verifyTargetChatPermissions(ctx, chatID, chat, "🧪 This chat has been set as the shadow target for experimental digests."); errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	b.updateShadowSetting(ctx, msg, digest.SettingShadowTargetChatID, chatID,
+		fmt.Sprintf("✅ Shadow digests will be posted to <code>%d</code> (<b>%s</b>).", chatID, html.EscapeString(chat.Title)))
+}
+
+// updateShadowSetting saves value under key, or deletes the setting when
+// value is nil, and replies with done.
+func (b *Bot) updateShadowSetting(ctx context.Context, msg *tgbotapi.Message, key string, value interface{}, done string) {
+	var err error
+	if value == nil {
+		err = b.database.DeleteSettingWithHistory(ctx, key, msg.From.ID)
+	} else {
+		err = b.database.SaveSettingWithHistory(ctx, key, value, msg.From.ID)
+	}
+
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, done)
+}
+
+func (b *Bot) setShadowOverride(ctx context.Context, msg *tgbotapi.Message, key, value string) {
+	if strings.HasPrefix(key, shadowKeyPrefix) {
+		b.reply(msg, "❌ Shadow settings cannot be overridden.")
+
+		return
+	}
+
+	overrides := b.loadShadowOverrides(ctx)
+	overrides[key] = parseShadowValue(value)
+
+	b.updateShadowSetting(ctx, msg, digest.SettingShadowOverrides, overrides,
+		fmt.Sprintf("✅ Shadow override <code>%s</code> = <code>%s</code>", html.EscapeString(key), html.EscapeString(string(overrides[key]))))
+}
+
+func (b *Bot) unsetShadowOverride(ctx context.Context, msg *tgbotapi.Message, key string) {
+	overrides := b.loadShadowOverrides(ctx)
+	if _, ok := overrides[key]; !ok {
+		b.reply(msg, fmt.Sprintf("❌ No shadow override for <code>%s</code>.", html.EscapeString(key)))
+
+		return
+	}
+
+	delete(overrides, key)
+
+	b.updateShadowSetting(ctx, msg, digest.SettingShadowOverrides, overrides,
+		fmt.Sprintf("✅ Shadow override <code>%s</code> removed.", html.EscapeString(key)))
+}
+
+// promoteShadowOverrides saves every override as the production setting and
+// clears the overrides, so both variants match until new ones are set.
+func (b *Bot) promoteShadowOverrides(ctx context.Context, msg *tgbotapi.Message) {
+	overrides := b.loadShadowOverrides(ctx)
+	if len(overrides) == 0 {
+		b.reply(msg, "No shadow overrides to promote.")
+
+		return
+	}
+
+	keys := sortedShadowKeys(overrides)
+
+	for _, key := range keys {
+		if err := b.database.SaveSettingWithHistory(ctx, key, overrides[key], msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		if b.llmClient != nil {
+			// Model overrides take effect in the LLM registry immediately
+			b.llmClient.RefreshOverride(ctx, b.database, key)
+		}
+	}
+
+	b.updateShadowSetting(ctx, msg, digest.SettingShadowOverrides, nil,
+		"✅ Promoted to production: <code>"+strings.Join(keys, "</code>, <code>")+"</code>")
+}
+
+// loadShadowOverrides returns the shadow overrides; never nil.
+func (b *Bot) loadShadowOverrides(ctx context.Context) map[string]json.RawMessage {
+	var overrides map[string]json.RawMessage
+
+	if err := b.database.GetSetting(ctx, digest.SettingShadowOverrides, &overrides); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get shadow_overrides from DB")
+	}
+
+	if overrides == nil {
+		overrides = make(map[string]json.RawMessage)
+	}
+
+	return overrides
+}
+
+// parseShadowValue keeps JSON values (true, 0.5, ["ua"]) as they are and
+// stores anything else as a string.
+func parseShadowValue(value string) json.RawMessage {
+	if json.Valid([]byte(value)) {
+		return json.RawMessage(value)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return json.RawMessage(`""`)
+	}
+
+	return encoded
+}
+
+func (b *Bot) replyShadowStatus(ctx context.Context, msg *tgbotapi.Message) {
+	var chatID int64
+	if err := b.database.GetSetting(ctx, digest.SettingShadowTargetChatID, &chatID); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get shadow_target_chat_id from DB")
+	}
+
+	stats, err := b.database.GetShadowComparison(ctx, time.Now().AddDate(0, 0, -shadowStatsDays))
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("failed to load shadow comparison")
+	}
+
+	b.reply(msg, formatShadowStatus(chatID, b.loadShadowOverrides(ctx), stats))
+}
+
+func formatShadowStatus(chatID int64, overrides map[string]json.RawMessage, stats []db.VariantRatingStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("🧪 <b>Shadow Digests</b>\n\n")
+
+	if chatID == 0 {
+		sb.WriteString("Target: off\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Target: <code>%d</code>\n", chatID))
+	}
+
+	sb.WriteString("\n<b>Overrides:</b>\n")
+
+	if len(overrides) == 0 {
+		sb.WriteString("none (shadow digests match production)\n")
+	}
+
+	for _, key := range sortedShadowKeys(overrides) {
+		sb.WriteString(fmt.Sprintf("• <code>%s</code> = <code>%s</code>\n", html.EscapeString(key), html.EscapeString(string(overrides[key]))))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n<b>Ratings, last %d days:</b>\n", shadowStatsDays))
+
+	for _, s := range stats {
+		sb.WriteString(fmt.Sprintf("• %s: %d digests, 👍 %d / 👎 %d%s\n", s.Variant, s.Digests, s.Up, s.Down, formatApproval(s)))
+	}
+
+	sb.WriteString("\n" + shadowUsage)
+
+	return sb.String()
+}
+
+func formatApproval(s db.VariantRatingStats) string {
+	if s.Ratings == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%.0f%% positive)", float64(s.Up)*percentageMultiplier/float64(s.Ratings))
+}
+
+func sortedShadowKeys(overrides map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdSimilar lists items nearest to an item or example text by embedding.
+	CmdSimilar = "similar"
+
+	similarResultLimit  = 8
+	similarSnippetLimit = 160
+)
+
+var (
+	errNoSimilarExample      = errors.New("no example text")
+	errEmbeddingsUnavailable = errors.New("embeddings are not configured for the bot")
+)
+
+// similarExample returns the item ID or text to look up: an explicit argument
+// wins, otherwise the text or caption of the replied-to (e.g. forwarded) message.
+func similarExample(msg *tgbotapi.Message) (itemID, text string, err error) {
+	arg := strings.TrimSpace(msg.CommandArguments())
+
+	switch {
+	case isUUIDString(arg):
+		return arg, "", nil
+	case arg != "":
+		return "", arg, nil
+	case msg.ReplyToMessage != nil:
+		text = strings.TrimSpace(msg.ReplyToMessage.Text)
+		if text == "" {
+			text = strings.TrimSpace(msg.ReplyToMessage.Caption)
+		}
+	}
+
+	if text == "" {
+		return "", "", errNoSimilarExample
+	}
+
+	return "", text, nil
+}
+
+func (b *Bot) handleSimilar(ctx context.Context, msg *tgbotapi.Message) {
+	itemID, text, err := similarExample(msg)
+	if err != nil {
+		b.reply(msg, "Usage: <code>/similar &lt;item_id|text&gt;</code>\n\n"+
+			"Or forward a message to the bot and reply to it with <code>/similar</code>.")
+
+		return
+	}
+
+	embedding, err := b.similarEmbedding(ctx, itemID, text)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Could not embed the example: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	items, err := b.database.FindNearestItems(ctx, embedding, itemID, similarResultLimit)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error finding similar items: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, formatSimilarItems(itemID, items, b.cfg.ClusterSimilarityThreshold))
+}
+
+// similarEmbedding loads the stored embedding of an item or embeds free text.
+func (b *Bot) similarEmbedding(ctx context.Context, itemID, text string) ([]float32, error) {
+	if itemID != "" {
+		embedding, err := b.database.GetItemEmbedding(ctx, itemID)
+		if err != nil {
+			return nil, fmt.Errorf("load item embedding: %w", err)
+		}
+
+		return embedding, nil
+	}
+
+	if b.embedder == nil {
+		return nil, errEmbeddingsUnavailable
+	}
+
+	embedding, err := b.embedder.GetEmbedding(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("get embedding: %w", err)
+	}
+
+	return embedding, nil
+}
+
+// formatSimilarItems renders nearest items. Items at or above dupThreshold
+// would be treated as duplicates by semantic dedup and are flagged.
+func formatSimilarItems(itemID string, items []db.SimilarItem, dupThreshold float32) string {
+	var sb strings.Builder
+
+	sb.WriteString("🧬 <b>Similar Items</b>")
+
+	if itemID != "" {
+		sb.WriteString(fmt.Sprintf(" to <code>%s</code>", itemID))
+	}
+
+	sb.WriteString("\n\n")
+
+	if len(items) == 0 {
+		sb.WriteString("No items with embeddings found.")
+
+		return sb.String()
+	}
+
+	for i, it := range items {
+		name := formatChannelName(it.ChannelUsername, it.ChannelTitle)
+
+		sb.WriteString(fmt.Sprintf("%d. <code>%.3f</code>", i+1, it.Similarity))
+
+		if it.Similarity >= float64(dupThreshold) {
+			sb.WriteString(" ⚠️ dup")
+		}
+
+		sb.WriteString(fmt.Sprintf(" · %s · <code>%s</code>\n",
+			FormatLink(it.ChannelUsername, it.ChannelPeerID, it.MessageID, name), it.TGDate.Format(DateTimeFormat)))
+		sb.WriteString(fmt.Sprintf("   <code>%s</code> · %s", it.ID, similarDigestState(it)))
+
+		if it.ClusterID != "" {
+			sb.WriteString(fmt.Sprintf(" · cluster <i>%s</i>", html.EscapeString(it.ClusterTopic)))
+		}
+
+		sb.WriteString("\n")
+
+		if snippet := buildEnrichmentSnippet(it.Summary, it.Text, similarSnippetLimit); snippet != "" {
+			sb.WriteString(fmt.Sprintf("   %s\n", html.EscapeString(snippet)))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n⚠️ marks similarity ≥ %.2f (semantic dedup threshold).", dupThreshold))
+
+	return sb.String()
+}
+
+func similarDigestState(it db.SimilarItem) string {
+	if it.DigestedAt != nil {
+		return "digested " + it.DigestedAt.Format(DateTimeFormat)
+	}
+
+	return html.EscapeString(it.Status)
+}
@@ -0,0 +1,239 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/process/pipeline"
+)
+
+const (
+	// CmdStages is the /config subcommand for the pipeline stage switches.
+	CmdStages = "stages"
+
+	stagesSubShadow      = "shadow"
+	stagesSubShadowModel = "shadow_model"
+
+	shadowAgreementWindow = 7 * 24 * time.Hour
+)
+
+const stagesUsage = "Usage:\n" +
+	"<code>/config stages &lt;stage&gt; &lt;on|off&gt;</code> - turn an optional stage on or off\n" +
+	"<code>/config stages shadow</code> - agreement of shadow stages with their live stages\n" +
+	"<code>/config stages shadow &lt;stage&gt; &lt;0-1|off&gt;</code> - share of messages a shadow stage runs on\n" +
+	"<code>/config stages shadow_model &lt;model|off&gt;</code> - model tried by <code>gate_shadow</code>\n\n" +
+	"Required stages always run. Changes apply from the next pipeline batch."
+
+func (b *Bot) handleStages(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) > 0 {
+		args[0] = strings.ToLower(args[0])
+	}
+
+	switch {
+	case len(args) == 0:
+		b.replyStages(ctx, msg)
+	case args[0] == stagesSubShadow && len(args) == 1:
+		b.replyShadowAgreement(ctx, msg)
+	case args[0] == stagesSubShadow && len(args) == 3:
+		b.setShadowSample(ctx, msg, strings.ToLower(args[1]), strings.ToLower(args[2]))
+	case args[0] == stagesSubShadowModel && len(args) == 2:
+		b.setShadowModel(ctx, msg, args[1])
+	case len(args) == 2 && (strings.ToLower(args[1]) == "on" || strings.ToLower(args[1]) == ToggleOff):
+		b.setStageEnabled(ctx, msg, args[0], strings.ToLower(args[1]) == "on")
+	default:
+		b.reply(msg, stagesUsage)
+	}
+}
+
+func (b *Bot) setStageEnabled(ctx context.Context, msg *tgbotapi.Message, name string, enabled bool) {
+	idx := slices.IndexFunc(pipeline.Stages(), func(s pipeline.StageInfo) bool { return s.Name == name })
+	if idx < 0 {
+		b.reply(msg, fmt.Sprintf("❌ Unknown stage: <code>%s</code>\n\n%s", html.EscapeString(name), stagesUsage))
+
+		return
+	}
+
+	if pipeline.Stages()[idx].Required {
+		b.reply(msg, fmt.Sprintf("❌ Stage <code>%s</code> is required and cannot be turned off.", html.EscapeString(name)))
+
+		return
+	}
+
+	disabled := slices.DeleteFunc(b.loadDisabledStages(ctx), func(s string) bool { return s == name })
+	if !enabled {
+		disabled = append(disabled, name)
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, pipeline.SettingDisabledStages, disabled, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, pipeline.SettingDisabledStages, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	state := "on"
+	if !enabled {
+		state = ToggleOff
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Pipeline stage <code>%s</code> turned <b>%s</b>.", html.EscapeString(name), state))
+}
+
+func (b *Bot) setShadowSample(ctx context.Context, msg *tgbotapi.Message, name, value string) {
+	if !slices.ContainsFunc(pipeline.Stages(), func(s pipeline.StageInfo) bool { return slices.Contains(s.Shadows, name) }) {
+		b.reply(msg, fmt.Sprintf("❌ Unknown shadow stage: <code>%s</code>\n\n%s", html.EscapeString(name), stagesUsage))
+
+		return
+	}
+
+	rate := 0.0
+
+	if value != ToggleOff {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			b.reply(msg, stagesUsage)
+
+			return
+		}
+
+		rate = parsed
+	}
+
+	samples := b.loadShadowSamples(ctx)
+	if samples == nil {
+		samples = make(map[string]float64)
+	}
+
+	if rate == 0 {
+		delete(samples, name)
+	} else {
+		samples[name] = rate
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, pipeline.SettingShadowSamples, samples, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, pipeline.SettingShadowSamples, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if rate == 0 {
+		b.reply(msg, fmt.Sprintf("✅ Shadow stage <code>%s</code> stopped.", html.EscapeString(name)))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Shadow stage <code>%s</code> runs on %s%% of messages.",
+		html.EscapeString(name), strconv.FormatFloat(rate*percentageMultiplier, 'f', -1, 64)))
+}
+
+func (b *Bot) setShadowModel(ctx context.Context, msg *tgbotapi.Message, model string) {
+	if strings.EqualFold(model, ToggleOff) {
+		if err := b.database.DeleteSettingWithHistory(ctx, pipeline.SettingGateShadowModel, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.reply(msg, "✅ <code>gate_shadow</code> model reset to <code>RELEVANCE_GATE_SHADOW_MODEL</code>.")
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, pipeline.SettingGateShadowModel, model, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, pipeline.SettingGateShadowModel, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ <code>gate_shadow</code> now tries <code>%s</code>.", html.EscapeString(model)))
+}
+
+func (b *Bot) loadDisabledStages(ctx context.Context) []string {
+	var disabled []string
+
+	if err := b.database.GetSetting(ctx, pipeline.SettingDisabledStages, &disabled); err != nil {
+		b.logger.Warn().Err(err).Msg("could not get pipeline_disabled_stages from DB")
+	}
+
+	return disabled
+}
+
+func (b *Bot) loadShadowSamples(ctx context.Context) map[string]float64 {
+	var samples map[string]float64
+
+	if err := b.database.GetSetting(ctx, pipeline.SettingShadowSamples, &samples); err != nil {
+		b.logger.Warn().Err(err).Msg("could not get pipeline_shadow_samples from DB")
+	}
+
+	return samples
+}
+
+func (b *Bot) replyStages(ctx context.Context, msg *tgbotapi.Message) {
+	disabled := b.loadDisabledStages(ctx)
+	samples := b.loadShadowSamples(ctx)
+
+	var sb strings.Builder
+
+	sb.WriteString("🧩 <b>Pipeline Stages</b> (in order)\n\n")
+
+	for i, stage := range pipeline.Stages() {
+		state := "on"
+
+		switch {
+		case stage.Required:
+			state = "required"
+		case slices.Contains(disabled, stage.Name):
+			state = "<b>off</b>"
+		}
+
+		fmt.Fprintf(&sb, "%d. <code>%s</code> - %s\n", i+1, html.EscapeString(stage.Name), state)
+
+		for _, shadow := range stage.Shadows {
+			sample := "off"
+			if rate := samples[shadow]; rate > 0 {
+				sample = strconv.FormatFloat(rate*percentageMultiplier, 'f', -1, 64) + "% sample"
+			}
+
+			fmt.Fprintf(&sb, "   ↳ shadow <code>%s</code> - %s\n", html.EscapeString(shadow), sample)
+		}
+	}
+
+	sb.WriteString("\n" + stagesUsage)
+
+	b.reply(msg, sb.String())
+}
+
+func (b *Bot) replyShadowAgreement(ctx context.Context, msg *tgbotapi.Message) {
+	stats, err := b.database.GetPipelineShadowAgreement(ctx, time.Now().Add(-shadowAgreementWindow))
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(stats) == 0 {
+		b.reply(msg, "No shadow stage decisions in the last 7 days.\n\n"+stagesUsage)
+
+		return
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("🧪 <b>Shadow Stage Agreement</b> (7d)\n\n")
+
+	for _, s := range stats {
+		fmt.Fprintf(&sb, "<code>%s</code> vs <code>%s</code>: <b>%.1f%%</b> of %d agree\n",
+			html.EscapeString(s.ShadowStage), html.EscapeString(s.Stage),
+			float64(s.Agreed)*percentageMultiplier/float64(s.Sampled), s.Sampled)
+		fmt.Fprintf(&sb, "   only shadow dropped: %d, only live dropped: %d\n", s.ShadowOnly, s.LiveOnly)
+	}
+
+	b.reply(msg, sb.String())
+}
@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdStale is the /config subcommand for stale item handling.
+	CmdStale = "stale"
+
+	settingFreshnessDecayHours = "freshness_decay_hours"
+	settingFreshnessFloor      = "freshness_floor"
+
+	staleFieldDecay = "decay"
+)
+
+// stalePolicySetters maps /config stale field names to policy updates.
+var stalePolicySetters = map[string]func(*db.StaleItemPolicy, string) error{
+	"misses": func(p *db.StaleItemPolicy, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+		}
+
+		p.MaxMissed = n
+
+		return nil
+	},
+	"lookback": func(p *db.StaleItemPolicy, v string) error {
+		hours, err := strconv.Atoi(strings.TrimSuffix(v, "h"))
+		if err != nil || hours < 1 {
+			return fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, v)
+		}
+
+		p.LookbackHours = hours
+
+		return nil
+	},
+}
+
+func (b *Bot) handleStale(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(strings.ToLower(msg.CommandArguments()))
+
+	policy := db.DefaultStaleItemPolicy()
+	if err := b.database.GetSetting(ctx, digest.SettingStaleItemPolicy, &policy); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get stale_item_policy")
+	}
+
+	if len(args) == 0 {
+		b.reply(msg, formatStalePolicy(policy)+b.formatFreshnessDecay(ctx)+"\n"+staleUsage())
+
+		return
+	}
+
+	if args[0] == staleFieldDecay {
+		b.handleFreshnessDecay(ctx, msg, args[1:])
+
+		return
+	}
+
+	if err := applyStaleArgs(&policy, args); err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), staleUsage()))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingStaleItemPolicy, policy, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingStaleItemPolicy, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, "✅ Stale item handling updated. Applies from the next digest.\n\n"+formatStalePolicy(policy))
+}
+
+// applyStaleArgs updates the policy from "/config stale off|skip|section" or
+// "/config stale <field> <value>" arguments.
+func applyStaleArgs(policy *db.StaleItemPolicy, args []string) error {
+	switch mode := args[0]; mode {
+	case db.StaleItemsOff, db.StaleItemsSkip, db.StaleItemsSection:
+		policy.Mode = mode
+
+		return nil
+	}
+
+	setter, ok := stalePolicySetters[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown field %s", errInvalidAutoPolicyValue, args[0])
+	}
+
+	if len(args) != 2 {
+		return fmt.Errorf("%w: %s needs one value", errInvalidAutoPolicyValue, args[0])
+	}
+
+	return setter(policy, args[1])
+}
+
+// handleFreshnessDecay sets the freshness decay applied to importance at
+// selection time: "/config stale decay <hours|off> [floor]".
+func (b *Bot) handleFreshnessDecay(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	hours, floor, err := parseFreshnessDecayArgs(args)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), staleUsage()))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, settingFreshnessDecayHours, hours, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, settingFreshnessDecayHours, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if floor != nil {
+		if err := b.database.SaveSettingWithHistory(ctx, settingFreshnessFloor, *floor, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrSavingFmt, settingFreshnessFloor, html.EscapeString(err.Error())))
+
+			return
+		}
+	}
+
+	b.reply(msg, "✅ Freshness decay updated.\n\n"+b.formatFreshnessDecay(ctx))
+}
+
+// parseFreshnessDecayArgs parses "<hours|off> [floor]"; the floor is nil when omitted.
+func parseFreshnessDecayArgs(args []string) (int, *float32, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return 0, nil, fmt.Errorf("%w: decay needs hours and an optional floor", errInvalidAutoPolicyValue)
+	}
+
+	hours := 0
+
+	if args[0] != ToggleOff {
+		h, err := strconv.Atoi(strings.TrimSuffix(args[0], "h"))
+		if err != nil || h < 1 {
+			return 0, nil, fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, args[0])
+		}
+
+		hours = h
+	}
+
+	if len(args) == 1 {
+		return hours, nil, nil
+	}
+
+	val, err := strconv.ParseFloat(args[1], 32)
+	if err != nil || val < 0 || val > 1 {
+		return 0, nil, fmt.Errorf("%w: %s", errInvalidAutoPolicyValue, args[1])
+	}
+
+	floor := float32(val)
+
+	return hours, &floor, nil
+}
+
+func (b *Bot) formatFreshnessDecay(ctx context.Context) string {
+	hours, floor := b.cfg.FreshnessDecayHours, b.cfg.FreshnessFloor
+
+	if err := b.database.GetSetting(ctx, settingFreshnessDecayHours, &hours); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get freshness_decay_hours")
+	}
+
+	if err := b.database.GetSetting(ctx, settingFreshnessFloor, &floor); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get freshness_floor")
+	}
+
+	if hours <= 0 {
+		return "• freshness decay: <i>off</i>\n"
+	}
+
+	return fmt.Sprintf("• freshness decay: importance × e^(-age/<code>%dh</code>), at least <code>%.2f</code>\n", hours, floor)
+}
+
+func formatStalePolicy(p db.StaleItemPolicy) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🕰 <b>Stale Items</b> (<code>%s</code>)\n\n", html.EscapeString(p.Mode))
+
+	if !p.Active() {
+		sb.WriteString("• undigested items from earlier windows: <i>never reconsidered</i>\n")
+
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "• undigested items from the last <code>%dh</code> compete in the next digest\n", p.LookbackHours)
+
+	stale := "skipped"
+	if p.Mode == db.StaleItemsSection {
+		stale = "listed under \"Previously missed\""
+	}
+
+	fmt.Fprintf(&sb, "• after <code>%d</code> missed digests: %s\n", p.MaxMissed, stale)
+
+	return sb.String()
+}
+
+func staleUsage() string {
+	return "Usage:\n" +
+		"<code>/config stale off|skip|section</code>\n" +
+		"<code>/config stale misses &lt;n&gt;</code> - missed digests until an item is stale\n" +
+		"<code>/config stale lookback &lt;hours&gt;</code> - how far back items are carried over\n" +
+		"<code>/config stale decay &lt;hours|off&gt; [floor]</code> - freshness decay of importance"
+}
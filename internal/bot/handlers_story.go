@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdStory lists ongoing stories and shows their event timelines.
+	CmdStory = "story"
+
+	storyListLimit   = 10
+	storyDateFormat  = "2006-01-02"
+	storySubTimeline = "timeline"
+)
+
+const storyUsage = "Usage: <code>/story</code> - recent stories\n" +
+	"<code>/story timeline &lt;id&gt;</code> - event timeline of a story"
+
+func (b *Bot) handleStory(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+
+	switch {
+	case len(args) == 0:
+		stories, err := b.database.ListStories(ctx, storyListLimit)
+		if err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.reply(msg, formatStoryList(stories))
+	case len(args) == 2 && strings.EqualFold(args[0], storySubTimeline) && isUUIDString(args[1]):
+		b.replyStoryTimeline(ctx, msg, args[1])
+	default:
+		b.reply(msg, storyUsage)
+	}
+}
+
+func (b *Bot) replyStoryTimeline(ctx context.Context, msg *tgbotapi.Message, storyID string) {
+	story, err := b.database.GetStory(ctx, storyID)
+	if errors.Is(err, db.ErrStoryNotFound) {
+		b.reply(msg, "❌ Story not found.")
+
+		return
+	}
+
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	events, err := b.database.GetStoryTimeline(ctx, storyID)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, formatStoryTimeline(story, events))
+}
+
+func formatStoryList(stories []db.Story) string {
+	var sb strings.Builder
+
+	sb.WriteString("🧵 <b>Ongoing Stories</b>\n\n")
+
+	if len(stories) == 0 {
+		sb.WriteString("No stories yet. Stories form when a digest cluster continues one from an earlier digest.")
+
+		return sb.String()
+	}
+
+	for _, s := range stories {
+		sb.WriteString(fmt.Sprintf("• <b>%s</b>\n   %s → %s · %d clusters · %d events\n   <code>%s</code>\n",
+			html.EscapeString(storyTitle(s)), s.FirstSeenAt.Format(storyDateFormat), s.LastSeenAt.Format(storyDateFormat),
+			s.ClusterCount, s.EventCount, s.ID))
+	}
+
+	sb.WriteString("\nShow a timeline with <code>/story timeline &lt;id&gt;</code>.")
+
+	return sb.String()
+}
+
+// formatStoryTimeline renders one line per event: date, development and a
+// link to the source message.
+func formatStoryTimeline(story *db.Story, events []db.StoryEvent) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🧵 <b>%s</b>\n\n", html.EscapeString(storyTitle(*story))))
+
+	if len(events) == 0 {
+		sb.WriteString("The timeline has not been built yet.")
+
+		return sb.String()
+	}
+
+	for _, e := range events {
+		sb.WriteString(fmt.Sprintf("<code>%s</code> — %s", e.Date.Format(storyDateFormat), html.EscapeString(e.Text)))
+
+		if e.MsgID != 0 {
+			label := e.ChannelUsername
+			if label == "" {
+				label = "source"
+			}
+
+			sb.WriteString(" (" + FormatLink(e.ChannelUsername, e.ChannelPeerID, e.MsgID, label) + ")")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func storyTitle(s db.Story) string {
+	if s.Title == "" {
+		return "Untitled story"
+	}
+
+	return s.Title
+}
@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdTemplate manages digest layout templates.
+const CmdTemplate = "template"
+
+func (b *Bot) handleTemplate(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		b.replyTemplateUsage(msg)
+
+		return
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "show":
+		b.handleTemplateShow(ctx, msg, args)
+	case subCmdSet:
+		b.handleTemplateSet(ctx, msg, args)
+	case "activate", "active":
+		b.handleTemplateActivate(ctx, msg, args)
+	default:
+		b.replyTemplateUsage(msg)
+	}
+}
+
+func (b *Bot) replyTemplateUsage(msg *tgbotapi.Message) {
+	b.reply(msg, "Usage:\n"+
+		"<code>/template show [version]</code>\n"+
+		"<code>/template set &lt;version&gt; &lt;template...&gt;</code>\n"+
+		"<code>/template activate &lt;version|builtin&gt;</code>\n\n"+
+		"Templates use Go text/template syntax over <code>.Header</code> and <code>.Tiers</code> "+
+		"(each tier has <code>.Clusters</code> with <code>.Items</code> and <code>.Sources</code>).")
+}
+
+func (b *Bot) activeTemplateVersion(ctx context.Context) string {
+	version := b.templateSetting(ctx, digest.TemplateActiveKey)
+	if version == "" {
+		return digest.TemplateBuiltinVersion
+	}
+
+	return version
+}
+
+// templateSetting reads a template setting, treating missing keys as empty.
+func (b *Bot) templateSetting(ctx context.Context, key string) string {
+	var value string
+
+	if err := b.database.GetSetting(ctx, key, &value); err != nil {
+		return ""
+	}
+
+	return value
+}
+
+func (b *Bot) handleTemplateShow(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	active := b.activeTemplateVersion(ctx)
+
+	version := active
+	if len(args) > 1 {
+		version = args[1]
+	}
+
+	if version == digest.TemplateBuiltinVersion {
+		b.reply(msg, "Using the built-in digest layout (no template active).")
+
+		return
+	}
+
+	text := b.templateSetting(ctx, fmt.Sprintf(digest.TemplateKeyFmt, version))
+	if text == "" {
+		b.reply(msg, fmt.Sprintf("No template saved as <code>%s</code>.", html.EscapeString(version)))
+
+		return
+	}
+
+	status := ""
+	if version == active {
+		status = " (active)"
+	}
+
+	b.reply(msg, fmt.Sprintf("Template <code>%s</code>%s:\n<pre>%s</pre>", html.EscapeString(version), status, html.EscapeString(text)))
+}
+
+func (b *Bot) handleTemplateSet(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	text := templateBody(msg.CommandArguments())
+	if len(args) < 3 || text == "" {
+		b.reply(msg, "Usage: <code>/template set &lt;version&gt; &lt;template...&gt;</code>")
+
+		return
+	}
+
+	version := args[1]
+	if version == digest.TemplateBuiltinVersion {
+		b.reply(msg, fmt.Sprintf("❌ <code>%s</code> is reserved for the built-in layout.", digest.TemplateBuiltinVersion))
+
+		return
+	}
+
+	if err := digest.ValidateDigestTemplate(text); err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Template rejected: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, fmt.Sprintf(digest.TemplateKeyFmt, version), text, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error saving template: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Template saved as <code>%s</code>. Use <code>/template activate %s</code> to use it.",
+		html.EscapeString(version), html.EscapeString(version)))
+}
+
+func (b *Bot) handleTemplateActivate(ctx context.Context, msg *tgbotapi.Message, args []string) {
+	if len(args) < 2 {
+		b.reply(msg, "Usage: <code>/template activate &lt;version|builtin&gt;</code>")
+
+		return
+	}
+
+	version := args[1]
+
+	if version != digest.TemplateBuiltinVersion {
+		text := b.templateSetting(ctx, fmt.Sprintf(digest.TemplateKeyFmt, version))
+		if text == "" {
+			b.reply(msg, fmt.Sprintf("❌ No template saved as <code>%s</code>.", html.EscapeString(version)))
+
+			return
+		}
+
+		// Templates may predate validation changes; re-check before activating.
+		if err := digest.ValidateDigestTemplate(text); err != nil {
+			b.reply(msg, fmt.Sprintf("❌ Template <code>%s</code> no longer renders: %s", html.EscapeString(version), html.EscapeString(err.Error())))
+
+			return
+		}
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.TemplateActiveKey, version, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf("❌ Error saving active template: %s", html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Active digest template set to <code>%s</code>.", html.EscapeString(version)))
+}
+
+// templateBody returns the template text of "set <version> <template...>",
+// keeping its line breaks.
+func templateBody(arguments string) string {
+	rest := strings.TrimSpace(arguments)
+
+	for range 2 {
+		idx := strings.IndexFunc(rest, isTemplateSpace)
+		if idx < 0 {
+			return ""
+		}
+
+		rest = strings.TrimLeftFunc(rest[idx:], isTemplateSpace)
+	}
+
+	return strings.TrimSpace(rest)
+}
+
+func isTemplateSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
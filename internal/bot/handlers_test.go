@@ -1,12 +1,19 @@
 package bot
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/schedule"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
@@ -1127,6 +1134,63 @@ func TestFormatRatingsSummaryOutput(t *testing.T) {
 	}
 }
 
+func TestFormatClickStatsOutput(t *testing.T) {
+	channels := []db.ChannelClickStats{
+		{ChannelID: "1", Username: "chan1", DigestedItems: 10, ClickedItems: 4, Clicks: 9},
+		{ChannelID: "2", Title: "Channel 2", DigestedItems: 10, ClickedItems: 1, Clicks: 1},
+		{ChannelID: "3", Username: "chan3", DigestedItems: 5},
+	}
+	bands := []db.ImportanceClickStats{{MinImportance: 0.4, DigestedItems: 20, ClickedItems: 5}}
+
+	got := formatClickStatsOutput(7, 2, channels, bands)
+
+	for _, part := range []string{
+		"last 7 days",
+		"Clicked items: <code>5</code>/<code>25</code> (20%)",
+		"<code>0.4+</code>: <code>5</code>/<code>20</code> (25%)",
+		"@chan1: <code>4</code>/<code>10</code> (40%) · 9 clicks",
+		"Channel 2",
+	} {
+		if !containsString(got, part) {
+			t.Errorf("formatClickStatsOutput() missing %q in output: %s", part, got)
+		}
+	}
+
+	if containsString(got, "@chan3") {
+		t.Errorf("formatClickStatsOutput() should respect the limit: %s", got)
+	}
+}
+
+func TestFormatAudienceReport(t *testing.T) {
+	morning := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	snapshots := []db.AudienceSnapshot{{RecordedAt: morning, MemberCount: 1000}, {RecordedAt: morning.AddDate(0, 0, 2), MemberCount: 1040}}
+	digests := []db.DigestEngagement{
+		{PostedAt: morning, Items: 4, Views: 400, Members: 1000, Topics: map[string]int{"Tech": 4}},
+		{PostedAt: morning.AddDate(0, 0, 1), Items: 8, Views: 200, Members: 1000, Topics: map[string]int{"Tech": 8}},
+	}
+
+	got := formatAudienceReport(30, db.BuildAudienceReport(snapshots, digests, time.UTC), time.UTC)
+
+	for _, part := range []string{
+		"last 30 days",
+		"Members: <code>1040</code> (+40)",
+		"Digests: <code>2</code> · avg views <code>300</code> · reach <code>30%</code>",
+		"By posting hour (UTC)",
+		"• 09:00: 2 digests · 300 views · 30%",
+		"• 1–5 items: 1 digests · 400 views · 40%",
+		"• Tech: 2 digests",
+	} {
+		if !containsString(got, part) {
+			t.Errorf("formatAudienceReport() missing %q in output: %s", part, got)
+		}
+	}
+
+	membersOnly := formatAudienceReport(7, db.AudienceReport{Snapshots: snapshots[:1]}, time.UTC)
+	if containsString(membersOnly, "Digests:") || !containsString(membersOnly, "Members: <code>1000</code> (+0)") {
+		t.Errorf("formatAudienceReport() without digests = %s", membersOnly)
+	}
+}
+
 func TestBuildDiscoveryKeyboard(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1775,6 +1839,28 @@ func TestCommandConstants(t *testing.T) {
 		"inlineimages":     CmdInlineImagesAlt,
 		"others_narrative": CmdOthersNarrative,
 		"othersnarrative":  CmdOthersNarrativeAlt,
+		"numbers_block":    CmdNumbersBlock,
+		"numbersblock":     CmdNumbersBlockAlt,
+		"quotes_block":     CmdQuotesBlock,
+		"quotesblock":      CmdQuotesBlockAlt,
+		"upcoming_block":   CmdUpcomingBlock,
+		"upcomingblock":    CmdUpcomingBlockAlt,
+		"rumor_watch":      CmdRumorWatch,
+		"rumorwatch":       CmdRumorWatchAlt,
+		"whats_new":        CmdWhatsNew,
+		"whatsnew":         CmdWhatsNewAlt,
+		"source_footer":    CmdSourceFooter,
+		"sourcefooter":     CmdSourceFooterAlt,
+		"click_tracking":   CmdClickTracking,
+		"clicktracking":    CmdClickTrackingAlt,
+
+		// Delivery
+		"slack_delivery":   CmdSlackDelivery,
+		"slackdelivery":    CmdSlackDeliveryAlt,
+		"discord_delivery": CmdDiscordDelivery,
+		"discorddelivery":  CmdDiscordDeliveryAlt,
+		"matrix_delivery":  CmdMatrixDelivery,
+		"matrixdelivery":   CmdMatrixDeliveryAlt,
 	}
 
 	for expected, actual := range commands {
@@ -2339,6 +2425,7 @@ func TestHelpChannelsMessage(t *testing.T) {
 		"/channel list",
 		"/channel weight",
 		"/channel relevance",
+		"/channel quota",
 		"/channel stats",
 	}
 
@@ -2450,6 +2537,7 @@ func TestHelpAIMessage(t *testing.T) {
 		"/ai tone",
 		"/ai prompt",
 		"/ai editor",
+		"/ai sections",
 		"/ai tiered",
 		"/ai vision",
 		"/ai consolidated",
@@ -2557,3 +2645,1134 @@ func TestBotFatherCommandsMessage(t *testing.T) {
 		t.Error("botFatherCommandsMessage() missing 'channel'")
 	}
 }
+
+func TestFormatChannelRecommendations(t *testing.T) {
+	recs := []db.ChannelRecommendation{
+		{
+			Username:             "newsx",
+			Title:                "News <X>",
+			DiscoveryCount:       4,
+			EngagementScore:      120,
+			SourceUsername:       "best",
+			SourceInclusionRate:  0.62,
+			SourceOverlap:        1,
+			SourceIsTopPerformer: true,
+			Score:                0.71,
+		},
+		{Username: "other", DiscoveryCount: 2, SourceUsername: "mid", SourceOverlap: 0.25, Score: 0.3},
+	}
+
+	text := formatChannelRecommendations(recs)
+
+	for _, want := range []string{
+		"1. <b>@newsx</b> (score <code>0.71</code>) — News &lt;X&gt;",
+		"Surfaced <code>4</code>× via @best",
+		"@best lands <code>62%</code> of its posts in digests",
+		"@best is one of your best performers",
+		"@mid overlaps with your best performers (Jaccard <code>0.25</code>)",
+	} {
+		require.Contains(t, text, want)
+	}
+
+	rows := buildRecommendationKeyboard(recs)
+	require.Len(t, rows, 2)
+	require.Equal(t, expectedCallbackDiscover+"approve:newsx", *rows[0][0].CallbackData)
+}
+
+func TestApplyDiscoverAutoArgs(t *testing.T) {
+	policy := db.DefaultDiscoveryAutoPolicy()
+
+	steps := [][]string{
+		{"on"},
+		{"min_seen", "3"},
+		{"categories", "Tech,", "science"},
+		{"reject_mismatched", "on"},
+		{"trial_weight", "0.3"},
+	}
+
+	for _, args := range steps {
+		if err := applyDiscoverAutoArgs(&policy, args); err != nil {
+			t.Fatalf("applyDiscoverAutoArgs(%v) error = %v", args, err)
+		}
+	}
+
+	if !policy.Enabled || policy.ApproveMinSeen != 3 || !policy.RejectMismatched || policy.TrialWeight != 0.3 {
+		t.Errorf("unexpected policy: %+v", policy)
+	}
+
+	if len(policy.Categories) != 2 || policy.Categories[0] != "tech" || policy.Categories[1] != "science" {
+		t.Errorf("categories = %v, want [tech science]", policy.Categories)
+	}
+
+	for _, args := range [][]string{{"bogus", "1"}, {"min_seen"}, {"min_seen", "-1"}, {"trial_weight", "2"}} {
+		if err := applyDiscoverAutoArgs(&policy, args); !errors.Is(err, errInvalidAutoPolicyValue) {
+			t.Errorf("applyDiscoverAutoArgs(%v) error = %v, want errInvalidAutoPolicyValue", args, err)
+		}
+	}
+}
+
+func TestParseChannelQuotaArgs(t *testing.T) {
+	tests := []struct {
+		args       []string
+		wantLimit  int
+		wantPeriod string
+		wantPolicy string
+	}{
+		{args: []string{"200"}, wantLimit: 200, wantPeriod: db.QuotaPeriodDay, wantPolicy: db.QuotaPolicySample},
+		{args: []string{"200/day"}, wantLimit: 200, wantPeriod: db.QuotaPeriodDay, wantPolicy: db.QuotaPolicySample},
+		{args: []string{"20/Hour", "DEFER"}, wantLimit: 20, wantPeriod: db.QuotaPeriodHour, wantPolicy: db.QuotaPolicyDefer},
+		{args: []string{"50/day", "drop"}, wantLimit: 50, wantPeriod: db.QuotaPeriodDay, wantPolicy: db.QuotaPolicyDrop},
+	}
+
+	for _, tt := range tests {
+		limit, period, policy, err := parseChannelQuotaArgs(tt.args)
+		if err != nil {
+			t.Fatalf("parseChannelQuotaArgs(%v) error = %v", tt.args, err)
+		}
+
+		if limit != tt.wantLimit || period != tt.wantPeriod || policy != tt.wantPolicy {
+			t.Errorf("parseChannelQuotaArgs(%v) = %d, %s, %s; want %d, %s, %s",
+				tt.args, limit, period, policy, tt.wantLimit, tt.wantPeriod, tt.wantPolicy)
+		}
+	}
+
+	for _, args := range [][]string{{"0"}, {"abc/day"}, {"10/week"}, {"10/day", "keep"}} {
+		if _, _, _, err := parseChannelQuotaArgs(args); !errors.Is(err, errInvalidChannelQuota) {
+			t.Errorf("parseChannelQuotaArgs(%v) error = %v, want errInvalidChannelQuota", args, err)
+		}
+	}
+}
+
+func TestFormatItemDetail(t *testing.T) {
+	item := &db.ItemDebugDetail{
+		Summary:         "Central bank raises rates",
+		Topic:           "Economy",
+		Status:          "ready",
+		RelevanceScore:  0.8,
+		ImportanceScore: 0.65,
+		EvidenceVerdict: "supported",
+		ChannelUsername: "newschan",
+		MessageID:       42,
+	}
+	evidence := []db.ItemEvidenceWithSource{
+		{ItemEvidence: db.ItemEvidence{AgreementScore: 0.9}, Source: db.EvidenceSource{URL: "https://example.com/a", Domain: "example.com"}},
+	}
+	cluster := &db.ClusterWithItems{Topic: "Rates"}
+	related := []db.ClusterItemInfo{{ChannelUsername: "other", MessageID: 7}}
+
+	got := formatItemDetail(item, evidence, cluster, related)
+
+	for _, want := range []string{
+		"Central bank raises rates",
+		"Importance: <code>0.65</code>",
+		"https://t.me/newschan/42",
+		"<code>supported</code>",
+		"https://example.com/a",
+		"<b>Cluster</b>: Rates (1 related)",
+		"https://t.me/other/7",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatItemDetail() missing %q in:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(formatItemDetail(item, nil, nil, nil), "<b>Cluster</b>") {
+		t.Error("formatItemDetail() should omit cluster section without a cluster")
+	}
+
+	if strings.Contains(got, "Ticket:") {
+		t.Error("formatItemDetail() should omit the ticket of an untracked item")
+	}
+
+	item.TicketKey = "NEWS-12"
+	item.TicketURL = "https://example.atlassian.net/browse/NEWS-12"
+
+	if got := formatItemDetail(item, nil, nil, nil); !strings.Contains(got, `Ticket: <a href="https://example.atlassian.net/browse/NEWS-12">NEWS-12</a>`) {
+		t.Errorf("formatItemDetail() missing ticket link in:\n%s", got)
+	}
+}
+
+func TestTemplateBody(t *testing.T) {
+	tests := []struct {
+		args string
+		want string
+	}{
+		{"set v1 <b>{{.Header.Title}}</b>", "<b>{{.Header.Title}}</b>"},
+		{"set v1\n{{range .Tiers}}\n{{.Name}}\n{{end}}", "{{range .Tiers}}\n{{.Name}}\n{{end}}"},
+		{"set v1", ""},
+		{"set", ""},
+	}
+
+	for _, tt := range tests {
+		if got := templateBody(tt.args); got != tt.want {
+			t.Errorf("templateBody(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestParseCatchupSince(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		arg  string
+		want time.Time
+	}{
+		{"12h", now.Add(-12 * time.Hour)},
+		{"3d", now.Add(-72 * time.Hour)},
+		{"2026-10-15", time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{"2026-10-15T08:30:00Z", time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCatchupSince(tt.arg, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseCatchupSince(%q) = %v, %v; want %v", tt.arg, got, err, tt.want)
+		}
+	}
+
+	for _, arg := range []string{"yesterday", "-3h", "0d"} {
+		if _, err := parseCatchupSince(arg, now); !errors.Is(err, errInvalidCatchupSince) {
+			t.Errorf("parseCatchupSince(%q) error = %v, want errInvalidCatchupSince", arg, err)
+		}
+	}
+
+	if got := clampCatchupSince(now.AddDate(0, -1, 0), now); !got.Equal(now.Add(-catchupMaxLookback)) {
+		t.Errorf("clampCatchupSince() = %v, want max lookback", got)
+	}
+}
+
+func TestCatchupDue(t *testing.T) {
+	windows, err := schedule.ParseDeliveryWindows("08:00-09:00")
+	require.NoError(t, err)
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	windowStart := time.Date(2026, 10, 16, 5, 0, 0, 0, time.UTC)
+	inWindow := windowStart.Add(20 * time.Minute)
+	yesterday := windowStart.AddDate(0, 0, -1)
+
+	start, due := catchupDue(db.CatchupSchedule{}, loc, windows, inWindow)
+	require.True(t, due)
+	require.True(t, start.Equal(windowStart))
+
+	_, due = catchupDue(db.CatchupSchedule{LastDeliveredAt: &yesterday}, loc, windows, inWindow)
+	require.True(t, due, "a recap from yesterday's window does not cover today's")
+
+	_, due = catchupDue(db.CatchupSchedule{LastDeliveredAt: &windowStart}, loc, windows, inWindow)
+	require.False(t, due, "one recap per window")
+
+	_, due = catchupDue(db.CatchupSchedule{}, loc, windows, windowStart.Add(-time.Minute))
+	require.False(t, due, "outside the window")
+}
+
+func TestDiffPreview(t *testing.T) {
+	items := []db.Item{{ID: "a", Summary: "ECB holds rates"}, {ID: "b", Summary: "Storm hits coast"}, {ID: "c", Summary: "Vote <delayed>"}}
+	clusters := []db.ClusterWithItems{
+		{Topic: "Economy", Items: items[:1]},
+		{Topic: " politics ", Items: items[2:]},
+		{Topic: "Weather", Items: items[1:2]},
+	}
+	matches := map[string]db.PostedItemMatch{
+		"a": {ItemID: "a", PostedItemID: "p1", Summary: "ECB keeps rates", Similarity: 0.91},
+	}
+
+	d := diffPreview(items, clusters, matches, []string{"Politics", ""})
+
+	require.Len(t, d.repeatedItems, 1)
+	require.Len(t, d.newItems, 2)
+	require.Len(t, d.repeatedClusters, 2)
+	require.Equal(t, 1, d.repeatedClusters[0].repeatedItems)
+	require.True(t, d.repeatedClusters[1].sameTopic)
+	require.Len(t, d.newClusters, 1)
+	require.Equal(t, "Weather", d.newClusters[0].Topic)
+
+	posted := &db.PostedDigest{ItemCount: 14, ClusterTopics: []string{"Politics"}}
+	text := formatPreviewDiff(context.Background(), posted, d, matches)
+
+	require.Contains(t, text, "Would repeat (1)")
+	require.Contains(t, text, "↳ already posted: ECB keeps rates (similarity 0.91)")
+	require.Contains(t, text, "Vote &lt;delayed&gt;")
+	require.Contains(t, text, "Clusters</b>: 1 new, 2 would repeat")
+}
+
+func TestParseWatchSpec(t *testing.T) {
+	tests := []struct {
+		args string
+		want watchSpec
+	}{
+		{`"sanctions" channel:@news`, watchSpec{Query: "sanctions", Channel: "news"}},
+		{`oil price topic:"Energy markets"`, watchSpec{Query: "oil price", Topic: "Energy markets"}},
+		{`“central bank” Channel:feed`, watchSpec{Query: "central bank", Channel: "feed"}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseWatchSpec(tt.args)
+		if err != nil || got != tt.want {
+			t.Errorf("parseWatchSpec(%q) = %+v, %v; want %+v", tt.args, got, err, tt.want)
+		}
+	}
+
+	if _, err := parseWatchSpec("channel:@news"); !errors.Is(err, errEmptyWatchQuery) {
+		t.Errorf("parseWatchSpec() without query error = %v, want errEmptyWatchQuery", err)
+	}
+
+	if _, err := parseWatchSpec(`"sanctions`); !errors.Is(err, errUnterminatedQuote) {
+		t.Errorf("parseWatchSpec() with open quote error = %v, want errUnterminatedQuote", err)
+	}
+}
+
+func TestParseWatchlistSpec(t *testing.T) {
+	got, err := parseWatchlistSpec(`"Olaf Scholz" alias:Scholz alias:"Олаф Шольц" type:Person`)
+	require.NoError(t, err)
+	require.Equal(t, watchlistSpec{Name: "Olaf Scholz", Aliases: []string{"Scholz", "Олаф Шольц"}, EntityType: watchlistTypePerson}, got)
+
+	got, err = parseWatchlistSpec("Acme Corp type:org")
+	require.NoError(t, err)
+	require.Equal(t, watchlistSpec{Name: "Acme Corp", EntityType: watchlistTypeOrg}, got)
+
+	_, err = parseWatchlistSpec("alias:Scholz")
+	require.ErrorIs(t, err, errEmptyWatchlistName)
+
+	_, err = parseWatchlistSpec("Scholz type:place")
+	require.ErrorIs(t, err, errInvalidWatchlistType)
+}
+
+func TestParseAPIKeySpec(t *testing.T) {
+	got, err := parseAPIKeySpec("notebook")
+	require.NoError(t, err)
+	require.Equal(t, apiKeySpec{Name: "notebook", Scope: db.ResearchAPIKeyScopeRead, RatePerMinute: defaultAPIKeyRatePerMinute}, got)
+
+	got, err = parseAPIKeySpec("labeler scope:label rate:120")
+	require.NoError(t, err)
+	require.Equal(t, apiKeySpec{Name: "labeler", Scope: db.ResearchAPIKeyScopeLabel, RatePerMinute: 120}, got)
+
+	_, err = parseAPIKeySpec("scope:read")
+	require.ErrorIs(t, err, errEmptyAPIKeyName)
+
+	_, err = parseAPIKeySpec("two words")
+	require.ErrorIs(t, err, errInvalidAPIKeyName)
+
+	for _, args := range []string{"k scope:admin", "k rate:0", "k rate:abc"} {
+		_, err = parseAPIKeySpec(args)
+		require.ErrorIs(t, err, errInvalidAPIKeyOpt, args)
+	}
+}
+
+func TestParseSearchArgs(t *testing.T) {
+	tests := []struct {
+		args      string
+		wantQuery string
+		wantDays  int
+	}{
+		{"sanctions", "sanctions", searchDefaultDays},
+		{"oil price 7", "oil price", 7},
+		{"2026", "2026", searchDefaultDays},
+		{"elections 1000", "elections", searchMaxDays},
+	}
+
+	for _, tt := range tests {
+		query, days, err := parseSearchArgs(tt.args)
+		if err != nil || query != tt.wantQuery || days != tt.wantDays {
+			t.Errorf("parseSearchArgs(%q) = %q, %d, %v; want %q, %d", tt.args, query, days, err, tt.wantQuery, tt.wantDays)
+		}
+	}
+
+	if _, _, err := parseSearchArgs("  "); !errors.Is(err, errEmptySearchQuery) {
+		t.Errorf("parseSearchArgs() empty error = %v, want errEmptySearchQuery", err)
+	}
+}
+
+func TestSearchCallbackDataRoundTrip(t *testing.T) {
+	data, ok := searchCallbackData("rates: ECB", 7, 2)
+	require.True(t, ok)
+
+	query, days, page, ok := parseSearchCallbackData(data)
+	require.True(t, ok)
+	require.Equal(t, "rates: ECB", query)
+	require.Equal(t, 7, days)
+	require.Equal(t, 2, page)
+
+	_, ok = searchCallbackData(strings.Repeat("x", maxCallbackDataLen), 7, 0)
+	require.False(t, ok)
+
+	_, _, _, ok = parseSearchCallbackData("search:x:7:query")
+	require.False(t, ok)
+}
+
+func TestSearchPageKeyboard(t *testing.T) {
+	require.Nil(t, searchPageKeyboard("q", 7, 0, searchPageSize))
+
+	first := searchPageKeyboard("q", 7, 0, searchPageSize+1)
+	require.NotNil(t, first)
+	require.Len(t, first.InlineKeyboard[0], 1)
+	require.Equal(t, "search:1:7:q", *first.InlineKeyboard[0][0].CallbackData)
+
+	middle := searchPageKeyboard("q", 7, 1, 3*searchPageSize)
+	require.Len(t, middle.InlineKeyboard[0], 2)
+}
+
+func similarCommand(text string, reply *tgbotapi.Message) *tgbotapi.Message {
+	return &tgbotapi.Message{
+		Text:           text,
+		Entities:       []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len("/similar")}},
+		ReplyToMessage: reply,
+	}
+}
+
+func TestSimilarExample(t *testing.T) {
+	const itemID = "6f1c2a9e-8d5b-4c3a-9e2f-1a2b3c4d5e6f"
+
+	id, text, err := similarExample(similarCommand("/similar "+itemID, nil))
+	require.NoError(t, err)
+	require.Equal(t, itemID, id)
+	require.Empty(t, text)
+
+	_, text, err = similarExample(similarCommand("/similar central bank raises rates", nil))
+	require.NoError(t, err)
+	require.Equal(t, "central bank raises rates", text)
+
+	_, text, err = similarExample(similarCommand("/similar", &tgbotapi.Message{Caption: "forwarded caption"}))
+	require.NoError(t, err)
+	require.Equal(t, "forwarded caption", text)
+
+	_, _, err = similarExample(similarCommand("/similar", nil))
+	require.ErrorIs(t, err, errNoSimilarExample)
+}
+
+func TestFormatSimilarItems(t *testing.T) {
+	digested := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	items := []db.SimilarItem{
+		{
+			ItemSearchResult: db.ItemSearchResult{ID: "a", Summary: "Rates up", ChannelUsername: "news", MessageID: 10},
+			Similarity:       0.95,
+			ClusterID:        "c1",
+			ClusterTopic:     "Economy",
+			DigestedAt:       &digested,
+		},
+		{
+			ItemSearchResult: db.ItemSearchResult{ID: "b", Summary: "Other", Status: "ready", ChannelUsername: "feed", MessageID: 11},
+			Similarity:       0.60,
+		},
+	}
+
+	got := formatSimilarItems("", items, 0.75)
+
+	require.Contains(t, got, "1. <code>0.950</code> ⚠️ dup")
+	require.Contains(t, got, "digested ")
+	require.Contains(t, got, "cluster <i>Economy</i>")
+	require.Contains(t, got, "2. <code>0.600</code> · ")
+	require.Contains(t, got, "<code>b</code> · ready\n")
+}
+
+func TestFormatDedupDecisions(t *testing.T) {
+	got := formatDedupDecisions([]db.DedupDecision{
+		{
+			RawMessageID:   "raw-1",
+			Mode:           "semantic",
+			Scope:          "global",
+			MatchedItemID:  "item-1",
+			Similarity:     0.912,
+			Threshold:      0.75,
+			Text:           "Rates <up>",
+			MatchedSummary: "Central bank raises rates",
+		},
+		{RawMessageID: "raw-2", Mode: "strict", Scope: "global", Similarity: 1, Threshold: 1},
+	})
+
+	require.Contains(t, got, "<code>semantic/global</code> similarity <code>0.912</code> (threshold <code>0.75</code>)")
+	require.Contains(t, got, "dropped <code>raw-1</code>: Rates &lt;up&gt;")
+	require.Contains(t, got, "matched item <code>item-1</code>: Central bank raises rates")
+	require.Contains(t, got, "matched neighbor unknown")
+}
+
+func TestToggleEditorSection(t *testing.T) {
+	sections := toggleEditorSection([]string{llm.EditorSectionLede, llm.EditorSectionWatchlist}, llm.EditorSectionNumbers, true)
+	require.Equal(t, []string{llm.EditorSectionLede, llm.EditorSectionWatchlist, llm.EditorSectionNumbers}, sections)
+
+	sections = toggleEditorSection(sections, llm.EditorSectionLede, false)
+	require.Equal(t, []string{llm.EditorSectionWatchlist, llm.EditorSectionNumbers}, sections)
+
+	sections = toggleEditorSection(sections, llm.EditorSectionWatchlist, true)
+	require.Equal(t, []string{llm.EditorSectionWatchlist, llm.EditorSectionNumbers}, sections, "enabling an enabled section should not duplicate it")
+}
+
+func TestFormatRegions(t *testing.T) {
+	require.Equal(t, "<code>ua</code> Ukraine, <code>pl</code> Poland", formatRegions([]string{"ua", "pl"}))
+}
+
+func TestFormatStoryTimeline(t *testing.T) {
+	story := &db.Story{Title: "Peace <talks>"}
+	events := []db.StoryEvent{
+		{Date: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), Text: "Talks began", ChannelUsername: "news", MsgID: 42},
+		{Date: time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC), Text: "Talks stalled"},
+	}
+
+	got := formatStoryTimeline(story, events)
+
+	require.Contains(t, got, "Peace &lt;talks&gt;")
+	require.Contains(t, got, `<code>2026-02-01</code> — Talks began (<a href="https://t.me/news/42">news</a>)`)
+	require.Contains(t, got, "<code>2026-02-03</code> — Talks stalled\n")
+	require.Contains(t, formatStoryTimeline(&db.Story{}, nil), "not been built yet")
+}
+
+func TestParseShadowValue(t *testing.T) {
+	require.JSONEq(t, `true`, string(parseShadowValue("true")))
+	require.JSONEq(t, `0.5`, string(parseShadowValue("0.5")))
+	require.JSONEq(t, `["ua","pl"]`, string(parseShadowValue(`["ua","pl"]`)))
+	require.JSONEq(t, `"casual"`, string(parseShadowValue("casual")))
+	require.JSONEq(t, `"gpt 5 mini"`, string(parseShadowValue("gpt 5 mini")))
+}
+
+func TestFormatShadowStatus(t *testing.T) {
+	overrides := map[string]json.RawMessage{"digest_tone": json.RawMessage(`"casual"`)}
+	stats := []db.VariantRatingStats{
+		{Variant: db.DigestVariantProduction, Digests: 4, Ratings: 4, Up: 3, Down: 1},
+		{Variant: db.DigestVariantShadow, Digests: 4},
+	}
+
+	got := formatShadowStatus(-100123, overrides, stats)
+
+	require.Contains(t, got, "Target: <code>-100123</code>")
+	require.Contains(t, got, "<code>digest_tone</code> = <code>&#34;casual&#34;</code>")
+	require.Contains(t, got, "production: 4 digests, 👍 3 / 👎 1 (75% positive)")
+	require.Contains(t, got, "shadow: 4 digests, 👍 0 / 👎 0\n")
+	require.Contains(t, formatShadowStatus(0, nil, nil), "Target: off")
+}
+
+func TestParseRollbackPoint(t *testing.T) {
+	point, err := parseRollbackPoint(nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, point.steps)
+
+	point, err = parseRollbackPoint([]string{"3"})
+	require.NoError(t, err)
+	require.Equal(t, 3, point.steps)
+
+	point, err = parseRollbackPoint([]string{"2026-02-01", "15:04"})
+	require.NoError(t, err)
+	require.Zero(t, point.steps)
+	require.Equal(t, time.Date(2026, 2, 1, 15, 4, 0, 0, time.UTC), point.at)
+
+	point, err = parseRollbackPoint([]string{"2026-02-01"})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), point.at)
+
+	_, err = parseRollbackPoint([]string{"0"})
+	require.ErrorIs(t, err, errInvalidRollbackPoint)
+
+	_, err = parseRollbackPoint([]string{"yesterday"})
+	require.ErrorIs(t, err, errInvalidRollbackPoint)
+}
+
+func TestRollbackCallbackData(t *testing.T) {
+	kind, id, ok := parseRollbackCallbackData(rollbackCallbackData(rollbackKindPrompt, 42))
+	require.True(t, ok)
+	require.Equal(t, rollbackKindPrompt, kind)
+	require.Equal(t, int64(42), id)
+
+	_, _, ok = parseRollbackCallbackData(CallbackPrefixRollback + rollbackCancel)
+	require.False(t, ok)
+
+	_, _, ok = parseRollbackCallbackData("rollback:x:42")
+	require.False(t, ok)
+}
+
+func TestRollbackScopeForEntry(t *testing.T) {
+	scope, ok := rollbackScopeForEntry(rollbackKindSetting, "digest_tone")
+	require.True(t, ok)
+	require.Equal(t, db.RollbackScope{Key: "digest_tone"}, scope)
+
+	scope, ok = rollbackScopeForEntry(rollbackKindPrompt, "prompt:narrative:active")
+	require.True(t, ok)
+	require.Equal(t, db.RollbackScope{Key: "prompt:narrative:", Prefix: true}, scope)
+
+	_, ok = rollbackScopeForEntry(rollbackKindPrompt, "digest_tone")
+	require.False(t, ok)
+}
+
+func TestFormatRollbackPlan(t *testing.T) {
+	got := formatRollbackPlan("Preview", []db.SettingRollback{
+		{Key: "digest_tone", Current: `"casual"`, Restored: `"professional"`, EntryID: 7, ChangedAt: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)},
+		{Key: "prompt:narrative:v2", Current: `"text"`, EntryID: 8, ChangedAt: time.Date(2026, 2, 1, 11, 0, 0, 0, time.UTC)},
+	})
+
+	require.Contains(t, got, "• <b>digest_tone</b> (undoes #7 from 2026-02-01 10:00:00)")
+	require.Contains(t, got, "📤 Current: <code>&#34;casual&#34;</code>")
+	require.Contains(t, got, "📥 Restore: <code>&#34;professional&#34;</code>")
+	require.Contains(t, got, "📥 Restore: <i>(default)</i>")
+}
+
+func TestConfigApplyCallbackData(t *testing.T) {
+	data, ok := configApplyCallbackData(SettingDigestWindow, "6h")
+	require.True(t, ok)
+
+	key, value, ok := parseConfigApplyCallbackData(data)
+	require.True(t, ok)
+	require.Equal(t, SettingDigestWindow, key)
+	require.Equal(t, "6h", value)
+
+	_, _, ok = parseConfigApplyCallbackData(CallbackPrefixConfigApply + configApplyCancel)
+	require.False(t, ok)
+
+	_, ok = configApplyCallbackData(SettingDigestWindow, strings.Repeat("1", maxCallbackDataLen))
+	require.False(t, ok)
+}
+
+func TestLintThresholdChange(t *testing.T) {
+	require.Empty(t, lintThresholdChange(0.5, 0.6, db.WhatIfResult{Scored: 10, Included: 4}))
+
+	warnings := lintThresholdChange(0.5, 0.95, db.WhatIfResult{Scored: 10})
+	require.Len(t, warnings, 3)
+	require.Contains(t, warnings[0], "Very strict")
+	require.Contains(t, warnings[1], "Large jump from 0.50")
+	require.Contains(t, warnings[2], "No item")
+
+	require.Contains(t, lintThresholdChange(0.2, 0.05, db.WhatIfResult{})[0], "Very lenient")
+}
+
+func TestLintWindowChange(t *testing.T) {
+	require.Empty(t, lintWindowChange(time.Hour))
+	require.Contains(t, lintWindowChange(5 * time.Minute)[0], "Very short")
+	require.Contains(t, lintWindowChange(96 * time.Hour)[0], "Very long")
+}
+
+func TestFormatConfigImpact(t *testing.T) {
+	got := formatThresholdImpact("relevance threshold", 0.7, db.WhatIfResult{Scored: 120, Included: 23}, db.WhatIfResult{Scored: 120, Included: 9})
+	require.Equal(t, "With relevance threshold <code>0.70</code>, the last 24h would have had <b>9</b> digest items instead of <b>23</b> (of 120 scored).", got)
+	require.Contains(t, formatThresholdImpact("relevance threshold", 0.7, db.WhatIfResult{}, db.WhatIfResult{}), "cannot be estimated")
+
+	got = formatWindowImpact(time.Hour, 6*time.Hour, 48)
+	require.Contains(t, got, "about <b>12</b> items per digest instead of <b>2</b>")
+
+	preview := formatConfigPreview(SettingDigestWindow, "1h0m0s", "6h", "impact", []string{"careful"})
+	require.Contains(t, preview, "<code>digest_window</code> 1h0m0s → 6h")
+	require.Contains(t, preview, "⚠️ careful\n")
+}
+
+func TestParseWhatIfArgs(t *testing.T) {
+	baseline := db.WhatIfThresholds{Relevance: 0.5, Importance: 0.3}
+
+	scenario, hours, err := parseWhatIfArgs([]string{"relevance=0.7", "weight=@News:1.5", "hours=48"}, baseline)
+	require.NoError(t, err)
+	require.Equal(t, 48, hours)
+	require.InDelta(t, 0.7, scenario.Relevance, 1e-6)
+	require.InDelta(t, 0.3, scenario.Importance, 1e-6)
+	require.Equal(t, map[string]float32{"news": 1.5}, scenario.ChannelWeights)
+	require.Nil(t, baseline.ChannelWeights)
+
+	_, _, err = parseWhatIfArgs([]string{"relevance"}, baseline)
+	require.ErrorIs(t, err, errInvalidWhatIfArg)
+
+	_, _, err = parseWhatIfArgs([]string{"hours=0"}, baseline)
+	require.ErrorIs(t, err, errInvalidWhatIfArg)
+
+	_, _, err = parseWhatIfArgs([]string{"relevance=2"}, baseline)
+	require.Error(t, err)
+}
+
+func TestFormatWhatIfSimulation(t *testing.T) {
+	sim := db.WhatIfSimulation{
+		Baseline: db.WhatIfResult{Scored: 10, Included: 4, Rated: 2, Noisy: 1},
+		Scenario: db.WhatIfResult{Scored: 10, Included: 3, Rated: 1},
+		Removed:  []db.WhatIfItem{{ChannelUsername: "news", Topic: "Markets", RelevanceScore: 0.6, ImportanceScore: 0.5, Rating: "bad"}},
+	}
+
+	got := formatWhatIfSimulation(24, db.WhatIfThresholds{Relevance: 0.5, Importance: 0.3},
+		db.WhatIfThresholds{Relevance: 0.65, Importance: 0.3, ChannelWeights: map[string]float32{"news": 1.5}}, sim)
+
+	require.Contains(t, got, "(last 24h, 10 scored items)")
+	require.Contains(t, got, "<b>Current</b> (relevance 0.50, importance 0.30): 4 items, noise 50% of 2 rated")
+	require.Contains(t, got, "<b>Scenario</b> (relevance 0.65, importance 0.30): 3 items, noise 0% of 1 rated")
+	require.Contains(t, got, "• weight <code>@news</code> = 1.50")
+	require.Contains(t, got, "➖ Removed (1):\n• Markets <i>@news</i> rel 0.60, imp 0.50 [bad]")
+	require.NotContains(t, got, "Added")
+}
+
+func TestFormatScoreVersions(t *testing.T) {
+	grid := db.ScoreQuantileGrid
+	shifted := make([]float64, len(grid))
+
+	for i, p := range grid {
+		shifted[i] = p*0.6 + 0.4
+	}
+
+	day := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	dists := []db.ScoreDistribution{
+		{Version: "old@v1", Count: 500, FirstSeen: day, LastSeen: day, Relevance: grid, Importance: grid},
+		{Version: "new@v2", Count: 300, FirstSeen: day, LastSeen: day, Relevance: shifted, Importance: grid},
+		{Version: "tiny@v3", Count: 5, FirstSeen: day, LastSeen: day, Relevance: grid, Importance: grid},
+	}
+
+	text := formatScoreVersions(dists, "", "new@v2", normalizeModeQuantile)
+
+	require.Contains(t, text, "Reference: <code>old@v1</code>")
+	require.Contains(t, text, "Normalization: <code>quantile</code>")
+	require.Contains(t, text, "📌 reference")
+	require.Contains(t, text, "⚠️ shift relevance 0.38")
+	require.Contains(t, text, "too few items to compare")
+	require.Less(t, strings.Index(text, "tiny@v3"), strings.Index(text, "<code>old@v1</code> —"))
+
+	require.Contains(t, formatScoreVersions(nil, "", "default@v1", normalizeModeOff), "No raw scores recorded yet.")
+}
+
+func TestApplyEnsembleArgs(t *testing.T) {
+	channels := []db.Channel{{ID: "ch-1", Username: "News"}, {ID: "ch-2", Username: "tech"}}
+	policy := db.DefaultEnsembleScoringPolicy()
+
+	steps := [][]string{
+		{"on"},
+		{"variants", "gpt-4o-mini,", "default@v2"},
+		{"max_disagreement", "0.25"},
+		{"route", "both"},
+		{"smart_model", "gpt-4o"},
+		{"channels", "@news"},
+	}
+
+	for _, args := range steps {
+		require.NoError(t, applyEnsembleArgs(&policy, args, channels), "args %v", args)
+	}
+
+	require.True(t, policy.Active())
+	require.Equal(t, []db.EnsembleVariant{{Model: "gpt-4o-mini"}, {Prompt: "v2"}}, policy.Variants)
+	require.InDelta(t, 0.25, policy.MaxDisagreement, 1e-6)
+	require.Equal(t, db.EnsembleRouteBoth, policy.Route)
+	require.Equal(t, "gpt-4o", policy.SmartModel)
+	require.Equal(t, []string{"ch-1"}, policy.Channels)
+
+	text := formatEnsemblePolicy(policy, channels)
+	require.Contains(t, text, "gpt-4o-mini, default@v2")
+	require.Contains(t, text, "@News")
+
+	require.NoError(t, applyEnsembleArgs(&policy, []string{"channels", "clear"}, channels))
+	require.Empty(t, policy.Channels)
+
+	for _, args := range [][]string{{"bogus", "1"}, {"route"}, {"route", "later"}, {"max_disagreement", "0"}, {"channels", "@missing"}, {"variants", "default"}} {
+		require.ErrorIs(t, applyEnsembleArgs(&policy, args, channels), errInvalidAutoPolicyValue, "args %v", args)
+	}
+}
+
+func TestPrefilterRuleHelpers(t *testing.T) {
+	rules := []string{"deny if keyword a", "allow if channel @b", "boost 0.1 if keyword c"}
+
+	require.Equal(t, []string{"boost 0.1 if keyword c", "deny if keyword a", "allow if channel @b"}, moveRule(rules, 2, 0))
+	require.Equal(t, []string{"allow if channel @b", "boost 0.1 if keyword c", "deny if keyword a"}, moveRule(rules, 0, 2))
+	require.Equal(t, "deny if keyword a", rules[0], "moveRule must not modify its input")
+
+	idx, err := parseRuleNumber([]string{"2"}, rules)
+	require.NoError(t, err)
+	require.Equal(t, 1, idx)
+
+	for _, args := range [][]string{nil, {"0"}, {"4"}, {"x"}} {
+		_, err := parseRuleNumber(args, rules)
+		require.ErrorIs(t, err, errRuleIndex)
+	}
+
+	hits := map[string]db.PrefilterRuleHit{"deny if keyword a": {Hits: 7, LastHitAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)}}
+	text := formatPrefilterRules(append(rules, "drop if x"), hits)
+	require.Contains(t, text, "1. <code>deny if keyword a</code> — 7 hits")
+	require.Contains(t, text, "4. <code>drop if x</code> ⚠️ invalid")
+}
+
+func TestApplyStaleArgs(t *testing.T) {
+	policy := db.DefaultStaleItemPolicy()
+
+	for _, args := range [][]string{{"section"}, {"misses", "3"}, {"lookback", "48h"}} {
+		require.NoError(t, applyStaleArgs(&policy, args), "args %v", args)
+	}
+
+	require.Equal(t, db.StaleItemPolicy{Mode: db.StaleItemsSection, MaxMissed: 3, LookbackHours: 48}, policy)
+	require.Contains(t, formatStalePolicy(policy), "Previously missed")
+
+	require.Error(t, applyStaleArgs(&policy, []string{"misses", "0"}))
+	require.Error(t, applyStaleArgs(&policy, []string{"lookback"}))
+	require.Error(t, applyStaleArgs(&policy, []string{"drop"}))
+
+	hours, floor, err := parseFreshnessDecayArgs([]string{"24h", "0.5"})
+	require.NoError(t, err)
+	require.Equal(t, 24, hours)
+	require.InDelta(t, 0.5, *floor, 1e-6)
+
+	hours, floor, err = parseFreshnessDecayArgs([]string{"off"})
+	require.NoError(t, err)
+	require.Zero(t, hours)
+	require.Nil(t, floor)
+
+	_, _, err = parseFreshnessDecayArgs([]string{"12", "2"})
+	require.Error(t, err)
+}
+
+func TestApplyDiversityArgs(t *testing.T) {
+	var caps db.DiversityCaps
+
+	require.NoError(t, applyDiversityArgs(&caps, []string{"channel", "3"}))
+	require.NoError(t, applyDiversityArgs(&caps, []string{"topic", "2"}))
+	require.Equal(t, db.DiversityCaps{MaxPerChannel: 3, MaxPerTopic: 2}, caps)
+
+	require.NoError(t, applyDiversityArgs(&caps, []string{"channel", "off"}))
+	require.Equal(t, db.DiversityCaps{MaxPerTopic: 2}, caps)
+	require.Contains(t, formatDiversityCaps(caps), "no cap")
+
+	require.Error(t, applyDiversityArgs(&caps, []string{"channel", "0"}))
+	require.Error(t, applyDiversityArgs(&caps, []string{"source", "2"}))
+
+	require.NoError(t, applyDiversityArgs(&caps, []string{"off"}))
+	require.False(t, caps.Active())
+}
+
+func TestParseMMRLambda(t *testing.T) {
+	lambda, err := parseMMRLambda("0.7")
+	require.NoError(t, err)
+	require.InDelta(t, 0.7, lambda, 1e-6)
+
+	lambda, err = parseMMRLambda("off")
+	require.NoError(t, err)
+	require.Zero(t, lambda)
+	require.Contains(t, formatMMRLambda(lambda), "off")
+
+	for _, arg := range []string{"0", "1", "1.5", "high"} {
+		_, err := parseMMRLambda(arg)
+		require.Error(t, err, arg)
+	}
+}
+
+func TestParseSetupCallbackData(t *testing.T) {
+	step, arg, ok := parseSetupCallbackData("setup:tone:casual")
+	require.True(t, ok)
+	require.Equal(t, setupStepTone, step)
+	require.Equal(t, "casual", arg)
+
+	for _, data := range []string{"setup:tone", "setup:unknown:next", "setup:tone:"} {
+		_, _, ok := parseSetupCallbackData(data)
+		require.False(t, ok, data)
+	}
+}
+
+func TestNextSetupStep(t *testing.T) {
+	require.Equal(t, setupStepChannels, nextSetupStep(setupStepTarget))
+	require.Equal(t, setupStepTest, nextSetupStep(setupStepSchedule))
+	require.Empty(t, nextSetupStep(setupStepTest))
+	require.Empty(t, nextSetupStep("unknown"))
+}
+
+func TestSetupStepMessage(t *testing.T) {
+	status := setupStatus{values: map[string]string{setupStepTone: "casual"}}
+
+	text, markup := setupStepMessage(setupStepTone, status)
+	require.Contains(t, text, "Step 4/6")
+	require.Len(t, markup.InlineKeyboard, 2)
+	require.Equal(t, "• casual •", markup.InlineKeyboard[0][1].Text)
+	require.Equal(t, "setup:tone:casual", *markup.InlineKeyboard[0][1].CallbackData)
+
+	for _, row := range markup.InlineKeyboard {
+		for _, button := range row {
+			require.LessOrEqual(t, len(*button.CallbackData), maxCallbackDataLen)
+		}
+	}
+
+	text, markup = setupStepMessage(setupStepChannels, setupStatus{channels: []db.Channel{{Username: "news"}, {Username: "live", TGPeerID: 1}}})
+	require.Contains(t, text, "⏳ @news")
+	require.Contains(t, text, "✅ @live")
+	require.Equal(t, "setup:channels:check", *markup.InlineKeyboard[0][0].CallbackData)
+
+	_, markup = setupStepMessage(setupStepTest, setupStatus{})
+	require.Equal(t, "✅ Finish", markup.InlineKeyboard[1][0].Text)
+}
+
+func TestCommandMenuMatchesRegistry(t *testing.T) {
+	registry := (&Bot{}).newCommandRegistry()
+	valid := regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+	seen := make(map[string]bool)
+
+	for _, cmd := range commandMenu {
+		require.Regexp(t, valid, cmd.Command)
+		require.NotEmpty(t, cmd.Description, cmd.Command)
+		require.LessOrEqual(t, len(cmd.Description), 256, cmd.Command)
+		require.False(t, seen[cmd.Command], "duplicate menu command %s", cmd.Command)
+		require.Contains(t, registry.handlers, cmd.Command, "menu command %s has no handler", cmd.Command)
+
+		seen[cmd.Command] = true
+	}
+
+	require.LessOrEqual(t, len(commandMenu), 100)
+}
+
+func TestParseChannelListArgs(t *testing.T) {
+	q, err := parseChannelListArgs("state=healthy weight=0.5-1.5 noise=-0.4 sort=noise compact page=2")
+	require.NoError(t, err)
+	require.Equal(t, db.ChannelListFilter{
+		HealthStatus: db.ChannelHealthHealthy,
+		MinWeight:    0.5,
+		MaxWeight:    1.5,
+		MaxNoise:     0.4,
+		Sort:         db.ChannelSortNoise,
+	}, q.filter)
+	require.True(t, q.compact)
+	require.Equal(t, 1, q.page)
+	require.Equal(t, "state=healthy weight=0.5-1.5 noise=-0.4 sort=noise", formatChannelListFilter(q.filter))
+
+	q, err = parseChannelListArgs("")
+	require.NoError(t, err)
+	require.False(t, q.filtered())
+
+	for _, args := range []string{"state=alive", "weight=2-1", "sort=size", "page=0", "verbose", "noise="} {
+		_, err := parseChannelListArgs(args)
+		require.Error(t, err, args)
+	}
+}
+
+func TestChannelListCallbackData(t *testing.T) {
+	q, err := parseChannelListArgs("state=renamed weight=0.25-1.75 noise=0.05-0.95 sort=weight compact page=12")
+	require.NoError(t, err)
+
+	data, ok := channelListCallbackData(q)
+	require.True(t, ok)
+	require.LessOrEqual(t, len(data), maxCallbackDataLen)
+
+	parsed, ok := parseChannelListCallbackData(data)
+	require.True(t, ok)
+	require.Equal(t, q, parsed)
+
+	_, ok = parseChannelListCallbackData("chl:1:0:name")
+	require.False(t, ok)
+}
+
+func TestChannelListKeyboard(t *testing.T) {
+	q := channelListQuery{filter: db.ChannelListFilter{Sort: db.ChannelSortName}, page: 1}
+
+	markup := channelListKeyboard(q, 25)
+	require.NotNil(t, markup)
+	require.Len(t, markup.InlineKeyboard, 2)
+	require.Equal(t, "◀ Prev", markup.InlineKeyboard[0][0].Text)
+	require.Equal(t, "Next ▶", markup.InlineKeyboard[0][1].Text)
+	require.Equal(t, "🗜 Compact", markup.InlineKeyboard[1][0].Text)
+
+	require.Nil(t, channelListKeyboard(channelListQuery{}, 0))
+
+	text := formatChannelListPage(channelListQuery{compact: true}, []db.ChannelListEntry{
+		{Channel: db.Channel{Username: "news", ImportanceWeight: 1.2}, NoiseRate: 0.25, HasNoiseRate: true},
+		{Channel: db.Channel{Title: "Quiet", ImportanceWeight: 1}},
+	}, 2)
+	require.Contains(t, text, "@news · <code>1.2x</code> · noise <code>25%</code>")
+	require.Contains(t, text, "Quiet · <code>1.0x</code> · noise <code>n/a</code>")
+}
+
+func TestChannelRecordsRoundTrip(t *testing.T) {
+	records := []channelRecord{
+		{Username: "news", PeerID: 100, Title: "News, daily", Context: "Local \"news\"", Category: "politics",
+			RelevanceThreshold: 0.4, ImportanceWeight: 1.5, WeightOverride: true, RelevanceDelta: -0.1},
+		{InviteLink: "https://t.me/+abc", ImportanceWeight: 1},
+	}
+
+	for _, format := range []string{channelFormatJSON, channelFormatCSV} {
+		data, err := encodeChannelRecords(records, format)
+		require.NoError(t, err)
+
+		rows, err := decodeChannelRecords("channels."+format, data)
+		require.NoError(t, err, format)
+		require.Len(t, rows, 2)
+
+		for i, row := range rows {
+			require.NoError(t, row.err)
+			require.Equal(t, records[i], row.record, format)
+		}
+	}
+
+	_, err := decodeChannelRecords("channels.csv", []byte("username,colour\nnews,red\n"))
+	require.ErrorIs(t, err, errChannelImportFormat)
+
+	_, err = decodeChannelRecords("upload", []byte(`[{"username":"news","unknown":1}]`))
+	require.ErrorIs(t, err, errChannelImportFormat)
+}
+
+func TestPlanChannelImport(t *testing.T) {
+	existing := []db.Channel{
+		{Username: "news", TGPeerID: 100, ImportanceWeight: 1},
+		{Username: "tech", ImportanceWeight: 1.2, Category: "tech"},
+	}
+
+	rows, err := decodeChannelRecords("import.csv", []byte(
+		"username,peer_id,category,importance_weight\n"+
+			",100,,1\n"+
+			"tech,,tech,1.5\n"+
+			"fresh,,,\n"+
+			"fresh,,,\n"+
+			"x,,,\n"+
+			"other,,,9\n"+
+			"bad,abc,,\n"))
+	require.NoError(t, err)
+
+	plan := planChannelImport(existing, rows)
+
+	require.Equal(t, 1, plan.unchanged)
+	require.Len(t, plan.updated, 1)
+	require.Equal(t, "@tech", plan.updated[0].record.display())
+	require.Equal(t, []string{"importance_weight: 1.2 → 1.5"}, plan.updated[0].changes)
+	require.Len(t, plan.added, 1)
+	require.Equal(t, "fresh", plan.added[0].Username)
+	require.InDelta(t, db.DefaultImportanceWeight, plan.added[0].ImportanceWeight, 0.001)
+	require.Len(t, plan.invalid, 4)
+	require.Contains(t, plan.invalid[0], "line 5: ")
+	require.Contains(t, plan.invalid[0], "duplicate")
+	require.Contains(t, plan.invalid[3], "peer_id")
+
+	text := formatChannelImportPlan(t.Context(), plan, "import.csv")
+	require.Contains(t, text, "➕ <b>Add (1):</b> @fresh")
+	require.Contains(t, text, "/channel import apply")
+}
+
+func TestFormatChannelGroupStats(t *testing.T) {
+	ctx := t.Context()
+
+	require.Empty(t, formatChannelGroupStats(ctx, nil))
+	require.Empty(t, formatChannelGroupStats(ctx, []db.ChannelGroupStats{{Channels: 5}}))
+
+	text := formatChannelGroupStats(ctx, []db.ChannelGroupStats{
+		{GroupID: "g1", Name: "AI", Channels: 3, ItemsCreated: 40, ItemsDigested: 10, ConversionRate: 0.25, AvgImportance: 0.5},
+		{Channels: 2, ItemsCreated: 8},
+	})
+	require.Contains(t, text, "<b>AI</b> · 3 channels · 40 items · 10 digested (25%) · avg importance <code>0.50</code>")
+	require.Contains(t, text, "<i>ungrouped</i>")
+}
+
+func TestChannelGroupHelpers(t *testing.T) {
+	v, err := parseGroupThreshold("0.45")
+	require.NoError(t, err)
+	require.InDelta(t, 0.45, v, 0.0001)
+
+	_, err = parseGroupThreshold("1.5")
+	require.Error(t, err)
+
+	require.True(t, channelGroupNamePattern.MatchString("Україна"))
+	require.False(t, channelGroupNamePattern.MatchString("two words"))
+	require.Equal(t, "24h", groupDigestWindowLabel(""))
+
+	text := formatChannelGroups(t.Context(), []db.ChannelGroup{
+		{Name: "Local", ImportanceThreshold: 0.4, ChannelCount: 2},
+		{Name: "AI", TargetChatID: -100, DigestWindow: "6h"},
+	})
+	require.Contains(t, text, "relevance <code>global</code>, importance <code>0.4</code>")
+	require.Contains(t, text, "Digest: main")
+	require.Contains(t, text, "Digest: <code>-100</code> every <code>6h</code>")
+}
+
+func TestChannelSnoozeHelpers(t *testing.T) {
+	d, err := parseSnoozeDuration("3d")
+	require.NoError(t, err)
+	require.Equal(t, 72*time.Hour, d)
+
+	d, err = parseSnoozeDuration("90m")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Minute, d)
+
+	for _, arg := range []string{"0h", "-1h", "31d", "soon"} {
+		_, err = parseSnoozeDuration(arg)
+		require.Error(t, err, arg)
+	}
+
+	until := time.Date(2026, 3, 4, 18, 30, 0, 0, time.UTC)
+	require.Empty(t, formatChannelSnooze(time.Time{}, true))
+	require.Equal(t, "😴 snoozed (ingestion) until 2026-03-04 18:30 UTC", formatChannelSnooze(until, true))
+
+	entries := []db.ChannelListEntry{{Channel: db.Channel{Username: "loud", ImportanceWeight: 1}, SnoozedUntil: until}}
+	require.Contains(t, formatChannelListPage(channelListQuery{}, entries, 1), "😴 snoozed (digest) until 2026-03-04 18:30 UTC")
+	require.Contains(t, formatChannelListPage(channelListQuery{compact: true}, entries, 1), "noise <code>n/a</code> · 😴")
+}
+
+func TestChannelTrialHelpers(t *testing.T) {
+	for arg, want := range map[string]int{"7": 7, "14d": 14, "off": 0} {
+		days, err := parseTrialDays(arg)
+		require.NoError(t, err, arg)
+		require.Equal(t, want, days, arg)
+	}
+
+	_, err := parseTrialDays("91")
+	require.Error(t, err)
+	require.Equal(t, "off", formatTrialDays(0))
+
+	id, promote, ok := parseChannelTrialCallbackData(CallbackPrefixTrial + trialActionReject + ":c1")
+	require.True(t, ok)
+	require.False(t, promote)
+	require.Equal(t, "c1", id)
+
+	_, _, ok = parseChannelTrialCallbackData(CallbackPrefixTrial + "maybe:c1")
+	require.False(t, ok)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+	text := formatChannelTrials(t.Context(), []db.ChannelTrial{
+		{Username: "fresh", Until: now.Add(time.Hour)},
+		{Title: "Done", Until: now.Add(-time.Hour)},
+	}, now)
+	require.Contains(t, text, "@fresh · until <code>2026-03-05 13:00 UTC</code>")
+	require.Contains(t, text, "Done · ended, awaiting promote/reject")
+
+	report := db.ChannelTrialReport{
+		ChannelTrial: db.ChannelTrial{Username: "fresh", StartedAt: now.AddDate(0, 0, -7), Until: now},
+		Messages:     20, Items: 8, Eligible: 5,
+		Samples: []db.ChannelTrialSample{{Summary: "Launch <b>announced</b>", ImportanceScore: 0.81, TGMessageID: 42}},
+	}
+	text = formatChannelTrialReport(t.Context(), report)
+	require.Contains(t, text, "Messages: 20 · Relevant items: 8 · Would reach digests: 5")
+	require.Contains(t, text, "Projected noise rate: <b>75%</b>")
+	require.Contains(t, text, `<a href="https://t.me/fresh/42">0.81</a> Launch &lt;b&gt;announced&lt;/b&gt;`)
+}
+
+func TestFormatQualityTrend(t *testing.T) {
+	require.Contains(t, formatQualityTrend(30, nil, nil), "No evaluations yet.")
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	text := formatQualityTrend(30, []db.QualityEvaluation{
+		{EvaluatedAt: day, Relevance: 0.5, Importance: 0.3, Rated: 20, Precision: 0.6, Recall: 0.5, NoiseRate: 0.4},
+		{
+			EvaluatedAt: day.AddDate(0, 0, 1), Relevance: 0.55, Importance: 0.3, Rated: 25, Precision: 0.75, Recall: 0.45, NoiseRate: 0.25,
+			Events: []db.ModelPromptEvent{{Kind: db.ModelPromptEventPrompt, Target: "summarize", NewValue: "v7"}},
+		},
+	}, []db.ModelPromptEvent{{Kind: db.ModelPromptEventModel, Target: "summarize", NewValue: "gpt-4o"}})
+
+	require.Contains(t, text, "Thresholds: relevance 0.55, importance 0.30")
+	require.Contains(t, text, "• <code>2026-03-02</code> precision <code>0.75</code> · recall <code>0.45</code> · noise <code>0.25</code> (25 rated)")
+	require.Contains(t, text, "Since 2026-03-01: precision +0.15, recall -0.05, noise -0.15")
+	require.Contains(t, text, "↳ after <code>prompt summarize:v7</code>: precision +0.15, recall -0.05, noise -0.15")
+	require.Contains(t, text, "Not yet evaluated: <code>model summarize:gpt-4o</code>")
+	require.Less(t, strings.Index(text, "2026-03-02"), strings.Index(text, "<code>2026-03-01</code>"))
+}
+
+func TestFormatSummaryRefinementStats(t *testing.T) {
+	require.Equal(t, "No items refined yet.\n", formatSummaryRefinementStats(db.SummaryRefinementStats{Drafted: 50}))
+
+	text := formatSummaryRefinementStats(db.SummaryRefinementStats{Drafted: 100, Refined: 20, DraftCostUSD: 0.1, RefineCostUSD: 0.4})
+	require.Contains(t, text, "Refined: <code>20</code> of <code>100</code> drafted items")
+	require.Contains(t, text, "(<code>$0.02000</code>/item)")
+	require.Contains(t, text, "Est. savings vs refine model only: <code>$1.5000</code>")
+}
+
+func TestFormatPreviewProgress(t *testing.T) {
+	ctx := context.Background()
+
+	require.Equal(t, "⏳ <b>Building digest preview…</b>\n<i>clustering…</i> (0s)", formatPreviewProgress(ctx, digest.ProgressClustering, 0, 0, 0))
+	require.Contains(t, formatPreviewProgress(ctx, digest.ProgressSummaries, 3, 7, 12*time.Second), "<i>summaries 3/7…</i> (12s)")
+	require.Contains(t, formatPreviewProgress(withLanguage(ctx, "ru"), digest.ProgressSummaries, 3, 7, 0), "<i>сводки 3/7…</i>")
+}
+
+func TestPreviewRunsCancel(t *testing.T) {
+	var runs previewRuns
+
+	key := previewKey{chatID: 1, msgID: 10}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer cancel()
+
+	runs.start(key, cancel)
+	require.False(t, runs.cancel(previewKey{chatID: 1, msgID: 11}))
+	require.True(t, runs.cancel(key))
+	require.Error(t, ctx.Err())
+	require.False(t, runs.cancel(key), "a preview is cancelled once")
+
+	runs.start(key, func() { t.Fatal("finished preview cancelled") })
+	runs.finish(key)
+	require.False(t, runs.cancel(key))
+}
+
+func TestFormatCoverSettings(t *testing.T) {
+	require.Equal(t, "🎨 <b>AI Cover</b>\nStyle: <code>editorial</code>\nPrompt: <i>built-in</i>\nLocal cover: <code>off</code>", formatCoverSettings("", "", false))
+	require.Contains(t, formatCoverSettings("collage", "News of {{DATE}} <b>", false), "Prompt template: <code>News of {{DATE}} &lt;b&gt;</code>")
+	require.Contains(t, formatCoverSettings("flat", "", true), "Local cover: <code>on</code>")
+}
@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// CmdTierSort is the /config subcommand for ordering items within digest tiers.
+const CmdTierSort = "tier_sort"
+
+const tierSortUsage = "Usage: <code>/config tier_sort &lt;importance|corroboration&gt;</code>\n\n" +
+	"Orders the stories within each importance tier. <code>corroboration</code> puts stories " +
+	"reported by the most distinct channels first, with importance breaking ties."
+
+func (b *Bot) handleTierSort(ctx context.Context, msg *tgbotapi.Message) {
+	arg := strings.ToLower(strings.TrimSpace(msg.CommandArguments()))
+
+	if arg == "" {
+		mode := digest.TierSortImportance
+		if err := b.database.GetSetting(ctx, digest.SettingDigestTierSort, &mode); err != nil {
+			b.logger.Debug().Err(err).Msg("could not get digest_tier_sort")
+		}
+
+		b.reply(msg, fmt.Sprintf("Digest tiers are sorted by <b>%s</b>.\n\n%s", html.EscapeString(mode), tierSortUsage))
+
+		return
+	}
+
+	if arg != digest.TierSortImportance && arg != digest.TierSortCorroboration {
+		b.reply(msg, tierSortUsage)
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestTierSort, arg, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrSavingFmt, digest.SettingDigestTierSort, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Digest tiers are now sorted by <b>%s</b>.", arg))
+}
@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+const (
+	// CmdVerbosity is the /config subcommand for digest verbosity.
+	CmdVerbosity = "verbosity"
+
+	// verbosityInherit clears a per-target override.
+	verbosityInherit = "default"
+)
+
+func (b *Bot) handleVerbosity(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 || len(args) > 2 {
+		b.replyVerbosityUsage(msg)
+
+		return
+	}
+
+	mode := strings.ToLower(args[0])
+	if len(args) == 1 {
+		b.setGlobalVerbosity(ctx, msg, mode)
+
+		return
+	}
+
+	b.setTargetVerbosity(ctx, msg, mode, args[1])
+}
+
+func (b *Bot) replyVerbosityUsage(msg *tgbotapi.Message) {
+	b.reply(msg, "Usage: <code>/config verbosity &lt;compact|standard|detailed&gt; [chat_id|@channel]</code>\n\n"+
+		"Without a target, sets the default for all targets. "+
+		"Use <code>/config verbosity default &lt;target&gt;</code> to remove a target override.")
+}
+
+func (b *Bot) setGlobalVerbosity(ctx context.Context, msg *tgbotapi.Message, mode string) {
+	if !digest.IsValidVerbosity(mode) {
+		b.replyVerbosityUsage(msg)
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, digest.SettingDigestVerbosity, mode, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Digest verbosity set to <code>%s</code>.", mode))
+}
+
+func (b *Bot) setTargetVerbosity(ctx context.Context, msg *tgbotapi.Message, mode, target string) {
+	if mode != verbosityInherit && !digest.IsValidVerbosity(mode) {
+		b.replyVerbosityUsage(msg)
+
+		return
+	}
+
+	chatID, chat, errMsg := b.resolveTargetChat(ctx, target)
+	if errMsg != "" {
+		b.reply(msg, errMsg)
+
+		return
+	}
+
+	key := fmt.Sprintf(digest.VerbosityTargetKeyFmt, chatID)
+
+	if mode == verbosityInherit {
+		if err := b.database.DeleteSettingWithHistory(ctx, key, msg.From.ID); err != nil {
+			b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf("✅ <b>%s</b> now uses the default digest verbosity.", html.EscapeString(chat.Title)))
+
+		return
+	}
+
+	if err := b.database.SaveSettingWithHistory(ctx, key, mode, msg.From.ID); err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Digest verbosity for <b>%s</b> set to <code>%s</code>.", html.EscapeString(chat.Title), mode))
+}
@@ -0,0 +1,207 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdWatch manages saved searches that notify admins about new matches.
+	CmdWatch = "watch"
+
+	watchFilterChannel = "channel:"
+	watchFilterTopic   = "topic:"
+)
+
+var (
+	errEmptyWatchQuery   = errors.New("watch query is empty")
+	errUnterminatedQuote = errors.New("unterminated quote")
+)
+
+// watchSpec is a parsed /watch add request.
+type watchSpec struct {
+	Query   string
+	Channel string
+	Topic   string
+}
+
+// parseWatchSpec parses `"query words" channel:@name topic:"Some topic"`.
+// Unprefixed tokens form the query; quotes keep multi-word values together.
+func parseWatchSpec(args string) (watchSpec, error) {
+	tokens, err := splitQuoted(args)
+	if err != nil {
+		return watchSpec{}, err
+	}
+
+	var (
+		spec  watchSpec
+		words []string
+	)
+
+	for _, tok := range tokens {
+		lower := strings.ToLower(tok)
+
+		switch {
+		case strings.HasPrefix(lower, watchFilterChannel):
+			spec.Channel = strings.TrimPrefix(tok[len(watchFilterChannel):], "@")
+		case strings.HasPrefix(lower, watchFilterTopic):
+			spec.Topic = tok[len(watchFilterTopic):]
+		default:
+			words = append(words, tok)
+		}
+	}
+
+	spec.Query = strings.Join(words, " ")
+	if spec.Query == "" {
+		return watchSpec{}, errEmptyWatchQuery
+	}
+
+	return spec, nil
+}
+
+// splitQuoted splits s on whitespace, keeping double-quoted sections (including
+// Telegram's curly quotes) together and dropping the quote characters.
+func splitQuoted(s string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		inQuote bool
+		hasTok  bool
+	)
+
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			inQuote = !inQuote
+			hasTok = true
+		case unicode.IsSpace(r) && !inQuote:
+			if hasTok {
+				tokens = append(tokens, current.String())
+				current.Reset()
+
+				hasTok = false
+			}
+		default:
+			current.WriteRune(r)
+
+			hasTok = true
+		}
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("%w: %s", errUnterminatedQuote, s)
+	}
+
+	if hasTok {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+func (b *Bot) handleWatch(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.TrimSpace(msg.CommandArguments())
+	sub, rest, _ := strings.Cut(args, " ")
+
+	switch strings.ToLower(sub) {
+	case "add":
+		b.handleWatchAdd(ctx, msg, strings.TrimSpace(rest))
+	case "list", "":
+		b.handleWatchList(ctx, msg)
+	case "remove", "rm":
+		b.handleWatchRemove(ctx, msg, strings.TrimSpace(rest))
+	default:
+		b.replyWatchUsage(msg)
+	}
+}
+
+func (b *Bot) replyWatchUsage(msg *tgbotapi.Message) {
+	b.reply(msg, "🔔 <b>Saved Searches</b>\n"+
+		"• <code>/watch add \"query\" [channel:@name] [topic:\"Topic\"]</code>\n"+
+		"• <code>/watch list</code>\n"+
+		"• <code>/watch remove &lt;id&gt;</code>\n\n"+
+		"Watches run with every scheduler tick and notify admins about new matching items.")
+}
+
+func (b *Bot) handleWatchAdd(ctx context.Context, msg *tgbotapi.Message, args string) {
+	spec, err := parseWatchSpec(args)
+	if err != nil {
+		b.replyWatchUsage(msg)
+
+		return
+	}
+
+	id, err := b.database.CreateSearchWatch(ctx, spec.Query, spec.Channel, spec.Topic, msg.From.ID)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	w := db.SearchWatch{ID: id, Query: spec.Query, Channel: spec.Channel, Topic: spec.Topic}
+	b.reply(msg, fmt.Sprintf("✅ Watch <b>#%d</b> saved: %s", id, digest.FormatSearchWatchFilters(w)))
+}
+
+func (b *Bot) handleWatchList(ctx context.Context, msg *tgbotapi.Message) {
+	watches, err := b.database.ListSearchWatches(ctx)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(watches) == 0 {
+		b.reply(msg, "No saved searches. Add one with <code>/watch add \"query\"</code>.")
+
+		return
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("🔔 <b>Saved Searches</b>\n\n")
+
+	for _, w := range watches {
+		lastMatch := "never"
+		if w.LastMatchAt != nil {
+			lastMatch = w.LastMatchAt.Format(time.DateTime)
+		}
+
+		sb.WriteString(fmt.Sprintf("<b>#%d</b> %s\n<i>last match: %s</i>\n", w.ID, digest.FormatSearchWatchFilters(w), lastMatch))
+	}
+
+	b.reply(msg, sb.String())
+}
+
+func (b *Bot) handleWatchRemove(ctx context.Context, msg *tgbotapi.Message, arg string) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		b.reply(msg, "Usage: <code>/watch remove &lt;id&gt;</code>")
+
+		return
+	}
+
+	if err := b.database.DeleteSearchWatch(ctx, id); err != nil {
+		if errors.Is(err, db.ErrSearchWatchNotFound) {
+			b.reply(msg, fmt.Sprintf("❌ Watch #%d not found.", id))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Watch #%d removed.", id))
+}
@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdWatchlist manages people and organizations whose mentions trigger alerts.
+	CmdWatchlist = "watchlist"
+
+	watchlistFilterAlias = "alias:"
+	watchlistFilterType  = "type:"
+
+	watchlistTypePerson = "PERSON"
+	watchlistTypeOrg    = "ORG"
+)
+
+var (
+	errEmptyWatchlistName   = errors.New("watchlist name is empty")
+	errInvalidWatchlistType = errors.New("watchlist type must be person or org")
+)
+
+// watchlistSpec is a parsed /watchlist add request.
+type watchlistSpec struct {
+	Name       string
+	Aliases    []string
+	EntityType string
+}
+
+// parseWatchlistSpec parses `"Full Name" alias:"Other Name" type:person`.
+// Unprefixed tokens form the name; alias: may be repeated.
+func parseWatchlistSpec(args string) (watchlistSpec, error) {
+	tokens, err := splitQuoted(args)
+	if err != nil {
+		return watchlistSpec{}, err
+	}
+
+	var (
+		spec  watchlistSpec
+		words []string
+	)
+
+	for _, tok := range tokens {
+		lower := strings.ToLower(tok)
+
+		switch {
+		case strings.HasPrefix(lower, watchlistFilterAlias):
+			if alias := strings.TrimSpace(tok[len(watchlistFilterAlias):]); alias != "" {
+				spec.Aliases = append(spec.Aliases, alias)
+			}
+		case strings.HasPrefix(lower, watchlistFilterType):
+			switch lower[len(watchlistFilterType):] {
+			case "person":
+				spec.EntityType = watchlistTypePerson
+			case "org":
+				spec.EntityType = watchlistTypeOrg
+			default:
+				return watchlistSpec{}, fmt.Errorf("%w: %s", errInvalidWatchlistType, tok)
+			}
+		default:
+			words = append(words, tok)
+		}
+	}
+
+	spec.Name = strings.Join(words, " ")
+	if spec.Name == "" {
+		return watchlistSpec{}, errEmptyWatchlistName
+	}
+
+	return spec, nil
+}
+
+func (b *Bot) handleWatchlist(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.TrimSpace(msg.CommandArguments())
+	sub, rest, _ := strings.Cut(args, " ")
+
+	switch strings.ToLower(sub) {
+	case "add":
+		b.handleWatchlistAdd(ctx, msg, strings.TrimSpace(rest))
+	case "list", "":
+		b.handleWatchlistList(ctx, msg)
+	case "remove", "rm":
+		b.handleWatchlistRemove(ctx, msg, strings.TrimSpace(rest))
+	default:
+		b.replyWatchlistUsage(msg)
+	}
+}
+
+func (b *Bot) replyWatchlistUsage(msg *tgbotapi.Message) {
+	b.reply(msg, "👤 <b>Watchlist</b>\n"+
+		"• <code>/watchlist add \"Name\" [alias:\"Other name\"] [type:person|org]</code>\n"+
+		"• <code>/watchlist list</code>\n"+
+		"• <code>/watchlist remove &lt;id&gt;</code>\n\n"+
+		"Admins are alerted when mentions exceed the volume or importance threshold. "+
+		"Requires the <code>entities</code> enrichment extract scope.")
+}
+
+func (b *Bot) handleWatchlistAdd(ctx context.Context, msg *tgbotapi.Message, args string) {
+	spec, err := parseWatchlistSpec(args)
+	if err != nil {
+		b.replyWatchlistUsage(msg)
+
+		return
+	}
+
+	id, err := b.database.CreateWatchlistEntity(ctx, spec.Name, spec.Aliases, spec.EntityType, msg.From.ID)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	e := db.WatchlistEntity{ID: id, Name: spec.Name, Aliases: spec.Aliases, EntityType: spec.EntityType}
+	b.reply(msg, fmt.Sprintf("✅ Watchlist <b>#%d</b> saved: %s", id, digest.FormatWatchlistEntity(e)))
+}
+
+func (b *Bot) handleWatchlistList(ctx context.Context, msg *tgbotapi.Message) {
+	entities, err := b.database.ListWatchlistEntities(ctx)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	if len(entities) == 0 {
+		b.reply(msg, "The watchlist is empty. Add someone with <code>/watchlist add \"Name\"</code>.")
+
+		return
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("👤 <b>Watchlist</b>\n\n")
+
+	for _, e := range entities {
+		lastAlert := "never"
+		if e.LastAlertAt != nil {
+			lastAlert = e.LastAlertAt.Format(time.DateTime)
+		}
+
+		sb.WriteString(fmt.Sprintf("<b>#%d</b> %s\n<i>last alert: %s</i>\n", e.ID, digest.FormatWatchlistEntity(e), lastAlert))
+	}
+
+	b.reply(msg, sb.String())
+}
+
+func (b *Bot) handleWatchlistRemove(ctx context.Context, msg *tgbotapi.Message, arg string) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		b.reply(msg, "Usage: <code>/watchlist remove &lt;id&gt;</code>")
+
+		return
+	}
+
+	if err := b.database.DeleteWatchlistEntity(ctx, id); err != nil {
+		if errors.Is(err, db.ErrWatchlistEntityNotFound) {
+			b.reply(msg, fmt.Sprintf("❌ Watchlist entry #%d not found.", id))
+
+			return
+		}
+
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, fmt.Sprintf("✅ Watchlist entry #%d removed.", id))
+}
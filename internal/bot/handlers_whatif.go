@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/research"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CmdWhatIf simulates digest inclusion with hypothetical thresholds and channel weights.
+	CmdWhatIf = "whatif"
+
+	whatIfParamHours    = "hours"
+	whatIfDefaultHours  = 24
+	whatIfMaxHours      = 24 * 30
+	whatIfChangesShown  = 5
+	whatIfTopicMaxRunes = 60
+)
+
+const whatIfUsage = "Usage: <code>/whatif [relevance=&lt;0-1&gt;] [importance=&lt;0-1&gt;] [weight=&lt;channel&gt;:&lt;0.1-2&gt;]... [hours=&lt;n&gt;]</code>\n\n" +
+	"Replays the relevance and importance filters over recent items with the given values and compares them with the current settings.\n" +
+	"Example: <code>/whatif relevance=0.7 weight=@news:1.5</code>"
+
+var errInvalidWhatIfArg = errors.New("expected key=value")
+
+// parseWhatIfArgs applies `key=value` arguments to baseline and returns the
+// scenario and the lookback in hours.
+func parseWhatIfArgs(args []string, baseline db.WhatIfThresholds) (db.WhatIfThresholds, int, error) {
+	scenario := baseline
+	hours := whatIfDefaultHours
+
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || value == "" {
+			return scenario, 0, fmt.Errorf("%w: %s", errInvalidWhatIfArg, arg)
+		}
+
+		key = strings.ToLower(key)
+
+		if key == whatIfParamHours {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > whatIfMaxHours {
+				return scenario, 0, fmt.Errorf("%w: %s", errInvalidWhatIfArg, arg)
+			}
+
+			hours = n
+
+			continue
+		}
+
+		if err := research.ApplyWhatIfParam(&scenario, key, value); err != nil {
+			return scenario, 0, fmt.Errorf("parse what-if argument: %w", err)
+		}
+	}
+
+	return scenario, hours, nil
+}
+
+func (b *Bot) handleWhatIf(ctx context.Context, msg *tgbotapi.Message) {
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		b.reply(msg, whatIfUsage)
+
+		return
+	}
+
+	baseline := b.currentThresholds(ctx)
+
+	scenario, hours, err := parseWhatIfArgs(args, baseline)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf("❌ %s\n\n%s", html.EscapeString(err.Error()), whatIfUsage))
+
+		return
+	}
+
+	now := time.Now()
+
+	items, err := b.database.GetWhatIfItems(ctx, now.Add(-time.Duration(hours)*time.Hour), now)
+	if err != nil {
+		b.reply(msg, fmt.Sprintf(ErrGenericFmt, html.EscapeString(err.Error())))
+
+		return
+	}
+
+	b.reply(msg, formatWhatIfSimulation(hours, baseline, scenario, db.SimulateWhatIf(items, baseline, scenario)))
+}
+
+// formatWhatIfNoise renders the noise rate of a result, or "n/a" without ratings.
+func formatWhatIfNoise(r db.WhatIfResult) string {
+	if r.Rated == 0 {
+		return "n/a"
+	}
+
+	return fmt.Sprintf("%.0f%% of %d rated", r.NoiseRate()*percentageMultiplier, r.Rated)
+}
+
+func formatWhatIfSimulation(hours int, baseline, scenario db.WhatIfThresholds, sim db.WhatIfSimulation) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🧪 <b>What-if</b> (last %dh, %d scored items)\n\n", hours, sim.Baseline.Scored)
+	fmt.Fprintf(&sb, "<b>Current</b> (relevance %.2f, importance %.2f): %d items, noise %s\n",
+		baseline.Relevance, baseline.Importance, sim.Baseline.Included, formatWhatIfNoise(sim.Baseline))
+	fmt.Fprintf(&sb, "<b>Scenario</b> (relevance %.2f, importance %.2f): %d items, noise %s\n",
+		scenario.Relevance, scenario.Importance, sim.Scenario.Included, formatWhatIfNoise(sim.Scenario))
+
+	for _, channel := range sortedWhatIfChannels(scenario.ChannelWeights) {
+		fmt.Fprintf(&sb, "• weight <code>@%s</code> = %.2f\n", html.EscapeString(channel), scenario.ChannelWeights[channel])
+	}
+
+	writeWhatIfChanges(&sb, "➕ Added", sim.Added)
+	writeWhatIfChanges(&sb, "➖ Removed", sim.Removed)
+
+	return sb.String()
+}
+
+func writeWhatIfChanges(sb *strings.Builder, title string, items []db.WhatIfItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "\n%s (%d):\n", title, len(items))
+
+	for i, it := range items {
+		if i == whatIfChangesShown {
+			fmt.Fprintf(sb, "… and %d more\n", len(items)-whatIfChangesShown)
+
+			break
+		}
+
+		topic := it.Topic
+		if topic == "" {
+			topic = "(no topic)"
+		}
+
+		fmt.Fprintf(sb, "• %s <i>@%s</i> rel %.2f, imp %.2f",
+			html.EscapeString(truncateAnnotationText(topic, whatIfTopicMaxRunes)), html.EscapeString(it.ChannelUsername), it.RelevanceScore, it.ImportanceScore)
+
+		if it.Rating != "" {
+			fmt.Fprintf(sb, " [%s]", html.EscapeString(it.Rating))
+		}
+
+		sb.WriteString("\n")
+	}
+}
+
+func sortedWhatIfChannels(weights map[string]float32) []string {
+	channels := make([]string, 0, len(weights))
+	for c := range weights {
+		channels = append(channels, c)
+	}
+
+	sort.Strings(channels)
+
+	return channels
+}
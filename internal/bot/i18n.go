@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// SettingBotLanguage selects the language of admin replies.
+	SettingBotLanguage = "bot_language"
+
+	botLanguageDefault = "en"
+)
+
+type botLanguageKey struct{}
+
+// botCatalogs holds admin reply translations per language, keyed by the
+// English message. English needs no entries: the keys are the English catalog.
+var botCatalogs = map[string]map[string]string{
+	botLanguageDefault: {},
+	"ru":               ruMessages,
+}
+
+// botLanguages returns the supported admin interface languages, sorted.
+func botLanguages() []string {
+	langs := make([]string, 0, len(botCatalogs))
+	for lang := range botCatalogs {
+		langs = append(langs, lang)
+	}
+
+	slices.Sort(langs)
+
+	return langs
+}
+
+// withBotLanguage stores the configured admin interface language in ctx for tr.
+func (b *Bot) withBotLanguage(ctx context.Context) context.Context {
+	lang := botLanguageDefault
+	if err := b.database.GetSetting(ctx, SettingBotLanguage, &lang); err != nil {
+		b.logger.Debug().Err(err).Msg("could not get bot_language")
+	}
+
+	return withLanguage(ctx, lang)
+}
+
+func withLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, botLanguageKey{}, strings.ToLower(lang))
+}
+
+func botLanguage(ctx context.Context) string {
+	if lang, ok := ctx.Value(botLanguageKey{}).(string); ok {
+		return lang
+	}
+
+	return botLanguageDefault
+}
+
+// tr translates format into the admin interface language and formats it like
+// fmt.Sprintf. Messages without a translation are sent in English.
+func tr(ctx context.Context, format string, args ...any) string {
+	if translated, ok := botCatalogs[botLanguage(ctx)][format]; ok {
+		format = translated
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,505 @@
+package bot
+
+// ruMessages is the Russian admin reply catalog.
+var ruMessages = map[string]string{
+	// Shared messages
+	"Unknown command":          "Неизвестная команда",
+	ErrGenericFmt:              "Ошибка: %s",
+	ErrSavingFmt:               "❌ Ошибка сохранения %s: %s",
+	ErrFetchingChannelsFmt:     "❌ Ошибка получения каналов: %s",
+	ErrChannelNotFoundFmt:      "Канал <code>%s</code> не найден.",
+	ErrUnknownBaseFmt:          "Неизвестная база. Используйте: <code>%s</code>",
+	ErrFetchingAdsKeywords:     "❌ Ошибка получения рекламных ключевых слов.",
+	MsgScoresDebugUsage:        "Использование: <code>/scores debug [hours]</code>",
+	MsgScoresDebugReasonsUsage: "Использование: <code>/scores debug reasons [channel|day] [hours]</code>",
+	errInvalidScheduleFmt:      "❌ Некорректное расписание: %s",
+	errKeywordAlreadyExists:    "❌ Ключевое слово уже есть в списке.",
+	errKeywordNotFound:         "❌ Ключевое слово не найдено.",
+	fmtErrRetryingItems:        "❌ Ошибка повторной обработки элементов: %s",
+	"❌ Invalid value. Please provide a number between 0.0 and 1.0.": "❌ Неверное значение. Укажите число от 0.0 до 1.0.",
+	"❌ Invalid value. Please provide a number between 1 and 5.":     "❌ Неверное значение. Укажите число от 1 до 5.",
+	"❌ Invalid value. Please provide a positive number.":            "❌ Неверное значение. Укажите положительное число.",
+
+	// Namespaces
+	`📺 <b>Channel Management</b>
+
+<b>Commands:</b>
+• <code>/channel add @user</code> - Add channel to tracking
+• <code>/channel remove @user</code> - Remove channel
+• <code>/channel list</code> - List channels (filters, sort, compact)
+• <code>/channel export [json|csv]</code> - Export channels with settings
+• <code>/channel import [apply]</code> - Import channels from a file (dry run first)
+• <code>/channel group</code> - Channel groups with own thresholds and digests
+• <code>/channel snooze @user 6h</code> - Pause a channel for a while
+• <code>/channel trial</code> - Trials of new channels (report, promote, reject)
+• <code>/channel purge @user</code> - Delete a channel and all its data
+• <code>/channel metadata @user ...</code> - Set category/tone
+• <code>/channel weight @user</code> - View/set importance weight
+• <code>/channel relevance @user</code> - View/set auto-relevance
+• <code>/channel quota @user</code> - View/set ingestion quota
+• <code>/channel stats</code> - Channel quality metrics`: `📺 <b>Управление каналами</b>
+
+<b>Команды:</b>
+• <code>/channel add @user</code> - Начать отслеживать канал
+• <code>/channel remove @user</code> - Удалить канал
+• <code>/channel list</code> - Список каналов (фильтры, сортировка, compact)
+• <code>/channel export [json|csv]</code> - Экспорт каналов с настройками
+• <code>/channel import [apply]</code> - Импорт каналов из файла (сначала пробный прогон)
+• <code>/channel group</code> - Группы каналов со своими порогами и дайджестами
+• <code>/channel snooze @user 6h</code> - Временно приостановить канал
+• <code>/channel trial</code> - Испытательный срок новых каналов (отчёт, принять, отклонить)
+• <code>/channel purge @user</code> - Удалить канал со всеми его данными
+• <code>/channel metadata @user ...</code> - Категория/тон канала
+• <code>/channel weight @user</code> - Просмотр/установка веса важности
+• <code>/channel relevance @user</code> - Просмотр/установка авто-релевантности
+• <code>/channel quota @user</code> - Просмотр/установка квоты сбора
+• <code>/channel stats</code> - Метрики качества каналов`,
+	"❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/channel</code> to see available commands.":                                                                                "❓ Неизвестная подкоманда: <code>%s</code>\n\n💡 Выполните <code>/channel</code>, чтобы увидеть доступные команды.",
+	"❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/filter</code> to see current filters, or use <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code>.": "❓ Неизвестная подкоманда: <code>%s</code>\n\n💡 Выполните <code>/filter</code>, чтобы увидеть текущие фильтры, или используйте <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code>.",
+	`⚙️ <b>Configuration</b>
+
+<b>Output:</b>
+• <code>/config target @channel</code> - Set digest target
+• <code>/config window 6h</code> - Set digest interval
+• <code>/config language en</code> - Set language
+• <code>/config bot_language ru</code> - Language of bot replies (en/ru)
+• <code>/config tone casual</code> - Set tone
+• <code>/config item_links bot</code> - Item detail links (off/bot/research)
+• <code>/config toc 5</code> - Table of contents from N topics (or off)
+• <code>/config stale section</code> - Carry over missed items (off/skip/section)
+• <code>/config diversity channel 3</code> - Max items per channel/topic in a digest
+• <code>/config mmr 0.7</code> - Trade importance for novelty among digest items (or off)
+• <code>/config tier_sort corroboration</code> - Order stories within tiers by importance or channel count
+• <code>/config prebuild 15</code> - Build digests N minutes before their slot (or off)
+• <code>/config cover style flat</code> - AI cover style, prompt template and local cover
+• <code>/config verbosity compact</code> - Compact/standard/detailed (optionally per target)
+• <code>/config format markdownv2</code> - HTML or MarkdownV2 output (optionally per target)
+• <code>/config alt_text caption</code> - Image descriptions in rich digests (off/caption/spoiler)
+• <code>/config safety blur</code> - NSFW/graphic/profane items: off/warn/blur/block (optionally per target)
+• <code>/config regions ua,pl</code> - Only items about these countries (or off)
+• <code>/config shadow</code> - Shadow target for testing experimental settings
+• <code>/config stages</code> - Turn optional pipeline stages on or off
+
+<b>Thresholds:</b>
+• <code>/config relevance 0.5</code> - Min relevance (0-1, higher = stricter)
+• <code>/config importance 0.3</code> - Min importance (0-1, higher = stricter)
+
+<b>Links:</b>
+• <code>/config links on</code> - Enable link enrichment
+• <code>/config maxlinks 3</code> - Max links per message
+
+<b>Reset:</b>
+• <code>/config reset &lt;key&gt;</code> - Reset setting to default`: `⚙️ <b>Настройки</b>
+
+<b>Вывод:</b>
+• <code>/config target @channel</code> - Канал для дайджеста
+• <code>/config window 6h</code> - Интервал дайджеста
+• <code>/config language en</code> - Язык дайджеста
+• <code>/config bot_language ru</code> - Язык ответов бота (en/ru)
+• <code>/config tone casual</code> - Тон дайджеста
+• <code>/config item_links bot</code> - Ссылки на подробности (off/bot/research)
+• <code>/config toc 5</code> - Оглавление начиная с N тем (или off)
+• <code>/config stale section</code> - Перенос пропущенных новостей (off/skip/section)
+• <code>/config diversity channel 3</code> - Максимум новостей на канал/тему в дайджесте
+• <code>/config mmr 0.7</code> - Баланс важности и новизны новостей дайджеста (или off)
+• <code>/config tier_sort corroboration</code> - Порядок новостей в уровнях: по важности или числу каналов
+• <code>/config prebuild 15</code> - Собирать дайджест за N минут до отправки (или off)
+• <code>/config cover style flat</code> - Стиль и шаблон промпта AI-обложки, локальная обложка
+• <code>/config verbosity compact</code> - Compact/standard/detailed (можно для отдельного канала)
+• <code>/config format markdownv2</code> - Вывод в HTML или MarkdownV2 (можно для отдельного канала)
+• <code>/config alt_text caption</code> - Описания изображений в дайджесте с картинками (off/caption/spoiler)
+• <code>/config safety blur</code> - NSFW, жестокость и мат: off/warn/blur/block (можно для отдельного канала)
+• <code>/config regions ua,pl</code> - Только новости об этих странах (или off)
+• <code>/config shadow</code> - Теневой канал для проверки экспериментальных настроек
+• <code>/config stages</code> - Включение и отключение необязательных этапов обработки
+
+<b>Пороги:</b>
+• <code>/config relevance 0.5</code> - Мин. релевантность (0-1, больше = строже)
+• <code>/config importance 0.3</code> - Мин. важность (0-1, больше = строже)
+
+<b>Ссылки:</b>
+• <code>/config links on</code> - Включить обогащение ссылок
+• <code>/config maxlinks 3</code> - Максимум ссылок в сообщении
+
+<b>Сброс:</b>
+• <code>/config reset &lt;key&gt;</code> - Сбросить настройку к значению по умолчанию`,
+	"❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/config</code> to see available settings.": "❓ Неизвестная подкоманда: <code>%s</code>\n\n💡 Выполните <code>/config</code>, чтобы увидеть доступные настройки.",
+	`🤖 <b>AI Settings</b>
+
+<b>Features (on/off):</b>
+• <code>/ai editor on</code> - Editor-in-chief
+• <code>/ai tiered on</code> - Tiered importance
+• <code>/ai vision on</code> - Vision routing
+• <code>/ai topics on</code> - Topic grouping
+• <code>/ai consolidated on</code> - Cluster consolidation
+• <code>/ai details on</code> - Detailed items
+• <code>/ai twopass on</code> - Two-pass summarization
+• <code>/ai sections</code> - Editor overview sections
+
+<b>Other:</b>
+• <code>/ai tone casual</code> - Set digest tone
+• <code>/ai dedup semantic</code> - Dedup mode (strict/semantic)
+• <code>/ai ensemble</code> - Ensemble scoring
+• <code>/ai prompt list</code> - Manage prompts`: `🤖 <b>Настройки ИИ</b>
+
+<b>Функции (on/off):</b>
+• <code>/ai editor on</code> - Главный редактор
+• <code>/ai tiered on</code> - Уровни важности
+• <code>/ai vision on</code> - Анализ изображений
+• <code>/ai topics on</code> - Группировка по темам
+• <code>/ai consolidated on</code> - Объединение кластеров
+• <code>/ai details on</code> - Подробные новости
+• <code>/ai twopass on</code> - Двухпроходное резюмирование
+• <code>/ai sections</code> - Разделы обзора редактора
+
+<b>Прочее:</b>
+• <code>/ai tone casual</code> - Тон дайджеста
+• <code>/ai dedup semantic</code> - Режим дедупликации (strict/semantic)
+• <code>/ai ensemble</code> - Ансамблевая оценка
+• <code>/ai prompt list</code> - Управление промптами`,
+	"❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/ai</code> to see available AI settings.": "❓ Неизвестная подкоманда: <code>%s</code>\n\n💡 Выполните <code>/ai</code>, чтобы увидеть доступные настройки ИИ.",
+	`🔧 <b>System Diagnostics</b>
+
+<b>Commands:</b>
+• <code>/system status</code> - System health dashboard
+• <code>/system settings</code> - Show all settings
+• <code>/system history</code> - Recent setting changes
+• <code>/system settings rollback &lt;key&gt; [steps|timestamp]</code> - Restore a setting from history
+• <code>/system errors</code> - Recent processing errors
+• <code>/system retry</code> - Retry failed items
+• <code>/system scores</code> - Item importance scores
+• <code>/system factcheck</code> - Fact check status`: `🔧 <b>Диагностика системы</b>
+
+<b>Команды:</b>
+• <code>/system status</code> - Состояние системы
+• <code>/system settings</code> - Все настройки
+• <code>/system history</code> - Последние изменения настроек
+• <code>/system settings rollback &lt;key&gt; [steps|timestamp]</code> - Восстановить настройку из истории
+• <code>/system errors</code> - Последние ошибки обработки
+• <code>/system retry</code> - Повторить обработку неудачных элементов
+• <code>/system scores</code> - Оценки важности новостей
+• <code>/system factcheck</code> - Статус проверки фактов`,
+	"❓ Unknown subcommand: <code>%s</code>\n\n💡 Run <code>/system</code> to see available diagnostics.": "❓ Неизвестная подкоманда: <code>%s</code>\n\n💡 Выполните <code>/system</code>, чтобы увидеть доступную диагностику.",
+
+	// Bot language
+	"🌐 Bot language: <code>%s</code>":         "🌐 Язык бота: <code>%s</code>",
+	"❌ Unsupported language <code>%s</code>.": "❌ Язык <code>%s</code> не поддерживается.",
+	"✅ Bot language set to <code>%s</code>.":  "✅ Язык бота: <code>%s</code>.",
+	botLanguageUsageFmt:                       "Использование: <code>/config bot_language &lt;%s&gt;</code>\n\nМеняет язык ответов бота. Язык дайджеста задаётся командой <code>/config language</code>.",
+
+	// Thresholds
+	`📊 <b>%s</b>
+
+Current value: <code>%.2f</code>
+Range: 0.0 - 1.0 (%s)
+
+Usage: <code>/%s &lt;0.0-1.0&gt;</code>`: `📊 <b>%s</b>
+
+Текущее значение: <code>%.2f</code>
+Диапазон: 0.0 - 1.0 (%s)
+
+Использование: <code>/%s &lt;0.0-1.0&gt;</code>`,
+	"higher = stricter filtering": "больше = строже фильтрация",
+
+	// Target chat
+	"Usage: <code>/target &lt;channel_id or @username&gt;</code>":                                                                            "Использование: <code>/target &lt;channel_id или @username&gt;</code>",
+	"❌ Error saving target chat ID: %s":                                                                                                      "❌ Ошибка сохранения ID целевого чата: %s",
+	"✅ This channel has been set as the target for digest posts.":                                                                            "✅ Этот канал назначен получателем дайджестов.",
+	"✅ Target chat updated to <code>%d</code> (<b>%s</b>). A confirmation message has been sent to that channel.":                            "✅ Целевой чат изменён на <code>%d</code> (<b>%s</b>). В этот канал отправлено подтверждение.",
+	"❌ Could not find chat %s: %s. Make sure the bot is an administrator in the channel.":                                                    "❌ Не удалось найти чат %s: %s. Убедитесь, что бот является администратором канала.",
+	"❌ Invalid channel ID. It should be a number (don't forget the <code>-100</code> prefix for channels) or a <code>@username</code>.":      "❌ Неверный ID канала. Укажите число (не забудьте префикс <code>-100</code> для каналов) или <code>@username</code>.",
+	"❌ Could not find chat %d: %s. Make sure the bot is added to the chat.":                                                                  "❌ Не удалось найти чат %d: %s. Убедитесь, что бот добавлен в чат.",
+	"❌ Could not find chat %d (nor %d): %s. Make sure the bot is added to the chat.":                                                         "❌ Не удалось найти чат %d (и %d): %s. Убедитесь, что бот добавлен в чат.",
+	"❌ Found chat <b>%s</b> but could not send a message to it: %s. Make sure the bot is an administrator with permission to post messages.": "❌ Чат <b>%s</b> найден, но отправить в него сообщение не удалось: %s. Убедитесь, что бот является администратором с правом публикации.",
+
+	// Window and schedule
+	"Usage: <code>/window &lt;duration&gt;</code> (e.g. <code>60m</code>, <code>6h</code>, <code>24h</code>)": "Использование: <code>/window &lt;duration&gt;</code> (например, <code>60m</code>, <code>6h</code>, <code>24h</code>)",
+	"❌ Invalid duration format. Use something like <code>60m</code>, <code>6h</code>, <code>24h</code>.":      "❌ Неверный формат длительности. Используйте, например, <code>60m</code>, <code>6h</code>, <code>24h</code>.",
+	"Usage: <code>/schedule timezone &lt;IANA&gt;</code> | <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule weekends hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule preview [count]</code> | <code>/schedule clear</code> | <code>/schedule show</code>": "Использование: <code>/schedule timezone &lt;IANA&gt;</code> | <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule weekends hourly &lt;HH:00-HH:00&gt;</code> | <code>/schedule preview [count]</code> | <code>/schedule clear</code> | <code>/schedule show</code>",
+	"❓ Unknown subcommand: <code>%s</code>\n\n💡 Use <code>/schedule show</code> to see current schedule, or <code>weekdays</code>, <code>weekends</code>, <code>timezone</code>.":                                                                                                                                                                   "❓ Неизвестная подкоманда: <code>%s</code>\n\n💡 Используйте <code>/schedule show</code>, чтобы увидеть текущее расписание, или <code>weekdays</code>, <code>weekends</code>, <code>timezone</code>.",
+	"Usage: <code>/schedule timezone &lt;IANA&gt;</code> (e.g. <code>Europe/Kyiv</code>)":                                         "Использование: <code>/schedule timezone &lt;IANA&gt;</code> (например, <code>Europe/Kyiv</code>)",
+	"Usage: <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code>": "Использование: <code>/schedule weekdays times &lt;HH:00,...&gt;</code> | <code>/schedule weekdays hourly &lt;HH:00-HH:00&gt;</code>",
+	"❌ Unknown schedule mode. Use <code>times</code> or <code>hourly</code>.":                                                     "❌ Неизвестный режим расписания. Используйте <code>times</code> или <code>hourly</code>.",
+	"❌ Error clearing schedule: %s": "❌ Ошибка очистки расписания: %s",
+	"✅ Digest schedule cleared. Configure a new schedule with <code>/schedule set</code>.": "✅ Расписание дайджестов очищено. Задайте новое с помощью <code>/schedule set</code>.",
+	"Usage: <code>/schedule preview [count]</code>":                                        "Использование: <code>/schedule preview [count]</code>",
+	"❌ Error computing schedule preview: %s":                                               "❌ Ошибка расчёта предпросмотра расписания: %s",
+	"ℹ️ No digest schedule configured.":                                                    "ℹ️ Расписание дайджестов не настроено.",
+	"❌ Invalid timezone: %s":                                                               "❌ Неверный часовой пояс: %s",
+	"ℹ️ No upcoming scheduled times found.":                                                "ℹ️ Ближайших запланированных запусков нет.",
+	"❌ Invalid time: %s":                                                                   "❌ Неверное время: %s",
+	"❌ Provide a list of times, e.g. <code>09:00,13:00,18:00</code>.":                      "❌ Укажите список времён, например <code>09:00,13:00,18:00</code>.",
+	"❌ Invalid hourly range: %s":                                                           "❌ Неверный почасовой диапазон: %s",
+	"❌ Error saving schedule: %s":                                                          "❌ Ошибка сохранения расписания: %s",
+	"✅ Digest schedule updated.":                                                           "✅ Расписание дайджестов обновлено.",
+
+	// Digest and link settings
+	"Usage: <code>/language &lt;lang_code&gt;</code> (e.g. <code>en</code>, <code>ru</code>, <code>de</code>)": "Использование: <code>/language &lt;lang_code&gt;</code> (например, <code>en</code>, <code>ru</code>, <code>de</code>)",
+	"❌ Error saving digest language: %s":                                                                          "❌ Ошибка сохранения языка дайджеста: %s",
+	"✅ Digest language updated to <code>%s</code>.":                                                               "✅ Язык дайджеста изменён на <code>%s</code>.",
+	"Usage: <code>/minlength &lt;number&gt;</code>":                                                               "Использование: <code>/minlength &lt;number&gt;</code>",
+	"❌ Error saving min length: %s":                                                                               "❌ Ошибка сохранения минимальной длины: %s",
+	"✅ Minimum message length updated to <code>%d</code>.":                                                        "✅ Минимальная длина сообщения изменена на <code>%d</code>.",
+	"Usage: <code>/max_links &lt;1-5&gt;</code>":                                                                  "Использование: <code>/max_links &lt;1-5&gt;</code>",
+	"❌ Error saving max links: %s":                                                                                "❌ Ошибка сохранения лимита ссылок: %s",
+	"✅ Max links per message updated to <code>%d</code>.":                                                         "✅ Максимум ссылок в сообщении изменён на <code>%d</code>.",
+	"Usage: <code>/link_cache &lt;duration&gt;</code> (e.g. <code>12h</code>, <code>24h</code>, <code>7d</code>)": "Использование: <code>/link_cache &lt;duration&gt;</code> (например, <code>12h</code>, <code>24h</code>, <code>7d</code>)",
+	"❌ Invalid duration format. Use something like <code>12h</code>, <code>24h</code>.":                           "❌ Неверный формат длительности. Используйте, например, <code>12h</code>, <code>24h</code>.",
+	"❌ Error saving link cache TTL: %s":                                                                           "❌ Ошибка сохранения TTL кэша ссылок: %s",
+	"✅ Link cache TTL updated to <code>%s</code>.":                                                                "✅ TTL кэша ссылок изменён на <code>%s</code>.",
+	"Usage: <code>/tone &lt;professional|casual|brief&gt;</code>":                                                 "Использование: <code>/tone &lt;professional|casual|brief&gt;</code>",
+	"❌ Error saving tone: %s":                                                                                     "❌ Ошибка сохранения тона: %s",
+	"✅ Digest tone set to <code>%s</code>.":                                                                       "✅ Тон дайджеста: <code>%s</code>.",
+	"Usage: <code>/dedup &lt;strict|semantic&gt;</code>":                                                          "Использование: <code>/dedup &lt;strict|semantic&gt;</code>",
+	"❌ Error saving dedup mode: %s":                                                                               "❌ Ошибка сохранения режима дедупликации: %s",
+	"✅ Deduplication mode set to <code>%s</code>.":                                                                "✅ Режим дедупликации: <code>%s</code>.",
+
+	// Media filter
+	"Usage: <code>/filter media &lt;off|downweight|drop&gt;</code>\n\nImage-only stickers and memes are detected before summarization and either kept, downweighted or dropped.": "Использование: <code>/filter media &lt;off|downweight|drop&gt;</code>\n\nСтикеры и мемы без текста распознаются до суммаризации и остаются, понижаются в весе или отбрасываются.",
+	"❌ Invalid mode. Use <code>off</code>, <code>downweight</code> or <code>drop</code>.":                                                                                        "❌ Неверный режим. Используйте <code>off</code>, <code>downweight</code> или <code>drop</code>.",
+	"❌ Error saving media filter: %s":        "❌ Ошибка сохранения медиафильтра: %s",
+	"✅ Media filter set to <code>%s</code>.": "✅ Медиафильтр: <code>%s</code>.",
+
+	// Ads keywords
+	"📋 <b>Ads Keywords:</b>\n<code>%s</code>\n\nUsage: <code>/adskeywords add &lt;word&gt;</code> or <code>/adskeywords remove &lt;word&gt;</code> or <code>/adskeywords clear</code>": "📋 <b>Рекламные ключевые слова:</b>\n<code>%s</code>\n\nИспользование: <code>/adskeywords add &lt;word&gt;</code>, <code>/adskeywords remove &lt;word&gt;</code> или <code>/adskeywords clear</code>",
+	"❌ Error saving ads keywords: %s":                "❌ Ошибка сохранения рекламных ключевых слов: %s",
+	"✅ Ads keywords updated. Total: <code>%d</code>": "✅ Рекламные ключевые слова обновлены. Всего: <code>%d</code>",
+	"❓ Unknown command. Use <code>add</code>, <code>remove</code>, <code>clear</code> or no arguments to list.": "❓ Неизвестная команда. Используйте <code>add</code>, <code>remove</code>, <code>clear</code> или команду без аргументов для списка.",
+	"Usage: <code>/adskeywords add &lt;word&gt;</code>":                                                         "Использование: <code>/adskeywords add &lt;word&gt;</code>",
+	"Usage: <code>/adskeywords remove &lt;word&gt;</code>":                                                      "Использование: <code>/adskeywords remove &lt;word&gt;</code>",
+
+	// Channels
+	"No active channels tracked.":                                             "Нет отслеживаемых каналов.",
+	"❌ Error fetching channel stats: %s":                                      "❌ Ошибка получения статистики каналов: %s",
+	"No stats available yet. Statistics are calculated over the last 7 days.": "Статистики пока нет. Она рассчитывается за последние 7 дней.",
+	"Usage:\n<code>/channel weight @username</code> - Show current weight\n<code>/channel weight @username 1.5</code> - Set weight (0.1-2.0)\n<code>/channel weight @username auto</code> - Enable auto-calculation\n<code>/channel weight @username 1.5 reason text</code> - Set weight with reason": "Использование:\n<code>/channel weight @username</code> - Текущий вес\n<code>/channel weight @username 1.5</code> - Установить вес (0.1-2.0)\n<code>/channel weight @username auto</code> - Включить авторасчёт\n<code>/channel weight @username 1.5 reason text</code> - Установить вес с причиной",
+	"Missing channel identifier.\nUsage: <code>/channel weight @username</code> or <code>/channel weight @username 1.5</code>":                                                                                                                                                                        "Не указан канал.\nИспользование: <code>/channel weight @username</code> или <code>/channel weight @username 1.5</code>",
+	"Channel <code>@%s</code> not found.":                                              "Канал <code>@%s</code> не найден.",
+	"Invalid weight. Use a number between 0.1 and 2.0, or 'auto' to reset to default.": "Неверный вес. Укажите число от 0.1 до 2.0 или 'auto' для значения по умолчанию.",
+	"Auto-weight enabled for %s. Weight reset to 1.0.":                                 "Автовес включён для %s. Вес сброшен до 1.0.",
+	"Missing channel identifier.\nUsage: <code>/channel relevance @username</code>":    "Не указан канал.\nИспользование: <code>/channel relevance @username</code>",
+	"Usage:\n<code>/channel relevance @username</code> - Show current auto relevance\n<code>/channel relevance @username auto</code> - Enable auto relevance\n<code>/channel relevance @username manual</code> - Disable auto relevance": "Использование:\n<code>/channel relevance @username</code> - Текущая авто-релевантность\n<code>/channel relevance @username auto</code> - Включить авто-релевантность\n<code>/channel relevance @username manual</code> - Выключить авто-релевантность",
+	"❌ Error %s auto relevance: %s":               "❌ Ошибка (%s) авто-релевантности: %s",
+	"Auto relevance %s for %s. Delta reset to 0.": "Авто-релевантность (%s) для %s. Поправка сброшена до 0.",
+	"Usage: <code>/channel metadata &lt;@username|ID&gt; &lt;category&gt; &lt;tone&gt; &lt;freq&gt; [relevance] [importance]</code>\nUse <code>-</code> to skip a field.": "Использование: <code>/channel metadata &lt;@username|ID&gt; &lt;category&gt; &lt;tone&gt; &lt;freq&gt; [relevance] [importance]</code>\n<code>-</code> пропускает поле.",
+	"❌ Error updating channel metadata: %s":                                                                               "❌ Ошибка обновления метаданных канала: %s",
+	"✅ Metadata updated for channel <code>%s</code>.":                                                                     "✅ Метаданные канала <code>%s</code> обновлены.",
+	"Usage: <code>/add &lt;@username|ID|invite_link&gt;</code>":                                                           "Использование: <code>/add &lt;@username|ID|invite_link&gt;</code>",
+	"❌ Error adding channel by invite link: %s":                                                                           "❌ Ошибка добавления канала по ссылке-приглашению: %s",
+	"✅ Channel added by invite link. Reader will attempt to join and track it soon.":                                      "✅ Канал добавлен по ссылке-приглашению. Ридер вскоре попробует вступить и начнёт его отслеживать.",
+	"❌ Error adding channel by ID: %s":                                                                                    "❌ Ошибка добавления канала по ID: %s",
+	"✅ Channel ID <code>%d</code> added. Reader will start tracking it soon.":                                             "✅ Канал с ID <code>%d</code> добавлен. Ридер скоро начнёт его отслеживать.",
+	"❌ Error adding channel by username: %s":                                                                              "❌ Ошибка добавления канала по имени: %s",
+	"✅ Channel <code>@%s</code> added. Reader will start tracking it soon.":                                               "✅ Канал <code>@%s</code> добавлен. Ридер скоро начнёт его отслеживать.",
+	"Usage: <code>/remove &lt;@username|ID&gt;</code>":                                                                    "Использование: <code>/remove &lt;@username|ID&gt;</code>",
+	"⚠️ Are you sure you want to stop tracking channel <code>%s</code>?\nUse <code>/remove %s confirm</code> to proceed.": "⚠️ Точно перестать отслеживать канал <code>%s</code>?\nДля подтверждения выполните <code>/remove %s confirm</code>.",
+	"❌ Error removing channel: %s":                                                                                        "❌ Ошибка удаления канала: %s",
+	"✅ Channel <code>%s</code> removed.":                                                                                  "✅ Канал <code>%s</code> удалён.",
+	"Usage: <code>/channel export [json|csv]</code>":                                                                      "Использование: <code>/channel export [json|csv]</code>",
+	"❌ Error exporting channels: %s":                                                                                      "❌ Ошибка экспорта каналов: %s",
+	"📤 %d channels":                                                                                                       "📤 Каналов: %d",
+	channelImportUsage: "Использование: отправьте JSON- или CSV-файл с подписью <code>/channel import</code> " +
+		"или ответьте на него командой <code>/channel import [apply]</code>.\n\n" +
+		"Без <code>apply</code> показывается только diff. Формат файла — как у <code>/channel export</code>.",
+	"❌ Error reading import file: %s": "❌ Ошибка чтения файла импорта: %s",
+	"📥 <b>Channel import applied</b>\nAdded: %d · Updated: %d · Unchanged: %d · Invalid: %d\n": "📥 <b>Импорт каналов выполнен</b>\nДобавлено: %d · Обновлено: %d · Без изменений: %d · Ошибочных: %d\n",
+	"\n❌ <b>Failed (%d):</b>\n":                                               "\n❌ <b>Не удалось (%d):</b>\n",
+	"📥 <b>Channel import (dry run)</b> · <code>%s</code>\n\n":                 "📥 <b>Импорт каналов (пробный прогон)</b> · <code>%s</code>\n\n",
+	"➕ <b>Add (%d):</b> %s\n":                                                 "➕ <b>Добавить (%d):</b> %s\n",
+	"✏️ <b>Update (%d):</b>\n":                                                "✏️ <b>Обновить (%d):</b>\n",
+	"= Unchanged: %d\n":                                                       "= Без изменений: %d\n",
+	"⚠️ <b>Invalid (%d):</b>\n":                                               "⚠️ <b>Ошибочные (%d):</b>\n",
+	"\n💡 Reply to the file with <code>/channel import apply</code> to apply.": "\n💡 Чтобы применить, ответьте на файл командой <code>/channel import apply</code>.",
+	channelGroupUsage: "📁 <b>Группы каналов</b>\n" +
+		"• <code>/channel group list</code>\n" +
+		"• <code>/channel group create &lt;name&gt;</code>\n" +
+		"• <code>/channel group delete &lt;name&gt;</code>\n" +
+		"• <code>/channel group add &lt;name&gt; @chan1 @chan2 ...</code>\n" +
+		"• <code>/channel group remove @chan1 @chan2 ...</code>\n" +
+		"• <code>/channel group threshold &lt;name&gt; &lt;relevance&gt; [importance]</code> (0 = глобальный)\n" +
+		"• <code>/channel group digest &lt;name&gt; &lt;@chat|ID|off&gt; [window]</code>\n\n" +
+		"Группы с целевым чатом получают свой дайджест и исключаются из основного.",
+	"✅ Group <b>%s</b> deleted; its channels are ungrouped.":            "✅ Группа <b>%s</b> удалена, её каналы больше не в группе.",
+	"❌ Error fetching channel groups: %s":                               "❌ Ошибка получения групп каналов: %s",
+	"No channel groups yet.":                                            "Групп каналов пока нет.",
+	"📁 <b>Channel Groups</b>\n\n":                                       "📁 <b>Группы каналов</b>\n\n",
+	"%d channels":                                                       "каналов: %d",
+	"Thresholds: relevance <code>%s</code>, importance <code>%s</code>": "Пороги: релевантность <code>%s</code>, важность <code>%s</code>",
+	"Digest: main":                                                      "Дайджест: основной",
+	"Digest: <code>%d</code> every <code>%s</code>":                     "Дайджест: <code>%d</code> каждые <code>%s</code>",
+	"❌ Group names are one word of up to 32 letters, digits, <code>-</code> or <code>_</code>.": "❌ Имя группы — одно слово до 32 букв, цифр, <code>-</code> или <code>_</code>.",
+	"❌ Error creating group: %s": "❌ Ошибка создания группы: %s",
+	"✅ Group <b>%s</b> created. Add channels with <code>/channel group add %s @channel</code>.": "✅ Группа <b>%s</b> создана. Добавьте каналы: <code>/channel group add %s @channel</code>.",
+	"❌ Group <b>%s</b> not found.":                               "❌ Группа <b>%s</b> не найдена.",
+	"❓ %s: channel not tracked":                                  "❓ %s: канал не отслеживается",
+	"✅ %s: ungrouped":                                            "✅ %s: убран из группы",
+	"❌ Thresholds must be numbers between 0 and 1 (0 = global).": "❌ Пороги — числа от 0 до 1 (0 = глобальный).",
+	"✅ Thresholds of <b>%s</b>: relevance <code>%s</code>, importance <code>%s</code>.": "✅ Пороги группы <b>%s</b>: релевантность <code>%s</code>, важность <code>%s</code>.",
+	"✅ Group <b>%s</b> is back in the main digest.":                                     "✅ Группа <b>%s</b> снова в основном дайджесте.",
+	"❌ Invalid window. Use a duration of at least 1h, e.g. <code>6h</code>.":            "❌ Неверное окно. Укажите длительность не меньше 1h, например <code>6h</code>.",
+	"✅ This chat will receive the <b>%s</b> group digest.":                              "✅ Этот чат будет получать дайджест группы <b>%s</b>.",
+	"✅ Group <b>%s</b> digest goes to <b>%s</b> every <code>%s</code>.":                 "✅ Дайджест группы <b>%s</b> будет приходить в <b>%s</b> каждые <code>%s</code>.",
+	"❌ Error updating group: %s":                                                        "❌ Ошибка обновления группы: %s",
+	"📁 <b>Groups (Last 7 Days)</b>\n":                                                   "📁 <b>Группы (за 7 дней)</b>\n",
+	"<i>ungrouped</i>":                                                                  "<i>без группы</i>",
+	"%d channels · %d items · %d digested (%.0f%%) · avg importance <code>%.2f</code>":  "каналов: %d · сообщений: %d · в дайджестах: %d (%.0f%%) · средняя важность <code>%.2f</code>",
+	channelSnoozeUsage: "Использование: <code>/channel snooze @user &lt;duration|off&gt; [digest]</code>\n" +
+		"Длительность вида <code>6h</code> или <code>3d</code>, до 30d. Снуз приостанавливает сбор; " +
+		"с <code>digest</code> канал читается, но не попадает в дайджесты. " +
+		"Сообщения, опубликованные во время снуза, в дайджест не попадут.",
+	"✅ %s is no longer snoozed.": "✅ %s больше не на паузе.",
+	"❌ Invalid duration. Use something like <code>6h</code> or <code>3d</code>, up to 30d.": "❌ Неверная длительность. Укажите, например, <code>6h</code> или <code>3d</code>, до 30d.",
+	"😴 %s is left out of digests until <code>%s</code>.":                                    "😴 %s не попадает в дайджесты до <code>%s</code>.",
+	"😴 %s ingestion is paused until <code>%s</code>.":                                       "😴 Сбор %s приостановлен до <code>%s</code>.",
+	"❌ Error snoozing channel: %s":                                                          "❌ Ошибка паузы канала: %s",
+	channelPurgeUsage: "Использование: <code>/channel purge @user [confirm]</code>\n" +
+		"Удаляет канал и всё, что из него получено: сообщения, элементы, эмбеддинги, членство в кластерах, " +
+		"оценки, связи с источниками и данные исследований. Без <code>confirm</code> только показывает, что будет удалено.",
+	"❌ Error purging channel: %s":    "❌ Ошибка удаления канала: %s",
+	"🗑 <b>Purge preview: %s</b>\n\n": "🗑 <b>Предпросмотр удаления: %s</b>\n\n",
+	"🗑 <b>Purged %s</b>\n\n":         "🗑 <b>Канал %s удалён</b>\n\n",
+	"\nTotal: <b>%d</b> rows":        "\nВсего строк: <b>%d</b>",
+	"\n\n⚠️ This cannot be undone. Run <code>/channel purge %s confirm</code> to delete.": "\n\n⚠️ Это необратимо. Выполните <code>/channel purge %s confirm</code>, чтобы удалить.",
+	channelTrialUsage: "🧪 <b>Испытательный срок каналов</b>\n" +
+		"Новые каналы оцениваются, но не попадают в дайджесты во время испытательного срока.\n" +
+		"• <code>/channel trial list</code>\n" +
+		"• <code>/channel trial start @user [days]</code>\n" +
+		"• <code>/channel trial report @user</code>\n" +
+		"• <code>/channel trial promote @user</code>\n" +
+		"• <code>/channel trial reject @user</code>\n" +
+		"• <code>/channel trial days &lt;n|off&gt;</code> - длительность срока для новых каналов",
+	"❌ Error fetching channel trials: %s":                                    "❌ Ошибка получения испытательных сроков: %s",
+	"🧪 <b>Channel Trials</b> · new channels: <code>%s</code>\n\n":            "🧪 <b>Испытательный срок</b> · новые каналы: <code>%s</code>\n\n",
+	"No channels on trial.":                                                  "Нет каналов на испытательном сроке.",
+	"• %s · until <code>%s</code>":                                           "• %s · до <code>%s</code>",
+	"• %s · ended, awaiting promote/reject":                                  "• %s · срок истёк, ждёт решения",
+	"❌ Trial length must be a number of days up to 90, or <code>off</code>.": "❌ Длительность срока — число дней до 90 или <code>off</code>.",
+	"✅ Trial length of new channels: <code>%s</code>.":                       "✅ Испытательный срок новых каналов: <code>%s</code>.",
+	"⚠️ Could not start the trial: %s":                                       "⚠️ Не удалось начать испытательный срок: %s",
+	"🧪 On trial until <code>%s</code>: scored but left out of digests. You will get a report with promote/reject buttons.": "🧪 Испытательный срок до <code>%s</code>: сообщения оцениваются, но не попадают в дайджесты. Затем придёт отчёт с кнопками «принять/отклонить».",
+	"❌ Error building trial report: %s":                           "❌ Ошибка построения отчёта: %s",
+	"🧪 <b>Trial report: %s</b>":                                   "🧪 <b>Отчёт об испытательном сроке: %s</b>",
+	"<code>%s</code> → <code>%s</code>":                           "<code>%s</code> → <code>%s</code>",
+	"Messages: %d · Relevant items: %d · Would reach digests: %d": "Сообщений: %d · Релевантных: %d · Попали бы в дайджесты: %d",
+	"Projected noise rate: <b>%.0f%%</b>":                         "Ожидаемая доля шума: <b>%.0f%%</b>",
+	"<i>No relevant items during the trial.</i>":                  "<i>За испытательный срок релевантных сообщений не было.</i>",
+	"<b>Sample items:</b>":                                        "<b>Примеры сообщений:</b>",
+	"✅ Promote":                                                   "✅ Принять",
+	"🗑 Reject":                                                    "🗑 Отклонить",
+	"✅ %s promoted: its items now reach digests.":                 "✅ %s принят: его сообщения теперь попадают в дайджесты.",
+	"🗑 %s rejected and no longer tracked.":                        "🗑 %s отклонён и больше не отслеживается.",
+	"❓ %s is not on trial (already promoted or rejected?).":       "❓ %s не на испытательном сроке (уже принят или отклонён?).",
+	"❌ Error updating channel trial: %s":                          "❌ Ошибка обновления испытательного срока: %s",
+
+	// Ratings and scores
+	"❌ Error fetching ratings: %s":                                                "❌ Ошибка получения оценок: %s",
+	"No item ratings in the last %d days.":                                        "Нет оценок новостей за последние %d дн.",
+	"Usage: <code>/ratings stats [limit]</code>":                                  "Использование: <code>/ratings stats [limit]</code>",
+	"❌ Error fetching rating stats: %s":                                           "❌ Ошибка получения статистики оценок: %s",
+	"No aggregated rating stats yet. The weekly job updates these automatically.": "Сводной статистики оценок пока нет. Еженедельная задача обновляет её автоматически.",
+	"❌ Error fetching global rating stats: %s":                                    "❌ Ошибка получения общей статистики оценок: %s",
+	"Usage: <code>/scores [hours] [limit]</code>":                                 "Использование: <code>/scores [hours] [limit]</code>",
+	"❌ Error fetching scores: %s":                                                 "❌ Ошибка получения оценок важности: %s",
+	"No ready items in the last %d hours.":                                        "Нет готовых новостей за последние %d ч.",
+	"❌ Error fetching items: %s":                                                  "❌ Ошибка получения новостей: %s",
+	"❌ Error fetching score stats: %s":                                            "❌ Ошибка получения статистики оценок важности: %s",
+	"❌ Error fetching item stats: %s":                                             "❌ Ошибка получения статистики новостей: %s",
+	"No messages in the last %d hours.":                                           "Нет сообщений за последние %d ч.",
+	"❌ Error fetching drop reasons: %s":                                           "❌ Ошибка получения причин отсева: %s",
+	"No drop reasons logged in the last %d hours.":                                "Нет записанных причин отсева за последние %d ч.",
+
+	// Digest clicks
+	"❌ Error fetching click stats: %s": "❌ Ошибка получения статистики кликов: %s",
+	"No tracked clicks in the last %d days. Enable tracking with <code>/click_tracking on</code>.": "Нет отслеженных кликов за последние %d дн. Включите отслеживание: <code>/click_tracking on</code>.",
+
+	// Prompts
+	"Usage:\n<code>/prompt list</code>\n<code>/prompt show &lt;summarize|narrative|cluster_summary|cluster_topic|relevance_gate&gt; [version]</code>\n<code>/prompt set &lt;base&gt; &lt;version&gt; &lt;text...&gt;</code>\n<code>/prompt activate &lt;base&gt; &lt;version&gt;</code>\n<code>/prompt rollback &lt;base&gt; [steps|timestamp]</code>": "Использование:\n<code>/prompt list</code>\n<code>/prompt show &lt;summarize|narrative|cluster_summary|cluster_topic|relevance_gate&gt; [version]</code>\n<code>/prompt set &lt;base&gt; &lt;version&gt; &lt;text...&gt;</code>\n<code>/prompt activate &lt;base&gt; &lt;version&gt;</code>\n<code>/prompt rollback &lt;base&gt; [steps|timestamp]</code>",
+	"Usage: <code>/prompt show &lt;base&gt; [version]</code>":                                  "Использование: <code>/prompt show &lt;base&gt; [version]</code>",
+	"No override found for <code>%s</code> (version <code>%s</code>). Using built-in default.": "Переопределение для <code>%s</code> (версия <code>%s</code>) не найдено. Используется встроенный промпт.",
+	"Prompt <b>%s</b> (<code>%s</code>):\n<pre>%s</pre>":                                       "Промпт <b>%s</b> (<code>%s</code>):\n<pre>%s</pre>",
+	"Usage: <code>/prompt set &lt;base&gt; &lt;version&gt; &lt;text...&gt;</code>":             "Использование: <code>/prompt set &lt;base&gt; &lt;version&gt; &lt;text...&gt;</code>",
+	"❌ Error saving prompt: %s":                                                                "❌ Ошибка сохранения промпта: %s",
+	"✅ Prompt <b>%s</b> saved as <code>%s</code>.":                                             "✅ Промпт <b>%s</b> сохранён как <code>%s</code>.",
+	"Usage: <code>/prompt activate &lt;base&gt; &lt;version&gt;</code>":                        "Использование: <code>/prompt activate &lt;base&gt; &lt;version&gt;</code>",
+	"❌ Error saving active version: %s":                                                        "❌ Ошибка сохранения активной версии: %s",
+	"✅ Active prompt for <b>%s</b> set to <code>%s</code>.":                                    "✅ Активный промпт для <b>%s</b>: <code>%s</code>.",
+
+	// Feedback and filters
+	"Usage: <code>/feedback &lt;item_id&gt; &lt;good|bad|irrelevant&gt; [comment]</code>": "Использование: <code>/feedback &lt;item_id&gt; &lt;good|bad|irrelevant&gt; [comment]</code>",
+	"❌ Invalid rating. Use <code>%s</code>, <code>%s</code>, or <code>%s</code>.":         "❌ Неверная оценка. Используйте <code>%s</code>, <code>%s</code> или <code>%s</code>.",
+	"❌ Error saving feedback: %s":                                                              "❌ Ошибка сохранения отзыва: %s",
+	"✅ Feedback for item <code>%s</code> recorded as <b>%s</b>.":                               "✅ Отзыв о новости <code>%s</code> записан как <b>%s</b>.",
+	"Usage: <code>/filters add &lt;allow|deny&gt; &lt;pattern&gt;</code>":                      "Использование: <code>/filters add &lt;allow|deny&gt; &lt;pattern&gt;</code>",
+	"❌ Error adding filter: %s":                                                                "❌ Ошибка добавления фильтра: %s",
+	"✅ Filter added: [%s] <code>%s</code>":                                                     "✅ Фильтр добавлен: [%s] <code>%s</code>",
+	"Usage: <code>/filters remove &lt;pattern&gt;</code>":                                      "Использование: <code>/filters remove &lt;pattern&gt;</code>",
+	"❌ Error removing filter: %s":                                                              "❌ Ошибка удаления фильтра: %s",
+	"✅ Filter removed: <code>%s</code>":                                                        "✅ Фильтр удалён: <code>%s</code>",
+	"Usage: <code>/filters ads &lt;on|off&gt;</code>":                                          "Использование: <code>/filters ads &lt;on|off&gt;</code>",
+	"❌ Error saving ads filter setting: %s":                                                    "❌ Ошибка сохранения настройки рекламного фильтра: %s",
+	"✅ Ads filter turned <code>%s</code>.":                                                     "✅ Рекламный фильтр: <code>%s</code>.",
+	"Usage: <code>/filters mode &lt;mixed|allowlist|denylist&gt;</code>":                       "Использование: <code>/filters mode &lt;mixed|allowlist|denylist&gt;</code>",
+	"❌ Invalid mode. Use <code>mixed</code>, <code>allowlist</code> or <code>denylist</code>.": "❌ Неверный режим. Используйте <code>mixed</code>, <code>allowlist</code> или <code>denylist</code>.",
+	"❌ Error saving filters mode: %s":                                                          "❌ Ошибка сохранения режима фильтров: %s",
+	"✅ Filters mode set to <code>%s</code>.":                                                   "✅ Режим фильтров: <code>%s</code>.",
+	"❌ Error fetching filters: %s":                                                             "❌ Ошибка получения фильтров: %s",
+	"❓ Unknown filters command. Use <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code> or no arguments to list.": "❓ Неизвестная команда фильтров. Используйте <code>add</code>, <code>remove</code>, <code>ads</code>, <code>mode</code> или команду без аргументов для списка.",
+
+	// Toggles, preview and settings
+	"Usage: <code>/%s &lt;on|off&gt;</code>":                                "Использование: <code>/%s &lt;on|off&gt;</code>",
+	"✅ <b>%s</b>\nOld status: <code>%s</code>\nNew status: <code>%s</code>": "✅ <b>%s</b>\nБыло: <code>%s</code>\nСтало: <code>%s</code>",
+	"❌ Digest preview is not available in this mode.":                       "❌ Предпросмотр дайджеста недоступен в этом режиме.",
+	"❌ Error building digest preview: %s":                                   "❌ Ошибка сборки предпросмотра дайджеста: %s",
+	"ℹ️ No items found for the current window to include in a digest.":      "ℹ️ В текущем окне нет новостей для дайджеста.",
+	"⏳ <b>Building digest preview…</b>\n<i>%s…</i> (%ds)":                   "⏳ <b>Собираю предпросмотр дайджеста…</b>\n<i>%s…</i> (%d с)",
+	"selecting items":                     "отбор новостей",
+	"refining summaries":                  "уточнение пересказов",
+	"clustering":                          "кластеризация",
+	"summaries":                           "сводки",
+	"rendering":                           "вёрстка",
+	"✖️ Cancel":                           "✖️ Отменить",
+	"✖️ Preview cancelled.":               "✖️ Предпросмотр отменён.",
+	"✅ Preview built in %ds.":             "✅ Предпросмотр собран за %d с.",
+	"Preview already finished.":           "Предпросмотр уже готов.",
+	"Cancelling preview…":                 "Отменяю предпросмотр…",
+	"Usage: <code>/preview [diff]</code>": "Использование: <code>/preview [diff]</code>",
+	"ℹ️ No digest has been posted yet, so everything in the preview is new.":                                                          "ℹ️ Дайджесты ещё не публиковались, поэтому всё в предпросмотре новое.",
+	"❌ Error loading the last posted digest: %s":                                                                                      "❌ Ошибка загрузки последнего опубликованного дайджеста: %s",
+	"🔀 <b>Preview diff</b> against the digest posted %s\nLast digest: %s – %s, %d items, %d clusters\nPreview: %d items, %d clusters": "🔀 <b>Сравнение предпросмотра</b> с дайджестом от %s\nПоследний дайджест: %s – %s, новостей: %d, кластеров: %d\nПредпросмотр: новостей: %d, кластеров: %d",
+	"✅ Nothing in the preview repeats the last posted digest.":                                                                        "✅ Ничто в предпросмотре не повторяет последний опубликованный дайджест.",
+	"🔁 <b>Would repeat (%d)</b>":                                                                                                      "🔁 <b>Повторятся (%d)</b>",
+	"🆕 <b>New items (%d)</b>":                                                                                                         "🆕 <b>Новые новости (%d)</b>",
+	"…and %d more":                                                                                                                    "…и ещё %d",
+	"🧩 <b>Clusters</b>: %d new, %d would repeat":                                                                                      "🧩 <b>Кластеры</b>: новых %d, повторятся %d",
+	"↳ already posted as the same item":                                                                                               "↳ уже опубликована эта же новость",
+	"↳ already posted: %s (similarity %.2f)":                                                                                          "↳ уже опубликовано: %s (сходство %.2f)",
+	"same topic":                                      "та же тема",
+	"%d of %d items repeat":                           "повторяются %d из %d новостей",
+	"Error fetching settings: %s":                     "Ошибка получения настроек: %s",
+	"Usage: <code>/settings reset &lt;key&gt;</code>": "Использование: <code>/settings reset &lt;key&gt;</code>",
+	"❌ Error resetting setting: %s":                   "❌ Ошибка сброса настройки: %s",
+	"✅ Setting <code>%s</code> has been reset to default (env var value).": "✅ Настройка <code>%s</code> сброшена к значению по умолчанию (из переменной окружения).",
+	"❓ Unknown help topic: <code>%s</code>\n\n%s":                          "❓ Неизвестный раздел справки: <code>%s</code>\n\n%s",
+
+	// Research
+	"❌ Research dashboard is not configured. Set EXPANDED_VIEW_SIGNING_SECRET and EXPANDED_VIEW_BASE_URL.": "❌ Исследовательская панель не настроена. Задайте EXPANDED_VIEW_SIGNING_SECRET и EXPANDED_VIEW_BASE_URL.",
+	"❌ Failed to generate login token: %s": "❌ Не удалось создать токен входа: %s",
+	"🔐 <b>Research Login</b>\n%s":          "🔐 <b>Вход в исследовательскую панель</b>\n%s",
+	"❌ Research rebuild failed: %s":        "❌ Ошибка перестроения исследовательских данных: %s",
+	"✅ Research rebuild complete.":         "✅ Исследовательские данные перестроены.",
+
+	// Errors, history and retries
+	"❌ Error fetching errors: %s":                                    "❌ Ошибка получения списка ошибок: %s",
+	"✅ No recent errors found.":                                      "✅ Недавних ошибок нет.",
+	"❌ Error fetching history: %s":                                   "❌ Ошибка получения истории: %s",
+	"📋 No setting history found.":                                    "📋 История настроек пуста.",
+	"❌ Error retrying item %s: %s":                                   "❌ Ошибка повторной обработки элемента %s: %s",
+	"✅ Item <code>%s</code> has been requeued.":                      "✅ Элемент <code>%s</code> снова поставлен в очередь.",
+	"✅ No failed items found to retry.":                              "✅ Нет неудачных элементов для повторной обработки.",
+	"✅ All failed pipeline items have been requeued for processing.": "✅ Все неудачные элементы конвейера снова поставлены в очередь.",
+	"❌ Error counting failed items: %s":                              "❌ Ошибка подсчёта неудачных элементов: %s",
+	"✅ No failed enrichment items found.":                            "✅ Неудачных элементов обогащения нет.",
+	"⚠️ <code>%d</code> failed enrichment items found.\n\nUse <code>/retry enrichment confirm</code> to requeue all.": "⚠️ Найдено неудачных элементов обогащения: <code>%d</code>.\n\nВыполните <code>/retry enrichment confirm</code>, чтобы поставить все в очередь.",
+	"✅ No failed enrichment items to retry.":                      "✅ Нет неудачных элементов обогащения для повтора.",
+	"✅ Requeued <code>%d</code> enrichment items for processing.": "✅ В очередь на обработку снова поставлено элементов обогащения: <code>%d</code>.",
+}
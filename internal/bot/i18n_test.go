@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTr(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, "Error: boom", tr(ctx, ErrGenericFmt, "boom"))
+
+	ru := withLanguage(ctx, "RU")
+	require.Equal(t, "Ошибка: boom", tr(ru, ErrGenericFmt, "boom"))
+	require.Equal(t, "untranslated", tr(ru, "untranslated"))
+	require.Equal(t, "Error: boom", tr(withLanguage(ctx, "xx"), ErrGenericFmt, "boom"))
+}
+
+// TestBotCatalogs checks that every tr message has a translation and that
+// translations keep the format verbs of the English message.
+func TestBotCatalogs(t *testing.T) {
+	messages := collectTrMessages(t)
+	require.NotEmpty(t, messages)
+
+	for lang, catalog := range botCatalogs {
+		if lang == botLanguageDefault {
+			continue
+		}
+
+		for msg := range messages {
+			translated, ok := catalog[msg]
+			require.True(t, ok, "%s: missing translation for %q", lang, msg)
+			require.Equal(t, formatVerbs(msg), formatVerbs(translated), "%s: format verbs differ for %q", lang, msg)
+		}
+
+		for msg := range catalog {
+			require.True(t, messages[msg], "%s: unused translation for %q", lang, msg)
+		}
+	}
+}
+
+// collectTrMessages returns the constant messages passed to tr in the package.
+func collectTrMessages(t *testing.T) map[string]bool {
+	t.Helper()
+
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	consts := make(map[string]ast.Expr)
+
+	var parsed []*ast.File
+
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		require.NoError(t, err)
+
+		parsed = append(parsed, f)
+
+		for _, spec := range constSpecs(f) {
+			for i, ident := range spec.Names {
+				if i < len(spec.Values) {
+					consts[ident.Name] = spec.Values[i]
+				}
+			}
+		}
+	}
+
+	messages := make(map[string]bool)
+
+	for _, f := range parsed {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) < 2 {
+				return true
+			}
+
+			if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "tr" {
+				msg, ok := constString(call.Args[1], consts)
+				require.True(t, ok, "tr message at %s is not a constant", fset.Position(call.Pos()))
+
+				messages[msg] = true
+			}
+
+			return true
+		})
+	}
+
+	return messages
+}
+
+func constSpecs(f *ast.File) []*ast.ValueSpec {
+	var specs []*ast.ValueSpec
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			specs = append(specs, spec.(*ast.ValueSpec))
+		}
+	}
+
+	return specs
+}
+
+func constString(expr ast.Expr, consts map[string]ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		s, err := strconv.Unquote(e.Value)
+
+		return s, err == nil && e.Kind == token.STRING
+	case *ast.Ident:
+		if value, ok := consts[e.Name]; ok {
+			return constString(value, consts)
+		}
+	case *ast.BinaryExpr:
+		left, okLeft := constString(e.X, consts)
+		right, okRight := constString(e.Y, consts)
+
+		return left + right, okLeft && okRight && e.Op == token.ADD
+	}
+
+	return "", false
+}
+
+// formatVerbs returns the fmt verbs of a format string in order.
+func formatVerbs(format string) []string {
+	var verbs []string
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 >= len(format) {
+			continue
+		}
+
+		verbs = append(verbs, format[i+1:i+2])
+		i++
+	}
+
+	return verbs
+}
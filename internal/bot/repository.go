@@ -11,21 +11,54 @@ import (
 type Repository interface {
 	// Settings operations
 	GetSetting(ctx context.Context, key string, target interface{}) error
+	SaveSetting(ctx context.Context, key string, value interface{}) error
 	SaveSettingWithHistory(ctx context.Context, key string, value interface{}, userID int64) error
 	DeleteSettingWithHistory(ctx context.Context, key string, userID int64) error
 	GetAllSettings(ctx context.Context) (map[string]interface{}, error)
 	GetRecentSettingHistory(ctx context.Context, limit int) ([]db.SettingHistory, error)
+	GetSettingHistoryEntry(ctx context.Context, id int64) (*db.SettingHistory, error)
+	GetRollbackPointBySteps(ctx context.Context, scope db.RollbackScope, steps int) (int64, error)
+	GetRollbackPointAt(ctx context.Context, scope db.RollbackScope, at time.Time) (int64, error)
+	GetRollbackPlan(ctx context.Context, scope db.RollbackScope, fromID int64) ([]db.SettingRollback, error)
+	RollbackSettings(ctx context.Context, scope db.RollbackScope, fromID, changedBy int64) ([]db.SettingRollback, error)
+	GetWhatIfItems(ctx context.Context, since, until time.Time) ([]db.WhatIfItem, error)
+	GetQualityEvaluations(ctx context.Context, since, until time.Time) ([]db.QualityEvaluation, error)
+	GetModelPromptEvents(ctx context.Context, since, until time.Time) ([]db.ModelPromptEvent, error)
 
 	// Rating operations
 	SaveRating(ctx context.Context, digestID string, userID int64, rating int16, feedback string) error
+	SaveShadowRating(ctx context.Context, digestID string, userID int64, rating int16) (bool, error)
+	GetShadowComparison(ctx context.Context, since time.Time) ([]db.VariantRatingStats, error)
 	SaveItemRating(ctx context.Context, itemID string, userID int64, rating, feedback, source string) error
 	GetItemRatingSummary(ctx context.Context, since time.Time) ([]db.RatingSummary, error)
 	GetLatestChannelRatingStats(ctx context.Context, limit int) ([]db.RatingStatsSummary, error)
+	GetChannelClickStats(ctx context.Context, since time.Time) ([]db.ChannelClickStats, error)
+	GetClickThroughByImportance(ctx context.Context, since time.Time) ([]db.ImportanceClickStats, error)
+	GetAudienceSnapshots(ctx context.Context, since, until time.Time) ([]db.AudienceSnapshot, error)
+	GetDigestEngagement(ctx context.Context, since, until time.Time) ([]db.DigestEngagement, error)
 	GetLatestGlobalRatingStats(ctx context.Context) (*db.GlobalRatingStats, error)
 
 	// Channel operations
 	GetActiveChannels(ctx context.Context) ([]db.Channel, error)
+	ListChannelsPage(ctx context.Context, filter db.ChannelListFilter, offset, limit int) ([]db.ChannelListEntry, int, error)
+	ImportChannel(ctx context.Context, c db.ChannelImport) error
+	CreateChannelGroup(ctx context.Context, name string) error
+	DeleteChannelGroup(ctx context.Context, name string) error
+	ListChannelGroups(ctx context.Context) ([]db.ChannelGroup, error)
+	SetChannelGroupThresholds(ctx context.Context, name string, relevance, importance float32) error
+	SetChannelGroupDigest(ctx context.Context, name string, chatID int64, window string) error
+	SetChannelGroup(ctx context.Context, identifier, name string) (bool, error)
+	SnoozeChannel(ctx context.Context, identifier string, until time.Time, ingestion bool) (bool, error)
+	UnsnoozeChannel(ctx context.Context, identifier string) (bool, error)
+	StartChannelTrial(ctx context.Context, identifier string, until time.Time) (bool, error)
+	ListChannelTrials(ctx context.Context) ([]db.ChannelTrial, error)
+	GetChannelTrial(ctx context.Context, identifier string) (db.ChannelTrial, error)
+	GetChannelTrialReport(ctx context.Context, trial db.ChannelTrial, importanceThreshold float32, samples int) (db.ChannelTrialReport, error)
+	EndChannelTrial(ctx context.Context, channelID string, promote bool) error
+	PurgeChannel(ctx context.Context, identifier string, purgedBy int64, dryRun bool) (*db.ChannelPurgeReport, error)
+	GetChannelGroupStats(ctx context.Context, since time.Time) ([]db.ChannelGroupStats, error)
 	CountActiveChannels(ctx context.Context) (int, error)
+	CountDeadChannels(ctx context.Context) (int, error)
 	CountRecentlyActiveChannels(ctx context.Context) (int, error)
 	GetChannelStats(ctx context.Context) (map[string]db.ChannelStats, error)
 	AddChannelByUsername(ctx context.Context, username string) error
@@ -37,6 +70,15 @@ type Repository interface {
 	UpdateChannelRelevanceDelta(ctx context.Context, channelID string, delta float32, enabled bool) error
 	GetChannelWeight(ctx context.Context, identifier string) (*db.ChannelWeight, error)
 	UpdateChannelWeight(ctx context.Context, identifier string, weight float32, autoEnabled, override bool, reason string, userID int64) (*db.UpdateChannelWeightResult, error)
+	GetChannelQuota(ctx context.Context, channelID string) (*db.ChannelQuota, error)
+	SetChannelQuota(ctx context.Context, channelID string, limit int, period, policy string) error
+	ClearChannelQuota(ctx context.Context, channelID string) error
+	CountChannelQuotaOverflow(ctx context.Context, channelID string, since time.Time) (dropped, deferred int, err error)
+
+	// Setup wizard operations
+	GetSetupWizardState(ctx context.Context, adminID int64) (db.SetupWizardState, error)
+	SaveSetupWizardStep(ctx context.Context, adminID int64, step string) error
+	DeleteSetupWizardState(ctx context.Context, adminID int64) error
 
 	// Filter operations
 	GetActiveFilters(ctx context.Context) ([]db.Filter, error)
@@ -51,10 +93,33 @@ type Repository interface {
 	GetImportanceStats(ctx context.Context, since time.Time, threshold float32) (db.ImportanceStats, error)
 	GetTopItemScores(ctx context.Context, since time.Time, limit int) ([]db.ItemScore, error)
 	GetScoreDebugStats(ctx context.Context, since time.Time) (db.ScoreDebugStats, error)
+	GetScoreDistributions(ctx context.Context, versions []string) ([]db.ScoreDistribution, error)
+	GetEnsembleStats(ctx context.Context, since time.Time) (db.EnsembleStats, error)
+	GetPrefilterRuleHits(ctx context.Context) (map[string]db.PrefilterRuleHit, error)
+	ResetPrefilterRuleHits(ctx context.Context, rules []string) error
 	GetItemStatusStats(ctx context.Context, since time.Time) (db.ItemStatusStats, error)
-	GetDropReasonStats(ctx context.Context, since time.Time, limit int) ([]db.DropReasonStat, error)
+	GetDropReasonBreakdown(ctx context.Context, since time.Time, group string) ([]db.DropReasonBreakdownRow, error)
+	GetSafetyFlagStats(ctx context.Context, since time.Time) ([]db.DropReasonStat, error)
+	GetPipelineShadowAgreement(ctx context.Context, since time.Time) ([]db.PipelineShadowAgreement, error)
 	SearchItemsByText(ctx context.Context, query string, limit int) ([]db.ItemSearchResult, error)
 	GetItemDebugDetail(ctx context.Context, id string) (*db.ItemDebugDetail, error)
+	GetItemEmbedding(ctx context.Context, itemID string) ([]float32, error)
+	GetDedupDecisions(ctx context.Context, filter db.DedupDecisionFilter) ([]db.DedupDecision, error)
+	FindNearestItems(ctx context.Context, embedding []float32, excludeItemID string, limit int) ([]db.SimilarItem, error)
+	ListStories(ctx context.Context, limit int) ([]db.Story, error)
+	GetStory(ctx context.Context, storyID string) (*db.Story, error)
+	GetStoryTimeline(ctx context.Context, storyID string) ([]db.StoryEvent, error)
+	ResolveItemDeepLink(ctx context.Context, code string) (string, error)
+	GetEvidenceForItems(ctx context.Context, itemIDs []string) (map[string][]db.ItemEvidenceWithSource, error)
+	SaveItemTicket(ctx context.Context, ticket db.ItemTicket) error
+	GetLastPostedDigestContent(ctx context.Context) (*db.PostedDigest, error)
+	MatchPostedDigestItems(ctx context.Context, digestID string, itemIDs []string, threshold float32) (map[string]db.PostedItemMatch, error)
+	SaveCatchupSchedule(ctx context.Context, schedule db.CatchupSchedule) error
+	GetCatchupSchedule(ctx context.Context, userID int64) (*db.CatchupSchedule, error)
+	ListCatchupSchedules(ctx context.Context) ([]db.CatchupSchedule, error)
+	DeleteCatchupSchedule(ctx context.Context, userID int64) (bool, error)
+	MarkCatchupScheduleDelivered(ctx context.Context, userID int64, windowStart time.Time) error
+	GetClusterForItem(ctx context.Context, itemID string) (*db.ClusterWithItems, []db.ClusterItemInfo, error)
 	GetLinksForMessage(ctx context.Context, rawMessageID string) ([]db.ResolvedLink, error)
 	GetRecentMessagesForChannel(ctx context.Context, channelID string, before time.Time, limit int) ([]string, error)
 
@@ -79,6 +144,10 @@ type Repository interface {
 	GetRecentErrors(ctx context.Context, limit int) ([]db.Item, error)
 	ClearDigestErrors(ctx context.Context) error
 	GetDigestCoverImage(ctx context.Context, start, end time.Time, threshold float32) ([]byte, error)
+	GetCachedDigestCover(ctx context.Context, key string) ([]byte, bool, error)
+	SaveCachedDigestCover(ctx context.Context, key string, image []byte) error
+	GetCachedMediaCollage(ctx context.Context, key string) ([]byte, bool, error)
+	SaveCachedMediaCollage(ctx context.Context, key string, itemIDs []string, image []byte) error
 	GetItemsForWindowWithMedia(ctx context.Context, start, end time.Time, threshold float32, limit int) ([]db.ItemWithMedia, error)
 
 	// Discovery operations
@@ -91,14 +160,34 @@ type Repository interface {
 	RejectDiscovery(ctx context.Context, username string, userID int64) error
 	GetDiscoveryStats(ctx context.Context) (*db.DiscoveryStats, error)
 	GetDiscoveryFilterStats(ctx context.Context, minSeen int, minEngagement float32) (*db.DiscoveryFilterStats, error)
+	GetChannelRecommendations(ctx context.Context, limit int) ([]db.ChannelRecommendation, error)
+	GetDiscoveryAutoDecisions(ctx context.Context, limit int) ([]db.DiscoveryAutoDecisionEntry, error)
 	IsChannelTracked(ctx context.Context, username string, peerID int64, inviteLink string) (bool, error)
 
 	// LLM usage operations
 	GetDailyLLMUsage(ctx context.Context) (*db.LLMUsageSummary, error)
 	GetMonthlyLLMUsage(ctx context.Context) (*db.LLMUsageSummary, error)
+	GetSummaryRefinementStats(ctx context.Context, since time.Time, refineModel string) (db.SummaryRefinementStats, error)
 
 	// Research operations
 	RefreshResearchMaterializedViews(ctx context.Context) error
+	SearchResearchItems(ctx context.Context, params db.ResearchSearchParams) ([]db.ResearchItemSearchResult, *db.ResearchSearchResultCount, error)
+
+	// Search watch operations
+	CreateSearchWatch(ctx context.Context, query, channel, topic string, createdBy int64) (int64, error)
+	ListSearchWatches(ctx context.Context) ([]db.SearchWatch, error)
+	DeleteSearchWatch(ctx context.Context, id int64) error
+
+	// Watchlist operations
+	CreateWatchlistEntity(ctx context.Context, name string, aliases []string, entityType string, createdBy int64) (int64, error)
+	ListWatchlistEntities(ctx context.Context) ([]db.WatchlistEntity, error)
+	DeleteWatchlistEntity(ctx context.Context, id int64) error
+
+	// Research API key operations
+	CreateResearchAPIKey(ctx context.Context, name, keyHash, scope string, ratePerMinute int, createdBy int64) (int64, error)
+	ListResearchAPIKeys(ctx context.Context) ([]db.ResearchAPIKey, error)
+	DeleteResearchAPIKey(ctx context.Context, name string) error
+	SetResearchAPIKeyRate(ctx context.Context, name string, ratePerMinute int) error
 }
 
 // Compile-time assertion that *db.DB implements Repository.
@@ -0,0 +1,309 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+)
+
+// Send queue constants.
+const (
+	// chatRateBurst is how many messages a chat may receive back to back
+	// before its per-minute rate applies.
+	chatRateBurst = 3
+	// chatQueueIdleTimeout is how long a chat queue outlives its last message.
+	chatQueueIdleTimeout = time.Minute
+	// chatQueueSize is the number of messages a chat queue buffers.
+	chatQueueSize = 64
+	// secondsPerMinute converts per-minute chat rates to limiter rates.
+	secondsPerMinute = 60
+)
+
+// Send outcomes, used as metric labels.
+const (
+	sendStatusSuccess   = "success"
+	sendStatusError     = "error"
+	sendStatusFloodWait = "flood_wait"
+)
+
+// telegramSender is the part of the Telegram bot API that sends to chats.
+type telegramSender interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
+// SendQueueConfig sets the rate limits of outbound Telegram sends.
+type SendQueueConfig struct {
+	// GlobalRate is the number of requests per second across all chats.
+	GlobalRate float64
+	// PrivateChatRate is the number of messages per minute to one user.
+	PrivateChatRate float64
+	// GroupChatRate is the number of messages per minute to one group or channel.
+	GroupChatRate float64
+	// MaxRetries is how many times a flood-waited request is retried.
+	MaxRetries int
+	// MaxRetryAfter is the longest retry_after honored; longer flood waits fail.
+	MaxRetryAfter time.Duration
+}
+
+// sendQueueConfigFromEnv reads the send queue settings from the configuration.
+func sendQueueConfigFromEnv(cfg *config.Config) SendQueueConfig {
+	return SendQueueConfig{
+		GlobalRate:      cfg.TelegramSendRate,
+		PrivateChatRate: cfg.TelegramPrivateChatRate,
+		GroupChatRate:   cfg.TelegramGroupChatRate,
+		MaxRetries:      cfg.TelegramSendMaxRetries,
+		MaxRetryAfter:   cfg.TelegramMaxRetryAfter,
+	}
+}
+
+// sendQueue routes every outbound Telegram request through global and
+// per-chat rate limits. Requests to a chat run one at a time in the order they
+// were submitted, so a flood wait delays later messages to that chat instead
+// of reordering them. Requests without a chat, such as callback answers, only
+// take the global limit.
+type sendQueue struct {
+	api    telegramSender
+	cfg    SendQueueConfig
+	global *rate.Limiter
+	sleep  func(d time.Duration)
+	logger *zerolog.Logger
+
+	mu    sync.Mutex
+	chats map[int64]*chatQueue
+}
+
+// chatQueue holds the pending requests of one chat.
+type chatQueue struct {
+	jobs    chan *sendJob
+	limiter *rate.Limiter
+	pending int
+}
+
+// sendJob is a request waiting in a chat queue.
+type sendJob struct {
+	call     func() error
+	queuedAt time.Time
+	done     chan error
+}
+
+func newSendQueue(api telegramSender, cfg SendQueueConfig, logger *zerolog.Logger) *sendQueue {
+	return &sendQueue{
+		api:    api,
+		cfg:    cfg,
+		global: newRateLimiter(cfg.GlobalRate, int(math.Max(cfg.GlobalRate, 1))),
+		sleep:  time.Sleep,
+		logger: logger,
+		chats:  make(map[int64]*chatQueue),
+	}
+}
+
+// newRateLimiter returns a limiter of ratePerSecond, or an unlimited one when
+// the rate is not positive.
+func newRateLimiter(ratePerSecond float64, burst int) *rate.Limiter {
+	if ratePerSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	return rate.NewLimiter(rate.Limit(ratePerSecond), burst)
+}
+
+// Send sends a message once the rate limits allow it.
+func (q *sendQueue) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	var msg tgbotapi.Message
+
+	err := q.submit(c, func() error {
+		var err error
+
+		msg, err = q.api.Send(c)
+		if err != nil {
+			return fmt.Errorf("send telegram message: %w", err)
+		}
+
+		return nil
+	})
+
+	return msg, err
+}
+
+// Request makes an API request once the rate limits allow it.
+func (q *sendQueue) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	var resp *tgbotapi.APIResponse
+
+	err := q.submit(c, func() error {
+		var err error
+
+		resp, err = q.api.Request(c)
+		if err != nil {
+			return fmt.Errorf("telegram request: %w", err)
+		}
+
+		return nil
+	})
+
+	return resp, err
+}
+
+// submit runs call in the queue of the chat c is addressed to and waits for it.
+func (q *sendQueue) submit(c tgbotapi.Chattable, call func() error) error {
+	chatID := chattableChatID(c)
+	if chatID == 0 {
+		return q.execute(nil, call, time.Now())
+	}
+
+	job := &sendJob{call: call, queuedAt: time.Now(), done: make(chan error, 1)}
+
+	q.enqueue(chatID, job)
+
+	return <-job.done
+}
+
+// enqueue adds a job to a chat queue, starting its worker if needed.
+func (q *sendQueue) enqueue(chatID int64, job *sendJob) {
+	q.mu.Lock()
+
+	chat, ok := q.chats[chatID]
+	if !ok {
+		chat = &chatQueue{
+			jobs:    make(chan *sendJob, chatQueueSize),
+			limiter: q.chatLimiter(chatID),
+		}
+		q.chats[chatID] = chat
+
+		go q.runChat(chatID, chat)
+	}
+
+	// Counting the job before it is queued keeps the worker from retiring
+	// while the job is on its way.
+	chat.pending++
+	q.mu.Unlock()
+
+	chat.jobs <- job
+}
+
+// chatLimiter returns the limiter of a chat. Group and channel IDs are negative.
+func (q *sendQueue) chatLimiter(chatID int64) *rate.Limiter {
+	perMinute := q.cfg.PrivateChatRate
+	if chatID < 0 {
+		perMinute = q.cfg.GroupChatRate
+	}
+
+	return newRateLimiter(perMinute/secondsPerMinute, chatRateBurst)
+}
+
+// runChat sends the jobs of a chat in order and retires once the chat has been
+// idle for chatQueueIdleTimeout.
+func (q *sendQueue) runChat(chatID int64, chat *chatQueue) {
+	idle := time.NewTimer(chatQueueIdleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case job := <-chat.jobs:
+			job.done <- q.execute(chat.limiter, job.call, job.queuedAt)
+
+			q.mu.Lock()
+			chat.pending--
+			q.mu.Unlock()
+
+			idle.Reset(chatQueueIdleTimeout)
+		case <-idle.C:
+			q.mu.Lock()
+
+			if chat.pending == 0 {
+				delete(q.chats, chatID)
+				q.mu.Unlock()
+
+				return
+			}
+
+			q.mu.Unlock()
+			idle.Reset(chatQueueIdleTimeout)
+		}
+	}
+}
+
+// execute waits for the rate limits and runs call, retrying it after the
+// retry_after of flood-wait errors.
+func (q *sendQueue) execute(chatLimiter *rate.Limiter, call func() error, queuedAt time.Time) error {
+	ctx := context.Background()
+
+	for attempt := 0; ; attempt++ {
+		if chatLimiter != nil {
+			if err := chatLimiter.Wait(ctx); err != nil {
+				return fmt.Errorf("wait for chat send rate: %w", err)
+			}
+		}
+
+		if err := q.global.Wait(ctx); err != nil {
+			return fmt.Errorf("wait for global send rate: %w", err)
+		}
+
+		if attempt == 0 {
+			observability.TelegramSendWait.Observe(time.Since(queuedAt).Seconds())
+		}
+
+		err := call()
+
+		retryAfter, flooded := floodWait(err)
+		if !flooded {
+			status := sendStatusSuccess
+			if err != nil {
+				status = sendStatusError
+			}
+
+			observability.TelegramSends.WithLabelValues(status).Inc()
+
+			return err
+		}
+
+		observability.TelegramSends.WithLabelValues(sendStatusFloodWait).Inc()
+
+		if attempt >= q.cfg.MaxRetries || retryAfter > q.cfg.MaxRetryAfter {
+			return err
+		}
+
+		q.logger.Warn().Dur("retry_after", retryAfter).Int("attempt", attempt+1).Msg("Telegram flood wait, retrying send")
+
+		q.sleep(retryAfter)
+	}
+}
+
+// floodWait returns the retry_after of a Telegram "too many requests" error.
+func floodWait(err error) (time.Duration, bool) {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(apiErr.RetryAfter) * time.Second, true
+}
+
+// chattableChatID returns the chat a request is addressed to, or 0 for
+// requests that are not sent to a chat.
+func chattableChatID(c tgbotapi.Chattable) int64 {
+	switch v := c.(type) {
+	case tgbotapi.MessageConfig:
+		return v.ChatID
+	case tgbotapi.PhotoConfig:
+		return v.ChatID
+	case tgbotapi.DocumentConfig:
+		return v.ChatID
+	case tgbotapi.EditMessageTextConfig:
+		return v.ChatID
+	case tgbotapi.EditMessageReplyMarkupConfig:
+		return v.ChatID
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender records sent texts and replies with queued errors.
+type fakeSender struct {
+	mu      sync.Mutex
+	sent    []string
+	errs    []error
+	release chan struct{}
+}
+
+func (f *fakeSender) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	if f.release != nil {
+		<-f.release
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+
+		if err != nil {
+			return tgbotapi.Message{}, err
+		}
+	}
+
+	if msg, ok := c.(tgbotapi.MessageConfig); ok {
+		f.sent = append(f.sent, msg.Text)
+	}
+
+	return tgbotapi.Message{MessageID: len(f.sent)}, nil
+}
+
+func (f *fakeSender) Request(tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	return &tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func floodError(retryAfter int) error {
+	return &tgbotapi.Error{
+		Code:               http.StatusTooManyRequests,
+		Message:            "Too Many Requests",
+		ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: retryAfter},
+	}
+}
+
+func newTestSendQueue(api telegramSender, cfg SendQueueConfig) (*sendQueue, *[]time.Duration) {
+	logger := zerolog.Nop()
+	queue := newSendQueue(api, cfg, &logger)
+
+	var slept []time.Duration
+
+	queue.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	return queue, &slept
+}
+
+func TestSendQueueHonorsRetryAfter(t *testing.T) {
+	api := &fakeSender{errs: []error{floodError(2), floodError(1)}}
+	queue, slept := newTestSendQueue(api, SendQueueConfig{MaxRetries: 3, MaxRetryAfter: time.Minute})
+
+	sent, err := queue.Send(tgbotapi.NewMessage(42, "hello"))
+	require.NoError(t, err)
+	require.Equal(t, 1, sent.MessageID)
+	require.Equal(t, []string{"hello"}, api.sent)
+	require.Equal(t, []time.Duration{2 * time.Second, time.Second}, *slept)
+}
+
+func TestSendQueueGivesUpOnLongFloodWait(t *testing.T) {
+	api := &fakeSender{errs: []error{floodError(120)}}
+	queue, slept := newTestSendQueue(api, SendQueueConfig{MaxRetries: 3, MaxRetryAfter: time.Minute})
+
+	_, err := queue.Send(tgbotapi.NewMessage(42, "hello"))
+
+	var apiErr *tgbotapi.Error
+	require.True(t, errors.As(err, &apiErr))
+	require.Empty(t, *slept)
+
+	api = &fakeSender{errs: []error{floodError(1), floodError(1)}}
+	queue, slept = newTestSendQueue(api, SendQueueConfig{MaxRetries: 1, MaxRetryAfter: time.Minute})
+
+	_, err = queue.Send(tgbotapi.NewMessage(42, "hello"))
+	require.Error(t, err)
+	require.Len(t, *slept, 1)
+}
+
+func TestSendQueuePreservesChatOrder(t *testing.T) {
+	api := &fakeSender{release: make(chan struct{})}
+	queue, _ := newTestSendQueue(api, SendQueueConfig{})
+
+	var wg sync.WaitGroup
+
+	texts := []string{"first", "second", "third", "fourth", "fifth"}
+
+	// Submit one message at a time, waiting for each to be queued, while the
+	// first send is blocked.
+	for i, text := range texts {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := queue.Send(tgbotapi.NewMessage(-100, text)); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		require.Eventually(t, func() bool {
+			queue.mu.Lock()
+			defer queue.mu.Unlock()
+
+			chat, ok := queue.chats[-100]
+
+			return ok && chat.pending == i+1
+		}, time.Second, time.Millisecond)
+	}
+
+	for range texts {
+		api.release <- struct{}{}
+	}
+
+	wg.Wait()
+
+	require.Equal(t, texts, api.sent)
+}
+
+func TestChattableChatID(t *testing.T) {
+	require.Equal(t, int64(7), chattableChatID(tgbotapi.NewMessage(7, "text")))
+	require.Equal(t, int64(-100), chattableChatID(tgbotapi.NewEditMessageText(-100, 1, "text")))
+	require.Zero(t, chattableChatID(tgbotapi.NewCallback("id", "text")))
+}
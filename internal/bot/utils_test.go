@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
@@ -598,6 +599,8 @@ func TestFormatDigestItemCaption(t *testing.T) {
 	tests := []struct {
 		name         string
 		item         digest.RichDigestItem
+		altTextMode  string
+		safetyPolicy string
 		wantContains []string
 	}{
 		{
@@ -652,11 +655,66 @@ func TestFormatDigestItemCaption(t *testing.T) {
 			},
 			wantContains: []string{"Link test", "https://t.me/linkchannel/100"},
 		},
+		{
+			name: "cluster entry",
+			item: digest.RichDigestItem{
+				Summary: "Cluster story",
+				Channel: "clusterchannel",
+				Related: 2,
+			},
+			wantContains: []string{"Cluster story", "@clusterchannel</i>\n   ↳ <i>+2 related</i>"},
+		},
+		{
+			name: "video item",
+			item: digest.RichDigestItem{
+				Summary:       "Video story",
+				VideoDuration: 83,
+			},
+			wantContains: []string{"▶️ 1:23 Video story"},
+		},
+		{
+			name: "alt text caption",
+			item: digest.RichDigestItem{
+				Summary:   "Photo story",
+				MediaData: []byte{1},
+				AltText:   "Crowd at a <rally>",
+			},
+			altTextMode:  digest.AltTextCaption,
+			wantContains: []string{"Photo story\n🖼 <i>Crowd at a &lt;rally&gt;</i>"},
+		},
+		{
+			name: "alt text spoiler",
+			item: digest.RichDigestItem{
+				Summary:   "Photo story",
+				MediaData: []byte{1},
+				AltText:   "Flooded street",
+			},
+			altTextMode:  digest.AltTextSpoiler,
+			wantContains: []string{"🖼 <tg-spoiler>Flooded street</tg-spoiler>"},
+		},
+		{
+			name: "sensitive item under blur",
+			item: digest.RichDigestItem{
+				Summary:   "Aftermath of the strike",
+				Sensitive: true,
+			},
+			safetyPolicy: filters.SafetyPolicyBlur,
+			wantContains: []string{"⚠️ <tg-spoiler>Aftermath of the strike</tg-spoiler>"},
+		},
+		{
+			name: "sensitive item under warn",
+			item: digest.RichDigestItem{
+				Summary:   "Aftermath of the strike",
+				Sensitive: true,
+			},
+			safetyPolicy: filters.SafetyPolicyWarn,
+			wantContains: []string{"⚠️ <i>Sensitive:</i> Aftermath of the strike"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatDigestItemCaption(tt.item)
+			got := formatDigestItemCaption(tt.item, captionOptions{altTextMode: tt.altTextMode, safetyPolicy: tt.safetyPolicy})
 
 			for _, s := range tt.wantContains {
 				if !strings.Contains(got, s) {
@@ -666,3 +724,30 @@ func TestFormatDigestItemCaption(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatVideoDuration(t *testing.T) {
+	tests := map[int]string{5: "0:05", 83: "1:23", 3600: "1:00:00", 3725: "1:02:05"}
+
+	for seconds, want := range tests {
+		if got := formatVideoDuration(seconds); got != want {
+			t.Errorf("formatVideoDuration(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestDigestMessageLink(t *testing.T) {
+	tests := []struct {
+		chatID int64
+		want   string
+	}{
+		{chatID: -1001234567890, want: "https://t.me/c/1234567890/42"},
+		{chatID: -123456, want: ""},
+		{chatID: 987654, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := digestMessageLink(tt.chatID, 42); got != tt.want {
+			t.Errorf("digestMessageLink(%d, 42) = %q, want %q", tt.chatID, got, tt.want)
+		}
+	}
+}
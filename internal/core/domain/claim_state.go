@@ -0,0 +1,39 @@
+package domain
+
+// Claim lifecycle states tracked by the rumor watch.
+const (
+	ClaimUnverified   = "unverified"
+	ClaimCorroborated = "corroborated"
+	ClaimDisputed     = "disputed"
+	ClaimDebunked     = "debunked"
+)
+
+// NextClaimState returns the state a claim moves to given the verdict
+// aggregated from the stances of its evidence (see AggregateStanceVerdict).
+// Claims without a verdict keep their state, so expired evidence never sends
+// a claim back to unverified. A debunked claim only reopens as disputed when
+// the evidence turns supportive.
+func NextClaimState(current, verdict string) string {
+	if current == "" {
+		current = ClaimUnverified
+	}
+
+	if current == ClaimDebunked {
+		if verdict == VerdictSupported {
+			return ClaimDisputed
+		}
+
+		return ClaimDebunked
+	}
+
+	switch verdict {
+	case VerdictSupported:
+		return ClaimCorroborated
+	case VerdictDisputed:
+		return ClaimDisputed
+	case VerdictRefuted:
+		return ClaimDebunked
+	}
+
+	return current
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestNextClaimState(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		verdict string
+		want    string
+	}{
+		{name: "new claim without verdict", current: "", verdict: VerdictUnverified, want: ClaimUnverified},
+		{name: "supported", current: ClaimUnverified, verdict: VerdictSupported, want: ClaimCorroborated},
+		{name: "corroborated then disputed", current: ClaimCorroborated, verdict: VerdictDisputed, want: ClaimDisputed},
+		{name: "disputed then refuted", current: ClaimDisputed, verdict: VerdictRefuted, want: ClaimDebunked},
+		{name: "no verdict keeps state", current: ClaimCorroborated, verdict: VerdictUnverified, want: ClaimCorroborated},
+		{name: "debunked stays on dispute", current: ClaimDebunked, verdict: VerdictDisputed, want: ClaimDebunked},
+		{name: "debunked reopens on support", current: ClaimDebunked, verdict: VerdictSupported, want: ClaimDisputed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextClaimState(tt.current, tt.verdict); got != tt.want {
+				t.Errorf("NextClaimState(%q, %q) = %q, want %q", tt.current, tt.verdict, got, tt.want)
+			}
+		})
+	}
+}
@@ -41,6 +41,22 @@ type RawMessage struct {
 	CanonicalHash           string
 	IsForward               bool
 	HasCommentsThread       bool
+	Views                   int
+	Forwards                int
+	// ForwardChain lists the channels a forwarded message passed through, origin first.
+	ForwardChain []ForwardHop
+	// VideoDuration is the length of a video post in seconds, zero for other
+	// posts. MediaData then holds the video's thumbnail.
+	VideoDuration int
+}
+
+// ForwardHop is one channel in a forwarded message's chain.
+type ForwardHop struct {
+	PeerID    int64     `json:"peer_id"`
+	MessageID int64     `json:"message_id,omitempty"`
+	Username  string    `json:"username,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Date      time.Time `json:"date,omitempty"`
 }
 
 // Item represents a processed digest item.
@@ -65,6 +81,16 @@ type Item struct {
 	Embedding           []float32
 	BulletTotalCount    int
 	BulletIncludedCount int
+	// Regions are the ISO 3166-1 alpha-2 codes of the countries the item is about.
+	Regions []string
+	// AltText describes the item's image for readers who cannot see it.
+	AltText string
+	// SafetyFlags are the content safety flags (nsfw, graphic, profanity) of the item.
+	SafetyFlags []string
+	// ForwardOrigin is the original channel post when the item was forwarded.
+	ForwardOrigin *ForwardHop
+	// MissedDigests counts the digests the item was eligible for but not included in.
+	MissedDigests int
 }
 
 // ResolvedLink represents a resolved external or Telegram link.
@@ -115,6 +141,14 @@ const (
 	ScopeFactCheck = "factcheck"
 )
 
+// Enrichment extraction scope constants.
+const (
+	ScopeNumbers  = "numbers"
+	ScopeQuotes   = "quotes"
+	ScopeEvents   = "events"
+	ScopeEntities = "entities"
+)
+
 // LanguageRoutingPolicy defines how enrichment queries are routed to target languages.
 type LanguageRoutingPolicy struct {
 	Default []string            `json:"default"`
@@ -0,0 +1,119 @@
+package domain
+
+import "strings"
+
+// DropCategory groups the fine-grained drop reasons logged by the pipeline
+// into a fixed taxonomy used by metrics and drop-reason reports.
+type DropCategory string
+
+// Drop categories, in report order.
+const (
+	DropGateIrrelevant  DropCategory = "gate_irrelevant"
+	DropBelowThreshold  DropCategory = "below_threshold"
+	DropRatedIrrelevant DropCategory = "rated_irrelevant"
+	DropAdFilter        DropCategory = "ad_filter"
+	DropContentFilter   DropCategory = "content_filter"
+	DropRuleDeny        DropCategory = "rule_deny"
+	DropMediaOnly       DropCategory = "media_only"
+	DropDedupFolded     DropCategory = "dedup_folded"
+	DropQuota           DropCategory = "quota"
+	DropMute            DropCategory = "mute"
+	DropSafety          DropCategory = "safety"
+	DropOther           DropCategory = "other"
+)
+
+// Drop reasons logged by the pipeline. Every reason belongs to exactly one
+// drop category; see CategorizeDropReason.
+const (
+	DropReasonRelevanceGate   = "relevance_gate"
+	DropReasonBelowThreshold  = "below_threshold"
+	DropReasonRatedIrrelevant = "rated_irrelevant_similar"
+
+	DropReasonAds         = "filter_ads"
+	DropReasonAdsComments = "filter_ads_comments_disabled"
+
+	DropReasonMinLength    = "filter_min_length"
+	DropReasonEmojiOnly    = "filter_emoji_only"
+	DropReasonBoilerplate  = "filter_boilerplate"
+	DropReasonForwardShell = "filter_forward_shell"
+	DropReasonDeny         = "filter_deny"
+	DropReasonAllowMiss    = "filter_allow_miss"
+	DropReasonForwarded    = "forwarded"
+
+	DropReasonRuleDeny = "filter_rule_deny"
+
+	DropReasonMediaSticker = "filter_media_sticker"
+	DropReasonMediaMeme    = "filter_media_meme"
+
+	DropReasonDuplicateBatch      = "duplicate_batch"
+	DropReasonForwardDuplicate    = "forward_duplicate"
+	DropReasonDedupSemanticBatch  = "dedup_semantic_batch"
+	DropReasonDedupSemanticSame   = "dedup_semantic_same_channel"
+	DropReasonDedupSemanticGlobal = "dedup_semantic_global"
+	DropReasonDedupStrictGlobal   = "dedup_strict_global"
+
+	DropReasonChannelQuota   = "channel_quota"
+	DropReasonChannelSnoozed = "channel_snoozed"
+
+	// DropReasonSafetyPrefix prefixes the drop reason of items blocked by the
+	// content safety policy with their first flag.
+	DropReasonSafetyPrefix = "safety_"
+)
+
+// DropCategories lists every drop category in report order.
+var DropCategories = []DropCategory{
+	DropGateIrrelevant,
+	DropBelowThreshold,
+	DropRatedIrrelevant,
+	DropAdFilter,
+	DropContentFilter,
+	DropRuleDeny,
+	DropMediaOnly,
+	DropDedupFolded,
+	DropQuota,
+	DropMute,
+	DropSafety,
+	DropOther,
+}
+
+// dropReasonCategories maps every pipeline drop reason to its category.
+var dropReasonCategories = map[string]DropCategory{
+	DropReasonRelevanceGate:       DropGateIrrelevant,
+	DropReasonBelowThreshold:      DropBelowThreshold,
+	DropReasonRatedIrrelevant:     DropRatedIrrelevant,
+	DropReasonAds:                 DropAdFilter,
+	DropReasonAdsComments:         DropAdFilter,
+	DropReasonMinLength:           DropContentFilter,
+	DropReasonEmojiOnly:           DropContentFilter,
+	DropReasonBoilerplate:         DropContentFilter,
+	DropReasonForwardShell:        DropContentFilter,
+	DropReasonDeny:                DropContentFilter,
+	DropReasonAllowMiss:           DropContentFilter,
+	DropReasonForwarded:           DropContentFilter,
+	DropReasonRuleDeny:            DropRuleDeny,
+	DropReasonMediaSticker:        DropMediaOnly,
+	DropReasonMediaMeme:           DropMediaOnly,
+	DropReasonDuplicateBatch:      DropDedupFolded,
+	DropReasonForwardDuplicate:    DropDedupFolded,
+	DropReasonDedupSemanticBatch:  DropDedupFolded,
+	DropReasonDedupSemanticSame:   DropDedupFolded,
+	DropReasonDedupSemanticGlobal: DropDedupFolded,
+	DropReasonDedupStrictGlobal:   DropDedupFolded,
+	DropReasonChannelQuota:        DropQuota,
+	DropReasonChannelSnoozed:      DropMute,
+}
+
+// CategorizeDropReason returns the category of a logged drop reason. Safety
+// reasons carry the flag after DropReasonSafetyPrefix. Unknown reasons fall
+// into DropOther.
+func CategorizeDropReason(reason string) DropCategory {
+	if category, ok := dropReasonCategories[reason]; ok {
+		return category
+	}
+
+	if strings.HasPrefix(reason, DropReasonSafetyPrefix) {
+		return DropSafety
+	}
+
+	return DropOther
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestCategorizeDropReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   DropCategory
+	}{
+		{DropReasonRelevanceGate, DropGateIrrelevant},
+		{DropReasonBelowThreshold, DropBelowThreshold},
+		{DropReasonRatedIrrelevant, DropRatedIrrelevant},
+		{DropReasonAds, DropAdFilter},
+		{DropReasonAdsComments, DropAdFilter},
+		{DropReasonMediaSticker, DropMediaOnly},
+		{DropReasonRuleDeny, DropRuleDeny},
+		{DropReasonMinLength, DropContentFilter},
+		{DropReasonForwarded, DropContentFilter},
+		{DropReasonDedupSemanticGlobal, DropDedupFolded},
+		{DropReasonForwardDuplicate, DropDedupFolded},
+		{DropReasonChannelQuota, DropQuota},
+		{DropReasonChannelSnoozed, DropMute},
+		{DropReasonSafetyPrefix + "graphic", DropSafety},
+		{"test_stage", DropOther},
+	}
+
+	for _, tt := range tests {
+		if got := CategorizeDropReason(tt.reason); got != tt.want {
+			t.Errorf("CategorizeDropReason(%q) = %q, want %q", tt.reason, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+// Evidence stance labels assigned to an item-evidence pair.
+const (
+	StanceSupports = "supports"
+	StanceRefutes  = "refutes"
+	StanceNeutral  = "neutral"
+)
+
+// Item-level evidence verdicts aggregated from per-evidence stances.
+const (
+	VerdictSupported  = "supported"
+	VerdictRefuted    = "refuted"
+	VerdictDisputed   = "disputed"
+	VerdictUnverified = "unverified"
+)
+
+// StanceDefaultConfidence is used when the classifier reports no confidence.
+const StanceDefaultConfidence = 0.5
+
+const (
+	// stanceMinConfidence is the confidence below which a stance is ignored.
+	stanceMinConfidence = 0.4
+	// stanceDominanceRatio is how much one side must outweigh the other
+	// before a mixed set of stances is no longer considered disputed.
+	stanceDominanceRatio = 2.0
+)
+
+// StanceVote is a single evidence stance used for verdict aggregation.
+type StanceVote struct {
+	Stance     string
+	Confidence float32
+}
+
+// AggregateStanceVerdict combines per-evidence stances into an item verdict.
+// Neutral and low-confidence stances are ignored. When both sides carry weight,
+// one must dominate the other, otherwise the item is disputed.
+func AggregateStanceVerdict(votes []StanceVote) string {
+	support, refute := stanceWeights(votes)
+
+	switch {
+	case support == 0 && refute == 0:
+		return VerdictUnverified
+	case support >= refute*stanceDominanceRatio:
+		return VerdictSupported
+	case refute >= support*stanceDominanceRatio:
+		return VerdictRefuted
+	default:
+		return VerdictDisputed
+	}
+}
+
+// stanceWeights sums confidence-weighted supporting and refuting stances.
+func stanceWeights(votes []StanceVote) (support, refute float32) {
+	for _, v := range votes {
+		if v.Confidence < stanceMinConfidence {
+			continue
+		}
+
+		switch v.Stance {
+		case StanceSupports:
+			support += v.Confidence
+		case StanceRefutes:
+			refute += v.Confidence
+		}
+	}
+
+	return support, refute
+}
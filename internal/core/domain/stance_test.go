@@ -0,0 +1,43 @@
+package domain
+
+import "testing"
+
+func TestAggregateStanceVerdict(t *testing.T) {
+	tests := []struct {
+		name  string
+		votes []StanceVote
+		want  string
+	}{
+		{name: "no votes", votes: nil, want: VerdictUnverified},
+		{name: "only neutral", votes: []StanceVote{{Stance: StanceNeutral, Confidence: 0.9}}, want: VerdictUnverified},
+		{name: "low confidence ignored", votes: []StanceVote{{Stance: StanceRefutes, Confidence: 0.2}}, want: VerdictUnverified},
+		{name: "supported", votes: []StanceVote{{Stance: StanceSupports, Confidence: 0.8}, {Stance: StanceNeutral}}, want: VerdictSupported},
+		{name: "refuted", votes: []StanceVote{{Stance: StanceRefutes, Confidence: 0.9}}, want: VerdictRefuted},
+		{
+			name: "dominant support",
+			votes: []StanceVote{
+				{Stance: StanceSupports, Confidence: 0.9},
+				{Stance: StanceSupports, Confidence: 0.8},
+				{Stance: StanceRefutes, Confidence: 0.5},
+			},
+			want: VerdictSupported,
+		},
+		{
+			name: "mixed is disputed",
+			votes: []StanceVote{
+				{Stance: StanceSupports, Confidence: 0.8},
+				{Stance: StanceRefutes, Confidence: 0.7},
+			},
+			want: VerdictDisputed,
+		},
+		{name: "zero confidence ignored", votes: []StanceVote{{Stance: StanceSupports}}, want: VerdictUnverified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AggregateStanceVerdict(tt.votes); got != tt.want {
+				t.Errorf("AggregateStanceVerdict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+// Package geo infers and normalizes the geographic focus (countries) of
+// news items as lowercase ISO 3166-1 alpha-2 codes.
+package geo
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxRegionsPerItem caps the regions stored for one item.
+const MaxRegionsPerItem = 3
+
+// prefixMarker marks an alias that matches any word starting with it, which
+// covers inflected forms such as "украинский" or "Ukrainian".
+const prefixMarker = "*"
+
+type country struct {
+	code    string
+	name    string
+	aliases []string
+}
+
+// countries is the gazetteer used for detection. Aliases are lowercase words
+// or two-word phrases; ambiguous short words (e.g. "us") are left out.
+var countries = []country{
+	{"ua", "Ukraine", []string{"ukrain*", "kyiv", "kiev", "kharkiv", "odesa", "odessa", "lviv", "zelensky*", "украин*", "киев*", "харьков*", "одесс*", "львов*", "зеленск*", "україн*", "київ*"}},
+	{"ru", "Russia", []string{"russia*", "moscow", "kremlin", "putin*", "росси*", "москв*", "кремл*", "путин*", "рф"}},
+	{"by", "Belarus", []string{"belarus*", "minsk", "lukashenko", "беларус*", "белорус*", "минск*", "лукашенк*"}},
+	{"pl", "Poland", []string{"poland", "polish", "warsaw", "польш*", "польск*", "варшав*", "поляк*"}},
+	{"de", "Germany", []string{"germany", "german", "germans", "berlin", "bundestag", "германи*", "немец*", "немецк*", "немц*", "берлин*", "бундестаг*"}},
+	{"fr", "France", []string{"france", "french", "paris", "macron", "франци*", "французск*", "париж*", "макрон*"}},
+	{"gb", "United Kingdom", []string{"britain", "british", "united kingdom", "uk", "london", "великобритани*", "британи*", "британск*", "лондон*"}},
+	{"us", "United States", []string{"united states", "usa", "american", "americans", "washington", "white house", "pentagon", "сша", "америк*", "американск*", "вашингтон*", "пентагон*", "белый дом", "белом доме"}},
+	{"cn", "China", []string{"china", "chinese", "beijing", "китай", "китая", "китаю", "китаем", "китае", "китайск*", "пекин*"}},
+	{"jp", "Japan", []string{"japan*", "tokyo", "япони*", "японск*", "токио"}},
+	{"kr", "South Korea", []string{"south korea*", "seoul", "южная корея", "южной кореи", "южную корею", "сеул*"}},
+	{"kp", "North Korea", []string{"north korea*", "pyongyang", "кндр", "северная корея", "северной кореи", "северную корею", "пхеньян*"}},
+	{"in", "India", []string{"india", "indian", "new delhi", "delhi", "индия", "индии", "индию", "индией", "индийск*", "дели"}},
+	{"il", "Israel", []string{"israel*", "tel aviv", "jerusalem", "netanyahu", "израил*", "тель-авив*", "иерусалим*", "нетаньяху"}},
+	{"ps", "Palestine", []string{"palestin*", "gaza", "west bank", "hamas", "палестин*", "сектор газа", "сектора газа", "секторе газа", "хамас*"}},
+	{"ir", "Iran", []string{"iran", "iranian*", "tehran", "иран", "ирана", "ирану", "ираном", "иране", "иранск*", "тегеран*"}},
+	{"iq", "Iraq", []string{"iraq*", "baghdad", "ирак", "ирака", "ираку", "ираке", "иракск*", "багдад*"}},
+	{"sy", "Syria", []string{"syria*", "damascus", "сири*", "дамаск*"}},
+	{"tr", "Turkey", []string{"turkey", "turkish", "türkiye", "ankara", "istanbul", "erdogan", "турци*", "турецк*", "анкар*", "стамбул*", "эрдоган*"}},
+	{"sa", "Saudi Arabia", []string{"saudi*", "riyadh", "саудовск*", "эр-рияд*"}},
+	{"ae", "United Arab Emirates", []string{"uae", "emirates", "dubai", "abu dhabi", "оаэ", "эмират*", "дубае", "дубай"}},
+	{"eg", "Egypt", []string{"egypt*", "cairo", "египет", "египта", "египте", "египетск*", "каир*"}},
+	{"it", "Italy", []string{"italy", "italian", "rome", "итали*", "итальянск*", "рим", "риме", "рима"}},
+	{"es", "Spain", []string{"spain", "spanish", "madrid", "испани*", "испанск*", "мадрид*"}},
+	{"nl", "Netherlands", []string{"netherlands", "dutch", "amsterdam", "the hague", "нидерланд*", "голланди*", "амстердам*", "гаага", "гааге"}},
+	{"be", "Belgium", []string{"belgium", "belgian", "brussels", "бельги*", "брюссел*"}},
+	{"ch", "Switzerland", []string{"switzerland", "swiss", "geneva", "zurich", "швейцари*", "женев*", "цюрих*"}},
+	{"at", "Austria", []string{"austria*", "vienna", "австри*", "вена", "вене", "вену"}},
+	{"se", "Sweden", []string{"sweden", "swedish", "stockholm", "швеци*", "шведск*", "стокгольм*"}},
+	{"no", "Norway", []string{"norway", "norwegian", "oslo", "норвеги*", "норвежск*", "осло"}},
+	{"fi", "Finland", []string{"finland", "finnish", "helsinki", "финлянди*", "финск*", "хельсинки"}},
+	{"dk", "Denmark", []string{"denmark", "danish", "copenhagen", "дания", "дании", "данию", "данией", "датск*", "копенгаген*"}},
+	{"ee", "Estonia", []string{"estonia*", "tallinn", "эстони*", "таллин*"}},
+	{"lv", "Latvia", []string{"latvia*", "riga", "латви*", "латвийск*", "рига", "риге", "риги"}},
+	{"lt", "Lithuania", []string{"lithuania*", "vilnius", "литв*", "литовск*", "вильнюс*"}},
+	{"md", "Moldova", []string{"moldova*", "chisinau", "молдав*", "молдов*", "кишинев*", "приднестров*"}},
+	{"ge", "Georgia", []string{"tbilisi", "грузия", "грузии", "грузию", "грузинск*", "тбилиси"}},
+	{"am", "Armenia", []string{"armenia*", "yerevan", "армени*", "армянск*", "ереван*"}},
+	{"az", "Azerbaijan", []string{"azerbaijan*", "baku", "азербайджан*", "баку"}},
+	{"kz", "Kazakhstan", []string{"kazakhstan*", "astana", "almaty", "казахстан*", "астан*", "алмат*"}},
+	{"uz", "Uzbekistan", []string{"uzbekistan*", "tashkent", "узбекистан*", "ташкент*"}},
+	{"kg", "Kyrgyzstan", []string{"kyrgyzstan*", "bishkek", "киргизи*", "кыргызстан*", "бишкек*"}},
+	{"tj", "Tajikistan", []string{"tajikistan*", "dushanbe", "таджикистан*", "душанбе"}},
+	{"ca", "Canada", []string{"canada", "canadian*", "ottawa", "toronto", "канад*", "оттав*", "торонто"}},
+	{"mx", "Mexico", []string{"mexico", "mexican*", "мексик*"}},
+	{"br", "Brazil", []string{"brazil*", "brasilia", "бразили*"}},
+	{"ar", "Argentina", []string{"argentin*", "buenos aires", "аргентин*", "буэнос-айрес*"}},
+	{"ve", "Venezuela", []string{"venezuela*", "caracas", "венесуэл*", "каракас*"}},
+	{"au", "Australia", []string{"australia*", "canberra", "sydney", "австрали*", "сидне*"}},
+	{"hu", "Hungary", []string{"hungary", "hungarian", "budapest", "orban", "венгри*", "венгерск*", "будапешт*", "орбан*"}},
+	{"cz", "Czechia", []string{"czech*", "prague", "чехи*", "чешск*", "прага", "праге", "прагу", "праги"}},
+	{"sk", "Slovakia", []string{"slovakia*", "bratislava", "словаки*", "братислав*"}},
+	{"ro", "Romania", []string{"romania*", "bucharest", "румыни*", "бухарест*"}},
+	{"bg", "Bulgaria", []string{"bulgaria*", "болгари*"}},
+	{"rs", "Serbia", []string{"serbia*", "belgrade", "серби*", "сербск*", "белград*"}},
+	{"gr", "Greece", []string{"greece", "greek", "athens", "греци*", "греческ*", "афин*"}},
+	{"pt", "Portugal", []string{"portugal*", "portuguese", "lisbon", "португали*", "лиссабон*"}},
+	{"ie", "Ireland", []string{"ireland", "irish", "dublin", "ирланди*", "дублин*"}},
+	{"qa", "Qatar", []string{"qatar*", "doha", "катар", "катара", "катаре", "катару", "катарск*", "доха", "дохе"}},
+	{"lb", "Lebanon", []string{"lebanon", "lebanese", "beirut", "hezbollah", "ливан*", "бейрут*", "хезболл*"}},
+	{"ye", "Yemen", []string{"yemen*", "houthi*", "йемен*", "хусит*"}},
+	{"af", "Afghanistan", []string{"afghanistan", "afghan*", "kabul", "taliban", "афганистан*", "кабул*", "талиб*"}},
+	{"pk", "Pakistan", []string{"pakistan*", "islamabad", "пакистан*", "исламабад*"}},
+	{"tw", "Taiwan", []string{"taiwan*", "taipei", "тайван*", "тайбэ*"}},
+	{"vn", "Vietnam", []string{"vietnam*", "hanoi", "вьетнам*", "ханой*"}},
+	{"th", "Thailand", []string{"thailand", "thai", "bangkok", "таиланд*", "тайланд*", "бангкок*"}},
+	{"id", "Indonesia", []string{"indonesia*", "jakarta", "индонези*", "джакарт*"}},
+	{"za", "South Africa", []string{"south africa*", "pretoria", "johannesburg", "юар", "южная африка", "претори*", "йоханнесбург*"}},
+	{"ng", "Nigeria", []string{"nigeria*", "abuja", "lagos", "нигери*"}},
+}
+
+// codeAliases maps common non-ISO codes to their ISO equivalent.
+var codeAliases = map[string]string{
+	"uk": "gb",
+}
+
+var (
+	countryNames = map[string]string{}
+	exactAliases = map[string]string{}
+	prefixStems  []stem
+)
+
+type stem struct {
+	prefix string
+	code   string
+}
+
+func init() {
+	for _, c := range countries {
+		countryNames[c.code] = c.name
+
+		for _, alias := range c.aliases {
+			if strings.HasSuffix(alias, prefixMarker) {
+				prefixStems = append(prefixStems, stem{prefix: strings.TrimSuffix(alias, prefixMarker), code: c.code})
+
+				continue
+			}
+
+			exactAliases[alias] = c.code
+		}
+	}
+
+	// Longer stems first so the most specific stem wins.
+	sort.SliceStable(prefixStems, func(i, j int) bool {
+		return len(prefixStems[i].prefix) > len(prefixStems[j].prefix)
+	})
+}
+
+// Name returns the English name of a region code, or the code itself if unknown.
+func Name(code string) string {
+	if name, ok := countryNames[code]; ok {
+		return name
+	}
+
+	return code
+}
+
+// IsKnown reports whether code is a supported region code.
+func IsKnown(code string) bool {
+	_, ok := countryNames[code]
+
+	return ok
+}
+
+// Normalize lowercases codes, maps aliases such as "uk" to ISO codes, and
+// drops unknown codes and duplicates, keeping at most MaxRegionsPerItem.
+func Normalize(codes []string) []string {
+	normalized := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+
+	for _, code := range codes {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if alias, ok := codeAliases[code]; ok {
+			code = alias
+		}
+
+		if !IsKnown(code) || seen[code] {
+			continue
+		}
+
+		seen[code] = true
+
+		normalized = append(normalized, code)
+		if len(normalized) == MaxRegionsPerItem {
+			break
+		}
+	}
+
+	return normalized
+}
+
+// ParseList parses a comma- or space-separated list of region codes. It
+// returns the normalized codes and any entries that are not known regions.
+func ParseList(list string) (codes, unknown []string) {
+	fields := strings.FieldsFunc(strings.ToLower(list), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	codes = make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+
+	for _, field := range fields {
+		code := field
+		if alias, ok := codeAliases[code]; ok {
+			code = alias
+		}
+
+		switch {
+		case !IsKnown(code):
+			unknown = append(unknown, field)
+		case !seen[code]:
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+
+	return codes, unknown
+}
+
+// Detect finds countries mentioned in text through the gazetteer and returns
+// the most mentioned ones, up to MaxRegionsPerItem. Ties keep the order of
+// first mention.
+func Detect(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	})
+
+	counts := make(map[string]int)
+
+	var order []string
+
+	count := func(code string) {
+		if counts[code] == 0 {
+			order = append(order, code)
+		}
+
+		counts[code]++
+	}
+
+	for i := 0; i < len(words); i++ {
+		word := strings.Trim(words[i], "-")
+
+		if i+1 < len(words) {
+			if code, ok := matchAlias(word + " " + strings.Trim(words[i+1], "-")); ok {
+				count(code)
+
+				i++ // the next word is part of the matched phrase
+
+				continue
+			}
+		}
+
+		if code, ok := matchAlias(word); ok {
+			count(code)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return counts[order[i]] > counts[order[j]]
+	})
+
+	if len(order) > MaxRegionsPerItem {
+		order = order[:MaxRegionsPerItem]
+	}
+
+	return order
+}
+
+func matchAlias(word string) (string, bool) {
+	if word == "" {
+		return "", false
+	}
+
+	if code, ok := exactAliases[word]; ok {
+		return code, true
+	}
+
+	for _, s := range prefixStems {
+		if strings.HasPrefix(word, s.prefix) {
+			return s.code, true
+		}
+	}
+
+	return "", false
+}
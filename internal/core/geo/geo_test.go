@@ -0,0 +1,69 @@
+package geo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "english names and capitals",
+			text: "Poland and Ukraine signed a deal in Kyiv. Warsaw will host the next round.",
+			want: []string{"pl", "ua"},
+		},
+		{
+			name: "russian inflections",
+			text: "Украинские дроны атаковали Москву, сообщили в Минобороны России.",
+			want: []string{"ru", "ua"},
+		},
+		{
+			name: "two-word phrases",
+			text: "The White House said the United States will respond to North Korean tests.",
+			want: []string{"us", "kp"},
+		},
+		{
+			name: "no mentions",
+			text: "Цены на газа выросли, а модели стали дороже.",
+			want: nil,
+		},
+		{
+			name: "capped at max regions",
+			text: "France, Germany, Italy and Spain met in Brussels.",
+			want: []string{"fr", "de", "it"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	got := Normalize([]string{" UA", "uk", "xx", "ua", "pl", "de"})
+
+	want := []string{"ua", "gb", "pl"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalize() = %v, want %v", got, want)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	codes, unknown := ParseList("ua, PL uk,zz,ua")
+
+	if want := []string{"ua", "pl", "gb"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("codes = %v, want %v", codes, want)
+	}
+
+	if want := []string{"zz"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("unknown = %v, want %v", unknown, want)
+	}
+}
@@ -5,6 +5,7 @@
 //   - Telegram post links (t.me/channel/123)
 //   - Telegram channel links (t.me/channel)
 //   - Telegram invite links (t.me/+abc or t.me/joinchat/abc)
+//   - Telegram shared folder links (t.me/addlist/abc)
 //   - @mentions (converted to t.me links)
 //
 // Some domains are blocked (e.g., Twitter) due to access restrictions.
@@ -32,17 +33,18 @@ type Link struct {
 	Position int
 
 	// Telegram-specific
-	TelegramType string // "post", "channel", "invite"
+	TelegramType string // "post", "channel", "invite", "addlist"
 	Username     string
 	ChannelID    int64
 	MessageID    int64
 }
 
 var (
-	urlRegex      = regexp.MustCompile(`https?://[^\s<>"{}|\\^\x60\[\]]+`)
-	tgPostRegex   = regexp.MustCompile(`t\.me/(?:c/(\d+)|([a-zA-Z][a-zA-Z0-9_]{3,}))/(\d+)`)
-	tgInviteRegex = regexp.MustCompile(`t\.me/(?:\+|joinchat/)([a-zA-Z0-9_-]+)`)
-	mentionRegex  = regexp.MustCompile(`@([a-zA-Z][a-zA-Z0-9_]{3,31})`)
+	urlRegex       = regexp.MustCompile(`https?://[^\s<>"{}|\\^\x60\[\]]+`)
+	tgPostRegex    = regexp.MustCompile(`t\.me/(?:c/(\d+)|([a-zA-Z][a-zA-Z0-9_]{3,}))/(\d+)`)
+	tgInviteRegex  = regexp.MustCompile(`t\.me/(?:\+|joinchat/)([a-zA-Z0-9_-]+)`)
+	tgAddlistRegex = regexp.MustCompile(`t\.me/addlist/([a-zA-Z0-9_-]+)`)
+	mentionRegex   = regexp.MustCompile(`@([a-zA-Z][a-zA-Z0-9_]{3,31})`)
 )
 
 var blockedDomains = map[string]bool{
@@ -111,6 +113,12 @@ func extractDomain(rawURL string) string {
 }
 
 func parseTelegramLink(link *Link) {
+	if tgAddlistRegex.MatchString(link.URL) {
+		link.TelegramType = "addlist"
+
+		return
+	}
+
 	if matches := tgPostRegex.FindStringSubmatch(link.URL); matches != nil {
 		link.TelegramType = "post"
 		if matches[1] != "" {
@@ -127,6 +135,16 @@ func parseTelegramLink(link *Link) {
 	}
 }
 
+// AddlistSlug returns the slug of a shared folder link (t.me/addlist/<slug>), or "" when
+// the URL is not a folder link.
+func AddlistSlug(rawURL string) string {
+	if matches := tgAddlistRegex.FindStringSubmatch(rawURL); matches != nil {
+		return matches[1]
+	}
+
+	return ""
+}
+
 // ExtractMentions extracts @username mentions from text
 func ExtractMentions(text string) []string {
 	matches := mentionRegex.FindAllStringSubmatch(text, -1)
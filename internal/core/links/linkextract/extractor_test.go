@@ -634,6 +634,11 @@ func TestParseTelegramLink(t *testing.T) {
 			url:              "https://t.me/news",
 			wantTelegramType: "channel",
 		},
+		{
+			name:             "shared folder link",
+			url:              "https://t.me/addlist/AbC-12_x",
+			wantTelegramType: "addlist",
+		},
 	}
 
 	for _, tt := range tests {
@@ -660,6 +665,16 @@ func TestParseTelegramLink(t *testing.T) {
 	}
 }
 
+func TestAddlistSlug(t *testing.T) {
+	if got := AddlistSlug("https://t.me/addlist/AbC-12_x"); got != "AbC-12_x" {
+		t.Errorf("AddlistSlug(folder link) = %q, want %q", got, "AbC-12_x")
+	}
+
+	if got := AddlistSlug("https://t.me/+abc123"); got != "" {
+		t.Errorf("AddlistSlug(invite link) = %q, want empty", got)
+	}
+}
+
 func TestExtractURLsFromText(t *testing.T) {
 	tests := []struct {
 		name string
@@ -418,7 +418,7 @@ func (p *anthropicProvider) CompressSummariesForCover(ctx context.Context, summa
 }
 
 // GenerateDigestCover returns an error as Anthropic doesn't support image generation.
-func (p *anthropicProvider) GenerateDigestCover(_ context.Context, _ []string, _ string) ([]byte, error) {
+func (p *anthropicProvider) GenerateDigestCover(_ context.Context, _ []string, _ string, _ CoverOptions) ([]byte, error) {
 	return nil, ErrNoImageProvider
 }
 
@@ -0,0 +1,177 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar event kinds.
+const (
+	CalendarKindEvent    = "event"
+	CalendarKindDeadline = "deadline"
+	CalendarKindMeeting  = "meeting"
+)
+
+const (
+	calendarMaxEvents     = 3
+	calendarMaxTitleRunes = 120
+	calendarPublishedFmt  = "2006-01-02 15:04 MST (Monday)"
+	calendarDateLayout    = "2006-01-02"
+	calendarTimeLayout    = "2006-01-02T15:04"
+	calendarZonedLayout   = "2006-01-02T15:04Z07:00"
+)
+
+var errParseCalendarEvents = errors.New("parse calendar events")
+
+// CalendarEvent is a future event announced in a message.
+type CalendarEvent struct {
+	Title string
+	Kind  string
+	Start time.Time
+	// End is zero when unknown.
+	End time.Time
+	// AllDay is set when only the date is known.
+	AllDay   bool
+	Location string
+}
+
+type rawCalendarEvent struct {
+	Title    string `json:"title"`
+	Kind     string `json:"kind"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Location string `json:"location"`
+}
+
+// BuildCalendarEventsPrompt builds a prompt asking for the dated future
+// events announced in a message as JSON. Relative dates are resolved from
+// the publication time.
+func BuildCalendarEventsPrompt(text string, published time.Time) string {
+	var sb strings.Builder
+
+	sb.WriteString(`You extract announced future events from a news message.
+Return STRICT JSON ONLY: an array of objects {"title": string, "kind": string, "start": string, "end": string, "location": string}. Return [] when there are none. No markdown. No extra keys.
+
+Rules:
+- Only events scheduled after the publication time: meetings, summits, votes, hearings, elections, launches, releases, deadlines.
+- Skip past events, plans without a specific date ("soon", "later this year") and routine recurring events.
+- "kind" is "meeting", "deadline" or "event".
+- "start" is "YYYY-MM-DD" when only the day is known, or "YYYY-MM-DDTHH:MM" with the local time. Append the UTC offset (e.g. "+02:00") only when the message states the time zone.
+- Resolve relative dates ("tomorrow", "next Tuesday") from the publication time.
+- "end" uses the same format, or is "" when unknown.
+- "title" names the event in at most 10 words, in the language of the message; plain text, no HTML.
+- "location" is the city or venue, or "".
+- At most ` + strconv.Itoa(calendarMaxEvents) + ` events.
+
+Published: `)
+	sb.WriteString(published.Format(calendarPublishedFmt))
+	sb.WriteString("\n\nMessage:\n")
+	sb.WriteString(text)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// ParseCalendarEvents parses an events response for a message published at
+// published. Times without a UTC offset are read in loc. Events with an
+// unknown date, events before the publication day and repeated events are
+// dropped.
+func ParseCalendarEvents(response string, published time.Time, loc *time.Location) ([]CalendarEvent, error) {
+	var raw []rawCalendarEvent
+	if err := json.Unmarshal([]byte(extractJSON(response)), &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", errParseCalendarEvents, err)
+	}
+
+	y, m, d := published.In(loc).Date()
+	publishedDay := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	events := make([]CalendarEvent, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+
+	for _, r := range raw {
+		event, ok := parseCalendarEvent(r, loc)
+		if !ok || event.Start.Before(publishedDay) {
+			continue
+		}
+
+		key := strings.ToLower(event.Title) + "|" + event.Start.Format(calendarDateLayout)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		events = append(events, event)
+		if len(events) == calendarMaxEvents {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+func parseCalendarEvent(r rawCalendarEvent, loc *time.Location) (CalendarEvent, bool) {
+	title := strings.Join(strings.Fields(r.Title), " ")
+	if title == "" {
+		return CalendarEvent{}, false
+	}
+
+	if runes := []rune(title); len(runes) > calendarMaxTitleRunes {
+		title = string(runes[:calendarMaxTitleRunes])
+	}
+
+	start, allDay, ok := parseCalendarTime(r.Start, loc)
+	if !ok {
+		return CalendarEvent{}, false
+	}
+
+	event := CalendarEvent{
+		Title:    title,
+		Kind:     normalizeCalendarKind(r.Kind),
+		Start:    start,
+		AllDay:   allDay,
+		Location: strings.TrimSpace(r.Location),
+	}
+
+	// An end is kept only when it has the same precision and is not before the start
+	if end, endAllDay, ok := parseCalendarTime(r.End, loc); ok && endAllDay == allDay && !end.Before(start) {
+		event.End = end
+	}
+
+	return event, true
+}
+
+// parseCalendarTime parses a date or a local or zoned date and time. It
+// reports whether only the date was given.
+func parseCalendarTime(value string, loc *time.Location) (time.Time, bool, bool) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.ParseInLocation(calendarDateLayout, value, loc); err == nil {
+		return t, true, true
+	}
+
+	if t, err := time.ParseInLocation(calendarTimeLayout, value, loc); err == nil {
+		return t, false, true
+	}
+
+	if t, err := time.Parse(calendarZonedLayout, value); err == nil {
+		return t, false, true
+	}
+
+	return time.Time{}, false, false
+}
+
+func normalizeCalendarKind(kind string) string {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case CalendarKindDeadline:
+		return CalendarKindDeadline
+	case CalendarKindMeeting:
+		return CalendarKindMeeting
+	default:
+		return CalendarKindEvent
+	}
+}
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCalendarEvents(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	published := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	response := "```json\n" + `[
+		{"title": " EU   summit ", "kind": "Meeting", "start": "2026-03-05T14:00", "end": "2026-03-05T18:00", "location": "Brussels"},
+		{"title": "Tax filing deadline", "kind": "deadline", "start": "2026-04-15", "end": "2026-04-10"},
+		{"title": "eu summit", "kind": "meeting", "start": "2026-03-05T15:00+01:00"},
+		{"title": "Launch", "kind": "party", "start": "2026-03-10T12:00+00:00", "end": "2026-03-11"},
+		{"title": "Past vote", "kind": "event", "start": "2026-02-20"},
+		{"title": "Someday", "kind": "event", "start": "later this year"},
+		{"title": "", "kind": "event", "start": "2026-05-01"}
+	]` + "\n```"
+
+	events, err := ParseCalendarEvents(response, published, berlin)
+	if err != nil {
+		t.Fatalf("ParseCalendarEvents() error = %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("events = %+v, want 3", events)
+	}
+
+	summit := events[0]
+	if summit.Title != "EU summit" || summit.Kind != CalendarKindMeeting || summit.AllDay || summit.Location != "Brussels" {
+		t.Errorf("events[0] = %+v", summit)
+	}
+
+	if want := time.Date(2026, 3, 5, 13, 0, 0, 0, time.UTC); !summit.Start.Equal(want) {
+		t.Errorf("summit start = %v, want %v", summit.Start, want)
+	}
+
+	if want := time.Date(2026, 3, 5, 17, 0, 0, 0, time.UTC); !summit.End.Equal(want) {
+		t.Errorf("summit end = %v, want %v", summit.End, want)
+	}
+
+	deadline := events[1]
+	if !deadline.AllDay || deadline.Kind != CalendarKindDeadline || !deadline.End.IsZero() {
+		t.Errorf("events[1] = %+v, want an all-day deadline without the end before its start", deadline)
+	}
+
+	launch := events[2]
+	if launch.Kind != CalendarKindEvent || !launch.End.IsZero() {
+		t.Errorf("events[2] = %+v, want an event without the date-only end", launch)
+	}
+}
+
+func TestParseCalendarEventsErrors(t *testing.T) {
+	if _, err := ParseCalendarEvents("not json", time.Now(), time.UTC); err == nil {
+		t.Error("expected an error for a non-JSON response")
+	}
+
+	events, err := ParseCalendarEvents("[]", time.Now(), time.UTC)
+	if err != nil || len(events) != 0 {
+		t.Errorf("ParseCalendarEvents([]) = %v, %v; want no events", events, err)
+	}
+}
+
+func TestBuildCalendarEventsPrompt(t *testing.T) {
+	prompt := BuildCalendarEventsPrompt("Summit on Thursday", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC))
+
+	for _, want := range []string{"Published: 2026-03-01 09:30 UTC (Sunday)", "Message:\nSummit on Thursday\n", "At most 3 events"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// ErrCassetteMiss is returned on replay when no response was recorded for a request.
+var ErrCassetteMiss = errors.New("no recorded LLM response for request")
+
+// Cassette events, as counted by the digest_llm_cassettes_total metric.
+const (
+	cassetteRecorded = "recorded"
+	cassetteHit      = "hit"
+	cassetteMiss     = "miss"
+)
+
+// Cassette methods, recorded with each response.
+const (
+	methodProcessBatch                  = "ProcessBatch"
+	methodTranslateText                 = "TranslateText"
+	methodCompleteText                  = "CompleteText"
+	methodGenerateNarrative             = "GenerateNarrative"
+	methodGenerateNarrativeWithEvidence = "GenerateNarrativeWithEvidence"
+	methodSummarizeCluster              = "SummarizeCluster"
+	methodSummarizeClusterWithEvidence  = "SummarizeClusterWithEvidence"
+	methodGenerateClusterTopic          = "GenerateClusterTopic"
+	methodRelevanceGate                 = "RelevanceGate"
+	methodCompressSummariesForCover     = "CompressSummariesForCover"
+	methodGenerateDigestCover           = "GenerateDigestCover"
+	methodExtractBullets                = "ExtractBullets"
+)
+
+// CassetteStore persists recorded LLM responses by request key.
+type CassetteStore interface {
+	// SaveLLMCassette records a response with the IDs of the raw messages the
+	// request was built from, so purging a channel can delete it.
+	SaveLLMCassette(ctx context.Context, key, method string, sources []string, response []byte) error
+	// GetLLMCassette returns the recorded response, or db.ErrCassetteNotFound
+	// when none was recorded.
+	GetLLMCassette(ctx context.Context, key string) ([]byte, error)
+}
+
+type cassetteSourcesKey struct{}
+
+// WithCassetteSources tags the LLM requests made with ctx with the raw
+// messages they are built from, for requests whose arguments carry only text.
+// Requests with messages or items as arguments are tagged from those.
+func WithCassetteSources(ctx context.Context, rawMessageIDs ...string) context.Context {
+	return context.WithValue(ctx, cassetteSourcesKey{}, rawMessageIDs)
+}
+
+// cassetteSources returns the raw message IDs of a request: those tagged on
+// ctx and those of the messages and items among its arguments.
+func cassetteSources(ctx context.Context, args []any) []string {
+	sources, _ := ctx.Value(cassetteSourcesKey{}).([]string)
+	sources = slices.Clone(sources)
+
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case []MessageInput:
+			for _, m := range v {
+				sources = append(sources, m.ID)
+			}
+		case []domain.Item:
+			for _, item := range v {
+				sources = append(sources, item.RawMessageID)
+			}
+		}
+	}
+
+	sources = slices.DeleteFunc(sources, func(id string) bool { return id == "" })
+	slices.Sort(sources)
+
+	return slices.Compact(sources)
+}
+
+// CassetteClient records the responses of LLM requests, or replays recorded
+// responses without calling a provider. A request is keyed by its method and
+// arguments, including the model argument, but not by the prompt templates
+// the providers apply; replaying after a prompt change returns the old
+// responses. Only successful responses are recorded, so on replay a request
+// that failed when recorded fails again with ErrCassetteMiss.
+type CassetteClient struct {
+	Client
+
+	store  CassetteStore
+	replay bool
+	hits   atomic.Int64
+	misses atomic.Int64
+	logger *zerolog.Logger
+}
+
+// NewRecordingClient wraps client so that every successful response is
+// recorded in store. Recording failures are logged and never fail the request.
+func NewRecordingClient(client Client, store CassetteStore, logger *zerolog.Logger) *CassetteClient {
+	return &CassetteClient{Client: client, store: store, logger: logger}
+}
+
+// NewReplayClient returns a client that answers requests from the responses
+// recorded in store and never calls a provider. Requests without a recorded
+// response fail with ErrCassetteMiss.
+func NewReplayClient(store CassetteStore, logger *zerolog.Logger) *CassetteClient {
+	return &CassetteClient{Client: NewRegistry(logger), store: store, replay: true, logger: logger}
+}
+
+// ReplayStats returns how many replayed requests had a recorded response
+// and how many did not.
+func (c *CassetteClient) ReplayStats() (hits, misses int64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+// CassetteKey returns the key of a request: the hex SHA-256 of the method
+// and the JSON encoding of its arguments.
+func CassetteKey(method string, args ...any) (string, error) {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("encode %s request: %w", method, err)
+	}
+
+	sum := sha256.New()
+	sum.Write([]byte(method))
+	sum.Write([]byte{0})
+	sum.Write(encoded)
+
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// withCassette records the response of call, or replays it without calling
+// it in replay mode.
+func withCassette[T any](ctx context.Context, c *CassetteClient, method string, args []any, call func() (T, error)) (T, error) {
+	var zero T
+
+	key, err := CassetteKey(method, args...)
+	if err != nil {
+		if c.replay {
+			return zero, err
+		}
+
+		c.logger.Warn().Err(err).Str("method", method).Msg("failed to key LLM request, not recording")
+
+		return call()
+	}
+
+	if c.replay {
+		return replayCassette[T](ctx, c, method, key)
+	}
+
+	result, err := call()
+	if err != nil {
+		return result, err
+	}
+
+	c.recordCassette(ctx, method, key, cassetteSources(ctx, args), result)
+
+	return result, nil
+}
+
+func replayCassette[T any](ctx context.Context, c *CassetteClient, method, key string) (T, error) {
+	var result T
+
+	response, err := c.store.GetLLMCassette(ctx, key)
+	if errors.Is(err, db.ErrCassetteNotFound) {
+		c.misses.Add(1)
+		observability.LLMCassettes.WithLabelValues(method, cassetteMiss).Inc()
+
+		return result, fmt.Errorf("%w: %s %s", ErrCassetteMiss, method, key)
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("load %s cassette: %w", method, err)
+	}
+
+	if err := json.Unmarshal(response, &result); err != nil {
+		return result, fmt.Errorf("decode %s cassette: %w", method, err)
+	}
+
+	c.hits.Add(1)
+	observability.LLMCassettes.WithLabelValues(method, cassetteHit).Inc()
+
+	return result, nil
+}
+
+func (c *CassetteClient) recordCassette(ctx context.Context, method, key string, sources []string, result any) {
+	response, err := json.Marshal(result)
+	if err == nil {
+		err = c.store.SaveLLMCassette(ctx, key, method, sources, response)
+	}
+
+	if err != nil {
+		c.logger.Warn().Err(err).Str("method", method).Msg("failed to record LLM cassette")
+
+		return
+	}
+
+	observability.LLMCassettes.WithLabelValues(method, cassetteRecorded).Inc()
+}
+
+// ProcessBatch implements Client.
+func (c *CassetteClient) ProcessBatch(ctx context.Context, messages []MessageInput, targetLanguage, model, tone string) ([]BatchResult, error) {
+	return withCassette(ctx, c, methodProcessBatch, []any{messages, targetLanguage, model, tone}, func() ([]BatchResult, error) {
+		return c.Client.ProcessBatch(ctx, messages, targetLanguage, model, tone)
+	})
+}
+
+// TranslateText implements Client.
+func (c *CassetteClient) TranslateText(ctx context.Context, text, targetLanguage, model string) (string, error) {
+	return withCassette(ctx, c, methodTranslateText, []any{text, targetLanguage, model}, func() (string, error) {
+		return c.Client.TranslateText(ctx, text, targetLanguage, model)
+	})
+}
+
+// CompleteText implements Client.
+func (c *CassetteClient) CompleteText(ctx context.Context, prompt, model string) (string, error) {
+	return withCassette(ctx, c, methodCompleteText, []any{prompt, model}, func() (string, error) {
+		return c.Client.CompleteText(ctx, prompt, model)
+	})
+}
+
+// GenerateNarrative implements Client.
+func (c *CassetteClient) GenerateNarrative(ctx context.Context, items []domain.Item, targetLanguage, model, tone string) (string, error) {
+	return withCassette(ctx, c, methodGenerateNarrative, []any{items, targetLanguage, model, tone}, func() (string, error) {
+		return c.Client.GenerateNarrative(ctx, items, targetLanguage, model, tone)
+	})
+}
+
+// GenerateNarrativeWithEvidence implements Client.
+func (c *CassetteClient) GenerateNarrativeWithEvidence(ctx context.Context, items []domain.Item, evidence ItemEvidence, targetLanguage, model, tone string) (string, error) {
+	return withCassette(ctx, c, methodGenerateNarrativeWithEvidence, []any{items, evidence, targetLanguage, model, tone}, func() (string, error) {
+		return c.Client.GenerateNarrativeWithEvidence(ctx, items, evidence, targetLanguage, model, tone)
+	})
+}
+
+// SummarizeCluster implements Client.
+func (c *CassetteClient) SummarizeCluster(ctx context.Context, items []domain.Item, targetLanguage, model, tone string) (string, error) {
+	return withCassette(ctx, c, methodSummarizeCluster, []any{items, targetLanguage, model, tone}, func() (string, error) {
+		return c.Client.SummarizeCluster(ctx, items, targetLanguage, model, tone)
+	})
+}
+
+// SummarizeClusterWithEvidence implements Client.
+func (c *CassetteClient) SummarizeClusterWithEvidence(ctx context.Context, items []domain.Item, evidence ItemEvidence, targetLanguage, model, tone string) (string, error) {
+	return withCassette(ctx, c, methodSummarizeClusterWithEvidence, []any{items, evidence, targetLanguage, model, tone}, func() (string, error) {
+		return c.Client.SummarizeClusterWithEvidence(ctx, items, evidence, targetLanguage, model, tone)
+	})
+}
+
+// GenerateClusterTopic implements Client.
+func (c *CassetteClient) GenerateClusterTopic(ctx context.Context, items []domain.Item, targetLanguage, model string) (string, error) {
+	return withCassette(ctx, c, methodGenerateClusterTopic, []any{items, targetLanguage, model}, func() (string, error) {
+		return c.Client.GenerateClusterTopic(ctx, items, targetLanguage, model)
+	})
+}
+
+// RelevanceGate implements Client.
+func (c *CassetteClient) RelevanceGate(ctx context.Context, text, model, prompt string) (RelevanceGateResult, error) {
+	return withCassette(ctx, c, methodRelevanceGate, []any{text, model, prompt}, func() (RelevanceGateResult, error) {
+		return c.Client.RelevanceGate(ctx, text, model, prompt)
+	})
+}
+
+// CompressSummariesForCover implements Client.
+func (c *CassetteClient) CompressSummariesForCover(ctx context.Context, summaries []string) ([]string, error) {
+	return withCassette(ctx, c, methodCompressSummariesForCover, []any{summaries}, func() ([]string, error) {
+		return c.Client.CompressSummariesForCover(ctx, summaries)
+	})
+}
+
+// GenerateDigestCover implements Client.
+func (c *CassetteClient) GenerateDigestCover(ctx context.Context, topics []string, narrative string, opts CoverOptions) ([]byte, error) {
+	return withCassette(ctx, c, methodGenerateDigestCover, []any{topics, narrative, opts}, func() ([]byte, error) {
+		return c.Client.GenerateDigestCover(ctx, topics, narrative, opts)
+	})
+}
+
+// ExtractBullets implements Client.
+func (c *CassetteClient) ExtractBullets(ctx context.Context, input BulletExtractionInput, targetLanguage, model string) (BulletExtractionResult, error) {
+	return withCassette(ctx, c, methodExtractBullets, []any{input, targetLanguage, model}, func() (BulletExtractionResult, error) {
+		return c.Client.ExtractBullets(ctx, input, targetLanguage, model)
+	})
+}
+
+// Ensure CassetteClient implements Client interface.
+var _ Client = (*CassetteClient)(nil)
@@ -0,0 +1,122 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+type mapCassetteStore map[string][]byte
+
+func (m mapCassetteStore) SaveLLMCassette(_ context.Context, key, _ string, _ []string, response []byte) error {
+	m[key] = response
+
+	return nil
+}
+
+func (m mapCassetteStore) GetLLMCassette(_ context.Context, key string) ([]byte, error) {
+	response, ok := m[key]
+	if !ok {
+		return nil, db.ErrCassetteNotFound
+	}
+
+	return response, nil
+}
+
+type countingClient struct {
+	Client
+
+	calls int
+}
+
+func (c *countingClient) CompleteText(_ context.Context, prompt, _ string) (string, error) {
+	c.calls++
+
+	return "answer to " + prompt, nil
+}
+
+func (c *countingClient) RelevanceGate(_ context.Context, _, _, _ string) (RelevanceGateResult, error) {
+	c.calls++
+
+	return RelevanceGateResult{}, errors.New("provider down")
+}
+
+func TestCassetteRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	store := mapCassetteStore{}
+	live := &countingClient{}
+
+	recorder := NewRecordingClient(live, store, &logger)
+
+	if got, err := recorder.CompleteText(ctx, "q1", "gpt-4o"); err != nil || got != "answer to q1" {
+		t.Fatalf("CompleteText() = %q, %v", got, err)
+	}
+
+	if _, err := recorder.RelevanceGate(ctx, "text", "", ""); err == nil {
+		t.Fatal("expected the provider error to pass through")
+	}
+
+	if len(store) != 1 {
+		t.Fatalf("recorded %d cassettes, want 1 (failures are not recorded)", len(store))
+	}
+
+	player := NewReplayClient(store, &logger)
+
+	if got, err := player.CompleteText(ctx, "q1", "gpt-4o"); err != nil || got != "answer to q1" {
+		t.Errorf("replayed CompleteText() = %q, %v", got, err)
+	}
+
+	if _, err := player.CompleteText(ctx, "q1", "gpt-4o-mini"); !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("CompleteText() with another model error = %v, want ErrCassetteMiss", err)
+	}
+
+	if _, err := player.RelevanceGate(ctx, "text", "", ""); !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("RelevanceGate() error = %v, want ErrCassetteMiss", err)
+	}
+
+	if hits, misses := player.ReplayStats(); hits != 1 || misses != 2 {
+		t.Errorf("ReplayStats() = %d, %d, want 1, 2", hits, misses)
+	}
+
+	if live.calls != 2 {
+		t.Errorf("live client called %d times, want 2 (replay must not call it)", live.calls)
+	}
+}
+
+func TestCassetteKey(t *testing.T) {
+	a, err := CassetteKey(methodCompleteText, "prompt", "model")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := CassetteKey(methodTranslateText, "prompt", "model")
+	c, _ := CassetteKey(methodCompleteText, "prompt", "model")
+
+	if a == b || a != c {
+		t.Errorf("keys: same args across methods must differ, same request must match: %s %s %s", a, b, c)
+	}
+}
+
+func TestCassetteSources(t *testing.T) {
+	ctx := WithCassetteSources(context.Background(), "m3")
+	args := []any{
+		[]MessageInput{{RawMessage: domain.RawMessage{ID: "m1"}}},
+		[]domain.Item{{RawMessageID: "m2"}, {RawMessageID: "m1"}, {}},
+		"text",
+	}
+
+	if got := cassetteSources(ctx, args); !slices.Equal(got, []string{"m1", "m2", "m3"}) {
+		t.Errorf("cassetteSources() = %v, want [m1 m2 m3]", got)
+	}
+
+	if got := cassetteSources(context.Background(), []any{"prompt"}); len(got) != 0 {
+		t.Errorf("cassetteSources() of a text request = %v, want none", got)
+	}
+}
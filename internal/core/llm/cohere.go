@@ -537,7 +537,7 @@ func (p *cohereProvider) CompressSummariesForCover(ctx context.Context, summarie
 }
 
 // GenerateDigestCover returns an error as Cohere doesn't support image generation.
-func (p *cohereProvider) GenerateDigestCover(_ context.Context, _ []string, _ string) ([]byte, error) {
+func (p *cohereProvider) GenerateDigestCover(_ context.Context, _ []string, _ string, _ CoverOptions) ([]byte, error) {
 	return nil, ErrNoImageProvider
 }
 
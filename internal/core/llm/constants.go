@@ -68,6 +68,16 @@ const (
 	ToneBrief        = "brief"
 )
 
+// Verbosity setting constants. Verbosity travels with the tone argument as
+// "<tone>+<verbosity>" (see WithVerbosity).
+const (
+	VerbosityCompact  = "compact"
+	VerbosityStandard = "standard"
+	VerbosityDetailed = "detailed"
+
+	toneVerbositySeparator = "+"
+)
+
 // Format strings
 const (
 	toneFormatString  = " Tone: %s."
@@ -196,4 +206,6 @@ const (
 	MetricValueUnavailable = 0.0
 	MetricValueCBOpen      = 1.0 // Circuit breaker is open (blocking requests)
 	MetricValueCBClosed    = 0.0 // Circuit breaker is closed (allowing requests)
+	MetricValueFailedOver  = 1.0 // Task is failed over to its secondary
+	MetricValueOnPrimary   = 0.0 // Task uses its primary
 )
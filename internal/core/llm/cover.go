@@ -0,0 +1,136 @@
+package llm
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Cover style presets for AI digest covers.
+const (
+	CoverStyleEditorial = "editorial"
+	CoverStyleFlat      = "flat"
+	CoverStyleCollage   = "collage"
+	CoverStyleMinimal   = "minimal"
+)
+
+const (
+	coverPlaceholderTopics    = "{{TOPICS}}"
+	coverPlaceholderNarrative = "{{NARRATIVE}}"
+	coverPlaceholderDate      = "{{DATE}}"
+	coverPlaceholderTone      = "{{TONE}}"
+	coverPlaceholderStyle     = "{{STYLE}}"
+
+	coverDateFormat      = "January 2, 2006"
+	coverEditorialStyle  = "editorial illustration, conceptual art, metaphorical imagery"
+	coverNoTextRule      = "IMPORTANT: Absolutely no text, letters, words, numbers, or writing of any kind. "
+	coverFinishRule      = "Clean, professional, visually striking."
+	coverTopicsSeparator = ", "
+)
+
+// coverStyles describes how each preset other than the default editorial
+// style looks in the image prompt.
+var coverStyles = map[string]string{
+	CoverStyleFlat:    "flat vector illustration, bold solid colors, simple geometric shapes, no gradients",
+	CoverStyleCollage: "vintage newspaper collage, torn paper cutouts, halftone print textures, layered clippings",
+	CoverStyleMinimal: "minimalist composition, a single symbolic object, generous negative space, muted palette",
+}
+
+// CoverStyles lists the cover style presets.
+func CoverStyles() []string {
+	return []string{CoverStyleEditorial, CoverStyleFlat, CoverStyleCollage, CoverStyleMinimal}
+}
+
+// IsCoverStyle reports whether style is a cover style preset.
+func IsCoverStyle(style string) bool {
+	return slices.Contains(CoverStyles(), style)
+}
+
+// CoverOptions shape the image prompt of a digest cover.
+type CoverOptions struct {
+	// Style is a cover style preset; empty means CoverStyleEditorial.
+	Style string
+	// Template replaces the built-in prompt. {{TOPICS}}, {{NARRATIVE}},
+	// {{DATE}}, {{TONE}} and {{STYLE}} are substituted; the no-text rule is
+	// always appended.
+	Template string
+	// Date is the digest date used for {{DATE}}.
+	Date time.Time
+	// Tone is the digest tone used for {{TONE}}.
+	Tone string
+}
+
+// styleDescription returns the image style of the options' preset.
+func (o CoverOptions) styleDescription() (string, bool) {
+	desc, ok := coverStyles[o.Style]
+	if !ok {
+		return coverEditorialStyle, false
+	}
+
+	return desc, true
+}
+
+// buildCoverPrompt creates an image prompt from digest topics and narrative.
+func buildCoverPrompt(topics []string, narrative string, opts CoverOptions) string {
+	if strings.TrimSpace(opts.Template) != "" {
+		return buildCoverPromptFromTemplate(topics, narrative, opts)
+	}
+
+	style, preset := opts.styleDescription()
+
+	var sb strings.Builder
+
+	// If we have narrative (actual content summaries), create a content-specific prompt
+	switch {
+	case narrative != "":
+		sb.WriteString("Create a symbolic illustration representing these current events: ")
+		sb.WriteString(truncate(narrative, coverPromptNarrativeMaxLength))
+		sb.WriteString(". ")
+		sb.WriteString("Style: " + style + ". ")
+		sb.WriteString("Use symbolic visual elements that represent the subjects (not literal depictions). ")
+	case len(topics) > 0:
+		// Fallback to topic-based prompt
+		sb.WriteString("Create an editorial illustration for a news digest covering: ")
+		sb.WriteString(strings.Join(topics, coverTopicsSeparator))
+		sb.WriteString(". ")
+
+		if preset {
+			sb.WriteString("Style: " + style + ". ")
+		} else {
+			sb.WriteString("Style: conceptual magazine cover art with symbolic imagery. ")
+		}
+	default:
+		sb.WriteString("Create an abstract editorial illustration for a news digest. ")
+
+		if preset {
+			sb.WriteString("Style: " + style + ". ")
+		} else {
+			sb.WriteString("Style: modern conceptual art, magazine cover aesthetic. ")
+		}
+	}
+
+	sb.WriteString(coverNoTextRule)
+	sb.WriteString(coverFinishRule)
+
+	return sb.String()
+}
+
+// buildCoverPromptFromTemplate fills the admin's cover prompt template.
+func buildCoverPromptFromTemplate(topics []string, narrative string, opts CoverOptions) string {
+	style, _ := opts.styleDescription()
+
+	date := ""
+	if !opts.Date.IsZero() {
+		date = opts.Date.Format(coverDateFormat)
+	}
+
+	prompt := strings.NewReplacer(
+		coverPlaceholderTopics, strings.Join(topics, coverTopicsSeparator),
+		coverPlaceholderNarrative, truncate(narrative, coverPromptNarrativeMaxLength),
+		coverPlaceholderDate, date,
+		coverPlaceholderTone, opts.Tone,
+		coverPlaceholderStyle, style,
+	).Replace(strings.TrimSpace(opts.Template))
+
+	return strings.TrimSpace(prompt) + " " + coverNoTextRule + coverFinishRule
+}
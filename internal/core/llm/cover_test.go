@@ -0,0 +1,45 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildCoverPromptStyles(t *testing.T) {
+	topics := []string{"Politics", "Finance"}
+
+	editorial := buildCoverPrompt(topics, "", CoverOptions{Style: CoverStyleEditorial})
+	if editorial != buildCoverPrompt(topics, "", CoverOptions{}) {
+		t.Errorf("editorial style must match the default prompt, got %q", editorial)
+	}
+
+	for style, desc := range coverStyles {
+		got := buildCoverPrompt(topics, "Central bank cuts rates", CoverOptions{Style: style})
+		if !strings.Contains(got, "Style: "+desc) || !strings.Contains(got, "Absolutely no text") {
+			t.Errorf("%s prompt = %q, want its style and the no-text rule", style, got)
+		}
+	}
+}
+
+func TestBuildCoverPromptTemplate(t *testing.T) {
+	got := buildCoverPrompt([]string{"Sports", "Health"}, "Marathon record", CoverOptions{
+		Style:    CoverStyleCollage,
+		Template: "A {{TONE}} cover for {{DATE}} about {{TOPICS}}: {{NARRATIVE}}. Look: {{STYLE}}.",
+		Date:     time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		Tone:     ToneCasual,
+	})
+
+	want := "A casual cover for March 2, 2026 about Sports, Health: Marathon record. Look: " + coverStyles[CoverStyleCollage] + "."
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("template prompt = %q, want prefix %q", got, want)
+	}
+
+	if !strings.Contains(got, "Absolutely no text") {
+		t.Errorf("template prompt = %q, want the no-text rule appended", got)
+	}
+
+	if !IsCoverStyle(CoverStyleMinimal) || IsCoverStyle("baroque") {
+		t.Error("IsCoverStyle() does not match the presets")
+	}
+}
@@ -0,0 +1,184 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+// Editor overview sections, in render order.
+const (
+	EditorSectionLede            = "lede"
+	EditorSectionKeyDevelopments = "key_developments"
+	EditorSectionWatchlist       = "watchlist"
+	EditorSectionNumbers         = "numbers"
+)
+
+const (
+	editorMaxKeyDevelopments = 5
+	editorMaxWatchlist       = 3
+	editorMaxNumbers         = 5
+)
+
+// EditorSections lists all editor overview sections in their default order.
+var EditorSections = []string{
+	EditorSectionLede,
+	EditorSectionKeyDevelopments,
+	EditorSectionWatchlist,
+	EditorSectionNumbers,
+}
+
+var (
+	// ErrEmptyEditorPlan is returned when the response has no content for any enabled section.
+	ErrEmptyEditorPlan = errors.New("editor plan has no content for enabled sections")
+	errParseEditorPlan = errors.New("parse editor plan")
+)
+
+// EditorNumber is a notable figure for the "numbers of the day" section.
+type EditorNumber struct {
+	Value   string `json:"value"`
+	Context string `json:"context"`
+}
+
+// EditorPlan is the structured editor-in-chief overview.
+type EditorPlan struct {
+	Lede            string         `json:"lede"`
+	KeyDevelopments []string       `json:"key_developments"`
+	Watchlist       []string       `json:"watchlist"`
+	Numbers         []EditorNumber `json:"numbers"`
+}
+
+// IsEditorSection reports whether name is a known editor overview section.
+func IsEditorSection(name string) bool {
+	for _, s := range EditorSections {
+		if s == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+var editorSectionInstructions = map[string]string{
+	EditorSectionLede:            `"lede": string — 2–3 sentences on the most significant story.`,
+	EditorSectionKeyDevelopments: `"key_developments": array of up to 5 strings — one sentence per notable secondary development.`,
+	EditorSectionWatchlist:       `"watchlist": array of up to 3 strings — what to watch next (upcoming decisions, deadlines, unresolved questions).`,
+	EditorSectionNumbers:         `"numbers": array of up to 5 objects {"value": string, "context": string} — notable figures (percentages, prices, counts) copied from the summaries, with a short explanation.`,
+}
+
+// BuildEditorPlanPrompt builds a prompt asking for the editor overview as JSON
+// with only the given sections.
+func BuildEditorPlanPrompt(items []domain.Item, evidence ItemEvidence, targetLanguage, tone string, sections []string) string {
+	var sb strings.Builder
+
+	sb.WriteString(`You are an editor-in-chief. Build the overview of a news digest from the following summaries.
+Return STRICT JSON ONLY: a single object with the keys listed below. No markdown. No extra keys.
+
+Language requirement:`)
+	sb.WriteString(buildPromptLangInstruction(targetLanguage, tone, contextTypeNarrative))
+	sb.WriteString("\n\nKeys:\n")
+
+	for _, section := range sections {
+		if instruction, ok := editorSectionInstructions[section]; ok {
+			sb.WriteString("- ")
+			sb.WriteString(instruction)
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString(`
+Rules:
+- Every fact must come from the input summaries or evidence (no new facts, no speculation).
+- Use an empty string or empty array when a section has nothing worth saying.
+- Inside strings, use only <b> for key names and numbers and <i> for direct quotes.
+
+Summaries:
+`)
+
+	for i, item := range items {
+		sb.WriteString("[")
+		sb.WriteString(strconv.Itoa(i + 1))
+		sb.WriteString("] Topic: ")
+		sb.WriteString(item.Topic)
+		sb.WriteString(" - ")
+		sb.WriteString(item.Summary)
+		sb.WriteString("\n")
+
+		if ev, ok := evidence[item.ID]; ok && len(ev) > 0 {
+			sb.WriteString(formatEvidenceForPrompt(ev))
+		}
+	}
+
+	return sb.String()
+}
+
+// ParseEditorPlan parses and validates an editor plan response. Sections that
+// are not enabled are dropped, lists are trimmed to their limits, and an
+// error is returned when no enabled section has content.
+func ParseEditorPlan(response string, sections []string) (EditorPlan, error) {
+	var raw EditorPlan
+	if err := json.Unmarshal([]byte(extractJSON(response)), &raw); err != nil {
+		return EditorPlan{}, fmt.Errorf("%w: %w", errParseEditorPlan, err)
+	}
+
+	var plan EditorPlan
+
+	for _, section := range sections {
+		switch section {
+		case EditorSectionLede:
+			plan.Lede = strings.TrimSpace(raw.Lede)
+		case EditorSectionKeyDevelopments:
+			plan.KeyDevelopments = cleanEditorLines(raw.KeyDevelopments, editorMaxKeyDevelopments)
+		case EditorSectionWatchlist:
+			plan.Watchlist = cleanEditorLines(raw.Watchlist, editorMaxWatchlist)
+		case EditorSectionNumbers:
+			plan.Numbers = cleanEditorNumbers(raw.Numbers)
+		}
+	}
+
+	if plan.Lede == "" && len(plan.KeyDevelopments) == 0 && len(plan.Watchlist) == 0 && len(plan.Numbers) == 0 {
+		return EditorPlan{}, ErrEmptyEditorPlan
+	}
+
+	return plan, nil
+}
+
+func cleanEditorLines(lines []string, limit int) []string {
+	cleaned := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			cleaned = append(cleaned, line)
+		}
+
+		if len(cleaned) == limit {
+			break
+		}
+	}
+
+	return cleaned
+}
+
+func cleanEditorNumbers(numbers []EditorNumber) []EditorNumber {
+	cleaned := make([]EditorNumber, 0, len(numbers))
+
+	for _, n := range numbers {
+		n.Value = strings.TrimSpace(n.Value)
+		n.Context = strings.TrimSpace(n.Context)
+
+		if n.Value == "" {
+			continue
+		}
+
+		cleaned = append(cleaned, n)
+		if len(cleaned) == editorMaxNumbers {
+			break
+		}
+	}
+
+	return cleaned
+}
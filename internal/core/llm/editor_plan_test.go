@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+func TestParseEditorPlan(t *testing.T) {
+	response := "Here is the overview:\n```json\n" + `{
+		"lede": " Markets rallied after the rate cut. ",
+		"key_developments": ["One", "", "Two", "Three", "Four", "Five", "Six"],
+		"watchlist": ["Next meeting in March"],
+		"numbers": [{"value": "0.25%", "context": "rate cut"}, {"value": " ", "context": "dropped"}],
+	}` + "\n```"
+
+	plan, err := ParseEditorPlan(response, EditorSections)
+	if err != nil {
+		t.Fatalf("ParseEditorPlan() error = %v", err)
+	}
+
+	if plan.Lede != "Markets rallied after the rate cut." {
+		t.Errorf("Lede = %q", plan.Lede)
+	}
+
+	if len(plan.KeyDevelopments) != editorMaxKeyDevelopments || plan.KeyDevelopments[1] != "Two" {
+		t.Errorf("KeyDevelopments = %v, want 5 non-empty entries", plan.KeyDevelopments)
+	}
+
+	if len(plan.Numbers) != 1 || plan.Numbers[0].Value != "0.25%" {
+		t.Errorf("Numbers = %v, want the single valid figure", plan.Numbers)
+	}
+}
+
+func TestParseEditorPlanDropsDisabledSections(t *testing.T) {
+	response := `{"lede": "Lede", "watchlist": ["Watch"]}`
+
+	plan, err := ParseEditorPlan(response, []string{EditorSectionWatchlist})
+	if err != nil {
+		t.Fatalf("ParseEditorPlan() error = %v", err)
+	}
+
+	if plan.Lede != "" || len(plan.Watchlist) != 1 {
+		t.Errorf("plan = %+v, want only the watchlist", plan)
+	}
+
+	if _, err := ParseEditorPlan(response, []string{EditorSectionNumbers}); !errors.Is(err, ErrEmptyEditorPlan) {
+		t.Errorf("error = %v, want ErrEmptyEditorPlan", err)
+	}
+
+	if _, err := ParseEditorPlan("not json", EditorSections); err == nil {
+		t.Error("expected an error for a non-JSON response")
+	}
+}
+
+func TestBuildEditorPlanPrompt(t *testing.T) {
+	items := []domain.Item{{ID: "1", Topic: "Finance", Summary: "Rates cut"}}
+
+	prompt := BuildEditorPlanPrompt(items, nil, "en", "", []string{EditorSectionLede, EditorSectionNumbers})
+
+	if !strings.Contains(prompt, `"lede"`) || !strings.Contains(prompt, `"numbers"`) {
+		t.Error("prompt should describe the enabled sections")
+	}
+
+	if strings.Contains(prompt, `"watchlist"`) {
+		t.Error("prompt should not describe disabled sections")
+	}
+
+	if !strings.Contains(prompt, "[1] Topic: Finance - Rates cut") {
+		t.Error("prompt should list the item summaries")
+	}
+}
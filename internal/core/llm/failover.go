@@ -0,0 +1,333 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+)
+
+// Failover directions, used as metric labels.
+const (
+	FailoverDirectionOver = "failover"
+	FailoverDirectionBack = "failback"
+)
+
+var errInvalidFailoverSpec = errors.New("invalid failover spec")
+
+// FailoverConfig controls when a task fails over from its primary
+// provider/model and when it fails back.
+type FailoverConfig struct {
+	// Threshold is the number of consecutive primary failures that trigger a
+	// failover. Zero disables failover.
+	Threshold int
+	// ProbeInterval is how often the primary is tried again while failed over.
+	ProbeInterval time.Duration
+	// FailbackSuccesses is the number of consecutive successful probes needed
+	// to fail back to the primary.
+	FailbackSuccesses int
+	// Secondaries are the provider/models to fail over to, per task. Tasks
+	// without one fail over to the next provider of their chain.
+	Secondaries map[TaskType]ProviderModel
+}
+
+// FailoverEvent is sent to the failover callback when a task fails over to
+// its secondary or fails back to its primary.
+type FailoverEvent struct {
+	Task      TaskType
+	Direction string
+	Primary   ProviderModel
+	Secondary ProviderModel
+	Failures  int
+	LastError string
+	Timestamp time.Time
+}
+
+// FailoverStatus describes a task that is currently failed over.
+type FailoverStatus struct {
+	Task      TaskType
+	Primary   ProviderModel
+	Secondary ProviderModel
+	Since     time.Time
+}
+
+// failoverState is the failover state of one task.
+type failoverState struct {
+	primary        ProviderModel
+	secondary      ProviderModel
+	active         bool
+	failures       int
+	probeSuccesses int
+	since          time.Time
+	lastProbe      time.Time
+}
+
+// failoverTracker tracks consecutive primary failures per task and reorders
+// provider chains while a task is failed over.
+type failoverTracker struct {
+	mu       sync.Mutex
+	cfg      FailoverConfig
+	states   map[TaskType]*failoverState
+	callback func(event FailoverEvent)
+	now      func() time.Time
+	logger   *zerolog.Logger
+}
+
+func newFailoverTracker(logger *zerolog.Logger) *failoverTracker {
+	return &failoverTracker{
+		states: make(map[TaskType]*failoverState),
+		now:    time.Now,
+		logger: logger,
+	}
+}
+
+func (t *failoverTracker) setConfig(cfg FailoverConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cfg = cfg
+}
+
+func (t *failoverTracker) setCallback(callback func(event FailoverEvent)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.callback = callback
+}
+
+// withSecondary places the configured secondary of a task right after the
+// primary, the first entry of chain.
+func (t *failoverTracker) withSecondary(taskType TaskType, chain []ProviderModel) []ProviderModel {
+	t.mu.Lock()
+	secondary, ok := t.cfg.Secondaries[taskType]
+	t.mu.Unlock()
+
+	if !ok || len(chain) == 0 || chain[0] == secondary {
+		return chain
+	}
+
+	ordered := make([]ProviderModel, 0, len(chain)+1)
+	ordered = append(ordered, chain[0], secondary)
+
+	for _, pm := range chain[1:] {
+		if pm != secondary {
+			ordered = append(ordered, pm)
+		}
+	}
+
+	return ordered
+}
+
+// isSecondary reports whether pm is the configured secondary of a task.
+func (t *failoverTracker) isSecondary(taskType TaskType, pm ProviderModel) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	secondary, ok := t.cfg.Secondaries[taskType]
+
+	return ok && secondary == pm
+}
+
+// order returns the chain to try for a task whose primary is chain[0]. While
+// the task is failed over, the primary moves to the end of the chain, except
+// for one request per probe interval that tries it first.
+func (t *failoverTracker) order(taskType TaskType, chain []ProviderModel) []ProviderModel {
+	if len(chain) < 2 {
+		return chain
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.states[taskType]
+	if !ok || !state.active || state.primary != chain[0] {
+		return chain
+	}
+
+	now := t.now()
+	if now.Sub(state.lastProbe) >= t.cfg.ProbeInterval {
+		state.lastProbe = now
+
+		return chain
+	}
+
+	ordered := make([]ProviderModel, 0, len(chain))
+	ordered = append(ordered, chain[1:]...)
+
+	return append(ordered, chain[0])
+}
+
+// record updates the failover state of a task after its primary was tried.
+// secondary is the provider/model requests go to while failed over.
+func (t *failoverTracker) record(taskType TaskType, primary, secondary ProviderModel, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cfg.Threshold <= 0 {
+		return
+	}
+
+	state, ok := t.states[taskType]
+	if !ok || state.primary != primary {
+		// The primary changed, e.g. a provider became unavailable: start over
+		if ok && state.active {
+			observability.LLMFailoverActive.WithLabelValues(string(taskType)).Set(MetricValueOnPrimary)
+		}
+
+		state = &failoverState{primary: primary}
+		t.states[taskType] = state
+	}
+
+	if state.active {
+		t.recordProbe(taskType, state, err)
+
+		return
+	}
+
+	if err == nil {
+		state.failures = 0
+
+		return
+	}
+
+	state.failures++
+	if state.failures < t.cfg.Threshold || secondary == (ProviderModel{}) {
+		return
+	}
+
+	now := t.now()
+	state.active = true
+	state.secondary = secondary
+	state.probeSuccesses = 0
+	state.since = now
+	state.lastProbe = now
+
+	t.emit(FailoverEvent{
+		Task:      taskType,
+		Direction: FailoverDirectionOver,
+		Primary:   primary,
+		Secondary: secondary,
+		Failures:  state.failures,
+		LastError: err.Error(),
+		Timestamp: now,
+	})
+}
+
+// recordProbe counts consecutive successful probes of a failed-over primary
+// and fails back once there are enough of them.
+func (t *failoverTracker) recordProbe(taskType TaskType, state *failoverState, err error) {
+	if err != nil {
+		state.probeSuccesses = 0
+
+		return
+	}
+
+	state.probeSuccesses++
+	if state.probeSuccesses < t.cfg.FailbackSuccesses {
+		return
+	}
+
+	state.active = false
+	state.failures = 0
+	state.probeSuccesses = 0
+
+	t.emit(FailoverEvent{
+		Task:      taskType,
+		Direction: FailoverDirectionBack,
+		Primary:   state.primary,
+		Secondary: state.secondary,
+		Timestamp: t.now(),
+	})
+}
+
+// emit logs a failover event, updates its metrics and fires the callback.
+// Callers hold t.mu.
+func (t *failoverTracker) emit(event FailoverEvent) {
+	active := MetricValueOnPrimary
+	if event.Direction == FailoverDirectionOver {
+		active = MetricValueFailedOver
+	}
+
+	observability.LLMFailoverActive.WithLabelValues(string(event.Task)).Set(active)
+	observability.LLMFailovers.WithLabelValues(string(event.Task), event.Direction).Inc()
+
+	t.logger.Warn().
+		Str(logKeyTask, string(event.Task)).
+		Str("direction", event.Direction).
+		Str("primary", FormatProviderModel(event.Primary)).
+		Str("secondary", FormatProviderModel(event.Secondary)).
+		Int("failures", event.Failures).
+		Msg("LLM task failover")
+
+	if t.callback != nil {
+		// Fire callback in goroutine to avoid blocking
+		go t.callback(event)
+	}
+}
+
+// statuses returns the tasks that are currently failed over, by task name.
+func (t *failoverTracker) statuses() []FailoverStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var statuses []FailoverStatus
+
+	for task, state := range t.states {
+		if state.active {
+			statuses = append(statuses, FailoverStatus{Task: task, Primary: state.primary, Secondary: state.secondary, Since: state.since})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Task < statuses[j].Task })
+
+	return statuses
+}
+
+// FormatProviderModel formats a provider/model as "provider:model", or just
+// the provider when the model is the provider default.
+func FormatProviderModel(pm ProviderModel) string {
+	if pm.Model == "" {
+		return string(pm.Provider)
+	}
+
+	return string(pm.Provider) + ":" + pm.Model
+}
+
+// ParseFailoverSecondaries parses comma-separated "task=provider:model"
+// entries. The model may be omitted to use the provider default.
+func ParseFailoverSecondaries(spec string) (map[TaskType]ProviderModel, error) {
+	secondaries := make(map[TaskType]ProviderModel)
+	known := DefaultTaskConfig()
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		task, target, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errInvalidFailoverSpec, entry)
+		}
+
+		taskType := TaskType(strings.TrimSpace(task))
+		if _, ok := known[taskType]; !ok {
+			return nil, fmt.Errorf("%w: unknown task %q", errInvalidFailoverSpec, task)
+		}
+
+		provider, model, _ := strings.Cut(strings.TrimSpace(target), ":")
+		if provider == "" {
+			return nil, fmt.Errorf("%w: %q", errInvalidFailoverSpec, entry)
+		}
+
+		secondaries[taskType] = ProviderModel{Provider: ProviderName(provider), Model: model}
+	}
+
+	return secondaries, nil
+}
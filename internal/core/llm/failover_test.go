@@ -0,0 +1,141 @@
+package llm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var errProviderDown = errors.New("provider down")
+
+func TestParseFailoverSecondaries(t *testing.T) {
+	got, err := ParseFailoverSecondaries(" summarize=anthropic:claude-haiku-4.5, narrative=openrouter:openai/gpt-oss-120b,translate=openai ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[TaskType]ProviderModel{
+		TaskTypeSummarize: {Provider: ProviderAnthropic, Model: "claude-haiku-4.5"},
+		TaskTypeNarrative: {Provider: ProviderOpenRouter, Model: "openai/gpt-oss-120b"},
+		TaskTypeTranslate: {Provider: ProviderOpenAI},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, spec := range []string{"summarize", "unknown=openai:gpt-5", "summarize=:gpt-5"} {
+		if _, err := ParseFailoverSecondaries(spec); !errors.Is(err, errInvalidFailoverSpec) {
+			t.Errorf("%q: expected errInvalidFailoverSpec, got %v", spec, err)
+		}
+	}
+}
+
+func TestFailoverWithSecondary(t *testing.T) {
+	logger := zerolog.Nop()
+	tracker := newFailoverTracker(&logger)
+
+	google := ProviderModel{Provider: ProviderGoogle, Model: "gemini-2.0-flash-lite"}
+	openai := ProviderModel{Provider: ProviderOpenAI, Model: "gpt-5-nano"}
+	anthropic := ProviderModel{Provider: ProviderAnthropic, Model: "claude-haiku-4.5"}
+
+	tracker.setConfig(FailoverConfig{Secondaries: map[TaskType]ProviderModel{TaskTypeSummarize: anthropic}})
+
+	got := tracker.withSecondary(TaskTypeSummarize, []ProviderModel{google, openai, anthropic})
+	if want := []ProviderModel{google, anthropic, openai}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	chain := []ProviderModel{google, openai}
+	if got := tracker.withSecondary(TaskTypeNarrative, chain); !reflect.DeepEqual(got, chain) {
+		t.Errorf("task without secondary: got %v, want %v", got, chain)
+	}
+}
+
+func TestFailoverHysteresis(t *testing.T) {
+	logger := zerolog.Nop()
+	tracker := newFailoverTracker(&logger)
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	events := make(chan FailoverEvent, 2)
+	tracker.setCallback(func(event FailoverEvent) { events <- event })
+	tracker.setConfig(FailoverConfig{Threshold: 2, ProbeInterval: time.Minute, FailbackSuccesses: 2})
+
+	primary := ProviderModel{Provider: ProviderGoogle, Model: "gemini-2.0-flash-lite"}
+	secondary := ProviderModel{Provider: ProviderOpenAI, Model: "gpt-5-nano"}
+	chain := []ProviderModel{primary, secondary}
+	failedOver := []ProviderModel{secondary, primary}
+
+	// A success resets the failure count, so failures must be consecutive
+	tracker.record(TaskTypeSummarize, primary, secondary, errProviderDown)
+	tracker.record(TaskTypeSummarize, primary, secondary, nil)
+	tracker.record(TaskTypeSummarize, primary, secondary, errProviderDown)
+
+	if got := tracker.order(TaskTypeSummarize, chain); !reflect.DeepEqual(got, chain) {
+		t.Fatalf("failed over too early: %v", got)
+	}
+
+	tracker.record(TaskTypeSummarize, primary, secondary, errProviderDown)
+
+	if event := <-events; event.Direction != FailoverDirectionOver || event.Failures != 2 || event.Secondary != secondary {
+		t.Errorf("unexpected failover event: %+v", event)
+	}
+
+	if got := tracker.order(TaskTypeSummarize, chain); !reflect.DeepEqual(got, failedOver) {
+		t.Errorf("while failed over: got %v, want %v", got, failedOver)
+	}
+
+	// One request per probe interval tries the primary first
+	now = now.Add(time.Minute)
+
+	if got := tracker.order(TaskTypeSummarize, chain); !reflect.DeepEqual(got, chain) {
+		t.Errorf("probe: got %v, want %v", got, chain)
+	}
+
+	if got := tracker.order(TaskTypeSummarize, chain); !reflect.DeepEqual(got, failedOver) {
+		t.Errorf("after probe: got %v, want %v", got, failedOver)
+	}
+
+	// A failed probe restarts the count of successful probes
+	tracker.record(TaskTypeSummarize, primary, secondary, nil)
+	tracker.record(TaskTypeSummarize, primary, secondary, errProviderDown)
+	tracker.record(TaskTypeSummarize, primary, secondary, nil)
+
+	if got := tracker.statuses(); len(got) != 1 || got[0].Task != TaskTypeSummarize {
+		t.Fatalf("expected summarize to stay failed over, got %v", got)
+	}
+
+	tracker.record(TaskTypeSummarize, primary, secondary, nil)
+
+	if event := <-events; event.Direction != FailoverDirectionBack {
+		t.Errorf("unexpected failback event: %+v", event)
+	}
+
+	if got := tracker.order(TaskTypeSummarize, chain); !reflect.DeepEqual(got, chain) {
+		t.Errorf("after failback: got %v, want %v", got, chain)
+	}
+
+	if got := tracker.statuses(); len(got) != 0 {
+		t.Errorf("expected no failovers, got %v", got)
+	}
+}
+
+func TestFailoverDisabled(t *testing.T) {
+	logger := zerolog.Nop()
+	tracker := newFailoverTracker(&logger)
+
+	primary := ProviderModel{Provider: ProviderGoogle}
+	secondary := ProviderModel{Provider: ProviderOpenAI}
+
+	for range 10 {
+		tracker.record(TaskTypeSummarize, primary, secondary, errProviderDown)
+	}
+
+	if got := tracker.statuses(); len(got) != 0 {
+		t.Errorf("expected no failovers with a zero threshold, got %v", got)
+	}
+}
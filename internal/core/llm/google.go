@@ -517,7 +517,7 @@ func (p *googleProvider) CompressSummariesForCover(ctx context.Context, summarie
 }
 
 // GenerateDigestCover returns an error as Google Gemini doesn't support image generation in this context.
-func (p *googleProvider) GenerateDigestCover(_ context.Context, _ []string, _ string) ([]byte, error) {
+func (p *googleProvider) GenerateDigestCover(_ context.Context, _ []string, _ string, _ CoverOptions) ([]byte, error) {
 	return nil, ErrNoImageProvider
 }
 
@@ -35,6 +35,9 @@ type BatchResult struct {
 	Summary         string    `json:"summary"`
 	Language        string    `json:"language"`
 	SourceChannel   string    `json:"source_channel"` // Echo back the source channel name for verification
+	Regions         []string  `json:"regions"`        // ISO 3166-1 alpha-2 codes of the countries the message is about
+	AltText         string    `json:"alt_text"`       // Description of the attached image, empty without one
+	ContentFlags    []string  `json:"content_flags"`  // Safety flags ("nsfw", "graphic") for the text or image
 	Embedding       []float32 `json:"-"`
 }
 
@@ -94,7 +97,7 @@ type Client interface {
 	GenerateClusterTopic(ctx context.Context, items []domain.Item, targetLanguage string, model string) (string, error)
 	RelevanceGate(ctx context.Context, text string, model string, prompt string) (RelevanceGateResult, error)
 	CompressSummariesForCover(ctx context.Context, summaries []string) ([]string, error)
-	GenerateDigestCover(ctx context.Context, topics []string, narrative string) ([]byte, error)
+	GenerateDigestCover(ctx context.Context, topics []string, narrative string, opts CoverOptions) ([]byte, error)
 	GetProviderStatuses() []ProviderStatus
 	// Bullet extraction for bulletized digest output
 	ExtractBullets(ctx context.Context, input BulletExtractionInput, targetLanguage string, model string) (BulletExtractionResult, error)
@@ -103,6 +106,9 @@ type Client interface {
 	GetBudgetStatus() (dailyTokens, dailyLimit int64, percentage float64)
 	SetBudgetAlertCallback(callback func(alert BudgetAlert))
 	RecordTokensForBudget(tokens int)
+	// Failover methods
+	SetFailoverCallback(callback func(event FailoverEvent))
+	GetFailoverStatuses() []FailoverStatus
 	// Runtime override methods
 	RefreshOverride(ctx context.Context, reader SettingsReader, settingKey string)
 }
@@ -181,7 +187,8 @@ func registerProviders(ctx context.Context, registry *Registry, cfg *config.Conf
 // It registers providers in priority order: OpenAI (primary), Anthropic (fallback), Google (second fallback).
 // If no providers are configured, it returns a mock client.
 // The usageStore parameter is optional and allows persisting token usage to the database.
-func New(ctx context.Context, cfg *config.Config, store PromptStore, usageStore UsageStore, logger *zerolog.Logger) Client {
+// The rateStore parameter is optional and backs request rates shared across replicas.
+func New(ctx context.Context, cfg *config.Config, store PromptStore, usageStore UsageStore, rateStore RateLimitStore, logger *zerolog.Logger) Client {
 	if logger == nil {
 		nopLogger := zerolog.Nop()
 		logger = &nopLogger
@@ -197,6 +204,12 @@ func New(ctx context.Context, cfg *config.Config, store PromptStore, usageStore
 	circuitCfg := buildCircuitConfig(cfg)
 	registerProviders(ctx, registry, cfg, store, logger, circuitCfg)
 
+	applyFailoverConfig(registry, cfg, logger)
+
+	if rateStore != nil {
+		applySharedRateLimits(registry, rateStore, cfg, logger)
+	}
+
 	// Apply env-based model overrides first
 	applyModelOverrides(registry, cfg)
 
@@ -208,6 +221,48 @@ func New(ctx context.Context, cfg *config.Config, store PromptStore, usageStore
 	return registry
 }
 
+// applyFailoverConfig configures per-task failover from config. Invalid
+// secondaries are logged and ignored.
+func applyFailoverConfig(registry *Registry, cfg *config.Config, logger *zerolog.Logger) {
+	secondaries, err := ParseFailoverSecondaries(cfg.LLMFailoverSecondaries)
+	if err != nil {
+		logger.Warn().Err(err).Msg("ignoring LLM_FAILOVER_SECONDARIES")
+
+		secondaries = nil
+	}
+
+	registry.SetFailoverConfig(FailoverConfig{
+		Threshold:         cfg.LLMFailoverThreshold,
+		ProbeInterval:     cfg.LLMFailoverProbeInterval,
+		FailbackSuccesses: cfg.LLMFailoverFailbackSuccesses,
+		Secondaries:       secondaries,
+	})
+}
+
+// applySharedRateLimits enables the shared rate limiter when
+// LLM_SHARED_RATE_LIMITS is set. Invalid reservations are logged and ignored.
+func applySharedRateLimits(registry *Registry, rateStore RateLimitStore, cfg *config.Config, logger *zerolog.Logger) {
+	limits, err := ParseSharedRateLimits(cfg.LLMSharedRateLimits)
+	if err != nil {
+		logger.Warn().Err(err).Msg("ignoring LLM_SHARED_RATE_LIMITS")
+
+		return
+	}
+
+	if len(limits) == 0 {
+		return
+	}
+
+	reservations, err := ParseRateReservations(cfg.LLMRateReservations)
+	if err != nil {
+		logger.Warn().Err(err).Msg("ignoring LLM_RATE_RESERVATIONS")
+
+		reservations = nil
+	}
+
+	registry.SetSharedRateLimits(rateStore, SharedRateLimitConfig{Limits: limits, Reservations: reservations})
+}
+
 // applyModelOverrides applies per-task model overrides from config.
 func applyModelOverrides(registry *Registry, cfg *config.Config) {
 	// Apply model overrides for each task type
@@ -125,7 +125,7 @@ func (p *mockProvider) CompressSummariesForCover(_ context.Context, summaries []
 }
 
 // GenerateDigestCover returns nil for mock provider.
-func (p *mockProvider) GenerateDigestCover(_ context.Context, _ []string, _ string) ([]byte, error) {
+func (p *mockProvider) GenerateDigestCover(_ context.Context, _ []string, _ string, _ CoverOptions) ([]byte, error) {
 	return nil, nil
 }
 
@@ -131,6 +131,14 @@ func (c *openaiClient) GetBudgetStatus() (int64, int64, float64) {
 // SetBudgetAlertCallback implements Client interface (no-op for single provider).
 func (c *openaiClient) SetBudgetAlertCallback(_ func(alert BudgetAlert)) {}
 
+// SetFailoverCallback implements Client interface (no-op for single provider).
+func (c *openaiClient) SetFailoverCallback(_ func(event FailoverEvent)) {}
+
+// GetFailoverStatuses implements Client interface (single provider never fails over).
+func (c *openaiClient) GetFailoverStatuses() []FailoverStatus {
+	return nil
+}
+
 // RecordTokensForBudget implements Client interface (no-op for single provider).
 func (c *openaiClient) RecordTokensForBudget(_ int) {}
 
@@ -438,12 +446,27 @@ func (c *openaiClient) buildMessageTextPart(index int, m MessageInput) string {
 		textPart += fmt.Sprintf("[BACKGROUND CONTEXT - DO NOT SUMMARIZE: %s] ", truncate(strings.Join(m.Context, " | "), truncateLengthShort))
 	}
 
+	textPart += videoPostNote(m)
 	textPart += c.buildLinkContext(m)
 	textPart += ">>> MESSAGE TO SUMMARIZE <<< " + m.Text + "\n"
 
 	return textPart
 }
 
+// videoPostNote tells the model a message is a video, so it does not read
+// the attached thumbnail as the whole content.
+func videoPostNote(m MessageInput) string {
+	if m.VideoDuration <= 0 {
+		return ""
+	}
+
+	if len(m.MediaData) == 0 {
+		return fmt.Sprintf("(Video post, %ds long) ", m.VideoDuration)
+	}
+
+	return fmt.Sprintf("(Video post, %ds long; the attached image is a single frame from it) ", m.VideoDuration)
+}
+
 func (c *openaiClient) buildLinkContext(m MessageInput) string {
 	return buildLinkContextString(c.cfg, m)
 }
@@ -990,7 +1013,36 @@ func (c *openaiClient) RelevanceGate(ctx context.Context, text string, model str
 	return result, nil
 }
 
+// WithVerbosity combines a tone with a verbosity mode so digest summaries and
+// narratives can be shortened or lengthened per target. Standard verbosity
+// leaves the tone unchanged.
+func WithVerbosity(tone, verbosity string) string {
+	verbosity = strings.ToLower(verbosity)
+	if verbosity == "" || verbosity == VerbosityStandard {
+		return tone
+	}
+
+	return tone + toneVerbositySeparator + verbosity
+}
+
 func getToneInstruction(tone string) string {
+	tone, verbosity, _ := strings.Cut(tone, toneVerbositySeparator)
+
+	return strings.TrimSpace(getBaseToneInstruction(tone) + " " + getVerbosityInstruction(verbosity))
+}
+
+func getVerbosityInstruction(verbosity string) string {
+	switch strings.ToLower(verbosity) {
+	case VerbosityCompact:
+		return "Keep it to a single short sentence per story."
+	case VerbosityDetailed:
+		return "Give a full paragraph per story with background and context."
+	default:
+		return ""
+	}
+}
+
+func getBaseToneInstruction(tone string) string {
 	switch strings.ToLower(tone) {
 	case ToneProfessional:
 		return "Write in a formal, journalistic tone."
@@ -1120,7 +1172,7 @@ func (c *openaiClient) CompressSummariesForCover(ctx context.Context, summaries
 	return phrases, nil
 }
 
-func (c *openaiClient) GenerateDigestCover(ctx context.Context, topics []string, narrative string) ([]byte, error) {
+func (c *openaiClient) GenerateDigestCover(ctx context.Context, topics []string, narrative string, opts CoverOptions) ([]byte, error) {
 	if err := c.checkCircuit(); err != nil {
 		return nil, err
 	}
@@ -1129,7 +1181,7 @@ func (c *openaiClient) GenerateDigestCover(ctx context.Context, topics []string,
 		return nil, fmt.Errorf(errRateLimiter, err)
 	}
 
-	prompt := buildCoverPrompt(topics, narrative)
+	prompt := buildCoverPrompt(topics, narrative, opts)
 	c.logger.Debug().Str("prompt", prompt).Msg("Generating digest cover image")
 
 	resp, err := c.client.CreateImage(ctx, openai.ImageRequest{
@@ -1199,34 +1251,6 @@ func (c *openaiClient) fetchImageFromURL(ctx context.Context, url string) ([]byt
 	return buf.Bytes(), nil
 }
 
-// buildCoverPrompt creates a DALL-E prompt from digest topics and narrative.
-func buildCoverPrompt(topics []string, narrative string) string {
-	var sb strings.Builder
-
-	// If we have narrative (actual content summaries), create a content-specific prompt
-	if narrative != "" {
-		sb.WriteString("Create a symbolic illustration representing these current events: ")
-		sb.WriteString(truncate(narrative, coverPromptNarrativeMaxLength))
-		sb.WriteString(". ")
-		sb.WriteString("Style: editorial illustration, conceptual art, metaphorical imagery. ")
-		sb.WriteString("Use symbolic visual elements that represent the subjects (not literal depictions). ")
-	} else if len(topics) > 0 {
-		// Fallback to topic-based prompt
-		sb.WriteString("Create an editorial illustration for a news digest covering: ")
-		sb.WriteString(strings.Join(topics, ", "))
-		sb.WriteString(". ")
-		sb.WriteString("Style: conceptual magazine cover art with symbolic imagery. ")
-	} else {
-		sb.WriteString("Create an abstract editorial illustration for a news digest. ")
-		sb.WriteString("Style: modern conceptual art, magazine cover aesthetic. ")
-	}
-
-	sb.WriteString("IMPORTANT: Absolutely no text, letters, words, numbers, or writing of any kind. ")
-	sb.WriteString("Clean, professional, visually striking.")
-
-	return sb.String()
-}
-
 // buildCompressSummariesPrompt creates a prompt to compress summaries into short English phrases.
 func buildCompressSummariesPrompt(summaries []string) string {
 	var sb strings.Builder
@@ -133,7 +133,7 @@ func TestBuildCoverPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildCoverPrompt(tt.topics, tt.narrative)
+			got := buildCoverPrompt(tt.topics, tt.narrative, CoverOptions{})
 
 			for _, s := range tt.wantContains {
 				if !strings.Contains(got, s) {
@@ -247,6 +247,9 @@ func TestGetToneInstruction(t *testing.T) {
 		{"CASUAL", "Write in a conversational, accessible tone."}, // case insensitive
 		{"unknown", ""},
 		{"", ""},
+		{WithVerbosity(ToneBrief, VerbosityStandard), "Be extremely concise, telegram-style."},
+		{WithVerbosity(ToneCasual, VerbosityCompact), "Write in a conversational, accessible tone. Keep it to a single short sentence per story."},
+		{WithVerbosity("", VerbosityDetailed), "Give a full paragraph per story with background and context."},
 	}
 
 	for _, tt := range tests {
@@ -692,6 +695,22 @@ func TestBuildMessageTextPart(t *testing.T) {
 			},
 			wantContains: []string{"[BACKGROUND CONTEXT", "Previous message 1", "Previous message 2"},
 		},
+		{
+			name:  "video post with thumbnail",
+			index: 0,
+			input: MessageInput{
+				RawMessage: domain.RawMessage{Text: "Clip", VideoDuration: 83, MediaData: []byte{0xff, 0xd8}},
+			},
+			wantContains: []string{"(Video post, 83s long; the attached image is a single frame from it)", "Clip"},
+		},
+		{
+			name:  "video post without thumbnail",
+			index: 0,
+			input: MessageInput{
+				RawMessage: domain.RawMessage{Text: "Clip", VideoDuration: 5},
+			},
+			wantContains: []string{"(Video post, 5s long)"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -710,7 +729,7 @@ func TestBuildMessageTextPart(t *testing.T) {
 
 func TestNew_MockClient(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	if client == nil {
 		t.Fatal("New() returned nil for empty API key")
@@ -730,7 +749,7 @@ func TestNew_MockClient(t *testing.T) {
 
 func TestNew_MockClientExplicit(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: testAPIKeyMock}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	if client == nil {
 		t.Fatal("New() returned nil for mock API key")
@@ -750,7 +769,7 @@ func TestNew_MockClientExplicit(t *testing.T) {
 
 func TestMockClient_ProcessBatch(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	messages := []MessageInput{
 		{RawMessage: domain.RawMessage{Text: "Message 1", ChannelTitle: "Channel1"}},
@@ -783,7 +802,7 @@ func TestMockClient_ProcessBatch(t *testing.T) {
 
 func TestMockClient_TranslateText(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	text := "Hello world"
 
@@ -799,7 +818,7 @@ func TestMockClient_TranslateText(t *testing.T) {
 
 func TestMockClient_GenerateNarrative(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	items := []domain.Item{
 		{ID: "1", Summary: "Summary 1"},
@@ -826,7 +845,7 @@ func TestMockClient_GenerateNarrative(t *testing.T) {
 
 func TestMockClient_SummarizeCluster(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	items := []domain.Item{
 		{ID: "1", Summary: "Summary 1"},
@@ -850,7 +869,7 @@ func TestMockClient_SummarizeCluster(t *testing.T) {
 
 func TestMockClient_GenerateClusterTopic(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	t.Run("with items", func(t *testing.T) {
 		items := []domain.Item{
@@ -883,7 +902,7 @@ func TestMockClient_GenerateClusterTopic(t *testing.T) {
 
 func TestMockClient_RelevanceGate(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	result, err := client.RelevanceGate(context.Background(), "some text", testModelGPT4, "custom prompt")
 	if err != nil {
@@ -901,7 +920,7 @@ func TestMockClient_RelevanceGate(t *testing.T) {
 
 func TestMockClient_CompressSummariesForCover(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
 	summaries := []string{"Summary one", "Summary two", "Summary three"}
 
@@ -924,9 +943,9 @@ func TestMockClient_CompressSummariesForCover(t *testing.T) {
 
 func TestMockClient_GenerateDigestCover(t *testing.T) {
 	cfg := &config.Config{LLMAPIKey: ""}
-	client := New(context.Background(), cfg, nil, nil, nil)
+	client := New(context.Background(), cfg, nil, nil, nil, nil)
 
-	result, err := client.GenerateDigestCover(context.Background(), []string{"Tech", "News"}, "Some narrative")
+	result, err := client.GenerateDigestCover(context.Background(), []string{"Tech", "News"}, "Some narrative", CoverOptions{})
 	// Mock provider doesn't support image generation, so Registry returns ErrNoImageProvider
 	if err == nil {
 		t.Fatalf("GenerateDigestCover() expected error for mock provider")
@@ -1685,6 +1704,26 @@ func TestLoadPrompt(t *testing.T) {
 		}
 	})
 
+	t.Run("context prompt version overrides active version", func(t *testing.T) {
+		store := &mockPromptStore{
+			settings: map[string]interface{}{
+				testPromptActiveKey: testPromptV3,
+				testPromptV2Key:     testPromptCustomV2,
+			},
+		}
+		c := &openaiClient{cfg: &config.Config{}, promptStore: store}
+		ctx := WithPromptVersions(context.Background(), map[string]string{testPromptKey: testPromptV2})
+		prompt, version := c.loadPrompt(ctx, testPromptKey, defaultPrompt)
+
+		if prompt != testPromptCustomV2 {
+			t.Errorf(testErrLoadPrompt, prompt, testPromptCustomV2)
+		}
+
+		if version != testPromptV2 {
+			t.Errorf(testErrLoadPromptVersion, version, testPromptV2)
+		}
+	})
+
 	t.Run("empty prompt override uses default", func(t *testing.T) {
 		store := &mockPromptStore{
 			settings: map[string]interface{}{
@@ -535,7 +535,7 @@ func (p *openRouterProvider) CompressSummariesForCover(ctx context.Context, summ
 }
 
 // GenerateDigestCover returns an error as OpenRouter doesn't support image generation.
-func (p *openRouterProvider) GenerateDigestCover(_ context.Context, _ []string, _ string) ([]byte, error) {
+func (p *openRouterProvider) GenerateDigestCover(_ context.Context, _ []string, _ string, _ CoverOptions) ([]byte, error) {
 	return nil, ErrNoImageProvider
 }
 
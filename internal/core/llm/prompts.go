@@ -48,6 +48,9 @@ Each result object must include:
   - If irrelevant/link-only/empty, use an empty string and keep scores ≤ 0.2.
 - language: string — 2-letter code of the output language (must match target language).
 - source_channel: string — Exactly the "Source Channel" name provided (verbatim).
+- regions: array of strings — lowercase ISO 3166-1 alpha-2 codes of up to 3 countries the message is mainly about (e.g. ["ua", "pl"]); empty array if none or global.
+- alt_text: string — if an image is attached to the message, a literal description of it for readers who cannot see it, ≤ 150 chars, in the target language, including any short text visible in the image; no HTML. Empty string when there is no image.
+- content_flags: array of strings — "nsfw" if the message or its image shows sexual content or nudity, "graphic" if it shows gore, severe injuries or dead bodies; empty array otherwise. News about violence without graphic detail is not flagged.
 
 Important: Each input has a ">>> MESSAGE TO SUMMARIZE <<<" section. Summarize ONLY that section. "BACKGROUND CONTEXT" is for tone only.
 
@@ -99,6 +102,29 @@ const defaultClusterTopicPrompt = `You are an expert news editor. Based on the f
 Summaries:
 `
 
+// promptVersionsKey is the context key for per-call prompt version overrides.
+type promptVersionsKey struct{}
+
+// WithPromptVersions returns a context whose LLM calls use the given prompt
+// versions, keyed by prompt name (e.g. "narrative"), instead of the active
+// ones. Shadow digests use it to try prompt versions without activating them.
+func WithPromptVersions(ctx context.Context, versions map[string]string) context.Context {
+	if len(versions) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, promptVersionsKey{}, versions)
+}
+
+func promptVersionFromContext(ctx context.Context, baseKey string) string {
+	versions, ok := ctx.Value(promptVersionsKey{}).(map[string]string)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(versions[baseKey])
+}
+
 func (c *openaiClient) loadPrompt(ctx context.Context, baseKey string, fallback string) (string, string) {
 	version := promptDefaultVersion
 
@@ -110,6 +136,10 @@ func (c *openaiClient) loadPrompt(ctx context.Context, baseKey string, fallback
 			}
 		}
 
+		if override := promptVersionFromContext(ctx, baseKey); override != "" {
+			version = override
+		}
+
 		var override string
 		if err := c.promptStore.GetSetting(ctx, promptVersionKey(baseKey, version), &override); err == nil {
 			if strings.TrimSpace(override) != "" {
@@ -55,5 +55,5 @@ type Provider interface {
 
 	// Optional capability - not all providers support image generation
 	SupportsImageGeneration() bool
-	GenerateDigestCover(ctx context.Context, topics []string, narrative string) ([]byte, error)
+	GenerateDigestCover(ctx context.Context, topics []string, narrative string, opts CoverOptions) ([]byte, error)
 }
@@ -31,6 +31,8 @@ type Registry struct {
 	modelOverrides  map[TaskType]string // Per-task model overrides from config
 	budgetTracker   *BudgetTracker
 	usageRecorder   UsageRecorder
+	failover        *failoverTracker
+	sharedLimiter   *sharedRateLimiter
 	logger          *zerolog.Logger
 }
 
@@ -47,6 +49,7 @@ func NewRegistry(logger *zerolog.Logger) *Registry {
 		modelOverrides:  make(map[TaskType]string),
 		budgetTracker:   bt,
 		usageRecorder:   recorder,
+		failover:        newFailoverTracker(logger),
 		logger:          logger,
 	}
 }
@@ -264,7 +267,7 @@ func (r *Registry) ExtractBullets(ctx context.Context, input BulletExtractionInp
 
 // GenerateDigestCover implements Client interface.
 // Uses task-aware fallback for image generation (OpenAI only).
-func (r *Registry) GenerateDigestCover(ctx context.Context, topics []string, narrative string) ([]byte, error) {
+func (r *Registry) GenerateDigestCover(ctx context.Context, topics []string, narrative string, opts CoverOptions) ([]byte, error) {
 	r.mu.RLock()
 	taskChain, hasConfig := r.taskConfig[TaskTypeImageGen]
 	r.mu.RUnlock()
@@ -294,7 +297,11 @@ func (r *Registry) GenerateDigestCover(ctx context.Context, topics []string, nar
 			continue
 		}
 
-		result, err := p.GenerateDigestCover(ctx, topics, narrative)
+		if err := r.sharedLimiter.Wait(ctx, pm.Provider, TaskTypeImageGen); err != nil {
+			return nil, err
+		}
+
+		result, err := p.GenerateDigestCover(ctx, topics, narrative, opts)
 		if err != nil {
 			cb.RecordFailure(embeddings.ProviderName(pm.Provider))
 
@@ -353,18 +360,46 @@ func (r *Registry) getProviderChainForTask(taskType TaskType) []ProviderModel {
 	return providerModels
 }
 
+// availableChain drops the entries of chain whose provider is not registered
+// or not available.
+func (r *Registry) availableChain(chain []ProviderModel) []ProviderModel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	available := make([]ProviderModel, 0, len(chain))
+
+	for _, pm := range chain {
+		if p, ok := r.providers[pm.Provider]; ok && p.IsAvailable() {
+			available = append(available, pm)
+		}
+	}
+
+	return available
+}
+
 // executeWithTaskFallback is a generic helper for task-aware fallback execution.
 // Each provider gets its own timeout via context.WithoutCancel to prevent cascade failures
 // where one slow provider exhausts the shared deadline for all subsequent providers.
 func executeWithTaskFallback[T any](ctx context.Context, r *Registry, taskType TaskType, modelOverride string, fn func(context.Context, Provider, string) (T, error)) (T, error) {
-	providerModels := r.getProviderChainForTask(taskType)
+	chain := r.availableChain(r.failover.withSecondary(taskType, r.availableChain(r.getProviderChainForTask(taskType))))
 
 	var zero T
 
-	if len(providerModels) == 0 {
+	if len(chain) == 0 {
 		return zero, ErrNoProvidersAvailable
 	}
 
+	// The primary is the first available provider; while the task is failed
+	// over, requests go to the one after it first.
+	primary := chain[0]
+
+	var secondary ProviderModel
+	if len(chain) > 1 {
+		secondary = chain[1]
+	}
+
+	providerModels := r.failover.order(taskType, chain)
+
 	// Check for config-level model override if no explicit override provided
 	effectiveModelOverride := modelOverride
 	if effectiveModelOverride == "" {
@@ -384,12 +419,26 @@ func executeWithTaskFallback[T any](ctx context.Context, r *Registry, taskType T
 	for _, pm := range providerModels {
 		providerCtx, cancel := context.WithTimeout(baseCtx, perProviderTimeout)
 
-		result, success, err := tryProviderExec(r, pm, effectiveModelOverride, taskType, func(p Provider, m string) (T, error) {
+		// A configured failover secondary keeps its own model
+		override := effectiveModelOverride
+		if r.failover.isSecondary(taskType, pm) {
+			override = ""
+		}
+
+		result, success, err := tryProviderExec(r, pm, override, taskType, func(p Provider, m string) (T, error) {
+			if err := r.sharedLimiter.Wait(providerCtx, pm.Provider, taskType); err != nil {
+				return zero, err
+			}
+
 			return fn(providerCtx, p, m)
 		})
 
 		cancel()
 
+		if pm == primary && (success || err != nil) {
+			r.failover.record(taskType, primary, secondary, err)
+		}
+
 		if err != nil {
 			lastErr = err
 
@@ -470,6 +519,13 @@ func tryProviderExec[T any](r *Registry, pm ProviderModel, modelOverride string,
 
 	duration := time.Since(start)
 
+	status := StatusSuccess
+	if err != nil {
+		status = StatusError
+	}
+
+	observability.LLMProviderAttempts.WithLabelValues(string(pm.Provider), status).Inc()
+
 	// Record latency metric
 	observability.LLMRequestLatency.WithLabelValues(
 		string(pm.Provider),
@@ -577,5 +633,26 @@ func (r *Registry) RecordTokensForBudget(tokens int) {
 	r.budgetTracker.RecordTokens(tokens)
 }
 
+// SetSharedRateLimits enforces aggregate per-provider request rates across
+// replicas through store. It must be called before the registry is used.
+func (r *Registry) SetSharedRateLimits(store RateLimitStore, cfg SharedRateLimitConfig) {
+	r.sharedLimiter = newSharedRateLimiter(store, cfg, r.logger)
+}
+
+// SetFailoverConfig sets when tasks fail over to their secondary provider/model.
+func (r *Registry) SetFailoverConfig(cfg FailoverConfig) {
+	r.failover.setConfig(cfg)
+}
+
+// SetFailoverCallback sets the callback for failover and failback events.
+func (r *Registry) SetFailoverCallback(callback func(event FailoverEvent)) {
+	r.failover.setCallback(callback)
+}
+
+// GetFailoverStatuses returns the tasks that are currently failed over.
+func (r *Registry) GetFailoverStatuses() []FailoverStatus {
+	return r.failover.statuses()
+}
+
 // Ensure Registry implements Client interface.
 var _ Client = (*Registry)(nil)
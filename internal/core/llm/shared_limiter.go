@@ -0,0 +1,203 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+)
+
+// sharedBucketScope names the bucket of a provider that scopes without a
+// reservation share.
+const sharedBucketScope = "shared"
+
+var (
+	errInvalidRateSpec        = errors.New("invalid rate limit spec")
+	errReservationsExceedRate = errors.New("rate reservations must add up to less than 1")
+)
+
+// RateLimitStore keeps token buckets shared by all replicas.
+type RateLimitStore interface {
+	// TakeRateLimitToken takes a token only if one is available now.
+	TakeRateLimitToken(ctx context.Context, bucket string, capacity, ratePerSecond float64) (bool, error)
+	// ReserveRateLimitToken reserves a token and returns how long to wait for it.
+	ReserveRateLimitToken(ctx context.Context, bucket string, capacity, ratePerSecond float64) (time.Duration, error)
+}
+
+// SharedRateLimitConfig sets aggregate request rates across replicas.
+type SharedRateLimitConfig struct {
+	// Limits are requests per second per provider, across all replicas.
+	Limits map[ProviderName]float64
+	// Reservations are the fractions of each provider limit reserved for a
+	// task. Other tasks share the rest.
+	Reservations map[TaskType]float64
+}
+
+// sharedRateLimiter enforces SharedRateLimitConfig through a RateLimitStore.
+// It complements the per-replica limiters of the providers.
+type sharedRateLimiter struct {
+	store  RateLimitStore
+	cfg    SharedRateLimitConfig
+	logger *zerolog.Logger
+}
+
+func newSharedRateLimiter(store RateLimitStore, cfg SharedRateLimitConfig, logger *zerolog.Logger) *sharedRateLimiter {
+	return &sharedRateLimiter{store: store, cfg: cfg, logger: logger}
+}
+
+// Wait blocks until a request of taskType may be sent to provider. A task
+// with a reservation uses its own bucket while it has tokens and the shared
+// bucket otherwise. Store errors are logged and let the request through, so
+// an unreachable database only falls back to per-replica limits.
+func (l *sharedRateLimiter) Wait(ctx context.Context, provider ProviderName, taskType TaskType) error {
+	if l == nil {
+		return nil
+	}
+
+	limit := l.cfg.Limits[provider]
+	if limit <= 0 {
+		return nil
+	}
+
+	wait, err := l.reserve(ctx, provider, taskType, limit)
+	if err != nil {
+		observability.LLMSharedRateLimitErrors.WithLabelValues(string(provider)).Inc()
+		l.logger.Warn().Err(err).Str(logKeyProvider, string(provider)).Msg("shared LLM rate limit unavailable")
+
+		return nil
+	}
+
+	observability.LLMSharedRateLimitWait.WithLabelValues(string(provider), string(taskType)).Observe(wait.Seconds())
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("shared rate limit wait: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token for a request and returns how long to wait for it.
+func (l *sharedRateLimiter) reserve(ctx context.Context, provider ProviderName, taskType TaskType, limit float64) (time.Duration, error) {
+	if share := l.cfg.Reservations[taskType]; share > 0 {
+		reserved := limit * share
+
+		ok, err := l.store.TakeRateLimitToken(ctx, rateBucketName(provider, string(taskType)), rateBucketCapacity(reserved), reserved)
+		if err != nil {
+			return 0, fmt.Errorf("take reserved token: %w", err)
+		}
+
+		if ok {
+			return 0, nil
+		}
+	}
+
+	shared := limit * (1 - reservedShare(l.cfg.Reservations))
+
+	wait, err := l.store.ReserveRateLimitToken(ctx, rateBucketName(provider, sharedBucketScope), rateBucketCapacity(shared), shared)
+	if err != nil {
+		return 0, fmt.Errorf("reserve shared token: %w", err)
+	}
+
+	return wait, nil
+}
+
+// rateBucketName names the bucket of a provider scope.
+func rateBucketName(provider ProviderName, scope string) string {
+	return "llm:" + string(provider) + ":" + scope
+}
+
+// rateBucketCapacity allows a burst of one second of requests, and at least one.
+func rateBucketCapacity(ratePerSecond float64) float64 {
+	return max(ratePerSecond, 1)
+}
+
+// reservedShare returns the sum of all reservations.
+func reservedShare(reservations map[TaskType]float64) float64 {
+	var total float64
+
+	for _, share := range reservations {
+		total += share
+	}
+
+	return total
+}
+
+// ParseSharedRateLimits parses comma-separated "provider=requests_per_second" entries.
+func ParseSharedRateLimits(spec string) (map[ProviderName]float64, error) {
+	entries, err := parseRateEntries(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[ProviderName]float64, len(entries))
+	for key, value := range entries {
+		limits[ProviderName(key)] = value
+	}
+
+	return limits, nil
+}
+
+// ParseRateReservations parses comma-separated "task=share" entries, where
+// share is the fraction of each provider limit reserved for the task.
+func ParseRateReservations(spec string) (map[TaskType]float64, error) {
+	entries, err := parseRateEntries(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	known := DefaultTaskConfig()
+	reservations := make(map[TaskType]float64, len(entries))
+
+	for key, value := range entries {
+		taskType := TaskType(key)
+		if _, ok := known[taskType]; !ok {
+			return nil, fmt.Errorf("%w: unknown task %q", errInvalidRateSpec, key)
+		}
+
+		reservations[taskType] = value
+	}
+
+	if reservedShare(reservations) >= 1 {
+		return nil, errReservationsExceedRate
+	}
+
+	return reservations, nil
+}
+
+// parseRateEntries parses comma-separated "key=positive number" entries.
+func parseRateEntries(spec string) (map[string]float64, error) {
+	entries := make(map[string]float64)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, raw, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if !ok || key == "" || err != nil || value <= 0 {
+			return nil, fmt.Errorf("%w: %q", errInvalidRateSpec, entry)
+		}
+
+		entries[key] = value
+	}
+
+	return entries, nil
+}
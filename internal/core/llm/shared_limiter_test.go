@@ -0,0 +1,141 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var errStoreDown = errors.New("store down")
+
+// fakeRateLimitStore keeps whole tokens per bucket without refilling.
+type fakeRateLimitStore struct {
+	tokens   map[string]float64
+	reserved []string
+	err      error
+}
+
+func (f *fakeRateLimitStore) TakeRateLimitToken(_ context.Context, bucket string, capacity, _ float64) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+
+	tokens, ok := f.tokens[bucket]
+	if !ok {
+		tokens = capacity
+	}
+
+	if tokens < 1 {
+		return false, nil
+	}
+
+	f.tokens[bucket] = tokens - 1
+
+	return true, nil
+}
+
+func (f *fakeRateLimitStore) ReserveRateLimitToken(_ context.Context, bucket string, capacity, _ float64) (time.Duration, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+
+	f.reserved = append(f.reserved, bucket)
+
+	tokens, ok := f.tokens[bucket]
+	if !ok {
+		tokens = capacity
+	}
+
+	f.tokens[bucket] = tokens - 1
+
+	return 0, nil
+}
+
+func TestSharedRateLimiterReservations(t *testing.T) {
+	logger := zerolog.Nop()
+	store := &fakeRateLimitStore{tokens: map[string]float64{}}
+
+	limiter := newSharedRateLimiter(store, SharedRateLimitConfig{
+		Limits:       map[ProviderName]float64{ProviderOpenAI: 10},
+		Reservations: map[TaskType]float64{TaskTypeNarrative: 0.2},
+	}, &logger)
+
+	ctx := context.Background()
+
+	// The narrative reservation is 2 requests/s with a burst of 2; further
+	// narrative requests spill over to the shared bucket.
+	for range 3 {
+		if err := limiter.Wait(ctx, ProviderOpenAI, TaskTypeNarrative); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := limiter.Wait(ctx, ProviderOpenAI, TaskTypeSummarize); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Providers without a limit are not rate limited
+	if err := limiter.Wait(ctx, ProviderGoogle, TaskTypeSummarize); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	shared := rateBucketName(ProviderOpenAI, sharedBucketScope)
+	if want := []string{shared, shared}; !reflect.DeepEqual(store.reserved, want) {
+		t.Errorf("shared reservations = %v, want %v", store.reserved, want)
+	}
+
+	// The shared bucket refills at the unreserved 8 requests/s
+	if got := store.tokens[shared]; got != 6 {
+		t.Errorf("shared tokens = %v, want 6", got)
+	}
+}
+
+func TestSharedRateLimiterFailsOpen(t *testing.T) {
+	logger := zerolog.Nop()
+	store := &fakeRateLimitStore{err: errStoreDown}
+
+	limiter := newSharedRateLimiter(store, SharedRateLimitConfig{Limits: map[ProviderName]float64{ProviderOpenAI: 1}}, &logger)
+
+	if err := limiter.Wait(context.Background(), ProviderOpenAI, TaskTypeSummarize); err != nil {
+		t.Errorf("expected store errors to let requests through, got %v", err)
+	}
+
+	var disabled *sharedRateLimiter
+	if err := disabled.Wait(context.Background(), ProviderOpenAI, TaskTypeSummarize); err != nil {
+		t.Errorf("expected nil limiter to allow requests, got %v", err)
+	}
+}
+
+func TestParseRateReservations(t *testing.T) {
+	got, err := ParseRateReservations("narrative=0.2, cluster_summary=0.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := map[TaskType]float64{TaskTypeNarrative: 0.2, TaskTypeClusterSummary: 0.1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := ParseRateReservations("narrative=0.6,summarize=0.4"); !errors.Is(err, errReservationsExceedRate) {
+		t.Errorf("expected errReservationsExceedRate, got %v", err)
+	}
+
+	for _, spec := range []string{"unknown=0.1", "narrative", "narrative=-0.1", "narrative=abc"} {
+		if _, err := ParseRateReservations(spec); !errors.Is(err, errInvalidRateSpec) {
+			t.Errorf("%q: expected errInvalidRateSpec, got %v", spec, err)
+		}
+	}
+
+	limits, err := ParseSharedRateLimits("openai=10,google=2.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := map[ProviderName]float64{ProviderOpenAI: 10, ProviderGoogle: 2.5}; !reflect.DeepEqual(limits, want) {
+		t.Errorf("got %v, want %v", limits, want)
+	}
+}
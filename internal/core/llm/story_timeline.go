@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	storyTimelineMaxEvents  = 12
+	storyTimelineDateFormat = "2006-01-02 15:04"
+)
+
+var (
+	// ErrEmptyStoryTimeline is returned when the response has no usable events.
+	ErrEmptyStoryTimeline = errors.New("story timeline has no events")
+	errParseStoryTimeline = errors.New("parse story timeline")
+)
+
+// TimelineSource is one dated item summary of a story, in chronological order.
+type TimelineSource struct {
+	Date    time.Time
+	Summary string
+	Channel string
+}
+
+// TimelineEvent is a one-line development taken from a source, by its index
+// in the source list.
+type TimelineEvent struct {
+	Source int    `json:"source"`
+	Event  string `json:"event"`
+}
+
+// BuildStoryTimelinePrompt builds a prompt asking for a chronological event
+// timeline of a story as JSON, one line per development, each citing the
+// source it came from.
+func BuildStoryTimelinePrompt(title string, sources []TimelineSource, targetLanguage string) string {
+	var sb strings.Builder
+
+	sb.WriteString(`You are a news editor. Build a chronological timeline of the ongoing story below from the dated source summaries.
+Return STRICT JSON ONLY: an array of objects {"source": number, "event": string}. No markdown. No extra keys.
+
+Language requirement:`)
+	sb.WriteString(buildPromptLangInstruction(targetLanguage, "", contextTypeSummary))
+	sb.WriteString(`
+
+Rules:
+- One entry per distinct development, at most ` + strconv.Itoa(storyTimelineMaxEvents) + ` entries.
+- "event" is a single short sentence (max 20 words) saying what happened; plain text, no HTML.
+- "source" is the number of the summary the development comes from.
+- Skip summaries that repeat an earlier development.
+- Every fact must come from the summaries (no new facts, no speculation).
+
+Story: `)
+	sb.WriteString(title)
+	sb.WriteString("\n\nSummaries:\n")
+
+	for i, s := range sources {
+		sb.WriteString("[")
+		sb.WriteString(strconv.Itoa(i + 1))
+		sb.WriteString("] ")
+		sb.WriteString(s.Date.UTC().Format(storyTimelineDateFormat))
+
+		if s.Channel != "" {
+			sb.WriteString(" @")
+			sb.WriteString(s.Channel)
+		}
+
+		sb.WriteString(" - ")
+		sb.WriteString(s.Summary)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// ParseStoryTimeline parses a timeline response for sourceCount sources.
+// Source numbers are converted to zero-based indexes, entries with unknown
+// sources or empty text are dropped, at most one event is kept per source,
+// and events are returned in source order.
+func ParseStoryTimeline(response string, sourceCount int) ([]TimelineEvent, error) {
+	var raw []TimelineEvent
+	if err := json.Unmarshal([]byte(extractJSON(response)), &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", errParseStoryTimeline, err)
+	}
+
+	events := make([]TimelineEvent, 0, len(raw))
+	seen := make(map[int]bool, len(raw))
+
+	for _, e := range raw {
+		e.Event = strings.TrimSpace(e.Event)
+		e.Source--
+
+		if e.Event == "" || e.Source < 0 || e.Source >= sourceCount || seen[e.Source] {
+			continue
+		}
+
+		seen[e.Source] = true
+
+		events = append(events, e)
+	}
+
+	if len(events) == 0 {
+		return nil, ErrEmptyStoryTimeline
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Source < events[j].Source })
+
+	if len(events) > storyTimelineMaxEvents {
+		events = events[:storyTimelineMaxEvents]
+	}
+
+	return events, nil
+}
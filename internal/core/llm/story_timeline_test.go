@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStoryTimeline(t *testing.T) {
+	response := "```json\n" + `[
+		{"source": 3, "event": " Parliament passed the bill. "},
+		{"source": 1, "event": "The bill was introduced."},
+		{"source": 1, "event": "Duplicate source."},
+		{"source": 9, "event": "Unknown source."},
+		{"source": 2, "event": ""}
+	]` + "\n```"
+
+	events, err := ParseStoryTimeline(response, 3)
+	if err != nil {
+		t.Fatalf("ParseStoryTimeline() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want 2", events)
+	}
+
+	if events[0].Source != 0 || events[0].Event != "The bill was introduced." {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+
+	if events[1].Source != 2 || events[1].Event != "Parliament passed the bill." {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+}
+
+func TestParseStoryTimelineErrors(t *testing.T) {
+	if _, err := ParseStoryTimeline(`[{"source": 5, "event": "Out of range"}]`, 2); !errors.Is(err, ErrEmptyStoryTimeline) {
+		t.Errorf("error = %v, want ErrEmptyStoryTimeline", err)
+	}
+
+	if _, err := ParseStoryTimeline("not json", 2); err == nil {
+		t.Error("expected an error for a non-JSON response")
+	}
+}
+
+func TestBuildStoryTimelinePrompt(t *testing.T) {
+	sources := []TimelineSource{
+		{Date: time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC), Summary: "Talks began", Channel: "news"},
+		{Date: time.Date(2026, 2, 2, 18, 0, 0, 0, time.UTC), Summary: "Talks stalled"},
+	}
+
+	prompt := BuildStoryTimelinePrompt("Peace talks", sources, "ru")
+
+	for _, want := range []string{"Story: Peace talks", "[1] 2026-02-01 09:30 @news - Talks began", "[2] 2026-02-02 18:00 - Talks stalled", "ru language"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Package synonyms expands search queries with equivalent spellings, so a
+// search for "Kyiv" also finds "Kiev", "Киев" and "Київ".
+//
+// An Expander combines a dictionary of synonym groups (built-in groups for
+// common Russian/Ukrainian/English place and name spellings, plus groups from
+// an optional dictionary file) with automatic transliteration between
+// Cyrillic and Latin script.
+package synonyms
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxPhrases caps the whole-query variants used for substring matching;
+	// the number of combinations grows with each expanded word.
+	maxPhrases = 16
+
+	// minTranslitRunes is the shortest word that gets a transliterated
+	// variant; shorter words transliterate into noise.
+	minTranslitRunes = 3
+
+	commentPrefix = "#"
+)
+
+// builtinGroups are synonym groups that are always available.
+var builtinGroups = [][]string{
+	{"kyiv", "kiev", "киев", "київ"},
+	{"kharkiv", "kharkov", "харьков", "харків"},
+	{"odesa", "odessa", "одесса", "одеса"},
+	{"lviv", "lvov", "львов", "львів"},
+	{"dnipro", "dnepr", "днепр", "дніпро"},
+	{"zaporizhzhia", "zaporozhye", "запорожье", "запоріжжя"},
+	{"mykolaiv", "nikolaev", "николаев", "миколаїв"},
+	{"chernihiv", "chernigov", "чернигов", "чернігів"},
+	{"zelensky", "zelenskyy", "zelenskiy", "зеленский", "зеленський"},
+	{"belarus", "byelorussia", "беларусь", "белоруссия", "білорусь"},
+	{"usa", "сша", "united states"},
+	{"eu", "ес", "євросоюз", "евросоюз", "european union"},
+	{"nato", "нато"},
+}
+
+// Expansion is the expanded form of a query.
+type Expansion struct {
+	// TSQuery is a to_tsquery expression matching the query words or any
+	// of their variants. It is empty when no word has a variant.
+	TSQuery string
+	// Phrases are whole-query variants for substring matching, starting
+	// with the query itself.
+	Phrases []string
+}
+
+// Expander expands queries with dictionary synonyms and transliterations.
+type Expander struct {
+	groups   map[string][]string
+	maxWords int
+	translit bool
+}
+
+// New creates an Expander from the built-in groups and the extra groups.
+// Groups sharing a term are merged. With translit set, words also match
+// their automatic Cyrillic/Latin transliteration.
+func New(extra [][]string, translit bool) *Expander {
+	e := &Expander{groups: make(map[string][]string), maxWords: 1, translit: translit}
+
+	for _, group := range slices.Concat(builtinGroups, extra) {
+		e.addGroup(group)
+	}
+
+	return e
+}
+
+func (e *Expander) addGroup(group []string) {
+	var merged []string
+
+	for _, term := range group {
+		term = normalizeTerm(term)
+		if term == "" {
+			continue
+		}
+
+		for _, existing := range slices.Concat(e.groups[term], []string{term}) {
+			if !slices.Contains(merged, existing) {
+				merged = append(merged, existing)
+			}
+		}
+	}
+
+	for _, term := range merged {
+		e.groups[term] = merged
+		e.maxWords = max(e.maxWords, len(strings.Fields(term)))
+	}
+}
+
+// LoadDictionary reads synonym groups from a file: one group per line,
+// terms separated by commas, lines starting with # ignored.
+func LoadDictionary(path string) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open synonym dictionary: %w", err)
+	}
+	defer f.Close()
+
+	return ParseDictionary(f)
+}
+
+// ParseDictionary reads synonym groups in the LoadDictionary format.
+func ParseDictionary(r io.Reader) ([][]string, error) {
+	var groups [][]string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+
+		var group []string
+
+		for _, term := range strings.Split(line, ",") {
+			if term = normalizeTerm(term); term != "" {
+				group = append(group, term)
+			}
+		}
+
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read synonym dictionary: %w", err)
+	}
+
+	return groups, nil
+}
+
+// Expand returns the variants of query. Dictionary terms are matched
+// longest first, so multi-word terms such as "united states" win over
+// their words.
+func (e *Expander) Expand(query string) Expansion {
+	words := tokenize(query)
+	alternatives := make([][]string, 0, len(words))
+	expanded := false
+
+	for i := 0; i < len(words); {
+		variants, n := e.variants(words[i:])
+		alternatives = append(alternatives, variants)
+		expanded = expanded || len(variants) > 1
+		i += n
+	}
+
+	phrases := []string{query}
+	if !expanded {
+		return Expansion{Phrases: phrases}
+	}
+
+	return Expansion{TSQuery: tsQuery(alternatives), Phrases: combine(phrases, alternatives)}
+}
+
+// variants returns the variants of the longest dictionary term at the start
+// of words and the number of words it spans, or the first word and its
+// transliteration.
+func (e *Expander) variants(words []string) ([]string, int) {
+	for n := min(e.maxWords, len(words)); n > 0; n-- {
+		if group, ok := e.groups[strings.Join(words[:n], " ")]; ok {
+			return group, n
+		}
+	}
+
+	word := words[0]
+	variants := []string{word}
+
+	if e.translit && len([]rune(word)) >= minTranslitRunes {
+		if t := Transliterate(word); t != "" && t != word {
+			variants = append(variants, t)
+		}
+	}
+
+	return variants, 1
+}
+
+// tsQuery ANDs the word positions, ORing the variants of each. Terms are
+// made of letters and digits only, so quoting them is safe.
+func tsQuery(alternatives [][]string) string {
+	parts := make([]string, 0, len(alternatives))
+
+	for _, variants := range alternatives {
+		terms := make([]string, 0, len(variants))
+
+		for _, variant := range variants {
+			terms = append(terms, "'"+strings.Join(strings.Fields(variant), "' <-> '")+"'")
+		}
+
+		part := strings.Join(terms, " | ")
+		if len(terms) > 1 {
+			part = "(" + part + ")"
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, " & ")
+}
+
+// combine appends the whole-query combinations of the variants to phrases,
+// up to maxPhrases.
+func combine(phrases []string, alternatives [][]string) []string {
+	combos := []string{""}
+
+	for _, variants := range alternatives {
+		next := make([]string, 0, min(len(combos)*len(variants), maxPhrases))
+
+		for _, prefix := range combos {
+			for _, variant := range variants {
+				if len(next) < maxPhrases {
+					next = append(next, strings.TrimSpace(prefix+" "+variant))
+				}
+			}
+		}
+
+		combos = next
+	}
+
+	for _, combo := range combos {
+		if len(phrases) < maxPhrases && !slices.Contains(phrases, combo) {
+			phrases = append(phrases, combo)
+		}
+	}
+
+	return phrases
+}
+
+// tokenize lowercases text and splits it into words of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func normalizeTerm(term string) string {
+	return strings.Join(tokenize(term), " ")
+}
@@ -0,0 +1,99 @@
+package synonyms
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := map[string]string{
+		"киев":     "kiev",
+		"київ":     "kyiv",
+		"харків":   "kharkiv",
+		"щука":     "shchuka",
+		"odessa":   "одесса",
+		"nikolay":  "николай",
+		"kharkov":  "харков",
+		"2024":     "2024",
+		"kievский": "kievский",
+	}
+
+	for word, want := range tests {
+		if got := Transliterate(word); got != want {
+			t.Errorf("Transliterate(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	e := New([][]string{{"kremenchuk", "kremenchug", "кременчуг"}, {"kyiv", "kyjiw"}}, true)
+
+	tests := []struct {
+		name        string
+		query       string
+		wantTSQuery string
+		wantPhrase  string
+	}{
+		{
+			name:        "dictionary group merged with extra terms",
+			query:       "Kiev",
+			wantTSQuery: "('kyiv' | 'kiev' | 'киев' | 'київ' | 'kyjiw')",
+			wantPhrase:  "київ",
+		},
+		{
+			name:        "transliteration of other words",
+			query:       "Кременчуг взрыв",
+			wantTSQuery: "('kremenchuk' | 'kremenchug' | 'кременчуг') & ('взрыв' | 'vzryv')",
+			wantPhrase:  "kremenchuk vzryv",
+		},
+		{
+			name:        "multi-word term",
+			query:       "United States tariffs",
+			wantTSQuery: "('usa' | 'сша' | 'united' <-> 'states') & ('tariffs' | 'тариффс')",
+			wantPhrase:  "сша tariffs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.Expand(tt.query)
+
+			if got.TSQuery != tt.wantTSQuery {
+				t.Errorf("TSQuery = %q, want %q", got.TSQuery, tt.wantTSQuery)
+			}
+
+			if got.Phrases[0] != tt.query || !slices.Contains(got.Phrases, tt.wantPhrase) {
+				t.Errorf("Phrases = %q, want %q first and containing %q", got.Phrases, tt.query, tt.wantPhrase)
+			}
+
+			if len(got.Phrases) > maxPhrases {
+				t.Errorf("len(Phrases) = %d, want at most %d", len(got.Phrases), maxPhrases)
+			}
+		})
+	}
+}
+
+func TestExpandWithoutVariants(t *testing.T) {
+	got := New(nil, false).Expand("drone strike")
+	want := Expansion{Phrases: []string{"drone strike"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseDictionary(t *testing.T) {
+	input := "# comment\n\nKremenchuk,  Кременчуг ,\nsingle\nUnited  States, USA\n"
+
+	got, err := ParseDictionary(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDictionary() error = %v", err)
+	}
+
+	want := [][]string{{"kremenchuk", "кременчуг"}, {"united states", "usa"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDictionary() = %q, want %q", got, want)
+	}
+}
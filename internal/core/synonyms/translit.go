@@ -0,0 +1,126 @@
+package synonyms
+
+import (
+	"strings"
+	"unicode"
+)
+
+// russianToLatin is a simplified BGN/PCGN romanization of Russian.
+var russianToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// ukrainianToLatin is the Ukrainian national romanization (without the
+// word-initial forms of є, ї, й, ю and я).
+var ukrainianToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e",
+	'є': "ie", 'ж': "zh", 'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch",
+	'ш': "sh", 'щ': "shch", 'ь': "", 'ю': "iu", 'я': "ia",
+}
+
+// latinToRussian reverses russianToLatin, digraphs first.
+var latinToRussian = []struct {
+	latin    string
+	cyrillic string
+}{
+	{"shch", "щ"}, {"zh", "ж"}, {"kh", "х"}, {"ts", "ц"}, {"ch", "ч"}, {"sh", "ш"},
+	{"yu", "ю"}, {"ya", "я"}, {"yo", "ё"},
+	{"a", "а"}, {"b", "б"}, {"c", "к"}, {"d", "д"}, {"e", "е"}, {"f", "ф"},
+	{"g", "г"}, {"h", "х"}, {"i", "и"}, {"j", "дж"}, {"k", "к"}, {"l", "л"},
+	{"m", "м"}, {"n", "н"}, {"o", "о"}, {"p", "п"}, {"q", "к"}, {"r", "р"},
+	{"s", "с"}, {"t", "т"}, {"u", "у"}, {"v", "в"}, {"w", "в"}, {"x", "кс"},
+	{"y", "ы"}, {"z", "з"},
+}
+
+// ukrainianLetters only occur in Ukrainian words.
+const ukrainianLetters = "ґєії"
+
+// Transliterate converts a lowercase Cyrillic word to Latin script, or a
+// lowercase Latin word to Russian Cyrillic. Words mixing scripts or
+// containing other characters are returned unchanged.
+func Transliterate(word string) string {
+	switch {
+	case isScript(word, unicode.Cyrillic):
+		table := russianToLatin
+		if strings.ContainsAny(word, ukrainianLetters) {
+			table = ukrainianToLatin
+		}
+
+		return cyrillicToLatin(word, table)
+	case isScript(word, unicode.Latin):
+		return latinToCyrillic(word)
+	default:
+		return word
+	}
+}
+
+func isScript(word string, script *unicode.RangeTable) bool {
+	for _, r := range word {
+		if !unicode.Is(script, r) {
+			return false
+		}
+	}
+
+	return word != ""
+}
+
+func cyrillicToLatin(word string, table map[rune]string) string {
+	var b strings.Builder
+
+	for _, r := range word {
+		if latin, ok := table[r]; ok {
+			b.WriteString(latin)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func latinToCyrillic(word string) string {
+	var b strings.Builder
+
+	for rest := word; rest != ""; {
+		matched := false
+
+		for _, m := range latinToRussian {
+			if after, ok := strings.CutPrefix(rest, m.latin); ok {
+				b.WriteString(vowelY(m.cyrillic, b.String()))
+
+				rest = after
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			r := []rune(rest)[0]
+			b.WriteRune(r)
+			rest = rest[len(string(r)):]
+		}
+	}
+
+	return b.String()
+}
+
+// vowelY spells a "y" after a vowel as "й" ("nikolay" → "николай").
+func vowelY(cyrillic, prefix string) string {
+	if cyrillic != "ы" || prefix == "" {
+		return cyrillic
+	}
+
+	last := []rune(prefix)
+	if strings.ContainsRune("аеёиоуыэюя", last[len(last)-1]) {
+		return "й"
+	}
+
+	return cyrillic
+}
@@ -0,0 +1,84 @@
+package expandedview
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// linkPreviewAgents are user agent fragments of crawlers that fetch links to
+// build previews; their requests are redirected but not counted as clicks.
+var linkPreviewAgents = []string{"telegrambot", "twitterbot", "facebookexternalhit", "slackbot", "discordbot"}
+
+// ClickHandler returns the handler of tracked digest source links
+// (/r/{code}). It counts a click on the item and redirects to the source
+// message.
+func (h *Handler) ClickHandler() http.Handler {
+	return http.HandlerFunc(h.serveClick)
+}
+
+func (h *Handler) serveClick(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	code := strings.TrimPrefix(r.URL.Path, "/")
+
+	target, err := h.database.ResolveItemClickTarget(r.Context(), code)
+	if err != nil {
+		w.Header().Set(headerContentType, "text/html; charset=utf-8")
+
+		if errors.Is(err, db.ErrDeepLinkNotFound) {
+			h.renderError(w, http.StatusNotFound, "Not Found", "This link is invalid or the item no longer exists.")
+			ClicksTotal.WithLabelValues(ClickNotFound).Inc()
+
+			return
+		}
+
+		h.logger.Error().Err(err).Str("code", code).Msg("Failed to resolve click target")
+		h.renderError(w, http.StatusInternalServerError, "Error", "Failed to load item data.")
+		ClicksTotal.WithLabelValues(ClickError).Inc()
+
+		return
+	}
+
+	switch {
+	case r.Method != http.MethodGet || isLinkPreviewAgent(r.UserAgent()):
+		ClicksTotal.WithLabelValues(ClickPreview).Inc()
+	case !h.allowRequest(getClientIP(r)):
+		ClicksTotal.WithLabelValues(ClickLimited).Inc()
+	default:
+		if err := h.database.RecordItemClick(r.Context(), target.ItemID); err != nil {
+			h.logger.Warn().Err(err).Str(logFieldItemID, target.ItemID).Msg("Failed to record item click")
+		}
+
+		ClicksTotal.WithLabelValues(ClickCounted).Inc()
+	}
+
+	http.Redirect(w, r, clickTargetURL(target), http.StatusFound)
+}
+
+// clickTargetURL returns the t.me link to the source message of a click target.
+func clickTargetURL(target *db.ItemClickTarget) string {
+	if target.ChannelUsername != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", target.ChannelUsername, target.MessageID)
+	}
+
+	return fmt.Sprintf("https://t.me/c/%d/%d", target.ChannelPeerID, target.MessageID)
+}
+
+// isLinkPreviewAgent reports whether a user agent belongs to a link preview crawler.
+func isLinkPreviewAgent(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+
+	for _, agent := range linkPreviewAgents {
+		if strings.Contains(userAgent, agent) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package expandedview
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestClickHandler_InvalidCode(t *testing.T) {
+	handler, _ := newTestHandler(t, newTestConfig())
+
+	req := httptest.NewRequest(http.MethodGet, "/not-a-code", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ClickHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestClickTargetURL(t *testing.T) {
+	public := &db.ItemClickTarget{ChannelUsername: "news", ChannelPeerID: 100, MessageID: 7}
+	if got := clickTargetURL(public); got != "https://t.me/news/7" {
+		t.Errorf("clickTargetURL(public) = %q", got)
+	}
+
+	private := &db.ItemClickTarget{ChannelPeerID: 100, MessageID: 7}
+	if got := clickTargetURL(private); got != "https://t.me/c/100/7" {
+		t.Errorf("clickTargetURL(private) = %q", got)
+	}
+}
+
+func TestIsLinkPreviewAgent(t *testing.T) {
+	tests := map[string]bool{
+		"TelegramBot (like TwitterBot)": true,
+		"facebookexternalhit/1.1":       true,
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15": false,
+		"": false,
+	}
+
+	for agent, want := range tests {
+		if got := isLinkPreviewAgent(agent); got != want {
+			t.Errorf("isLinkPreviewAgent(%q) = %v, want %v", agent, got, want)
+		}
+	}
+}
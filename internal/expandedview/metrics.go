@@ -21,6 +21,12 @@ const (
 
 	ErrorTypeDB     = "db_error"
 	ErrorTypeRender = "render_error"
+
+	ClickCounted  = "counted"
+	ClickPreview  = "preview"
+	ClickLimited  = "limited"
+	ClickNotFound = "not_found"
+	ClickError    = "error"
 )
 
 var (
@@ -42,6 +48,12 @@ var (
 		Help: "Total number of expanded view errors",
 	}, []string{"type"})
 
+	// ClicksTotal counts tracked digest link requests by result.
+	ClicksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_link_clicks_total",
+		Help: "Total number of tracked digest link requests",
+	}, []string{"result"})
+
 	// LatencyHistogram measures request latency.
 	LatencyHistogram = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "expanded_view_latency_seconds",
@@ -0,0 +1,145 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gotd/td/telegram/query/dialogs"
+	"github.com/gotd/td/tg"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/settings"
+)
+
+const (
+	// audienceStatsInterval is how often the target channel's member count and
+	// digest views are recorded.
+	audienceStatsInterval = 6 * time.Hour
+	// audienceViewsWindow is how far back digest views are refreshed; views of
+	// older posts have settled.
+	audienceViewsWindow = 14 * 24 * time.Hour
+	// audienceViewsBatch is the number of messages per views request.
+	audienceViewsBatch = 100
+	dialogsBatchSize   = 100
+
+	// botAPIChannelOffset turns a Bot API channel chat id (-100…) into an MTProto channel id.
+	botAPIChannelOffset = -1000000000000
+)
+
+// ErrTargetChannelNotFound indicates the target channel is not among the reader's dialogs.
+var ErrTargetChannelNotFound = errors.New("target channel not found in dialogs")
+
+// maybeRecordAudienceStats starts recording the target channel's audience when
+// the previous recording is older than audienceStatsInterval.
+func (r *Reader) maybeRecordAudienceStats(ctx context.Context, api *tg.Client) {
+	if time.Since(r.lastAudienceStatsRun) < audienceStatsInterval {
+		return
+	}
+
+	r.lastAudienceStatsRun = time.Now()
+
+	go r.recordAudienceStats(ctx, api)
+}
+
+// recordAudienceStats records the member count of the target channel and the
+// current views of the digests recently posted to it.
+func (r *Reader) recordAudienceStats(ctx context.Context, api *tg.Client) {
+	chatID := r.cfg.TargetChatID
+
+	if err := r.database.GetSetting(ctx, settings.TargetChatID, &chatID); err != nil {
+		r.logger.Debug().Err(err).Msg("could not get target_chat_id from DB, using default")
+	}
+
+	channel, err := r.resolveTargetChannel(ctx, api, chatID)
+	if err != nil {
+		r.logger.Warn().Err(err).Int64(logFieldPeerID, chatID).Msg("audience stats skipped: target channel unavailable")
+
+		return
+	}
+
+	full, err := api.ChannelsGetFullChannel(ctx, channel)
+	if err != nil {
+		r.targetChannel = nil
+
+		r.logger.Warn().Err(err).Msg("failed to get target channel for audience stats")
+
+		return
+	}
+
+	if info, ok := full.FullChat.(*tg.ChannelFull); ok {
+		if err := r.database.SaveAudienceSnapshot(ctx, chatID, info.ParticipantsCount); err != nil {
+			r.logger.Warn().Err(err).Msg("failed to save audience snapshot")
+		}
+	}
+
+	r.recordDigestViews(ctx, api, chatID, &tg.InputPeerChannel{ChannelID: channel.ChannelID, AccessHash: channel.AccessHash})
+}
+
+// recordDigestViews stores the view and forward counts of the digests posted
+// within audienceViewsWindow.
+func (r *Reader) recordDigestViews(ctx context.Context, api *tg.Client, chatID int64, peer tg.InputPeerClass) {
+	digests, err := r.database.GetPostedDigestMessages(ctx, chatID, time.Now().Add(-audienceViewsWindow))
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("failed to get posted digests for audience stats")
+
+		return
+	}
+
+	for start := 0; start < len(digests); start += audienceViewsBatch {
+		batch := digests[start:min(start+audienceViewsBatch, len(digests))]
+
+		ids := make([]int, len(batch))
+		for i, d := range batch {
+			ids[i] = int(d.MsgID)
+		}
+
+		res, err := api.MessagesGetMessagesViews(ctx, &tg.MessagesGetMessagesViewsRequest{Peer: peer, ID: ids})
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("failed to get digest views")
+
+			return
+		}
+
+		// Views are returned in request order
+		for i, views := range res.Views {
+			if i >= len(batch) {
+				break
+			}
+
+			if err := r.database.SaveDigestPostStats(ctx, batch[i].DigestID, views.Views, views.Forwards); err != nil {
+				r.logger.Warn().Err(err).Str("digest_id", batch[i].DigestID).Msg("failed to save digest views")
+			}
+		}
+	}
+}
+
+// resolveTargetChannel finds the access hash of the target channel among the
+// reader's dialogs; the reader account must have joined the channel. The
+// result is cached until a request with it fails.
+func (r *Reader) resolveTargetChannel(ctx context.Context, api *tg.Client, chatID int64) (*tg.InputChannel, error) {
+	channelID := botAPIChannelOffset - chatID
+	if channelID <= 0 {
+		return nil, fmt.Errorf(errFmtWrapString, ErrNotAChannel, "target chat id")
+	}
+
+	if r.targetChannel != nil && r.targetChannel.ChannelID == channelID {
+		return r.targetChannel, nil
+	}
+
+	iter := dialogs.NewQueryBuilder(api).GetDialogs().BatchSize(dialogsBatchSize).Iter()
+
+	for iter.Next(ctx) {
+		if p, ok := iter.Value().Peer.(*tg.InputPeerChannel); ok && p.ChannelID == channelID {
+			r.targetChannel = &tg.InputChannel{ChannelID: p.ChannelID, AccessHash: p.AccessHash}
+
+			return r.targetChannel, nil
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dialogs: %w", err)
+	}
+
+	return nil, ErrTargetChannelNotFound
+}
@@ -0,0 +1,124 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gotd/td/tg"
+	"github.com/gotd/td/tgerr"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// channelHealthCheckInterval is how often tracked channels are probed for renames,
+	// privacy changes and deletion.
+	channelHealthCheckInterval = 24 * time.Hour
+	// channelHealthRequestDelay spaces out probe requests to stay under flood limits.
+	channelHealthRequestDelay = time.Second
+
+	errChannelInvalid = "CHANNEL_INVALID"
+)
+
+// maybeCheckChannelHealth starts a health probe of tracked channels when the previous
+// one is older than channelHealthCheckInterval.
+func (r *Reader) maybeCheckChannelHealth(ctx context.Context, api *tg.Client, channels []db.Channel) {
+	if time.Since(r.lastChannelHealthCheck) < channelHealthCheckInterval {
+		return
+	}
+
+	r.lastChannelHealthCheck = time.Now()
+
+	go r.checkChannelHealth(ctx, api, channels)
+}
+
+func (r *Reader) checkChannelHealth(ctx context.Context, api *tg.Client, channels []db.Channel) {
+	for i := range channels {
+		ch := &channels[i]
+		if ch.TGPeerID == 0 || ch.AccessHash == 0 {
+			continue
+		}
+
+		r.checkSingleChannelHealth(ctx, api, ch)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(channelHealthRequestDelay):
+		}
+	}
+}
+
+// checkSingleChannelHealth fetches a channel by its cached peer and records it as
+// private, deleted or renamed when that is what Telegram reports.
+func (r *Reader) checkSingleChannelHealth(ctx context.Context, api *tg.Client, ch *db.Channel) {
+	res, err := api.ChannelsGetChannels(ctx, []tg.InputChannelClass{
+		&tg.InputChannel{ChannelID: ch.TGPeerID, AccessHash: ch.AccessHash},
+	})
+	if err != nil {
+		switch {
+		case tgerr.Is(err, errChannelPrivate):
+			r.markChannelUnavailable(ctx, ch, db.ChannelHealthPrivate, "channel became private")
+		case tgerr.Is(err, errChannelInvalid):
+			r.markChannelUnavailable(ctx, ch, db.ChannelHealthDeleted, "channel no longer exists")
+		default:
+			r.logger.Debug().Err(err).Str(logFieldUsername, ch.Username).Msg("channel health check failed")
+		}
+
+		return
+	}
+
+	chats := res.MapChats()
+	if len(chats.AsChannelForbidden()) > 0 {
+		r.markChannelUnavailable(ctx, ch, db.ChannelHealthPrivate, "channel became private")
+
+		return
+	}
+
+	if found := chats.AsChannel(); len(found) > 0 {
+		r.checkChannelRename(ctx, ch, &found[0])
+	}
+}
+
+// checkChannelRename updates the stored username and title when a channel changed
+// them and records a rename event.
+func (r *Reader) checkChannelRename(ctx context.Context, ch *db.Channel, current *tg.Channel) {
+	changes := channelRenameChanges(ch, current)
+	if len(changes) == 0 {
+		return
+	}
+
+	if err := r.database.UpdateChannelIdentity(ctx, ch.ID, current.Username, current.Title); err != nil {
+		r.logger.Warn().Err(err).Str(logFieldUsername, ch.Username).Msg("failed to update renamed channel")
+
+		return
+	}
+
+	if err := r.database.SetChannelHealth(ctx, ch.ID, db.ChannelHealthRenamed, strings.Join(changes, ", ")); err != nil {
+		r.logger.Warn().Err(err).Str(logFieldUsername, ch.Username).Msg("failed to record channel rename")
+	}
+}
+
+func channelRenameChanges(ch *db.Channel, current *tg.Channel) []string {
+	var changes []string
+
+	if ch.Username != "" && !strings.EqualFold(ch.Username, current.Username) {
+		changes = append(changes, fmt.Sprintf("@%s → @%s", ch.Username, current.Username))
+	}
+
+	if ch.Title != "" && current.Title != "" && ch.Title != current.Title {
+		changes = append(changes, fmt.Sprintf("%q → %q", ch.Title, current.Title))
+	}
+
+	return changes
+}
+
+// markChannelUnavailable deactivates a channel that went private or was deleted and
+// records the health change so admins are notified.
+func (r *Reader) markChannelUnavailable(ctx context.Context, ch *db.Channel, status, detail string) {
+	if err := r.database.SetChannelHealth(ctx, ch.ID, status, detail); err != nil {
+		r.logger.Error().Err(err).Str(logFieldUsername, ch.Username).Str("status", status).Msg("failed to deactivate unavailable channel")
+	}
+}
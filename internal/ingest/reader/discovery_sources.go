@@ -0,0 +1,139 @@
+package reader
+
+import (
+	"context"
+	"time"
+
+	"github.com/gotd/td/tg"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// similarChannelsInterval is how often tracked channels are asked for
+	// Telegram's similar-channel recommendations.
+	similarChannelsInterval = 24 * time.Hour
+	// similarChannelsRequestDelay spaces out recommendation requests to stay under flood limits.
+	similarChannelsRequestDelay = 2 * time.Second
+
+	telegramLinkTypeAddlist = "addlist"
+
+	sourceTypeSimilarChannels = "similar_channels"
+	sourceTypeFolder          = "folder"
+)
+
+// maybeDiscoverSimilarChannels starts a similar-channels sweep when the previous one
+// is older than similarChannelsInterval.
+func (r *Reader) maybeDiscoverSimilarChannels(ctx context.Context, api *tg.Client, channels []db.Channel) {
+	if time.Since(r.lastSimilarChannelsRun) < similarChannelsInterval {
+		return
+	}
+
+	r.lastSimilarChannelsRun = time.Now()
+
+	go r.discoverSimilarChannels(ctx, api, channels)
+}
+
+// discoverSimilarChannels records Telegram's similar-channel recommendations for every
+// tracked channel as discoveries.
+func (r *Reader) discoverSimilarChannels(ctx context.Context, api *tg.Client, channels []db.Channel) {
+	recorded := 0
+
+	for _, ch := range channels {
+		if ch.TGPeerID == 0 || ch.AccessHash == 0 {
+			continue
+		}
+
+		recorded += r.discoverSimilarChannelsFor(ctx, api, ch)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(similarChannelsRequestDelay):
+		}
+	}
+
+	r.logger.Info().Int(logFieldChannels, len(channels)).Int(logFieldCount, recorded).Msg("Similar-channels discovery finished")
+}
+
+func (r *Reader) discoverSimilarChannelsFor(ctx context.Context, api *tg.Client, ch db.Channel) int {
+	req := &tg.ChannelsGetChannelRecommendationsRequest{}
+	req.SetChannel(&tg.InputChannel{ChannelID: ch.TGPeerID, AccessHash: ch.AccessHash})
+
+	res, err := api.ChannelsGetChannelRecommendations(ctx, req)
+	if err != nil {
+		r.logger.Debug().Err(err).Str(logFieldUsername, ch.Username).Msg("failed to get similar channels")
+
+		return 0
+	}
+
+	discoveries := channelDiscoveries(res.MapChats().AsChannel(), ch.ID, sourceTypeSimilarChannels)
+	r.recordDiscoveries(ctx, discoveries)
+
+	return len(discoveries)
+}
+
+// expandFolderLinkDiscovery records every channel in a shared folder (t.me/addlist/...)
+// as its own discovery and marks the folder link itself as expanded.
+func (r *Reader) expandFolderLinkDiscovery(ctx context.Context, api *tg.Client, d db.InviteLinkDiscovery, slug string) {
+	invite, err := api.ChatlistsCheckChatlistInvite(ctx, slug)
+	if err != nil {
+		r.logger.Debug().Err(err).Str(logFieldInviteLink, d.InviteLink).Msg("failed to check folder link")
+		r.incrementResolutionAttempts(ctx, d.ID)
+
+		return
+	}
+
+	fromChannelID, err := r.database.MarkDiscoveryExpanded(ctx, d.ID, folderTitle(invite))
+	if err != nil {
+		r.logger.Warn().Err(err).Str(logFieldInviteLink, d.InviteLink).Msg("failed to mark folder link expanded")
+
+		return
+	}
+
+	discoveries := channelDiscoveries(invite.MapChats().AsChannel(), fromChannelID, sourceTypeFolder)
+	r.recordDiscoveries(ctx, discoveries)
+
+	r.logger.Info().
+		Str(logFieldInviteLink, d.InviteLink).
+		Int(logFieldCount, len(discoveries)).
+		Msg("Expanded folder link discovery")
+}
+
+func folderTitle(invite tg.ChatlistsChatlistInviteClass) string {
+	if i, ok := invite.(*tg.ChatlistsChatlistInvite); ok {
+		return i.Title.Text
+	}
+
+	return ""
+}
+
+// channelDiscoveries converts broadcast channels returned by the API into discoveries.
+// Public channels are recorded by username so they are immediately actionable.
+func channelDiscoveries(channels tg.ChannelArray, fromChannelID, sourceType string) []db.Discovery {
+	discoveries := make([]db.Discovery, 0, len(channels))
+
+	for i := range channels {
+		c := &channels[i]
+		if !c.Broadcast {
+			continue
+		}
+
+		d := db.Discovery{
+			Title:         c.Title,
+			SourceType:    sourceType,
+			FromChannelID: fromChannelID,
+		}
+
+		if c.Username != "" {
+			d.Username = c.Username
+		} else {
+			d.TGPeerID = c.ID
+			d.AccessHash = c.AccessHash
+		}
+
+		discoveries = append(discoveries, d)
+	}
+
+	return discoveries
+}
@@ -0,0 +1,62 @@
+package reader
+
+import (
+	"time"
+
+	"github.com/gotd/td/tg"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// buildChannelUsernames maps channel IDs from a history response to their public usernames.
+func buildChannelUsernames(chats []tg.ChatClass) map[int64]string {
+	usernames := make(map[int64]string)
+
+	for _, chat := range chats {
+		if channel, ok := chat.(*tg.Channel); ok && channel.Username != "" {
+			usernames[channel.ID] = channel.Username
+		}
+	}
+
+	return usernames
+}
+
+// forwardChain unwraps a forwarded channel post into its chain of channels, origin
+// first, followed by the channel it was saved from when that differs. Titles and
+// usernames are filled in when the history response includes those channels.
+// It returns nil for messages not forwarded from a channel.
+func (hpc *historyProcessingContext) forwardChain(msg *tg.Message) []db.ForwardHop {
+	fwd, ok := msg.GetFwdFrom()
+	if !ok {
+		return nil
+	}
+
+	origin, ok := fwd.FromID.(*tg.PeerChannel)
+	if !ok {
+		return nil
+	}
+
+	title := fwd.FromName
+	if title == "" {
+		title = hpc.channelTitles[origin.ChannelID]
+	}
+
+	chain := []db.ForwardHop{{
+		PeerID:    origin.ChannelID,
+		MessageID: int64(fwd.ChannelPost),
+		Username:  hpc.channelUsernames[origin.ChannelID],
+		Title:     title,
+		Date:      time.Unix(int64(fwd.Date), 0).UTC(),
+	}}
+
+	if saved, ok := fwd.SavedFromPeer.(*tg.PeerChannel); ok && saved.ChannelID != origin.ChannelID && saved.ChannelID != hpc.ch.TGPeerID {
+		chain = append(chain, db.ForwardHop{
+			PeerID:    saved.ChannelID,
+			MessageID: int64(fwd.SavedFromMsgID),
+			Username:  hpc.channelUsernames[saved.ChannelID],
+			Title:     hpc.channelTitles[saved.ChannelID],
+		})
+	}
+
+	return chain
+}
@@ -77,7 +77,6 @@ const (
 
 	// Error messages
 	errMsgIncrementResolutionAttempts = "failed to increment resolution attempts"
-	errMsgDeactivatePrivateChannel    = "failed to deactivate private channel"
 
 	// TG Errors
 	errChannelPrivate      = "CHANNEL_PRIVATE"
@@ -136,6 +135,14 @@ type Reader struct {
 	solrSem    chan struct{}
 	// authFailed tracks whether the Telegram session has been revoked
 	authFailed atomic.Bool
+	// lastSimilarChannelsRun is when similar-channel recommendations were last fetched
+	lastSimilarChannelsRun time.Time
+	// lastChannelHealthCheck is when tracked channels were last probed for health
+	lastChannelHealthCheck time.Time
+	// lastAudienceStatsRun is when the target channel's audience was last recorded
+	lastAudienceStatsRun time.Time
+	// targetChannel is the resolved target channel, nil until found in the dialogs
+	targetChannel *tg.InputChannel
 }
 
 // New creates a new Reader with the given dependencies.
@@ -224,6 +231,8 @@ func (r *Reader) loadActiveChannels(ctx context.Context) ([]db.Channel, bool, er
 		return nil, true, nil
 	}
 
+	channels = r.withoutSnoozedChannels(ctx, channels)
+
 	if len(channels) == 0 {
 		r.logger.Info().Msg("No active channels to track. Waiting...")
 
@@ -237,6 +246,36 @@ func (r *Reader) loadActiveChannels(ctx context.Context) ([]db.Channel, bool, er
 	return channels, false, nil
 }
 
+// withoutSnoozedChannels drops the channels whose ingestion is snoozed. When
+// the snooze state cannot be loaded, all channels are kept.
+func (r *Reader) withoutSnoozedChannels(ctx context.Context, channels []db.Channel) []db.Channel {
+	snoozed, err := r.database.GetIngestionSnoozedChannelIDs(ctx)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("failed to get snoozed channels")
+
+		return channels
+	}
+
+	if len(snoozed) == 0 {
+		return channels
+	}
+
+	skip := make(map[string]bool, len(snoozed))
+	for _, id := range snoozed {
+		skip[id] = true
+	}
+
+	active := make([]db.Channel, 0, len(channels))
+
+	for _, ch := range channels {
+		if !skip[ch.ID] {
+			active = append(active, ch)
+		}
+	}
+
+	return active
+}
+
 type fetchResult struct {
 	channel string
 	count   int
@@ -284,6 +323,15 @@ func (r *Reader) ingestMessages(ctx context.Context) error {
 		// Resolve invite link discoveries
 		go r.resolveInviteLinkDiscoveries(ctx, api)
 
+		// Discover channels Telegram recommends as similar to tracked ones
+		r.maybeDiscoverSimilarChannels(ctx, api, channels)
+
+		// Detect channels that were renamed, went private or were deleted
+		r.maybeCheckChannelHealth(ctx, api, channels)
+
+		// Record the target channel's member count and digest views
+		r.maybeRecordAudienceStats(ctx, api)
+
 		// Adaptive delay: shorter if we found messages, longer if quiet
 		cycleDelay := defaultCycleDelay * time.Second
 		if cycleMsgs > 0 {
@@ -544,6 +592,12 @@ func (r *Reader) resolveInviteLinkDiscoveries(ctx context.Context, api *tg.Clien
 }
 
 func (r *Reader) resolveSingleInviteLinkDiscovery(ctx context.Context, api *tg.Client, d db.InviteLinkDiscovery) {
+	if slug := linkextract.AddlistSlug(d.InviteLink); slug != "" {
+		r.expandFolderLinkDiscovery(ctx, api, d, slug)
+
+		return
+	}
+
 	hash := extractInviteHash(d.InviteLink)
 	if hash == "" {
 		r.logger.Debug().Str(logFieldInviteLink, d.InviteLink).Msg("invalid invite link format")
@@ -730,7 +784,7 @@ func createDiscoveryFromTelegramLink(link linkextract.Link, channelID, sourceTyp
 				FromChannelID: channelID,
 			}
 		}
-	case telegramLinkTypeInvite:
+	case telegramLinkTypeInvite, telegramLinkTypeAddlist:
 		return &db.Discovery{
 			InviteLink:    link.URL,
 			SourceType:    sourceType,
@@ -776,9 +830,7 @@ func (r *Reader) fetchChannelMessages(ctx context.Context, api *tg.Client, ch db
 		if tgerr.Is(err, errChannelPrivate) {
 			r.logger.Warn().Err(err).Str(logFieldUsername, ch.Username).Msg("Channel is private, deactivating")
 
-			if deactivateErr := r.database.DeactivateChannelByID(ctx, ch.ID); deactivateErr != nil {
-				r.logger.Error().Err(deactivateErr).Str(logFieldUsername, ch.Username).Msg(errMsgDeactivatePrivateChannel)
-			}
+			r.markChannelUnavailable(ctx, &ch, db.ChannelHealthPrivate, "channel became private")
 		}
 
 		return 0, err
@@ -789,9 +841,7 @@ func (r *Reader) fetchChannelMessages(ctx context.Context, api *tg.Client, ch db
 		if tgerr.Is(err, errChannelPrivate) {
 			r.logger.Warn().Err(err).Str(logFieldUsername, ch.Username).Msg("Channel is private (from history), deactivating")
 
-			if deactivateErr := r.database.DeactivateChannelByID(ctx, ch.ID); deactivateErr != nil {
-				r.logger.Error().Err(deactivateErr).Str(logFieldUsername, ch.Username).Msg(errMsgDeactivatePrivateChannel)
-			}
+			r.markChannelUnavailable(ctx, &ch, db.ChannelHealthPrivate, "channel became private")
 		}
 
 		return 0, err
@@ -933,9 +983,7 @@ func (r *Reader) resolveByUsername(ctx context.Context, api *tg.Client, ch *db.C
 		if tgerr.Is(err, "USERNAME_INVALID", "USERNAME_NOT_OCCUPIED") {
 			r.logger.Warn().Err(err).Str(logFieldUsername, ch.Username).Msg("Invalid or unoccupied username, deactivating channel")
 
-			if deactivateErr := r.database.DeactivateChannel(ctx, ch.Username); deactivateErr != nil {
-				r.logger.Error().Err(deactivateErr).Str(logFieldUsername, ch.Username).Msg("failed to deactivate invalid channel")
-			}
+			r.markChannelUnavailable(ctx, ch, db.ChannelHealthDeleted, "username no longer exists")
 		}
 
 		return nil, false, fmt.Errorf("failed to resolve username: %w", err)
@@ -989,9 +1037,7 @@ func (r *Reader) fetchDescriptionIfMissing(ctx context.Context, api *tg.Client,
 		if tgerr.Is(err, errChannelPrivate) {
 			r.logger.Warn().Err(err).Int64(logFieldPeerID, ch.TGPeerID).Msg("Channel is private (from description fetch), deactivating")
 
-			if deactivateErr := r.database.DeactivateChannelByID(ctx, ch.ID); deactivateErr != nil {
-				r.logger.Error().Err(deactivateErr).Str(logFieldUsername, ch.Username).Msg(errMsgDeactivatePrivateChannel)
-			}
+			r.markChannelUnavailable(ctx, ch, db.ChannelHealthPrivate, "channel became private")
 		} else {
 			r.logger.Warn().Err(err).Int64(logFieldPeerID, ch.TGPeerID).Msg("failed to fetch channel description")
 		}
@@ -1050,6 +1096,7 @@ type historyProcessingContext struct {
 	ch                  db.Channel
 	channelTitles       map[int64]string
 	channelAccessHashes map[int64]int64
+	channelUsernames    map[int64]string
 	seenCount           int
 	processedCount      int
 	backfillCount       int
@@ -1109,6 +1156,10 @@ func (r *Reader) processSingleMessage(ctx context.Context, hpc *historyProcessin
 		CanonicalHash:     r.canonicalize(msg.Message),
 		IsForward:         isForward,
 		HasCommentsThread: hasCommentsThread,
+		Views:             msg.Views,
+		Forwards:          msg.Forwards,
+		ForwardChain:      hpc.forwardChain(msg),
+		VideoDuration:     videoDuration(msg.Media),
 	}
 
 	age := time.Since(rawMsg.TGDate).Seconds()
@@ -1253,6 +1304,7 @@ func (r *Reader) processHistoryMessages(ctx context.Context, api *tg.Client, his
 		ch:  ch,
 	}
 	hpc.channelTitles, hpc.channelAccessHashes = r.buildChannelLookups(chats)
+	hpc.channelUsernames = buildChannelUsernames(chats)
 
 	r.logger.Debug().Str(logFieldChannel, ch.Username).Int(logFieldCount, len(messages)).Int("chats_in_response", len(chats)).Msg("Processing messages")
 
@@ -1453,38 +1505,8 @@ func (r *Reader) getPhotoFileLocation(m *tg.MessageMediaPhoto) tg.InputFileLocat
 		return nil
 	}
 
-	// Find the largest photo size
-	var largest tg.PhotoSizeClass
-
-	maxSize := 0
-
-	for _, size := range photo.Sizes {
-		switch s := size.(type) {
-		case *tg.PhotoSize:
-			if s.W*s.H > maxSize {
-				maxSize = s.W * s.H
-				largest = size
-			}
-		case *tg.PhotoSizeProgressive:
-			if s.W*s.H > maxSize {
-				maxSize = s.W * s.H
-				largest = size
-			}
-		}
-	}
-
-	if largest == nil {
-		return nil
-	}
-
-	var thumbSize string
-
-	switch s := largest.(type) {
-	case *tg.PhotoSize:
-		thumbSize = s.Type
-	case *tg.PhotoSizeProgressive:
-		thumbSize = s.Type
-	default:
+	thumbSize, ok := largestPhotoSize(photo.Sizes)
+	if !ok {
 		return nil
 	}
 
@@ -1516,6 +1538,11 @@ func (r *Reader) getDocumentFileLocation(m *tg.MessageMediaDocument) tg.InputFil
 		}
 	}
 
+	// Videos contribute their thumbnail instead
+	if !isImage && isVideoDocument(doc) {
+		return videoThumbLocation(doc)
+	}
+
 	if !isImage {
 		return nil
 	}
@@ -1575,7 +1602,7 @@ func (dc *discoveryContext) extractTelegramLinkDiscoveries(url, sourceType strin
 					Forwards:      dc.forwards,
 				})
 			}
-		case telegramLinkTypeInvite:
+		case telegramLinkTypeInvite, telegramLinkTypeAddlist:
 			discoveries = append(discoveries, db.Discovery{
 				InviteLink:    link.URL,
 				SourceType:    sourceType,
@@ -2,6 +2,7 @@ package reader
 
 import (
 	"context"
+	"time"
 
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
@@ -10,10 +11,11 @@ import (
 type Repository interface {
 	// Channel operations
 	GetActiveChannels(ctx context.Context) ([]db.Channel, error)
+	GetIngestionSnoozedChannelIDs(ctx context.Context) ([]string, error)
 	UpdateChannel(ctx context.Context, id string, peerID int64, title string, accessHash int64, username, description string) error
 	UpdateChannelLastMessageID(ctx context.Context, id string, msgID int64) error
-	DeactivateChannel(ctx context.Context, identifier string) error
-	DeactivateChannelByID(ctx context.Context, id string) error
+	UpdateChannelIdentity(ctx context.Context, id, username, title string) error
+	SetChannelHealth(ctx context.Context, channelID, status, detail string) error
 
 	// Message operations
 	SaveRawMessage(ctx context.Context, msg *db.RawMessage) error
@@ -26,6 +28,13 @@ type Repository interface {
 	IncrementDiscoveryResolutionAttempts(ctx context.Context, id string) error
 	UpdateDiscoveryChannelInfo(ctx context.Context, id, title, username, description string) error
 	UpdateDiscoveryFromInvite(ctx context.Context, id, title, username, description string, peerID, accessHash int64) error
+	MarkDiscoveryExpanded(ctx context.Context, id, title string) (string, error)
+
+	// Audience operations
+	GetSetting(ctx context.Context, key string, target interface{}) error
+	SaveAudienceSnapshot(ctx context.Context, chatID int64, members int) error
+	GetPostedDigestMessages(ctx context.Context, chatID int64, since time.Time) ([]db.PostedDigestMessage, error)
+	SaveDigestPostStats(ctx context.Context, digestID string, views, forwards int) error
 }
 
 // Compile-time assertion that *db.DB implements Repository.
@@ -0,0 +1,90 @@
+package reader
+
+import (
+	"math"
+	"strings"
+
+	"github.com/gotd/td/tg"
+)
+
+// videoAttribute returns the video attribute of a video document.
+func videoAttribute(doc *tg.Document) (*tg.DocumentAttributeVideo, bool) {
+	for _, attr := range doc.Attributes {
+		if video, ok := attr.(*tg.DocumentAttributeVideo); ok {
+			return video, true
+		}
+	}
+
+	return nil, false
+}
+
+// isVideoDocument reports whether doc is a video (round videos included).
+func isVideoDocument(doc *tg.Document) bool {
+	if _, ok := videoAttribute(doc); ok {
+		return true
+	}
+
+	return strings.HasPrefix(doc.MimeType, "video/")
+}
+
+// videoDuration returns the length of a video post in whole seconds, rounded
+// up so that short clips still count as videos. It is zero for other posts.
+func videoDuration(media tg.MessageMediaClass) int {
+	m, ok := media.(*tg.MessageMediaDocument)
+	if !ok {
+		return 0
+	}
+
+	doc, ok := m.Document.(*tg.Document)
+	if !ok {
+		return 0
+	}
+
+	video, ok := videoAttribute(doc)
+	if !ok || video.Duration <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(video.Duration))
+}
+
+// videoThumbLocation locates the largest thumbnail Telegram generated for a
+// video, so video posts get a picture without downloading the video itself.
+func videoThumbLocation(doc *tg.Document) tg.InputFileLocationClass {
+	thumbSize, ok := largestPhotoSize(doc.Thumbs)
+	if !ok {
+		return nil
+	}
+
+	return &tg.InputDocumentFileLocation{
+		ID:            doc.ID,
+		AccessHash:    doc.AccessHash,
+		FileReference: doc.FileReference,
+		ThumbSize:     thumbSize,
+	}
+}
+
+// largestPhotoSize returns the type of the largest downloadable size.
+func largestPhotoSize(sizes []tg.PhotoSizeClass) (string, bool) {
+	var (
+		thumbSize string
+		maxSize   int
+	)
+
+	for _, size := range sizes {
+		switch s := size.(type) {
+		case *tg.PhotoSize:
+			if s.W*s.H > maxSize {
+				maxSize = s.W * s.H
+				thumbSize = s.Type
+			}
+		case *tg.PhotoSizeProgressive:
+			if s.W*s.H > maxSize {
+				maxSize = s.W * s.H
+				thumbSize = s.Type
+			}
+		}
+	}
+
+	return thumbSize, thumbSize != ""
+}
@@ -0,0 +1,127 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestRenderICS(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 5, 17, 0, 0, 0, time.UTC)
+	events := []db.CalendarEvent{
+		{
+			ID: "e1", Title: "EU summit; day one, Brussels", Kind: "meeting",
+			StartsAt: time.Date(2026, 3, 5, 13, 0, 0, 0, time.UTC), EndsAt: &end,
+			Location: "Brussels", FirstSeenAt: updated, UpdatedAt: updated,
+			Mentions: 2, Summary: "<b>Leaders</b> meet", ChannelUsername: "news", MessageID: 42,
+		},
+		{
+			ID: "e2", Title: "Tax deadline", Kind: "deadline", AllDay: true,
+			StartsAt:    time.Date(2026, 4, 14, 22, 0, 0, 0, time.UTC),
+			FirstSeenAt: updated, UpdatedAt: updated, ChannelPeerID: 100, MessageID: 7,
+		},
+	}
+
+	berlin := time.FixedZone("CEST", 2*60*60)
+	out := RenderICS(events, berlin)
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:e1@telegram-digest-bot\r\n",
+		"DTSTART:20260305T130000Z\r\n",
+		"DTEND:20260305T170000Z\r\n",
+		`SUMMARY:EU summit\; day one\, Brussels` + "\r\n",
+		"CATEGORIES:MEETING\r\n",
+		"LOCATION:Brussels\r\n",
+		`DESCRIPTION:Leaders meet\n\nSource: https://t.me/news/42\n\nAnnounced`,
+		"URL:https://t.me/news/42\r\n",
+		"DTSTART;VALUE=DATE:20260415\r\n",
+		"DTEND;VALUE=DATE:20260416\r\n",
+		"URL:https://t.me/c/100/7\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderICS() missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteLineFolds(t *testing.T) {
+	var sb strings.Builder
+
+	writeLine(&sb, "SUMMARY:"+strings.Repeat("я", 80))
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected a folded line, got %q", sb.String())
+	}
+
+	var unfolded strings.Builder
+
+	for i, line := range lines {
+		if len(line) > icsMaxLineOctets {
+			t.Errorf("line %d has %d octets", i, len(line))
+		}
+
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+
+			line = line[1:]
+		}
+
+		unfolded.WriteString(line)
+	}
+
+	if want := "SUMMARY:" + strings.Repeat("я", 80); unfolded.String() != want {
+		t.Errorf("unfolded = %q, want %q", unfolded.String(), want)
+	}
+}
+
+type fakeRepo struct {
+	events []db.CalendarEvent
+	calls  int
+}
+
+func (f *fakeRepo) GetCalendarEvents(_ context.Context, _, _ time.Time, _ int) ([]db.CalendarEvent, error) {
+	f.calls++
+
+	return f.events, nil
+}
+
+func TestHandler(t *testing.T) {
+	logger := zerolog.Nop()
+	repo := &fakeRepo{events: []db.CalendarEvent{{ID: "e1", Title: "Launch", Kind: "event", StartsAt: time.Now()}}}
+	h := NewHandler(repo, "secret", time.UTC, 30, &logger)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar.ics?token=wrong", nil))
+
+	if rec.Code != http.StatusForbidden || repo.calls != 0 {
+		t.Fatalf("wrong token: status = %d, calls = %d", rec.Code, repo.calls)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar.ics?token=secret", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	if !strings.Contains(rec.Body.String(), "SUMMARY:Launch") {
+		t.Errorf("body missing event: %s", rec.Body.String())
+	}
+}
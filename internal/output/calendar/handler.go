@@ -0,0 +1,74 @@
+package calendar
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	feedPastDays  = 7
+	feedMaxEvents = 500
+	hoursPerDay   = 24
+)
+
+// Repository provides the announced events.
+type Repository interface {
+	GetCalendarEvents(ctx context.Context, from, to time.Time, limit int) ([]db.CalendarEvent, error)
+}
+
+// Handler serves the ICS feed (/calendar.ics?token=...). The feed covers the
+// past week and the configured number of days ahead.
+type Handler struct {
+	repo   Repository
+	token  string
+	loc    *time.Location
+	days   int
+	logger *zerolog.Logger
+}
+
+// NewHandler creates the feed handler. Requests must carry token in the
+// token query parameter.
+func NewHandler(repo Repository, token string, loc *time.Location, days int, logger *zerolog.Logger) *Handler {
+	return &Handler{repo: repo, token: token, loc: loc, days: days, logger: logger}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(h.token)) != 1 {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	now := time.Now()
+
+	events, err := h.repo.GetCalendarEvents(r.Context(), now.Add(-feedPastDays*hoursPerDay*time.Hour), now.AddDate(0, 0, h.days), feedMaxEvents)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to load calendar events")
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="calendar.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+
+	if _, err := w.Write([]byte(RenderICS(events, h.loc))); err != nil {
+		h.logger.Debug().Err(err).Msg("Failed to write calendar feed")
+	}
+}
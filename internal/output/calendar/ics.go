@@ -0,0 +1,135 @@
+// Package calendar publishes the events announced in items as an iCalendar
+// (RFC 5545) feed that calendar apps can subscribe to.
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// CalendarName is the display name of the feed.
+	CalendarName = "Telegram Digest: Upcoming"
+
+	icsProdID        = "-//telegram-digest-bot//Announced events//EN"
+	icsUIDDomain     = "telegram-digest-bot"
+	icsUTCLayout     = "20060102T150405Z"
+	icsDateLayout    = "20060102"
+	icsMaxLineOctets = 75
+	icsLineBreak     = "\r\n"
+)
+
+// RenderICS renders events as an iCalendar document. All-day events are
+// dated in loc.
+func RenderICS(events []db.CalendarEvent, loc *time.Location) string {
+	var sb strings.Builder
+
+	writeLine(&sb, "BEGIN:VCALENDAR")
+	writeLine(&sb, "VERSION:2.0")
+	writeLine(&sb, "PRODID:"+icsProdID)
+	writeLine(&sb, "CALSCALE:GREGORIAN")
+	writeLine(&sb, "METHOD:PUBLISH")
+	writeLine(&sb, "X-WR-CALNAME:"+escapeText(CalendarName))
+
+	for _, e := range events {
+		writeEvent(&sb, e, loc)
+	}
+
+	writeLine(&sb, "END:VCALENDAR")
+
+	return sb.String()
+}
+
+func writeEvent(sb *strings.Builder, e db.CalendarEvent, loc *time.Location) {
+	writeLine(sb, "BEGIN:VEVENT")
+	writeLine(sb, "UID:"+e.ID+"@"+icsUIDDomain)
+	writeLine(sb, "DTSTAMP:"+e.UpdatedAt.UTC().Format(icsUTCLayout))
+	writeLine(sb, "CREATED:"+e.FirstSeenAt.UTC().Format(icsUTCLayout))
+	writeLine(sb, "LAST-MODIFIED:"+e.UpdatedAt.UTC().Format(icsUTCLayout))
+
+	if e.AllDay {
+		start := e.StartsAt.In(loc)
+		// DTEND of an all-day event is exclusive
+		end := start.AddDate(0, 0, 1)
+
+		if e.EndsAt != nil && e.EndsAt.After(e.StartsAt) {
+			end = e.EndsAt.In(loc).AddDate(0, 0, 1)
+		}
+
+		writeLine(sb, "DTSTART;VALUE=DATE:"+start.Format(icsDateLayout))
+		writeLine(sb, "DTEND;VALUE=DATE:"+end.Format(icsDateLayout))
+	} else {
+		writeLine(sb, "DTSTART:"+e.StartsAt.UTC().Format(icsUTCLayout))
+
+		if e.EndsAt != nil && e.EndsAt.After(e.StartsAt) {
+			writeLine(sb, "DTEND:"+e.EndsAt.UTC().Format(icsUTCLayout))
+		}
+	}
+
+	writeLine(sb, "SUMMARY:"+escapeText(e.Title))
+	writeLine(sb, "CATEGORIES:"+escapeText(strings.ToUpper(e.Kind)))
+
+	if e.Location != "" {
+		writeLine(sb, "LOCATION:"+escapeText(e.Location))
+	}
+
+	source := messageURL(e)
+	writeLine(sb, "DESCRIPTION:"+escapeText(eventDescription(e, source)))
+	writeLine(sb, "URL:"+source)
+	writeLine(sb, "END:VEVENT")
+}
+
+func eventDescription(e db.CalendarEvent, source string) string {
+	var parts []string
+
+	if summary := strings.TrimSpace(htmlutils.StripHTMLTags(e.Summary)); summary != "" {
+		parts = append(parts, summary)
+	}
+
+	parts = append(parts, "Source: "+source)
+
+	if e.Mentions > 1 {
+		parts = append(parts, fmt.Sprintf("Announced in %d messages", e.Mentions))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// messageURL links an event to the message that first announced it.
+func messageURL(e db.CalendarEvent) string {
+	if e.ChannelUsername != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", e.ChannelUsername, e.MessageID)
+	}
+
+	return fmt.Sprintf("https://t.me/c/%d/%d", e.ChannelPeerID, e.MessageID)
+}
+
+// escapeText escapes a TEXT property value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// writeLine writes a content line folded at 75 octets without splitting
+// UTF-8 sequences.
+func writeLine(sb *strings.Builder, line string) {
+	limit := icsMaxLineOctets
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		sb.WriteString(line[:cut] + icsLineBreak + " ")
+		line = line[cut:]
+		// Continuation lines start with a space
+		limit = icsMaxLineOctets - 1
+	}
+
+	sb.WriteString(line + icsLineBreak)
+}
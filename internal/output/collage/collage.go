@@ -0,0 +1,169 @@
+// Package collage composes several item images into one grid image, so a
+// cluster can be shown with all of its pictures in a single message.
+package collage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Register the formats channel media arrives in.
+	_ "image/gif"
+	_ "image/png"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxImages caps how many images a collage shows.
+	MaxImages = 4
+	// MaxBytes caps the size of an encoded collage.
+	MaxBytes = 5 << 20
+
+	// Width and Height are the size of composed collages.
+	Width  = 1280
+	Height = 720
+
+	gap             = 6
+	jpegQuality     = 85
+	maxSourceBytes  = 10 << 20
+	maxSourcePixels = 40_000_000
+	minImages       = 2
+)
+
+var (
+	// ErrTooFewImages is returned when fewer than two images can be decoded.
+	ErrTooFewImages = errors.New("collage needs at least two usable images")
+	// ErrTooLarge is returned when the encoded collage exceeds MaxBytes.
+	ErrTooLarge = errors.New("collage exceeds the size limit")
+)
+
+var background = color.RGBA{0x11, 0x11, 0x11, 0xff}
+
+// Key identifies a collage by the hashes of its images, in order.
+func Key(images [][]byte) string {
+	sums := make([]byte, 0, len(images)*sha256.Size)
+
+	for _, data := range images {
+		sum := sha256.Sum256(data)
+		sums = append(sums, sum[:]...)
+	}
+
+	key := sha256.Sum256(sums)
+
+	return hex.EncodeToString(key[:])
+}
+
+// Compose draws up to MaxImages images into a grid and encodes it as a JPEG.
+// Images that are too large or cannot be decoded are skipped.
+func Compose(images [][]byte) ([]byte, error) {
+	decoded := decodeImages(images)
+	if len(decoded) < minImages {
+		return nil, ErrTooFewImages
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	xdraw.Draw(canvas, canvas.Bounds(), image.NewUniform(background), image.Point{}, xdraw.Src)
+
+	for i, cell := range layout(len(decoded)) {
+		drawFill(canvas, cell, decoded[i])
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode collage: %w", err)
+	}
+
+	if buf.Len() > MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeImages decodes the first MaxImages usable images.
+func decodeImages(images [][]byte) []image.Image {
+	decoded := make([]image.Image, 0, MaxImages)
+
+	for _, data := range images {
+		if len(decoded) == MaxImages {
+			break
+		}
+
+		if img, ok := decodeImage(data); ok {
+			decoded = append(decoded, img)
+		}
+	}
+
+	return decoded
+}
+
+// decodeImage decodes data, rejecting oversized files and dimensions before
+// allocating the image.
+func decodeImage(data []byte) (image.Image, bool) {
+	if len(data) == 0 || len(data) > maxSourceBytes {
+		return nil, false
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, false
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+
+	return img, true
+}
+
+// layout splits the canvas into n cells: two side by side, one large cell
+// beside two stacked ones, or a 2×2 grid.
+func layout(n int) []image.Rectangle {
+	halfW := (Width - gap) / 2
+	halfH := (Height - gap) / 2
+	left := image.Rect(0, 0, halfW, Height)
+	topRight := image.Rect(halfW+gap, 0, Width, halfH)
+	bottomRight := image.Rect(halfW+gap, halfH+gap, Width, Height)
+
+	switch n {
+	case minImages:
+		return []image.Rectangle{left, image.Rect(halfW+gap, 0, Width, Height)}
+	case minImages + 1:
+		return []image.Rectangle{left, topRight, bottomRight}
+	default:
+		return []image.Rectangle{
+			image.Rect(0, 0, halfW, halfH),
+			topRight,
+			image.Rect(0, halfH+gap, halfW, Height),
+			bottomRight,
+		}
+	}
+}
+
+// drawFill scales src to fill cell, cropping the overflow around the center.
+func drawFill(dst *image.RGBA, cell image.Rectangle, src image.Image) {
+	bounds := src.Bounds()
+	crop := bounds
+	cw, ch := cell.Dx(), cell.Dy()
+	sw, sh := bounds.Dx(), bounds.Dy()
+
+	if sw*ch > sh*cw {
+		w := sh * cw / ch
+		crop.Min.X = bounds.Min.X + (sw-w)/2
+		crop.Max.X = crop.Min.X + w
+	} else {
+		h := sw * ch / cw
+		crop.Min.Y = bounds.Min.Y + (sh-h)/2
+		crop.Max.Y = crop.Min.Y + h
+	}
+
+	xdraw.CatmullRom.Scale(dst, cell, src, crop, xdraw.Src, nil)
+}
@@ -0,0 +1,100 @@
+package collage
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testImage(t *testing.T, w, h int, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode test image: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestCompose(t *testing.T) {
+	red := testImage(t, 200, 100, color.RGBA{0xff, 0, 0, 0xff})
+	blue := testImage(t, 100, 300, color.RGBA{0, 0, 0xff, 0xff})
+
+	for n := 2; n <= MaxImages+1; n++ {
+		images := [][]byte{red, blue, red, blue, red}[:n]
+
+		out, err := Compose(images)
+		if err != nil {
+			t.Fatalf("Compose(%d images) error = %v", n, err)
+		}
+
+		img, err := jpeg.Decode(bytes.NewReader(out))
+		if err != nil {
+			t.Fatalf("collage is not a JPEG: %v", err)
+		}
+
+		if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+			t.Errorf("collage size = %dx%d, want %dx%d", b.Dx(), b.Dy(), Width, Height)
+		}
+	}
+}
+
+func TestComposeSkipsUnusableImages(t *testing.T) {
+	red := testImage(t, 50, 50, color.RGBA{0xff, 0, 0, 0xff})
+
+	_, err := Compose([][]byte{red, []byte("not an image"), nil})
+	if !errors.Is(err, ErrTooFewImages) {
+		t.Errorf("Compose() error = %v, want %v", err, ErrTooFewImages)
+	}
+
+	if _, err := Compose([][]byte{red, []byte("not an image"), red}); err != nil {
+		t.Errorf("Compose() error = %v, want nil", err)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	canvas := image.Rect(0, 0, Width, Height)
+
+	for n := 2; n <= MaxImages; n++ {
+		cells := layout(n)
+		if len(cells) != n {
+			t.Fatalf("layout(%d) has %d cells", n, len(cells))
+		}
+
+		for i, cell := range cells {
+			if !cell.In(canvas) || cell.Empty() {
+				t.Errorf("layout(%d) cell %d = %v is outside the canvas", n, i, cell)
+			}
+
+			for j := range i {
+				if cell.Overlaps(cells[j]) {
+					t.Errorf("layout(%d) cells %d and %d overlap", n, i, j)
+				}
+			}
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	a, b := []byte("a"), []byte("b")
+
+	if Key([][]byte{a, b}) != Key([][]byte{a, b}) {
+		t.Error("Key() should be stable")
+	}
+
+	if Key([][]byte{a, b}) == Key([][]byte{b, a}) {
+		t.Error("Key() should depend on image order")
+	}
+}
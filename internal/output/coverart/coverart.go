@@ -0,0 +1,273 @@
+// Package coverart composes digest cover images locally, without an image
+// model: a gradient background, the digest title and date, and an icon per
+// topic. The same parameters always produce the same image.
+package coverart
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// Width and Height are the size of composed covers (16:9).
+	Width  = 1280
+	Height = 720
+
+	// MaxTopics caps how many topics a cover shows.
+	MaxTopics = 4
+
+	margin         = 80
+	titleSize      = 72
+	subtitleSize   = 34
+	topicSize      = 34
+	iconLetterSize = 38
+	iconRadius     = 34
+	titleBaseline  = 170
+	subtitleOffset = 58
+	topicsTop      = 340
+	topicSpacing   = 88
+	iconLabelGap   = 28
+	maxLabelRunes  = 40
+	decorCircles   = 3
+	decorAlpha     = 0x22
+	dpi            = 72
+	byteRange      = 256
+	pixelCenter    = 0.5
+)
+
+// palettes are the gradient start and end colors; a cover picks one by its
+// parameters, so covers vary between digests but not between renders.
+var palettes = [][2]color.RGBA{
+	{{0x1e, 0x3c, 0x72, 0xff}, {0x2a, 0x52, 0x98, 0xff}},
+	{{0x42, 0x27, 0x5a, 0xff}, {0x73, 0x4b, 0x6d, 0xff}},
+	{{0x13, 0x4e, 0x5e, 0xff}, {0x71, 0xb2, 0x80, 0xff}},
+	{{0x37, 0x3b, 0x44, 0xff}, {0x42, 0x86, 0xf4, 0xff}},
+	{{0x8e, 0x2d, 0xe2, 0xff}, {0x4a, 0x00, 0xe0, 0xff}},
+	{{0xc0, 0x39, 0x2b, 0xff}, {0x8e, 0x44, 0xad, 0xff}},
+}
+
+// Params describe the cover to compose.
+type Params struct {
+	// Title is the large heading, e.g. "Digest".
+	Title string
+	// Subtitle is shown under the title, typically the digest date.
+	Subtitle string
+	// Topics are drawn as icon rows, at most MaxTopics of them.
+	Topics []string
+}
+
+type fonts struct {
+	regular, bold *opentype.Font
+}
+
+var loadFonts = sync.OnceValues(func() (fonts, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return fonts{}, fmt.Errorf("parse regular font: %w", err)
+	}
+
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return fonts{}, fmt.Errorf("parse bold font: %w", err)
+	}
+
+	return fonts{regular: regular, bold: bold}, nil
+})
+
+// Compose renders the cover as a PNG image.
+func Compose(p Params) ([]byte, error) {
+	f, err := loadFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	seed := paramsHash(p)
+	palette := palettes[int(seed[0])%len(palettes)]
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	drawGradient(img, palette[0], palette[1])
+	drawDecorations(img, seed)
+
+	faces, err := newFaces(f)
+	if err != nil {
+		return nil, err
+	}
+
+	drawText(img, faces.title, p.Title, margin, titleBaseline, color.White)
+	drawText(img, faces.subtitle, p.Subtitle, margin, titleBaseline+subtitleOffset, color.NRGBA{0xff, 0xff, 0xff, 0xcc})
+
+	for i, topic := range limitTopics(p.Topics) {
+		centerY := topicsTop + i*topicSpacing
+		drawTopicIcon(img, faces.iconLetter, topic, margin+iconRadius, centerY, palette[0])
+		drawText(img, faces.topic, truncateRunes(topic, maxLabelRunes), margin+2*iconRadius+iconLabelGap, centerY+topicSize/3, color.White)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode cover: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type faceSet struct {
+	title, subtitle, topic, iconLetter font.Face
+}
+
+func newFaces(f fonts) (*faceSet, error) {
+	specs := []struct {
+		font *opentype.Font
+		size float64
+	}{
+		{f.bold, titleSize},
+		{f.regular, subtitleSize},
+		{f.regular, topicSize},
+		{f.bold, iconLetterSize},
+	}
+
+	faces := make([]font.Face, len(specs))
+
+	for i, spec := range specs {
+		face, err := opentype.NewFace(spec.font, &opentype.FaceOptions{Size: spec.size, DPI: dpi, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("create font face: %w", err)
+		}
+
+		faces[i] = face
+	}
+
+	return &faceSet{title: faces[0], subtitle: faces[1], topic: faces[2], iconLetter: faces[3]}, nil
+}
+
+// paramsHash seeds the palette and decorations.
+func paramsHash(p Params) [sha256.Size]byte {
+	return sha256.Sum256([]byte(p.Title + "\x00" + p.Subtitle + "\x00" + strings.Join(limitTopics(p.Topics), "\x00")))
+}
+
+func limitTopics(topics []string) []string {
+	var out []string
+
+	for _, t := range topics {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+
+		if len(out) == MaxTopics {
+			break
+		}
+	}
+
+	return out
+}
+
+// drawGradient fills img with a diagonal gradient from one color to another.
+func drawGradient(img *image.RGBA, from, to color.RGBA) {
+	span := Width + Height
+
+	for y := range Height {
+		for x := range Width {
+			t := float64(x+y) / float64(span)
+			img.SetRGBA(x, y, color.RGBA{
+				R: lerp(from.R, to.R, t),
+				G: lerp(from.G, to.G, t),
+				B: lerp(from.B, to.B, t),
+				A: 0xff,
+			})
+		}
+	}
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
+
+// drawDecorations adds large translucent circles on the right side.
+func drawDecorations(img *image.RGBA, seed [sha256.Size]byte) {
+	overlay := image.NewUniform(color.NRGBA{0xff, 0xff, 0xff, decorAlpha})
+
+	for i := range decorCircles {
+		b := seed[1+i*3:]
+		cx := Width*2/3 + int(b[0])*(Width/3)/byteRange
+		cy := int(b[1]) * Height / byteRange
+		r := Height/5 + int(b[2])*(Height/4)/byteRange
+		fillCircle(img, overlay, &circle{cx: cx, cy: cy, r: r})
+	}
+}
+
+// drawTopicIcon draws a round badge with the topic's initial.
+func drawTopicIcon(img *image.RGBA, face font.Face, topic string, cx, cy int, letterColor color.RGBA) {
+	badge := image.NewUniform(color.NRGBA{0xff, 0xff, 0xff, 0xee})
+	fillCircle(img, badge, &circle{cx: cx, cy: cy, r: iconRadius})
+
+	initial := topicInitial(topic)
+	width := font.MeasureString(face, initial).Round()
+	drawText(img, face, initial, cx-width/2, cy+iconLetterSize/3+1, letterColor)
+}
+
+func topicInitial(topic string) string {
+	for _, r := range topic {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return string(unicode.ToUpper(r))
+		}
+	}
+
+	return "•"
+}
+
+func drawText(img *image.RGBA, face font.Face, text string, x, y int, c color.Color) {
+	if text == "" {
+		return
+	}
+
+	d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, y)}
+	d.DrawString(text)
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
+
+// fillCircle blends src into img inside the circle.
+func fillCircle(img *image.RGBA, src image.Image, c *circle) {
+	bounds := c.Bounds()
+	draw.DrawMask(img, bounds, src, image.Point{}, c, bounds.Min, draw.Over)
+}
+
+// circle is an alpha mask of a filled circle.
+type circle struct {
+	cx, cy, r int
+}
+
+func (c *circle) ColorModel() color.Model { return color.AlphaModel }
+
+func (c *circle) Bounds() image.Rectangle {
+	return image.Rect(c.cx-c.r, c.cy-c.r, c.cx+c.r, c.cy+c.r)
+}
+
+func (c *circle) At(x, y int) color.Color {
+	dx, dy := float64(x-c.cx)+pixelCenter, float64(y-c.cy)+pixelCenter
+	if dx*dx+dy*dy <= float64(c.r*c.r) {
+		return color.Alpha{A: 0xff}
+	}
+
+	return color.Alpha{}
+}
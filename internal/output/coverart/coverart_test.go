@@ -0,0 +1,85 @@
+package coverart
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestComposeDeterministic(t *testing.T) {
+	p := Params{Title: "Дайджест", Subtitle: "02.03.2026 · 09:00–13:00", Topics: []string{"Economy", "Politics", "Tech"}}
+
+	first, err := Compose(p)
+	if err != nil {
+		t.Fatalf("Compose() error = %v", err)
+	}
+
+	second, err := Compose(p)
+	if err != nil {
+		t.Fatalf("Compose() error = %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Error("Compose() should render the same parameters identically")
+	}
+
+	p.Topics = []string{"Sports"}
+
+	other, err := Compose(p)
+	if err != nil {
+		t.Fatalf("Compose() error = %v", err)
+	}
+
+	if bytes.Equal(first, other) {
+		t.Error("Compose() should render different topics differently")
+	}
+
+	img, err := png.Decode(bytes.NewReader(first))
+	if err != nil {
+		t.Fatalf("cover is not a PNG: %v", err)
+	}
+
+	if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+		t.Errorf("cover size = %dx%d, want %dx%d", b.Dx(), b.Dy(), Width, Height)
+	}
+}
+
+func TestLimitTopics(t *testing.T) {
+	got := limitTopics([]string{" A ", "", "B", "C", "  ", "D", "E"})
+	want := []string{"A", "B", "C", "D"}
+
+	if len(got) != len(want) {
+		t.Fatalf("limitTopics() = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("limitTopics() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTopicInitial(t *testing.T) {
+	tests := map[string]string{
+		"economy":    "E",
+		"«Политика»": "П",
+		"2026 vote":  "2",
+		"—":          "•",
+	}
+
+	for topic, want := range tests {
+		if got := topicInitial(topic); got != want {
+			t.Errorf("topicInitial(%q) = %q, want %q", topic, got, want)
+		}
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("Экономика", 5); got != "Экон…" {
+		t.Errorf("truncateRunes() = %q, want %q", got, "Экон…")
+	}
+
+	if got := truncateRunes("Tech", 5); got != "Tech" {
+		t.Errorf("truncateRunes() = %q, want %q", got, "Tech")
+	}
+}
@@ -0,0 +1,154 @@
+// Package delivery sends digests to platforms other than Telegram through
+// incoming webhooks and chat APIs, converting the internal digest model to
+// each platform's message format.
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+)
+
+const (
+	// PlatformSlack names the Slack delivery target.
+	PlatformSlack = "slack"
+	// PlatformDiscord names the Discord delivery target.
+	PlatformDiscord = "discord"
+	// PlatformMatrix names the Matrix delivery target.
+	PlatformMatrix = "matrix"
+
+	defaultTimeout  = 15 * time.Second
+	contentTypeJSON = "application/json"
+	errBodyLimit    = 512
+	ellipsis        = "…"
+	sourceSeparator = " — "
+)
+
+// ErrUnexpectedStatus indicates the webhook rejected a message.
+var ErrUnexpectedStatus = errors.New("unexpected webhook status")
+
+// postJSON sends payload to a webhook URL and fails on a non-2xx status.
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	return sendJSON(ctx, client, http.MethodPost, url, "", payload)
+}
+
+// sendJSON sends payload with the given method, authorized by bearer when it
+// is set, and fails on a non-2xx status.
+func sendJSON(ctx context.Context, client *http.Client, method, url, bearer string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, err := io.ReadAll(io.LimitReader(resp.Body, errBodyLimit))
+		if err != nil {
+			return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+		}
+
+		return fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &http.Client{Timeout: timeout}
+}
+
+// digestCounts summarizes the digest size, e.g. "12 items · 5 channels · 3 topics".
+func digestCounts(h digest.TemplateHeader) string {
+	parts := []string{fmt.Sprintf("%d items", h.ItemCount), fmt.Sprintf("%d channels", h.ChannelCount)}
+
+	if h.TopicCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d topics", h.TopicCount))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// plainSummary strips the Telegram HTML markup from an item summary.
+func plainSummary(item digest.TemplateItem) string {
+	return strings.TrimSpace(htmlutils.StripHTMLTags(item.Summary))
+}
+
+// truncate shortens s to at most limit runes, ending it with an ellipsis.
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+
+	return string(runes[:limit-1]) + ellipsis
+}
+
+// packLines joins lines into chunks of at most limit runes, truncating lines
+// that do not fit a chunk on their own.
+func packLines(lines []string, limit int) []string {
+	var (
+		chunks []string
+		sb     strings.Builder
+		size   int
+	)
+
+	for _, line := range lines {
+		line = truncate(line, limit)
+		n := len([]rune(line))
+
+		if size > 0 && size+1+n > limit {
+			chunks = append(chunks, sb.String())
+			sb.Reset()
+
+			size = 0
+		}
+
+		if size > 0 {
+			sb.WriteString("\n")
+
+			size++
+		}
+
+		sb.WriteString(line)
+
+		size += n
+	}
+
+	if size > 0 {
+		chunks = append(chunks, sb.String())
+	}
+
+	return chunks
+}
@@ -0,0 +1,190 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+func testDigest() digest.TemplateData {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	return digest.TemplateData{
+		Header: digest.TemplateHeader{Title: "Digest for", Start: start, End: start.Add(time.Hour), ItemCount: 3, ChannelCount: 2, TopicCount: 1},
+		Tiers: []digest.TemplateTier{
+			{Name: "Breaking", Emoji: digest.EmojiBreaking, Clusters: []digest.TemplateCluster{{
+				Items: []digest.TemplateItem{{
+					Summary: "<b>Rates</b> up &amp; <i>markets</i> down",
+					Sources: []digest.TemplateSource{{Channel: "news|wire", URL: "https://t.me/news/1"}},
+				}},
+			}}},
+			{Name: "Also", Emoji: digest.EmojiStandard, Clusters: []digest.TemplateCluster{{
+				Topic: "Tech",
+				Items: []digest.TemplateItem{{Summary: "New *chip*"}, {Summary: "New_phone"}},
+			}}},
+		},
+	}
+}
+
+func TestBuildSlackMessages(t *testing.T) {
+	msgs := BuildSlackMessages(testDigest())
+	if len(msgs) != 1 {
+		t.Fatalf("got %d messages, want 1", len(msgs))
+	}
+
+	blocks := msgs[0].Blocks
+	if blocks[0].Type != slackBlockHeader || blocks[0].Text.Text != "Digest for Mar 1 09:00 – 10:00 UTC" {
+		t.Errorf("header = %+v", blocks[0])
+	}
+
+	if got := blocks[1].Elements[0].Text; got != "3 items · 2 channels · 1 topics" {
+		t.Errorf("context = %q", got)
+	}
+
+	var sections []string
+
+	for _, b := range blocks {
+		if b.Type == slackBlockSection {
+			sections = append(sections, b.Text.Text)
+		}
+	}
+
+	want := []string{
+		"🔴 *Breaking*",
+		"• Rates up &amp; markets down — <https://t.me/news/1|news/wire>",
+		"📝 *Also*",
+		"*Tech*\n    • New *chip*\n    • New_phone",
+	}
+
+	if fmt.Sprint(sections) != fmt.Sprint(want) {
+		t.Errorf("sections = %q, want %q", sections, want)
+	}
+}
+
+func TestBuildSlackMessagesSplitsBlocks(t *testing.T) {
+	data := testDigest()
+	data.Tiers = nil
+
+	for i := range slackMaxBlocks {
+		data.Tiers = append(data.Tiers, digest.TemplateTier{Name: fmt.Sprintf("Tier %d", i)})
+	}
+
+	msgs := BuildSlackMessages(data)
+	if len(msgs) != 3 {
+		t.Fatalf("got %d messages, want 3", len(msgs))
+	}
+
+	for _, msg := range msgs {
+		if len(msg.Blocks) > slackMaxBlocks {
+			t.Errorf("message has %d blocks, limit is %d", len(msg.Blocks), slackMaxBlocks)
+		}
+
+		if msg.Text == "" {
+			t.Error("message has no fallback text")
+		}
+	}
+}
+
+func TestBuildDiscordMessages(t *testing.T) {
+	msgs := BuildDiscordMessages(testDigest())
+	if len(msgs) != 1 || len(msgs[0].Embeds) != 3 {
+		t.Fatalf("got %+v, want one message with 3 embeds", msgs)
+	}
+
+	embeds := msgs[0].Embeds
+	if embeds[1].Color != discordColorBreaking || embeds[2].Color != discordColorOther {
+		t.Errorf("tier colors = %#x, %#x", embeds[1].Color, embeds[2].Color)
+	}
+
+	if want := "• Rates up & markets down — [news\\|wire](https://t.me/news/1)"; embeds[1].Description != want {
+		t.Errorf("breaking = %q, want %q", embeds[1].Description, want)
+	}
+
+	if want := "**Tech**\n  ◦ New \\*chip\\*\n  ◦ New\\_phone"; embeds[2].Description != want {
+		t.Errorf("also = %q, want %q", embeds[2].Description, want)
+	}
+}
+
+func TestBuildDiscordMessagesRespectsLimits(t *testing.T) {
+	data := testDigest()
+	items := make([]digest.TemplateItem, 200)
+
+	for i := range items {
+		items[i] = digest.TemplateItem{Summary: strings.Repeat("x", 100)}
+	}
+
+	data.Tiers = []digest.TemplateTier{{Name: "Also", Clusters: []digest.TemplateCluster{{Items: items}}}}
+
+	msgs := BuildDiscordMessages(data)
+	if len(msgs) < 2 {
+		t.Fatalf("got %d messages, want the tier split across several", len(msgs))
+	}
+
+	for _, msg := range msgs {
+		size := 0
+
+		for _, e := range msg.Embeds {
+			if n := len([]rune(e.Description)); n > discordDescriptionMaxLen {
+				t.Errorf("description has %d chars, limit is %d", n, discordDescriptionMaxLen)
+			}
+
+			size += len([]rune(e.Title)) + len([]rune(e.Description))
+		}
+
+		if len(msg.Embeds) > discordMaxEmbeds || size > discordMaxMessageChars {
+			t.Errorf("message has %d embeds and %d chars", len(msg.Embeds), size)
+		}
+	}
+}
+
+func TestDeliverPostsMessages(t *testing.T) {
+	var bodies []map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != contentTypeJSON {
+			t.Errorf("content type = %q", r.Header.Get("Content-Type"))
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		bodies = append(bodies, body)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := NewSlack(srv.URL, time.Second).Deliver(context.Background(), testDigest()); err != nil {
+		t.Fatalf("slack Deliver() error = %v", err)
+	}
+
+	if err := NewDiscord(srv.URL, time.Second).Deliver(context.Background(), testDigest()); err != nil {
+		t.Fatalf("discord Deliver() error = %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0]["blocks"] == nil || bodies[1]["embeds"] == nil {
+		t.Errorf("bodies = %v, want a Slack then a Discord payload", bodies)
+	}
+}
+
+func TestDeliverReportsRejectedMessage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_blocks", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	err := NewSlack(srv.URL, time.Second).Deliver(context.Background(), testDigest())
+	if !errors.Is(err, ErrUnexpectedStatus) || !strings.Contains(err.Error(), "invalid_blocks") {
+		t.Errorf("Deliver() error = %v, want ErrUnexpectedStatus with the response body", err)
+	}
+}
@@ -0,0 +1,177 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// Discord embed limits.
+const (
+	discordMaxEmbeds         = 10
+	discordMaxMessageChars   = 6000
+	discordTitleMaxLen       = 256
+	discordDescriptionMaxLen = 4096
+
+	discordColorHeader   = 0x5865F2
+	discordColorBreaking = 0xE53935
+	discordColorNotable  = 0xFB8C00
+	discordColorOther    = 0x9E9E9E
+)
+
+// Discord delivers digests to a Discord webhook as embeds.
+type Discord struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// DiscordMessage is a Discord webhook payload.
+type DiscordMessage struct {
+	Embeds []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a Discord message embed.
+type DiscordEmbed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color,omitempty"`
+}
+
+// NewDiscord creates a Discord delivery target for a webhook URL.
+func NewDiscord(webhookURL string, timeout time.Duration) *Discord {
+	return &Discord{webhookURL: webhookURL, client: newHTTPClient(timeout)}
+}
+
+// Platform implements digest.DeliveryTarget.
+func (d *Discord) Platform() string {
+	return PlatformDiscord
+}
+
+// Deliver posts the digest to the webhook, split into as many messages as
+// the embed limits require.
+func (d *Discord) Deliver(ctx context.Context, data digest.TemplateData) error {
+	for i, msg := range BuildDiscordMessages(data) {
+		if err := postJSON(ctx, d.client, d.webhookURL, msg); err != nil {
+			return fmt.Errorf("discord message %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// BuildDiscordMessages converts a digest to Discord messages: a header embed
+// with the digest totals, then an embed per tier colored by importance. Tiers
+// longer than an embed description continue in untitled embeds.
+func BuildDiscordMessages(data digest.TemplateData) []DiscordMessage {
+	embeds := []DiscordEmbed{{
+		Title:       truncate(data.Header.WindowTitle(), discordTitleMaxLen),
+		Description: digestCounts(data.Header),
+		Color:       discordColorHeader,
+	}}
+
+	for _, tier := range data.Tiers {
+		color := discordTierColor(tier)
+
+		for i, text := range packLines(discordTierLines(tier), discordDescriptionMaxLen) {
+			embed := DiscordEmbed{Description: text, Color: color}
+			if i == 0 {
+				embed.Title = truncate(tier.Emoji+" "+tier.Name, discordTitleMaxLen)
+			}
+
+			embeds = append(embeds, embed)
+		}
+	}
+
+	return packDiscordEmbeds(embeds)
+}
+
+// packDiscordEmbeds groups embeds into messages within the per-message embed
+// count and total text limits.
+func packDiscordEmbeds(embeds []DiscordEmbed) []DiscordMessage {
+	var (
+		messages []DiscordMessage
+		current  []DiscordEmbed
+		size     int
+	)
+
+	for _, embed := range embeds {
+		n := len([]rune(embed.Title)) + len([]rune(embed.Description))
+
+		if len(current) > 0 && (len(current) == discordMaxEmbeds || size+n > discordMaxMessageChars) {
+			messages = append(messages, DiscordMessage{Embeds: current})
+			current, size = nil, 0
+		}
+
+		current = append(current, embed)
+		size += n
+	}
+
+	if len(current) > 0 {
+		messages = append(messages, DiscordMessage{Embeds: current})
+	}
+
+	return messages
+}
+
+func discordTierColor(tier digest.TemplateTier) int {
+	switch tier.Emoji {
+	case digest.EmojiBreaking:
+		return discordColorBreaking
+	case digest.EmojiNotable:
+		return discordColorNotable
+	default:
+		return discordColorOther
+	}
+}
+
+// discordTierLines renders the items of a tier as markdown bullets; clusters
+// with several items get their topic as a bold line above them.
+func discordTierLines(tier digest.TemplateTier) []string {
+	var lines []string
+
+	for _, cluster := range tier.Clusters {
+		bullet := "• "
+
+		if len(cluster.Items) > 1 && cluster.Topic != "" {
+			lines = append(lines, "**"+discordEscape(cluster.Topic)+"**")
+			bullet = "  ◦ "
+		}
+
+		for _, item := range cluster.Items {
+			lines = append(lines, bullet+discordItem(item))
+		}
+	}
+
+	return lines
+}
+
+func discordItem(item digest.TemplateItem) string {
+	text := discordEscape(plainSummary(item))
+
+	links := make([]string, 0, len(item.Sources))
+
+	for _, src := range item.Sources {
+		if src.URL == "" {
+			continue
+		}
+
+		links = append(links, fmt.Sprintf("[%s](%s)", discordEscape(src.Channel), src.URL))
+	}
+
+	if len(links) == 0 {
+		return text
+	}
+
+	return text + sourceSeparator + strings.Join(links, ", ")
+}
+
+// discordEscape escapes the markdown control characters Discord renders.
+func discordEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, "[", `\[`, "]", `\]`,
+	).Replace(s)
+}
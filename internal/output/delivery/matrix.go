@@ -0,0 +1,218 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+const (
+	// matrixMaxMessageBytes keeps events well below the 64 KiB Matrix event
+	// limit, which covers both the plain and the HTML body.
+	matrixMaxMessageBytes = 24000
+
+	matrixSendPathFmt = "%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s"
+	matrixMsgTypeText = "m.text"
+	matrixFormatHTML  = "org.matrix.custom.html"
+)
+
+// Matrix delivers digests to a Matrix room as HTML messages through the
+// client-server API.
+type Matrix struct {
+	homeserverURL string
+	accessToken   string
+	roomID        string
+	client        *http.Client
+}
+
+// MatrixMessage is the content of an m.room.message event. Body is the plain
+// text fallback of FormattedBody.
+type MatrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// matrixBlock is a part of a digest that is never split across messages.
+type matrixBlock struct {
+	html string
+	text string
+}
+
+// NewMatrix creates a Matrix delivery target for a room. The access token's
+// user must have joined the room.
+func NewMatrix(homeserverURL, accessToken, roomID string, timeout time.Duration) *Matrix {
+	return &Matrix{
+		homeserverURL: strings.TrimRight(homeserverURL, "/"),
+		accessToken:   accessToken,
+		roomID:        roomID,
+		client:        newHTTPClient(timeout),
+	}
+}
+
+// Platform implements digest.DeliveryTarget.
+func (m *Matrix) Platform() string {
+	return PlatformMatrix
+}
+
+// Deliver sends the digest to the room, split into as many messages as the
+// event size requires.
+func (m *Matrix) Deliver(ctx context.Context, data digest.TemplateData) error {
+	// Transaction ids make retried requests idempotent; they only need to be
+	// unique per access token.
+	txnPrefix := fmt.Sprintf("digest-%d", time.Now().UnixNano())
+
+	for i, msg := range BuildMatrixMessages(data) {
+		endpoint := fmt.Sprintf(matrixSendPathFmt, m.homeserverURL, url.PathEscape(m.roomID), fmt.Sprintf("%s-%d", txnPrefix, i))
+
+		if err := sendJSON(ctx, m.client, http.MethodPut, endpoint, m.accessToken, msg); err != nil {
+			return fmt.Errorf("matrix message %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// BuildMatrixMessages converts a digest to Matrix HTML messages: a heading
+// with the digest totals, then a heading and a bullet list per tier. Clusters
+// with several items are nested under their topic.
+func BuildMatrixMessages(data digest.TemplateData) []MatrixMessage {
+	title := data.Header.WindowTitle()
+	counts := digestCounts(data.Header)
+
+	blocks := []matrixBlock{{
+		html: fmt.Sprintf("<h3>%s</h3><p>%s</p>", html.EscapeString(title), html.EscapeString(counts)),
+		text: title + "\n" + counts,
+	}}
+
+	for _, tier := range data.Tiers {
+		blocks = append(blocks, matrixTierBlocks(tier)...)
+	}
+
+	return packMatrixBlocks(blocks)
+}
+
+// matrixTierBlocks renders a tier as a heading and bullet lists of at most
+// matrixMaxMessageBytes each.
+func matrixTierBlocks(tier digest.TemplateTier) []matrixBlock {
+	heading := tier.Emoji + " " + tier.Name
+	current := matrixBlock{html: "<h4>" + html.EscapeString(heading) + "</h4><ul>", text: heading}
+
+	var blocks []matrixBlock
+
+	for _, entry := range matrixTierEntries(tier) {
+		if len(current.html)+len(entry.html) > matrixMaxMessageBytes && strings.HasSuffix(current.html, "</li>") {
+			current.html += "</ul>"
+			blocks = append(blocks, current)
+			current = matrixBlock{html: "<ul>"}
+		}
+
+		current.html += entry.html
+		current.text = strings.TrimPrefix(current.text+"\n"+entry.text, "\n")
+	}
+
+	current.html += "</ul>"
+
+	return append(blocks, current)
+}
+
+// matrixTierEntries renders the list entries of a tier, one per cluster.
+func matrixTierEntries(tier digest.TemplateTier) []matrixBlock {
+	entries := make([]matrixBlock, 0, len(tier.Clusters))
+
+	for _, cluster := range tier.Clusters {
+		if len(cluster.Items) > 1 && cluster.Topic != "" {
+			var htmlSB, textSB strings.Builder
+
+			fmt.Fprintf(&htmlSB, "<li><b>%s</b><ul>", html.EscapeString(cluster.Topic))
+			textSB.WriteString("• " + cluster.Topic)
+
+			for _, item := range cluster.Items {
+				itemHTML, itemText := matrixItem(item)
+				htmlSB.WriteString("<li>" + itemHTML + "</li>")
+				textSB.WriteString("\n    ◦ " + itemText)
+			}
+
+			htmlSB.WriteString("</ul></li>")
+			entries = append(entries, matrixBlock{html: htmlSB.String(), text: textSB.String()})
+
+			continue
+		}
+
+		for _, item := range cluster.Items {
+			itemHTML, itemText := matrixItem(item)
+			entries = append(entries, matrixBlock{html: "<li>" + itemHTML + "</li>", text: "• " + itemText})
+		}
+	}
+
+	return entries
+}
+
+func matrixItem(item digest.TemplateItem) (string, string) {
+	summary := plainSummary(item)
+
+	htmlLinks := make([]string, 0, len(item.Sources))
+	textLinks := make([]string, 0, len(item.Sources))
+
+	for _, src := range item.Sources {
+		if src.URL == "" {
+			continue
+		}
+
+		htmlLinks = append(htmlLinks, fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(src.URL), html.EscapeString(src.Channel)))
+		textLinks = append(textLinks, src.Channel+": "+src.URL)
+	}
+
+	if len(htmlLinks) == 0 {
+		return html.EscapeString(summary), summary
+	}
+
+	return html.EscapeString(summary) + sourceSeparator + strings.Join(htmlLinks, ", "),
+		summary + sourceSeparator + strings.Join(textLinks, ", ")
+}
+
+// packMatrixBlocks groups blocks into messages of at most
+// matrixMaxMessageBytes of HTML.
+func packMatrixBlocks(blocks []matrixBlock) []MatrixMessage {
+	var (
+		messages []MatrixMessage
+		htmlSB   strings.Builder
+		text     []string
+	)
+
+	flush := func() {
+		messages = append(messages, MatrixMessage{
+			MsgType:       matrixMsgTypeText,
+			Body:          strings.Join(text, "\n\n"),
+			Format:        matrixFormatHTML,
+			FormattedBody: htmlSB.String(),
+		})
+
+		htmlSB.Reset()
+
+		text = nil
+	}
+
+	for _, block := range blocks {
+		if htmlSB.Len() > 0 && htmlSB.Len()+len(block.html) > matrixMaxMessageBytes {
+			flush()
+		}
+
+		htmlSB.WriteString(block.html)
+
+		text = append(text, block.text)
+	}
+
+	if htmlSB.Len() > 0 {
+		flush()
+	}
+
+	return messages
+}
@@ -0,0 +1,115 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+func TestBuildMatrixMessages(t *testing.T) {
+	msgs := BuildMatrixMessages(testDigest())
+	if len(msgs) != 1 {
+		t.Fatalf("got %d messages, want 1", len(msgs))
+	}
+
+	msg := msgs[0]
+	if msg.MsgType != matrixMsgTypeText || msg.Format != matrixFormatHTML {
+		t.Errorf("msgtype = %q, format = %q", msg.MsgType, msg.Format)
+	}
+
+	wantHTML := "<h3>Digest for Mar 1 09:00 – 10:00 UTC</h3><p>3 items · 2 channels · 1 topics</p>" +
+		"<h4>🔴 Breaking</h4><ul><li>Rates up &amp; markets down — <a href=\"https://t.me/news/1\">news|wire</a></li></ul>" +
+		"<h4>📝 Also</h4><ul><li><b>Tech</b><ul><li>New *chip*</li><li>New_phone</li></ul></li></ul>"
+	if msg.FormattedBody != wantHTML {
+		t.Errorf("formatted_body = %q, want %q", msg.FormattedBody, wantHTML)
+	}
+
+	wantText := "Digest for Mar 1 09:00 – 10:00 UTC\n3 items · 2 channels · 1 topics\n\n" +
+		"🔴 Breaking\n• Rates up & markets down — news|wire: https://t.me/news/1\n\n" +
+		"📝 Also\n• Tech\n    ◦ New *chip*\n    ◦ New_phone"
+	if msg.Body != wantText {
+		t.Errorf("body = %q, want %q", msg.Body, wantText)
+	}
+}
+
+func TestBuildMatrixMessagesSplitsLargeTiers(t *testing.T) {
+	data := testDigest()
+	items := make([]digest.TemplateItem, 300)
+
+	for i := range items {
+		items[i] = digest.TemplateItem{Summary: fmt.Sprintf("%03d %s", i, strings.Repeat("x", 200))}
+	}
+
+	data.Tiers = []digest.TemplateTier{{Name: "Also", Clusters: []digest.TemplateCluster{{Items: items}}}}
+
+	msgs := BuildMatrixMessages(data)
+	if len(msgs) < 2 {
+		t.Fatalf("got %d messages, want the tier split across several", len(msgs))
+	}
+
+	listed := 0
+
+	for _, msg := range msgs {
+		if len(msg.FormattedBody) > matrixMaxMessageBytes {
+			t.Errorf("message has %d bytes, limit is %d", len(msg.FormattedBody), matrixMaxMessageBytes)
+		}
+
+		if strings.Count(msg.FormattedBody, "<ul>") != strings.Count(msg.FormattedBody, "</ul>") {
+			t.Errorf("message has unbalanced lists: %q", msg.FormattedBody[:100])
+		}
+
+		listed += strings.Count(msg.FormattedBody, "<li>")
+	}
+
+	if listed != len(items) {
+		t.Errorf("messages have %d items, want %d", listed, len(items))
+	}
+}
+
+func TestMatrixDeliver(t *testing.T) {
+	var (
+		paths []string
+		msg   MatrixMessage
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("authorization = %q", got)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		paths = append(paths, r.URL.EscapedPath())
+
+		if _, err := w.Write([]byte(`{"event_id":"$1"}`)); err != nil {
+			t.Errorf("write response: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	target := NewMatrix(srv.URL+"/", "secret", "!room:example.org", time.Second)
+	if err := target.Deliver(context.Background(), testDigest()); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	if len(paths) != 1 || !strings.HasPrefix(paths[0], "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/digest-") {
+		t.Errorf("paths = %v", paths)
+	}
+
+	if msg.FormattedBody == "" || msg.Body == "" {
+		t.Errorf("message = %+v, want plain and HTML bodies", msg)
+	}
+}
@@ -0,0 +1,157 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+)
+
+// Slack Block Kit limits.
+const (
+	slackMaxBlocks     = 50
+	slackHeaderMaxLen  = 150
+	slackSectionMaxLen = 3000
+
+	slackBlockHeader  = "header"
+	slackBlockContext = "context"
+	slackBlockDivider = "divider"
+	slackBlockSection = "section"
+	slackTextPlain    = "plain_text"
+	slackTextMarkdown = "mrkdwn"
+)
+
+// Slack delivers digests to a Slack incoming webhook as Block Kit messages.
+type Slack struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// SlackMessage is a Slack incoming webhook payload. Text is the notification
+// fallback for clients that do not render blocks.
+type SlackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block.
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object.
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NewSlack creates a Slack delivery target for an incoming webhook URL.
+func NewSlack(webhookURL string, timeout time.Duration) *Slack {
+	return &Slack{webhookURL: webhookURL, client: newHTTPClient(timeout)}
+}
+
+// Platform implements digest.DeliveryTarget.
+func (s *Slack) Platform() string {
+	return PlatformSlack
+}
+
+// Deliver posts the digest to the webhook, split into as many messages as
+// the block limit requires.
+func (s *Slack) Deliver(ctx context.Context, data digest.TemplateData) error {
+	for i, msg := range BuildSlackMessages(data) {
+		if err := postJSON(ctx, s.client, s.webhookURL, msg); err != nil {
+			return fmt.Errorf("slack message %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// BuildSlackMessages converts a digest to Slack Block Kit messages: a header
+// with the digest totals, then a section per tier with one bullet per item.
+func BuildSlackMessages(data digest.TemplateData) []SlackMessage {
+	title := data.Header.WindowTitle()
+
+	blocks := []SlackBlock{
+		{Type: slackBlockHeader, Text: &SlackText{Type: slackTextPlain, Text: truncate(title, slackHeaderMaxLen)}},
+		{Type: slackBlockContext, Elements: []SlackText{{Type: slackTextMarkdown, Text: digestCounts(data.Header)}}},
+	}
+
+	for _, tier := range data.Tiers {
+		blocks = append(blocks,
+			SlackBlock{Type: slackBlockDivider},
+			slackSection(fmt.Sprintf("%s *%s*", tier.Emoji, slackEscape(tier.Name))),
+		)
+
+		for _, text := range packLines(slackTierLines(tier), slackSectionMaxLen) {
+			blocks = append(blocks, slackSection(text))
+		}
+	}
+
+	messages := make([]SlackMessage, 0, len(blocks)/slackMaxBlocks+1)
+
+	for start := 0; start < len(blocks); start += slackMaxBlocks {
+		messages = append(messages, SlackMessage{
+			Text:   title,
+			Blocks: blocks[start:min(start+slackMaxBlocks, len(blocks))],
+		})
+	}
+
+	return messages
+}
+
+func slackSection(text string) SlackBlock {
+	return SlackBlock{Type: slackBlockSection, Text: &SlackText{Type: slackTextMarkdown, Text: text}}
+}
+
+// slackTierLines renders the items of a tier as mrkdwn bullets; clusters with
+// several items get their topic as a bold line above them.
+func slackTierLines(tier digest.TemplateTier) []string {
+	var lines []string
+
+	for _, cluster := range tier.Clusters {
+		indent := ""
+
+		if len(cluster.Items) > 1 && cluster.Topic != "" {
+			lines = append(lines, "*"+slackEscape(cluster.Topic)+"*")
+			indent = "    "
+		}
+
+		for _, item := range cluster.Items {
+			lines = append(lines, indent+"• "+slackItem(item))
+		}
+	}
+
+	return lines
+}
+
+func slackItem(item digest.TemplateItem) string {
+	text := slackEscape(plainSummary(item))
+
+	links := make([]string, 0, len(item.Sources))
+
+	for _, src := range item.Sources {
+		if src.URL == "" {
+			continue
+		}
+
+		label := strings.ReplaceAll(slackEscape(src.Channel), "|", "/")
+		links = append(links, fmt.Sprintf("<%s|%s>", src.URL, label))
+	}
+
+	if len(links) == 0 {
+		return text
+	}
+
+	return text + sourceSeparator + strings.Join(links, ", ")
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -94,6 +94,32 @@ func calculateReliabilityDelta(st *ratingStats) (float32, float64, float64, bool
 	return delta, reliability, irrelevantRate, true
 }
 
+// applyReliabilityAdjustment shifts a channel's weight by its rating reliability
+// once the channel has enough ratings.
+func (s *Scheduler) applyReliabilityAdjustment(channelID string, weight float32, st *ratingStats, cfg AutoWeightConfig, logger *zerolog.Logger) float32 {
+	if st == nil || st.count < s.cfg.RatingMinSampleChannel {
+		return weight
+	}
+
+	delta, reliability, irrelevantRate, ok := calculateReliabilityDelta(st)
+	if !ok {
+		return weight
+	}
+
+	logger.Debug().
+		Str(LogFieldChannelID, channelID).
+		Float64(LogFieldReliability, reliability).
+		Float64("irrelevant_rate", irrelevantRate).
+		Float32(LogFieldDelta, delta).
+		Msg("Applied reliability adjustment to auto-weight")
+
+	return clampAutoWeight(weight+delta, cfg)
+}
+
+func clampAutoWeight(weight float32, cfg AutoWeightConfig) float32 {
+	return float32(math.Max(float64(cfg.AutoMin), math.Min(float64(cfg.AutoMax), float64(weight))))
+}
+
 // CalculateAutoWeight computes a channel's weight based on historical stats
 func CalculateAutoWeight(stats *db.RollingStats, cfg AutoWeightConfig, days int) float32 {
 	// Guard: insufficient data - return neutral weight
@@ -186,6 +212,7 @@ func (s *Scheduler) UpdateAutoWeights(ctx context.Context, logger *zerolog.Logge
 	}
 
 	reliabilityStats := aggregateReliabilityStats(now, ratings)
+	clicks := s.loadClickThrough(ctx, since, logger)
 	updated := 0
 	skipped := 0
 
@@ -197,19 +224,8 @@ func (s *Scheduler) UpdateAutoWeights(ctx context.Context, logger *zerolog.Logge
 		}
 
 		newWeight := CalculateAutoWeight(stats, cfg, cfg.RollingDays)
-
-		if st := reliabilityStats[ch.ID]; st != nil && st.count >= s.cfg.RatingMinSampleChannel {
-			delta, reliability, irrelevantRate, ok := calculateReliabilityDelta(st)
-			if ok {
-				newWeight = float32(math.Max(float64(cfg.AutoMin), math.Min(float64(cfg.AutoMax), float64(newWeight+delta))))
-				logger.Debug().
-					Str(LogFieldChannelID, ch.ID).
-					Float64(LogFieldReliability, reliability).
-					Float64("irrelevant_rate", irrelevantRate).
-					Float32(LogFieldDelta, delta).
-					Msg("Applied reliability adjustment to auto-weight")
-			}
-		}
+		newWeight = s.applyReliabilityAdjustment(ch.ID, newWeight, reliabilityStats[ch.ID], cfg, logger)
+		newWeight = applyClickAdjustment(ch.ID, newWeight, clicks, cfg, logger)
 
 		// Only update if weight changed significantly (> 0.05)
 		if math.Abs(float64(newWeight-ch.ImportanceWeight)) < 0.05 {
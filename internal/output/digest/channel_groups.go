@@ -0,0 +1,136 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// DefaultGroupDigestWindow is the digest window of groups that have a target
+// chat but no window of their own.
+const DefaultGroupDigestWindow = 24 * time.Hour
+
+// groupScopedRepository restricts digest candidates to one channel group.
+// The unscoped queries leave out channels of groups with their own target.
+type groupScopedRepository struct {
+	Repository
+	groupID string
+}
+
+func (r groupScopedRepository) GetItemsForWindow(ctx context.Context, start, end time.Time, threshold float32, limit int) ([]db.Item, error) {
+	return r.getItems(ctx, start, end, threshold, limit, db.DiversityCaps{})
+}
+
+func (r groupScopedRepository) GetItemsForWindowDiverse(ctx context.Context, start, end time.Time, threshold float32, limit int, caps db.DiversityCaps) ([]db.Item, error) {
+	return r.getItems(ctx, start, end, threshold, limit, caps)
+}
+
+func (r groupScopedRepository) GetCarryOverItems(ctx context.Context, since, before time.Time, threshold float32, limit int) ([]db.Item, error) {
+	return r.getItems(ctx, since, before, threshold, limit, db.DiversityCaps{})
+}
+
+func (r groupScopedRepository) getItems(ctx context.Context, start, end time.Time, threshold float32, limit int, caps db.DiversityCaps) ([]db.Item, error) {
+	items, err := r.GetChannelGroupItems(ctx, r.groupID, start, end, threshold, limit, caps)
+	if err != nil {
+		return nil, fmt.Errorf("get group items: %w", err)
+	}
+
+	return items, nil
+}
+
+// maybeRunGroupDigests posts the digest of every group with a target chat
+// whose window has elapsed.
+func (s *Scheduler) maybeRunGroupDigests(ctx context.Context) {
+	groups, err := s.database.ListChannelGroups(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("failed to list channel groups")
+
+		return
+	}
+
+	now := time.Now()
+
+	for _, g := range groups {
+		if g.TargetChatID == 0 {
+			continue
+		}
+
+		logger := s.logger.With().Str(LogFieldTask, "group-digest").Str("group", g.Name).Logger()
+
+		if err := s.postGroupDigestIfDue(ctx, g, now, &logger); err != nil {
+			logger.Error().Err(err).Msg("failed to post group digest")
+		}
+	}
+}
+
+// groupDigestWindow returns the group's next window ending at the last
+// multiple of its window length before now, and false when none has elapsed
+// since the last group digest.
+func groupDigestWindow(g db.ChannelGroup, now time.Time) (start, end time.Time, due bool) {
+	length := DefaultGroupDigestWindow
+	if d, err := time.ParseDuration(g.DigestWindow); err == nil && d > 0 {
+		length = d
+	}
+
+	end = now.UTC().Truncate(length)
+	start = end.Add(-length)
+
+	if g.LastDigestAt.After(start) {
+		start = g.LastDigestAt.UTC()
+	}
+
+	return start, end, end.After(start)
+}
+
+func (s *Scheduler) postGroupDigestIfDue(ctx context.Context, g db.ChannelGroup, now time.Time, logger *zerolog.Logger) error {
+	start, end, due := groupDigestWindow(g, now)
+	if !due {
+		return nil
+	}
+
+	importanceThreshold := s.cfg.ImportanceThreshold
+	if err := s.database.GetSetting(ctx, SettingImportanceThreshold, &importanceThreshold); err != nil {
+		logger.Debug().Err(err).Msg("could not get importance_threshold from DB, using default")
+	}
+
+	// Group windows can coincide with main digest windows, so topic clustering
+	// (stored per window) stays off to keep the main digest's clusters intact.
+	scoped := *s
+	scoped.database = shadowSettingsRepository{
+		Repository: groupScopedRepository{Repository: s.database, groupID: g.ID},
+		overrides:  map[string]json.RawMessage{"topics_enabled": json.RawMessage("false")},
+	}
+
+	text, items, _, _, err := scoped.buildDigest(ctx, start, end, g.TargetChatID, importanceThreshold, logger)
+	if err != nil {
+		return err
+	}
+
+	if text != "" {
+		if _, err := s.bot.SendDigest(ctx, g.TargetChatID, text, ""); err != nil {
+			return fmt.Errorf("send group digest: %w", err)
+		}
+
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+
+		if err := s.database.MarkItemsAsDigested(ctx, ids); err != nil {
+			logger.Error().Err(err).Msg("failed to mark group items as digested")
+		}
+
+		logger.Info().Int(LogFieldCount, len(items)).Time(LogFieldEnd, end).Msg("Posted group digest")
+	}
+
+	if err := s.database.MarkChannelGroupDigested(ctx, g.ID, end); err != nil {
+		return fmt.Errorf("mark group digested: %w", err)
+	}
+
+	return nil
+}
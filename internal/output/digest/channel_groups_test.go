@@ -0,0 +1,81 @@
+package digest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestGroupDigestWindow(t *testing.T) {
+	now := time.Date(2026, 3, 3, 14, 25, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		group     db.ChannelGroup
+		wantStart time.Time
+		wantEnd   time.Time
+		wantDue   bool
+	}{
+		{
+			name:      "first run uses one default window",
+			group:     db.ChannelGroup{},
+			wantStart: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+			wantDue:   true,
+		},
+		{
+			name:      "continues from the last digest",
+			group:     db.ChannelGroup{DigestWindow: "6h", LastDigestAt: time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC)},
+			wantStart: time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC),
+			wantDue:   true,
+		},
+		{
+			name:    "not due within the window",
+			group:   db.ChannelGroup{DigestWindow: "6h", LastDigestAt: time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)},
+			wantDue: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, due := groupDigestWindow(tt.group, now)
+			if due != tt.wantDue {
+				t.Fatalf("due = %v, want %v", due, tt.wantDue)
+			}
+
+			if due && (!start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd)) {
+				t.Errorf("window = %v - %v, want %v - %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+type groupItemsRepository struct {
+	Repository
+	groupID string
+	caps    db.DiversityCaps
+}
+
+func (r *groupItemsRepository) GetChannelGroupItems(_ context.Context, groupID string, _, _ time.Time, _ float32, _ int, caps db.DiversityCaps) ([]db.Item, error) {
+	r.groupID, r.caps = groupID, caps
+
+	return []db.Item{{ID: "item"}}, nil
+}
+
+func TestGroupScopedRepository(t *testing.T) {
+	inner := &groupItemsRepository{}
+	repo := groupScopedRepository{Repository: inner, groupID: "g1"}
+	caps := db.DiversityCaps{MaxPerChannel: 2}
+
+	items, err := repo.GetItemsForWindowDiverse(context.Background(), time.Time{}, time.Time{}, 0.3, 10, caps)
+	if err != nil || len(items) != 1 || inner.groupID != "g1" || inner.caps != caps {
+		t.Fatalf("GetItemsForWindowDiverse = %v, %v; group %q caps %+v", items, err, inner.groupID, inner.caps)
+	}
+
+	if _, err := repo.GetCarryOverItems(context.Background(), time.Time{}, time.Time{}, 0.3, 10); err != nil || inner.caps.Active() {
+		t.Errorf("GetCarryOverItems should query the group without caps, got caps %+v, err %v", inner.caps, err)
+	}
+}
@@ -0,0 +1,128 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingChannelHealthAlertsEnabled toggles admin alerts about channel health changes.
+	SettingChannelHealthAlertsEnabled = "channel_health_alerts_enabled"
+
+	// channelHealthRefreshInterval is how often channels are reclassified by posting activity.
+	channelHealthRefreshInterval = 24 * time.Hour
+	// channelHealthAlertLimit caps how many health events are sent in one alert.
+	channelHealthAlertLimit = 30
+)
+
+// maybeRunChannelHealthCheck reclassifies channels by posting activity once a day and
+// forwards any new health events (including ones recorded by the reader) to admins.
+func (s *Scheduler) maybeRunChannelHealthCheck(ctx context.Context, lastRefresh *time.Time) {
+	logger := s.logger.With().Str(LogFieldTask, "channel-health").Logger()
+
+	now := time.Now()
+	if lastRefresh.IsZero() || now.Sub(*lastRefresh) >= channelHealthRefreshInterval {
+		changed, err := s.database.RefreshChannelActivityHealth(ctx, now)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to refresh channel health")
+		} else {
+			*lastRefresh = now
+
+			logger.Info().Int("changed", changed).Msg("Channel health refreshed")
+		}
+	}
+
+	enabled := true
+	if err := s.database.GetSetting(ctx, SettingChannelHealthAlertsEnabled, &enabled); err != nil {
+		logger.Debug().Err(err).Msg("channel_health_alerts_enabled not set, defaulting to true")
+	}
+
+	if !enabled {
+		return
+	}
+
+	if err := s.SendChannelHealthAlerts(ctx, &logger); err != nil {
+		logger.Error().Err(err).Msg("failed to send channel health alerts")
+	}
+}
+
+// SendChannelHealthAlerts notifies admins about channel health events they have not
+// seen yet and marks them as notified.
+func (s *Scheduler) SendChannelHealthAlerts(ctx context.Context, logger *zerolog.Logger) error {
+	events, err := s.database.GetUnnotifiedChannelHealthEvents(ctx, channelHealthAlertLimit)
+	if err != nil {
+		return fmt.Errorf("get channel health events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := s.bot.SendNotification(ctx, formatChannelHealthAlert(events)); err != nil {
+		return fmt.Errorf("send channel health alert: %w", err)
+	}
+
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	if err := s.database.MarkChannelHealthEventsNotified(ctx, ids); err != nil {
+		return fmt.Errorf("mark channel health events notified: %w", err)
+	}
+
+	logger.Info().Int("events", len(events)).Msg("Sent channel health alert")
+
+	return nil
+}
+
+// formatChannelHealthAlert renders channel health events as an HTML admin notification.
+func formatChannelHealthAlert(events []db.ChannelHealthEvent) string {
+	var sb strings.Builder
+
+	sb.WriteString("🩺 <b>Channel Health</b>\n\n")
+
+	for _, e := range events {
+		sb.WriteString(fmt.Sprintf("%s %s: %s → <b>%s</b>",
+			channelHealthIcon(e.Status),
+			adminChannelLabel(e.ChannelID, e.Title, e.Username),
+			html.EscapeString(e.PreviousStatus),
+			html.EscapeString(e.Status)))
+
+		if e.Detail != "" {
+			sb.WriteString(fmt.Sprintf(" <i>(%s)</i>", html.EscapeString(e.Detail)))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\nDead channels are left out of channel counts and stats; private and deleted channels were deactivated.")
+
+	return sb.String()
+}
+
+func channelHealthIcon(status string) string {
+	switch status {
+	case db.ChannelHealthHealthy:
+		return "✅"
+	case db.ChannelHealthStale:
+		return "⏸"
+	case db.ChannelHealthDead:
+		return "💀"
+	case db.ChannelHealthPrivate:
+		return "🔒"
+	case db.ChannelHealthDeleted:
+		return "🗑"
+	case db.ChannelHealthRenamed:
+		return "✏️"
+	default:
+		return "•"
+	}
+}
@@ -0,0 +1,28 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestFormatChannelHealthAlert(t *testing.T) {
+	got := formatChannelHealthAlert([]db.ChannelHealthEvent{
+		{ChannelID: "a", Username: "quiet", Status: db.ChannelHealthDead, PreviousStatus: db.ChannelHealthStale, Detail: "last post 2026-01-01"},
+		{ChannelID: "b", Username: "locked", Status: db.ChannelHealthPrivate, PreviousStatus: db.ChannelHealthHealthy},
+		{ChannelID: "c", Username: "newname", Status: db.ChannelHealthRenamed, PreviousStatus: db.ChannelHealthHealthy, Detail: "@old → @newname"},
+	})
+
+	for _, want := range []string{
+		"💀 ",
+		"stale → <b>dead</b> <i>(last post 2026-01-01)</i>",
+		"🔒 ",
+		"healthy → <b>private</b>\n",
+		"<i>(@old → @newname)</i>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatChannelHealthAlert() missing %q in:\n%s", want, got)
+		}
+	}
+}
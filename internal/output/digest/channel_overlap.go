@@ -0,0 +1,97 @@
+package digest
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// channelOverlap holds the Jaccard index of channel pairs, by peer ID, that
+// overlap at least CORROBORATION_OVERLAP_THRESHOLD. A nil overlap treats all
+// channels as independent.
+type channelOverlap map[[2]int64]float64
+
+// storyCorroboration is how many distinct channels reported a story and how
+// many of them count as independent once correlated channels are discounted.
+type storyCorroboration struct {
+	channels    int
+	independent float64
+}
+
+// loadChannelOverlap loads the correlated channel pairs when the digest has
+// clusters that could be corroborated by them.
+func (s *Scheduler) loadChannelOverlap(ctx context.Context, clusters []db.ClusterWithItems, logger *zerolog.Logger) channelOverlap {
+	threshold := s.cfg.CorroborationOverlapThreshold
+	if threshold <= 0 || len(clusters) == 0 {
+		return nil
+	}
+
+	pairs, err := s.database.GetChannelOverlapPairs(ctx, threshold)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load channel overlap, counting all channels as independent")
+
+		return nil
+	}
+
+	overlap := make(channelOverlap, len(pairs))
+	for _, p := range pairs {
+		overlap[overlapKey(p.PeerA, p.PeerB)] = p.Jaccard
+	}
+
+	return overlap
+}
+
+func overlapKey(a, b int64) [2]int64 {
+	if a > b {
+		a, b = b, a
+	}
+
+	return [2]int64{a, b}
+}
+
+// jaccard returns the overlap of two channels, 0 for unknown or
+// uncorrelated pairs.
+func (o channelOverlap) jaccard(a, b int64) float64 {
+	if a == 0 || b == 0 || a == b {
+		return 0
+	}
+
+	return o[overlapKey(a, b)]
+}
+
+// corroborate counts the channels of a story (see storyChannels). Each
+// channel, in key order, adds 1 minus its largest overlap with the channels
+// counted before it, so a near-duplicate of an earlier channel adds almost
+// nothing.
+func (o channelOverlap) corroborate(items []db.Item, clusters []db.ClusterWithItems) storyCorroboration {
+	channels := storyChannels(items, clusters)
+
+	keys := make([]string, 0, len(channels))
+	for key := range channels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	c := storyCorroboration{channels: len(keys)}
+	counted := make([]int64, 0, len(keys))
+
+	for _, key := range keys {
+		peer := channels[key]
+		weight := 1.0
+
+		for _, other := range counted {
+			if w := 1 - o.jaccard(peer, other); w < weight {
+				weight = w
+			}
+		}
+
+		c.independent += weight
+		counted = append(counted, peer)
+	}
+
+	return c
+}
@@ -0,0 +1,56 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// channelTrialReportSamples is the number of sample items in a trial report.
+const channelTrialReportSamples = 5
+
+// maybeRunChannelTrialReports sends the end-of-trial report of every channel
+// whose trial has ended, once per trial.
+func (s *Scheduler) maybeRunChannelTrialReports(ctx context.Context) {
+	logger := s.logger.With().Str(LogFieldTask, "channel-trials").Logger()
+
+	trials, err := s.database.GetDueChannelTrials(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to get due channel trials")
+
+		return
+	}
+
+	if len(trials) == 0 {
+		return
+	}
+
+	importanceThreshold := s.cfg.ImportanceThreshold
+	if err := s.database.GetSetting(ctx, SettingImportanceThreshold, &importanceThreshold); err != nil {
+		logger.Debug().Err(err).Msg("could not get importance_threshold from DB, using default")
+	}
+
+	for _, trial := range trials {
+		if err := s.reportChannelTrial(ctx, trial, importanceThreshold); err != nil {
+			logger.Error().Err(err).Str("channel_id", trial.ChannelID).Msg("failed to report channel trial")
+		}
+	}
+}
+
+func (s *Scheduler) reportChannelTrial(ctx context.Context, trial db.ChannelTrial, importanceThreshold float32) error {
+	report, err := s.database.GetChannelTrialReport(ctx, trial, importanceThreshold, channelTrialReportSamples)
+	if err != nil {
+		return fmt.Errorf("build trial report: %w", err)
+	}
+
+	if err := s.bot.SendChannelTrialReport(ctx, report); err != nil {
+		return fmt.Errorf("send trial report: %w", err)
+	}
+
+	if err := s.database.MarkChannelTrialReported(ctx, trial.ChannelID); err != nil {
+		return fmt.Errorf("mark trial reported: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+type trialRepository struct {
+	Repository
+	reported []string
+}
+
+func (r *trialRepository) GetChannelTrialReport(_ context.Context, trial db.ChannelTrial, _ float32, samples int) (db.ChannelTrialReport, error) {
+	return db.ChannelTrialReport{ChannelTrial: trial, Messages: samples}, nil
+}
+
+func (r *trialRepository) MarkChannelTrialReported(_ context.Context, channelID string) error {
+	r.reported = append(r.reported, channelID)
+
+	return nil
+}
+
+type trialPoster struct {
+	DigestPoster
+	reports []db.ChannelTrialReport
+	err     error
+}
+
+func (p *trialPoster) SendChannelTrialReport(_ context.Context, report db.ChannelTrialReport) error {
+	p.reports = append(p.reports, report)
+
+	return p.err
+}
+
+func TestReportChannelTrial(t *testing.T) {
+	repo := &trialRepository{}
+	poster := &trialPoster{}
+	s := &Scheduler{database: repo, bot: poster}
+
+	if err := s.reportChannelTrial(context.Background(), db.ChannelTrial{ChannelID: "c1"}, 0.3); err != nil {
+		t.Fatalf("reportChannelTrial: %v", err)
+	}
+
+	if len(poster.reports) != 1 || poster.reports[0].Messages != channelTrialReportSamples {
+		t.Errorf("reports = %+v, want one report built with %d samples", poster.reports, channelTrialReportSamples)
+	}
+
+	if len(repo.reported) != 1 || repo.reported[0] != "c1" {
+		t.Errorf("reported = %v, want [c1]", repo.reported)
+	}
+
+	poster.err = errors.New("telegram down")
+
+	if err := s.reportChannelTrial(context.Background(), db.ChannelTrial{ChannelID: "c2"}, 0.3); err == nil {
+		t.Fatal("expected an error when sending fails")
+	}
+
+	if len(repo.reported) != 1 {
+		t.Errorf("a failed report must not be marked as sent, reported = %v", repo.reported)
+	}
+}
@@ -0,0 +1,89 @@
+package digest
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// clickMinDigestedItems is how many digested items a channel needs before
+	// its click-through rate adjusts its weight.
+	clickMinDigestedItems = 20
+	// clickDeltaFactor scales how far a channel's click-through rate is from
+	// the global rate (as a ratio) into a weight delta.
+	clickDeltaFactor = 0.1
+	clickDeltaCap    = 0.1
+)
+
+// clickThrough holds the click stats of tracked digest links.
+type clickThrough struct {
+	byChannel  map[string]db.ChannelClickStats
+	globalRate float64
+}
+
+// loadClickThrough loads per-channel click stats of the items digested since
+// the given time. Without tracked clicks the global rate stays zero and no
+// channel is adjusted.
+func (s *Scheduler) loadClickThrough(ctx context.Context, since time.Time, logger *zerolog.Logger) clickThrough {
+	stats, err := s.database.GetChannelClickStats(ctx, since)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load click stats for auto-weight")
+
+		return clickThrough{}
+	}
+
+	ct := clickThrough{byChannel: make(map[string]db.ChannelClickStats, len(stats))}
+
+	var digested, clicked int
+
+	for _, st := range stats {
+		ct.byChannel[st.ChannelID] = st
+		digested += st.DigestedItems
+		clicked += st.ClickedItems
+	}
+
+	if digested > 0 {
+		ct.globalRate = float64(clicked) / float64(digested)
+	}
+
+	return ct
+}
+
+// calculateClickDelta returns the weight delta of a channel whose digested
+// items are clicked more or less often than items overall.
+func calculateClickDelta(st db.ChannelClickStats, globalRate float64) (float32, bool) {
+	if globalRate <= 0 || st.DigestedItems < clickMinDigestedItems {
+		return 0, false
+	}
+
+	rate := float64(st.ClickedItems) / float64(st.DigestedItems)
+	delta := clampFloat64((rate/globalRate-1)*clickDeltaFactor, -clickDeltaCap, clickDeltaCap)
+
+	return float32(delta), true
+}
+
+// applyClickAdjustment shifts a channel's weight by its click-through rate.
+func applyClickAdjustment(channelID string, weight float32, clicks clickThrough, cfg AutoWeightConfig, logger *zerolog.Logger) float32 {
+	st, ok := clicks.byChannel[channelID]
+	if !ok {
+		return weight
+	}
+
+	delta, ok := calculateClickDelta(st, clicks.globalRate)
+	if !ok {
+		return weight
+	}
+
+	logger.Debug().
+		Str(LogFieldChannelID, channelID).
+		Int("digested_items", st.DigestedItems).
+		Int("clicked_items", st.ClickedItems).
+		Float32(LogFieldDelta, delta).
+		Msg("Applied click-through adjustment to auto-weight")
+
+	return clampAutoWeight(weight+delta, cfg)
+}
@@ -0,0 +1,39 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestCalculateClickDelta(t *testing.T) {
+	tests := []struct {
+		name       string
+		stats      db.ChannelClickStats
+		globalRate float64
+		want       float32
+		wantOK     bool
+	}{
+		{"no global clicks", db.ChannelClickStats{DigestedItems: 50, ClickedItems: 10}, 0, 0, false},
+		{"too few items", db.ChannelClickStats{DigestedItems: 10, ClickedItems: 5}, 0.2, 0, false},
+		{"average", db.ChannelClickStats{DigestedItems: 50, ClickedItems: 10}, 0.2, 0, true},
+		{"above average", db.ChannelClickStats{DigestedItems: 50, ClickedItems: 15}, 0.2, 0.05, true},
+		{"capped", db.ChannelClickStats{DigestedItems: 50, ClickedItems: 50}, 0.2, clickDeltaCap, true},
+		{"never clicked", db.ChannelClickStats{DigestedItems: 50}, 0.2, -clickDeltaFactor, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := calculateClickDelta(tt.stats, tt.globalRate)
+			if ok != tt.wantOK || !floatNear(got, tt.want) {
+				t.Errorf("calculateClickDelta() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func floatNear(a, b float32) bool {
+	const epsilon = 1e-6
+
+	return a-b < epsilon && b-a < epsilon
+}
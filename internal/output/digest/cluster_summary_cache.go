@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
@@ -16,8 +17,19 @@ const (
 	clusterSummaryMinOverlap      = 0.8
 )
 
+// usesClusterSummaryCache reports whether cached summaries match the digest's
+// verbosity. The cache only holds standard-length production summaries, so
+// shadow digests always generate their own.
+func (rc *digestRenderContext) usesClusterSummaryCache() bool {
+	if rc.settings.variant != "" {
+		return false
+	}
+
+	return rc.settings.verbosity == "" || rc.settings.verbosity == llm.VerbosityStandard
+}
+
 func (rc *digestRenderContext) findCachedClusterSummary(ctx context.Context, items []db.Item) (string, bool) {
-	if len(items) == 0 {
+	if len(items) == 0 || !rc.usesClusterSummaryCache() {
 		return "", false
 	}
 
@@ -33,14 +45,28 @@ func (rc *digestRenderContext) findCachedClusterSummary(ctx context.Context, ite
 
 	fingerprint := clusterFingerprint(itemIDs)
 
-	bestSummary := ""
-	bestScore := 0.0
-
 	for _, entry := range cache {
 		if entry.ClusterFingerprint == fingerprint {
 			return entry.Summary, true
 		}
+	}
 
+	if base, delta, ok := findIncrementalClusterSummaryBase(cache, itemIDs); ok {
+		if summary, ok := rc.mergeClusterSummary(ctx, base, items, delta); ok {
+			return summary, true
+		}
+	}
+
+	return bestOverlapClusterSummary(cache, itemIDs)
+}
+
+// bestOverlapClusterSummary reuses the summary of the cached member set that
+// overlaps the current one the most, if the overlap is high enough.
+func bestOverlapClusterSummary(cache []db.ClusterSummaryCacheEntry, itemIDs []string) (string, bool) {
+	bestSummary := ""
+	bestScore := 0.0
+
+	for _, entry := range cache {
 		score := overlapScore(itemIDs, entry.ItemIDs)
 		if score >= clusterSummaryMinOverlap && score > bestScore {
 			bestScore = score
@@ -56,7 +82,7 @@ func (rc *digestRenderContext) findCachedClusterSummary(ctx context.Context, ite
 }
 
 func (rc *digestRenderContext) storeClusterSummaryCache(ctx context.Context, items []db.Item, summary string) {
-	if rc == nil || rc.scheduler == nil || rc.scheduler.database == nil {
+	if rc == nil || rc.scheduler == nil || rc.scheduler.database == nil || !rc.usesClusterSummaryCache() {
 		return
 	}
 
@@ -0,0 +1,168 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// clusterSummaryMaxDeltaItems caps how many new items are merged into a
+	// cached summary; larger changes regenerate the summary from scratch.
+	clusterSummaryMaxDeltaItems  = 5
+	clusterSummaryDeltaTextLimit = 400
+)
+
+// findIncrementalClusterSummaryBase returns the cached entry whose member set is
+// the largest strict subset of itemIDs, together with the IDs it is missing.
+// The delta must be no larger than the cached set and clusterSummaryMaxDeltaItems,
+// otherwise merging would cost about as much as regenerating.
+func findIncrementalClusterSummaryBase(cache []db.ClusterSummaryCacheEntry, itemIDs []string) (db.ClusterSummaryCacheEntry, []string, bool) {
+	var (
+		best      db.ClusterSummaryCacheEntry
+		bestDelta []string
+	)
+
+	for _, entry := range cache {
+		delta, ok := clusterSummaryDelta(entry.ItemIDs, itemIDs)
+		if !ok || len(delta) > clusterSummaryMaxDeltaItems || len(delta) > len(entry.ItemIDs) {
+			continue
+		}
+
+		if bestDelta == nil || len(delta) < len(bestDelta) {
+			best = entry
+			bestDelta = delta
+		}
+	}
+
+	if bestDelta == nil {
+		return db.ClusterSummaryCacheEntry{}, nil, false
+	}
+
+	return best, bestDelta, true
+}
+
+// clusterSummaryDelta returns the IDs in current that are not in cached. It
+// reports false unless cached is a non-empty strict subset of current.
+func clusterSummaryDelta(cached, current []string) ([]string, bool) {
+	if len(cached) == 0 || len(cached) >= len(current) {
+		return nil, false
+	}
+
+	set := make(map[string]struct{}, len(cached))
+	for _, id := range cached {
+		set[id] = struct{}{}
+	}
+
+	delta := make([]string, 0, len(current)-len(cached))
+
+	for _, id := range current {
+		if _, ok := set[id]; ok {
+			delete(set, id)
+
+			continue
+		}
+
+		delta = append(delta, id)
+	}
+
+	if len(set) > 0 {
+		return nil, false
+	}
+
+	return delta, true
+}
+
+// mergeClusterSummary updates a cached summary with the items that joined the
+// cluster since it was generated and caches the result under the new member set.
+func (rc *digestRenderContext) mergeClusterSummary(ctx context.Context, base db.ClusterSummaryCacheEntry, items []db.Item, delta []string) (string, bool) {
+	if rc.llmClient == nil {
+		return "", false
+	}
+
+	deltaItems := filterItemsByID(items, delta)
+	if len(deltaItems) == 0 {
+		return "", false
+	}
+
+	prompt := buildClusterSummaryMergePrompt(base.Summary, deltaItems, rc.settings.digestLanguage, rc.llmTone())
+
+	// Pass empty model to let the LLM registry handle task-specific model selection
+	merged, err := rc.llmClient.CompleteText(ctx, prompt, "")
+	if err != nil || strings.TrimSpace(merged) == "" {
+		if err != nil {
+			rc.logger.Warn().Err(err).Int("new_items", len(deltaItems)).Msg("failed to merge cluster summary, regenerating")
+		}
+
+		return "", false
+	}
+
+	merged = htmlutils.SanitizeHTML(strings.TrimSpace(merged))
+	rc.storeClusterSummaryCache(ctx, items, merged)
+
+	rc.logger.Debug().
+		Int("cached_items", len(base.ItemIDs)).
+		Int("new_items", len(deltaItems)).
+		Msg("merged new items into cached cluster summary")
+
+	return merged, true
+}
+
+func filterItemsByID(items []db.Item, ids []string) []db.Item {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+
+	filtered := make([]db.Item, 0, len(ids))
+
+	for _, item := range items {
+		if _, ok := set[item.ID]; ok {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+// buildClusterSummaryMergePrompt asks the LLM to fold new reports into an
+// existing cluster summary instead of summarizing every item again.
+func buildClusterSummaryMergePrompt(summary string, newItems []db.Item, targetLanguage, tone string) string {
+	var sb strings.Builder
+
+	sb.WriteString(`You maintain a running summary of a news story for a Telegram digest.
+Update the existing summary with the new reports below.
+
+Rules:
+- Keep the same length and style as the existing summary.
+- Add only facts from the new reports that are not already covered; replace outdated numbers or outcomes.
+- Do not mention that the summary was updated.
+- Use only <b>, <i> and <a> HTML tags.
+`)
+
+	if targetLanguage != "" {
+		fmt.Fprintf(&sb, "- Write in language: %s.\n", targetLanguage)
+	}
+
+	if tone != "" {
+		fmt.Fprintf(&sb, "- Tone: %s.\n", tone)
+	}
+
+	sb.WriteString("\nReturn ONLY the updated summary text.\n\nExisting summary:\n")
+	sb.WriteString(strings.TrimSpace(summary))
+	sb.WriteString("\n\nNew reports:\n")
+
+	for i, item := range newItems {
+		text := strings.TrimSpace(item.Summary)
+		if runes := []rune(text); len(runes) > clusterSummaryDeltaTextLimit {
+			text = string(runes[:clusterSummaryDeltaTextLimit]) + "…"
+		}
+
+		fmt.Fprintf(&sb, "%d. [%s] %s\n", i+1, sourceLabel(item), text)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+package digest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestClusterSummaryDelta(t *testing.T) {
+	tests := []struct {
+		name    string
+		cached  []string
+		current []string
+		want    []string
+		wantOK  bool
+	}{
+		{name: "one new item", cached: []string{"a", "b"}, current: []string{"a", "b", "c"}, want: []string{"c"}, wantOK: true},
+		{name: "same set", cached: []string{"a", "b"}, current: []string{"a", "b"}},
+		{name: "item removed", cached: []string{"a", "b", "x"}, current: []string{"a", "b", "c", "d"}},
+		{name: "empty cache entry", cached: nil, current: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := clusterSummaryDelta(tt.cached, tt.current)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clusterSummaryDelta() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFindIncrementalClusterSummaryBase(t *testing.T) {
+	cache := []db.ClusterSummaryCacheEntry{
+		{ClusterFingerprint: "small", ItemIDs: []string{"a", "b"}, Summary: "small"},
+		{ClusterFingerprint: "large", ItemIDs: []string{"a", "b", "c"}, Summary: "large"},
+		{ClusterFingerprint: "other", ItemIDs: []string{"a", "z", "y"}, Summary: "other"},
+	}
+
+	base, delta, ok := findIncrementalClusterSummaryBase(cache, []string{"a", "b", "c", "d"})
+	if !ok {
+		t.Fatal("expected an incremental base")
+	}
+
+	if base.Summary != "large" || !reflect.DeepEqual(delta, []string{"d"}) {
+		t.Errorf("got base %q delta %v, want large [d]", base.Summary, delta)
+	}
+
+	// The delta may not exceed the cached set: 2 cached items, 3 new ones.
+	if _, _, ok := findIncrementalClusterSummaryBase(cache[:1], []string{"a", "b", "c", "d", "e"}); ok {
+		t.Error("expected no base when the delta outgrows the cached set")
+	}
+}
+
+func TestBuildClusterSummaryMergePrompt(t *testing.T) {
+	items := []db.Item{
+		{ID: "c", Summary: "Officials confirmed 12 casualties.", SourceChannel: "newsroom"},
+		{ID: "d", Summary: "Roads reopened this morning."},
+	}
+
+	prompt := buildClusterSummaryMergePrompt("Storm hit the coast.", items, "en", "neutral")
+
+	for _, want := range []string{
+		"Existing summary:\nStorm hit the coast.",
+		"1. [newsroom] Officials confirmed 12 casualties.",
+		"2. [" + DefaultSourceLabel + "] Roads reopened this morning.",
+		"language: en",
+		"Tone: neutral",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// whatsNewMaxItems caps how many cluster items are contrasted with the
+	// previously posted summary.
+	whatsNewMaxItems = 10
+	// whatsNewNothing is the LLM answer when the cluster adds nothing new.
+	whatsNewNothing = "NONE"
+)
+
+// getWhatsNewLabel returns the localized label of an incremental summary.
+func (rc *digestRenderContext) getWhatsNewLabel() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "Что нового"
+	case "de":
+		return "Neu"
+	case "es":
+		return "Novedades"
+	case "fr":
+		return "Du nouveau"
+	case "it":
+		return "Novità"
+	}
+
+	return "What's new"
+}
+
+// whatsNewClusterSummary stores the cluster's full summary together with the
+// incremental one against the previously posted summary of its story, and
+// returns the summary to render. The full summary is rendered when the
+// cluster starts a story, adds nothing new, or incremental summaries are off.
+func (rc *digestRenderContext) whatsNewClusterSummary(ctx context.Context, c db.ClusterWithItems, summary string) string {
+	// Shadow digests share the production clusters and must not overwrite
+	// their summaries.
+	if c.ID == "" || rc.settings.variant != "" || rc.scheduler == nil || rc.scheduler.database == nil {
+		return summary
+	}
+
+	var incremental, previousID string
+
+	if rc.settings.whatsNewEnabled {
+		incremental, previousID = rc.generateWhatsNew(ctx, c)
+	}
+
+	if err := rc.scheduler.database.SaveClusterSummary(ctx, db.ClusterSummary{
+		ClusterID:          c.ID,
+		Summary:            summary,
+		IncrementalSummary: incremental,
+		PreviousClusterID:  previousID,
+	}); err != nil {
+		rc.logger.Warn().Err(err).Str("cluster_id", c.ID).Msg("failed to save cluster summary")
+	}
+
+	if incremental == "" {
+		return summary
+	}
+
+	return fmt.Sprintf("<i>%s:</i> %s", rc.getWhatsNewLabel(), incremental)
+}
+
+// generateWhatsNew asks the LLM what the cluster adds to the summary of the
+// closest cluster from an earlier posted digest. It returns the sanitized
+// incremental summary, empty when there is none, and the earlier cluster's ID.
+func (rc *digestRenderContext) generateWhatsNew(ctx context.Context, c db.ClusterWithItems) (string, string) {
+	if rc.llmClient == nil {
+		return "", ""
+	}
+
+	cfg := rc.scheduler.cfg
+	since := rc.start.AddDate(0, 0, -cfg.StoryLookbackDays)
+
+	previous, err := rc.scheduler.database.GetPreviousClusterSummary(ctx, c.ID, since)
+	if errors.Is(err, db.ErrNoPreviousClusterSummary) {
+		return "", ""
+	}
+
+	if err != nil {
+		rc.logger.Warn().Err(err).Str("cluster_id", c.ID).Msg("failed to get previous cluster summary")
+
+		return "", ""
+	}
+
+	if previous.Similarity < cfg.StoryLinkThreshold {
+		return "", ""
+	}
+
+	prompt := buildWhatsNewPrompt(previous.Summary, c.Items, rc.settings.digestLanguage, rc.llmTone())
+
+	// Pass empty model to let the LLM registry handle task-specific model selection
+	resp, err := rc.llmClient.CompleteText(ctx, prompt, "")
+	if err != nil {
+		rc.logger.Warn().Err(err).Str("cluster_id", c.ID).Msg("failed to generate incremental cluster summary")
+
+		return "", previous.ClusterID
+	}
+
+	return parseWhatsNew(resp), previous.ClusterID
+}
+
+// parseWhatsNew sanitizes the LLM answer, returning an empty string when the
+// cluster adds nothing new.
+func parseWhatsNew(resp string) string {
+	text := strings.TrimSpace(resp)
+	if text == "" || strings.EqualFold(strings.Trim(text, ".\"'"), whatsNewNothing) {
+		return ""
+	}
+
+	return htmlutils.SanitizeHTML(text)
+}
+
+// buildWhatsNewPrompt asks the LLM to contrast a cluster's reports with the
+// summary posted in an earlier digest and to write only the new developments.
+func buildWhatsNewPrompt(previous string, items []db.Item, targetLanguage, tone string) string {
+	var sb strings.Builder
+
+	sb.WriteString(`You write a Telegram digest that follows an ongoing news story.
+The previous digest already posted the summary below. Compare the new reports with it
+and write only what is new: developments, changed numbers or outcomes, and new reactions.
+
+Rules:
+- Do not repeat facts that the previous summary already covers.
+- Write 1-2 sentences, shorter than the previous summary.
+- Do not mention the previous digest or that this is an update.
+- Use only <b>, <i> and <a> HTML tags.
+`)
+
+	fmt.Fprintf(&sb, "- If the reports add nothing new, return exactly %s.\n", whatsNewNothing)
+
+	if targetLanguage != "" {
+		fmt.Fprintf(&sb, "- Write in language: %s.\n", targetLanguage)
+	}
+
+	if tone != "" {
+		fmt.Fprintf(&sb, "- Tone: %s.\n", tone)
+	}
+
+	sb.WriteString("\nReturn ONLY the text of the new developments.\n\nPreviously posted summary:\n")
+	sb.WriteString(strings.TrimSpace(previous))
+	sb.WriteString("\n\nNew reports:\n")
+
+	for i, item := range items {
+		if i == whatsNewMaxItems {
+			break
+		}
+
+		text := strings.TrimSpace(item.Summary)
+		if runes := []rune(text); len(runes) > clusterSummaryDeltaTextLimit {
+			text = string(runes[:clusterSummaryDeltaTextLimit]) + "…"
+		}
+
+		fmt.Fprintf(&sb, "%d. [%s] %s\n", i+1, sourceLabel(item), text)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestParseWhatsNew(t *testing.T) {
+	tests := []struct {
+		resp string
+		want string
+	}{
+		{resp: "  Roads reopened this morning.  ", want: "Roads reopened this morning."},
+		{resp: "NONE", want: ""},
+		{resp: "none.", want: ""},
+		{resp: "   ", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseWhatsNew(tt.resp); got != tt.want {
+			t.Errorf("parseWhatsNew(%q) = %q, want %q", tt.resp, got, tt.want)
+		}
+	}
+}
+
+func TestBuildWhatsNewPrompt(t *testing.T) {
+	items := make([]db.Item, whatsNewMaxItems+1)
+	for i := range items {
+		items[i] = db.Item{Summary: "Report", SourceChannel: "newsroom"}
+	}
+
+	items[0].Summary = "Officials confirmed 12 casualties."
+
+	prompt := buildWhatsNewPrompt("Storm hit the coast.", items, "ru", "")
+
+	for _, want := range []string{
+		"Previously posted summary:\nStorm hit the coast.",
+		"1. [newsroom] Officials confirmed 12 casualties.",
+		"return exactly " + whatsNewNothing,
+		"language: ru",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	if strings.Contains(prompt, "Tone:") {
+		t.Error("prompt has a tone rule without a tone")
+	}
+
+	if strings.Contains(prompt, "11. [newsroom]") {
+		t.Errorf("prompt lists more than %d reports", whatsNewMaxItems)
+	}
+}
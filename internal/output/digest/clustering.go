@@ -56,8 +56,18 @@ func (s *Scheduler) clusterItemsWithSource(ctx context.Context, items []db.Item,
 		cfg:         cfg,
 	}
 
+	var pending []pendingCluster
+
 	for topic, groupItems := range clusterCtx.topicGroups {
-		if err := s.processTopicGroup(ctx, topic, groupItems, clusterCtx, start, end, source, minClusterSize, logger); err != nil {
+		pending = append(pending, s.processTopicGroup(topic, groupItems, clusterCtx, minClusterSize, logger)...)
+	}
+
+	// Topics need link lookups and an LLM call per cluster, so they are
+	// generated concurrently; clusters are still stored in the order found.
+	topics := s.generateClusterTopics(ctx, pending, cfg.digestLanguage)
+
+	for i, pc := range pending {
+		if err := s.persistCluster(ctx, pc.items, topics[i], start, end, source, logger); err != nil {
 			return err
 		}
 	}
@@ -88,7 +98,9 @@ func (s *Scheduler) limitClusterItems(items []db.Item, logger *zerolog.Logger) [
 	return items
 }
 
-func (s *Scheduler) processTopicGroup(ctx context.Context, topic string, groupItems []db.Item, bc *clusterBuildContext, start, end time.Time, source string, minClusterSize int, logger *zerolog.Logger) error {
+func (s *Scheduler) processTopicGroup(topic string, groupItems []db.Item, bc *clusterBuildContext, minClusterSize int, logger *zerolog.Logger) []pendingCluster {
+	var pending []pendingCluster
+
 	for _, itemA := range groupItems {
 		if bc.assigned[itemA.ID] {
 			continue
@@ -102,12 +114,18 @@ func (s *Scheduler) processTopicGroup(ctx context.Context, topic string, groupIt
 			continue
 		}
 
-		if err := s.persistCluster(ctx, clusterItemsList, topic, bc.cfg, start, end, source, logger); err != nil {
-			return err
-		}
+		s.sortClusterItems(clusterItemsList)
+
+		logger.Debug().
+			Int("cluster_size", len(clusterItemsList)).
+			Str("representative", clusterItemsList[0].ID).
+			Float32("rep_importance", clusterItemsList[0].ImportanceScore).
+			Msg("Cluster representative selected")
+
+		pending = append(pending, pendingCluster{items: clusterItemsList, defaultTopic: topic})
 	}
 
-	return nil
+	return pending
 }
 
 func (s *Scheduler) validateClusterCoherence(clusterItemsList []db.Item, bc *clusterBuildContext, logger *zerolog.Logger) []db.Item {
@@ -126,17 +144,7 @@ func (s *Scheduler) validateClusterCoherence(clusterItemsList []db.Item, bc *clu
 	return clusterItemsList
 }
 
-func (s *Scheduler) persistCluster(ctx context.Context, clusterItemsList []db.Item, topic string, cfg clusteringConfig, start, end time.Time, source string, logger *zerolog.Logger) error {
-	s.sortClusterItems(clusterItemsList)
-
-	logger.Debug().
-		Int("cluster_size", len(clusterItemsList)).
-		Str("representative", clusterItemsList[0].ID).
-		Float32("rep_importance", clusterItemsList[0].ImportanceScore).
-		Msg("Cluster representative selected")
-
-	clusterTopic := s.generateClusterTopic(ctx, clusterItemsList, topic, cfg.digestLanguage)
-
+func (s *Scheduler) persistCluster(ctx context.Context, clusterItemsList []db.Item, clusterTopic string, start, end time.Time, source string, logger *zerolog.Logger) error {
 	clusterID, err := s.database.CreateClusterWithSource(ctx, start, end, clusterTopic, source)
 	if err != nil {
 		return fmt.Errorf("failed to create cluster: %w", err)
@@ -696,6 +696,60 @@ func TestCollectSourceLinks(t *testing.T) {
 	})
 }
 
+func TestCollectAttributedSourceLinks(t *testing.T) {
+	s := &Scheduler{}
+	rc := &digestRenderContext{scheduler: s}
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("marks first mover and orders by lag", func(t *testing.T) {
+		items := []db.Item{
+			{SourceChannel: "late", SourceMsgID: 1, TGDate: base.Add(30 * time.Minute)},
+			{SourceChannel: "origin", SourceMsgID: 2, TGDate: base},
+			{SourceChannel: "mid", SourceMsgID: 3, TGDate: base.Add(5 * time.Minute)},
+		}
+
+		links := rc.collectAttributedSourceLinks(items)
+		if len(links) != 3 {
+			t.Fatalf("expected 3 links, got %d", len(links))
+		}
+
+		if !strings.HasPrefix(links[0], DigestSourceFirstPrefix) || !strings.Contains(links[0], "@origin") {
+			t.Errorf("first link should mark @origin, got %q", links[0])
+		}
+
+		if !strings.Contains(links[1], "@mid") || !strings.Contains(links[2], "@late") {
+			t.Errorf("remaining links should be ordered by lag, got %v", links)
+		}
+
+		if items[0].SourceChannel != "late" {
+			t.Error("input items should not be reordered")
+		}
+	})
+
+	t.Run("undated items keep order without marker", func(t *testing.T) {
+		items := []db.Item{
+			{SourceChannel: "a", SourceMsgID: 1},
+			{SourceChannel: "b", SourceMsgID: 2},
+		}
+
+		links := rc.collectAttributedSourceLinks(items)
+		if strings.HasPrefix(links[0], DigestSourceFirstPrefix) {
+			t.Errorf("undated links should not be marked, got %q", links[0])
+		}
+
+		if !strings.Contains(links[0], "@a") {
+			t.Errorf("undated links should keep original order, got %v", links)
+		}
+	})
+
+	t.Run("single source is not marked", func(t *testing.T) {
+		links := rc.collectAttributedSourceLinks([]db.Item{{SourceChannel: "solo", SourceMsgID: 1, TGDate: base}})
+		if strings.HasPrefix(links[0], DigestSourceFirstPrefix) {
+			t.Errorf("single source should not be marked, got %q", links[0])
+		}
+	})
+}
+
 func TestClusteringConfigStruct(t *testing.T) {
 	cfg := clusteringConfig{
 		similarityThreshold: testSimilarityThresholdDefault,
@@ -75,6 +75,21 @@ const (
 	SettingTargetChatID        = "target_chat_id"
 	SettingImportanceThreshold = "importance_threshold"
 	SettingRelevanceThreshold  = "relevance_threshold"
+	SettingDigestItemLinks     = "digest_item_links"
+	SettingDigestTOCMinTopics  = "digest_toc_min_topics"
+	SettingDigestVerbosity     = "digest_verbosity"
+	SettingDigestFormat        = "digest_format"
+	SettingEditorSections      = "editor_sections"
+	SettingDigestNumbersBlock  = "digest_numbers_block"
+	SettingDigestQuotesBlock   = "digest_quotes_block"
+	SettingDigestUpcomingBlock = "digest_upcoming_block"
+	SettingDigestRumorWatch    = "digest_rumor_watch"
+	SettingDigestWhatsNew      = "digest_whats_new"
+	SettingDigestSourceFooter  = "digest_source_footer"
+	SettingDigestClickTracking = "digest_click_tracking"
+	SettingDigestRegions       = "digest_regions"
+	SettingShadowTargetChatID  = "shadow_target_chat_id"
+	SettingShadowOverrides     = "shadow_overrides"
 )
 
 // Log message constants
@@ -104,6 +119,7 @@ const (
 	EmojiStandard              = "📝"
 	EmojiBullet                = "•"
 	DigestSourceVia            = "\n    ↳ <i>via %s</i>"
+	DigestSourceFirstPrefix    = "first: "
 )
 
 // Low reliability badge constants (no config overrides).
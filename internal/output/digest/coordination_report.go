@@ -0,0 +1,105 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingCoordinationReportEnabled toggles the weekly coordinated-posting admin report.
+	SettingCoordinationReportEnabled = "coordination_report_enabled"
+
+	// coordinationReportMinScore is the coordination score a channel pair needs to be reported.
+	coordinationReportMinScore = 0.3
+	// coordinationReportLimit caps how many channel pairs are listed in the report.
+	coordinationReportLimit = 10
+)
+
+// maybeRunCoordinationReport sends the weekly coordinated-posting report to admins.
+func (s *Scheduler) maybeRunCoordinationReport(ctx context.Context, lastRun *time.Time) {
+	enabled := true
+	if err := s.database.GetSetting(ctx, SettingCoordinationReportEnabled, &enabled); err != nil {
+		s.logger.Debug().Err(err).Msg("coordination_report_enabled not set, defaulting to true")
+	}
+
+	if !enabled {
+		return
+	}
+
+	now := time.Now()
+	isSunday := now.Weekday() == time.Sunday
+	isMidnightHour := now.Hour() == 0
+	notRunThisWeek := lastRun.IsZero() || now.Sub(*lastRun) > 6*HoursPerDay*time.Hour
+
+	if isSunday && isMidnightHour && notRunThisWeek {
+		logger := s.logger.With().Str(LogFieldTask, "coordination-report").Logger()
+		logger.Info().Msg("Starting weekly coordination report")
+
+		if err := s.SendCoordinationReport(ctx, &logger); err != nil {
+			logger.Error().Err(err).Msg("failed to send coordination report")
+		} else {
+			*lastRun = now
+		}
+	}
+}
+
+// SendCoordinationReport notifies admins about channel pairs that repeatedly post
+// the same stories within minutes of each other. Nothing is sent when no pair
+// reaches coordinationReportMinScore.
+func (s *Scheduler) SendCoordinationReport(ctx context.Context, logger *zerolog.Logger) error {
+	pairs, err := s.database.GetChannelCoordination(ctx, coordinationReportMinScore, coordinationReportLimit)
+	if err != nil {
+		return fmt.Errorf("get channel coordination: %w", err)
+	}
+
+	if len(pairs) == 0 {
+		logger.Info().Msg("no coordinated channel pairs to report")
+
+		return nil
+	}
+
+	if err := s.bot.SendNotification(ctx, formatCoordinationReport(pairs)); err != nil {
+		return fmt.Errorf("send coordination report: %w", err)
+	}
+
+	logger.Info().Int("pairs", len(pairs)).Msg("Sent coordination report")
+
+	return nil
+}
+
+// formatCoordinationReport renders coordinated channel pairs as an HTML admin notification.
+func formatCoordinationReport(pairs []db.ChannelCoordinationEntry) string {
+	var sb strings.Builder
+
+	sb.WriteString("🕸 <b>Coordinated Posting Report</b>\n")
+	sb.WriteString("Channel pairs repeatedly posting the same stories within minutes of each other:\n\n")
+
+	for i, p := range pairs {
+		sb.WriteString(fmt.Sprintf("%d. %s ↔ %s\n", i+1,
+			adminChannelLabel(p.ChannelA, p.ChannelATitle, p.ChannelAUsername),
+			adminChannelLabel(p.ChannelB, p.ChannelBTitle, p.ChannelBUsername)))
+		sb.WriteString(fmt.Sprintf("   score <code>%.2f</code> · co-posts <code>%d</code>/<code>%d</code> shared · avg lag <code>%.0fs</code>\n",
+			p.Score, p.CoPosts, p.SharedClusters, p.AvgLagSeconds))
+	}
+
+	return sb.String()
+}
+
+// adminChannelLabel returns an HTML-safe channel label for admin notifications.
+func adminChannelLabel(id, title, username string) string {
+	switch {
+	case username != "":
+		return "@" + html.EscapeString(username)
+	case title != "":
+		return html.EscapeString(title)
+	default:
+		return id
+	}
+}
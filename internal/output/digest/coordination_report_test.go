@@ -0,0 +1,38 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestFormatCoordinationReport(t *testing.T) {
+	pairs := []db.ChannelCoordinationEntry{
+		{
+			ChannelA:         "a",
+			ChannelB:         "b",
+			ChannelAUsername: "alpha",
+			ChannelBTitle:    "Beta <News>",
+			CoPosts:          7,
+			SharedClusters:   9,
+			AvgLagSeconds:    95,
+			Score:            0.82,
+		},
+		{ChannelA: "c-id", ChannelB: "d-id", CoPosts: 3, SharedClusters: 3, Score: 0.4},
+	}
+
+	got := formatCoordinationReport(pairs)
+
+	for _, want := range []string{
+		"1. @alpha ↔ Beta &lt;News&gt;",
+		"score <code>0.82</code>",
+		"co-posts <code>7</code>/<code>9</code> shared",
+		"avg lag <code>95s</code>",
+		"2. c-id ↔ d-id",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report missing %q:\n%s", want, got)
+		}
+	}
+}
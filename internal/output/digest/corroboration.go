@@ -1,9 +1,28 @@
 package digest
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
+const (
+	// SettingDigestTierSort selects how items are ordered within each
+	// importance tier.
+	SettingDigestTierSort = "digest_tier_sort"
+	// TierSortImportance keeps items ordered by importance (default).
+	TierSortImportance = "importance"
+	// TierSortCorroboration orders items by the number of channels that
+	// reported them.
+	TierSortCorroboration = "corroboration"
+
+	minCorroboratingChannels = 2
+)
+
 func (s *Scheduler) applyCorroborationAdjustments(items []db.Item, clusters []db.ClusterWithItems, settings digestSettings) ([]db.Item, []db.ClusterWithItems) {
 	if len(clusters) == 0 {
 		return items, clusters
@@ -33,36 +52,26 @@ func buildItemIndex(items []db.Item) map[string]*db.Item {
 }
 
 func (s *Scheduler) adjustClusterScores(cluster *db.ClusterWithItems, itemIndex map[string]*db.Item, settings digestSettings) {
-	channelCount := countUniqueChannels(cluster.Items)
-	if channelCount == 0 {
+	c := settings.channelOverlap.corroborate(cluster.Items, nil)
+	if c.channels == 0 {
 		return
 	}
 
-	boost, penalty := calculateBoostPenalty(channelCount, len(cluster.Items), settings)
+	boost, penalty := calculateBoostPenalty(c, len(cluster.Items), settings)
 
 	for i := range cluster.Items {
 		applyScoreAdjustment(&cluster.Items[i], itemIndex, boost, penalty)
 	}
 }
 
-func countUniqueChannels(items []db.Item) int {
-	channelSet := make(map[string]struct{})
-
-	for _, it := range items {
-		if it.SourceChannel != "" {
-			channelSet[it.SourceChannel] = struct{}{}
-		}
+// calculateBoostPenalty boosts stories by their independent channels beyond
+// the first, so correlated channels add less than full weight.
+func calculateBoostPenalty(c storyCorroboration, itemCount int, settings digestSettings) (boost, penalty float32) {
+	if c.channels > 1 && settings.corroborationBoost > 0 {
+		boost = float32(c.independent-1) * settings.corroborationBoost
 	}
 
-	return len(channelSet)
-}
-
-func calculateBoostPenalty(channelCount, itemCount int, settings digestSettings) (boost, penalty float32) {
-	if channelCount > 1 && settings.corroborationBoost > 0 {
-		boost = float32(channelCount-1) * settings.corroborationBoost
-	}
-
-	if channelCount == 1 && itemCount > 1 && settings.singleSourcePenalty > 0 {
+	if c.channels == 1 && itemCount > 1 && settings.singleSourcePenalty > 0 {
 		penalty = settings.singleSourcePenalty
 	}
 
@@ -103,8 +112,140 @@ func clampScore(score float32) float32 {
 	return score
 }
 
-// buildCorroborationLine returns an empty string (corroboration display disabled).
-// Reserved for future implementation of multi-source verification display.
-func (s *Scheduler) buildCorroborationLine(_ []db.Item, _ db.Item) string {
-	return ""
+// buildCorroborationLine reports how many distinct channels covered the
+// story of items: the channels of items and of every cluster containing one
+// of them. Correlated channels are noted with the independent count. It is
+// empty for single-channel stories.
+func (rc *digestRenderContext) buildCorroborationLine(items []db.Item) string {
+	c := rc.settings.channelOverlap.corroborate(items, rc.clusters)
+	if c.channels < minCorroboratingChannels {
+		return ""
+	}
+
+	line := fmt.Sprintf(rc.getReportedByFormat(), c.channels)
+
+	if independent := max(int(math.Round(c.independent)), 1); independent < c.channels {
+		line += " (" + fmt.Sprintf(rc.getIndependentFormat(), independent) + ")"
+	}
+
+	return fmt.Sprintf("\n    ↳ <i>%s</i>", line)
+}
+
+// getReportedByFormat returns the localized "reported by N channels" format.
+func (rc *digestRenderContext) getReportedByFormat() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "Сообщили каналы: %d"
+	case "de":
+		return "Von %d Kanälen gemeldet"
+	case "es":
+		return "Publicado por %d canales"
+	case "fr":
+		return "Rapporté par %d chaînes"
+	case "it":
+		return "Riportato da %d canali"
+	}
+
+	return "Reported by %d channels"
+}
+
+// getIndependentFormat returns the localized "≈N independent" format.
+func (rc *digestRenderContext) getIndependentFormat() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "≈%d независимых"
+	case "de":
+		return "≈%d unabhängige"
+	case "es":
+		return "≈%d independientes"
+	case "fr":
+		return "≈%d indépendantes"
+	case "it":
+		return "≈%d indipendenti"
+	}
+
+	return "≈%d independent"
+}
+
+// storyChannels returns the distinct channels of items and of the clusters
+// they belong to, by channel key, with their peer IDs.
+func storyChannels(items []db.Item, clusters []db.ClusterWithItems) map[string]int64 {
+	ids := make(map[string]bool, len(items))
+	channels := make(map[string]int64, len(items))
+
+	for _, item := range items {
+		ids[item.ID] = true
+
+		if key := channelKey(item); key != "" {
+			channels[key] = item.SourceChannelID
+		}
+	}
+
+	for _, c := range clusters {
+		if !clusterContainsAny(c, ids) {
+			continue
+		}
+
+		for _, item := range c.Items {
+			if key := channelKey(item); key != "" {
+				channels[key] = item.SourceChannelID
+			}
+		}
+	}
+
+	return channels
+}
+
+func clusterContainsAny(c db.ClusterWithItems, ids map[string]bool) bool {
+	for _, item := range c.Items {
+		if ids[item.ID] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// channelKey identifies an item's channel by username, then peer ID, then
+// title for private channels.
+func channelKey(item db.Item) string {
+	switch {
+	case item.SourceChannel != "":
+		return "@" + strings.ToLower(item.SourceChannel)
+	case item.SourceChannelID != 0:
+		return strconv.FormatInt(item.SourceChannelID, 10)
+	default:
+		return item.SourceChannelTitle
+	}
+}
+
+// sortTiersByCorroboration orders the clusters and items of each tier by the
+// number of independent channels that reported them, most first, keeping
+// importance order among equally corroborated ones.
+func (rc *digestRenderContext) sortTiersByCorroboration(groups ...*clusterGroup) {
+	overlap := rc.settings.channelOverlap
+
+	for _, g := range groups {
+		sort.SliceStable(g.clusters, func(i, j int) bool {
+			ci := overlap.corroborate(g.clusters[i].Items, rc.clusters).independent
+			cj := overlap.corroborate(g.clusters[j].Items, rc.clusters).independent
+
+			if ci != cj {
+				return ci > cj
+			}
+
+			return clusterMaxImportance(g.clusters[i]) > clusterMaxImportance(g.clusters[j])
+		})
+
+		sort.SliceStable(g.items, func(i, j int) bool {
+			ci := overlap.corroborate(g.items[i:i+1], rc.clusters).independent
+			cj := overlap.corroborate(g.items[j:j+1], rc.clusters).independent
+
+			if ci != cj {
+				return ci > cj
+			}
+
+			return g.items[i].ImportanceScore > g.items[j].ImportanceScore
+		})
+	}
 }
@@ -0,0 +1,97 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestCorroborate(t *testing.T) {
+	clusters := []db.ClusterWithItems{
+		{ID: "c1", Items: []db.Item{
+			{ID: "a", SourceChannel: "alpha", SourceChannelID: 1},
+			{ID: "b", SourceChannel: "Alpha", SourceChannelID: 1},
+			{ID: "c", SourceChannelID: 42},
+			{ID: "d", SourceChannelTitle: "Private"},
+		}},
+		{ID: "c2", Items: []db.Item{{ID: "e", SourceChannel: "beta"}}},
+	}
+
+	var independent channelOverlap
+
+	if got := independent.corroborate(clusters[0].Items[:1], clusters); got.channels != 3 || got.independent != 3 {
+		t.Errorf("corroboration of a = %+v, want 3 channels from its cluster", got)
+	}
+
+	if got := independent.corroborate([]db.Item{{ID: "e", SourceChannel: "beta"}}, clusters); got.channels != 1 {
+		t.Errorf("channels of e = %d, want 1", got.channels)
+	}
+
+	correlated := channelOverlap{overlapKey(42, 1): 0.9}
+
+	got := correlated.corroborate(clusters[0].Items[:1], clusters)
+	if got.channels != 3 || got.independent < 2.09 || got.independent > 2.11 {
+		t.Errorf("correlated corroboration = %+v, want 3 channels and 2.1 independent", got)
+	}
+}
+
+func TestCalculateBoostPenaltyDiscountsCorrelatedChannels(t *testing.T) {
+	settings := digestSettings{corroborationBoost: 0.1, singleSourcePenalty: 0.05}
+
+	boost, _ := calculateBoostPenalty(storyCorroboration{channels: 3, independent: 1.5}, 3, settings)
+	if boost < 0.049 || boost > 0.051 {
+		t.Errorf("boost = %v, want 0.05 for 1.5 independent channels", boost)
+	}
+
+	_, penalty := calculateBoostPenalty(storyCorroboration{channels: 1, independent: 1}, 2, settings)
+	if penalty != 0.05 {
+		t.Errorf("penalty = %v, want 0.05 for a single-source cluster", penalty)
+	}
+}
+
+func TestBuildCorroborationLine(t *testing.T) {
+	items := []db.Item{{ID: "a", SourceChannel: "alpha"}, {ID: "b", SourceChannel: "beta"}}
+
+	rc := &digestRenderContext{settings: digestSettings{digestLanguage: "ru"}}
+	if got, want := rc.buildCorroborationLine(items), "\n    ↳ <i>Сообщили каналы: 2</i>"; got != want {
+		t.Errorf("line = %q, want %q", got, want)
+	}
+
+	if got := rc.buildCorroborationLine(items[:1]); got != "" {
+		t.Errorf("single-channel line = %q, want empty", got)
+	}
+
+	items = append(items, db.Item{ID: "c", SourceChannel: "gamma", SourceChannelID: 3})
+	items[0].SourceChannelID, items[1].SourceChannelID = 1, 2
+	rc.settings.digestLanguage = ""
+	rc.settings.channelOverlap = channelOverlap{overlapKey(1, 2): 0.9, overlapKey(1, 3): 0.8}
+
+	if got, want := rc.buildCorroborationLine(items), "\n    ↳ <i>Reported by 3 channels (≈1 independent)</i>"; got != want {
+		t.Errorf("correlated line = %q, want %q", got, want)
+	}
+}
+
+func TestCategorizeByImportanceSortsByCorroboration(t *testing.T) {
+	rc := &digestRenderContext{
+		settings: digestSettings{topicsEnabled: true, tierSort: TierSortCorroboration},
+		clusters: []db.ClusterWithItems{
+			{Topic: "Solo", Items: []db.Item{{ID: "a", SourceChannel: "alpha", ImportanceScore: 0.95}}},
+			{Topic: "Wide", Items: []db.Item{
+				{ID: "b", SourceChannel: "alpha", ImportanceScore: 0.85},
+				{ID: "c", SourceChannel: "beta", ImportanceScore: 0.8},
+			}},
+		},
+	}
+
+	breaking, _, _ := rc.categorizeByImportance()
+	if len(breaking.clusters) != 2 || breaking.clusters[0].Topic != "Wide" {
+		t.Errorf("breaking clusters = %v, want Wide first", breaking.clusters)
+	}
+
+	rc.settings.tierSort = TierSortImportance
+
+	breaking, _, _ = rc.categorizeByImportance()
+	if breaking.clusters[0].Topic != "Solo" {
+		t.Errorf("importance sort put %q first, want Solo", breaking.clusters[0].Topic)
+	}
+}
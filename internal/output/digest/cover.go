@@ -0,0 +1,87 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingDigestCoverStyle is the AI cover style preset (see llm.CoverStyles).
+	SettingDigestCoverStyle = "digest_cover_style"
+	// SettingDigestCoverPrompt is the AI cover prompt template; empty uses
+	// the built-in prompt of the style.
+	SettingDigestCoverPrompt = "digest_cover_prompt"
+
+	coverCacheDateFormat = "2006-01-02"
+)
+
+// CoverStore reads the cover settings and caches generated AI covers.
+type CoverStore interface {
+	GetSetting(ctx context.Context, key string, target interface{}) error
+	GetCachedDigestCover(ctx context.Context, key string) ([]byte, bool, error)
+	SaveCachedDigestCover(ctx context.Context, key string, image []byte) error
+}
+
+// LoadCoverOptions reads the cover style, prompt template and digest tone
+// for a digest posted at date.
+func LoadCoverOptions(ctx context.Context, store CoverStore, date time.Time, logger *zerolog.Logger) llm.CoverOptions {
+	opts := llm.CoverOptions{Date: date}
+
+	// Missing settings leave the defaults: editorial style, built-in prompt.
+	if err := store.GetSetting(ctx, SettingDigestCoverStyle, &opts.Style); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_cover_style from DB")
+	}
+
+	if err := store.GetSetting(ctx, SettingDigestCoverPrompt, &opts.Template); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_cover_prompt from DB")
+	}
+
+	if err := store.GetSetting(ctx, "digest_tone", &opts.Tone); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_tone from DB")
+	}
+
+	return opts
+}
+
+// CoverCacheKey identifies the AI cover of a digest: the same items drawn
+// with the same style, template and tone on the same day share a cover, so
+// a preview and the posted digest generate it once.
+func CoverCacheKey(items []db.Item, opts llm.CoverOptions) string {
+	parts := append(collectItemIDs(items), opts.Style, opts.Template, opts.Tone, opts.Date.UTC().Format(coverCacheDateFormat))
+
+	return clusterFingerprint(parts)
+}
+
+// GenerateCover returns the AI cover of a digest, generating it only when no
+// cover is cached for the digest. narrative is called on cache misses only,
+// since preparing it takes an LLM call too.
+func GenerateCover(ctx context.Context, client llm.Client, store CoverStore, items []db.Item, topics []string, date time.Time, narrative func() string, logger *zerolog.Logger) (image []byte, cached bool, err error) {
+	opts := LoadCoverOptions(ctx, store, date, logger)
+	key := CoverCacheKey(items, opts)
+
+	hit, found, cacheErr := store.GetCachedDigestCover(ctx, key)
+	if cacheErr != nil {
+		logger.Warn().Err(cacheErr).Msg("failed to read digest cover cache")
+	} else if found && len(hit) > 0 {
+		return hit, true, nil
+	}
+
+	image, err = client.GenerateDigestCover(ctx, topics, narrative(), opts)
+	if err != nil {
+		return nil, false, fmt.Errorf("generate digest cover: %w", err)
+	}
+
+	if len(image) > 0 {
+		if saveErr := store.SaveCachedDigestCover(ctx, key, image); saveErr != nil {
+			logger.Warn().Err(saveErr).Msg("failed to cache digest cover")
+		}
+	}
+
+	return image, false, nil
+}
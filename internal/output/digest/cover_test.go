@@ -0,0 +1,93 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+var errSettingNotFound = errors.New("setting not found")
+
+type stubCoverStore struct {
+	settings map[string]string
+	covers   map[string][]byte
+}
+
+func (s *stubCoverStore) GetSetting(_ context.Context, key string, target interface{}) error {
+	value, ok := s.settings[key]
+	if !ok {
+		return errSettingNotFound
+	}
+
+	return json.Unmarshal([]byte(value), target)
+}
+
+func (s *stubCoverStore) GetCachedDigestCover(_ context.Context, key string) ([]byte, bool, error) {
+	image, ok := s.covers[key]
+
+	return image, ok, nil
+}
+
+func (s *stubCoverStore) SaveCachedDigestCover(_ context.Context, key string, image []byte) error {
+	s.covers[key] = image
+
+	return nil
+}
+
+type stubCoverClient struct {
+	llm.Client
+
+	calls int
+	opts  llm.CoverOptions
+}
+
+func (c *stubCoverClient) GenerateDigestCover(_ context.Context, _ []string, _ string, opts llm.CoverOptions) ([]byte, error) {
+	c.calls++
+	c.opts = opts
+
+	return []byte{byte(c.calls)}, nil
+}
+
+func TestGenerateCoverCachesPerDigest(t *testing.T) {
+	logger := zerolog.Nop()
+	store := &stubCoverStore{
+		settings: map[string]string{SettingDigestCoverStyle: `"flat"`, "digest_tone": `"brief"`},
+		covers:   make(map[string][]byte),
+	}
+	client := &stubCoverClient{}
+	date := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	items := []db.Item{{ID: "a"}, {ID: "b"}}
+	narratives := 0
+	narrative := func() string {
+		narratives++
+
+		return "narrative"
+	}
+
+	first, cached, err := GenerateCover(context.Background(), client, store, items, nil, date, narrative, &logger)
+	if err != nil || cached {
+		t.Fatalf("first GenerateCover() = %v, %v, %v", first, cached, err)
+	}
+
+	if client.opts.Style != llm.CoverStyleFlat || client.opts.Tone != llm.ToneBrief {
+		t.Errorf("cover options = %+v, want the stored style and tone", client.opts)
+	}
+
+	again, cached, _ := GenerateCover(context.Background(), client, store, []db.Item{{ID: "b"}, {ID: "a"}}, nil, date.Add(time.Hour), narrative, &logger)
+	if !cached || string(again) != string(first) || client.calls != 1 || narratives != 1 {
+		t.Errorf("same digest redrawn: cached=%v calls=%d narratives=%d", cached, client.calls, narratives)
+	}
+
+	store.settings[SettingDigestCoverStyle] = `"minimal"`
+
+	if _, cached, _ := GenerateCover(context.Background(), client, store, items, nil, date, narrative, &logger); cached || client.calls != 2 {
+		t.Errorf("style change must draw a new cover: cached=%v calls=%d", cached, client.calls)
+	}
+}
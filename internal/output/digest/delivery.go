@@ -0,0 +1,75 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// SettingDeliveryEnabledFmt is the setting key that enables or pauses a
+// delivery target, by platform. Targets are enabled by default.
+const SettingDeliveryEnabledFmt = "delivery_%s_enabled"
+
+// DeliveryTarget delivers digests to a platform other than Telegram.
+type DeliveryTarget interface {
+	// Platform names the target, e.g. "slack".
+	Platform() string
+	Deliver(ctx context.Context, data TemplateData) error
+}
+
+// SetDeliveryTargets sets the platforms that receive each posted digest in
+// addition to the Telegram target chat.
+func (s *Scheduler) SetDeliveryTargets(targets ...DeliveryTarget) {
+	s.deliveryTargets = targets
+}
+
+// deliverDigest sends the posted digest to the enabled delivery targets and
+// the exporter. Failures are logged and do not affect the Telegram digest.
+func (s *Scheduler) deliverDigest(ctx context.Context, digestID string, targetChatID int64, start, end time.Time, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) {
+	targets := s.enabledDeliveryTargets(ctx, logger)
+	if (len(targets) == 0 && s.exporter == nil) || len(items) == 0 {
+		return
+	}
+
+	settings := s.getDigestSettings(ctx, targetChatID, logger)
+	data := s.newRenderContext(ctx, settings, items, clusters, start, end, nil, nil, logger).templateData()
+
+	for _, target := range targets {
+		if err := target.Deliver(ctx, data); err != nil {
+			observability.DigestDeliveries.WithLabelValues(target.Platform(), StatusError).Inc()
+			logger.Warn().Err(err).Str("platform", target.Platform()).Msg("failed to deliver digest")
+
+			continue
+		}
+
+		observability.DigestDeliveries.WithLabelValues(target.Platform(), StatusPosted).Inc()
+		logger.Info().Str("platform", target.Platform()).Msg("Digest delivered")
+	}
+
+	s.exportDigest(ctx, digestID, data, logger)
+}
+
+// enabledDeliveryTargets returns the delivery targets not paused by their setting.
+func (s *Scheduler) enabledDeliveryTargets(ctx context.Context, logger *zerolog.Logger) []DeliveryTarget {
+	enabled := make([]DeliveryTarget, 0, len(s.deliveryTargets))
+
+	for _, target := range s.deliveryTargets {
+		key := fmt.Sprintf(SettingDeliveryEnabledFmt, target.Platform())
+		on := true
+
+		if err := s.database.GetSetting(ctx, key, &on); err != nil {
+			logger.Debug().Err(err).Msgf("could not get %s from DB, defaulting to enabled", key)
+		}
+
+		if on {
+			enabled = append(enabled, target)
+		}
+	}
+
+	return enabled
+}
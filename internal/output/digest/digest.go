@@ -23,9 +23,11 @@ import (
 
 	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/events"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/schedule"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
@@ -38,6 +40,16 @@ type RichDigestItem struct {
 	ChannelID  int64
 	MsgID      int64
 	MediaData  []byte
+	// Related counts the other items of the entry's cluster.
+	Related int
+	// VideoDuration is the length of a video post in seconds; MediaData
+	// then holds its thumbnail.
+	VideoDuration int
+	// AltText describes the entry's image; the alt texts of a collage's
+	// images are joined.
+	AltText string
+	// Sensitive is set when an item of the entry has content safety flags.
+	Sensitive bool
 }
 
 // RichDigestContent holds content for inline image digest display.
@@ -45,6 +57,10 @@ type RichDigestContent struct {
 	Header   string
 	Items    []RichDigestItem
 	DigestID string
+	// AltTextMode is the digest_alt_text mode for item captions.
+	AltTextMode string
+	// SafetyPolicy is the content safety policy for sensitive entries.
+	SafetyPolicy string
 }
 
 // DigestPoster sends digest content to Telegram.
@@ -54,6 +70,7 @@ type DigestPoster interface {
 	SendDigestWithImage(ctx context.Context, chatID int64, text string, digestID string, imageData []byte) (int64, error)
 	SendRichDigest(ctx context.Context, chatID int64, content RichDigestContent) (int64, error)
 	SendNotification(ctx context.Context, text string) error
+	SendChannelTrialReport(ctx context.Context, report db.ChannelTrialReport) error
 }
 
 // ExpandLinkGenerator generates tokens for expanded view links.
@@ -69,21 +86,33 @@ type Scheduler struct {
 	database            Repository
 	bot                 DigestPoster
 	llmClient           llm.Client
+	llmStats            *llmCallStats
+	prebuilt            *prebuildCache
 	expandLinkGenerator ExpandLinkGenerator
+	deliveryTargets     []DeliveryTarget
+	events              *events.Publisher
+	exporter            Exporter
 	logger              *zerolog.Logger
 	holderID            string // Unique ID for row-based lock ownership
 }
 
 // New creates a new Scheduler with the given dependencies.
 func New(cfg *config.Config, database Repository, bot DigestPoster, llmClient llm.Client, logger *zerolog.Logger) *Scheduler {
-	return &Scheduler{
-		cfg:       cfg,
-		database:  database,
-		bot:       bot,
-		llmClient: llmClient,
-		logger:    logger,
-		holderID:  uuid.New().String(),
+	s := &Scheduler{
+		cfg:      cfg,
+		database: database,
+		bot:      bot,
+		logger:   logger,
+		holderID: uuid.New().String(),
+		prebuilt: &prebuildCache{},
 	}
+
+	if llmClient != nil {
+		s.llmStats = &llmCallStats{}
+		s.llmClient = &timedLLMClient{Client: llmClient, stats: s.llmStats}
+	}
+
+	return s
 }
 
 // SetExpandLinkGenerator sets the optional expand link generator for digest items.
@@ -91,6 +120,11 @@ func (s *Scheduler) SetExpandLinkGenerator(gen ExpandLinkGenerator) {
 	s.expandLinkGenerator = gen
 }
 
+// SetEventPublisher sets the publisher of digest_posted events.
+func (s *Scheduler) SetEventPublisher(pub *events.Publisher) {
+	s.events = pub
+}
+
 func (s *Scheduler) getLockName() string {
 	return s.cfg.LeaderElectionLeaseName
 }
@@ -122,6 +156,11 @@ func (s *Scheduler) Run(ctx context.Context) error {
 		lastAutoRelevanceRun time.Time
 		lastThresholdRun     time.Time
 		lastRatingStatsRun   time.Time
+		lastCoordinationRun  time.Time
+		lastTopicDriftRun    time.Time
+		lastScoreDriftRun    time.Time
+		lastQualityEvalRun   time.Time
+		lastHealthRefresh    time.Time
 	)
 
 	for { // select loop immediately follows declarations
@@ -130,11 +169,21 @@ func (s *Scheduler) Run(ctx context.Context) error {
 			return ctx.Err() //nolint:wrapcheck
 		case <-ticker.C:
 			s.runOnceWithLock(ctx)
+			s.maybeRunSearchWatches(ctx)
+			s.maybeRunWatchlistAlerts(ctx)
+			s.maybeRunGroupDigests(ctx)
+			s.maybeRunChannelTrialReports(ctx)
 		case <-autoWeightTicker.C:
 			s.maybeRunAutoWeightUpdate(ctx, &lastAutoWeightRun)
 			s.maybeRunAutoRelevanceUpdate(ctx, &lastAutoRelevanceRun)
 			s.maybeRunThresholdTuning(ctx, &lastThresholdRun)
 			s.maybeRunRatingStatsUpdate(ctx, &lastRatingStatsRun)
+			s.maybeRunCoordinationReport(ctx, &lastCoordinationRun)
+			s.maybeRunTopicDriftAlerts(ctx, &lastTopicDriftRun)
+			s.maybeRunScoreDriftAlerts(ctx, &lastScoreDriftRun)
+			s.maybeRunQualityEvaluation(ctx, &lastQualityEvalRun)
+			s.maybeRunChannelHealthCheck(ctx, &lastHealthRefresh)
+			s.maybeRunRollups(ctx)
 		}
 	}
 }
@@ -404,6 +453,8 @@ func (s *Scheduler) processDigest(ctx context.Context, logger *zerolog.Logger) e
 		s.sendConsolidatedAnomalyNotification(ctx, anomalies, cfg.importanceThreshold, logger)
 	}
 
+	s.maybePrebuildDigest(ctx, cfg, now, logger)
+
 	return nil
 }
 
@@ -567,7 +618,9 @@ func (s *Scheduler) processWindow(ctx context.Context, start, end time.Time, tar
 		return nil, nil //nolint:nilnil // nil,nil indicates digest already exists
 	}
 
-	text, items, clusters, anomalyAny, err := s.BuildDigest(ctx, start, end, importanceThreshold, logger)
+	llmBefore := s.llmStats.snapshot()
+
+	text, items, clusters, anomalyAny, err := s.buildDigest(ctx, start, end, targetChatID, importanceThreshold, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -594,6 +647,11 @@ func (s *Scheduler) processWindow(ctx context.Context, start, end time.Time, tar
 	}
 
 	s.finalizeDigest(ctx, digestID, start, end, targetChatID, msgID, items, clusters, logger)
+	s.deliverDigest(ctx, digestID, targetChatID, start, end, items, clusters, logger)
+	s.prebuilt.clear(start, end)
+	s.recordMissedDigests(ctx, start, end, importanceThreshold, logger)
+	s.sendDigestScorecard(ctx, digestID, start, end, items, clusters, llmBefore, logger)
+	s.postShadowDigest(ctx, digestID, start, end, items, clusters, logger)
 
 	return nil, nil //nolint:nilnil // nil,nil indicates successful completion with no anomaly
 }
@@ -608,7 +666,7 @@ func (s *Scheduler) postDigest(ctx context.Context, targetChatID int64, text, di
 
 	// If inline images are enabled, use rich digest mode
 	if inlineImagesEnabled {
-		return s.postRichDigest(ctx, targetChatID, text, digestID, start, end, importanceThreshold, items, logger)
+		return s.postRichDigest(ctx, targetChatID, text, digestID, start, end, importanceThreshold, items, clusters, logger)
 	}
 
 	// Fetch cover image
@@ -641,17 +699,25 @@ func (s *Scheduler) fetchCoverImage(ctx context.Context, start, end time.Time, i
 		logger.Debug().Err(err).Msg("could not get digest_ai_cover from DB, defaulting to disabled")
 	}
 
-	// Try AI-generated cover first if enabled
+	// Try AI-generated cover first if enabled, preferring one generated while pre-building
 	if aiCoverEnabled && s.llmClient != nil {
-		topics := extractTopicsFromDigest(items, clusters)
-		narrative := s.prepareNarrativeForCover(ctx, items, clusters, logger)
+		if coverImage := s.prebuilt.coverImage(start, end); len(coverImage) > 0 {
+			logger.Info().Msg("Using pre-built AI cover")
 
-		coverImage, err := s.llmClient.GenerateDigestCover(ctx, topics, narrative)
+			return coverImage
+		}
+
+		if coverImage := s.generateAICover(ctx, items, clusters, logger); len(coverImage) > 0 {
+			return coverImage
+		}
+	}
+
+	// Compose a branded cover locally when no AI cover is available
+	if LocalCoverEnabled(ctx, s.database, logger) {
+		coverImage, err := ComposeLocalCover(ctx, s.database, items, clusters, start, end, logger)
 		if err != nil {
-			logger.Warn().Err(err).Msg("failed to generate AI cover, falling back to original image")
+			logger.Warn().Err(err).Msg("failed to compose local cover")
 		} else {
-			logger.Info().Int("topics_count", len(topics)).Str("narrative_preview", truncateForLog(narrative)).Msg("AI cover generated successfully")
-
 			return coverImage
 		}
 	}
@@ -678,6 +744,39 @@ func (s *Scheduler) fetchCoverImage(ctx context.Context, start, end time.Time, i
 	return coverImage
 }
 
+// generateAICover generates a cover image from the digest's topics and
+// narrative, returning nil on failure.
+func (s *Scheduler) generateAICover(ctx context.Context, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) []byte {
+	if s.llmClient == nil {
+		return nil
+	}
+
+	topics := extractTopicsFromDigest(items, clusters)
+
+	var narrative string
+
+	coverImage, cached, err := GenerateCover(ctx, s.llmClient, s.database, items, topics, time.Now(), func() string {
+		narrative = s.prepareNarrativeForCover(ctx, items, clusters, logger)
+
+		return narrative
+	}, logger)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to generate AI cover, falling back to original image")
+
+		return nil
+	}
+
+	if cached {
+		logger.Info().Msg("Using cached AI cover")
+
+		return coverImage
+	}
+
+	logger.Info().Int("topics_count", len(topics)).Str("narrative_preview", truncateForLog(narrative)).Msg("AI cover generated successfully")
+
+	return coverImage
+}
+
 // sendDigest sends the digest using the bot.
 func (s *Scheduler) sendDigest(ctx context.Context, targetChatID int64, text, digestID string, coverImage []byte) (int64, error) {
 	var (
@@ -699,7 +798,7 @@ func (s *Scheduler) sendDigest(ctx context.Context, targetChatID int64, text, di
 }
 
 // postRichDigest sends the digest with inline images per item.
-func (s *Scheduler) postRichDigest(ctx context.Context, targetChatID int64, headerText, digestID string, start, end time.Time, importanceThreshold float32, items []db.Item, logger *zerolog.Logger) (int64, error) {
+func (s *Scheduler) postRichDigest(ctx context.Context, targetChatID int64, headerText, digestID string, start, end time.Time, importanceThreshold float32, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) (int64, error) {
 	// Fetch items with media data
 	itemsWithMedia, err := s.database.GetItemsForWindowWithMedia(ctx, start, end, importanceThreshold, len(items))
 	if err != nil {
@@ -717,25 +816,20 @@ func (s *Scheduler) postRichDigest(ctx context.Context, targetChatID int64, head
 	// Build header from the text (extract first part before items)
 	header := extractDigestHeader(headerText)
 
-	// Convert items to RichDigestItem format
-	richItems := make([]RichDigestItem, 0, len(itemsWithMedia))
-
-	for _, item := range itemsWithMedia {
-		richItems = append(richItems, RichDigestItem{
-			Summary:    item.Summary,
-			Topic:      item.Topic,
-			Importance: item.ImportanceScore,
-			Channel:    item.SourceChannel,
-			ChannelID:  item.SourceChannelID,
-			MsgID:      item.SourceMsgID,
-			MediaData:  item.MediaData,
-		})
+	safetyPolicy := LoadSafetyPolicy(ctx, s.database, targetChatID)
+	if safetyPolicy == filters.SafetyPolicyBlock {
+		itemsWithMedia = withoutFlaggedItems(itemsWithMedia)
 	}
 
+	// Convert items to RichDigestItem format, one entry per cluster
+	richItems := BuildRichItems(ctx, s.database, itemsWithMedia, clusters, logger)
+
 	content := RichDigestContent{
-		Header:   header,
-		Items:    richItems,
-		DigestID: digestID,
+		Header:       header,
+		Items:        richItems,
+		DigestID:     digestID,
+		AltTextMode:  LoadAltTextMode(ctx, s.database),
+		SafetyPolicy: safetyPolicy,
 	}
 
 	msgID, err := s.bot.SendRichDigest(ctx, targetChatID, content)
@@ -912,6 +1006,15 @@ func (s *Scheduler) finalizeDigest(ctx context.Context, digestID string, start,
 		logger.Error().Err(err).Msg("failed to save digest record")
 	}
 
+	s.events.Publish(events.TypeDigestPosted, events.DigestPostedData{
+		DigestID:    digestID,
+		ChatID:      targetChatID,
+		MessageID:   msgID,
+		WindowStart: start.UTC(),
+		WindowEnd:   end.UTC(),
+		ItemIDs:     itemIDs,
+	})
+
 	// Save digest entries
 	entries := s.createDigestEntries(items, clusters)
 
@@ -919,6 +1022,12 @@ func (s *Scheduler) finalizeDigest(ctx context.Context, digestID string, start,
 		logger.Error().Err(err).Msg("failed to save digest entries")
 	}
 
+	if err := s.database.SaveDigestItems(ctx, digestID, itemIDs); err != nil {
+		logger.Error().Err(err).Msg("failed to save digest items")
+	}
+
+	s.updateStories(ctx, clusters, logger)
+
 	if err := s.updateStatsAfterDigest(ctx, start, end, logger); err != nil {
 		logger.Debug().Err(err).Msg("stats collection failed (non-fatal)")
 	}
@@ -967,9 +1076,56 @@ func (s *Scheduler) updateStatsAfterDigest(ctx context.Context, start, end time.
 	return nil
 }
 
-// BuildDigest builds a digest for the given window.
+// BuildDigest builds a digest for the given window as the current target chat would receive it.
 // The fourth return value is package-private anomaly info (returned as any for interface compatibility).
 func (s *Scheduler) BuildDigest(ctx context.Context, start, end time.Time, importanceThreshold float32, logger *zerolog.Logger) (string, []db.Item, []db.ClusterWithItems, any, error) {
+	return s.buildDigest(ctx, start, end, s.currentTargetChatID(ctx, logger), importanceThreshold, logger)
+}
+
+func (s *Scheduler) buildDigest(ctx context.Context, start, end time.Time, targetChatID int64, importanceThreshold float32, logger *zerolog.Logger) (string, []db.Item, []db.ClusterWithItems, any, error) {
+	sel, err := s.selectDigestItems(ctx, start, end, targetChatID, importanceThreshold, logger)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	if len(sel.items) == 0 {
+		if sel.anomaly != nil {
+			return "", nil, nil, sel.anomaly, nil
+		}
+
+		return "", nil, nil, nil, nil
+	}
+
+	if pb, ok := s.prebuilt.lookup(start, end, targetChatID, sel.items); ok {
+		logger.Info().Time(LogFieldStart, start).Time(LogFieldEnd, end).Int(LogFieldCount, len(sel.items)).
+			Dur("age", time.Since(pb.builtAt)).Msg("Using pre-built digest, no late items")
+		s.recordDigestQuality(ctx, sel.items, end, importanceThreshold, logger)
+
+		return pb.text, pb.items, pb.clusters, nil, nil
+	}
+
+	text, items, clusters, anomaly, err := s.composeDigest(ctx, sel, start, end, importanceThreshold, logger)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	return text, items, clusters, anomaly, nil
+}
+
+// digestSelection holds the items selected for a digest window and the
+// settings they were selected with. No items means there is nothing to post;
+// anomaly is set when the empty window is worth reporting.
+type digestSelection struct {
+	items    []db.Item
+	settings digestSettings
+	anomaly  *anomalyInfo
+}
+
+// selectDigestItems fetches the window's items and narrows them down to the
+// ones the digest will show, refining their summaries if enabled.
+func (s *Scheduler) selectDigestItems(ctx context.Context, start, end time.Time, targetChatID int64, importanceThreshold float32, logger *zerolog.Logger) (digestSelection, error) {
+	reportProgress(ctx, ProgressSelecting, 0, 0)
+
 	totalItems, err := s.database.CountItemsInWindow(ctx, start, end)
 	if err != nil {
 		logger.Warn().Err(err).Msg("failed to count items in window")
@@ -980,30 +1136,66 @@ func (s *Scheduler) BuildDigest(ctx context.Context, start, end time.Time, impor
 		logger.Warn().Err(err).Msg("failed to count ready items in window")
 	}
 
-	items, err := s.database.GetItemsForWindow(ctx, start, end, importanceThreshold, s.cfg.DigestTopN*DigestPoolMultiplier)
+	settings := s.getDigestSettings(ctx, targetChatID, logger)
+
+	items, err := s.fetchWindowItems(ctx, start, end, importanceThreshold, settings.diversity)
 	if err != nil {
-		return "", nil, nil, nil, fmt.Errorf("failed to get items for window: %w", err)
+		return digestSelection{}, err
 	}
 
+	carried, stale := s.loadCarryOverItems(ctx, start, importanceThreshold, s.loadStaleItemPolicy(ctx, logger), logger)
+	settings.previouslyMissed = stale
+	items = mergeCarryOverItems(items, carried)
+
 	if anomaly := s.checkEmptyWindow(ctx, items, start, end, totalItems, readyItems, importanceThreshold, logger); anomaly != nil || len(items) == 0 {
-		return "", nil, nil, anomaly, nil
+		return digestSelection{anomaly: anomaly}, nil
+	}
+
+	items = s.applyRegionFilter(ctx, items, settings, logger)
+	if len(items) == 0 {
+		logger.Info().Strs("regions", settings.regions).Msg("No items match the digest region filter")
+
+		return digestSelection{}, nil
+	}
+
+	items = s.applySafetyPolicy(ctx, items, settings, logger)
+	settings.previouslyMissed = s.applySafetyPolicy(ctx, settings.previouslyMissed, settings, logger)
+
+	if len(items) == 0 {
+		logger.Info().Msg("All digest items were blocked by the content safety policy")
+
+		return digestSelection{}, nil
 	}
 
-	settings := s.getDigestSettings(ctx, logger)
 	items = s.applySmartSelection(items, settings)
 	items = s.deduplicateItems(items, logger)
+	items = s.suppressRepeatStories(ctx, items, logger)
+	items = s.enforceDiversityCaps(items, settings, logger)
+	items = s.applyMMRSelection(items, settings, logger)
 	items = s.applyTopicBalanceAndLimit(items, settings, logger)
+	s.refineSelectedSummaries(ctx, items, settings, logger)
+
+	return digestSelection{items: items, settings: settings}, nil
+}
+
+// composeDigest clusters and renders the selected items.
+func (s *Scheduler) composeDigest(ctx context.Context, sel digestSelection, start, end time.Time, importanceThreshold float32, logger *zerolog.Logger) (string, []db.Item, []db.ClusterWithItems, *anomalyInfo, error) {
+	items, settings := sel.items, sel.settings
 
 	logger.Info().Time(LogFieldStart, start).Time(LogFieldEnd, end).Int(LogFieldCount, len(items)).Msg("Processing items for digest")
+	reportProgress(ctx, ProgressClustering, 0, 0)
 
 	clusters, err := s.performClusteringIfEnabled(ctx, items, start, end, settings, logger)
 	if err != nil {
 		return "", nil, nil, nil, err
 	}
 
+	settings.channelOverlap = s.loadChannelOverlap(ctx, clusters, logger)
 	items, clusters = s.applyCorroborationAdjustments(items, clusters, settings)
+	s.attachForwardOrigins(ctx, items, clusters, logger)
 
 	s.recordDigestQuality(ctx, items, end, importanceThreshold, logger)
+	reportProgress(ctx, ProgressRendering, 0, 0)
 
 	return s.renderDigest(ctx, items, clusters, start, end, settings, logger)
 }
@@ -1151,19 +1343,34 @@ func (s *Scheduler) renderDigest(ctx context.Context, items []db.Item, clusters
 	evidence := s.loadEvidence(ctx, items, logger)
 	rc := s.newRenderContext(ctx, settings, items, clusters, start, end, factChecks, evidence, logger)
 
-	var sb strings.Builder
+	if text, ok := rc.renderActiveTemplate(ctx); ok {
+		return text, items, clusters, nil, nil
+	}
+
+	var sb, body strings.Builder
 
 	rc.buildHeaderSection(&sb)
 	rc.buildMetadataSection(&sb)
 
-	narrativeGenerated := rc.generateNarrative(ctx, &sb)
+	narrativeGenerated := rc.generateNarrative(ctx, &body)
 
 	if !narrativeGenerated || settings.editorDetailedItems {
-		s.renderDetailedItems(ctx, &sb, rc)
+		s.renderDetailedItems(ctx, &body, rc)
 	}
 
-	rc.buildContextSection(&sb)
+	rc.buildNumbersBlock(ctx, &body)
+	rc.buildQuotesBlock(ctx, &body)
+	rc.buildUpcomingBlock(ctx, &body)
+	rc.buildRumorWatchSection(ctx, &body)
+	rc.buildContextSection(&body)
 
+	// Items listed as previously missed are digested along with the rest.
+	items = append(items, rc.buildPreviouslyMissedSection(&body)...)
+
+	// The table of contents only lists topics whose items were actually rendered.
+	rc.buildTOCSection(&sb)
+	sb.WriteString(body.String())
+	rc.buildSourceFooter(&sb)
 	sb.WriteString("\n" + DigestSeparatorLine)
 
 	return sb.String(), items, clusters, nil, nil
@@ -1221,6 +1428,12 @@ func (s *Scheduler) renderDetailedItems(ctx context.Context, sb *strings.Builder
 	breakingTitle, notableTitle, alsoTitle := rc.getSectionTitles()
 	breaking, notable, also := rc.categorizeByImportance()
 
+	if rc.settings.othersAsNarrative {
+		rc.prepareClusterSummaries(ctx, breaking, notable)
+	} else {
+		rc.prepareClusterSummaries(ctx, breaking, notable, also)
+	}
+
 	rc.renderGroup(ctx, sb, breaking, EmojiBreaking, breakingTitle)
 	rc.renderGroup(ctx, sb, notable, EmojiNotable, notableTitle)
 
@@ -1293,21 +1506,46 @@ func (s *Scheduler) sendConsolidatedAnomalyNotification(ctx context.Context, ano
 }
 
 func (s *Scheduler) formatLink(item db.Item, label string) string {
+	return formatSourceLink(item, label, itemMessageURL(item))
+}
+
+// formatSourceLink links label to url, crediting the original channel of
+// forwarded items.
+func formatSourceLink(item db.Item, label, url string) string {
 	if label == "" {
-		label = item.SourceChannel
-		if label == "" {
-			label = item.SourceChannelTitle
-		}
+		label = sourceLabel(item)
+	}
 
-		if label == "" {
-			label = DefaultSourceLabel
-		}
+	link := fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(url), html.EscapeString(label))
+
+	// Credit the original channel of forwarded posts
+	if origin := formatForwardOrigin(item.ForwardOrigin); origin != "" {
+		return origin + " via " + link
+	}
+
+	return link
+}
+
+// sourceLabel returns the display name of an item's source channel.
+func sourceLabel(item db.Item) string {
+	if item.SourceChannel != "" {
+		return item.SourceChannel
+	}
+
+	if item.SourceChannelTitle != "" {
+		return item.SourceChannelTitle
 	}
 
+	return DefaultSourceLabel
+}
+
+// itemMessageURL returns the t.me link to an item's source message.
+func itemMessageURL(item db.Item) string {
 	if item.SourceChannel != "" {
-		return fmt.Sprintf("<a href=\"https://t.me/%s/%d\">%s</a>", html.EscapeString(item.SourceChannel), item.SourceMsgID, html.EscapeString(label))
+		return fmt.Sprintf("https://t.me/%s/%d", item.SourceChannel, item.SourceMsgID)
 	}
+
 	// For private channels or channels without username
 	// Note: tg_peer_id in DB is already the MTProto ID (positive for channels)
-	return fmt.Sprintf("<a href=\"https://t.me/c/%d/%d\">%s</a>", item.SourceChannelID, item.SourceMsgID, html.EscapeString(label))
+	return fmt.Sprintf("https://t.me/c/%d/%d", item.SourceChannelID, item.SourceMsgID)
 }
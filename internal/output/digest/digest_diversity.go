@@ -0,0 +1,80 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// SettingDigestDiversity stores the per-digest channel and topic caps as JSON.
+const SettingDigestDiversity = "digest_diversity"
+
+// fetchWindowItems returns the window's candidate pool. With diversity caps
+// set, the query already leaves out each channel's and topic's overflow.
+func (s *Scheduler) fetchWindowItems(ctx context.Context, start, end time.Time, importanceThreshold float32, caps db.DiversityCaps) ([]db.Item, error) {
+	limit := s.cfg.DigestTopN * DigestPoolMultiplier
+
+	var (
+		items []db.Item
+		err   error
+	)
+
+	if caps.Active() {
+		items, err = s.database.GetItemsForWindowDiverse(ctx, start, end, importanceThreshold, limit, caps)
+	} else {
+		items, err = s.database.GetItemsForWindow(ctx, start, end, importanceThreshold, limit)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items for window: %w", err)
+	}
+
+	return items, nil
+}
+
+// applyDiversityCaps keeps items in order while their channel and topic are
+// under the caps and returns the overflow as deferred. The query enforces the
+// caps on the window; this also covers carried-over items.
+func applyDiversityCaps(items []db.Item, caps db.DiversityCaps) (kept, deferred []db.Item) {
+	if !caps.Active() {
+		return items, nil
+	}
+
+	channelCounts := make(map[int64]int)
+	topicCounts := make(map[string]int)
+
+	for _, item := range items {
+		topic, capped := topicKey(item.Topic)
+
+		if (caps.MaxPerChannel > 0 && channelCounts[item.SourceChannelID] >= caps.MaxPerChannel) ||
+			(caps.MaxPerTopic > 0 && capped && topicCounts[topic] >= caps.MaxPerTopic) {
+			deferred = append(deferred, item)
+
+			continue
+		}
+
+		channelCounts[item.SourceChannelID]++
+		topicCounts[topic]++
+
+		kept = append(kept, item)
+	}
+
+	return kept, deferred
+}
+
+func (s *Scheduler) enforceDiversityCaps(items []db.Item, settings digestSettings, logger *zerolog.Logger) []db.Item {
+	kept, deferred := applyDiversityCaps(items, settings.diversity)
+	if len(deferred) > 0 {
+		logger.Info().
+			Int("deferred", len(deferred)).
+			Int("max_per_channel", settings.diversity.MaxPerChannel).
+			Int("max_per_topic", settings.diversity.MaxPerTopic).
+			Msg("Deferred items over the digest diversity caps")
+	}
+
+	return kept
+}
@@ -0,0 +1,40 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestApplyDiversityCaps(t *testing.T) {
+	items := []db.Item{
+		{ID: "a1", SourceChannelID: 1, Topic: "War"},
+		{ID: "a2", SourceChannelID: 1, Topic: "Economy"},
+		{ID: "a3", SourceChannelID: 1, Topic: "Sports"},
+		{ID: "b1", SourceChannelID: 2, Topic: "war "},
+		{ID: "c1", SourceChannelID: 3, Topic: "War"},
+		{ID: "c2", SourceChannelID: 3},
+		{ID: "d1", SourceChannelID: 4},
+	}
+
+	kept, deferred := applyDiversityCaps(items, db.DiversityCaps{MaxPerChannel: 2, MaxPerTopic: 2})
+
+	wantKept := []string{"a1", "a2", "b1", "c2", "d1"}
+	if len(kept) != len(wantKept) {
+		t.Fatalf("kept = %v, want %v", kept, wantKept)
+	}
+
+	for i, id := range wantKept {
+		if kept[i].ID != id {
+			t.Errorf("kept[%d] = %s, want %s", i, kept[i].ID, id)
+		}
+	}
+
+	if len(deferred) != 2 || deferred[0].ID != "a3" || deferred[1].ID != "c1" {
+		t.Errorf("deferred = %v, want a3 and c1", deferred)
+	}
+
+	if kept, deferred := applyDiversityCaps(items, db.DiversityCaps{}); len(kept) != len(items) || deferred != nil {
+		t.Errorf("caps off changed the items: kept %d, deferred %d", len(kept), len(deferred))
+	}
+}
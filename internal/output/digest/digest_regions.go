@@ -0,0 +1,55 @@
+package digest
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// applyRegionFilter keeps only items tagged with one of the configured
+// regions. Untagged items are dropped while a filter is set. When the
+// regions cannot be loaded, items are returned unfiltered.
+func (s *Scheduler) applyRegionFilter(ctx context.Context, items []db.Item, settings digestSettings, logger *zerolog.Logger) []db.Item {
+	if len(settings.regions) == 0 || len(items) == 0 {
+		return items
+	}
+
+	regions, err := s.database.GetItemRegions(ctx, collectItemIDs(items))
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load item regions, skipping region filter")
+
+		return items
+	}
+
+	filtered := filterItemsByRegion(items, regions, settings.regions)
+
+	logger.Debug().Strs("regions", settings.regions).Int("before", len(items)).Int("after", len(filtered)).Msg("applied region filter")
+
+	return filtered
+}
+
+// filterItemsByRegion returns the items whose regions intersect allowed,
+// with their Regions filled in.
+func filterItemsByRegion(items []db.Item, itemRegions map[string][]string, allowed []string) []db.Item {
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, r := range allowed {
+		allowedSet[r] = true
+	}
+
+	filtered := make([]db.Item, 0, len(items))
+
+	for _, item := range items {
+		for _, r := range itemRegions[item.ID] {
+			if allowedSet[r] {
+				item.Regions = itemRegions[item.ID]
+				filtered = append(filtered, item)
+
+				break
+			}
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,32 @@
+package digest
+
+import (
+	"reflect"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestFilterItemsByRegion(t *testing.T) {
+	items := []db.Item{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "untagged"}}
+	regions := map[string][]string{
+		"a": {"ua", "ru"},
+		"b": {"us"},
+		"c": {"pl"},
+	}
+
+	got := filterItemsByRegion(items, regions, []string{"ua", "pl"})
+
+	ids := make([]string, 0, len(got))
+	for _, item := range got {
+		ids = append(ids, item.ID)
+	}
+
+	if want := []string{"a", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("filterItemsByRegion() kept %v, want %v", ids, want)
+	}
+
+	if !reflect.DeepEqual(got[0].Regions, []string{"ua", "ru"}) {
+		t.Errorf("item regions = %v, want [ua ru]", got[0].Regions)
+	}
+}
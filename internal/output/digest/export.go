@@ -0,0 +1,42 @@
+package digest
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+// Exporter writes digests and stories to note-taking apps such as Notion or
+// Obsidian, updating earlier pages of the same story.
+type Exporter interface {
+	ExportDigest(ctx context.Context, digestID string, data TemplateData) error
+	ExportStory(ctx context.Context, storyID string) error
+}
+
+// SetExporter sets the exporter of posted digests and updated stories.
+func (s *Scheduler) SetExporter(exporter Exporter) {
+	s.exporter = exporter
+}
+
+// exportDigest exports a posted digest. Failures are logged only.
+func (s *Scheduler) exportDigest(ctx context.Context, digestID string, data TemplateData, logger *zerolog.Logger) {
+	if s.exporter == nil {
+		return
+	}
+
+	if err := s.exporter.ExportDigest(ctx, digestID, data); err != nil {
+		logger.Warn().Err(err).Str("digest_id", digestID).Msg("failed to export digest")
+	}
+}
+
+// exportStory exports a story after its timeline changed. Failures are
+// logged only.
+func (s *Scheduler) exportStory(ctx context.Context, storyID string, logger *zerolog.Logger) {
+	if s.exporter == nil {
+		return
+	}
+
+	if err := s.exporter.ExportStory(ctx, storyID); err != nil {
+		logger.Warn().Err(err).Str("story_id", storyID).Msg("failed to export story")
+	}
+}
@@ -0,0 +1,80 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// attachForwardOrigins marks forwarded items with their original post so source
+// links credit the original channel.
+func (s *Scheduler) attachForwardOrigins(ctx context.Context, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.RawMessageID)
+	}
+
+	for _, c := range clusters {
+		for _, item := range c.Items {
+			ids = append(ids, item.RawMessageID)
+		}
+	}
+
+	origins, err := s.database.GetForwardOrigins(ctx, ids)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load forward origins")
+
+		return
+	}
+
+	if len(origins) == 0 {
+		return
+	}
+
+	applyForwardOrigins(items, origins)
+
+	for i := range clusters {
+		applyForwardOrigins(clusters[i].Items, origins)
+	}
+}
+
+func applyForwardOrigins(items []db.Item, origins map[string]db.ForwardHop) {
+	for i := range items {
+		if origin, ok := origins[items[i].RawMessageID]; ok {
+			items[i].ForwardOrigin = &origin
+		}
+	}
+}
+
+// formatForwardOrigin renders the original channel of a forwarded item, linking to
+// the original post when the channel is public. It returns "" when the origin is unknown.
+func formatForwardOrigin(origin *db.ForwardHop) string {
+	if origin == nil {
+		return ""
+	}
+
+	if origin.Username != "" {
+		if origin.MessageID > 0 {
+			return fmt.Sprintf("<a href=\"https://t.me/%s/%d\">@%s</a>", html.EscapeString(origin.Username), origin.MessageID, html.EscapeString(origin.Username))
+		}
+
+		return "@" + html.EscapeString(origin.Username)
+	}
+
+	return html.EscapeString(origin.Title)
+}
+
+// itemPostTime returns when an item's content was first posted, using the original
+// post time for forwards.
+func itemPostTime(item db.Item) time.Time {
+	if item.ForwardOrigin != nil && !item.ForwardOrigin.Date.IsZero() {
+		return item.ForwardOrigin.Date
+	}
+
+	return item.TGDate
+}
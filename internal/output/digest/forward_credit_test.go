@@ -0,0 +1,46 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestFormatLinkCreditsForwardOrigin(t *testing.T) {
+	s := &Scheduler{}
+
+	item := db.Item{
+		SourceChannel: "aggregator",
+		SourceMsgID:   55,
+		ForwardOrigin: &db.ForwardHop{PeerID: 1, MessageID: 7, Username: "original"},
+	}
+
+	got := s.formatLink(item, "@aggregator")
+	want := `<a href="https://t.me/original/7">@original</a> via <a href="https://t.me/aggregator/55">@aggregator</a>`
+
+	if got != want {
+		t.Errorf("formatLink() = %q, want %q", got, want)
+	}
+
+	item.ForwardOrigin = &db.ForwardHop{PeerID: 2, MessageID: 9, Title: "Closed <Club>"}
+
+	if got := s.formatLink(item, "@aggregator"); !strings.HasPrefix(got, "Closed &lt;Club&gt; via ") {
+		t.Errorf("formatLink() for private origin = %q", got)
+	}
+}
+
+func TestOrderByFirstMoverUsesForwardOriginDate(t *testing.T) {
+	base := time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC)
+
+	items := []db.Item{
+		{ID: "direct", TGDate: base.Add(10 * time.Minute)},
+		{ID: "forward", TGDate: base.Add(30 * time.Minute), ForwardOrigin: &db.ForwardHop{Date: base}},
+	}
+
+	ordered, firstKnown := orderByFirstMover(items)
+	if !firstKnown || ordered[0].ID != "forward" {
+		t.Errorf("orderByFirstMover() first = %s (known %v), want forward", ordered[0].ID, firstKnown)
+	}
+}
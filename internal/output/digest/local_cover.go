@@ -0,0 +1,123 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/coverart"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// SettingDigestLocalCover enables the locally composed cover, used when no
+// AI cover is available.
+const SettingDigestLocalCover = "digest_local_cover"
+
+const localCoverDateFormat = "02.01.2006"
+
+// LocalCoverEnabled reports whether the local cover fallback is enabled.
+func LocalCoverEnabled(ctx context.Context, store CoverStore, logger *zerolog.Logger) bool {
+	var enabled bool
+
+	if err := store.GetSetting(ctx, SettingDigestLocalCover, &enabled); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_local_cover from DB, defaulting to disabled")
+	}
+
+	return enabled
+}
+
+// ComposeLocalCover draws the branded cover of a digest without an image
+// model: the localized title, the digest window in the schedule's timezone
+// and the digest's largest topics.
+func ComposeLocalCover(ctx context.Context, store CoverStore, items []db.Item, clusters []db.ClusterWithItems, start, end time.Time, logger *zerolog.Logger) ([]byte, error) {
+	var language string
+
+	if err := store.GetSetting(ctx, SettingDigestLanguage, &language); err != nil {
+		logger.Debug().Err(err).Msg(MsgCouldNotGetDigestLanguage)
+	}
+
+	if loc, ok := scheduleLocation(ctx, store, logger); ok {
+		start, end = start.In(loc), end.In(loc)
+	}
+
+	image, err := coverart.Compose(coverart.Params{
+		Title:    localCoverTitle(language),
+		Subtitle: formatLocalCoverWindow(start, end),
+		Topics:   rankCoverTopics(items, clusters),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("compose local cover: %w", err)
+	}
+
+	return image, nil
+}
+
+// localCoverTitle returns the cover heading in the digest language.
+func localCoverTitle(language string) string {
+	switch strings.ToLower(language) {
+	case "ru":
+		return "Дайджест"
+	case "es":
+		return "Resumen"
+	case "fr":
+		return "Résumé"
+	case "it":
+		return "Riassunto"
+	default:
+		return "Digest"
+	}
+}
+
+// formatLocalCoverWindow formats the digest window, e.g. "02.03.2026 · 09:00–13:00".
+func formatLocalCoverWindow(start, end time.Time) string {
+	if end.IsZero() {
+		return ""
+	}
+
+	if start.IsZero() {
+		return end.Format(localCoverDateFormat)
+	}
+
+	return fmt.Sprintf("%s · %s–%s", end.Format(localCoverDateFormat), start.Format(TimeFormatHourMinute), end.Format(TimeFormatHourMinute))
+}
+
+// rankCoverTopics orders the digest's topics by how many items they cover,
+// breaking ties by name so the cover stays deterministic.
+func rankCoverTopics(items []db.Item, clusters []db.ClusterWithItems) []string {
+	counts := make(map[string]int)
+
+	for _, c := range clusters {
+		if c.Topic != "" {
+			counts[c.Topic] += max(len(c.Items), 1)
+		}
+	}
+
+	for _, item := range items {
+		if item.Topic != "" {
+			counts[item.Topic]++
+		}
+	}
+
+	topics := make([]string, 0, len(counts))
+	for topic := range counts {
+		topics = append(topics, topic)
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		if counts[topics[i]] != counts[topics[j]] {
+			return counts[topics[i]] > counts[topics[j]]
+		}
+
+		return topics[i] < topics[j]
+	})
+
+	if len(topics) > coverart.MaxTopics {
+		topics = topics[:coverart.MaxTopics]
+	}
+
+	return topics
+}
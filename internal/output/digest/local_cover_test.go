@@ -0,0 +1,88 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestRankCoverTopics(t *testing.T) {
+	items := []db.Item{{Topic: "Tech"}, {Topic: "Sports"}, {Topic: "Sports"}, {Topic: "Art"}, {Topic: ""}}
+	clusters := []db.ClusterWithItems{
+		{Topic: "Economy", Items: []db.Item{{}, {}, {}}},
+		{Topic: "Culture"},
+	}
+
+	got := rankCoverTopics(items, clusters)
+	want := []string{"Economy", "Sports", "Art", "Culture"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rankCoverTopics() = %v, want %v", got, want)
+	}
+}
+
+func TestLocalCoverTitle(t *testing.T) {
+	tests := map[string]string{"": "Digest", "RU": "Дайджест", "de": "Digest", "fr": "Résumé"}
+
+	for language, want := range tests {
+		if got := localCoverTitle(language); got != want {
+			t.Errorf("localCoverTitle(%q) = %q, want %q", language, got, want)
+		}
+	}
+}
+
+func TestFormatLocalCoverWindow(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	if got, want := formatLocalCoverWindow(start, end), "02.03.2026 · 09:00–13:00"; got != want {
+		t.Errorf("formatLocalCoverWindow() = %q, want %q", got, want)
+	}
+
+	if got, want := formatLocalCoverWindow(time.Time{}, end), "02.03.2026"; got != want {
+		t.Errorf("formatLocalCoverWindow() = %q, want %q", got, want)
+	}
+}
+
+func TestComposeLocalCover(t *testing.T) {
+	store := &stubCoverStore{settings: map[string]string{
+		SettingDigestLanguage:   `"ru"`,
+		SettingDigestLocalCover: `true`,
+	}}
+	logger := zerolog.Nop()
+	items := []db.Item{{ID: "1", Topic: "Экономика"}, {ID: "2", Topic: "Tech"}}
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	if !LocalCoverEnabled(context.Background(), store, &logger) {
+		t.Fatal("LocalCoverEnabled() = false, want true")
+	}
+
+	first, err := ComposeLocalCover(context.Background(), store, items, nil, start, start.Add(time.Hour), &logger)
+	if err != nil {
+		t.Fatalf("ComposeLocalCover() error = %v", err)
+	}
+
+	second, err := ComposeLocalCover(context.Background(), store, items, nil, start, start.Add(time.Hour), &logger)
+	if err != nil {
+		t.Fatalf("ComposeLocalCover() error = %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Error("ComposeLocalCover() should be deterministic")
+	}
+
+	if _, err := png.Decode(bytes.NewReader(first)); err != nil {
+		t.Errorf("local cover is not a PNG: %v", err)
+	}
+
+	if LocalCoverEnabled(context.Background(), &stubCoverStore{}, &logger) {
+		t.Error("LocalCoverEnabled() should default to false")
+	}
+}
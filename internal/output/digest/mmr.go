@@ -0,0 +1,64 @@
+package digest
+
+import (
+	"math"
+	"slices"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/process/dedup"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// SettingDigestMMRLambda stores the Maximal Marginal Relevance trade-off
+// between importance (1) and novelty (0). Values outside (0, 1) disable MMR.
+const SettingDigestMMRLambda = "digest_mmr_lambda"
+
+// applyMMR reorders items by Maximal Marginal Relevance: each step picks the
+// item with the best lambda*importance - (1-lambda)*similarity, where
+// similarity is the highest cosine similarity to an already picked item.
+// Items without embeddings count as dissimilar to everything.
+func applyMMR(items []db.Item, lambda float32) []db.Item {
+	if lambda <= 0 || lambda >= 1 || len(items) < 2 {
+		return items
+	}
+
+	remaining := slices.Clone(items)
+	maxSim := make([]float32, len(remaining))
+	ordered := make([]db.Item, 0, len(items))
+
+	for len(remaining) > 0 {
+		best, bestScore := 0, float32(math.Inf(-1))
+
+		for i, item := range remaining {
+			if score := lambda*item.ImportanceScore - (1-lambda)*maxSim[i]; score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		picked := remaining[best]
+		ordered = append(ordered, picked)
+		remaining = slices.Delete(remaining, best, best+1)
+		maxSim = slices.Delete(maxSim, best, best+1)
+
+		for i := range remaining {
+			maxSim[i] = max(maxSim[i], dedup.CosineSimilarity(picked.Embedding, remaining[i].Embedding))
+		}
+	}
+
+	return ordered
+}
+
+// applyMMRSelection reorders the candidates by MMR so the topic balance and
+// the TopN limit keep important items that are not near-copies of each other.
+func (s *Scheduler) applyMMRSelection(items []db.Item, settings digestSettings, logger *zerolog.Logger) []db.Item {
+	if settings.mmrLambda <= 0 || settings.mmrLambda >= 1 {
+		return items
+	}
+
+	ordered := applyMMR(items, settings.mmrLambda)
+
+	logger.Debug().Float32("lambda", settings.mmrLambda).Int(LogFieldCount, len(ordered)).Msg("Reordered digest candidates by MMR")
+
+	return ordered
+}
@@ -0,0 +1,38 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestApplyMMR(t *testing.T) {
+	items := []db.Item{
+		{ID: "war-1", ImportanceScore: 0.9, Embedding: []float32{1, 0, 0}},
+		{ID: "war-2", ImportanceScore: 0.85, Embedding: []float32{0.99, 0.1, 0}},
+		{ID: "economy", ImportanceScore: 0.7, Embedding: []float32{0, 1, 0}},
+		{ID: "no-embedding", ImportanceScore: 0.5},
+	}
+
+	got := applyMMR(items, 0.5)
+
+	want := []string{"war-1", "economy", "no-embedding", "war-2"}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Fatalf("order = %v, want %v", orderedIDs(got), want)
+		}
+	}
+
+	if got := applyMMR(items, 1); got[1].ID != "war-2" {
+		t.Errorf("lambda 1 changed the order: %v", orderedIDs(got))
+	}
+}
+
+func orderedIDs(items []db.Item) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	return ids
+}
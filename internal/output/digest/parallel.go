@@ -0,0 +1,138 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// buildConcurrency is how many LLM-bound steps of a digest build run at once.
+// Providers still pace the requests with their own rate limiters, so this
+// only bounds how many wait or run in parallel.
+func (s *Scheduler) buildConcurrency() int {
+	if s.cfg == nil || s.cfg.DigestBuildConcurrency < 1 {
+		return 1
+	}
+
+	return s.cfg.DigestBuildConcurrency
+}
+
+// runBounded calls fn for every index in [0, n) with at most limit calls in
+// flight and returns the first error. Callers store results by index, so the
+// output order does not depend on which call finishes first.
+func runBounded(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(limit, 1))
+
+	for i := range n {
+		g.Go(func() error {
+			return fn(gctx, i)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("bounded run: %w", err)
+	}
+
+	return nil
+}
+
+// preparedClusterSummary is a consolidated cluster summary generated before
+// rendering; ok is false when generation failed.
+type preparedClusterSummary struct {
+	summary string
+	ok      bool
+}
+
+// prepareClusterSummaries generates the consolidated summaries of the given
+// groups' multi-item clusters concurrently, so rendering, which stays
+// sequential and keeps its order, only looks them up.
+func (rc *digestRenderContext) prepareClusterSummaries(ctx context.Context, groups ...clusterGroup) {
+	if !rc.settings.consolidatedClustersEnabled || rc.llmClient == nil {
+		return
+	}
+
+	var clusters []db.ClusterWithItems
+
+	for _, g := range groups {
+		for _, c := range g.clusters {
+			if len(c.Items) > 1 {
+				clusters = append(clusters, c)
+			}
+		}
+	}
+
+	if len(clusters) < 2 {
+		return
+	}
+
+	// Load the summary cache up front; the workers only read it.
+	rc.loadClusterSummaryCache(ctx)
+
+	results := make([]preparedClusterSummary, len(clusters))
+
+	var done atomic.Int32
+
+	reportProgress(ctx, ProgressSummaries, 0, len(clusters))
+
+	if err := runBounded(ctx, len(clusters), rc.scheduler.buildConcurrency(), func(ctx context.Context, i int) error {
+		summary, ok := rc.consolidatedClusterSummary(ctx, clusters[i])
+		results[i] = preparedClusterSummary{summary: summary, ok: ok}
+
+		reportProgress(ctx, ProgressSummaries, int(done.Add(1)), len(clusters))
+
+		return nil
+	}); err != nil {
+		rc.logger.Warn().Err(err).Msg("failed to prepare cluster summaries")
+
+		return
+	}
+
+	rc.preparedSummaries = make(map[string]preparedClusterSummary, len(clusters))
+	for i, c := range clusters {
+		rc.preparedSummaries[clusterFingerprint(collectItemIDs(c.Items))] = results[i]
+	}
+}
+
+// preparedClusterSummary returns the summary prepared for a cluster's items;
+// prepared is false when the cluster was not prepared.
+func (rc *digestRenderContext) preparedClusterSummary(items []db.Item) (summary string, ok, prepared bool) {
+	if len(rc.preparedSummaries) == 0 {
+		return "", false, false
+	}
+
+	p, prepared := rc.preparedSummaries[clusterFingerprint(collectItemIDs(items))]
+
+	return p.summary, p.ok, prepared
+}
+
+// pendingCluster is a cluster found during clustering whose topic is not
+// generated yet.
+type pendingCluster struct {
+	items        []db.Item
+	defaultTopic string
+}
+
+// generateClusterTopics generates the topics of the pending clusters
+// concurrently; topics[i] belongs to pending[i].
+func (s *Scheduler) generateClusterTopics(ctx context.Context, pending []pendingCluster, digestLanguage string) []string {
+	topics := make([]string, len(pending))
+
+	var done atomic.Int32
+
+	// Topic generation never fails (it falls back to the default topic),
+	// so runBounded cannot return an error here.
+	_ = runBounded(ctx, len(pending), s.buildConcurrency(), func(ctx context.Context, i int) error {
+		topics[i] = s.generateClusterTopic(ctx, pending[i].items, pending[i].defaultTopic, digestLanguage)
+
+		reportProgress(ctx, ProgressClustering, int(done.Add(1)), len(pending))
+
+		return nil
+	})
+
+	return topics
+}
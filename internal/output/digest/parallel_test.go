@@ -0,0 +1,135 @@
+package digest
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestRunBoundedLimitsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+
+	results := make([]int, 10)
+
+	err := runBounded(context.Background(), len(results), 3, func(_ context.Context, i int) error {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+
+		results[i] = i * i
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("runBounded() error = %v", err)
+	}
+
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", peak.Load())
+	}
+
+	for i, r := range results {
+		if r != i*i {
+			t.Fatalf("results[%d] = %d, want %d", i, r, i*i)
+		}
+	}
+}
+
+type stubClusterSummaryClient struct {
+	llm.Client
+
+	calls atomic.Int32
+}
+
+func (c *stubClusterSummaryClient) SummarizeClusterWithEvidence(_ context.Context, items []domain.Item, _ llm.ItemEvidence, _, _, _ string) (string, error) {
+	c.calls.Add(1)
+
+	if items[0].ID == "fail" {
+		return "", nil
+	}
+
+	return "summary of " + items[0].ID, nil
+}
+
+func TestPrepareClusterSummaries(t *testing.T) {
+	logger := zerolog.Nop()
+	client := &stubClusterSummaryClient{}
+	rc := &digestRenderContext{
+		scheduler:                 &Scheduler{cfg: &config.Config{DigestBuildConcurrency: 2}},
+		llmClient:                 client,
+		settings:                  digestSettings{consolidatedClustersEnabled: true},
+		seenSummaries:             make(map[string]bool),
+		clusterSummaryCacheLoaded: true,
+		logger:                    &logger,
+	}
+
+	cluster := func(ids ...string) db.ClusterWithItems {
+		c := db.ClusterWithItems{Topic: "Tech"}
+		for _, id := range ids {
+			c.Items = append(c.Items, db.Item{ID: id, Summary: "item " + id, ImportanceScore: 0.5})
+		}
+
+		return c
+	}
+
+	groups := []clusterGroup{
+		{clusters: []db.ClusterWithItems{cluster("a", "b"), cluster("single")}},
+		{clusters: []db.ClusterWithItems{cluster("c", "d"), cluster("fail", "e")}},
+	}
+
+	var reported atomic.Int32
+
+	ctx := WithProgress(context.Background(), func(stage string, done, total int) {
+		if stage == ProgressSummaries && total == 3 && done > 0 {
+			reported.Add(1)
+		}
+	})
+
+	rc.prepareClusterSummaries(ctx, groups...)
+
+	if got := client.calls.Load(); got != 3 {
+		t.Fatalf("summarize calls = %d, want 3 (multi-item clusters only)", got)
+	}
+
+	if got := reported.Load(); got != 3 {
+		t.Errorf("summary progress reports = %d, want 3", got)
+	}
+
+	var sb strings.Builder
+	for _, g := range groups {
+		for _, c := range g.clusters {
+			if len(c.Items) > 1 {
+				rc.renderMultiItemCluster(context.Background(), &sb, c)
+			}
+		}
+	}
+
+	if got := client.calls.Load(); got != 3 {
+		t.Errorf("rendering summarized again: calls = %d, want 3", got)
+	}
+
+	out := sb.String()
+	if strings.Index(out, "summary of a") > strings.Index(out, "summary of c") || strings.Index(out, "summary of a") < 0 {
+		t.Errorf("summaries missing or out of order:\n%s", out)
+	}
+
+	if !strings.Contains(out, "item fail") {
+		t.Errorf("failed cluster should fall back to its representative item:\n%s", out)
+	}
+}
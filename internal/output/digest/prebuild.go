@@ -0,0 +1,230 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/schedule"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// SettingDigestPrebuildMinutes is how many minutes before a scheduled slot
+// the digest is pre-built; 0 disables pre-building.
+const SettingDigestPrebuildMinutes = "digest_prebuild_minutes"
+
+// prebuiltDigest is a digest rendered ahead of its slot.
+type prebuiltDigest struct {
+	start, end   time.Time
+	targetChatID int64
+	// fingerprint identifies the selected items the digest was rendered from.
+	fingerprint string
+	text        string
+	items       []db.Item
+	clusters    []db.ClusterWithItems
+	coverImage  []byte
+	builtAt     time.Time
+}
+
+func (pb *prebuiltDigest) matches(start, end time.Time, targetChatID int64) bool {
+	return pb != nil && pb.start.Equal(start) && pb.end.Equal(end) && pb.targetChatID == targetChatID
+}
+
+// prebuildCache holds the pre-built digest of the upcoming slot. Previews
+// build digests from the bot's goroutine, so access is locked.
+type prebuildCache struct {
+	mu      sync.Mutex
+	current *prebuiltDigest
+}
+
+// lookup returns the pre-built digest of the window if it was rendered from
+// exactly the given selection, i.e. no items arrived or dropped out since.
+func (c *prebuildCache) lookup(start, end time.Time, targetChatID int64, items []db.Item) (*prebuiltDigest, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.current.matches(start, end, targetChatID) || c.current.fingerprint != clusterFingerprint(collectItemIDs(items)) {
+		return nil, false
+	}
+
+	return c.current, true
+}
+
+func (c *prebuildCache) has(start, end time.Time, targetChatID int64) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.current.matches(start, end, targetChatID)
+}
+
+// coverImage returns the AI cover pre-generated for the window, if any.
+func (c *prebuildCache) coverImage(start, end time.Time) []byte {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current == nil || !c.current.start.Equal(start) || !c.current.end.Equal(end) {
+		return nil
+	}
+
+	return c.current.coverImage
+}
+
+func (c *prebuildCache) store(pb *prebuiltDigest) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.current = pb
+}
+
+// clear drops the pre-built digest once its window was posted.
+func (c *prebuildCache) clear(start, end time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current != nil && c.current.start.Equal(start) && c.current.end.Equal(end) {
+		c.current = nil
+	}
+}
+
+// prebuildWindow returns the window of the next scheduled slot if the slot
+// is due within lead. The window starts at the previous slot, like the one
+// processed at post time, limited to catchup before the slot.
+func prebuildWindow(sched schedule.Schedule, now time.Time, lead, catchup time.Duration) (scheduleWindow, bool, error) {
+	next, err := sched.NextTimes(now, 1)
+	if err != nil {
+		return scheduleWindow{}, false, fmt.Errorf("find next schedule time: %w", err)
+	}
+
+	if len(next) == 0 || next[0].Sub(now) > lead {
+		return scheduleWindow{}, false, nil
+	}
+
+	end := next[0]
+
+	start, ok, err := sched.PreviousTimeBefore(end)
+	if err != nil {
+		return scheduleWindow{}, false, fmt.Errorf("find previous schedule time: %w", err)
+	}
+
+	if minStart := end.Add(-catchup); !ok || start.Before(minStart) {
+		start = minStart
+	}
+
+	return scheduleWindow{start: start.UTC(), end: end.UTC()}, true, nil
+}
+
+// maybePrebuildDigest renders the digest of the next slot ahead of time when
+// the slot is within digest_prebuild_minutes. Item refinements, clusters and
+// cluster summaries are stored as a side effect, and the AI cover is kept
+// with the rendered text. At post time the pre-built digest is used as is
+// when the selection did not change; otherwise the digest is rebuilt with
+// the late items, reusing the stored work.
+func (s *Scheduler) maybePrebuildDigest(ctx context.Context, cfg digestProcessConfig, now time.Time, logger *zerolog.Logger) {
+	if cfg.schedule == nil || s.prebuilt == nil {
+		return
+	}
+
+	var minutes int
+	if err := s.database.GetSetting(ctx, SettingDigestPrebuildMinutes, &minutes); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_prebuild_minutes from DB")
+	}
+
+	if minutes <= 0 {
+		return
+	}
+
+	w, ok, err := prebuildWindow(*cfg.schedule, now, time.Duration(minutes)*time.Minute, cfg.catchupWindow)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to find next digest slot for pre-build")
+
+		return
+	}
+
+	if !ok || s.prebuilt.has(w.start, w.end, cfg.targetChatID) {
+		return
+	}
+
+	if exists, err := s.database.DigestExists(ctx, w.start, w.end); err != nil || exists {
+		return
+	}
+
+	pbLogger := logger.With().Str(LogFieldTask, "prebuild").Time(LogFieldStart, w.start).Time(LogFieldEnd, w.end).Logger()
+	began := time.Now()
+
+	sel, err := s.selectDigestItems(ctx, w.start, w.end, cfg.targetChatID, cfg.importanceThreshold, &pbLogger)
+	if err != nil {
+		pbLogger.Warn().Err(err).Msg("failed to select items for pre-build")
+
+		return
+	}
+
+	if len(sel.items) == 0 {
+		pbLogger.Debug().Msg("nothing to pre-build yet")
+
+		return
+	}
+
+	text, items, clusters, _, err := s.composeDigest(ctx, sel, w.start, w.end, cfg.importanceThreshold, &pbLogger)
+	if err != nil || text == "" {
+		pbLogger.Warn().Err(err).Msg("failed to pre-build digest")
+
+		return
+	}
+
+	s.prebuilt.store(&prebuiltDigest{
+		start:        w.start,
+		end:          w.end,
+		targetChatID: cfg.targetChatID,
+		fingerprint:  clusterFingerprint(collectItemIDs(sel.items)),
+		text:         text,
+		items:        items,
+		clusters:     clusters,
+		coverImage:   s.prebuildCoverImage(ctx, items, clusters, &pbLogger),
+		builtAt:      time.Now(),
+	})
+
+	pbLogger.Info().Int(LogFieldCount, len(sel.items)).Dur("took", time.Since(began)).Msg("Pre-built digest")
+}
+
+// prebuildCoverImage generates the AI cover ahead of time when the digest
+// will be posted with one.
+func (s *Scheduler) prebuildCoverImage(ctx context.Context, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) []byte {
+	var inlineImages, aiCover bool
+
+	if err := s.database.GetSetting(ctx, "digest_inline_images", &inlineImages); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_inline_images from DB")
+	}
+
+	if err := s.database.GetSetting(ctx, "digest_ai_cover", &aiCover); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_ai_cover from DB")
+	}
+
+	if inlineImages || !aiCover {
+		return nil
+	}
+
+	return s.generateAICover(ctx, items, clusters, logger)
+}
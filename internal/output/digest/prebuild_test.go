@@ -0,0 +1,69 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/schedule"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestPrebuildWindow(t *testing.T) {
+	day := schedule.DaySchedule{Times: []string{"09:00", "13:00", "18:00"}}
+	sched := schedule.Schedule{Timezone: "UTC", Weekdays: day, Weekends: day}
+	now := time.Date(2026, 3, 2, 12, 45, 0, 0, time.UTC)
+
+	w, ok, err := prebuildWindow(sched, now, 20*time.Minute, 24*time.Hour)
+	if err != nil || !ok {
+		t.Fatalf("prebuildWindow() = %v, %v, %v", w, ok, err)
+	}
+
+	if !w.start.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) || !w.end.Equal(time.Date(2026, 3, 2, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("window = %v – %v, want 09:00 – 13:00", w.start, w.end)
+	}
+
+	if _, ok, _ := prebuildWindow(sched, now, 10*time.Minute, 24*time.Hour); ok {
+		t.Error("slot 15 minutes away must not be pre-built with a 10 minute lead")
+	}
+
+	w, _, _ = prebuildWindow(sched, now, 20*time.Minute, time.Hour)
+	if !w.start.Equal(time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("start = %v, want limited to the catch-up window", w.start)
+	}
+}
+
+func TestPrebuildCacheLookup(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+	items := []db.Item{{ID: "b"}, {ID: "a"}}
+
+	var cache prebuildCache
+
+	cache.store(&prebuiltDigest{
+		start: start, end: end, targetChatID: 42,
+		fingerprint: clusterFingerprint(collectItemIDs(items)),
+		text:        "digest", coverImage: []byte{1},
+	})
+
+	if pb, ok := cache.lookup(start, end, 42, []db.Item{{ID: "a"}, {ID: "b"}}); !ok || pb.text != "digest" {
+		t.Fatalf("lookup with the same selection = %v, %v", pb, ok)
+	}
+
+	if _, ok := cache.lookup(start, end, 42, append(items, db.Item{ID: "late"})); ok {
+		t.Error("lookup must miss when a late item joined the selection")
+	}
+
+	if _, ok := cache.lookup(start, end, 7, items); ok {
+		t.Error("lookup must miss for another target chat")
+	}
+
+	if len(cache.coverImage(start, end)) != 1 {
+		t.Error("cover image of the pre-built window not returned")
+	}
+
+	cache.clear(start, end)
+
+	if cache.has(start, end, 42) {
+		t.Error("pre-built digest kept after clear")
+	}
+}
@@ -0,0 +1,32 @@
+package digest
+
+import "context"
+
+// Digest build stages reported to a ProgressFunc.
+const (
+	ProgressSelecting  = "selecting"
+	ProgressRefining   = "refining"
+	ProgressClustering = "clustering"
+	ProgressRendering  = "rendering"
+	ProgressSummaries  = "summaries"
+)
+
+// ProgressFunc receives digest build progress: the current stage and, for
+// stages made of several LLM calls, how many of total are done (total is 0
+// otherwise). Concurrent build steps may call it from several goroutines.
+type ProgressFunc func(stage string, done, total int)
+
+type progressKey struct{}
+
+// WithProgress returns a context whose digest builds report their progress
+// to fn. It is used by previews; scheduled digests do not report progress.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress reports a build stage to the context's ProgressFunc, if any.
+func reportProgress(ctx context.Context, stage string, done, total int) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(stage, done, total)
+	}
+}
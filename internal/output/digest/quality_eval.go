@@ -0,0 +1,90 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingQualityEvalEnabled toggles the daily continuous evaluation.
+	SettingQualityEvalEnabled = "quality_eval_enabled"
+
+	// qualityEvalInterval is how often ratings are compared with live scores.
+	qualityEvalInterval = 24 * time.Hour
+	// QualityEvalWindow is the rolling window of rated items per evaluation.
+	QualityEvalWindow = 7 * 24 * time.Hour
+)
+
+// maybeRunQualityEvaluation records a quality evaluation once a day.
+func (s *Scheduler) maybeRunQualityEvaluation(ctx context.Context, lastRun *time.Time) {
+	enabled := true
+	if err := s.database.GetSetting(ctx, SettingQualityEvalEnabled, &enabled); err != nil {
+		s.logger.Debug().Err(err).Msg("quality_eval_enabled not set, defaulting to true")
+	}
+
+	now := time.Now()
+	if !enabled || (!lastRun.IsZero() && now.Sub(*lastRun) < qualityEvalInterval) {
+		return
+	}
+
+	logger := s.logger.With().Str(LogFieldTask, "quality-eval").Logger()
+
+	if err := s.RunQualityEvaluation(ctx, now, &logger); err != nil {
+		logger.Error().Err(err).Msg("failed to run quality evaluation")
+
+		return
+	}
+
+	*lastRun = now
+}
+
+// RunQualityEvaluation compares the ratings of items scored in the last
+// QualityEvalWindow with the current thresholds and stores the resulting
+// precision, recall and noise rate.
+func (s *Scheduler) RunQualityEvaluation(ctx context.Context, now time.Time, logger *zerolog.Logger) error {
+	thresholds := db.WhatIfThresholds{Relevance: s.cfg.RelevanceThreshold, Importance: s.cfg.ImportanceThreshold}
+
+	if err := s.database.GetSetting(ctx, SettingRelevanceThreshold, &thresholds.Relevance); err != nil {
+		logger.Debug().Err(err).Msg("could not get relevance_threshold from DB")
+	}
+
+	if err := s.database.GetSetting(ctx, SettingImportanceThreshold, &thresholds.Importance); err != nil {
+		logger.Debug().Err(err).Msg("could not get importance_threshold from DB")
+	}
+
+	since := now.Add(-QualityEvalWindow)
+
+	items, err := s.database.GetWhatIfItems(ctx, since, now)
+	if err != nil {
+		return fmt.Errorf("get rated items: %w", err)
+	}
+
+	eval := db.EvaluateQuality(items, thresholds)
+	if eval.Rated == 0 {
+		logger.Info().Msg("no rated items, skipping quality evaluation")
+
+		return nil
+	}
+
+	eval.EvaluatedAt = now
+	eval.WindowStart = since
+	eval.WindowEnd = now
+
+	if err := s.database.InsertQualityEvaluation(ctx, &eval); err != nil {
+		return fmt.Errorf("save quality evaluation: %w", err)
+	}
+
+	logger.Info().
+		Int(LogFieldRatingCount, eval.Rated).
+		Float64("precision", eval.Precision).
+		Float64("recall", eval.Recall).
+		Float64("noise_rate", eval.NoiseRate).
+		Msg("Recorded quality evaluation")
+
+	return nil
+}
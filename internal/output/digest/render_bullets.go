@@ -266,6 +266,7 @@ func (rc *digestRenderContext) formatBulletGroup(sb *strings.Builder, g bulletGr
 
 	// Write compact topic header for mobile-friendly output
 	sb.WriteString(htmlutils.ItemStart)
+	sb.WriteString(rc.topicAnchor(g.topic))
 	fmt.Fprintf(sb, "%s <b>%s</b> (%d)\n", emoji, strings.ToUpper(html.EscapeString(g.topic)), len(g.bullets))
 
 	// Write each bullet
@@ -280,7 +281,7 @@ func (rc *digestRenderContext) formatBulletGroup(sb *strings.Builder, g bulletGr
 // formatSingleBullet formats a single bullet point with optional expanded view link.
 func (rc *digestRenderContext) formatSingleBullet(sb *strings.Builder, b db.BulletForDigest) {
 	prefix := getImportancePrefix(b.ImportanceScore)
-	sanitizedText := htmlutils.SanitizeHTML(b.Text)
+	sanitizedText := rc.safetyText(htmlutils.SanitizeHTML(b.Text), b.ItemID)
 
 	sb.WriteString(prefix)
 	sb.WriteString(" ")
@@ -311,6 +312,10 @@ func (rc *digestRenderContext) formatSingleBullet(sb *strings.Builder, b db.Bull
 		}
 	}
 
+	if link := rc.itemDetailsURL(b.ItemID); link != "" {
+		sourceParts = append(sourceParts, fmt.Sprintf("<a href=\"%s\">🔎</a>", html.EscapeString(link)))
+	}
+
 	if len(sourceParts) > 0 {
 		sb.WriteString("\n    ↳ ")
 		sb.WriteString(strings.Join(sourceParts, " "))
@@ -0,0 +1,102 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	upcomingBlockMaxEvents = 5
+	upcomingBlockEmoji     = "📅"
+	upcomingDateLayout     = "02.01"
+	upcomingTimeLayout     = "02.01 15:04"
+)
+
+// getUpcomingBlockTitle returns the localized upcoming events block title.
+func (rc *digestRenderContext) getUpcomingBlockTitle() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "Скоро"
+	case "de":
+		return "Demnächst"
+	case "es":
+		return "Próximamente"
+	case "fr":
+		return "À venir"
+	case "it":
+		return "Prossimamente"
+	}
+
+	return "Upcoming"
+}
+
+// buildUpcomingBlock renders the announced events starting within
+// CALENDAR_UPCOMING_DAYS after the digest window.
+func (rc *digestRenderContext) buildUpcomingBlock(ctx context.Context, sb *strings.Builder) {
+	if !rc.settings.upcomingBlockEnabled || rc.scheduler.cfg.CalendarUpcomingDays <= 0 {
+		return
+	}
+
+	events, err := rc.scheduler.database.GetCalendarEvents(ctx, rc.end, rc.end.AddDate(0, 0, rc.scheduler.cfg.CalendarUpcomingDays), upcomingBlockMaxEvents)
+	if err != nil {
+		rc.logger.Warn().Err(err).Msg("failed to load calendar events")
+
+		return
+	}
+
+	if len(events) == 0 {
+		return
+	}
+
+	loc, err := time.LoadLocation(rc.scheduler.cfg.CalendarTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	fmt.Fprintf(sb, FormatSectionHeader, upcomingBlockEmoji, rc.getUpcomingBlockTitle())
+
+	for _, e := range events {
+		source := db.Item{
+			ID:              e.ItemID,
+			SourceChannel:   e.ChannelUsername,
+			SourceChannelID: e.ChannelPeerID,
+			SourceMsgID:     e.MessageID,
+		}
+		links := rc.collectSourceLinks([]db.Item{source})
+		fmt.Fprintf(sb, "• %s <i>via %s</i>\n", formatUpcomingEvent(e, loc), strings.Join(links, DigestSourceSeparator))
+	}
+}
+
+// formatUpcomingEvent renders the date, title and location of an event.
+func formatUpcomingEvent(e db.CalendarEvent, loc *time.Location) string {
+	layout := upcomingTimeLayout
+	if e.AllDay {
+		layout = upcomingDateLayout
+	}
+
+	when := e.StartsAt.In(loc).Format(layout)
+	if e.EndsAt != nil && e.EndsAt.After(e.StartsAt) {
+		end := e.EndsAt.In(loc)
+
+		switch {
+		case e.AllDay:
+			when += "–" + end.Format(upcomingDateLayout)
+		case end.Format(upcomingDateLayout) == e.StartsAt.In(loc).Format(upcomingDateLayout):
+			when += "–" + end.Format("15:04")
+		default:
+			when += "–" + end.Format(upcomingTimeLayout)
+		}
+	}
+
+	line := fmt.Sprintf("<b>%s</b> %s", when, html.EscapeString(e.Title))
+	if e.Location != "" {
+		line += " (" + html.EscapeString(e.Location) + ")"
+	}
+
+	return line
+}
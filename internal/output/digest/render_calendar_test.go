@@ -0,0 +1,45 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestFormatUpcomingEvent(t *testing.T) {
+	loc := time.FixedZone("CET", 60*60)
+	start := time.Date(2026, 3, 5, 13, 0, 0, 0, time.UTC)
+	sameDay := time.Date(2026, 3, 5, 17, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2026, 3, 6, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event db.CalendarEvent
+		want  string
+	}{
+		{
+			name:  "timed with location",
+			event: db.CalendarEvent{Title: "EU <summit>", StartsAt: start, EndsAt: &sameDay, Location: "Brussels"},
+			want:  "<b>05.03 14:00–18:00</b> EU &lt;summit&gt; (Brussels)",
+		},
+		{
+			name:  "ends another day",
+			event: db.CalendarEvent{Title: "Hackathon", StartsAt: start, EndsAt: &nextDay},
+			want:  "<b>05.03 14:00–07.03 00:30</b> Hackathon",
+		},
+		{
+			name:  "all day",
+			event: db.CalendarEvent{Title: "Tax deadline", StartsAt: time.Date(2026, 4, 14, 23, 0, 0, 0, time.UTC), AllDay: true},
+			want:  "<b>15.04</b> Tax deadline",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatUpcomingEvent(tt.event, loc); got != tt.want {
+				t.Errorf("formatUpcomingEvent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
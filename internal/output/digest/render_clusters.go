@@ -21,25 +21,13 @@ func (rc *digestRenderContext) renderMultiItemCluster(ctx context.Context, sb *s
 
 // renderConsolidatedCluster renders a cluster with an LLM-generated summary.
 func (rc *digestRenderContext) renderConsolidatedCluster(ctx context.Context, sb *strings.Builder, c db.ClusterWithItems) bool {
-	summary, ok := rc.findCachedClusterSummary(ctx, c.Items)
-	if !ok {
-		// Pass empty model to let the LLM registry handle task-specific model selection
-		// via LLM_CLUSTER_MODEL env var or default task config
-		evidence := rc.convertEvidenceForLLM(c.Items)
-		generated, err := rc.llmClient.SummarizeClusterWithEvidence(ctx, c.Items, evidence, rc.settings.digestLanguage, "", rc.settings.digestTone)
-
-		if err != nil || generated == "" {
-			if err != nil {
-				rc.logger.Warn().Err(err).Str("cluster", c.Topic).Msg("failed to summarize cluster, falling back to detailed list")
-			}
-
-			return rc.renderRepresentativeCluster(sb, c)
-		}
+	summary, ok, prepared := rc.preparedClusterSummary(c.Items)
+	if !prepared {
+		summary, ok = rc.consolidatedClusterSummary(ctx, c)
+	}
 
-		summary = htmlutils.SanitizeHTML(generated)
-		rc.storeClusterSummaryCache(ctx, c.Items, summary)
-	} else {
-		summary = htmlutils.SanitizeHTML(summary)
+	if !ok {
+		return rc.renderRepresentativeCluster(sb, c)
 	}
 
 	if rc.seenSummaries[summary] {
@@ -53,9 +41,49 @@ func (rc *digestRenderContext) renderConsolidatedCluster(ctx context.Context, sb
 	return true
 }
 
+// consolidatedClusterSummary returns the summary to render for a cluster:
+// the full summary, or only what is new for a cluster that continues an
+// earlier posted story. It reports false when no summary could be generated.
+func (rc *digestRenderContext) consolidatedClusterSummary(ctx context.Context, c db.ClusterWithItems) (string, bool) {
+	summary, ok := rc.fullClusterSummary(ctx, c)
+	if !ok {
+		return "", false
+	}
+
+	return rc.whatsNewClusterSummary(ctx, c, summary), true
+}
+
+// fullClusterSummary returns the sanitized summary of a cluster from the
+// summary cache, generating and caching it if needed. It reports false when
+// the summary could not be generated.
+func (rc *digestRenderContext) fullClusterSummary(ctx context.Context, c db.ClusterWithItems) (string, bool) {
+	if summary, ok := rc.findCachedClusterSummary(ctx, c.Items); ok {
+		return htmlutils.SanitizeHTML(summary), true
+	}
+
+	// An empty model (all but shadow digests) lets the LLM registry handle task-specific
+	// model selection via LLM_CLUSTER_MODEL env var or default task config
+	evidence := rc.convertEvidenceForLLM(c.Items)
+	generated, err := rc.llmClient.SummarizeClusterWithEvidence(ctx, c.Items, evidence, rc.settings.digestLanguage, rc.settings.clusterModel, rc.llmTone())
+
+	if err != nil || generated == "" {
+		if err != nil {
+			rc.logger.Warn().Err(err).Str("cluster", c.Topic).Msg("failed to summarize cluster, falling back to detailed list")
+		}
+
+		return "", false
+	}
+
+	summary := htmlutils.SanitizeHTML(generated)
+	rc.storeClusterSummaryCache(ctx, c.Items, summary)
+
+	return summary, true
+}
+
 // renderConsolidatedSummary writes a consolidated cluster summary to the builder.
 func (rc *digestRenderContext) renderConsolidatedSummary(sb *strings.Builder, summary string, c db.ClusterWithItems) {
 	sb.WriteString(htmlutils.ItemStart)
+	sb.WriteString(rc.topicAnchor(c.Topic))
 
 	if c.Topic != "" {
 		emoji := topicEmojis[c.Topic]
@@ -68,9 +96,9 @@ func (rc *digestRenderContext) renderConsolidatedSummary(sb *strings.Builder, su
 		sb.WriteString(DigestTopicBorderBot)
 	}
 
-	fmt.Fprintf(sb, FormatPrefixSummary, getImportancePrefix(c.Items[0].ImportanceScore), summary)
+	fmt.Fprintf(sb, FormatPrefixSummary, getImportancePrefix(c.Items[0].ImportanceScore), rc.safetyText(summary, collectItemIDs(c.Items)...))
 
-	links := rc.collectSourceLinks(c.Items)
+	links := rc.collectAttributedSourceLinks(c.Items)
 	if len(links) > 0 {
 		fmt.Fprintf(sb, " <i>via %s</i>", strings.Join(links, DigestSourceSeparator))
 	}
@@ -81,7 +109,7 @@ func (rc *digestRenderContext) renderConsolidatedSummary(sb *strings.Builder, su
 		}
 	}
 
-	if line := rc.scheduler.buildCorroborationLine(c.Items, c.Items[0]); line != "" {
+	if line := rc.buildCorroborationLine(c.Items); line != "" {
 		sb.WriteString(line)
 	}
 
@@ -92,6 +120,7 @@ func (rc *digestRenderContext) renderConsolidatedSummary(sb *strings.Builder, su
 	// Add expand link for the first (representative) item
 	if len(c.Items) > 0 {
 		rc.appendExpandLink(sb, c.Items[0].ID)
+		rc.appendItemDetailsLink(sb, c.Items[0].ID)
 	}
 
 	sb.WriteString(htmlutils.ItemEnd)
@@ -113,15 +142,16 @@ func (rc *digestRenderContext) renderRepresentativeCluster(sb *strings.Builder,
 	rc.seenSummaries[representative.Summary] = true
 
 	sb.WriteString(htmlutils.ItemStart)
+	sb.WriteString(rc.topicAnchor(c.Topic))
 	sb.WriteString(DigestTopicBorderTop)
 	fmt.Fprintf(sb, "│ %s <b>%s</b>\n", emoji, strings.ToUpper(html.EscapeString(c.Topic)))
 	sb.WriteString(DigestTopicBorderBot)
 
-	sanitizedSummary := htmlutils.SanitizeHTML(representative.Summary)
+	sanitizedSummary := rc.safetyText(htmlutils.SanitizeHTML(representative.Summary), collectItemIDs(c.Items)...)
 	prefix := getImportancePrefix(representative.ImportanceScore)
 	fmt.Fprintf(sb, FormatPrefixSummary, prefix, sanitizedSummary)
 
-	links := rc.collectSourceLinks(c.Items)
+	links := rc.collectAttributedSourceLinks(c.Items)
 	if len(links) > 0 {
 		fmt.Fprintf(sb, DigestSourceVia, strings.Join(links, DigestSourceSeparator))
 	}
@@ -132,7 +162,7 @@ func (rc *digestRenderContext) renderRepresentativeCluster(sb *strings.Builder,
 		}
 	}
 
-	if line := rc.scheduler.buildCorroborationLine(c.Items, representative); line != "" {
+	if line := rc.buildCorroborationLine(c.Items); line != "" {
 		sb.WriteString(line)
 	}
 
@@ -146,6 +176,7 @@ func (rc *digestRenderContext) renderRepresentativeCluster(sb *strings.Builder,
 
 	// Add expand link for the representative item
 	rc.appendExpandLink(sb, representative.ID)
+	rc.appendItemDetailsLink(sb, representative.ID)
 
 	sb.WriteString(htmlutils.ItemEnd)
 	sb.WriteString("\n\n")
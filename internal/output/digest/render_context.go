@@ -11,6 +11,11 @@ import (
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
+// settingsReader reads bot settings.
+type settingsReader interface {
+	GetSetting(ctx context.Context, key string, target interface{}) error
+}
+
 // clusterGroup groups clusters or items by importance level.
 type clusterGroup struct {
 	clusters []db.ClusterWithItems
@@ -33,9 +38,16 @@ type digestRenderContext struct {
 	evidence                  map[string][]db.ItemEvidenceWithSource
 	clusterSummaryCache       []db.ClusterSummaryCacheEntry
 	clusterSummaryCacheLoaded bool
+	preparedSummaries         map[string]preparedClusterSummary
 	expandLinksEnabled        bool
 	expandBaseURL             string
+	itemLinks                 map[string]string
+	itemLinkPrefix            string
+	clickLinks                map[string]string
+	clickLinkPrefix           string
+	toc                       *tocIndex
 	lowReliability            lowReliabilityIndex
+	flaggedItems              map[string]bool
 	logger                    *zerolog.Logger
 }
 
@@ -51,6 +63,8 @@ func (s *Scheduler) newRenderContext(ctx context.Context, settings digestSetting
 	// Check if expanded view links are enabled (requires signing secret and base URL)
 	expandLinksEnabled := s.expandLinkGenerator != nil && s.cfg.ExpandedViewBaseURL != ""
 	lowReliability := s.loadLowReliabilityIndex(ctx, logger)
+	itemLinks, itemLinkPrefix := s.loadItemDetailLinks(ctx, settings.itemLinksMode, items, clusters, logger)
+	clickLinks, clickLinkPrefix := s.loadClickLinks(ctx, settings.clickTrackingEnabled, items, clusters, logger)
 
 	return &digestRenderContext{
 		scheduler:          s,
@@ -67,14 +81,25 @@ func (s *Scheduler) newRenderContext(ctx context.Context, settings digestSetting
 		evidence:           evidence,
 		expandLinksEnabled: expandLinksEnabled,
 		expandBaseURL:      s.cfg.ExpandedViewBaseURL,
+		itemLinks:          itemLinks,
+		itemLinkPrefix:     itemLinkPrefix,
+		clickLinks:         clickLinks,
+		clickLinkPrefix:    clickLinkPrefix,
+		toc:                newTOCIndex(items, settings.tocMinTopics),
 		lowReliability:     lowReliability,
+		flaggedItems:       flaggedItemIDs(items, settings.previouslyMissed),
 		logger:             logger,
 	}
 }
 
 func (s *Scheduler) resolveScheduleLocation(ctx context.Context, logger *zerolog.Logger) (*time.Location, bool) {
+	return scheduleLocation(ctx, s.database, logger)
+}
+
+// scheduleLocation returns the timezone of the configured digest schedule.
+func scheduleLocation(ctx context.Context, store settingsReader, logger *zerolog.Logger) (*time.Location, bool) {
 	var sched schedule.Schedule
-	if err := s.database.GetSetting(ctx, schedule.SettingDigestSchedule, &sched); err != nil {
+	if err := store.GetSetting(ctx, schedule.SettingDigestSchedule, &sched); err != nil {
 		logger.Debug().Err(err).Msg("could not get digest_schedule for timezone")
 		return nil, false
 	}
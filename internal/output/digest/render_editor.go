@@ -0,0 +1,169 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+)
+
+var editorSectionEmojis = map[string]string{
+	llm.EditorSectionLede:            "🔥",
+	llm.EditorSectionKeyDevelopments: "📌",
+	llm.EditorSectionWatchlist:       "🔮",
+	llm.EditorSectionNumbers:         "📊",
+}
+
+// editorSectionTitles holds localized editor section titles, keyed by language.
+var editorSectionTitles = map[string]map[string]string{
+	"en": {
+		llm.EditorSectionLede:            "Main",
+		llm.EditorSectionKeyDevelopments: "Key developments",
+		llm.EditorSectionWatchlist:       "Watch",
+		llm.EditorSectionNumbers:         "Numbers of the day",
+	},
+	"ru": {
+		llm.EditorSectionLede:            "Главное",
+		llm.EditorSectionKeyDevelopments: "Важно",
+		llm.EditorSectionWatchlist:       "Следим за",
+		llm.EditorSectionNumbers:         "Цифры дня",
+	},
+	"de": {
+		llm.EditorSectionLede:            "Das Wichtigste",
+		llm.EditorSectionKeyDevelopments: "Entwicklungen",
+		llm.EditorSectionWatchlist:       "Im Blick",
+		llm.EditorSectionNumbers:         "Zahlen des Tages",
+	},
+	"es": {
+		llm.EditorSectionLede:            "Lo principal",
+		llm.EditorSectionKeyDevelopments: "Novedades clave",
+		llm.EditorSectionWatchlist:       "A seguir",
+		llm.EditorSectionNumbers:         "Cifras del día",
+	},
+	"fr": {
+		llm.EditorSectionLede:            "L'essentiel",
+		llm.EditorSectionKeyDevelopments: "Faits marquants",
+		llm.EditorSectionWatchlist:       "À suivre",
+		llm.EditorSectionNumbers:         "Les chiffres du jour",
+	},
+	"it": {
+		llm.EditorSectionLede:            "In primo piano",
+		llm.EditorSectionKeyDevelopments: "Sviluppi chiave",
+		llm.EditorSectionWatchlist:       "Da seguire",
+		llm.EditorSectionNumbers:         "I numeri del giorno",
+	},
+}
+
+// NormalizeEditorSections returns the known sections from sections in render
+// order, without duplicates.
+func NormalizeEditorSections(sections []string) []string {
+	enabled := make(map[string]bool, len(sections))
+	for _, s := range sections {
+		enabled[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+
+	normalized := make([]string, 0, len(llm.EditorSections))
+
+	for _, s := range llm.EditorSections {
+		if enabled[s] {
+			normalized = append(normalized, s)
+		}
+	}
+
+	return normalized
+}
+
+// editorSectionPlan returns the enabled editor sections. An unset setting
+// enables every section.
+func (rc *digestRenderContext) editorSectionPlan() []string {
+	if rc.settings.editorSections == nil {
+		return llm.EditorSections
+	}
+
+	return NormalizeEditorSections(rc.settings.editorSections)
+}
+
+// generateEditorPlan asks the LLM for the structured editor overview and
+// renders the enabled sections. It reports false when no sections are enabled
+// or the response is invalid, so the caller can fall back to the free-form narrative.
+func (rc *digestRenderContext) generateEditorPlan(ctx context.Context) (string, bool) {
+	sections := rc.editorSectionPlan()
+	if len(sections) == 0 {
+		return "", false
+	}
+
+	prompt := llm.BuildEditorPlanPrompt(rc.items, rc.convertEvidenceForLLM(rc.items), rc.settings.digestLanguage, rc.llmTone(), sections)
+
+	// Pass empty model to let the LLM registry handle task-specific model selection
+	response, err := rc.llmClient.CompleteText(ctx, prompt, "")
+	if err != nil {
+		rc.logger.Warn().Err(err).Msg("editor section plan generation failed")
+
+		return "", false
+	}
+
+	plan, err := llm.ParseEditorPlan(response, sections)
+	if err != nil {
+		rc.logger.Warn().Err(err).Msg("invalid editor section plan, falling back to narrative")
+
+		return "", false
+	}
+
+	return renderEditorPlan(plan, sections, rc.settings.digestLanguage), true
+}
+
+// renderEditorPlan renders the plan's non-empty sections in plan order.
+func renderEditorPlan(plan llm.EditorPlan, sections []string, language string) string {
+	titles, ok := editorSectionTitles[strings.ToLower(language)]
+	if !ok {
+		titles = editorSectionTitles["en"]
+	}
+
+	blocks := make([]string, 0, len(sections))
+
+	for _, section := range sections {
+		body := editorSectionBody(plan, section)
+		if body == "" {
+			continue
+		}
+
+		blocks = append(blocks, fmt.Sprintf("%s <b>%s</b>\n%s", editorSectionEmojis[section], titles[section], body))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+func editorSectionBody(plan llm.EditorPlan, section string) string {
+	switch section {
+	case llm.EditorSectionLede:
+		return plan.Lede
+	case llm.EditorSectionKeyDevelopments:
+		return editorBullets(plan.KeyDevelopments)
+	case llm.EditorSectionWatchlist:
+		return editorBullets(plan.Watchlist)
+	case llm.EditorSectionNumbers:
+		lines := make([]string, 0, len(plan.Numbers))
+
+		for _, n := range plan.Numbers {
+			line := "<b>" + n.Value + "</b>"
+			if n.Context != "" {
+				line += " — " + n.Context
+			}
+
+			lines = append(lines, line)
+		}
+
+		return editorBullets(lines)
+	}
+
+	return ""
+}
+
+func editorBullets(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return "• " + strings.Join(lines, "\n• ")
+}
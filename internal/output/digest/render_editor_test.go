@@ -0,0 +1,50 @@
+package digest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+)
+
+func TestNormalizeEditorSections(t *testing.T) {
+	got := NormalizeEditorSections([]string{"numbers", "bogus", " Lede ", "numbers"})
+	want := []string{llm.EditorSectionLede, llm.EditorSectionNumbers}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeEditorSections() = %v, want %v", got, want)
+	}
+}
+
+func TestEditorSectionPlan(t *testing.T) {
+	rc := &digestRenderContext{}
+	if got := rc.editorSectionPlan(); !reflect.DeepEqual(got, llm.EditorSections) {
+		t.Errorf("unset plan = %v, want all sections", got)
+	}
+
+	rc.settings.editorSections = []string{}
+	if got := rc.editorSectionPlan(); len(got) != 0 {
+		t.Errorf("empty plan = %v, want no sections", got)
+	}
+}
+
+func TestRenderEditorPlan(t *testing.T) {
+	plan := llm.EditorPlan{
+		Lede:      "Rates were cut.",
+		Watchlist: []string{"March meeting", "Bond yields"},
+		Numbers:   []llm.EditorNumber{{Value: "0.25%", Context: "cut size"}},
+	}
+
+	got := renderEditorPlan(plan, llm.EditorSections, "ru")
+	want := "🔥 <b>Главное</b>\nRates were cut.\n\n" +
+		"🔮 <b>Следим за</b>\n• March meeting\n• Bond yields\n\n" +
+		"📊 <b>Цифры дня</b>\n• <b>0.25%</b> — cut size"
+
+	if got != want {
+		t.Errorf("renderEditorPlan() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := renderEditorPlan(plan, []string{llm.EditorSectionLede}, "xx"); got != "🔥 <b>Main</b>\nRates were cut." {
+		t.Errorf("unknown language should fall back to English titles, got %q", got)
+	}
+}
@@ -6,17 +6,26 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
 	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
 // appendEvidenceLine appends evidence sources to the builder.
 func (rc *digestRenderContext) appendEvidenceLine(sb *strings.Builder, items []db.Item) {
+	if rc.isCompact() {
+		return
+	}
+
 	evidenceList := findEvidenceForItems(items, rc.evidence)
 	if len(evidenceList) == 0 {
 		return
 	}
 
+	if rc.settings.stanceBadgesEnabled {
+		sb.WriteString(formatStanceBadge(db.EvidenceVerdict(evidenceList)))
+	}
+
 	evidenceList = filterEvidenceForDisplay(evidenceList, rc.evidenceDisplayMinAgreement())
 	if len(evidenceList) == 0 {
 		return
@@ -245,6 +254,25 @@ func formatConfidenceTier(tier string, sourceCount int) string {
 	return fmt.Sprintf("\n    ↳ <i>%s Corroborated (%d sources)</i>", emoji, sourceCount)
 }
 
+// formatStanceBadge formats the evidence verdict badge line.
+// Unverified and unknown verdicts produce no badge.
+func formatStanceBadge(verdict string) string {
+	var badge string
+
+	switch verdict {
+	case domain.VerdictSupported:
+		badge = "🟢 Supported by sources"
+	case domain.VerdictRefuted:
+		badge = "🔴 Refuted by sources"
+	case domain.VerdictDisputed:
+		badge = "🟠 Disputed by sources"
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf("\n    ↳ <i>%s</i>", badge)
+}
+
 // findEvidenceForItems finds evidence for a list of items.
 func findEvidenceForItems(items []db.Item, evidence map[string][]db.ItemEvidenceWithSource) []db.ItemEvidenceWithSource {
 	if evidence == nil {
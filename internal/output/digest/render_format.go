@@ -0,0 +1,33 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/markup"
+)
+
+// FormatTargetKeyFmt overrides digest_format for a single target chat.
+const FormatTargetKeyFmt = SettingDigestFormat + ":%d"
+
+// LoadOutputFormat resolves the output mode for a target chat: the per-target
+// override first, then the global setting, then HTML.
+func LoadOutputFormat(ctx context.Context, store settingsReader, targetChatID int64) markup.Format {
+	var name string
+
+	if targetChatID != 0 {
+		if err := store.GetSetting(ctx, fmt.Sprintf(FormatTargetKeyFmt, targetChatID), &name); err == nil {
+			if format, ok := markup.ParseFormat(name); ok {
+				return format
+			}
+		}
+	}
+
+	if err := store.GetSetting(ctx, SettingDigestFormat, &name); err == nil {
+		if format, ok := markup.ParseFormat(name); ok {
+			return format
+		}
+	}
+
+	return markup.FormatHTML
+}
@@ -97,17 +97,12 @@ func (rc *digestRenderContext) generateNarrative(ctx context.Context, sb *string
 		return false
 	}
 
-	evidence := rc.convertEvidenceForLLM(rc.items)
-
-	// Pass empty model to let the LLM registry handle task-specific model selection
-	// via LLM_NARRATIVE_MODEL env var or default task config
-	narrative, err := rc.llmClient.GenerateNarrativeWithEvidence(ctx, rc.items, evidence, rc.settings.digestLanguage, "", rc.settings.digestTone)
-	if err != nil {
-		rc.logger.Warn().Err(err).Msg("Editor-in-Chief narrative generation failed")
-		return false
+	narrative, ok := rc.generateEditorPlan(ctx)
+	if !ok {
+		narrative, ok = rc.generateFreeformNarrative(ctx)
 	}
 
-	if narrative == "" {
+	if !ok {
 		return false
 	}
 
@@ -123,6 +118,22 @@ func (rc *digestRenderContext) generateNarrative(ctx context.Context, sb *string
 	return true
 }
 
+// generateFreeformNarrative generates the single-blob editor narrative used
+// when the section plan is disabled or invalid.
+func (rc *digestRenderContext) generateFreeformNarrative(ctx context.Context) (string, bool) {
+	evidence := rc.convertEvidenceForLLM(rc.items)
+
+	// An empty model (all but shadow digests) lets the LLM registry handle task-specific
+	// model selection via LLM_NARRATIVE_MODEL env var or default task config
+	narrative, err := rc.llmClient.GenerateNarrativeWithEvidence(ctx, rc.items, evidence, rc.settings.digestLanguage, rc.settings.narrativeModel, rc.llmTone())
+	if err != nil {
+		rc.logger.Warn().Err(err).Msg("Editor-in-Chief narrative generation failed")
+		return "", false
+	}
+
+	return narrative, narrative != ""
+}
+
 // renderGroup renders a group of items or clusters.
 func (rc *digestRenderContext) renderGroup(ctx context.Context, sb *strings.Builder, group clusterGroup, emoji, title string) {
 	if len(group.clusters) == 0 && len(group.items) == 0 {
@@ -174,10 +185,10 @@ func (rc *digestRenderContext) renderOthersAsNarrative(ctx context.Context, sb *
 	narrative, ok := rc.findCachedClusterSummary(ctx, allItems)
 	if !ok {
 		// Generate narrative for "others" items with evidence context
-		// Pass empty model to let the LLM registry handle task-specific model selection
-		// via LLM_CLUSTER_MODEL env var or default task config
+		// An empty model (all but shadow digests) lets the LLM registry handle task-specific
+		// model selection via LLM_CLUSTER_MODEL env var or default task config
 		evidence := rc.convertEvidenceForLLM(allItems)
-		generated, err := rc.llmClient.SummarizeClusterWithEvidence(ctx, allItems, evidence, rc.settings.digestLanguage, "", rc.settings.digestTone)
+		generated, err := rc.llmClient.SummarizeClusterWithEvidence(ctx, allItems, evidence, rc.settings.digestLanguage, rc.settings.clusterModel, rc.llmTone())
 
 		if err != nil || generated == "" {
 			if err != nil {
@@ -212,10 +223,6 @@ func (rc *digestRenderContext) renderNarrativeSection(sb *strings.Builder, narra
 		}
 	}
 
-	if line := rc.scheduler.buildCorroborationLine(allItems, allItems[0]); line != "" {
-		sb.WriteString(line)
-	}
-
 	rc.appendExplainabilityLine(sb, allItems)
 
 	rc.appendEvidenceLine(sb, allItems)
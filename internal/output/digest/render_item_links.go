@@ -0,0 +1,143 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	telegramBotLinkFmt = "https://t.me/%s?start=%s"
+	clickLinkPath      = "/r/"
+)
+
+// itemDetailsLinkPrefix returns the URL prefix that a deep-link code is appended
+// to for the given digest_item_links mode, or "" when links cannot be built.
+func itemDetailsLinkPrefix(mode, botUsername, researchBaseURL string) string {
+	switch mode {
+	case db.ItemLinksBot:
+		botUsername = strings.TrimPrefix(strings.TrimSpace(botUsername), "@")
+		if botUsername == "" {
+			return ""
+		}
+
+		return fmt.Sprintf(telegramBotLinkFmt, botUsername, db.ItemDeepLinkStartPrefix)
+	case db.ItemLinksResearch:
+		researchBaseURL = strings.TrimRight(strings.TrimSpace(researchBaseURL), "/")
+		if researchBaseURL == "" {
+			return ""
+		}
+
+		return researchBaseURL + "/research/i/"
+	default:
+		return ""
+	}
+}
+
+// loadItemDetailLinks returns deep-link codes for every item that can appear in
+// the digest, keyed by item ID, along with the URL prefix for those codes.
+func (s *Scheduler) loadItemDetailLinks(ctx context.Context, mode string, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) (map[string]string, string) {
+	prefix := itemDetailsLinkPrefix(mode, s.cfg.TelegramBotUsername, s.cfg.ExpandedViewBaseURL)
+	if prefix == "" {
+		if mode != db.ItemLinksOff && mode != "" {
+			logger.Debug().Str("mode", mode).Msg("item detail links enabled but bot username or base URL is not configured")
+		}
+
+		return nil, ""
+	}
+
+	links, err := s.database.GetOrCreateItemDeepLinks(ctx, digestItemIDs(items, clusters))
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load item detail links")
+
+		return nil, ""
+	}
+
+	return links, prefix
+}
+
+// loadClickLinks returns deep-link codes for the tracked source links of every
+// item that can appear in the digest, keyed by item ID, along with the URL
+// prefix of the redirect endpoint. Tracking needs the public HTTP server, so
+// it stays off without a base URL and signing secret.
+func (s *Scheduler) loadClickLinks(ctx context.Context, enabled bool, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) (map[string]string, string) {
+	if !enabled {
+		return nil, ""
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(s.cfg.ExpandedViewBaseURL), "/")
+	if baseURL == "" || s.cfg.ExpandedViewSigningSecret == "" {
+		logger.Debug().Msg("click tracking enabled but expanded view base URL or signing secret is not configured")
+
+		return nil, ""
+	}
+
+	links, err := s.database.GetOrCreateItemDeepLinks(ctx, digestItemIDs(items, clusters))
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load click tracking links")
+
+		return nil, ""
+	}
+
+	return links, baseURL + clickLinkPath
+}
+
+// digestItemIDs returns the unique IDs of items and cluster members.
+func digestItemIDs(items []db.Item, clusters []db.ClusterWithItems) []string {
+	seen := make(map[string]bool, len(items))
+	ids := make([]string, 0, len(items))
+
+	addID := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, item := range items {
+		addID(item.ID)
+	}
+
+	for _, c := range clusters {
+		for _, item := range c.Items {
+			addID(item.ID)
+		}
+	}
+
+	return ids
+}
+
+// sourceURL returns the link to an item's source message, wrapped in the
+// click tracking redirect when tracking is enabled.
+func (rc *digestRenderContext) sourceURL(item db.Item) string {
+	if code, ok := rc.clickLinks[item.ID]; ok {
+		return rc.clickLinkPrefix + code
+	}
+
+	return itemMessageURL(item)
+}
+
+// itemDetailsURL returns the deep link for an item, or "" when none is available.
+func (rc *digestRenderContext) itemDetailsURL(itemID string) string {
+	code, ok := rc.itemLinks[itemID]
+	if !ok {
+		return ""
+	}
+
+	return rc.itemLinkPrefix + code
+}
+
+// appendItemDetailsLink adds a "Details" deep link for a single item.
+func (rc *digestRenderContext) appendItemDetailsLink(sb *strings.Builder, itemID string) {
+	link := rc.itemDetailsURL(itemID)
+	if link == "" {
+		return
+	}
+
+	fmt.Fprintf(sb, "\n    🔎 <a href=\"%s\">Details</a>", html.EscapeString(link))
+}
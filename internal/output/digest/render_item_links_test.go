@@ -0,0 +1,71 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestItemDetailsLinkPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		botUsername string
+		baseURL     string
+		want        string
+	}{
+		{"off", db.ItemLinksOff, "digest_bot", "https://example.com", ""},
+		{"bot", db.ItemLinksBot, "@digest_bot", "", "https://t.me/digest_bot?start=item_"},
+		{"bot without username", db.ItemLinksBot, "", "https://example.com", ""},
+		{"research", db.ItemLinksResearch, "", "https://example.com/", "https://example.com/research/i/"},
+		{"research without base URL", db.ItemLinksResearch, "digest_bot", "", ""},
+		{"unknown mode", "web", "digest_bot", "https://example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := itemDetailsLinkPrefix(tt.mode, tt.botUsername, tt.baseURL); got != tt.want {
+				t.Errorf("itemDetailsLinkPrefix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendItemDetailsLink(t *testing.T) {
+	rc := &digestRenderContext{
+		itemLinks:      map[string]string{"item-1": "aB3dE6gH"},
+		itemLinkPrefix: "https://t.me/digest_bot?start=item_",
+	}
+
+	var sb strings.Builder
+
+	rc.appendItemDetailsLink(&sb, "item-1")
+	rc.appendItemDetailsLink(&sb, "item-2")
+
+	got := sb.String()
+	if !strings.Contains(got, `href="https://t.me/digest_bot?start=item_aB3dE6gH"`) {
+		t.Errorf("expected deep link for item-1, got %q", got)
+	}
+
+	if strings.Count(got, "Details") != 1 {
+		t.Errorf("expected exactly one details link, got %q", got)
+	}
+}
+
+func TestSourceURL(t *testing.T) {
+	rc := &digestRenderContext{
+		clickLinks:      map[string]string{"tracked": "Ab12Cd34"},
+		clickLinkPrefix: "https://example.com/r/",
+	}
+
+	tracked := db.Item{ID: "tracked", SourceChannel: "news", SourceMsgID: 5}
+	if got := rc.sourceURL(tracked); got != "https://example.com/r/Ab12Cd34" {
+		t.Errorf("sourceURL(tracked) = %q", got)
+	}
+
+	untracked := db.Item{ID: "other", SourceChannel: "news", SourceMsgID: 5}
+	if got := rc.sourceURL(untracked); got != "https://t.me/news/5" {
+		t.Errorf("sourceURL(untracked) = %q", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"sort"
 	"strings"
 
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
@@ -81,7 +82,7 @@ func groupItemsBySummary(items []db.Item, seenSummaries map[string]bool) []summa
 
 // formatSummaryGroup formats a group of items with the same summary.
 func (rc *digestRenderContext) formatSummaryGroup(sb *strings.Builder, g summaryGroup, includeTopic bool) {
-	sanitizedSummary := htmlutils.SanitizeHTML(g.summary)
+	sanitizedSummary := rc.safetyText(htmlutils.SanitizeHTML(rc.displaySummary(g.summary)), collectItemIDs(g.items)...)
 	prefix := getImportancePrefix(g.importanceScore)
 	lowReliability := rc.isLowReliabilityGroup(g.items)
 
@@ -90,6 +91,7 @@ func (rc *digestRenderContext) formatSummaryGroup(sb *strings.Builder, g summary
 	}
 
 	sb.WriteString(htmlutils.ItemStart)
+	sb.WriteString(rc.topicAnchor(g.items[0].Topic))
 	sb.WriteString(formatSummaryLine(g, includeTopic, prefix, sanitizedSummary, lowReliability))
 	fmt.Fprintf(sb, DigestSourceVia, strings.Join(rc.formatItemLinks(g.items), DigestSourceSeparator))
 
@@ -99,8 +101,8 @@ func (rc *digestRenderContext) formatSummaryGroup(sb *strings.Builder, g summary
 		}
 	}
 
-	if len(g.items) > 0 {
-		if line := rc.scheduler.buildCorroborationLine(g.items, g.items[0]); line != "" {
+	if len(g.items) > 0 && !rc.isCompact() {
+		if line := rc.buildCorroborationLine(g.items); line != "" {
 			sb.WriteString(line)
 		}
 	}
@@ -113,6 +115,7 @@ func (rc *digestRenderContext) formatSummaryGroup(sb *strings.Builder, g summary
 	// Add expand link for the first item in the group
 	if len(g.items) > 0 {
 		rc.appendExpandLink(sb, g.items[0].ID)
+		rc.appendItemDetailsLink(sb, g.items[0].ID)
 	}
 
 	sb.WriteString(htmlutils.ItemEnd)
@@ -145,7 +148,7 @@ func (rc *digestRenderContext) formatItemLinks(items []db.Item) []string {
 
 	for _, item := range items {
 		label := formatItemLabel(item)
-		links = append(links, rc.scheduler.formatLink(item, label))
+		links = append(links, formatSourceLink(item, label, rc.sourceURL(item)))
 	}
 
 	return links
@@ -182,12 +185,45 @@ func (rc *digestRenderContext) collectSourceLinks(items []db.Item) []string {
 			label = DefaultSourceLabel
 		}
 
-		links = append(links, rc.scheduler.formatLink(item, label))
+		links = append(links, formatSourceLink(item, label, rc.sourceURL(item)))
+	}
+
+	return links
+}
+
+// collectAttributedSourceLinks collects cluster source links ordered by posting time,
+// using the original post time for forwards.
+// The channel that posted first is marked as the origin, and the remaining sources
+// follow in order of lag. Without timestamps the original order is kept unmarked.
+func (rc *digestRenderContext) collectAttributedSourceLinks(items []db.Item) []string {
+	ordered, firstKnown := orderByFirstMover(items)
+
+	links := rc.collectSourceLinks(ordered)
+	if firstKnown && len(links) > 1 {
+		links[0] = DigestSourceFirstPrefix + links[0]
 	}
 
 	return links
 }
 
+// orderByFirstMover returns a copy of items sorted by Telegram post time, earliest
+// first, with undated items last. It reports whether the first item has a timestamp.
+func orderByFirstMover(items []db.Item) ([]db.Item, bool) {
+	ordered := make([]db.Item, len(items))
+	copy(ordered, items)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := itemPostTime(ordered[i]), itemPostTime(ordered[j])
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+
+		return a.Before(b)
+	})
+
+	return ordered, len(ordered) > 0 && !itemPostTime(ordered[0]).IsZero()
+}
+
 // findFactCheckMatch finds a fact-check match for a list of items.
 func findFactCheckMatch(items []db.Item, factChecks map[string]db.FactCheckMatch) (db.FactCheckMatch, bool) {
 	for _, item := range items {
@@ -0,0 +1,103 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	numbersBlockMaxFacts = 6
+	numbersBlockEmoji    = "📊"
+)
+
+// numberFact pairs a numeric fact with the item it came from.
+type numberFact struct {
+	fact db.NumericFact
+	item db.Item
+}
+
+// getNumbersBlockTitle returns the localized "by the numbers" title.
+func (rc *digestRenderContext) getNumbersBlockTitle() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "В цифрах"
+	case "de":
+		return "In Zahlen"
+	case "es":
+		return "En cifras"
+	case "fr":
+		return "En chiffres"
+	case "it":
+		return "In cifre"
+	}
+
+	return "By the numbers"
+}
+
+// buildNumbersBlock renders the "by the numbers" section from the key figures
+// extracted during enrichment.
+func (rc *digestRenderContext) buildNumbersBlock(ctx context.Context, sb *strings.Builder) {
+	if !rc.settings.numbersBlockEnabled || len(rc.items) == 0 {
+		return
+	}
+
+	facts, err := rc.scheduler.database.GetNumericFactsForItems(ctx, collectItemIDs(rc.items))
+	if err != nil {
+		rc.logger.Warn().Err(err).Msg("failed to load numeric facts")
+
+		return
+	}
+
+	selected := selectNumberFacts(rc.items, facts, numbersBlockMaxFacts)
+	if len(selected) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, FormatSectionHeader, numbersBlockEmoji, rc.getNumbersBlockTitle())
+
+	for _, nf := range selected {
+		links := rc.collectSourceLinks([]db.Item{nf.item})
+		fmt.Fprintf(sb, "• <b>%s</b> — %s <i>via %s</i>\n",
+			html.EscapeString(nf.fact.Value), html.EscapeString(nf.fact.Context), strings.Join(links, DigestSourceSeparator))
+	}
+}
+
+// selectNumberFacts picks the first figure of the most important items, one
+// per item and without repeating a value, up to limit.
+func selectNumberFacts(items []db.Item, facts map[string][]db.NumericFact, limit int) []numberFact {
+	ordered := make([]db.Item, len(items))
+	copy(ordered, items)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ImportanceScore > ordered[j].ImportanceScore
+	})
+
+	selected := make([]numberFact, 0, limit)
+	seen := make(map[string]bool)
+
+	for _, item := range ordered {
+		for _, f := range facts[item.ID] {
+			key := strings.ToLower(f.Value)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			selected = append(selected, numberFact{fact: f, item: item})
+
+			break
+		}
+
+		if len(selected) == limit {
+			break
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,38 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSelectNumberFacts(t *testing.T) {
+	items := []db.Item{
+		{ID: "low", ImportanceScore: 0.2},
+		{ID: "high", ImportanceScore: 0.9},
+		{ID: "mid", ImportanceScore: 0.5},
+		{ID: "none", ImportanceScore: 1},
+	}
+	facts := map[string][]db.NumericFact{
+		"low":  {{Value: "12 killed"}},
+		"high": {{Value: "3.2%"}, {Value: "$5 million"}},
+		"mid":  {{Value: "3.2%"}, {Value: "40 people"}},
+	}
+
+	got := selectNumberFacts(items, facts, 2)
+
+	want := []string{"3.2%", "40 people"}
+	if len(got) != len(want) {
+		t.Fatalf("selectNumberFacts() returned %d facts, want %d", len(got), len(want))
+	}
+
+	for i, nf := range got {
+		if nf.fact.Value != want[i] {
+			t.Errorf("fact %d = %q, want %q", i, nf.fact.Value, want[i])
+		}
+	}
+
+	if got[0].item.ID != "high" || got[1].item.ID != "mid" {
+		t.Errorf("facts should be attributed to their items, got %q and %q", got[0].item.ID, got[1].item.ID)
+	}
+}
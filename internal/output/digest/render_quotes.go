@@ -0,0 +1,102 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	quotesBlockMaxQuotes = 4
+	quotesBlockEmoji     = "💬"
+)
+
+// itemQuote pairs a quote with the item it came from.
+type itemQuote struct {
+	quote db.ItemQuote
+	item  db.Item
+}
+
+// getQuotesBlockTitle returns the localized quotes block title.
+func (rc *digestRenderContext) getQuotesBlockTitle() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "Цитаты"
+	case "de":
+		return "Zitate"
+	case "es":
+		return "Citas"
+	case "fr":
+		return "Citations"
+	case "it":
+		return "Citazioni"
+	}
+
+	return "Quotes"
+}
+
+// buildQuotesBlock renders the attributed quotes extracted during enrichment.
+func (rc *digestRenderContext) buildQuotesBlock(ctx context.Context, sb *strings.Builder) {
+	if !rc.settings.quotesBlockEnabled || len(rc.items) == 0 {
+		return
+	}
+
+	quotes, err := rc.scheduler.database.GetQuotesForItems(ctx, collectItemIDs(rc.items))
+	if err != nil {
+		rc.logger.Warn().Err(err).Msg("failed to load item quotes")
+
+		return
+	}
+
+	selected := selectQuotes(rc.items, quotes, quotesBlockMaxQuotes)
+	if len(selected) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, FormatSectionHeader, quotesBlockEmoji, rc.getQuotesBlockTitle())
+
+	for _, q := range selected {
+		links := rc.collectSourceLinks([]db.Item{q.item})
+		fmt.Fprintf(sb, "• «%s» — <b>%s</b> <i>via %s</i>\n",
+			html.EscapeString(q.quote.Quote), html.EscapeString(q.quote.Speaker), strings.Join(links, DigestSourceSeparator))
+	}
+}
+
+// selectQuotes picks the first quote of the most important items, one per item
+// and one per speaker, up to limit.
+func selectQuotes(items []db.Item, quotes map[string][]db.ItemQuote, limit int) []itemQuote {
+	ordered := make([]db.Item, len(items))
+	copy(ordered, items)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ImportanceScore > ordered[j].ImportanceScore
+	})
+
+	selected := make([]itemQuote, 0, limit)
+	seen := make(map[string]bool)
+
+	for _, item := range ordered {
+		for _, q := range quotes[item.ID] {
+			key := strings.ToLower(q.Speaker)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			selected = append(selected, itemQuote{quote: q, item: item})
+
+			break
+		}
+
+		if len(selected) == limit {
+			break
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,33 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSelectQuotes(t *testing.T) {
+	items := []db.Item{
+		{ID: "low", ImportanceScore: 0.2},
+		{ID: "high", ImportanceScore: 0.9},
+		{ID: "mid", ImportanceScore: 0.5},
+	}
+	quotes := map[string][]db.ItemQuote{
+		"low":  {{Speaker: "Jerome Powell", Quote: "low"}},
+		"high": {{Speaker: "Olaf Scholz", Quote: "high"}},
+		"mid":  {{Speaker: "olaf scholz", Quote: "repeat"}, {Speaker: "Janet Yellen", Quote: "mid"}},
+	}
+
+	got := selectQuotes(items, quotes, 2)
+
+	want := []string{"high", "mid"}
+	if len(got) != len(want) {
+		t.Fatalf("selectQuotes() returned %d quotes, want %d", len(got), len(want))
+	}
+
+	for i, q := range got {
+		if q.quote.Quote != want[i] || q.item.ID != want[i] {
+			t.Errorf("quote %d = %q from %q, want %q", i, q.quote.Quote, q.item.ID, want[i])
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	rumorWatchMaxChanges = 5
+	rumorWatchEmoji      = "🕵"
+	rumorWatchClaimLimit = 160
+)
+
+// getRumorWatchTitle returns the localized rumor watch section title.
+func (rc *digestRenderContext) getRumorWatchTitle() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "Проверка слухов"
+	case "de":
+		return "Gerüchte-Check"
+	case "es":
+		return "Vigilancia de rumores"
+	case "fr":
+		return "Veille des rumeurs"
+	case "it":
+		return "Osservatorio voci"
+	}
+
+	return "Rumor watch"
+}
+
+// getClaimStateLabel returns the localized label of a claim state.
+func (rc *digestRenderContext) getClaimStateLabel(state string) string {
+	labels := map[string][4]string{
+		"ru": {"не подтверждено", "подтверждено", "оспаривается", "опровергнуто"},
+		"de": {"unbestätigt", "bestätigt", "umstritten", "widerlegt"},
+		"es": {"sin verificar", "corroborado", "en disputa", "desmentido"},
+		"fr": {"non vérifié", "corroboré", "contesté", "démenti"},
+		"it": {"non verificato", "confermato", "contestato", "smentito"},
+	}
+
+	l, ok := labels[strings.ToLower(rc.settings.digestLanguage)]
+	if !ok {
+		l = [4]string{domain.ClaimUnverified, domain.ClaimCorroborated, domain.ClaimDisputed, domain.ClaimDebunked}
+	}
+
+	switch state {
+	case domain.ClaimCorroborated:
+		return l[1]
+	case domain.ClaimDisputed:
+		return l[2]
+	case domain.ClaimDebunked:
+		return l[3]
+	}
+
+	return l[0]
+}
+
+// buildRumorWatchSection lists the claims whose state changed during the
+// digest window.
+func (rc *digestRenderContext) buildRumorWatchSection(ctx context.Context, sb *strings.Builder) {
+	if !rc.settings.rumorWatchEnabled {
+		return
+	}
+
+	changes, err := rc.scheduler.database.GetClaimStateChanges(ctx, &rc.start, &rc.end, rumorWatchMaxChanges)
+	if err != nil {
+		rc.logger.Warn().Err(err).Msg("failed to load claim state changes")
+
+		return
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, FormatSectionHeader, rumorWatchEmoji, rc.getRumorWatchTitle())
+
+	for _, c := range changes {
+		sb.WriteString(rc.formatClaimStateChange(c) + "\n")
+	}
+}
+
+// formatClaimStateChange renders a change as "• claim — unverified → debunked".
+func (rc *digestRenderContext) formatClaimStateChange(c db.ClaimStateChange) string {
+	claim := c.ClaimText
+	if runes := []rune(claim); len(runes) > rumorWatchClaimLimit {
+		claim = string(runes[:rumorWatchClaimLimit]) + "…"
+	}
+
+	return fmt.Sprintf("• %s — <i>%s → <b>%s</b></i>", html.EscapeString(claim), rc.getClaimStateLabel(c.FromState), rc.getClaimStateLabel(c.ToState))
+}
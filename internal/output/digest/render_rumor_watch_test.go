@@ -0,0 +1,28 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestFormatClaimStateChange(t *testing.T) {
+	change := db.ClaimStateChange{ClaimText: "Bridge <closed>", FromState: domain.ClaimUnverified, ToState: domain.ClaimDebunked}
+
+	rc := &digestRenderContext{}
+	if got, want := rc.formatClaimStateChange(change), "• Bridge &lt;closed&gt; — <i>unverified → <b>debunked</b></i>"; got != want {
+		t.Errorf("line = %q, want %q", got, want)
+	}
+
+	rc.settings.digestLanguage = "ru"
+	if got := rc.formatClaimStateChange(change); !strings.Contains(got, "не подтверждено → <b>опровергнуто</b>") {
+		t.Errorf("ru line = %q, want localized states", got)
+	}
+
+	change.ClaimText = strings.Repeat("я", rumorWatchClaimLimit+10)
+	if got := rc.formatClaimStateChange(change); !strings.Contains(got, strings.Repeat("я", rumorWatchClaimLimit)+"…") {
+		t.Errorf("long claim not truncated: %q", got)
+	}
+}
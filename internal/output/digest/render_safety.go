@@ -0,0 +1,152 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// safetyWarningLabel marks flagged item lines under the warn policy.
+const safetyWarningLabel = "⚠️ <i>Sensitive:</i> "
+
+// safetyBlurIcon precedes flagged item lines hidden under the blur policy.
+const safetyBlurIcon = "⚠️ "
+
+// LoadSafetyPolicy resolves the content safety policy for a target chat: the
+// per-target override first, then the global setting, then off.
+func LoadSafetyPolicy(ctx context.Context, store settingsReader, targetChatID int64) string {
+	var policy string
+
+	if targetChatID != 0 {
+		if err := store.GetSetting(ctx, fmt.Sprintf(filters.SafetyTargetKeyFmt, targetChatID), &policy); err == nil && filters.IsValidSafetyPolicy(policy) {
+			return policy
+		}
+	}
+
+	if err := store.GetSetting(ctx, filters.SettingContentSafety, &policy); err == nil && filters.IsValidSafetyPolicy(policy) {
+		return policy
+	}
+
+	return filters.SafetyPolicyOff
+}
+
+// applySafetyPolicy loads the safety flags of the items. Under the block
+// policy, flagged items are left out; under warn and blur they are kept with
+// their flags so their lines are marked when rendered. When the flags cannot
+// be loaded, items are returned unchanged.
+func (s *Scheduler) applySafetyPolicy(ctx context.Context, items []db.Item, settings digestSettings, logger *zerolog.Logger) []db.Item {
+	if settings.safetyPolicy == filters.SafetyPolicyOff || settings.safetyPolicy == "" || len(items) == 0 {
+		return items
+	}
+
+	flags, err := s.database.GetItemSafetyFlags(ctx, collectItemIDs(items))
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load item safety flags, skipping content safety policy")
+
+		return items
+	}
+
+	kept := filterItemsBySafety(items, flags, settings.safetyPolicy)
+	if flagged := countFlagged(items, flags); flagged > 0 {
+		observability.ContentSafetyActionsTotal.WithLabelValues(settings.safetyPolicy).Add(float64(flagged))
+		logger.Debug().Str("policy", settings.safetyPolicy).Int("flagged", flagged).Int("kept", len(kept)).Msg("applied content safety policy")
+	}
+
+	return kept
+}
+
+// filterItemsBySafety drops flagged items under the block policy and fills
+// in the flags of the others.
+func filterItemsBySafety(items []db.Item, flags map[string][]string, policy string) []db.Item {
+	kept := make([]db.Item, 0, len(items))
+
+	for _, item := range items {
+		item.SafetyFlags = flags[item.ID]
+
+		if len(item.SafetyFlags) > 0 && policy == filters.SafetyPolicyBlock {
+			continue
+		}
+
+		kept = append(kept, item)
+	}
+
+	return kept
+}
+
+func countFlagged(items []db.Item, flags map[string][]string) int {
+	count := 0
+
+	for _, item := range items {
+		if len(flags[item.ID]) > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// flaggedItemIDs returns the IDs of items with safety flags.
+func flaggedItemIDs(itemLists ...[]db.Item) map[string]bool {
+	flagged := make(map[string]bool)
+
+	for _, items := range itemLists {
+		for _, item := range items {
+			if len(item.SafetyFlags) > 0 {
+				flagged[item.ID] = true
+			}
+		}
+	}
+
+	return flagged
+}
+
+// safetyText marks rendered item text when any of the items behind it is
+// flagged: a warning label under warn, a spoiler under blur.
+func (rc *digestRenderContext) safetyText(text string, itemIDs ...string) string {
+	flagged := false
+
+	for _, id := range itemIDs {
+		if rc.flaggedItems[id] {
+			flagged = true
+
+			break
+		}
+	}
+
+	if !flagged {
+		return text
+	}
+
+	return MarkSensitive(text, rc.settings.safetyPolicy)
+}
+
+// MarkSensitive marks the rendered text of a flagged item for a safety
+// policy: a warning label under warn, a spoiler under blur.
+func MarkSensitive(text, policy string) string {
+	switch policy {
+	case filters.SafetyPolicyWarn:
+		return safetyWarningLabel + text
+	case filters.SafetyPolicyBlur:
+		return safetyBlurIcon + "<tg-spoiler>" + text + "</tg-spoiler>"
+	default:
+		return text
+	}
+}
+
+// withoutFlaggedItems drops the rich digest items with safety flags.
+func withoutFlaggedItems(items []db.ItemWithMedia) []db.ItemWithMedia {
+	kept := make([]db.ItemWithMedia, 0, len(items))
+
+	for _, item := range items {
+		if len(item.SafetyFlags) == 0 {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
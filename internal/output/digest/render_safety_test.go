@@ -0,0 +1,50 @@
+package digest
+
+import (
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestFilterItemsBySafety(t *testing.T) {
+	items := []db.Item{{ID: "clean"}, {ID: "flagged"}}
+	flags := map[string][]string{"flagged": {filters.SafetyFlagNSFW}}
+
+	blocked := filterItemsBySafety(items, flags, filters.SafetyPolicyBlock)
+	if len(blocked) != 1 || blocked[0].ID != "clean" {
+		t.Errorf("block kept %v, want only the clean item", blocked)
+	}
+
+	blurred := filterItemsBySafety(items, flags, filters.SafetyPolicyBlur)
+	if len(blurred) != 2 || len(blurred[1].SafetyFlags) != 1 {
+		t.Errorf("blur kept %v, want both items with flags", blurred)
+	}
+
+	if len(items[1].SafetyFlags) != 0 {
+		t.Error("filterItemsBySafety should not modify its input")
+	}
+}
+
+func TestSafetyText(t *testing.T) {
+	items := []db.Item{{ID: "a"}, {ID: "b", SafetyFlags: []string{filters.SafetyFlagProfanity}}}
+
+	tests := []struct {
+		policy string
+		ids    []string
+		want   string
+	}{
+		{filters.SafetyPolicyWarn, []string{"a", "b"}, "⚠️ <i>Sensitive:</i> text"},
+		{filters.SafetyPolicyBlur, []string{"b"}, "⚠️ <tg-spoiler>text</tg-spoiler>"},
+		{filters.SafetyPolicyBlur, []string{"a"}, "text"},
+		{filters.SafetyPolicyOff, []string{"b"}, "text"},
+	}
+
+	for _, tt := range tests {
+		rc := &digestRenderContext{settings: digestSettings{safetyPolicy: tt.policy}, flaggedItems: flaggedItemIDs(items)}
+
+		if got := rc.safetyText("text", tt.ids...); got != tt.want {
+			t.Errorf("safetyText(%s, %v) = %q, want %q", tt.policy, tt.ids, got, tt.want)
+		}
+	}
+}
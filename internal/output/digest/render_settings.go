@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
 // digestSettings holds all settings needed for building a digest.
@@ -16,23 +18,48 @@ type digestSettings struct {
 	editorEnabled               bool
 	consolidatedClustersEnabled bool
 	editorDetailedItems         bool
+	editorSections              []string
 	digestLanguage              string
 	digestTone                  string
 	othersAsNarrative           bool
 	corroborationBoost          float32
 	singleSourcePenalty         float32
+	channelOverlap              channelOverlap
 	explainabilityLineEnabled   bool
+	stanceBadgesEnabled         bool
+	numbersBlockEnabled         bool
+	quotesBlockEnabled          bool
+	upcomingBlockEnabled        bool
+	rumorWatchEnabled           bool
+	whatsNewEnabled             bool
+	sourceFooterEnabled         bool
+	clickTrackingEnabled        bool
+	regions                     []string
+	diversity                   db.DiversityCaps
+	mmrLambda                   float32
+	itemLinksMode               string
+	tocMinTopics                int
+	verbosity                   string
+	tierSort                    string
+	safetyPolicy                string
+	// Shadow variant settings; empty for production digests
+	variant        string
+	narrativeModel string
+	clusterModel   string
 	// Bullet mode settings
 	bulletModeEnabled       bool
 	bulletSourceAttribution bool
 	bulletSourceFormat      string
 	bulletMaxPerCluster     int
 	bulletMinImportance     float32
+	// previouslyMissed are stale items for the "Previously missed" section;
+	// set by buildDigest, empty for other renders
+	previouslyMissed []db.Item
 }
 
 const errInvalidScheduleTimezone = "invalid digest schedule timezone"
 
-func (s *Scheduler) getDigestSettings(ctx context.Context, logger *zerolog.Logger) digestSettings {
+func (s *Scheduler) getDigestSettings(ctx context.Context, targetChatID int64, logger *zerolog.Logger) digestSettings {
 	ds := digestSettings{
 		topicsEnabled:             true,
 		freshnessDecayHours:       s.cfg.FreshnessDecayHours,
@@ -43,6 +70,9 @@ func (s *Scheduler) getDigestSettings(ctx context.Context, logger *zerolog.Logge
 		corroborationBoost:        s.cfg.CorroborationImportanceBoost,
 		singleSourcePenalty:       s.cfg.SingleSourcePenalty,
 		explainabilityLineEnabled: true,
+		whatsNewEnabled:           true,
+		itemLinksMode:             db.ItemLinksOff,
+		tocMinTopics:              DefaultTOCMinTopics,
 		// Bullet mode defaults from config
 		bulletModeEnabled:       true,
 		bulletSourceAttribution: s.cfg.BulletSourceAttribution,
@@ -52,6 +82,8 @@ func (s *Scheduler) getDigestSettings(ctx context.Context, logger *zerolog.Logge
 	}
 
 	s.loadDigestSettingsFromDB(ctx, logger, &ds)
+	ds.applyVerbosity(s.loadVerbosity(ctx, targetChatID, logger))
+	ds.safetyPolicy = LoadSafetyPolicy(ctx, s.database, targetChatID)
 
 	return ds
 }
@@ -71,9 +103,24 @@ func (s *Scheduler) loadDigestSettingsFromDB(ctx context.Context, logger *zerolo
 	loadSetting("editor_enabled", &ds.editorEnabled, "could not get editor_enabled from DB")
 	loadSetting("consolidated_clusters_enabled", &ds.consolidatedClustersEnabled, "could not get consolidated_clusters_enabled from DB")
 	loadSetting("editor_detailed_items", &ds.editorDetailedItems, "could not get editor_detailed_items from DB")
+	loadSetting(SettingEditorSections, &ds.editorSections, "could not get editor_sections from DB")
 	loadSetting(SettingDigestLanguage, &ds.digestLanguage, MsgCouldNotGetDigestLanguage)
 	loadSetting("digest_tone", &ds.digestTone, "could not get digest_tone from DB")
 	loadSetting("others_as_narrative", &ds.othersAsNarrative, "could not get others_as_narrative from DB")
+	loadSetting("digest_stance_badges", &ds.stanceBadgesEnabled, "could not get digest_stance_badges from DB")
+	loadSetting(SettingDigestNumbersBlock, &ds.numbersBlockEnabled, "could not get digest_numbers_block from DB")
+	loadSetting(SettingDigestQuotesBlock, &ds.quotesBlockEnabled, "could not get digest_quotes_block from DB")
+	loadSetting(SettingDigestUpcomingBlock, &ds.upcomingBlockEnabled, "could not get digest_upcoming_block from DB")
+	loadSetting(SettingDigestRumorWatch, &ds.rumorWatchEnabled, "could not get digest_rumor_watch from DB")
+	loadSetting(SettingDigestWhatsNew, &ds.whatsNewEnabled, "could not get digest_whats_new from DB")
+	loadSetting(SettingDigestSourceFooter, &ds.sourceFooterEnabled, "could not get digest_source_footer from DB")
+	loadSetting(SettingDigestClickTracking, &ds.clickTrackingEnabled, "could not get digest_click_tracking from DB")
+	loadSetting(SettingDigestRegions, &ds.regions, "could not get digest_regions from DB")
+	loadSetting(SettingDigestDiversity, &ds.diversity, "could not get digest_diversity from DB")
+	loadSetting(SettingDigestMMRLambda, &ds.mmrLambda, "could not get digest_mmr_lambda from DB")
+	loadSetting(SettingDigestItemLinks, &ds.itemLinksMode, "could not get digest_item_links from DB")
+	loadSetting(SettingDigestTOCMinTopics, &ds.tocMinTopics, "could not get digest_toc_min_topics from DB")
+	loadSetting(SettingDigestTierSort, &ds.tierSort, "could not get digest_tier_sort from DB")
 	// Bullet mode settings (can be overridden from DB)
 	loadSetting("bullet_mode_enabled", &ds.bulletModeEnabled, "could not get bullet_mode_enabled from DB")
 	loadSetting("bullet_source_attribution", &ds.bulletSourceAttribution, "could not get bullet_source_attribution from DB")
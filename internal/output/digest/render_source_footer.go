@@ -0,0 +1,132 @@
+package digest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	sourceFooterEmoji = "🧾"
+	percentScale      = 100
+)
+
+// sourceMix summarizes where the items of a digest came from.
+type sourceMix struct {
+	// sources is the number of distinct source channels.
+	sources int
+	// originalShare is the share of items that were not forwarded.
+	originalShare float64
+	// lowReliability is the number of channels flagged by reader ratings.
+	lowReliability int
+	// disputedDomains is the number of evidence domains contradicting an item.
+	disputedDomains int
+}
+
+// sourceFooterLabels are the localized labels of the footer parts.
+type sourceFooterLabels struct {
+	sources, original, lowReliability, disputed string
+}
+
+// getSourceFooterLabels returns the footer labels in the digest language.
+// Labels are followed by the number, which avoids plural forms.
+func (rc *digestRenderContext) getSourceFooterLabels() sourceFooterLabels {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return sourceFooterLabels{"Источники", "оригинальные материалы", "ненадёжные каналы", "спорные домены"}
+	case "de":
+		return sourceFooterLabels{"Quellen", "Eigenberichte", "unzuverlässige Kanäle", "widersprechende Domains"}
+	case "es":
+		return sourceFooterLabels{"Fuentes", "información original", "canales poco fiables", "dominios en disputa"}
+	case "fr":
+		return sourceFooterLabels{"Sources", "informations originales", "chaînes peu fiables", "domaines contradictoires"}
+	case "it":
+		return sourceFooterLabels{"Fonti", "notizie originali", "canali poco affidabili", "domini contrastanti"}
+	}
+
+	return sourceFooterLabels{"Sources", "original reporting", "low-reliability channels", "disputed evidence domains"}
+}
+
+// buildSourceFooter renders a one-line summary of the digest's source mix so
+// readers can judge its inputs.
+func (rc *digestRenderContext) buildSourceFooter(sb *strings.Builder) {
+	if !rc.settings.sourceFooterEnabled || len(rc.items) == 0 {
+		return
+	}
+
+	mix := summarizeSources(rc.items, rc.evidence, rc.isLowReliabilityItem)
+	if mix.sources == 0 {
+		return
+	}
+
+	sb.WriteString("\n" + formatSourceFooter(mix, rc.getSourceFooterLabels()) + "\n")
+}
+
+// summarizeSources counts the distinct channels of items, the share of items
+// that are original reporting rather than forwards, the channels flagged as
+// low reliability and the evidence domains that contradict an item.
+func summarizeSources(items []db.Item, evidence map[string][]db.ItemEvidenceWithSource, isLowReliability func(db.Item) bool) sourceMix {
+	channels := make(map[string]bool)
+	flagged := make(map[string]bool)
+	disputed := make(map[string]bool)
+	original := 0
+
+	for _, item := range items {
+		key := item.SourceChannel
+		if key == "" && item.SourceChannelID != 0 {
+			key = strconv.FormatInt(item.SourceChannelID, 10)
+		}
+
+		if key != "" {
+			channels[key] = true
+
+			if isLowReliability(item) {
+				flagged[key] = true
+			}
+		}
+
+		if item.ForwardOrigin == nil {
+			original++
+		}
+
+		for _, ev := range evidence[item.ID] {
+			if ev.IsContradiction && ev.Source.Domain != "" {
+				disputed[normalizeDomain(ev.Source.Domain)] = true
+			}
+		}
+	}
+
+	mix := sourceMix{
+		sources:         len(channels),
+		lowReliability:  len(flagged),
+		disputedDomains: len(disputed),
+	}
+
+	if len(items) > 0 {
+		mix.originalShare = float64(original) / float64(len(items))
+	}
+
+	return mix
+}
+
+// formatSourceFooter formats the footer, e.g.
+// "🧾 Sources: 12 · original reporting: 60% · low-reliability channels: 3".
+// Flag counts are left out when zero.
+func formatSourceFooter(mix sourceMix, labels sourceFooterLabels) string {
+	parts := []string{
+		fmt.Sprintf("%s: %d", labels.sources, mix.sources),
+		fmt.Sprintf("%s: %.0f%%", labels.original, mix.originalShare*percentScale),
+	}
+
+	if mix.lowReliability > 0 {
+		parts = append(parts, fmt.Sprintf("%s: %d", labels.lowReliability, mix.lowReliability))
+	}
+
+	if mix.disputedDomains > 0 {
+		parts = append(parts, fmt.Sprintf("%s: %d", labels.disputed, mix.disputedDomains))
+	}
+
+	return fmt.Sprintf("%s <i>%s</i>", sourceFooterEmoji, strings.Join(parts, tocEntrySeparator))
+}
@@ -0,0 +1,62 @@
+package digest
+
+import (
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSummarizeSources(t *testing.T) {
+	items := []db.Item{
+		{ID: "a", SourceChannel: "news"},
+		{ID: "b", SourceChannel: "news"},
+		{ID: "c", SourceChannel: "rumors", ForwardOrigin: &domain.ForwardHop{}},
+		{ID: "d", SourceChannelID: 42},
+		{ID: "e", SourceChannel: "wire", ForwardOrigin: &domain.ForwardHop{}},
+	}
+	evidence := map[string][]db.ItemEvidenceWithSource{
+		"a": {
+			{ItemEvidence: db.ItemEvidence{IsContradiction: true}, Source: db.EvidenceSource{Domain: "www.example.com"}},
+			{Source: db.EvidenceSource{Domain: "reuters.com"}},
+		},
+		"c": {{ItemEvidence: db.ItemEvidence{IsContradiction: true}, Source: db.EvidenceSource{Domain: "example.com"}}},
+	}
+	lowReliability := func(item db.Item) bool { return item.SourceChannel == "rumors" }
+
+	got := summarizeSources(items, evidence, lowReliability)
+
+	want := sourceMix{sources: 4, originalShare: 0.6, lowReliability: 1, disputedDomains: 1}
+	if got != want {
+		t.Errorf("summarizeSources() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormatSourceFooter(t *testing.T) {
+	labels := sourceFooterLabels{"Sources", "original reporting", "low-reliability channels", "disputed evidence domains"}
+
+	tests := []struct {
+		name string
+		mix  sourceMix
+		want string
+	}{
+		{
+			name: "no flags",
+			mix:  sourceMix{sources: 12, originalShare: 0.6},
+			want: "🧾 <i>Sources: 12 · original reporting: 60%</i>",
+		},
+		{
+			name: "flags",
+			mix:  sourceMix{sources: 12, originalShare: 1, lowReliability: 3, disputedDomains: 1},
+			want: "🧾 <i>Sources: 12 · original reporting: 100% · low-reliability channels: 3 · disputed evidence domains: 1</i>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSourceFooter(tt.mix, labels); got != tt.want {
+				t.Errorf("formatSourceFooter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
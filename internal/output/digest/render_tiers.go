@@ -7,10 +7,16 @@ import (
 // categorizeByImportance categorizes items or clusters into breaking, notable, and also groups.
 func (rc *digestRenderContext) categorizeByImportance() (breaking, notable, also clusterGroup) {
 	if rc.settings.topicsEnabled && len(rc.clusters) > 0 {
-		return categorizeClusters(rc.clusters)
+		breaking, notable, also = categorizeClusters(rc.clusters)
+	} else {
+		breaking, notable, also = categorizeItems(rc.items)
 	}
 
-	return categorizeItems(rc.items)
+	if rc.settings.tierSort == TierSortCorroboration {
+		rc.sortTiersByCorroboration(&breaking, &notable, &also)
+	}
+
+	return breaking, notable, also
 }
 
 // categorizeClusters categorizes clusters by their maximum importance score.
@@ -0,0 +1,111 @@
+package digest
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// DefaultTOCMinTopics is the distinct-topic count from which a digest gets a
+	// table of contents when digest_toc_min_topics is not set; 0 disables it.
+	DefaultTOCMinTopics = 5
+
+	tocEntrySeparator = " · "
+)
+
+// tocIndex tracks which topics get a table-of-contents entry and whether their
+// anchor has been placed in the rendered digest yet.
+type tocIndex struct {
+	numbers  map[string]int
+	anchored map[string]bool
+}
+
+// newTOCIndex numbers the distinct topics of items when there are at least minTopics of them.
+func newTOCIndex(items []db.Item, minTopics int) *tocIndex {
+	if minTopics <= 0 || countDistinctTopics(items) < minTopics {
+		return nil
+	}
+
+	idx := &tocIndex{
+		numbers:  make(map[string]int),
+		anchored: make(map[string]bool),
+	}
+
+	for _, item := range items {
+		if item.Topic == "" {
+			continue
+		}
+
+		if _, ok := idx.numbers[item.Topic]; !ok {
+			idx.numbers[item.Topic] = len(idx.numbers)
+		}
+	}
+
+	return idx
+}
+
+// topicAnchor returns the anchor marker for the first rendered unit of a topic
+// and "" for every later one.
+func (rc *digestRenderContext) topicAnchor(topic string) string {
+	if rc.toc == nil || topic == "" || rc.toc.anchored[topic] {
+		return ""
+	}
+
+	n, ok := rc.toc.numbers[topic]
+	if !ok {
+		return ""
+	}
+
+	rc.toc.anchored[topic] = true
+
+	return htmlutils.TopicAnchor(n)
+}
+
+type tocEntry struct {
+	topic string
+	num   int
+	count int
+}
+
+// buildTOCSection writes a compact table of contents listing every anchored
+// topic with its item count. The sender links entries to the messages that
+// hold each topic once the digest is split and posted.
+func (rc *digestRenderContext) buildTOCSection(sb *strings.Builder) {
+	if rc.toc == nil || len(rc.toc.anchored) == 0 {
+		return
+	}
+
+	counts := make(map[string]int)
+
+	for _, item := range rc.items {
+		if rc.toc.anchored[item.Topic] {
+			counts[item.Topic]++
+		}
+	}
+
+	entries := make([]tocEntry, 0, len(counts))
+	for topic, count := range counts {
+		entries = append(entries, tocEntry{topic: topic, num: rc.toc.numbers[topic], count: count})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].count != entries[j].count {
+			return entries[i].count > entries[j].count
+		}
+
+		return entries[i].topic < entries[j].topic
+	})
+
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		label := fmt.Sprintf("%s (%d)", html.EscapeString(e.topic), e.count)
+		parts = append(parts, htmlutils.TOCEntry(e.num, label))
+	}
+
+	fmt.Fprintf(sb, "📑 <b>Contents:</b> %s\n\n", strings.Join(parts, tocEntrySeparator))
+}
@@ -0,0 +1,57 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestNewTOCIndexThreshold(t *testing.T) {
+	items := []db.Item{{Topic: "Economy"}, {Topic: "Sports"}, {Topic: "Economy"}}
+
+	if idx := newTOCIndex(items, 3); idx != nil {
+		t.Errorf("expected no TOC below the topic threshold, got %+v", idx)
+	}
+
+	if idx := newTOCIndex(items, 0); idx != nil {
+		t.Errorf("expected no TOC when disabled, got %+v", idx)
+	}
+
+	idx := newTOCIndex(items, 2)
+	if idx == nil || len(idx.numbers) != 2 {
+		t.Fatalf("expected TOC with 2 topics, got %+v", idx)
+	}
+}
+
+func TestBuildTOCSection(t *testing.T) {
+	items := []db.Item{
+		{Topic: "Economy"}, {Topic: "Sports"}, {Topic: "Economy"}, {Topic: "Science"},
+	}
+	rc := &digestRenderContext{items: items, toc: newTOCIndex(items, 2)}
+
+	economy := rc.topicAnchor("Economy")
+	if economy == "" {
+		t.Fatal("expected an anchor for the first Economy unit")
+	}
+
+	if again := rc.topicAnchor("Economy"); again != "" {
+		t.Errorf("expected no anchor for the second Economy unit, got %q", again)
+	}
+
+	rc.topicAnchor("Sports")
+
+	var sb strings.Builder
+
+	rc.buildTOCSection(&sb)
+
+	got := htmlutils.StripItemMarkers(sb.String())
+	if got != "📑 <b>Contents:</b> Economy (2) · Sports (1)\n\n" {
+		t.Errorf("buildTOCSection() = %q", got)
+	}
+
+	if anchors := htmlutils.FindTopicAnchors(economy); len(anchors) != 1 || !strings.Contains(sb.String(), htmlutils.TOCEntry(anchors[0], "Economy (2)")) {
+		t.Errorf("TOC entry for Economy does not match its anchor %q: %q", economy, sb.String())
+	}
+}
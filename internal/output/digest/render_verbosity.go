@@ -0,0 +1,109 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+)
+
+const (
+	// VerbosityTargetKeyFmt overrides digest_verbosity for a single target chat.
+	VerbosityTargetKeyFmt = SettingDigestVerbosity + ":%d"
+
+	// compactBulletsPerCluster keeps compact digests to one line per story.
+	compactBulletsPerCluster = 1
+)
+
+// IsValidVerbosity reports whether mode is a supported digest verbosity mode.
+func IsValidVerbosity(mode string) bool {
+	switch mode {
+	case llm.VerbosityCompact, llm.VerbosityStandard, llm.VerbosityDetailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// loadVerbosity resolves the verbosity for a target chat: the per-target
+// override first, then the global setting, then standard.
+func (s *Scheduler) loadVerbosity(ctx context.Context, targetChatID int64, logger *zerolog.Logger) string {
+	var mode string
+
+	if targetChatID != 0 {
+		if err := s.database.GetSetting(ctx, fmt.Sprintf(VerbosityTargetKeyFmt, targetChatID), &mode); err == nil && IsValidVerbosity(mode) {
+			return mode
+		}
+	}
+
+	if err := s.database.GetSetting(ctx, SettingDigestVerbosity, &mode); err != nil {
+		logger.Debug().Err(err).Msg("could not get digest_verbosity from DB")
+	}
+
+	if !IsValidVerbosity(mode) {
+		return llm.VerbosityStandard
+	}
+
+	return mode
+}
+
+// applyVerbosity adjusts the layout settings for the verbosity mode. Compact
+// drops narratives and per-item detail lines; detailed always renders items
+// with their explainability line.
+func (ds *digestSettings) applyVerbosity(mode string) {
+	ds.verbosity = mode
+
+	switch mode {
+	case llm.VerbosityCompact:
+		ds.editorEnabled = false
+		ds.othersAsNarrative = false
+		ds.explainabilityLineEnabled = false
+		ds.stanceBadgesEnabled = false
+		ds.bulletMaxPerCluster = compactBulletsPerCluster
+	case llm.VerbosityDetailed:
+		ds.editorDetailedItems = true
+		ds.explainabilityLineEnabled = true
+	}
+}
+
+func (rc *digestRenderContext) isCompact() bool {
+	return rc.settings.verbosity == llm.VerbosityCompact
+}
+
+// llmTone returns the tone passed to narrative and cluster summary prompts,
+// including the length instruction for the verbosity mode.
+func (rc *digestRenderContext) llmTone() string {
+	return llm.WithVerbosity(rc.settings.digestTone, rc.settings.verbosity)
+}
+
+// displaySummary shortens summaries to their first sentence in compact mode.
+func (rc *digestRenderContext) displaySummary(summary string) string {
+	if !rc.isCompact() {
+		return summary
+	}
+
+	return firstSentence(summary)
+}
+
+// firstSentence returns text up to the first sentence-ending punctuation that
+// is followed by whitespace, so decimals and URLs are not cut.
+func firstSentence(text string) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+
+	for i, r := range runes {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+
+		if i+1 < len(runes) && unicode.IsSpace(runes[i+1]) {
+			return string(runes[:i+1])
+		}
+	}
+
+	return text
+}
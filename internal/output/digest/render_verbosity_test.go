@@ -0,0 +1,84 @@
+package digest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+)
+
+func TestFirstSentence(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Rates rise by 0.25 points. Markets fell sharply.", "Rates rise by 0.25 points."},
+		{"Is this the end? Probably not.", "Is this the end?"},
+		{"No terminal punctuation", "No terminal punctuation"},
+		{"  Single sentence.  ", "Single sentence."},
+	}
+
+	for _, tt := range tests {
+		if got := firstSentence(tt.in); got != tt.want {
+			t.Errorf("firstSentence(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestApplyVerbosity(t *testing.T) {
+	base := digestSettings{
+		editorEnabled:             true,
+		othersAsNarrative:         true,
+		explainabilityLineEnabled: true,
+		bulletMaxPerCluster:       3,
+	}
+
+	compact := base
+	compact.applyVerbosity(llm.VerbosityCompact)
+
+	if compact.editorEnabled || compact.othersAsNarrative || compact.explainabilityLineEnabled {
+		t.Errorf("compact verbosity should disable narrative and detail lines: %+v", compact)
+	}
+
+	if compact.bulletMaxPerCluster != compactBulletsPerCluster {
+		t.Errorf("compact bulletMaxPerCluster = %d, want %d", compact.bulletMaxPerCluster, compactBulletsPerCluster)
+	}
+
+	detailed := base
+	detailed.explainabilityLineEnabled = false
+	detailed.applyVerbosity(llm.VerbosityDetailed)
+
+	if !detailed.editorDetailedItems || !detailed.explainabilityLineEnabled {
+		t.Errorf("detailed verbosity should render detailed items: %+v", detailed)
+	}
+
+	standard := base
+	standard.applyVerbosity(llm.VerbosityStandard)
+	standard.verbosity = ""
+
+	if !reflect.DeepEqual(standard, base) {
+		t.Errorf("standard verbosity should not change settings: %+v", standard)
+	}
+}
+
+func TestVerbosityRenderHelpers(t *testing.T) {
+	rc := &digestRenderContext{settings: digestSettings{digestTone: llm.ToneBrief}}
+
+	if !rc.usesClusterSummaryCache() || rc.llmTone() != llm.ToneBrief {
+		t.Error("unset verbosity should behave as standard")
+	}
+
+	rc.settings.verbosity = llm.VerbosityCompact
+
+	if rc.usesClusterSummaryCache() {
+		t.Error("compact digests should not reuse standard cluster summaries")
+	}
+
+	if got := rc.displaySummary("First. Second."); got != "First." {
+		t.Errorf("displaySummary() = %q, want %q", got, "First.")
+	}
+
+	if rc.llmTone() == llm.ToneBrief {
+		t.Error("llmTone() should carry the compact verbosity")
+	}
+}
@@ -0,0 +1,93 @@
+package digest
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/dedup"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// repeatStory is a candidate item that repeats an item of a recent digest.
+type repeatStory struct {
+	item       db.Item
+	posted     db.PostedItemEmbedding
+	similarity float32
+}
+
+// suppressRepeatStories drops items whose story was already covered in one of
+// the last RepeatStoryDigests posted digests, unless they moved far enough
+// from every posted item to count as a new development.
+func (s *Scheduler) suppressRepeatStories(ctx context.Context, items []db.Item, logger *zerolog.Logger) []db.Item {
+	if s.cfg.RepeatStoryDigests <= 0 || len(items) == 0 {
+		return items
+	}
+
+	posted, err := s.database.GetRecentlyPostedItems(ctx, s.cfg.RepeatStoryDigests)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to get recently posted items")
+
+		return items
+	}
+
+	kept, repeats := splitRepeatStories(items, posted, s.cfg.StoryLinkThreshold, s.cfg.RepeatStoryMinDelta)
+
+	for _, r := range repeats {
+		logger.Debug().
+			Str("skipped_id", r.item.ID).
+			Str("posted_id", r.posted.ItemID).
+			Str("digest_id", r.posted.DigestID).
+			Str("story_id", r.posted.StoryID).
+			Float32("similarity", r.similarity).
+			Msg("Skipping story covered in a recent digest")
+	}
+
+	if len(repeats) > 0 {
+		observability.RepeatStoriesSuppressed.Add(float64(len(repeats)))
+		logger.Info().Int("suppressed", len(repeats)).Int("digests", s.cfg.RepeatStoryDigests).Msg("Suppressed repeat stories")
+	}
+
+	return kept
+}
+
+// splitRepeatStories separates items that repeat a posted item. An item is
+// covered when its closest posted item reaches coveredThreshold, and repeats
+// it when the embedding delta (1 - similarity) stays below minDelta. Items
+// without embeddings are always kept.
+func splitRepeatStories(items []db.Item, posted []db.PostedItemEmbedding, coveredThreshold, minDelta float32) (kept []db.Item, repeats []repeatStory) {
+	for _, item := range items {
+		best, ok := closestPostedItem(item, posted)
+		if ok && best.similarity >= coveredThreshold && 1-best.similarity < minDelta {
+			repeats = append(repeats, best)
+
+			continue
+		}
+
+		kept = append(kept, item)
+	}
+
+	return kept, repeats
+}
+
+func closestPostedItem(item db.Item, posted []db.PostedItemEmbedding) (repeatStory, bool) {
+	best := repeatStory{item: item}
+	found := false
+
+	if len(item.Embedding) == 0 {
+		return best, false
+	}
+
+	for _, p := range posted {
+		if len(p.Embedding) == 0 {
+			continue
+		}
+
+		if similarity := dedup.CosineSimilarity(item.Embedding, p.Embedding); !found || similarity > best.similarity {
+			best.posted, best.similarity, found = p, similarity, true
+		}
+	}
+
+	return best, found
+}
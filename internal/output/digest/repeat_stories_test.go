@@ -0,0 +1,35 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSplitRepeatStories(t *testing.T) {
+	posted := []db.PostedItemEmbedding{
+		{ItemID: "posted", DigestID: "d1", StoryID: "s1", Embedding: []float32{1, 0, 0}},
+	}
+
+	items := []db.Item{
+		{ID: "repost", Embedding: []float32{1, 0.05, 0}},
+		{ID: "development", Embedding: []float32{1, 0.7, 0}},
+		{ID: "unrelated", Embedding: []float32{0, 0, 1}},
+		{ID: "no-embedding"},
+	}
+
+	kept, repeats := splitRepeatStories(items, posted, 0.8, 0.15)
+
+	if len(repeats) != 1 || repeats[0].item.ID != "repost" || repeats[0].posted.StoryID != "s1" {
+		t.Fatalf("repeats = %v, want repost of story s1", repeats)
+	}
+
+	if len(kept) != 3 || kept[0].ID != "development" || kept[1].ID != "unrelated" || kept[2].ID != "no-embedding" {
+		t.Errorf("kept = %v, want development, unrelated and no-embedding", kept)
+	}
+
+	kept, repeats = splitRepeatStories(items, nil, 0.8, 0.15)
+	if len(kept) != len(items) || len(repeats) != 0 {
+		t.Errorf("without posted items kept %d and suppressed %d, want all kept", len(kept), len(repeats))
+	}
+}
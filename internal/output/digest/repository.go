@@ -24,19 +24,45 @@ type Repository interface {
 	SaveDigest(ctx context.Context, id string, start, end time.Time, chatID, msgID int64) (string, error)
 	SaveDigestError(ctx context.Context, start, end time.Time, chatID int64, err error) error
 	SaveDigestEntries(ctx context.Context, digestID string, entries []db.DigestEntry) error
+	SaveDigestItems(ctx context.Context, digestID string, itemIDs []string) error
+	GetRecentlyPostedItems(ctx context.Context, digests int) ([]db.PostedItemEmbedding, error)
+	SaveClusterSummary(ctx context.Context, s db.ClusterSummary) error
+	GetPreviousClusterSummary(ctx context.Context, clusterID string, since time.Time) (*db.PreviousClusterSummary, error)
+	GetClaimStateChanges(ctx context.Context, from, to *time.Time, limit int) ([]db.ClaimStateChange, error)
+	GetChannelOverlapPairs(ctx context.Context, minJaccard float64) ([]db.ChannelOverlapPair, error)
 	GetDigestCoverImage(ctx context.Context, start, end time.Time, threshold float32) ([]byte, error)
+	GetCachedDigestCover(ctx context.Context, key string) ([]byte, bool, error)
+	SaveCachedDigestCover(ctx context.Context, key string, image []byte) error
+	GetCachedMediaCollage(ctx context.Context, key string) ([]byte, bool, error)
+	SaveCachedMediaCollage(ctx context.Context, key string, itemIDs []string, image []byte) error
 
 	// Item operations
 	GetItemsForWindow(ctx context.Context, start, end time.Time, threshold float32, limit int) ([]db.Item, error)
+	GetItemsForWindowDiverse(ctx context.Context, start, end time.Time, threshold float32, limit int, caps db.DiversityCaps) ([]db.Item, error)
 	GetItemsForWindowWithMedia(ctx context.Context, start, end time.Time, threshold float32, limit int) ([]db.ItemWithMedia, error)
+	GetForwardOrigins(ctx context.Context, rawMessageIDs []string) (map[string]db.ForwardHop, error)
 	CountItemsInWindow(ctx context.Context, start, end time.Time) (int, error)
 	CountReadyItemsInWindow(ctx context.Context, start, end time.Time) (int, error)
 	MarkItemsAsDigested(ctx context.Context, ids []string) error
+	GetCarryOverItems(ctx context.Context, since, before time.Time, threshold float32, limit int) ([]db.Item, error)
+	RecordMissedDigests(ctx context.Context, since, before time.Time, threshold float32) (int64, error)
+	ListChannelGroups(ctx context.Context) ([]db.ChannelGroup, error)
+	GetChannelGroupItems(ctx context.Context, groupID string, start, end time.Time, threshold float32, limit int, caps db.DiversityCaps) ([]db.Item, error)
+	MarkChannelGroupDigested(ctx context.Context, groupID string, windowEnd time.Time) error
+	GetDueChannelTrials(ctx context.Context) ([]db.ChannelTrial, error)
+	GetChannelTrialReport(ctx context.Context, trial db.ChannelTrial, importanceThreshold float32, samples int) (db.ChannelTrialReport, error)
+	MarkChannelTrialReported(ctx context.Context, channelID string) error
 	GetItemEmbedding(ctx context.Context, id string) ([]float32, error)
 	GetBacklogCount(ctx context.Context) (int, error)
 	GetLinksForMessage(ctx context.Context, msgID string) ([]domain.ResolvedLink, error)
 	GetFactChecksForItems(ctx context.Context, itemIDs []string) (map[string]db.FactCheckMatch, error)
 	GetEvidenceForItems(ctx context.Context, itemIDs []string) (map[string][]db.ItemEvidenceWithSource, error)
+	GetNumericFactsForItems(ctx context.Context, itemIDs []string) (map[string][]db.NumericFact, error)
+	GetQuotesForItems(ctx context.Context, itemIDs []string) (map[string][]db.ItemQuote, error)
+	GetCalendarEvents(ctx context.Context, from, to time.Time, limit int) ([]db.CalendarEvent, error)
+	GetItemRegions(ctx context.Context, itemIDs []string) (map[string][]string, error)
+	GetItemSafetyFlags(ctx context.Context, itemIDs []string) (map[string][]string, error)
+	GetOrCreateItemDeepLinks(ctx context.Context, itemIDs []string) (map[string]string, error)
 	GetClusterSummaryCache(ctx context.Context, digestLanguage string, since time.Time) ([]db.ClusterSummaryCacheEntry, error)
 	GetClusterSummaryCacheEntry(ctx context.Context, digestLanguage, fingerprint string) (*db.ClusterSummaryCacheEntry, error)
 	UpsertClusterSummaryCache(ctx context.Context, entry *db.ClusterSummaryCacheEntry) error
@@ -62,6 +88,7 @@ type Repository interface {
 	GetActiveChannels(ctx context.Context) ([]db.Channel, error)
 	GetChannelsForAutoWeight(ctx context.Context) ([]db.ChannelForAutoWeight, error)
 	GetChannelStatsRolling(ctx context.Context, channelID string, since time.Time) (*db.RollingStats, error)
+	GetChannelClickStats(ctx context.Context, since time.Time) ([]db.ChannelClickStats, error)
 	UpdateChannelAutoWeight(ctx context.Context, channelID string, weight float32) error
 	UpdateChannelRelevanceDelta(ctx context.Context, channelID string, delta float32, enabled bool) error
 	CollectAndSaveChannelStats(ctx context.Context, start, end time.Time) error
@@ -69,6 +96,47 @@ type Repository interface {
 	GetItemStatusStats(ctx context.Context, since time.Time) (db.ItemStatusStats, error)
 	GetItemRatingSummary(ctx context.Context, since time.Time) ([]db.RatingSummary, error)
 	GetWeightedChannelRatingSummary(ctx context.Context, since time.Time, halfLifeDays float64) ([]db.WeightedRatingSummary, error)
+	GetChannelTopicCounts(ctx context.Context, start, end time.Time) ([]db.ChannelTopicCount, error)
+	GetScoreDistributions(ctx context.Context, versions []string) ([]db.ScoreDistribution, error)
+	GetWhatIfItems(ctx context.Context, since, until time.Time) ([]db.WhatIfItem, error)
+	InsertQualityEvaluation(ctx context.Context, eval *db.QualityEvaluation) error
+	GetDailyLLMUsage(ctx context.Context) (*db.LLMUsageSummary, error)
+	GetDigestWindowCounts(ctx context.Context, start, end time.Time) (db.DigestWindowCounts, error)
+	GetPreviousDigestFeedback(ctx context.Context, excludeID string) (db.DigestFeedback, bool, error)
+	GetUnrefinedItemSources(ctx context.Context, itemIDs []string) (map[string]db.RawMessage, error)
+	SaveSummaryRefinement(ctx context.Context, itemID, summary, model string) error
+	RefreshChannelActivityHealth(ctx context.Context, now time.Time) (int, error)
+	GetUnnotifiedChannelHealthEvents(ctx context.Context, limit int) ([]db.ChannelHealthEvent, error)
+	MarkChannelHealthEventsNotified(ctx context.Context, ids []string) error
+	GetChannelCoordination(ctx context.Context, minScore float32, limit int) ([]db.ChannelCoordinationEntry, error)
+
+	// Roll-up operations
+	GetRollupStories(ctx context.Context, start, end time.Time, limit int) ([]db.RollupStory, error)
+	GetTopRatedItems(ctx context.Context, start, end time.Time, limit int) ([]db.RatedItem, error)
+	GetWeeklyDiff(ctx context.Context, from, to time.Time, limit int) ([]db.ResearchWeeklyDiff, error)
+	GetWatchlistStats(ctx context.Context, start, end time.Time) ([]db.WatchlistStat, error)
+
+	// Search watch operations
+	ListSearchWatches(ctx context.Context) ([]db.SearchWatch, error)
+	SearchResearchItems(ctx context.Context, params db.ResearchSearchParams) ([]db.ResearchItemSearchResult, *db.ResearchSearchResultCount, error)
+	RecordSearchWatchMatches(ctx context.Context, watchID int64, itemIDs []string) ([]string, error)
+	MarkSearchWatchChecked(ctx context.Context, id int64, checkedAt time.Time, matched bool) error
+
+	// Watchlist operations
+	ListWatchlistEntities(ctx context.Context) ([]db.WatchlistEntity, error)
+	GetWatchlistMentions(ctx context.Context, e db.WatchlistEntity, from, to time.Time, limit int) ([]db.WatchlistMention, int, error)
+	RecordWatchlistAlerts(ctx context.Context, watchID int64, itemIDs []string) ([]string, error)
+	MarkWatchlistAlerted(ctx context.Context, id int64, alertedAt time.Time) error
+
+	// Shadow digest operations
+	SaveShadowDigest(ctx context.Context, sd db.ShadowDigest) error
+
+	// Story operations
+	FindStoryCandidate(ctx context.Context, clusterID string, since time.Time) (db.StoryCandidate, error)
+	LinkClustersToStory(ctx context.Context, storyID string, clusterIDs []string, similarity float32, title string) (string, error)
+	GetStory(ctx context.Context, storyID string) (*db.Story, error)
+	GetStoryItems(ctx context.Context, storyID string, limit int) ([]db.StoryItem, error)
+	SaveStoryTimeline(ctx context.Context, storyID string, events []db.StoryEvent) error
 }
 
 // Compile-time assertion that *db.DB implements Repository.
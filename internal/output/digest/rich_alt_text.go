@@ -0,0 +1,47 @@
+package digest
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	// SettingDigestAltText selects how media alt text is shown in rich digests.
+	SettingDigestAltText = "digest_alt_text"
+	// AltTextOff leaves alt text out of captions (default).
+	AltTextOff = "off"
+	// AltTextCaption adds alt text as an italic caption line.
+	AltTextCaption = "caption"
+	// AltTextSpoiler adds alt text as a caption line hidden behind a spoiler.
+	AltTextSpoiler = "spoiler"
+
+	// altTextSeparator joins the alt texts of the images in a collage.
+	altTextSeparator = "; "
+)
+
+// IsValidAltTextMode reports whether mode is a digest_alt_text value.
+func IsValidAltTextMode(mode string) bool {
+	switch mode {
+	case AltTextOff, AltTextCaption, AltTextSpoiler:
+		return true
+	default:
+		return false
+	}
+}
+
+// LoadAltTextMode returns the configured digest_alt_text mode, or AltTextOff
+// when it is unset or invalid.
+func LoadAltTextMode(ctx context.Context, store settingsReader) string {
+	var mode string
+
+	if err := store.GetSetting(ctx, SettingDigestAltText, &mode); err != nil {
+		return AltTextOff
+	}
+
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if !IsValidAltTextMode(mode) {
+		return AltTextOff
+	}
+
+	return mode
+}
@@ -0,0 +1,149 @@
+package digest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/collage"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// CollageStore caches composed media collages.
+type CollageStore interface {
+	GetCachedMediaCollage(ctx context.Context, key string) ([]byte, bool, error)
+	SaveCachedMediaCollage(ctx context.Context, key string, itemIDs []string, image []byte) error
+}
+
+// BuildRichItems converts items into rich digest entries. The items of a
+// cluster become one entry at the position of its first item; when several
+// of them have images, the entry shows a collage of them.
+func BuildRichItems(ctx context.Context, store CollageStore, items []db.ItemWithMedia, clusters []db.ClusterWithItems, logger *zerolog.Logger) []RichDigestItem {
+	clusterOf := clusterMembership(items, clusters)
+	entries := make([]RichDigestItem, 0, len(items))
+	images := make([][][]byte, 0, len(items))
+	imageItems := make([][]string, 0, len(items))
+	altTexts := make([][]string, 0, len(items))
+	entryOf := make(map[string]int)
+
+	for _, item := range items {
+		clusterID, clustered := clusterOf[item.ID]
+
+		idx, seen := entryOf[clusterID]
+		if !clustered || !seen {
+			idx = len(entries)
+			entries = append(entries, newRichItem(item))
+			images = append(images, nil)
+			imageItems = append(imageItems, nil)
+			altTexts = append(altTexts, nil)
+
+			if clustered {
+				entryOf[clusterID] = idx
+			}
+		} else {
+			entries[idx].Related++
+		}
+
+		if len(item.SafetyFlags) > 0 {
+			entries[idx].Sensitive = true
+		}
+
+		if len(item.MediaData) > 0 {
+			images[idx] = append(images[idx], item.MediaData)
+			imageItems[idx] = append(imageItems[idx], item.ID)
+
+			// Only the images that make it into a collage are described
+			if item.AltText != "" && len(images[idx]) <= collage.MaxImages {
+				altTexts[idx] = append(altTexts[idx], item.AltText)
+			}
+		}
+	}
+
+	for idx := range entries {
+		switch {
+		case len(images[idx]) > 1:
+			entries[idx].MediaData = clusterCollage(ctx, store, images[idx], imageItems[idx], logger)
+		case len(images[idx]) == 1:
+			entries[idx].MediaData = images[idx][0]
+		}
+
+		entries[idx].AltText = strings.Join(altTexts[idx], altTextSeparator)
+	}
+
+	return entries
+}
+
+func newRichItem(item db.ItemWithMedia) RichDigestItem {
+	return RichDigestItem{
+		Summary:       item.Summary,
+		Topic:         item.Topic,
+		Importance:    item.ImportanceScore,
+		Channel:       item.SourceChannel,
+		ChannelID:     item.SourceChannelID,
+		MsgID:         item.SourceMsgID,
+		MediaData:     item.MediaData,
+		VideoDuration: item.VideoDuration,
+	}
+}
+
+// clusterMembership maps item IDs to their cluster for clusters with at
+// least two of the items; other items are shown on their own.
+func clusterMembership(items []db.ItemWithMedia, clusters []db.ClusterWithItems) map[string]string {
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[item.ID] = true
+	}
+
+	membership := make(map[string]string)
+
+	for _, c := range clusters {
+		var members []string
+
+		for _, item := range c.Items {
+			if present[item.ID] {
+				members = append(members, item.ID)
+			}
+		}
+
+		if len(members) < 2 {
+			continue
+		}
+
+		for _, id := range members {
+			membership[id] = c.ID
+		}
+	}
+
+	return membership
+}
+
+// clusterCollage returns the collage of a cluster's images, composing it only
+// when none is cached. It falls back to the first image when composing fails.
+// The cached collage records the items its images came from, so purging
+// them also drops the collage.
+func clusterCollage(ctx context.Context, store CollageStore, images [][]byte, itemIDs []string, logger *zerolog.Logger) []byte {
+	images = images[:min(len(images), collage.MaxImages)]
+	itemIDs = itemIDs[:len(images)]
+	key := collage.Key(images)
+
+	cached, found, err := store.GetCachedMediaCollage(ctx, key)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to read media collage cache")
+	} else if found && len(cached) > 0 {
+		return cached
+	}
+
+	image, err := collage.Compose(images)
+	if err != nil {
+		logger.Debug().Err(err).Msg("could not compose media collage, using the first image")
+
+		return images[0]
+	}
+
+	if err := store.SaveCachedMediaCollage(ctx, key, itemIDs, image); err != nil {
+		logger.Warn().Err(err).Msg("failed to cache media collage")
+	}
+
+	return image
+}
@@ -0,0 +1,84 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+type stubCollageStore struct {
+	collages map[string][]byte
+	saves    int
+}
+
+func (s *stubCollageStore) GetCachedMediaCollage(_ context.Context, key string) ([]byte, bool, error) {
+	image, ok := s.collages[key]
+
+	return image, ok, nil
+}
+
+func (s *stubCollageStore) SaveCachedMediaCollage(_ context.Context, key string, _ []string, image []byte) error {
+	s.collages[key] = image
+	s.saves++
+
+	return nil
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 30))); err != nil {
+		t.Fatalf("encode test image: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestBuildRichItems(t *testing.T) {
+	img := testPNG(t)
+	items := []db.ItemWithMedia{
+		{Item: db.Item{ID: "a", Summary: "Lead", AltText: "Lead photo"}, MediaData: img},
+		{Item: db.Item{ID: "solo", Summary: "Solo", AltText: "Solo photo"}, MediaData: img},
+		{Item: db.Item{ID: "b", Summary: "Member", AltText: "Member photo"}, MediaData: img},
+		{Item: db.Item{ID: "c", Summary: "Text only", AltText: "ignored"}},
+	}
+	clusters := []db.ClusterWithItems{
+		{ID: "c1", Items: []db.Item{{ID: "a"}, {ID: "b"}, {ID: "c"}}},
+		{ID: "c2", Items: []db.Item{{ID: "solo"}, {ID: "elsewhere"}}},
+	}
+	store := &stubCollageStore{collages: map[string][]byte{}}
+	logger := zerolog.Nop()
+
+	entries := BuildRichItems(context.Background(), store, items, clusters, &logger)
+	if len(entries) != 2 {
+		t.Fatalf("BuildRichItems() returned %d entries, want 2", len(entries))
+	}
+
+	if entries[0].Summary != "Lead" || entries[0].Related != 2 {
+		t.Errorf("cluster entry = %q with %d related, want Lead with 2", entries[0].Summary, entries[0].Related)
+	}
+
+	if bytes.Equal(entries[0].MediaData, img) || len(entries[0].MediaData) == 0 {
+		t.Error("cluster entry should show a collage")
+	}
+
+	if entries[0].AltText != "Lead photo; Member photo" || entries[1].AltText != "Solo photo" {
+		t.Errorf("alt texts = %q, %q, want the collage's joined", entries[0].AltText, entries[1].AltText)
+	}
+
+	if entries[1].Summary != "Solo" || entries[1].Related != 0 || !bytes.Equal(entries[1].MediaData, img) {
+		t.Errorf("solo entry = %+v, want its own image", entries[1].Summary)
+	}
+
+	again := BuildRichItems(context.Background(), store, items, clusters, &logger)
+	if store.saves != 1 || !bytes.Equal(again[0].MediaData, entries[0].MediaData) {
+		t.Errorf("collage should be reused from the cache, saved %d times", store.saves)
+	}
+}
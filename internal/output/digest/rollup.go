@@ -0,0 +1,307 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Roll-up kinds.
+const (
+	RollupWeekly  = "weekly"
+	RollupMonthly = "monthly"
+)
+
+const (
+	// SettingRollupWeeklyEnabled and SettingRollupMonthlyEnabled toggle the
+	// scheduled retrospectives posted to the target chat.
+	SettingRollupWeeklyEnabled  = "rollup_weekly_enabled"
+	SettingRollupMonthlyEnabled = "rollup_monthly_enabled"
+	// SettingRollupHour is the local hour after which a due roll-up is posted.
+	SettingRollupHour = "rollup_hour"
+
+	// DefaultRollupHour posts roll-ups in the morning after the period ends.
+	DefaultRollupHour = 9
+
+	// rollupLastPostedKeyFmt stores the last posted period per kind, so
+	// restarts and multiple instances do not post a period twice.
+	rollupLastPostedKeyFmt = "rollup_last_posted:%s"
+
+	rollupMaxStories = 10
+	rollupMaxRated   = 5
+	rollupMaxTrends  = 8
+	daysPerWeek      = 7
+)
+
+var errUnknownRollupKind = errors.New("unknown roll-up kind")
+
+// rollupPeriod is a complete week or month covered by a roll-up.
+type rollupPeriod struct {
+	kind  string
+	start time.Time
+	end   time.Time
+	key   string
+	label string
+}
+
+// RollupData is the data model of the roll-up template.
+type RollupData struct {
+	Title     string
+	Label     string
+	Period    string
+	ItemCount int
+	Stories   []db.RollupStory
+	TopRated  []RollupRatedItem
+	Trends    []db.ResearchWeeklyDiff
+	Watchlist []db.WatchlistStat
+}
+
+// RollupRatedItem is a top-rated item with a link to its source message.
+type RollupRatedItem struct {
+	Summary string
+	Topic   string
+	URL     string
+	Good    int
+	Bad     int
+}
+
+const rollupTemplateText = `📆 <b>{{.Title}}</b>
+<i>{{.Label}}</i> · {{.ItemCount}} items
+{{if .Stories}}
+🗂 <b>Biggest stories</b>
+{{range $i, $s := .Stories}}{{inc $i}}. <b>{{$s.Topic}}</b>: {{$s.Summary}} <i>({{$s.ItemCount}} posts, {{$s.ChannelCount}} channels)</i>
+{{end}}{{end}}{{if .TopRated}}
+⭐ <b>Top rated by readers</b>
+{{range .TopRated}}• {{.Summary}} <a href="{{.URL}}">source</a> (👍 {{.Good}})
+{{end}}{{end}}{{if .Trends}}
+📈 <b>Topic trends</b> vs previous {{.Period}}
+{{range .Trends}}• {{.Topic}} <code>{{printf "%+d" .Delta}}</code>
+{{end}}{{end}}{{if .Watchlist}}
+👤 <b>Watchlist</b>
+{{range .Watchlist}}• <b>{{.Name}}</b>: {{.Mentions}} mentions in {{.Channels}} channels
+{{end}}{{end}}`
+
+var rollupTemplate = template.Must(template.New("rollup").Funcs(templateFuncs).Option("missingkey=error").Parse(rollupTemplateText))
+
+// rollupPeriodFor returns the last complete period of kind before now.
+// Weeks run Monday to Monday; months run from the first of the month.
+func rollupPeriodFor(kind string, now time.Time) (rollupPeriod, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch kind {
+	case RollupWeekly:
+		end := today.AddDate(0, 0, -((int(today.Weekday()) + daysPerWeek - 1) % daysPerWeek))
+		start := end.AddDate(0, 0, -daysPerWeek)
+		year, week := start.ISOWeek()
+
+		return rollupPeriod{
+			kind:  kind,
+			start: start,
+			end:   end,
+			key:   fmt.Sprintf("%d-W%02d", year, week),
+			label: fmt.Sprintf("%s – %s", start.Format("Jan 2"), end.AddDate(0, 0, -1).Format("Jan 2, 2006")),
+		}, nil
+	case RollupMonthly:
+		end := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		start := end.AddDate(0, -1, 0)
+
+		return rollupPeriod{
+			kind:  kind,
+			start: start,
+			end:   end,
+			key:   start.Format("2006-01"),
+			label: start.Format("January 2006"),
+		}, nil
+	default:
+		return rollupPeriod{}, fmt.Errorf("%w: %s", errUnknownRollupKind, kind)
+	}
+}
+
+func rollupTitle(kind string) (title, period string) {
+	if kind == RollupMonthly {
+		return "Monthly roll-up", "month"
+	}
+
+	return "Weekly roll-up", "week"
+}
+
+func rollupEnabledKey(kind string) string {
+	if kind == RollupMonthly {
+		return SettingRollupMonthlyEnabled
+	}
+
+	return SettingRollupWeeklyEnabled
+}
+
+// maybeRunRollups posts weekly and monthly roll-ups once their period is complete.
+func (s *Scheduler) maybeRunRollups(ctx context.Context) {
+	for _, kind := range []string{RollupWeekly, RollupMonthly} {
+		var enabled bool
+		if err := s.database.GetSetting(ctx, rollupEnabledKey(kind), &enabled); err != nil || !enabled {
+			continue
+		}
+
+		logger := s.logger.With().Str(LogFieldTask, "rollup-"+kind).Logger()
+
+		if err := s.postRollupIfDue(ctx, kind, time.Now(), &logger); err != nil {
+			logger.Error().Err(err).Msg("failed to post roll-up")
+		}
+	}
+}
+
+func (s *Scheduler) postRollupIfDue(ctx context.Context, kind string, now time.Time, logger *zerolog.Logger) error {
+	if loc, ok := s.resolveScheduleLocation(ctx, logger); ok {
+		now = now.In(loc)
+	}
+
+	hour := DefaultRollupHour
+	if err := s.database.GetSetting(ctx, SettingRollupHour, &hour); err != nil {
+		logger.Debug().Err(err).Msg("could not get rollup_hour from DB, using default")
+	}
+
+	period, err := rollupPeriodFor(kind, now)
+	if err != nil {
+		return err
+	}
+
+	lastKey := ""
+	lastPostedSetting := fmt.Sprintf(rollupLastPostedKeyFmt, kind)
+
+	if err := s.database.GetSetting(ctx, lastPostedSetting, &lastKey); err != nil {
+		logger.Debug().Err(err).Msg("no previous roll-up recorded")
+	}
+
+	if now.Hour() < hour || lastKey == period.key {
+		return nil
+	}
+
+	text, err := s.buildRollup(ctx, period, logger)
+	if err != nil {
+		return err
+	}
+
+	if text != "" {
+		if _, err := s.bot.SendDigest(ctx, s.currentTargetChatID(ctx, logger), text, ""); err != nil {
+			return fmt.Errorf("send %s roll-up: %w", kind, err)
+		}
+
+		logger.Info().Str("period", period.key).Msg("Posted roll-up digest")
+	} else {
+		logger.Info().Str("period", period.key).Msg("No stories for roll-up period, skipping")
+	}
+
+	if err := s.database.SaveSetting(ctx, lastPostedSetting, period.key); err != nil {
+		return fmt.Errorf("save last %s roll-up: %w", kind, err)
+	}
+
+	return nil
+}
+
+// BuildRollup renders the roll-up of kind for the last complete period before now.
+// It returns "" when the period has no stories or ratings.
+func (s *Scheduler) BuildRollup(ctx context.Context, kind string, now time.Time, logger *zerolog.Logger) (string, error) {
+	if loc, ok := s.resolveScheduleLocation(ctx, logger); ok {
+		now = now.In(loc)
+	}
+
+	period, err := rollupPeriodFor(kind, now)
+	if err != nil {
+		return "", err
+	}
+
+	return s.buildRollup(ctx, period, logger)
+}
+
+func (s *Scheduler) buildRollup(ctx context.Context, period rollupPeriod, logger *zerolog.Logger) (string, error) {
+	stories, err := s.database.GetRollupStories(ctx, period.start, period.end, rollupMaxStories)
+	if err != nil {
+		return "", fmt.Errorf("get roll-up stories: %w", err)
+	}
+
+	rated, err := s.database.GetTopRatedItems(ctx, period.start, period.end, rollupMaxRated)
+	if err != nil {
+		return "", fmt.Errorf("get top rated items: %w", err)
+	}
+
+	if len(stories) == 0 && len(rated) == 0 {
+		return "", nil
+	}
+
+	trends, err := s.database.GetWeeklyDiff(ctx, period.start, period.end, rollupMaxTrends)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to get topic trends for roll-up")
+	}
+
+	itemCount, err := s.database.CountItemsInWindow(ctx, period.start, period.end)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to count items for roll-up")
+	}
+
+	watchlist, err := s.database.GetWatchlistStats(ctx, period.start, period.end)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to get watchlist stats for roll-up")
+	}
+
+	if len(watchlist) > watchlistMaxRollup {
+		watchlist = watchlist[:watchlistMaxRollup]
+	}
+
+	title, periodName := rollupTitle(period.kind)
+	data := RollupData{
+		Title:     title,
+		Label:     period.label,
+		Period:    periodName,
+		ItemCount: itemCount,
+		Stories:   stories,
+		TopRated:  rollupRatedItems(rated),
+		Trends:    rollupTrends(trends),
+		Watchlist: watchlist,
+	}
+
+	return renderSanitizedTemplate(rollupTemplate, data)
+}
+
+func rollupRatedItems(rated []db.RatedItem) []RollupRatedItem {
+	items := make([]RollupRatedItem, 0, len(rated))
+
+	for _, r := range rated {
+		items = append(items, RollupRatedItem{
+			Summary: r.Summary,
+			Topic:   r.Topic,
+			URL:     itemMessageURL(db.Item{SourceChannel: r.ChannelUsername, SourceChannelID: r.ChannelPeerID, SourceMsgID: r.MessageID}),
+			Good:    r.Good,
+			Bad:     r.Bad,
+		})
+	}
+
+	return items
+}
+
+// rollupTrends drops unnamed topics and topics whose volume did not change.
+func rollupTrends(diffs []db.ResearchWeeklyDiff) []db.ResearchWeeklyDiff {
+	trends := make([]db.ResearchWeeklyDiff, 0, len(diffs))
+
+	for _, d := range diffs {
+		if d.Topic != "" && d.Delta != 0 {
+			trends = append(trends, d)
+		}
+	}
+
+	return trends
+}
+
+// currentTargetChatID returns the target chat, preferring the DB setting over config.
+func (s *Scheduler) currentTargetChatID(ctx context.Context, logger *zerolog.Logger) int64 {
+	targetChatID := s.cfg.TargetChatID
+	if err := s.database.GetSetting(ctx, SettingTargetChatID, &targetChatID); err != nil {
+		logger.Debug().Err(err).Msg("could not get target_chat_id from DB, using default")
+	}
+
+	return targetChatID
+}
@@ -0,0 +1,84 @@
+package digest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestRollupPeriodFor(t *testing.T) {
+	// Wednesday, 2026-10-14.
+	now := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+
+	weekly, err := rollupPeriodFor(RollupWeekly, now)
+	if err != nil {
+		t.Fatalf("rollupPeriodFor(weekly) error = %v", err)
+	}
+
+	if !weekly.start.Equal(time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)) || !weekly.end.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("weekly period = %v - %v, want Oct 5 - Oct 12", weekly.start, weekly.end)
+	}
+
+	if weekly.key != "2026-W41" || weekly.label != "Oct 5 – Oct 11, 2026" {
+		t.Errorf("weekly key/label = %q/%q", weekly.key, weekly.label)
+	}
+
+	// On a Monday the previous week is already complete.
+	monday, err := rollupPeriodFor(RollupWeekly, time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC))
+	if err != nil || monday.key != weekly.key {
+		t.Errorf("Monday weekly key = %q, err = %v, want %q", monday.key, err, weekly.key)
+	}
+
+	monthly, err := rollupPeriodFor(RollupMonthly, now)
+	if err != nil {
+		t.Fatalf("rollupPeriodFor(monthly) error = %v", err)
+	}
+
+	if !monthly.start.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)) || monthly.key != "2026-09" || monthly.label != "September 2026" {
+		t.Errorf("monthly period = %v key %q label %q", monthly.start, monthly.key, monthly.label)
+	}
+
+	if _, err := rollupPeriodFor("daily", now); !errors.Is(err, errUnknownRollupKind) {
+		t.Errorf("rollupPeriodFor(daily) error = %v, want errUnknownRollupKind", err)
+	}
+}
+
+func TestRollupTemplate(t *testing.T) {
+	data := RollupData{
+		Title:     "Weekly roll-up",
+		Label:     "Oct 5 – Oct 11, 2026",
+		Period:    "week",
+		ItemCount: 120,
+		Stories:   []db.RollupStory{{Topic: "Rates", Summary: "Central bank raises rates", ItemCount: 12, ChannelCount: 4}},
+		TopRated: rollupRatedItems([]db.RatedItem{
+			{Summary: "Budget passes", ChannelUsername: "news", MessageID: 5, Good: 3},
+		}),
+		Trends:    rollupTrends([]db.ResearchWeeklyDiff{{Topic: "Economy", Delta: 7}, {Topic: "Flat", Delta: 0}, {Delta: 3}}),
+		Watchlist: []db.WatchlistStat{{Name: "Olaf Scholz", Mentions: 9, Channels: 3}},
+	}
+
+	got, err := renderSanitizedTemplate(rollupTemplate, data)
+	if err != nil {
+		t.Fatalf("render roll-up error = %v", err)
+	}
+
+	for _, want := range []string{
+		"📆 <b>Weekly roll-up</b>",
+		"1. <b>Rates</b>: Central bank raises rates <i>(12 posts, 4 channels)</i>",
+		`<a href="https://t.me/news/5">source</a> (👍 3)`,
+		"vs previous week",
+		"Economy <code>+7</code>",
+		"• <b>Olaf Scholz</b>: 9 mentions in 3 channels",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("roll-up missing %q in:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "Flat") {
+		t.Error("roll-up should skip unchanged topics")
+	}
+}
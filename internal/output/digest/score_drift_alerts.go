@@ -0,0 +1,160 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingScoreDriftAlertsEnabled toggles daily score drift alerts.
+	SettingScoreDriftAlertsEnabled = "score_drift_alerts_enabled"
+	// SettingScoreReferenceVersion names the scoring version other versions are
+	// compared with. The oldest version is used when it is unset.
+	SettingScoreReferenceVersion = "score_reference_version"
+
+	// scoreDriftCheckInterval is how often score distributions are compared.
+	scoreDriftCheckInterval = 24 * time.Hour
+	// scoreDriftActiveWindow limits the check to versions that scored items recently.
+	scoreDriftActiveWindow = 7 * 24 * time.Hour
+	// ScoreDriftBound is the quantile shift of relevance or importance scores at
+	// or above which a version is reported as drifted.
+	ScoreDriftBound = 0.15
+)
+
+// ScoreDrift describes how far a scoring version's scores moved from the reference.
+type ScoreDrift struct {
+	Version         string
+	Count           int
+	RelevanceShift  float64
+	ImportanceShift float64
+}
+
+// Shift is the larger of the relevance and importance shifts.
+func (d ScoreDrift) Shift() float64 {
+	return math.Max(d.RelevanceShift, d.ImportanceShift)
+}
+
+// maybeRunScoreDriftAlerts alerts admins daily when a scoring version drifted.
+func (s *Scheduler) maybeRunScoreDriftAlerts(ctx context.Context, lastRun *time.Time) {
+	enabled := true
+	if err := s.database.GetSetting(ctx, SettingScoreDriftAlertsEnabled, &enabled); err != nil {
+		s.logger.Debug().Err(err).Msg("score_drift_alerts_enabled not set, defaulting to true")
+	}
+
+	now := time.Now()
+	if !enabled || (!lastRun.IsZero() && now.Sub(*lastRun) < scoreDriftCheckInterval) {
+		return
+	}
+
+	logger := s.logger.With().Str(LogFieldTask, "score-drift-alerts").Logger()
+
+	if err := s.SendScoreDriftAlerts(ctx, now, &logger); err != nil {
+		logger.Error().Err(err).Msg("failed to send score drift alerts")
+
+		return
+	}
+
+	*lastRun = now
+}
+
+// SendScoreDriftAlerts compares the score distributions of recently active
+// scoring versions with the reference version and notifies admins about
+// versions that drifted past ScoreDriftBound.
+func (s *Scheduler) SendScoreDriftAlerts(ctx context.Context, now time.Time, logger *zerolog.Logger) error {
+	var reference string
+	if err := s.database.GetSetting(ctx, SettingScoreReferenceVersion, &reference); err != nil {
+		logger.Debug().Err(err).Msg("score_reference_version not set, using the oldest version")
+	}
+
+	dists, err := s.database.GetScoreDistributions(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("get score distributions: %w", err)
+	}
+
+	ref, ok := db.ReferenceScoreDistribution(dists, reference)
+	if !ok || !ref.HasEnoughSamples() {
+		logger.Debug().Str("reference", reference).Msg("no reference score distribution yet")
+
+		return nil
+	}
+
+	drifts := DetectScoreDrift(dists, ref, now.Add(-scoreDriftActiveWindow), ScoreDriftBound)
+	if len(drifts) == 0 {
+		logger.Info().Msg("no score drift detected")
+
+		return nil
+	}
+
+	if err := s.bot.SendNotification(ctx, formatScoreDriftAlert(ref.Version, drifts)); err != nil {
+		return fmt.Errorf("send score drift alert: %w", err)
+	}
+
+	logger.Info().Int("versions", len(drifts)).Msg("Sent score drift alert")
+
+	return nil
+}
+
+// DetectScoreDrift returns the versions active since activeSince whose score
+// quantiles shifted from the reference by at least bound, most drifted first.
+func DetectScoreDrift(dists []db.ScoreDistribution, ref db.ScoreDistribution, activeSince time.Time, bound float64) []ScoreDrift {
+	var drifts []ScoreDrift
+
+	for _, d := range dists {
+		if d.Version == ref.Version || d.LastSeen.Before(activeSince) || !d.HasEnoughSamples() {
+			continue
+		}
+
+		drift := CompareScoreDistributions(d, ref)
+		if drift.Shift() >= bound {
+			drifts = append(drifts, drift)
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Shift() != drifts[j].Shift() {
+			return drifts[i].Shift() > drifts[j].Shift()
+		}
+
+		return drifts[i].Version < drifts[j].Version
+	})
+
+	return drifts
+}
+
+// CompareScoreDistributions measures how far d moved from ref.
+func CompareScoreDistributions(d, ref db.ScoreDistribution) ScoreDrift {
+	return ScoreDrift{
+		Version:         d.Version,
+		Count:           d.Count,
+		RelevanceShift:  db.ScoreQuantileShift(d.Relevance, ref.Relevance),
+		ImportanceShift: db.ScoreQuantileShift(d.Importance, ref.Importance),
+	}
+}
+
+// formatScoreDriftAlert renders drifted versions as an HTML admin notification.
+func formatScoreDriftAlert(reference string, drifts []ScoreDrift) string {
+	var sb strings.Builder
+
+	sb.WriteString("📉 <b>Score Drift</b>\n")
+	sb.WriteString(fmt.Sprintf("Score distributions compared with <code>%s</code>:\n\n", html.EscapeString(reference)))
+
+	for _, d := range drifts {
+		sb.WriteString(fmt.Sprintf("• <code>%s</code> (%d items) — relevance shift <code>%.2f</code>, importance shift <code>%.2f</code>\n",
+			html.EscapeString(d.Version), d.Count, d.RelevanceShift, d.ImportanceShift))
+	}
+
+	sb.WriteString("\n💡 Thresholds tuned for the reference may no longer fit. Enable quantile normalization with ")
+	sb.WriteString("<code>/scores normalize quantile</code>, or re-tune thresholds and run ")
+	sb.WriteString("<code>/scores reference &lt;version&gt;</code>.")
+
+	return sb.String()
+}
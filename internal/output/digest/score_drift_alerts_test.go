@@ -0,0 +1,65 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func testScoreDistribution(version string, lastSeen time.Time, shift float64) db.ScoreDistribution {
+	quantiles := make([]float64, len(db.ScoreQuantileGrid))
+	for i, p := range db.ScoreQuantileGrid {
+		quantiles[i] = p*(1-shift) + shift
+	}
+
+	return db.ScoreDistribution{
+		Version:    version,
+		Count:      db.MinScoreDistributionSamples,
+		LastSeen:   lastSeen,
+		Relevance:  quantiles,
+		Importance: db.ScoreQuantileGrid,
+	}
+}
+
+func TestDetectScoreDrift(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	activeSince := now.Add(-scoreDriftActiveWindow)
+
+	ref := testScoreDistribution("old@v1", now.Add(-30*24*time.Hour), 0)
+	small := testScoreDistribution("small@v1", now, 0.5)
+	small.Count = db.MinScoreDistributionSamples - 1
+
+	dists := []db.ScoreDistribution{
+		ref,
+		testScoreDistribution("stale@v1", now.Add(-10*24*time.Hour), 0.5),
+		testScoreDistribution("close@v1", now, 0.05),
+		testScoreDistribution("mild@v2", now, 0.3),
+		testScoreDistribution("strong@v1", now, 0.6),
+		small,
+	}
+
+	drifts := DetectScoreDrift(dists, ref, activeSince, ScoreDriftBound)
+	if len(drifts) != 2 {
+		t.Fatalf("expected 2 drifted versions, got %+v", drifts)
+	}
+
+	if drifts[0].Version != "strong@v1" || drifts[1].Version != "mild@v2" {
+		t.Errorf("unexpected order: %s, %s", drifts[0].Version, drifts[1].Version)
+	}
+
+	if drifts[0].ImportanceShift != 0 || drifts[0].Shift() != drifts[0].RelevanceShift {
+		t.Errorf("unexpected shifts: %+v", drifts[0])
+	}
+}
+
+func TestFormatScoreDriftAlert(t *testing.T) {
+	text := formatScoreDriftAlert("old@v1", []ScoreDrift{{Version: "new<b>@v2", Count: 150, RelevanceShift: 0.21, ImportanceShift: 0.04}})
+
+	for _, want := range []string{"<code>old@v1</code>", "new&lt;b&gt;@v2", "150 items", "<code>0.21</code>", "/scores reference"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("alert missing %q:\n%s", want, text)
+		}
+	}
+}
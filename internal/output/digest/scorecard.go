@@ -0,0 +1,295 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingDigestScorecardEnabled toggles the quality scorecard sent to admins after each digest.
+	SettingDigestScorecardEnabled = "digest_scorecard_enabled"
+
+	// scorecardTopicsShown caps how many topics the scorecard lists.
+	scorecardTopicsShown = 5
+	scorecardUntitled    = "Other"
+)
+
+// llmCallStats accumulates the number and duration of LLM calls made while
+// building digests. The scheduler works on one window at a time, so the
+// difference between two snapshots belongs to the digest built in between.
+type llmCallStats struct {
+	calls atomic.Int64
+	nanos atomic.Int64
+}
+
+type llmCallSnapshot struct {
+	calls int64
+	total time.Duration
+}
+
+func (st *llmCallStats) snapshot() llmCallSnapshot {
+	if st == nil {
+		return llmCallSnapshot{}
+	}
+
+	return llmCallSnapshot{calls: st.calls.Load(), total: time.Duration(st.nanos.Load())}
+}
+
+func (st *llmCallStats) observe(start time.Time) {
+	st.calls.Add(1)
+	st.nanos.Add(int64(time.Since(start)))
+}
+
+// since returns the calls made after snapshot prev.
+func (sn llmCallSnapshot) since(prev llmCallSnapshot) llmCallSnapshot {
+	return llmCallSnapshot{calls: sn.calls - prev.calls, total: sn.total - prev.total}
+}
+
+// timedLLMClient records the latency of the LLM calls digest building makes.
+type timedLLMClient struct {
+	llm.Client
+
+	stats *llmCallStats
+}
+
+func (c *timedLLMClient) ProcessBatch(ctx context.Context, messages []llm.MessageInput, targetLanguage, model, tone string) ([]llm.BatchResult, error) {
+	defer c.stats.observe(time.Now())
+
+	results, err := c.Client.ProcessBatch(ctx, messages, targetLanguage, model, tone)
+	if err != nil {
+		return nil, fmt.Errorf("process batch: %w", err)
+	}
+
+	return results, nil
+}
+
+func (c *timedLLMClient) CompleteText(ctx context.Context, prompt, model string) (string, error) {
+	defer c.stats.observe(time.Now())
+
+	text, err := c.Client.CompleteText(ctx, prompt, model)
+	if err != nil {
+		return "", fmt.Errorf("complete text: %w", err)
+	}
+
+	return text, nil
+}
+
+func (c *timedLLMClient) GenerateNarrativeWithEvidence(ctx context.Context, items []domain.Item, evidence llm.ItemEvidence, targetLanguage, model, tone string) (string, error) {
+	defer c.stats.observe(time.Now())
+
+	narrative, err := c.Client.GenerateNarrativeWithEvidence(ctx, items, evidence, targetLanguage, model, tone)
+	if err != nil {
+		return "", fmt.Errorf("generate narrative: %w", err)
+	}
+
+	return narrative, nil
+}
+
+func (c *timedLLMClient) SummarizeClusterWithEvidence(ctx context.Context, items []domain.Item, evidence llm.ItemEvidence, targetLanguage, model, tone string) (string, error) {
+	defer c.stats.observe(time.Now())
+
+	summary, err := c.Client.SummarizeClusterWithEvidence(ctx, items, evidence, targetLanguage, model, tone)
+	if err != nil {
+		return "", fmt.Errorf("summarize cluster: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (c *timedLLMClient) GenerateClusterTopic(ctx context.Context, items []domain.Item, targetLanguage, model string) (string, error) {
+	defer c.stats.observe(time.Now())
+
+	topic, err := c.Client.GenerateClusterTopic(ctx, items, targetLanguage, model)
+	if err != nil {
+		return "", fmt.Errorf("generate cluster topic: %w", err)
+	}
+
+	return topic, nil
+}
+
+func (c *timedLLMClient) CompressSummariesForCover(ctx context.Context, summaries []string) ([]string, error) {
+	defer c.stats.observe(time.Now())
+
+	phrases, err := c.Client.CompressSummariesForCover(ctx, summaries)
+	if err != nil {
+		return nil, fmt.Errorf("compress summaries for cover: %w", err)
+	}
+
+	return phrases, nil
+}
+
+func (c *timedLLMClient) GenerateDigestCover(ctx context.Context, topics []string, narrative string, opts llm.CoverOptions) ([]byte, error) {
+	defer c.stats.observe(time.Now())
+
+	image, err := c.Client.GenerateDigestCover(ctx, topics, narrative, opts)
+	if err != nil {
+		return nil, fmt.Errorf("generate digest cover: %w", err)
+	}
+
+	return image, nil
+}
+
+// DigestScorecard summarizes a posted digest for admins.
+type DigestScorecard struct {
+	Start, End    time.Time
+	Items         int
+	Clusters      int
+	Topics        []TopicCount
+	AvgImportance float32
+	AvgRelevance  float32
+	LLMCalls      int64
+	LLMLatency    time.Duration
+	// CostToday and RequestsToday are the LLM spend of the whole day so far,
+	// since usage is only stored per day.
+	CostToday     float64
+	RequestsToday int64
+	Window        db.DigestWindowCounts
+	Previous      *db.DigestFeedback
+}
+
+// TopicCount is the number of digest items of one topic.
+type TopicCount struct {
+	Topic string
+	Count int
+}
+
+// sendDigestScorecard sends admins the scorecard of the digest just posted.
+// llmBefore is the LLM call snapshot taken before the digest was built.
+func (s *Scheduler) sendDigestScorecard(ctx context.Context, digestID string, start, end time.Time, items []db.Item, clusters []db.ClusterWithItems, llmBefore llmCallSnapshot, logger *zerolog.Logger) {
+	enabled := true
+	if err := s.database.GetSetting(ctx, SettingDigestScorecardEnabled, &enabled); err != nil {
+		logger.Debug().Err(err).Msg("digest_scorecard_enabled not set, defaulting to true")
+	}
+
+	if !enabled || s.bot == nil {
+		return
+	}
+
+	llmCalls := s.llmStats.snapshot().since(llmBefore)
+	sc := buildDigestScorecard(start, end, items, clusters, llmCalls)
+
+	if usage, err := s.database.GetDailyLLMUsage(ctx); err != nil {
+		logger.Warn().Err(err).Msg("failed to load LLM usage for scorecard")
+	} else if usage != nil {
+		sc.CostToday, sc.RequestsToday = usage.TotalCostUSD, usage.TotalRequests
+	}
+
+	if counts, err := s.database.GetDigestWindowCounts(ctx, start, end); err != nil {
+		logger.Warn().Err(err).Msg("failed to load window counts for scorecard")
+	} else {
+		sc.Window = counts
+	}
+
+	if prev, ok, err := s.database.GetPreviousDigestFeedback(ctx, digestID); err != nil {
+		logger.Warn().Err(err).Msg("failed to load previous digest feedback for scorecard")
+	} else if ok {
+		sc.Previous = &prev
+	}
+
+	if err := s.bot.SendNotification(ctx, formatDigestScorecard(sc)); err != nil {
+		logger.Warn().Err(err).Msg("failed to send digest scorecard")
+
+		return
+	}
+
+	logger.Debug().Str("digest_id", digestID).Msg("Sent digest scorecard")
+}
+
+// buildDigestScorecard computes the parts of the scorecard that come from
+// the digest itself.
+func buildDigestScorecard(start, end time.Time, items []db.Item, clusters []db.ClusterWithItems, llmCalls llmCallSnapshot) DigestScorecard {
+	sc := DigestScorecard{Start: start, End: end, Items: len(items), Clusters: len(clusters), LLMCalls: llmCalls.calls}
+
+	if llmCalls.calls > 0 {
+		sc.LLMLatency = llmCalls.total / time.Duration(llmCalls.calls)
+	}
+
+	counts := make(map[string]int)
+
+	for _, item := range items {
+		sc.AvgImportance += item.ImportanceScore
+		sc.AvgRelevance += item.RelevanceScore
+
+		topic := strings.TrimSpace(item.Topic)
+		if topic == "" {
+			topic = scorecardUntitled
+		}
+
+		counts[topic]++
+	}
+
+	if len(items) > 0 {
+		sc.AvgImportance /= float32(len(items))
+		sc.AvgRelevance /= float32(len(items))
+	}
+
+	for topic, n := range counts {
+		sc.Topics = append(sc.Topics, TopicCount{Topic: topic, Count: n})
+	}
+
+	sort.Slice(sc.Topics, func(i, j int) bool {
+		if sc.Topics[i].Count != sc.Topics[j].Count {
+			return sc.Topics[i].Count > sc.Topics[j].Count
+		}
+
+		return sc.Topics[i].Topic < sc.Topics[j].Topic
+	})
+
+	return sc
+}
+
+// formatDigestScorecard renders the scorecard as an HTML admin notification.
+func formatDigestScorecard(sc DigestScorecard) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "📋 <b>Digest Scorecard</b> (%s – %s)\n\n",
+		sc.Start.Format(TimeFormatHourMinute), sc.End.Format(TimeFormatHourMinute))
+	fmt.Fprintf(&sb, "• Items: <code>%d</code> in <code>%d</code> clusters\n", sc.Items, sc.Clusters)
+	fmt.Fprintf(&sb, "• Avg importance <code>%.2f</code> · relevance <code>%.2f</code>\n", sc.AvgImportance, sc.AvgRelevance)
+
+	if len(sc.Topics) > 0 {
+		topics := make([]string, 0, scorecardTopicsShown)
+		for i, t := range sc.Topics {
+			if i == scorecardTopicsShown {
+				topics = append(topics, fmt.Sprintf("+%d more", len(sc.Topics)-scorecardTopicsShown))
+
+				break
+			}
+
+			topics = append(topics, fmt.Sprintf("%s (%d)", html.EscapeString(t.Topic), t.Count))
+		}
+
+		fmt.Fprintf(&sb, "• Topics: %s\n", strings.Join(topics, ", "))
+	}
+
+	if sc.LLMCalls > 0 {
+		fmt.Fprintf(&sb, "• LLM: <code>%d</code> calls, avg <code>%.1fs</code>\n", sc.LLMCalls, sc.LLMLatency.Seconds())
+	}
+
+	fmt.Fprintf(&sb, "• Cost today: <code>$%.2f</code> (%d requests)\n", sc.CostToday, sc.RequestsToday)
+	fmt.Fprintf(&sb, "• Dedup folds: <code>%d</code> · quota drops: <code>%d</code> · snoozed: <code>%d</code>\n",
+		sc.Window.DedupFolds, sc.Window.QuotaDrops, sc.Window.Snoozed)
+
+	if sc.Previous != nil {
+		p := sc.Previous
+		fmt.Fprintf(&sb, "\n<b>Previous digest</b> (%s): 👍 %d · 👎 %d · items good %d, bad %d, irrelevant %d",
+			p.PostedAt.Format(TimeFormatHourMinute), p.Up, p.Down, p.Good, p.Bad, p.Irrelevant)
+
+		if p.Total() == 0 {
+			sb.WriteString(" (no ratings)")
+		}
+	}
+
+	return sb.String()
+}
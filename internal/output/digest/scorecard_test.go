@@ -0,0 +1,98 @@
+package digest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestBuildDigestScorecard(t *testing.T) {
+	items := []db.Item{
+		{Topic: "Tech", ImportanceScore: 0.8, RelevanceScore: 0.9},
+		{Topic: "Tech", ImportanceScore: 0.6, RelevanceScore: 0.7},
+		{Topic: "", ImportanceScore: 0.4, RelevanceScore: 0.5},
+	}
+
+	sc := buildDigestScorecard(time.Time{}, time.Time{}, items, make([]db.ClusterWithItems, 2),
+		llmCallSnapshot{calls: 4, total: 10 * time.Second})
+
+	if sc.Items != 3 || sc.Clusters != 2 || sc.LLMCalls != 4 || sc.LLMLatency != 2500*time.Millisecond {
+		t.Fatalf("scorecard = %+v", sc)
+	}
+
+	if diff := sc.AvgImportance - 0.6; diff > 1e-6 || diff < -1e-6 {
+		t.Errorf("AvgImportance = %v, want 0.6", sc.AvgImportance)
+	}
+
+	if len(sc.Topics) != 2 || sc.Topics[0] != (TopicCount{Topic: "Tech", Count: 2}) || sc.Topics[1].Topic != scorecardUntitled {
+		t.Errorf("Topics = %+v, want Tech (2), Other (1)", sc.Topics)
+	}
+}
+
+func TestFormatDigestScorecard(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	sc := DigestScorecard{
+		Start:         start,
+		End:           start.Add(time.Hour),
+		Items:         7,
+		Clusters:      3,
+		Topics:        []TopicCount{{Topic: "A & B", Count: 4}, {Topic: "C", Count: 3}},
+		AvgImportance: 0.61,
+		AvgRelevance:  0.72,
+		LLMCalls:      5,
+		LLMLatency:    1500 * time.Millisecond,
+		CostToday:     1.234,
+		RequestsToday: 120,
+		Window:        db.DigestWindowCounts{DedupFolds: 12, QuotaDrops: 2, Snoozed: 1},
+		Previous:      &db.DigestFeedback{PostedAt: start.Add(-time.Hour), Up: 2, Good: 3, Bad: 1},
+	}
+
+	got := formatDigestScorecard(sc)
+
+	for _, want := range []string{
+		"Digest Scorecard</b> (09:00 – 10:00)",
+		"Items: <code>7</code> in <code>3</code> clusters",
+		"Avg importance <code>0.61</code> · relevance <code>0.72</code>",
+		"Topics: A &amp; B (4), C (3)",
+		"LLM: <code>5</code> calls, avg <code>1.5s</code>",
+		"Cost today: <code>$1.23</code> (120 requests)",
+		"Dedup folds: <code>12</code> · quota drops: <code>2</code> · snoozed: <code>1</code>",
+		"<b>Previous digest</b> (08:00): 👍 2 · 👎 0 · items good 3, bad 1, irrelevant 0",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("scorecard missing %q:\n%s", want, got)
+		}
+	}
+
+	sc.Previous = &db.DigestFeedback{}
+	if got := formatDigestScorecard(sc); !strings.Contains(got, "(no ratings)") {
+		t.Errorf("scorecard without ratings missing marker:\n%s", got)
+	}
+}
+
+type stubCompleteTextClient struct {
+	llm.Client
+}
+
+func (stubCompleteTextClient) CompleteText(context.Context, string, string) (string, error) {
+	return "ok", nil
+}
+
+func TestTimedLLMClientRecordsCalls(t *testing.T) {
+	stats := &llmCallStats{}
+	client := &timedLLMClient{Client: stubCompleteTextClient{}, stats: stats}
+
+	before := stats.snapshot()
+
+	if text, err := client.CompleteText(context.Background(), "prompt", ""); err != nil || text != "ok" {
+		t.Fatalf("CompleteText() = %q, %v", text, err)
+	}
+
+	if got := stats.snapshot().since(before); got.calls != 1 {
+		t.Errorf("calls = %d, want 1", got.calls)
+	}
+}
@@ -0,0 +1,173 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// searchWatchProcessingLag widens each watch window backwards so items that
+	// were posted before the last check but processed after it are still found.
+	searchWatchProcessingLag = 6 * time.Hour
+	// searchWatchMaxResults caps how many results a single watch run fetches.
+	searchWatchMaxResults = 50
+	// searchWatchMaxListed caps how many matches are listed in one notification.
+	searchWatchMaxListed = 5
+	// searchWatchSummaryRunes truncates match summaries in notifications.
+	searchWatchSummaryRunes = 200
+)
+
+// maybeRunSearchWatches re-runs saved searches and notifies admins about new matches.
+func (s *Scheduler) maybeRunSearchWatches(ctx context.Context) {
+	logger := s.logger.With().Str(LogFieldTask, "search-watches").Logger()
+
+	if err := s.RunSearchWatches(ctx, time.Now(), &logger); err != nil {
+		logger.Error().Err(err).Msg("failed to run search watches")
+	}
+}
+
+// RunSearchWatches runs every saved search watch once. Each watch searches items
+// posted since its previous check; items already reported are skipped.
+func (s *Scheduler) RunSearchWatches(ctx context.Context, now time.Time, logger *zerolog.Logger) error {
+	watches, err := s.database.ListSearchWatches(ctx)
+	if err != nil {
+		return fmt.Errorf("list search watches: %w", err)
+	}
+
+	for _, w := range watches {
+		if err := s.runSearchWatch(ctx, w, now); err != nil {
+			logger.Warn().Err(err).Int64("watch_id", w.ID).Msg("search watch failed")
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) runSearchWatch(ctx context.Context, w db.SearchWatch, now time.Time) error {
+	from := searchWatchFrom(w)
+
+	results, _, err := s.database.SearchResearchItems(ctx, db.ResearchSearchParams{
+		Query:    w.Query,
+		From:     &from,
+		SearchAt: now,
+		Channel:  w.Channel,
+		Topic:    w.Topic,
+		Limit:    searchWatchMaxResults,
+	})
+	if err != nil {
+		return fmt.Errorf("search items: %w", err)
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+
+	fresh, err := s.database.RecordSearchWatchMatches(ctx, w.ID, ids)
+	if err != nil {
+		return fmt.Errorf("record matches: %w", err)
+	}
+
+	if len(fresh) > 0 {
+		if err := s.bot.SendNotification(ctx, formatSearchWatchAlert(w, filterSearchResults(results, fresh))); err != nil {
+			return fmt.Errorf("send search watch alert: %w", err)
+		}
+	}
+
+	if err := s.database.MarkSearchWatchChecked(ctx, w.ID, now, len(fresh) > 0); err != nil {
+		return fmt.Errorf("mark checked: %w", err)
+	}
+
+	return nil
+}
+
+// searchWatchFrom returns the start of the next search window for a watch:
+// the previous check minus processing lag, but never before the watch existed.
+func searchWatchFrom(w db.SearchWatch) time.Time {
+	if w.LastCheckedAt == nil {
+		return w.CreatedAt
+	}
+
+	from := w.LastCheckedAt.Add(-searchWatchProcessingLag)
+	if from.Before(w.CreatedAt) {
+		return w.CreatedAt
+	}
+
+	return from
+}
+
+// filterSearchResults keeps results whose IDs are in ids, preserving result order.
+func filterSearchResults(results []db.ResearchItemSearchResult, ids []string) []db.ResearchItemSearchResult {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+
+	filtered := make([]db.ResearchItemSearchResult, 0, len(ids))
+
+	for _, r := range results {
+		if keep[r.ID] {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// FormatSearchWatchFilters describes a watch's query and filters for display.
+func FormatSearchWatchFilters(w db.SearchWatch) string {
+	parts := []string{fmt.Sprintf("<code>%s</code>", html.EscapeString(w.Query))}
+
+	if w.Channel != "" {
+		parts = append(parts, "channel:@"+html.EscapeString(strings.TrimPrefix(w.Channel, "@")))
+	}
+
+	if w.Topic != "" {
+		parts = append(parts, "topic:"+html.EscapeString(w.Topic))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatSearchWatchAlert renders new matches of a watch as an HTML admin notification.
+func formatSearchWatchAlert(w db.SearchWatch, matches []db.ResearchItemSearchResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🔔 <b>Watch #%d</b> %s\n%d new match(es):\n\n", w.ID, FormatSearchWatchFilters(w), len(matches)))
+
+	for i, m := range matches {
+		if i == searchWatchMaxListed {
+			sb.WriteString(fmt.Sprintf("…and %d more\n", len(matches)-searchWatchMaxListed))
+
+			break
+		}
+
+		item := db.Item{SourceChannel: m.ChannelUsername, SourceChannelTitle: m.ChannelTitle, SourceChannelID: m.ChannelPeerID, SourceMsgID: m.MessageID}
+		sb.WriteString(fmt.Sprintf("• <a href=\"%s\">%s</a>: %s\n",
+			itemMessageURL(item), html.EscapeString(sourceLabel(item)), html.EscapeString(searchWatchSnippet(m))))
+	}
+
+	return sb.String()
+}
+
+// searchWatchSnippet returns the item summary, or its text, truncated for display.
+func searchWatchSnippet(m db.ResearchItemSearchResult) string {
+	text := m.Summary
+	if text == "" {
+		text = m.Text
+	}
+
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) > searchWatchSummaryRunes {
+		return string(runes[:searchWatchSummaryRunes]) + "…"
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,66 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSearchWatchFrom(t *testing.T) {
+	created := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	checked := created.Add(24 * time.Hour)
+	early := created.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		watch db.SearchWatch
+		want  time.Time
+	}{
+		{"never checked", db.SearchWatch{CreatedAt: created}, created},
+		{"checked", db.SearchWatch{CreatedAt: created, LastCheckedAt: &checked}, checked.Add(-searchWatchProcessingLag)},
+		{"clamped to creation", db.SearchWatch{CreatedAt: created, LastCheckedAt: &early}, created},
+	}
+
+	for _, tt := range tests {
+		if got := searchWatchFrom(tt.watch); !got.Equal(tt.want) {
+			t.Errorf("%s: searchWatchFrom() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatSearchWatchAlert(t *testing.T) {
+	w := db.SearchWatch{ID: 3, Query: "sanctions", Channel: "news", Topic: "Politics"}
+
+	matches := make([]db.ResearchItemSearchResult, 0, searchWatchMaxListed+2)
+	for i := range searchWatchMaxListed + 2 {
+		matches = append(matches, db.ResearchItemSearchResult{Summary: "New <sanctions>", ChannelUsername: "news", MessageID: int64(i + 1)})
+	}
+
+	got := formatSearchWatchAlert(w, matches)
+
+	for _, want := range []string{
+		"<b>Watch #3</b> <code>sanctions</code> channel:@news topic:Politics",
+		"7 new match(es)",
+		`<a href="https://t.me/news/1">news</a>: New &lt;sanctions&gt;`,
+		"…and 2 more",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatSearchWatchAlert() missing %q in:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "https://t.me/news/6") {
+		t.Errorf("formatSearchWatchAlert() listed more than %d matches", searchWatchMaxListed)
+	}
+}
+
+func TestFilterSearchResults(t *testing.T) {
+	results := []db.ResearchItemSearchResult{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	got := filterSearchResults(results, []string{"c", "a"})
+	if len(got) != 2 || got[0].ID != "a" || got[1].ID != "c" {
+		t.Errorf("filterSearchResults() = %+v, want a, c", got)
+	}
+}
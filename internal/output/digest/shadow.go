@@ -0,0 +1,170 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	shadowPromptKeyPrefix = "prompt:"
+	shadowPromptKeySuffix = ":active"
+)
+
+// shadowSettingsRepository serves shadow overrides in place of the stored
+// settings, so a digest can be rendered with experimental settings without
+// changing them.
+type shadowSettingsRepository struct {
+	Repository
+	overrides map[string]json.RawMessage
+}
+
+// GetSetting returns the override for key if there is one, otherwise the
+// stored setting.
+func (r shadowSettingsRepository) GetSetting(ctx context.Context, key string, target interface{}) error {
+	raw, ok := r.overrides[key]
+	if !ok {
+		if err := r.Repository.GetSetting(ctx, key, target); err != nil {
+			return fmt.Errorf("get setting %s: %w", key, err)
+		}
+
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("decode shadow override %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// postShadowDigest renders the digest's items and clusters again with the
+// shadow overrides and posts the result to the shadow target, so admins can
+// compare the variants and rate them separately.
+func (s *Scheduler) postShadowDigest(ctx context.Context, digestID string, start, end time.Time, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) {
+	var chatID int64
+	if err := s.database.GetSetting(ctx, SettingShadowTargetChatID, &chatID); err != nil || chatID == 0 {
+		return
+	}
+
+	var overrides map[string]json.RawMessage
+	if err := s.database.GetSetting(ctx, SettingShadowOverrides, &overrides); err != nil {
+		logger.Debug().Err(err).Msg("could not get shadow_overrides from DB")
+	}
+
+	text, err := s.renderShadowDigest(ctx, overrides, chatID, start, end, items, clusters, logger)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to render shadow digest")
+
+		return
+	}
+
+	shadowID := uuid.New().String()
+
+	msgID, err := s.bot.SendDigest(ctx, chatID, shadowDigestBanner(overrides)+text, shadowID)
+	if err != nil {
+		logger.Warn().Err(err).Int64("chat_id", chatID).Msg("failed to post shadow digest")
+
+		return
+	}
+
+	if err := s.database.SaveShadowDigest(ctx, db.ShadowDigest{
+		ID:        shadowID,
+		DigestID:  digestID,
+		Start:     start,
+		End:       end,
+		ChatID:    chatID,
+		MsgID:     msgID,
+		Overrides: overrides,
+	}); err != nil {
+		logger.Warn().Err(err).Msg("failed to save shadow digest")
+	}
+
+	logger.Info().Int64(LogFieldMsgID, msgID).Int("overrides", len(overrides)).Msg("Shadow digest posted")
+}
+
+// renderShadowDigest renders with settings read through the overrides. Model
+// and prompt version overrides are passed to the LLM calls directly, and the
+// cluster summary cache is bypassed so every cluster summary reflects them.
+func (s *Scheduler) renderShadowDigest(ctx context.Context, overrides map[string]json.RawMessage, chatID int64, start, end time.Time, items []db.Item, clusters []db.ClusterWithItems, logger *zerolog.Logger) (string, error) {
+	shadow := *s
+	shadow.database = shadowSettingsRepository{Repository: s.database, overrides: overrides}
+
+	settings := shadow.getDigestSettings(ctx, chatID, logger)
+	settings.variant = db.DigestVariantShadow
+	settings.narrativeModel = shadowModelOverride(overrides, llm.TaskTypeNarrative)
+	settings.clusterModel = shadowModelOverride(overrides, llm.TaskTypeClusterSummary)
+
+	ctx = llm.WithPromptVersions(ctx, shadowPromptVersions(overrides))
+
+	text, _, _, _, err := shadow.renderDigest(ctx, append([]db.Item(nil), items...), append([]db.ClusterWithItems(nil), clusters...), start, end, settings, logger)
+	if err != nil {
+		return "", err
+	}
+
+	return text, nil
+}
+
+// shadowModelOverride returns the model set for the task through its
+// llm_override_* key, if any.
+func shadowModelOverride(overrides map[string]json.RawMessage, task llm.TaskType) string {
+	for key, t := range llm.DBSettingToTaskType {
+		if t != task {
+			continue
+		}
+
+		var model string
+		if raw, ok := overrides[key]; ok && json.Unmarshal(raw, &model) == nil {
+			return strings.TrimSpace(model)
+		}
+	}
+
+	return ""
+}
+
+// shadowPromptVersions maps "prompt:<name>:active" overrides to prompt versions by name.
+func shadowPromptVersions(overrides map[string]json.RawMessage) map[string]string {
+	versions := make(map[string]string)
+
+	for key, raw := range overrides {
+		if !strings.HasPrefix(key, shadowPromptKeyPrefix) || !strings.HasSuffix(key, shadowPromptKeySuffix) {
+			continue
+		}
+
+		var version string
+		if json.Unmarshal(raw, &version) != nil || strings.TrimSpace(version) == "" {
+			continue
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(key, shadowPromptKeyPrefix), shadowPromptKeySuffix)
+		versions[name] = version
+	}
+
+	return versions
+}
+
+// shadowDigestBanner labels a shadow digest with the settings it overrides.
+func shadowDigestBanner(overrides map[string]json.RawMessage) string {
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, html.EscapeString(key))
+	}
+
+	sort.Strings(keys)
+
+	if len(keys) == 0 {
+		return "🧪 <b>Shadow digest</b> (no overrides)\n\n"
+	}
+
+	return "🧪 <b>Shadow digest</b> with <code>" + strings.Join(keys, "</code>, <code>") + "</code>\n\n"
+}
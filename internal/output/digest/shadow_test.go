@@ -0,0 +1,67 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+)
+
+func testShadowOverrides() map[string]json.RawMessage {
+	return map[string]json.RawMessage{
+		"digest_tone":              json.RawMessage(`"casual"`),
+		"editor_enabled":           json.RawMessage(`true`),
+		"llm_override_narrative":   json.RawMessage(`"gpt-5"`),
+		"prompt:narrative:active":  json.RawMessage(`"v2"`),
+		"prompt:cluster_summary:x": json.RawMessage(`"v3"`),
+	}
+}
+
+func TestShadowSettingsRepositoryOverrides(t *testing.T) {
+	repo := shadowSettingsRepository{overrides: testShadowOverrides()}
+
+	var tone string
+	if err := repo.GetSetting(context.Background(), "digest_tone", &tone); err != nil || tone != "casual" {
+		t.Fatalf("GetSetting(digest_tone) = %q, %v; want casual", tone, err)
+	}
+
+	var editor bool
+	if err := repo.GetSetting(context.Background(), "editor_enabled", &editor); err != nil || !editor {
+		t.Fatalf("GetSetting(editor_enabled) = %v, %v; want true", editor, err)
+	}
+
+	var n int
+	if err := repo.GetSetting(context.Background(), "digest_tone", &n); err == nil {
+		t.Error("expected an error decoding a string override into an int")
+	}
+}
+
+func TestShadowLLMOverrides(t *testing.T) {
+	overrides := testShadowOverrides()
+
+	if got := shadowModelOverride(overrides, llm.TaskTypeNarrative); got != "gpt-5" {
+		t.Errorf("narrative model = %q, want gpt-5", got)
+	}
+
+	if got := shadowModelOverride(overrides, llm.TaskTypeClusterSummary); got != "" {
+		t.Errorf("cluster model = %q, want empty", got)
+	}
+
+	if got, want := shadowPromptVersions(overrides), map[string]string{"narrative": "v2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prompt versions = %v, want %v", got, want)
+	}
+}
+
+func TestShadowDigestBanner(t *testing.T) {
+	banner := shadowDigestBanner(map[string]json.RawMessage{"b": nil, "a": nil})
+	if !strings.Contains(banner, "<code>a</code>, <code>b</code>") {
+		t.Errorf("banner = %q, want sorted keys", banner)
+	}
+
+	if !strings.Contains(shadowDigestBanner(nil), "no overrides") {
+		t.Error("banner without overrides should say so")
+	}
+}
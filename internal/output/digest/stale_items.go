@@ -0,0 +1,137 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingStaleItemPolicy stores the stale item policy as JSON.
+	SettingStaleItemPolicy = "stale_item_policy"
+
+	staleSectionMaxItems = 5
+	staleSectionEmoji    = "🕰"
+)
+
+func (s *Scheduler) loadStaleItemPolicy(ctx context.Context, logger *zerolog.Logger) db.StaleItemPolicy {
+	policy := db.DefaultStaleItemPolicy()
+	if err := s.database.GetSetting(ctx, SettingStaleItemPolicy, &policy); err != nil {
+		logger.Debug().Err(err).Msg("could not get stale_item_policy from DB")
+	}
+
+	return policy
+}
+
+// loadCarryOverItems returns the undigested items from the lookback before the
+// window, split into items that compete in the selection again and stale items
+// for the "Previously missed" section.
+func (s *Scheduler) loadCarryOverItems(ctx context.Context, start time.Time, importanceThreshold float32, policy db.StaleItemPolicy, logger *zerolog.Logger) (carried, stale []db.Item) {
+	if !policy.Active() {
+		return nil, nil
+	}
+
+	items, err := s.database.GetCarryOverItems(ctx, start.Add(-policy.Lookback()), start, importanceThreshold, s.cfg.DigestTopN*DigestPoolMultiplier)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to get carry-over items")
+
+		return nil, nil
+	}
+
+	carried, stale = splitStaleItems(items, policy)
+
+	logger.Debug().Int("carried_over", len(carried)).Int("stale", len(stale)).Msg("Loaded undigested items from earlier windows")
+
+	return carried, stale
+}
+
+// splitStaleItems separates stale items from the ones still carried over.
+// Stale items are dropped unless the policy lists them in a section.
+func splitStaleItems(items []db.Item, policy db.StaleItemPolicy) (carried, stale []db.Item) {
+	for _, item := range items {
+		switch {
+		case !policy.IsStale(item):
+			carried = append(carried, item)
+		case policy.Mode == db.StaleItemsSection && len(stale) < staleSectionMaxItems:
+			stale = append(stale, item)
+		}
+	}
+
+	return carried, stale
+}
+
+// mergeCarryOverItems appends carried-over items that are not in the window already.
+func mergeCarryOverItems(items, carried []db.Item) []db.Item {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		seen[item.ID] = true
+	}
+
+	for _, item := range carried {
+		if !seen[item.ID] {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// recordMissedDigests counts a missed digest for every eligible item of the
+// window and its lookback that the posted digest left out.
+func (s *Scheduler) recordMissedDigests(ctx context.Context, start, end time.Time, importanceThreshold float32, logger *zerolog.Logger) {
+	policy := s.loadStaleItemPolicy(ctx, logger)
+	if !policy.Active() {
+		return
+	}
+
+	missed, err := s.database.RecordMissedDigests(ctx, start.Add(-policy.Lookback()), end, importanceThreshold)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to record missed digests")
+
+		return
+	}
+
+	logger.Debug().Int64("missed_items", missed).Msg("Recorded items left out of the digest")
+}
+
+// getPreviouslyMissedTitle returns the localized "Previously missed" section title.
+func (rc *digestRenderContext) getPreviouslyMissedTitle() string {
+	switch strings.ToLower(rc.settings.digestLanguage) {
+	case "ru":
+		return "Ранее пропущено"
+	case "de":
+		return "Bisher verpasst"
+	case "es":
+		return "Omitido anteriormente"
+	case "fr":
+		return "Précédemment manqué"
+	case "it":
+		return "Perso in precedenza"
+	}
+
+	return "Previously missed"
+}
+
+// buildPreviouslyMissedSection lists stale items that missed earlier digests
+// and returns them so they get marked as digested.
+func (rc *digestRenderContext) buildPreviouslyMissedSection(sb *strings.Builder) []db.Item {
+	if len(rc.settings.previouslyMissed) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(sb, FormatSectionHeader, staleSectionEmoji, rc.getPreviouslyMissedTitle())
+
+	for _, item := range rc.settings.previouslyMissed {
+		links := rc.collectSourceLinks([]db.Item{item})
+		summary := rc.safetyText(htmlutils.SanitizeHTML(rc.displaySummary(item.Summary)), item.ID)
+		fmt.Fprintf(sb, "• %s <i>via %s</i>\n", summary, strings.Join(links, DigestSourceSeparator))
+	}
+
+	return rc.settings.previouslyMissed
+}
@@ -0,0 +1,43 @@
+package digest
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSplitStaleItems(t *testing.T) {
+	items := []db.Item{
+		{ID: "late", MissedDigests: 0},
+		{ID: "once", MissedDigests: 1},
+		{ID: "stale", MissedDigests: 2},
+		{ID: "older", MissedDigests: 5},
+	}
+
+	policy := db.DefaultStaleItemPolicy()
+	policy.Mode = db.StaleItemsSkip
+
+	carried, stale := splitStaleItems(items, policy)
+	if len(carried) != 2 || carried[0].ID != "late" || carried[1].ID != "once" {
+		t.Errorf("carried = %v, want late and once", carried)
+	}
+
+	if len(stale) != 0 {
+		t.Errorf("skip mode returned stale items %v", stale)
+	}
+
+	policy.Mode = db.StaleItemsSection
+
+	_, stale = splitStaleItems(items, policy)
+	if len(stale) != 2 || stale[0].ID != "stale" || stale[1].ID != "older" {
+		t.Errorf("stale = %v, want stale and older", stale)
+	}
+}
+
+func TestMergeCarryOverItems(t *testing.T) {
+	items := mergeCarryOverItems([]db.Item{{ID: "a"}, {ID: "b"}}, []db.Item{{ID: "b"}, {ID: "c"}})
+
+	if len(items) != 3 || items[2].ID != "c" {
+		t.Errorf("merged = %v, want a, b, c", items)
+	}
+}
@@ -0,0 +1,189 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const storyTimelineMaxSources = 30
+
+// updateStories links the digest's clusters to stories from earlier digests
+// and rebuilds the timeline of every story that grew.
+func (s *Scheduler) updateStories(ctx context.Context, clusters []db.ClusterWithItems, logger *zerolog.Logger) {
+	if !s.cfg.StoryTrackingEnabled || len(clusters) == 0 {
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -s.cfg.StoryLookbackDays)
+	updated := make([]string, 0, len(clusters))
+	seen := make(map[string]bool, len(clusters))
+
+	for _, c := range clusters {
+		storyID, err := s.linkClusterToStory(ctx, c, since)
+		if err != nil {
+			logger.Warn().Err(err).Str("cluster_id", c.ID).Msg("failed to link cluster to story")
+
+			continue
+		}
+
+		if storyID != "" && !seen[storyID] {
+			seen[storyID] = true
+
+			updated = append(updated, storyID)
+		}
+	}
+
+	if len(updated) == 0 {
+		return
+	}
+
+	var language string
+	if err := s.database.GetSetting(ctx, SettingDigestLanguage, &language); err != nil {
+		logger.Debug().Err(err).Msg(MsgCouldNotGetDigestLanguage)
+	}
+
+	for _, storyID := range updated {
+		if err := s.refreshStoryTimeline(ctx, storyID, language, logger); err != nil {
+			logger.Warn().Err(err).Str("story_id", storyID).Msg("failed to refresh story timeline")
+
+			continue
+		}
+
+		s.exportStory(ctx, storyID, logger)
+	}
+}
+
+// linkClusterToStory attaches the cluster to the story of the closest earlier
+// cluster, starting a new story when that cluster has none. It returns an
+// empty ID when no earlier cluster is similar enough.
+func (s *Scheduler) linkClusterToStory(ctx context.Context, c db.ClusterWithItems, since time.Time) (string, error) {
+	candidate, err := s.database.FindStoryCandidate(ctx, c.ID, since)
+	if errors.Is(err, db.ErrNoStoryCandidate) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("find story candidate: %w", err)
+	}
+
+	if candidate.Similarity < s.cfg.StoryLinkThreshold {
+		return "", nil
+	}
+
+	clusterIDs := []string{c.ID}
+	if candidate.StoryID == "" {
+		clusterIDs = append(clusterIDs, candidate.ClusterID)
+	}
+
+	storyID, err := s.database.LinkClustersToStory(ctx, candidate.StoryID, clusterIDs, candidate.Similarity, c.Topic)
+	if err != nil {
+		return "", fmt.Errorf("link story: %w", err)
+	}
+
+	return storyID, nil
+}
+
+// refreshStoryTimeline rebuilds and stores the event timeline of a story.
+func (s *Scheduler) refreshStoryTimeline(ctx context.Context, storyID, language string, logger *zerolog.Logger) error {
+	story, err := s.database.GetStory(ctx, storyID)
+	if err != nil {
+		return fmt.Errorf("get story: %w", err)
+	}
+
+	items, err := s.database.GetStoryItems(ctx, storyID, storyTimelineMaxSources)
+	if err != nil {
+		return fmt.Errorf("get story items: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	events := s.generateStoryTimeline(ctx, story.Title, items, language, logger)
+
+	if err := s.database.SaveStoryTimeline(ctx, storyID, events); err != nil {
+		return fmt.Errorf("save story timeline: %w", err)
+	}
+
+	return nil
+}
+
+// generateStoryTimeline asks the LLM for one line per development, falling
+// back to the lead item of each cluster when the LLM is unavailable or its
+// response is invalid.
+func (s *Scheduler) generateStoryTimeline(ctx context.Context, title string, items []db.StoryItem, language string, logger *zerolog.Logger) []db.StoryEvent {
+	if s.llmClient == nil {
+		return fallbackStoryTimeline(items)
+	}
+
+	sources := make([]llm.TimelineSource, len(items))
+	for i, it := range items {
+		sources[i] = llm.TimelineSource{Date: it.TGDate, Summary: storyItemText(it), Channel: it.ChannelUsername}
+	}
+
+	// Pass empty model to let the LLM registry handle task-specific model selection
+	response, err := s.llmClient.CompleteText(ctx, llm.BuildStoryTimelinePrompt(title, sources, language), "")
+	if err != nil {
+		logger.Warn().Err(err).Msg("story timeline generation failed")
+
+		return fallbackStoryTimeline(items)
+	}
+
+	timeline, err := llm.ParseStoryTimeline(response, len(items))
+	if err != nil {
+		logger.Warn().Err(err).Msg("invalid story timeline, using cluster lead items")
+
+		return fallbackStoryTimeline(items)
+	}
+
+	return storyEventsFromTimeline(items, timeline)
+}
+
+// storyEventsFromTimeline dates each LLM event with the item it came from.
+func storyEventsFromTimeline(items []db.StoryItem, timeline []llm.TimelineEvent) []db.StoryEvent {
+	events := make([]db.StoryEvent, 0, len(timeline))
+
+	for _, e := range timeline {
+		it := items[e.Source]
+		events = append(events, db.StoryEvent{Date: it.TGDate, Text: e.Event, ItemID: it.ItemID})
+	}
+
+	return events
+}
+
+// fallbackStoryTimeline uses the most important item of each cluster as its
+// event, in chronological order. items must be sorted by date.
+func fallbackStoryTimeline(items []db.StoryItem) []db.StoryEvent {
+	lead := make(map[string]db.StoryItem)
+
+	for _, it := range items {
+		if cur, ok := lead[it.ClusterID]; !ok || it.ImportanceScore > cur.ImportanceScore {
+			lead[it.ClusterID] = it
+		}
+	}
+
+	events := make([]db.StoryEvent, 0, len(lead))
+
+	for _, it := range items {
+		if lead[it.ClusterID].ItemID != it.ItemID {
+			continue
+		}
+
+		events = append(events, db.StoryEvent{Date: it.TGDate, Text: storyItemText(it), ItemID: it.ItemID})
+	}
+
+	return events
+}
+
+func storyItemText(it db.StoryItem) string {
+	return strings.TrimSpace(htmlutils.StripHTMLTags(it.Summary))
+}
@@ -0,0 +1,53 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func storyTestItems() []db.StoryItem {
+	day := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	return []db.StoryItem{
+		{ItemID: "a1", ClusterID: "a", Summary: "Talks <b>announced</b>", ImportanceScore: 0.5, TGDate: day},
+		{ItemID: "a2", ClusterID: "a", Summary: "Talks confirmed", ImportanceScore: 0.7, TGDate: day.Add(time.Hour)},
+		{ItemID: "b1", ClusterID: "b", Summary: "Talks stalled", ImportanceScore: 0.6, TGDate: day.AddDate(0, 0, 1)},
+	}
+}
+
+func TestFallbackStoryTimeline(t *testing.T) {
+	events := fallbackStoryTimeline(storyTestItems())
+
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want one per cluster", events)
+	}
+
+	if events[0].ItemID != "a2" || events[1].ItemID != "b1" {
+		t.Errorf("event items = %s, %s; want a2, b1", events[0].ItemID, events[1].ItemID)
+	}
+
+	if !events[0].Date.Before(events[1].Date) {
+		t.Error("events are not in chronological order")
+	}
+}
+
+func TestStoryEventsFromTimeline(t *testing.T) {
+	items := storyTestItems()
+
+	events := storyEventsFromTimeline(items, []llm.TimelineEvent{{Source: 0, Event: "Talks announced"}, {Source: 2, Event: "Talks stalled"}})
+
+	if len(events) != 2 || events[0].ItemID != "a1" || events[1].ItemID != "b1" {
+		t.Fatalf("events = %+v", events)
+	}
+
+	if !events[1].Date.Equal(items[2].TGDate) {
+		t.Errorf("event date = %v, want the source item date", events[1].Date)
+	}
+
+	if got := storyItemText(items[0]); got != "Talks announced" {
+		t.Errorf("storyItemText() = %q", got)
+	}
+}
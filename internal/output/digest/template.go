@@ -0,0 +1,305 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Digest templates are versioned like prompts: each version is stored under
+// digest_template:<version> and digest_template:active selects the one in use.
+// The builtin version (or no active version) keeps the hardcoded layout.
+const (
+	TemplateActiveKey      = "digest_template:active"
+	TemplateKeyFmt         = "digest_template:%s"
+	TemplateBuiltinVersion = "builtin"
+
+	// templateMaxOutput caps rendered template size before splitting into messages.
+	templateMaxOutput = 64 * 1024
+)
+
+var (
+	errTemplateEmptyOutput = errors.New("digest template rendered empty output")
+	errTemplateTooLarge    = errors.New("digest template output too large")
+)
+
+// TemplateData is the data model exposed to digest templates.
+type TemplateData struct {
+	Header TemplateHeader
+	Tiers  []TemplateTier
+}
+
+// TemplateHeader describes the digest window and totals.
+type TemplateHeader struct {
+	Title        string
+	Start        time.Time
+	End          time.Time
+	ItemCount    int
+	ChannelCount int
+	TopicCount   int
+}
+
+// WindowTitle is the title with the digest window in UTC, e.g.
+// "Digest for Mar 1 09:00 – 10:00 UTC".
+func (h TemplateHeader) WindowTitle() string {
+	start, end := h.Start.UTC(), h.End.UTC()
+
+	endLayout := "15:04 MST"
+	if start.YearDay() != end.YearDay() || start.Year() != end.Year() {
+		endLayout = "Jan 2 15:04 MST"
+	}
+
+	return fmt.Sprintf("%s %s – %s", h.Title, start.Format("Jan 2 15:04"), end.Format(endLayout))
+}
+
+// TemplateTier is an importance tier (breaking, notable, others). Tiers
+// without content are omitted.
+type TemplateTier struct {
+	Name     string
+	Emoji    string
+	Clusters []TemplateCluster
+}
+
+// TemplateCluster groups items about the same story. Unclustered items are
+// exposed as single-item clusters.
+type TemplateCluster struct {
+	Topic string
+	Items []TemplateItem
+}
+
+// TemplateItem is a single digest item.
+type TemplateItem struct {
+	Summary    string
+	Topic      string
+	Importance float32
+	Relevance  float32
+	Sources    []TemplateSource
+}
+
+// TemplateSource links an item to the Telegram message it came from.
+type TemplateSource struct {
+	Channel string
+	URL     string
+}
+
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+	"inc":   func(i int) int { return i + 1 },
+	"truncate": func(n int, s string) string {
+		runes := []rune(s)
+		if len(runes) <= n {
+			return s
+		}
+
+		return string(runes[:n]) + "…"
+	},
+}
+
+// ParseDigestTemplate parses a digest template with the digest function set.
+func ParseDigestTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("digest").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse digest template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// RenderDigestTemplate executes a digest template and sanitizes the result
+// down to Telegram-supported HTML.
+func RenderDigestTemplate(tmpl *template.Template, data TemplateData) (string, error) {
+	return renderSanitizedTemplate(tmpl, data)
+}
+
+// renderSanitizedTemplate executes tmpl with a capped output size and
+// sanitizes the result; it backs both digest and roll-up templates.
+func renderSanitizedTemplate(tmpl *template.Template, data any) (string, error) {
+	out := &limitedBuffer{limit: templateMaxOutput}
+
+	if err := tmpl.Execute(out, data); err != nil {
+		return "", fmt.Errorf("render digest template: %w", err)
+	}
+
+	rendered := strings.TrimSpace(htmlutils.SanitizeHTML(out.String()))
+	if rendered == "" {
+		return "", errTemplateEmptyOutput
+	}
+
+	return rendered, nil
+}
+
+// ValidateDigestTemplate parses a template and renders it against sample data,
+// so broken templates are rejected before they can be activated.
+func ValidateDigestTemplate(text string) error {
+	tmpl, err := ParseDigestTemplate(text)
+	if err != nil {
+		return err
+	}
+
+	_, err = RenderDigestTemplate(tmpl, SampleTemplateData())
+
+	return err
+}
+
+// SampleTemplateData returns representative data used to validate templates.
+func SampleTemplateData() TemplateData {
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	item := TemplateItem{
+		Summary:    "Central bank <b>raises rates</b> by 25 bps",
+		Topic:      "Economy",
+		Importance: 0.82,
+		Relevance:  0.9,
+		Sources:    []TemplateSource{{Channel: "newschan", URL: "https://t.me/newschan/1"}},
+	}
+
+	return TemplateData{
+		Header: TemplateHeader{Title: "Digest", Start: end.Add(-time.Hour), End: end, ItemCount: 2, ChannelCount: 1, TopicCount: 1},
+		Tiers: []TemplateTier{
+			{Name: "Breaking", Emoji: EmojiBreaking, Clusters: []TemplateCluster{{Topic: item.Topic, Items: []TemplateItem{item, item}}}},
+		},
+	}
+}
+
+// loadActiveTemplate returns the active digest template text, or "" when the
+// built-in layout is in use.
+func (s *Scheduler) loadActiveTemplate(ctx context.Context) (text, version string) {
+	if err := s.database.GetSetting(ctx, TemplateActiveKey, &version); err != nil {
+		return "", ""
+	}
+
+	if version == "" || version == TemplateBuiltinVersion {
+		return "", ""
+	}
+
+	if err := s.database.GetSetting(ctx, fmt.Sprintf(TemplateKeyFmt, version), &text); err != nil {
+		return "", version
+	}
+
+	return text, version
+}
+
+// renderActiveTemplate renders the digest with the active template. It returns
+// false when no template is active or it fails, so the built-in layout is used.
+func (rc *digestRenderContext) renderActiveTemplate(ctx context.Context) (string, bool) {
+	text, version := rc.scheduler.loadActiveTemplate(ctx)
+	if strings.TrimSpace(text) == "" {
+		if version != "" {
+			rc.logger.Warn().Str("version", version).Msg("active digest template not found, using built-in layout")
+		}
+
+		return "", false
+	}
+
+	tmpl, err := ParseDigestTemplate(text)
+	if err == nil {
+		var rendered string
+
+		if rendered, err = RenderDigestTemplate(tmpl, rc.templateData()); err == nil {
+			return rendered, true
+		}
+	}
+
+	rc.logger.Warn().Err(err).Str("version", version).Msg("digest template failed, using built-in layout")
+
+	return "", false
+}
+
+// templateData builds the template data model from the render context.
+func (rc *digestRenderContext) templateData() TemplateData {
+	channels := make(map[string]bool)
+	for _, item := range rc.items {
+		channels[item.SourceChannel] = true
+	}
+
+	data := TemplateData{
+		Header: TemplateHeader{
+			Title:        rc.getHeader(),
+			Start:        rc.displayStart,
+			End:          rc.displayEnd,
+			ItemCount:    len(rc.items),
+			ChannelCount: len(channels),
+			TopicCount:   countDistinctTopics(rc.items),
+		},
+	}
+
+	breakingTitle, notableTitle, alsoTitle := rc.getSectionTitles()
+	breaking, notable, also := rc.categorizeByImportance()
+
+	for _, tier := range []struct {
+		group        clusterGroup
+		emoji, title string
+	}{
+		{breaking, EmojiBreaking, breakingTitle},
+		{notable, EmojiNotable, notableTitle},
+		{also, EmojiStandard, alsoTitle},
+	} {
+		clusters := templateClusters(tier.group)
+		if len(clusters) > 0 {
+			data.Tiers = append(data.Tiers, TemplateTier{Name: tier.title, Emoji: tier.emoji, Clusters: clusters})
+		}
+	}
+
+	return data
+}
+
+func templateClusters(group clusterGroup) []TemplateCluster {
+	clusters := make([]TemplateCluster, 0, len(group.clusters)+len(group.items))
+
+	for _, c := range group.clusters {
+		if len(c.Items) == 0 {
+			continue
+		}
+
+		clusters = append(clusters, TemplateCluster{Topic: c.Topic, Items: templateItems(c.Items)})
+	}
+
+	for _, item := range group.items {
+		clusters = append(clusters, TemplateCluster{Topic: item.Topic, Items: templateItems([]db.Item{item})})
+	}
+
+	return clusters
+}
+
+func templateItems(items []db.Item) []TemplateItem {
+	result := make([]TemplateItem, 0, len(items))
+
+	for _, item := range items {
+		result = append(result, TemplateItem{
+			Summary:    item.Summary,
+			Topic:      item.Topic,
+			Importance: item.ImportanceScore,
+			Relevance:  item.RelevanceScore,
+			Sources:    []TemplateSource{{Channel: sourceLabel(item), URL: itemMessageURL(item)}},
+		})
+	}
+
+	return result
+}
+
+// limitedBuffer stops template execution once output exceeds limit bytes.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("%w: over %d bytes", errTemplateTooLarge, b.limit)
+	}
+
+	n, err := b.Buffer.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("write digest template output: %w", err)
+	}
+
+	return n, nil
+}
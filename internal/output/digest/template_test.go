@@ -0,0 +1,86 @@
+package digest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const testDigestTemplate = `<b>{{.Header.Title}}</b> {{.Header.Start.Format "15:04"}}-{{.Header.End.Format "15:04"}}
+{{range .Tiers}}
+{{.Emoji}} <b>{{.Name}}</b>
+{{range $i, $c := .Clusters}}{{inc $i}}. {{upper $c.Topic}}: {{(index $c.Items 0).Summary}}{{range (index $c.Items 0).Sources}} <a href="{{.URL}}">{{.Channel}}</a>{{end}}
+{{end}}{{end}}`
+
+func TestValidateDigestTemplate(t *testing.T) {
+	if err := ValidateDigestTemplate(testDigestTemplate); err != nil {
+		t.Fatalf("ValidateDigestTemplate() error = %v", err)
+	}
+
+	invalid := map[string]string{
+		"parse error":   "{{range .Tiers}}",
+		"unknown field": "{{.Header.Missing}}",
+		"empty output":  "{{if false}}x{{end}}",
+		"recursion":     `{{define "r"}}{{.}}{{template "r" .}}{{end}}{{template "r" "x"}}`,
+	}
+
+	for name, text := range invalid {
+		if err := ValidateDigestTemplate(text); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestRenderDigestTemplateSanitizes(t *testing.T) {
+	tmpl, err := ParseDigestTemplate(`<script>x</script><b>{{(index (index .Tiers 0).Clusters 0).Topic}}</b>`)
+	if err != nil {
+		t.Fatalf("ParseDigestTemplate() error = %v", err)
+	}
+
+	got, err := RenderDigestTemplate(tmpl, SampleTemplateData())
+	if err != nil {
+		t.Fatalf("RenderDigestTemplate() error = %v", err)
+	}
+
+	if got != "x<b>Economy</b>" {
+		t.Errorf("RenderDigestTemplate() = %q, want %q", got, "x<b>Economy</b>")
+	}
+}
+
+func TestRenderDigestTemplateOutputLimit(t *testing.T) {
+	tmpl, err := ParseDigestTemplate(`{{range .Tiers}}{{.Name}}{{end}}`)
+	if err != nil {
+		t.Fatalf("ParseDigestTemplate() error = %v", err)
+	}
+
+	data := TemplateData{Tiers: []TemplateTier{{Name: strings.Repeat("x", templateMaxOutput+1)}}}
+
+	if _, err := RenderDigestTemplate(tmpl, data); !errors.Is(err, errTemplateTooLarge) {
+		t.Errorf("RenderDigestTemplate() error = %v, want errTemplateTooLarge", err)
+	}
+}
+
+func TestTemplateClusters(t *testing.T) {
+	group := clusterGroup{
+		clusters: []db.ClusterWithItems{
+			{Topic: "Economy", Items: []db.Item{{Summary: "a", SourceChannel: "chan", SourceMsgID: 1}, {Summary: "b"}}},
+			{Topic: "Empty"},
+		},
+		items: []db.Item{{Summary: "c", Topic: "Sports", SourceChannelID: 42, SourceMsgID: 7}},
+	}
+
+	got := templateClusters(group)
+	if len(got) != 2 {
+		t.Fatalf("templateClusters() returned %d clusters, want 2", len(got))
+	}
+
+	if got[0].Topic != "Economy" || len(got[0].Items) != 2 || got[0].Items[0].Sources[0].URL != "https://t.me/chan/1" {
+		t.Errorf("unexpected first cluster: %+v", got[0])
+	}
+
+	if got[1].Topic != "Sports" || got[1].Items[0].Sources[0].URL != "https://t.me/c/42/7" {
+		t.Errorf("unexpected single-item cluster: %+v", got[1])
+	}
+}
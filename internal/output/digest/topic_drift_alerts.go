@@ -0,0 +1,267 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingTopicDriftAlertsEnabled toggles weekly channel topic drift alerts.
+	SettingTopicDriftAlertsEnabled = "topic_drift_alerts_enabled"
+
+	// topicDriftRecentDays is the period compared against the trailing baseline.
+	topicDriftRecentDays = 7
+	// topicDriftBaselineDays is the trailing baseline preceding the recent period.
+	topicDriftBaselineDays = 28
+	// topicDriftThreshold is the total variation distance between topic
+	// distributions at or above which a channel is flagged.
+	topicDriftThreshold = 0.5
+	// topicDriftMinBaselineItems and topicDriftMinRecentItems skip channels with
+	// too few items for their distributions to be meaningful.
+	topicDriftMinBaselineItems = 20
+	topicDriftMinRecentItems   = 10
+	// topicDriftMaxChannels caps how many channels are listed in one alert.
+	topicDriftMaxChannels = 10
+	// topicDriftTopTopics is how many topics are shown per distribution.
+	topicDriftTopTopics = 3
+	percentMultiplier   = 100
+)
+
+// channelTopicDrift describes how far a channel's topic mix moved from its baseline.
+type channelTopicDrift struct {
+	ChannelID string
+	Username  string
+	Title     string
+	Drift     float64
+	Before    []topicShare
+	After     []topicShare
+}
+
+// topicShare is a topic's share of a channel's items in a period.
+type topicShare struct {
+	Topic string
+	Share float64
+}
+
+// maybeRunTopicDriftAlerts alerts admins weekly about channels whose topics shifted.
+func (s *Scheduler) maybeRunTopicDriftAlerts(ctx context.Context, lastRun *time.Time) {
+	enabled := true
+	if err := s.database.GetSetting(ctx, SettingTopicDriftAlertsEnabled, &enabled); err != nil {
+		s.logger.Debug().Err(err).Msg("topic_drift_alerts_enabled not set, defaulting to true")
+	}
+
+	if !enabled {
+		return
+	}
+
+	now := time.Now()
+	isSunday := now.Weekday() == time.Sunday
+	isMidnightHour := now.Hour() == 0
+	notRunThisWeek := lastRun.IsZero() || now.Sub(*lastRun) > 6*HoursPerDay*time.Hour
+
+	if isSunday && isMidnightHour && notRunThisWeek {
+		logger := s.logger.With().Str(LogFieldTask, "topic-drift-alerts").Logger()
+		logger.Info().Msg("Starting weekly topic drift check")
+
+		if err := s.SendTopicDriftAlerts(ctx, now, &logger); err != nil {
+			logger.Error().Err(err).Msg("failed to send topic drift alerts")
+		} else {
+			*lastRun = now
+		}
+	}
+}
+
+// SendTopicDriftAlerts compares each channel's recent topic distribution with its
+// trailing baseline and notifies admins about channels that drifted past the threshold.
+func (s *Scheduler) SendTopicDriftAlerts(ctx context.Context, now time.Time, logger *zerolog.Logger) error {
+	recentStart := now.AddDate(0, 0, -topicDriftRecentDays)
+	baselineStart := recentStart.AddDate(0, 0, -topicDriftBaselineDays)
+
+	baseline, err := s.database.GetChannelTopicCounts(ctx, baselineStart, recentStart)
+	if err != nil {
+		return fmt.Errorf("get baseline topic counts: %w", err)
+	}
+
+	recent, err := s.database.GetChannelTopicCounts(ctx, recentStart, now)
+	if err != nil {
+		return fmt.Errorf("get recent topic counts: %w", err)
+	}
+
+	drifts := detectChannelTopicDrift(baseline, recent, topicDriftThreshold)
+	if len(drifts) == 0 {
+		logger.Info().Msg("no channel topic drift detected")
+
+		return nil
+	}
+
+	if err := s.bot.SendNotification(ctx, formatTopicDriftAlert(drifts)); err != nil {
+		return fmt.Errorf("send topic drift alert: %w", err)
+	}
+
+	logger.Info().Int("channels", len(drifts)).Msg("Sent topic drift alert")
+
+	return nil
+}
+
+// detectChannelTopicDrift returns channels whose recent topic distribution differs
+// from their baseline by at least threshold, most drifted first.
+func detectChannelTopicDrift(baseline, recent []db.ChannelTopicCount, threshold float64) []channelTopicDrift {
+	before := groupTopicCounts(baseline)
+	after := groupTopicCounts(recent)
+
+	var drifts []channelTopicDrift
+
+	for channelID, recentCounts := range after {
+		baselineCounts, ok := before[channelID]
+		if !ok || baselineCounts.total < topicDriftMinBaselineItems || recentCounts.total < topicDriftMinRecentItems {
+			continue
+		}
+
+		beforeShares := topicShares(baselineCounts)
+		afterShares := topicShares(recentCounts)
+
+		drift := topicDistributionDistance(beforeShares, afterShares)
+		if drift < threshold {
+			continue
+		}
+
+		drifts = append(drifts, channelTopicDrift{
+			ChannelID: channelID,
+			Username:  recentCounts.username,
+			Title:     recentCounts.title,
+			Drift:     drift,
+			Before:    topTopicShares(beforeShares, topicDriftTopTopics),
+			After:     topTopicShares(afterShares, topicDriftTopTopics),
+		})
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Drift != drifts[j].Drift {
+			return drifts[i].Drift > drifts[j].Drift
+		}
+
+		return drifts[i].ChannelID < drifts[j].ChannelID
+	})
+
+	if len(drifts) > topicDriftMaxChannels {
+		drifts = drifts[:topicDriftMaxChannels]
+	}
+
+	return drifts
+}
+
+// channelTopicCounts holds a channel's topic counts for one period.
+type channelTopicCounts struct {
+	username string
+	title    string
+	topics   map[string]int
+	total    int
+}
+
+func groupTopicCounts(rows []db.ChannelTopicCount) map[string]*channelTopicCounts {
+	grouped := make(map[string]*channelTopicCounts)
+
+	for _, r := range rows {
+		c, ok := grouped[r.ChannelID]
+		if !ok {
+			c = &channelTopicCounts{username: r.Username, title: r.Title, topics: make(map[string]int)}
+			grouped[r.ChannelID] = c
+		}
+
+		c.topics[r.Topic] += r.Count
+		c.total += r.Count
+	}
+
+	return grouped
+}
+
+func topicShares(c *channelTopicCounts) map[string]float64 {
+	shares := make(map[string]float64, len(c.topics))
+	if c.total == 0 {
+		return shares
+	}
+
+	for topic, n := range c.topics {
+		shares[topic] = float64(n) / float64(c.total)
+	}
+
+	return shares
+}
+
+// topicDistributionDistance returns the total variation distance between two topic
+// distributions: 0 for identical mixes, 1 for completely disjoint topics.
+func topicDistributionDistance(a, b map[string]float64) float64 {
+	var sum float64
+
+	for topic, p := range a {
+		sum += math.Abs(p - b[topic])
+	}
+
+	for topic, q := range b {
+		if _, ok := a[topic]; !ok {
+			sum += q
+		}
+	}
+
+	return sum / 2
+}
+
+func topTopicShares(shares map[string]float64, n int) []topicShare {
+	result := make([]topicShare, 0, len(shares))
+	for topic, share := range shares {
+		result = append(result, topicShare{Topic: topic, Share: share})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Share != result[j].Share {
+			return result[i].Share > result[j].Share
+		}
+
+		return result[i].Topic < result[j].Topic
+	})
+
+	if len(result) > n {
+		result = result[:n]
+	}
+
+	return result
+}
+
+// formatTopicDriftAlert renders drifted channels as an HTML admin notification.
+func formatTopicDriftAlert(drifts []channelTopicDrift) string {
+	var sb strings.Builder
+
+	sb.WriteString("🧭 <b>Channel Topic Drift</b>\n")
+	sb.WriteString(fmt.Sprintf("Topic mix of the last %d days vs the previous %d days:\n\n", topicDriftRecentDays, topicDriftBaselineDays))
+
+	for _, d := range drifts {
+		label := adminChannelLabel(d.ChannelID, d.Title, d.Username)
+
+		sb.WriteString(fmt.Sprintf("• %s — drift <code>%.0f%%</code>\n", label, d.Drift*percentMultiplier))
+		sb.WriteString(fmt.Sprintf("   before: %s\n", formatTopicShares(d.Before)))
+		sb.WriteString(fmt.Sprintf("   after: %s\n", formatTopicShares(d.After)))
+	}
+
+	sb.WriteString("\n💡 The channel may have been sold or repurposed. Re-review it with ")
+	sb.WriteString("<code>/channel weight</code> and <code>/channel metadata</code>.")
+
+	return sb.String()
+}
+
+func formatTopicShares(shares []topicShare) string {
+	parts := make([]string, 0, len(shares))
+	for _, s := range shares {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", html.EscapeString(s.Topic), s.Share*percentMultiplier))
+	}
+
+	return strings.Join(parts, ", ")
+}
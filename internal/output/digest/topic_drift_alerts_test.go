@@ -0,0 +1,73 @@
+package digest
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestTopicDistributionDistance(t *testing.T) {
+	const eps = 1e-9
+
+	tests := []struct {
+		name string
+		a, b map[string]float64
+		want float64
+	}{
+		{name: "identical", a: map[string]float64{"x": 0.5, "y": 0.5}, b: map[string]float64{"x": 0.5, "y": 0.5}, want: 0},
+		{name: "disjoint", a: map[string]float64{"x": 1}, b: map[string]float64{"y": 1}, want: 1},
+		{name: "partial shift", a: map[string]float64{"x": 0.8, "y": 0.2}, b: map[string]float64{"x": 0.2, "y": 0.5, "z": 0.3}, want: 0.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topicDistributionDistance(tt.a, tt.b); math.Abs(got-tt.want) > eps {
+				t.Errorf("topicDistributionDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectChannelTopicDrift(t *testing.T) {
+	baseline := []db.ChannelTopicCount{
+		{ChannelID: "drifted", Username: "sold", Topic: "Politics", Count: 18},
+		{ChannelID: "drifted", Username: "sold", Topic: "Economy", Count: 12},
+		{ChannelID: "stable", Username: "steady", Topic: "Politics", Count: 30},
+		{ChannelID: "small", Username: "tiny", Topic: "Politics", Count: 5},
+	}
+
+	recent := []db.ChannelTopicCount{
+		{ChannelID: "drifted", Username: "sold", Topic: "Crypto", Count: 9},
+		{ChannelID: "drifted", Username: "sold", Topic: "Economy", Count: 3},
+		{ChannelID: "stable", Username: "steady", Topic: "Politics", Count: 12},
+		{ChannelID: "small", Username: "tiny", Topic: "Crypto", Count: 15},
+		{ChannelID: "new", Username: "fresh", Topic: "Crypto", Count: 20},
+	}
+
+	drifts := detectChannelTopicDrift(baseline, recent, topicDriftThreshold)
+	if len(drifts) != 1 {
+		t.Fatalf("expected 1 drifted channel, got %d: %+v", len(drifts), drifts)
+	}
+
+	d := drifts[0]
+	if d.ChannelID != "drifted" {
+		t.Fatalf("expected drifted channel, got %q", d.ChannelID)
+	}
+
+	if len(d.Before) == 0 || d.Before[0].Topic != "Politics" {
+		t.Errorf("expected Politics to lead before, got %+v", d.Before)
+	}
+
+	if len(d.After) == 0 || d.After[0].Topic != "Crypto" {
+		t.Errorf("expected Crypto to lead after, got %+v", d.After)
+	}
+
+	msg := formatTopicDriftAlert(drifts)
+	for _, want := range []string{"@sold", "before: Politics 60%, Economy 40%", "after: Crypto 75%, Economy 25%", "/channel weight"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("alert missing %q:\n%s", want, msg)
+		}
+	}
+}
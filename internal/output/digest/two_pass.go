@@ -0,0 +1,128 @@
+package digest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// SettingSummarizeTwoPass enables two-pass summarization: the pipeline
+	// drafts every summary with the summarize model and the digest re-summarizes
+	// only the items it selects with the refine model.
+	SettingSummarizeTwoPass = "summarize_two_pass"
+	// SettingLLMOverrideRefine names the model that refines selected items.
+	SettingLLMOverrideRefine = "llm_override_refine"
+
+	// refineBatchSize caps how many items are refined per LLM request.
+	refineBatchSize = 10
+	// refineBatchTimeout bounds a single refine request.
+	refineBatchTimeout = 2 * time.Minute
+)
+
+// refineSelectedSummaries re-summarizes the selected items with the refine
+// model when two-pass summarization is enabled. Items refined for an earlier
+// digest or preview keep their refined summary; scores and topics are left
+// unchanged so the selection stays the same.
+func (s *Scheduler) refineSelectedSummaries(ctx context.Context, items []db.Item, settings digestSettings, logger *zerolog.Logger) {
+	if s.llmClient == nil || len(items) == 0 {
+		return
+	}
+
+	var enabled bool
+	if err := s.database.GetSetting(ctx, SettingSummarizeTwoPass, &enabled); err != nil {
+		logger.Debug().Err(err).Msg("could not get summarize_two_pass from DB")
+	}
+
+	if !enabled {
+		return
+	}
+
+	var model string
+	if err := s.database.GetSetting(ctx, SettingLLMOverrideRefine, &model); err != nil {
+		logger.Debug().Err(err).Msg("could not get llm_override_refine from DB")
+	}
+
+	model = strings.TrimSpace(model)
+	if model == "" {
+		logger.Warn().Msg("summarize_two_pass is enabled but no refine model is set, skipping refinement")
+
+		return
+	}
+
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	sources, err := s.database.GetUnrefinedItemSources(ctx, ids)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load sources for summary refinement")
+
+		return
+	}
+
+	var indices []int
+
+	for i, item := range items {
+		if _, ok := sources[item.ID]; ok {
+			indices = append(indices, i)
+		}
+	}
+
+	refined := 0
+
+	for start := 0; start < len(indices); start += refineBatchSize {
+		reportProgress(ctx, ProgressRefining, start, len(indices))
+
+		batch := indices[start:min(start+refineBatchSize, len(indices))]
+		refined += s.refineBatch(ctx, items, batch, sources, model, settings, logger)
+	}
+
+	if len(indices) > 0 {
+		logger.Info().Int("refined", refined).Int(LogFieldCount, len(items)).Str("model", model).Msg("Refined selected summaries")
+	}
+}
+
+// refineBatch re-summarizes items[indices] and returns how many were refined.
+func (s *Scheduler) refineBatch(ctx context.Context, items []db.Item, indices []int, sources map[string]db.RawMessage, model string, settings digestSettings, logger *zerolog.Logger) int {
+	inputs := make([]llm.MessageInput, len(indices))
+	for j, idx := range indices {
+		inputs[j] = llm.MessageInput{RawMessage: sources[items[idx].ID]}
+	}
+
+	llmCtx, cancel := context.WithTimeout(ctx, refineBatchTimeout)
+	defer cancel()
+
+	results, err := s.llmClient.ProcessBatch(llmCtx, inputs, settings.digestLanguage, model, settings.digestTone)
+	if err != nil || len(results) != len(inputs) {
+		logger.Warn().Err(err).Str("model", model).Msg("Summary refinement failed, keeping draft summaries")
+
+		return 0
+	}
+
+	refined := 0
+
+	for j, idx := range indices {
+		summary := strings.TrimSpace(results[j].Summary)
+		if summary == "" {
+			continue
+		}
+
+		if err := s.database.SaveSummaryRefinement(ctx, items[idx].ID, summary, model); err != nil {
+			logger.Warn().Err(err).Str("item_id", items[idx].ID).Msg("failed to save refined summary")
+
+			continue
+		}
+
+		items[idx].Summary = summary
+		refined++
+	}
+
+	return refined
+}
@@ -0,0 +1,175 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// watchlistMaxMentions caps how many mentions a single entity check fetches.
+	watchlistMaxMentions = 50
+	// watchlistMaxListed caps how many mentions are listed in one alert.
+	watchlistMaxListed = 5
+	// watchlistMaxRollup caps the watchlist section of roll-ups.
+	watchlistMaxRollup = 10
+)
+
+// maybeRunWatchlistAlerts checks watchlist entities and alerts admins when
+// mentions cross the volume or importance threshold.
+func (s *Scheduler) maybeRunWatchlistAlerts(ctx context.Context) {
+	logger := s.logger.With().Str(LogFieldTask, "watchlist-alerts").Logger()
+
+	if err := s.RunWatchlistAlerts(ctx, time.Now(), &logger); err != nil {
+		logger.Error().Err(err).Msg("failed to run watchlist alerts")
+	}
+}
+
+// RunWatchlistAlerts checks every watchlist entity once against the items of
+// the alert window. Items already alerted for an entity are not repeated.
+func (s *Scheduler) RunWatchlistAlerts(ctx context.Context, now time.Time, logger *zerolog.Logger) error {
+	entities, err := s.database.ListWatchlistEntities(ctx)
+	if err != nil {
+		return fmt.Errorf("list watchlist entities: %w", err)
+	}
+
+	for _, e := range entities {
+		if err := s.runWatchlistAlert(ctx, e, now); err != nil {
+			logger.Warn().Err(err).Int64("watchlist_id", e.ID).Msg("watchlist alert failed")
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) runWatchlistAlert(ctx context.Context, e db.WatchlistEntity, now time.Time) error {
+	from := now.Add(-time.Duration(s.cfg.WatchlistWindowHours) * time.Hour)
+	if from.Before(e.CreatedAt) {
+		from = e.CreatedAt
+	}
+
+	mentions, total, err := s.database.GetWatchlistMentions(ctx, e, from, now, watchlistMaxMentions)
+	if err != nil {
+		return fmt.Errorf("get mentions: %w", err)
+	}
+
+	if !watchlistThresholdMet(mentions, total, s.cfg.WatchlistVolumeThreshold, s.cfg.WatchlistImportanceThreshold) {
+		return nil
+	}
+
+	ids := make([]string, 0, len(mentions))
+	for _, m := range mentions {
+		ids = append(ids, m.ItemID)
+	}
+
+	fresh, err := s.database.RecordWatchlistAlerts(ctx, e.ID, ids)
+	if err != nil {
+		return fmt.Errorf("record alerts: %w", err)
+	}
+
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	if err := s.bot.SendNotification(ctx, formatWatchlistAlert(e, total, filterWatchlistMentions(mentions, fresh))); err != nil {
+		return fmt.Errorf("send watchlist alert: %w", err)
+	}
+
+	if err := s.database.MarkWatchlistAlerted(ctx, e.ID, now); err != nil {
+		return fmt.Errorf("mark alerted: %w", err)
+	}
+
+	return nil
+}
+
+// watchlistThresholdMet reports whether an entity was mentioned by at least
+// volume items, or by an item at least as important as importance. A
+// non-positive threshold disables that trigger.
+func watchlistThresholdMet(mentions []db.WatchlistMention, total, volume int, importance float32) bool {
+	if volume > 0 && total >= volume {
+		return true
+	}
+
+	if importance <= 0 {
+		return false
+	}
+
+	for _, m := range mentions {
+		if m.Importance >= importance {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterWatchlistMentions keeps mentions whose item IDs are in ids, preserving order.
+func filterWatchlistMentions(mentions []db.WatchlistMention, ids []string) []db.WatchlistMention {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+
+	filtered := make([]db.WatchlistMention, 0, len(ids))
+
+	for _, m := range mentions {
+		if keep[m.ItemID] {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
+}
+
+// FormatWatchlistEntity describes a watchlist entity and its aliases for display.
+func FormatWatchlistEntity(e db.WatchlistEntity) string {
+	var sb strings.Builder
+
+	sb.WriteString("<b>" + html.EscapeString(e.Name) + "</b>")
+
+	if len(e.Aliases) > 0 {
+		sb.WriteString(" <i>aka " + html.EscapeString(strings.Join(e.Aliases, ", ")) + "</i>")
+	}
+
+	if e.EntityType != "" {
+		sb.WriteString(" [" + strings.ToLower(e.EntityType) + "]")
+	}
+
+	return sb.String()
+}
+
+// formatWatchlistAlert renders new mentions of an entity as an HTML admin notification.
+func formatWatchlistAlert(e db.WatchlistEntity, total int, mentions []db.WatchlistMention) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("👤 <b>Watchlist #%d</b> %s\n%d mention(s) in the window, %d new:\n\n", e.ID, FormatWatchlistEntity(e), total, len(mentions)))
+
+	for i, m := range mentions {
+		if i == watchlistMaxListed {
+			sb.WriteString(fmt.Sprintf("…and %d more\n", len(mentions)-watchlistMaxListed))
+
+			break
+		}
+
+		item := db.Item{SourceChannel: m.ChannelUsername, SourceChannelTitle: m.ChannelTitle, SourceChannelID: m.ChannelPeerID, SourceMsgID: m.MessageID}
+		sb.WriteString(fmt.Sprintf("• <a href=\"%s\">%s</a> <code>%.2f</code>: %s\n",
+			itemMessageURL(item), html.EscapeString(sourceLabel(item)), m.Importance, html.EscapeString(truncateWatchlistSummary(m.Summary))))
+	}
+
+	return sb.String()
+}
+
+func truncateWatchlistSummary(summary string) string {
+	runes := []rune(strings.TrimSpace(summary))
+	if len(runes) > searchWatchSummaryRunes {
+		return string(runes[:searchWatchSummaryRunes]) + "…"
+	}
+
+	return string(runes)
+}
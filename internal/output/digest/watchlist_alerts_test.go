@@ -0,0 +1,54 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestWatchlistThresholdMet(t *testing.T) {
+	mentions := []db.WatchlistMention{{Importance: 0.4}, {Importance: 0.6}}
+
+	tests := []struct {
+		name       string
+		total      int
+		volume     int
+		importance float32
+		want       bool
+	}{
+		{"volume reached", 5, 5, 0.9, true},
+		{"below volume and importance", 2, 5, 0.9, false},
+		{"importance reached", 2, 5, 0.6, true},
+		{"both disabled", 50, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := watchlistThresholdMet(mentions, tt.total, tt.volume, tt.importance); got != tt.want {
+			t.Errorf("%s: watchlistThresholdMet() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatWatchlistAlert(t *testing.T) {
+	e := db.WatchlistEntity{ID: 4, Name: "Olaf Scholz", Aliases: []string{"Scholz"}, EntityType: "PERSON"}
+
+	mentions := make([]db.WatchlistMention, 0, watchlistMaxListed+1)
+	for i := range watchlistMaxListed + 1 {
+		mentions = append(mentions, db.WatchlistMention{Summary: "Chancellor <speaks>", ChannelUsername: "news", MessageID: int64(i + 1), Importance: 0.75})
+	}
+
+	got := formatWatchlistAlert(e, 9, filterWatchlistMentions(mentions, []string{""}))
+
+	for _, want := range []string{
+		"👤 <b>Watchlist #4</b> <b>Olaf Scholz</b> <i>aka Scholz</i> [person]",
+		"9 mention(s) in the window, 6 new",
+		`<a href="https://t.me/news/1">`,
+		"<code>0.75</code>: Chancellor &lt;speaks&gt;",
+		"…and 1 more",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("alert missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package export writes digests and stories as pages to note-taking apps:
+// a Notion database or an Obsidian vault folder. Each exported page is
+// recorded in the export_pages table, so the page is updated in place when
+// a story evolves instead of being duplicated.
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Export statuses reported in metrics.
+const (
+	StatusExported  = "exported"
+	StatusUnchanged = "unchanged"
+	StatusError     = "error"
+)
+
+// Repository is the storage the exporter needs.
+type Repository interface {
+	GetExportPage(ctx context.Context, target, kind, entityID string) (db.ExportPage, error)
+	SaveExportPage(ctx context.Context, page db.ExportPage) error
+	GetStory(ctx context.Context, storyID string) (*db.Story, error)
+	GetStoryTimeline(ctx context.Context, storyID string) ([]db.StoryEvent, error)
+}
+
+// Target is a place pages are exported to.
+type Target interface {
+	// Name identifies the target in the page mapping, e.g. "notion".
+	Name() string
+	// Upsert creates the page, or replaces the page with the given ID when
+	// it is not empty, and returns the ID of the page.
+	Upsert(ctx context.Context, page Page, pageID string) (string, error)
+}
+
+// Exporter exports digests and stories to all of its targets.
+type Exporter struct {
+	repo    Repository
+	targets []Target
+	logger  *zerolog.Logger
+}
+
+// New creates an exporter for the given targets.
+func New(repo Repository, logger *zerolog.Logger, targets ...Target) *Exporter {
+	return &Exporter{repo: repo, targets: targets, logger: logger}
+}
+
+// ExportDigest implements digest.Exporter.
+func (e *Exporter) ExportDigest(ctx context.Context, digestID string, data digest.TemplateData) error {
+	return e.export(ctx, BuildDigestPage(digestID, data))
+}
+
+// ExportStory implements digest.Exporter. Stories are exported with their
+// current timeline, so they should be exported again after it changes.
+func (e *Exporter) ExportStory(ctx context.Context, storyID string) error {
+	story, err := e.repo.GetStory(ctx, storyID)
+	if err != nil {
+		return fmt.Errorf("get story: %w", err)
+	}
+
+	events, err := e.repo.GetStoryTimeline(ctx, storyID)
+	if err != nil {
+		return fmt.Errorf("get story timeline: %w", err)
+	}
+
+	return e.export(ctx, BuildStoryPage(*story, events))
+}
+
+// export writes the page to every target, skipping targets that already
+// have the same content. A failing target does not stop the others.
+func (e *Exporter) export(ctx context.Context, page Page) error {
+	hash := contentHash(page)
+
+	var errs []error
+
+	for _, target := range e.targets {
+		status, err := e.exportTo(ctx, target, page, hash)
+		observability.ExportedPages.WithLabelValues(target.Name(), page.Kind, status).Inc()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.Name(), err))
+
+			continue
+		}
+
+		e.logger.Debug().Str("target", target.Name()).Str("kind", page.Kind).Str("id", page.EntityID).Str("status", status).Msg("Page exported")
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) exportTo(ctx context.Context, target Target, page Page, hash string) (string, error) {
+	existing, err := e.repo.GetExportPage(ctx, target.Name(), page.Kind, page.EntityID)
+	if err != nil && !errors.Is(err, db.ErrExportPageNotFound) {
+		return StatusError, fmt.Errorf("get export page: %w", err)
+	}
+
+	if existing.PageID != "" && existing.ContentHash == hash {
+		return StatusUnchanged, nil
+	}
+
+	pageID, err := target.Upsert(ctx, page, existing.PageID)
+	if err != nil {
+		return StatusError, fmt.Errorf("upsert page: %w", err)
+	}
+
+	if err := e.repo.SaveExportPage(ctx, db.ExportPage{
+		Target:      target.Name(),
+		Kind:        page.Kind,
+		EntityID:    page.EntityID,
+		PageID:      pageID,
+		ContentHash: hash,
+	}); err != nil {
+		return StatusError, fmt.Errorf("save export page: %w", err)
+	}
+
+	return StatusExported, nil
+}
+
+// contentHash fingerprints the page content, including its properties.
+func contentHash(page Page) string {
+	sum := sha256.Sum256([]byte(RenderMarkdown(page)))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,429 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const testDigestID = "11111111-2222-3333-4444-555555555555"
+
+func testDigest() digest.TemplateData {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	return digest.TemplateData{
+		Header: digest.TemplateHeader{Title: "Digest for", Start: start, End: start.Add(time.Hour), ItemCount: 3, ChannelCount: 2, TopicCount: 1},
+		Tiers: []digest.TemplateTier{
+			{Name: "Breaking", Emoji: digest.EmojiBreaking, Clusters: []digest.TemplateCluster{{
+				Items: []digest.TemplateItem{{
+					Summary: "<b>Rates</b> up",
+					Sources: []digest.TemplateSource{{Channel: "news", URL: "https://t.me/news/1"}},
+				}},
+			}}},
+			{Name: "Also", Emoji: digest.EmojiStandard, Clusters: []digest.TemplateCluster{{
+				Topic: "Tech",
+				Items: []digest.TemplateItem{{Summary: "New *chip*"}, {Summary: "New_phone"}},
+			}}},
+		},
+	}
+}
+
+func testStory() (db.Story, []db.StoryEvent) {
+	first := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	story := db.Story{ID: testDigestID, Title: "Port strike", FirstSeenAt: first, LastSeenAt: first.AddDate(0, 0, 2), ClusterCount: 3}
+	events := []db.StoryEvent{
+		{Date: first, Text: "Dockers walk out", ChannelUsername: "news", MsgID: 7},
+		{Date: first.AddDate(0, 0, 2), Text: "Talks resume", ChannelPeerID: 1234, MsgID: 9},
+	}
+
+	return story, events
+}
+
+func TestRenderDigestMarkdown(t *testing.T) {
+	got := RenderMarkdown(BuildDigestPage(testDigestID, testDigest()))
+
+	want := `---
+type: "digest"
+id: "` + testDigestID + `"
+window_start: 2026-03-01T09:00:00Z
+window_end: 2026-03-01T10:00:00Z
+items: 3
+channels: 2
+topics: 1
+tags: ["digest"]
+---
+
+# Digest for Mar 1 09:00 – 10:00 UTC
+
+## 🔴 Breaking
+
+- Rates up — [news](https://t.me/news/1)
+
+## 📝 Also
+
+### Tech
+
+- New \*chip\*
+- New\_phone
+`
+	if got != want {
+		t.Errorf("markdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildStoryPage(t *testing.T) {
+	story, events := testStory()
+	page := BuildStoryPage(story, events)
+
+	if page.Title != "Port strike" || page.Kind != db.ExportKindStory || !page.Date.Equal(story.LastSeenAt) {
+		t.Errorf("page = %q %q %v", page.Title, page.Kind, page.Date)
+	}
+
+	md := RenderMarkdown(page)
+	for _, want := range []string{
+		"- **2026-03-01** — Dockers walk out ([news](https://t.me/news/7))\n",
+		"- **2026-03-03** — Talks resume ([source](https://t.me/c/1234/9))\n",
+		"events: 2\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	empty := BuildStoryPage(db.Story{ID: testDigestID}, nil)
+	if empty.Title != untitledStory || len(empty.Blocks) != 1 || empty.Blocks[0].Type != BlockParagraph {
+		t.Errorf("empty story page = %+v", empty)
+	}
+}
+
+func TestObsidianUpsert(t *testing.T) {
+	dir := t.TempDir()
+	vault := NewObsidianVault(dir)
+	story, events := testStory()
+
+	pageID, err := vault.Upsert(context.Background(), BuildStoryPage(story, events), "")
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	if want := filepath.Join("Stories", "Port strike (11111111).md"); pageID != want {
+		t.Errorf("page ID = %q, want %q", pageID, want)
+	}
+
+	// A renamed story keeps its file
+	story.Title = "Port strike: day 3"
+
+	updatedID, err := vault.Upsert(context.Background(), BuildStoryPage(story, events), pageID)
+	if err != nil {
+		t.Fatalf("Upsert update: %v", err)
+	}
+
+	if updatedID != pageID {
+		t.Errorf("updated page ID = %q, want %q", updatedID, pageID)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, pageID))
+	if err != nil {
+		t.Fatalf("read page: %v", err)
+	}
+
+	if !strings.Contains(string(data), "# Port strike: day 3\n") {
+		t.Errorf("page not updated:\n%s", data)
+	}
+
+	digestID, err := vault.Upsert(context.Background(), BuildDigestPage(testDigestID, testDigest()), "")
+	if err != nil {
+		t.Fatalf("Upsert digest: %v", err)
+	}
+
+	if want := filepath.Join("Digests", "2026-03-01 10-00.md"); digestID != want {
+		t.Errorf("digest page ID = %q, want %q", digestID, want)
+	}
+
+	if _, err := vault.Upsert(context.Background(), BuildStoryPage(story, events), "../outside.md"); !errors.Is(err, ErrInvalidPagePath) {
+		t.Errorf("Upsert outside the vault: err = %v, want %v", err, ErrInvalidPagePath)
+	}
+}
+
+func TestFileTitle(t *testing.T) {
+	tests := map[string]string{
+		"Port strike":            "Port strike",
+		"A/B: [test]?  #tag":     "AB test tag",
+		"  ":                     untitledStory,
+		strings.Repeat("я", 100): strings.Repeat("я", obsidianMaxTitleLen),
+	}
+
+	for in, want := range tests {
+		if got := fileTitle(in); got != want {
+			t.Errorf("fileTitle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// fakeNotion records requests to a minimal Notion API.
+type fakeNotion struct {
+	mu       sync.Mutex
+	requests []string
+	appended int
+	children []string
+	missing  bool
+}
+
+func (f *fakeNotion) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	if r.Header.Get("Notion-Version") != notionVersion || r.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	var body struct {
+		Children []json.RawMessage `json:"children"`
+	}
+
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && r.Method != http.MethodGet && r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	if len(body.Children) > notionMaxChildren {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	f.appended += len(body.Children)
+
+	var resp any = map[string]any{}
+
+	switch {
+	case f.missing && r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/pages/"):
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	case r.Method == http.MethodPost && r.URL.Path == "/pages":
+		resp = notionPage{ID: "page-1"}
+	case r.Method == http.MethodGet:
+		results := make([]notionPage, len(f.children))
+		for i, id := range f.children {
+			results[i] = notionPage{ID: id}
+		}
+
+		resp = notionChildren{Results: results}
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func newTestNotion(t *testing.T, f *fakeNotion) *Notion {
+	t.Helper()
+
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	n := NewNotion("secret", "db-1", time.Second)
+	n.baseURL = srv.URL
+
+	return n
+}
+
+func largePage() Page {
+	page := Page{Kind: db.ExportKindDigest, EntityID: testDigestID, Title: "Big"}
+	for i := range 150 {
+		page.Blocks = append(page.Blocks, Block{Type: BlockBullet, Spans: []Span{{Text: fmt.Sprintf("item %d", i)}}})
+	}
+
+	return page
+}
+
+func TestNotionCreatesPageInBatches(t *testing.T) {
+	f := &fakeNotion{}
+
+	pageID, err := newTestNotion(t, f).Upsert(context.Background(), largePage(), "")
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	if pageID != "page-1" {
+		t.Errorf("page ID = %q, want page-1", pageID)
+	}
+
+	want := []string{"POST /pages", "PATCH /blocks/page-1/children"}
+	if strings.Join(f.requests, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", f.requests, want)
+	}
+
+	if f.appended != 150 {
+		t.Errorf("sent %d blocks, want 150", f.appended)
+	}
+}
+
+func TestNotionUpdatesPage(t *testing.T) {
+	f := &fakeNotion{children: []string{"b1", "b2"}}
+
+	pageID, err := newTestNotion(t, f).Upsert(context.Background(), largePage(), "page-9")
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	if pageID != "page-9" {
+		t.Errorf("page ID = %q, want page-9", pageID)
+	}
+
+	want := []string{
+		"PATCH /pages/page-9", "GET /blocks/page-9/children", "DELETE /blocks/b1", "DELETE /blocks/b2",
+		"PATCH /blocks/page-9/children", "PATCH /blocks/page-9/children",
+	}
+	if strings.Join(f.requests, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", f.requests, want)
+	}
+}
+
+func TestNotionRecreatesDeletedPage(t *testing.T) {
+	f := &fakeNotion{missing: true}
+
+	pageID, err := newTestNotion(t, f).Upsert(context.Background(), BuildDigestPage(testDigestID, testDigest()), "gone")
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	if pageID != "page-1" {
+		t.Errorf("page ID = %q, want the recreated page-1", pageID)
+	}
+}
+
+func TestNotionRichTextSplitsLongText(t *testing.T) {
+	text := notionRichText([]Span{{Text: strings.Repeat("a", notionMaxRichTextChars+1), URL: "https://t.me/x/1"}})
+	if len(text) != 2 {
+		t.Fatalf("got %d rich text objects, want 2", len(text))
+	}
+
+	if _, ok := text[1]["text"].(map[string]any)["link"]; !ok {
+		t.Error("link missing from the continuation")
+	}
+}
+
+type fakeRepo struct {
+	pages map[string]db.ExportPage
+}
+
+func (r *fakeRepo) GetExportPage(_ context.Context, target, kind, entityID string) (db.ExportPage, error) {
+	page, ok := r.pages[target+kind+entityID]
+	if !ok {
+		return db.ExportPage{}, db.ErrExportPageNotFound
+	}
+
+	return page, nil
+}
+
+func (r *fakeRepo) SaveExportPage(_ context.Context, page db.ExportPage) error {
+	r.pages[page.Target+page.Kind+page.EntityID] = page
+
+	return nil
+}
+
+func (r *fakeRepo) GetStory(_ context.Context, _ string) (*db.Story, error) {
+	story, _ := testStory()
+
+	return &story, nil
+}
+
+func (r *fakeRepo) GetStoryTimeline(_ context.Context, _ string) ([]db.StoryEvent, error) {
+	_, events := testStory()
+
+	return events, nil
+}
+
+type fakeTarget struct {
+	upserts []string
+	err     error
+}
+
+func (t *fakeTarget) Name() string {
+	return "fake"
+}
+
+func (t *fakeTarget) Upsert(_ context.Context, page Page, pageID string) (string, error) {
+	t.upserts = append(t.upserts, pageID)
+
+	if t.err != nil {
+		return "", t.err
+	}
+
+	if pageID == "" {
+		pageID = "page-" + page.Kind
+	}
+
+	return pageID, nil
+}
+
+func TestExporterUpdatesMappedPages(t *testing.T) {
+	repo := &fakeRepo{pages: map[string]db.ExportPage{}}
+	target := &fakeTarget{}
+	logger := zerolog.Nop()
+	exporter := New(repo, &logger, target)
+
+	for range 2 {
+		if err := exporter.ExportStory(context.Background(), testDigestID); err != nil {
+			t.Fatalf("ExportStory: %v", err)
+		}
+	}
+
+	// The second export has the same content and is skipped
+	if len(target.upserts) != 1 || target.upserts[0] != "" {
+		t.Fatalf("upserts = %q, want one create", target.upserts)
+	}
+
+	// Changed content updates the mapped page
+	page := repo.pages["fake"+db.ExportKindStory+testDigestID]
+	page.ContentHash = "stale"
+	repo.pages["fake"+db.ExportKindStory+testDigestID] = page
+
+	if err := exporter.ExportStory(context.Background(), testDigestID); err != nil {
+		t.Fatalf("ExportStory: %v", err)
+	}
+
+	if len(target.upserts) != 2 || target.upserts[1] != "page-story" {
+		t.Errorf("upserts = %q, want an update of page-story", target.upserts)
+	}
+}
+
+var errTargetDown = errors.New("target down")
+
+func TestExporterReportsTargetErrors(t *testing.T) {
+	repo := &fakeRepo{pages: map[string]db.ExportPage{}}
+	logger := zerolog.Nop()
+
+	err := New(repo, &logger, &fakeTarget{err: errTargetDown}).ExportDigest(context.Background(), testDigestID, testDigest())
+	if !errors.Is(err, errTargetDown) {
+		t.Errorf("err = %v, want %v", err, errTargetDown)
+	}
+
+	if len(repo.pages) != 0 {
+		t.Errorf("saved %d mappings for a failed export", len(repo.pages))
+	}
+}
@@ -0,0 +1,283 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// TargetNotion names the Notion database target.
+	TargetNotion = "notion"
+
+	notionBaseURL          = "https://api.notion.com/v1"
+	notionVersion          = "2022-06-28"
+	notionMaxChildren      = 100
+	notionMaxRichTextChars = 2000
+	notionErrBodyLimit     = 512
+	defaultTimeout         = 30 * time.Second
+
+	// Properties the Notion database must have.
+	notionPropTitle = "Name"
+	notionPropType  = "Type"
+	notionPropDate  = "Date"
+)
+
+var (
+	// ErrUnexpectedStatus indicates the Notion API rejected a request.
+	ErrUnexpectedStatus = errors.New("unexpected notion status")
+	// errNotionNotFound indicates the page was deleted or the integration lost access.
+	errNotionNotFound = errors.New("notion page not found")
+)
+
+// Notion writes pages into a Notion database. The database needs a "Name"
+// title property, a "Type" select and a "Date" date property, and must be
+// shared with the integration. Updating a page replaces its content.
+type Notion struct {
+	token      string
+	databaseID string
+	baseURL    string
+	client     *http.Client
+}
+
+type notionPage struct {
+	ID string `json:"id"`
+}
+
+type notionChildren struct {
+	Results    []notionPage `json:"results"`
+	HasMore    bool         `json:"has_more"`
+	NextCursor string       `json:"next_cursor"`
+}
+
+// NewNotion creates a target for a Notion database.
+func NewNotion(token, databaseID string, timeout time.Duration) *Notion {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Notion{token: token, databaseID: databaseID, baseURL: notionBaseURL, client: &http.Client{Timeout: timeout}}
+}
+
+// Name implements Target.
+func (n *Notion) Name() string {
+	return TargetNotion
+}
+
+// Upsert implements Target. A page deleted in Notion is created again.
+func (n *Notion) Upsert(ctx context.Context, page Page, pageID string) (string, error) {
+	if pageID != "" {
+		err := n.updatePage(ctx, pageID, page)
+		if !errors.Is(err, errNotionNotFound) {
+			return pageID, err
+		}
+	}
+
+	return n.createPage(ctx, page)
+}
+
+func (n *Notion) createPage(ctx context.Context, page Page) (string, error) {
+	blocks := notionBlocks(page.Blocks)
+	first := blocks[:min(len(blocks), notionMaxChildren)]
+
+	var created notionPage
+
+	err := n.do(ctx, http.MethodPost, "/pages", map[string]any{
+		"parent":     map[string]any{"database_id": n.databaseID},
+		"properties": notionProperties(page),
+		"children":   first,
+	}, &created)
+	if err != nil {
+		return "", fmt.Errorf("create notion page: %w", err)
+	}
+
+	if err := n.appendBlocks(ctx, created.ID, blocks[len(first):]); err != nil {
+		return created.ID, err
+	}
+
+	return created.ID, nil
+}
+
+// updatePage replaces the properties and the content of a page.
+func (n *Notion) updatePage(ctx context.Context, pageID string, page Page) error {
+	if err := n.do(ctx, http.MethodPatch, "/pages/"+url.PathEscape(pageID), map[string]any{
+		"properties": notionProperties(page),
+	}, nil); err != nil {
+		return fmt.Errorf("update notion page: %w", err)
+	}
+
+	if err := n.clearPage(ctx, pageID); err != nil {
+		return err
+	}
+
+	return n.appendBlocks(ctx, pageID, notionBlocks(page.Blocks))
+}
+
+// clearPage deletes the content blocks of a page.
+func (n *Notion) clearPage(ctx context.Context, pageID string) error {
+	var ids []string
+
+	cursor := ""
+
+	for {
+		query := url.Values{"page_size": {fmt.Sprint(notionMaxChildren)}}
+		if cursor != "" {
+			query.Set("start_cursor", cursor)
+		}
+
+		var children notionChildren
+		if err := n.do(ctx, http.MethodGet, "/blocks/"+url.PathEscape(pageID)+"/children?"+query.Encode(), nil, &children); err != nil {
+			return fmt.Errorf("list notion blocks: %w", err)
+		}
+
+		for _, c := range children.Results {
+			ids = append(ids, c.ID)
+		}
+
+		if !children.HasMore || children.NextCursor == "" {
+			break
+		}
+
+		cursor = children.NextCursor
+	}
+
+	for _, id := range ids {
+		if err := n.do(ctx, http.MethodDelete, "/blocks/"+url.PathEscape(id), nil, nil); err != nil {
+			return fmt.Errorf("delete notion block: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// appendBlocks adds blocks to a page in requests of at most notionMaxChildren.
+func (n *Notion) appendBlocks(ctx context.Context, pageID string, blocks []map[string]any) error {
+	for start := 0; start < len(blocks); start += notionMaxChildren {
+		batch := blocks[start:min(start+notionMaxChildren, len(blocks))]
+
+		if err := n.do(ctx, http.MethodPatch, "/blocks/"+url.PathEscape(pageID)+"/children", map[string]any{"children": batch}, nil); err != nil {
+			return fmt.Errorf("append notion blocks: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// do sends a Notion API request and decodes the response into out when set.
+func (n *Notion) do(ctx context.Context, method, path string, payload, out any) error {
+	var body io.Reader
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("marshal notion request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, n.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("create notion request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+n.token)
+	req.Header.Set("Notion-Version", notionVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotionNotFound
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, err := io.ReadAll(io.LimitReader(resp.Body, notionErrBodyLimit))
+		if err != nil {
+			return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+		}
+
+		return fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, resp.StatusCode, msg)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode notion response: %w", err)
+	}
+
+	return nil
+}
+
+func notionProperties(page Page) map[string]any {
+	return map[string]any{
+		notionPropTitle: map[string]any{"title": notionRichText([]Span{{Text: page.Title}})},
+		notionPropType:  map[string]any{"select": map[string]any{"name": page.Kind}},
+		notionPropDate:  map[string]any{"date": map[string]any{"start": page.Date.UTC().Format(time.RFC3339)}},
+	}
+}
+
+func notionBlocks(blocks []Block) []map[string]any {
+	out := make([]map[string]any, 0, len(blocks))
+
+	for _, b := range blocks {
+		blockType := "paragraph"
+
+		switch b.Type {
+		case BlockHeading:
+			blockType = "heading_2"
+		case BlockSubheading:
+			blockType = "heading_3"
+		case BlockBullet:
+			blockType = "bulleted_list_item"
+		}
+
+		out = append(out, map[string]any{
+			"object":  "block",
+			"type":    blockType,
+			blockType: map[string]any{"rich_text": notionRichText(b.Spans)},
+		})
+	}
+
+	return out
+}
+
+// notionRichText converts spans to rich text objects, splitting text longer
+// than the per-object limit.
+func notionRichText(spans []Span) []map[string]any {
+	out := make([]map[string]any, 0, len(spans))
+
+	for _, s := range spans {
+		runes := []rune(s.Text)
+
+		for start := 0; start < len(runes); start += notionMaxRichTextChars {
+			text := map[string]any{"content": string(runes[start:min(start+notionMaxRichTextChars, len(runes))])}
+			if s.URL != "" {
+				text["link"] = map[string]any{"url": s.URL}
+			}
+
+			out = append(out, map[string]any{
+				"type":        "text",
+				"text":        text,
+				"annotations": map[string]any{"bold": s.Bold},
+			})
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,224 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// TargetObsidian names the Obsidian vault target.
+	TargetObsidian = "obsidian"
+
+	obsidianDigestDir   = "Digests"
+	obsidianStoryDir    = "Stories"
+	obsidianDigestName  = "2006-01-02 15-04"
+	obsidianMaxTitleLen = 80
+	obsidianIDPrefixLen = 8
+	obsidianDirPerm     = 0o750
+	markdownExt         = ".md"
+)
+
+// ErrInvalidPagePath indicates a stored page path points outside the vault.
+var ErrInvalidPagePath = errors.New("page path outside the vault")
+
+// ObsidianVault writes pages as Markdown files with YAML frontmatter into a
+// vault folder, e.g. one synced with git by the Obsidian Git plugin. Digests
+// go to "Digests/" and stories to "Stories/"; a page keeps its file when its
+// title changes.
+type ObsidianVault struct {
+	dir string
+}
+
+// NewObsidianVault creates a target for a vault folder.
+func NewObsidianVault(dir string) *ObsidianVault {
+	return &ObsidianVault{dir: dir}
+}
+
+// Name implements Target.
+func (v *ObsidianVault) Name() string {
+	return TargetObsidian
+}
+
+// Upsert implements Target. The page ID is the file path relative to the vault.
+func (v *ObsidianVault) Upsert(_ context.Context, page Page, pageID string) (string, error) {
+	if pageID == "" {
+		pageID = obsidianPath(page)
+	}
+
+	if !filepath.IsLocal(pageID) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidPagePath, pageID)
+	}
+
+	path := filepath.Join(v.dir, pageID)
+
+	if err := os.MkdirAll(filepath.Dir(path), obsidianDirPerm); err != nil {
+		return "", fmt.Errorf("create vault folder: %w", err)
+	}
+
+	if err := writeFileAtomic(path, []byte(RenderMarkdown(page))); err != nil {
+		return "", err
+	}
+
+	return pageID, nil
+}
+
+// obsidianPath names a new page file: digests by their window end (UTC),
+// stories by title with an ID prefix to keep names unique.
+func obsidianPath(page Page) string {
+	if page.Kind == db.ExportKindDigest {
+		return filepath.Join(obsidianDigestDir, page.Date.UTC().Format(obsidianDigestName)+markdownExt)
+	}
+
+	id := page.EntityID
+	if len(id) > obsidianIDPrefixLen {
+		id = id[:obsidianIDPrefixLen]
+	}
+
+	return filepath.Join(obsidianStoryDir, fmt.Sprintf("%s (%s)%s", fileTitle(page.Title), id, markdownExt))
+}
+
+// fileTitle removes the characters that are invalid in file names or break
+// Obsidian links.
+func fileTitle(title string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`\/:*?"<>|#^[]`, r) || r < ' ' {
+			return -1
+		}
+
+		return r
+	}, title)
+
+	cleaned = strings.Join(strings.Fields(cleaned), " ")
+
+	if runes := []rune(cleaned); len(runes) > obsidianMaxTitleLen {
+		cleaned = strings.TrimSpace(string(runes[:obsidianMaxTitleLen]))
+	}
+
+	if cleaned == "" {
+		return untitledStory
+	}
+
+	return cleaned
+}
+
+// writeFileAtomic writes data through a temporary file, so sync tools never
+// see a partial page.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".export-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("write page: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close page: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace page: %w", err)
+	}
+
+	return nil
+}
+
+// RenderMarkdown renders a page as Markdown with its properties as YAML
+// frontmatter.
+func RenderMarkdown(page Page) string {
+	var sb strings.Builder
+
+	sb.WriteString("---\n")
+
+	for _, p := range page.Properties {
+		sb.WriteString(p.Key + ": " + yamlValue(p.Value) + "\n")
+	}
+
+	sb.WriteString("---\n\n# " + markdownEscape(page.Title) + "\n")
+
+	prev := ""
+
+	for _, b := range page.Blocks {
+		// Consecutive bullets form one list
+		if b.Type != BlockBullet || prev != BlockBullet {
+			sb.WriteString("\n")
+		}
+
+		switch b.Type {
+		case BlockHeading:
+			sb.WriteString("## ")
+		case BlockSubheading:
+			sb.WriteString("### ")
+		case BlockBullet:
+			sb.WriteString("- ")
+		}
+
+		sb.WriteString(markdownSpans(b.Spans) + "\n")
+
+		prev = b.Type
+	}
+
+	return sb.String()
+}
+
+func markdownSpans(spans []Span) string {
+	var sb strings.Builder
+
+	for _, s := range spans {
+		text := markdownEscape(s.Text)
+
+		switch {
+		case s.URL != "":
+			text = "[" + text + "](" + strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace(s.URL) + ")"
+		case s.Bold && text != "":
+			text = "**" + text + "**"
+		}
+
+		sb.WriteString(text)
+	}
+
+	return sb.String()
+}
+
+// markdownEscape escapes the characters that start Markdown formatting.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+	).Replace(s)
+}
+
+// yamlValue formats a property value as a YAML scalar or flow sequence.
+func yamlValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return strconv.Quote(val)
+	case int:
+		return strconv.Itoa(val)
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case []string:
+		quoted := make([]string, len(val))
+		for i, s := range val {
+			quoted[i] = strconv.Quote(s)
+		}
+
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return strconv.Quote(fmt.Sprint(val))
+	}
+}
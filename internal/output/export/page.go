@@ -0,0 +1,179 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/output/digest"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Block types of a page.
+const (
+	BlockHeading    = "heading"
+	BlockSubheading = "subheading"
+	BlockBullet     = "bullet"
+	BlockParagraph  = "paragraph"
+)
+
+const (
+	untitledStory   = "Untitled story"
+	storyDateLayout = "2006-01-02"
+	sourceSeparator = " — "
+	sourceLabel     = "source"
+)
+
+// Page is a digest or story in a target-neutral form: metadata properties
+// and a list of text blocks.
+type Page struct {
+	Kind     string
+	EntityID string
+	Title    string
+	Date     time.Time
+	// Properties are the page metadata, in order; values are strings, ints,
+	// times or string slices.
+	Properties []Property
+	Blocks     []Block
+}
+
+// Property is a metadata field of a page.
+type Property struct {
+	Key   string
+	Value any
+}
+
+// Block is a heading, bullet or paragraph made of text spans.
+type Block struct {
+	Type  string
+	Spans []Span
+}
+
+// Span is a run of text, optionally linked or bold.
+type Span struct {
+	Text string
+	URL  string
+	Bold bool
+}
+
+// BuildDigestPage converts a posted digest to a page with one heading per
+// tier and one bullet per item. Clusters with several items get their topic
+// as a subheading.
+func BuildDigestPage(digestID string, data digest.TemplateData) Page {
+	h := data.Header
+
+	page := Page{
+		Kind:     db.ExportKindDigest,
+		EntityID: digestID,
+		Title:    h.WindowTitle(),
+		Date:     h.End.UTC(),
+		Properties: []Property{
+			{Key: "type", Value: db.ExportKindDigest},
+			{Key: "id", Value: digestID},
+			{Key: "window_start", Value: h.Start.UTC()},
+			{Key: "window_end", Value: h.End.UTC()},
+			{Key: "items", Value: h.ItemCount},
+			{Key: "channels", Value: h.ChannelCount},
+			{Key: "topics", Value: h.TopicCount},
+			{Key: "tags", Value: []string{db.ExportKindDigest}},
+		},
+	}
+
+	for _, tier := range data.Tiers {
+		page.Blocks = append(page.Blocks, Block{Type: BlockHeading, Spans: []Span{{Text: tier.Emoji + " " + tier.Name}}})
+
+		for _, cluster := range tier.Clusters {
+			if len(cluster.Items) > 1 && cluster.Topic != "" {
+				page.Blocks = append(page.Blocks, Block{Type: BlockSubheading, Spans: []Span{{Text: cluster.Topic}}})
+			}
+
+			for _, item := range cluster.Items {
+				page.Blocks = append(page.Blocks, Block{Type: BlockBullet, Spans: digestItemSpans(item)})
+			}
+		}
+	}
+
+	return page
+}
+
+func digestItemSpans(item digest.TemplateItem) []Span {
+	spans := []Span{{Text: strings.TrimSpace(htmlutils.StripHTMLTags(item.Summary))}}
+
+	for _, src := range item.Sources {
+		if src.URL == "" {
+			continue
+		}
+
+		sep := ", "
+		if len(spans) == 1 {
+			sep = sourceSeparator
+		}
+
+		spans = append(spans, Span{Text: sep}, Span{Text: src.Channel, URL: src.URL})
+	}
+
+	return spans
+}
+
+// BuildStoryPage converts a story to a page with one bullet per timeline
+// event, linked to its source message.
+func BuildStoryPage(story db.Story, events []db.StoryEvent) Page {
+	title := story.Title
+	if title == "" {
+		title = untitledStory
+	}
+
+	props := []Property{
+		{Key: "type", Value: db.ExportKindStory},
+		{Key: "id", Value: story.ID},
+		{Key: "first_seen", Value: story.FirstSeenAt.UTC()},
+		{Key: "last_seen", Value: story.LastSeenAt.UTC()},
+		{Key: "clusters", Value: story.ClusterCount},
+		{Key: "events", Value: len(events)},
+	}
+
+	if story.TimelineUpdatedAt != nil {
+		props = append(props, Property{Key: "timeline_updated", Value: story.TimelineUpdatedAt.UTC()})
+	}
+
+	page := Page{
+		Kind:       db.ExportKindStory,
+		EntityID:   story.ID,
+		Title:      title,
+		Date:       story.LastSeenAt.UTC(),
+		Properties: append(props, Property{Key: "tags", Value: []string{db.ExportKindStory}}),
+	}
+
+	if len(events) == 0 {
+		page.Blocks = []Block{{Type: BlockParagraph, Spans: []Span{{Text: "The timeline has not been built yet."}}}}
+
+		return page
+	}
+
+	for _, e := range events {
+		spans := []Span{{Text: e.Date.UTC().Format(storyDateLayout), Bold: true}, {Text: sourceSeparator + e.Text}}
+
+		if e.MsgID != 0 {
+			label := e.ChannelUsername
+			if label == "" {
+				label = sourceLabel
+			}
+
+			spans = append(spans, Span{Text: " ("}, Span{Text: label, URL: messageURL(e)}, Span{Text: ")"})
+		}
+
+		page.Blocks = append(page.Blocks, Block{Type: BlockBullet, Spans: spans})
+	}
+
+	return page
+}
+
+// messageURL links a story event to its source message.
+func messageURL(e db.StoryEvent) string {
+	if e.ChannelUsername != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", e.ChannelUsername, e.MsgID)
+	}
+
+	return fmt.Sprintf("https://t.me/c/%d/%d", e.ChannelPeerID, e.MsgID)
+}
@@ -0,0 +1,258 @@
+package markup
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// markdownV2Special are the characters MarkdownV2 requires to be escaped
+// outside of code entities.
+const markdownV2Special = "_*[]()~`>#+-=|{}.!\\"
+
+// MarkdownV2 renders digest HTML as Telegram MarkdownV2.
+type MarkdownV2 struct{}
+
+// Format implements Renderer.
+func (MarkdownV2) Format() Format {
+	return FormatMarkdownV2
+}
+
+// ParseMode implements Renderer.
+func (MarkdownV2) ParseMode() string {
+	return ParseModeMarkdownV2
+}
+
+// Render implements Renderer. Telegram's HTML tags map to their MarkdownV2
+// entities, entities are decoded and text is escaped for the entity it is in.
+// Tags without a MarkdownV2 equivalent keep only their text; unclosed tags are
+// closed at the end.
+func (MarkdownV2) Render(text string) string {
+	w := &markdownV2Writer{stack: []*markdownV2Frame{{}}}
+	z := html.NewTokenizer(strings.NewReader(text))
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			for len(w.stack) > 1 {
+				w.close()
+			}
+
+			return w.stack[0].sb.String()
+		case html.TextToken:
+			w.text(string(z.Text()))
+		case html.StartTagToken:
+			w.open(z)
+		case html.SelfClosingTagToken:
+			if name, _ := z.TagName(); string(name) == "br" {
+				w.text("\n")
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			w.end(string(name))
+		}
+	}
+}
+
+// EscapeMarkdownV2 escapes text for use outside of MarkdownV2 entities.
+func EscapeMarkdownV2(text string) string {
+	return escapeChars(text, markdownV2Special)
+}
+
+// markdownV2Frame is an open HTML element and the MarkdownV2 rendered inside it.
+type markdownV2Frame struct {
+	tag  string
+	href string
+	lang string
+	sb   strings.Builder
+}
+
+// markdownV2Writer renders HTML tokens onto a stack of open elements.
+type markdownV2Writer struct {
+	stack []*markdownV2Frame
+}
+
+func (w *markdownV2Writer) top() *markdownV2Frame {
+	return w.stack[len(w.stack)-1]
+}
+
+// inCode reports whether text is inside a code or pre entity, where only `
+// and \ are escaped.
+func (w *markdownV2Writer) inCode() bool {
+	for _, f := range w.stack {
+		if f.tag == "code" || f.tag == "pre" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *markdownV2Writer) text(s string) {
+	if w.inCode() {
+		w.top().sb.WriteString(escapeChars(s, "`\\"))
+
+		return
+	}
+
+	w.top().sb.WriteString(EscapeMarkdownV2(s))
+}
+
+func (w *markdownV2Writer) open(z *html.Tokenizer) {
+	name, hasAttr := z.TagName()
+	frame := &markdownV2Frame{tag: normalizeTag(string(name))}
+
+	for hasAttr {
+		var key, val []byte
+
+		key, val, hasAttr = z.TagAttr()
+
+		switch string(key) {
+		case "href":
+			frame.href = string(val)
+		case "class":
+			class := string(val)
+			if class == "tg-spoiler" {
+				frame.tag = "tg-spoiler"
+			}
+
+			// <pre><code class="language-go"> sets the language of the block
+			if lang, ok := strings.CutPrefix(class, "language-"); ok && frame.tag == "code" && w.top().tag == "pre" {
+				w.top().lang = lang
+			}
+		}
+	}
+
+	if frame.tag == "br" {
+		w.text("\n")
+
+		return
+	}
+
+	w.stack = append(w.stack, frame)
+}
+
+// end closes the innermost open element named tag, and any elements opened
+// inside it. End tags without an open element are ignored.
+func (w *markdownV2Writer) end(tag string) {
+	tag = normalizeTag(tag)
+
+	for i := len(w.stack) - 1; i > 0; i-- {
+		// A span is only open as a spoiler
+		if w.stack[i].tag == tag || (tag == "span" && w.stack[i].tag == "tg-spoiler") {
+			for len(w.stack) > i {
+				w.close()
+			}
+
+			return
+		}
+	}
+}
+
+// close pops the innermost element and writes it as MarkdownV2 to its parent.
+func (w *markdownV2Writer) close() {
+	frame := w.top()
+	w.stack = w.stack[:len(w.stack)-1]
+	parent := w.top()
+	content := frame.sb.String()
+
+	switch frame.tag {
+	case "a":
+		if frame.href == "" {
+			parent.sb.WriteString(content)
+
+			return
+		}
+
+		parent.sb.WriteString("[" + content + "](" + escapeChars(frame.href, ")\\") + ")")
+	case "code":
+		if parent.tag == "pre" {
+			parent.sb.WriteString(content)
+
+			return
+		}
+
+		wrapEntity(&parent.sb, "`", content, "`")
+	case "pre":
+		wrapEntity(&parent.sb, "```"+frame.lang+"\n", content, "\n```")
+	case "blockquote":
+		if content == "" {
+			return
+		}
+
+		// Quote lines must start at the beginning of a line
+		if parent.sb.Len() > 0 && !strings.HasSuffix(parent.sb.String(), "\n") {
+			parent.sb.WriteString("\n")
+		}
+
+		parent.sb.WriteString(">" + strings.ReplaceAll(content, "\n", "\n>"))
+	default:
+		marker, ok := markdownV2Markers[frame.tag]
+		if !ok {
+			parent.sb.WriteString(content)
+
+			return
+		}
+
+		// "___" is read as underline first, so a closing italic marker
+		// right before an underline one needs the separator Telegram ignores.
+		closing := marker
+		if frame.tag == "u" && strings.HasSuffix(content, "_") && !strings.HasSuffix(content, "\\_") {
+			closing = "\r" + marker
+		}
+
+		wrapEntity(&parent.sb, marker, content, closing)
+	}
+}
+
+// markdownV2Markers are the delimiters of the inline MarkdownV2 entities.
+var markdownV2Markers = map[string]string{
+	"b":          "*",
+	"i":          "_",
+	"u":          "__",
+	"s":          "~",
+	"tg-spoiler": "||",
+}
+
+// normalizeTag maps HTML tag aliases Telegram accepts to one name.
+func normalizeTag(tag string) string {
+	switch tag {
+	case "strong":
+		return "b"
+	case "em":
+		return "i"
+	case "ins":
+		return "u"
+	case "strike", "del":
+		return "s"
+	default:
+		return tag
+	}
+}
+
+// wrapEntity writes content between markers, dropping empty entities that
+// Telegram would reject.
+func wrapEntity(sb *strings.Builder, open, content, closing string) {
+	if content == "" {
+		return
+	}
+
+	sb.WriteString(open + content + closing)
+}
+
+// escapeChars prefixes every character of s found in special with a backslash.
+func escapeChars(s, special string) string {
+	var sb strings.Builder
+
+	sb.Grow(len(s))
+
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			sb.WriteByte('\\')
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
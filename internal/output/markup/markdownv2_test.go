@@ -0,0 +1,53 @@
+package markup
+
+import "testing"
+
+func TestMarkdownV2Render(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text escaping", "Rates rose 0.25% (a record!)", `Rates rose 0\.25% \(a record\!\)`},
+		{"entities are decoded", "Tom &amp; Jerry &lt;3", `Tom & Jerry <3`},
+		{"bold and italic", "<b>Breaking</b> <i>news</i> <strong>now</strong>", `*Breaking* _news_ *now*`},
+		{"underline, strike and spoiler", "<u>a</u> <s>b</s> <tg-spoiler>c</tg-spoiler> <span class=\"tg-spoiler\">d</span>", `__a__ ~b~ ||c|| ||d||`},
+		{"italic inside underline", "<u><i>x</i></u>", "___x_\r__"},
+		{"link", `<a href="https://t.me/chan/1?a=(b)">@chan_news</a>`, `[@chan\_news](https://t.me/chan/1?a=(b\))`},
+		{"inline code", "<code>a_b `c`</code>", "`a_b \\`c\\``"},
+		{"pre with language", `<pre><code class="language-go">x := 1.5</code></pre>`, "```go\nx := 1.5\n```"},
+		{"blockquote", "Intro<blockquote>line one\nline two</blockquote>", "Intro\n>line one\n>line two"},
+		{"unsupported tags keep text", "<p>para</p><br>next", "para\nnext"},
+		{"empty entities are dropped", "<b></b>text", "text"},
+		{"unclosed tags are closed", "<b>bold <i>both", `*bold _both_*`},
+		{"stray end tags are ignored", "text</b>", "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (MarkdownV2{}).Render(tt.in); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"html": FormatHTML, " MarkdownV2 ": FormatMarkdownV2} {
+		if got, ok := ParseFormat(name); !ok || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q", name, got, ok, want)
+		}
+	}
+
+	if _, ok := ParseFormat("markdown"); ok {
+		t.Error("expected legacy markdown to be rejected")
+	}
+
+	if r := NewRenderer(FormatMarkdownV2); r.ParseMode() != ParseModeMarkdownV2 {
+		t.Errorf("parse mode = %q", r.ParseMode())
+	}
+
+	if r := NewRenderer(""); r.Format() != FormatHTML || r.Render("<b>x</b>") != "<b>x</b>" {
+		t.Error("expected unknown formats to render HTML unchanged")
+	}
+}
@@ -0,0 +1,70 @@
+// Package markup renders digest text for the Telegram parse modes a target
+// chat can use. Digests are built once as Telegram HTML from the digest data
+// model; a Renderer turns that markup into the output mode of a target.
+package markup
+
+import "strings"
+
+// Format is a digest output mode.
+type Format string
+
+// Supported output modes.
+const (
+	FormatHTML       Format = "html"
+	FormatMarkdownV2 Format = "markdownv2"
+)
+
+// Telegram parse modes of the output modes.
+const (
+	ParseModeHTML       = "HTML"
+	ParseModeMarkdownV2 = "MarkdownV2"
+)
+
+// Renderer converts digest HTML to an output mode.
+type Renderer interface {
+	// Format is the output mode the renderer produces.
+	Format() Format
+	// ParseMode is the Telegram parse mode for the rendered text.
+	ParseMode() string
+	// Render converts Telegram HTML to the output mode.
+	Render(html string) string
+}
+
+// ParseFormat parses an output mode name, case-insensitively.
+func ParseFormat(name string) (Format, bool) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case FormatHTML:
+		return FormatHTML, true
+	case FormatMarkdownV2:
+		return FormatMarkdownV2, true
+	default:
+		return "", false
+	}
+}
+
+// NewRenderer returns the renderer of an output mode. Unknown modes render HTML.
+func NewRenderer(format Format) Renderer {
+	if format == FormatMarkdownV2 {
+		return MarkdownV2{}
+	}
+
+	return HTML{}
+}
+
+// HTML keeps digest text as Telegram HTML.
+type HTML struct{}
+
+// Format implements Renderer.
+func (HTML) Format() Format {
+	return FormatHTML
+}
+
+// ParseMode implements Renderer.
+func (HTML) ParseMode() string {
+	return ParseModeHTML
+}
+
+// Render implements Renderer. Digest HTML is already in Telegram's subset.
+func (HTML) Render(html string) string {
+	return html
+}
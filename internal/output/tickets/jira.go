@@ -0,0 +1,102 @@
+package tickets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const jiraLabel = "telegram-digest"
+
+// Jira creates issues through the Jira REST API v2, which takes descriptions
+// in wiki markup. It works with Jira Cloud (email and API token) and Jira
+// Data Center (username and password or token).
+type Jira struct {
+	baseURL    string
+	auth       string
+	projectKey string
+	issueType  string
+	client     *http.Client
+}
+
+type jiraCreatedIssue struct {
+	Key string `json:"key"`
+}
+
+// NewJira creates a Jira tracker for a project.
+func NewJira(baseURL, user, token, projectKey, issueType string, timeout time.Duration) *Jira {
+	return &Jira{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		auth:       "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+token)),
+		projectKey: projectKey,
+		issueType:  issueType,
+		client:     newHTTPClient(timeout),
+	}
+}
+
+// Name implements the bot's ticket tracker.
+func (j *Jira) Name() string {
+	return TrackerJira
+}
+
+// CreateTicket creates a Jira issue for the ticket.
+func (j *Jira) CreateTicket(ctx context.Context, ticket Ticket) (Issue, error) {
+	var created jiraCreatedIssue
+
+	err := postJSON(ctx, j.client, j.baseURL+"/rest/api/2/issue", j.auth, map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": j.projectKey},
+			"issuetype":   map[string]string{"name": j.issueType},
+			"summary":     ticket.Title(),
+			"description": JiraDescription(ticket),
+			"labels":      []string{jiraLabel},
+		},
+	}, &created)
+	if err != nil {
+		return Issue{}, fmt.Errorf("create jira issue: %w", err)
+	}
+
+	return Issue{Key: created.Key, URL: j.baseURL + "/browse/" + created.Key}, nil
+}
+
+// JiraDescription renders the ticket body in Jira wiki markup.
+func JiraDescription(t Ticket) string {
+	var sb strings.Builder
+
+	sb.WriteString(jiraEscape(strings.TrimSpace(t.Summary)) + "\n\n")
+	fmt.Fprintf(&sb, "*Source:* [%s|%s] (%s)\n", jiraEscape(t.Channel), t.SourceURL, t.Date.UTC().Format(ticketDateFmt))
+
+	if t.Topic != "" {
+		fmt.Fprintf(&sb, "*Topic:* %s\n", jiraEscape(t.Topic))
+	}
+
+	fmt.Fprintf(&sb, "*Importance:* %.2f\n", t.Importance)
+
+	if len(t.Evidence) > 0 || t.Verdict != "" {
+		sb.WriteString("\n*Evidence*")
+
+		if t.Verdict != "" {
+			fmt.Fprintf(&sb, " (%s)", jiraEscape(t.Verdict))
+		}
+
+		sb.WriteString("\n")
+
+		for _, e := range t.Evidence {
+			fmt.Fprintf(&sb, "* [%s|%s] — %s\n", jiraEscape(e.Title), e.URL, evidenceLabel(e))
+		}
+	}
+
+	fmt.Fprintf(&sb, "\nItem ID: {{%s}}", t.ItemID)
+
+	return sb.String()
+}
+
+// jiraEscape escapes the characters that start wiki markup formatting.
+func jiraEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "[", `\[`, "]", `\]`, "|", `\|`, "{", `\{`, "}", `\}`, "*", `\*`, "_", `\_`,
+	).Replace(s)
+}
@@ -0,0 +1,129 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	linearGraphQLURL = "https://api.linear.app/graphql"
+
+	linearIssueCreate = `mutation IssueCreate($input: IssueCreateInput!) {
+  issue_create: issueCreate(input: $input) {
+    success
+    issue { identifier url }
+  }
+}`
+)
+
+// ErrTicketNotCreated indicates the tracker accepted the request but did not
+// create a ticket.
+var ErrTicketNotCreated = errors.New("ticket not created")
+
+// Linear creates issues through the Linear GraphQL API, which takes
+// descriptions in Markdown.
+type Linear struct {
+	apiKey   string
+	teamID   string
+	endpoint string
+	client   *http.Client
+}
+
+type linearResponse struct {
+	Data struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				Identifier string `json:"identifier"`
+				URL        string `json:"url"`
+			} `json:"issue"`
+		} `json:"issue_create"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// NewLinear creates a Linear tracker for a team. The API key is a personal
+// API key of the account that files the issues.
+func NewLinear(apiKey, teamID string, timeout time.Duration) *Linear {
+	return &Linear{apiKey: apiKey, teamID: teamID, endpoint: linearGraphQLURL, client: newHTTPClient(timeout)}
+}
+
+// Name implements the bot's ticket tracker.
+func (l *Linear) Name() string {
+	return TrackerLinear
+}
+
+// CreateTicket creates a Linear issue for the ticket.
+func (l *Linear) CreateTicket(ctx context.Context, ticket Ticket) (Issue, error) {
+	var resp linearResponse
+
+	err := postJSON(ctx, l.client, l.endpoint, l.apiKey, map[string]any{
+		"query": linearIssueCreate,
+		"variables": map[string]any{
+			"input": map[string]any{
+				"teamId":      l.teamID,
+				"title":       ticket.Title(),
+				"description": LinearDescription(ticket),
+			},
+		},
+	}, &resp)
+	if err != nil {
+		return Issue{}, fmt.Errorf("create linear issue: %w", err)
+	}
+
+	if len(resp.Errors) > 0 {
+		return Issue{}, fmt.Errorf("create linear issue: %w: %s", ErrTicketNotCreated, resp.Errors[0].Message)
+	}
+
+	created := resp.Data.IssueCreate
+	if !created.Success || created.Issue.Identifier == "" {
+		return Issue{}, fmt.Errorf("create linear issue: %w", ErrTicketNotCreated)
+	}
+
+	return Issue{Key: created.Issue.Identifier, URL: created.Issue.URL}, nil
+}
+
+// LinearDescription renders the ticket body in Markdown.
+func LinearDescription(t Ticket) string {
+	var sb strings.Builder
+
+	sb.WriteString(markdownEscape(strings.TrimSpace(t.Summary)) + "\n\n")
+	fmt.Fprintf(&sb, "**Source:** [%s](%s) (%s)  \n", markdownEscape(t.Channel), t.SourceURL, t.Date.UTC().Format(ticketDateFmt))
+
+	if t.Topic != "" {
+		fmt.Fprintf(&sb, "**Topic:** %s  \n", markdownEscape(t.Topic))
+	}
+
+	fmt.Fprintf(&sb, "**Importance:** %.2f\n", t.Importance)
+
+	if len(t.Evidence) > 0 || t.Verdict != "" {
+		sb.WriteString("\n**Evidence**")
+
+		if t.Verdict != "" {
+			fmt.Fprintf(&sb, " (%s)", markdownEscape(t.Verdict))
+		}
+
+		sb.WriteString("\n\n")
+
+		for _, e := range t.Evidence {
+			fmt.Fprintf(&sb, "- [%s](%s) — %s\n", markdownEscape(e.Title), e.URL, evidenceLabel(e))
+		}
+	}
+
+	fmt.Fprintf(&sb, "\nItem ID: `%s`", t.ItemID)
+
+	return sb.String()
+}
+
+// markdownEscape escapes the characters that start Markdown formatting.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+	).Replace(s)
+}
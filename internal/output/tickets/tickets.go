@@ -0,0 +1,140 @@
+// Package tickets creates issue tracker tickets for digest items, in Jira or
+// Linear, so teams can follow up on news operationally.
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Tracker names.
+const (
+	TrackerJira   = "jira"
+	TrackerLinear = "linear"
+)
+
+const (
+	defaultTimeout  = 15 * time.Second
+	errBodyLimit    = 512
+	maxTitleLen     = 120
+	ticketDateFmt   = "2006-01-02 15:04 MST"
+	untitledItemFmt = "News item %s"
+)
+
+// ErrUnexpectedStatus indicates the tracker rejected a request.
+var ErrUnexpectedStatus = errors.New("unexpected tracker status")
+
+// Ticket is the content of a ticket for an item.
+type Ticket struct {
+	ItemID     string
+	Summary    string
+	Topic      string
+	Channel    string
+	SourceURL  string
+	Date       time.Time
+	Importance float32
+	// Verdict is the overall evidence verdict, if the item was enriched.
+	Verdict  string
+	Evidence []Evidence
+}
+
+// Evidence is a source that supports or contradicts the item.
+type Evidence struct {
+	Title         string
+	URL           string
+	Agreement     float32
+	Contradiction bool
+}
+
+// Issue references a created ticket.
+type Issue struct {
+	Key string
+	URL string
+}
+
+// Title returns the ticket title: the first line of the summary, shortened
+// at a word boundary.
+func (t Ticket) Title() string {
+	title, _, _ := strings.Cut(strings.TrimSpace(t.Summary), "\n")
+	if title == "" {
+		return fmt.Sprintf(untitledItemFmt, t.ItemID)
+	}
+
+	runes := []rune(title)
+	if len(runes) <= maxTitleLen {
+		return title
+	}
+
+	cut := string(runes[:maxTitleLen])
+	if i := strings.LastIndex(cut, " "); i > maxTitleLen/2 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &http.Client{Timeout: timeout}
+}
+
+// postJSON sends payload to endpoint with the given authorization header and
+// decodes the response into out.
+func postJSON(ctx context.Context, client *http.Client, endpoint, authorization string, payload, out any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, err := io.ReadAll(io.LimitReader(resp.Body, errBodyLimit))
+		if err != nil {
+			return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+		}
+
+		return fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// evidenceLabel describes how a source relates to the item.
+func evidenceLabel(e Evidence) string {
+	if e.Contradiction {
+		return fmt.Sprintf("contradicts, agreement %.2f", e.Agreement)
+	}
+
+	return fmt.Sprintf("agreement %.2f", e.Agreement)
+}
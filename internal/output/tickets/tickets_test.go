@@ -0,0 +1,202 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTicket() Ticket {
+	return Ticket{
+		ItemID:     "11111111-2222-3333-4444-555555555555",
+		Summary:    "Port of Rotterdam *halts* operations",
+		Topic:      "Logistics",
+		Channel:    "@news",
+		SourceURL:  "https://t.me/news/42",
+		Date:       time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		Importance: 0.82,
+		Verdict:    "supported",
+		Evidence: []Evidence{
+			{Title: "Reuters [live]", URL: "https://example.com/a", Agreement: 0.9},
+			{Title: "Blog", URL: "https://example.com/b", Agreement: 0.2, Contradiction: true},
+		},
+	}
+}
+
+func TestTicketTitle(t *testing.T) {
+	long := strings.Repeat("word ", 40)
+
+	tests := []struct {
+		summary string
+		want    string
+	}{
+		{summary: "Short summary\nsecond line", want: "Short summary"},
+		{summary: "  ", want: "News item 1"},
+		{summary: long, want: strings.TrimSpace(strings.Repeat("word ", 24)) + "…"},
+	}
+
+	for _, tt := range tests {
+		if got := (Ticket{ItemID: "1", Summary: tt.summary}).Title(); got != tt.want {
+			t.Errorf("Title(%q) = %q, want %q", tt.summary, got, tt.want)
+		}
+	}
+}
+
+func TestJiraDescription(t *testing.T) {
+	want := "Port of Rotterdam \\*halts\\* operations\n\n" +
+		"*Source:* [@news|https://t.me/news/42] (2026-03-01 09:30 UTC)\n" +
+		"*Topic:* Logistics\n" +
+		"*Importance:* 0.82\n\n" +
+		"*Evidence* (supported)\n" +
+		"* [Reuters \\[live\\]|https://example.com/a] — agreement 0.90\n" +
+		"* [Blog|https://example.com/b] — contradicts, agreement 0.20\n\n" +
+		"Item ID: {{11111111-2222-3333-4444-555555555555}}"
+
+	if got := JiraDescription(testTicket()); got != want {
+		t.Errorf("JiraDescription() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLinearDescription(t *testing.T) {
+	got := LinearDescription(testTicket())
+
+	for _, want := range []string{
+		"Port of Rotterdam \\*halts\\* operations\n\n",
+		"**Source:** [@news](https://t.me/news/42) (2026-03-01 09:30 UTC)",
+		"- [Reuters \\[live\\]](https://example.com/a) — agreement 0.90\n",
+		"Item ID: `11111111-2222-3333-4444-555555555555`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("LinearDescription() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestJiraCreateTicket(t *testing.T) {
+	var fields map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue" || r.Header.Get("Authorization") != "Basic dXNlckBleGFtcGxlLmNvbTp0b2tlbg==" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		fields = body.Fields
+
+		w.WriteHeader(http.StatusCreated)
+
+		if _, err := w.Write([]byte(`{"id":"10001","key":"NEWS-7"}`)); err != nil {
+			t.Errorf("write response: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	issue, err := NewJira(srv.URL+"/", "user@example.com", "token", "NEWS", "Task", time.Second).CreateTicket(context.Background(), testTicket())
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+
+	if issue.Key != "NEWS-7" || issue.URL != srv.URL+"/browse/NEWS-7" {
+		t.Errorf("issue = %+v", issue)
+	}
+
+	if fields["summary"] != "Port of Rotterdam *halts* operations" {
+		t.Errorf("summary = %v", fields["summary"])
+	}
+
+	if project, ok := fields["project"].(map[string]any); !ok || project["key"] != "NEWS" {
+		t.Errorf("project = %v", fields["project"])
+	}
+}
+
+func TestJiraCreateTicketRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+
+		if _, err := w.Write([]byte(`{"errors":{"project":"project is required"}}`)); err != nil {
+			t.Errorf("write response: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	_, err := NewJira(srv.URL, "u", "t", "NEWS", "Task", time.Second).CreateTicket(context.Background(), testTicket())
+	if !errors.Is(err, ErrUnexpectedStatus) || !strings.Contains(err.Error(), "project is required") {
+		t.Errorf("err = %v, want %v with the response body", err, ErrUnexpectedStatus)
+	}
+}
+
+func TestLinearCreateTicket(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    string
+		wantKey string
+		wantErr error
+	}{
+		{
+			name:    "created",
+			resp:    `{"data":{"issue_create":{"success":true,"issue":{"identifier":"OPS-3","url":"https://linear.app/acme/issue/OPS-3"}}}}`,
+			wantKey: "OPS-3",
+		},
+		{
+			name:    "graphql error",
+			resp:    `{"errors":[{"message":"team not found"}]}`,
+			wantErr: ErrTicketNotCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Query     string                    `json:"query"`
+					Variables map[string]map[string]any `json:"variables"`
+				}
+
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Header.Get("Authorization") != "lin_api_key" {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				if body.Variables["input"]["teamId"] != "team-1" || !strings.Contains(body.Query, "issueCreate") {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				if _, err := w.Write([]byte(tt.resp)); err != nil {
+					t.Errorf("write response: %v", err)
+				}
+			}))
+			defer srv.Close()
+
+			linear := NewLinear("lin_api_key", "team-1", time.Second)
+			linear.endpoint = srv.URL
+
+			issue, err := linear.CreateTicket(context.Background(), testTicket())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if issue.Key != tt.wantKey {
+				t.Errorf("key = %q, want %q", issue.Key, tt.wantKey)
+			}
+		})
+	}
+}
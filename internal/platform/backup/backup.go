@@ -0,0 +1,283 @@
+// Package backup takes and restores logical backups of the bot database.
+//
+// A backup is a directory with one gzipped COPY file per table and a
+// manifest recording the schema version, row counts, checksums and pgvector
+// statistics. All tables are dumped from a single REPEATABLE READ snapshot,
+// so the backup is consistent while the bot keeps running. Materialized
+// views are not dumped; a restore refreshes them from the restored tables.
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// schemaVersionTable is goose's bookkeeping table; a restore recreates it by
+// migrating.
+const schemaVersionTable = "goose_db_version"
+
+var errBackupExists = errors.New("backup directory already contains a backup")
+
+// mediaColumns are the columns holding downloaded media, dumped as NULL when
+// media is excluded.
+var mediaColumns = map[string][]string{
+	"raw_messages": {"media_data"},
+}
+
+// Options configures a backup.
+type Options struct {
+	// Dir is the backup directory; it is created if missing and must not
+	// already contain a backup.
+	Dir string
+	// ExcludeMedia dumps media columns as NULL, which keeps backups small.
+	ExcludeMedia bool
+}
+
+// column is a table column as dumped.
+type column struct {
+	name   string
+	vector bool
+}
+
+// Backup dumps every table of the public schema into opts.Dir and returns
+// the manifest it wrote.
+func Backup(ctx context.Context, database *db.DB, opts Options) (*Manifest, error) {
+	ctx = db.WithoutQueryTimeout(ctx)
+
+	if _, err := os.Stat(filepath.Join(opts.Dir, ManifestFile)); err == nil {
+		return nil, fmt.Errorf("%w: %s", errBackupExists, opts.Dir)
+	}
+
+	if err := os.MkdirAll(opts.Dir, backupDirPerm); err != nil {
+		return nil, fmt.Errorf("create backup directory: %w", err)
+	}
+
+	m := &Manifest{FormatVersion: formatVersion, CreatedAt: time.Now().UTC(), ExcludeMedia: opts.ExcludeMedia}
+
+	snapshot := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+
+	err := pgx.BeginTxFunc(ctx, database.Pool, snapshot, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+			return fmt.Errorf("disable statement timeout: %w", err)
+		}
+
+		if err := tx.QueryRow(ctx, `
+			SELECT COALESCE(max(version_id), 0) FROM goose_db_version WHERE is_applied
+		`).Scan(&m.SchemaVersion); err != nil {
+			return fmt.Errorf("get schema version: %w", err)
+		}
+
+		return dumpSnapshot(ctx, tx, opts, m)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backup: %w", err)
+	}
+
+	if err := writeManifest(opts.Dir, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func dumpSnapshot(ctx context.Context, tx pgx.Tx, opts Options, m *Manifest) error {
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		columns, err := listColumns(ctx, tx, table)
+		if err != nil {
+			return err
+		}
+
+		dump, err := dumpTable(ctx, tx, opts, table, columns)
+		if err != nil {
+			return err
+		}
+
+		m.Tables = append(m.Tables, dump)
+	}
+
+	m.MaterializedViews, err = listMaterializedViews(ctx, tx)
+
+	return err
+}
+
+func listTables(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT table_name::text
+		FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE' AND table_name <> $1
+		ORDER BY table_name
+	`, schemaVersionTable)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	return tables, nil
+}
+
+// listColumns returns the writable columns of table; generated columns are
+// recomputed on restore.
+func listColumns(ctx context.Context, tx pgx.Tx, table string) ([]column, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT column_name::text, udt_name = 'vector'
+		FROM information_schema.columns
+		WHERE table_schema = 'public' AND table_name = $1 AND is_generated = 'NEVER'
+		ORDER BY ordinal_position
+	`, table)
+	if err != nil {
+		return nil, fmt.Errorf("list columns of %s: %w", table, err)
+	}
+
+	defer rows.Close()
+
+	var columns []column
+
+	for rows.Next() {
+		var c column
+		if err := rows.Scan(&c.name, &c.vector); err != nil {
+			return nil, fmt.Errorf("scan column of %s: %w", table, err)
+		}
+
+		columns = append(columns, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list columns of %s: %w", table, err)
+	}
+
+	return columns, nil
+}
+
+func listMaterializedViews(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT matviewname::text FROM pg_matviews WHERE schemaname = 'public' ORDER BY matviewname
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list materialized views: %w", err)
+	}
+
+	views, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list materialized views: %w", err)
+	}
+
+	return views, nil
+}
+
+// dumpTable copies table into a gzipped file, hashing the compressed bytes.
+func dumpTable(ctx context.Context, tx pgx.Tx, opts Options, table string, columns []column) (TableDump, error) {
+	dump := TableDump{Name: table, File: table + tableFileSuffix}
+
+	for _, c := range columns {
+		dump.Columns = append(dump.Columns, c.name)
+	}
+
+	f, err := os.OpenFile(filepath.Join(opts.Dir, dump.File), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, backupFilePerm)
+	if err != nil {
+		return dump, fmt.Errorf("create %s: %w", dump.File, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(f, h))
+
+	query := fmt.Sprintf("COPY (SELECT %s FROM %s) TO STDOUT", selectList(table, columns, opts.ExcludeMedia), pgx.Identifier{table}.Sanitize())
+
+	tag, err := tx.Conn().PgConn().CopyTo(ctx, gz, query)
+	if err != nil {
+		return dump, fmt.Errorf("dump %s: %w", table, err)
+	}
+
+	if err := gz.Close(); err != nil {
+		return dump, fmt.Errorf("write %s: %w", dump.File, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return dump, fmt.Errorf("write %s: %w", dump.File, err)
+	}
+
+	dump.Rows = tag.RowsAffected()
+	dump.SHA256 = hex.EncodeToString(h.Sum(nil))
+
+	dump.Vectors, err = vectorStats(ctx, tx, table, columns)
+
+	return dump, err
+}
+
+// selectList returns the dumped columns of table, with media columns
+// replaced by NULL when excludeMedia is set.
+func selectList(table string, columns []column, excludeMedia bool) string {
+	exprs := make([]string, 0, len(columns))
+
+	for _, c := range columns {
+		name := pgx.Identifier{c.name}.Sanitize()
+
+		if excludeMedia && isMediaColumn(table, c.name) {
+			name = "NULL AS " + name
+		}
+
+		exprs = append(exprs, name)
+	}
+
+	return strings.Join(exprs, ", ")
+}
+
+func isMediaColumn(table, name string) bool {
+	return slices.Contains(mediaColumns[table], name)
+}
+
+// vectorStats records the non-null count and the dimensions of each vector
+// column of table.
+func vectorStats(ctx context.Context, q querier, table string, columns []column) ([]VectorColumn, error) {
+	var stats []VectorColumn
+
+	for _, c := range columns {
+		if !c.vector {
+			continue
+		}
+
+		name := pgx.Identifier{c.name}.Sanitize()
+		stat := VectorColumn{Column: c.name}
+
+		query := fmt.Sprintf(`
+			SELECT count(%[1]s), COALESCE(array_agg(DISTINCT vector_dims(%[1]s) ORDER BY vector_dims(%[1]s)) FILTER (WHERE %[1]s IS NOT NULL), '{}')
+			FROM %[2]s
+		`, name, pgx.Identifier{table}.Sanitize())
+
+		if err := q.QueryRow(ctx, query).Scan(&stat.NonNull, &stat.Dims); err != nil {
+			return nil, fmt.Errorf("vector stats of %s.%s: %w", table, c.name, err)
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// querier is the subset of pgx.Tx and pgxpool.Pool used for reads.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
@@ -0,0 +1,111 @@
+package backup
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+
+	data := []byte("compressed table data")
+	if err := os.WriteFile(filepath.Join(dir, "items.copy.gz"), data, backupFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := fileSHA256(filepath.Join(dir, "items.copy.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manifest{
+		FormatVersion: formatVersion,
+		SchemaVersion: 20260307000000,
+		Tables:        []TableDump{{Name: "items", File: "items.copy.gz", Rows: 3, SHA256: sum}},
+	}
+	if err := writeManifest(dir, m); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Verify(dir)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if got.SchemaVersion != m.SchemaVersion || got.Rows() != 3 {
+		t.Errorf("Verify() = %+v, want schema %d and 3 rows", got, m.SchemaVersion)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "items.copy.gz"), []byte("tampered"), backupFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Verify(dir); !errors.Is(err, errChecksumMismatch) {
+		t.Errorf("Verify() after tampering error = %v, want %v", err, errChecksumMismatch)
+	}
+}
+
+func TestReadManifestRejectsUnknownFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := writeManifest(dir, &Manifest{FormatVersion: formatVersion + 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadManifest(dir); !errors.Is(err, errUnsupportedFormat) {
+		t.Errorf("ReadManifest() error = %v, want %v", err, errUnsupportedFormat)
+	}
+}
+
+func TestRestoreOrder(t *testing.T) {
+	tables := []TableDump{{Name: "channels"}, {Name: "items"}, {Name: "raw_messages"}, {Name: "settings"}}
+	deps := map[string][]string{
+		"items":        {"raw_messages", "items"},
+		"raw_messages": {"channels"},
+		"settings":     {"not_restored"},
+	}
+
+	var got []string
+	for _, table := range restoreOrder(tables, deps) {
+		got = append(got, table.Name)
+	}
+
+	want := []string{"channels", "raw_messages", "settings", "items"}
+	if len(got) != len(want) {
+		t.Fatalf("restoreOrder() = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("restoreOrder() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRestoreOrderKeepsCycles(t *testing.T) {
+	tables := []TableDump{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	deps := map[string][]string{"a": {"b"}, "b": {"a"}}
+
+	got := restoreOrder(tables, deps)
+	if len(got) != len(tables) || got[0].Name != "c" {
+		t.Errorf("restoreOrder() = %+v, want c first and all tables kept", got)
+	}
+}
+
+func TestSelectList(t *testing.T) {
+	columns := []column{{name: "id"}, {name: "media_data"}, {name: "embedding", vector: true}}
+
+	if got, want := selectList("raw_messages", columns, false), `"id", "media_data", "embedding"`; got != want {
+		t.Errorf("selectList() = %s, want %s", got, want)
+	}
+
+	if got, want := selectList("raw_messages", columns, true), `"id", NULL AS "media_data", "embedding"`; got != want {
+		t.Errorf("selectList(excludeMedia) = %s, want %s", got, want)
+	}
+
+	if got, want := selectList("items", columns, true), `"id", "media_data", "embedding"`; got != want {
+		t.Errorf("selectList(items, excludeMedia) = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,139 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// ManifestFile is the name of the manifest in a backup directory.
+	ManifestFile = "manifest.json"
+
+	// formatVersion is bumped when the backup layout changes incompatibly.
+	formatVersion = 1
+
+	tableFileSuffix = ".copy.gz"
+	backupDirPerm   = 0o700
+	backupFilePerm  = 0o600
+)
+
+var (
+	errUnsupportedFormat = errors.New("unsupported backup format version")
+	errChecksumMismatch  = errors.New("backup file checksum mismatch")
+)
+
+// Manifest describes a backup: the schema version it was taken at and, per
+// table, the dumped columns, row count and checksum.
+type Manifest struct {
+	FormatVersion     int         `json:"format_version"`
+	SchemaVersion     int64       `json:"schema_version"`
+	CreatedAt         time.Time   `json:"created_at"`
+	ExcludeMedia      bool        `json:"exclude_media"`
+	Tables            []TableDump `json:"tables"`
+	MaterializedViews []string    `json:"materialized_views"`
+}
+
+// TableDump is one table's data file.
+type TableDump struct {
+	Name    string         `json:"name"`
+	File    string         `json:"file"`
+	Columns []string       `json:"columns"`
+	Rows    int64          `json:"rows"`
+	SHA256  string         `json:"sha256"`
+	Vectors []VectorColumn `json:"vectors,omitempty"`
+}
+
+// VectorColumn records the pgvector values of a column, checked again after
+// a restore: the number of non-null vectors and their dimensions.
+type VectorColumn struct {
+	Column  string  `json:"column"`
+	NonNull int64   `json:"non_null"`
+	Dims    []int32 `json:"dims"`
+}
+
+// Rows returns the number of rows across all tables.
+func (m *Manifest) Rows() int64 {
+	var rows int64
+
+	for _, t := range m.Tables {
+		rows += t.Rows
+	}
+
+	return rows
+}
+
+func writeManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), data, backupFilePerm); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ReadManifest reads the manifest of the backup in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+
+	if m.FormatVersion != formatVersion {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedFormat, m.FormatVersion)
+	}
+
+	return &m, nil
+}
+
+// Verify checks the table files of the backup in dir against the checksums
+// in its manifest, without a database.
+func Verify(dir string) (*Manifest, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range m.Tables {
+		sum, err := fileSHA256(filepath.Join(dir, t.File))
+		if err != nil {
+			return nil, err
+		}
+
+		if sum != t.SHA256 {
+			return nil, fmt.Errorf("%w: %s", errChecksumMismatch, t.File)
+		}
+	}
+
+	return m, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", filepath.Base(path), err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,356 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+var (
+	errTargetNotEmpty   = errors.New("restore target is not a fresh database")
+	errRowCountMismatch = errors.New("restored row count does not match backup")
+	errVectorMismatch   = errors.New("restored vectors do not match backup")
+	errMissingView      = errors.New("materialized view missing after restore")
+)
+
+// RestoreReport summarizes a restore.
+type RestoreReport struct {
+	// SchemaVersion is the schema version of the backup; MigratedTo is the
+	// version after applying the newer migrations.
+	SchemaVersion int64
+	MigratedTo    int64
+	Tables        int
+	Rows          int64
+	VectorColumns int
+	// Views maps each refreshed materialized view to its row count.
+	Views map[string]int64
+}
+
+// Restore loads the backup in dir into database, which must not have been
+// migrated yet. It migrates to the backup's schema version, loads every
+// table in foreign key order in one transaction and checks row counts and
+// pgvector statistics against the manifest, then applies the remaining
+// migrations and refreshes the materialized views.
+func Restore(ctx context.Context, database *db.DB, dir string) (*RestoreReport, error) {
+	ctx = db.WithoutQueryTimeout(ctx)
+
+	m, err := Verify(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := database.SchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 {
+		return nil, fmt.Errorf("%w: schema version %d", errTargetNotEmpty, version)
+	}
+
+	if err := database.MigrateTo(ctx, m.SchemaVersion); err != nil {
+		return nil, err
+	}
+
+	report := &RestoreReport{SchemaVersion: m.SchemaVersion, Tables: len(m.Tables), Rows: m.Rows()}
+
+	if err := loadTables(ctx, database, dir, m, report); err != nil {
+		return nil, err
+	}
+
+	if err := database.Migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	if report.MigratedTo, err = database.SchemaVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	if report.Views, err = refreshViews(ctx, database, m, report.MigratedTo); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// loadTables replaces the rows seeded by migrations with the backup.
+func loadTables(ctx context.Context, database *db.DB, dir string, m *Manifest, report *RestoreReport) error {
+	deps, err := foreignKeyDeps(ctx, database)
+	if err != nil {
+		return err
+	}
+
+	tables := restoreOrder(m.Tables, deps)
+
+	err = pgx.BeginFunc(ctx, database.Pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+			return fmt.Errorf("disable statement timeout: %w", err)
+		}
+
+		names := make([]string, 0, len(tables))
+		for _, t := range tables {
+			names = append(names, pgx.Identifier{t.Name}.Sanitize())
+		}
+
+		if _, err := tx.Exec(ctx, "TRUNCATE "+strings.Join(names, ", ")); err != nil {
+			return fmt.Errorf("truncate tables: %w", err)
+		}
+
+		for _, t := range tables {
+			if err := loadTable(ctx, tx, dir, t); err != nil {
+				return err
+			}
+
+			report.VectorColumns += len(t.Vectors)
+		}
+
+		return resetSequences(ctx, tx)
+	})
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	return nil
+}
+
+func loadTable(ctx context.Context, tx pgx.Tx, dir string, t TableDump) error {
+	f, err := os.Open(filepath.Join(dir, t.File))
+	if err != nil {
+		return fmt.Errorf("open %s: %w", t.File, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", t.File, err)
+	}
+	defer gz.Close()
+
+	query := fmt.Sprintf("COPY %s (%s) FROM STDIN", pgx.Identifier{t.Name}.Sanitize(), columnList(t.Columns))
+
+	tag, err := tx.Conn().PgConn().CopyFrom(ctx, gz, query)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", t.Name, err)
+	}
+
+	if tag.RowsAffected() != t.Rows {
+		return fmt.Errorf("%w: %s has %d rows, expected %d", errRowCountMismatch, t.Name, tag.RowsAffected(), t.Rows)
+	}
+
+	return verifyVectors(ctx, tx, t)
+}
+
+// verifyVectors compares the restored vector columns of t with the manifest,
+// catching vectors that were truncated or lost their dimensions.
+func verifyVectors(ctx context.Context, tx pgx.Tx, t TableDump) error {
+	columns := make([]column, 0, len(t.Vectors))
+	for _, v := range t.Vectors {
+		columns = append(columns, column{name: v.Column, vector: true})
+	}
+
+	restored, err := vectorStats(ctx, tx, t.Name, columns)
+	if err != nil {
+		return err
+	}
+
+	for i, want := range t.Vectors {
+		got := restored[i]
+		if got.NonNull != want.NonNull || !slices.Equal(got.Dims, want.Dims) {
+			return fmt.Errorf("%w: %s.%s has %d vectors of dims %v, expected %d of dims %v",
+				errVectorMismatch, t.Name, want.Column, got.NonNull, got.Dims, want.NonNull, want.Dims)
+		}
+	}
+
+	return nil
+}
+
+func columnList(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, c := range columns {
+		quoted = append(quoted, pgx.Identifier{c}.Sanitize())
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// foreignKeyDeps maps each table to the tables it references.
+func foreignKeyDeps(ctx context.Context, database *db.DB) (map[string][]string, error) {
+	rows, err := database.Pool.Query(ctx, `
+		SELECT child.relname::text, parent.relname::text
+		FROM pg_constraint c
+		JOIN pg_class child ON child.oid = c.conrelid
+		JOIN pg_class parent ON parent.oid = c.confrelid
+		JOIN pg_namespace n ON n.oid = child.relnamespace
+		WHERE c.contype = 'f' AND n.nspname = 'public'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	deps := make(map[string][]string)
+
+	for rows.Next() {
+		var child, parent string
+		if err := rows.Scan(&child, &parent); err != nil {
+			return nil, fmt.Errorf("scan foreign key: %w", err)
+		}
+
+		deps[child] = append(deps[child], parent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list foreign keys: %w", err)
+	}
+
+	return deps, nil
+}
+
+// restoreOrder sorts tables so referenced tables load first, keeping the
+// manifest order otherwise. Self references are ignored; tables in a
+// reference cycle keep their manifest order at the end.
+func restoreOrder(tables []TableDump, deps map[string][]string) []TableDump {
+	ordered := make([]TableDump, 0, len(tables))
+	loaded := make(map[string]bool, len(tables))
+	pending := slices.Clone(tables)
+
+	for len(pending) > 0 {
+		next := pending[:0]
+
+		for _, t := range pending {
+			if parentsLoaded(t.Name, deps[t.Name], loaded, tables) {
+				ordered = append(ordered, t)
+				loaded[t.Name] = true
+			} else {
+				next = append(next, t)
+			}
+		}
+
+		if len(next) == len(pending) {
+			return append(ordered, next...)
+		}
+
+		pending = next
+	}
+
+	return ordered
+}
+
+// parentsLoaded reports whether every restored table that name references
+// is already loaded.
+func parentsLoaded(name string, parents []string, loaded map[string]bool, tables []TableDump) bool {
+	for _, parent := range parents {
+		if parent == name || loaded[parent] {
+			continue
+		}
+
+		if slices.ContainsFunc(tables, func(t TableDump) bool { return t.Name == parent }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ownedSequence is a sequence generating the values of a table column.
+type ownedSequence struct {
+	name   string
+	table  string
+	column string
+}
+
+// resetSequences moves serial and identity sequences past the restored ids.
+func resetSequences(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT seq.relname::text, tbl.relname::text, a.attname::text
+		FROM pg_depend d
+		JOIN pg_class seq ON seq.oid = d.objid AND seq.relkind = 'S'
+		JOIN pg_class tbl ON tbl.oid = d.refobjid
+		JOIN pg_namespace n ON n.oid = tbl.relnamespace
+		JOIN pg_attribute a ON a.attrelid = tbl.oid AND a.attnum = d.refobjsubid
+		WHERE d.deptype IN ('a', 'i') AND n.nspname = 'public'
+	`)
+	if err != nil {
+		return fmt.Errorf("list sequences: %w", err)
+	}
+
+	defer rows.Close()
+
+	var sequences []ownedSequence
+
+	for rows.Next() {
+		var s ownedSequence
+		if err := rows.Scan(&s.name, &s.table, &s.column); err != nil {
+			return fmt.Errorf("scan sequence: %w", err)
+		}
+
+		sequences = append(sequences, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("list sequences: %w", err)
+	}
+
+	rows.Close()
+
+	for _, s := range sequences {
+		query := fmt.Sprintf("SELECT setval($1::regclass, COALESCE(max(%s), 0) + 1, false) FROM %s",
+			pgx.Identifier{s.column}.Sanitize(), pgx.Identifier{s.table}.Sanitize())
+
+		if _, err := tx.Exec(ctx, query, pgx.Identifier{s.name}.Sanitize()); err != nil {
+			return fmt.Errorf("reset sequence %s: %w", s.name, err)
+		}
+	}
+
+	return nil
+}
+
+// refreshViews populates the materialized views of the restored database and
+// counts their rows. Views of the backup must exist unless newer migrations
+// ran, which may have dropped them.
+func refreshViews(ctx context.Context, database *db.DB, m *Manifest, migratedTo int64) (map[string]int64, error) {
+	var views []string
+
+	err := pgx.BeginFunc(ctx, database.Pool, func(tx pgx.Tx) error {
+		var err error
+
+		views, err = listMaterializedViews(ctx, tx)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, view := range m.MaterializedViews {
+		if migratedTo == m.SchemaVersion && !slices.Contains(views, view) {
+			return nil, fmt.Errorf("%w: %s", errMissingView, view)
+		}
+	}
+
+	counts := make(map[string]int64, len(views))
+
+	for _, view := range views {
+		if err := database.RefreshMaterializedView(ctx, view); err != nil {
+			return nil, err
+		}
+
+		var rows int64
+		if err := database.Pool.QueryRow(ctx, "SELECT count(*) FROM "+pgx.Identifier{view}.Sanitize()).Scan(&rows); err != nil {
+			return nil, fmt.Errorf("count rows of %s: %w", view, err)
+		}
+
+		counts[view] = rows
+	}
+
+	return counts, nil
+}
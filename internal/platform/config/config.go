@@ -24,6 +24,9 @@ type Config struct {
 	DBMaxConnIdleTime             time.Duration `env:"DB_MAX_CONN_IDLE_TIME" envDefault:"30m"`
 	DBMaxConnLifetime             time.Duration `env:"DB_MAX_CONN_LIFETIME" envDefault:"1h"`
 	DBHealthCheckPeriod           time.Duration `env:"DB_HEALTH_CHECK_PERIOD" envDefault:"1m"`
+	DBStatementTimeout            time.Duration `env:"DB_STATEMENT_TIMEOUT" envDefault:"5m"`
+	DBQueryTimeout                time.Duration `env:"DB_QUERY_TIMEOUT" envDefault:"2m"`
+	DBSlowQueryThreshold          time.Duration `env:"DB_SLOW_QUERY_THRESHOLD" envDefault:"1s"`
 	BotToken                      string        `env:"BOT_TOKEN,required"`
 	TelegramBotUsername           string        `env:"TELEGRAM_BOT_USERNAME" envDefault:""`
 	AdminIDs                      []int64       `env:"ADMIN_IDS" envSeparator:","`
@@ -48,9 +51,11 @@ type Config struct {
 	WorkerPollInterval            string        `env:"WORKER_POLL_INTERVAL" envDefault:"10s"`
 	SchedulerTickInterval         string        `env:"SCHEDULER_TICK_INTERVAL" envDefault:"10m"`
 	SchedulerCatchupWindow        string        `env:"SCHEDULER_CATCHUP_WINDOW" envDefault:"24h"`
+	DigestBuildConcurrency        int           `env:"DIGEST_BUILD_CONCURRENCY" envDefault:"4"`
 	RelevanceGateEnabled          bool          `env:"RELEVANCE_GATE_ENABLED" envDefault:"false"`
 	RelevanceGateMode             string        `env:"RELEVANCE_GATE_MODE" envDefault:"heuristic"`
 	RelevanceGateModel            string        `env:"RELEVANCE_GATE_MODEL"`
+	RelevanceGateShadowModel      string        `env:"RELEVANCE_GATE_SHADOW_MODEL"`
 	TopicDiversityCap             float32       `env:"TOPIC_DIVERSITY_CAP" envDefault:"0.30"`
 	FreshnessDecayHours           int           `env:"FRESHNESS_DECAY_HOURS" envDefault:"36"`
 	FreshnessFloor                float32       `env:"FRESHNESS_FLOOR" envDefault:"0.4"`
@@ -68,8 +73,14 @@ type Config struct {
 	CrossTopicSimilarityThreshold float32       `env:"CROSS_TOPIC_SIMILARITY_THRESHOLD" envDefault:"0.90"`
 	EvidenceClusteringBoost       float32       `env:"EVIDENCE_CLUSTERING_BOOST" envDefault:"0.15"`
 	EvidenceClusteringMinScore    float32       `env:"EVIDENCE_CLUSTERING_MIN_SCORE" envDefault:"0.5"`
+	StoryTrackingEnabled          bool          `env:"STORY_TRACKING_ENABLED" envDefault:"true"`
+	StoryLinkThreshold            float32       `env:"STORY_LINK_THRESHOLD" envDefault:"0.8"`
+	StoryLookbackDays             int           `env:"STORY_LOOKBACK_DAYS" envDefault:"7"`
+	RepeatStoryDigests            int           `env:"REPEAT_STORY_DIGESTS" envDefault:"0"`
+	RepeatStoryMinDelta           float32       `env:"REPEAT_STORY_MIN_DELTA" envDefault:"0.15"`
 	RatingMinSampleChannel        int           `env:"RATING_MIN_SAMPLE_CHANNEL" envDefault:"15"`
 	RatingMinSampleGlobal         int           `env:"RATING_MIN_SAMPLE_GLOBAL" envDefault:"100"`
+	ChannelTrialDays              int           `env:"CHANNEL_TRIAL_DAYS" envDefault:"7"`
 	FilterMinLengthRu             int           `env:"FILTER_MIN_LENGTH_RU" envDefault:"20"`
 	FilterMinLengthUk             int           `env:"FILTER_MIN_LENGTH_UK" envDefault:"20"`
 	FilterMinLengthEn             int           `env:"FILTER_MIN_LENGTH_EN" envDefault:"15"`
@@ -81,6 +92,7 @@ type Config struct {
 	DedupSameChannelWindowHours   int           `env:"DEDUP_SAME_CHANNEL_WINDOW_HOURS" envDefault:"6"`
 	CorroborationImportanceBoost  float32       `env:"CORROBORATION_IMPORTANCE_BOOST" envDefault:"0.08"`
 	SingleSourcePenalty           float32       `env:"SINGLE_SOURCE_PENALTY" envDefault:"0.05"`
+	CorroborationOverlapThreshold float64       `env:"CORROBORATION_OVERLAP_THRESHOLD" envDefault:"0.5"`
 	DomainAllowlist               string        `env:"DOMAIN_ALLOWLIST" envDefault:""`
 	DomainDenylist                string        `env:"DOMAIN_DENYLIST" envDefault:""`
 	FactCheckGoogleEnabled        bool          `env:"FACTCHECK_GOOGLE_ENABLED" envDefault:"false"`
@@ -113,6 +125,16 @@ type Config struct {
 	LinkCanonicalTrusted   string        `env:"LINK_CANONICAL_TRUSTED_DOMAINS" envDefault:""`
 	LinkCanonicalDenylist  string        `env:"LINK_CANONICAL_DENYLIST" envDefault:""`
 
+	// Database maintenance (zero intervals disable a task)
+	MaintenanceEnabled           bool          `env:"MAINTENANCE_ENABLED" envDefault:"true"`
+	MaintenanceViewsInterval     time.Duration `env:"MAINTENANCE_VIEWS_INTERVAL" envDefault:"1h"`
+	MaintenanceDerivedInterval   time.Duration `env:"MAINTENANCE_DERIVED_INTERVAL" envDefault:"1h"`
+	MaintenanceRetentionInterval time.Duration `env:"MAINTENANCE_RETENTION_INTERVAL" envDefault:"1h"`
+	MaintenanceVacuumInterval    time.Duration `env:"MAINTENANCE_VACUUM_INTERVAL" envDefault:"24h"`
+	MaintenanceVacuumTables      string        `env:"MAINTENANCE_VACUUM_TABLES" envDefault:"raw_messages,items,embeddings,cluster_items"`
+	MaintenanceJitter            time.Duration `env:"MAINTENANCE_JITTER" envDefault:"5m"`
+	MaintenanceTaskTimeout       time.Duration `env:"MAINTENANCE_TASK_TIMEOUT" envDefault:"30m"`
+
 	// Source enrichment (Phase 2)
 	EnrichmentEnabled             bool          `env:"ENRICHMENT_ENABLED" envDefault:"false"`
 	EnrichmentMaxResults          int           `env:"ENRICHMENT_MAX_RESULTS" envDefault:"5"`
@@ -134,6 +156,9 @@ type Config struct {
 	EnrichmentMaxQueriesPerItem   int           `env:"ENRICHMENT_MAX_QUERIES_PER_ITEM" envDefault:"5"`
 	EnrichmentLanguagePolicy      string        `env:"ENRICHMENT_LANGUAGE_POLICY" envDefault:""`
 	EnrichmentLLMTimeout          time.Duration `env:"ENRICHMENT_LLM_TIMEOUT" envDefault:"45s"`
+	EnrichmentStanceEnabled       bool          `env:"ENRICHMENT_STANCE_ENABLED" envDefault:"false"`
+	EnrichmentStanceLLMModel      string        `env:"ENRICHMENT_STANCE_LLM_MODEL" envDefault:""`
+	EnrichmentExtractScope        string        `env:"ENRICHMENT_EXTRACT_SCOPE" envDefault:"numbers,quotes"`
 	TranslationModel              string        `env:"TRANSLATION_MODEL"`
 	EnrichmentDailyBudgetUSD      float64       `env:"ENRICHMENT_DAILY_BUDGET_USD" envDefault:"0"`
 	EnrichmentMonthlyCapUSD       float64       `env:"ENRICHMENT_MONTHLY_CAP_USD" envDefault:"0"`
@@ -194,6 +219,27 @@ type Config struct {
 	LLMCircuitThreshold int           `env:"LLM_CIRCUIT_THRESHOLD" envDefault:"5"`
 	LLMCircuitTimeout   time.Duration `env:"LLM_CIRCUIT_TIMEOUT" envDefault:"1m"`
 
+	// Per-task LLM failover to a secondary provider/model
+	LLMFailoverThreshold         int           `env:"LLM_FAILOVER_THRESHOLD" envDefault:"3"`
+	LLMFailoverProbeInterval     time.Duration `env:"LLM_FAILOVER_PROBE_INTERVAL" envDefault:"5m"`
+	LLMFailoverFailbackSuccesses int           `env:"LLM_FAILOVER_FAILBACK_SUCCESSES" envDefault:"3"`
+	LLMFailoverSecondaries       string        `env:"LLM_FAILOVER_SECONDARIES" envDefault:""`
+
+	// LLM request rates shared across replicas
+	LLMSharedRateLimits string `env:"LLM_SHARED_RATE_LIMITS" envDefault:""`
+	LLMRateReservations string `env:"LLM_RATE_RESERVATIONS" envDefault:""`
+
+	// Opt-in recording of LLM responses for digestctl replay
+	LLMRecordCassettes   bool          `env:"LLM_RECORD_CASSETTES" envDefault:"false"`
+	LLMCassetteRetention time.Duration `env:"LLM_CASSETTE_RETENTION" envDefault:"336h"`
+
+	// Outbound Telegram bot send limits (per-chat rates are messages per minute)
+	TelegramSendRate        float64       `env:"TELEGRAM_SEND_RATE" envDefault:"25"`
+	TelegramPrivateChatRate float64       `env:"TELEGRAM_PRIVATE_CHAT_RATE" envDefault:"60"`
+	TelegramGroupChatRate   float64       `env:"TELEGRAM_GROUP_CHAT_RATE" envDefault:"20"`
+	TelegramSendMaxRetries  int           `env:"TELEGRAM_SEND_MAX_RETRIES" envDefault:"3"`
+	TelegramMaxRetryAfter   time.Duration `env:"TELEGRAM_MAX_RETRY_AFTER" envDefault:"1m"`
+
 	// Per-task LLM model configuration
 	LLMSummarizeModel     string `env:"LLM_SUMMARIZE_MODEL" envDefault:""`
 	LLMClusterModel       string `env:"LLM_CLUSTER_MODEL" envDefault:""`
@@ -222,11 +268,64 @@ type Config struct {
 	ExpandedViewRequireAdmin      bool   `env:"EXPANDED_VIEW_REQUIRE_ADMIN" envDefault:"true"`
 	ExpandedViewAllowSystemTokens bool   `env:"EXPANDED_VIEW_ALLOW_SYSTEM_TOKENS" envDefault:"false"`
 
+	// Research search query expansion
+	ResearchSearchTranslit     bool   `env:"RESEARCH_SEARCH_TRANSLIT" envDefault:"true"`
+	ResearchSearchSynonymsFile string `env:"RESEARCH_SEARCH_SYNONYMS_FILE" envDefault:""`
+
 	// Apple Shortcuts integration for ChatGPT
 	ExpandedShortcutName      string `env:"EXPANDED_CHATGPT_SHORTCUT_NAME" envDefault:"Ask ChatGPT"`
 	ExpandedShortcutICloudURL string `env:"EXPANDED_CHATGPT_SHORTCUT_ICLOUD_URL" envDefault:""`
 	ExpandedShortcutMaxChars  int    `env:"EXPANDED_SHORTCUT_URL_MAX_CHARS" envDefault:"2000"`
 
+	// Digest delivery to Slack and Discord incoming webhooks and a Matrix room
+	SlackWebhookURL     string        `env:"SLACK_WEBHOOK_URL" envDefault:""`
+	DiscordWebhookURL   string        `env:"DISCORD_WEBHOOK_URL" envDefault:""`
+	MatrixHomeserverURL string        `env:"MATRIX_HOMESERVER_URL" envDefault:""`
+	MatrixAccessToken   string        `env:"MATRIX_ACCESS_TOKEN" envDefault:""`
+	MatrixRoomID        string        `env:"MATRIX_ROOM_ID" envDefault:""`
+	DeliveryTimeout     time.Duration `env:"DELIVERY_TIMEOUT" envDefault:"15s"`
+
+	// Pipeline event publishing to a webhook, NATS or a Kafka REST Proxy
+	EventsWebhookURL     string        `env:"EVENTS_WEBHOOK_URL" envDefault:""`
+	EventsNATSURL        string        `env:"EVENTS_NATS_URL" envDefault:""`
+	EventsNATSSubject    string        `env:"EVENTS_NATS_SUBJECT" envDefault:"telegram_digest.events"`
+	EventsKafkaRESTURL   string        `env:"EVENTS_KAFKA_REST_URL" envDefault:""`
+	EventsKafkaClusterID string        `env:"EVENTS_KAFKA_CLUSTER_ID" envDefault:""`
+	EventsKafkaTopic     string        `env:"EVENTS_KAFKA_TOPIC" envDefault:"telegram_digest.events"`
+	EventsTypes          []string      `env:"EVENTS_TYPES" envSeparator:","`
+	EventsSigningSecret  string        `env:"EVENTS_SIGNING_SECRET" envDefault:""`
+	EventsQueueSize      int           `env:"EVENTS_QUEUE_SIZE" envDefault:"1000"`
+	EventsMaxAttempts    int           `env:"EVENTS_MAX_ATTEMPTS" envDefault:"5"`
+	EventsRetryBackoff   time.Duration `env:"EVENTS_RETRY_BACKOFF" envDefault:"2s"`
+	EventsTimeout        time.Duration `env:"EVENTS_TIMEOUT" envDefault:"10s"`
+
+	// Digest and story export to a Notion database or an Obsidian vault folder
+	ObsidianVaultDir string        `env:"OBSIDIAN_VAULT_DIR" envDefault:""`
+	NotionAPIToken   string        `env:"NOTION_API_TOKEN" envDefault:""`
+	NotionDatabaseID string        `env:"NOTION_DATABASE_ID" envDefault:""`
+	ExportTimeout    time.Duration `env:"EXPORT_TIMEOUT" envDefault:"30s"`
+
+	// Issue tracker tickets from /item track, in Jira or Linear
+	JiraBaseURL    string        `env:"JIRA_BASE_URL" envDefault:""`
+	JiraUser       string        `env:"JIRA_USER" envDefault:""`
+	JiraAPIToken   string        `env:"JIRA_API_TOKEN" envDefault:""`
+	JiraProjectKey string        `env:"JIRA_PROJECT_KEY" envDefault:""`
+	JiraIssueType  string        `env:"JIRA_ISSUE_TYPE" envDefault:"Task"`
+	LinearAPIKey   string        `env:"LINEAR_API_KEY" envDefault:""`
+	LinearTeamID   string        `env:"LINEAR_TEAM_ID" envDefault:""`
+	TicketTimeout  time.Duration `env:"TICKET_TIMEOUT" envDefault:"15s"`
+
+	// Announced events extracted with the "events" enrichment scope
+	CalendarTimezone     string `env:"CALENDAR_TIMEZONE" envDefault:"UTC"`
+	CalendarFeedToken    string `env:"CALENDAR_FEED_TOKEN" envDefault:""`
+	CalendarFeedDays     int    `env:"CALENDAR_FEED_DAYS" envDefault:"90"`
+	CalendarUpcomingDays int    `env:"CALENDAR_UPCOMING_DAYS" envDefault:"7"`
+
+	// Watchlist alerts on entities extracted with the "entities" enrichment scope
+	WatchlistVolumeThreshold     int     `env:"WATCHLIST_VOLUME_THRESHOLD" envDefault:"5"`
+	WatchlistImportanceThreshold float32 `env:"WATCHLIST_IMPORTANCE_THRESHOLD" envDefault:"0.8"`
+	WatchlistWindowHours         int     `env:"WATCHLIST_WINDOW_HOURS" envDefault:"24"`
+
 	// Bulletized output settings
 	BulletBatchSize          int     `env:"BULLET_BATCH_SIZE" envDefault:"3"`
 	BulletDedupThreshold     float64 `env:"BULLET_DEDUP_THRESHOLD" envDefault:"0.92"`
@@ -73,6 +73,7 @@ type DigestConfig struct {
 	TopN                 int           `env:"DIGEST_TOP_N" envDefault:"20"`
 	TickInterval         string        `env:"SCHEDULER_TICK_INTERVAL" envDefault:"10m"`
 	CatchupWindow        string        `env:"SCHEDULER_CATCHUP_WINDOW" envDefault:"24h"`
+	BuildConcurrency     int           `env:"DIGEST_BUILD_CONCURRENCY" envDefault:"4"`
 	TimeToAlertThreshold time.Duration `env:"TIME_TO_DIGEST_ALERT_THRESHOLD" envDefault:"0"`
 }
 
@@ -222,6 +223,7 @@ func (c *Config) DigestCfg() DigestConfig {
 		TopN:                 c.DigestTopN,
 		TickInterval:         c.SchedulerTickInterval,
 		CatchupWindow:        c.SchedulerCatchupWindow,
+		BuildConcurrency:     c.DigestBuildConcurrency,
 		TimeToAlertThreshold: c.TimeToDigestAlertThreshold,
 	}
 }
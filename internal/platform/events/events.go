@@ -0,0 +1,336 @@
+// Package events publishes structured pipeline events (items created or
+// rejected, digests posted, claims detected) to external systems through a
+// webhook, NATS or a Kafka REST Proxy.
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+)
+
+// SchemaVersion is the version of the event envelope and payloads. It changes
+// only on incompatible changes; new fields may be added within a version.
+const SchemaVersion = 1
+
+// Event types.
+const (
+	TypeItemCreated   = "item_created"
+	TypeItemRejected  = "item_rejected"
+	TypeDigestPosted  = "digest_posted"
+	TypeClaimDetected = "claim_detected"
+)
+
+// Header names sent with each event.
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+	HeaderTimestamp = "X-Event-Timestamp"
+	HeaderSignature = "X-Event-Signature"
+)
+
+const (
+	statusPublished = "published"
+	statusError     = "error"
+	statusDropped   = "dropped"
+
+	signaturePrefix     = "sha256="
+	defaultRetryBackoff = 2 * time.Second
+	maxBackoff          = time.Minute
+	closeTimeout        = 30 * time.Second
+)
+
+// Event is the envelope of every published event.
+type Event struct {
+	SchemaVersion int       `json:"schema_version"`
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	Data          any       `json:"data"`
+}
+
+// ItemData is the payload of item_created and item_rejected events.
+type ItemData struct {
+	ItemID       string   `json:"item_id"`
+	RawMessageID string   `json:"raw_message_id"`
+	ChannelID    string   `json:"channel_id"`
+	ChannelTitle string   `json:"channel_title,omitempty"`
+	TGPeerID     int64    `json:"tg_peer_id"`
+	TGMessageID  int64    `json:"tg_message_id"`
+	Status       string   `json:"status"`
+	Topic        string   `json:"topic,omitempty"`
+	Summary      string   `json:"summary"`
+	Language     string   `json:"language,omitempty"`
+	Relevance    float32  `json:"relevance"`
+	Importance   float32  `json:"importance"`
+	Regions      []string `json:"regions,omitempty"`
+}
+
+// DigestPostedData is the payload of digest_posted events.
+type DigestPostedData struct {
+	DigestID    string    `json:"digest_id"`
+	ChatID      int64     `json:"chat_id"`
+	MessageID   int64     `json:"message_id"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	ItemIDs     []string  `json:"item_ids"`
+}
+
+// ClaimDetectedData is the payload of claim_detected events.
+type ClaimDetectedData struct {
+	ItemID          string `json:"item_id"`
+	Claim           string `json:"claim"`
+	NormalizedClaim string `json:"normalized_claim"`
+}
+
+// Message is an encoded event ready to be sent to a sink.
+type Message struct {
+	ID        string
+	Type      string
+	Body      []byte
+	Timestamp int64
+	// Signature is the hex HMAC-SHA256 of "<timestamp>.<body>" with the
+	// "sha256=" prefix, or empty when no signing secret is configured.
+	Signature string
+}
+
+// Headers returns the event headers sent along with the body.
+func (m Message) Headers() map[string]string {
+	headers := map[string]string{
+		HeaderEventID:   m.ID,
+		HeaderEventType: m.Type,
+		HeaderTimestamp: strconv.FormatInt(m.Timestamp, 10),
+	}
+
+	if m.Signature != "" {
+		headers[HeaderSignature] = m.Signature
+	}
+
+	return headers
+}
+
+// Sink sends encoded events to an external system.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config configures a Publisher.
+type Config struct {
+	// Types limits the published event types; empty publishes all.
+	Types         []string
+	SigningSecret string
+	QueueSize     int
+	MaxAttempts   int
+	RetryBackoff  time.Duration
+}
+
+// Publisher queues events and sends them to its sinks in the background,
+// retrying failed sends with exponential backoff. A nil Publisher discards
+// events, so callers do not need to check whether publishing is configured.
+type Publisher struct {
+	sinks  []Sink
+	types  map[string]bool
+	secret []byte
+	queue  chan Message
+	cfg    Config
+	logger *zerolog.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+	abort  chan struct{}
+	// abortOnce guards abort against repeated Close calls.
+	abortOnce sync.Once
+}
+
+// NewPublisher creates a publisher for the given sinks. Run must be started
+// for queued events to be sent. A RetryBackoff that is not positive defaults
+// to one second.
+func NewPublisher(cfg Config, sinks []Sink, logger *zerolog.Logger) *Publisher {
+	var types map[string]bool
+
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+
+	if len(cfg.Types) > 0 {
+		types = make(map[string]bool, len(cfg.Types))
+		for _, t := range cfg.Types {
+			types[t] = true
+		}
+	}
+
+	return &Publisher{
+		sinks:  sinks,
+		types:  types,
+		secret: []byte(cfg.SigningSecret),
+		queue:  make(chan Message, max(cfg.QueueSize, 1)),
+		cfg:    cfg,
+		logger: logger,
+		done:   make(chan struct{}),
+		abort:  make(chan struct{}),
+	}
+}
+
+// Publish queues an event without blocking. Events are dropped when the queue
+// is full or after Close.
+func (p *Publisher) Publish(eventType string, data any) {
+	if p == nil || (p.types != nil && !p.types[eventType]) {
+		return
+	}
+
+	msg, err := p.encode(eventType, data, time.Now())
+	if err != nil {
+		p.logger.Warn().Err(err).Str("event_type", eventType).Msg("failed to encode event")
+
+		return
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		observability.PipelineEvents.WithLabelValues(eventType, "", statusDropped).Inc()
+
+		return
+	}
+
+	select {
+	case p.queue <- msg:
+	default:
+		observability.PipelineEvents.WithLabelValues(eventType, "", statusDropped).Inc()
+		p.logger.Warn().Str("event_type", eventType).Msg("event queue full, dropping event")
+	}
+}
+
+// encode wraps data in the event envelope and signs it.
+func (p *Publisher) encode(eventType string, data any, now time.Time) (Message, error) {
+	event := Event{
+		SchemaVersion: SchemaVersion,
+		ID:            uuid.NewString(),
+		Type:          eventType,
+		OccurredAt:    now.UTC(),
+		Data:          data,
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, fmt.Errorf("marshal event: %w", err)
+	}
+
+	msg := Message{ID: event.ID, Type: eventType, Body: body, Timestamp: now.Unix()}
+	if len(p.secret) > 0 {
+		msg.Signature = Sign(p.secret, msg.Timestamp, body)
+	}
+
+	return msg, nil
+}
+
+// Sign returns the signature of an event body sent at timestamp (Unix
+// seconds): "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Receivers recompute it with the shared secret to verify an event.
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run sends queued events until Close is called and the queue is drained.
+// Canceling ctx does not stop it: services cancel their context before they
+// close the publisher, and the events queued by then are still sent. Sends
+// are only canceled once Close gives up after closeTimeout.
+func (p *Publisher) Run(ctx context.Context) {
+	defer close(p.done)
+
+	sendCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	go func() {
+		select {
+		case <-p.abort:
+			cancel()
+		case <-sendCtx.Done():
+		}
+	}()
+
+	for msg := range p.queue {
+		if sendCtx.Err() != nil {
+			observability.PipelineEvents.WithLabelValues(msg.Type, "", statusDropped).Inc()
+
+			continue
+		}
+
+		for _, sink := range p.sinks {
+			p.send(sendCtx, sink, msg)
+		}
+	}
+}
+
+// Close stops accepting events and waits up to closeTimeout for Run to send
+// the queued ones, then cancels the sends still in progress.
+func (p *Publisher) Close() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+	case <-time.After(closeTimeout):
+		p.logger.Warn().Int("queued", len(p.queue)).Msg("timed out sending queued events")
+		p.abortOnce.Do(func() { close(p.abort) })
+	}
+}
+
+// send delivers msg to a sink, retrying with exponential backoff.
+func (p *Publisher) send(ctx context.Context, sink Sink, msg Message) {
+	backoff := p.cfg.RetryBackoff
+	attempts := max(p.cfg.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		err := sink.Send(ctx, msg)
+		if err == nil {
+			observability.PipelineEvents.WithLabelValues(msg.Type, sink.Name(), statusPublished).Inc()
+
+			return
+		}
+
+		if attempt >= attempts {
+			observability.PipelineEvents.WithLabelValues(msg.Type, sink.Name(), statusError).Inc()
+			p.logger.Warn().Err(err).Str("sink", sink.Name()).Str("event_type", msg.Type).Str("event_id", msg.ID).
+				Int("attempts", attempt).Msg("failed to publish event")
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
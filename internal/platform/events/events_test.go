@@ -0,0 +1,263 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var errSinkDown = errors.New("sink down")
+
+type recordingSink struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	sent     []Message
+}
+
+func (s *recordingSink) Name() string { return "test" }
+
+func (s *recordingSink) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	if s.calls <= s.failures {
+		return errSinkDown
+	}
+
+	s.sent = append(s.sent, msg)
+
+	return nil
+}
+
+func newTestPublisher(cfg Config, sink Sink) *Publisher {
+	logger := zerolog.Nop()
+
+	return NewPublisher(cfg, []Sink{sink}, &logger)
+}
+
+func TestPublisherEnvelopeAndSignature(t *testing.T) {
+	sink := &recordingSink{}
+	pub := newTestPublisher(Config{SigningSecret: "secret", QueueSize: 10}, sink)
+
+	go pub.Run(context.Background())
+
+	pub.Publish(TypeClaimDetected, ClaimDetectedData{ItemID: "item-1", Claim: "Rates rose", NormalizedClaim: "rates rose"})
+	pub.Close()
+
+	if len(sink.sent) != 1 {
+		t.Fatalf("sent %d events, want 1", len(sink.sent))
+	}
+
+	msg := sink.sent[0]
+
+	var event struct {
+		SchemaVersion int               `json:"schema_version"`
+		ID            string            `json:"id"`
+		Type          string            `json:"type"`
+		Data          ClaimDetectedData `json:"data"`
+	}
+
+	if err := json.Unmarshal(msg.Body, &event); err != nil {
+		t.Fatalf("unmarshal event: %v", err)
+	}
+
+	if event.SchemaVersion != SchemaVersion || event.Type != TypeClaimDetected || event.ID != msg.ID || event.Data.ItemID != "item-1" {
+		t.Errorf("event = %+v", event)
+	}
+
+	if want := Sign([]byte("secret"), msg.Timestamp, msg.Body); msg.Signature != want || msg.Headers()[HeaderSignature] != want {
+		t.Errorf("signature = %q, want %q", msg.Signature, want)
+	}
+}
+
+func TestPublisherFiltersTypes(t *testing.T) {
+	sink := &recordingSink{}
+	pub := newTestPublisher(Config{Types: []string{TypeDigestPosted}, QueueSize: 10}, sink)
+
+	go pub.Run(context.Background())
+
+	pub.Publish(TypeItemCreated, ItemData{ItemID: "item-1"})
+	pub.Publish(TypeDigestPosted, DigestPostedData{DigestID: "digest-1"})
+	pub.Close()
+
+	if len(sink.sent) != 1 || sink.sent[0].Type != TypeDigestPosted {
+		t.Errorf("sent = %+v, want only the digest_posted event", sink.sent)
+	}
+
+	if sink.sent[0].Signature != "" {
+		t.Errorf("signature = %q without a secret, want none", sink.sent[0].Signature)
+	}
+}
+
+func TestPublisherRetries(t *testing.T) {
+	sink := &recordingSink{failures: 2}
+	pub := newTestPublisher(Config{QueueSize: 10, MaxAttempts: 3, RetryBackoff: time.Millisecond}, sink)
+
+	go pub.Run(context.Background())
+
+	pub.Publish(TypeItemRejected, ItemData{ItemID: "item-1"})
+	pub.Publish(TypeItemRejected, ItemData{ItemID: "item-2"})
+	pub.Close()
+
+	// The first event succeeds on its third attempt, the second on its first
+	if sink.calls != 4 || len(sink.sent) != 2 {
+		t.Errorf("calls = %d, sent = %d, want 4 calls and 2 events", sink.calls, len(sink.sent))
+	}
+}
+
+func TestPublisherGivesUpAfterMaxAttempts(t *testing.T) {
+	sink := &recordingSink{failures: 5}
+	pub := newTestPublisher(Config{QueueSize: 10, MaxAttempts: 2, RetryBackoff: time.Millisecond}, sink)
+
+	go pub.Run(context.Background())
+
+	pub.Publish(TypeItemCreated, ItemData{ItemID: "item-1"})
+	pub.Close()
+
+	if sink.calls != 2 || len(sink.sent) != 0 {
+		t.Errorf("calls = %d, sent = %d, want 2 failed calls", sink.calls, len(sink.sent))
+	}
+}
+
+func TestNilPublisherDiscardsEvents(t *testing.T) {
+	var pub *Publisher
+
+	pub.Publish(TypeItemCreated, ItemData{})
+	pub.Close()
+}
+
+func TestPublishAfterCloseIsDropped(t *testing.T) {
+	sink := &recordingSink{}
+	pub := newTestPublisher(Config{QueueSize: 10}, sink)
+
+	go pub.Run(context.Background())
+
+	pub.Close()
+	pub.Publish(TypeItemCreated, ItemData{ItemID: "item-1"})
+
+	if len(sink.sent) != 0 {
+		t.Errorf("sent %d events after Close, want 0", len(sink.sent))
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	msg := Message{ID: "event-1", Type: TypeItemCreated, Body: []byte(`{"id":"event-1"}`), Timestamp: 1700000000, Signature: "sha256=abc"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+
+		if string(body) != string(msg.Body) {
+			t.Errorf("body = %s, want %s", body, msg.Body)
+		}
+
+		if r.Header.Get(HeaderEventType) != TypeItemCreated || r.Header.Get(HeaderSignature) != "sha256=abc" ||
+			r.Header.Get(HeaderTimestamp) != strconv.FormatInt(msg.Timestamp, 10) {
+			t.Errorf("headers = %v", r.Header)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookSink(srv.URL, time.Second).Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	if err := NewWebhookSink(failing.URL, time.Second).Send(context.Background(), msg); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("Send() error = %v, want ErrUnexpectedStatus", err)
+	}
+}
+
+func TestKafkaRESTSink(t *testing.T) {
+	msg := Message{ID: "event-1", Type: TypeDigestPosted, Body: []byte(`{"id":"event-1"}`), Timestamp: 1700000000}
+
+	var record kafkaRecord
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/clusters/cluster-1/topics/digest.events/records" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			t.Errorf("decode record: %v", err)
+		}
+
+		if _, err := w.Write([]byte(`{"error_code":200,"partition_id":0,"offset":1}`)); err != nil {
+			t.Errorf("write response: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	if err := NewKafkaRESTSink(srv.URL+"/", "cluster-1", "digest.events", time.Second).Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(record.Value.Data)
+	if err != nil || string(value) != string(msg.Body) || record.Value.Type != kafkaTypeBinary {
+		t.Errorf("value = %+v, want the base64 event body", record.Value)
+	}
+
+	if record.Key.Data != "event-1" || len(record.Headers) != 3 {
+		t.Errorf("record = %+v, want the event id key and 3 headers", record)
+	}
+}
+
+func TestKafkaRESTSinkRejectedRecord(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, err := w.Write([]byte(`{"error_code":40403,"message":"topic not found"}`)); err != nil {
+			t.Errorf("write response: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	err := NewKafkaRESTSink(srv.URL, "cluster-1", "missing", time.Second).Send(context.Background(), Message{ID: "event-1", Body: []byte(`{}`)})
+	if !errors.Is(err, ErrKafkaRecordRejected) {
+		t.Errorf("Send() error = %v, want ErrKafkaRecordRejected", err)
+	}
+}
+
+func TestCloseDrainsAfterRunContextIsCanceled(t *testing.T) {
+	sink := &recordingSink{}
+	pub := newTestPublisher(Config{QueueSize: 10}, sink)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go pub.Run(ctx)
+
+	pub.Publish(TypeItemCreated, ItemData{ItemID: "item-1"})
+	pub.Publish(TypeItemCreated, ItemData{ItemID: "item-2"})
+	cancel()
+	pub.Close()
+
+	if len(sink.sent) != 2 {
+		t.Errorf("sent %d events, want 2 (events queued before shutdown are sent)", len(sink.sent))
+	}
+}
+
+func TestNonPositiveRetryBackoffDefaults(t *testing.T) {
+	pub := newTestPublisher(Config{RetryBackoff: 0}, &recordingSink{})
+
+	if pub.cfg.RetryBackoff != defaultRetryBackoff {
+		t.Errorf("RetryBackoff = %v, want %v", pub.cfg.RetryBackoff, defaultRetryBackoff)
+	}
+}
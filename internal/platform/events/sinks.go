@@ -0,0 +1,245 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	sinkWebhook   = "webhook"
+	sinkNATS      = "nats"
+	sinkKafkaREST = "kafka"
+
+	contentTypeJSON = "application/json"
+	defaultTimeout  = 10 * time.Second
+
+	kafkaRecordsPathFmt = "%s/v3/clusters/%s/topics/%s/records"
+	kafkaTypeString     = "STRING"
+	kafkaTypeBinary     = "BINARY"
+)
+
+var (
+	// ErrUnexpectedStatus indicates an HTTP sink rejected an event.
+	ErrUnexpectedStatus = errors.New("unexpected status")
+	// ErrKafkaRecordRejected indicates the Kafka REST Proxy did not produce a record.
+	ErrKafkaRecordRejected = errors.New("kafka record rejected")
+)
+
+// WebhookSink posts events as JSON to an HTTP endpoint with the event headers.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink that posts events to url.
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{url: url, client: newHTTPClient(timeout)}
+}
+
+// Name implements Sink.
+func (s *WebhookSink) Name() string {
+	return sinkWebhook
+}
+
+// Send implements Sink. Any 2xx response acknowledges the event.
+func (s *WebhookSink) Send(ctx context.Context, msg Message) error {
+	return doPost(ctx, s.client, s.url, msg.Body, msg.Headers())
+}
+
+// NATSSink publishes events to a NATS subject, with the event headers as
+// message headers.
+type NATSSink struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNATSSink connects to a NATS server. The connection reconnects on its own;
+// events published while it is down fail and are retried.
+func NewNATSSink(serverURL, subject string, timeout time.Duration) (*NATSSink, error) {
+	conn, err := nats.Connect(serverURL,
+		nats.Name("telegram-digest-bot"),
+		nats.Timeout(orDefaultTimeout(timeout)),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats: %w", err)
+	}
+
+	return &NATSSink{conn: conn, subject: subject}, nil
+}
+
+// Name implements Sink.
+func (s *NATSSink) Name() string {
+	return sinkNATS
+}
+
+// Send implements Sink. The event is flushed to the server before returning,
+// so a send fails when the server is unreachable.
+func (s *NATSSink) Send(ctx context.Context, msg Message) error {
+	natsMsg := nats.NewMsg(s.subject)
+	natsMsg.Data = msg.Body
+
+	for k, v := range msg.Headers() {
+		natsMsg.Header.Set(k, v)
+	}
+
+	if err := s.conn.PublishMsg(natsMsg); err != nil {
+		return fmt.Errorf("nats publish: %w", err)
+	}
+
+	if err := s.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("nats flush: %w", err)
+	}
+
+	return nil
+}
+
+// Close drains and closes the NATS connection.
+func (s *NATSSink) Close() {
+	if err := s.conn.Drain(); err != nil {
+		s.conn.Close()
+	}
+}
+
+// KafkaRESTSink produces events to a Kafka topic through the Confluent REST
+// Proxy v3 API. Records are keyed by event ID; the value is the event JSON,
+// sent as binary so consumers get the exact signed bytes.
+type KafkaRESTSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+type kafkaRecord struct {
+	Headers []kafkaHeader `json:"headers"`
+	Key     kafkaData     `json:"key"`
+	Value   kafkaData     `json:"value"`
+}
+
+type kafkaHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type kafkaData struct {
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+type kafkaResult struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// NewKafkaRESTSink creates a sink for a topic of the cluster behind a REST Proxy.
+func NewKafkaRESTSink(proxyURL, clusterID, topic string, timeout time.Duration) *KafkaRESTSink {
+	return &KafkaRESTSink{
+		endpoint: fmt.Sprintf(kafkaRecordsPathFmt, strings.TrimRight(proxyURL, "/"), url.PathEscape(clusterID), url.PathEscape(topic)),
+		client:   newHTTPClient(timeout),
+	}
+}
+
+// Name implements Sink.
+func (s *KafkaRESTSink) Name() string {
+	return sinkKafkaREST
+}
+
+// Send implements Sink.
+func (s *KafkaRESTSink) Send(ctx context.Context, msg Message) error {
+	record := kafkaRecord{
+		Key:   kafkaData{Type: kafkaTypeString, Data: msg.ID},
+		Value: kafkaData{Type: kafkaTypeBinary, Data: base64.StdEncoding.EncodeToString(msg.Body)},
+	}
+
+	// Record header values are base64-encoded bytes
+	for k, v := range msg.Headers() {
+		record.Headers = append(record.Headers, kafkaHeader{Name: k, Value: base64.StdEncoding.EncodeToString([]byte(v))})
+	}
+
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal kafka record: %w", err)
+	}
+
+	resp, err := post(ctx, s.client, s.endpoint, body, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// The proxy reports per-record failures in the body of a 200 response
+	var result kafkaResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode kafka response: %w", err)
+	}
+
+	if result.ErrorCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d %s", ErrKafkaRecordRejected, result.ErrorCode, result.Message)
+	}
+
+	return nil
+}
+
+// doPost posts body and discards the response.
+func doPost(ctx context.Context, client *http.Client, endpoint string, body []byte, headers map[string]string) error {
+	resp, err := post(ctx, client, endpoint, body, headers)
+	if err != nil {
+		return err
+	}
+
+	_ = resp.Body.Close()
+
+	return nil
+}
+
+// post sends a JSON body and fails on a non-2xx status. The caller closes
+// the body of the returned response.
+func post(ctx context.Context, client *http.Client, endpoint string, body []byte, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create event request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("event request: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: orDefaultTimeout(timeout)}
+}
+
+func orDefaultTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return defaultTimeout
+	}
+
+	return timeout
+}
@@ -190,12 +190,12 @@ const (
 	ItemEnd   = "<!-- /ITEM -->"
 )
 
-// StripItemMarkers removes item boundary markers from text before sending to Telegram
+// StripItemMarkers removes item boundary and navigation markers from text before sending to Telegram
 func StripItemMarkers(text string) string {
 	text = strings.ReplaceAll(text, ItemStart, "")
 	text = strings.ReplaceAll(text, ItemEnd, "")
 
-	return text
+	return stripNavigationMarkers(text)
 }
 
 // StripHTMLTags removes all HTML tags from text, keeping only the content.
@@ -285,6 +285,10 @@ func parseNextToken(remaining string) (htmlToken, int) {
 		return htmlToken{val: ItemEnd, isTag: true, isMarker: true}, len(ItemEnd)
 	}
 
+	if marker := navigationMarkerAt(remaining); marker != "" {
+		return htmlToken{val: marker, isTag: true, isMarker: true}, len(marker)
+	}
+
 	if tagMatch := tagRegex.FindStringIndex(remaining); tagMatch != nil && tagMatch[0] == 0 {
 		return htmlToken{val: remaining[:tagMatch[1]], isTag: true}, tagMatch[1]
 	}
@@ -312,6 +316,10 @@ func findNextBoundary(remaining string) int {
 		nextTag = idx
 	}
 
+	if idx := nextNavigationMarker(remaining); idx >= 0 && idx < nextTag {
+		nextTag = idx
+	}
+
 	return nextTag
 }
 
@@ -0,0 +1,103 @@
+package htmlutils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Navigation markers tie table-of-contents entries to the topic anchors they
+// point at. Like item markers they are invisible to Telegram and stripped
+// before sending; they survive SplitHTML so the sender can tell which message
+// each anchor landed in.
+const (
+	tocEntryStartFmt = "<!-- TOC:%d -->"
+	tocEntryEnd      = "<!-- /TOC -->"
+	topicAnchorFmt   = "<!-- ANCHOR:%d -->"
+)
+
+var (
+	navMarkerRegex   = regexp.MustCompile(`<!-- (?:TOC:\d+|/TOC|ANCHOR:\d+) -->`)
+	navMarkerPrefix  = regexp.MustCompile(`^<!-- (?:TOC:\d+|/TOC|ANCHOR:\d+) -->`)
+	topicAnchorRegex = regexp.MustCompile(`<!-- ANCHOR:(\d+) -->`)
+	tocEntryRegex    = regexp.MustCompile(`<!-- TOC:(\d+) -->(.*?)<!-- /TOC -->`)
+)
+
+// TopicAnchor returns the marker placed where topic n first appears.
+func TopicAnchor(n int) string {
+	return fmt.Sprintf(topicAnchorFmt, n)
+}
+
+// TOCEntry wraps a table-of-contents label so it can later be linked to topic n.
+func TOCEntry(n int, label string) string {
+	return fmt.Sprintf(tocEntryStartFmt, n) + label + tocEntryEnd
+}
+
+// FindTopicAnchors returns the topic numbers anchored in text, in order.
+func FindTopicAnchors(text string) []int {
+	matches := topicAnchorRegex.FindAllStringSubmatch(text, -1)
+	anchors := make([]int, 0, len(matches))
+
+	for _, m := range matches {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			anchors = append(anchors, n)
+		}
+	}
+
+	return anchors
+}
+
+// LinkTOCEntries turns table-of-contents entries into links using urlFor.
+// Entries for which urlFor returns "" keep their plain label. It returns the
+// rewritten text and the number of entries linked.
+func LinkTOCEntries(text string, urlFor func(n int) string) (string, int) {
+	linked := 0
+
+	result := tocEntryRegex.ReplaceAllStringFunc(text, func(entry string) string {
+		m := tocEntryRegex.FindStringSubmatch(entry)
+
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return m[2]
+		}
+
+		url := urlFor(n)
+		if url == "" {
+			return m[2]
+		}
+
+		linked++
+
+		return `<a href="` + url + `">` + m[2] + `</a>`
+	})
+
+	return result, linked
+}
+
+func stripNavigationMarkers(text string) string {
+	if !strings.Contains(text, "<!-- ") {
+		return text
+	}
+
+	return navMarkerRegex.ReplaceAllString(text, "")
+}
+
+// navigationMarkerAt returns the navigation marker at the start of s, if any.
+func navigationMarkerAt(s string) string {
+	if !strings.HasPrefix(s, "<!-- ") {
+		return ""
+	}
+
+	return navMarkerPrefix.FindString(s)
+}
+
+// nextNavigationMarker returns the index of the next navigation marker in s, or -1.
+func nextNavigationMarker(s string) int {
+	loc := navMarkerRegex.FindStringIndex(s)
+	if loc == nil {
+		return -1
+	}
+
+	return loc[0]
+}
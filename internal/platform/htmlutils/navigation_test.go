@@ -0,0 +1,55 @@
+package htmlutils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinkTOCEntries(t *testing.T) {
+	text := "📑 " + TOCEntry(0, "Economy (3)") + " · " + TOCEntry(1, "Sports (2)")
+
+	got, linked := LinkTOCEntries(text, func(n int) string {
+		if n == 1 {
+			return "https://t.me/c/123/45"
+		}
+
+		return ""
+	})
+
+	want := `📑 Economy (3) · <a href="https://t.me/c/123/45">Sports (2)</a>`
+	if got != want || linked != 1 {
+		t.Errorf("LinkTOCEntries() = %q, %d; want %q, 1", got, linked, want)
+	}
+}
+
+func TestFindTopicAnchors(t *testing.T) {
+	text := ItemStart + TopicAnchor(2) + "one" + ItemEnd + ItemStart + TopicAnchor(5) + "two" + ItemEnd
+
+	got := FindTopicAnchors(text)
+	if len(got) != 2 || got[0] != 2 || got[1] != 5 {
+		t.Errorf("FindTopicAnchors() = %v, want [2 5]", got)
+	}
+}
+
+func TestNavigationMarkersSurviveSplit(t *testing.T) {
+	toc := TOCEntry(0, "Alpha") + " " + TOCEntry(1, "Beta") + "\n\n"
+	itemOne := ItemStart + TopicAnchor(0) + strings.Repeat("A", 40) + ItemEnd
+	itemTwo := ItemStart + TopicAnchor(1) + strings.Repeat("B", 40) + ItemEnd
+	text := toc + itemOne + "\n" + itemTwo
+
+	parts := SplitHTML(text, 70)
+	if len(parts) < 2 {
+		t.Fatalf("SplitHTML() got %d parts, want at least 2. Parts: %v", len(parts), parts)
+	}
+
+	last := parts[len(parts)-1]
+	if anchors := FindTopicAnchors(last); len(anchors) != 1 || anchors[0] != 1 {
+		t.Errorf("last part anchors = %v, want [1]. Part: %q", anchors, last)
+	}
+
+	for i, part := range parts {
+		if stripped := StripItemMarkers(part); strings.Contains(stripped, "<!--") {
+			t.Errorf("part %d still has markers after stripping: %q", i, stripped)
+		}
+	}
+}
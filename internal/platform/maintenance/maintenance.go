@@ -0,0 +1,323 @@
+// Package maintenance schedules database upkeep: materialized view refreshes,
+// derived table rebuilds, retention cleanup and vacuuming.
+//
+// Each task runs on its own interval with random jitter and under a row-based
+// scheduler lock, so replicas (or the digest and maintenance modes running
+// side by side) never run the same task at once. Step durations and run
+// outcomes are exported as Prometheus metrics.
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/worker"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Task names, also used as metric labels and lock name suffixes.
+const (
+	TaskViews     = "views"
+	TaskDerived   = "derived"
+	TaskRetention = "retention"
+	TaskVacuum    = "vacuum"
+)
+
+const (
+	lockPrefix = "maintenance:"
+
+	statusSuccess = "success"
+	statusError   = "error"
+	statusLocked  = "locked"
+
+	logFieldTask = "task"
+	logFieldStep = "step"
+)
+
+// Repository defines the storage operations required by the Scheduler.
+type Repository interface {
+	TryAcquireSchedulerLock(ctx context.Context, lockName, holderID string, ttl time.Duration) (bool, error)
+	ReleaseSchedulerLock(ctx context.Context, lockName, holderID string) error
+	RefreshMaterializedView(ctx context.Context, view string) error
+	RebuildResearchDerivedTable(ctx context.Context, name string) error
+	DeleteExpiredResearchSessions(ctx context.Context) error
+	CleanupResearchRetention(ctx context.Context) (db.ResearchRetentionCounts, error)
+	DeleteLLMCassettesBefore(ctx context.Context, before time.Time) (int64, error)
+	VacuumAnalyzeTable(ctx context.Context, table string) error
+}
+
+// Compile-time assertion that *db.DB implements Repository.
+var _ Repository = (*db.DB)(nil)
+
+// Step is one timed unit of a task.
+type Step struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Task is a named list of steps run together every Interval. A zero
+// interval disables the task.
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Steps    []Step
+}
+
+// Scheduler runs maintenance tasks in the background.
+type Scheduler struct {
+	database          Repository
+	tasks             []Task
+	jitter            time.Duration
+	timeout           time.Duration
+	cassetteRetention time.Duration
+	holderID          string
+	logger            *zerolog.Logger
+}
+
+// New creates a Scheduler with the tasks configured in cfg.
+func New(cfg *config.Config, database Repository, logger *zerolog.Logger) *Scheduler {
+	s := &Scheduler{
+		database:          database,
+		jitter:            cfg.MaintenanceJitter,
+		timeout:           cfg.MaintenanceTaskTimeout,
+		cassetteRetention: cfg.LLMCassetteRetention,
+		holderID:          uuid.New().String(),
+		logger:            logger,
+	}
+
+	s.tasks = []Task{
+		{Name: TaskViews, Interval: cfg.MaintenanceViewsInterval, Steps: s.viewSteps()},
+		{Name: TaskDerived, Interval: cfg.MaintenanceDerivedInterval, Steps: s.derivedSteps()},
+		{Name: TaskRetention, Interval: cfg.MaintenanceRetentionInterval, Steps: s.retentionSteps()},
+		{Name: TaskVacuum, Interval: cfg.MaintenanceVacuumInterval, Steps: s.vacuumSteps(parseTables(cfg.MaintenanceVacuumTables))},
+	}
+
+	return s
+}
+
+func (s *Scheduler) viewSteps() []Step {
+	steps := make([]Step, 0, len(db.ResearchMaterializedViews))
+
+	for _, view := range db.ResearchMaterializedViews {
+		steps = append(steps, Step{Name: view, Run: func(ctx context.Context) error {
+			return s.database.RefreshMaterializedView(ctx, view)
+		}})
+	}
+
+	return steps
+}
+
+func (s *Scheduler) derivedSteps() []Step {
+	steps := make([]Step, 0, len(db.ResearchDerivedTables))
+
+	for _, table := range db.ResearchDerivedTables {
+		steps = append(steps, Step{Name: table, Run: func(ctx context.Context) error {
+			return s.database.RebuildResearchDerivedTable(ctx, table)
+		}})
+	}
+
+	return steps
+}
+
+func (s *Scheduler) retentionSteps() []Step {
+	return []Step{
+		{Name: "research_sessions", Run: s.database.DeleteExpiredResearchSessions},
+		{Name: "research_retention", Run: s.cleanupResearchRetention},
+		{Name: "llm_cassettes", Run: s.cleanupLLMCassettes},
+	}
+}
+
+// cleanupLLMCassettes deletes recorded LLM responses older than
+// LLM_CASSETTE_RETENTION. A zero retention keeps them.
+func (s *Scheduler) cleanupLLMCassettes(ctx context.Context) error {
+	if s.cassetteRetention <= 0 {
+		return nil
+	}
+
+	deleted, err := s.database.DeleteLLMCassettesBefore(ctx, time.Now().Add(-s.cassetteRetention))
+	if err != nil {
+		return fmt.Errorf("cleanup llm cassettes: %w", err)
+	}
+
+	if deleted > 0 {
+		s.logger.Info().Int64("deleted", deleted).Msg("llm cassette retention cleanup")
+	}
+
+	return nil
+}
+
+func (s *Scheduler) cleanupResearchRetention(ctx context.Context) error {
+	counts, err := s.database.CleanupResearchRetention(ctx)
+	if err != nil {
+		return fmt.Errorf("cleanup research retention: %w", err)
+	}
+
+	if counts.ItemsDeleted > 0 || counts.EvidenceDeleted > 0 || counts.TranslationsDeleted > 0 {
+		s.logger.Info().
+			Int64("items_deleted", counts.ItemsDeleted).
+			Int64("evidence_deleted", counts.EvidenceDeleted).
+			Int64("translations_deleted", counts.TranslationsDeleted).
+			Msg("research retention cleanup")
+	}
+
+	return nil
+}
+
+func (s *Scheduler) vacuumSteps(tables []string) []Step {
+	steps := make([]Step, 0, len(tables))
+
+	for _, table := range tables {
+		steps = append(steps, Step{Name: table, Run: func(ctx context.Context) error {
+			return s.database.VacuumAnalyzeTable(ctx, table)
+		}})
+	}
+
+	return steps
+}
+
+// parseTables splits a comma-separated table list.
+func parseTables(list string) []string {
+	var tables []string
+
+	for _, table := range strings.Split(list, ",") {
+		if table = strings.TrimSpace(table); table != "" {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables
+}
+
+// Run runs every enabled task on its own schedule until the context is
+// canceled. The first run of each task happens after a jitter delay.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	for _, task := range s.tasks {
+		if task.Interval <= 0 || len(task.Steps) == 0 {
+			s.logger.Info().Str(logFieldTask, task.Name).Msg("maintenance task disabled")
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			s.loop(ctx, task)
+		}()
+	}
+
+	wg.Wait()
+	<-ctx.Done()
+
+	return fmt.Errorf("maintenance scheduler: %w", ctx.Err())
+}
+
+func (s *Scheduler) loop(ctx context.Context, task Task) {
+	delay := s.jitterDelay()
+
+	for {
+		if err := worker.Wait(ctx, delay); err != nil {
+			return
+		}
+
+		if err := s.RunTask(ctx, task); err != nil {
+			s.logger.Warn().Err(err).Str(logFieldTask, task.Name).Msg("maintenance task failed")
+		}
+
+		delay = task.Interval + s.jitterDelay()
+	}
+}
+
+// jitterDelay returns a random delay in [0, jitter).
+func (s *Scheduler) jitterDelay() time.Duration {
+	if s.jitter <= 0 {
+		return 0
+	}
+
+	return rand.N(s.jitter)
+}
+
+// RunTask runs the task's steps once under the task's lock. It returns nil
+// without running anything when another holder has the lock. A failed step
+// does not stop later steps; all step errors are joined in the result.
+func (s *Scheduler) RunTask(ctx context.Context, task Task) error {
+	lockName := lockPrefix + task.Name
+
+	acquired, err := s.database.TryAcquireSchedulerLock(ctx, lockName, s.holderID, s.timeout)
+	if err != nil {
+		observability.MaintenanceRunsTotal.WithLabelValues(task.Name, statusError).Inc()
+
+		return fmt.Errorf("acquire lock: %w", err)
+	}
+
+	if !acquired {
+		observability.MaintenanceRunsTotal.WithLabelValues(task.Name, statusLocked).Inc()
+
+		return nil
+	}
+
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+
+		if err := s.database.ReleaseSchedulerLock(releaseCtx, lockName, s.holderID); err != nil {
+			s.logger.Warn().Err(err).Str(logFieldTask, task.Name).Msg("failed to release maintenance lock")
+		}
+	}()
+
+	taskCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := s.runSteps(taskCtx, task); err != nil {
+		observability.MaintenanceRunsTotal.WithLabelValues(task.Name, statusError).Inc()
+
+		return err
+	}
+
+	observability.MaintenanceRunsTotal.WithLabelValues(task.Name, statusSuccess).Inc()
+	observability.MaintenanceLastSuccessTimestamp.WithLabelValues(task.Name).SetToCurrentTime()
+
+	return nil
+}
+
+func (s *Scheduler) runSteps(ctx context.Context, task Task) error {
+	var errs []error
+
+	for _, step := range task.Steps {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("%s canceled before %s: %w", task.Name, step.Name, err))
+
+			break
+		}
+
+		start := time.Now()
+		err := step.Run(ctx)
+		duration := time.Since(start)
+
+		observability.MaintenanceStepDuration.WithLabelValues(task.Name, step.Name).Observe(duration.Seconds())
+
+		if err != nil {
+			s.logger.Error().Err(err).Str(logFieldTask, task.Name).Str(logFieldStep, step.Name).Msg("maintenance step failed")
+			errs = append(errs, fmt.Errorf("%s/%s: %w", task.Name, step.Name, err))
+
+			continue
+		}
+
+		s.logger.Info().Str(logFieldTask, task.Name).Str(logFieldStep, step.Name).Dur("duration", duration).Msg("maintenance step finished")
+	}
+
+	return errors.Join(errs...)
+}
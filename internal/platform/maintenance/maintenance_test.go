@@ -0,0 +1,85 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var errStep = errors.New("step failed")
+
+type fakeRepository struct {
+	Repository
+	locked   bool
+	released []string
+}
+
+func (f *fakeRepository) TryAcquireSchedulerLock(_ context.Context, _, _ string, _ time.Duration) (bool, error) {
+	return !f.locked, nil
+}
+
+func (f *fakeRepository) ReleaseSchedulerLock(_ context.Context, lockName, _ string) error {
+	f.released = append(f.released, lockName)
+
+	return nil
+}
+
+func newTestScheduler(repo Repository) *Scheduler {
+	logger := zerolog.Nop()
+
+	return &Scheduler{database: repo, timeout: time.Minute, holderID: "test", logger: &logger}
+}
+
+func TestRunTask(t *testing.T) {
+	var ran []string
+
+	step := func(name string, err error) Step {
+		return Step{Name: name, Run: func(context.Context) error {
+			ran = append(ran, name)
+
+			return err
+		}}
+	}
+
+	task := Task{Name: "test", Interval: time.Hour, Steps: []Step{step("a", nil), step("b", errStep), step("c", nil)}}
+
+	repo := &fakeRepository{}
+	s := newTestScheduler(repo)
+
+	err := s.RunTask(context.Background(), task)
+	if !errors.Is(err, errStep) {
+		t.Fatalf("RunTask() error = %v, want %v", err, errStep)
+	}
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran steps = %v, want %v", ran, want)
+	}
+
+	if want := []string{"maintenance:test"}; !reflect.DeepEqual(repo.released, want) {
+		t.Errorf("released locks = %v, want %v", repo.released, want)
+	}
+
+	ran = nil
+	repo.locked = true
+
+	if err := s.RunTask(context.Background(), task); err != nil {
+		t.Fatalf("RunTask() while locked error = %v", err)
+	}
+
+	if len(ran) != 0 {
+		t.Errorf("ran steps while locked = %v, want none", ran)
+	}
+}
+
+func TestParseTables(t *testing.T) {
+	got := parseTables(" raw_messages, ,public.items,")
+	want := []string{"raw_messages", "public.items"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTables() = %v, want %v", got, want)
+	}
+}
@@ -5,7 +5,9 @@
 //   - /readyz: Readiness probe (checks database connectivity)
 //   - /metrics: Prometheus metrics endpoint
 //   - /i/*: Optional expanded view handler
+//   - /r/*: Optional tracked digest link handler
 //   - /research/*: Optional research dashboard handler
+//   - /calendar.ics: Optional announced events feed
 package observability
 
 import (
@@ -52,7 +54,9 @@ const (
 	shutdownTimeout      = 5 * time.Second
 	readHeaderTimeout    = 10 * time.Second
 	expandedViewPathBase = "/i/"
+	clickPathBase        = "/r/"
 	researchPathBase     = "/research/"
+	calendarPath         = "/calendar.ics"
 )
 
 type Server struct {
@@ -61,6 +65,8 @@ type Server struct {
 	logger          *zerolog.Logger
 	expandedHandler http.Handler
 	researchHandler http.Handler
+	clickHandler    http.Handler
+	calendarHandler http.Handler
 }
 
 func NewServer(db *db.DB, port int, logger *zerolog.Logger) *Server {
@@ -87,6 +93,16 @@ func NewServerWithHandlers(db *db.DB, port int, expandedHandler http.Handler, re
 	}
 }
 
+// SetClickHandler sets the optional handler of tracked digest links.
+func (s *Server) SetClickHandler(clickHandler http.Handler) {
+	s.clickHandler = clickHandler
+}
+
+// SetCalendarHandler sets the optional handler of the announced events feed.
+func (s *Server) SetCalendarHandler(calendarHandler http.Handler) {
+	s.calendarHandler = calendarHandler
+}
+
 func (s *Server) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
@@ -118,7 +134,7 @@ func (s *Server) Start(ctx context.Context) error {
 	// Robots.txt to prevent indexing of expanded view pages
 	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
-		_, _ = fmt.Fprint(w, "User-agent: *\nDisallow: /i/\nDisallow: /research/\n")
+		_, _ = fmt.Fprint(w, "User-agent: *\nDisallow: /i/\nDisallow: /r/\nDisallow: /research/\nDisallow: /calendar.ics\n")
 	})
 
 	// Register expanded view handler if configured
@@ -126,6 +142,11 @@ func (s *Server) Start(ctx context.Context) error {
 		mux.Handle(expandedViewPathBase, http.StripPrefix(expandedViewPathBase, s.expandedHandler))
 	}
 
+	// Register tracked digest link handler if configured
+	if s.clickHandler != nil {
+		mux.Handle(clickPathBase, http.StripPrefix(clickPathBase, s.clickHandler))
+	}
+
 	// Register research handler if configured
 	if s.researchHandler != nil {
 		mux.HandleFunc("/research", func(w http.ResponseWriter, r *http.Request) {
@@ -134,6 +155,11 @@ func (s *Server) Start(ctx context.Context) error {
 		mux.Handle(researchPathBase, http.StripPrefix(researchPathBase, s.researchHandler))
 	}
 
+	// Register announced events feed if configured
+	if s.calendarHandler != nil {
+		mux.Handle(calendarPath, s.calendarHandler)
+	}
+
 	srv := &http.Server{
 		Addr:              fmt.Sprintf(":%d", s.port),
 		Handler:           mux,
@@ -55,6 +55,27 @@ var (
 		Buckets: []float64{1, 2, 5, 10, 20, 30, 60, 120, 300},
 	})
 
+	PipelineStageDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "digest_pipeline_stage_duration_seconds",
+		Help:    "Duration in seconds of a pipeline stage per batch",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30, 60, 120},
+	}, []string{"stage"})
+
+	PipelineStageMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_pipeline_stage_messages_total",
+		Help: "Messages leaving a pipeline stage by result (passed, dropped, disabled)",
+	}, []string{"stage", "result"})
+
+	PipelineStageErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_pipeline_stage_errors_total",
+		Help: "Pipeline stage errors; a live stage error aborts the batch, a shadow stage error is ignored",
+	}, []string{"stage"})
+
+	PipelineShadowDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_pipeline_shadow_decisions_total",
+		Help: "Sampled messages by whether a shadow stage agreed with its live stage (agree, shadow_only, live_only)",
+	}, []string{"stage", "shadow", "outcome"})
+
 	AnnotationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "digest_item_annotations_total",
 		Help: "Total number of item annotations by rating",
@@ -81,6 +102,26 @@ var (
 		Help: "The total number of digests posted",
 	}, []string{"status"})
 
+	RepeatStoriesSuppressed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "digest_repeat_story_suppressed_total",
+		Help: "Total number of digest candidates dropped as repeats of recently posted stories",
+	})
+
+	DigestDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_deliveries_total",
+		Help: "The total number of digests delivered to Slack, Discord and Matrix",
+	}, []string{"platform", "status"})
+
+	PipelineEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_events_total",
+		Help: "The total number of pipeline events sent to external sinks",
+	}, []string{"type", "sink", "status"})
+
+	ExportedPages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "exported_pages_total",
+		Help: "The total number of digest and story pages exported to Notion and Obsidian",
+	}, []string{"target", "kind", "status"})
+
 	DigestTimeToDigestSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "digest_time_to_digest_seconds",
 		Help:    "Time from message timestamp to digest inclusion",
@@ -104,8 +145,23 @@ var (
 
 	DropsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "digest_drops_total",
-		Help: "Total number of dropped messages by reason",
-	}, []string{"reason"})
+		Help: "Total number of dropped messages by reason and drop category",
+	}, []string{"reason", "category"})
+
+	MediaClassTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_media_class_total",
+		Help: "Total number of image-only posts by media class",
+	}, []string{"class"})
+
+	ContentSafetyFlagsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_content_safety_flags_total",
+		Help: "Total number of processed items by content safety flag",
+	}, []string{"flag"})
+
+	ContentSafetyActionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_content_safety_actions_total",
+		Help: "Total number of flagged digest items by applied safety policy",
+	}, []string{"policy"})
 
 	LinkContextUsedTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "digest_link_context_used_total",
@@ -351,6 +407,53 @@ var (
 		Help: "Whether LLM provider is currently available (0=no, 1=yes)",
 	}, []string{"provider"})
 
+	// LLM provider attempts by outcome, for per-provider availability
+	LLMProviderAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_llm_provider_attempts_total",
+		Help: "Total number of LLM provider attempts by status (success, error)",
+	}, []string{"provider", "status"})
+
+	// LLM cassettes recorded and replayed for deterministic digest replays
+	LLMCassettes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_llm_cassettes_total",
+		Help: "Total number of LLM cassette events by method and event (recorded, hit, miss)",
+	}, []string{"method", "event"})
+
+	// LLM task failover to a secondary provider/model
+	LLMFailoverActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "digest_llm_failover_active",
+		Help: "Whether an LLM task is failed over to its secondary provider (0=no, 1=yes)",
+	}, []string{"task"})
+
+	LLMFailovers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_llm_failovers_total",
+		Help: "Total number of LLM task failovers and failbacks",
+	}, []string{"task", "direction"})
+
+	// LLM rate limits shared across replicas
+	LLMSharedRateLimitWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "digest_llm_shared_rate_limit_wait_seconds",
+		Help:    "Time LLM requests wait for the shared rate limit",
+		Buckets: []float64{0, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+	}, []string{"provider", "task"})
+
+	LLMSharedRateLimitErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_llm_shared_rate_limit_errors_total",
+		Help: "Total number of shared rate limit store errors (requests fall back to per-replica limits)",
+	}, []string{"provider"})
+
+	// Outbound Telegram bot sends
+	TelegramSends = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_telegram_sends_total",
+		Help: "Total number of Telegram bot API requests by outcome (flood_wait counts each rate-limited attempt)",
+	}, []string{"status"})
+
+	TelegramSendWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "digest_telegram_send_wait_seconds",
+		Help:    "Time Telegram bot API requests wait in the send queue for ordering and rate limits",
+		Buckets: []float64{0, 0.1, 0.5, 1, 3, 10, 30, 60},
+	})
+
 	// Embedding metrics
 	EmbeddingRequests = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "digest_embedding_requests_total",
@@ -491,4 +594,20 @@ var (
 		Name: "digest_reader_forwarded_ratio",
 		Help: "Share of ingested messages that are forwarded within the last batch",
 	}, []string{"channel"})
+
+	MaintenanceStepDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "digest_maintenance_step_duration_seconds",
+		Help:    "Duration of database maintenance steps (view refreshes, table rebuilds, vacuums)",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600, 1800},
+	}, []string{"task", "step"})
+
+	MaintenanceRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_maintenance_runs_total",
+		Help: "Total number of database maintenance task runs by status (success, error, locked)",
+	}, []string{"task", "status"})
+
+	MaintenanceLastSuccessTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "digest_maintenance_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful run of each database maintenance task",
+	}, []string{"task"})
 )
@@ -0,0 +1,394 @@
+// Package provenance exports everything known about an item as a signed
+// JSON bundle for audit and compliance requests.
+//
+// A bundle holds the source message and its link, the pipeline decisions
+// (relevance gate, drop log, status and scores, safety flags), the models
+// and prompt versions active when the item was processed, the evidence and
+// fact checks attached to it and its ratings. Bundles are signed with
+// HMAC-SHA256 so a recipient holding the key can check that one was not
+// altered after export.
+package provenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/settings"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// FormatVersion is the version of the bundle layout.
+const FormatVersion = 1
+
+// maxRatings caps the ratings included in a bundle.
+const maxRatings = 1000
+
+// ErrItemNotFound is returned when the exported item does not exist.
+var ErrItemNotFound = errors.New("item not found")
+
+// Store reads the item data a bundle is built from.
+type Store interface {
+	GetItemDebugDetail(ctx context.Context, id string) (*db.ItemDebugDetail, error)
+	GetRawMessageDropLog(ctx context.Context, rawMsgID string) (*db.RawMessageDropInfo, error)
+	GetRelevanceGateDecision(ctx context.Context, rawMessageID string) (*db.RelevanceGateDecision, error)
+	GetItemRawScores(ctx context.Context, itemID string) (*db.ItemRawScores, error)
+	GetItemSafetyFlags(ctx context.Context, itemIDs []string) (map[string][]string, error)
+	GetItemRegions(ctx context.Context, itemIDs []string) (map[string][]string, error)
+	GetModelPromptStateAt(ctx context.Context, at time.Time) ([]db.ModelPromptEvent, error)
+	GetSetting(ctx context.Context, key string, target interface{}) error
+	GetEvidenceForItems(ctx context.Context, itemIDs []string) (map[string][]db.ItemEvidenceWithSource, error)
+	GetFactChecksForItems(ctx context.Context, itemIDs []string) (map[string]db.FactCheckMatch, error)
+	GetItemRatingsByItem(ctx context.Context, itemID string, limit int) ([]db.ItemRatingDetail, error)
+}
+
+// Bundle is everything known about one item.
+type Bundle struct {
+	FormatVersion int        `json:"format_version"`
+	ExportedAt    time.Time  `json:"exported_at"`
+	Item          Item       `json:"item"`
+	Source        Source     `json:"source"`
+	Decisions     Decisions  `json:"decisions"`
+	Models        []Model    `json:"models"`
+	Prompts       []Prompt   `json:"prompts"`
+	Evidence      []Evidence `json:"evidence"`
+	FactCheck     *FactCheck `json:"fact_check,omitempty"`
+	Ratings       []Rating   `json:"ratings"`
+}
+
+// Item is the item as stored.
+type Item struct {
+	ID              string    `json:"id"`
+	Summary         string    `json:"summary"`
+	Topic           string    `json:"topic"`
+	Language        string    `json:"language"`
+	LanguageSource  string    `json:"language_source"`
+	Status          string    `json:"status"`
+	RelevanceScore  float32   `json:"relevance_score"`
+	ImportanceScore float32   `json:"importance_score"`
+	EvidenceVerdict string    `json:"evidence_verdict,omitempty"`
+	SafetyFlags     []string  `json:"safety_flags"`
+	Regions         []string  `json:"regions"`
+	ProcessedAt     time.Time `json:"processed_at"`
+}
+
+// Source is the Telegram message the item was made from.
+type Source struct {
+	RawMessageID    string    `json:"raw_message_id"`
+	ChannelID       string    `json:"channel_id"`
+	ChannelUsername string    `json:"channel_username,omitempty"`
+	ChannelTitle    string    `json:"channel_title"`
+	MessageID       int64     `json:"message_id"`
+	Link            string    `json:"link"`
+	PostedAt        time.Time `json:"posted_at"`
+	Text            string    `json:"text"`
+	PreviewText     string    `json:"preview_text,omitempty"`
+}
+
+// Decisions are the pipeline decisions taken on the item.
+type Decisions struct {
+	RelevanceGate *GateDecision `json:"relevance_gate,omitempty"`
+	Scoring       *Scoring      `json:"scoring,omitempty"`
+	Drop          *Drop         `json:"drop,omitempty"`
+}
+
+// GateDecision is the relevance gate verdict on the source message.
+type GateDecision struct {
+	Decision    string  `json:"decision"`
+	Confidence  float32 `json:"confidence"`
+	Reason      string  `json:"reason"`
+	Model       string  `json:"model"`
+	GateVersion string  `json:"gate_version"`
+}
+
+// Scoring is the raw LLM scoring of the item, before normalization.
+type Scoring struct {
+	Version         string    `json:"version"`
+	RelevanceScore  float32   `json:"relevance_score"`
+	ImportanceScore float32   `json:"importance_score"`
+	ScoredAt        time.Time `json:"scored_at"`
+}
+
+// Drop is the reason the source message was dropped.
+type Drop struct {
+	Reason    string    `json:"reason"`
+	Detail    string    `json:"detail,omitempty"`
+	DroppedAt time.Time `json:"dropped_at"`
+}
+
+// Model is the model override of an LLM task when the item was processed.
+type Model struct {
+	Task        string    `json:"task"`
+	Model       string    `json:"model"`
+	ActivatedAt time.Time `json:"activated_at"`
+}
+
+// Prompt is the prompt version of an LLM task when the item was processed,
+// with the current text of that version.
+type Prompt struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	ActivatedAt time.Time `json:"activated_at"`
+	Text        string    `json:"text,omitempty"`
+}
+
+// Evidence is an external source matched against the item.
+type Evidence struct {
+	URL              string    `json:"url"`
+	Domain           string    `json:"domain"`
+	Title            string    `json:"title"`
+	Provider         string    `json:"provider"`
+	AgreementScore   float32   `json:"agreement_score"`
+	IsContradiction  bool      `json:"is_contradiction"`
+	Stance           string    `json:"stance,omitempty"`
+	StanceConfidence float32   `json:"stance_confidence,omitempty"`
+	MatchedAt        time.Time `json:"matched_at"`
+}
+
+// FactCheck is the fact check matched against the item.
+type FactCheck struct {
+	Claim     string    `json:"claim"`
+	URL       string    `json:"url"`
+	Publisher string    `json:"publisher"`
+	Rating    string    `json:"rating"`
+	MatchedAt time.Time `json:"matched_at"`
+}
+
+// Rating is a user rating of the item.
+type Rating struct {
+	UserID    int64     `json:"user_id"`
+	Rating    string    `json:"rating"`
+	Feedback  string    `json:"feedback,omitempty"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Export builds the bundle of an item. Missing optional records, such as a
+// gate decision for messages that skipped the gate, are left out.
+func Export(ctx context.Context, store Store, itemID string, now time.Time) (*Bundle, error) {
+	detail, err := store.GetItemDebugDetail(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("load item: %w", err)
+	}
+
+	if detail == nil {
+		return nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+
+	b := &Bundle{
+		FormatVersion: FormatVersion,
+		ExportedAt:    now.UTC(),
+		Item:          itemFromDetail(detail),
+		Source:        sourceFromDetail(detail),
+	}
+
+	if err := loadDecisions(ctx, store, b, detail.RawMessageID); err != nil {
+		return nil, err
+	}
+
+	if err := loadLabels(ctx, store, b); err != nil {
+		return nil, err
+	}
+
+	if err := loadModelsAndPrompts(ctx, store, b, detail.ProcessedAt); err != nil {
+		return nil, err
+	}
+
+	if err := loadEvidence(ctx, store, b); err != nil {
+		return nil, err
+	}
+
+	ratings, err := store.GetItemRatingsByItem(ctx, itemID, maxRatings)
+	if err != nil {
+		return nil, fmt.Errorf("load ratings: %w", err)
+	}
+
+	b.Ratings = make([]Rating, 0, len(ratings))
+	for _, r := range ratings {
+		b.Ratings = append(b.Ratings, Rating(r))
+	}
+
+	return b, nil
+}
+
+func itemFromDetail(d *db.ItemDebugDetail) Item {
+	return Item{
+		ID:              d.ID,
+		Summary:         d.Summary,
+		Topic:           d.Topic,
+		Language:        d.Language,
+		LanguageSource:  d.LanguageSource,
+		Status:          d.Status,
+		RelevanceScore:  d.RelevanceScore,
+		ImportanceScore: d.ImportanceScore,
+		EvidenceVerdict: d.EvidenceVerdict,
+		SafetyFlags:     []string{},
+		Regions:         []string{},
+		ProcessedAt:     d.ProcessedAt.UTC(),
+	}
+}
+
+func sourceFromDetail(d *db.ItemDebugDetail) Source {
+	return Source{
+		RawMessageID:    d.RawMessageID,
+		ChannelID:       d.ChannelID,
+		ChannelUsername: d.ChannelUsername,
+		ChannelTitle:    d.ChannelTitle,
+		MessageID:       d.MessageID,
+		Link:            MessageLink(d.ChannelUsername, d.ChannelPeerID, d.MessageID),
+		PostedAt:        d.TGDate.UTC(),
+		Text:            d.Text,
+		PreviewText:     d.PreviewText,
+	}
+}
+
+// MessageLink returns the t.me link of a channel message: by username for
+// public channels, by peer ID otherwise.
+func MessageLink(username string, peerID, msgID int64) string {
+	if username != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", username, msgID)
+	}
+
+	return fmt.Sprintf("https://t.me/c/%d/%d", peerID, msgID)
+}
+
+func loadDecisions(ctx context.Context, store Store, b *Bundle, rawMsgID string) error {
+	gate, err := store.GetRelevanceGateDecision(ctx, rawMsgID)
+
+	switch {
+	case err == nil:
+		b.Decisions.RelevanceGate = &GateDecision{
+			Decision:    gate.Decision,
+			Confidence:  gate.Confidence,
+			Reason:      gate.Reason,
+			Model:       gate.Model,
+			GateVersion: gate.GateVersion,
+		}
+	case !errors.Is(err, db.ErrRelevanceGateNotFound):
+		return fmt.Errorf("load relevance gate decision: %w", err)
+	}
+
+	scores, err := store.GetItemRawScores(ctx, b.Item.ID)
+
+	switch {
+	case err == nil:
+		b.Decisions.Scoring = &Scoring{
+			Version:         scores.ScoringVersion,
+			RelevanceScore:  scores.RelevanceScore,
+			ImportanceScore: scores.ImportanceScore,
+			ScoredAt:        scores.ScoredAt.UTC(),
+		}
+	case !errors.Is(err, db.ErrRawScoresNotFound):
+		return fmt.Errorf("load raw scores: %w", err)
+	}
+
+	drop, err := store.GetRawMessageDropLog(ctx, rawMsgID)
+
+	switch {
+	case err == nil:
+		b.Decisions.Drop = &Drop{Reason: drop.Reason, Detail: drop.Detail, DroppedAt: drop.UpdatedAt.UTC()}
+	case !errors.Is(err, db.ErrDropLogNotFound):
+		return fmt.Errorf("load drop log: %w", err)
+	}
+
+	return nil
+}
+
+func loadLabels(ctx context.Context, store Store, b *Bundle) error {
+	ids := []string{b.Item.ID}
+
+	flags, err := store.GetItemSafetyFlags(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("load safety flags: %w", err)
+	}
+
+	if f := flags[b.Item.ID]; len(f) > 0 {
+		b.Item.SafetyFlags = f
+	}
+
+	regions, err := store.GetItemRegions(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("load regions: %w", err)
+	}
+
+	if r := regions[b.Item.ID]; len(r) > 0 {
+		b.Item.Regions = r
+	}
+
+	return nil
+}
+
+// loadModelsAndPrompts records the model overrides and prompt versions that
+// were active when the item was processed. Tasks without an activation ran
+// on their built-in defaults and are left out.
+func loadModelsAndPrompts(ctx context.Context, store Store, b *Bundle, processedAt time.Time) error {
+	events, err := store.GetModelPromptStateAt(ctx, processedAt)
+	if err != nil {
+		return fmt.Errorf("load model/prompt state: %w", err)
+	}
+
+	b.Models = []Model{}
+	b.Prompts = []Prompt{}
+
+	for _, e := range events {
+		if e.NewValue == "" {
+			continue
+		}
+
+		switch e.Kind {
+		case db.ModelPromptEventModel:
+			b.Models = append(b.Models, Model{Task: e.Target, Model: e.NewValue, ActivatedAt: e.ChangedAt.UTC()})
+		case db.ModelPromptEventPrompt:
+			p := Prompt{Name: e.Target, Version: e.NewValue, ActivatedAt: e.ChangedAt.UTC()}
+			if err := store.GetSetting(ctx, fmt.Sprintf(settings.PromptKeyFmt, e.Target, e.NewValue), &p.Text); err != nil {
+				return fmt.Errorf("load prompt %s:%s: %w", e.Target, e.NewValue, err)
+			}
+
+			b.Prompts = append(b.Prompts, p)
+		}
+	}
+
+	return nil
+}
+
+func loadEvidence(ctx context.Context, store Store, b *Bundle) error {
+	ids := []string{b.Item.ID}
+
+	evidence, err := store.GetEvidenceForItems(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("load evidence: %w", err)
+	}
+
+	b.Evidence = make([]Evidence, 0, len(evidence[b.Item.ID]))
+	for _, e := range evidence[b.Item.ID] {
+		b.Evidence = append(b.Evidence, Evidence{
+			URL:              e.Source.URL,
+			Domain:           e.Source.Domain,
+			Title:            e.Source.Title,
+			Provider:         e.Source.Provider,
+			AgreementScore:   e.AgreementScore,
+			IsContradiction:  e.IsContradiction,
+			Stance:           e.Stance,
+			StanceConfidence: e.StanceConfidence,
+			MatchedAt:        e.MatchedAt.UTC(),
+		})
+	}
+
+	checks, err := store.GetFactChecksForItems(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("load fact checks: %w", err)
+	}
+
+	if fc, ok := checks[b.Item.ID]; ok {
+		b.FactCheck = &FactCheck{
+			Claim:     fc.Claim,
+			URL:       fc.URL,
+			Publisher: fc.Publisher,
+			Rating:    fc.Rating,
+			MatchedAt: fc.MatchedAt.UTC(),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package provenance
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+type fakeStore struct {
+	detail  *db.ItemDebugDetail
+	events  []db.ModelPromptEvent
+	prompts map[string]string
+}
+
+func (f *fakeStore) GetItemDebugDetail(_ context.Context, _ string) (*db.ItemDebugDetail, error) {
+	return f.detail, nil
+}
+
+func (f *fakeStore) GetRawMessageDropLog(_ context.Context, _ string) (*db.RawMessageDropInfo, error) {
+	return nil, db.ErrDropLogNotFound
+}
+
+func (f *fakeStore) GetRelevanceGateDecision(_ context.Context, _ string) (*db.RelevanceGateDecision, error) {
+	return &db.RelevanceGateDecision{Decision: "relevant", Confidence: 0.9, Model: "gpt-4o-mini", GateVersion: "v2"}, nil
+}
+
+func (f *fakeStore) GetItemRawScores(_ context.Context, _ string) (*db.ItemRawScores, error) {
+	return nil, db.ErrRawScoresNotFound
+}
+
+func (f *fakeStore) GetItemSafetyFlags(_ context.Context, ids []string) (map[string][]string, error) {
+	return map[string][]string{ids[0]: {"profanity"}}, nil
+}
+
+func (f *fakeStore) GetItemRegions(_ context.Context, _ []string) (map[string][]string, error) {
+	return map[string][]string{}, nil
+}
+
+func (f *fakeStore) GetModelPromptStateAt(_ context.Context, _ time.Time) ([]db.ModelPromptEvent, error) {
+	return f.events, nil
+}
+
+func (f *fakeStore) GetSetting(_ context.Context, key string, target interface{}) error {
+	if text, ok := f.prompts[key]; ok {
+		*target.(*string) = text
+	}
+
+	return nil
+}
+
+func (f *fakeStore) GetEvidenceForItems(_ context.Context, _ []string) (map[string][]db.ItemEvidenceWithSource, error) {
+	return map[string][]db.ItemEvidenceWithSource{}, nil
+}
+
+func (f *fakeStore) GetFactChecksForItems(_ context.Context, _ []string) (map[string]db.FactCheckMatch, error) {
+	return map[string]db.FactCheckMatch{}, nil
+}
+
+func (f *fakeStore) GetItemRatingsByItem(_ context.Context, _ string, _ int) ([]db.ItemRatingDetail, error) {
+	return []db.ItemRatingDetail{{UserID: 7, Rating: "good", Source: "button"}}, nil
+}
+
+func TestExport(t *testing.T) {
+	store := &fakeStore{
+		detail: &db.ItemDebugDetail{
+			ID:              "item-1",
+			RawMessageID:    "raw-1",
+			Summary:         "Rates rose",
+			Status:          "ready",
+			Text:            "Central bank raised rates",
+			MessageID:       42,
+			ChannelUsername: "econ",
+		},
+		events: []db.ModelPromptEvent{
+			{Kind: db.ModelPromptEventModel, Target: "summarize", NewValue: "gpt-4o"},
+			{Kind: db.ModelPromptEventPrompt, Target: "summarize", NewValue: "v7"},
+			{Kind: db.ModelPromptEventPrompt, Target: "narrative", NewValue: ""},
+		},
+		prompts: map[string]string{"prompt:summarize:v7": "Summarize the message."},
+	}
+
+	b, err := Export(context.Background(), store, "item-1", time.Now())
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if b.Source.Link != "https://t.me/econ/42" || b.Source.Text != "Central bank raised rates" {
+		t.Errorf("source = %+v", b.Source)
+	}
+
+	if b.Decisions.RelevanceGate == nil || b.Decisions.RelevanceGate.Model != "gpt-4o-mini" || b.Decisions.Drop != nil {
+		t.Errorf("decisions = %+v", b.Decisions)
+	}
+
+	if len(b.Models) != 1 || b.Models[0].Model != "gpt-4o" {
+		t.Errorf("models = %+v", b.Models)
+	}
+
+	if len(b.Prompts) != 1 || b.Prompts[0].Text != "Summarize the message." {
+		t.Errorf("prompts = %+v, want only the summarize:v7 prompt", b.Prompts)
+	}
+
+	if len(b.Item.SafetyFlags) != 1 || len(b.Ratings) != 1 {
+		t.Errorf("safety flags = %v, ratings = %+v", b.Item.SafetyFlags, b.Ratings)
+	}
+
+	store.detail = nil
+	if _, err := Export(context.Background(), store, "missing", time.Now()); !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("Export(missing) error = %v, want ErrItemNotFound", err)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	privateKey, publicKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	private, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("ParsePrivateKey() error = %v", err)
+	}
+
+	public, err := ParsePublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("ParsePublicKey() error = %v", err)
+	}
+
+	b := &Bundle{FormatVersion: FormatVersion, Item: Item{ID: "item-1", Summary: "<b>x</b> & y"}}
+
+	signed, err := Sign(b, private, "2026-10")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	data, err := json.MarshalIndent(signed, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, sig, err := Verify(data, public)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if got.Item.Summary != b.Item.Summary || sig.Signature.KeyID != "2026-10" || sig.Signature.Algorithm != SignatureAlgorithm {
+		t.Errorf("Verify() = %+v, %+v", got.Item, sig.Signature)
+	}
+
+	_, otherPublic, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := ParsePublicKey(otherPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Verify(data, other); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(wrong key) error = %v, want ErrInvalidSignature", err)
+	}
+
+	tampered := bytes.Replace(data, []byte("item-1"), []byte("item-2"), 1)
+	if _, _, err := Verify(tampered, public); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(tampered) error = %v, want ErrInvalidSignature", err)
+	}
+
+	if _, err := Sign(b, nil, ""); !errors.Is(err, ErrSigningKeyRequired) {
+		t.Errorf("Sign(no key) error = %v, want ErrSigningKeyRequired", err)
+	}
+
+	if _, _, err := Verify(data, ed25519.PublicKey("short")); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Verify(short key) error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	if _, err := ParsePrivateKey(""); !errors.Is(err, ErrSigningKeyRequired) {
+		t.Errorf("ParsePrivateKey(empty) error = %v, want ErrSigningKeyRequired", err)
+	}
+
+	if _, err := ParsePublicKey(""); !errors.Is(err, ErrPublicKeyRequired) {
+		t.Errorf("ParsePublicKey(empty) error = %v, want ErrPublicKeyRequired", err)
+	}
+
+	if _, err := ParsePrivateKey("c2VjcmV0"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("ParsePrivateKey(short) error = %v, want ErrInvalidKey", err)
+	}
+
+	if _, err := ParsePublicKey("not base64!"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("ParsePublicKey(garbage) error = %v, want ErrInvalidKey", err)
+	}
+}
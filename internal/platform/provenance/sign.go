@@ -0,0 +1,155 @@
+package provenance
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SignatureAlgorithm names the signature scheme of signed bundles.
+const SignatureAlgorithm = "Ed25519"
+
+// Signature errors.
+var (
+	ErrSigningKeyRequired = errors.New("signing key is required")
+	ErrPublicKeyRequired  = errors.New("public key is required")
+	ErrInvalidKey         = errors.New("invalid ed25519 key")
+	ErrInvalidSignature   = errors.New("invalid bundle signature")
+	ErrUnknownAlgorithm   = errors.New("unknown signature algorithm")
+)
+
+// SignedBundle is a bundle together with its signature. Bundle holds the
+// compact JSON that was signed; verification compacts it again, so
+// indenting the file does not break the signature, but re-encoding it does.
+type SignedBundle struct {
+	Bundle    json.RawMessage `json:"bundle"`
+	Signature Signature       `json:"signature"`
+}
+
+// Signature is the Ed25519 signature of a bundle, base64 encoded.
+type Signature struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"key_id,omitempty"`
+	Value     string `json:"value"`
+}
+
+// GenerateKey returns a new key pair, base64 encoded as ParsePrivateKey and
+// ParsePublicKey expect them.
+func GenerateKey() (privateKey, publicKey string, err error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generate ed25519 key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(private.Seed()), base64.StdEncoding.EncodeToString(public), nil
+}
+
+// ParsePrivateKey decodes a base64 Ed25519 private key, either its 32-byte
+// seed or the 64-byte expanded form.
+func ParsePrivateKey(s string) (ed25519.PrivateKey, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, ErrSigningKeyRequired
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, fmt.Errorf("%w: private key has %d bytes", ErrInvalidKey, len(raw))
+	}
+}
+
+// ParsePublicKey decodes a base64 Ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, ErrPublicKeyRequired
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: public key has %d bytes", ErrInvalidKey, len(raw))
+	}
+
+	return ed25519.PublicKey(raw), nil
+}
+
+// Sign encodes the bundle and signs it with the private key. keyID is
+// recorded so recipients know which public key to verify with after a
+// rotation.
+func Sign(b *Bundle, key ed25519.PrivateKey, keyID string) (*SignedBundle, error) {
+	if len(key) == 0 {
+		return nil, ErrSigningKeyRequired
+	}
+
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: private key has %d bytes", ErrInvalidKey, len(key))
+	}
+
+	payload, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("marshal bundle: %w", err)
+	}
+
+	return &SignedBundle{
+		Bundle: payload,
+		Signature: Signature{
+			Algorithm: SignatureAlgorithm,
+			KeyID:     keyID,
+			Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+		},
+	}, nil
+}
+
+// Verify checks the signature of an encoded signed bundle against the public
+// key and returns the bundle it holds.
+func Verify(data []byte, key ed25519.PublicKey) (*Bundle, *SignedBundle, error) {
+	if len(key) == 0 {
+		return nil, nil, ErrPublicKeyRequired
+	}
+
+	if len(key) != ed25519.PublicKeySize {
+		return nil, nil, fmt.Errorf("%w: public key has %d bytes", ErrInvalidKey, len(key))
+	}
+
+	var signed SignedBundle
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, nil, fmt.Errorf("decode signed bundle: %w", err)
+	}
+
+	if signed.Signature.Algorithm != SignatureAlgorithm {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, signed.Signature.Algorithm)
+	}
+
+	var payload bytes.Buffer
+	if err := json.Compact(&payload, signed.Bundle); err != nil {
+		return nil, nil, fmt.Errorf("compact bundle: %w", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signed.Signature.Value)
+	if err != nil || !ed25519.Verify(key, payload.Bytes(), sig) {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(signed.Bundle, &b); err != nil {
+		return nil, nil, fmt.Errorf("decode bundle: %w", err)
+	}
+
+	return &b, &signed, nil
+}
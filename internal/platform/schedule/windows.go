@@ -0,0 +1,169 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxDeliveryWindows bounds the number of daily delivery windows.
+const MaxDeliveryWindows = 4
+
+// Static errors for delivery window validation.
+var (
+	ErrWindowFormat     = errors.New("window must be HH:MM-HH:MM")
+	ErrEmptyWindow      = errors.New("window start equals its end")
+	ErrNoWindows        = errors.New("no delivery windows")
+	ErrTooManyWindows   = errors.New("too many delivery windows")
+	ErrOverlappingStart = errors.New("window starts inside another window")
+)
+
+// DeliveryWindow is a daily time range in minutes since midnight. A window
+// whose end is before its start runs past midnight.
+type DeliveryWindow struct {
+	Start int
+	End   int
+}
+
+// ParseDeliveryWindows parses comma-separated windows such as
+// "08:00-09:00,22:30-01:00".
+func ParseDeliveryWindows(value string) ([]DeliveryWindow, error) {
+	parts := strings.Split(value, ",")
+	windows := make([]DeliveryWindow, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		w, err := parseDeliveryWindow(part)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", part, err)
+		}
+
+		windows = append(windows, w)
+	}
+
+	switch {
+	case len(windows) == 0:
+		return nil, ErrNoWindows
+	case len(windows) > MaxDeliveryWindows:
+		return nil, ErrTooManyWindows
+	}
+
+	for i, w := range windows {
+		for j, other := range windows {
+			if i != j && other.contains(w.Start) {
+				return nil, fmt.Errorf("%s: %w", w, ErrOverlappingStart)
+			}
+		}
+	}
+
+	return windows, nil
+}
+
+func parseDeliveryWindow(value string) (DeliveryWindow, error) {
+	start, end, ok := strings.Cut(value, "-")
+	if !ok {
+		return DeliveryWindow{}, ErrWindowFormat
+	}
+
+	startHour, startMinute, err := parseHourMinute(start)
+	if err != nil {
+		return DeliveryWindow{}, err
+	}
+
+	endHour, endMinute, err := parseHourMinute(end)
+	if err != nil {
+		return DeliveryWindow{}, err
+	}
+
+	w := DeliveryWindow{
+		Start: startHour*minutesPerHour + startMinute,
+		End:   endHour*minutesPerHour + endMinute,
+	}
+
+	if w.Start == w.End {
+		return DeliveryWindow{}, ErrEmptyWindow
+	}
+
+	return w, nil
+}
+
+// String formats the window as HH:MM-HH:MM.
+func (w DeliveryWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d",
+		w.Start/minutesPerHour, w.Start%minutesPerHour, w.End/minutesPerHour, w.End%minutesPerHour)
+}
+
+// contains reports whether the minute of day falls inside the window.
+func (w DeliveryWindow) contains(minute int) bool {
+	if w.Start < w.End {
+		return minute >= w.Start && minute < w.End
+	}
+
+	return minute >= w.Start || minute < w.End
+}
+
+// FormatDeliveryWindows formats windows in the form accepted by
+// ParseDeliveryWindows.
+func FormatDeliveryWindows(windows []DeliveryWindow) string {
+	parts := make([]string, len(windows))
+	for i, w := range windows {
+		parts[i] = w.String()
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// CurrentWindowStart returns the start of the window occurrence that contains
+// now, with windows read in loc. The start identifies the occurrence, so a
+// caller can deliver once per window.
+func CurrentWindowStart(windows []DeliveryWindow, now time.Time, loc *time.Location) (time.Time, bool) {
+	local := now.In(loc)
+	today := dateOnly(local)
+
+	for _, w := range windows {
+		// A window past midnight may have started the day before
+		for _, day := range []time.Time{today, today.AddDate(0, 0, -1)} {
+			start := atMinute(day, w.Start)
+
+			end := atMinute(day, w.End)
+			if w.End < w.Start {
+				end = atMinute(day.AddDate(0, 0, 1), w.End)
+			}
+
+			if !local.Before(start) && local.Before(end) {
+				return start, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NextWindowStart returns the next window start after now, with windows read
+// in loc.
+func NextWindowStart(windows []DeliveryWindow, now time.Time, loc *time.Location) (time.Time, bool) {
+	local := now.In(loc)
+	today := dateOnly(local)
+
+	var next time.Time
+
+	for _, w := range windows {
+		for _, day := range []time.Time{today, today.AddDate(0, 0, 1)} {
+			start := atMinute(day, w.Start)
+			if start.After(local) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/minutesPerHour, minute%minutesPerHour, 0, 0, day.Location())
+}
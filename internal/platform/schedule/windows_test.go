@@ -0,0 +1,84 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDeliveryWindows(t *testing.T) {
+	windows, err := ParseDeliveryWindows(" 7:30-09:00, 22:00-01:00 ")
+	if err != nil {
+		t.Fatalf(testErrUnexpected, err)
+	}
+
+	if got := FormatDeliveryWindows(windows); got != "07:30-09:00,22:00-01:00" {
+		t.Errorf("FormatDeliveryWindows() = %q", got)
+	}
+
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{"", ErrNoWindows},
+		{"08:00", ErrWindowFormat},
+		{"08:00-08:00", ErrEmptyWindow},
+		{"08:00-25:00", ErrHourOutOfRange},
+		{"08:00-10:00,09:00-11:00", ErrOverlappingStart},
+		{"01:00-02:00,03:00-04:00,05:00-06:00,07:00-08:00,09:00-10:00", ErrTooManyWindows},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseDeliveryWindows(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("ParseDeliveryWindows(%q) error = %v, want %v", tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestCurrentWindowStart(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf(testErrUnexpected, err)
+	}
+
+	windows, err := ParseDeliveryWindows("08:00-09:00,23:00-01:00")
+	if err != nil {
+		t.Fatalf(testErrUnexpected, err)
+	}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"inside morning window", time.Date(2026, 3, 2, 8, 30, 0, 0, loc), time.Date(2026, 3, 2, 8, 0, 0, 0, loc), true},
+		{"window end is exclusive", time.Date(2026, 3, 2, 9, 0, 0, 0, loc), time.Time{}, false},
+		{"overnight before midnight", time.Date(2026, 3, 2, 23, 15, 0, 0, loc), time.Date(2026, 3, 2, 23, 0, 0, 0, loc), true},
+		{"overnight after midnight", time.Date(2026, 3, 3, 0, 45, 0, 0, loc), time.Date(2026, 3, 2, 23, 0, 0, 0, loc), true},
+		{"outside", time.Date(2026, 3, 2, 12, 0, 0, 0, loc), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CurrentWindowStart(windows, tt.now.UTC(), loc)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("CurrentWindowStart() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNextWindowStart(t *testing.T) {
+	windows, err := ParseDeliveryWindows("08:00-09:00,18:00-19:00")
+	if err != nil {
+		t.Fatalf(testErrUnexpected, err)
+	}
+
+	now := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+
+	next, ok := NextWindowStart(windows, now, time.UTC)
+	if want := time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC); !ok || !next.Equal(want) {
+		t.Errorf("NextWindowStart() = %v, %v; want %v", next, ok, want)
+	}
+}
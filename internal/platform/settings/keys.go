@@ -44,6 +44,8 @@ const (
 	DiscoveryDescriptionAllow = "discovery_description_allow"
 	// DiscoveryDescriptionDeny contains denied keywords in descriptions.
 	DiscoveryDescriptionDeny = "discovery_description_deny"
+	// DiscoveryAutoPolicy contains the JSON auto-approval policy for discoveries.
+	DiscoveryAutoPolicy = "discovery_auto_policy"
 )
 
 // LLM override settings
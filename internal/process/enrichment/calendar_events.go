@@ -0,0 +1,141 @@
+package enrichment
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const calendarKeyDateLayout = "2006-01-02"
+
+// calendarStopwords are dropped from event titles before matching repeated
+// announcements.
+var calendarStopwords = map[string]bool{
+	"the": true, "of": true, "in": true, "on": true, "at": true, "for": true, "and": true, "to": true,
+	"в": true, "на": true, "и": true, "по": true, "с": true, "для": true,
+}
+
+// saveCalendarEvents asks the LLM for the future events announced in the item
+// and stores them. Items are skipped when no LLM client is configured.
+func (w *Worker) saveCalendarEvents(ctx context.Context, item *db.EnrichmentQueueItem) {
+	if w.queryLLM == nil {
+		return
+	}
+
+	text := strings.TrimSpace(item.Text)
+	if text == "" {
+		text = strings.TrimSpace(item.Summary)
+	}
+
+	if text == "" {
+		return
+	}
+
+	loc, err := time.LoadLocation(w.cfg.CalendarTimezone)
+	if err != nil {
+		w.logger.Warn().Err(err).Str("timezone", w.cfg.CalendarTimezone).Msg("invalid calendar timezone, using UTC")
+
+		loc = time.UTC
+	}
+
+	published := item.TGDate
+	if published.IsZero() {
+		published = time.Now()
+	}
+
+	if w.cfg.EnrichmentLLMTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, w.cfg.EnrichmentLLMTimeout)
+		defer cancel()
+	}
+
+	model := w.queryLLMModel
+	if model == "" {
+		model = w.cfg.LLMModel
+	}
+
+	resp, err := w.queryLLM.CompleteText(ctx, llm.BuildCalendarEventsPrompt(text, published), model)
+	if err != nil {
+		w.logger.Debug().Err(err).Str(logKeyItemID, item.ItemID).Msg("calendar event extraction failed")
+
+		return
+	}
+
+	extracted, err := llm.ParseCalendarEvents(resp, published, loc)
+	if err != nil {
+		w.logger.Debug().Err(err).Str(logKeyItemID, item.ItemID).Msg("invalid calendar events response")
+
+		return
+	}
+
+	if err := w.db.SaveCalendarEvents(ctx, item.ItemID, calendarEvents(extracted, loc)); err != nil {
+		w.logger.Warn().Err(err).Str(logKeyItemID, item.ItemID).Msg("failed to save calendar events")
+	}
+}
+
+// calendarEvents converts extracted events to stored events with their dedup
+// keys, keeping the first of several events with the same key.
+func calendarEvents(extracted []llm.CalendarEvent, loc *time.Location) []db.CalendarEvent {
+	events := make([]db.CalendarEvent, 0, len(extracted))
+	seen := make(map[string]bool, len(extracted))
+
+	for _, e := range extracted {
+		key := calendarEventKey(e.Title, e.Start, loc)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		event := db.CalendarEvent{
+			DedupKey: key,
+			Title:    e.Title,
+			Kind:     e.Kind,
+			StartsAt: e.Start,
+			AllDay:   e.AllDay,
+			Location: e.Location,
+		}
+
+		if !e.End.IsZero() {
+			end := e.End
+			event.EndsAt = &end
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// calendarEventKey identifies an event by its start day and the set of
+// normalized title words, so announcements with reordered or inflected
+// titles match.
+func calendarEventKey(title string, start time.Time, loc *time.Location) string {
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	tokens := make([]string, 0, len(words))
+
+	for _, word := range words {
+		token := normalizeToken(word)
+		if token == "" || calendarStopwords[token] || seen[token] {
+			continue
+		}
+
+		seen[token] = true
+
+		tokens = append(tokens, token)
+	}
+
+	sort.Strings(tokens)
+
+	return start.In(loc).Format(calendarKeyDateLayout) + "|" + strings.Join(tokens, " ")
+}
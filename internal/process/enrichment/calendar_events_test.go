@@ -0,0 +1,51 @@
+package enrichment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+)
+
+func TestCalendarEventKey(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2026, 3, 5, 22, 0, 0, 0, time.UTC)
+
+	a := calendarEventKey("The EU Summit in Brussels", start, loc)
+	b := calendarEventKey("Brussels: EU summit", start.Add(time.Hour), loc)
+
+	if a != b {
+		t.Errorf("keys differ: %q vs %q", a, b)
+	}
+
+	if want := "2026-03-06|"; a[:len(want)] != want {
+		t.Errorf("key %q does not start with the local date %q", a, want)
+	}
+
+	if c := calendarEventKey("EU summit in Brussels", start.AddDate(0, 0, 1), loc); c == a {
+		t.Errorf("events on different days share key %q", c)
+	}
+}
+
+func TestCalendarEvents(t *testing.T) {
+	start := time.Date(2026, 3, 5, 13, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	events := calendarEvents([]llm.CalendarEvent{
+		{Title: "EU summit", Kind: llm.CalendarKindMeeting, Start: start, End: end},
+		{Title: "summit EU", Kind: llm.CalendarKindEvent, Start: start},
+		{Title: "Tax deadline", Kind: llm.CalendarKindDeadline, Start: start, AllDay: true},
+	}, time.UTC)
+
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want 2", events)
+	}
+
+	if events[0].EndsAt == nil || !events[0].EndsAt.Equal(end) || events[0].Kind != llm.CalendarKindMeeting {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+
+	if events[1].EndsAt != nil || !events[1].AllDay || events[1].DedupKey == "" {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+}
@@ -0,0 +1,50 @@
+package enrichment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// maxEntitiesPerItem caps how many entities are stored for one item.
+const maxEntitiesPerItem = 20
+
+// extractItemEntities returns the people and organizations named in text.
+// Other entity types are not useful for the watchlist and are dropped.
+func extractItemEntities(text string) []db.ItemEntity {
+	entities := make([]db.ItemEntity, 0, maxEntitiesPerItem)
+
+	for _, e := range extractEntities(htmlutils.StripHTMLTags(text)) {
+		if e.Type != entityTypePerson && e.Type != entityTypeOrg {
+			continue
+		}
+
+		entities = append(entities, db.ItemEntity{Text: e.Text, Type: e.Type})
+
+		if len(entities) == maxEntitiesPerItem {
+			break
+		}
+	}
+
+	return entities
+}
+
+// saveItemEntities extracts people and organizations from the item text (or
+// summary) and stores them for watchlist alerts.
+func (w *Worker) saveItemEntities(ctx context.Context, item *db.EnrichmentQueueItem) {
+	text := item.Text
+	if strings.TrimSpace(text) == "" {
+		text = item.Summary
+	}
+
+	entities := extractItemEntities(text)
+	if len(entities) == 0 {
+		return
+	}
+
+	if err := w.db.SaveItemEntities(ctx, item.ItemID, entities); err != nil {
+		w.logger.Warn().Err(err).Str(logKeyItemID, item.ItemID).Msg("failed to save entities")
+	}
+}
@@ -0,0 +1,34 @@
+package enrichment
+
+import (
+	"testing"
+)
+
+func TestExtractItemEntities(t *testing.T) {
+	got := extractItemEntities("<b>Olaf Scholz</b> met Acme Corp executives in Berlin on a $5 million deal, up 10%.")
+
+	types := map[string]bool{}
+	hasScholz := false
+
+	for _, e := range got {
+		types[e.Type] = true
+
+		if e.Text == "Olaf Scholz" && e.Type == entityTypePerson {
+			hasScholz = true
+		}
+	}
+
+	if !hasScholz {
+		t.Errorf("extractItemEntities() = %+v, want Olaf Scholz as a person", got)
+	}
+
+	if !types[entityTypeOrg] {
+		t.Errorf("extractItemEntities() = %+v, want an organization", got)
+	}
+
+	for _, typ := range []string{entityTypeLoc, entityTypeMoney, entityTypePercent} {
+		if types[typ] {
+			t.Errorf("extractItemEntities() kept %s entities: %+v", typ, got)
+		}
+	}
+}
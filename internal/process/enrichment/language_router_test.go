@@ -80,6 +80,10 @@ func (m *mockRouterRepo) UpdateItemFactCheckScore(_ context.Context, _ string, _
 	return nil
 }
 
+func (m *mockRouterRepo) RefreshItemEvidenceVerdict(_ context.Context, _ string) error {
+	return nil
+}
+
 func (m *mockRouterRepo) DeleteExpiredEvidenceSources(_ context.Context) (int64, error) {
 	return 0, nil
 }
@@ -137,6 +141,22 @@ func (m *mockRouterRepo) AddItemLinkLangQueries(_ context.Context, _ string, _ i
 	return nil
 }
 
+func (m *mockRouterRepo) SaveItemNumericFacts(_ context.Context, _ string, _ []db.NumericFact) error {
+	return nil
+}
+
+func (m *mockRouterRepo) SaveItemQuotes(_ context.Context, _ string, _ []db.ItemQuote) error {
+	return nil
+}
+
+func (m *mockRouterRepo) SaveCalendarEvents(_ context.Context, _ string, _ []db.CalendarEvent) error {
+	return nil
+}
+
+func (m *mockRouterRepo) SaveItemEntities(_ context.Context, _ string, _ []db.ItemEntity) error {
+	return nil
+}
+
 func (m *mockRouterRepo) RecoverStuckEnrichmentItems(_ context.Context, _ time.Duration) (int64, error) {
 	return 0, nil
 }
@@ -233,7 +253,7 @@ func TestLanguageRouter_GetTargetLanguages(t *testing.T) {
 	})
 }
 
-func TestWorker_ExpandQueriesWithRouting(t *testing.T) {
+func TestWorker_ExpandQueriesForLanguages(t *testing.T) {
 	policy := domain.LanguageRoutingPolicy{
 		Default: []string{"en", "el"},
 	}
@@ -266,7 +286,7 @@ func TestWorker_ExpandQueriesWithRouting(t *testing.T) {
 		trans.On(methodTranslate, ctx, testQueryRouter, "el").Return(translatedEl, nil).Once()
 		repo.On(methodSaveTranslation, ctx, testQueryRouter, "el", translatedEl, mock.Anything).Return(nil).Once()
 
-		res := w.expandQueriesWithRouting(ctx, item, queries)
+		res := w.expandQueriesForLanguages(ctx, queries, w.getTargetLanguages(ctx, item))
 
 		// Only translated queries are included (original "ru" doesn't match targets "en", "el")
 		assert.Len(t, res, 2)
@@ -282,7 +302,7 @@ func TestWorker_ExpandQueriesWithRouting(t *testing.T) {
 		repo.On(methodGetTranslation, ctx, testQueryRouter, "en").Return(cachedEn, nil).Once()
 		repo.On(methodGetTranslation, ctx, testQueryRouter, "el").Return(cachedEl, nil).Once()
 
-		res := w.expandQueriesWithRouting(ctx, item, queries)
+		res := w.expandQueriesForLanguages(ctx, queries, w.getTargetLanguages(ctx, item))
 
 		// Only translated queries are included
 		assert.Len(t, res, 2)
@@ -291,6 +311,26 @@ func TestWorker_ExpandQueriesWithRouting(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("Translate each query on its own", func(t *testing.T) {
+		w.languageRouter.policy.Default = []string{"en"}
+		mixed := []GeneratedQuery{
+			{Query: "already english", Language: "en", Strategy: "llm"},
+			{Query: testQueryRouter, Language: "ru", Strategy: "llm"},
+			{Query: "уже есть", Language: "ru", Strategy: "llm"},
+		}
+
+		repo.On(methodGetTranslation, ctx, testQueryRouter, "en").Return(cachedEn, nil).Once()
+		// A translation the LLM already wrote in English is not repeated.
+		repo.On(methodGetTranslation, ctx, "уже есть", "en").Return("Already English", nil).Once()
+
+		res := w.expandQueriesForLanguages(ctx, mixed, w.getTargetLanguages(ctx, item))
+
+		assert.Len(t, res, 2)
+		assert.Equal(t, "already english", res[0].Query)
+		assert.Equal(t, cachedEn, res[1].Query)
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("Respect query cap", func(t *testing.T) {
 		w.cfg.EnrichmentMaxQueriesPerItem = 2
 		w.languageRouter.policy.Default = []string{"en", "el", "es"}
@@ -299,7 +339,7 @@ func TestWorker_ExpandQueriesWithRouting(t *testing.T) {
 		repo.On(methodGetTranslation, ctx, testQueryRouter, "el").Return("el q", nil).Once()
 		// "es" should not be called because of cap (0 original + 2 translations = 2)
 
-		res := w.expandQueriesWithRouting(ctx, item, queries)
+		res := w.expandQueriesForLanguages(ctx, queries, w.getTargetLanguages(ctx, item))
 
 		assert.Len(t, res, 2) // 2 translated (cap reached)
 		repo.AssertExpectations(t)
@@ -0,0 +1,144 @@
+package enrichment
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	maxNumericFactsPerItem = 5
+	numericFactContextLen  = 200
+)
+
+const numberPattern = `\d+(?:[ \x{00A0},.]\d{3})*(?:[.,]\d+)?`
+
+var (
+	numericPercentRe = regexp.MustCompile(`(?i)[-+−]?` + numberPattern + `\s?(?:%|percent|per cent|процент\p{L}*)`)
+
+	numericMoneyRe = regexp.MustCompile(`(?i)(?:[$€£₽¥]\s?` + numberPattern + `(?:\s?(?:billion|million|thousand|bn|млрд|млн|тыс\.?))?` +
+		`|` + numberPattern + `\s?(?:(?:billion|million|thousand|bn|млрд|млн|тыс\.?)\s?)?` +
+		`(?:[$€£₽¥]|usd|eur|gbp|rub|dollars?|euros?|долл\p{L}*|евро|руб\p{L}*))`)
+
+	numericCountRe = regexp.MustCompile(`(?i)` + numberPattern + `\s+(?:\p{L}+\s+)?` +
+		`(?:killed|dead|deaths|injured|wounded|casualties|victims|people|погиб\p{L}*|ранен\p{L}*|пострадав\p{L}*|жертв\p{L}*|человек)`)
+
+	numericFactPatterns = []struct {
+		kind string
+		re   *regexp.Regexp
+	}{
+		// Money first so "$5 million" is not also read as a bare count.
+		{db.NumericFactMoney, numericMoneyRe},
+		{db.NumericFactPercent, numericPercentRe},
+		{db.NumericFactCount, numericCountRe},
+	}
+)
+
+type numericMatch struct {
+	start, end int
+	kind       string
+}
+
+// extractNumericFacts finds percentages, amounts of money and casualty or
+// people counts in text, each with the sentence it appears in.
+func extractNumericFacts(text string) []db.NumericFact {
+	facts := make([]db.NumericFact, 0, maxNumericFactsPerItem)
+	seen := make(map[string]bool)
+
+	for _, sentence := range splitLineSentences(htmlutils.StripHTMLTags(text)) {
+		for _, m := range findNumericMatches(sentence) {
+			value := strings.TrimSpace(sentence[m.start:m.end])
+			key := strings.ToLower(value)
+
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			facts = append(facts, db.NumericFact{
+				Value:   value,
+				Kind:    m.kind,
+				Context: truncateRunes(sentence, numericFactContextLen),
+			})
+
+			if len(facts) == maxNumericFactsPerItem {
+				return facts
+			}
+		}
+	}
+
+	return facts
+}
+
+// findNumericMatches returns non-overlapping matches in order of appearance;
+// earlier patterns win overlaps.
+func findNumericMatches(sentence string) []numericMatch {
+	var matches []numericMatch
+
+	for _, p := range numericFactPatterns {
+		for _, loc := range p.re.FindAllStringIndex(sentence, -1) {
+			if !overlapsAny(matches, loc[0], loc[1]) {
+				matches = append(matches, numericMatch{start: loc[0], end: loc[1], kind: p.kind})
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	return matches
+}
+
+func overlapsAny(matches []numericMatch, start, end int) bool {
+	for _, m := range matches {
+		if start < m.end && m.start < end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitLineSentences splits text into sentences, treating line breaks as
+// sentence boundaries as Telegram posts often omit final punctuation.
+func splitLineSentences(text string) []string {
+	var sentences []string
+
+	for _, line := range strings.Split(text, "\n") {
+		sentences = append(sentences, splitSentences(line)...)
+	}
+
+	return sentences
+}
+
+// truncateRunes shortens text to maxRunes without splitting a UTF-8 sequence.
+func truncateRunes(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+
+	return string(runes[:maxRunes]) + "…"
+}
+
+// saveNumericFacts extracts key figures from the item text (or summary) and
+// stores them for the "by the numbers" digest block.
+func (w *Worker) saveNumericFacts(ctx context.Context, item *db.EnrichmentQueueItem) {
+	text := item.Text
+	if strings.TrimSpace(text) == "" {
+		text = item.Summary
+	}
+
+	facts := extractNumericFacts(text)
+	if len(facts) == 0 {
+		return
+	}
+
+	if err := w.db.SaveItemNumericFacts(ctx, item.ItemID, facts); err != nil {
+		w.logger.Warn().Err(err).Str(logKeyItemID, item.ItemID).Msg("failed to save numeric facts")
+	}
+}
@@ -0,0 +1,62 @@
+package enrichment
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestExtractNumericFacts(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []db.NumericFact
+	}{
+		{
+			name: "percent and money",
+			text: "Inflation slowed to 3.2% in March. The deal is worth $5 million",
+			want: []db.NumericFact{
+				{Value: "3.2%", Kind: db.NumericFactPercent, Context: "Inflation slowed to 3.2% in March"},
+				{Value: "$5 million", Kind: db.NumericFactMoney, Context: "The deal is worth $5 million"},
+			},
+		},
+		{
+			name: "russian casualties and rubles",
+			text: "<b>12 человек</b> погибли.\nУщерб оценили в 3 млрд рублей",
+			want: []db.NumericFact{
+				{Value: "12 человек погибли", Kind: db.NumericFactCount, Context: "12 человек погибли."},
+				{Value: "3 млрд рублей", Kind: db.NumericFactMoney, Context: "Ущерб оценили в 3 млрд рублей"},
+			},
+		},
+		{
+			name: "duplicates and bare numbers are skipped",
+			text: "Shares rose 5%. Analysts expected 5% in 2026",
+			want: []db.NumericFact{
+				{Value: "5%", Kind: db.NumericFactPercent, Context: "Shares rose 5%"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractNumericFacts(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("extractNumericFacts() = %+v, want %+v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("fact %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExtractNumericFactsLimit(t *testing.T) {
+	text := "Up 1%. Up 2%. Up 3%. Up 4%. Up 5%. Up 6%. Up 7%"
+
+	if got := extractNumericFacts(text); len(got) != maxNumericFactsPerItem {
+		t.Errorf("got %d facts, want %d", len(got), maxNumericFactsPerItem)
+	}
+}
@@ -0,0 +1,97 @@
+package enrichment
+
+import (
+	"strings"
+	"unicode"
+)
+
+// nearDuplicateQueryThreshold is the token-set Jaccard similarity at or above which
+// two queries in the same language are treated as near-identical.
+const nearDuplicateQueryThreshold = 0.8
+
+// dedupeNearIdenticalQueries drops queries whose token set is nearly identical to an
+// earlier query in the same language. Earlier queries win, so callers should pass
+// queries in order of preference. Queries without usable tokens fall back to exact matching.
+func dedupeNearIdenticalQueries(queries []GeneratedQuery, threshold float64) []GeneratedQuery {
+	if len(queries) < 2 {
+		return queries
+	}
+
+	type keptQuery struct {
+		language string
+		tokens   map[string]struct{}
+		exact    string
+	}
+
+	kept := make([]keptQuery, 0, len(queries))
+	result := make([]GeneratedQuery, 0, len(queries))
+
+	for _, q := range queries {
+		candidate := keptQuery{
+			language: q.Language,
+			tokens:   queryTokenSet(q.Query),
+			exact:    strings.ToLower(strings.TrimSpace(q.Query)),
+		}
+
+		duplicate := false
+
+		for _, k := range kept {
+			if k.language != candidate.language {
+				continue
+			}
+
+			if k.exact == candidate.exact || tokenJaccard(k.tokens, candidate.tokens) >= threshold {
+				duplicate = true
+
+				break
+			}
+		}
+
+		if duplicate {
+			continue
+		}
+
+		kept = append(kept, candidate)
+		result = append(result, q)
+	}
+
+	return result
+}
+
+// queryTokenSet returns the lower-cased, stopword-free tokens of a query.
+func queryTokenSet(query string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make(map[string]struct{}, len(words))
+
+	for _, w := range words {
+		if isStopWord(w) {
+			continue
+		}
+
+		tokens[w] = struct{}{}
+	}
+
+	return tokens
+}
+
+// tokenJaccard returns the Jaccard similarity of two token sets (0 when either is empty).
+func tokenJaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	intersection := 0
+
+	for t := range a {
+		if _, ok := b[t]; ok {
+			intersection++
+		}
+	}
+
+	union := len(a) + len(b) - intersection
+
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,113 @@
+package enrichment
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestDedupeNearIdenticalQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries []GeneratedQuery
+		want    []string
+	}{
+		{
+			name:    "empty input",
+			queries: nil,
+			want:    nil,
+		},
+		{
+			name: "reordered tokens are duplicates",
+			queries: []GeneratedQuery{
+				{Query: "Zelensky Biden meeting Washington", Language: langEnglish},
+				{Query: "Washington meeting Biden Zelensky", Language: langEnglish},
+			},
+			want: []string{"Zelensky Biden meeting Washington"},
+		},
+		{
+			name: "stopwords and case are ignored",
+			queries: []GeneratedQuery{
+				{Query: "sanctions on Russian banks", Language: langEnglish},
+				{Query: "Sanctions the Russian banks", Language: langEnglish},
+			},
+			want: []string{"sanctions on Russian banks"},
+		},
+		{
+			name: "distinct queries are kept",
+			queries: []GeneratedQuery{
+				{Query: "ECB interest rate decision", Language: langEnglish},
+				{Query: "eurozone inflation forecast 2025", Language: langEnglish},
+			},
+			want: []string{"ECB interest rate decision", "eurozone inflation forecast 2025"},
+		},
+		{
+			name: "same text in different languages is kept",
+			queries: []GeneratedQuery{
+				{Query: "NATO summit Vilnius", Language: langEnglish},
+				{Query: "NATO summit Vilnius", Language: langGerman},
+			},
+			want: []string{"NATO summit Vilnius", "NATO summit Vilnius"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dedupeNearIdenticalQueries(tt.queries, nearDuplicateQueryThreshold)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("query count: got %d, want %d (%v)", len(got), len(tt.want), got)
+			}
+
+			for i, q := range got {
+				if q.Query != tt.want[i] {
+					t.Errorf("query %d: got %q, want %q", i, q.Query, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTokenJaccard(t *testing.T) {
+	a := queryTokenSet("alpha beta gamma")
+	b := queryTokenSet("alpha beta delta")
+
+	if got := tokenJaccard(a, b); got != 0.5 {
+		t.Errorf("tokenJaccard: got %v, want 0.5", got)
+	}
+
+	if got := tokenJaccard(a, map[string]struct{}{}); got != 0 {
+		t.Errorf("tokenJaccard with empty set: got %v, want 0", got)
+	}
+}
+
+func TestLLMQueryCap(t *testing.T) {
+	if got := llmQueryCap(nil); got != maxQueries {
+		t.Errorf("llmQueryCap(nil): got %d, want %d", got, maxQueries)
+	}
+
+	if got := llmQueryCap([]string{"en", "uk", "ru"}); got != 3*llmQueriesPerLanguage {
+		t.Errorf("llmQueryCap(3 langs): got %d, want %d", got, 3*llmQueriesPerLanguage)
+	}
+}
+
+func TestBuildLLMQueryPromptCount(t *testing.T) {
+	w := &Worker{}
+	item := &db.EnrichmentQueueItem{Summary: "Parliament approves the budget"}
+
+	tests := []struct {
+		languages []string
+		want      string
+	}{
+		{languages: nil, want: "2-4 distinct queries"},
+		{languages: []string{"en"}, want: "2-4 distinct queries"},
+		{languages: []string{"en", "uk", "ru"}, want: "3-6 distinct queries"},
+	}
+
+	for _, tt := range tests {
+		if prompt := w.buildLLMQueryPrompt(item, nil, tt.languages); !strings.Contains(prompt, tt.want) {
+			t.Errorf("buildLLMQueryPrompt(%v) should ask for %q, got:\n%s", tt.languages, tt.want, prompt)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package enrichment
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -93,17 +94,32 @@ func (e *QueryExpander) appendTranslatedQueries(ctx context.Context, result, que
 	return result
 }
 
+// hasQuery reports whether queries already hold text, ignoring case. It keeps
+// translations of LLM multilingual queries from repeating the query the LLM
+// already wrote in that language.
+func hasQuery(queries []GeneratedQuery, text string) bool {
+	for _, q := range queries {
+		if strings.EqualFold(q.Query, text) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (e *QueryExpander) translateQueriesForLanguage(ctx context.Context, result, queries []GeneratedQuery, targetLang string, maxQueries int) []GeneratedQuery {
 	for _, originalQ := range queries {
 		if len(result) >= maxQueries {
 			break
 		}
 
+		// Each query is decided on its own: one already in the target language
+		// does not stop the others from being translated.
 		if originalQ.Language == targetLang {
 			continue
 		}
 
-		if translated := e.tryTranslateQuery(ctx, originalQ, targetLang); translated != nil {
+		if translated := e.tryTranslateQuery(ctx, originalQ, targetLang); translated != nil && !hasQuery(result, translated.Query) {
 			result = append(result, *translated)
 		}
 	}
@@ -103,12 +103,14 @@ func (g *QueryGenerator) Generate(summary, text, topic, channelTitle string, lin
 
 	language := detectLanguage(cleaned)
 	entities, locations, keywords := g.extractComponents(cleaned, text, links)
+	dates := extractQueryDates(cleaned)
 
 	qb := newQueryBuilder(language)
 
 	g.addEntityQuery(qb, entities, keywords)
 	g.addLocationQuery(qb, entities, locations, keywords)
 	g.addTopicQuery(qb, topic, entities, keywords)
+	g.addDateQuery(qb, entities, dates, keywords)
 	g.addKeywordQuery(qb, keywords)
 	g.addFallbackQuery(qb, cleaned, channelTitle, keywords)
 
@@ -214,6 +216,12 @@ func (g *QueryGenerator) addTopicQuery(qb *queryBuilder, topic string, entities,
 	}
 }
 
+func (g *QueryGenerator) addDateQuery(qb *queryBuilder, entities, dates, keywords []string) {
+	if len(qb.queries) < maxQueries && len(entities) > 0 && len(dates) > 0 {
+		qb.add(buildDateQuery(entities[0], dates[0], keywords), "date")
+	}
+}
+
 func (g *QueryGenerator) addKeywordQuery(qb *queryBuilder, keywords []string) {
 	if len(qb.queries) < maxQueries && len(keywords) >= 2 {
 		qb.add(buildKeywordQuery(keywords), "keyword")
@@ -323,8 +331,34 @@ var (
 	qgAcronymPattern  = regexp.MustCompile(`\b[A-Z]{2,6}\b`)
 	qgQuotedPattern   = regexp.MustCompile(`"([^"]+)"`)
 	qgLocationPattern = regexp.MustCompile(`(?i)\b(United States|Russia|China|Ukraine|Germany|France|UK|USA|EU|Moscow|Washington|Beijing|London|Paris|Berlin|Kyiv|Kiev|Brussels|Tokyo|New York|California|Texas|Florida)\b`)
+	// Dates in ISO/numeric form, English month names, and Russian/Ukrainian genitive month names.
+	// Cyrillic alternatives avoid \b because Go word boundaries are ASCII-only.
+	qgDatePattern = regexp.MustCompile(`(?i)\b\d{4}-\d{2}-\d{2}\b|\b\d{1,2}[./]\d{1,2}[./]\d{2,4}\b|` +
+		`\b\d{1,2}\s+(?:January|February|March|April|May|June|July|August|September|October|November|December)(?:\s+\d{4})?\b|` +
+		`\b(?:January|February|March|April|May|June|July|August|September|October|November|December)\s+\d{1,2}(?:,?\s+\d{4})?\b|` +
+		`\d{1,2}\s+(?:января|февраля|марта|апреля|мая|июня|июля|августа|сентября|октября|ноября|декабря|` +
+		`січня|лютого|березня|квітня|травня|червня|липня|серпня|вересня|жовтня|листопада|грудня)(?:\s+\d{4})?`)
 )
 
+// extractQueryDates extracts explicit calendar dates from text in order of appearance.
+func extractQueryDates(text string) []string {
+	dates := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range qgDatePattern.FindAllString(text, -1) {
+		match = strings.TrimSpace(match)
+		lower := strings.ToLower(match)
+
+		if match != "" && !seen[lower] {
+			seen[lower] = true
+
+			dates = append(dates, match)
+		}
+	}
+
+	return dates
+}
+
 // extractQueryEntities extracts named entities from text.
 func extractQueryEntities(text string) []string {
 	entities := make([]string, 0)
@@ -488,6 +522,23 @@ func buildLocationQuery(entity, location string, keywords []string) string {
 	return TruncateQuery(query)
 }
 
+// buildDateQuery creates a query anchoring the primary entity to a specific date.
+func buildDateQuery(entity, date string, keywords []string) string {
+	parts := []string{entity, date}
+
+	for _, kw := range keywords {
+		if !strings.Contains(strings.ToLower(entity), kw) {
+			parts = append(parts, kw)
+
+			break
+		}
+	}
+
+	query := strings.Join(parts, " ")
+
+	return TruncateQuery(query)
+}
+
 // buildTopicQuery creates a query with topic context.
 func buildTopicQuery(topic string, entities, keywords []string) string {
 	parts := []string{topic}
@@ -496,3 +496,50 @@ func assertLanguageInQueries(t *testing.T, gen *QueryGenerator, summary, topic,
 		}
 	}
 }
+
+func TestExtractQueryDates(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "iso date", text: "The vote is scheduled for 2024-03-15 in Brussels", want: []string{"2024-03-15"}},
+		{name: "english month", text: "Talks resumed on 12 March 2024 and again on April 3", want: []string{"12 March 2024", "April 3"}},
+		{name: "russian month", text: "Встреча пройдет 5 июня 2024 года в Москве", want: []string{"5 июня 2024"}},
+		{name: "ukrainian month", text: "Засідання відбудеться 20 березня", want: []string{"20 березня"}},
+		{name: "no dates", text: "Parliament approved the budget", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractQueryDates(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("dates: got %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("date %d: got %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestQueryGenerator_DateQuery(t *testing.T) {
+	gen := NewQueryGenerator()
+
+	queries := gen.Generate("European Commission will present the sanctions package on 12 March 2024", "", "", "", nil)
+
+	found := false
+
+	for _, q := range queries {
+		if q.Strategy == "date" && strings.Contains(q.Query, "12 March 2024") {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("expected a date-anchored query, got %v", queries)
+	}
+}
@@ -0,0 +1,124 @@
+package enrichment
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	maxQuotesPerItem = 3
+
+	// quoteSpeakerMaxDistance is how far (in bytes) from the quote marks a
+	// speaker name may appear on the same line.
+	quoteSpeakerMaxDistance = 120
+)
+
+var (
+	// quotePattern matches «…», “…” and "…" spans of 20–300 characters.
+	quotePattern = regexp.MustCompile(`«([^«»\n]{20,300})»|“([^“”\n]{20,300})”|"([^"\n]{20,300})"`)
+
+	speechVerbPattern = regexp.MustCompile(`(?i)\b(?:said|says|told|stated|added|noted|warned|wrote|claimed|announced|according to)\b|` +
+		`(?:заявил\p{L}*|сказал\p{L}*|отметил\p{L}*|подчеркнул\p{L}*|добавил\p{L}*|написал\p{L}*|сообщил\p{L}*|` +
+		`признал\p{L}*|пообещал\p{L}*|по словам)`)
+)
+
+// extractQuotes finds direct quotes in text and attributes each to the person
+// named nearest to it on the same line. Quotes without a speaker are skipped,
+// as are lines without a speech verb, so quoted titles and names are not
+// mistaken for statements.
+func extractQuotes(text string) []db.ItemQuote {
+	quotes := make([]db.ItemQuote, 0, maxQuotesPerItem)
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(htmlutils.StripHTMLTags(text), "\n") {
+		for _, m := range quotePattern.FindAllStringSubmatchIndex(line, -1) {
+			quote := strings.TrimSpace(quoteGroup(line, m))
+			key := strings.ToLower(quote)
+
+			if quote == "" || seen[key] {
+				continue
+			}
+
+			speaker := findQuoteSpeaker(line, m[0], m[1])
+			if speaker == "" {
+				continue
+			}
+
+			seen[key] = true
+
+			quotes = append(quotes, db.ItemQuote{Speaker: speaker, Quote: quote})
+
+			if len(quotes) == maxQuotesPerItem {
+				return quotes
+			}
+		}
+	}
+
+	return quotes
+}
+
+// quoteGroup returns the text of whichever alternative of quotePattern matched.
+func quoteGroup(line string, m []int) string {
+	for g := 2; g+1 < len(m); g += 2 {
+		if m[g] >= 0 {
+			return line[m[g]:m[g+1]]
+		}
+	}
+
+	return ""
+}
+
+// findQuoteSpeaker returns the person name closest to the quote at
+// [start, end) on the line, or "" if none is close enough or the line has no
+// speech verb outside the quote.
+func findQuoteSpeaker(line string, start, end int) string {
+	if !speechVerbPattern.MatchString(line[:start] + " " + line[end:]) {
+		return ""
+	}
+
+	speaker := ""
+	bestDist := quoteSpeakerMaxDistance + 1
+
+	for _, loc := range personPattern.FindAllStringIndex(line, -1) {
+		var dist int
+
+		switch {
+		case loc[1] <= start:
+			dist = start - loc[1]
+		case loc[0] >= end:
+			dist = loc[0] - end
+		default:
+			// Names inside the quote are not its speaker.
+			continue
+		}
+
+		if dist < bestDist {
+			bestDist = dist
+			speaker = line[loc[0]:loc[1]]
+		}
+	}
+
+	return speaker
+}
+
+// saveQuotes extracts attributed quotes from the item text (or summary) and
+// stores them for the quotes digest block and research search.
+func (w *Worker) saveQuotes(ctx context.Context, item *db.EnrichmentQueueItem) {
+	text := item.Text
+	if strings.TrimSpace(text) == "" {
+		text = item.Summary
+	}
+
+	quotes := extractQuotes(text)
+	if len(quotes) == 0 {
+		return
+	}
+
+	if err := w.db.SaveItemQuotes(ctx, item.ItemID, quotes); err != nil {
+		w.logger.Warn().Err(err).Str(logKeyItemID, item.ItemID).Msg("failed to save quotes")
+	}
+}
@@ -0,0 +1,62 @@
+package enrichment
+
+import (
+	"testing"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestExtractQuotes(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []db.ItemQuote
+	}{
+		{
+			name: "speaker after quote",
+			text: `"We will not raise taxes this year," Olaf Scholz told reporters.`,
+			want: []db.ItemQuote{
+				{Speaker: "Olaf Scholz", Quote: "We will not raise taxes this year,"},
+			},
+		},
+		{
+			name: "russian speaker before quote",
+			text: "<b>Эльвира Набиуллина</b> заявила: «Инфляция замедляется быстрее прогноза».",
+			want: []db.ItemQuote{
+				{Speaker: "Эльвира Набиуллина", Quote: "Инфляция замедляется быстрее прогноза"},
+			},
+		},
+		{
+			name: "nearest speaker wins and names inside the quote are ignored",
+			text: `Janet Yellen met the press. Jerome Powell said "Janet Yellen and I agree on the outlook".`,
+			want: []db.ItemQuote{
+				{Speaker: "Jerome Powell", Quote: "Janet Yellen and I agree on the outlook"},
+			},
+		},
+		{
+			name: "no speech verb",
+			text: `Tickets for "The Grand Budapest Hotel Revival" sold out, Wes Anderson fans rejoiced.`,
+			want: []db.ItemQuote{},
+		},
+		{
+			name: "no speaker",
+			text: `Officials said "the bridge will reopen next week".`,
+			want: []db.ItemQuote{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractQuotes(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("extractQuotes() = %+v, want %+v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("quote %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+)
+
+const (
+	stanceSummaryLimit   = 600
+	stanceEvidenceLimit  = 2500
+	stanceMaxClaims      = 8
+	stanceMaxQuotes      = 3
+	stanceQuoteMaxLen    = 300
+	defaultStanceTimeout = 45 * time.Second
+)
+
+var (
+	errStanceEmptyInput      = errors.New("stance classification input is empty")
+	errInvalidStanceResponse = errors.New("invalid stance response")
+)
+
+// StanceResult is the stance of one evidence source towards an item's claims.
+type StanceResult struct {
+	Stance     string   `json:"stance"`
+	Confidence float32  `json:"confidence"`
+	Quotes     []string `json:"quotes"`
+}
+
+// QuotesJSON returns the supporting quotes encoded for storage, or nil when there are none.
+func (r StanceResult) QuotesJSON() []byte {
+	if len(r.Quotes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(r.Quotes)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// StanceClassifier asks the LLM whether an evidence source supports or refutes an item.
+type StanceClassifier struct {
+	client  llm.Client
+	model   string
+	timeout time.Duration
+}
+
+// NewStanceClassifier creates a stance classifier backed by the given LLM client.
+func NewStanceClassifier(client llm.Client, model string, timeout time.Duration) *StanceClassifier {
+	if timeout <= 0 {
+		timeout = defaultStanceTimeout
+	}
+
+	return &StanceClassifier{
+		client:  client,
+		model:   model,
+		timeout: timeout,
+	}
+}
+
+// Classify returns the stance of the evidence towards the item summary.
+func (c *StanceClassifier) Classify(ctx context.Context, summary string, evidence *ExtractedEvidence) (StanceResult, error) {
+	summary = strings.TrimSpace(summary)
+	evidenceText := buildStanceEvidenceText(evidence)
+
+	if summary == "" || evidenceText == "" {
+		return StanceResult{}, errStanceEmptyInput
+	}
+
+	llmCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.client.CompleteText(llmCtx, buildStancePrompt(summary, evidenceText), c.model)
+	if err != nil {
+		return StanceResult{}, fmt.Errorf("stance completion: %w", err)
+	}
+
+	return parseStanceResponse(res)
+}
+
+func buildStancePrompt(summary, evidenceText string) string {
+	return `You compare a news item with an external evidence source and decide the source's stance towards the item's claims.
+
+Return ONLY a valid JSON object, no other text:
+{"stance": "supports" | "refutes" | "neutral", "confidence": number between 0 and 1, "quotes": [up to 3 short verbatim quotes from the evidence that justify the stance]}
+
+Rules:
+- "supports": the evidence confirms the main claims of the item.
+- "refutes": the evidence contradicts or denies the main claims (different numbers, dates, outcomes, or explicit denial).
+- "neutral": the evidence is related but neither confirms nor contradicts the claims.
+- Quotes must be copied from the evidence text, in its original language. Use [] for neutral.
+
+Item:
+` + truncateText(summary, stanceSummaryLimit) + `
+
+Evidence:
+` + evidenceText
+}
+
+// buildStanceEvidenceText renders the evidence title, description and claims for the prompt.
+func buildStanceEvidenceText(evidence *ExtractedEvidence) string {
+	if evidence == nil || evidence.Source == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	for _, part := range []string{evidence.Source.Title, evidence.Source.Description} {
+		if part = strings.TrimSpace(part); part != "" {
+			sb.WriteString(part)
+			sb.WriteString("\n")
+		}
+	}
+
+	for i, claim := range evidence.Claims {
+		if i >= stanceMaxClaims {
+			break
+		}
+
+		if text := strings.TrimSpace(claim.Text); text != "" {
+			sb.WriteString("- ")
+			sb.WriteString(text)
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(evidence.Claims) == 0 {
+		sb.WriteString(strings.TrimSpace(evidence.Source.Content))
+	}
+
+	return truncateText(strings.TrimSpace(sb.String()), stanceEvidenceLimit)
+}
+
+// parseStanceResponse extracts a StanceResult from raw LLM output.
+func parseStanceResponse(raw string) (StanceResult, error) {
+	raw = stripMarkdownCodeBlocks(raw)
+
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+
+	if start == -1 || end <= start {
+		return StanceResult{}, fmt.Errorf("%w: no JSON object found", errInvalidStanceResponse)
+	}
+
+	// Confidence is decoded separately to tell a missing value from an explicit 0.
+	var decoded struct {
+		StanceResult
+		Confidence *float32 `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &decoded); err != nil {
+		return StanceResult{}, fmt.Errorf("unmarshal stance: %w", err)
+	}
+
+	result := decoded.StanceResult
+	result.Confidence = domain.StanceDefaultConfidence
+
+	if decoded.Confidence != nil {
+		result.Confidence = *decoded.Confidence
+	}
+
+	stance := normalizeStance(result.Stance)
+	if stance == "" {
+		return StanceResult{}, fmt.Errorf(fmtErrWrapStr, errInvalidStanceResponse, result.Stance)
+	}
+
+	result.Stance = stance
+	result.Confidence = clampUnit(result.Confidence)
+	result.Quotes = cleanStanceQuotes(result.Quotes)
+
+	return result, nil
+}
+
+// normalizeStance maps common LLM stance spellings to the canonical labels.
+func normalizeStance(stance string) string {
+	switch strings.ToLower(strings.TrimSpace(stance)) {
+	case "supports", "support", "supported", "agrees", "confirms":
+		return domain.StanceSupports
+	case "refutes", "refute", "refuted", "contradicts", "disputes":
+		return domain.StanceRefutes
+	case "neutral", "unrelated", "unclear":
+		return domain.StanceNeutral
+	default:
+		return ""
+	}
+}
+
+func cleanStanceQuotes(quotes []string) []string {
+	cleaned := make([]string, 0, len(quotes))
+
+	for _, q := range quotes {
+		q = strings.TrimSpace(q)
+		if q == "" {
+			continue
+		}
+
+		cleaned = append(cleaned, truncateText(q, stanceQuoteMaxLen))
+
+		if len(cleaned) >= stanceMaxQuotes {
+			break
+		}
+	}
+
+	return cleaned
+}
+
+func clampUnit(v float32) float32 {
+	if v < 0 {
+		return 0
+	}
+
+	if v > 1 {
+		return 1
+	}
+
+	return v
+}
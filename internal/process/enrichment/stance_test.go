@@ -0,0 +1,134 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestParseStanceResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		wantStance string
+		wantConf   float32
+		wantQuotes int
+		wantErr    bool
+	}{
+		{
+			name:       "plain json",
+			raw:        `{"stance": "supports", "confidence": 0.9, "quotes": ["The ministry confirmed the figures."]}`,
+			wantStance: domain.StanceSupports,
+			wantConf:   0.9,
+			wantQuotes: 1,
+		},
+		{
+			name:       "markdown fenced with synonym",
+			raw:        "```json\n{\"stance\": \"Contradicts\", \"confidence\": 0.7, \"quotes\": [\"Officials denied it\", \"  \"]}\n```",
+			wantStance: domain.StanceRefutes,
+			wantConf:   0.7,
+			wantQuotes: 1,
+		},
+		{
+			name:       "confidence is clamped and quotes are capped",
+			raw:        `Sure: {"stance": "neutral", "confidence": 1.4, "quotes": ["a", "b", "c", "d"]}`,
+			wantStance: domain.StanceNeutral,
+			wantConf:   1,
+			wantQuotes: stanceMaxQuotes,
+		},
+		{
+			name:       "missing confidence uses default",
+			raw:        `{"stance": "supports"}`,
+			wantStance: domain.StanceSupports,
+			wantConf:   domain.StanceDefaultConfidence,
+		},
+		{
+			name:       "explicit zero confidence is kept",
+			raw:        `{"stance": "refutes", "confidence": 0}`,
+			wantStance: domain.StanceRefutes,
+			wantConf:   0,
+		},
+		{
+			name:    "unknown stance",
+			raw:     `{"stance": "maybe", "confidence": 0.5}`,
+			wantErr: true,
+		},
+		{
+			name:    "no json",
+			raw:     "I cannot determine the stance.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStanceResponse(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf(unexpectedErrFmt, err)
+			}
+
+			if got.Stance != tt.wantStance {
+				t.Errorf("stance = %q, want %q", got.Stance, tt.wantStance)
+			}
+
+			if got.Confidence != tt.wantConf {
+				t.Errorf("confidence = %v, want %v", got.Confidence, tt.wantConf)
+			}
+
+			if len(got.Quotes) != tt.wantQuotes {
+				t.Errorf("quotes = %v, want %d", got.Quotes, tt.wantQuotes)
+			}
+		})
+	}
+}
+
+func TestStanceClassifier_EmptyInput(t *testing.T) {
+	classifier := NewStanceClassifier(nil, "", 0)
+
+	_, err := classifier.Classify(context.Background(), "summary", &ExtractedEvidence{Source: &db.EvidenceSource{}})
+	if !errors.Is(err, errStanceEmptyInput) {
+		t.Fatalf("expected errStanceEmptyInput, got %v", err)
+	}
+}
+
+func TestBuildStanceEvidenceText(t *testing.T) {
+	evidence := &ExtractedEvidence{
+		Source: &db.EvidenceSource{Title: "Title", Description: "Desc", Content: "Body"},
+		Claims: []ExtractedClaim{{Text: "Claim one"}, {Text: " "}},
+	}
+
+	got := buildStanceEvidenceText(evidence)
+	want := "Title\nDesc\n- Claim one"
+
+	if got != want {
+		t.Errorf("buildStanceEvidenceText() = %q, want %q", got, want)
+	}
+
+	evidence.Claims = nil
+
+	if got := buildStanceEvidenceText(evidence); got != "Title\nDesc\nBody" {
+		t.Errorf("buildStanceEvidenceText() without claims = %q", got)
+	}
+}
+
+func TestStanceResultQuotesJSON(t *testing.T) {
+	if got := (StanceResult{}).QuotesJSON(); got != nil {
+		t.Errorf("expected nil for no quotes, got %s", got)
+	}
+
+	got := StanceResult{Quotes: []string{"a"}}.QuotesJSON()
+	if string(got) != `["a"]` {
+		t.Errorf("QuotesJSON() = %s", got)
+	}
+}
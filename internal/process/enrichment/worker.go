@@ -64,6 +64,9 @@ const (
 	llmQuerySummaryLimit       = 400
 	llmQueryTextLimit          = 800
 	llmQueryLinksLimit         = 3
+	llmQueryEntityLimit        = 6
+	llmQueriesPerLanguage      = 2
+	minLLMQueries              = 2
 	// stuckProcessingThreshold is the duration after which a "processing" item
 	// is considered stuck and should be recovered. Set to 2x item timeout.
 	stuckProcessingThreshold = 2 * defaultItemTimeout
@@ -83,6 +86,7 @@ type Repository interface {
 	SaveEvidenceClaim(ctx context.Context, claim *db.EvidenceClaim) (string, error)
 	SaveItemEvidence(ctx context.Context, ie *db.ItemEvidence) error
 	UpdateItemFactCheckScore(ctx context.Context, itemID string, score float32, tier, notes string) error
+	RefreshItemEvidenceVerdict(ctx context.Context, itemID string) error
 	DeleteExpiredEvidenceSources(ctx context.Context) (int64, error)
 	CleanupExcessEvidencePerItem(ctx context.Context, maxPerItem int) (int64, error)
 	DeduplicateEvidenceClaims(ctx context.Context) (int64, error)
@@ -107,6 +111,10 @@ type Repository interface {
 	// Claims retrieval for cached sources
 	GetClaimsForSource(ctx context.Context, sourceID string) ([]db.EvidenceClaim, error)
 	AddItemLinkLangQueries(ctx context.Context, itemID string, count int) error
+	SaveItemNumericFacts(ctx context.Context, itemID string, facts []db.NumericFact) error
+	SaveItemQuotes(ctx context.Context, itemID string, quotes []db.ItemQuote) error
+	SaveCalendarEvents(ctx context.Context, itemID string, events []db.CalendarEvent) error
+	SaveItemEntities(ctx context.Context, itemID string, entities []db.ItemEntity) error
 }
 
 // EmbeddingClient provides embedding generation for semantic deduplication.
@@ -126,6 +134,7 @@ type Worker struct {
 	translationClient TranslationClient
 	queryLLM          llm.Client
 	queryLLMModel     string
+	stanceClassifier  *StanceClassifier
 	queryExpander     *QueryExpander
 	registry          *ProviderRegistry
 	extractor         *Extractor
@@ -199,6 +208,15 @@ func (w *Worker) EnableLLMQueryGeneration(client llm.Client, model string) {
 	w.queryLLMModel = model
 }
 
+// EnableStanceClassification enables LLM stance detection for matched evidence.
+func (w *Worker) EnableStanceClassification(client llm.Client, model string) {
+	if client == nil {
+		return
+	}
+
+	w.stanceClassifier = NewStanceClassifier(client, model, w.cfg.EnrichmentLLMTimeout)
+}
+
 // EnableLLMExtraction enables optional LLM claim extraction.
 func (w *Worker) EnableLLMExtraction(client llm.Client, model string) {
 	w.extractor.SetLLMClient(client, model)
@@ -330,6 +348,22 @@ func (w *Worker) processItem(ctx context.Context, item *db.EnrichmentQueueItem)
 	itemCtx, cancel := context.WithTimeout(ctx, w.getItemTimeout())
 	defer cancel()
 
+	if strings.Contains(w.cfg.EnrichmentExtractScope, domain.ScopeNumbers) {
+		w.saveNumericFacts(itemCtx, item)
+	}
+
+	if strings.Contains(w.cfg.EnrichmentExtractScope, domain.ScopeQuotes) {
+		w.saveQuotes(itemCtx, item)
+	}
+
+	if strings.Contains(w.cfg.EnrichmentExtractScope, domain.ScopeEvents) {
+		w.saveCalendarEvents(itemCtx, item)
+	}
+
+	if strings.Contains(w.cfg.EnrichmentExtractScope, domain.ScopeEntities) {
+		w.saveItemEntities(itemCtx, item)
+	}
+
 	if err := w.processWithProviders(itemCtx, item); err != nil {
 		w.handleError(ctx, item, err)
 		return
@@ -365,10 +399,18 @@ func (w *Worker) processWithProviders(ctx context.Context, item *db.EnrichmentQu
 	}
 
 	resolvedLinks = w.filterLinksForQueries(item, resolvedLinks)
-	queries := w.generateQueries(ctx, item, resolvedLinks)
 
-	// Route queries to target languages
-	queries = w.expandQueriesWithRouting(ctx, item, queries)
+	// Without query translation, queries stay in the item's original language
+	var targetLangs []string
+	if w.cfg.EnrichmentQueryTranslate {
+		targetLangs = w.getTargetLanguages(ctx, item)
+	}
+
+	queries := w.generateQueries(ctx, item, resolvedLinks, targetLangs)
+
+	// Route queries to target languages, then drop near-identical variants
+	queries = w.expandQueriesForLanguages(ctx, queries, targetLangs)
+	queries = dedupeNearIdenticalQueries(queries, nearDuplicateQueryThreshold)
 
 	w.logGeneratedQueries(item.ItemID, queries)
 
@@ -389,10 +431,10 @@ func (w *Worker) getMaxResults() int {
 	return w.cfg.EnrichmentMaxResults
 }
 
-func (w *Worker) generateQueries(ctx context.Context, item *db.EnrichmentQueueItem, links []domain.ResolvedLink) []GeneratedQuery {
+func (w *Worker) generateQueries(ctx context.Context, item *db.EnrichmentQueueItem, links []domain.ResolvedLink, targetLangs []string) []GeneratedQuery {
 	// Always try LLM query generation when LLM client is available
 	if w.queryLLM != nil {
-		if queries := w.generateQueriesWithLLM(ctx, item, links); len(queries) > 0 {
+		if queries := w.generateQueriesWithLLM(ctx, item, links, targetLangs); len(queries) > 0 {
 			return w.appendLinkLanguageQueries(ctx, queries, links, item.ItemID)
 		}
 	}
@@ -524,7 +566,7 @@ func (w *Worker) buildFallbackQuery(item *db.EnrichmentQueueItem) []GeneratedQue
 	return []GeneratedQuery{{Query: TruncateQuery(query), Strategy: "fallback", Language: lang}}
 }
 
-func (w *Worker) generateQueriesWithLLM(ctx context.Context, item *db.EnrichmentQueueItem, links []domain.ResolvedLink) []GeneratedQuery {
+func (w *Worker) generateQueriesWithLLM(ctx context.Context, item *db.EnrichmentQueueItem, links []domain.ResolvedLink, targetLangs []string) []GeneratedQuery {
 	if w.queryLLM == nil {
 		return nil
 	}
@@ -539,7 +581,7 @@ func (w *Worker) generateQueriesWithLLM(ctx context.Context, item *db.Enrichment
 		return nil
 	}
 
-	prompt := w.buildLLMQueryPrompt(item, links)
+	prompt := w.buildLLMQueryPrompt(item, links, targetLangs)
 	if prompt == "" {
 		return nil
 	}
@@ -565,10 +607,15 @@ func (w *Worker) generateQueriesWithLLM(ctx context.Context, item *db.Enrichment
 
 	fallbackLang := w.queryGenerator.DetectLanguage(source)
 
-	return buildLLMGeneratedQueries(rawQueries, fallbackLang)
+	return buildLLMGeneratedQueries(rawQueries, fallbackLang, llmQueryCap(targetLangs))
 }
 
-func (w *Worker) buildLLMQueryPrompt(item *db.EnrichmentQueueItem, links []domain.ResolvedLink) string {
+// llmQueryCap scales the LLM query cap so every target language gets at least two queries.
+func llmQueryCap(targetLangs []string) int {
+	return max(maxQueries, len(targetLangs)*llmQueriesPerLanguage)
+}
+
+func (w *Worker) buildLLMQueryPrompt(item *db.EnrichmentQueueItem, links []domain.ResolvedLink, languages []string) string {
 	summary := strings.TrimSpace(item.Summary)
 	text := strings.TrimSpace(item.Text)
 	topic := strings.TrimSpace(item.Topic)
@@ -581,9 +628,15 @@ func (w *Worker) buildLLMQueryPrompt(item *db.EnrichmentQueueItem, links []domai
 	var sb strings.Builder
 
 	sb.WriteString("Generate web search queries to corroborate the news item below.\n")
-	sb.WriteString("Return a JSON array of 2-4 distinct queries (3-8 words each).\n")
-	sb.WriteString("Use the original language of the item. No emojis, hashtags, or quotes.\n")
-	sb.WriteString("Include key people, organizations, locations, and specific terms; avoid generic words like \"news\" or \"report\".\n")
+	sb.WriteString(fmt.Sprintf("Return a JSON array of %d-%d distinct queries (3-8 words each).\n", max(minLLMQueries, len(languages)), llmQueryCap(languages)))
+
+	if len(languages) > 0 {
+		sb.WriteString(fmt.Sprintf("Write at least one query in each of these languages: %s. Translate names into the local spelling. No emojis, hashtags, or quotes.\n", strings.Join(languages, ", ")))
+	} else {
+		sb.WriteString("Use the original language of the item. No emojis, hashtags, or quotes.\n")
+	}
+
+	sb.WriteString("Include key people, organizations, locations, dates, and specific terms; avoid generic words like \"news\" or \"report\".\n")
 	sb.WriteString("Output JSON only (double quotes, no trailing commas).\n\n")
 
 	if summary != "" {
@@ -610,6 +663,8 @@ func (w *Worker) buildLLMQueryPrompt(item *db.EnrichmentQueueItem, links []domai
 		sb.WriteString("\n")
 	}
 
+	writeEntityHints(&sb, summary+". "+truncateText(text, llmQueryTextLimit))
+
 	linkHints := buildLinkHints(links, llmQueryLinksLimit, w.canonicalAllow, w.canonicalTrusted, w.canonicalDeny)
 	if linkHints != "" {
 		sb.WriteString("Links: ")
@@ -622,6 +677,32 @@ func (w *Worker) buildLLMQueryPrompt(item *db.EnrichmentQueueItem, links []domai
 	return sb.String()
 }
 
+// writeEntityHints appends extracted entities, locations and dates so the LLM anchors
+// queries on concrete identifiers instead of paraphrasing them away.
+func writeEntityHints(sb *strings.Builder, source string) {
+	cleaned := cleanText(source)
+	if cleaned == "" {
+		return
+	}
+
+	entities := uniqueStrings(append(extractQueryEntities(cleaned), extractLocations(cleaned)...))
+	if len(entities) > llmQueryEntityLimit {
+		entities = entities[:llmQueryEntityLimit]
+	}
+
+	if len(entities) > 0 {
+		sb.WriteString("Entities: ")
+		sb.WriteString(strings.Join(entities, "; "))
+		sb.WriteString("\n")
+	}
+
+	if dates := extractQueryDates(cleaned); len(dates) > 0 {
+		sb.WriteString("Dates: ")
+		sb.WriteString(strings.Join(dates, "; "))
+		sb.WriteString("\n")
+	}
+}
+
 func buildLinkHints(links []domain.ResolvedLink, limit int, allow, trusted, deny map[string]struct{}) string {
 	if len(links) == 0 || limit <= 0 {
 		return ""
@@ -721,12 +802,12 @@ func cleanQueryLine(line string) string {
 	return line
 }
 
-func buildLLMGeneratedQueries(raw []string, fallbackLang string) []GeneratedQuery {
+func buildLLMGeneratedQueries(raw []string, fallbackLang string, limit int) []GeneratedQuery {
 	seen := make(map[string]bool)
-	results := make([]GeneratedQuery, 0, maxQueries)
+	results := make([]GeneratedQuery, 0, limit)
 
 	for _, entry := range raw {
-		if len(results) >= maxQueries {
+		if len(results) >= limit {
 			break
 		}
 
@@ -846,14 +927,9 @@ func (w *Worker) filterLinksForQueries(_ *db.EnrichmentQueueItem, links []domain
 	return filtered
 }
 
-// expandQueriesWithRouting translates queries based on the routing policy.
-func (w *Worker) expandQueriesWithRouting(ctx context.Context, item *db.EnrichmentQueueItem, queries []GeneratedQuery) []GeneratedQuery {
-	if !w.cfg.EnrichmentQueryTranslate || w.queryExpander == nil {
-		return queries
-	}
-
-	targetLangs := w.getTargetLanguages(ctx, item)
-	if len(targetLangs) == 0 {
+// expandQueriesForLanguages translates queries into already-resolved target languages.
+func (w *Worker) expandQueriesForLanguages(ctx context.Context, queries []GeneratedQuery, targetLangs []string) []GeneratedQuery {
+	if !w.cfg.EnrichmentQueryTranslate || w.queryExpander == nil || len(targetLangs) == 0 {
 		return queries
 	}
 
@@ -1206,10 +1282,11 @@ var (
 
 func (w *Worker) processSearchResults(ctx context.Context, item *db.EnrichmentQueueItem, results []SearchResult, provider ProviderName) error {
 	params := w.buildResultProcessingParams(ctx, item, provider)
-	scores, sourceCount := w.processResultsConcurrently(ctx, results, params)
+	outcome := w.processResultsConcurrently(ctx, results, params)
 
-	if sourceCount > 0 {
-		w.updateItemScore(ctx, item.ItemID, scores, sourceCount)
+	if outcome.sourceCount > 0 {
+		w.updateItemScore(ctx, item.ItemID, outcome.scores, outcome.sourceCount)
+		w.updateItemVerdict(ctx, item.ItemID, outcome.stances)
 
 		return nil
 	}
@@ -1248,12 +1325,24 @@ func (w *Worker) buildResultProcessingParams(ctx context.Context, item *db.Enric
 	}
 }
 
-func (w *Worker) processResultsConcurrently(ctx context.Context, results []SearchResult, params resultProcessingParams) ([]float32, int) {
+// resultsOutcome aggregates the matches accepted for a single item.
+type resultsOutcome struct {
+	scores      []float32
+	stances     []domain.StanceVote
+	sourceCount int
+}
+
+// resultMatch is a single accepted evidence match.
+type resultMatch struct {
+	score  float32
+	stance *StanceResult
+}
+
+func (w *Worker) processResultsConcurrently(ctx context.Context, results []SearchResult, params resultProcessingParams) resultsOutcome {
 	var (
-		wg          sync.WaitGroup
-		mu          sync.Mutex
-		scores      []float32
-		sourceCount int
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		outcome resultsOutcome
 	)
 
 	sem := make(chan struct{}, defaultMaxConcurrentResults)
@@ -1274,7 +1363,7 @@ func (w *Worker) processResultsConcurrently(ctx context.Context, results []Searc
 			defer func() { <-sem }()
 			defer w.recoverPanic("processSingleResult")
 
-			score, ok := w.processSingleResult(ctx, params.item, res, params.provider, params.cacheTTL, params.minAgreement, params.targetLangs)
+			match, ok := w.processSingleResult(ctx, params.item, res, params.provider, params.cacheTTL, params.minAgreement, params.targetLangs)
 			if !ok {
 				return
 			}
@@ -1282,21 +1371,25 @@ func (w *Worker) processResultsConcurrently(ctx context.Context, results []Searc
 			mu.Lock()
 			defer mu.Unlock()
 
-			if sourceCount >= params.maxEvidence {
+			if outcome.sourceCount >= params.maxEvidence {
 				return
 			}
 
-			scores = append(scores, score)
-			sourceCount++
+			outcome.scores = append(outcome.scores, match.score)
+			outcome.sourceCount++
+
+			if match.stance != nil {
+				outcome.stances = append(outcome.stances, domain.StanceVote{Stance: match.stance.Stance, Confidence: match.stance.Confidence})
+			}
 
 			observability.EnrichmentMatches.Inc()
-			observability.EnrichmentCorroborationScore.Observe(float64(score))
+			observability.EnrichmentCorroborationScore.Observe(float64(match.score))
 		}(result)
 	}
 
 	wg.Wait()
 
-	return scores, sourceCount
+	return outcome
 }
 
 func (w *Worker) acquireSemaphore(ctx context.Context, sem chan struct{}) bool {
@@ -1347,6 +1440,39 @@ func (w *Worker) updateItemScore(ctx context.Context, itemID string, scores []fl
 	}
 }
 
+// updateItemVerdict recomputes the item verdict from all of its stored
+// evidence stances, so a batch with few stances does not override earlier
+// ones. It is a no-op when stance classification is disabled or the batch
+// produced no stances.
+func (w *Worker) updateItemVerdict(ctx context.Context, itemID string, stances []domain.StanceVote) {
+	if w.stanceClassifier == nil || len(stances) == 0 {
+		return
+	}
+
+	dbCtx, dbCancel := w.createDBContext(ctx)
+	defer dbCancel()
+
+	if err := w.db.RefreshItemEvidenceVerdict(dbCtx, itemID); err != nil {
+		w.logger.Warn().Err(err).Str(logKeyItemID, itemID).Msg("failed to update item evidence verdict")
+	}
+}
+
+// classifyStance runs optional stance detection for an accepted evidence match.
+func (w *Worker) classifyStance(ctx context.Context, item *db.EnrichmentQueueItem, evidence *ExtractedEvidence) *StanceResult {
+	if w.stanceClassifier == nil {
+		return nil
+	}
+
+	stance, err := w.stanceClassifier.Classify(ctx, item.Summary, evidence)
+	if err != nil {
+		w.logger.Debug().Err(err).Str(logKeyItemID, item.ItemID).Str(logKeyURL, evidence.Source.URL).Msg("stance classification failed")
+
+		return nil
+	}
+
+	return &stance
+}
+
 func (w *Worker) processSingleResult(
 	ctx context.Context,
 	item *db.EnrichmentQueueItem,
@@ -1355,16 +1481,16 @@ func (w *Worker) processSingleResult(
 	cacheTTL time.Duration,
 	minAgreement float32,
 	targetLangs []string,
-) (float32, bool) {
+) (resultMatch, bool) {
 	evidence, err := w.processEvidenceSource(ctx, result, provider, cacheTTL)
 	if err != nil {
 		w.logger.Warn().Err(err).Str(logKeyURL, result.URL).Msg("failed to process evidence source")
 
-		return 0, false
+		return resultMatch{}, false
 	}
 
 	if evidence.Source.ExtractionFailed {
-		return 0, false
+		return resultMatch{}, false
 	}
 
 	// Get summary for scoring - translate if language mismatch
@@ -1374,22 +1500,24 @@ func (w *Worker) processSingleResult(
 	claimLang := linkscore.DetectLanguage(scoringResult.BestClaim)
 
 	if w.shouldSkipForLanguageMismatch(result, evidence, claimLang, targetLangs) {
-		return 0, false
+		return resultMatch{}, false
 	}
 
 	w.logScoringResult(item, result, evidence, scoringResult, minAgreement, claimLang)
 
 	if scoringResult.AgreementScore < minAgreement {
-		return 0, false
+		return resultMatch{}, false
 	}
 
-	if err := w.saveItemEvidence(ctx, item.ItemID, evidence, scoringResult); err != nil {
+	stance := w.classifyStance(ctx, item, evidence)
+
+	if err := w.saveItemEvidence(ctx, item.ItemID, evidence, scoringResult, stance); err != nil {
 		w.logger.Warn().Err(err).Msg("failed to save item evidence")
 
-		return 0, false
+		return resultMatch{}, false
 	}
 
-	return scoringResult.AgreementScore, true
+	return resultMatch{score: scoringResult.AgreementScore, stance: stance}, true
 }
 
 func (w *Worker) shouldSkipForLanguageMismatch(result SearchResult, evidence *ExtractedEvidence, claimLang string, targetLangs []string) bool {
@@ -1831,7 +1959,7 @@ func truncateText(text string, maxLen int) string {
 	return text[:maxLen] + "..."
 }
 
-func (w *Worker) saveItemEvidence(ctx context.Context, itemID string, evidence *ExtractedEvidence, scoringResult ScoringResult) error {
+func (w *Worker) saveItemEvidence(ctx context.Context, itemID string, evidence *ExtractedEvidence, scoringResult ScoringResult, stance *StanceResult) error {
 	ie := &db.ItemEvidence{
 		ItemID:            itemID,
 		EvidenceID:        evidence.Source.ID,
@@ -1841,6 +1969,12 @@ func (w *Worker) saveItemEvidence(ctx context.Context, itemID string, evidence *
 		MatchedAt:         time.Now(),
 	}
 
+	if stance != nil {
+		ie.Stance = stance.Stance
+		ie.StanceConfidence = stance.Confidence
+		ie.StanceQuotesJSON = stance.QuotesJSON()
+	}
+
 	// Use independent DB context to avoid timeout when item context is near expiry
 	dbCtx, dbCancel := w.createDBContext(ctx)
 	defer dbCancel()
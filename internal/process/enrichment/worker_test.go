@@ -135,6 +135,10 @@ func (m *mockRepository) UpdateItemFactCheckScore(_ context.Context, _ string, _
 	return nil
 }
 
+func (m *mockRepository) RefreshItemEvidenceVerdict(_ context.Context, _ string) error {
+	return nil
+}
+
 func (m *mockRepository) DeleteExpiredEvidenceSources(_ context.Context) (int64, error) {
 	return 0, nil
 }
@@ -212,6 +216,22 @@ func (m *mockRepository) AddItemLinkLangQueries(_ context.Context, _ string, _ i
 	return nil
 }
 
+func (m *mockRepository) SaveItemNumericFacts(_ context.Context, _ string, _ []db.NumericFact) error {
+	return nil
+}
+
+func (m *mockRepository) SaveItemQuotes(_ context.Context, _ string, _ []db.ItemQuote) error {
+	return nil
+}
+
+func (m *mockRepository) SaveCalendarEvents(_ context.Context, _ string, _ []db.CalendarEvent) error {
+	return nil
+}
+
+func (m *mockRepository) SaveItemEntities(_ context.Context, _ string, _ []db.ItemEntity) error {
+	return nil
+}
+
 func TestWorker_generateClaimEmbedding(t *testing.T) {
 	logger := zerolog.Nop()
 
@@ -15,6 +15,7 @@ import (
 
 	"golang.org/x/text/cases"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
 	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
@@ -23,14 +24,14 @@ const (
 	filterModeMixed     = "mixed"
 	filterModeDenylist  = "denylist"
 
-	ReasonMinLength    = "filter_min_length"
-	ReasonEmojiOnly    = "filter_emoji_only"
-	ReasonBoilerplate  = "filter_boilerplate"
-	ReasonForwardShell = "filter_forward_shell"
-	ReasonAds          = "filter_ads"
-	ReasonAdsComments  = "filter_ads_comments_disabled"
-	ReasonDeny         = "filter_deny"
-	ReasonAllowMiss    = "filter_allow_miss"
+	ReasonMinLength    = domain.DropReasonMinLength
+	ReasonEmojiOnly    = domain.DropReasonEmojiOnly
+	ReasonBoilerplate  = domain.DropReasonBoilerplate
+	ReasonForwardShell = domain.DropReasonForwardShell
+	ReasonAds          = domain.DropReasonAds
+	ReasonAdsComments  = domain.DropReasonAdsComments
+	ReasonDeny         = domain.DropReasonDeny
+	ReasonAllowMiss    = domain.DropReasonAllowMiss
 )
 
 // Filterer applies content filters to determine if messages should be excluded.
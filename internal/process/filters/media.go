@@ -0,0 +1,205 @@
+package filters
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+
+	// Register the formats channel media arrives in.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+// Media classes assigned to image-only posts by ClassifyMedia.
+const (
+	MediaClassSticker     = "sticker"
+	MediaClassMeme        = "meme"
+	MediaClassScreenshot  = "screenshot"
+	MediaClassPhotoOfText = "photo_of_text"
+	MediaClassNewsPhoto   = "news_photo"
+)
+
+// Drop reasons for low-value media classes.
+const (
+	ReasonMediaSticker = domain.DropReasonMediaSticker
+	ReasonMediaMeme    = domain.DropReasonMediaMeme
+)
+
+const (
+	mimeAnimatedSticker = "application/x-tgsticker"
+	formatWebP          = "webp"
+	formatPNG           = "png"
+	stickerSide         = 512
+
+	// Images are sampled on a grid instead of reading every pixel.
+	mediaSampleGrid = 48
+	// A banner meme has a near-white caption band, the top sampled rows,
+	// over a colorful picture.
+	memeBandRows      = 9
+	memeBandWhite     = 0.8
+	memeBodyColorful  = 0.3
+	screenshotAspect  = 1.9
+	textGrayShare     = 0.9
+	textContrastShare = 0.7
+	whiteLevel        = 0xe0
+	darkLevel         = 0x40
+	grayTolerance     = 0x18
+	// channelScale converts 8-bit levels to the 16-bit channels of color.Color.
+	channelScale = 0x101
+)
+
+// IsLowValueMediaClass reports whether posts of class are skipped or
+// downweighted before summarization.
+func IsLowValueMediaClass(class string) bool {
+	return class == MediaClassSticker || class == MediaClassMeme
+}
+
+// MediaDropReason returns the drop reason of a low-value media class.
+func MediaDropReason(class string) string {
+	if class == MediaClassSticker {
+		return ReasonMediaSticker
+	}
+
+	return ReasonMediaMeme
+}
+
+// ClassifyMedia labels the picture of an image-only post from its Telegram
+// media metadata and image data, without calling a model. It returns an
+// empty class when the post carries no classifiable image.
+func ClassifyMedia(mediaJSON, mediaData []byte) string {
+	if isStickerMedia(mediaJSON) {
+		return MediaClassSticker
+	}
+
+	if len(mediaData) == 0 {
+		return ""
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(mediaData))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return ""
+	}
+
+	// Static stickers forwarded as files are 512px WebP images
+	if format == formatWebP && max(cfg.Width, cfg.Height) == stickerSide {
+		return MediaClassSticker
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(mediaData))
+	if err != nil {
+		return ""
+	}
+
+	stats := sampleImage(img)
+
+	switch {
+	case stats.bandWhite >= memeBandWhite && stats.bodyColorful >= memeBodyColorful:
+		return MediaClassMeme
+	case format == formatPNG || float64(cfg.Height)/float64(cfg.Width) >= screenshotAspect:
+		return MediaClassScreenshot
+	case stats.gray >= textGrayShare && stats.contrast >= textContrastShare:
+		return MediaClassPhotoOfText
+	default:
+		return MediaClassNewsPhoto
+	}
+}
+
+// isStickerMedia reports whether the media metadata describes a sticker.
+func isStickerMedia(mediaJSON []byte) bool {
+	if len(mediaJSON) == 0 {
+		return false
+	}
+
+	var media struct {
+		Document struct {
+			MimeType   string                       `json:"MimeType"`
+			Attributes []map[string]json.RawMessage `json:"Attributes"`
+		} `json:"Document"`
+	}
+
+	if err := json.Unmarshal(mediaJSON, &media); err != nil {
+		return false
+	}
+
+	if media.Document.MimeType == mimeAnimatedSticker {
+		return true
+	}
+
+	for _, attr := range media.Document.Attributes {
+		if _, ok := attr["Stickerset"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// imageStats are shares of sampled pixels.
+type imageStats struct {
+	// gray is the share of pixels without noticeable color.
+	gray float64
+	// contrast is the share of near-white or near-black pixels.
+	contrast float64
+	// bandWhite is the share of near-white pixels in the top band.
+	bandWhite float64
+	// bodyColorful is the share of colored pixels below the top band.
+	bodyColorful float64
+}
+
+func sampleImage(img image.Image) imageStats {
+	bounds := img.Bounds()
+	var gray, contrast, bandWhite, bodyColorful, bodyTotal int
+
+	for row := range mediaSampleGrid {
+		y := bounds.Min.Y + (2*row+1)*bounds.Dy()/(2*mediaSampleGrid)
+
+		for col := range mediaSampleGrid {
+			x := bounds.Min.X + (2*col+1)*bounds.Dx()/(2*mediaSampleGrid)
+			r, g, b, _ := img.At(x, y).RGBA()
+			isGray := isGrayPixel(r, g, b)
+			isWhite := isGray && r >= whiteLevel*channelScale
+			isDark := isGray && r <= darkLevel*channelScale
+
+			if isGray {
+				gray++
+			}
+
+			if isWhite || isDark {
+				contrast++
+			}
+
+			if row < memeBandRows {
+				if isWhite {
+					bandWhite++
+				}
+
+				continue
+			}
+
+			bodyTotal++
+
+			if !isGray {
+				bodyColorful++
+			}
+		}
+	}
+
+	total := float64(mediaSampleGrid * mediaSampleGrid)
+
+	return imageStats{
+		gray:         float64(gray) / total,
+		contrast:     float64(contrast) / total,
+		bandWhite:    float64(bandWhite) / float64(memeBandRows*mediaSampleGrid),
+		bodyColorful: float64(bodyColorful) / float64(max(1, bodyTotal)),
+	}
+}
+
+// isGrayPixel reports whether 16-bit channel values carry no noticeable color.
+func isGrayPixel(r, g, b uint32) bool {
+	return max(r, g, b)-min(r, g, b) <= grayTolerance*channelScale
+}
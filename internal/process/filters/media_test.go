@@ -0,0 +1,102 @@
+package filters
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+type paintFunc func(x, y, w, h int) color.Color
+
+func encodeTestImage(t *testing.T, w, h int, asPNG bool, paint paintFunc) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, paint(x, y, w, h))
+		}
+	}
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+
+	if asPNG {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95})
+	}
+
+	if err != nil {
+		t.Fatalf("encode test image: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func colorfulPaint(x, y, w, h int) color.Color {
+	return color.RGBA{R: uint8(x * 255 / w), G: uint8(y * 255 / h), B: 0x80, A: 0xff}
+}
+
+func TestClassifyMedia(t *testing.T) {
+	stickerJSON := []byte(`{"Document":{"MimeType":"image/webp","Attributes":[{"Alt":"😀","Stickerset":{}},{"W":512,"H":512}]}}`)
+	animatedJSON := []byte(`{"Document":{"MimeType":"application/x-tgsticker","Attributes":[]}}`)
+	photoJSON := []byte(`{"Photo":{"ID":1}}`)
+
+	meme := func(x, y, w, h int) color.Color {
+		if y < h/4 {
+			return color.White
+		}
+
+		return colorfulPaint(x, y, w, h)
+	}
+
+	text := func(x, y, _, _ int) color.Color {
+		if (y/8)%2 == 0 && x%5 != 0 {
+			return color.White
+		}
+
+		return color.Black
+	}
+
+	tests := []struct {
+		name      string
+		mediaJSON []byte
+		mediaData []byte
+		want      string
+	}{
+		{name: "sticker attribute", mediaJSON: stickerJSON, want: MediaClassSticker},
+		{name: "animated sticker", mediaJSON: animatedJSON, want: MediaClassSticker},
+		{name: "no image", mediaJSON: photoJSON, want: ""},
+		{name: "undecodable image", mediaData: []byte("not an image"), want: ""},
+		{name: "banner meme", mediaData: encodeTestImage(t, 400, 400, false, meme), want: MediaClassMeme},
+		{name: "png screenshot", mediaData: encodeTestImage(t, 300, 200, true, colorfulPaint), want: MediaClassScreenshot},
+		{name: "phone screenshot", mediaData: encodeTestImage(t, 200, 420, false, colorfulPaint), want: MediaClassScreenshot},
+		{name: "photo of text", mediaData: encodeTestImage(t, 400, 300, false, text), want: MediaClassPhotoOfText},
+		{name: "news photo", mediaJSON: photoJSON, mediaData: encodeTestImage(t, 400, 300, false, colorfulPaint), want: MediaClassNewsPhoto},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyMedia(tt.mediaJSON, tt.mediaData); got != tt.want {
+				t.Errorf("ClassifyMedia() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMediaDropReason(t *testing.T) {
+	if !IsLowValueMediaClass(MediaClassSticker) || !IsLowValueMediaClass(MediaClassMeme) || IsLowValueMediaClass(MediaClassNewsPhoto) {
+		t.Error("only stickers and memes should be low-value media")
+	}
+
+	if MediaDropReason(MediaClassSticker) != ReasonMediaSticker || MediaDropReason(MediaClassMeme) != ReasonMediaMeme {
+		t.Error("MediaDropReason() should map classes to their drop reasons")
+	}
+}
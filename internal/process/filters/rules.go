@@ -0,0 +1,351 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+// Pre-filter rule actions.
+const (
+	RuleActionAllow = "allow"
+	RuleActionDeny  = "deny"
+	RuleActionBoost = "boost"
+
+	// ReasonRuleDeny is the drop reason for messages denied by a pre-filter rule.
+	ReasonRuleDeny = domain.DropReasonRuleDeny
+
+	ruleKeywordIf  = "if"
+	ruleKeywordAnd = "and"
+	ruleKeywordNot = "not"
+	maxRuleBoost   = 1
+)
+
+var (
+	// ErrInvalidRule is returned for rules that do not follow the DSL.
+	ErrInvalidRule = errors.New("invalid rule")
+
+	ruleFolder = cases.Fold()
+)
+
+// RuleInput is the message data pre-filter rules are evaluated on.
+type RuleInput struct {
+	Text            string
+	ChannelUsername string
+	ChannelPeerID   int64
+	IsForward       bool
+}
+
+// Rule is a parsed pre-filter rule:
+//
+//	<allow|deny|boost <delta>> if <condition> [and <condition>]...
+//
+// Conditions, each optionally prefixed with "not":
+//
+//	regex "<pattern>"        case-insensitive regular expression on the text
+//	keyword "<word>"         case-insensitive substring of the text
+//	length <op> <n>          text length in characters; op is <, <=, >, >= or =
+//	channel <@username|id>   source channel username or peer ID
+//	forward                  the message is a forward
+type Rule struct {
+	Source string
+	Action string
+	// Boost is added to the importance score of matching messages.
+	Boost      float32
+	conditions []ruleCondition
+}
+
+type ruleCondition struct {
+	negate bool
+	match  func(RuleInput) bool
+}
+
+// RuleVerdict is the outcome of evaluating rules on a message.
+type RuleVerdict struct {
+	// Action is allow or deny when such a rule matched, empty otherwise.
+	Action string
+	// Rule is the source of the rule that decided Action.
+	Rule string
+	// Boost is the sum of the boosts of all matching boost rules.
+	Boost float32
+	// Hits lists the sources of all rules that took effect.
+	Hits []string
+}
+
+// ParseRule parses a rule in the pre-filter DSL.
+func ParseRule(src string) (Rule, error) {
+	tokens, err := tokenizeRule(src)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	rule := Rule{Source: strings.TrimSpace(src)}
+
+	rest, err := parseRuleAction(&rule, tokens)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if len(rest) == 0 || !strings.EqualFold(rest[0], ruleKeywordIf) {
+		return Rule{}, fmt.Errorf("%w: expected \"if\" after %s", ErrInvalidRule, rule.Action)
+	}
+
+	rule.conditions, err = parseRuleConditions(rest[1:])
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+func parseRuleAction(rule *Rule, tokens []string) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule.Action = strings.ToLower(tokens[0])
+
+	switch rule.Action {
+	case RuleActionAllow, RuleActionDeny:
+		return tokens[1:], nil
+	case RuleActionBoost:
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("%w: boost needs a value", ErrInvalidRule)
+		}
+
+		boost, err := strconv.ParseFloat(tokens[1], 32)
+		if err != nil || boost == 0 || boost < -maxRuleBoost || boost > maxRuleBoost {
+			return nil, fmt.Errorf("%w: boost must be between -1 and 1, got %s", ErrInvalidRule, tokens[1])
+		}
+
+		rule.Boost = float32(boost)
+
+		return tokens[2:], nil
+	default:
+		return nil, fmt.Errorf("%w: unknown action %s", ErrInvalidRule, tokens[0])
+	}
+}
+
+func parseRuleConditions(tokens []string) ([]ruleCondition, error) {
+	var conditions []ruleCondition
+
+	for {
+		cond, rest, err := parseRuleCondition(tokens)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, cond)
+
+		if len(rest) == 0 {
+			return conditions, nil
+		}
+
+		if !strings.EqualFold(rest[0], ruleKeywordAnd) {
+			return nil, fmt.Errorf("%w: expected \"and\", got %s", ErrInvalidRule, rest[0])
+		}
+
+		tokens = rest[1:]
+	}
+}
+
+func parseRuleCondition(tokens []string) (ruleCondition, []string, error) {
+	var cond ruleCondition
+
+	if len(tokens) > 0 && strings.EqualFold(tokens[0], ruleKeywordNot) {
+		cond.negate = true
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) == 0 {
+		return cond, nil, fmt.Errorf("%w: missing condition", ErrInvalidRule)
+	}
+
+	parse, ok := ruleConditionParsers[strings.ToLower(tokens[0])]
+	if !ok {
+		return cond, nil, fmt.Errorf("%w: unknown condition %s", ErrInvalidRule, tokens[0])
+	}
+
+	match, rest, err := parse(tokens[1:])
+	if err != nil {
+		return cond, nil, err
+	}
+
+	cond.match = match
+
+	return cond, rest, nil
+}
+
+// ruleConditionParsers parse a condition's operands and return the matcher and
+// the remaining tokens.
+var ruleConditionParsers = map[string]func([]string) (func(RuleInput) bool, []string, error){
+	"regex":   parseRegexCondition,
+	"keyword": parseKeywordCondition,
+	"length":  parseLengthCondition,
+	"channel": parseChannelCondition,
+	"forward": func(tokens []string) (func(RuleInput) bool, []string, error) {
+		return func(in RuleInput) bool { return in.IsForward }, tokens, nil
+	},
+}
+
+func parseRegexCondition(tokens []string) (func(RuleInput) bool, []string, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("%w: regex needs a pattern", ErrInvalidRule)
+	}
+
+	re, err := regexp.Compile("(?i)" + tokens[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidRule, err.Error())
+	}
+
+	return func(in RuleInput) bool { return re.MatchString(in.Text) }, tokens[1:], nil
+}
+
+func parseKeywordCondition(tokens []string) (func(RuleInput) bool, []string, error) {
+	if len(tokens) == 0 || tokens[0] == "" {
+		return nil, nil, fmt.Errorf("%w: keyword needs a word", ErrInvalidRule)
+	}
+
+	keyword := ruleFolder.String(tokens[0])
+
+	return func(in RuleInput) bool { return strings.Contains(ruleFolder.String(in.Text), keyword) }, tokens[1:], nil
+}
+
+func parseLengthCondition(tokens []string) (func(RuleInput) bool, []string, error) {
+	if len(tokens) < 2 {
+		return nil, nil, fmt.Errorf("%w: length needs an operator and a number", ErrInvalidRule)
+	}
+
+	n, err := strconv.Atoi(tokens[1])
+	if err != nil || n < 0 {
+		return nil, nil, fmt.Errorf("%w: invalid length %s", ErrInvalidRule, tokens[1])
+	}
+
+	compare, ok := lengthComparisons[tokens[0]]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: unknown operator %s", ErrInvalidRule, tokens[0])
+	}
+
+	return func(in RuleInput) bool { return compare(utf8.RuneCountInString(in.Text), n) }, tokens[2:], nil
+}
+
+var lengthComparisons = map[string]func(a, b int) bool{
+	"<":  func(a, b int) bool { return a < b },
+	"<=": func(a, b int) bool { return a <= b },
+	">":  func(a, b int) bool { return a > b },
+	">=": func(a, b int) bool { return a >= b },
+	"=":  func(a, b int) bool { return a == b },
+}
+
+func parseChannelCondition(tokens []string) (func(RuleInput) bool, []string, error) {
+	if len(tokens) == 0 || strings.TrimPrefix(tokens[0], "@") == "" {
+		return nil, nil, fmt.Errorf("%w: channel needs a username or ID", ErrInvalidRule)
+	}
+
+	channel := strings.TrimPrefix(tokens[0], "@")
+
+	if peerID, err := strconv.ParseInt(channel, 10, 64); err == nil {
+		return func(in RuleInput) bool { return in.ChannelPeerID == peerID }, tokens[1:], nil
+	}
+
+	return func(in RuleInput) bool { return strings.EqualFold(in.ChannelUsername, channel) }, tokens[1:], nil
+}
+
+// tokenizeRule splits a rule on whitespace. Double-quoted strings form one
+// token; inside them \" and \\ are escapes.
+func tokenizeRule(src string) ([]string, error) {
+	var t ruleTokenizer
+
+	for _, r := range src {
+		t.add(r)
+	}
+
+	if t.quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidRule)
+	}
+
+	t.flush()
+
+	return t.tokens, nil
+}
+
+type ruleTokenizer struct {
+	tokens  []string
+	current strings.Builder
+	inToken bool
+	quoted  bool
+	escaped bool
+}
+
+func (t *ruleTokenizer) add(r rune) {
+	switch {
+	case t.escaped:
+		t.current.WriteRune(r)
+		t.escaped = false
+	case t.quoted && r == '\\':
+		t.escaped = true
+	case r == '"':
+		t.quoted = !t.quoted
+		t.inToken = true
+	case !t.quoted && unicode.IsSpace(r):
+		t.flush()
+	default:
+		t.current.WriteRune(r)
+		t.inToken = true
+	}
+}
+
+func (t *ruleTokenizer) flush() {
+	if t.inToken {
+		t.tokens = append(t.tokens, t.current.String())
+		t.current.Reset()
+		t.inToken = false
+	}
+}
+
+// Matches reports whether all of the rule's conditions hold.
+func (r Rule) Matches(in RuleInput) bool {
+	for _, c := range r.conditions {
+		if c.match(in) == c.negate {
+			return false
+		}
+	}
+
+	return len(r.conditions) > 0
+}
+
+// EvaluateRules applies rules in order. The first matching allow or deny rule
+// decides the verdict; a deny stops evaluation. Boosts of all matching boost
+// rules add up.
+func EvaluateRules(rules []Rule, in RuleInput) RuleVerdict {
+	var verdict RuleVerdict
+
+	for _, r := range rules {
+		if (r.Action != RuleActionBoost && verdict.Action != "") || !r.Matches(in) {
+			continue
+		}
+
+		verdict.Hits = append(verdict.Hits, r.Source)
+
+		switch r.Action {
+		case RuleActionBoost:
+			verdict.Boost += r.Boost
+		case RuleActionDeny:
+			verdict.Action, verdict.Rule = r.Action, r.Source
+
+			return verdict
+		default:
+			verdict.Action, verdict.Rule = r.Action, r.Source
+		}
+	}
+
+	return verdict
+}
@@ -0,0 +1,97 @@
+package filters
+
+import (
+	"errors"
+	"testing"
+)
+
+func mustParseRules(t *testing.T, srcs ...string) []Rule {
+	t.Helper()
+
+	rules := make([]Rule, 0, len(srcs))
+
+	for _, src := range srcs {
+		r, err := ParseRule(src)
+		if err != nil {
+			t.Fatalf("ParseRule(%q) error = %v", src, err)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules
+}
+
+func TestParseRuleConditions(t *testing.T) {
+	tests := []struct {
+		rule string
+		in   RuleInput
+		want bool
+	}{
+		{`deny if regex "(casino|betting) bonus"`, RuleInput{Text: "Best CASINO bonus today"}, true},
+		{`deny if regex "(casino|betting) bonus"`, RuleInput{Text: "casino news"}, false},
+		{`deny if keyword "Промокод"`, RuleInput{Text: "Ваш промокод внутри"}, true},
+		{`deny if length < 5`, RuleInput{Text: "абвг"}, true},
+		{`deny if length >= 5`, RuleInput{Text: "абвг"}, false},
+		{`allow if channel @News`, RuleInput{ChannelUsername: "news"}, true},
+		{`allow if channel 42`, RuleInput{ChannelPeerID: 42}, true},
+		{`deny if forward and not keyword http`, RuleInput{IsForward: true, Text: "see https://x"}, false},
+		{`deny if forward and not keyword http`, RuleInput{IsForward: true, Text: "repost"}, true},
+		{`deny if regex "say \"hi\""`, RuleInput{Text: `they say "hi"`}, true},
+	}
+
+	for _, tt := range tests {
+		r := mustParseRules(t, tt.rule)[0]
+		if got := r.Matches(tt.in); got != tt.want {
+			t.Errorf("%q.Matches(%+v) = %v, want %v", tt.rule, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"drop if length < 5",
+		"deny length < 5",
+		"deny if",
+		"boost if keyword x",
+		"boost 2 if keyword x",
+		"deny if length ~ 5",
+		"deny if keyword",
+		"deny if keyword x or keyword y",
+		`deny if regex "("`,
+		`deny if regex "unterminated`,
+		"deny if colour red",
+	} {
+		if _, err := ParseRule(src); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("ParseRule(%q) error = %v, want ErrInvalidRule", src, err)
+		}
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	rules := mustParseRules(t,
+		"boost 0.2 if keyword breaking",
+		"allow if channel @wire",
+		"deny if keyword promo",
+		"boost -0.1 if keyword promo",
+	)
+
+	allowed := EvaluateRules(rules, RuleInput{Text: "Breaking: promo", ChannelUsername: "wire"})
+	if allowed.Action != RuleActionAllow || allowed.Rule != "allow if channel @wire" {
+		t.Errorf("unexpected verdict: %+v", allowed)
+	}
+
+	if allowed.Boost < 0.099 || allowed.Boost > 0.101 || len(allowed.Hits) != 3 {
+		t.Errorf("boost = %v, hits = %v; want 0.1 from both boost rules and the allow", allowed.Boost, allowed.Hits)
+	}
+
+	denied := EvaluateRules(rules, RuleInput{Text: "promo code"})
+	if denied.Action != RuleActionDeny || len(denied.Hits) != 1 {
+		t.Errorf("unexpected verdict: %+v", denied)
+	}
+
+	if none := EvaluateRules(rules, RuleInput{Text: "weather"}); none.Action != "" || len(none.Hits) != 0 {
+		t.Errorf("unexpected verdict: %+v", none)
+	}
+}
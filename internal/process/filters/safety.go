@@ -0,0 +1,118 @@
+package filters
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+// Content safety flags set on items by the safety stage.
+const (
+	// SafetyFlagNSFW marks sexual or nude content, flagged by the LLM.
+	SafetyFlagNSFW = "nsfw"
+	// SafetyFlagGraphic marks gore, injuries or dead bodies, flagged by the LLM.
+	SafetyFlagGraphic = "graphic"
+	// SafetyFlagProfanity marks obscene language in the text or summary.
+	SafetyFlagProfanity = "profanity"
+)
+
+// Content safety policies for flagged items.
+const (
+	// SettingContentSafety is the global content safety policy.
+	SettingContentSafety = "content_safety_policy"
+	// SafetyTargetKeyFmt overrides content_safety_policy for a single target chat.
+	SafetyTargetKeyFmt = SettingContentSafety + ":%d"
+
+	// SafetyPolicyOff shows flagged items unchanged (default).
+	SafetyPolicyOff = "off"
+	// SafetyPolicyWarn shows flagged items with a warning label.
+	SafetyPolicyWarn = "warn"
+	// SafetyPolicyBlur hides flagged items behind spoiler tags.
+	SafetyPolicyBlur = "blur"
+	// SafetyPolicyBlock leaves flagged items out.
+	SafetyPolicyBlock = "block"
+)
+
+// profanityWords are obscene words matched as whole words.
+var profanityWords = map[string]bool{
+	"shit": true, "shitty": true, "cunt": true, "cunts": true, "asshole": true,
+	"assholes": true, "bitch": true, "bitches": true, "бля": true, "сука": true,
+	"суки": true,
+}
+
+// profanityStems are the stems of obscene word families, matched as word prefixes.
+var profanityStems = []string{
+	"fuck", "motherfuck", "bullshit",
+	"хуй", "хуе", "хуя", "пизд", "ебан", "ебат", "ебал", "ебу", "заеб", "выеб",
+	"уеб", "отъеб", "долбоеб", "бляд", "блят", "мудак", "мудил",
+}
+
+// IsValidSafetyPolicy reports whether policy is a content safety policy.
+func IsValidSafetyPolicy(policy string) bool {
+	switch policy {
+	case SafetyPolicyOff, SafetyPolicyWarn, SafetyPolicyBlur, SafetyPolicyBlock:
+		return true
+	default:
+		return false
+	}
+}
+
+// SafetyFlags returns the safety flags of a message: the LLM's media flags
+// that are known, and profanity when any of texts contains obscene words.
+func SafetyFlags(llmFlags []string, texts ...string) []string {
+	var flags []string
+
+	for _, flag := range llmFlags {
+		flag = strings.ToLower(strings.TrimSpace(flag))
+		if (flag == SafetyFlagNSFW || flag == SafetyFlagGraphic) && !slices.Contains(flags, flag) {
+			flags = append(flags, flag)
+		}
+	}
+
+	for _, text := range texts {
+		if ContainsProfanity(text) {
+			flags = append(flags, SafetyFlagProfanity)
+
+			break
+		}
+	}
+
+	slices.Sort(flags)
+
+	return flags
+}
+
+// SafetyDropReason returns the drop reason of an item blocked for flags.
+func SafetyDropReason(flags []string) string {
+	if len(flags) == 0 {
+		return ""
+	}
+
+	return domain.DropReasonSafetyPrefix + flags[0]
+}
+
+// ContainsProfanity reports whether text contains an obscene English or
+// Russian word.
+func ContainsProfanity(text string) bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	for _, word := range words {
+		word = strings.ReplaceAll(word, "ё", "е")
+
+		if profanityWords[word] {
+			return true
+		}
+
+		for _, stem := range profanityStems {
+			if strings.HasPrefix(word, stem) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+package filters
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestContainsProfanity(t *testing.T) {
+	tests := map[string]bool{
+		"What the FUCK happened":           true,
+		"Полная хуйня с этими тарифами":    true,
+		"Заёбанные водители":               true,
+		"Ну бля, опять":                    true,
+		"Shitake mushrooms and Scunthorpe": false,
+		"Потребление энергии выросло":      false,
+		"Учебный год начался":              false,
+	}
+
+	for text, want := range tests {
+		if got := ContainsProfanity(text); got != want {
+			t.Errorf("ContainsProfanity(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestSafetyFlags(t *testing.T) {
+	got := SafetyFlags([]string{" NSFW ", "violence", "nsfw", "graphic"}, "calm text", "shit summary")
+	want := []string{SafetyFlagGraphic, SafetyFlagNSFW, SafetyFlagProfanity}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("SafetyFlags() = %v, want %v", got, want)
+	}
+
+	if got := SafetyFlags(nil, "calm text"); len(got) != 0 {
+		t.Errorf("SafetyFlags() = %v, want none", got)
+	}
+
+	if reason := SafetyDropReason(want); reason != "safety_graphic" {
+		t.Errorf("SafetyDropReason() = %q", reason)
+	}
+}
@@ -40,7 +40,7 @@ func (p *Pipeline) extractBullets(ctx context.Context, logger zerolog.Logger, c
 		LinkContextRole: linkRole,
 	}
 
-	extracted, err := p.llmClient.ExtractBullets(ctx, input, digestLanguage, "")
+	extracted, err := p.llmClient.ExtractBullets(llm.WithCassetteSources(ctx, c.ID), input, digestLanguage, "")
 	if err != nil {
 		logger.Warn().Err(err).Str(LogFieldMsgID, c.ID).Msg("bullet extraction failed")
 		return nil
@@ -112,7 +112,7 @@ func (p *Pipeline) translateBulletsIfNeeded(ctx context.Context, logger zerolog.
 
 		detected := detectSummaryLanguage(text, "")
 		if detected == "" || detected != targetLang {
-			out, err := p.llmClient.TranslateText(ctx, text, targetLang, "")
+			out, err := p.llmClient.TranslateText(llm.WithCassetteSources(ctx, msgID), text, targetLang, "")
 			if err != nil {
 				logger.Warn().Err(err).Str(LogFieldMsgID, msgID).Msg("failed to translate bullet")
 			} else if strings.TrimSpace(out) != "" {
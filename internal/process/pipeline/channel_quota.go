@@ -0,0 +1,152 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// quotaForwardWeight values one forward as this many views when ranking messages for sampling.
+const quotaForwardWeight = 10
+
+// applyChannelQuotas enforces per-channel ingestion quotas on a claimed batch.
+// Messages over quota are dropped or deferred according to the channel's overflow
+// policy; the remaining messages are returned in their original order.
+func (p *Pipeline) applyChannelQuotas(ctx context.Context, logger zerolog.Logger, messages []db.RawMessage) []db.RawMessage {
+	quotas, err := p.database.GetChannelQuotas(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load channel quotas, skipping quota enforcement")
+
+		return messages
+	}
+
+	if len(quotas) == 0 {
+		return messages
+	}
+
+	admitted := make([]db.RawMessage, 0, len(messages))
+	limited := make(map[string][]db.RawMessage)
+
+	for _, m := range messages {
+		if _, ok := quotas[m.ChannelID]; ok {
+			limited[m.ChannelID] = append(limited[m.ChannelID], m)
+		} else {
+			admitted = append(admitted, m)
+		}
+	}
+
+	for channelID, msgs := range limited {
+		admitted = append(admitted, p.applyChannelQuota(ctx, logger, quotas[channelID], msgs)...)
+	}
+
+	sort.SliceStable(admitted, func(i, j int) bool {
+		return admitted[i].TGDate.Before(admitted[j].TGDate)
+	})
+
+	return admitted
+}
+
+func (p *Pipeline) applyChannelQuota(ctx context.Context, logger zerolog.Logger, q db.ChannelQuota, msgs []db.RawMessage) []db.RawMessage {
+	now := time.Now()
+
+	usage, err := p.database.GetChannelQuotaUsage(ctx, q.ChannelID, now.Add(-q.Window()))
+	if err != nil {
+		logger.Warn().Err(err).Str(LogFieldChannelID, q.ChannelID).Msg("failed to get channel quota usage")
+
+		return msgs
+	}
+
+	admitted, overflow := splitByQuota(msgs, q.Limit-usage.Processed, q.Policy)
+	if len(overflow) == 0 {
+		return admitted
+	}
+
+	logger.Info().
+		Str(LogFieldChannelID, q.ChannelID).
+		Str("channel", q.Username).
+		Int(LogFieldLimit, q.Limit).
+		Str("period", q.Period).
+		Str("policy", q.Policy).
+		Int("used", usage.Processed).
+		Int("overflow", len(overflow)).
+		Msg("Channel quota exceeded")
+
+	if q.Policy == db.QuotaPolicyDefer {
+		p.deferQuotaOverflow(ctx, logger, overflow, quotaReopensAt(usage, q.Window(), now))
+
+		return admitted
+	}
+
+	detail := fmt.Sprintf("%d/%s quota, policy %s", q.Limit, q.Period, q.Policy)
+
+	for _, m := range overflow {
+		p.recordDrop(ctx, logger, m.ID, domain.DropReasonChannelQuota, detail)
+		p.markProcessed(ctx, logger, m.ID)
+	}
+
+	return admitted
+}
+
+func (p *Pipeline) deferQuotaOverflow(ctx context.Context, logger zerolog.Logger, overflow []db.RawMessage, until time.Time) {
+	ids := make([]string, 0, len(overflow))
+	for _, m := range overflow {
+		ids = append(ids, m.ID)
+	}
+
+	if err := p.database.DeferRawMessages(ctx, ids, until); err != nil {
+		logger.Warn().Err(err).Int(LogFieldCount, len(ids)).Msg("failed to defer messages over channel quota")
+
+		for _, id := range ids {
+			p.releaseClaimedMessage(ctx, logger, id)
+		}
+	}
+}
+
+// splitByQuota admits at most remaining messages. The sample policy keeps the
+// highest-engagement messages; drop and defer keep the earliest ones. msgs are
+// the channel's messages in the claimed batch, so sampling ranks per batch,
+// not across everything the channel posted in the quota window.
+func splitByQuota(msgs []db.RawMessage, remaining int, policy string) (admitted, overflow []db.RawMessage) {
+	if remaining >= len(msgs) {
+		return msgs, nil
+	}
+
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	ranked := make([]db.RawMessage, len(msgs))
+	copy(ranked, msgs)
+
+	if policy == db.QuotaPolicySample {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return messageEngagement(ranked[i]) > messageEngagement(ranked[j])
+		})
+	} else {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].TGDate.Before(ranked[j].TGDate)
+		})
+	}
+
+	return ranked[:remaining], ranked[remaining:]
+}
+
+func messageEngagement(m db.RawMessage) int {
+	return m.Views + m.Forwards*quotaForwardWeight
+}
+
+// quotaReopensAt returns when the oldest message counted against the quota leaves
+// the rolling window, which is when deferred messages may be retried.
+func quotaReopensAt(usage db.ChannelQuotaUsage, window time.Duration, now time.Time) time.Time {
+	if usage.OldestProcessedAt == nil {
+		return now.Add(window)
+	}
+
+	return usage.OldestProcessedAt.Add(window)
+}
@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSplitByQuota(t *testing.T) {
+	base := time.Date(2026, 2, 15, 10, 0, 0, 0, time.UTC)
+	msgs := []db.RawMessage{
+		{ID: "first", TGDate: base, Views: 100},
+		{ID: "viral", TGDate: base.Add(time.Minute), Views: 5000},
+		{ID: "shared", TGDate: base.Add(2 * time.Minute), Views: 300, Forwards: 50},
+		{ID: "last", TGDate: base.Add(3 * time.Minute), Views: 10},
+	}
+
+	tests := []struct {
+		name         string
+		remaining    int
+		policy       string
+		wantAdmitted []string
+	}{
+		{name: "under quota", remaining: 10, policy: db.QuotaPolicyDrop, wantAdmitted: []string{"first", "viral", "shared", "last"}},
+		{name: "drop keeps earliest", remaining: 2, policy: db.QuotaPolicyDrop, wantAdmitted: []string{"first", "viral"}},
+		{name: "defer keeps earliest", remaining: 1, policy: db.QuotaPolicyDefer, wantAdmitted: []string{"first"}},
+		{name: "sample keeps top engagement", remaining: 2, policy: db.QuotaPolicySample, wantAdmitted: []string{"viral", "shared"}},
+		{name: "exhausted", remaining: -3, policy: db.QuotaPolicySample, wantAdmitted: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admitted, overflow := splitByQuota(msgs, tt.remaining, tt.policy)
+
+			if len(admitted)+len(overflow) != len(msgs) {
+				t.Fatalf("admitted %d + overflow %d != %d messages", len(admitted), len(overflow), len(msgs))
+			}
+
+			if len(admitted) != len(tt.wantAdmitted) {
+				t.Fatalf("admitted %d messages, want %d", len(admitted), len(tt.wantAdmitted))
+			}
+
+			for i, id := range tt.wantAdmitted {
+				if admitted[i].ID != id {
+					t.Errorf("admitted[%d] = %s, want %s", i, admitted[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestQuotaReopensAt(t *testing.T) {
+	now := time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC)
+	oldest := now.Add(-20 * time.Hour)
+
+	if got := quotaReopensAt(db.ChannelQuotaUsage{OldestProcessedAt: &oldest}, 24*time.Hour, now); !got.Equal(now.Add(4 * time.Hour)) {
+		t.Errorf("quotaReopensAt() = %v, want %v", got, now.Add(4*time.Hour))
+	}
+
+	if got := quotaReopensAt(db.ChannelQuotaUsage{}, time.Hour, now); !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("quotaReopensAt() without usage = %v, want %v", got, now.Add(time.Hour))
+	}
+}
+
+func TestFullyDeferredBatch(t *testing.T) {
+	logger := zerolog.Nop()
+	cfg := &config.Config{WorkerBatchSize: 10, RelevanceThreshold: 0.5}
+	repo := &mockRepo{
+		settings:      map[string]interface{}{},
+		channelQuotas: map[string]db.ChannelQuota{"c1": {ChannelID: "c1", Limit: 0, Period: db.QuotaPeriodHour, Policy: db.QuotaPolicyDefer}},
+		unprocessedMessages: []db.RawMessage{
+			{ID: "1", ChannelID: "c1", Text: "Message 1 that is long enough to pass filters", CanonicalHash: "hash1"},
+			{ID: "2", ChannelID: "c1", Text: "Message 2 that is long enough to pass filters", CanonicalHash: "hash2"},
+		},
+	}
+
+	p := New(cfg, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+
+	// Age metrics run after the quota, on an empty batch here.
+	if err := p.processNextBatch(context.Background(), "quota"); err != nil {
+		t.Fatalf("processNextBatch failed: %v", err)
+	}
+
+	if len(repo.deferredMessages) != 2 || len(repo.savedItems) != 0 {
+		t.Errorf("deferred %v and saved %d items, want both messages deferred and none saved", repo.deferredMessages, len(repo.savedItems))
+	}
+}
@@ -23,6 +23,9 @@ const (
 	ReasonPassed   = "passed"
 )
 
+// maxAltTextRunes caps the image descriptions stored with items.
+const maxAltTextRunes = 200
+
 // Deduplication mode constants
 const (
 	DedupModeSemantic = "semantic"
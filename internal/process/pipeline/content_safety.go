@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// applyContentSafety sets the safety flags of an item from the LLM's media
+// flags and a profanity check of the message and summary. Under the global
+// block policy, flagged items are rejected and logged with a safety drop
+// reason; the other policies are applied per target when digests render.
+func (p *Pipeline) applyContentSafety(ctx context.Context, logger zerolog.Logger, c llm.MessageInput, res llm.BatchResult, item *db.Item, s *pipelineSettings) {
+	item.SafetyFlags = filters.SafetyFlags(res.ContentFlags, c.Text, res.Summary)
+	if len(item.SafetyFlags) == 0 {
+		return
+	}
+
+	for _, flag := range item.SafetyFlags {
+		observability.ContentSafetyFlagsTotal.WithLabelValues(flag).Inc()
+	}
+
+	if s.safetyPolicy != filters.SafetyPolicyBlock || item.Status != StatusReady {
+		return
+	}
+
+	logger.Info().Str(LogFieldMsgID, c.ID).Strs("safety_flags", item.SafetyFlags).Msg("blocking flagged item")
+
+	item.Status = StatusRejected
+	p.recordDrop(ctx, logger, c.ID, filters.SafetyDropReason(item.SafetyFlags), strings.Join(item.SafetyFlags, ","))
+}
@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestApplyContentSafety(t *testing.T) {
+	logger := zerolog.Nop()
+	input := llm.MessageInput{RawMessage: db.RawMessage{ID: "m1", Text: "Footage from the scene"}}
+	res := llm.BatchResult{Summary: "Footage from the scene", ContentFlags: []string{"graphic"}}
+
+	tests := []struct {
+		name       string
+		policy     string
+		res        llm.BatchResult
+		wantStatus string
+		wantDrop   string
+	}{
+		{name: "clean", policy: filters.SafetyPolicyBlock, res: llm.BatchResult{Summary: "Calm news"}, wantStatus: StatusReady},
+		{name: "blur keeps flagged item", policy: filters.SafetyPolicyBlur, res: res, wantStatus: StatusReady},
+		{name: "block rejects flagged item", policy: filters.SafetyPolicyBlock, res: res, wantStatus: StatusRejected, wantDrop: "safety_graphic"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepo{}
+			p := New(&config.Config{}, repo, nil, nil, nil, nil, &logger)
+			item := &db.Item{Status: StatusReady}
+
+			p.applyContentSafety(context.Background(), logger, input, tt.res, item, &pipelineSettings{safetyPolicy: tt.policy})
+
+			if item.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", item.Status, tt.wantStatus)
+			}
+
+			if len(tt.res.ContentFlags) > 0 && !slices.Equal(item.SafetyFlags, []string{filters.SafetyFlagGraphic}) {
+				t.Errorf("SafetyFlags = %v, want graphic", item.SafetyFlags)
+			}
+
+			var drops []string
+			for _, call := range repo.saveDropLogCalls {
+				drops = append(drops, call.reason)
+			}
+
+			if tt.wantDrop == "" && len(drops) > 0 || tt.wantDrop != "" && !slices.Equal(drops, []string{tt.wantDrop}) {
+				t.Errorf("drop reasons = %v, want %q", drops, tt.wantDrop)
+			}
+		})
+	}
+}
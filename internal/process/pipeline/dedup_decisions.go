@@ -0,0 +1,71 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/process/dedup"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Dedup decision scopes: where the matched neighbor was found.
+const (
+	dedupScopeBatch       = "batch"
+	dedupScopeSameChannel = "same_channel"
+	dedupScopeGlobal      = "global"
+
+	// strictDuplicateSimilarity is recorded for exact (hash) duplicates.
+	strictDuplicateSimilarity = 1.0
+)
+
+// recordDedupDecision stores why a message was dropped as a duplicate so false
+// merges can be diagnosed later. Failures are logged and never block processing.
+func (p *Pipeline) recordDedupDecision(ctx context.Context, logger zerolog.Logger, d db.DedupDecision) {
+	if err := p.database.SaveDedupDecision(ctx, d); err != nil {
+		logger.Warn().Str(LogFieldMsgID, d.RawMessageID).Err(err).Msg("failed to save dedup decision")
+	}
+}
+
+// itemSimilarity returns the cosine similarity between emb and an item's stored
+// embedding, or 0 if the embedding cannot be loaded.
+func (p *Pipeline) itemSimilarity(ctx context.Context, logger zerolog.Logger, itemID string, emb []float32) float32 {
+	itemEmb, err := p.database.GetItemEmbedding(ctx, itemID)
+	if err != nil {
+		logger.Debug().Str(LogFieldDuplicateID, itemID).Err(err).Msg("failed to load matched item embedding")
+
+		return 0
+	}
+
+	return dedup.CosineSimilarity(emb, itemEmb)
+}
+
+// recordGlobalDedupDecision resolves the neighbor and score of a global
+// duplicate. The strict deduplicator does not report which message matched, so
+// it is looked up by canonical hash.
+func (p *Pipeline) recordGlobalDedupDecision(ctx context.Context, logger zerolog.Logger, m *db.RawMessage, s *pipelineSettings, emb []float32, dupID string) {
+	decision := db.DedupDecision{
+		RawMessageID: m.ID,
+		Mode:         s.dedupMode,
+		Scope:        dedupScopeGlobal,
+	}
+
+	if s.dedupMode == DedupModeSemantic {
+		decision.MatchedItemID = dupID
+		decision.Similarity = p.itemSimilarity(ctx, logger, dupID, emb)
+		decision.Threshold = p.cfg.ClusterSimilarityThreshold
+	} else {
+		decision.Mode = DedupModeStrict
+		decision.Similarity = strictDuplicateSimilarity
+		decision.Threshold = strictDuplicateSimilarity
+
+		matched, err := p.database.FindStrictDuplicateMessage(ctx, m.CanonicalHash, m.ID)
+		if err != nil {
+			logger.Debug().Str(LogFieldMsgID, m.ID).Err(err).Msg("failed to resolve strict duplicate")
+		}
+
+		decision.MatchedRawMessageID = matched
+	}
+
+	p.recordDedupDecision(ctx, logger, decision)
+}
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestCheckBatchDuplicateRecordsDecision(t *testing.T) {
+	repo := &mockRepo{}
+	logger := zerolog.Nop()
+	p := New(&config.Config{ClusterSimilarityThreshold: 0.8}, repo, nil, nil, nil, nil, &logger)
+
+	candidates := []llm.MessageInput{{RawMessage: db.RawMessage{ID: "kept"}}}
+	embeddings := map[string][]float32{"kept": {1, 0}}
+	s := &pipelineSettings{dedupMode: DedupModeSemantic}
+	m := db.RawMessage{ID: "dup"}
+
+	if !p.checkBatchDuplicate(context.Background(), logger, &m, s, candidates, embeddings, []float32{1, 0.1}) {
+		t.Fatal("checkBatchDuplicate() = false, want true")
+	}
+
+	if len(repo.dedupDecisions) != 1 {
+		t.Fatalf("dedup decisions = %v, want one", repo.dedupDecisions)
+	}
+
+	d := repo.dedupDecisions[0]
+	if d.RawMessageID != "dup" || d.MatchedRawMessageID != "kept" || d.Mode != DedupModeSemantic || d.Scope != dedupScopeBatch {
+		t.Errorf("decision = %+v, want dup matched to kept in semantic batch scope", d)
+	}
+
+	if d.Similarity <= d.Threshold || d.Threshold != 0.8 {
+		t.Errorf("similarity = %v, threshold = %v; want similarity above 0.8", d.Similarity, d.Threshold)
+	}
+}
+
+func TestSkipBatchDuplicateRecordsStrictDecision(t *testing.T) {
+	repo := &mockRepo{}
+	logger := zerolog.Nop()
+	p := New(&config.Config{}, repo, nil, nil, nil, nil, &logger)
+
+	m := db.RawMessage{ID: "dup", CanonicalHash: "h"}
+	if !p.skipBatchDuplicate(context.Background(), logger, &m, map[string]string{"h": "kept"}) {
+		t.Fatal("skipBatchDuplicate() = false, want true")
+	}
+
+	if len(repo.dedupDecisions) != 1 {
+		t.Fatalf("dedup decisions = %v, want one", repo.dedupDecisions)
+	}
+
+	d := repo.dedupDecisions[0]
+	if d.Mode != DedupModeStrict || d.MatchedRawMessageID != "kept" || d.Similarity != strictDuplicateSimilarity {
+		t.Errorf("decision = %+v, want strict match to kept", d)
+	}
+}
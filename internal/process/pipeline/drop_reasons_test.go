@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestDropReasonsAreCategorized(t *testing.T) {
+	reasons := []string{
+		domain.DropReasonDuplicateBatch, domain.DropReasonForwarded, domain.DropReasonRelevanceGate,
+		domain.DropReasonDedupSemanticBatch, domain.DropReasonDedupSemanticSame, domain.DropReasonDedupSemanticGlobal,
+		domain.DropReasonDedupStrictGlobal, domain.DropReasonForwardDuplicate, domain.DropReasonChannelQuota,
+		domain.DropReasonBelowThreshold, domain.DropReasonRatedIrrelevant, domain.DropReasonChannelSnoozed,
+		filters.ReasonMinLength, filters.ReasonEmojiOnly, filters.ReasonBoilerplate,
+		filters.ReasonForwardShell, filters.ReasonAds, filters.ReasonAdsComments,
+		filters.ReasonDeny, filters.ReasonAllowMiss, filters.ReasonRuleDeny,
+		filters.MediaDropReason(filters.MediaClassSticker), filters.MediaDropReason(filters.MediaClassMeme),
+		filters.SafetyDropReason([]string{filters.SafetyFlagGraphic}),
+	}
+
+	for _, reason := range reasons {
+		if got := domain.CategorizeDropReason(reason); got == domain.DropOther {
+			t.Errorf("drop reason %q has no category", reason)
+		}
+	}
+}
+
+func TestRejectedItemRecordsBelowThresholdDrop(t *testing.T) {
+	logger := zerolog.Nop()
+	cfg := &config.Config{WorkerBatchSize: 10, RelevanceThreshold: 0.95}
+	repo := &mockRepo{
+		settings: map[string]interface{}{},
+		unprocessedMessages: []db.RawMessage{
+			{ID: "1", Text: "Message 1 that is long enough to pass filters", CanonicalHash: "hash1"},
+		},
+	}
+
+	p := New(cfg, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+
+	if err := p.processNextBatch(context.Background(), "threshold"); err != nil {
+		t.Fatalf("processNextBatch failed: %v", err)
+	}
+
+	if len(repo.saveDropLogCalls) != 1 || repo.saveDropLogCalls[0].reason != domain.DropReasonBelowThreshold {
+		t.Errorf("drop log = %+v, want one below_threshold drop", repo.saveDropLogCalls)
+	}
+}
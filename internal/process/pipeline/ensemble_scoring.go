@@ -0,0 +1,244 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	settingEnsembleScoringPolicy = "ensemble_scoring_policy"
+	summarizePromptBase          = "summarize"
+
+	// smartScorerPrefix labels the smart model's score of a low-confidence item.
+	smartScorerPrefix = "smart:"
+	// ensembleVersionSuffix keeps averaged scores out of the single-scorer
+	// distribution of the scoring version.
+	ensembleVersionSuffix = "+ensemble"
+)
+
+// ensembleOutcome is how one item was ensemble scored.
+type ensembleOutcome struct {
+	scores        []db.EnsembleScore
+	disagreement  float32
+	lowConfidence bool
+	route         string
+}
+
+// loadEnsemblePolicy loads the ensemble scoring policy.
+func (p *Pipeline) loadEnsemblePolicy(ctx context.Context, s *pipelineSettings, logger zerolog.Logger) {
+	policy := db.DefaultEnsembleScoringPolicy()
+	p.getSetting(ctx, settingEnsembleScoringPolicy, &policy, logger)
+	s.ensemblePolicy = policy
+}
+
+// runEnsembleScoring scores eligible items with the policy's extra scorers and
+// replaces their scores with the average. Items the scorers disagree on are
+// low confidence and are re-scored with the smart model or queued for
+// annotation. The returned slice is aligned with candidates; items that were
+// not ensemble scored have a nil outcome.
+func (p *Pipeline) runEnsembleScoring(ctx context.Context, logger zerolog.Logger, candidates []llm.MessageInput, results []llm.BatchResult, cached []bool, s *pipelineSettings) []*ensembleOutcome {
+	policy := s.ensemblePolicy
+	if !policy.Active() {
+		return nil
+	}
+
+	indices := selectEnsembleCandidates(candidates, results, cached, policy)
+	if len(indices) == 0 {
+		return nil
+	}
+
+	outcomes := make([]*ensembleOutcome, len(candidates))
+	for _, idx := range indices {
+		outcomes[idx] = &ensembleOutcome{scores: []db.EnsembleScore{ensembleScore(s.scoringVersion, results[idx])}}
+	}
+
+	inputs := make([]llm.MessageInput, len(indices))
+	for j, idx := range indices {
+		inputs[j] = candidates[idx]
+		inputs[j].Text = p.augmentTextForLLM(candidates[idx], s)
+	}
+
+	for _, v := range policy.Variants {
+		variantResults, ok := p.scoreEnsembleVariant(ctx, logger, inputs, v, s)
+		if !ok {
+			continue
+		}
+
+		for j, idx := range indices {
+			if strings.TrimSpace(variantResults[j].Summary) != "" {
+				outcomes[idx].scores = append(outcomes[idx].scores, ensembleScore(v.String(), variantResults[j]))
+			}
+		}
+	}
+
+	lowConfidence := applyEnsembleScores(results, outcomes, indices, policy)
+
+	if policy.RoutesToSmart() && len(lowConfidence) > 0 {
+		p.rescoreLowConfidence(ctx, logger, candidates, results, outcomes, lowConfidence, s)
+	}
+
+	logger.Info().Int(LogFieldCount, len(indices)).Int("low_confidence", len(lowConfidence)).Msg("Ensemble scoring finished")
+
+	return outcomes
+}
+
+// selectEnsembleCandidates returns the indices of freshly summarized items
+// from channels the policy applies to.
+func selectEnsembleCandidates(candidates []llm.MessageInput, results []llm.BatchResult, cached []bool, policy db.EnsembleScoringPolicy) []int {
+	var indices []int
+
+	for i, res := range results {
+		if (len(cached) > i && cached[i]) || strings.TrimSpace(res.Summary) == "" {
+			continue
+		}
+
+		if policy.AppliesTo(candidates[i].ChannelID) {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// scoreEnsembleVariant scores the inputs with one extra scorer. It reports
+// false when the call failed or returned misaligned results.
+func (p *Pipeline) scoreEnsembleVariant(ctx context.Context, logger zerolog.Logger, inputs []llm.MessageInput, v db.EnsembleVariant, s *pipelineSettings) ([]llm.BatchResult, bool) {
+	if v.Prompt != "" {
+		ctx = llm.WithPromptVersions(ctx, map[string]string{summarizePromptBase: v.Prompt})
+	}
+
+	llmCtx, cancel := context.WithTimeout(ctx, LLMBatchTimeout)
+	defer cancel()
+
+	llmStart := time.Now()
+
+	variantResults, err := p.llmClient.ProcessBatch(llmCtx, inputs, s.digestLanguage, v.Model, s.digestTone)
+	if err != nil {
+		logger.Warn().Err(err).Str("scorer", v.String()).Msg("Ensemble scorer failed, skipping it")
+
+		return nil, false
+	}
+
+	if len(variantResults) != len(inputs) {
+		logger.Warn().Int("expected", len(inputs)).Int("actual", len(variantResults)).Str("scorer", v.String()).Msg("Ensemble scorer size mismatch, skipping it")
+
+		return nil, false
+	}
+
+	observability.LLMRequestDuration.WithLabelValues(v.String()).Observe(time.Since(llmStart).Seconds())
+
+	return variantResults, true
+}
+
+// applyEnsembleScores averages each item's scores into its result and marks
+// items whose scorers disagree by at least MaxDisagreement as low confidence.
+// It returns the indices of the low-confidence items.
+func applyEnsembleScores(results []llm.BatchResult, outcomes []*ensembleOutcome, indices []int, policy db.EnsembleScoringPolicy) []int {
+	var lowConfidence []int
+
+	for _, idx := range indices {
+		o := outcomes[idx]
+		results[idx].RelevanceScore, results[idx].ImportanceScore = db.AverageEnsembleScores(o.scores)
+		o.disagreement = db.EnsembleDisagreement(o.scores)
+		o.lowConfidence = len(o.scores) > 1 && o.disagreement >= policy.MaxDisagreement
+
+		if !o.lowConfidence {
+			continue
+		}
+
+		lowConfidence = append(lowConfidence, idx)
+
+		if policy.RoutesToAnnotation() {
+			o.route = db.EnsembleRouteAnnotate
+		}
+	}
+
+	return lowConfidence
+}
+
+// rescoreLowConfidence replaces the results of low-confidence items with the
+// smart model's, like tiered importance analysis does for important items.
+func (p *Pipeline) rescoreLowConfidence(ctx context.Context, logger zerolog.Logger, candidates []llm.MessageInput, results []llm.BatchResult, outcomes []*ensembleOutcome, indices []int, s *pipelineSettings) {
+	inputs := make([]llm.MessageInput, len(indices))
+	for j, idx := range indices {
+		inputs[j] = candidates[idx]
+	}
+
+	model := s.ensemblePolicy.SmartModel
+
+	llmCtx, cancel := context.WithTimeout(ctx, LLMBatchTimeout)
+	defer cancel()
+
+	smartResults, err := p.llmClient.ProcessBatch(llmCtx, inputs, s.digestLanguage, model, s.digestTone)
+	if err != nil || len(smartResults) != len(inputs) {
+		logger.Warn().Err(err).Str(LogFieldModel, model).Msg("Smart re-scoring of low-confidence items failed, keeping averaged scores")
+
+		return
+	}
+
+	scorer := smartScorerPrefix + db.EnsembleVariant{Model: model}.String()
+
+	for j, idx := range indices {
+		if strings.TrimSpace(smartResults[j].Summary) == "" {
+			continue
+		}
+
+		results[idx] = smartResults[j]
+
+		o := outcomes[idx]
+		o.scores = append(o.scores, ensembleScore(scorer, smartResults[j]))
+
+		if o.route == db.EnsembleRouteAnnotate {
+			o.route = db.EnsembleRouteBoth
+		} else {
+			o.route = db.EnsembleRouteSmart
+		}
+	}
+}
+
+func ensembleScore(scorer string, res llm.BatchResult) db.EnsembleScore {
+	return db.EnsembleScore{Scorer: scorer, Relevance: res.RelevanceScore, Importance: res.ImportanceScore}
+}
+
+// saveEnsembleOutcome records how a stored item was ensemble scored.
+func (p *Pipeline) saveEnsembleOutcome(ctx context.Context, logger zerolog.Logger, item *db.Item, o *ensembleOutcome) {
+	if o == nil || item.ID == "" {
+		return
+	}
+
+	if err := p.database.SaveItemScoreEnsemble(ctx, db.ItemScoreEnsemble{
+		ItemID:        item.ID,
+		Scores:        o.scores,
+		Disagreement:  o.disagreement,
+		LowConfidence: o.lowConfidence,
+		Route:         o.route,
+	}); err != nil {
+		logger.Warn().Str(LogFieldItemID, item.ID).Err(err).Msg("failed to save ensemble scores")
+	}
+}
+
+// rawScoreVersion is the version an item's raw scores are recorded under.
+func rawScoreVersion(s *pipelineSettings, o *ensembleOutcome) string {
+	if o == nil {
+		return s.scoringVersion
+	}
+
+	return s.scoringVersion + ensembleVersionSuffix
+}
+
+// ensembleOutcomeAt returns the outcome of the i-th item, nil when it was not
+// ensemble scored.
+func ensembleOutcomeAt(outcomes []*ensembleOutcome, i int) *ensembleOutcome {
+	if i >= len(outcomes) {
+		return nil
+	}
+
+	return outcomes[i]
+}
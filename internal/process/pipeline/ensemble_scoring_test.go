@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// mockEnsembleLLM scores every message with the importance configured for the model.
+type mockEnsembleLLM struct {
+	llm.Client
+	importance map[string]float32
+	calls      []string
+}
+
+func (m *mockEnsembleLLM) ProcessBatch(_ context.Context, messages []llm.MessageInput, _, model, _ string) ([]llm.BatchResult, error) {
+	m.calls = append(m.calls, model)
+
+	res := make([]llm.BatchResult, len(messages))
+	for i := range messages {
+		res[i] = llm.BatchResult{Index: i, RelevanceScore: 0.5, ImportanceScore: m.importance[model], Summary: model + " summary"}
+	}
+
+	return res, nil
+}
+
+func TestRunEnsembleScoring(t *testing.T) {
+	logger := zerolog.Nop()
+	client := &mockEnsembleLLM{importance: map[string]float32{"cheap-b": 0.2, "smart": 0.9}}
+	p := New(&config.Config{}, &mockRepo{}, client, nil, nil, nil, &logger)
+
+	candidates := []llm.MessageInput{
+		{RawMessage: domain.RawMessage{ID: "agree", ChannelID: "ch-1"}},
+		{RawMessage: domain.RawMessage{ID: "disagree", ChannelID: "ch-1"}},
+		{RawMessage: domain.RawMessage{ID: "cached", ChannelID: "ch-1"}},
+		{RawMessage: domain.RawMessage{ID: "out-of-scope", ChannelID: "ch-2"}},
+	}
+	results := []llm.BatchResult{
+		{RelevanceScore: 0.5, ImportanceScore: 0.3, Summary: "a"},
+		{RelevanceScore: 0.5, ImportanceScore: 0.8, Summary: "b"},
+		{RelevanceScore: 0.5, ImportanceScore: 0.8, Summary: "c"},
+		{RelevanceScore: 0.5, ImportanceScore: 0.8, Summary: "d"},
+	}
+	cached := []bool{false, false, true, false}
+
+	s := &pipelineSettings{
+		scoringVersion: "cheap-a@v1",
+		ensemblePolicy: db.EnsembleScoringPolicy{
+			Enabled:         true,
+			Variants:        []db.EnsembleVariant{{Model: "cheap-b"}},
+			MaxDisagreement: 0.3,
+			Route:           db.EnsembleRouteBoth,
+			SmartModel:      "smart",
+			Channels:        []string{"ch-1"},
+		},
+	}
+
+	outcomes := p.runEnsembleScoring(context.Background(), logger, candidates, results, cached, s)
+
+	if outcomes[2] != nil || outcomes[3] != nil {
+		t.Fatalf("cached and out-of-scope items were ensemble scored: %+v, %+v", outcomes[2], outcomes[3])
+	}
+
+	agree := outcomes[0]
+	if agree == nil || agree.lowConfidence || agree.route != "" || len(agree.scores) != 2 {
+		t.Fatalf("unexpected outcome for agreeing scorers: %+v", agree)
+	}
+
+	if results[0].ImportanceScore != 0.25 {
+		t.Errorf("averaged importance = %v, want 0.25", results[0].ImportanceScore)
+	}
+
+	disagree := outcomes[1]
+	if disagree == nil || !disagree.lowConfidence || disagree.route != db.EnsembleRouteBoth || len(disagree.scores) != 3 {
+		t.Fatalf("unexpected outcome for disagreeing scorers: %+v", disagree)
+	}
+
+	if results[1].ImportanceScore != 0.9 || results[1].Summary != "smart summary" {
+		t.Errorf("low-confidence item not re-scored by the smart model: %+v", results[1])
+	}
+
+	if len(client.calls) != 2 || client.calls[1] != "smart" {
+		t.Errorf("LLM calls = %v, want [cheap-b smart]", client.calls)
+	}
+
+	if rawScoreVersion(s, disagree) != "cheap-a@v1+ensemble" || rawScoreVersion(s, nil) != "cheap-a@v1" {
+		t.Errorf("unexpected raw score versions")
+	}
+}
+
+func TestRunEnsembleScoringInactive(t *testing.T) {
+	logger := zerolog.Nop()
+	client := &mockEnsembleLLM{}
+	p := New(&config.Config{}, &mockRepo{}, client, nil, nil, nil, &logger)
+
+	candidates := []llm.MessageInput{{RawMessage: domain.RawMessage{ID: "a"}}}
+	results := []llm.BatchResult{{Summary: "a"}}
+
+	s := &pipelineSettings{ensemblePolicy: db.EnsembleScoringPolicy{Enabled: true}}
+	if outcomes := p.runEnsembleScoring(context.Background(), logger, candidates, results, nil, s); outcomes != nil || len(client.calls) != 0 {
+		t.Errorf("policy without variants scored items: %+v, calls %v", outcomes, client.calls)
+	}
+}
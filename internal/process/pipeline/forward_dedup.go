@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// skipKnownForward drops a forward whose original post was already kept: the
+// origin channel's own message when it is tracked, or an earlier forward of the
+// same post. Copies that were filtered out, rejected or are still being processed
+// do not count, so the story is judged again. The original post gets the credit.
+func (p *Pipeline) skipKnownForward(ctx context.Context, logger zerolog.Logger, m *db.RawMessage) bool {
+	key, ok := forwardOriginKey(m)
+	if !ok {
+		return false
+	}
+
+	origin := m.ForwardChain[0]
+
+	dupID, err := p.database.FindRawMessageByForwardOrigin(ctx, origin.PeerID, origin.MessageID, m.ID)
+	if err != nil {
+		logger.Warn().Err(err).Str(LogFieldMsgID, m.ID).Msg("failed to look up forward origin")
+
+		return false
+	}
+
+	if dupID == "" {
+		return false
+	}
+
+	p.dropForwardDuplicate(ctx, logger, m, dupID, key)
+
+	return true
+}
+
+// skipBatchForward drops a forward of the same post as a message accepted
+// earlier in the batch. acceptedOrigins maps forward origins to the accepted
+// message.
+func (p *Pipeline) skipBatchForward(ctx context.Context, logger zerolog.Logger, m *db.RawMessage, acceptedOrigins map[string]string) bool {
+	key, ok := forwardOriginKey(m)
+	if !ok {
+		return false
+	}
+
+	dupID, seen := acceptedOrigins[key]
+	if !seen {
+		return false
+	}
+
+	p.dropForwardDuplicate(ctx, logger, m, dupID, key)
+
+	return true
+}
+
+func (p *Pipeline) dropForwardDuplicate(ctx context.Context, logger zerolog.Logger, m *db.RawMessage, dupID, key string) {
+	logger.Info().Str(LogFieldMsgID, m.ID).Str(LogFieldDuplicateID, dupID).Str("origin", key).Msg("skipping forward of already known post")
+	p.recordDrop(ctx, logger, m.ID, domain.DropReasonForwardDuplicate, dupID)
+	p.markProcessed(ctx, logger, m.ID)
+}
+
+// forwardOriginKey identifies the original post of a forwarded message.
+func forwardOriginKey(m *db.RawMessage) (string, bool) {
+	if len(m.ForwardChain) == 0 || m.ForwardChain[0].MessageID == 0 {
+		return "", false
+	}
+
+	origin := m.ForwardChain[0]
+
+	return fmt.Sprintf("%d/%d", origin.PeerID, origin.MessageID), true
+}
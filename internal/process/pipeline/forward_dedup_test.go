@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestSkipKnownForward(t *testing.T) {
+	repo := &mockRepo{keptForwardOrigins: map[int64]string{42: "kept"}}
+	logger := zerolog.Nop()
+	p := New(&config.Config{}, repo, nil, nil, nil, nil, &logger)
+
+	msgs := []db.RawMessage{
+		{ID: "fwd-1", ForwardChain: []db.ForwardHop{{PeerID: 100, MessageID: 42}}},
+		{ID: "plain"},
+		{ID: "other", ForwardChain: []db.ForwardHop{{PeerID: 100, MessageID: 43}}},
+	}
+
+	var skipped []string
+
+	for i := range msgs {
+		if p.skipKnownForward(context.Background(), logger, &msgs[i]) {
+			skipped = append(skipped, msgs[i].ID)
+		}
+	}
+
+	if len(skipped) != 1 || skipped[0] != "fwd-1" {
+		t.Fatalf("skipped = %v, want [fwd-1]", skipped)
+	}
+
+	if len(repo.saveDropLogCalls) != 1 || repo.saveDropLogCalls[0].reason != domain.DropReasonForwardDuplicate {
+		t.Errorf("drop log calls = %v, want one %s", repo.saveDropLogCalls, domain.DropReasonForwardDuplicate)
+	}
+
+	if len(repo.markedProcessed) != 1 || repo.markedProcessed[0] != "fwd-1" {
+		t.Errorf("marked processed = %v, want [fwd-1]", repo.markedProcessed)
+	}
+}
+
+func TestBatchForwardFolding(t *testing.T) {
+	origin := []db.ForwardHop{{PeerID: 100, MessageID: 42}}
+
+	tests := []struct {
+		name      string
+		firstText string
+		wantSaved string
+	}{
+		// The first copy is still unprocessed when the second is filtered, so
+		// it does not count until it is accepted.
+		{"first copy accepted", "Forward 1 of the post that is long enough", "fwd-1"},
+		{"first copy rejected by a filter", "Short", "fwd-2"},
+		{"first copy rejected by the gate", "Forward 1 of the post that is sponsored", "fwd-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zerolog.Nop()
+			// Semantic batch dedup never matches, so only forwards are folded
+			cfg := &config.Config{WorkerBatchSize: 10, RelevanceThreshold: 0.5, ClusterSimilarityThreshold: 2}
+			repo := &mockRepo{
+				settings: map[string]interface{}{},
+				unprocessedMessages: []db.RawMessage{
+					{ID: "fwd-1", Text: tt.firstText, CanonicalHash: "hash1", ForwardChain: origin},
+					{ID: "fwd-2", Text: "Forward 2 of the post that is long enough", CanonicalHash: "hash2", ForwardChain: origin},
+				},
+			}
+
+			p := New(cfg, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+			p.stages = newStageRegistry()
+			p.stages.register(StageGate, &dropStage{text: "sponsored"})
+
+			if err := p.processNextBatch(context.Background(), "forwards"); err != nil {
+				t.Fatalf("processNextBatch failed: %v", err)
+			}
+
+			var saved []string
+			for _, item := range repo.savedItems {
+				saved = append(saved, item.RawMessageID)
+			}
+
+			if got := strings.Join(saved, ","); got != tt.wantSaved {
+				t.Errorf("saved items of messages %s, want %s", got, tt.wantSaved)
+			}
+		})
+	}
+}
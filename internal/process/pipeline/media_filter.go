@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// settingFiltersMedia selects what happens to image-only stickers and memes:
+// off, downweight or drop.
+const settingFiltersMedia = "filters_media"
+
+const (
+	mediaFilterOff        = "off"
+	mediaFilterDownweight = "downweight"
+	mediaFilterDrop       = "drop"
+)
+
+const (
+	// mediaCaptionMaxRunes is the longest caption of a post still treated as image-only.
+	mediaCaptionMaxRunes = 40
+	// mediaDownweightFactor scales the importance weight of downweighted posts.
+	mediaDownweightFactor = 0.5
+)
+
+// skipLowValueMedia classifies image-only posts before any LLM call and, per
+// the media filter mode, drops stickers and memes or lowers their importance
+// weight. It returns true when the message was dropped.
+func (p *Pipeline) skipLowValueMedia(ctx context.Context, logger zerolog.Logger, m *db.RawMessage, s *pipelineSettings, filterText, previewText string) bool {
+	if s.mediaFilterMode != mediaFilterDownweight && s.mediaFilterMode != mediaFilterDrop {
+		return false
+	}
+
+	if !isImageOnlyPost(m, filterText, previewText) {
+		return false
+	}
+
+	class := filters.ClassifyMedia(m.MediaJSON, m.MediaData)
+	if class == "" {
+		return false
+	}
+
+	observability.MediaClassTotal.WithLabelValues(class).Inc()
+
+	if !filters.IsLowValueMediaClass(class) {
+		return false
+	}
+
+	if s.mediaFilterMode == mediaFilterDrop {
+		logger.Info().Str(LogFieldMsgID, m.ID).Str("media_class", class).Msg("skipping low-value media post")
+		p.recordDrop(ctx, logger, m.ID, filters.MediaDropReason(class), class)
+		p.markProcessed(ctx, logger, m.ID)
+
+		return true
+	}
+
+	m.ImportanceWeight = max(m.ImportanceWeight*mediaDownweightFactor, MinChannelWeight)
+
+	return false
+}
+
+// isImageOnlyPost reports whether a post is a picture with at most a short
+// caption and no link. Video posts are left alone: their image is a thumbnail.
+func isImageOnlyPost(m *db.RawMessage, filterText, previewText string) bool {
+	if len(m.MediaJSON) == 0 || m.VideoDuration > 0 {
+		return false
+	}
+
+	if len([]rune(strings.TrimSpace(filterText))) > mediaCaptionMaxRunes {
+		return false
+	}
+
+	return !hasLinkOrPreview(m, previewText)
+}
@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const testStickerMediaJSON = `{"Document":{"MimeType":"application/x-tgsticker","Attributes":[]}}`
+
+func TestSkipLowValueMedia(t *testing.T) {
+	logger := zerolog.Nop()
+
+	tests := []struct {
+		name       string
+		mode       string
+		text       string
+		video      int
+		wantSkip   bool
+		wantWeight float32
+	}{
+		{name: "off", mode: mediaFilterOff, wantWeight: 1},
+		{name: "drop", mode: mediaFilterDrop, wantSkip: true, wantWeight: 1},
+		{name: "downweight", mode: mediaFilterDownweight, wantWeight: mediaDownweightFactor},
+		{name: "long caption", mode: mediaFilterDrop, text: "A sticker with a caption long enough to carry news of its own", wantWeight: 1},
+		{name: "video", mode: mediaFilterDrop, video: 12, wantWeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepo{}
+			p := New(&config.Config{}, repo, nil, nil, nil, nil, &logger)
+			m := &db.RawMessage{ID: "m1", MediaJSON: []byte(testStickerMediaJSON), ImportanceWeight: 1, VideoDuration: tt.video}
+			s := &pipelineSettings{mediaFilterMode: tt.mode}
+
+			if got := p.skipLowValueMedia(context.Background(), logger, m, s, tt.text, ""); got != tt.wantSkip {
+				t.Fatalf("skipLowValueMedia() = %v, want %v", got, tt.wantSkip)
+			}
+
+			if m.ImportanceWeight != tt.wantWeight {
+				t.Errorf("ImportanceWeight = %v, want %v", m.ImportanceWeight, tt.wantWeight)
+			}
+
+			if tt.wantSkip && (len(repo.saveDropLogCalls) != 1 || repo.saveDropLogCalls[0].reason != filters.ReasonMediaSticker) {
+				t.Errorf("drop log calls = %v, want one %s", repo.saveDropLogCalls, filters.ReasonMediaSticker)
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@
 //   - Enrichment: Enqueues items for fact-checking and evidence gathering
 //
 // The pipeline runs as a continuous loop, processing messages in batches.
+// Each batch passes through an ordered registry of named stages (see
+// stages.go); optional stages can be disabled and plugins can add their own.
 package pipeline
 
 import (
@@ -18,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -27,9 +30,12 @@ import (
 
 	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
 	"github.com/lueurxax/telegram-digest-bot/internal/core/embeddings"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/geo"
 	linkscore "github.com/lueurxax/telegram-digest-bot/internal/core/links"
 	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/events"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/htmlutils"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
 	"github.com/lueurxax/telegram-digest-bot/internal/process/dedup"
 	"github.com/lueurxax/telegram-digest-bot/internal/process/factcheck"
@@ -44,15 +50,24 @@ type Repository interface {
 	GetUnprocessedMessages(ctx context.Context, limit int) ([]db.RawMessage, error)
 	GetBacklogCount(ctx context.Context) (int, error)
 	GetActiveFilters(ctx context.Context) ([]db.Filter, error)
+	GetActiveChannels(ctx context.Context) ([]db.Channel, error)
+	IncrementPrefilterRuleHits(ctx context.Context, hits map[string]int) error
 	MarkAsProcessed(ctx context.Context, id string) error
 	ReleaseClaimedMessage(ctx context.Context, id string) error
 	RecoverStuckPipelineMessages(ctx context.Context, stuckThreshold time.Duration) (int64, error)
 	GetRecentMessagesForChannel(ctx context.Context, channelID string, before time.Time, limit int) ([]string, error)
 	GetChannelStats(ctx context.Context) (map[string]db.ChannelStats, error)
+	GetScoreDistributions(ctx context.Context, versions []string) ([]db.ScoreDistribution, error)
+	SaveItemRawScores(ctx context.Context, itemID, version string, relevance, importance float32) error
+	SaveItemScoreEnsemble(ctx context.Context, e db.ItemScoreEnsemble) error
 	SaveItem(ctx context.Context, item *db.Item) error
 	SaveItemError(ctx context.Context, rawMsgID string, errJSON []byte) error
 	SaveRelevanceGateLog(ctx context.Context, rawMsgID string, decision string, confidence *float32, reason, model, gateVersion string) error
 	SaveRawMessageDropLog(ctx context.Context, rawMsgID, reason, detail string) error
+	GetChannelQuotas(ctx context.Context) (map[string]db.ChannelQuota, error)
+	GetChannelQuotaUsage(ctx context.Context, channelID string, since time.Time) (db.ChannelQuotaUsage, error)
+	DeferRawMessages(ctx context.Context, ids []string, until time.Time) error
+	FindRawMessageByForwardOrigin(ctx context.Context, originPeerID, originMsgID int64, excludeID string) (string, error)
 	SaveEmbedding(ctx context.Context, itemID string, embedding []float32) error
 	GetItemEmbedding(ctx context.Context, itemID string) ([]float32, error)
 	EnqueueFactCheck(ctx context.Context, itemID, claim, normalizedClaim string) error
@@ -60,6 +75,9 @@ type Repository interface {
 	EnqueueEnrichment(ctx context.Context, itemID, summary string) error
 	CountPendingEnrichments(ctx context.Context) (int, error)
 	CheckStrictDuplicate(ctx context.Context, hash string, id string) (bool, error)
+	FindStrictDuplicateMessage(ctx context.Context, hash, excludeID string) (string, error)
+	SaveDedupDecision(ctx context.Context, d db.DedupDecision) error
+	SavePipelineShadowDecisions(ctx context.Context, decisions []db.PipelineShadowDecision) error
 	ChannelHasCommentedPostsSince(ctx context.Context, channelID string, since time.Time) (bool, error)
 	FindSimilarItem(ctx context.Context, embedding []float32, threshold float32, minCreatedAt time.Time) (string, error)
 	FindSimilarItemForChannel(ctx context.Context, embedding []float32, channelID string, threshold float32, minCreatedAt time.Time) (string, error)
@@ -123,8 +141,10 @@ type Pipeline struct {
 	embeddingClient embeddings.Client
 	linkResolver    LinkResolver
 	linkSeeder      LinkSeeder
+	events          *events.Publisher
 	logger          *zerolog.Logger
 	commentableChan map[string]bool
+	stages          *stageRegistry
 }
 
 type pipelineSettings struct {
@@ -150,6 +170,12 @@ type pipelineSettings struct {
 	tieredImportanceEnabled    bool
 	digestTone                 string
 	normalizeScores            bool
+	normalizeScoresMode        string
+	scoringVersion             string
+	scoreCurrent               *db.ScoreDistribution
+	scoreReference             *db.ScoreDistribution
+	ensemblePolicy             db.EnsembleScoringPolicy
+	prefilter                  *prefilterRules
 	relevanceGateEnabled       bool
 	relevanceGateMode          string
 	relevanceGateModel         string
@@ -174,17 +200,15 @@ type pipelineSettings struct {
 	summaryCachePromptVersion  string
 	bulletModeEnabled          bool
 	bulletMinImportance        float32
+	mediaFilterMode            string
+	safetyPolicy               string
+	disabledStages             []string
+	shadowSamples              map[string]float64
+	relevanceGateShadowModel   string
 }
 
 const (
-	dropReasonDuplicateBatch      = "duplicate_batch"
-	dropReasonForwarded           = "forwarded"
-	dropReasonRelevanceGate       = "relevance_gate"
-	dropReasonDedupSemanticBatch  = "dedup_semantic_batch"
-	dropReasonDedupSemanticSame   = "dedup_semantic_same_channel"
-	dropReasonDedupSemanticGlobal = "dedup_semantic_global"
-	dropReasonDedupStrictGlobal   = "dedup_strict_global"
-	bulletLLMBatchSizeLimit       = 5
+	bulletLLMBatchSizeLimit = 5
 
 	channelCommentCapabilityLookback = 30 * 24 * time.Hour
 
@@ -204,9 +228,15 @@ func New(cfg *config.Config, database Repository, llmClient llm.Client, embeddin
 		linkSeeder:      linkSeeder,
 		logger:          logger,
 		commentableChan: make(map[string]bool),
+		stages:          defaultStages,
 	}
 }
 
+// SetEventPublisher sets the publisher of item and claim events.
+func (p *Pipeline) SetEventPublisher(pub *events.Publisher) {
+	p.events = pub
+}
+
 // Run starts the pipeline's main processing loop.
 // It processes messages in batches at the configured poll interval until
 // the context is canceled.
@@ -299,6 +329,9 @@ func (p *Pipeline) processNextBatch(ctx context.Context, correlationID string) e
 		observability.PipelineBatchDurationSeconds.Observe(time.Since(batchStart).Seconds())
 	}()
 
+	// Quota first, so deferred messages are only counted when they come back
+	messages = p.applyChannelQuotas(ctx, logger, messages)
+
 	p.recordMessageAgeMetrics(messages)
 
 	// Log backlog
@@ -308,25 +341,15 @@ func (p *Pipeline) processNextBatch(ctx context.Context, correlationID string) e
 		observability.PipelineBacklog.Set(float64(backlog))
 	}
 
-	candidates, embeddings, err := p.prepareCandidates(ctx, logger, messages, s)
-	if err != nil {
-		return err
-	}
-
-	if len(candidates) == 0 {
-		return nil
-	}
-
-	results, err := p.runLLMProcessing(ctx, logger, candidates, s)
-	if err != nil {
-		return err
-	}
-
-	return p.storeResults(ctx, logger, candidates, results, embeddings, s)
+	return p.stages.run(ctx, newBatch(p, logger, messages, s), disabledStageSet(s.disabledStages))
 }
 
 // recordMessageAgeMetrics records metrics for message age and backlog.
 func (p *Pipeline) recordMessageAgeMetrics(messages []db.RawMessage) {
+	if len(messages) == 0 {
+		return
+	}
+
 	now := time.Now()
 
 	oldestAge := now.Sub(messages[0].TGDate).Seconds()
@@ -413,6 +436,7 @@ func (p *Pipeline) loadPipelineSettings(ctx context.Context, logger zerolog.Logg
 		relevanceGateEnabled:      p.cfg.RelevanceGateEnabled,
 		relevanceGateMode:         p.cfg.RelevanceGateMode,
 		relevanceGateModel:        p.cfg.RelevanceGateModel,
+		relevanceGateShadowModel:  p.cfg.RelevanceGateShadowModel,
 		linkEnrichmentEnabled:     true,
 		maxLinks:                  p.cfg.MaxLinksPerMessage,
 		linkCacheTTL:              p.cfg.LinkCacheTTL,
@@ -502,23 +526,35 @@ func (p *Pipeline) loadFilterSettings(ctx context.Context, s *pipelineSettings,
 	p.getSetting(ctx, "filters_skip_forwards", &s.skipForwards, logger)
 	p.getSetting(ctx, "filters_mode", &s.filtersMode, logger)
 	p.getSetting(ctx, "dedup_mode", &s.dedupMode, logger)
+	p.getSetting(ctx, settingFiltersMedia, &s.mediaFilterMode, logger)
+	p.getSetting(ctx, filters.SettingContentSafety, &s.safetyPolicy, logger)
+	p.loadPrefilterRules(ctx, s, logger)
 }
 
 func (p *Pipeline) loadCoreSettings(ctx context.Context, s *pipelineSettings, logger zerolog.Logger) {
 	p.getSetting(ctx, "topics_enabled", &s.topicsEnabled, logger)
+	p.getSetting(ctx, SettingDisabledStages, &s.disabledStages, logger)
+	p.getSetting(ctx, SettingShadowSamples, &s.shadowSamples, logger)
 	p.getSetting(ctx, "relevance_threshold", &s.relevanceThreshold, logger)
 	p.getSetting(ctx, "digest_language", &s.digestLanguage, logger)
 	p.getSetting(ctx, "vision_routing_enabled", &s.visionRoutingEnabled, logger)
 	p.getSetting(ctx, "tiered_importance_enabled", &s.tieredImportanceEnabled, logger)
 	p.getSetting(ctx, "digest_tone", &s.digestTone, logger)
 	p.getSetting(ctx, "normalize_scores", &s.normalizeScores, logger)
+	p.getSetting(ctx, settingNormalizeScoresMode, &s.normalizeScoresMode, logger)
 	p.getSetting(ctx, "relevance_gate_enabled", &s.relevanceGateEnabled, logger)
 	p.getSetting(ctx, "relevance_gate_mode", &s.relevanceGateMode, logger)
 	p.getSetting(ctx, "relevance_gate_model", &s.relevanceGateModel, logger)
+	p.getSetting(ctx, SettingGateShadowModel, &s.relevanceGateShadowModel, logger)
 	p.getSetting(ctx, "bullet_mode_enabled", &s.bulletModeEnabled, logger)
 	p.getSetting(ctx, "bullet_min_importance", &s.bulletMinImportance, logger)
 
-	if s.normalizeScores {
+	p.loadScoringVersion(ctx, s, logger)
+	p.loadEnsemblePolicy(ctx, s, logger)
+
+	if s.normalizeScores && s.normalizeScoresMode == NormalizeModeQuantile {
+		p.loadQuantileNormalization(ctx, s, logger)
+	} else if s.normalizeScores {
 		var err error
 
 		s.channelStats, err = p.database.GetChannelStats(ctx)
@@ -572,7 +608,7 @@ func (p *Pipeline) recordDrop(ctx context.Context, logger zerolog.Logger, msgID,
 		logger.Warn().Str(LogFieldMsgID, msgID).Err(err).Msg("failed to save drop log")
 	}
 
-	observability.DropsTotal.WithLabelValues(reason).Inc()
+	observability.DropsTotal.WithLabelValues(reason, string(domain.CategorizeDropReason(reason))).Inc()
 }
 
 func (p *Pipeline) recordRelevanceGateDecision(ctx context.Context, logger zerolog.Logger, msgID string, decision gateDecision) {
@@ -593,56 +629,25 @@ func (p *Pipeline) recordRelevanceGateDecision(ctx context.Context, logger zerol
 	}
 }
 
-func (p *Pipeline) prepareCandidates(ctx context.Context, logger zerolog.Logger, messages []db.RawMessage, s *pipelineSettings) ([]llm.MessageInput, map[string][]float32, error) {
-	f := filters.New(s.filterList, s.adsFilterEnabled, s.minLengthDefault, s.adsKeywords, s.filtersMode)
+func (p *Pipeline) skipMessageBasic(ctx context.Context, logger zerolog.Logger, m *db.RawMessage, s *pipelineSettings, f *filters.Filterer) bool {
+	previewText := previewTextFromMessage(m)
+	filterText, stripped := p.prepareFilterText(m.Text, previewText)
 
-	var deduplicator dedup.Deduplicator
-	if s.dedupMode == DedupModeSemantic {
-		deduplicator = dedup.NewSemantic(p.database, p.cfg.ClusterSimilarityThreshold, s.dedupWindow)
-	} else {
-		deduplicator = dedup.NewStrict(p.database)
+	if p.skipEmptyContent(ctx, logger, m, s, filterText, previewText, stripped) {
+		return true
 	}
 
-	candidates := make([]llm.MessageInput, 0, len(messages))
-
-	embeddings := make(map[string][]float32)
-	seenHashes := make(map[string]string) // hash -> msg_id
-
-	for _, m := range messages {
-		if p.skipMessageBasic(ctx, logger, &m, s, seenHashes, f) {
-			continue
-		}
-
-		// 1. Fetch links and channel context early
-		candidate := p.enrichMessage(ctx, logger, m, s)
-
-		// 2. Advanced filters (Relevance Gate) using augmented text
-		if p.skipMessageAdvanced(ctx, logger, &candidate, s) {
-			continue
-		}
-
-		// 3. Deduplication
-		_, skip := p.handleDeduplication(ctx, logger, &candidate, s, candidates, embeddings, deduplicator)
-		if skip {
-			continue
-		}
-
-		candidates = append(candidates, candidate)
-		seenHashes[m.CanonicalHash] = m.ID
+	if p.applyPrefilterRules(ctx, logger, m, filterText, s) {
+		return true
 	}
 
-	return candidates, embeddings, nil
-}
+	if s.prefilter.allowed(m.ID) {
+		m.Text = filterText
 
-func (p *Pipeline) skipMessageBasic(ctx context.Context, logger zerolog.Logger, m *db.RawMessage, s *pipelineSettings, seenHashes map[string]string, f *filters.Filterer) bool {
-	if p.skipBatchDuplicate(ctx, logger, m, seenHashes) {
-		return true
+		return false
 	}
 
-	previewText := previewTextFromMessage(m)
-	filterText, stripped := p.prepareFilterText(m.Text, previewText)
-
-	if p.skipEmptyContent(ctx, logger, m, s, filterText, previewText, stripped) {
+	if p.skipLowValueMedia(ctx, logger, m, s, filterText, previewText) {
 		return true
 	}
 
@@ -662,7 +667,15 @@ func (p *Pipeline) skipBatchDuplicate(ctx context.Context, logger zerolog.Logger
 	}
 
 	logger.Info().Str(LogFieldMsgID, m.ID).Str(LogFieldDuplicateID, dupID).Msg("skipping strict duplicate in batch")
-	p.recordDrop(ctx, logger, m.ID, dropReasonDuplicateBatch, dupID)
+	p.recordDrop(ctx, logger, m.ID, domain.DropReasonDuplicateBatch, dupID)
+	p.recordDedupDecision(ctx, logger, db.DedupDecision{
+		RawMessageID:        m.ID,
+		Mode:                DedupModeStrict,
+		Scope:               dedupScopeBatch,
+		MatchedRawMessageID: dupID,
+		Similarity:          strictDuplicateSimilarity,
+		Threshold:           strictDuplicateSimilarity,
+	})
 	p.markProcessed(ctx, logger, m.ID)
 
 	return true
@@ -696,7 +709,7 @@ func (p *Pipeline) skipEmptyContent(ctx context.Context, logger zerolog.Logger,
 
 	if s.skipForwards && m.IsForward {
 		logger.Info().Str(LogFieldMsgID, m.ID).Msg("skipping forwarded message")
-		p.recordDrop(ctx, logger, m.ID, dropReasonForwarded, "")
+		p.recordDrop(ctx, logger, m.ID, domain.DropReasonForwarded, "")
 		p.markProcessed(ctx, logger, m.ID)
 
 		return true
@@ -790,14 +803,14 @@ func (p *Pipeline) shouldDropByCommentsDisabledAdRule(ctx context.Context, logge
 }
 
 func (p *Pipeline) skipMessageAdvanced(ctx context.Context, logger zerolog.Logger, c *llm.MessageInput, s *pipelineSettings) bool {
-	if s.relevanceGateEnabled {
+	if s.relevanceGateEnabled && !s.prefilter.allowed(c.ID) {
 		text := p.augmentTextWithLinks(c, s, domain.ScopeRelevance)
-		decision := p.evaluateRelevanceGate(ctx, logger, text, s)
+		decision := p.evaluateRelevanceGate(llm.WithCassetteSources(ctx, c.ID), logger, text, s)
 		p.recordRelevanceGateDecision(ctx, logger, c.ID, decision)
 
 		if decision.decision == DecisionIrrelevant {
 			logger.Info().Str(LogFieldMsgID, c.ID).Str("reason", decision.reason).Msg("skipping message by relevance gate")
-			p.recordDrop(ctx, logger, c.ID, dropReasonRelevanceGate, decision.reason)
+			p.recordDrop(ctx, logger, c.ID, domain.DropReasonRelevanceGate, decision.reason)
 			p.markProcessed(ctx, logger, c.ID)
 
 			return true
@@ -807,25 +820,12 @@ func (p *Pipeline) skipMessageAdvanced(ctx context.Context, logger zerolog.Logge
 	return false
 }
 
-func (p *Pipeline) handleDeduplication(ctx context.Context, logger zerolog.Logger, c *llm.MessageInput, s *pipelineSettings, candidates []llm.MessageInput, embeddings map[string][]float32, deduplicator dedup.Deduplicator) ([]float32, bool) {
-	emb, skip := p.generateEmbeddingIfNeeded(ctx, logger, c, s, embeddings)
-	if skip {
-		return nil, true
-	}
-
-	if p.checkBatchDuplicate(ctx, logger, &c.RawMessage, s, candidates, embeddings, emb) {
-		return nil, true
-	}
-
-	if p.checkSameChannelDuplicate(ctx, logger, &c.RawMessage, s, emb) {
-		return nil, true
-	}
-
-	if p.checkGlobalDuplicate(ctx, logger, &c.RawMessage, s, emb, deduplicator) {
-		return nil, true
-	}
+func (p *Pipeline) isDuplicate(ctx context.Context, logger zerolog.Logger, c *llm.MessageInput, s *pipelineSettings, accepted []llm.MessageInput, embeddings map[string][]float32, deduplicator dedup.Deduplicator) bool {
+	emb := embeddings[c.ID]
 
-	return emb, false
+	return p.checkBatchDuplicate(ctx, logger, &c.RawMessage, s, accepted, embeddings, emb) ||
+		p.checkSameChannelDuplicate(ctx, logger, &c.RawMessage, s, emb) ||
+		p.checkGlobalDuplicate(ctx, logger, &c.RawMessage, s, emb, deduplicator)
 }
 
 func (p *Pipeline) generateEmbeddingIfNeeded(ctx context.Context, logger zerolog.Logger, c *llm.MessageInput, s *pipelineSettings, embeddings map[string][]float32) ([]float32, bool) {
@@ -870,9 +870,17 @@ func (p *Pipeline) checkBatchDuplicate(ctx context.Context, logger zerolog.Logge
 	}
 
 	for _, cand := range candidates {
-		if dedup.CosineSimilarity(embeddings[cand.ID], emb) > p.cfg.ClusterSimilarityThreshold {
+		if similarity := dedup.CosineSimilarity(embeddings[cand.ID], emb); similarity > p.cfg.ClusterSimilarityThreshold {
 			logger.Info().Str(LogFieldMsgID, m.ID).Str(LogFieldDuplicateID, cand.ID).Msg("skipping semantic duplicate in batch")
-			p.recordDrop(ctx, logger, m.ID, dropReasonDedupSemanticBatch, cand.ID)
+			p.recordDrop(ctx, logger, m.ID, domain.DropReasonDedupSemanticBatch, cand.ID)
+			p.recordDedupDecision(ctx, logger, db.DedupDecision{
+				RawMessageID:        m.ID,
+				Mode:                DedupModeSemantic,
+				Scope:               dedupScopeBatch,
+				MatchedRawMessageID: cand.ID,
+				Similarity:          similarity,
+				Threshold:           p.cfg.ClusterSimilarityThreshold,
+			})
 			p.markProcessed(ctx, logger, m.ID)
 
 			return true
@@ -912,7 +920,15 @@ func (p *Pipeline) checkSameChannelDuplicate(ctx context.Context, logger zerolog
 	}
 
 	logger.Info().Str(LogFieldMsgID, m.ID).Str(LogFieldDuplicateID, dupID).Msg("skipping same-channel near-duplicate")
-	p.recordDrop(ctx, logger, m.ID, dropReasonDedupSemanticSame, dupID)
+	p.recordDrop(ctx, logger, m.ID, domain.DropReasonDedupSemanticSame, dupID)
+	p.recordDedupDecision(ctx, logger, db.DedupDecision{
+		RawMessageID:  m.ID,
+		Mode:          DedupModeSemantic,
+		Scope:         dedupScopeSameChannel,
+		MatchedItemID: dupID,
+		Similarity:    p.itemSimilarity(ctx, logger, dupID, emb),
+		Threshold:     threshold,
+	})
 	p.markProcessed(ctx, logger, m.ID)
 
 	return true
@@ -932,12 +948,13 @@ func (p *Pipeline) checkGlobalDuplicate(ctx context.Context, logger zerolog.Logg
 
 	logger.Info().Str(LogFieldMsgID, m.ID).Str(LogFieldDuplicateID, dupID).Msg("skipping duplicate message")
 
-	reason := dropReasonDedupStrictGlobal
+	reason := domain.DropReasonDedupStrictGlobal
 	if s.dedupMode == DedupModeSemantic {
-		reason = dropReasonDedupSemanticGlobal
+		reason = domain.DropReasonDedupSemanticGlobal
 	}
 
 	p.recordDrop(ctx, logger, m.ID, reason, dupID)
+	p.recordGlobalDedupDecision(ctx, logger, m, s, emb, dupID)
 	p.markProcessed(ctx, logger, m.ID)
 
 	return true
@@ -964,7 +981,7 @@ func (p *Pipeline) enrichMessage(ctx context.Context, logger zerolog.Logger, m d
 	}
 }
 
-func (p *Pipeline) runLLMProcessing(ctx context.Context, logger zerolog.Logger, candidates []llm.MessageInput, s *pipelineSettings) ([]llm.BatchResult, error) {
+func (p *Pipeline) runLLMProcessing(ctx context.Context, logger zerolog.Logger, candidates []llm.MessageInput, s *pipelineSettings) ([]llm.BatchResult, []*ensembleOutcome, error) {
 	start := time.Now()
 
 	results := make([]llm.BatchResult, len(candidates))
@@ -978,16 +995,19 @@ func (p *Pipeline) runLLMProcessing(ctx context.Context, logger zerolog.Logger,
 
 	for model, indices := range modelGroups {
 		if err := p.processModelBatch(ctx, logger, candidates, results, model, indices, s); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	// 2.1 Tiered Importance Analysis
 	p.performTieredImportanceAnalysis(ctx, logger, candidates, results, cached, s)
 
+	// 2.2 Ensemble Scoring
+	ensembles := p.runEnsembleScoring(ctx, logger, candidates, results, cached, s)
+
 	logger.Info().Int(LogFieldCount, len(candidates)).Dur("duration", time.Since(start)).Msg("LLM processing finished")
 
-	return results, nil
+	return results, ensembles, nil
 }
 
 func (p *Pipeline) loadCachedSummaries(ctx context.Context, logger zerolog.Logger, candidates []llm.MessageInput, results []llm.BatchResult, cached []bool, digestLang, promptVersion string) {
@@ -1160,7 +1180,8 @@ func applyTieredResults(results []llm.BatchResult, tieredResults []llm.BatchResu
 	}
 }
 
-func (p *Pipeline) storeResults(ctx context.Context, logger zerolog.Logger, candidates []llm.MessageInput, results []llm.BatchResult, embeddings map[string][]float32, s *pipelineSettings) error {
+func (p *Pipeline) storeResults(ctx context.Context, logger zerolog.Logger, candidates []llm.MessageInput, results []llm.BatchResult, ensembles []*ensembleOutcome, embeddings map[string][]float32, s *pipelineSettings) error {
+	raw := captureRawScores(results)
 	p.normalizeResults(candidates, results, s)
 
 	channelBiases := p.loadChannelBias(ctx, logger)
@@ -1188,7 +1209,8 @@ func (p *Pipeline) storeResults(ctx context.Context, logger zerolog.Logger, cand
 
 		extractedBullets, bulletSummary := p.processBullets(ctx, logger, candidates[i], &res, s)
 		bias := p.applyChannelBias(candidates[i], &res, channelBiases)
-		forceReject, _ := p.applyIrrelevantSuppression(ctx, logger, candidates[i].ID, embeddings[candidates[i].ID], &res)
+		applyPrefilterBoost(&res, s.prefilter.boost(candidates[i].ID))
+		forceReject, similarity := p.applyIrrelevantSuppression(ctx, logger, candidates[i].ID, embeddings[candidates[i].ID], &res)
 
 		item := p.createItem(logger, candidates[i], res, bias, s)
 		item.Language = lang
@@ -1199,11 +1221,17 @@ func (p *Pipeline) storeResults(ctx context.Context, logger zerolog.Logger, cand
 			item.Status = StatusRejected
 		}
 
+		p.recordRejection(ctx, logger, item, forceReject, similarity)
+		p.applyContentSafety(ctx, logger, candidates[i], res, item, s)
+
 		if item.Status == StatusReady {
 			p.finalizeReadyItem(ctx, logger, candidates[i].ID, item, s)
 		}
 
 		ready, rejected := p.storeAndCount(ctx, logger, candidates[i], item, embeddings, extractedBullets, s)
+		ensemble := ensembleOutcomeAt(ensembles, i)
+		p.saveRawScores(ctx, logger, item, raw[i], rawScoreVersion(s, ensemble))
+		p.saveEnsembleOutcome(ctx, logger, item, ensemble)
 		p.persistLinkDebug(ctx, logger, item, debugInfo, canonicalMatch)
 
 		readyCount += ready
@@ -1215,6 +1243,17 @@ func (p *Pipeline) storeResults(ctx context.Context, logger zerolog.Logger, cand
 	return nil
 }
 
+// recordRejection logs why a summarized item was rejected, so rejections show
+// up next to the earlier stage drops in drop-reason reports.
+func (p *Pipeline) recordRejection(ctx context.Context, logger zerolog.Logger, item *db.Item, forceReject bool, similarity float64) {
+	switch {
+	case forceReject:
+		p.recordDrop(ctx, logger, item.RawMessageID, domain.DropReasonRatedIrrelevant, strconv.FormatFloat(similarity, 'f', 2, 64))
+	case item.Status == StatusRejected:
+		p.recordDrop(ctx, logger, item.RawMessageID, domain.DropReasonBelowThreshold, strconv.FormatFloat(float64(item.RelevanceScore), 'f', 2, 32))
+	}
+}
+
 func (p *Pipeline) tryFallbackSummary(summary, text string, stripPhrases []string) string {
 	if summary != "" && !isWeakSummary(summary) {
 		return summary
@@ -1523,6 +1562,12 @@ func (p *Pipeline) storeAndCount(ctx context.Context, logger zerolog.Logger, can
 }
 
 func (p *Pipeline) normalizeResults(candidates []llm.MessageInput, results []llm.BatchResult, s *pipelineSettings) {
+	if s.normalizeScores && s.normalizeScoresMode == NormalizeModeQuantile {
+		normalizeResultsByQuantile(results, s.scoreCurrent, s.scoreReference)
+
+		return
+	}
+
 	if !s.normalizeScores || s.channelStats == nil {
 		return
 	}
@@ -1553,7 +1598,7 @@ func (p *Pipeline) translateSummaryIfNeeded(ctx context.Context, logger zerolog.
 
 	// Pass empty model to let the LLM registry handle task-specific model selection
 	// via LLM_TRANSLATE_MODEL env var or default task config
-	translated, err := p.llmClient.TranslateText(ctx, summary, targetLang, "")
+	translated, err := p.llmClient.TranslateText(llm.WithCassetteSources(ctx, msgID), summary, targetLang, "")
 	if err != nil {
 		logger.Warn().Err(err).Str(LogFieldMsgID, msgID).Msg("failed to translate summary")
 		return summary
@@ -1610,7 +1655,36 @@ func (p *Pipeline) createItem(logger zerolog.Logger, c llm.MessageInput, res llm
 		Summary:         res.Summary,
 		Language:        res.Language,
 		Status:          status,
+		Regions:         itemRegions(c, res),
+		AltText:         itemAltText(c, res),
+	}
+}
+
+// itemAltText returns the LLM description of the message image, capped to
+// maxAltTextRunes. Messages without an image get none, whatever the LLM said.
+func itemAltText(c llm.MessageInput, res llm.BatchResult) string {
+	if len(c.MediaData) == 0 {
+		return ""
+	}
+
+	alt := strings.Join(strings.Fields(htmlutils.StripHTMLTags(res.AltText)), " ")
+
+	runes := []rune(alt)
+	if len(runes) > maxAltTextRunes {
+		alt = strings.TrimSpace(string(runes[:maxAltTextRunes-1])) + "…"
+	}
+
+	return alt
+}
+
+// itemRegions returns the countries tagged by the LLM, falling back to
+// gazetteer detection on the message text and summary.
+func itemRegions(c llm.MessageInput, res llm.BatchResult) []string {
+	if regions := geo.Normalize(res.Regions); len(regions) > 0 {
+		return regions
 	}
+
+	return geo.Detect(c.Text + "\n" + res.Summary)
 }
 
 func (p *Pipeline) calculateImportance(logger zerolog.Logger, c llm.MessageInput, res llm.BatchResult, bias float32, s *pipelineSettings) float32 {
@@ -1700,6 +1774,7 @@ func (p *Pipeline) saveAndMarkProcessed(ctx context.Context, logger zerolog.Logg
 	cacheKey := summaryCacheKey(c, promptVersion)
 	p.upsertSummaryCache(ctx, logger, cacheKey, digestLanguage, item)
 
+	p.publishItemEvent(c, item)
 	p.enqueueFactCheck(ctx, logger, item)
 	p.enqueueEnrichment(ctx, logger, item)
 
@@ -1716,6 +1791,8 @@ func (p *Pipeline) enqueueFactCheck(ctx context.Context, logger zerolog.Logger,
 		return
 	}
 
+	p.events.Publish(events.TypeClaimDetected, events.ClaimDetectedData{ItemID: item.ID, Claim: claim, NormalizedClaim: normalized})
+
 	if !p.factCheckQueueHasCapacity(ctx, logger) {
 		return
 	}
@@ -1725,6 +1802,31 @@ func (p *Pipeline) enqueueFactCheck(ctx context.Context, logger zerolog.Logger,
 	}
 }
 
+// publishItemEvent publishes item_rejected for rejected items and
+// item_created for the others.
+func (p *Pipeline) publishItemEvent(c llm.MessageInput, item *db.Item) {
+	eventType := events.TypeItemCreated
+	if item.Status == StatusRejected {
+		eventType = events.TypeItemRejected
+	}
+
+	p.events.Publish(eventType, events.ItemData{
+		ItemID:       item.ID,
+		RawMessageID: c.ID,
+		ChannelID:    c.ChannelID,
+		ChannelTitle: c.ChannelTitle,
+		TGPeerID:     c.TGPeerID,
+		TGMessageID:  c.TGMessageID,
+		Status:       item.Status,
+		Topic:        item.Topic,
+		Summary:      item.Summary,
+		Language:     item.Language,
+		Relevance:    item.RelevanceScore,
+		Importance:   item.ImportanceScore,
+		Regions:      item.Regions,
+	})
+}
+
 func (p *Pipeline) factCheckEnabled(item *db.Item) bool {
 	if !p.cfg.FactCheckGoogleEnabled || p.cfg.FactCheckGoogleAPIKey == "" {
 		return false
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -35,6 +36,16 @@ type mockRepo struct {
 	markedProcessed      []string
 	channelsWithComments map[string]bool
 	saveDropLogCalls     []dropLogCall
+	dedupDecisions       []db.DedupDecision
+	scoreDistributions   []db.ScoreDistribution
+	rawScores            map[string]string
+	scoreEnsembles       []db.ItemScoreEnsemble
+	channels             []db.Channel
+	ruleHits             map[string]int
+	shadowDecisions      []db.PipelineShadowDecision
+	keptForwardOrigins   map[int64]string // origin message ID -> kept raw message ID
+	channelQuotas        map[string]db.ChannelQuota
+	deferredMessages     []string
 }
 
 type dropLogCall struct {
@@ -74,6 +85,22 @@ func (m *mockRepo) GetActiveFilters(_ context.Context) ([]db.Filter, error) {
 	return m.filters, nil
 }
 
+func (m *mockRepo) GetActiveChannels(_ context.Context) ([]db.Channel, error) {
+	return m.channels, nil
+}
+
+func (m *mockRepo) IncrementPrefilterRuleHits(_ context.Context, hits map[string]int) error {
+	if m.ruleHits == nil {
+		m.ruleHits = make(map[string]int)
+	}
+
+	for rule, n := range hits {
+		m.ruleHits[rule] += n
+	}
+
+	return nil
+}
+
 func (m *mockRepo) MarkAsProcessed(_ context.Context, id string) error {
 	m.markedProcessed = append(m.markedProcessed, id)
 
@@ -96,6 +123,26 @@ func (m *mockRepo) GetChannelStats(_ context.Context) (map[string]db.ChannelStat
 	return map[string]db.ChannelStats{}, nil
 }
 
+func (m *mockRepo) GetScoreDistributions(_ context.Context, _ []string) ([]db.ScoreDistribution, error) {
+	return m.scoreDistributions, nil
+}
+
+func (m *mockRepo) SaveItemRawScores(_ context.Context, itemID, version string, _, _ float32) error {
+	if m.rawScores == nil {
+		m.rawScores = make(map[string]string)
+	}
+
+	m.rawScores[itemID] = version
+
+	return nil
+}
+
+func (m *mockRepo) SaveItemScoreEnsemble(_ context.Context, e db.ItemScoreEnsemble) error {
+	m.scoreEnsembles = append(m.scoreEnsembles, e)
+
+	return nil
+}
+
 func (m *mockRepo) SaveItem(_ context.Context, item *db.Item) error {
 	m.savedItems = append(m.savedItems, item)
 	item.ID = "new-id"
@@ -116,6 +163,24 @@ func (m *mockRepo) SaveRawMessageDropLog(_ context.Context, _ string, reason str
 	return nil
 }
 
+func (m *mockRepo) GetChannelQuotas(_ context.Context) (map[string]db.ChannelQuota, error) {
+	return m.channelQuotas, nil
+}
+
+func (m *mockRepo) GetChannelQuotaUsage(_ context.Context, _ string, _ time.Time) (db.ChannelQuotaUsage, error) {
+	return db.ChannelQuotaUsage{}, nil
+}
+
+func (m *mockRepo) DeferRawMessages(_ context.Context, ids []string, _ time.Time) error {
+	m.deferredMessages = append(m.deferredMessages, ids...)
+
+	return nil
+}
+
+func (m *mockRepo) FindRawMessageByForwardOrigin(_ context.Context, _, originMsgID int64, _ string) (string, error) {
+	return m.keptForwardOrigins[originMsgID], nil
+}
+
 func (m *mockRepo) SaveEmbedding(_ context.Context, _ string, _ []float32) error {
 	return nil
 }
@@ -144,6 +209,22 @@ func (m *mockRepo) CheckStrictDuplicate(_ context.Context, _, _ string) (bool, e
 	return false, nil
 }
 
+func (m *mockRepo) FindStrictDuplicateMessage(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+func (m *mockRepo) SaveDedupDecision(_ context.Context, d db.DedupDecision) error {
+	m.dedupDecisions = append(m.dedupDecisions, d)
+
+	return nil
+}
+
+func (m *mockRepo) SavePipelineShadowDecisions(_ context.Context, decisions []db.PipelineShadowDecision) error {
+	m.shadowDecisions = append(m.shadowDecisions, decisions...)
+
+	return nil
+}
+
 func (m *mockRepo) ChannelHasCommentedPostsSince(_ context.Context, channelID string, _ time.Time) (bool, error) {
 	if m.channelsWithComments == nil {
 		return false, nil
@@ -1239,6 +1320,103 @@ func TestNormalizeResults(t *testing.T) {
 	}
 }
 
+func TestNormalizeResultsQuantile(t *testing.T) {
+	current := &db.ScoreDistribution{
+		Relevance:  []float64{0.2, 0.24, 0.28, 0.4, 0.6, 0.8, 0.92, 0.96, 1},
+		Importance: []float64{0.2, 0.24, 0.28, 0.4, 0.6, 0.8, 0.92, 0.96, 1},
+	}
+	reference := &db.ScoreDistribution{
+		Relevance:  []float64{0, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 1},
+		Importance: []float64{0, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 1},
+	}
+
+	p := New(&config.Config{}, nil, nil, nil, nil, nil, nil)
+
+	results := []llm.BatchResult{
+		{RelevanceScore: 0.6, ImportanceScore: 0.7, Summary: "test"},
+		{RelevanceScore: 0.6, ImportanceScore: 0.7, Summary: ""},
+	}
+
+	s := &pipelineSettings{
+		normalizeScores:     true,
+		normalizeScoresMode: NormalizeModeQuantile,
+		scoreCurrent:        current,
+		scoreReference:      reference,
+	}
+
+	p.normalizeResults(make([]llm.MessageInput, len(results)), results, s)
+
+	if math.Abs(float64(results[0].RelevanceScore)-0.5) > 0.001 || math.Abs(float64(results[0].ImportanceScore)-0.625) > 0.001 {
+		t.Errorf("normalized scores = %v, %v, want 0.5, 0.625", results[0].RelevanceScore, results[0].ImportanceScore)
+	}
+
+	if results[1].RelevanceScore != 0.6 || results[1].ImportanceScore != 0.7 {
+		t.Errorf("empty summary scores changed to %v, %v", results[1].RelevanceScore, results[1].ImportanceScore)
+	}
+
+	// Without loaded distributions the scores stay raw.
+	s.scoreCurrent = nil
+	results[0].RelevanceScore = 0.6
+
+	p.normalizeResults(make([]llm.MessageInput, 1), results[:1], s)
+
+	if results[0].RelevanceScore != 0.6 {
+		t.Errorf("RelevanceScore = %v, want raw 0.6", results[0].RelevanceScore)
+	}
+}
+
+func TestLoadScoringVersion(t *testing.T) {
+	logger := zerolog.Nop()
+
+	repo := &mockRepo{settings: map[string]interface{}{}}
+	p := New(&config.Config{LLMSummarizeModel: "gpt-4o-mini"}, repo, nil, nil, nil, nil, &logger)
+
+	s := &pipelineSettings{}
+	p.loadScoringVersion(context.Background(), s, logger)
+
+	if s.scoringVersion != "gpt-4o-mini@v1" {
+		t.Errorf("scoringVersion = %q, want gpt-4o-mini@v1", s.scoringVersion)
+	}
+
+	repo.settings[settingSummarizeModelOverride] = "gemini-2.0-flash"
+	repo.settings[summarizePromptActiveKey] = "v3"
+
+	p.loadScoringVersion(context.Background(), s, logger)
+
+	if s.scoringVersion != "gemini-2.0-flash@v3" {
+		t.Errorf("scoringVersion = %q, want gemini-2.0-flash@v3", s.scoringVersion)
+	}
+}
+
+func TestLoadQuantileNormalization(t *testing.T) {
+	logger := zerolog.Nop()
+	quantiles := []float64{0, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 1}
+
+	repo := &mockRepo{
+		settings: map[string]interface{}{},
+		scoreDistributions: []db.ScoreDistribution{
+			{Version: "old@v1", Count: db.MinScoreDistributionSamples, Relevance: quantiles, Importance: quantiles},
+			{Version: "new@v1", Count: db.MinScoreDistributionSamples, Relevance: quantiles, Importance: quantiles},
+		},
+	}
+	p := New(&config.Config{}, repo, nil, nil, nil, nil, &logger)
+
+	s := &pipelineSettings{scoringVersion: "new@v1"}
+	p.loadQuantileNormalization(context.Background(), s, logger)
+
+	if s.scoreReference == nil || s.scoreReference.Version != "old@v1" || s.scoreCurrent == nil {
+		t.Fatalf("expected normalization from new@v1 to old@v1, got %+v", s.scoreReference)
+	}
+
+	// The reference version itself is not normalized.
+	s = &pipelineSettings{scoringVersion: "old@v1"}
+	p.loadQuantileNormalization(context.Background(), s, logger)
+
+	if s.scoreReference != nil {
+		t.Errorf("scoreReference = %+v, want nil for the reference version", s.scoreReference)
+	}
+}
+
 func TestEvaluateRelevanceGate(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1608,34 +1786,24 @@ func TestSkipMessage(t *testing.T) {
 		linkEnrichmentEnabled bool
 		adsFilterEnabled      bool
 		channelHasComments    bool
-		seenHashes            map[string]string
 		expectSkip            bool
 		expectDropReason      string
 	}{
-		{
-			name:       "duplicate hash in batch",
-			message:    db.RawMessage{ID: "2", CanonicalHash: "hash1", Text: "Long enough text for filter"},
-			seenHashes: map[string]string{"hash1": "1"},
-			expectSkip: true,
-		},
 		{
 			name:         "forwarded message when skip enabled",
 			message:      db.RawMessage{ID: "1", CanonicalHash: "hash1", Text: "Long enough text for filter", IsForward: true},
 			skipForwards: true,
-			seenHashes:   make(map[string]string),
 			expectSkip:   true,
 		},
 		{
 			name:         "forwarded message when skip disabled",
 			message:      db.RawMessage{ID: "1", CanonicalHash: "hash1", Text: "Long enough text for filter", IsForward: true},
 			skipForwards: false,
-			seenHashes:   make(map[string]string),
 			expectSkip:   false,
 		},
 		{
 			name:       "normal message passes",
 			message:    db.RawMessage{ID: "1", CanonicalHash: "hash1", Text: "Long enough text for filter"},
-			seenHashes: make(map[string]string),
 			expectSkip: false,
 		},
 		{
@@ -1643,7 +1811,6 @@ func TestSkipMessage(t *testing.T) {
 			message:            db.RawMessage{ID: "1", ChannelID: "ch-1", CanonicalHash: "hash1", Text: "Long enough text for filter", HasCommentsThread: false, TGDate: time.Now()},
 			adsFilterEnabled:   true,
 			channelHasComments: true,
-			seenHashes:         make(map[string]string),
 			expectSkip:         true,
 			expectDropReason:   filters.ReasonAdsComments,
 		},
@@ -1652,21 +1819,18 @@ func TestSkipMessage(t *testing.T) {
 			message:            db.RawMessage{ID: "1", ChannelID: "ch-1", CanonicalHash: "hash1", Text: "Long enough text for filter", HasCommentsThread: true, TGDate: time.Now()},
 			adsFilterEnabled:   true,
 			channelHasComments: true,
-			seenHashes:         make(map[string]string),
 			expectSkip:         false,
 		},
 		{
 			name:                  "short message with link passes when enrichment enabled",
 			message:               db.RawMessage{ID: "1", Text: "https://t.me/1"},
 			linkEnrichmentEnabled: true,
-			seenHashes:            make(map[string]string),
 			expectSkip:            false,
 		},
 		{
 			name:                  "short message with link skipped when enrichment disabled",
 			message:               db.RawMessage{ID: "1", Text: "https://t.me/1"},
 			linkEnrichmentEnabled: false,
-			seenHashes:            make(map[string]string),
 			expectSkip:            false,
 		},
 	}
@@ -1695,7 +1859,7 @@ func TestSkipMessage(t *testing.T) {
 
 			f := filters.New(nil, false, 20, nil, "mixed")
 
-			skip := p.skipMessageBasic(context.Background(), logger, &tt.message, s, tt.seenHashes, f)
+			skip := p.skipMessageBasic(context.Background(), logger, &tt.message, s, f)
 
 			if skip != tt.expectSkip {
 				t.Errorf("skipMessage() = %v, want %v", skip, tt.expectSkip)
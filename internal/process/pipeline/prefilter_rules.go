@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const settingPrefilterRules = "prefilter_rules"
+
+// prefilterRules holds a batch's pre-filter rules and their outcomes.
+type prefilterRules struct {
+	rules []filters.Rule
+	// usernames maps channel IDs to usernames for channel conditions.
+	usernames map[string]string
+	// verdicts are keyed by raw message ID.
+	verdicts map[string]filters.RuleVerdict
+	hits     map[string]int
+}
+
+// loadPrefilterRules parses the pre-filter rules. Invalid rules are skipped.
+func (p *Pipeline) loadPrefilterRules(ctx context.Context, s *pipelineSettings, logger zerolog.Logger) {
+	var sources []string
+
+	p.getSetting(ctx, settingPrefilterRules, &sources, logger)
+
+	s.prefilter = &prefilterRules{
+		verdicts: make(map[string]filters.RuleVerdict),
+		hits:     make(map[string]int),
+	}
+
+	for _, src := range sources {
+		rule, err := filters.ParseRule(src)
+		if err != nil {
+			logger.Warn().Err(err).Str("rule", src).Msg("skipping invalid prefilter rule")
+
+			continue
+		}
+
+		s.prefilter.rules = append(s.prefilter.rules, rule)
+	}
+
+	if len(s.prefilter.rules) == 0 {
+		return
+	}
+
+	channels, err := p.database.GetActiveChannels(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to get channels for prefilter rules")
+
+		return
+	}
+
+	s.prefilter.usernames = make(map[string]string, len(channels))
+	for _, c := range channels {
+		s.prefilter.usernames[c.ID] = c.Username
+	}
+}
+
+// applyPrefilterRules evaluates the pre-filter rules on a message and drops it
+// when a deny rule matched. It reports whether the message was dropped.
+func (p *Pipeline) applyPrefilterRules(ctx context.Context, logger zerolog.Logger, m *db.RawMessage, filterText string, s *pipelineSettings) bool {
+	pf := s.prefilter
+	if pf == nil || len(pf.rules) == 0 {
+		return false
+	}
+
+	verdict := filters.EvaluateRules(pf.rules, filters.RuleInput{
+		Text:            filterText,
+		ChannelUsername: pf.usernames[m.ChannelID],
+		ChannelPeerID:   m.TGPeerID,
+		IsForward:       m.IsForward,
+	})
+
+	for _, hit := range verdict.Hits {
+		pf.hits[hit]++
+	}
+
+	pf.verdicts[m.ID] = verdict
+
+	if verdict.Action != filters.RuleActionDeny {
+		return false
+	}
+
+	logger.Info().Str(LogFieldMsgID, m.ID).Str("rule", verdict.Rule).Msg("skipping message by prefilter rule")
+	p.recordDrop(ctx, logger, m.ID, filters.ReasonRuleDeny, verdict.Rule)
+	p.markProcessed(ctx, logger, m.ID)
+
+	return true
+}
+
+// allowed reports whether an allow rule matched the message; such messages
+// skip the heuristic filters and the relevance gate.
+func (pf *prefilterRules) allowed(msgID string) bool {
+	return pf != nil && pf.verdicts[msgID].Action == filters.RuleActionAllow
+}
+
+// boost returns the importance boost of the message's matching boost rules.
+func (pf *prefilterRules) boost(msgID string) float32 {
+	if pf == nil {
+		return 0
+	}
+
+	return pf.verdicts[msgID].Boost
+}
+
+// applyPrefilterBoost adds the message's rule boost to its importance score.
+func applyPrefilterBoost(res *llm.BatchResult, boost float32) {
+	if boost != 0 {
+		res.ImportanceScore = clampScore(res.ImportanceScore + boost)
+	}
+}
+
+// savePrefilterRuleHits persists the batch's rule hit counts.
+func (p *Pipeline) savePrefilterRuleHits(ctx context.Context, logger zerolog.Logger, s *pipelineSettings) {
+	if s.prefilter == nil || len(s.prefilter.hits) == 0 {
+		return
+	}
+
+	if err := p.database.IncrementPrefilterRuleHits(ctx, s.prefilter.hits); err != nil {
+		logger.Warn().Err(err).Msg("failed to save prefilter rule hits")
+	}
+}
@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestPrefilterRulesInBatch(t *testing.T) {
+	const (
+		denyRule  = `deny if keyword "casino"`
+		allowRule = "allow if channel @wire"
+		boostRule = "boost -0.3 if keyword launch"
+	)
+
+	repo := &mockRepo{
+		settings: map[string]interface{}{
+			settingPrefilterRules: []string{denyRule, allowRule, boostRule, "drop everything"},
+			"dedup_mode":          DedupModeStrict,
+		},
+		channels: []db.Channel{{ID: "ch-wire", Username: "Wire"}},
+		unprocessedMessages: []db.RawMessage{
+			{ID: "denied", Text: "Big casino bonus for everyone who joins today", CanonicalHash: "h1"},
+			{ID: "allowed", ChannelID: "ch-wire", Text: "Short", CanonicalHash: "h2"},
+			{ID: "boosted", Text: "The rocket launch is scheduled for tomorrow morning", CanonicalHash: "h3"},
+		},
+	}
+
+	logger := zerolog.Nop()
+	p := New(&config.Config{WorkerBatchSize: 10}, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+
+	if err := p.processNextBatch(context.Background(), "rules"); err != nil {
+		t.Fatalf("processNextBatch failed: %v", err)
+	}
+
+	if len(repo.saveDropLogCalls) != 1 || repo.saveDropLogCalls[0].reason != filters.ReasonRuleDeny {
+		t.Errorf("drops = %+v, want one %s", repo.saveDropLogCalls, filters.ReasonRuleDeny)
+	}
+
+	if len(repo.savedItems) != 2 {
+		t.Fatalf("expected the allowed short message and the boosted one to be saved, got %d items", len(repo.savedItems))
+	}
+
+	for _, item := range repo.savedItems {
+		if item.RawMessageID == "boosted" && (item.ImportanceScore < 0.49 || item.ImportanceScore > 0.51) {
+			t.Errorf("boosted importance = %v, want 0.5", item.ImportanceScore)
+		}
+	}
+
+	if repo.ruleHits[denyRule] != 1 || repo.ruleHits[allowRule] != 1 || repo.ruleHits[boostRule] != 1 {
+		t.Errorf("rule hits = %v", repo.ruleHits)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"unicode"
 
 	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
 )
 
 const (
@@ -82,13 +84,13 @@ func (p *Pipeline) evaluateGateLLM(ctx context.Context, logger zerolog.Logger, t
 	// via LLM_RELEVANCE_GATE_MODEL env var or default task config
 	result, err := p.llmClient.RelevanceGate(ctx, text, s.relevanceGateModel, prompt)
 	if err != nil {
-		logger.Warn().Err(err).Str(LogFieldTask, dropReasonRelevanceGate).Msg("relevance gate LLM call failed")
+		logger.Warn().Err(err).Str(LogFieldTask, domain.DropReasonRelevanceGate).Msg("relevance gate LLM call failed")
 		return gateDecision{}, false
 	}
 
 	decision := strings.ToLower(strings.TrimSpace(result.Decision))
 	if decision != DecisionRelevant && decision != DecisionIrrelevant {
-		logger.Warn().Str(LogFieldTask, dropReasonRelevanceGate).Str(LogFieldDecision, result.Decision).Msg("invalid relevance gate decision")
+		logger.Warn().Str(LogFieldTask, domain.DropReasonRelevanceGate).Str(LogFieldDecision, result.Decision).Msg("invalid relevance gate decision")
 		return gateDecision{}, false
 	}
 
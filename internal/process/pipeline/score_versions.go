@@ -0,0 +1,113 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	// NormalizeModeZScore normalizes scores per channel (the default).
+	NormalizeModeZScore = "zscore"
+	// NormalizeModeQuantile maps scores of the current scoring version onto the
+	// distribution of the reference version.
+	NormalizeModeQuantile = "quantile"
+
+	settingNormalizeScoresMode    = "normalize_scores_mode"
+	settingScoreReferenceVersion  = "score_reference_version"
+	settingSummarizeModelOverride = "llm_override_summarize"
+	summarizePromptActiveKey      = "prompt:summarize:active"
+)
+
+// rawScore holds the scores an item got from the LLM before normalization.
+type rawScore struct {
+	relevance  float32
+	importance float32
+}
+
+// loadScoringVersion resolves the model and prompt version that score items.
+func (p *Pipeline) loadScoringVersion(ctx context.Context, s *pipelineSettings, logger zerolog.Logger) {
+	model := p.cfg.LLMSummarizeModel
+	p.getSetting(ctx, settingSummarizeModelOverride, &model, logger)
+
+	promptVersion := defaultPromptVersion
+	p.getSetting(ctx, summarizePromptActiveKey, &promptVersion, logger)
+
+	if strings.TrimSpace(promptVersion) == "" {
+		promptVersion = defaultPromptVersion
+	}
+
+	s.scoringVersion = db.ScoringVersion(strings.TrimSpace(model), promptVersion)
+}
+
+// loadQuantileNormalization loads the score distributions of the current and
+// the reference scoring version. Normalization is disabled when either has
+// too few samples or the current version is the reference.
+func (p *Pipeline) loadQuantileNormalization(ctx context.Context, s *pipelineSettings, logger zerolog.Logger) {
+	var reference string
+
+	p.getSetting(ctx, settingScoreReferenceVersion, &reference, logger)
+
+	var versions []string
+	if reference != "" {
+		versions = []string{reference, s.scoringVersion}
+	}
+
+	dists, err := p.database.GetScoreDistributions(ctx, versions)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to fetch score distributions for normalization")
+
+		return
+	}
+
+	ref, refOK := db.ReferenceScoreDistribution(dists, reference)
+	current, currentOK := db.FindScoreDistribution(dists, s.scoringVersion)
+
+	if !refOK || !currentOK || ref.Version == current.Version || !ref.HasEnoughSamples() || !current.HasEnoughSamples() {
+		return
+	}
+
+	s.scoreReference = &ref
+	s.scoreCurrent = &current
+}
+
+// normalizeResultsByQuantile maps scores onto the reference version's distribution.
+func normalizeResultsByQuantile(results []llm.BatchResult, current, reference *db.ScoreDistribution) {
+	if current == nil || reference == nil {
+		return
+	}
+
+	for i := range results {
+		if results[i].Summary == "" {
+			continue
+		}
+
+		results[i].RelevanceScore = db.MapScoreQuantile(results[i].RelevanceScore, current.Relevance, reference.Relevance)
+		results[i].ImportanceScore = db.MapScoreQuantile(results[i].ImportanceScore, current.Importance, reference.Importance)
+	}
+}
+
+// captureRawScores copies the LLM scores before they are normalized.
+func captureRawScores(results []llm.BatchResult) []rawScore {
+	raw := make([]rawScore, len(results))
+	for i, res := range results {
+		raw[i] = rawScore{relevance: res.RelevanceScore, importance: res.ImportanceScore}
+	}
+
+	return raw
+}
+
+// saveRawScores records a stored item's raw scores for its scoring version.
+func (p *Pipeline) saveRawScores(ctx context.Context, logger zerolog.Logger, item *db.Item, raw rawScore, version string) {
+	if item.ID == "" {
+		return
+	}
+
+	if err := p.database.SaveItemRawScores(ctx, item.ID, version, raw.relevance, raw.importance); err != nil {
+		logger.Warn().Str(LogFieldItemID, item.ID).Err(err).Msg("failed to save raw scores")
+	}
+}
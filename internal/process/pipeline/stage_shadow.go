@@ -0,0 +1,236 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"maps"
+	"time"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// SettingShadowSamples maps shadow stage names to the share of messages,
+// from 0 to 1, they run on. Shadow stages without a share do not run.
+const SettingShadowSamples = "pipeline_shadow_samples"
+
+// SettingGateShadowModel overrides RELEVANCE_GATE_SHADOW_MODEL, the model
+// the gate_shadow stage tries.
+const SettingGateShadowModel = "relevance_gate_shadow_model"
+
+var errGateShadowModelRequired = errors.New("gate_shadow needs a model: set RELEVANCE_GATE_SHADOW_MODEL or relevance_gate_shadow_model")
+
+// errShadowSkipped is returned by a shadow stage that has nothing to compare
+// with, such as gate_shadow while the live gate is off. Its sample is
+// dropped without recording decisions.
+var errShadowSkipped = errors.New("shadow stage skipped")
+
+// Shadow outcomes, as counted by the digest_pipeline_shadow_decisions_total metric.
+const (
+	shadowAgree      = "agree"
+	shadowOnlyDrop   = "shadow_only"
+	shadowLiveOnly   = "live_only"
+	shadowSampleBase = 10000
+)
+
+// shadowRun is the outcome of a shadow stage on its sample of a batch.
+type shadowRun struct {
+	name    string
+	sampled []string
+	kept    map[string]bool
+	reasons map[string]string
+}
+
+// RegisterShadowStage adds a stage that runs in shadow of the named live
+// stage: on a sample of the messages reaching the live stage, set by the
+// pipeline_shadow_samples setting, its drops are recorded and compared with
+// the live stage's but never applied. Like RegisterStage it is meant to be
+// called from init and panics when the name is taken or the live stage is
+// unknown.
+func RegisterShadowStage(live string, stage Stage) {
+	defaultStages.registerShadow(live, stage)
+}
+
+// runShadows runs the shadow stages on their samples of the batch before the
+// live stage sees it. A failing shadow stage is logged and ignored.
+func (b *Batch) runShadows(ctx context.Context, shadows []Stage) []shadowRun {
+	var runs []shadowRun
+
+	for _, stage := range shadows {
+		name := stage.Name()
+
+		sb := b.shadowSample(name, b.s.shadowSamples[name])
+		if sb == nil {
+			continue
+		}
+
+		run := shadowRun{name: name, reasons: sb.shadowReasons, kept: make(map[string]bool)}
+		for _, c := range sb.Candidates {
+			run.sampled = append(run.sampled, c.ID)
+		}
+
+		start := time.Now()
+
+		if err := stage.Run(ctx, sb); err != nil {
+			if errors.Is(err, errShadowSkipped) {
+				continue
+			}
+
+			observability.PipelineStageErrors.WithLabelValues(name).Inc()
+			b.Logger.Warn().Err(err).Str("shadow_stage", name).Msg("shadow stage failed")
+
+			continue
+		}
+
+		sb.compact()
+		observability.PipelineStageDurationSeconds.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+		for _, c := range sb.Candidates {
+			run.kept[c.ID] = true
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs
+}
+
+// shadowSample copies the sampled messages of the batch, with their results
+// and embeddings, for a shadow stage. It returns nil when nothing is sampled.
+func (b *Batch) shadowSample(name string, rate float64) *Batch {
+	if rate <= 0 {
+		return nil
+	}
+
+	aligned := len(b.Results) == len(b.Candidates)
+	sb := &Batch{
+		Embeddings:    maps.Clone(b.Embeddings),
+		Logger:        b.Logger.With().Str("shadow_stage", name).Logger(),
+		p:             b.p,
+		s:             b.s,
+		shadowReasons: make(map[string]string),
+	}
+
+	for i, c := range b.Candidates {
+		if !inShadowSample(name, c.ID, rate) {
+			continue
+		}
+
+		sb.Candidates = append(sb.Candidates, c)
+
+		if aligned {
+			sb.Results = append(sb.Results, b.Results[i])
+		}
+	}
+
+	if len(sb.Candidates) == 0 {
+		return nil
+	}
+
+	return sb
+}
+
+// inShadowSample picks messages by a hash of their ID, so a message is
+// sampled the same way by every run of the same shadow stage.
+func inShadowSample(name, msgID string, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte(msgID))
+
+	return float64(h.Sum32()%shadowSampleBase) < rate*shadowSampleBase
+}
+
+// compareShadows records, for every sampled message, whether each shadow
+// stage agreed with the live stage on dropping it.
+func (b *Batch) compareShadows(ctx context.Context, live string, runs []shadowRun) {
+	if len(runs) == 0 {
+		return
+	}
+
+	liveKept := make(map[string]bool, len(b.Candidates))
+	for _, c := range b.Candidates {
+		liveKept[c.ID] = true
+	}
+
+	var decisions []db.PipelineShadowDecision
+
+	for _, run := range runs {
+		for _, id := range run.sampled {
+			d := db.PipelineShadowDecision{
+				RawMessageID:  id,
+				Stage:         live,
+				ShadowStage:   run.name,
+				LiveDropped:   !liveKept[id],
+				ShadowDropped: !run.kept[id],
+				ShadowReason:  run.reasons[id],
+			}
+
+			outcome := shadowAgree
+
+			switch {
+			case d.ShadowDropped && !d.LiveDropped:
+				outcome = shadowOnlyDrop
+			case d.LiveDropped && !d.ShadowDropped:
+				outcome = shadowLiveOnly
+			}
+
+			if outcome != shadowAgree {
+				b.Logger.Info().
+					Str(LogFieldMsgID, id).
+					Str("stage", live).
+					Str("shadow_stage", run.name).
+					Str("outcome", outcome).
+					Str("reason", d.ShadowReason).
+					Msg("shadow stage disagrees with live stage")
+			}
+
+			observability.PipelineShadowDecisions.WithLabelValues(live, run.name, outcome).Inc()
+
+			decisions = append(decisions, d)
+		}
+	}
+
+	if err := b.p.database.SavePipelineShadowDecisions(ctx, decisions); err != nil {
+		b.Logger.Warn().Err(err).Msg("failed to save pipeline shadow decisions")
+	}
+}
+
+// runGateShadowStage asks the LLM relevance gate with the shadow model about
+// each message. Nothing is logged to the relevance gate log. It is skipped
+// while the live gate is off, since every message would count as a drop the
+// live gate disagreed with.
+func runGateShadowStage(ctx context.Context, b *Batch) error {
+	if !b.s.relevanceGateEnabled {
+		return errShadowSkipped
+	}
+
+	if b.s.relevanceGateShadowModel == "" {
+		return errGateShadowModelRequired
+	}
+
+	s := *b.s
+	s.relevanceGateMode = gateModeLLM
+	s.relevanceGateModel = b.s.relevanceGateShadowModel
+
+	for i := range b.Candidates {
+		c := &b.Candidates[i]
+		if s.prefilter.allowed(c.ID) {
+			continue
+		}
+
+		text := b.p.augmentTextWithLinks(c, &s, domain.ScopeRelevance)
+
+		if decision := b.p.evaluateRelevanceGate(llm.WithCassetteSources(ctx, c.ID), b.Logger, text, &s); decision.decision == DecisionIrrelevant {
+			b.Drop(ctx, c.ID, domain.DropReasonRelevanceGate, decision.reason)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,432 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/llm"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/dedup"
+	"github.com/lueurxax/telegram-digest-bot/internal/process/filters"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Built-in stage names, in the order the stages run.
+const (
+	StageFilter    = "filter"
+	StageEnrich    = "enrich"
+	StageGate      = "gate"
+	StageEmbed     = "embed"
+	StageDedup     = "dedup"
+	StageSummarize = "summarize"
+	StagePersist   = "persist"
+
+	// StageGateShadow runs the relevance gate with another model in shadow of the gate stage.
+	StageGateShadow = "gate_shadow"
+)
+
+var errStageResultsMismatch = errors.New("stage results do not match the batch messages")
+
+// SettingDisabledStages lists the optional stages that are turned off.
+const SettingDisabledStages = "pipeline_disabled_stages"
+
+// Stage outcomes, as counted by the digest_pipeline_stage_messages_total metric.
+const (
+	stagePassed   = "passed"
+	stageDropped  = "dropped"
+	stageDisabled = "disabled"
+)
+
+// Stage is one step of a pipeline batch. A stage reads and updates the batch
+// in place and drops the messages it rejects with Batch.Drop.
+type Stage interface {
+	Name() string
+	Run(ctx context.Context, b *Batch) error
+}
+
+// StageInfo describes a registered stage.
+type StageInfo struct {
+	Name string
+	// Required stages cannot be disabled.
+	Required bool
+	// Shadows are the stages registered to run in shadow of this one.
+	Shadows []string
+}
+
+// Batch is a batch of messages moving through the stages.
+type Batch struct {
+	// Candidates are the messages still in the batch, in arrival order.
+	// Link context and channel context are filled in by the enrich stage.
+	Candidates []llm.MessageInput
+	// Results are aligned with Candidates once the summarize stage has run.
+	Results []llm.BatchResult
+	// Embeddings are keyed by message ID once the embed stage has run.
+	Embeddings map[string][]float32
+	Logger     zerolog.Logger
+
+	p         *Pipeline
+	s         *pipelineSettings
+	ensembles []*ensembleOutcome
+	dropped   map[string]bool
+	// shadowReasons is set on the copy of a batch given to a shadow stage,
+	// whose drops are only collected.
+	shadowReasons map[string]string
+}
+
+// Drop removes a message from the batch after the current stage, records
+// the drop reason and marks the message processed. In a shadow stage the
+// drop is only compared with the live stage's decision.
+func (b *Batch) Drop(ctx context.Context, msgID, reason, detail string) {
+	if b.shadowReasons != nil {
+		b.shadowReasons[msgID] = reason
+		b.remove(msgID)
+
+		return
+	}
+
+	b.p.recordDrop(ctx, b.Logger, msgID, reason, detail)
+	b.p.markProcessed(ctx, b.Logger, msgID)
+	b.remove(msgID)
+}
+
+// remove takes a message out of the batch without recording anything, for
+// built-in checks that record their own decisions.
+func (b *Batch) remove(msgID string) {
+	if b.dropped == nil {
+		b.dropped = make(map[string]bool)
+	}
+
+	b.dropped[msgID] = true
+}
+
+// compact removes the dropped messages along with their aligned results.
+func (b *Batch) compact() {
+	if len(b.dropped) == 0 {
+		return
+	}
+
+	aligned := len(b.Results) == len(b.Candidates)
+	alignedEnsembles := len(b.ensembles) == len(b.Candidates)
+	kept := 0
+
+	for i := range b.Candidates {
+		if b.dropped[b.Candidates[i].ID] {
+			continue
+		}
+
+		b.Candidates[kept] = b.Candidates[i]
+
+		if aligned {
+			b.Results[kept] = b.Results[i]
+		}
+
+		if alignedEnsembles {
+			b.ensembles[kept] = b.ensembles[i]
+		}
+
+		kept++
+	}
+
+	b.Candidates = b.Candidates[:kept]
+
+	if aligned {
+		b.Results = b.Results[:kept]
+	}
+
+	if alignedEnsembles {
+		b.ensembles = b.ensembles[:kept]
+	}
+
+	b.dropped = nil
+}
+
+type registeredStage struct {
+	stage    Stage
+	required bool
+	shadows  []Stage
+}
+
+// stageRegistry holds the stages in the order they run.
+type stageRegistry struct {
+	stages []registeredStage
+}
+
+var defaultStages = newStageRegistry()
+
+func newStageRegistry() *stageRegistry {
+	return &stageRegistry{stages: []registeredStage{
+		{stage: stageFunc{StageFilter, runFilterStage}, required: true},
+		{stage: stageFunc{StageEnrich, runEnrichStage}},
+		{stage: stageFunc{StageGate, runGateStage}, shadows: []Stage{stageFunc{StageGateShadow, runGateShadowStage}}},
+		{stage: stageFunc{StageEmbed, runEmbedStage}, required: true},
+		{stage: stageFunc{StageDedup, runDedupStage}},
+		{stage: stageFunc{StageSummarize, runSummarizeStage}, required: true},
+		{stage: stageFunc{StagePersist, runPersistStage}, required: true},
+	}}
+}
+
+// RegisterStage adds a custom stage to run right after the named stage.
+// It is meant to be called from the init function of a plugin package that
+// the binary imports behind a build tag, and panics when the name is taken
+// or the stage to run after is unknown.
+func RegisterStage(after string, stage Stage) {
+	defaultStages.register(after, stage)
+}
+
+// Stages returns the registered stages in the order they run.
+func Stages() []StageInfo {
+	return defaultStages.info()
+}
+
+func (r *stageRegistry) register(after string, stage Stage) {
+	r.checkUnique(stage.Name())
+
+	pos := -1
+
+	for i, rs := range r.stages {
+		if rs.stage.Name() == after {
+			pos = i + 1
+		}
+	}
+
+	if pos < 0 {
+		panic(fmt.Sprintf("pipeline: stage %q registered after unknown stage %q", stage.Name(), after))
+	}
+
+	r.stages = append(r.stages, registeredStage{})
+	copy(r.stages[pos+1:], r.stages[pos:])
+	r.stages[pos] = registeredStage{stage: stage}
+}
+
+func (r *stageRegistry) registerShadow(live string, stage Stage) {
+	r.checkUnique(stage.Name())
+
+	for i := range r.stages {
+		if r.stages[i].stage.Name() == live {
+			r.stages[i].shadows = append(r.stages[i].shadows, stage)
+
+			return
+		}
+	}
+
+	panic(fmt.Sprintf("pipeline: shadow stage %q registered for unknown stage %q", stage.Name(), live))
+}
+
+func (r *stageRegistry) checkUnique(name string) {
+	for _, rs := range r.stages {
+		taken := rs.stage.Name() == name
+
+		for _, shadow := range rs.shadows {
+			taken = taken || shadow.Name() == name
+		}
+
+		if taken {
+			panic(fmt.Sprintf("pipeline: stage %q registered twice", name))
+		}
+	}
+}
+
+func (r *stageRegistry) info() []StageInfo {
+	out := make([]StageInfo, len(r.stages))
+	for i, rs := range r.stages {
+		out[i] = StageInfo{Name: rs.stage.Name(), Required: rs.required}
+
+		for _, shadow := range rs.shadows {
+			out[i].Shadows = append(out[i].Shadows, shadow.Name())
+		}
+	}
+
+	return out
+}
+
+// run passes the batch through the enabled stages and stops early once
+// every message has been dropped.
+func (r *stageRegistry) run(ctx context.Context, b *Batch, disabled map[string]bool) error {
+	for _, rs := range r.stages {
+		if len(b.Candidates) == 0 {
+			return nil
+		}
+
+		name := rs.stage.Name()
+
+		if !rs.required && disabled[name] {
+			observability.PipelineStageMessages.WithLabelValues(name, stageDisabled).Add(float64(len(b.Candidates)))
+
+			continue
+		}
+
+		shadows := b.runShadows(ctx, rs.shadows)
+
+		in := len(b.Candidates)
+		start := time.Now()
+
+		err := rs.stage.Run(ctx, b)
+		b.compact()
+
+		observability.PipelineStageDurationSeconds.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		observability.PipelineStageMessages.WithLabelValues(name, stagePassed).Add(float64(len(b.Candidates)))
+		observability.PipelineStageMessages.WithLabelValues(name, stageDropped).Add(float64(in - len(b.Candidates)))
+
+		if err != nil {
+			observability.PipelineStageErrors.WithLabelValues(name).Inc()
+
+			return fmt.Errorf("stage %s: %w", name, err)
+		}
+
+		b.compareShadows(ctx, name, shadows)
+	}
+
+	return nil
+}
+
+// stageFunc adapts a function to the Stage interface.
+type stageFunc struct {
+	name string
+	run  func(ctx context.Context, b *Batch) error
+}
+
+func (f stageFunc) Name() string { return f.name }
+
+func (f stageFunc) Run(ctx context.Context, b *Batch) error { return f.run(ctx, b) }
+
+// runFilterStage applies the cheap checks: forwards of already kept posts,
+// prefilter rules, media and content filters.
+func runFilterStage(ctx context.Context, b *Batch) error {
+	p, s := b.p, b.s
+	f := filters.New(s.filterList, s.adsFilterEnabled, s.minLengthDefault, s.adsKeywords, s.filtersMode)
+
+	for i := range b.Candidates {
+		m := &b.Candidates[i].RawMessage
+
+		if p.skipKnownForward(ctx, b.Logger, m) || p.skipMessageBasic(ctx, b.Logger, m, s, f) {
+			b.remove(m.ID)
+		}
+	}
+
+	p.savePrefilterRuleHits(ctx, b.Logger, s)
+
+	return nil
+}
+
+// runEnrichStage fetches channel context and resolves links.
+func runEnrichStage(ctx context.Context, b *Batch) error {
+	for i := range b.Candidates {
+		b.Candidates[i] = b.p.enrichMessage(ctx, b.Logger, b.Candidates[i].RawMessage, b.s)
+	}
+
+	return nil
+}
+
+// runGateStage asks the relevance gate about each message.
+func runGateStage(ctx context.Context, b *Batch) error {
+	for i := range b.Candidates {
+		if b.p.skipMessageAdvanced(ctx, b.Logger, &b.Candidates[i], b.s) {
+			b.remove(b.Candidates[i].ID)
+		}
+	}
+
+	return nil
+}
+
+// runEmbedStage embeds the messages when dedup or topics need it. A message
+// whose embedding fails stays claimed and is retried after recovery.
+func runEmbedStage(ctx context.Context, b *Batch) error {
+	for i := range b.Candidates {
+		if _, skip := b.p.generateEmbeddingIfNeeded(ctx, b.Logger, &b.Candidates[i], b.s, b.Embeddings); skip {
+			b.remove(b.Candidates[i].ID)
+		}
+	}
+
+	return nil
+}
+
+// runDedupStage drops duplicates of earlier messages in the batch, of recent
+// items from the same channel and of items across all channels. A strict
+// duplicate or another forward of the same post in the batch is only dropped
+// when the earlier message was accepted, so a copy of a message rejected by
+// the gate is still judged on its own.
+func runDedupStage(ctx context.Context, b *Batch) error {
+	p, s := b.p, b.s
+
+	var deduplicator dedup.Deduplicator
+	if s.dedupMode == DedupModeSemantic {
+		deduplicator = dedup.NewSemantic(p.database, p.cfg.ClusterSimilarityThreshold, s.dedupWindow)
+	} else {
+		deduplicator = dedup.NewStrict(p.database)
+	}
+
+	accepted := make([]llm.MessageInput, 0, len(b.Candidates))
+	seenHashes := make(map[string]string)  // hash -> msg_id
+	seenOrigins := make(map[string]string) // forward origin -> msg_id
+
+	for i := range b.Candidates {
+		c := &b.Candidates[i]
+
+		if p.skipBatchDuplicate(ctx, b.Logger, &c.RawMessage, seenHashes) ||
+			p.skipBatchForward(ctx, b.Logger, &c.RawMessage, seenOrigins) ||
+			p.isDuplicate(ctx, b.Logger, c, s, accepted, b.Embeddings, deduplicator) {
+			b.remove(c.ID)
+
+			continue
+		}
+
+		accepted = append(accepted, *c)
+		seenHashes[c.CanonicalHash] = c.ID
+
+		if key, ok := forwardOriginKey(&c.RawMessage); ok {
+			seenOrigins[key] = c.ID
+		}
+	}
+
+	return nil
+}
+
+// runSummarizeStage scores and summarizes the messages with the LLM.
+func runSummarizeStage(ctx context.Context, b *Batch) error {
+	results, ensembles, err := b.p.runLLMProcessing(ctx, b.Logger, b.Candidates, b.s)
+	if err != nil {
+		return err
+	}
+
+	b.Results = results
+	b.ensembles = ensembles
+
+	return nil
+}
+
+// runPersistStage stores the summarized messages as items.
+func runPersistStage(ctx context.Context, b *Batch) error {
+	if len(b.Results) != len(b.Candidates) {
+		return fmt.Errorf("%w: %d results for %d messages", errStageResultsMismatch, len(b.Results), len(b.Candidates))
+	}
+
+	return b.p.storeResults(ctx, b.Logger, b.Candidates, b.Results, b.ensembles, b.Embeddings, b.s)
+}
+
+func newBatch(p *Pipeline, logger zerolog.Logger, messages []db.RawMessage, s *pipelineSettings) *Batch {
+	candidates := make([]llm.MessageInput, len(messages))
+	for i, m := range messages {
+		candidates[i] = llm.MessageInput{RawMessage: m}
+	}
+
+	return &Batch{
+		Candidates: candidates,
+		Embeddings: make(map[string][]float32),
+		Logger:     logger,
+		p:          p,
+		s:          s,
+	}
+}
+
+func disabledStageSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+
+	return set
+}
@@ -0,0 +1,234 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+type dropStage struct {
+	text string
+	ran  int
+}
+
+func (d *dropStage) Name() string { return "drop_test" }
+
+func (d *dropStage) Run(ctx context.Context, b *Batch) error {
+	d.ran++
+
+	for _, c := range b.Candidates {
+		if strings.Contains(c.Text, d.text) {
+			b.Drop(ctx, c.ID, "test_stage", "")
+		}
+	}
+
+	return nil
+}
+
+func TestStageRegistryOrder(t *testing.T) {
+	r := newStageRegistry()
+	r.register(StageEnrich, &dropStage{})
+
+	var names []string
+	for _, info := range r.info() {
+		names = append(names, info.Name)
+	}
+
+	want := "filter,enrich,drop_test,gate,embed,dedup,summarize,persist"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("stages = %s, want %s", got, want)
+	}
+
+	for _, tc := range []struct{ after, name string }{
+		{"unknown", "other"},
+		{StageGate, StageEnrich},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("register(%q, %q) did not panic", tc.after, tc.name)
+				}
+			}()
+
+			r.register(tc.after, stageFunc{name: tc.name})
+		}()
+	}
+}
+
+func TestCustomStageDropsAndCanBeDisabled(t *testing.T) {
+	newRepo := func(settings map[string]interface{}) *mockRepo {
+		return &mockRepo{
+			settings: settings,
+			unprocessedMessages: []db.RawMessage{
+				{ID: "1", Text: "Message 1 that is long enough to pass filters", CanonicalHash: "hash1"},
+				{ID: "2", Text: "Message 2 that is sponsored and long enough", CanonicalHash: "hash2"},
+			},
+		}
+	}
+
+	logger := zerolog.Nop()
+	cfg := &config.Config{WorkerBatchSize: 10, RelevanceThreshold: 0.5}
+
+	stage := &dropStage{text: "sponsored"}
+	repo := newRepo(map[string]interface{}{})
+	p := New(cfg, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+	p.stages = newStageRegistry()
+	p.stages.register(StageGate, stage)
+
+	if err := p.processNextBatch(context.Background(), "stages"); err != nil {
+		t.Fatalf("processNextBatch failed: %v", err)
+	}
+
+	if len(repo.savedItems) != 1 || len(repo.markedProcessed) != 2 {
+		t.Errorf("saved %d items, marked %d processed, want 1 and 2", len(repo.savedItems), len(repo.markedProcessed))
+	}
+
+	if len(repo.saveDropLogCalls) != 1 || repo.saveDropLogCalls[0].reason != "test_stage" {
+		t.Errorf("drop log = %+v, want one test_stage drop", repo.saveDropLogCalls)
+	}
+
+	repo = newRepo(map[string]interface{}{SettingDisabledStages: []string{"drop_test", StagePersist}})
+	p.database = repo
+
+	if err := p.processNextBatch(context.Background(), "stages"); err != nil {
+		t.Fatalf("processNextBatch failed: %v", err)
+	}
+
+	if stage.ran != 1 {
+		t.Errorf("disabled stage ran %d times, want 1", stage.ran)
+	}
+
+	if len(repo.savedItems) != 2 {
+		t.Errorf("saved %d items, want 2 (persist is required and cannot be disabled)", len(repo.savedItems))
+	}
+}
+
+func TestBatchDuplicateOfDroppedMessageIsKept(t *testing.T) {
+	logger := zerolog.Nop()
+	// Semantic batch dedup never matches, so only strict duplicates are folded
+	cfg := &config.Config{WorkerBatchSize: 10, RelevanceThreshold: 0.5, ClusterSimilarityThreshold: 2}
+	repo := &mockRepo{
+		settings: map[string]interface{}{},
+		unprocessedMessages: []db.RawMessage{
+			{ID: "1", Text: "Message 1 that is sponsored and long enough", CanonicalHash: "hash1"},
+			{ID: "2", Text: "Message 2 that is long enough to pass filters", CanonicalHash: "hash1"},
+			{ID: "3", Text: "Message 3 that is long enough to pass filters", CanonicalHash: "hash3"},
+			{ID: "4", Text: "Message 4 that is long enough to pass filters", CanonicalHash: "hash3"},
+		},
+	}
+
+	p := New(cfg, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+	p.stages = newStageRegistry()
+	p.stages.register(StageGate, &dropStage{text: "sponsored"})
+
+	if err := p.processNextBatch(context.Background(), "dup"); err != nil {
+		t.Fatalf("processNextBatch failed: %v", err)
+	}
+
+	// Message 2 is kept because message 1 was dropped; message 4 duplicates the accepted message 3
+	var saved []string
+	for _, item := range repo.savedItems {
+		saved = append(saved, item.RawMessageID)
+	}
+
+	if got := strings.Join(saved, ","); got != "2,3" {
+		t.Errorf("saved items of messages %s, want 2,3", got)
+	}
+
+	if len(repo.saveDropLogCalls) != 2 || repo.saveDropLogCalls[1].reason != domain.DropReasonDuplicateBatch {
+		t.Errorf("drop log = %+v, want test_stage and %s", repo.saveDropLogCalls, domain.DropReasonDuplicateBatch)
+	}
+}
+
+func TestShadowStageDoesNotAffectOutcome(t *testing.T) {
+	logger := zerolog.Nop()
+	cfg := &config.Config{WorkerBatchSize: 10, RelevanceThreshold: 0.5}
+	repo := &mockRepo{
+		settings: map[string]interface{}{SettingShadowSamples: map[string]float64{"drop_test": 1}},
+		unprocessedMessages: []db.RawMessage{
+			{ID: "1", Text: "Message 1 that is long enough to pass filters", CanonicalHash: "hash1"},
+			{ID: "2", Text: "Message 2 that is sponsored and long enough", CanonicalHash: "hash2"},
+		},
+	}
+
+	p := New(cfg, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+	p.stages = newStageRegistry()
+	p.stages.registerShadow(StageGate, &dropStage{text: "sponsored"})
+
+	if err := p.processNextBatch(context.Background(), "shadow"); err != nil {
+		t.Fatalf("processNextBatch failed: %v", err)
+	}
+
+	if len(repo.savedItems) != 2 || len(repo.saveDropLogCalls) != 0 {
+		t.Errorf("saved %d items with %d drops, want 2 and 0 (shadow drops are not applied)", len(repo.savedItems), len(repo.saveDropLogCalls))
+	}
+
+	if len(repo.shadowDecisions) != 2 {
+		t.Fatalf("recorded %d shadow decisions, want 2", len(repo.shadowDecisions))
+	}
+
+	for _, d := range repo.shadowDecisions {
+		wantDropped := d.RawMessageID == "2"
+		if d.Stage != StageGate || d.LiveDropped || d.ShadowDropped != wantDropped {
+			t.Errorf("decision = %+v", d)
+		}
+	}
+}
+
+func TestGateShadowSkippedWhileGateIsOff(t *testing.T) {
+	for _, gateEnabled := range []bool{false, true} {
+		logger := zerolog.Nop()
+		cfg := &config.Config{WorkerBatchSize: 10, RelevanceThreshold: 0.5}
+		repo := &mockRepo{
+			settings: map[string]interface{}{
+				"relevance_gate_enabled": gateEnabled,
+				SettingShadowSamples:     map[string]float64{StageGateShadow: 1},
+				SettingGateShadowModel:   "shadow-model",
+			},
+			unprocessedMessages: []db.RawMessage{
+				{ID: "1", Text: "Message 1 that is long enough to pass filters", CanonicalHash: "hash1"},
+				{ID: "2", Text: "Message 2 that is long enough to pass filters", CanonicalHash: "hash2"},
+			},
+		}
+
+		p := New(cfg, repo, &mockLLM{}, &mockEmbeddingClient{}, nil, nil, &logger)
+
+		if err := p.processNextBatch(context.Background(), "gate-shadow"); err != nil {
+			t.Fatalf("processNextBatch failed: %v", err)
+		}
+
+		want := 0
+		if gateEnabled {
+			want = 2
+		}
+
+		if len(repo.shadowDecisions) != want {
+			t.Errorf("gate enabled %v: recorded %d shadow decisions, want %d", gateEnabled, len(repo.shadowDecisions), want)
+		}
+	}
+}
+
+func TestInShadowSample(t *testing.T) {
+	sampled := 0
+
+	for i := range 1000 {
+		if inShadowSample("gate_shadow", fmt.Sprintf("msg-%d", i), 0.2) {
+			sampled++
+		}
+	}
+
+	if sampled < 150 || sampled > 250 {
+		t.Errorf("sampled %d of 1000 at rate 0.2", sampled)
+	}
+
+	if !inShadowSample("gate_shadow", "msg", 1) || inShadowSample("gate_shadow", "msg", 0) {
+		t.Error("rates 1 and 0 must sample all and nothing")
+	}
+}
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
@@ -332,3 +333,20 @@ func TestSummarizeBullets(t *testing.T) {
 		})
 	}
 }
+
+func TestItemAltText(t *testing.T) {
+	withImage := llm.MessageInput{RawMessage: domain.RawMessage{MediaData: []byte{1}}}
+
+	if got := itemAltText(llm.MessageInput{}, llm.BatchResult{AltText: "A chart"}); got != "" {
+		t.Errorf("itemAltText() without media = %q, want empty", got)
+	}
+
+	if got := itemAltText(withImage, llm.BatchResult{AltText: " <b>Smoke</b> over\n the  port "}); got != "Smoke over the port" {
+		t.Errorf("itemAltText() = %q, want cleaned text", got)
+	}
+
+	long := itemAltText(withImage, llm.BatchResult{AltText: strings.Repeat("word ", 100)})
+	if n := len([]rune(long)); n > maxAltTextRunes || !strings.HasSuffix(long, "…") {
+		t.Errorf("itemAltText() kept %d runes, want at most %d with an ellipsis", n, maxAltTextRunes)
+	}
+}
@@ -0,0 +1,234 @@
+package research
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	headerAPIKey        = "X-API-Key"
+	headerAuthorization = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	routeAPIKeyUsage = "apikeys/usage"
+
+	defaultAPIKeyUsageDays = 30
+
+	errMsgAPIKeyScope   = "API key scope does not allow this request."
+	errMsgAPIKeySession = "This endpoint requires a session login."
+)
+
+// apiKeyContextKey stores the API key that authenticated a request.
+type apiKeyContextKey struct{}
+
+// apiKeyFromHeaders returns the key sent in X-API-Key or as a bearer token.
+func apiKeyFromHeaders(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get(headerAPIKey)); key != "" {
+		return key
+	}
+
+	auth := r.Header.Get(headerAuthorization)
+	if strings.HasPrefix(auth, bearerPrefix) {
+		return strings.TrimSpace(strings.TrimPrefix(auth, bearerPrefix))
+	}
+
+	return ""
+}
+
+// apiKeyCacheTTL is how long a looked-up API key is reused. Rate changes and
+// revocations take effect within this time.
+const apiKeyCacheTTL = time.Minute
+
+// cachedAPIKey is an API key looked up by the hash of its secret.
+type cachedAPIKey struct {
+	key       *db.ResearchAPIKey
+	fetchedAt time.Time
+}
+
+// keyLimiter is the rate limiter of an API key and the rate it was built for.
+type keyLimiter struct {
+	limiter   *rate.Limiter
+	perMinute int
+}
+
+// admitRequest resolves the API key of a request and applies the rate limit.
+// Requests with a valid key are limited by the key's own rate only; all
+// others by the per-IP limit. Keys never seen before are looked up only
+// within the per-IP limit, so made-up keys cannot reach the database
+// unthrottled.
+func (h *Handler) admitRequest(r *http.Request) (*http.Request, bool) {
+	secret := apiKeyFromHeaders(r)
+	if secret == "" {
+		return r, h.allowRequest(getClientIP(r))
+	}
+
+	hash := HashAPIKey(secret)
+
+	cached, known := h.cachedAPIKey(hash)
+	key := cached.key
+
+	if !known || time.Since(cached.fetchedAt) >= apiKeyCacheTTL {
+		if !known && !h.allowRequest(getClientIP(r)) {
+			return r, false
+		}
+
+		if key = h.lookupAPIKey(r.Context(), hash); key == nil {
+			// Unknown keys were charged to the IP above; revoked ones are charged now.
+			return r, !known || h.allowRequest(getClientIP(r))
+		}
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key))
+
+	return r, h.allowAPIKey(key)
+}
+
+// cachedAPIKey returns the key cached for the hash, fresh or not.
+func (h *Handler) cachedAPIKey(hash string) (cachedAPIKey, bool) {
+	h.limitersMu.Lock()
+	defer h.limitersMu.Unlock()
+
+	cached, ok := h.apiKeys[hash]
+
+	return cached, ok
+}
+
+// lookupAPIKey loads the key from the database and caches it. Unknown keys
+// return nil; a key that was revoked since it was cached is forgotten.
+func (h *Handler) lookupAPIKey(ctx context.Context, hash string) *db.ResearchAPIKey {
+	key, err := h.db.UseResearchAPIKey(ctx, hash)
+	if err != nil {
+		if errors.Is(err, db.ErrResearchAPIKeyNotFound) {
+			h.forgetAPIKey(hash)
+		} else {
+			h.logger.Error().Err(err).Msg("look up research API key failed")
+		}
+
+		return nil
+	}
+
+	h.limitersMu.Lock()
+	h.apiKeys[hash] = cachedAPIKey{key: key, fetchedAt: time.Now()}
+	h.limitersMu.Unlock()
+
+	return key
+}
+
+// forgetAPIKey drops the cached key of the hash and its rate limiter.
+func (h *Handler) forgetAPIKey(hash string) {
+	h.limitersMu.Lock()
+	defer h.limitersMu.Unlock()
+
+	if cached, ok := h.apiKeys[hash]; ok {
+		delete(h.keyLimiters, cached.key.ID)
+		delete(h.apiKeys, hash)
+	}
+}
+
+// apiKeyFromRequest returns the API key that authenticated the request, if any.
+func apiKeyFromRequest(r *http.Request) (*db.ResearchAPIKey, bool) {
+	key, ok := r.Context().Value(apiKeyContextKey{}).(*db.ResearchAPIKey)
+
+	return key, ok
+}
+
+// apiKeyAllows reports whether the key's scope permits the request method.
+// Read-only keys may only GET; label keys may also write annotations.
+func apiKeyAllows(key *db.ResearchAPIKey, method string) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+
+	return key.Scope == db.ResearchAPIKeyScopeLabel
+}
+
+// apiKeyUserID authorizes an API key request and returns the ID of the admin
+// who created the key. Keys stop working when their creator is no longer an admin.
+func (h *Handler) apiKeyUserID(r *http.Request, key *db.ResearchAPIKey) (int64, string, bool) {
+	if !h.isAdmin(key.CreatedBy) {
+		return 0, errMsgAccessDenied, false
+	}
+
+	if !apiKeyAllows(key, r.Method) {
+		return 0, errMsgAPIKeyScope, false
+	}
+
+	return key.CreatedBy, "", true
+}
+
+// allowAPIKey applies the per-key rate limit. The limiter is rebuilt when
+// the key's rate changes.
+func (h *Handler) allowAPIKey(key *db.ResearchAPIKey) bool {
+	perMinute := max(key.RatePerMinute, 1)
+	limit := rate.Every(rateLimitWindow / time.Duration(perMinute))
+
+	h.limitersMu.Lock()
+
+	entry, ok := h.keyLimiters[key.ID]
+	if !ok || entry.perMinute != perMinute {
+		entry = &keyLimiter{limiter: rate.NewLimiter(limit, perMinute), perMinute: perMinute}
+		h.keyLimiters[key.ID] = entry
+	}
+
+	h.limitersMu.Unlock()
+
+	return entry.limiter.Allow()
+}
+
+// handleAPIKeyUsage reports requests, errors and rate-limited requests per API
+// key. Requests made with an API key only see that key's usage.
+func (h *Handler) handleAPIKeyUsage(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, _, err := parseRangeWithDefault(r, defaultAPIKeyUsageDays)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	var keyID int64
+	if key, ok := apiKeyFromRequest(r); ok {
+		keyID = key.ID
+	}
+
+	usage, err := h.db.GetResearchAPIKeyUsage(r.Context(), from, keyID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get research API key usage failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load API key usage."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(usage))
+		for _, u := range usage {
+			lastUsed := "never"
+			if u.LastUsedAt != nil {
+				lastUsed = u.LastUsedAt.Format(time.RFC3339)
+			}
+
+			rows = append(rows, []string{u.Name, u.Scope, strconv.Itoa(u.Requests), strconv.Itoa(u.Errors), strconv.Itoa(u.RateLimited), lastUsed})
+		}
+
+		data := TableViewData{
+			Title:       "API Key Usage",
+			Headers:     []string{"Key", "Scope", "Requests", "Errors", "Rate limited", "Last used"},
+			Rows:        rows,
+			Description: "Requests made with each research API key since " + from.Format(researchQueryLayout) + ", from the research audit log.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, usage), len(usage)
+}
@@ -0,0 +1,160 @@
+package research
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const (
+	audienceDefaultDays    = 30
+	audienceTopicsPerEntry = 3
+	fmtPercent0            = "%.0f%%"
+	percentScale           = 100
+)
+
+// handleAudience returns the member count of the target channel and the views
+// of the digests posted to it, grouped by posting hour (UTC), digest size and
+// topic share.
+func (h *Handler) handleAudience(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRangeWithDefault(r, audienceDefaultDays)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	snapshots, err := h.db.GetAudienceSnapshots(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get audience snapshots failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load audience snapshots."), 0
+	}
+
+	digests, err := h.db.GetDigestEngagement(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get digest engagement failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load digest engagement."), 0
+	}
+
+	if snapshots == nil {
+		snapshots = []db.AudienceSnapshot{}
+	}
+
+	if digests == nil {
+		digests = []db.DigestEngagement{}
+	}
+
+	report := db.BuildAudienceReport(snapshots, digests, time.UTC)
+
+	if wantsHTML(r) {
+		return h.renderAudience(w, r, report)
+	}
+
+	return h.writeJSON(w, http.StatusOK, report), len(digests)
+}
+
+func (h *Handler) renderAudience(w http.ResponseWriter, r *http.Request, report db.AudienceReport) (int, int) {
+	rows := make([][]string, 0, len(report.Digests))
+
+	for i := len(report.Digests) - 1; i >= 0; i-- {
+		d := report.Digests[i]
+		rows = append(rows, []string{
+			d.PostedAt.UTC().Format(time.RFC3339),
+			fmt.Sprintf("%d", d.Items),
+			fmt.Sprintf("%d", d.Views),
+			fmt.Sprintf("%d", d.Forwards),
+			formatAudienceMembers(d.Members),
+			fmt.Sprintf(fmtPercent0, d.ViewRate()*percentScale),
+			formatTopTopics(d.Topics, d.Items),
+		})
+	}
+
+	groupRows := make([][]string, 0, len(report.ByHour)+len(report.BySize)+len(report.ByTopic))
+	groupRows = appendBucketRows(groupRows, "Hour (UTC)", report.ByHour)
+	groupRows = appendBucketRows(groupRows, "Size", report.BySize)
+	groupRows = appendBucketRows(groupRows, "Topic", report.ByTopic)
+
+	data := TableViewData{
+		Title:            "Audience",
+		Headers:          []string{"Posted", "Items", "Views", "Forwards", "Members", "Reach", "Top Topics"},
+		Rows:             rows,
+		SecondaryTitle:   "Engagement by Timing, Size and Content",
+		SecondaryHeaders: []string{"Group", "Value", "Digests", "Avg Views", "Avg Reach"},
+		SecondaryRows:    groupRows,
+		Description: fmt.Sprintf("Views of posted digests with the target channel's member count before posting (%s). "+
+			"Reach is views per member. Topic rows weight each digest by the topic's share of its items.",
+			formatMemberTrend(report.Snapshots)),
+	}
+
+	if err := h.renderHTML(w, tmplTable, data); err != nil {
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+	}
+
+	return http.StatusOK, len(rows)
+}
+
+func appendBucketRows(rows [][]string, group string, buckets []db.EngagementBucket) [][]string {
+	for _, b := range buckets {
+		rows = append(rows, []string{
+			group,
+			b.Label,
+			fmt.Sprintf("%d", b.Digests),
+			fmt.Sprintf("%.0f", b.AvgViews),
+			fmt.Sprintf(fmtPercent0, b.AvgViewRate*percentScale),
+		})
+	}
+
+	return rows
+}
+
+func formatAudienceMembers(members int) string {
+	if members <= 0 {
+		return "—"
+	}
+
+	return fmt.Sprintf("%d", members)
+}
+
+// formatMemberTrend describes the member count change over the snapshots,
+// e.g. "1000 → 1040 members".
+func formatMemberTrend(snapshots []db.AudienceSnapshot) string {
+	if len(snapshots) == 0 {
+		return "no member counts recorded"
+	}
+
+	return fmt.Sprintf("%d → %d members", snapshots[0].MemberCount, snapshots[len(snapshots)-1].MemberCount)
+}
+
+// formatTopTopics lists the largest topics of a digest with their share of
+// its items, e.g. "Tech 50%, Politics 25%".
+func formatTopTopics(topics map[string]int, items int) string {
+	names := make([]string, 0, len(topics))
+	for name := range topics {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if topics[names[i]] != topics[names[j]] {
+			return topics[names[i]] > topics[names[j]]
+		}
+
+		return names[i] < names[j]
+	})
+
+	if len(names) > audienceTopicsPerEntry {
+		names = names[:audienceTopicsPerEntry]
+	}
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s "+fmtPercent0, name, float64(topics[name])/float64(max(items, 1))*percentScale))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -3,7 +3,7 @@
 // The Handler serves a UI for:
 //   - Browsing and searching digest items
 //   - Viewing clustering and deduplication results
-//   - Annotating items for quality evaluation
+//   - Annotating items for quality evaluation, including a labeling queue
 //   - Analyzing channel performance and rating trends
 //   - Exporting data for external analysis
 //
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,6 +30,7 @@ import (
 	"github.com/rs/zerolog"
 	"golang.org/x/time/rate"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/geo"
 	"github.com/lueurxax/telegram-digest-bot/internal/expandedview"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/config"
 	"github.com/lueurxax/telegram-digest-bot/internal/platform/observability"
@@ -59,6 +61,8 @@ const (
 	routeSettings  = "settings"
 	routeChannels  = "channels/"
 	routeClaims    = "claims"
+	routeMerges    = "claims/merges"
+	routeStates    = "claims/states"
 	routeRebuild   = "rebuild"
 	routeAnnotate  = "annotate"
 	routeAnnBatch  = "annotate/batch"
@@ -66,6 +70,16 @@ const (
 	routeTopics    = "topics/"
 	routeLanguages = "languages/"
 	routeDiff      = "diff/"
+	routeItemLink  = "i/"
+	routeDedup     = "dedup/decisions"
+	routeQuotes    = "quotes"
+	routeRegions   = "regions"
+	routeStories   = "stories"
+	routeStory     = "story/"
+	routeWhatIf    = "whatif"
+	routeLabel     = "label"
+	routeQuality   = "quality/trend"
+	routeAudience  = "audience"
 
 	// Scope constants.
 	scopeAll      = "all"
@@ -73,7 +87,8 @@ const (
 	scopeEvidence = "evidence"
 
 	// Route name constants (for metrics/logging).
-	routeNameItem = "item"
+	routeNameItem  = "item"
+	routeRateLimit = "rate_limit"
 
 	// Display limit constants.
 	maxDisplayedMatchedClaims = 2
@@ -150,8 +165,12 @@ const (
 	sourceDB  = "db"
 
 	// Query parameter constants.
-	queryParamChannel = "channel"
-	queryParamAsOf    = "as_of"
+	queryParamChannel        = "channel"
+	queryParamAsOf           = "as_of"
+	queryParamCursor         = "cursor"
+	queryParamEvidenceCursor = "evidence_cursor"
+	queryParamIncludeCount   = "include_count"
+	includeCountEstimate     = "estimate"
 
 	// Format constants for percentage display.
 	percentMultiplier = 100
@@ -171,6 +190,7 @@ const (
 // Static errors for err113 compliance.
 var (
 	errInvalidScope    = errors.New("invalid scope")
+	errInvalidRegion   = errors.New("invalid region")
 	errInvalidBucket   = errors.New("invalid bucket")
 	errItemNotFound    = errors.New("item not found")
 	errInvalidJSONBody = errors.New("invalid JSON body")
@@ -194,6 +214,8 @@ type Handler struct {
 	rebuildFunc   func(context.Context) error
 	limitersMu    sync.Mutex
 	limiters      map[string]*rate.Limiter
+	keyLimiters   map[int64]*keyLimiter
+	apiKeys       map[string]cachedAPIKey
 	annotateMu    sync.Mutex
 	annotate      map[int64]*rate.Limiter
 	annotateBatch map[int64]*rate.Limiter
@@ -220,6 +242,8 @@ func NewHandler(cfg *config.Config, dbConn *db.DB, tokenService *AuthTokenServic
 		logger:        logger,
 		rebuildFunc:   rebuildFunc,
 		limiters:      make(map[string]*rate.Limiter),
+		keyLimiters:   make(map[int64]*keyLimiter),
+		apiKeys:       make(map[string]cachedAPIKey),
 		annotate:      make(map[int64]*rate.Limiter),
 		annotateBatch: make(map[int64]*rate.Limiter),
 	}, nil
@@ -228,7 +252,18 @@ func NewHandler(cfg *config.Config, dbConn *db.DB, tokenService *AuthTokenServic
 // ServeHTTP routes requests to research endpoints.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
-	route, status, resultSize := h.dispatch(w, r)
+
+	var (
+		route              = routeRateLimit
+		status, resultSize int
+	)
+
+	r, allowed := h.admitRequest(r)
+	if allowed {
+		route, status, resultSize = h.dispatch(w, r)
+	} else {
+		status = h.writeError(w, r, http.StatusTooManyRequests, "Too Many Requests", errMsgRateLimited)
+	}
 
 	h.recordMetrics(route, status, resultSize, start)
 	h.maybeAuditLog(r, route, status)
@@ -236,10 +271,6 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // dispatch handles route matching and dispatches to the appropriate handler.
 func (h *Handler) dispatch(w http.ResponseWriter, r *http.Request) (route string, status int, resultSize int) {
-	if !h.allowRequest(getClientIP(r)) {
-		return "rate_limit", h.writeError(w, r, http.StatusTooManyRequests, "Too Many Requests", errMsgRateLimited), 0
-	}
-
 	path := strings.TrimPrefix(r.URL.Path, "/")
 	if path == "" {
 		return "index", h.handleIndex(w, r), 0
@@ -251,6 +282,8 @@ func (h *Handler) dispatch(w http.ResponseWriter, r *http.Request) (route string
 // dispatchPath matches the path to a handler and executes it.
 func (h *Handler) dispatchPath(w http.ResponseWriter, r *http.Request, path string) (route string, status int, resultSize int) {
 	switch {
+	case strings.HasPrefix(path, routeTelegramLogin):
+		return "login_telegram", h.handleTelegramLogin(w, r), 0
 	case strings.HasPrefix(path, routeLogin):
 		return "login", h.handleLogin(w, r), 0
 	case strings.HasPrefix(path, routeSearch):
@@ -294,6 +327,9 @@ var extendedRoutes = []extendedRoute{
 	{routeChannels + "overlap", "channels_overlap", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
 		return h.handleChannelOverlap(w, r)
 	}},
+	{routeChannels + "coordination", "channels_coordination", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleChannelCoordination(w, r)
+	}},
 	{routeChannels + "quality", "channels_quality", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
 		return h.handleChannelQualitySummary(w, r)
 	}},
@@ -312,15 +348,54 @@ var extendedRoutes = []extendedRoute{
 	{routeLanguages + "coverage", "languages_coverage", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
 		return h.handleLanguageCoverage(w, r)
 	}},
+	{routeMerges, "claims_merges", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleClaimMerges(w, r)
+	}},
+	{routeStates, "claims_states", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleClaimStates(w, r)
+	}},
 	{routeClaims, "claims", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
 		return h.handleClaims(w, r)
 	}},
 	{routeDiff + "weekly", "diff_weekly", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
 		return h.handleWeeklyDiff(w, r)
 	}},
+	{routeAPIKeyUsage, "apikeys_usage", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleAPIKeyUsage(w, r)
+	}},
 	{routeRebuild, "rebuild", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
 		return h.handleRebuild(w, r), 0
 	}},
+	{routeDedup, "dedup_decisions", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleDedupDecisions(w, r)
+	}},
+	{routeQuotes, "quotes", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleQuotes(w, r)
+	}},
+	{routeRegions, "regions", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleRegions(w, r)
+	}},
+	{routeStories, "stories", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleStories(w, r)
+	}},
+	{routeStory, "story_timeline", func(h *Handler, w http.ResponseWriter, r *http.Request, path string) (int, int) {
+		return h.handleStoryTimeline(w, r, strings.TrimPrefix(path, routeStory))
+	}},
+	{routeWhatIf, "whatif", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleWhatIf(w, r)
+	}},
+	{routeLabel, "label", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleLabel(w, r)
+	}},
+	{routeQuality, "quality_trend", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleQualityTrend(w, r)
+	}},
+	{routeAudience, "audience", func(h *Handler, w http.ResponseWriter, r *http.Request, _ string) (int, int) {
+		return h.handleAudience(w, r)
+	}},
+	{routeItemLink, "item_link", func(h *Handler, w http.ResponseWriter, r *http.Request, path string) (int, int) {
+		return h.handleItemLink(w, r, strings.TrimPrefix(path, routeItemLink)), 0
+	}},
 }
 
 // dispatchExtendedPath handles additional route patterns using table-driven routing.
@@ -346,12 +421,21 @@ func (h *Handler) recordMetrics(route string, status, resultSize int, start time
 }
 
 func (h *Handler) maybeAuditLog(r *http.Request, route string, status int) {
-	userID, ok := h.getSessionUserID(r)
-	if !ok {
+	var (
+		userID   int64
+		apiKeyID int64
+	)
+
+	if key, ok := apiKeyFromRequest(r); ok {
+		// Rejected and rate-limited key requests are logged too, for usage stats.
+		userID, apiKeyID = key.CreatedBy, key.ID
+	} else if id, ok := h.getSessionUserID(r); ok {
+		userID = id
+	} else {
 		return
 	}
 
-	if err := h.db.InsertResearchAuditLog(r.Context(), userID, route, status, getClientIP(r), hashString(r.URL.RawQuery)); err != nil {
+	if err := h.db.InsertResearchAuditLog(r.Context(), userID, apiKeyID, route, status, getClientIP(r), hashString(r.URL.RawQuery)); err != nil {
 		h.logger.Warn().Err(err).Msg("write research audit log failed")
 	}
 }
@@ -419,7 +503,13 @@ func (h *Handler) handleLogin(w http.ResponseWriter, r *http.Request) int {
 		return h.writeError(w, r, http.StatusUnauthorized, title, "Login token is invalid or expired.")
 	}
 
-	if !h.isAdmin(payload.UserID) {
+	return h.startSession(w, r, payload.UserID)
+}
+
+// startSession creates a session for an admin, sets the session cookie and
+// redirects to the dashboard.
+func (h *Handler) startSession(w http.ResponseWriter, r *http.Request, userID int64) int {
+	if !h.isAdmin(userID) {
 		return h.writeError(w, r, http.StatusUnauthorized, errTitleUnauthorized, "You do not have access.")
 	}
 
@@ -430,7 +520,7 @@ func (h *Handler) handleLogin(w http.ResponseWriter, r *http.Request) int {
 	}
 
 	expiresAt := time.Now().Add(DefaultSessionTTL)
-	if err := h.db.CreateResearchSession(r.Context(), sessionToken, payload.UserID, expiresAt); err != nil {
+	if err := h.db.CreateResearchSession(r.Context(), sessionToken, userID, expiresAt); err != nil {
 		h.logger.Error().Err(err).Msg("create research session failed")
 		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgCreateSession)
 	}
@@ -513,15 +603,20 @@ func (h *Handler) renderSearchResults(
 
 	h.applyNeedsReview(items)
 
+	nextItems := db.NextResearchItemCursor(params, items)
+	nextEvidence := db.NextResearchEvidenceCursor(params, evidence)
+
 	if wantsHTML(r) {
 		data := SearchViewData{
-			Title:         "Search Results",
-			Params:        params,
-			Scope:         scope,
-			Items:         items,
-			Evidence:      evidence,
-			ItemCount:     itemCount,
-			EvidenceCount: evCount,
+			Title:           "Search Results",
+			Params:          params,
+			Scope:           scope,
+			Items:           items,
+			Evidence:        evidence,
+			ItemCount:       itemCount,
+			EvidenceCount:   evCount,
+			NextItemsURL:    nextSearchURL(r, queryParamCursor, nextItems),
+			NextEvidenceURL: nextSearchURL(r, queryParamEvidenceCursor, nextEvidence),
 		}
 		if err := h.renderHTML(w, "search.html", data); err != nil {
 			h.logger.Error().Err(err).Msg("render search failed")
@@ -538,6 +633,14 @@ func (h *Handler) renderSearchResults(
 		EvidenceCount: evCount,
 	}
 
+	if nextItems != nil {
+		resp.NextCursor = nextItems.Encode()
+	}
+
+	if nextEvidence != nil {
+		resp.NextEvidenceCursor = nextEvidence.Encode()
+	}
+
 	return h.writeJSON(w, http.StatusOK, resp), resultSize
 }
 
@@ -610,6 +713,26 @@ func (h *Handler) handleItem(w http.ResponseWriter, r *http.Request, itemID stri
 	return h.writeJSON(w, http.StatusOK, resp)
 }
 
+// handleItemLink resolves a digest deep-link code and renders the item it points to.
+func (h *Handler) handleItemLink(w http.ResponseWriter, r *http.Request, code string) int {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized
+	}
+
+	itemID, err := h.db.ResolveItemDeepLink(r.Context(), code)
+	if err != nil {
+		if errors.Is(err, db.ErrDeepLinkNotFound) {
+			return h.writeError(w, r, http.StatusNotFound, errTitleNotFound, errMsgItemNotFound)
+		}
+
+		h.logger.Error().Err(err).Msg("resolve item deep link failed")
+
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load item.")
+	}
+
+	return h.handleItem(w, r, itemID)
+}
+
 func (h *Handler) handleCluster(w http.ResponseWriter, r *http.Request, clusterID string) int {
 	if _, ok := h.requireSession(w, r); !ok {
 		return http.StatusUnauthorized
@@ -668,12 +791,16 @@ func (h *Handler) handleEvidence(w http.ResponseWriter, r *http.Request, itemID
 				MatchedAt:          entry.MatchedAt,
 				MatchedClaimsCount: countMatchedClaims(entry.MatchedClaimsJSON),
 				MatchedClaims:      formatMatchedClaims(entry.MatchedClaimsJSON, maxDisplayedMatchedClaims),
+				Stance:             entry.Stance,
+				StanceConfidence:   entry.StanceConfidence,
+				StanceQuotes:       formatStanceQuotes(entry.StanceQuotesJSON),
 			})
 		}
 
 		data := EvidenceViewData{
 			Title:        "Evidence Sources",
 			ItemID:       itemID,
+			Verdict:      db.EvidenceVerdict(evidence),
 			EvidenceRows: rows,
 		}
 		if err := h.renderHTML(w, "evidence.html", data); err != nil {
@@ -1173,6 +1300,48 @@ func formatChannelTitle(baseTitle string, ref *db.ResearchChannelRef) string {
 	return fmt.Sprintf("%s (%s)", baseTitle, ref.Title)
 }
 
+func (h *Handler) handleChannelCoordination(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	limit := parseLimit(r, defaultSearchLimit)
+
+	pairs, err := h.db.GetChannelCoordination(r.Context(), 0, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get channel coordination failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load channel coordination."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(pairs))
+		for _, p := range pairs {
+			rows = append(rows, []string{
+				formatOverlapChannelLabel(p.ChannelA, p.ChannelATitle, p.ChannelAUsername),
+				formatOverlapChannelLabel(p.ChannelB, p.ChannelBTitle, p.ChannelBUsername),
+				strconv.Itoa(p.CoPosts),
+				strconv.Itoa(p.SharedClusters),
+				fmt.Sprintf("%.0fs", p.AvgLagSeconds),
+				fmt.Sprintf("%.3f", p.Score),
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Channel Coordination",
+			Headers:     []string{"Channel A", "Channel B", "Co-posts", "Shared clusters", "Avg lag", "Score"},
+			Rows:        rows,
+			Description: "Channel pairs that repeatedly post the same story within minutes of each other over the last week.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, pairs), len(pairs)
+}
+
 func formatOverlapChannelLabel(id, title, username string) string {
 	switch {
 	case title != "" && username != "":
@@ -1538,10 +1707,12 @@ func (h *Handler) handleClaims(w http.ResponseWriter, r *http.Request) (int, int
 			rows = append(rows, ClaimLedgerRow{
 				ID:                c.ID,
 				ClaimText:         c.ClaimText,
+				Aliases:           strings.Join(c.Aliases, " | "),
 				FirstSeenAt:       c.FirstSeenAt,
 				OriginClusterID:   c.OriginClusterID,
 				ClusterCount:      len(c.ClusterIDs),
 				ContradictedCount: len(c.ContradictedBy),
+				State:             c.State,
 			})
 		}
 
@@ -1565,6 +1736,352 @@ func (h *Handler) handleClaims(w http.ResponseWriter, r *http.Request) (int, int
 	return h.writeJSON(w, http.StatusOK, claims), len(claims)
 }
 
+func (h *Handler) handleClaimMerges(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	limit := parseLimit(r, defaultSearchLimit)
+
+	merges, err := h.db.GetClaimMergeLog(r.Context(), from, to, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get claim merges failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load claim merges."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(merges))
+		for _, m := range merges {
+			rows = append(rows, []string{
+				m.CanonicalText,
+				m.MergedText,
+				fmt.Sprintf("%.3f", m.Similarity),
+				m.MergedAt.Format(time.RFC3339),
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Claim Merges",
+			Headers:     []string{"Canonical claim", "Merged claim", "Similarity", "Merged at"},
+			Rows:        rows,
+			Description: "Claims merged by embedding similarity during the derived-tables rebuild.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, merges), len(merges)
+}
+
+// handleClaimStates lists claim lifecycle transitions, most recent first.
+func (h *Handler) handleClaimStates(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	limit := parseLimit(r, defaultSearchLimit)
+
+	changes, err := h.db.GetClaimStateChanges(r.Context(), from, to, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get claim state changes failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load claim state changes."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(changes))
+		for _, c := range changes {
+			rows = append(rows, []string{
+				c.ClaimText,
+				c.FromState,
+				c.ToState,
+				strconv.Itoa(c.Supports),
+				strconv.Itoa(c.Refutes),
+				c.ChangedAt.Format(time.RFC3339),
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Claim State Changes",
+			Headers:     []string{"Claim", "From", "To", "Supporting", "Refuting", "Changed at"},
+			Rows:        rows,
+			Description: "Claims move from unverified to corroborated, disputed or debunked as evidence stances accumulate during the derived-tables rebuild.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, changes), len(changes)
+}
+
+// handleDedupDecisions lists duplicate decisions for threshold tuning. The
+// optional id parameter narrows them to one item or message.
+func (h *Handler) handleDedupDecisions(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	q := r.URL.Query()
+	filter := db.DedupDecisionFilter{
+		From:  from,
+		To:    to,
+		Mode:  strings.TrimSpace(q.Get("mode")),
+		ID:    strings.TrimSpace(q.Get("id")),
+		Limit: parseLimit(r, defaultSearchLimit),
+	}
+
+	if filter.ID != "" && !isValidUUID(filter.ID) {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleBadRequest, "Invalid id."), 0
+	}
+
+	decisions, err := h.db.GetDedupDecisions(r.Context(), filter)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get dedup decisions failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load dedup decisions."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(decisions))
+		for _, d := range decisions {
+			rows = append(rows, []string{
+				d.Mode + "/" + d.Scope,
+				d.Text,
+				d.MatchedSummary,
+				fmt.Sprintf("%.3f", d.Similarity),
+				fmt.Sprintf("%.2f", d.Threshold),
+				d.CreatedAt.Format(time.RFC3339),
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Dedup Decisions",
+			Headers:     []string{"Mode", "Dropped message", "Matched neighbor", "Similarity", "Threshold", "Decided at"},
+			Rows:        rows,
+			Description: "Messages dropped as duplicates, with the neighbor they matched. Filter with mode=strict|semantic and id=<item or message id>.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, decisions), len(decisions)
+}
+
+// handleQuotes searches attributed quotes by speaker and quote text.
+func (h *Handler) handleQuotes(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	q := r.URL.Query()
+	filter := db.QuoteSearchFilter{
+		From:    from,
+		To:      to,
+		Speaker: strings.TrimSpace(q.Get("speaker")),
+		Query:   strings.TrimSpace(q.Get("q")),
+		Limit:   parseLimit(r, defaultSearchLimit),
+	}
+
+	quotes, err := h.db.SearchQuotes(r.Context(), filter)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("search quotes failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to search quotes."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(quotes))
+		for _, qt := range quotes {
+			rows = append(rows, []string{
+				qt.Speaker,
+				qt.Quote,
+				qt.ChannelUsername,
+				qt.TGDate.Format(time.RFC3339),
+				qt.ItemID,
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Quotes",
+			Headers:     []string{"Speaker", "Quote", "Channel", "Date", "Item"},
+			Rows:        rows,
+			Description: "Direct quotes extracted during enrichment. Filter with speaker=<name> and q=<text>.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, quotes), len(quotes)
+}
+
+// handleRegions lists how many ready items cover each region.
+func (h *Handler) handleRegions(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	counts, err := h.db.GetRegionBreakdown(r.Context(), from, to, parseLimit(r, defaultSearchLimit))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get region breakdown failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load region breakdown."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(counts))
+		for _, c := range counts {
+			rows = append(rows, []string{
+				c.Region,
+				geo.Name(c.Region),
+				strconv.Itoa(c.Items),
+				fmt.Sprintf("%.2f", c.AvgImportance),
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Regions",
+			Headers:     []string{"Code", "Region", "Items", "Avg importance"},
+			Rows:        rows,
+			Description: "Ready items per country they are about. Search one region with /research/search?region=<code>.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, counts), len(counts)
+}
+
+func (h *Handler) handleStories(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	stories, err := h.db.ListStories(r.Context(), parseLimit(r, defaultSearchLimit))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("list stories failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load stories."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(stories))
+		for _, s := range stories {
+			rows = append(rows, []string{
+				s.ID,
+				s.Title,
+				s.FirstSeenAt.Format(fmtDateTime),
+				s.LastSeenAt.Format(fmtDateTime),
+				strconv.Itoa(s.ClusterCount),
+				strconv.Itoa(s.EventCount),
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Stories",
+			Headers:     []string{"ID", "Title", "First seen", "Last seen", "Clusters", "Events"},
+			Rows:        rows,
+			Description: "Digest clusters linked across digests. Open a timeline with /research/story/<id>.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, stories), len(stories)
+}
+
+// storyTimelineResponse is the JSON body of /research/story/<id>.
+type storyTimelineResponse struct {
+	Story  *db.Story       `json:"story"`
+	Events []db.StoryEvent `json:"events"`
+}
+
+func (h *Handler) handleStoryTimeline(w http.ResponseWriter, r *http.Request, storyID string) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	story, err := h.db.GetStory(r.Context(), storyID)
+	if err != nil {
+		if errors.Is(err, db.ErrStoryNotFound) {
+			return h.writeError(w, r, http.StatusNotFound, errTitleNotFound, "Story not found."), 0
+		}
+
+		h.logger.Error().Err(err).Msg("get story failed")
+
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load story."), 0
+	}
+
+	events, err := h.db.GetStoryTimeline(r.Context(), storyID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get story timeline failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load story timeline."), 0
+	}
+
+	if wantsHTML(r) {
+		rows := make([][]string, 0, len(events))
+		for _, e := range events {
+			rows = append(rows, []string{
+				e.Date.Format(fmtDateTime),
+				e.Text,
+				e.ChannelUsername,
+				e.ItemID,
+			})
+		}
+
+		data := TableViewData{
+			Title:       "Story: " + story.Title,
+			Headers:     []string{"Date", "Development", "Channel", "Item"},
+			Rows:        rows,
+			Description: "Chronological event timeline, rebuilt after each digest that extends the story.",
+		}
+		if err := h.renderHTML(w, tmplTable, data); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+		}
+
+		return http.StatusOK, len(rows)
+	}
+
+	return h.writeJSON(w, http.StatusOK, storyTimelineResponse{Story: story, Events: events}), len(events)
+}
+
 func (h *Handler) handleWeeklyDiff(w http.ResponseWriter, r *http.Request) (int, int) {
 	if _, ok := h.requireSession(w, r); !ok {
 		return http.StatusUnauthorized, 0
@@ -1619,6 +2136,10 @@ func (h *Handler) handleWeeklyDiff(w http.ResponseWriter, r *http.Request) (int,
 }
 
 func (h *Handler) handleRebuild(w http.ResponseWriter, r *http.Request) int {
+	if _, ok := apiKeyFromRequest(r); ok {
+		return h.writeError(w, r, http.StatusForbidden, errTitleUnauthorized, errMsgAPIKeySession)
+	}
+
 	if _, ok := h.requireSession(w, r); !ok {
 		return http.StatusUnauthorized
 	}
@@ -2033,6 +2554,16 @@ func observabilityAnnotationRequest(status string, rating string, start time.Tim
 }
 
 func (h *Handler) requireSession(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	if key, ok := apiKeyFromRequest(r); ok {
+		userID, msg, ok := h.apiKeyUserID(r, key)
+		if !ok {
+			h.writeError(w, r, http.StatusForbidden, errTitleUnauthorized, msg)
+			return 0, false
+		}
+
+		return userID, true
+	}
+
 	cookie, err := r.Cookie(researchCookieName)
 	if err != nil || cookie.Value == "" {
 		h.writeError(w, r, http.StatusUnauthorized, errTitleUnauthorized, errMsgLoginRequired)
@@ -2066,6 +2597,11 @@ func (h *Handler) requireSession(w http.ResponseWriter, r *http.Request) (int64,
 }
 
 func (h *Handler) getSessionUserID(r *http.Request) (int64, bool) {
+	if key, ok := apiKeyFromRequest(r); ok {
+		userID, _, ok := h.apiKeyUserID(r, key)
+		return userID, ok
+	}
+
 	cookie, err := r.Cookie(researchCookieName)
 	if err != nil || cookie.Value == "" {
 		return 0, false
@@ -2146,12 +2682,11 @@ func getClientIP(r *http.Request) string {
 func parseSearchParams(r *http.Request) (db.ResearchSearchParams, string, error) {
 	q := r.URL.Query()
 	params := db.ResearchSearchParams{
-		Query:        strings.TrimSpace(q.Get("q")),
-		Channel:      strings.TrimSpace(q.Get(queryParamChannel)),
-		Topic:        strings.TrimSpace(q.Get("topic")),
-		Lang:         strings.TrimSpace(q.Get("lang")),
-		Provider:     strings.TrimSpace(q.Get("provider")),
-		IncludeCount: parseBool(q.Get("include_count")),
+		Query:    strings.TrimSpace(q.Get("q")),
+		Channel:  strings.TrimSpace(q.Get(queryParamChannel)),
+		Topic:    strings.TrimSpace(q.Get("topic")),
+		Lang:     strings.TrimSpace(q.Get("lang")),
+		Provider: strings.TrimSpace(q.Get("provider")),
 	}
 
 	limit := parseLimit(r, defaultSearchLimit)
@@ -2159,6 +2694,10 @@ func parseSearchParams(r *http.Request) (db.ResearchSearchParams, string, error)
 	params.Limit = limit
 	params.Offset = offset
 
+	if err := parseSearchPaging(q, &params); err != nil {
+		return params, "", err
+	}
+
 	from, to, err := parseRange(r)
 	if err != nil {
 		return params, "", err
@@ -2174,6 +2713,13 @@ func parseSearchParams(r *http.Request) (db.ResearchSearchParams, string, error)
 
 	params.SearchAt = searchAt
 
+	regions, unknown := geo.ParseList(q.Get("region"))
+	if len(unknown) > 0 {
+		return params, "", fmt.Errorf("%w: %s", errInvalidRegion, strings.Join(unknown, ","))
+	}
+
+	params.Regions = regions
+
 	scope := strings.ToLower(strings.TrimSpace(q.Get("scope")))
 	if scope == "" {
 		scope = scopeItems
@@ -2188,6 +2734,47 @@ func parseSearchParams(r *http.Request) (db.ResearchSearchParams, string, error)
 	return params, scope, nil
 }
 
+// parseSearchPaging reads the keyset cursors and the count mode:
+// include_count=estimate asks for planner estimates instead of exact counts.
+func parseSearchPaging(q url.Values, params *db.ResearchSearchParams) error {
+	includeCount := q.Get(queryParamIncludeCount)
+	params.EstimateCount = strings.EqualFold(strings.TrimSpace(includeCount), includeCountEstimate)
+	params.IncludeCount = params.EstimateCount || parseBool(includeCount)
+
+	for name, cursor := range map[string]**db.ResearchSearchCursor{
+		queryParamCursor:         &params.ItemCursor,
+		queryParamEvidenceCursor: &params.EvidenceCursor,
+	} {
+		value := strings.TrimSpace(q.Get(name))
+		if value == "" {
+			continue
+		}
+
+		c, err := db.ParseResearchSearchCursor(value)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+
+		*cursor = c
+	}
+
+	return nil
+}
+
+// nextSearchURL links to the page after a cursor, keeping the other filters.
+func nextSearchURL(r *http.Request, param string, cursor *db.ResearchSearchCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	q := r.URL.Query()
+	q.Del("page")
+	q.Del("offset")
+	q.Set(param, cursor.Encode())
+
+	return r.URL.Path + "?" + q.Encode()
+}
+
 func (h *Handler) loadSettingsSnapshot(ctx context.Context) ([]SettingEntry, error) {
 	entries := []SettingEntry{}
 
@@ -2422,24 +3009,36 @@ func wantsHTML(r *http.Request) bool {
 }
 
 func hashSearchParams(params db.ResearchSearchParams, scope string) string {
-	payload := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s|%d|%d|%t",
+	payload := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s|%s|%d|%d|%t|%t|%s|%s",
 		scope,
 		params.Query,
 		params.Channel,
 		params.Topic,
 		params.Lang,
 		params.Provider,
+		strings.Join(params.Regions, ","),
 		formatTimePtr(params.From),
 		formatTimePtr(params.To),
 		formatSearchTime(params.SearchAt),
 		params.Limit,
 		params.Offset,
 		params.IncludeCount,
+		params.EstimateCount,
+		formatSearchCursor(params.ItemCursor),
+		formatSearchCursor(params.EvidenceCursor),
 	)
 
 	return hashString(payload)
 }
 
+func formatSearchCursor(c *db.ResearchSearchCursor) string {
+	if c == nil {
+		return ""
+	}
+
+	return c.Encode()
+}
+
 func hashString(value string) string {
 	sum := sha256.Sum256([]byte(value))
 	return hex.EncodeToString(sum[:6])
@@ -2555,6 +3154,27 @@ func formatMatchedClaims(raw []byte, limit int) string {
 	return strings.Join(parts, " | ")
 }
 
+// formatStanceQuotes joins stored stance quotes for display.
+func formatStanceQuotes(raw []byte) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var quotes []string
+	if err := json.Unmarshal(raw, &quotes); err != nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(quotes))
+	for _, quote := range quotes {
+		if quote = strings.TrimSpace(quote); quote != "" {
+			parts = append(parts, "“"+quote+"”")
+		}
+	}
+
+	return strings.Join(parts, " | ")
+}
+
 func clampFloat32(value, min, max float32) float32 {
 	if value < min {
 		return min
@@ -2588,11 +3208,16 @@ func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, status int,
 		w.Header().Set(contentTypeHeader, contentTypeHTML)
 		w.WriteHeader(status)
 
-		if err := h.renderer.Render(w, "error.html", ErrorViewData{
+		data := ErrorViewData{
 			Title:   title,
 			Message: message,
 			Status:  status,
-		}); err != nil {
+		}
+		if status == http.StatusUnauthorized && h.telegramLoginEnabled() {
+			data.LoginURL = telegramLoginPath
+		}
+
+		if err := h.renderer.Render(w, "error.html", data); err != nil {
 			h.logger.Error().Err(err).Msg("failed to render error page")
 		}
 
@@ -2634,6 +3259,10 @@ type SearchResponse struct {
 	Evidence      []db.ResearchEvidenceSearchResult `json:"evidence,omitempty"`
 	ItemCount     *db.ResearchSearchResultCount     `json:"item_count,omitempty"`
 	EvidenceCount *db.ResearchSearchResultCount     `json:"evidence_count,omitempty"`
+	// NextCursor and NextEvidenceCursor fetch the following page through the
+	// cursor and evidence_cursor parameters; empty on the last page.
+	NextCursor         string `json:"next_cursor,omitempty"`
+	NextEvidenceCursor string `json:"next_evidence_cursor,omitempty"`
 }
 
 // ItemResponse is the JSON payload for item detail.
@@ -2657,13 +3286,15 @@ type IndexViewData struct {
 }
 
 type SearchViewData struct {
-	Title         string
-	Params        db.ResearchSearchParams
-	Scope         string
-	Items         []db.ResearchItemSearchResult
-	Evidence      []db.ResearchEvidenceSearchResult
-	ItemCount     *db.ResearchSearchResultCount
-	EvidenceCount *db.ResearchSearchResultCount
+	Title           string
+	Params          db.ResearchSearchParams
+	Scope           string
+	Items           []db.ResearchItemSearchResult
+	Evidence        []db.ResearchEvidenceSearchResult
+	ItemCount       *db.ResearchSearchResultCount
+	EvidenceCount   *db.ResearchSearchResultCount
+	NextItemsURL    string
+	NextEvidenceURL string
 }
 
 type ItemViewData struct {
@@ -2683,6 +3314,7 @@ type ClusterViewData struct {
 type EvidenceViewData struct {
 	Title        string
 	ItemID       string
+	Verdict      string
 	EvidenceRows []EvidenceViewRow
 }
 
@@ -2706,10 +3338,12 @@ type ClaimLedgerViewData struct {
 type ClaimLedgerRow struct {
 	ID                string
 	ClaimText         string
+	Aliases           string
 	FirstSeenAt       time.Time
 	OriginClusterID   string
 	ClusterCount      int
 	ContradictedCount int
+	State             string
 }
 
 // TableAction defines an action button for table views.
@@ -2736,6 +3370,9 @@ type EvidenceViewRow struct {
 	MatchedAt          time.Time
 	MatchedClaimsCount int
 	MatchedClaims      string
+	Stance             string
+	StanceConfidence   float32
+	StanceQuotes       string
 }
 
 type ItemExplainData struct {
@@ -2760,7 +3397,8 @@ type RelevanceGateInfo struct {
 }
 
 type ErrorViewData struct {
-	Title   string
-	Message string
-	Status  int
+	Title    string
+	Message  string
+	Status   int
+	LoginURL string
 }
@@ -1,10 +1,22 @@
 package research
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
 )
 
 const errMismatchFmt = "expected %v, got %v"
@@ -73,3 +85,240 @@ func TestValidateAnnotationRequest_InvalidRating(t *testing.T) {
 		t.Fatalf(errMismatchFmt, errInvalidRating, err)
 	}
 }
+
+func TestApplyWhatIfParam(t *testing.T) {
+	var th db.WhatIfThresholds
+
+	if err := ApplyWhatIfParam(&th, WhatIfParamImportance, "0.4"); err != nil || th.Importance != 0.4 {
+		t.Fatalf("importance = %v, err = %v", th.Importance, err)
+	}
+
+	if err := ApplyWhatIfParam(&th, WhatIfParamWeight, "@Chan:0.5"); err != nil || th.ChannelWeights["chan"] != 0.5 {
+		t.Fatalf("weights = %v, err = %v", th.ChannelWeights, err)
+	}
+
+	for _, tc := range []struct{ key, value string }{
+		{WhatIfParamRelevance, "1.5"},
+		{WhatIfParamWeight, "chan"},
+		{WhatIfParamWeight, "chan:3"},
+		{WhatIfParamWeight, ":1"},
+	} {
+		if err := ApplyWhatIfParam(&th, tc.key, tc.value); !errors.Is(err, errInvalidWhatIfParam) {
+			t.Errorf("ApplyWhatIfParam(%s=%s) error = %v, want %v", tc.key, tc.value, err, errInvalidWhatIfParam)
+		}
+	}
+
+	if err := ApplyWhatIfParam(&th, "hours", "1"); !errors.Is(err, errUnknownWhatIfParam) {
+		t.Errorf("unknown param error = %v", err)
+	}
+}
+
+func TestParseSearchPaging(t *testing.T) {
+	cursor := db.ResearchSearchCursor{Score: 0.75, ID: uuid.NewString()}
+
+	var params db.ResearchSearchParams
+	if err := parseSearchPaging(url.Values{"include_count": {"estimate"}, "cursor": {cursor.Encode()}}, &params); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !params.IncludeCount || !params.EstimateCount {
+		t.Errorf("include_count=estimate: IncludeCount = %v, EstimateCount = %v", params.IncludeCount, params.EstimateCount)
+	}
+
+	if params.ItemCursor == nil || params.ItemCursor.ID != cursor.ID || params.EvidenceCursor != nil {
+		t.Errorf("cursors = %+v, %+v", params.ItemCursor, params.EvidenceCursor)
+	}
+
+	params = db.ResearchSearchParams{}
+	if err := parseSearchPaging(url.Values{"include_count": {"1"}}, &params); err != nil || !params.IncludeCount || params.EstimateCount {
+		t.Errorf("include_count=1: %+v, err = %v", params, err)
+	}
+
+	if err := parseSearchPaging(url.Values{"evidence_cursor": {"bad"}}, &params); !errors.Is(err, db.ErrInvalidResearchCursor) {
+		t.Errorf(errMismatchFmt, db.ErrInvalidResearchCursor, err)
+	}
+}
+
+func TestFormatTopTopics(t *testing.T) {
+	topics := map[string]int{"Tech": 2, "Politics": 4, "Sports": 1, "Culture": 1}
+
+	got := formatTopTopics(topics, 8)
+	if want := "Politics 50%, Tech 25%, Culture 12%"; got != want {
+		t.Errorf(errMismatchFmt, want, got)
+	}
+
+	if got := formatTopTopics(nil, 0); got != "" {
+		t.Errorf(errMismatchFmt, "", got)
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	key, err := GenerateAPIKey()
+	if err != nil || !strings.HasPrefix(key, APIKeyPrefix) {
+		t.Fatalf("GenerateAPIKey() = %q, %v", key, err)
+	}
+
+	if HashAPIKey(key) == HashAPIKey(key+"x") || len(HashAPIKey(key)) != 64 {
+		t.Errorf("HashAPIKey() should return distinct hex SHA-256 hashes")
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/search", nil)
+	r.Header.Set(headerAuthorization, "Bearer "+key)
+
+	if got := apiKeyFromHeaders(r); got != key {
+		t.Errorf("bearer key = %q, want %q", got, key)
+	}
+
+	r.Header.Set(headerAPIKey, "header-key")
+
+	if got := apiKeyFromHeaders(r); got != "header-key" {
+		t.Errorf("X-API-Key should win, got %q", got)
+	}
+
+	readKey := &db.ResearchAPIKey{Scope: db.ResearchAPIKeyScopeRead}
+	labelKey := &db.ResearchAPIKey{Scope: db.ResearchAPIKeyScopeLabel}
+
+	if !apiKeyAllows(readKey, http.MethodGet) || apiKeyAllows(readKey, http.MethodPost) {
+		t.Error("read keys should allow GET only")
+	}
+
+	if !apiKeyAllows(labelKey, http.MethodPost) {
+		t.Error("label keys should allow POST")
+	}
+}
+
+func newRateLimitTestHandler(ipLimiter *rate.Limiter) *Handler {
+	logger := zerolog.Nop()
+
+	return &Handler{
+		logger:      &logger,
+		limiters:    map[string]*rate.Limiter{"192.0.2.1": ipLimiter},
+		keyLimiters: make(map[int64]*keyLimiter),
+		apiKeys:     make(map[string]cachedAPIKey),
+	}
+}
+
+func newAPIKeyRequest(secret string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/search", nil)
+	r.Header.Set("X-Real-IP", "192.0.2.1")
+	r.Header.Set(headerAPIKey, secret)
+
+	return r
+}
+
+func TestRateLimitedIPSkipsAPIKeyLookup(t *testing.T) {
+	h := newRateLimitTestHandler(rate.NewLimiter(0, 0))
+
+	// h.db is nil, so looking the key up would panic.
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newAPIKeyRequest("made-up-key"))
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestAPIKeyRequestsSkipIPLimit(t *testing.T) {
+	h := newRateLimitTestHandler(rate.NewLimiter(0, 0))
+	key := &db.ResearchAPIKey{ID: 7, RatePerMinute: 2}
+	h.apiKeys[HashAPIKey("valid-key")] = cachedAPIKey{key: key, fetchedAt: time.Now()}
+
+	for i := range 2 {
+		r, allowed := h.admitRequest(newAPIKeyRequest("valid-key"))
+		if !allowed {
+			t.Fatalf("request %d with a valid key was limited by the exhausted IP limit", i)
+		}
+
+		if got, ok := apiKeyFromRequest(r); !ok || got.ID != key.ID {
+			t.Fatalf("request %d has key %+v, want key %d", i, got, key.ID)
+		}
+	}
+
+	r, allowed := h.admitRequest(newAPIKeyRequest("valid-key"))
+	if allowed {
+		t.Fatal("request above the key's rate was allowed")
+	}
+
+	// Rate-limited requests keep their key, so the audit log counts them.
+	if _, ok := apiKeyFromRequest(r); !ok {
+		t.Error("rate-limited request lost its API key")
+	}
+}
+
+func TestAllowAPIKeyFollowsRateChanges(t *testing.T) {
+	h := newRateLimitTestHandler(rate.NewLimiter(0, 0))
+	key := &db.ResearchAPIKey{ID: 7, RatePerMinute: 1}
+
+	if !h.allowAPIKey(key) || h.allowAPIKey(key) {
+		t.Fatal("a key with a rate of 1 should allow exactly one request")
+	}
+
+	key.RatePerMinute = 120
+
+	if !h.allowAPIKey(key) {
+		t.Fatal("raising the rate did not take effect")
+	}
+
+	if got := h.keyLimiters[key.ID].limiter.Burst(); got != 120 {
+		t.Errorf("burst = %d, want 120", got)
+	}
+}
+
+func TestForgetAPIKeyDropsLimiter(t *testing.T) {
+	h := newRateLimitTestHandler(rate.NewLimiter(0, 0))
+	key := &db.ResearchAPIKey{ID: 7, RatePerMinute: 60}
+	hash := HashAPIKey("revoked-key")
+	h.apiKeys[hash] = cachedAPIKey{key: key, fetchedAt: time.Now()}
+	h.allowAPIKey(key)
+
+	h.forgetAPIKey(hash)
+
+	if _, ok := h.apiKeys[hash]; ok {
+		t.Error("revoked key is still cached")
+	}
+
+	if _, ok := h.keyLimiters[key.ID]; ok {
+		t.Error("revoked key still has a rate limiter")
+	}
+}
+
+func TestVerifyTelegramLogin(t *testing.T) {
+	const botToken = "123456:test-token"
+
+	now := time.Unix(1_800_000_000, 0)
+	values := url.Values{
+		"id":         {"42"},
+		"first_name": {"Ada"},
+		"username":   {"ada"},
+		"auth_date":  {"1799999900"},
+	}
+
+	secret := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte("auth_date=1799999900\nfirst_name=Ada\nid=42\nusername=ada"))
+	values.Set("hash", hex.EncodeToString(mac.Sum(nil)))
+
+	login, err := VerifyTelegramLogin(values, botToken, time.Hour, now)
+	if err != nil || login.UserID != 42 || login.Username != "ada" {
+		t.Fatalf("VerifyTelegramLogin() = %+v, %v", login, err)
+	}
+
+	if _, err := VerifyTelegramLogin(values, botToken, time.Minute, now); !errors.Is(err, ErrTelegramLoginExpired) {
+		t.Errorf(errMismatchFmt, ErrTelegramLoginExpired, err)
+	}
+
+	// A correctly signed login dated beyond the clock skew is rejected
+	if _, err := VerifyTelegramLogin(values, botToken, time.Hour, now.Add(-5*time.Minute)); !errors.Is(err, ErrTelegramLoginInvalid) {
+		t.Errorf(errMismatchFmt, ErrTelegramLoginInvalid, err)
+	}
+
+	if _, err := VerifyTelegramLogin(values, botToken, time.Hour, now.Add(-90*time.Second)); err != nil {
+		t.Errorf("login within the clock skew: %v", err)
+	}
+
+	values.Set("id", "43")
+
+	if _, err := VerifyTelegramLogin(values, botToken, time.Hour, now); !errors.Is(err, ErrTelegramLoginInvalid) {
+		t.Errorf(errMismatchFmt, ErrTelegramLoginInvalid, err)
+	}
+}
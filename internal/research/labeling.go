@@ -0,0 +1,292 @@
+package research
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// Labeling queue parameters.
+const (
+	labelDefaultLimit = 20
+	labelMaxLimit     = 50
+	labelActionSkip   = "skip"
+	labelTextMaxRunes = 1200
+
+	tmplLabel = "label.html"
+
+	errCodeNotAssigned = "not_assigned"
+	errMsgNotAssigned  = "Item is not assigned to you; reload the queue."
+	errMsgLoadQueue    = "Failed to load the labeling queue."
+	errMsgUseLabel     = "Use GET to load the queue and POST to label."
+)
+
+var errInvalidLabelLimit = errors.New("invalid limit")
+
+// labelItem is a queued item as shown to the labeler.
+type labelItem struct {
+	ItemID          string    `json:"item_id"`
+	Summary         string    `json:"summary"`
+	Text            string    `json:"text"`
+	Topic           string    `json:"topic"`
+	Channel         string    `json:"channel"`
+	Link            string    `json:"link"`
+	TGDate          time.Time `json:"tg_date"`
+	RelevanceScore  float32   `json:"relevance_score"`
+	ImportanceScore float32   `json:"importance_score"`
+}
+
+// labelStats is the progress of the annotation queue.
+type labelStats struct {
+	Pending  int `json:"pending"`
+	Assigned int `json:"assigned"`
+	Labeled  int `json:"labeled"`
+	Skipped  int `json:"skipped"`
+	Total    int `json:"total"`
+	// Percent is the share of queue items labeled or skipped.
+	Percent int `json:"percent"`
+}
+
+type labelQueueResponse struct {
+	OK    bool        `json:"ok"`
+	Items []labelItem `json:"items"`
+	Stats labelStats  `json:"stats"`
+}
+
+type labelRequest struct {
+	ItemID  string `json:"item_id"`
+	Rating  string `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+type labelResponse struct {
+	OK     bool       `json:"ok"`
+	ItemID string     `json:"item_id"`
+	Rating string     `json:"rating"`
+	Stats  labelStats `json:"stats"`
+}
+
+// LabelViewData is the data of the labeling page.
+type LabelViewData struct {
+	Title string
+	Items []labelItem
+	Stats labelStats
+}
+
+// handleLabel serves the labeling queue: GET claims a batch of queued items
+// for the session user, POST labels or skips one of them.
+func (h *Handler) handleLabel(w http.ResponseWriter, r *http.Request) (int, int) {
+	switch r.Method {
+	case http.MethodGet:
+		return h.handleLabelQueue(w, r)
+	case http.MethodPost:
+		return h.handleLabelSubmit(w, r), 0
+	default:
+		return h.writeAnnotateError(w, http.StatusMethodNotAllowed, errCodeMethodNotAllow, errMsgUseLabel), 0
+	}
+}
+
+func (h *Handler) handleLabelQueue(w http.ResponseWriter, r *http.Request) (int, int) {
+	userID, ok := h.requireSession(w, r)
+	if !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	limit, err := parseLabelLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleBadRequest, err.Error()), 0
+	}
+
+	claimed, err := h.db.ClaimAnnotations(r.Context(), userID, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("claim annotations failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgLoadQueue), 0
+	}
+
+	stats, err := h.labelStats(r)
+	if err != nil {
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgLoadQueue), 0
+	}
+
+	items := make([]labelItem, 0, len(claimed))
+	for _, it := range claimed {
+		items = append(items, toLabelItem(it))
+	}
+
+	if wantsHTML(r) {
+		if err := h.renderHTML(w, tmplLabel, LabelViewData{Title: "Labeling Queue", Items: items, Stats: stats}); err != nil {
+			return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to render labeling queue."), 0
+		}
+
+		return http.StatusOK, len(items)
+	}
+
+	return h.writeJSON(w, http.StatusOK, labelQueueResponse{OK: true, Items: items, Stats: stats}), len(items)
+}
+
+func (h *Handler) handleLabelSubmit(w http.ResponseWriter, r *http.Request) int {
+	start := time.Now()
+
+	var req labelRequest
+	if err := h.decodeJSON(w, r, &req); err != nil {
+		return h.writeAnnotateError(w, http.StatusBadRequest, errCodeInvalidJSON, err.Error())
+	}
+
+	req.Rating = strings.ToLower(strings.TrimSpace(req.Rating))
+	req.Comment = strings.TrimSpace(req.Comment)
+
+	if err := validateLabelRequest(req); err != nil {
+		return h.writeAnnotateError(w, http.StatusBadRequest, errCodeInvalidPayload, err.Error())
+	}
+
+	userID, ok := h.requireSession(w, r)
+	if !ok {
+		return http.StatusUnauthorized
+	}
+
+	if !h.allowAnnotate(userID, false) {
+		w.Header().Set(headerRetryAfter, "60")
+		return h.writeAnnotateError(w, http.StatusTooManyRequests, errCodeRateLimited, errMsgRateLimited)
+	}
+
+	item, err := h.applyLabel(r, userID, req)
+	if errors.Is(err, db.ErrAnnotationItemNotFound) {
+		return h.writeAnnotateError(w, http.StatusConflict, errCodeNotAssigned, errMsgNotAssigned)
+	}
+
+	if err != nil {
+		h.logger.Error().Err(err).Msg("label queued annotation failed")
+		return h.writeAnnotateError(w, http.StatusInternalServerError, errCodeSaveFailed, errMsgSaveAnn)
+	}
+
+	if item == nil {
+		return h.writeAnnotateError(w, http.StatusConflict, errCodeNotAssigned, errMsgNotAssigned)
+	}
+
+	rating := req.Rating
+	if rating == labelActionSkip {
+		rating = ""
+	}
+
+	observabilityAnnotationRequest("ok", rating, start)
+
+	stats, err := h.labelStats(r)
+	if err != nil {
+		return h.writeAnnotateError(w, http.StatusInternalServerError, errCodeQueryFailed, errMsgLoadQueue)
+	}
+
+	return h.writeJSON(w, http.StatusOK, labelResponse{OK: true, ItemID: req.ItemID, Rating: req.Rating, Stats: stats})
+}
+
+func (h *Handler) applyLabel(r *http.Request, userID int64, req labelRequest) (*db.AnnotationItem, error) {
+	if req.Rating == labelActionSkip {
+		item, err := h.db.SkipAnnotationByItem(r.Context(), userID, req.ItemID)
+		if err != nil {
+			return nil, fmt.Errorf("skip annotation: %w", err)
+		}
+
+		return item, nil
+	}
+
+	item, err := h.db.LabelQueuedAnnotation(r.Context(), userID, req.ItemID, req.Rating, req.Comment, db.AnnotationSourceWebQueue)
+	if err != nil {
+		return nil, fmt.Errorf("label annotation: %w", err)
+	}
+
+	return item, nil
+}
+
+func (h *Handler) labelStats(r *http.Request) (labelStats, error) {
+	counts, err := h.db.GetAnnotationStats(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get annotation stats failed")
+		return labelStats{}, fmt.Errorf("annotation stats: %w", err)
+	}
+
+	return newLabelStats(counts), nil
+}
+
+func newLabelStats(counts map[string]int) labelStats {
+	stats := labelStats{
+		Pending:  counts[db.AnnotationStatusPending],
+		Assigned: counts[db.AnnotationStatusAssigned],
+		Labeled:  counts[db.AnnotationStatusLabeled],
+		Skipped:  counts[db.AnnotationStatusSkipped],
+	}
+
+	stats.Total = stats.Pending + stats.Assigned + stats.Labeled + stats.Skipped
+	if stats.Total > 0 {
+		stats.Percent = (stats.Labeled + stats.Skipped) * percentMultiplier / stats.Total
+	}
+
+	return stats
+}
+
+func validateLabelRequest(req labelRequest) error {
+	if req.ItemID == "" {
+		return errMissingItemID
+	}
+
+	if !isValidUUID(req.ItemID) {
+		return errInvalidItemID
+	}
+
+	if req.Rating != labelActionSkip && !isValidRating(req.Rating) {
+		return errInvalidRating
+	}
+
+	if len(req.Comment) > annotationMaxComment {
+		return errCommentTooLong
+	}
+
+	return nil
+}
+
+func parseLabelLimit(value string) (int, error) {
+	if value == "" {
+		return labelDefaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > labelMaxLimit {
+		return 0, fmt.Errorf("%w: %s", errInvalidLabelLimit, value)
+	}
+
+	return limit, nil
+}
+
+func toLabelItem(it db.AnnotationItem) labelItem {
+	text := it.Text
+	if text == "" {
+		text = it.PreviewText
+	}
+
+	channel := it.ChannelTitle
+	link := fmt.Sprintf("https://t.me/c/%d/%d", it.ChannelPeerID, it.MessageID)
+
+	if it.ChannelUsername != "" {
+		channel = fmt.Sprintf(fmtChannelLabel, it.ChannelTitle, it.ChannelUsername)
+		link = fmt.Sprintf("https://t.me/%s/%d", it.ChannelUsername, it.MessageID)
+	}
+
+	if runes := []rune(text); len(runes) > labelTextMaxRunes {
+		text = string(runes[:labelTextMaxRunes]) + "…"
+	}
+
+	return labelItem{
+		ItemID:          it.ItemID,
+		Summary:         it.Summary,
+		Text:            text,
+		Topic:           it.Topic,
+		Channel:         channel,
+		Link:            link,
+		TGDate:          it.TGDate,
+		RelevanceScore:  it.RelevanceScore,
+		ImportanceScore: it.ImportanceScore,
+	}
+}
@@ -0,0 +1,109 @@
+package research
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+func TestValidateLabelRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  labelRequest
+		want error
+	}{
+		{"good", labelRequest{ItemID: uuid.NewString(), Rating: "good"}, nil},
+		{"skip", labelRequest{ItemID: uuid.NewString(), Rating: labelActionSkip}, nil},
+		{"missing item", labelRequest{Rating: "good"}, errMissingItemID},
+		{"bad uuid", labelRequest{ItemID: "x", Rating: "good"}, errInvalidItemID},
+		{"bad rating", labelRequest{ItemID: uuid.NewString(), Rating: "meh"}, errInvalidRating},
+		{"long comment", labelRequest{ItemID: uuid.NewString(), Rating: "bad", Comment: strings.Repeat("x", annotationMaxComment+1)}, errCommentTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateLabelRequest(tt.req); !errors.Is(err, tt.want) {
+				t.Errorf(errMismatchFmt, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseLabelLimit(t *testing.T) {
+	if got, err := parseLabelLimit(""); err != nil || got != labelDefaultLimit {
+		t.Errorf("parseLabelLimit(\"\") = %d, %v", got, err)
+	}
+
+	if got, err := parseLabelLimit("5"); err != nil || got != 5 {
+		t.Errorf("parseLabelLimit(5) = %d, %v", got, err)
+	}
+
+	for _, value := range []string{"0", "-1", "abc", "51"} {
+		if _, err := parseLabelLimit(value); !errors.Is(err, errInvalidLabelLimit) {
+			t.Errorf("parseLabelLimit(%q) error = %v, want %v", value, err, errInvalidLabelLimit)
+		}
+	}
+}
+
+func TestNewLabelStats(t *testing.T) {
+	stats := newLabelStats(map[string]int{
+		db.AnnotationStatusPending:  5,
+		db.AnnotationStatusAssigned: 1,
+		db.AnnotationStatusLabeled:  3,
+		db.AnnotationStatusSkipped:  1,
+	})
+
+	if stats.Total != 10 || stats.Percent != 40 {
+		t.Errorf("newLabelStats() = %+v, want total 10 and 40%%", stats)
+	}
+
+	if empty := newLabelStats(nil); empty.Percent != 0 {
+		t.Errorf("newLabelStats(nil).Percent = %d, want 0", empty.Percent)
+	}
+}
+
+func TestToLabelItem(t *testing.T) {
+	public := toLabelItem(db.AnnotationItem{ChannelUsername: "news", ChannelTitle: "News", MessageID: 42, PreviewText: "preview"})
+	if public.Link != "https://t.me/news/42" || public.Channel != "News (@news)" || public.Text != "preview" {
+		t.Errorf("toLabelItem(public) = %+v", public)
+	}
+
+	private := toLabelItem(db.AnnotationItem{ChannelTitle: "Closed", ChannelPeerID: 777, MessageID: 9, Text: strings.Repeat("я", labelTextMaxRunes+10)})
+	if private.Link != "https://t.me/c/777/9" || private.Channel != "Closed" {
+		t.Errorf("toLabelItem(private) = %+v", private)
+	}
+
+	if got := len([]rune(private.Text)); got != labelTextMaxRunes+1 {
+		t.Errorf("truncated text has %d runes, want %d", got, labelTextMaxRunes+1)
+	}
+}
+
+func TestRenderLabelTemplate(t *testing.T) {
+	renderer, err := NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	data := LabelViewData{
+		Title: "Labeling Queue",
+		Items: []labelItem{{ItemID: "id-1", Summary: "Summary <b>", Text: "Text", Link: "https://t.me/news/1", TGDate: time.Now()}},
+		Stats: labelStats{Pending: 1, Total: 2, Percent: 50},
+	}
+
+	if err := renderer.Render(&buf, tmplLabel, data); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `data-item-id="id-1"`) || !strings.Contains(out, "Summary &lt;b&gt;") {
+		t.Errorf("rendered label page misses the item or does not escape it")
+	}
+}
@@ -0,0 +1,107 @@
+package research
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+const qualityDefaultDays = 30
+
+// qualityTrendResponse is the JSON body of the quality trend endpoint.
+type qualityTrendResponse struct {
+	From        time.Time              `json:"from"`
+	To          time.Time              `json:"to"`
+	Evaluations []db.QualityEvaluation `json:"evaluations"`
+	// Pending lists model and prompt activations not yet reflected in an evaluation.
+	Pending []db.ModelPromptEvent `json:"pending"`
+}
+
+// handleQualityTrend returns the continuous evaluation time series: the
+// precision, recall and noise rate of live scores against item ratings,
+// annotated with the model and prompt activations between evaluations.
+func (h *Handler) handleQualityTrend(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRangeWithDefault(r, qualityDefaultDays)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	evals, err := h.db.GetQualityEvaluations(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get quality evaluations failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load quality evaluations."), 0
+	}
+
+	events, err := h.db.GetModelPromptEvents(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get model/prompt events failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load model and prompt changes."), 0
+	}
+
+	pending := db.AnnotateQualityEvaluations(evals, events)
+
+	if evals == nil {
+		evals = []db.QualityEvaluation{}
+	}
+
+	if pending == nil {
+		pending = []db.ModelPromptEvent{}
+	}
+
+	if wantsHTML(r) {
+		return h.renderQualityTrend(w, r, evals)
+	}
+
+	resp := qualityTrendResponse{From: from, To: to, Evaluations: evals, Pending: pending}
+
+	return h.writeJSON(w, http.StatusOK, resp), len(evals)
+}
+
+func (h *Handler) renderQualityTrend(w http.ResponseWriter, r *http.Request, evals []db.QualityEvaluation) (int, int) {
+	rows := make([][]string, 0, len(evals))
+
+	for i := len(evals) - 1; i >= 0; i-- {
+		e := evals[i]
+		rows = append(rows, []string{
+			e.EvaluatedAt.Format(time.RFC3339),
+			fmt.Sprintf("%.2f / %.2f", e.Relevance, e.Importance),
+			fmt.Sprintf("%d", e.Rated),
+			fmt.Sprintf("%.3f", e.Precision),
+			fmt.Sprintf("%.3f", e.Recall),
+			fmt.Sprintf("%.3f", e.NoiseRate),
+			fmt.Sprintf("%d / %d / %d / %d", e.TP, e.FP, e.FN, e.TN),
+			qualityEventLabels(e.Events),
+		})
+	}
+
+	data := TableViewData{
+		Title:   "Quality Trend",
+		Headers: []string{"Evaluated", "Thresholds", "Rated", "Precision", "Recall", "Noise", "TP / FP / FN / TN", "Changes"},
+		Rows:    rows,
+		Description: "Daily comparison of rated items from the previous 7 days with the relevance and importance thresholds " +
+			"at evaluation time. Good ratings are relevant; bad and irrelevant ratings are noise. " +
+			"Changes lists the model and prompt activations since the previous evaluation.",
+	}
+
+	if err := h.renderHTML(w, tmplTable, data); err != nil {
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+	}
+
+	return http.StatusOK, len(rows)
+}
+
+func qualityEventLabels(events []db.ModelPromptEvent) string {
+	labels := make([]string, 0, len(events))
+	for _, ev := range events {
+		labels = append(labels, ev.Label())
+	}
+
+	return strings.Join(labels, ", ")
+}
@@ -0,0 +1,74 @@
+package research
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	routeTelegramLogin = "login/telegram"
+	telegramLoginPath  = researchCookiePath + "/" + routeTelegramLogin
+)
+
+// TelegramLoginViewData is the data model of the Telegram login page.
+type TelegramLoginViewData struct {
+	BotUsername string
+	AuthURL     string
+}
+
+// telegramLoginEnabled reports whether the Telegram Login Widget can be used.
+// It needs the bot username; the bot's domain must also be set with BotFather.
+func (h *Handler) telegramLoginEnabled() bool {
+	return h.cfg.TelegramBotUsername != "" && h.cfg.BotToken != ""
+}
+
+// handleTelegramLogin shows the Telegram Login Widget and, when the widget
+// redirects back with signed user data, starts a session for admins.
+func (h *Handler) handleTelegramLogin(w http.ResponseWriter, r *http.Request) int {
+	if r.Method != http.MethodGet {
+		return h.writeError(w, r, http.StatusMethodNotAllowed, errTitleMethodNotAllow, "Use GET to login.")
+	}
+
+	if !h.telegramLoginEnabled() {
+		return h.writeError(w, r, http.StatusNotFound, errTitleNotFound, "Telegram login is not configured. Set TELEGRAM_BOT_USERNAME.")
+	}
+
+	if !r.URL.Query().Has("hash") {
+		return h.renderTelegramLoginPage(w, r)
+	}
+
+	login, err := VerifyTelegramLogin(r.URL.Query(), h.cfg.BotToken, DefaultLoginTokenTTL, time.Now())
+	if err != nil {
+		title := "Invalid Login"
+		if errors.Is(err, ErrTelegramLoginExpired) {
+			title = "Expired Login"
+		}
+
+		return h.writeError(w, r, http.StatusUnauthorized, title, "Telegram login data is invalid or expired.")
+	}
+
+	h.logger.Info().Int64("user_id", login.UserID).Str("username", login.Username).Msg("research Telegram login")
+
+	return h.startSession(w, r, login.UserID)
+}
+
+func (h *Handler) renderTelegramLoginPage(w http.ResponseWriter, r *http.Request) int {
+	authURL := telegramLoginPath
+	if base := strings.TrimRight(h.cfg.ExpandedViewBaseURL, "/"); base != "" {
+		authURL = base + telegramLoginPath
+	}
+
+	data := TelegramLoginViewData{
+		BotUsername: strings.TrimPrefix(h.cfg.TelegramBotUsername, "@"),
+		AuthURL:     authURL,
+	}
+
+	if err := h.renderHTML(w, "login.html", data); err != nil {
+		h.logger.Error().Err(err).Msg("render Telegram login failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to render page.")
+	}
+
+	return http.StatusOK
+}
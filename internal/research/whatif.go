@@ -0,0 +1,197 @@
+package research
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/lueurxax/telegram-digest-bot/internal/storage"
+)
+
+// What-if scenario parameters, shared by the research endpoint and /whatif.
+const (
+	WhatIfParamRelevance  = "relevance"
+	WhatIfParamImportance = "importance"
+	WhatIfParamWeight     = "weight"
+
+	whatIfDefaultDays = 1
+	whatIfMinWeight   = 0.1
+	whatIfMaxWeight   = 2.0
+	whatIfRowsLimit   = 200
+)
+
+var (
+	errInvalidWhatIfParam = errors.New("invalid what-if parameter")
+	errUnknownWhatIfParam = errors.New("unknown what-if parameter")
+)
+
+// whatIfResponse is the JSON body of the what-if endpoint.
+type whatIfResponse struct {
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Baseline   db.WhatIfThresholds `json:"baseline_thresholds"`
+	Scenario   db.WhatIfThresholds `json:"scenario_thresholds"`
+	Simulation db.WhatIfSimulation `json:"simulation"`
+	// Noise rates are the shares of rated included items rated bad or irrelevant.
+	BaselineNoiseRate float64 `json:"baseline_noise_rate"`
+	ScenarioNoiseRate float64 `json:"scenario_noise_rate"`
+}
+
+// ApplyWhatIfParam sets one scenario parameter on t: a relevance or importance
+// threshold (0-1), or a channel weight given as "<channel>:<weight>" (0.1-2).
+func ApplyWhatIfParam(t *db.WhatIfThresholds, key, value string) error {
+	switch key {
+	case WhatIfParamRelevance, WhatIfParamImportance:
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil || v < 0 || v > 1 {
+			return fmt.Errorf("%w: %s=%s", errInvalidWhatIfParam, key, value)
+		}
+
+		if key == WhatIfParamRelevance {
+			t.Relevance = float32(v)
+		} else {
+			t.Importance = float32(v)
+		}
+
+		return nil
+	case WhatIfParamWeight:
+		return applyWhatIfWeight(t, value)
+	}
+
+	return fmt.Errorf("%w: %s", errUnknownWhatIfParam, key)
+}
+
+func applyWhatIfWeight(t *db.WhatIfThresholds, value string) error {
+	idx := strings.LastIndex(value, ":")
+	if idx <= 0 {
+		return fmt.Errorf("%w: weight=%s", errInvalidWhatIfParam, value)
+	}
+
+	channel := strings.ToLower(strings.TrimPrefix(value[:idx], "@"))
+
+	w, err := strconv.ParseFloat(value[idx+1:], 32)
+	if err != nil || w < whatIfMinWeight || w > whatIfMaxWeight || channel == "" {
+		return fmt.Errorf("%w: weight=%s", errInvalidWhatIfParam, value)
+	}
+
+	weights := make(map[string]float32, len(t.ChannelWeights)+1)
+	for k, v := range t.ChannelWeights {
+		weights[k] = v
+	}
+
+	weights[channel] = float32(w)
+	t.ChannelWeights = weights
+
+	return nil
+}
+
+// currentWhatIfThresholds returns the stored global thresholds, falling back to the config.
+func (h *Handler) currentWhatIfThresholds(r *http.Request) (db.WhatIfThresholds, error) {
+	t := db.WhatIfThresholds{Relevance: h.cfg.RelevanceThreshold, Importance: h.cfg.ImportanceThreshold}
+
+	if _, err := h.getSettingValue(r.Context(), settingRelevanceThreshold, &t.Relevance); err != nil {
+		return t, err
+	}
+
+	if _, err := h.getSettingValue(r.Context(), settingImportanceThreshold, &t.Importance); err != nil {
+		return t, err
+	}
+
+	return t, nil
+}
+
+func (h *Handler) handleWhatIf(w http.ResponseWriter, r *http.Request) (int, int) {
+	if _, ok := h.requireSession(w, r); !ok {
+		return http.StatusUnauthorized, 0
+	}
+
+	from, to, err := parseRangeWithDefault(r, whatIfDefaultDays)
+	if err != nil {
+		return h.writeError(w, r, http.StatusBadRequest, errTitleInvalidRange, err.Error()), 0
+	}
+
+	baseline, err := h.currentWhatIfThresholds(r)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get what-if thresholds failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load thresholds."), 0
+	}
+
+	scenario := baseline
+
+	for _, key := range []string{WhatIfParamRelevance, WhatIfParamImportance, WhatIfParamWeight} {
+		for _, value := range r.URL.Query()[key] {
+			if err := ApplyWhatIfParam(&scenario, key, value); err != nil {
+				return h.writeError(w, r, http.StatusBadRequest, errTitleBadRequest, err.Error()), 0
+			}
+		}
+	}
+
+	items, err := h.db.GetWhatIfItems(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("get what-if items failed")
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, "Failed to load items."), 0
+	}
+
+	sim := db.SimulateWhatIf(items, baseline, scenario)
+
+	if wantsHTML(r) {
+		return h.renderWhatIf(w, r, baseline, scenario, sim)
+	}
+
+	resp := whatIfResponse{
+		From:              from,
+		To:                to,
+		Baseline:          baseline,
+		Scenario:          scenario,
+		Simulation:        sim,
+		BaselineNoiseRate: sim.Baseline.NoiseRate(),
+		ScenarioNoiseRate: sim.Scenario.NoiseRate(),
+	}
+
+	return h.writeJSON(w, http.StatusOK, resp), len(sim.Added) + len(sim.Removed)
+}
+
+func (h *Handler) renderWhatIf(w http.ResponseWriter, r *http.Request, baseline, scenario db.WhatIfThresholds, sim db.WhatIfSimulation) (int, int) {
+	rows := whatIfChangeRows("added", sim.Added, nil)
+	rows = whatIfChangeRows("removed", sim.Removed, rows)
+
+	data := TableViewData{
+		Title:   "What-if Simulator",
+		Headers: []string{"Change", "Item", "Channel", "Topic", "Relevance", "Importance", "Rating"},
+		Rows:    rows,
+		Description: fmt.Sprintf("Baseline (relevance %.2f, importance %.2f): %d of %d items, noise %.0f%%. "+
+			"Scenario (relevance %.2f, importance %.2f): %d items, noise %.0f%%. "+
+			"Set relevance=, importance= and weight=<channel>:<weight> in the query.",
+			baseline.Relevance, baseline.Importance, sim.Baseline.Included, sim.Baseline.Scored, sim.Baseline.NoiseRate()*percentMultiplier,
+			scenario.Relevance, scenario.Importance, sim.Scenario.Included, sim.Scenario.NoiseRate()*percentMultiplier),
+	}
+
+	if err := h.renderHTML(w, tmplTable, data); err != nil {
+		return h.writeError(w, r, http.StatusInternalServerError, errTitleError, errMsgRenderTable), 0
+	}
+
+	return http.StatusOK, len(rows)
+}
+
+func whatIfChangeRows(change string, items []db.WhatIfItem, rows [][]string) [][]string {
+	for _, it := range items {
+		if len(rows) == whatIfRowsLimit {
+			break
+		}
+
+		rows = append(rows, []string{
+			change,
+			it.ItemID,
+			it.ChannelUsername,
+			it.Topic,
+			fmt.Sprintf("%.2f", it.RelevanceScore),
+			fmt.Sprintf("%.2f", it.ImportanceScore),
+			it.Rating,
+		})
+	}
+
+	return rows
+}
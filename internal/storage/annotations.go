@@ -17,6 +17,11 @@ const (
 	AnnotationStatusLabeled  = "labeled"
 	AnnotationStatusSkipped  = "skipped"
 
+	// AnnotationSourceBot and AnnotationSourceWebQueue are the item_ratings
+	// sources of labels from the bot and the web labeling queue.
+	AnnotationSourceBot      = "bot-annotate"
+	AnnotationSourceWebQueue = "web-queue"
+
 	errBeginTransaction       = "begin transaction: %w"
 	errCommitTransaction      = "commit transaction: %w"
 	errUpdateAnnotationQueue  = "update annotation queue: %w"
@@ -26,6 +31,10 @@ const (
 
 var errInvalidItemID = errors.New("invalid item id")
 
+// ErrAnnotationItemNotFound is returned when the item of an annotation no
+// longer exists.
+var ErrAnnotationItemNotFound = errors.New("annotation item not found")
+
 type AnnotationItem struct {
 	ItemID          string
 	Summary         string
@@ -47,6 +56,12 @@ type AnnotationExport struct {
 	Label           string
 	RelevanceScore  float32
 	ImportanceScore float32
+	Summary         string
+	Text            string
+	ChannelUsername string
+	ChannelTitle    string
+	ChannelPeerID   int64
+	MessageID       int64
 }
 
 func (db *DB) EnqueueAnnotationItems(ctx context.Context, since time.Time, limit int) (int, error) {
@@ -107,6 +122,67 @@ func (db *DB) AssignNextAnnotation(ctx context.Context, userID int64) (*Annotati
 	return db.getAnnotationItemByID(ctx, fromUUID(itemID))
 }
 
+// ClaimAnnotations assigns pending queue items to userID until limit items
+// are assigned to them, and returns the assigned items, oldest assignment
+// first.
+func (db *DB) ClaimAnnotations(ctx context.Context, userID int64, limit int) ([]AnnotationItem, error) {
+	_, err := db.Pool.Exec(ctx, `
+		WITH picked AS (
+			SELECT id
+			FROM annotation_queue
+			WHERE status = $1
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT GREATEST($4 - (
+				SELECT COUNT(*) FROM annotation_queue WHERE assigned_to = $3 AND status = $2
+			), 0)
+		)
+		UPDATE annotation_queue aq
+		SET status = $2,
+			assigned_to = $3,
+			assigned_at = NOW(),
+			updated_at = NOW()
+		FROM picked
+		WHERE aq.id = picked.id
+	`, AnnotationStatusPending, AnnotationStatusAssigned, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim annotations: %w", err)
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT `+annotationItemColumns+`
+		FROM annotation_queue aq
+		JOIN items i ON aq.item_id = i.id
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels c ON rm.channel_id = c.id
+		WHERE aq.assigned_to = $1
+		  AND aq.status = $2
+		ORDER BY aq.assigned_at, aq.created_at
+		LIMIT $3
+	`, userID, AnnotationStatusAssigned, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list claimed annotations: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]AnnotationItem, 0, limit)
+
+	for rows.Next() {
+		item, err := scanAnnotationItem(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate claimed annotations: %w", err)
+	}
+
+	return items, nil
+}
+
 func (db *DB) GetAssignedAnnotation(ctx context.Context, userID int64) (*AnnotationItem, error) {
 	var itemID pgtype.UUID
 
@@ -164,7 +240,7 @@ func (db *DB) LabelAssignedAnnotation(ctx context.Context, userID int64, label,
 		UserID:   userID,
 		Rating:   label,
 		Feedback: toText(comment),
-		Source:   "bot-annotate",
+		Source:   AnnotationSourceBot,
 	})
 	if err != nil {
 		return nil, fmt.Errorf(errSaveItemRating, err)
@@ -178,6 +254,13 @@ func (db *DB) LabelAssignedAnnotation(ctx context.Context, userID int64, label,
 }
 
 func (db *DB) LabelAnnotationByItem(ctx context.Context, userID int64, itemID, label, comment string) (*AnnotationItem, error) {
+	return db.LabelQueuedAnnotation(ctx, userID, itemID, label, comment, AnnotationSourceBot)
+}
+
+// LabelQueuedAnnotation labels a queue item assigned to userID and records
+// the rating with the given source. It returns nil when the item is not
+// assigned to the user, and ErrAnnotationItemNotFound when the item is gone.
+func (db *DB) LabelQueuedAnnotation(ctx context.Context, userID int64, itemID, label, comment, source string) (*AnnotationItem, error) {
 	itemUUID := toUUID(itemID)
 	if !itemUUID.Valid {
 		return nil, fmt.Errorf(errInvalidItemIDFormat, errInvalidItemID, itemID)
@@ -218,7 +301,7 @@ func (db *DB) LabelAnnotationByItem(ctx context.Context, userID int64, itemID, l
 		UserID:   userID,
 		Rating:   label,
 		Feedback: toText(comment),
-		Source:   "bot-annotate",
+		Source:   source,
 	})
 	if err != nil {
 		return nil, fmt.Errorf(errSaveItemRating, err)
@@ -316,9 +399,13 @@ func (db *DB) GetAnnotationStats(ctx context.Context) (map[string]int, error) {
 
 func (db *DB) GetLabeledAnnotations(ctx context.Context, limit int) ([]AnnotationExport, error) {
 	rows, err := db.Pool.Query(ctx, `
-		SELECT i.id, aq.label, i.relevance_score, i.importance_score
+		SELECT i.id, aq.label, i.relevance_score, i.importance_score,
+		       COALESCE(i.summary, ''), COALESCE(rm.text, ''),
+		       COALESCE(c.username, ''), COALESCE(c.title, ''), c.tg_peer_id, rm.tg_message_id
 		FROM annotation_queue aq
 		JOIN items i ON aq.item_id = i.id
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels c ON rm.channel_id = c.id
 		WHERE aq.status = $1
 		  AND aq.label IS NOT NULL
 		ORDER BY aq.updated_at DESC
@@ -339,7 +426,8 @@ func (db *DB) GetLabeledAnnotations(ctx context.Context, limit int) ([]Annotatio
 
 		var rec AnnotationExport
 
-		if err := rows.Scan(&itemID, &label, &rec.RelevanceScore, &rec.ImportanceScore); err != nil {
+		if err := rows.Scan(&itemID, &label, &rec.RelevanceScore, &rec.ImportanceScore,
+			&rec.Summary, &rec.Text, &rec.ChannelUsername, &rec.ChannelTitle, &rec.ChannelPeerID, &rec.MessageID); err != nil {
 			return nil, fmt.Errorf("scan labeled annotation row: %w", err)
 		}
 
@@ -358,7 +446,27 @@ func (db *DB) GetLabeledAnnotations(ctx context.Context, limit int) ([]Annotatio
 
 func (db *DB) getAnnotationItemByID(ctx context.Context, id string) (*AnnotationItem, error) {
 	row := db.Pool.QueryRow(ctx, `
-		SELECT i.id,
+		SELECT `+annotationItemColumns+`
+		FROM items i
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels c ON rm.channel_id = c.id
+		WHERE i.id = $1
+	`, toUUID(id))
+
+	item, err := scanAnnotationItem(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAnnotationItemNotFound
+		}
+
+		return nil, fmt.Errorf("get annotation item by id: %w", err)
+	}
+
+	return item, nil
+}
+
+// annotationItemColumns are the columns read by scanAnnotationItem.
+const annotationItemColumns = `i.id,
 		       i.summary,
 		       i.topic,
 		       i.relevance_score,
@@ -370,13 +478,9 @@ func (db *DB) getAnnotationItemByID(ctx context.Context, id string) (*Annotation
 		       rm.tg_message_id,
 		       c.username,
 		       c.title,
-		       c.tg_peer_id
-		FROM items i
-		JOIN raw_messages rm ON i.raw_message_id = rm.id
-		JOIN channels c ON rm.channel_id = c.id
-		WHERE i.id = $1
-	`, toUUID(id))
+		       c.tg_peer_id`
 
+func scanAnnotationItem(row pgx.Row) (*AnnotationItem, error) {
 	var (
 		itemID  pgtype.UUID
 		summary pgtype.Text
@@ -404,11 +508,7 @@ func (db *DB) getAnnotationItemByID(ctx context.Context, id string) (*Annotation
 		&title,
 		&item.ChannelPeerID,
 	); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil //nolint:nilnil // nil,nil indicates annotation item not found
-		}
-
-		return nil, fmt.Errorf("get annotation item by id: %w", err)
+		return nil, fmt.Errorf("scan annotation item: %w", err)
 	}
 
 	item.ItemID = fromUUID(itemID)
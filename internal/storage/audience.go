@@ -0,0 +1,367 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Digest size bands of the audience report, by item count.
+const (
+	audienceSizeSmall  = 5
+	audienceSizeMedium = 10
+	audienceSizeLarge  = 20
+)
+
+// AudienceSnapshot is the member count of the target channel at a point in time.
+type AudienceSnapshot struct {
+	RecordedAt  time.Time `json:"recorded_at"`
+	MemberCount int       `json:"member_count"`
+}
+
+// PostedDigestMessage is a posted digest and its message in the target channel.
+type PostedDigestMessage struct {
+	DigestID string
+	MsgID    int64
+}
+
+// DigestEngagement is the reach of a posted digest and what it contained.
+type DigestEngagement struct {
+	DigestID string    `json:"digest_id"`
+	PostedAt time.Time `json:"posted_at"`
+	Items    int       `json:"items"`
+	Views    int       `json:"views"`
+	Forwards int       `json:"forwards"`
+	// Members is the latest member count recorded before the digest was
+	// posted, 0 when none was recorded.
+	Members int `json:"members"`
+	// Topics counts the digest's items per topic.
+	Topics map[string]int `json:"topics"`
+}
+
+// ViewRate is the share of members that viewed the digest, 0 when the member
+// count is unknown.
+func (e DigestEngagement) ViewRate() float64 {
+	if e.Members <= 0 {
+		return 0
+	}
+
+	return float64(e.Views) / float64(e.Members)
+}
+
+// EngagementBucket averages the engagement of a group of digests.
+type EngagementBucket struct {
+	Label       string  `json:"label"`
+	Digests     int     `json:"digests"`
+	AvgViews    float64 `json:"avg_views"`
+	AvgViewRate float64 `json:"avg_view_rate"`
+}
+
+// AudienceReport correlates the growth of the target channel with the timing,
+// size and content mix of the digests posted to it.
+type AudienceReport struct {
+	Snapshots []AudienceSnapshot `json:"snapshots"`
+	Digests   []DigestEngagement `json:"digests"`
+	ByHour    []EngagementBucket `json:"by_hour"`
+	BySize    []EngagementBucket `json:"by_size"`
+	ByTopic   []EngagementBucket `json:"by_topic"`
+}
+
+// SaveAudienceSnapshot records the member count of a chat.
+func (db *DB) SaveAudienceSnapshot(ctx context.Context, chatID int64, members int) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO audience_snapshots (chat_id, member_count) VALUES ($1, $2)
+	`, chatID, members); err != nil {
+		return fmt.Errorf("save audience snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetPostedDigestMessages returns the digests posted to a chat since the
+// given time that have a message id.
+func (db *DB) GetPostedDigestMessages(ctx context.Context, chatID int64, since time.Time) ([]PostedDigestMessage, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, posted_msg_id
+		FROM digests
+		WHERE status = 'posted' AND posted_chat_id = $1 AND posted_msg_id IS NOT NULL AND posted_at >= $2
+		ORDER BY posted_at
+	`, chatID, since)
+	if err != nil {
+		return nil, fmt.Errorf("get posted digest messages: %w", err)
+	}
+	defer rows.Close()
+
+	var digests []PostedDigestMessage
+
+	for rows.Next() {
+		var (
+			id pgtype.UUID
+			d  PostedDigestMessage
+		)
+
+		if err := rows.Scan(&id, &d.MsgID); err != nil {
+			return nil, fmt.Errorf("scan posted digest message: %w", err)
+		}
+
+		d.DigestID = fromUUID(id)
+		digests = append(digests, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate posted digest messages: %w", err)
+	}
+
+	return digests, nil
+}
+
+// SaveDigestPostStats stores the current view and forward counts of a posted
+// digest.
+func (db *DB) SaveDigestPostStats(ctx context.Context, digestID string, views, forwards int) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO digest_post_stats (digest_id, views, forwards) VALUES ($1, $2, $3)
+		ON CONFLICT (digest_id) DO UPDATE SET views = $2, forwards = $3, updated_at = now()
+	`, toUUID(digestID), views, forwards); err != nil {
+		return fmt.Errorf("save digest post stats: %w", err)
+	}
+
+	return nil
+}
+
+// GetAudienceSnapshots returns the member counts recorded in [since, until]
+// for the chat recorded last, oldest first.
+func (db *DB) GetAudienceSnapshots(ctx context.Context, since, until time.Time) ([]AudienceSnapshot, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT recorded_at, member_count
+		FROM audience_snapshots
+		WHERE recorded_at >= $1 AND recorded_at <= $2
+		  AND chat_id = (SELECT chat_id FROM audience_snapshots ORDER BY recorded_at DESC LIMIT 1)
+		ORDER BY recorded_at
+	`, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("get audience snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []AudienceSnapshot
+
+	for rows.Next() {
+		var s AudienceSnapshot
+
+		if err := rows.Scan(&s.RecordedAt, &s.MemberCount); err != nil {
+			return nil, fmt.Errorf("scan audience snapshot: %w", err)
+		}
+
+		snapshots = append(snapshots, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate audience snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetDigestEngagement returns the views of the digests posted in
+// [since, until], with the member count before posting and the topics of the items each
+// digest marked as digested, oldest first.
+func (db *DB) GetDigestEngagement(ctx context.Context, since, until time.Time) ([]DigestEngagement, error) {
+	rows, err := db.Pool.Query(ctx, `
+		WITH ordered AS (
+			SELECT id, window_start, posted_at, posted_chat_id,
+			       lag(posted_at) OVER (ORDER BY posted_at) AS previous_posted_at
+			FROM digests
+			WHERE status = 'posted' AND posted_at IS NOT NULL
+		),
+		posted AS (
+			SELECT o.id, o.window_start, o.posted_at, o.previous_posted_at, s.views, s.forwards,
+			       COALESCE((SELECT a.member_count FROM audience_snapshots a
+			                 WHERE a.chat_id = o.posted_chat_id AND a.recorded_at <= o.posted_at
+			                 ORDER BY a.recorded_at DESC LIMIT 1), 0) AS members
+			FROM ordered o
+			JOIN digest_post_stats s ON s.digest_id = o.id
+			WHERE o.posted_at >= $1 AND o.posted_at <= $2
+		)
+		SELECT p.id, p.posted_at, p.views, p.forwards, p.members, COALESCE(i.topic, ''), count(i.id)
+		FROM posted p
+		LEFT JOIN items i ON i.digested_at > COALESCE(p.previous_posted_at, p.window_start) AND i.digested_at <= p.posted_at
+		GROUP BY p.id, p.posted_at, p.views, p.forwards, p.members, i.topic
+		ORDER BY p.posted_at, p.id
+	`, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("get digest engagement: %w", err)
+	}
+	defer rows.Close()
+
+	var digests []DigestEngagement
+
+	for rows.Next() {
+		var (
+			id                       pgtype.UUID
+			postedAt                 time.Time
+			views, forwards, members int32
+			topic                    string
+			count                    int64
+		)
+
+		if err := rows.Scan(&id, &postedAt, &views, &forwards, &members, &topic, &count); err != nil {
+			return nil, fmt.Errorf("scan digest engagement: %w", err)
+		}
+
+		digestID := fromUUID(id)
+		if len(digests) == 0 || digests[len(digests)-1].DigestID != digestID {
+			digests = append(digests, DigestEngagement{
+				DigestID: digestID,
+				PostedAt: postedAt,
+				Views:    int(views),
+				Forwards: int(forwards),
+				Members:  int(members),
+				Topics:   make(map[string]int),
+			})
+		}
+
+		d := &digests[len(digests)-1]
+		d.Items += int(count)
+
+		if topic != "" && count > 0 {
+			d.Topics[topic] += int(count)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate digest engagement: %w", err)
+	}
+
+	return digests, nil
+}
+
+// BuildAudienceReport groups the digests by posting hour in loc, by size and
+// by topic. A digest counts toward a topic in proportion to the topic's share
+// of its items.
+func BuildAudienceReport(snapshots []AudienceSnapshot, digests []DigestEngagement, loc *time.Location) AudienceReport {
+	report := AudienceReport{Snapshots: snapshots, Digests: digests}
+
+	hours := make(map[int]*engagementAcc)
+	sizes := make(map[int]*engagementAcc)
+	topics := make(map[string]*engagementAcc)
+
+	for _, d := range digests {
+		hour := d.PostedAt.In(loc).Hour()
+		accFor(hours, hour).add(d, 1)
+		accFor(sizes, digestSizeBand(d.Items)).add(d, 1)
+
+		for topic, count := range d.Topics {
+			accFor(topics, topic).add(d, float64(count)/float64(d.Items))
+		}
+	}
+
+	for _, hour := range sortedKeys(hours) {
+		report.ByHour = append(report.ByHour, hours[hour].bucket(fmt.Sprintf("%02d:00", hour)))
+	}
+
+	for _, band := range sortedKeys(sizes) {
+		report.BySize = append(report.BySize, sizes[band].bucket(digestSizeLabel(band)))
+	}
+
+	for topic, acc := range topics {
+		report.ByTopic = append(report.ByTopic, acc.bucket(topic))
+	}
+
+	sort.Slice(report.ByTopic, func(i, j int) bool {
+		a, b := report.ByTopic[i], report.ByTopic[j]
+		if a.Digests != b.Digests {
+			return a.Digests > b.Digests
+		}
+
+		return a.Label < b.Label
+	})
+
+	return report
+}
+
+// engagementAcc accumulates weighted views and view rates of digests.
+type engagementAcc struct {
+	digests    int
+	weight     float64
+	views      float64
+	rateWeight float64
+	rate       float64
+}
+
+func accFor[K comparable](accs map[K]*engagementAcc, key K) *engagementAcc {
+	acc, ok := accs[key]
+	if !ok {
+		acc = &engagementAcc{}
+		accs[key] = acc
+	}
+
+	return acc
+}
+
+func (a *engagementAcc) add(d DigestEngagement, weight float64) {
+	a.digests++
+	a.weight += weight
+	a.views += weight * float64(d.Views)
+
+	// Digests posted before the first member count are left out of the rate
+	if d.Members > 0 {
+		a.rateWeight += weight
+		a.rate += weight * d.ViewRate()
+	}
+}
+
+func (a *engagementAcc) bucket(label string) EngagementBucket {
+	b := EngagementBucket{Label: label, Digests: a.digests}
+
+	if a.weight > 0 {
+		b.AvgViews = a.views / a.weight
+	}
+
+	if a.rateWeight > 0 {
+		b.AvgViewRate = a.rate / a.rateWeight
+	}
+
+	return b
+}
+
+func sortedKeys(accs map[int]*engagementAcc) []int {
+	keys := make([]int, 0, len(accs))
+	for k := range accs {
+		keys = append(keys, k)
+	}
+
+	sort.Ints(keys)
+
+	return keys
+}
+
+// digestSizeBand returns the upper item count of the size band of a digest;
+// the open-ended largest band sorts last.
+func digestSizeBand(items int) int {
+	switch {
+	case items <= audienceSizeSmall:
+		return audienceSizeSmall
+	case items <= audienceSizeMedium:
+		return audienceSizeMedium
+	case items <= audienceSizeLarge:
+		return audienceSizeLarge
+	default:
+		return audienceSizeLarge + 1
+	}
+}
+
+func digestSizeLabel(band int) string {
+	switch band {
+	case audienceSizeSmall:
+		return fmt.Sprintf("1–%d items", audienceSizeSmall)
+	case audienceSizeMedium:
+		return fmt.Sprintf("%d–%d items", audienceSizeSmall+1, audienceSizeMedium)
+	case audienceSizeLarge:
+		return fmt.Sprintf("%d–%d items", audienceSizeMedium+1, audienceSizeLarge)
+	default:
+		return fmt.Sprintf("%d+ items", audienceSizeLarge+1)
+	}
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBuildAudienceReport(t *testing.T) {
+	morning := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	evening := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	digests := []DigestEngagement{
+		{PostedAt: morning, Items: 4, Views: 400, Members: 1000, Topics: map[string]int{"Politics": 3, "Tech": 1}},
+		{PostedAt: morning.AddDate(0, 0, 1), Items: 12, Views: 200, Members: 1000, Topics: map[string]int{"Tech": 12}},
+		{PostedAt: evening, Items: 25, Views: 300, Topics: map[string]int{"Politics": 25}},
+	}
+
+	report := BuildAudienceReport(nil, digests, time.UTC)
+
+	if len(report.ByHour) != 2 || report.ByHour[0].Label != "09:00" || report.ByHour[1].Label != "18:00" {
+		t.Fatalf("ByHour = %+v, want 09:00 and 18:00", report.ByHour)
+	}
+
+	if morningBucket := report.ByHour[0]; morningBucket.Digests != 2 || morningBucket.AvgViews != 300 || math.Abs(morningBucket.AvgViewRate-0.3) > 1e-9 {
+		t.Errorf("09:00 bucket = %+v, want 2 digests, 300 views, 0.3 rate", morningBucket)
+	}
+
+	// The evening digest has no member count, so it has views but no rate
+	if eveningBucket := report.ByHour[1]; eveningBucket.AvgViews != 300 || eveningBucket.AvgViewRate != 0 {
+		t.Errorf("18:00 bucket = %+v, want 300 views and no rate", eveningBucket)
+	}
+
+	wantSizes := []string{"1–5 items", "11–20 items", "21+ items"}
+	if len(report.BySize) != len(wantSizes) {
+		t.Fatalf("BySize = %+v, want %v", report.BySize, wantSizes)
+	}
+
+	for i, want := range wantSizes {
+		if report.BySize[i].Label != want {
+			t.Errorf("BySize[%d] = %q, want %q", i, report.BySize[i].Label, want)
+		}
+	}
+
+	if len(report.ByTopic) != 2 || report.ByTopic[0].Label != "Politics" || report.ByTopic[1].Label != "Tech" {
+		t.Fatalf("ByTopic = %+v, want Politics then Tech", report.ByTopic)
+	}
+
+	// Tech is 1/4 of the first digest and all of the second
+	tech := report.ByTopic[1]
+	if want := (0.25*400 + 200) / 1.25; tech.Digests != 2 || math.Abs(tech.AvgViews-want) > 1e-9 {
+		t.Errorf("Tech bucket = %+v, want 2 digests and %v views", tech, want)
+	}
+}
+
+func TestDigestEngagementViewRate(t *testing.T) {
+	if rate := (DigestEngagement{Views: 50, Members: 200}).ViewRate(); rate != 0.25 {
+		t.Errorf("ViewRate() = %v, want 0.25", rate)
+	}
+
+	if rate := (DigestEngagement{Views: 50}).ViewRate(); rate != 0 {
+		t.Errorf("ViewRate() without members = %v, want 0", rate)
+	}
+}
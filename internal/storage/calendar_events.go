@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CalendarEvent is a future event announced in one or more items.
+type CalendarEvent struct {
+	ID string
+	// DedupKey identifies repeated announcements of the same event.
+	DedupKey string
+	Title    string
+	Kind     string
+	StartsAt time.Time
+	EndsAt   *time.Time
+	// AllDay is set when only the date is known; StartsAt is then midnight
+	// in the calendar time zone.
+	AllDay      bool
+	Location    string
+	FirstSeenAt time.Time
+	UpdatedAt   time.Time
+
+	// Read-only context filled by GetCalendarEvents: the number of items
+	// announcing the event and the earliest of them.
+	Mentions        int
+	ItemID          string
+	Summary         string
+	ChannelUsername string
+	ChannelPeerID   int64
+	MessageID       int64
+}
+
+// SaveCalendarEvents records the events announced in an item. An event with
+// the dedup key of a known event adds the item as another announcement and
+// fills in the time, end and location if the known event lacks them. The
+// item's announcements of events not in the list are removed.
+func (db *DB) SaveCalendarEvents(ctx context.Context, itemID string, events []CalendarEvent) error {
+	var (
+		keys      = make([]string, len(events))
+		titles    = make([]string, len(events))
+		kinds     = make([]string, len(events))
+		starts    = make([]time.Time, len(events))
+		ends      = make([]pgtype.Timestamptz, len(events))
+		allDay    = make([]bool, len(events))
+		locations = make([]string, len(events))
+	)
+
+	for i, e := range events {
+		keys[i] = e.DedupKey
+		titles[i] = SanitizeUTF8(e.Title)
+		kinds[i] = e.Kind
+		starts[i] = e.StartsAt
+		allDay[i] = e.AllDay
+		locations[i] = SanitizeUTF8(e.Location)
+
+		if e.EndsAt != nil {
+			ends[i] = pgtype.Timestamptz{Time: *e.EndsAt, Valid: true}
+		}
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		WITH upserted AS (
+			INSERT INTO calendar_events (dedup_key, title, kind, starts_at, ends_at, all_day, location)
+			SELECT * FROM unnest($2::text[], $3::text[], $4::text[], $5::timestamptz[], $6::timestamptz[], $7::bool[], $8::text[])
+			ON CONFLICT (dedup_key) DO UPDATE SET
+				starts_at = CASE WHEN calendar_events.all_day AND NOT EXCLUDED.all_day THEN EXCLUDED.starts_at ELSE calendar_events.starts_at END,
+				ends_at = CASE WHEN calendar_events.all_day AND NOT EXCLUDED.all_day THEN EXCLUDED.ends_at
+				               ELSE COALESCE(calendar_events.ends_at, EXCLUDED.ends_at) END,
+				all_day = calendar_events.all_day AND EXCLUDED.all_day,
+				location = CASE WHEN calendar_events.location = '' THEN EXCLUDED.location ELSE calendar_events.location END,
+				updated_at = now()
+			RETURNING id
+		),
+		removed AS (
+			DELETE FROM calendar_event_items
+			WHERE item_id = $1 AND event_id NOT IN (SELECT id FROM upserted)
+		)
+		INSERT INTO calendar_event_items (event_id, item_id)
+		SELECT id, $1 FROM upserted
+		ON CONFLICT DO NOTHING
+	`, toUUID(itemID), keys, titles, kinds, starts, ends, allDay, locations); err != nil {
+		return fmt.Errorf("save calendar events: %w", err)
+	}
+
+	return nil
+}
+
+// GetCalendarEvents returns the announced events that have not ended by from
+// and start before to, in start order. All-day events last until the end of
+// their day.
+func (db *DB) GetCalendarEvents(ctx context.Context, from, to time.Time, limit int) ([]CalendarEvent, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT e.id, e.dedup_key, e.title, e.kind, e.starts_at, e.ends_at, e.all_day, e.location, e.first_seen_at, e.updated_at,
+		       m.mentions, src.item_id, COALESCE(src.summary, ''), COALESCE(src.username, ''), COALESCE(src.tg_peer_id, 0), src.tg_message_id
+		FROM calendar_events e
+		JOIN LATERAL (
+			SELECT count(*) AS mentions FROM calendar_event_items WHERE event_id = e.id
+		) m ON m.mentions > 0
+		JOIN LATERAL (
+			SELECT i.id AS item_id, i.summary, c.username, c.tg_peer_id, rm.tg_message_id
+			FROM calendar_event_items ei
+			JOIN items i ON i.id = ei.item_id
+			JOIN raw_messages rm ON rm.id = i.raw_message_id
+			JOIN channels c ON c.id = rm.channel_id
+			WHERE ei.event_id = e.id
+			ORDER BY rm.tg_date
+			LIMIT 1
+		) src ON true
+		WHERE COALESCE(e.ends_at, e.starts_at + CASE WHEN e.all_day THEN interval '1 day' ELSE interval '0' END) >= $1
+		  AND e.starts_at < $2
+		ORDER BY e.starts_at, m.mentions DESC
+		LIMIT $3
+	`, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []CalendarEvent
+
+	for rows.Next() {
+		var (
+			e      CalendarEvent
+			id     pgtype.UUID
+			itemID pgtype.UUID
+		)
+
+		if err := rows.Scan(&id, &e.DedupKey, &e.Title, &e.Kind, &e.StartsAt, &e.EndsAt, &e.AllDay, &e.Location, &e.FirstSeenAt, &e.UpdatedAt,
+			&e.Mentions, &itemID, &e.Summary, &e.ChannelUsername, &e.ChannelPeerID, &e.MessageID); err != nil {
+			return nil, fmt.Errorf("scan calendar event: %w", err)
+		}
+
+		e.ID = fromUUID(id)
+		e.ItemID = fromUUID(itemID)
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate calendar events: %w", err)
+	}
+
+	return events, nil
+}
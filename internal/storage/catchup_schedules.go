@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrCatchupScheduleNotFound is returned when a user has no catch-up schedule.
+var ErrCatchupScheduleNotFound = errors.New("catchup schedule not found")
+
+// CatchupSchedule is a user's scheduled catch-up recap delivery.
+type CatchupSchedule struct {
+	UserID   int64
+	Timezone string
+	// Windows holds comma-separated daily HH:MM-HH:MM windows in Timezone.
+	Windows string
+	// LastDeliveredAt is the start of the last window a recap was sent in.
+	LastDeliveredAt *time.Time
+	UpdatedAt       time.Time
+}
+
+// SaveCatchupSchedule creates or replaces a user's schedule. The delivery
+// state is kept.
+func (db *DB) SaveCatchupSchedule(ctx context.Context, schedule CatchupSchedule) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO catchup_schedules (user_id, timezone, windows)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone, windows = EXCLUDED.windows, updated_at = now()
+	`, schedule.UserID, schedule.Timezone, schedule.Windows); err != nil {
+		return fmt.Errorf("save catchup schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetCatchupSchedule returns a user's schedule, or
+// ErrCatchupScheduleNotFound.
+func (db *DB) GetCatchupSchedule(ctx context.Context, userID int64) (*CatchupSchedule, error) {
+	var s CatchupSchedule
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT user_id, timezone, windows, last_delivered_at, updated_at
+		FROM catchup_schedules
+		WHERE user_id = $1
+	`, userID).Scan(&s.UserID, &s.Timezone, &s.Windows, &s.LastDeliveredAt, &s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCatchupScheduleNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get catchup schedule: %w", err)
+	}
+
+	return &s, nil
+}
+
+// ListCatchupSchedules returns all schedules.
+func (db *DB) ListCatchupSchedules(ctx context.Context) ([]CatchupSchedule, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT user_id, timezone, windows, last_delivered_at, updated_at
+		FROM catchup_schedules
+		ORDER BY user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list catchup schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []CatchupSchedule
+
+	for rows.Next() {
+		var s CatchupSchedule
+		if err := rows.Scan(&s.UserID, &s.Timezone, &s.Windows, &s.LastDeliveredAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan catchup schedule: %w", err)
+		}
+
+		schedules = append(schedules, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate catchup schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// DeleteCatchupSchedule removes a user's schedule and reports whether one
+// existed.
+func (db *DB) DeleteCatchupSchedule(ctx context.Context, userID int64) (bool, error) {
+	tag, err := db.Pool.Exec(ctx, `DELETE FROM catchup_schedules WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete catchup schedule: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// MarkCatchupScheduleDelivered records the window a recap was sent in.
+func (db *DB) MarkCatchupScheduleDelivered(ctx context.Context, userID int64, windowStart time.Time) error {
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE catchup_schedules SET last_delivered_at = $2 WHERE user_id = $1
+	`, userID, windowStart); err != nil {
+		return fmt.Errorf("mark catchup schedule delivered: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrChannelGroupNotFound is returned when no group has the given name.
+var ErrChannelGroupNotFound = errors.New("channel group not found")
+
+// ChannelGroup is a named set of channels. Zero thresholds fall back to the
+// global thresholds; a group with a target chat gets its own digest and its
+// channels are left out of the main digest.
+type ChannelGroup struct {
+	ID                  string
+	Name                string
+	RelevanceThreshold  float32
+	ImportanceThreshold float32
+	TargetChatID        int64
+	DigestWindow        string
+	LastDigestAt        time.Time
+	ChannelCount        int
+}
+
+// ChannelGroupStats summarizes a group's items over a period. The group with
+// an empty ID collects the ungrouped channels.
+type ChannelGroupStats struct {
+	GroupID        string
+	Name           string
+	Channels       int
+	ItemsCreated   int
+	ItemsDigested  int
+	AvgImportance  float64
+	AvgRelevance   float64
+	ConversionRate float64
+}
+
+// CreateChannelGroup adds an empty group.
+func (db *DB) CreateChannelGroup(ctx context.Context, name string) error {
+	if _, err := db.Pool.Exec(ctx, `INSERT INTO channel_groups (name) VALUES ($1)`, name); err != nil {
+		return fmt.Errorf("create channel group: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteChannelGroup removes the group; its channels become ungrouped.
+func (db *DB) DeleteChannelGroup(ctx context.Context, name string) error {
+	tag, err := db.Pool.Exec(ctx, `DELETE FROM channel_groups WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("delete channel group: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrChannelGroupNotFound, name)
+	}
+
+	return nil
+}
+
+// ListChannelGroups returns all groups with their active channel counts.
+func (db *DB) ListChannelGroups(ctx context.Context) ([]ChannelGroup, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT g.id, g.name, g.relevance_threshold, g.importance_threshold, g.target_chat_id,
+		       COALESCE(g.digest_window, ''), g.last_digest_at,
+		       COUNT(c.id) FILTER (WHERE c.is_active)
+		FROM channel_groups g
+		LEFT JOIN channels c ON c.group_id = g.id
+		GROUP BY g.id
+		ORDER BY g.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list channel groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []ChannelGroup
+
+	for rows.Next() {
+		var (
+			g                    ChannelGroup
+			id                   pgtype.UUID
+			relevance, important pgtype.Float4
+			target               pgtype.Int8
+			lastDigest           pgtype.Timestamptz
+			count                int64
+		)
+
+		if err := rows.Scan(&id, &g.Name, &relevance, &important, &target, &g.DigestWindow, &lastDigest, &count); err != nil {
+			return nil, fmt.Errorf("scan channel group: %w", err)
+		}
+
+		g.ID = fromUUID(id)
+		g.RelevanceThreshold = relevance.Float32
+		g.ImportanceThreshold = important.Float32
+		g.TargetChatID = target.Int64
+		g.LastDigestAt = lastDigest.Time
+		g.ChannelCount = int(count)
+		groups = append(groups, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// SetChannelGroupThresholds sets the group's relevance and importance
+// thresholds; zero clears a threshold.
+func (db *DB) SetChannelGroupThresholds(ctx context.Context, name string, relevance, importance float32) error {
+	return db.updateChannelGroup(ctx, name, `
+		UPDATE channel_groups SET relevance_threshold = NULLIF($2, 0), importance_threshold = NULLIF($3, 0)
+		WHERE name = $1
+	`, relevance, importance)
+}
+
+// SetChannelGroupDigest sets the group's digest target chat and window.
+// A zero chat ID turns the group digest off.
+func (db *DB) SetChannelGroupDigest(ctx context.Context, name string, chatID int64, window string) error {
+	return db.updateChannelGroup(ctx, name, `
+		UPDATE channel_groups SET target_chat_id = NULLIF($2::bigint, 0), digest_window = NULLIF($3, '')
+		WHERE name = $1
+	`, chatID, window)
+}
+
+// MarkChannelGroupDigested records the end of the group's last digest window.
+func (db *DB) MarkChannelGroupDigested(ctx context.Context, groupID string, windowEnd time.Time) error {
+	if _, err := db.Pool.Exec(ctx, `UPDATE channel_groups SET last_digest_at = $2 WHERE id = $1`,
+		toUUID(groupID), toTimestamptz(windowEnd)); err != nil {
+		return fmt.Errorf("mark channel group digested: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) updateChannelGroup(ctx context.Context, name, query string, args ...any) error {
+	tag, err := db.Pool.Exec(ctx, query, append([]any{name}, args...)...)
+	if err != nil {
+		return fmt.Errorf("update channel group: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrChannelGroupNotFound, name)
+	}
+
+	return nil
+}
+
+// SetChannelGroup moves the channel into the named group, or out of any group
+// when name is empty. It returns false when the channel is not tracked.
+func (db *DB) SetChannelGroup(ctx context.Context, identifier, name string) (bool, error) {
+	var groupID pgtype.UUID
+
+	if name != "" {
+		err := db.Pool.QueryRow(ctx, `SELECT id FROM channel_groups WHERE name = $1`, name).Scan(&groupID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf("%w: %s", ErrChannelGroupNotFound, name)
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("get channel group: %w", err)
+		}
+	}
+
+	tag, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET group_id = $2
+		WHERE username = $1 OR '@' || username = $1 OR tg_peer_id::text = $1
+	`, normalizeUsername(identifier), groupID)
+	if err != nil {
+		return false, fmt.Errorf("set channel group: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetChannelGroupItems returns the digest candidates of the group's channels
+// from [start, end), like GetItemsForWindowDiverse. Zero caps disable capping.
+func (db *DB) GetChannelGroupItems(ctx context.Context, groupID string, start, end time.Time, importanceThreshold float32, limit int, caps DiversityCaps) ([]Item, error) {
+	rows, err := db.Pool.Query(ctx, `
+		WITH eligible AS (
+			SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i.summary, i.language,
+			       i.status, i.first_seen_at, rm.tg_date, c.username, c.title, c.tg_peer_id, rm.tg_message_id,
+			       e.embedding, i.missed_digests,
+			       row_number() OVER (PARTITION BY rm.channel_id ORDER BY i.importance_score DESC, i.relevance_score DESC) AS channel_rank
+			FROM items i
+			JOIN raw_messages rm ON i.raw_message_id = rm.id
+			JOIN channels c ON rm.channel_id = c.id
+			JOIN channel_groups g ON c.group_id = g.id
+			LEFT JOIN embeddings e ON i.id = e.item_id
+			WHERE g.id = $7
+			  AND rm.tg_date >= $1 AND rm.tg_date < $2
+			  AND i.status = 'ready'
+			  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+			  AND channel_in_digests(c, rm.tg_date)
+			  AND i.digested_at IS NULL
+		), channel_capped AS (
+			SELECT *,
+			       row_number() OVER (PARTITION BY lower(btrim(topic)) ORDER BY importance_score DESC, relevance_score DESC) AS topic_rank
+			FROM eligible
+			WHERE $5 = 0 OR channel_rank <= $5
+		)
+		SELECT id, raw_message_id, relevance_score, importance_score, topic, summary, language,
+		       status, first_seen_at, tg_date, username, title, tg_peer_id, tg_message_id,
+		       embedding, missed_digests
+		FROM channel_capped
+		WHERE $6 = 0 OR topic_rank <= $6 OR COALESCE(btrim(topic), '') = ''
+		ORDER BY importance_score DESC, relevance_score DESC
+		LIMIT $4
+	`, toTimestamptz(start), toTimestamptz(end), importanceThreshold, safeIntToInt32(limit),
+		safeIntToInt32(caps.MaxPerChannel), safeIntToInt32(caps.MaxPerTopic), toUUID(groupID))
+	if err != nil {
+		return nil, fmt.Errorf("get channel group items: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := scanSelectionItems(rows)
+	if err != nil {
+		return nil, fmt.Errorf("get channel group items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetChannelGroupStats breaks down item counts and scores since the given
+// time by group, with ungrouped channels as the last row.
+func (db *DB) GetChannelGroupStats(ctx context.Context, since time.Time) ([]ChannelGroupStats, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT COALESCE(g.id::text, ''), COALESCE(g.name, ''),
+		       COUNT(DISTINCT c.id),
+		       COUNT(i.id),
+		       COUNT(i.digested_at),
+		       COALESCE(AVG(i.importance_score), 0)::float8,
+		       COALESCE(AVG(i.relevance_score), 0)::float8
+		FROM channels c
+		LEFT JOIN channel_groups g ON c.group_id = g.id
+		LEFT JOIN raw_messages rm ON rm.channel_id = c.id AND rm.tg_date >= $1
+		LEFT JOIN items i ON i.raw_message_id = rm.id
+		WHERE c.is_active = TRUE
+		GROUP BY g.id, g.name
+		ORDER BY g.name NULLS LAST
+	`, toTimestamptz(since))
+	if err != nil {
+		return nil, fmt.Errorf("get channel group stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []ChannelGroupStats
+
+	for rows.Next() {
+		var (
+			s                        ChannelGroupStats
+			channels, items, digests int64
+		)
+
+		if err := rows.Scan(&s.GroupID, &s.Name, &channels, &items, &digests, &s.AvgImportance, &s.AvgRelevance); err != nil {
+			return nil, fmt.Errorf("scan channel group stats: %w", err)
+		}
+
+		s.Channels, s.ItemsCreated, s.ItemsDigested = int(channels), int(items), int(digests)
+
+		if items > 0 {
+			s.ConversionRate = float64(digests) / float64(items)
+		}
+
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel group stats: %w", err)
+	}
+
+	return stats, nil
+}
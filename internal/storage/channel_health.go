@@ -0,0 +1,253 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Channel health states. Healthy, stale and dead are derived from posting activity;
+// private and deleted are detected by the reader and deactivate the channel.
+// Renamed is only recorded as an event and does not change the channel state.
+const (
+	ChannelHealthHealthy = "healthy"
+	ChannelHealthStale   = "stale"
+	ChannelHealthDead    = "dead"
+	ChannelHealthPrivate = "private"
+	ChannelHealthDeleted = "deleted"
+	ChannelHealthRenamed = "renamed"
+)
+
+const (
+	// ChannelStaleAfter is how long a channel can go without posting before it is stale.
+	ChannelStaleAfter = 7 * 24 * time.Hour
+	// ChannelDeadAfter is how long a channel can go without posting before it is dead.
+	ChannelDeadAfter = 30 * 24 * time.Hour
+
+	defaultChannelHealthEventLimit = 50
+)
+
+// ChannelHealthEvent records a change in a channel's health.
+type ChannelHealthEvent struct {
+	ID             string
+	ChannelID      string
+	Username       string
+	Title          string
+	Status         string
+	PreviousStatus string
+	Detail         string
+	CreatedAt      time.Time
+}
+
+// ClassifyChannelActivity returns the activity-based health state of a channel whose
+// latest post (or, without posts, the time it was added) is lastActivity.
+func ClassifyChannelActivity(lastActivity, now time.Time) string {
+	idle := now.Sub(lastActivity)
+
+	switch {
+	case idle >= ChannelDeadAfter:
+		return ChannelHealthDead
+	case idle >= ChannelStaleAfter:
+		return ChannelHealthStale
+	default:
+		return ChannelHealthHealthy
+	}
+}
+
+type channelActivity struct {
+	ID           string
+	Status       string
+	LastActivity time.Time
+}
+
+// RefreshChannelActivityHealth reclassifies active channels as healthy, stale or dead
+// from their latest post and records an event for every change. It returns the
+// number of channels whose state changed.
+func (db *DB) RefreshChannelActivityHealth(ctx context.Context, now time.Time) (int, error) {
+	activities, err := db.getChannelActivity(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+
+	for _, a := range activities {
+		status := ClassifyChannelActivity(a.LastActivity, now)
+		if status == a.Status {
+			continue
+		}
+
+		detail := fmt.Sprintf("last post %s", a.LastActivity.UTC().Format(time.DateOnly))
+		if err := db.SetChannelHealth(ctx, a.ID, status, detail); err != nil {
+			return changed, err
+		}
+
+		changed++
+	}
+
+	return changed, nil
+}
+
+func (db *DB) getChannelActivity(ctx context.Context) ([]channelActivity, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT c.id, c.health_status, COALESCE(MAX(rm.tg_date), c.added_at)
+		FROM channels c
+		LEFT JOIN raw_messages rm ON rm.channel_id = c.id
+		WHERE c.is_active = TRUE
+		GROUP BY c.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("get channel activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []channelActivity
+
+	for rows.Next() {
+		var (
+			id pgtype.UUID
+			a  channelActivity
+		)
+
+		if err := rows.Scan(&id, &a.Status, &a.LastActivity); err != nil {
+			return nil, fmt.Errorf("scan channel activity: %w", err)
+		}
+
+		a.ID = fromUUID(id)
+		activities = append(activities, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel activity: %w", err)
+	}
+
+	return activities, nil
+}
+
+// SetChannelHealth moves a channel to a new health state and records the change.
+// Private and deleted channels are deactivated. Renamed is recorded as an event
+// without changing the state. Setting the current state again only refreshes
+// the check time.
+func (db *DB) SetChannelHealth(ctx context.Context, channelID, status, detail string) error {
+	if status == ChannelHealthRenamed {
+		return db.recordChannelRename(ctx, channelID, detail)
+	}
+
+	deactivate := status == ChannelHealthPrivate || status == ChannelHealthDeleted
+
+	if _, err := db.Pool.Exec(ctx, `
+		WITH prev AS (
+			SELECT id, health_status FROM channels WHERE id = $1
+		),
+		upd AS (
+			UPDATE channels c
+			SET health_status = $2,
+			    health_checked_at = now(),
+			    is_active = CASE WHEN $3 THEN FALSE ELSE c.is_active END
+			FROM prev
+			WHERE c.id = prev.id
+			RETURNING c.id
+		)
+		INSERT INTO channel_health_events (channel_id, status, previous_status, detail)
+		SELECT prev.id, $2, prev.health_status, $4
+		FROM prev
+		JOIN upd ON upd.id = prev.id
+		WHERE prev.health_status <> $2
+	`, toUUID(channelID), status, deactivate, detail); err != nil {
+		return fmt.Errorf("set channel health: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) recordChannelRename(ctx context.Context, channelID, detail string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO channel_health_events (channel_id, status, previous_status, detail)
+		SELECT id, $2, health_status, $3 FROM channels WHERE id = $1
+	`, toUUID(channelID), ChannelHealthRenamed, detail); err != nil {
+		return fmt.Errorf("record channel rename: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateChannelIdentity stores a channel's current username and title after a rename.
+func (db *DB) UpdateChannelIdentity(ctx context.Context, channelID, username, title string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE channels
+		SET username = NULLIF($2, ''),
+		    title = COALESCE(NULLIF($3, ''), title)
+		WHERE id = $1
+	`, toUUID(channelID), normalizeUsername(username), SanitizeUTF8(title)); err != nil {
+		return fmt.Errorf("update channel identity: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnnotifiedChannelHealthEvents returns health events admins have not been told about yet.
+func (db *DB) GetUnnotifiedChannelHealthEvents(ctx context.Context, limit int) ([]ChannelHealthEvent, error) {
+	if limit <= 0 {
+		limit = defaultChannelHealthEventLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT e.id, e.channel_id, COALESCE(c.username, ''), COALESCE(c.title, ''),
+		       e.status, e.previous_status, e.detail, e.created_at
+		FROM channel_health_events e
+		JOIN channels c ON c.id = e.channel_id
+		WHERE e.notified_at IS NULL
+		ORDER BY e.created_at
+		LIMIT $1
+	`, safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get unnotified channel health events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []ChannelHealthEvent
+
+	for rows.Next() {
+		var (
+			id        pgtype.UUID
+			channelID pgtype.UUID
+			e         ChannelHealthEvent
+		)
+
+		if err := rows.Scan(&id, &channelID, &e.Username, &e.Title, &e.Status, &e.PreviousStatus, &e.Detail, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan channel health event: %w", err)
+		}
+
+		e.ID = fromUUID(id)
+		e.ChannelID = fromUUID(channelID)
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel health events: %w", err)
+	}
+
+	return events, nil
+}
+
+// MarkChannelHealthEventsNotified marks health events as delivered to admins.
+func (db *DB) MarkChannelHealthEventsNotified(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	uuids := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		uuids = append(uuids, toUUID(id))
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE channel_health_events SET notified_at = now() WHERE id = ANY($1)
+	`, uuids); err != nil {
+		return fmt.Errorf("mark channel health events notified: %w", err)
+	}
+
+	return nil
+}
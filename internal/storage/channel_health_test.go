@@ -0,0 +1,30 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyChannelActivity(t *testing.T) {
+	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		idle time.Duration
+		want string
+	}{
+		{name: "posted today", idle: time.Hour, want: ChannelHealthHealthy},
+		{name: "just under stale", idle: ChannelStaleAfter - time.Minute, want: ChannelHealthHealthy},
+		{name: "stale", idle: ChannelStaleAfter, want: ChannelHealthStale},
+		{name: "just under dead", idle: ChannelDeadAfter - time.Minute, want: ChannelHealthStale},
+		{name: "dead", idle: ChannelDeadAfter, want: ChannelHealthDead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyChannelActivity(now.Add(-tt.idle), now); got != tt.want {
+				t.Errorf("ClassifyChannelActivity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
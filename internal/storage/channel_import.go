@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var errChannelImportIdentifier = errors.New("channel import needs a username, peer ID or invite link")
+
+// ChannelImport is one channel of a bulk import. Username, PeerID or
+// InviteLink (checked in that order) identifies the channel; the remaining
+// fields overwrite its curated settings.
+type ChannelImport struct {
+	Username                string
+	PeerID                  int64
+	InviteLink              string
+	Context                 string
+	Category                string
+	Tone                    string
+	UpdateFreq              string
+	RelevanceThreshold      float32
+	ImportanceThreshold     float32
+	ImportanceWeight        float32
+	AutoWeightEnabled       bool
+	WeightOverride          bool
+	AutoRelevanceEnabled    bool
+	RelevanceThresholdDelta float32
+}
+
+// ImportChannel adds the channel (or reactivates it when it is already known)
+// and overwrites its context, metadata, thresholds and weight settings.
+func (db *DB) ImportChannel(ctx context.Context, c ChannelImport) error {
+	username := normalizeUsername(c.Username)
+
+	var err error
+
+	switch {
+	case username != "":
+		err = db.AddChannelByUsername(ctx, username)
+	case c.PeerID != 0:
+		err = db.AddChannelByID(ctx, c.PeerID)
+	case c.InviteLink != "":
+		err = db.AddChannelByInviteLink(ctx, c.InviteLink)
+	default:
+		return errChannelImportIdentifier
+	}
+
+	if err != nil {
+		return fmt.Errorf("import channel: %w", err)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET
+			context = NULLIF($4, ''),
+			category = NULLIF($5, ''),
+			tone = NULLIF($6, ''),
+			update_freq = NULLIF($7, ''),
+			relevance_threshold = NULLIF($8, 0),
+			importance_threshold = NULLIF($9, 0),
+			importance_weight = $10,
+			auto_weight_enabled = $11,
+			weight_override = $12,
+			auto_relevance_enabled = $13,
+			relevance_threshold_delta = $14
+		WHERE CASE
+			WHEN $1 <> '' THEN username = $1
+			WHEN $2::bigint <> 0 THEN tg_peer_id = $2
+			ELSE invite_link = $3
+		END
+	`, username, c.PeerID, c.InviteLink, c.Context, c.Category, c.Tone, c.UpdateFreq,
+		c.RelevanceThreshold, c.ImportanceThreshold, c.ImportanceWeight, c.AutoWeightEnabled,
+		c.WeightOverride, c.AutoRelevanceEnabled, c.RelevanceThresholdDelta); err != nil {
+		return fmt.Errorf("import channel settings: %w", err)
+	}
+
+	return nil
+}
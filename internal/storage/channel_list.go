@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Channel list sort orders.
+const (
+	ChannelSortName   = "name"
+	ChannelSortWeight = "weight"
+	ChannelSortNoise  = "noise"
+	ChannelSortAdded  = "added"
+)
+
+// channelListOrder maps sort orders to their ORDER BY clauses.
+var channelListOrder = map[string]string{
+	ChannelSortName:   "lower(COALESCE(NULLIF(c.title, ''), c.username, '')), c.id",
+	ChannelSortWeight: "weight DESC, c.id",
+	ChannelSortNoise:  "q.noise_rate DESC NULLS LAST, c.id",
+	ChannelSortAdded:  "c.added_at DESC, c.id",
+}
+
+// ChannelListFilter selects and orders active channels for a paged listing.
+// Zero bounds are not applied; noise bounds skip channels without quality history.
+type ChannelListFilter struct {
+	HealthStatus string
+	MinWeight    float32
+	MaxWeight    float32
+	MinNoise     float64
+	MaxNoise     float64
+	Sort         string
+}
+
+// ChannelListEntry is a channel with its health, latest noise rate and
+// snooze state. SnoozedUntil is zero unless the channel is snoozed.
+type ChannelListEntry struct {
+	Channel
+	HealthStatus    string
+	NoiseRate       float64
+	HasNoiseRate    bool
+	SnoozedUntil    time.Time
+	SnoozeIngestion bool
+}
+
+// ListChannelsPage returns one page of active channels matching the filter and
+// the total number of matching channels.
+func (db *DB) ListChannelsPage(ctx context.Context, filter ChannelListFilter, offset, limit int) ([]ChannelListEntry, int, error) {
+	order, ok := channelListOrder[filter.Sort]
+	if !ok {
+		order = channelListOrder[ChannelSortName]
+	}
+
+	rows, err := db.Pool.Query(ctx, fmt.Sprintf(`
+		SELECT c.id, c.tg_peer_id, COALESCE(c.username, ''), COALESCE(c.title, ''), COALESCE(c.invite_link, ''),
+		       COALESCE(c.context, ''), COALESCE(c.description, ''), COALESCE(c.category, ''),
+		       COALESCE(c.tone, ''), COALESCE(c.update_freq, ''),
+		       COALESCE(NULLIF(c.importance_weight, 0), $6) AS weight,
+		       COALESCE(c.weight_override, FALSE), COALESCE(c.auto_relevance_enabled, FALSE),
+		       COALESCE(c.relevance_threshold_delta, 0), c.health_status, q.noise_rate,
+		       CASE WHEN c.snoozed_until > now() THEN c.snoozed_until END, c.snooze_ingestion,
+		       COUNT(*) OVER () AS total
+		FROM channels c
+		LEFT JOIN LATERAL (
+			SELECT noise_rate FROM channel_quality_history
+			WHERE channel_id = c.id
+			ORDER BY period_end DESC
+			LIMIT 1
+		) q ON TRUE
+		WHERE c.is_active = TRUE
+		  AND ($1 = '' OR c.health_status = $1)
+		  AND ($2::real = 0 OR COALESCE(NULLIF(c.importance_weight, 0), $6) >= $2)
+		  AND ($3::real = 0 OR COALESCE(NULLIF(c.importance_weight, 0), $6) <= $3)
+		  AND ($4::float8 = 0 OR q.noise_rate >= $4)
+		  AND ($5::float8 = 0 OR q.noise_rate <= $5)
+		ORDER BY %s
+		OFFSET $7 LIMIT $8
+	`, order), filter.HealthStatus, filter.MinWeight, filter.MaxWeight, filter.MinNoise, filter.MaxNoise,
+		float32(DefaultImportanceWeight), safeIntToInt32(offset), safeIntToInt32(limit))
+	if err != nil {
+		return nil, 0, fmt.Errorf("list channels page: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		entries []ChannelListEntry
+		total   int
+	)
+
+	for rows.Next() {
+		var (
+			e       ChannelListEntry
+			id      pgtype.UUID
+			noise   pgtype.Float8
+			snoozed pgtype.Timestamptz
+			count   int64
+		)
+
+		if err := rows.Scan(&id, &e.TGPeerID, &e.Username, &e.Title, &e.InviteLink, &e.Context, &e.Description,
+			&e.Category, &e.Tone, &e.UpdateFreq, &e.ImportanceWeight, &e.WeightOverride, &e.AutoRelevanceEnabled,
+			&e.RelevanceThresholdDelta, &e.HealthStatus, &noise,
+			&snoozed, &e.SnoozeIngestion, &count); err != nil {
+			return nil, 0, fmt.Errorf("scan channel list entry: %w", err)
+		}
+
+		e.ID = fromUUID(id)
+		e.IsActive = true
+		e.NoiseRate, e.HasNoiseRate = noise.Float64, noise.Valid
+		e.SnoozedUntil = snoozed.Time
+		total = int(count)
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list channels page: %w", err)
+	}
+
+	return entries, total, nil
+}
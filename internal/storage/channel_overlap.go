@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChannelOverlapPair is a pair of channels, by Telegram peer ID, that often
+// report the same stories.
+type ChannelOverlapPair struct {
+	PeerA   int64
+	PeerB   int64
+	Jaccard float64
+}
+
+// GetChannelOverlapPairs returns the channel pairs from mv_channel_overlap
+// whose Jaccard index of shared clusters is at least minJaccard.
+func (db *DB) GetChannelOverlapPairs(ctx context.Context, minJaccard float64) ([]ChannelOverlapPair, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT ca.tg_peer_id, cb.tg_peer_id, o.jaccard
+		FROM mv_channel_overlap o
+		JOIN channels ca ON ca.id = o.channel_a
+		JOIN channels cb ON cb.id = o.channel_b
+		WHERE o.jaccard >= $1
+	`, minJaccard)
+	if err != nil {
+		return nil, fmt.Errorf("get channel overlap pairs: %w", err)
+	}
+	defer rows.Close()
+
+	var pairs []ChannelOverlapPair
+
+	for rows.Next() {
+		var p ChannelOverlapPair
+		if err := rows.Scan(&p.PeerA, &p.PeerB, &p.Jaccard); err != nil {
+			return nil, fmt.Errorf("scan channel overlap pair: %w", err)
+		}
+
+		pairs = append(pairs, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel overlap pairs: %w", err)
+	}
+
+	return pairs, nil
+}
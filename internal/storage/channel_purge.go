@@ -0,0 +1,313 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrChannelNotFound is returned when no tracked channel matches an identifier.
+var ErrChannelNotFound = errors.New("channel not found")
+
+// errPurgeDryRun rolls back the purge transaction of a dry run.
+var errPurgeDryRun = errors.New("channel purge dry run")
+
+// ChannelPurgeCount is the number of rows a purge deleted from one table.
+type ChannelPurgeCount struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+}
+
+// ChannelPurgeReport lists what a channel purge deleted, or would delete for
+// a dry run.
+type ChannelPurgeReport struct {
+	ChannelID string
+	PeerID    int64
+	Username  string
+	Title     string
+	DryRun    bool
+	Counts    []ChannelPurgeCount
+}
+
+// Total returns the number of deleted rows across all tables.
+func (r ChannelPurgeReport) Total() int64 {
+	var total int64
+
+	for _, c := range r.Counts {
+		total += c.Rows
+	}
+
+	return total
+}
+
+// channelPurgeSets are temporary tables with the ids of everything derived
+// from the purged channel, built before anything is deleted.
+var channelPurgeSets = []string{
+	`CREATE TEMP TABLE purge_messages ON COMMIT DROP AS
+		SELECT id, canonical_hash FROM raw_messages WHERE channel_id = (SELECT id FROM purge_channel)`,
+	`CREATE TEMP TABLE purge_items ON COMMIT DROP AS
+		SELECT id FROM items WHERE raw_message_id IN (SELECT id FROM purge_messages)`,
+	`CREATE TEMP TABLE purge_clusters ON COMMIT DROP AS
+		SELECT DISTINCT cluster_id AS id FROM cluster_items WHERE item_id IN (SELECT id FROM purge_items)`,
+	`CREATE TEMP TABLE purge_evidence ON COMMIT DROP AS
+		SELECT DISTINCT evidence_id AS id FROM item_evidence WHERE item_id IN (SELECT id FROM purge_items)`,
+	`CREATE TEMP TABLE purge_calendar_events ON COMMIT DROP AS
+		SELECT DISTINCT event_id AS id FROM calendar_event_items WHERE item_id IN (SELECT id FROM purge_items)`,
+	`CREATE TEMP TABLE purge_claim_texts ON COMMIT DROP AS
+		SELECT DISTINCT claim_text FROM claims WHERE cluster_ids && ARRAY(SELECT id FROM purge_clusters)`,
+	// Exported digest and story pages show the channel's items.
+	`CREATE TEMP TABLE purge_exports ON COMMIT DROP AS
+		SELECT digest_id AS id FROM digest_items WHERE item_id IN (SELECT id FROM purge_items)
+		UNION
+		SELECT story_id FROM story_clusters WHERE cluster_id IN (SELECT id FROM purge_clusters)`,
+}
+
+// purgeItemTables are the tables keyed by item_id. Most cascade from items,
+// but they are deleted explicitly so the report can count them. A test checks
+// that every table with a foreign key to items, raw_messages or channels has
+// a purge step.
+var purgeItemTables = []string{
+	"embeddings", "cluster_items", "item_ratings", "annotation_queue", "fact_check_queue",
+	"item_fact_checks", "item_evidence", "enrichment_queue", "item_bullets", "item_link_debug",
+	"item_deep_links", "search_watch_matches", "item_numeric_facts", "item_quotes",
+	"item_raw_scores", "item_score_ensembles", "story_timeline_events", "item_summary_refinements",
+	"item_clicks", "item_tickets", "calendar_event_items", "digest_items", "item_entities",
+	"watchlist_alerts",
+}
+
+// purgeMessageTables are the tables keyed by raw_message_id.
+var purgeMessageTables = []string{"message_links", "relevance_gate_log", "raw_message_drop_log", "pipeline_shadow_decisions"}
+
+// purgeChannelTables are the tables keyed by channel_id.
+var purgeChannelTables = []string{
+	"channel_stats", "channel_rating_stats", "channel_quality_history",
+	"channel_weight_history", "channel_health_events",
+}
+
+// channelPurgeStep deletes the purged channel's rows from one table.
+type channelPurgeStep struct {
+	table string
+	sql   string
+}
+
+// channelPurgeSteps returns the deletions of a purge, dependents first.
+func channelPurgeSteps() []channelPurgeStep {
+	steps := make([]channelPurgeStep, 0, len(purgeItemTables)+len(purgeMessageTables)+len(purgeChannelTables)+len(channelPurgeExtraSteps))
+
+	for _, table := range purgeItemTables {
+		steps = append(steps, channelPurgeStep{table, `DELETE FROM ` + table + ` WHERE item_id IN (SELECT id FROM purge_items)`})
+	}
+
+	for _, table := range purgeMessageTables {
+		steps = append(steps, channelPurgeStep{table, `DELETE FROM ` + table + ` WHERE raw_message_id IN (SELECT id FROM purge_messages)`})
+	}
+
+	for _, table := range purgeChannelTables {
+		steps = append(steps, channelPurgeStep{table, `DELETE FROM ` + table + ` WHERE channel_id = (SELECT id FROM purge_channel)`})
+	}
+
+	return append(steps, channelPurgeExtraSteps...)
+}
+
+// channelPurgeExtraSteps delete rows that are not keyed by a single item,
+// message or channel id. Clusters, claims, calendar events and evidence
+// sources are only deleted once nothing outside the channel refers to them.
+var channelPurgeExtraSteps = []channelPurgeStep{
+	{"export_pages", `DELETE FROM export_pages WHERE entity_id IN (SELECT id FROM purge_exports)`},
+	{"item_canonical_links", `DELETE FROM item_canonical_links
+		WHERE item_id IN (SELECT id FROM purge_items) OR canonical_item_id IN (SELECT id FROM purge_items)`},
+	{"dedup_decisions", `DELETE FROM dedup_decisions
+		WHERE raw_message_id IN (SELECT id FROM purge_messages)
+		   OR matched_raw_message_id IN (SELECT id FROM purge_messages)
+		   OR matched_item_id IN (SELECT id FROM purge_items)`},
+	{"cluster_first_appearance", `DELETE FROM cluster_first_appearance
+		WHERE cluster_id IN (SELECT id FROM purge_clusters) OR channel_id = (SELECT id FROM purge_channel)`},
+	{"cluster_topic_history", `DELETE FROM cluster_topic_history WHERE cluster_id IN (SELECT id FROM purge_clusters)`},
+	{"cluster_language_links", `DELETE FROM cluster_language_links
+		WHERE cluster_id IN (SELECT id FROM purge_clusters) OR linked_cluster_id IN (SELECT id FROM purge_clusters)`},
+	{"cluster_summary_cache", `DELETE FROM cluster_summary_cache
+		WHERE item_ids ?| ARRAY(SELECT id::text FROM purge_items)`},
+	{"summary_cache", `DELETE FROM summary_cache sc
+		WHERE sc.canonical_hash IN (SELECT canonical_hash FROM purge_messages)
+		  AND NOT EXISTS (
+			SELECT 1 FROM raw_messages rm
+			WHERE rm.canonical_hash = sc.canonical_hash AND rm.channel_id <> (SELECT id FROM purge_channel)
+		  )`},
+	{"media_collage_cache", `DELETE FROM media_collage_cache
+		WHERE item_ids && ARRAY(SELECT id FROM purge_items)`},
+	// Recorded LLM requests that carry only text cannot be traced to a
+	// channel, so they go too.
+	{"llm_cassettes", `DELETE FROM llm_cassettes
+		WHERE raw_message_ids && ARRAY(SELECT id FROM purge_messages) OR raw_message_ids = '{}'`},
+	{"items", `DELETE FROM items WHERE id IN (SELECT id FROM purge_items)`},
+	{"raw_messages", `DELETE FROM raw_messages WHERE id IN (SELECT id FROM purge_messages)`},
+	{"story_clusters", `DELETE FROM story_clusters sc
+		WHERE sc.cluster_id IN (SELECT id FROM purge_clusters)
+		  AND NOT EXISTS (SELECT 1 FROM cluster_items ci WHERE ci.cluster_id = sc.cluster_id)`},
+	{"clusters", `DELETE FROM clusters c
+		WHERE c.id IN (SELECT id FROM purge_clusters)
+		  AND NOT EXISTS (SELECT 1 FROM cluster_items ci WHERE ci.cluster_id = c.id)`},
+	{"claims", `DELETE FROM claims cl
+		WHERE cl.cluster_ids && ARRAY(SELECT id FROM purge_clusters)
+		  AND NOT EXISTS (SELECT 1 FROM clusters c WHERE c.id = ANY(cl.cluster_ids))`},
+	{"claim_states", `DELETE FROM claim_states s
+		WHERE s.claim_text IN (SELECT claim_text FROM purge_claim_texts)
+		  AND NOT EXISTS (SELECT 1 FROM claims cl WHERE cl.claim_text = s.claim_text)`},
+	{"claim_state_log", `DELETE FROM claim_state_log l
+		WHERE l.claim_text IN (SELECT claim_text FROM purge_claim_texts)
+		  AND NOT EXISTS (SELECT 1 FROM claims cl WHERE cl.claim_text = l.claim_text)`},
+	{"calendar_events", `DELETE FROM calendar_events ce
+		WHERE ce.id IN (SELECT id FROM purge_calendar_events)
+		  AND NOT EXISTS (SELECT 1 FROM calendar_event_items cei WHERE cei.event_id = ce.id)`},
+	{"evidence_sources", `DELETE FROM evidence_sources es
+		WHERE es.id IN (SELECT id FROM purge_evidence)
+		  AND NOT EXISTS (SELECT 1 FROM item_evidence ie WHERE ie.evidence_id = es.id)`},
+	{"channel_coordination", `DELETE FROM channel_coordination
+		WHERE channel_a = (SELECT id FROM purge_channel) OR channel_b = (SELECT id FROM purge_channel)`},
+	{"link_cache", `DELETE FROM link_cache
+		WHERE link_type = 'telegram'
+		  AND (channel_id = (SELECT tg_peer_id FROM purge_channel) OR channel_username = (SELECT username FROM purge_channel))`},
+	{"discovered_channels", `DELETE FROM discovered_channels
+		WHERE matched_channel_id = (SELECT id FROM purge_channel)
+		   OR (tg_peer_id <> 0 AND tg_peer_id = (SELECT tg_peer_id FROM purge_channel))
+		   OR username = (SELECT username FROM purge_channel)`},
+	{"channels", `DELETE FROM channels WHERE id = (SELECT id FROM purge_channel)`},
+}
+
+// PurgeChannel deletes the channel identified by username, peer ID or invite
+// link together with everything derived from it: raw messages, items,
+// embeddings, cluster memberships, ratings, evidence links, caches and
+// research tables. Clusters, claims, calendar events and evidence sources
+// shared with other channels are kept. The purge runs in one transaction and
+// is recorded in channel_purge_log; with dryRun set it is rolled back and only
+// the report is returned. Published digests are not rewritten.
+func (db *DB) PurgeChannel(ctx context.Context, identifier string, purgedBy int64, dryRun bool) (*ChannelPurgeReport, error) {
+	ctx = WithoutQueryTimeout(ctx)
+	report := &ChannelPurgeReport{DryRun: dryRun}
+
+	err := pgx.BeginFunc(ctx, db.Pool, func(tx pgx.Tx) error {
+		if err := lockPurgedChannel(ctx, tx, identifier, report); err != nil {
+			return err
+		}
+
+		if err := runChannelPurge(ctx, tx, report); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errPurgeDryRun
+		}
+
+		return logChannelPurge(ctx, tx, report, purgedBy)
+	})
+	if err != nil && !errors.Is(err, errPurgeDryRun) {
+		return nil, err
+	}
+
+	if !dryRun {
+		db.refreshViewsAfterPurge(ctx)
+	}
+
+	return report, nil
+}
+
+// lockPurgedChannel locks the channel row and stores it in the purge_channel
+// temporary table.
+func lockPurgedChannel(ctx context.Context, tx pgx.Tx, identifier string, report *ChannelPurgeReport) error {
+	var id pgtype.UUID
+
+	err := tx.QueryRow(ctx, `
+		SELECT id, tg_peer_id, COALESCE(username, ''), COALESCE(title, '')
+		FROM channels
+		WHERE username = $1 OR tg_peer_id::text = $1 OR invite_link = $2
+		LIMIT 1
+		FOR UPDATE
+	`, normalizeUsername(identifier), identifier).Scan(&id, &report.PeerID, &report.Username, &report.Title)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrChannelNotFound, identifier)
+	}
+
+	if err != nil {
+		return fmt.Errorf("get purged channel: %w", err)
+	}
+
+	report.ChannelID = fromUUID(id)
+
+	if _, err := tx.Exec(ctx, `CREATE TEMP TABLE purge_channel ON COMMIT DROP AS
+		SELECT id, tg_peer_id, username FROM channels WHERE id = $1
+	`, id); err != nil {
+		return fmt.Errorf("create purge_channel: %w", err)
+	}
+
+	return nil
+}
+
+func runChannelPurge(ctx context.Context, tx pgx.Tx, report *ChannelPurgeReport) error {
+	if _, err := tx.Exec(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+		return fmt.Errorf("lift statement timeout for purge: %w", err)
+	}
+
+	for _, sql := range channelPurgeSets {
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return fmt.Errorf("collect purged rows: %w", err)
+		}
+	}
+
+	for _, step := range channelPurgeSteps() {
+		tag, err := tx.Exec(ctx, step.sql)
+		if err != nil {
+			return fmt.Errorf("purge %s: %w", step.table, err)
+		}
+
+		report.Counts = append(report.Counts, ChannelPurgeCount{Table: step.table, Rows: tag.RowsAffected()})
+	}
+
+	// Claims that keep other clusters drop the deleted ones.
+	if _, err := tx.Exec(ctx, `
+		UPDATE claims cl
+		SET cluster_ids = ARRAY(SELECT x FROM unnest(cl.cluster_ids) AS x WHERE EXISTS (SELECT 1 FROM clusters c WHERE c.id = x)),
+		    updated_at = now()
+		WHERE cl.cluster_ids && ARRAY(SELECT id FROM purge_clusters)
+	`); err != nil {
+		return fmt.Errorf("purge claim clusters: %w", err)
+	}
+
+	// Surviving clusters lost items, so derived research tables get a full
+	// rebuild next time.
+	if _, err := tx.Exec(ctx, `DELETE FROM rebuild_state`); err != nil {
+		return fmt.Errorf("reset rebuild state: %w", err)
+	}
+
+	return nil
+}
+
+func logChannelPurge(ctx context.Context, tx pgx.Tx, report *ChannelPurgeReport, purgedBy int64) error {
+	counts, err := json.Marshal(report.Counts)
+	if err != nil {
+		return fmt.Errorf("marshal purge counts: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO channel_purge_log (channel_id, tg_peer_id, username, title, counts, purged_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, toUUID(report.ChannelID), report.PeerID, report.Username, report.Title, counts, purgedBy); err != nil {
+		return fmt.Errorf("log channel purge: %w", err)
+	}
+
+	return nil
+}
+
+// refreshViewsAfterPurge refreshes the research materialized views so they
+// stop showing the purged channel. Failures are logged; the next scheduled
+// refresh catches up.
+func (db *DB) refreshViewsAfterPurge(ctx context.Context) {
+	for _, view := range ResearchMaterializedViews {
+		if err := db.RefreshMaterializedView(ctx, view); err != nil {
+			db.Logger.Warn().Err(err).Str(logFieldView, view).Msg("failed to refresh view after channel purge")
+		}
+	}
+}
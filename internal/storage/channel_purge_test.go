@@ -0,0 +1,146 @@
+package db
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var (
+	migrationTableRe = regexp.MustCompile(`(?i)^\s*(?:CREATE TABLE(?: IF NOT EXISTS)?|ALTER TABLE(?: IF EXISTS)?)\s+(\w+)`)
+	migrationDropRe  = regexp.MustCompile(`(?i)^\s*DROP TABLE(?: IF EXISTS)?\s+(\w+)`)
+	migrationFKRe    = regexp.MustCompile(`(?i)REFERENCES\s+(items|raw_messages|channels)\s*\(`)
+)
+
+// referencingTables returns the tables that the Up migrations give a foreign
+// key to items, raw_messages or channels.
+func referencingTables(t *testing.T) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("list migrations: %v (%d files)", err, len(files))
+	}
+
+	tables := make(map[string]bool)
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatalf("open %s: %v", file, err)
+		}
+
+		current, up := "", false
+		scanner := bufio.NewScanner(f)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			switch {
+			case strings.HasPrefix(line, "-- +goose Up"):
+				up = true
+			case strings.HasPrefix(line, "-- +goose Down"):
+				up = false
+			}
+
+			if !up {
+				continue
+			}
+
+			if m := migrationDropRe.FindStringSubmatch(line); m != nil {
+				delete(tables, strings.ToLower(m[1]))
+			}
+
+			if m := migrationTableRe.FindStringSubmatch(line); m != nil {
+				current = strings.ToLower(m[1])
+			}
+
+			if m := migrationFKRe.FindStringSubmatch(line); m != nil && current != "" && current != strings.ToLower(m[1]) {
+				tables[current] = true
+			}
+		}
+
+		f.Close()
+
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+	}
+
+	names := make([]string, 0, len(tables))
+	for table := range tables {
+		names = append(names, table)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func TestChannelPurgeCoversReferencingTables(t *testing.T) {
+	purged := make(map[string]bool)
+	for _, step := range channelPurgeSteps() {
+		purged[step.table] = true
+	}
+
+	for _, table := range referencingTables(t) {
+		if !purged[table] {
+			t.Errorf("table %s references items, raw_messages or channels but has no purge step", table)
+		}
+	}
+}
+
+func TestChannelPurgeStepsOrder(t *testing.T) {
+	steps := channelPurgeSteps()
+	tables := make([]string, 0, len(steps))
+
+	for _, step := range steps {
+		if slices.Contains(tables, step.table) {
+			t.Errorf("table %s is purged twice", step.table)
+		}
+
+		tables = append(tables, step.table)
+	}
+
+	before := func(first, second string) {
+		t.Helper()
+
+		i, j := slices.Index(tables, first), slices.Index(tables, second)
+		if i < 0 || j < 0 || i > j {
+			t.Errorf("%s (%d) must be purged before %s (%d)", first, i, second, j)
+		}
+	}
+
+	for _, table := range purgeItemTables {
+		before(table, "items")
+	}
+
+	for _, table := range purgeMessageTables {
+		before(table, "raw_messages")
+	}
+
+	before("items", "raw_messages")
+	before("raw_messages", "clusters")
+	before("story_clusters", "clusters")
+	before("clusters", "claims")
+	before("claims", "claim_states")
+	before("claims", "claim_state_log")
+	before("item_evidence", "evidence_sources")
+	before("calendar_event_items", "calendar_events")
+
+	if tables[len(tables)-1] != "channels" {
+		t.Errorf("last purged table = %s, want channels", tables[len(tables)-1])
+	}
+}
+
+func TestChannelPurgeReportTotal(t *testing.T) {
+	report := ChannelPurgeReport{Counts: []ChannelPurgeCount{{Table: "items", Rows: 3}, {Table: "raw_messages", Rows: 5}}}
+
+	if got := report.Total(); got != 8 {
+		t.Errorf("Total() = %d, want 8", got)
+	}
+}
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+// Channel quota periods.
+const (
+	QuotaPeriodHour = "hour"
+	QuotaPeriodDay  = "day"
+)
+
+// Channel quota overflow policies. Sample keeps the highest-engagement messages,
+// drop keeps the earliest ones, and defer holds overflow back until the window frees up.
+const (
+	QuotaPolicySample = "sample"
+	QuotaPolicyDrop   = "drop"
+	QuotaPolicyDefer  = "defer"
+)
+
+// ErrChannelQuotaNotSet is returned when a channel has no ingestion quota.
+var ErrChannelQuotaNotSet = errors.New("channel quota not set")
+
+// ChannelQuota caps how many messages of a channel the pipeline processes per period.
+type ChannelQuota struct {
+	ChannelID string
+	Username  string
+	Title     string
+	Limit     int
+	Period    string
+	Policy    string
+}
+
+// Window returns the rolling window the quota limit applies to.
+func (q ChannelQuota) Window() time.Duration {
+	if q.Period == QuotaPeriodHour {
+		return time.Hour
+	}
+
+	return 24 * time.Hour
+}
+
+// ChannelQuotaUsage describes how much of a quota window is already used.
+type ChannelQuotaUsage struct {
+	Processed int
+	// OldestProcessedAt is when the oldest message still inside the window was processed.
+	OldestProcessedAt *time.Time
+}
+
+// GetChannelQuotas returns configured quotas keyed by channel ID.
+func (db *DB) GetChannelQuotas(ctx context.Context) (map[string]ChannelQuota, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, COALESCE(username, ''), COALESCE(title, ''), quota_limit,
+		       COALESCE(quota_period, $1), COALESCE(quota_policy, $2)
+		FROM channels
+		WHERE quota_limit IS NOT NULL
+	`, QuotaPeriodDay, QuotaPolicySample)
+	if err != nil {
+		return nil, fmt.Errorf("get channel quotas: %w", err)
+	}
+	defer rows.Close()
+
+	quotas := make(map[string]ChannelQuota)
+
+	for rows.Next() {
+		var (
+			id    pgtype.UUID
+			limit int32
+			q     ChannelQuota
+		)
+
+		if err := rows.Scan(&id, &q.Username, &q.Title, &limit, &q.Period, &q.Policy); err != nil {
+			return nil, fmt.Errorf("scan channel quota: %w", err)
+		}
+
+		q.ChannelID = fromUUID(id)
+		q.Limit = int(limit)
+		quotas[q.ChannelID] = q
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel quotas: %w", err)
+	}
+
+	return quotas, nil
+}
+
+// GetChannelQuota returns the quota of a single channel, or ErrChannelQuotaNotSet when none is set.
+func (db *DB) GetChannelQuota(ctx context.Context, channelID string) (*ChannelQuota, error) {
+	var (
+		limit pgtype.Int4
+		q     = ChannelQuota{ChannelID: channelID}
+	)
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT COALESCE(username, ''), COALESCE(title, ''), quota_limit,
+		       COALESCE(quota_period, $2), COALESCE(quota_policy, $3)
+		FROM channels
+		WHERE id = $1
+	`, toUUID(channelID), QuotaPeriodDay, QuotaPolicySample).Scan(&q.Username, &q.Title, &limit, &q.Period, &q.Policy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrChannelQuotaNotSet, channelID)
+		}
+
+		return nil, fmt.Errorf("get channel quota: %w", err)
+	}
+
+	if !limit.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrChannelQuotaNotSet, channelID)
+	}
+
+	q.Limit = int(limit.Int32)
+
+	return &q, nil
+}
+
+// SetChannelQuota sets or replaces a channel's ingestion quota.
+func (db *DB) SetChannelQuota(ctx context.Context, channelID string, limit int, period, policy string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET quota_limit = $2, quota_period = $3, quota_policy = $4 WHERE id = $1
+	`, toUUID(channelID), safeIntToInt32(limit), period, policy); err != nil {
+		return fmt.Errorf("set channel quota: %w", err)
+	}
+
+	return nil
+}
+
+// ClearChannelQuota removes a channel's quota and releases its deferred messages.
+func (db *DB) ClearChannelQuota(ctx context.Context, channelID string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		WITH cleared AS (
+			UPDATE channels SET quota_limit = NULL, quota_period = NULL, quota_policy = NULL
+			WHERE id = $1
+			RETURNING id
+		)
+		UPDATE raw_messages SET deferred_until = NULL
+		WHERE channel_id IN (SELECT id FROM cleared) AND deferred_until IS NOT NULL
+	`, toUUID(channelID)); err != nil {
+		return fmt.Errorf("clear channel quota: %w", err)
+	}
+
+	return nil
+}
+
+// GetChannelQuotaUsage counts a channel's messages processed since the given time.
+// Messages dropped by the quota itself are not counted.
+func (db *DB) GetChannelQuotaUsage(ctx context.Context, channelID string, since time.Time) (ChannelQuotaUsage, error) {
+	var (
+		usage  ChannelQuotaUsage
+		count  int64
+		oldest pgtype.Timestamptz
+	)
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT COUNT(*), MIN(rm.processed_at)
+		FROM raw_messages rm
+		LEFT JOIN raw_message_drop_log dl ON dl.raw_message_id = rm.id AND dl.reason = $3
+		WHERE rm.channel_id = $1
+		  AND rm.processed_at >= $2
+		  AND dl.raw_message_id IS NULL
+	`, toUUID(channelID), toTimestamptz(since), domain.DropReasonChannelQuota).Scan(&count, &oldest)
+	if err != nil {
+		return usage, fmt.Errorf("get channel quota usage: %w", err)
+	}
+
+	usage.Processed = int(count)
+
+	if oldest.Valid {
+		t := oldest.Time
+		usage.OldestProcessedAt = &t
+	}
+
+	return usage, nil
+}
+
+// CountChannelQuotaOverflow returns how many of a channel's messages were dropped
+// by its quota since the given time and how many are currently deferred.
+func (db *DB) CountChannelQuotaOverflow(ctx context.Context, channelID string, since time.Time) (dropped, deferred int, err error) {
+	var droppedCount, deferredCount int64
+
+	err = db.Pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM raw_message_drop_log dl
+			 JOIN raw_messages rm ON rm.id = dl.raw_message_id
+			 WHERE rm.channel_id = $1 AND dl.reason = $3 AND dl.updated_at >= $2),
+			(SELECT COUNT(*) FROM raw_messages
+			 WHERE channel_id = $1 AND processed_at IS NULL AND deferred_until > now())
+	`, toUUID(channelID), toTimestamptz(since), domain.DropReasonChannelQuota).Scan(&droppedCount, &deferredCount)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count channel quota overflow: %w", err)
+	}
+
+	return int(droppedCount), int(deferredCount), nil
+}
+
+// DeferRawMessages releases claimed messages and hides them from the pipeline until the given time.
+func (db *DB) DeferRawMessages(ctx context.Context, ids []string, until time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	uuids := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		uuids = append(uuids, toUUID(id))
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE raw_messages
+		SET deferred_until = $2, processing_started_at = NULL
+		WHERE id = ANY($1)
+	`, uuids, toTimestamptz(until)); err != nil {
+		return fmt.Errorf("defer raw messages: %w", err)
+	}
+
+	return nil
+}
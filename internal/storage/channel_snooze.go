@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SnoozeChannel leaves the channel's messages posted from now until the given
+// time out of digests; with ingestion set, the reader also stops fetching the
+// channel until then. It returns false when the channel is not tracked.
+func (db *DB) SnoozeChannel(ctx context.Context, identifier string, until time.Time, ingestion bool) (bool, error) {
+	tag, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET snoozed_at = now(), snoozed_until = $2, snooze_ingestion = $3
+		WHERE username = $1 OR '@' || username = $1 OR tg_peer_id::text = $1
+	`, normalizeUsername(identifier), toTimestamptz(until), ingestion)
+	if err != nil {
+		return false, fmt.Errorf("snooze channel: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// UnsnoozeChannel ends the channel's snooze now. It returns false when the
+// channel is not tracked.
+func (db *DB) UnsnoozeChannel(ctx context.Context, identifier string) (bool, error) {
+	tag, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET snoozed_until = LEAST(snoozed_until, now())
+		WHERE username = $1 OR '@' || username = $1 OR tg_peer_id::text = $1
+	`, normalizeUsername(identifier))
+	if err != nil {
+		return false, fmt.Errorf("unsnooze channel: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetIngestionSnoozedChannelIDs returns the channels whose ingestion is
+// currently snoozed.
+func (db *DB) GetIngestionSnoozedChannelIDs(ctx context.Context) ([]string, error) {
+	rows, err := db.Pool.Query(ctx, `SELECT id FROM channels WHERE snooze_ingestion AND snoozed_until > now()`)
+	if err != nil {
+		return nil, fmt.Errorf("get snoozed channels: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan snoozed channel: %w", err)
+		}
+
+		ids = append(ids, fromUUID(id))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate snoozed channels: %w", err)
+	}
+
+	return ids, nil
+}
@@ -234,3 +234,55 @@ func ratioFloat64(numerator, denominator int64) float64 {
 
 	return float64(numerator) / float64(denominator)
 }
+
+// ChannelTopicCount is the number of items a channel produced for a topic in a period.
+type ChannelTopicCount struct {
+	ChannelID string
+	Username  string
+	Title     string
+	Topic     string
+	Count     int
+}
+
+// GetChannelTopicCounts returns per-channel topic item counts for active channels
+// over messages posted in [start, end).
+func (db *DB) GetChannelTopicCounts(ctx context.Context, start, end time.Time) ([]ChannelTopicCount, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT ch.id, COALESCE(ch.username, ''), COALESCE(ch.title, ''), i.topic, COUNT(*)
+		FROM items i
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels ch ON rm.channel_id = ch.id
+		WHERE ch.is_active = TRUE
+		  AND i.topic IS NOT NULL AND i.topic <> ''
+		  AND rm.tg_date >= $1 AND rm.tg_date < $2
+		GROUP BY ch.id, ch.username, ch.title, i.topic
+	`, toTimestamptz(start), toTimestamptz(end))
+	if err != nil {
+		return nil, fmt.Errorf("get channel topic counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []ChannelTopicCount
+
+	for rows.Next() {
+		var (
+			channelID pgtype.UUID
+			count     int64
+			entry     ChannelTopicCount
+		)
+
+		if err := rows.Scan(&channelID, &entry.Username, &entry.Title, &entry.Topic, &count); err != nil {
+			return nil, fmt.Errorf("scan channel topic count: %w", err)
+		}
+
+		entry.ChannelID = fromUUID(channelID)
+		entry.Count = int(count)
+		counts = append(counts, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel topic counts: %w", err)
+	}
+
+	return counts, nil
+}
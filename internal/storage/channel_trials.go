@@ -0,0 +1,230 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrChannelTrialNotFound is returned when the channel is not on trial.
+var ErrChannelTrialNotFound = errors.New("channel trial not found")
+
+// ChannelTrial is a channel whose items are scored but left out of digests
+// until it is promoted or rejected.
+type ChannelTrial struct {
+	ChannelID string
+	PeerID    int64
+	Username  string
+	Title     string
+	StartedAt time.Time
+	Until     time.Time
+}
+
+// ChannelTrialSample is one item a channel produced during its trial.
+type ChannelTrialSample struct {
+	Summary         string
+	Topic           string
+	ImportanceScore float32
+	TGMessageID     int64
+}
+
+// ChannelTrialReport summarizes what a channel on trial would have brought to
+// digests. Eligible counts ready items above the importance threshold.
+type ChannelTrialReport struct {
+	ChannelTrial
+	Messages int
+	Items    int
+	Eligible int
+	Samples  []ChannelTrialSample
+}
+
+// ProjectedNoiseRate is the share of the channel's messages that would not
+// have made a digest.
+func (r ChannelTrialReport) ProjectedNoiseRate() float64 {
+	if r.Messages == 0 {
+		return 0
+	}
+
+	return 1 - float64(r.Eligible)/float64(r.Messages)
+}
+
+// channelTrialColumns are the columns scanned by scanChannelTrials.
+const channelTrialColumns = `id, tg_peer_id, COALESCE(username, ''), COALESCE(title, ''), probation_started_at, probation_until`
+
+// StartChannelTrial puts the channel identified by username, peer ID or
+// invite link on trial until the given time. It returns false when the
+// channel is not tracked.
+func (db *DB) StartChannelTrial(ctx context.Context, identifier string, until time.Time) (bool, error) {
+	tag, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET probation_started_at = now(), probation_until = $3, probation_reported_at = NULL
+		WHERE username = $1 OR tg_peer_id::text = $1 OR invite_link = $2
+	`, normalizeUsername(identifier), identifier, toTimestamptz(until))
+	if err != nil {
+		return false, fmt.Errorf("start channel trial: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListChannelTrials returns the active channels on trial, ending soonest first.
+func (db *DB) ListChannelTrials(ctx context.Context) ([]ChannelTrial, error) {
+	rows, err := db.Pool.Query(ctx, `SELECT `+channelTrialColumns+`
+		FROM channels
+		WHERE is_active = TRUE AND probation_until IS NOT NULL
+		ORDER BY probation_until
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list channel trials: %w", err)
+	}
+
+	return scanChannelTrials(rows)
+}
+
+// GetDueChannelTrials returns the active channels whose trial has ended and
+// has not been reported yet.
+func (db *DB) GetDueChannelTrials(ctx context.Context) ([]ChannelTrial, error) {
+	rows, err := db.Pool.Query(ctx, `SELECT `+channelTrialColumns+`
+		FROM channels
+		WHERE is_active = TRUE AND probation_until <= now() AND probation_reported_at IS NULL
+		ORDER BY probation_until
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("get due channel trials: %w", err)
+	}
+
+	return scanChannelTrials(rows)
+}
+
+// GetChannelTrial returns the trial of the channel identified by ID, username
+// or peer ID.
+func (db *DB) GetChannelTrial(ctx context.Context, identifier string) (ChannelTrial, error) {
+	rows, err := db.Pool.Query(ctx, `SELECT `+channelTrialColumns+`
+		FROM channels
+		WHERE (id::text = $1 OR username = $1 OR tg_peer_id::text = $1) AND probation_until IS NOT NULL
+	`, normalizeUsername(identifier))
+	if err != nil {
+		return ChannelTrial{}, fmt.Errorf("get channel trial: %w", err)
+	}
+
+	trials, err := scanChannelTrials(rows)
+	if err != nil {
+		return ChannelTrial{}, err
+	}
+
+	if len(trials) == 0 {
+		return ChannelTrial{}, fmt.Errorf("%w: %s", ErrChannelTrialNotFound, identifier)
+	}
+
+	return trials[0], nil
+}
+
+func scanChannelTrials(rows pgx.Rows) ([]ChannelTrial, error) {
+	defer rows.Close()
+
+	var trials []ChannelTrial
+
+	for rows.Next() {
+		var (
+			t          ChannelTrial
+			id         pgtype.UUID
+			start, end pgtype.Timestamptz
+		)
+
+		if err := rows.Scan(&id, &t.PeerID, &t.Username, &t.Title, &start, &end); err != nil {
+			return nil, fmt.Errorf("scan channel trial: %w", err)
+		}
+
+		t.ChannelID = fromUUID(id)
+		t.StartedAt, t.Until = start.Time, end.Time
+		trials = append(trials, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel trials: %w", err)
+	}
+
+	return trials, nil
+}
+
+// GetChannelTrialReport counts the channel's messages and items since its
+// trial started and returns its most important items as samples.
+func (db *DB) GetChannelTrialReport(ctx context.Context, trial ChannelTrial, importanceThreshold float32, samples int) (ChannelTrialReport, error) {
+	report := ChannelTrialReport{ChannelTrial: trial}
+
+	var messages, items, eligible int64
+
+	if err := db.Pool.QueryRow(ctx, `
+		SELECT COUNT(rm.id), COUNT(i.id) FILTER (WHERE i.status = 'ready'),
+		       COUNT(i.id) FILTER (WHERE i.status = 'ready'
+		                             AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3))
+		FROM channels c
+		LEFT JOIN channel_groups g ON c.group_id = g.id
+		JOIN raw_messages rm ON rm.channel_id = c.id AND rm.tg_date >= $2
+		LEFT JOIN items i ON i.raw_message_id = rm.id
+		WHERE c.id = $1
+	`, toUUID(trial.ChannelID), toTimestamptz(trial.StartedAt), importanceThreshold).Scan(&messages, &items, &eligible); err != nil {
+		return report, fmt.Errorf("count channel trial items: %w", err)
+	}
+
+	report.Messages, report.Items, report.Eligible = int(messages), int(items), int(eligible)
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT COALESCE(i.summary, ''), COALESCE(i.topic, ''), i.importance_score, rm.tg_message_id
+		FROM items i
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		WHERE rm.channel_id = $1 AND rm.tg_date >= $2 AND i.status = 'ready'
+		ORDER BY i.importance_score DESC, rm.tg_date DESC
+		LIMIT $3
+	`, toUUID(trial.ChannelID), toTimestamptz(trial.StartedAt), safeIntToInt32(samples))
+	if err != nil {
+		return report, fmt.Errorf("get channel trial samples: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s ChannelTrialSample
+		if err := rows.Scan(&s.Summary, &s.Topic, &s.ImportanceScore, &s.TGMessageID); err != nil {
+			return report, fmt.Errorf("scan channel trial sample: %w", err)
+		}
+
+		report.Samples = append(report.Samples, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return report, fmt.Errorf("iterate channel trial samples: %w", err)
+	}
+
+	return report, nil
+}
+
+// MarkChannelTrialReported records that the end-of-trial report was sent.
+func (db *DB) MarkChannelTrialReported(ctx context.Context, channelID string) error {
+	if _, err := db.Pool.Exec(ctx, `UPDATE channels SET probation_reported_at = now() WHERE id = $1`, toUUID(channelID)); err != nil {
+		return fmt.Errorf("mark channel trial reported: %w", err)
+	}
+
+	return nil
+}
+
+// EndChannelTrial ends the channel's trial. A promoted channel joins digests;
+// a rejected one is deactivated.
+func (db *DB) EndChannelTrial(ctx context.Context, channelID string, promote bool) error {
+	tag, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET probation_started_at = NULL, probation_until = NULL, probation_reported_at = NULL,
+		                    is_active = is_active AND $2
+		WHERE id = $1 AND probation_until IS NOT NULL
+	`, toUUID(channelID), promote)
+	if err != nil {
+		return fmt.Errorf("end channel trial: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrChannelTrialNotFound, channelID)
+	}
+
+	return nil
+}
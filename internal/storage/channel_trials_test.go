@@ -0,0 +1,13 @@
+package db
+
+import "testing"
+
+func TestChannelTrialReportProjectedNoiseRate(t *testing.T) {
+	if got := (ChannelTrialReport{}).ProjectedNoiseRate(); got != 0 {
+		t.Errorf("ProjectedNoiseRate() without messages = %v, want 0", got)
+	}
+
+	if got := (ChannelTrialReport{Messages: 40, Items: 12, Eligible: 10}).ProjectedNoiseRate(); got != 0.75 {
+		t.Errorf("ProjectedNoiseRate() = %v, want 0.75", got)
+	}
+}
@@ -0,0 +1,270 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// claimMergeSimilarity is the embedding cosine similarity at or above which two
+	// claims are treated as the same statement (typically a translation).
+	claimMergeSimilarity = 0.9
+	// claimMergeCandidateLimit caps how many unmerged claims are checked per rebuild.
+	claimMergeCandidateLimit  = 5000
+	defaultClaimMergeLogLimit = 200
+)
+
+// claimMergePair links a claim to the closest older claim it duplicates.
+type claimMergePair struct {
+	ClaimID    string
+	TargetID   string
+	Similarity float64
+}
+
+// ClaimMergeEntry is a single row of the claim merge audit trail.
+type ClaimMergeEntry struct {
+	CanonicalClaimID string
+	CanonicalText    string
+	MergedClaimID    string
+	MergedText       string
+	Similarity       float32
+	MergedAt         time.Time
+}
+
+// rebuildClaimMerges merges claims whose embeddings are near-identical, which
+// collapses translations of the same statement into a single canonical claim.
+// The older claim stays canonical; merged texts are kept as aliases and every
+// merge is recorded in claim_merge_log.
+func (db *DB) rebuildClaimMerges(ctx context.Context, tx pgx.Tx) error {
+	db.Logger.Info().Msg("merging similar claims")
+
+	pairs, err := findClaimMergePairs(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	roots := resolveClaimMergeRoots(pairs)
+	if len(roots) == 0 {
+		return nil
+	}
+
+	similarity := make(map[string]float64, len(pairs))
+	for _, p := range pairs {
+		similarity[p.ClaimID] = p.Similarity
+	}
+
+	claimIDs := make([]string, 0, len(roots))
+	for id := range roots {
+		claimIDs = append(claimIDs, id)
+	}
+
+	sort.Strings(claimIDs)
+
+	for _, id := range claimIDs {
+		if err := mergeClaimInto(ctx, tx, id, roots[id], similarity[id]); err != nil {
+			return err
+		}
+	}
+
+	db.Logger.Info().Int("merged_claims", len(claimIDs)).Msg("claim merge completed")
+
+	return nil
+}
+
+func findClaimMergePairs(ctx context.Context, tx pgx.Tx) ([]claimMergePair, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT c.id, m.id, m.similarity
+		FROM claims c
+		CROSS JOIN LATERAL (
+			SELECT p.id, 1.0 - (p.embedding <=> c.embedding) AS similarity
+			FROM claims p
+			WHERE p.id <> c.id
+			  AND p.embedding IS NOT NULL
+			  AND p.merged_into IS NULL
+			  AND (p.first_seen_at, p.id) < (c.first_seen_at, c.id)
+			ORDER BY p.embedding <=> c.embedding
+			LIMIT 1
+		) m
+		WHERE c.embedding IS NOT NULL
+		  AND c.merged_into IS NULL
+		  AND m.similarity >= $1
+		ORDER BY c.first_seen_at
+		LIMIT $2
+	`, claimMergeSimilarity, claimMergeCandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("find claim merge pairs: %w", err)
+	}
+	defer rows.Close()
+
+	var pairs []claimMergePair
+
+	for rows.Next() {
+		var (
+			claimID  pgtype.UUID
+			targetID pgtype.UUID
+			pair     claimMergePair
+		)
+
+		if err := rows.Scan(&claimID, &targetID, &pair.Similarity); err != nil {
+			return nil, fmt.Errorf("scan claim merge pair: %w", err)
+		}
+
+		pair.ClaimID = fromUUID(claimID)
+		pair.TargetID = fromUUID(targetID)
+		pairs = append(pairs, pair)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate claim merge pairs: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// resolveClaimMergeRoots maps every duplicate claim to the canonical claim at the
+// end of its merge chain, so A→B→C merges both A and B into C.
+func resolveClaimMergeRoots(pairs []claimMergePair) map[string]string {
+	parent := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		if p.ClaimID != "" && p.TargetID != "" && p.ClaimID != p.TargetID {
+			parent[p.ClaimID] = p.TargetID
+		}
+	}
+
+	roots := make(map[string]string, len(parent))
+
+	for id := range parent {
+		root := id
+		seen := map[string]bool{id: true}
+
+		for {
+			next, ok := parent[root]
+			if !ok || seen[next] {
+				break
+			}
+
+			seen[next] = true
+			root = next
+		}
+
+		if root != id {
+			roots[id] = root
+		}
+	}
+
+	return roots
+}
+
+// mergeClaimInto folds a duplicate claim into its canonical claim and records the merge.
+func mergeClaimInto(ctx context.Context, tx pgx.Tx, claimID, canonicalID string, similarity float64) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE claims SET merged_into = $2, updated_at = now()
+		WHERE merged_into = $1
+	`, toUUID(claimID), toUUID(canonicalID)); err != nil {
+		return fmt.Errorf("repoint merged claims: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		WITH dup AS (
+			UPDATE claims
+			SET merged_into = $2, updated_at = now()
+			WHERE id = $1 AND merged_into IS NULL
+			RETURNING claim_text, aliases, cluster_ids, contradicted_by, first_seen_at
+		),
+		canonical AS (
+			UPDATE claims c
+			SET canonical_text = COALESCE(c.canonical_text, c.claim_text),
+			    aliases = array_remove(ARRAY(SELECT DISTINCT unnest(c.aliases || dup.aliases || ARRAY[dup.claim_text])), c.claim_text),
+			    cluster_ids = ARRAY(SELECT DISTINCT unnest(c.cluster_ids || dup.cluster_ids)),
+			    contradicted_by = ARRAY(SELECT DISTINCT unnest(c.contradicted_by || dup.contradicted_by)),
+			    first_seen_at = LEAST(c.first_seen_at, dup.first_seen_at),
+			    updated_at = now()
+			FROM dup
+			WHERE c.id = $2
+			RETURNING c.id
+		)
+		INSERT INTO claim_merge_log (canonical_claim_id, merged_claim_id, merged_text, similarity)
+		SELECT canonical.id, $1, dup.claim_text, $3
+		FROM dup, canonical
+	`, toUUID(claimID), toUUID(canonicalID), similarity); err != nil {
+		return fmt.Errorf("merge claim: %w", err)
+	}
+
+	return nil
+}
+
+// GetClaimMergeLog returns the most recent claim merges for auditing.
+func (db *DB) GetClaimMergeLog(ctx context.Context, from, to *time.Time, limit int) ([]ClaimMergeEntry, error) {
+	if limit <= 0 {
+		limit = defaultClaimMergeLogLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT l.canonical_claim_id,
+		       COALESCE(c.canonical_text, c.claim_text),
+		       l.merged_claim_id,
+		       l.merged_text,
+		       l.similarity,
+		       l.merged_at
+		FROM claim_merge_log l
+		JOIN claims c ON c.id = l.canonical_claim_id
+		WHERE ($1::timestamptz IS NULL OR l.merged_at >= $1)
+		  AND ($2::timestamptz IS NULL OR l.merged_at <= $2)
+		ORDER BY l.merged_at DESC
+		LIMIT $3
+	`, toTimestamptzPtr(from), toTimestamptzPtr(to), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get claim merge log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []ClaimMergeEntry{}
+
+	for rows.Next() {
+		var (
+			canonicalID pgtype.UUID
+			mergedID    pgtype.UUID
+			entry       ClaimMergeEntry
+		)
+
+		if err := rows.Scan(&canonicalID, &entry.CanonicalText, &mergedID, &entry.MergedText, &entry.Similarity, &entry.MergedAt); err != nil {
+			return nil, fmt.Errorf("scan claim merge log: %w", err)
+		}
+
+		entry.CanonicalClaimID = fromUUID(canonicalID)
+		entry.MergedClaimID = fromUUID(mergedID)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate claim merge log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// addToCanonicalClaim adds clusters to the canonical claim of a merged claim
+// with the given normalized hash. It reports false when no merged claim has
+// that hash, so the caller upserts the claim itself.
+func (db *DB) addToCanonicalClaim(ctx context.Context, normalizedHash string, firstSeenAt time.Time, clusterIDs []pgtype.UUID) (bool, error) {
+	tag, err := db.Pool.Exec(ctx, `
+		UPDATE claims c
+		SET cluster_ids = ARRAY(SELECT DISTINCT unnest(c.cluster_ids || $3::uuid[])),
+		    first_seen_at = LEAST(c.first_seen_at, $2),
+		    updated_at = now()
+		FROM claims dup
+		WHERE dup.normalized_hash = $1
+		  AND dup.merged_into IS NOT NULL
+		  AND c.id = dup.merged_into
+	`, normalizedHash, firstSeenAt, clusterIDs)
+	if err != nil {
+		return false, fmt.Errorf("update canonical claim: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveClaimMergeRoots(t *testing.T) {
+	tests := []struct {
+		name  string
+		pairs []claimMergePair
+		want  map[string]string
+	}{
+		{
+			name:  "no pairs",
+			pairs: nil,
+			want:  map[string]string{},
+		},
+		{
+			name:  "direct merge",
+			pairs: []claimMergePair{{ClaimID: "b", TargetID: "a"}},
+			want:  map[string]string{"b": "a"},
+		},
+		{
+			name: "chain resolves to root",
+			pairs: []claimMergePair{
+				{ClaimID: "c", TargetID: "b"},
+				{ClaimID: "b", TargetID: "a"},
+			},
+			want: map[string]string{"b": "a", "c": "a"},
+		},
+		{
+			name: "self and empty references are ignored",
+			pairs: []claimMergePair{
+				{ClaimID: "a", TargetID: "a"},
+				{ClaimID: "", TargetID: "a"},
+			},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveClaimMergeRoots(tt.pairs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveClaimMergeRoots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+const defaultClaimStateLogLimit = 200
+
+// ClaimStateChange is a single row of the claim lifecycle log.
+type ClaimStateChange struct {
+	ClaimText string
+	FromState string
+	ToState   string
+	Supports  int
+	Refutes   int
+	ChangedAt time.Time
+}
+
+// claimStanceTally collects the evidence stances of one claim.
+type claimStanceTally struct {
+	state    string
+	votes    []domain.StanceVote
+	supports int
+	refutes  int
+}
+
+// rebuildClaimStates moves every canonical claim through its lifecycle using
+// the stances of the evidence linked to the items of its clusters. Changed
+// states are saved in claim_states and logged in claim_state_log.
+func (db *DB) rebuildClaimStates(ctx context.Context, tx pgx.Tx) error {
+	db.Logger.Info().Msg("updating claim states")
+
+	tallies, err := loadClaimStanceTallies(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	texts := make([]string, 0, len(tallies))
+	for text := range tallies {
+		texts = append(texts, text)
+	}
+
+	sort.Strings(texts)
+
+	changed := 0
+
+	for _, text := range texts {
+		t := tallies[text]
+
+		from := t.state
+		if from == "" {
+			from = domain.ClaimUnverified
+		}
+
+		to := domain.NextClaimState(from, domain.AggregateStanceVerdict(t.votes))
+		if to == from {
+			continue
+		}
+
+		if err := saveClaimState(ctx, tx, text, from, to, t); err != nil {
+			return err
+		}
+
+		changed++
+	}
+
+	db.Logger.Info().Int("changed_claims", changed).Msg("claim state update completed")
+
+	return nil
+}
+
+func loadClaimStanceTallies(ctx context.Context, tx pgx.Tx) (map[string]*claimStanceTally, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT ON (c.claim_text, ie.id)
+		       c.claim_text,
+		       COALESCE(s.state, ''),
+		       ie.stance,
+		       COALESCE(ie.stance_confidence, 0)
+		FROM claims c
+		JOIN cluster_items ci ON ci.cluster_id = ANY(c.cluster_ids)
+		JOIN item_evidence ie ON ie.item_id = ci.item_id AND ie.stance IS NOT NULL
+		LEFT JOIN claim_states s ON s.claim_text = c.claim_text
+		WHERE c.merged_into IS NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("load claim stances: %w", err)
+	}
+	defer rows.Close()
+
+	tallies := make(map[string]*claimStanceTally)
+
+	for rows.Next() {
+		var (
+			text  string
+			state string
+			vote  domain.StanceVote
+		)
+
+		if err := rows.Scan(&text, &state, &vote.Stance, &vote.Confidence); err != nil {
+			return nil, fmt.Errorf("scan claim stance: %w", err)
+		}
+
+		t, ok := tallies[text]
+		if !ok {
+			t = &claimStanceTally{state: state}
+			tallies[text] = t
+		}
+
+		t.votes = append(t.votes, vote)
+
+		switch vote.Stance {
+		case domain.StanceSupports:
+			t.supports++
+		case domain.StanceRefutes:
+			t.refutes++
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate claim stances: %w", err)
+	}
+
+	return tallies, nil
+}
+
+func saveClaimState(ctx context.Context, tx pgx.Tx, text, from, to string, t *claimStanceTally) error {
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO claim_states (claim_text, state, supports, refutes, changed_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT (claim_text) DO UPDATE SET
+			state = EXCLUDED.state,
+			supports = EXCLUDED.supports,
+			refutes = EXCLUDED.refutes,
+			changed_at = EXCLUDED.changed_at
+	`, text, to, t.supports, t.refutes); err != nil {
+		return fmt.Errorf("save claim state: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO claim_state_log (claim_text, from_state, to_state, supports, refutes)
+		VALUES ($1, $2, $3, $4, $5)
+	`, text, from, to, t.supports, t.refutes); err != nil {
+		return fmt.Errorf("log claim state change: %w", err)
+	}
+
+	return nil
+}
+
+// GetClaimStateChanges returns the most recent claim state changes.
+func (db *DB) GetClaimStateChanges(ctx context.Context, from, to *time.Time, limit int) ([]ClaimStateChange, error) {
+	if limit <= 0 {
+		limit = defaultClaimStateLogLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT claim_text, from_state, to_state, supports, refutes, changed_at
+		FROM claim_state_log
+		WHERE ($1::timestamptz IS NULL OR changed_at >= $1)
+		  AND ($2::timestamptz IS NULL OR changed_at <= $2)
+		ORDER BY changed_at DESC
+		LIMIT $3
+	`, toTimestamptzPtr(from), toTimestamptzPtr(to), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get claim state changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []ClaimStateChange{}
+
+	for rows.Next() {
+		var c ClaimStateChange
+		if err := rows.Scan(&c.ClaimText, &c.FromState, &c.ToState, &c.Supports, &c.Refutes, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan claim state change: %w", err)
+		}
+
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate claim state changes: %w", err)
+	}
+
+	return changes, nil
+}
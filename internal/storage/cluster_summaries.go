@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrNoPreviousClusterSummary is returned when no earlier posted cluster
+// continues the story of a cluster.
+var ErrNoPreviousClusterSummary = errors.New("no previous cluster summary")
+
+// ClusterSummary is the rendered summary of a digest cluster. For clusters
+// that continue an earlier posted cluster, IncrementalSummary holds only what
+// is new since that cluster's summary.
+type ClusterSummary struct {
+	ClusterID          string
+	Summary            string
+	IncrementalSummary string
+	PreviousClusterID  string
+}
+
+// PreviousClusterSummary is the summary of the earlier posted cluster closest
+// to a cluster.
+type PreviousClusterSummary struct {
+	ClusterID  string
+	Summary    string
+	Similarity float32
+}
+
+// SaveClusterSummary stores the full and incremental summaries of a cluster.
+func (db *DB) SaveClusterSummary(ctx context.Context, s ClusterSummary) error {
+	var previous pgtype.UUID
+	if s.PreviousClusterID != "" {
+		previous = toUUID(s.PreviousClusterID)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO cluster_summaries (cluster_id, summary, incremental_summary, previous_cluster_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (cluster_id) DO UPDATE SET
+			summary = EXCLUDED.summary,
+			incremental_summary = EXCLUDED.incremental_summary,
+			previous_cluster_id = EXCLUDED.previous_cluster_id,
+			updated_at = now()
+	`, toUUID(s.ClusterID), SanitizeUTF8(s.Summary), SanitizeUTF8(s.IncrementalSummary), previous); err != nil {
+		return fmt.Errorf("save cluster summary: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreviousClusterSummary returns the summary of the digest cluster from an
+// earlier posted digest, ending no earlier than since, whose embedding
+// centroid is closest to the cluster's. It returns ErrNoPreviousClusterSummary
+// when no such cluster has a stored summary.
+func (db *DB) GetPreviousClusterSummary(ctx context.Context, clusterID string, since time.Time) (*PreviousClusterSummary, error) {
+	var (
+		p          PreviousClusterSummary
+		id         pgtype.UUID
+		similarity float64
+	)
+
+	err := db.Pool.QueryRow(ctx, `
+		WITH target AS (
+			SELECT c.window_start, AVG(e.embedding) AS centroid
+			FROM clusters c
+			JOIN cluster_items ci ON ci.cluster_id = c.id
+			JOIN embeddings e ON e.item_id = ci.item_id
+			WHERE c.id = $1
+			GROUP BY c.window_start
+		),
+		candidates AS (
+			SELECT c.id, cs.summary, AVG(e.embedding) AS centroid
+			FROM clusters c
+			JOIN target t ON c.window_end <= t.window_start
+			JOIN cluster_summaries cs ON cs.cluster_id = c.id
+			JOIN cluster_items ci ON ci.cluster_id = c.id
+			JOIN embeddings e ON e.item_id = ci.item_id
+			WHERE c.source = $3 AND c.window_end >= $2
+			  AND EXISTS (
+				SELECT 1 FROM digests d
+				WHERE d.status = 'posted' AND d.window_start = c.window_start AND d.window_end = c.window_end
+			  )
+			GROUP BY c.id, cs.summary
+		)
+		SELECT cand.id, cand.summary, 1 - (cand.centroid <=> t.centroid)
+		FROM candidates cand
+		CROSS JOIN target t
+		ORDER BY cand.centroid <=> t.centroid
+		LIMIT 1
+	`, toUUID(clusterID), toTimestamptz(since), ClusterSourceDigest).Scan(&id, &p.Summary, &similarity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNoPreviousClusterSummary
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get previous cluster summary: %w", err)
+	}
+
+	p.ClusterID = fromUUID(id)
+	p.Similarity = float32(similarity)
+
+	return &p, nil
+}
@@ -68,7 +68,8 @@ func (db *DB) AddToCluster(ctx context.Context, clusterID, itemID string) error
 func (db *DB) GetClustersForWindow(ctx context.Context, start, end time.Time) ([]ClusterWithItems, error) {
 	rows, err := db.Pool.Query(ctx, `
 		SELECT c.id as cluster_id, c.topic as cluster_topic, i.id as item_id, i.summary as item_summary,
-		       ch.username as channel_username, ch.tg_peer_id as channel_peer_id, rm.tg_message_id as rm_msg_id
+		       ch.username as channel_username, ch.title as channel_title, ch.tg_peer_id as channel_peer_id,
+		       rm.tg_message_id as rm_msg_id, rm.tg_date as rm_tg_date
 		FROM clusters c
 		JOIN cluster_items ci ON c.id = ci.cluster_id
 		JOIN items i ON ci.item_id = i.id
@@ -93,11 +94,13 @@ func (db *DB) GetClustersForWindow(ctx context.Context, start, end time.Time) ([
 			itemID          pgtype.UUID
 			itemSummary     pgtype.Text
 			channelUsername pgtype.Text
+			channelTitle    pgtype.Text
 			channelPeerID   int64
 			rmMsgID         int64
+			rmTGDate        pgtype.Timestamptz
 		)
 
-		if err := rows.Scan(&clusterID, &clusterTopic, &itemID, &itemSummary, &channelUsername, &channelPeerID, &rmMsgID); err != nil {
+		if err := rows.Scan(&clusterID, &clusterTopic, &itemID, &itemSummary, &channelUsername, &channelTitle, &channelPeerID, &rmMsgID, &rmTGDate); err != nil {
 			return nil, fmt.Errorf("scan clusters for window: %w", err)
 		}
 
@@ -108,11 +111,13 @@ func (db *DB) GetClustersForWindow(ctx context.Context, start, end time.Time) ([
 		}
 
 		clusterMap[cID].Items = append(clusterMap[cID].Items, Item{
-			ID:              fromUUID(itemID),
-			Summary:         itemSummary.String,
-			SourceChannel:   channelUsername.String,
-			SourceChannelID: channelPeerID,
-			SourceMsgID:     rmMsgID,
+			ID:                 fromUUID(itemID),
+			Summary:            itemSummary.String,
+			TGDate:             rmTGDate.Time,
+			SourceChannel:      channelUsername.String,
+			SourceChannelTitle: channelTitle.String,
+			SourceChannelID:    channelPeerID,
+			SourceMsgID:        rmMsgID,
 		})
 	}
 
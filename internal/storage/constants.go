@@ -11,6 +11,9 @@ const (
 	DiscoveryStatusAdded    = "added"
 	DiscoveryStatusRejected = "rejected"
 	DiscoveryStatusPending  = "pending"
+	// DiscoveryStatusExpanded marks a shared folder link whose channels were recorded
+	// as separate discoveries.
+	DiscoveryStatusExpanded = "expanded"
 )
 
 // Link resolution status constants (aliased from domain)
@@ -46,4 +49,6 @@ const (
 	defaultMaxConnIdleTime   time.Duration = 30 * time.Minute
 	defaultMaxConnLifetime   time.Duration = time.Hour
 	defaultHealthCheckPeriod time.Duration = time.Minute
+
+	statementTimeoutParam = "statement_timeout"
 )
@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// coordinationLookbackDays is the period scanned for coordinated posting.
+	coordinationLookbackDays = 7
+	// coordinationWindow is the maximum gap between two channels posting the same
+	// story for the posts to count as coordinated.
+	coordinationWindow = 10 * time.Minute
+	// coordinationMinCoPosts is the number of coordinated posts a pair needs before it is flagged.
+	coordinationMinCoPosts = 3
+	// coordinationLagWeight is how much of the score is lost when the average lag
+	// reaches the full coordination window.
+	coordinationLagWeight    = 0.5
+	defaultCoordinationLimit = 100
+)
+
+// ChannelCoordinationEntry describes a channel pair that repeatedly posts the same
+// stories within minutes of each other.
+type ChannelCoordinationEntry struct {
+	ChannelA         string
+	ChannelB         string
+	ChannelATitle    string
+	ChannelAUsername string
+	ChannelBTitle    string
+	ChannelBUsername string
+	CoPosts          int
+	SharedClusters   int
+	TotalA           int
+	TotalB           int
+	AvgLagSeconds    float32
+	Score            float32
+	WindowStart      time.Time
+	WindowEnd        time.Time
+	ComputedAt       time.Time
+}
+
+// coordinationScore rates a channel pair from 0 to 1. It is the share of the smaller
+// channel's stories that were co-posted within the window, discounted as the
+// average lag between the two posts grows.
+func coordinationScore(coPosts, totalA, totalB int, avgLagSeconds float64) float32 {
+	smaller := min(totalA, totalB)
+	if coPosts <= 0 || smaller <= 0 {
+		return 0
+	}
+
+	share := min(float64(coPosts)/float64(smaller), 1)
+
+	lagRatio := min(max(avgLagSeconds/coordinationWindow.Seconds(), 0), 1)
+
+	return float32(share * (1 - coordinationLagWeight*lagRatio))
+}
+
+// rebuildChannelCoordination recomputes coordination scores for channel pairs whose
+// posts land in the same research cluster within coordinationWindow of each other.
+func (db *DB) rebuildChannelCoordination(ctx context.Context, tx pgx.Tx) error {
+	db.Logger.Info().Msg("rebuilding channel_coordination")
+
+	if _, err := tx.Exec(ctx, "TRUNCATE channel_coordination"); err != nil {
+		return fmt.Errorf("truncate channel_coordination: %w", err)
+	}
+
+	windowEnd := time.Now().UTC()
+	windowStart := windowEnd.AddDate(0, 0, -coordinationLookbackDays)
+
+	entries, err := findCoordinatedChannelPairs(ctx, tx, windowStart)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		score := coordinationScore(e.CoPosts, e.TotalA, e.TotalB, float64(e.AvgLagSeconds))
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO channel_coordination (
+				channel_a, channel_b, co_posts, shared_clusters, total_a, total_b,
+				avg_lag_seconds, score, window_start, window_end
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, toUUID(e.ChannelA), toUUID(e.ChannelB), e.CoPosts, e.SharedClusters, e.TotalA, e.TotalB,
+			e.AvgLagSeconds, score, windowStart, windowEnd); err != nil {
+			return fmt.Errorf("insert channel coordination: %w", err)
+		}
+	}
+
+	db.Logger.Info().Int("coordinated_pairs", len(entries)).Msg("channel coordination rebuilt")
+
+	return nil
+}
+
+// findCoordinatedChannelPairs aggregates, per channel pair, how many shared research
+// clusters both channels posted to within coordinationWindow of each other.
+func findCoordinatedChannelPairs(ctx context.Context, tx pgx.Tx, since time.Time) ([]ChannelCoordinationEntry, error) {
+	rows, err := tx.Query(ctx, `
+		WITH posts AS (
+			SELECT ci.cluster_id, rm.channel_id, MIN(rm.tg_date) AS posted_at
+			FROM cluster_items ci
+			JOIN clusters c ON ci.cluster_id = c.id AND c.source = $1
+			JOIN items i ON ci.item_id = i.id
+			JOIN raw_messages rm ON i.raw_message_id = rm.id
+			WHERE rm.tg_date >= $2
+			GROUP BY ci.cluster_id, rm.channel_id
+		),
+		channel_totals AS (
+			SELECT channel_id, COUNT(*) AS total
+			FROM posts
+			GROUP BY channel_id
+		),
+		pair_posts AS (
+			SELECT a.channel_id AS channel_a,
+			       b.channel_id AS channel_b,
+			       ABS(EXTRACT(EPOCH FROM (a.posted_at - b.posted_at))) AS lag_seconds
+			FROM posts a
+			JOIN posts b ON a.cluster_id = b.cluster_id AND a.channel_id < b.channel_id
+		),
+		pairs AS (
+			SELECT channel_a,
+			       channel_b,
+			       COUNT(*) AS shared_clusters,
+			       COUNT(*) FILTER (WHERE lag_seconds <= $3) AS co_posts,
+			       COALESCE(AVG(lag_seconds) FILTER (WHERE lag_seconds <= $3), 0) AS avg_lag
+			FROM pair_posts
+			GROUP BY channel_a, channel_b
+		)
+		SELECT p.channel_a, p.channel_b, p.co_posts, p.shared_clusters, ta.total, tb.total, p.avg_lag
+		FROM pairs p
+		JOIN channel_totals ta ON ta.channel_id = p.channel_a
+		JOIN channel_totals tb ON tb.channel_id = p.channel_b
+		WHERE p.co_posts >= $4
+	`, ClusterSourceResearch, since, coordinationWindow.Seconds(), coordinationMinCoPosts)
+	if err != nil {
+		return nil, fmt.Errorf("find coordinated channel pairs: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []ChannelCoordinationEntry
+
+	for rows.Next() {
+		var (
+			channelA pgtype.UUID
+			channelB pgtype.UUID
+			avgLag   float64
+			entry    ChannelCoordinationEntry
+		)
+
+		if err := rows.Scan(&channelA, &channelB, &entry.CoPosts, &entry.SharedClusters, &entry.TotalA, &entry.TotalB, &avgLag); err != nil {
+			return nil, fmt.Errorf("scan coordinated channel pair: %w", err)
+		}
+
+		entry.ChannelA = fromUUID(channelA)
+		entry.ChannelB = fromUUID(channelB)
+		entry.AvgLagSeconds = float32(avgLag)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate coordinated channel pairs: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetChannelCoordination returns channel pairs ordered by coordination score,
+// keeping only pairs scoring at least minScore.
+func (db *DB) GetChannelCoordination(ctx context.Context, minScore float32, limit int) ([]ChannelCoordinationEntry, error) {
+	if limit <= 0 {
+		limit = defaultCoordinationLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT cc.channel_a, cc.channel_b,
+		       COALESCE(ca.title, ''), COALESCE(ca.username, ''),
+		       COALESCE(cb.title, ''), COALESCE(cb.username, ''),
+		       cc.co_posts, cc.shared_clusters, cc.total_a, cc.total_b,
+		       cc.avg_lag_seconds, cc.score,
+		       cc.window_start, cc.window_end, cc.computed_at
+		FROM channel_coordination cc
+		JOIN channels ca ON ca.id = cc.channel_a
+		JOIN channels cb ON cb.id = cc.channel_b
+		WHERE cc.score >= $1
+		ORDER BY cc.score DESC, cc.co_posts DESC
+		LIMIT $2
+	`, minScore, safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get channel coordination: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []ChannelCoordinationEntry{}
+
+	for rows.Next() {
+		var (
+			channelA pgtype.UUID
+			channelB pgtype.UUID
+			entry    ChannelCoordinationEntry
+		)
+
+		if err := rows.Scan(
+			&channelA, &channelB,
+			&entry.ChannelATitle, &entry.ChannelAUsername,
+			&entry.ChannelBTitle, &entry.ChannelBUsername,
+			&entry.CoPosts, &entry.SharedClusters, &entry.TotalA, &entry.TotalB,
+			&entry.AvgLagSeconds, &entry.Score,
+			&entry.WindowStart, &entry.WindowEnd, &entry.ComputedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan channel coordination: %w", err)
+		}
+
+		entry.ChannelA = fromUUID(channelA)
+		entry.ChannelB = fromUUID(channelB)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel coordination: %w", err)
+	}
+
+	return entries, nil
+}
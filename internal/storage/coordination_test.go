@@ -0,0 +1,35 @@
+package db
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoordinationScore(t *testing.T) {
+	const eps = 1e-6
+
+	tests := []struct {
+		name    string
+		coPosts int
+		totalA  int
+		totalB  int
+		avgLag  float64
+		want    float64
+	}{
+		{name: "no co-posts", coPosts: 0, totalA: 10, totalB: 10, avgLag: 0, want: 0},
+		{name: "empty channel", coPosts: 3, totalA: 0, totalB: 10, avgLag: 0, want: 0},
+		{name: "simultaneous posts", coPosts: 5, totalA: 10, totalB: 20, avgLag: 0, want: 0.5},
+		{name: "lag at window halves score", coPosts: 10, totalA: 10, totalB: 10, avgLag: coordinationWindow.Seconds(), want: 0.5},
+		{name: "half-window lag", coPosts: 4, totalA: 8, totalB: 4, avgLag: coordinationWindow.Seconds() / 2, want: 0.75},
+		{name: "share capped at one", coPosts: 6, totalA: 5, totalB: 5, avgLag: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := coordinationScore(tt.coPosts, tt.totalA, tt.totalB, tt.avgLag)
+			if math.Abs(float64(got)-tt.want) > eps {
+				t.Errorf("coordinationScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
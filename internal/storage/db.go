@@ -12,8 +12,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -24,6 +26,7 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/rs/zerolog"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/synonyms"
 	"github.com/lueurxax/telegram-digest-bot/internal/storage/sqlc"
 	"github.com/lueurxax/telegram-digest-bot/migrations"
 	"github.com/pressly/goose/v3"
@@ -35,6 +38,8 @@ type DB struct {
 	Pool    *pgxpool.Pool
 	Queries *sqlc.Queries
 	Logger  *zerolog.Logger
+
+	searchExpander *synonyms.Expander
 }
 
 // PoolOptions configures the database connection pool.
@@ -44,6 +49,14 @@ type PoolOptions struct {
 	MaxConnIdleTime   time.Duration
 	MaxConnLifetime   time.Duration
 	HealthCheckPeriod time.Duration
+	// StatementTimeout is the server-side statement_timeout of pooled
+	// connections. Zero keeps the server default.
+	StatementTimeout time.Duration
+	// QueryTimeout bounds queries whose context has no deadline.
+	QueryTimeout time.Duration
+	// SlowQueryThreshold is the duration above which queries are logged and
+	// counted as slow. Zero disables slow-query logging.
+	SlowQueryThreshold time.Duration
 }
 
 // DefaultPoolOptions returns sensible default pool configuration.
@@ -70,6 +83,7 @@ func NewWithOptions(ctx context.Context, dsn string, opts PoolOptions, logger *z
 	}
 
 	applyPoolOptions(config, opts)
+	applyQueryOptions(config, opts, logger)
 
 	return connectWithRetries(ctx, config, logger)
 }
@@ -97,6 +111,21 @@ func applyPoolOptions(config *pgxpool.Config, opts PoolOptions) {
 	}
 }
 
+// applyQueryOptions sets the statement timeout and installs the query tracer.
+func applyQueryOptions(config *pgxpool.Config, opts PoolOptions, logger *zerolog.Logger) {
+	if opts.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams[statementTimeoutParam] = strconv.FormatInt(opts.StatementTimeout.Milliseconds(), 10)
+	}
+
+	if opts.QueryTimeout > 0 || opts.SlowQueryThreshold > 0 {
+		config.ConnConfig.Tracer = &queryTracer{
+			queryTimeout:  opts.QueryTimeout,
+			slowThreshold: opts.SlowQueryThreshold,
+			logger:        logger,
+		}
+	}
+}
+
 // connectWithRetries attempts to connect to the database with retries.
 func connectWithRetries(ctx context.Context, config *pgxpool.Config, logger *zerolog.Logger) (*DB, error) {
 	var pool *pgxpool.Pool
@@ -144,12 +173,63 @@ func (l *gooseLogger) Printf(format string, v ...interface{}) {
 // It acquires an advisory lock to ensure only one migration runs at a time
 // across multiple instances.
 func (db *DB) Migrate(ctx context.Context) error {
+	return db.migrate(ctx, func(dbSQL *sql.DB) error {
+		return goose.Up(dbSQL, ".")
+	})
+}
+
+// MigrateTo runs the migrations up to and including version, like Migrate.
+func (db *DB) MigrateTo(ctx context.Context, version int64) error {
+	return db.migrate(ctx, func(dbSQL *sql.DB) error {
+		return goose.UpTo(dbSQL, ".", version)
+	})
+}
+
+// SchemaVersion returns the latest applied migration version, or 0 when no
+// migration has run.
+func (db *DB) SchemaVersion(ctx context.Context) (int64, error) {
+	var exists bool
+
+	if err := db.Pool.QueryRow(ctx, `SELECT to_regclass('goose_db_version') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("check schema version table: %w", err)
+	}
+
+	if !exists {
+		return 0, nil
+	}
+
+	var version int64
+
+	if err := db.Pool.QueryRow(ctx, `
+		SELECT COALESCE(max(version_id), 0) FROM goose_db_version WHERE is_applied
+	`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("get schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+func (db *DB) migrate(ctx context.Context, up func(dbSQL *sql.DB) error) error {
+	// Waiting for another instance's migrations is not bounded by the
+	// default query timeout or the statement timeout.
+	ctx = WithoutQueryTimeout(ctx)
+
 	conn, err := db.Pool.Acquire(ctx)
 	if err != nil {
 		return fmt.Errorf("acquire connection: %w", err)
 	}
 	defer conn.Release()
 
+	if _, err := conn.Exec(ctx, "SET statement_timeout = 0"); err != nil {
+		return fmt.Errorf("lift statement timeout: %w", err)
+	}
+
+	defer func() {
+		if _, err := conn.Exec(ctx, "RESET statement_timeout"); err != nil {
+			db.Logger.Warn().Err(err).Msg("failed to reset statement timeout after migrations")
+		}
+	}()
+
 	// Acquire blocking advisory lock to ensure only one migration runs at a time
 	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
 		return fmt.Errorf("acquire advisory lock: %w", err)
@@ -160,7 +240,13 @@ func (db *DB) Migrate(ctx context.Context) error {
 		_, _ = conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID)
 	}()
 
-	dbSQL := stdlib.OpenDB(*db.Pool.Config().ConnConfig)
+	// Migrations may run long: they get neither the statement timeout nor the
+	// default query timeout.
+	connConfig := db.Pool.Config().ConnConfig
+	connConfig.Tracer = nil
+	delete(connConfig.RuntimeParams, statementTimeoutParam)
+
+	dbSQL := stdlib.OpenDB(*connConfig)
 
 	defer func() {
 		_ = dbSQL.Close()
@@ -173,7 +259,7 @@ func (db *DB) Migrate(ctx context.Context) error {
 		return fmt.Errorf("set goose dialect: %w", err)
 	}
 
-	if err := goose.Up(dbSQL, "."); err != nil {
+	if err := up(dbSQL); err != nil {
 		return fmt.Errorf("run migrations: %w", err)
 	}
 
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultDedupDecisionLimit = 50
+	dedupDecisionTextLimit    = 300
+)
+
+// DedupDecision records why a message was dropped as a duplicate: the matched
+// neighbor, the similarity score and the dedup mode and scope that fired.
+type DedupDecision struct {
+	RawMessageID        string    `json:"raw_message_id"`
+	Mode                string    `json:"mode"`
+	Scope               string    `json:"scope"`
+	MatchedItemID       string    `json:"matched_item_id,omitempty"`
+	MatchedRawMessageID string    `json:"matched_raw_message_id,omitempty"`
+	Similarity          float32   `json:"similarity"`
+	Threshold           float32   `json:"threshold"`
+	CreatedAt           time.Time `json:"created_at"`
+
+	// Read-only context filled by GetDedupDecisions.
+	Text           string `json:"text,omitempty"`
+	MatchedSummary string `json:"matched_summary,omitempty"`
+}
+
+// DedupDecisionFilter narrows GetDedupDecisions. ID matches the dropped
+// message, the matched item or the matched message.
+type DedupDecisionFilter struct {
+	From  *time.Time
+	To    *time.Time
+	Mode  string
+	ID    string
+	Limit int
+}
+
+// SaveDedupDecision stores a duplicate decision.
+func (db *DB) SaveDedupDecision(ctx context.Context, d DedupDecision) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO dedup_decisions (raw_message_id, mode, scope, matched_item_id, matched_raw_message_id, similarity, threshold)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, toUUID(d.RawMessageID), d.Mode, d.Scope, toUUID(d.MatchedItemID), toUUID(d.MatchedRawMessageID), d.Similarity, d.Threshold); err != nil {
+		return fmt.Errorf("save dedup decision: %w", err)
+	}
+
+	return nil
+}
+
+// GetDedupDecisions returns duplicate decisions, newest first.
+func (db *DB) GetDedupDecisions(ctx context.Context, filter DedupDecisionFilter) ([]DedupDecision, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDedupDecisionLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT d.raw_message_id, d.mode, d.scope, d.matched_item_id, d.matched_raw_message_id,
+		       d.similarity, d.threshold, d.created_at,
+		       LEFT(COALESCE(rm.text, ''), $6),
+		       COALESCE(mi.summary, LEFT(COALESCE(mrm.text, ''), $6))
+		FROM dedup_decisions d
+		JOIN raw_messages rm ON rm.id = d.raw_message_id
+		LEFT JOIN items mi ON mi.id = d.matched_item_id
+		LEFT JOIN raw_messages mrm ON mrm.id = d.matched_raw_message_id
+		WHERE ($1::timestamptz IS NULL OR d.created_at >= $1)
+		  AND ($2::timestamptz IS NULL OR d.created_at <= $2)
+		  AND ($3 = '' OR d.mode = $3)
+		  AND ($4::uuid IS NULL OR d.raw_message_id = $4 OR d.matched_item_id = $4 OR d.matched_raw_message_id = $4
+		       OR d.matched_raw_message_id = (SELECT raw_message_id FROM items WHERE id = $4))
+		ORDER BY d.created_at DESC
+		LIMIT $5
+	`, toTimestamptzPtr(filter.From), toTimestamptzPtr(filter.To), strings.TrimSpace(filter.Mode),
+		toUUID(filter.ID), safeIntToInt32(limit), dedupDecisionTextLimit)
+	if err != nil {
+		return nil, fmt.Errorf("get dedup decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := []DedupDecision{}
+
+	for rows.Next() {
+		var (
+			d          DedupDecision
+			rawID      pgtype.UUID
+			matchedID  pgtype.UUID
+			matchedRaw pgtype.UUID
+		)
+
+		if err := rows.Scan(&rawID, &d.Mode, &d.Scope, &matchedID, &matchedRaw,
+			&d.Similarity, &d.Threshold, &d.CreatedAt, &d.Text, &d.MatchedSummary); err != nil {
+			return nil, fmt.Errorf("scan dedup decision: %w", err)
+		}
+
+		d.RawMessageID = fromUUID(rawID)
+		d.MatchedItemID = fromUUID(matchedID)
+		d.MatchedRawMessageID = fromUUID(matchedRaw)
+		decisions = append(decisions, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dedup decisions: %w", err)
+	}
+
+	return decisions, nil
+}
+
+// FindStrictDuplicateMessage returns the processed message with the same
+// canonical hash that CheckStrictDuplicate matched, or "" if there is none.
+func (db *DB) FindStrictDuplicateMessage(ctx context.Context, hash, excludeID string) (string, error) {
+	var id pgtype.UUID
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT rm.id
+		FROM raw_messages rm
+		LEFT JOIN items i ON rm.id = i.raw_message_id
+		WHERE rm.canonical_hash = $1 AND rm.id != $2
+		  AND rm.processed_at IS NOT NULL AND (i.status IS NULL OR i.status != 'error')
+		ORDER BY rm.processed_at
+		LIMIT 1
+	`, hash, toUUID(excludeID)).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("find strict duplicate message: %w", err)
+	}
+
+	return fromUUID(id), nil
+}
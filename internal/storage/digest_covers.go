@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// digestCoverRetention is how long generated covers stay cached.
+const digestCoverRetention = 30 * 24 * time.Hour
+
+// GetCachedDigestCover returns the AI cover cached under key; found is false
+// when there is none.
+func (db *DB) GetCachedDigestCover(ctx context.Context, key string) (image []byte, found bool, err error) {
+	err = db.Pool.QueryRow(ctx, `SELECT image FROM digest_cover_cache WHERE cache_key = $1`, key).Scan(&image)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("get cached digest cover: %w", err)
+	}
+
+	return image, true, nil
+}
+
+// SaveCachedDigestCover caches a generated AI cover under key and drops
+// covers older than the retention period.
+func (db *DB) SaveCachedDigestCover(ctx context.Context, key string, image []byte) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO digest_cover_cache (cache_key, image) VALUES ($1, $2)
+		ON CONFLICT (cache_key) DO UPDATE SET image = EXCLUDED.image, created_at = now()
+	`, key, image); err != nil {
+		return fmt.Errorf("save cached digest cover: %w", err)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `DELETE FROM digest_cover_cache WHERE created_at < $1`, time.Now().Add(-digestCoverRetention)); err != nil {
+		return fmt.Errorf("prune digest cover cache: %w", err)
+	}
+
+	return nil
+}
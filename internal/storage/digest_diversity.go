@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DiversityCaps limits how many items of one channel and of one topic a digest
+// may include. Zero disables a cap. Items without a topic are not topic capped.
+type DiversityCaps struct {
+	MaxPerChannel int `json:"max_per_channel"`
+	MaxPerTopic   int `json:"max_per_topic"`
+}
+
+// Active reports whether any cap is set.
+func (c DiversityCaps) Active() bool {
+	return c.MaxPerChannel > 0 || c.MaxPerTopic > 0
+}
+
+// GetItemsForWindowDiverse is GetItemsForWindow with diversity caps: only the
+// top MaxPerChannel items of each channel and then the top MaxPerTopic items
+// of each topic are returned. Overflow items stay undigested.
+func (db *DB) GetItemsForWindowDiverse(ctx context.Context, start, end time.Time, importanceThreshold float32, limit int, caps DiversityCaps) ([]Item, error) {
+	rows, err := db.Pool.Query(ctx, `
+		WITH eligible AS (
+			SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i.summary, i.language,
+			       i.status, i.first_seen_at, rm.tg_date, c.username, c.title, c.tg_peer_id, rm.tg_message_id,
+			       e.embedding, i.missed_digests,
+			       row_number() OVER (PARTITION BY rm.channel_id ORDER BY i.importance_score DESC, i.relevance_score DESC) AS channel_rank
+			FROM items i
+			JOIN raw_messages rm ON i.raw_message_id = rm.id
+			JOIN channels c ON rm.channel_id = c.id
+			LEFT JOIN channel_groups g ON c.group_id = g.id
+			LEFT JOIN embeddings e ON i.id = e.item_id
+			WHERE rm.tg_date >= $1 AND rm.tg_date < $2
+			  AND i.status = 'ready'
+			  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+			  AND channel_in_digests(c, rm.tg_date)
+			  AND g.target_chat_id IS NULL
+			  AND i.digested_at IS NULL
+		), channel_capped AS (
+			SELECT *,
+			       row_number() OVER (PARTITION BY lower(btrim(topic)) ORDER BY importance_score DESC, relevance_score DESC) AS topic_rank
+			FROM eligible
+			WHERE $5 = 0 OR channel_rank <= $5
+		)
+		SELECT id, raw_message_id, relevance_score, importance_score, topic, summary, language,
+		       status, first_seen_at, tg_date, username, title, tg_peer_id, tg_message_id,
+		       embedding, missed_digests
+		FROM channel_capped
+		WHERE $6 = 0 OR topic_rank <= $6 OR COALESCE(btrim(topic), '') = ''
+		ORDER BY importance_score DESC, relevance_score DESC
+		LIMIT $4
+	`, toTimestamptz(start), toTimestamptz(end), importanceThreshold, safeIntToInt32(limit),
+		safeIntToInt32(caps.MaxPerChannel), safeIntToInt32(caps.MaxPerTopic))
+	if err != nil {
+		return nil, fmt.Errorf("get diverse items for window: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := scanSelectionItems(rows)
+	if err != nil {
+		return nil, fmt.Errorf("get diverse items for window: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,245 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pgvector/pgvector-go"
+)
+
+// ErrNoPostedDigest is returned when no digest has been posted yet.
+var ErrNoPostedDigest = errors.New("no posted digest")
+
+// PostedDigest is the most recently posted digest and its content.
+type PostedDigest struct {
+	ID       string
+	Start    time.Time
+	End      time.Time
+	PostedAt time.Time
+	// ItemCount is the number of posted items that could be resolved.
+	ItemCount     int
+	ClusterTopics []string
+}
+
+// PostedItemMatch is the posted item closest to a candidate item.
+type PostedItemMatch struct {
+	ItemID       string
+	PostedItemID string
+	Summary      string
+	Similarity   float64
+}
+
+// PostedItemEmbedding is an item posted in a recent digest with its
+// embedding and the story its digest cluster belongs to, if any.
+type PostedItemEmbedding struct {
+	ItemID    string
+	DigestID  string
+	StoryID   string
+	Embedding []float32
+}
+
+// SaveDigestItems records the items posted in a digest.
+func (db *DB) SaveDigestItems(ctx context.Context, digestID string, itemIDs []string) error {
+	ids := make([]pgtype.UUID, len(itemIDs))
+	for i, id := range itemIDs {
+		ids[i] = toUUID(id)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO digest_items (digest_id, item_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`, toUUID(digestID), ids); err != nil {
+		return fmt.Errorf("save digest items: %w", err)
+	}
+
+	return nil
+}
+
+// postedDigestItemsCTE resolves the items of digest $1: recorded digest
+// items, plus the public-channel sources of its entries for digests posted
+// before items were recorded.
+const postedDigestItemsCTE = `
+	WITH posted AS (
+		SELECT item_id FROM digest_items WHERE digest_id = $1
+		UNION
+		SELECT i.id
+		FROM digest_entries de
+		CROSS JOIN LATERAL jsonb_array_elements(de.sources_json) src
+		JOIN channels c ON c.username = src->>'channel'
+		JOIN raw_messages rm ON rm.channel_id = c.id AND rm.tg_message_id = (src->>'msg_id')::bigint
+		JOIN items i ON i.raw_message_id = rm.id
+		WHERE de.digest_id = $1 AND COALESCE(src->>'channel', '') <> ''
+	)`
+
+// GetLastPostedDigestContent returns the most recently posted digest with its item
+// count and cluster topics, or ErrNoPostedDigest.
+func (db *DB) GetLastPostedDigestContent(ctx context.Context) (*PostedDigest, error) {
+	var (
+		d  PostedDigest
+		id pgtype.UUID
+	)
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT id, window_start, window_end, posted_at
+		FROM digests
+		WHERE status = 'posted'
+		ORDER BY posted_at DESC
+		LIMIT 1
+	`).Scan(&id, &d.Start, &d.End, &d.PostedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNoPostedDigest
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get last posted digest: %w", err)
+	}
+
+	d.ID = fromUUID(id)
+
+	if err := db.Pool.QueryRow(ctx, postedDigestItemsCTE+`
+		SELECT count(*) FROM posted
+	`, id).Scan(&d.ItemCount); err != nil {
+		return nil, fmt.Errorf("count posted digest items: %w", err)
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT topic FROM clusters
+		WHERE source = $1 AND window_start = $2 AND window_end = $3 AND COALESCE(topic, '') <> ''
+		ORDER BY created_at
+	`, ClusterSourceDigest, d.Start, d.End)
+	if err != nil {
+		return nil, fmt.Errorf("get posted digest clusters: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var topic string
+		if err := rows.Scan(&topic); err != nil {
+			return nil, fmt.Errorf("scan posted digest cluster: %w", err)
+		}
+
+		d.ClusterTopics = append(d.ClusterTopics, topic)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate posted digest clusters: %w", err)
+	}
+
+	return &d, nil
+}
+
+// MatchPostedDigestItems returns, for each of itemIDs, the closest item of
+// digest digestID by embedding similarity if it reaches threshold. An item
+// posted in that digest matches itself.
+func (db *DB) MatchPostedDigestItems(ctx context.Context, digestID string, itemIDs []string, threshold float32) (map[string]PostedItemMatch, error) {
+	ids := make([]pgtype.UUID, len(itemIDs))
+	for i, id := range itemIDs {
+		ids[i] = toUUID(id)
+	}
+
+	rows, err := db.Pool.Query(ctx, postedDigestItemsCTE+`
+		SELECT cand.item_id, m.item_id, m.summary, m.similarity
+		FROM unnest($2::uuid[]) AS cand(item_id)
+		LEFT JOIN embeddings ce ON ce.item_id = cand.item_id
+		JOIN LATERAL (
+			SELECT i.id AS item_id, COALESCE(i.summary, '') AS summary,
+			       CASE WHEN i.id = cand.item_id THEN 1 ELSE 1 - (pe.embedding <=> ce.embedding) END AS similarity
+			FROM posted
+			JOIN items i ON i.id = posted.item_id
+			LEFT JOIN embeddings pe ON pe.item_id = i.id
+			WHERE i.id = cand.item_id OR (pe.embedding IS NOT NULL AND ce.embedding IS NOT NULL)
+			ORDER BY similarity DESC
+			LIMIT 1
+		) m ON m.similarity >= $3
+	`, toUUID(digestID), ids, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("match posted digest items: %w", err)
+	}
+	defer rows.Close()
+
+	matches := make(map[string]PostedItemMatch)
+
+	for rows.Next() {
+		var (
+			m        PostedItemMatch
+			itemID   pgtype.UUID
+			postedID pgtype.UUID
+		)
+
+		if err := rows.Scan(&itemID, &postedID, &m.Summary, &m.Similarity); err != nil {
+			return nil, fmt.Errorf("scan posted digest match: %w", err)
+		}
+
+		m.ItemID = fromUUID(itemID)
+		m.PostedItemID = fromUUID(postedID)
+		matches[m.ItemID] = m
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate posted digest matches: %w", err)
+	}
+
+	return matches, nil
+}
+
+// GetRecentlyPostedItems returns the items with embeddings recorded in the
+// given number of most recently posted digests.
+func (db *DB) GetRecentlyPostedItems(ctx context.Context, digests int) ([]PostedItemEmbedding, error) {
+	rows, err := db.Pool.Query(ctx, `
+		WITH recent AS (
+			SELECT id FROM digests
+			WHERE status = 'posted'
+			ORDER BY posted_at DESC
+			LIMIT $1
+		)
+		SELECT DISTINCT ON (di.item_id) di.item_id, di.digest_id, s.story_id, e.embedding
+		FROM recent r
+		JOIN digest_items di ON di.digest_id = r.id
+		JOIN embeddings e ON e.item_id = di.item_id
+		LEFT JOIN LATERAL (
+			SELECT sc.story_id
+			FROM cluster_items ci
+			JOIN clusters c ON c.id = ci.cluster_id AND c.source = $2
+			JOIN story_clusters sc ON sc.cluster_id = c.id
+			WHERE ci.item_id = di.item_id
+			ORDER BY sc.created_at DESC
+			LIMIT 1
+		) s ON true
+		ORDER BY di.item_id
+	`, digests, ClusterSourceDigest)
+	if err != nil {
+		return nil, fmt.Errorf("get recently posted items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []PostedItemEmbedding
+
+	for rows.Next() {
+		var (
+			itemID, digestID, storyID pgtype.UUID
+			embedding                 pgvector.Vector
+		)
+
+		if err := rows.Scan(&itemID, &digestID, &storyID, &embedding); err != nil {
+			return nil, fmt.Errorf("scan recently posted item: %w", err)
+		}
+
+		items = append(items, PostedItemEmbedding{
+			ItemID:    fromUUID(itemID),
+			DigestID:  fromUUID(digestID),
+			StoryID:   fromUUID(storyID),
+			Embedding: embedding.Slice(),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recently posted items: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+// DigestWindowCounts counts what the pipeline held back from a digest window.
+type DigestWindowCounts struct {
+	// DedupFolds is the number of messages folded into an existing item as duplicates.
+	DedupFolds int
+	// QuotaDrops is the number of messages dropped by channel quotas.
+	QuotaDrops int
+	// Snoozed is the number of ready items left out because their channel was snoozed.
+	Snoozed int
+}
+
+// DigestFeedback holds the ratings a posted digest and its items received.
+type DigestFeedback struct {
+	DigestID   string
+	PostedAt   time.Time
+	Up         int
+	Down       int
+	Good       int
+	Bad        int
+	Irrelevant int
+}
+
+// Total is the number of digest and item ratings.
+func (f DigestFeedback) Total() int {
+	return f.Up + f.Down + f.Good + f.Bad + f.Irrelevant
+}
+
+// GetDigestWindowCounts counts the dedup folds, quota drops and snoozed items
+// of messages posted in [start, end).
+func (db *DB) GetDigestWindowCounts(ctx context.Context, start, end time.Time) (DigestWindowCounts, error) {
+	var counts DigestWindowCounts
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT
+			(SELECT count(*)
+			 FROM dedup_decisions d
+			 JOIN raw_messages rm ON rm.id = d.raw_message_id
+			 WHERE rm.tg_date >= $1 AND rm.tg_date < $2),
+			(SELECT count(*)
+			 FROM raw_message_drop_log l
+			 JOIN raw_messages rm ON rm.id = l.raw_message_id
+			 WHERE rm.tg_date >= $1 AND rm.tg_date < $2 AND l.reason = $3),
+			(SELECT count(*)
+			 FROM items i
+			 JOIN raw_messages rm ON rm.id = i.raw_message_id
+			 JOIN channels c ON c.id = rm.channel_id
+			 WHERE rm.tg_date >= $1 AND rm.tg_date < $2 AND i.status = 'ready'
+			   AND rm.tg_date >= c.snoozed_at AND rm.tg_date < c.snoozed_until)
+	`, start, end, domain.DropReasonChannelQuota).Scan(&counts.DedupFolds, &counts.QuotaDrops, &counts.Snoozed)
+	if err != nil {
+		return DigestWindowCounts{}, fmt.Errorf("get digest window counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetPreviousDigestFeedback returns the ratings of the last posted digest
+// other than excludeID, counting item ratings of the items it marked as
+// digested. The boolean is false when there is no such digest.
+func (db *DB) GetPreviousDigestFeedback(ctx context.Context, excludeID string) (DigestFeedback, bool, error) {
+	var f DigestFeedback
+
+	err := db.Pool.QueryRow(ctx, `
+		WITH ordered AS (
+			SELECT id, window_start, posted_at,
+			       lag(posted_at) OVER (ORDER BY posted_at) AS previous_posted_at
+			FROM digests
+			WHERE status = 'posted' AND posted_at IS NOT NULL AND ($1::uuid IS NULL OR id <> $1)
+		),
+		prev AS (
+			SELECT * FROM ordered ORDER BY posted_at DESC LIMIT 1
+		)
+		SELECT p.id::text, p.posted_at,
+		       (SELECT count(*) FROM digest_ratings r WHERE r.digest_id = p.id AND r.rating > 0),
+		       (SELECT count(*) FROM digest_ratings r WHERE r.digest_id = p.id AND r.rating < 0),
+		       count(ir.id) FILTER (WHERE ir.rating = 'good'),
+		       count(ir.id) FILTER (WHERE ir.rating = 'bad'),
+		       count(ir.id) FILTER (WHERE ir.rating = 'irrelevant')
+		FROM prev p
+		LEFT JOIN items i ON i.digested_at > COALESCE(p.previous_posted_at, p.window_start) AND i.digested_at <= p.posted_at
+		LEFT JOIN item_ratings ir ON ir.item_id = i.id
+		GROUP BY p.id, p.posted_at
+	`, toUUID(excludeID)).Scan(&f.DigestID, &f.PostedAt, &f.Up, &f.Down, &f.Good, &f.Bad, &f.Irrelevant)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DigestFeedback{}, false, nil
+	}
+
+	if err != nil {
+		return DigestFeedback{}, false, fmt.Errorf("get previous digest feedback: %w", err)
+	}
+
+	return f, true, nil
+}
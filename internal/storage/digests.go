@@ -99,8 +99,11 @@ func (db *DB) GetItemsForWindowWithMedia(ctx context.Context, start, end time.Ti
 				SourceChannelID:    item.SourceChannelID,
 				SourceMsgID:        item.SourceMsgID,
 				Embedding:          item.Embedding.Slice(),
+				AltText:            item.AltText.String,
+				SafetyFlags:        item.SafetyFlags,
 			},
-			MediaData: item.MediaData,
+			MediaData:     item.MediaData,
+			VideoDuration: int(item.VideoDurationSeconds.Int32),
 		}
 	}
 
@@ -57,7 +57,7 @@ type Discovery struct {
 	TGPeerID      int64
 	InviteLink    string
 	Title         string
-	SourceType    string // "forward", "link", "mention", "reply", "entity_*"
+	SourceType    string // "forward", "link", "mention", "reply", "entity_*", "similar_channels", "folder"
 	FromChannelID string
 	Views         int
 	Forwards      int
@@ -510,6 +510,25 @@ func (db *DB) UpdateDiscoveryFromInvite(ctx context.Context, id string, title st
 	return nil
 }
 
+// MarkDiscoveryExpanded marks a shared folder link discovery as expanded into its
+// channels and returns the tracked channel it was discovered from.
+func (db *DB) MarkDiscoveryExpanded(ctx context.Context, id string, title string) (string, error) {
+	var fromChannelID pgtype.UUID
+
+	if err := db.Pool.QueryRow(ctx, `
+		UPDATE discovered_channels
+		SET status = $2,
+		    title = COALESCE(NULLIF($3, ''), title),
+		    status_changed_at = now()
+		WHERE id = $1
+		RETURNING discovered_from_channel_id
+	`, toUUID(id), DiscoveryStatusExpanded, SanitizeUTF8(title)).Scan(&fromChannelID); err != nil {
+		return "", fmt.Errorf("mark discovery expanded: %w", err)
+	}
+
+	return fromUUID(fromChannelID), nil
+}
+
 // CleanupDiscoveriesBatch marks discoveries as added when a tracked channel matches identifiers.
 func (db *DB) CleanupDiscoveriesBatch(ctx context.Context, limit int, adminID int64) (int, error) {
 	allowedStatuses := []string{DiscoveryStatusPending, DiscoveryStatusRejected, DiscoveryStatusAdded}
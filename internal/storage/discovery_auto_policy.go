@@ -0,0 +1,482 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/links"
+)
+
+// Discovery auto-policy actions.
+const (
+	DiscoveryAutoActionApprove = "approve"
+	DiscoveryAutoActionReject  = "reject"
+	DiscoveryAutoActionHold    = "hold"
+)
+
+// Discovery auto-policy rule names, as recorded in the decision log.
+const (
+	DiscoveryRuleMinSeen       = "min_seen"
+	DiscoveryRuleMinEngagement = "min_engagement"
+	DiscoveryRuleCategory      = "category"
+	DiscoveryRuleLanguage      = "language"
+	DiscoveryRuleLowEngagement = "low_engagement"
+)
+
+const (
+	// DiscoveryTrialReason marks the weight override applied to auto-approved channels.
+	DiscoveryTrialReason = "auto-approval trial"
+	// discoveryTrialEndedReason is recorded in weight history when a trial finishes.
+	discoveryTrialEndedReason = "auto-approval trial ended"
+
+	defaultDiscoveryAutoMinSeen       = 5
+	defaultDiscoveryAutoMinEngagement = 200
+	defaultDiscoveryAutoTrialDays     = 14
+	defaultDiscoveryAutoTrialWeight   = 0.5
+	defaultDiscoveryAutoLogLimit      = 20
+	discoveryAutoCandidateLimit       = 500
+)
+
+// DiscoveryAutoPolicy configures automatic approval and rejection of pending discoveries.
+// A discovery is approved when every configured rule passes, rejected when a reject
+// rule fires, and otherwise left pending for manual review.
+type DiscoveryAutoPolicy struct {
+	Enabled bool `json:"enabled"`
+
+	// ApproveMinSeen and ApproveMinEngagement are the thresholds a discovery must reach
+	// before it is approved.
+	ApproveMinSeen       int     `json:"approve_min_seen"`
+	ApproveMinEngagement float64 `json:"approve_min_engagement"`
+	// Categories limits approval to discoveries surfaced by channels in these categories.
+	Categories []string `json:"categories,omitempty"`
+	// Languages limits approval to discoveries whose title and description are in these languages.
+	Languages []string `json:"languages,omitempty"`
+
+	// RejectBelowEngagement rejects discoveries seen at least RejectMinSeen times whose
+	// engagement is still below this value. Zero disables the rule.
+	RejectBelowEngagement float64 `json:"reject_below_engagement"`
+	RejectMinSeen         int     `json:"reject_min_seen"`
+	// RejectMismatched rejects discoveries that fail the category or language rule
+	// instead of leaving them pending.
+	RejectMismatched bool `json:"reject_mismatched"`
+
+	// TrialDays is how long auto-approved channels run with TrialWeight before
+	// returning to the default weight. Zero disables the trial.
+	TrialDays   int     `json:"trial_days"`
+	TrialWeight float32 `json:"trial_weight"`
+}
+
+// DefaultDiscoveryAutoPolicy returns a disabled policy with conservative thresholds.
+func DefaultDiscoveryAutoPolicy() DiscoveryAutoPolicy {
+	return DiscoveryAutoPolicy{
+		ApproveMinSeen:       defaultDiscoveryAutoMinSeen,
+		ApproveMinEngagement: defaultDiscoveryAutoMinEngagement,
+		TrialDays:            defaultDiscoveryAutoTrialDays,
+		TrialWeight:          defaultDiscoveryAutoTrialWeight,
+	}
+}
+
+// DiscoveryAutoCandidate is a pending discovery with the context the policy needs.
+type DiscoveryAutoCandidate struct {
+	DiscoveredChannel
+
+	// SourceCategory is the category of the tracked channel the discovery came from.
+	SourceCategory string
+	// Language is detected from the discovery's title and description.
+	Language string
+}
+
+// DiscoveryRuleResult is the outcome of a single policy rule for a discovery.
+type DiscoveryRuleResult struct {
+	Rule   string `json:"rule"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// DiscoveryAutoDecision is the policy outcome for one discovery.
+type DiscoveryAutoDecision struct {
+	DiscoveryID string
+	Username    string
+	Action      string
+	Rules       []DiscoveryRuleResult
+}
+
+// DiscoveryAutoDecisionEntry is a row of the auto-policy decision log.
+type DiscoveryAutoDecisionEntry struct {
+	Username  string
+	Action    string
+	Rules     []DiscoveryRuleResult
+	DecidedAt time.Time
+}
+
+// EvaluateDiscoveryAutoPolicy applies the policy rules to a candidate and returns the
+// resulting action together with the result of every rule.
+func EvaluateDiscoveryAutoPolicy(policy DiscoveryAutoPolicy, c DiscoveryAutoCandidate) DiscoveryAutoDecision {
+	decision := DiscoveryAutoDecision{
+		DiscoveryID: c.ID,
+		Username:    c.Username,
+		Action:      DiscoveryAutoActionHold,
+	}
+
+	lowEngagement, hasLowEngagement := lowEngagementRule(policy, c)
+	if hasLowEngagement {
+		decision.Rules = append(decision.Rules, lowEngagement)
+	}
+
+	approveRules := []DiscoveryRuleResult{
+		{
+			Rule:   DiscoveryRuleMinSeen,
+			Passed: c.DiscoveryCount >= policy.ApproveMinSeen,
+			Detail: fmt.Sprintf("seen %d, need %d", c.DiscoveryCount, policy.ApproveMinSeen),
+		},
+		{
+			Rule:   DiscoveryRuleMinEngagement,
+			Passed: c.EngagementScore >= policy.ApproveMinEngagement,
+			Detail: fmt.Sprintf("engagement %.0f, need %.0f", c.EngagementScore, policy.ApproveMinEngagement),
+		},
+	}
+
+	mismatch := matchRules(policy, c)
+	decision.Rules = append(decision.Rules, approveRules...)
+	decision.Rules = append(decision.Rules, mismatch...)
+
+	switch {
+	case hasLowEngagement && !lowEngagement.Passed:
+		decision.Action = DiscoveryAutoActionReject
+	case policy.RejectMismatched && hasDefinedMismatch(mismatch, c):
+		decision.Action = DiscoveryAutoActionReject
+	case allRulesPassed(decision.Rules):
+		decision.Action = DiscoveryAutoActionApprove
+	}
+
+	return decision
+}
+
+// lowEngagementRule fails when a discovery has been seen often enough to judge yet
+// its engagement is still below the reject threshold.
+func lowEngagementRule(policy DiscoveryAutoPolicy, c DiscoveryAutoCandidate) (DiscoveryRuleResult, bool) {
+	if policy.RejectBelowEngagement <= 0 {
+		return DiscoveryRuleResult{}, false
+	}
+
+	low := c.DiscoveryCount >= policy.RejectMinSeen && c.EngagementScore < policy.RejectBelowEngagement
+
+	return DiscoveryRuleResult{
+		Rule:   DiscoveryRuleLowEngagement,
+		Passed: !low,
+		Detail: fmt.Sprintf("engagement %.0f after %d sightings, reject below %.0f", c.EngagementScore, c.DiscoveryCount, policy.RejectBelowEngagement),
+	}, true
+}
+
+// matchRules checks the category and language allowlists, when configured.
+func matchRules(policy DiscoveryAutoPolicy, c DiscoveryAutoCandidate) []DiscoveryRuleResult {
+	var rules []DiscoveryRuleResult
+
+	if len(policy.Categories) > 0 {
+		rules = append(rules, DiscoveryRuleResult{
+			Rule:   DiscoveryRuleCategory,
+			Passed: containsFold(policy.Categories, c.SourceCategory),
+			Detail: fmt.Sprintf("source category %q, allowed %s", c.SourceCategory, strings.Join(policy.Categories, ",")),
+		})
+	}
+
+	if len(policy.Languages) > 0 {
+		rules = append(rules, DiscoveryRuleResult{
+			Rule:   DiscoveryRuleLanguage,
+			Passed: containsFold(policy.Languages, c.Language),
+			Detail: fmt.Sprintf("language %q, allowed %s", c.Language, strings.Join(policy.Languages, ",")),
+		})
+	}
+
+	return rules
+}
+
+// hasDefinedMismatch reports whether a category or language rule failed on a known
+// value. Unknown categories or languages keep the discovery pending instead.
+func hasDefinedMismatch(rules []DiscoveryRuleResult, c DiscoveryAutoCandidate) bool {
+	for _, r := range rules {
+		if r.Passed {
+			continue
+		}
+
+		if r.Rule == DiscoveryRuleCategory && c.SourceCategory != "" {
+			return true
+		}
+
+		if r.Rule == DiscoveryRuleLanguage && c.Language != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allRulesPassed(rules []DiscoveryRuleResult) bool {
+	for _, r := range rules {
+		if !r.Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(values []string, v string) bool {
+	if v == "" {
+		return false
+	}
+
+	for _, candidate := range values {
+		if strings.EqualFold(strings.TrimSpace(candidate), v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetDiscoveryAutoCandidates returns actionable pending discoveries with the category of
+// their source channel and a detected language.
+func (db *DB) GetDiscoveryAutoCandidates(ctx context.Context) ([]DiscoveryAutoCandidate, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT DISTINCT ON (lower(dc.username))
+		       dc.id, dc.username, COALESCE(dc.title, ''), COALESCE(dc.description, ''),
+		       dc.discovery_count, COALESCE(dc.engagement_score, 0), COALESCE(src.category, '')
+		FROM discovered_channels dc
+		LEFT JOIN channels src ON src.id = dc.discovered_from_channel_id
+		WHERE dc.status = $1
+		  AND dc.username IS NOT NULL AND dc.username != ''
+		  AND dc.matched_channel_id IS NULL
+		  AND NOT EXISTS (
+		    SELECT 1
+		    FROM channels c
+		    WHERE c.is_active = TRUE AND (
+		      (c.username != '' AND lower(c.username) = lower(dc.username)) OR
+		      (c.tg_peer_id = dc.tg_peer_id AND dc.tg_peer_id != 0 AND c.tg_peer_id != 0)
+		    )
+		  )
+		ORDER BY lower(dc.username), dc.engagement_score DESC
+		LIMIT $2
+	`, DiscoveryStatusPending, discoveryAutoCandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("get discovery auto candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []DiscoveryAutoCandidate
+
+	for rows.Next() {
+		var (
+			c          DiscoveryAutoCandidate
+			id         pgtype.UUID
+			count      int32
+			engagement float32
+		)
+
+		if err := rows.Scan(&id, &c.Username, &c.Title, &c.Description, &count, &engagement, &c.SourceCategory); err != nil {
+			return nil, fmt.Errorf("scan discovery auto candidate: %w", err)
+		}
+
+		c.ID = fromUUID(id)
+		c.DiscoveryCount = int(count)
+		c.EngagementScore = float64(engagement)
+		c.Language = links.DetectLanguage(strings.TrimSpace(c.Title + " " + c.Description))
+		candidates = append(candidates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate discovery auto candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// ApplyDiscoveryAutoDecision approves or rejects a discovery according to the decision
+// and records it in the decision log. Approved channels start a trial at reduced weight
+// when the policy configures one. Hold decisions are not recorded.
+func (db *DB) ApplyDiscoveryAutoDecision(ctx context.Context, policy DiscoveryAutoPolicy, decision DiscoveryAutoDecision, now time.Time) error {
+	switch decision.Action {
+	case DiscoveryAutoActionApprove:
+		if err := db.ApproveDiscovery(ctx, decision.Username, 0); err != nil {
+			return err
+		}
+
+		if err := db.startChannelTrial(ctx, decision.Username, policy, now); err != nil {
+			return err
+		}
+	case DiscoveryAutoActionReject:
+		if err := db.RejectDiscovery(ctx, decision.Username, 0); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	return db.recordDiscoveryAutoDecision(ctx, decision)
+}
+
+// startChannelTrial lowers the weight of a newly approved channel until the trial ends.
+func (db *DB) startChannelTrial(ctx context.Context, username string, policy DiscoveryAutoPolicy, now time.Time) error {
+	if policy.TrialDays <= 0 || policy.TrialWeight <= 0 {
+		return nil
+	}
+
+	identifier := normalizeUsername(username)
+
+	if _, err := db.UpdateChannelWeight(ctx, identifier, policy.TrialWeight, false, true, DiscoveryTrialReason, 0); err != nil {
+		return fmt.Errorf("set trial weight: %w", err)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE channels SET trial_until = $2
+		WHERE lower(username) = lower($1)
+	`, identifier, now.AddDate(0, 0, policy.TrialDays)); err != nil {
+		return fmt.Errorf("set channel trial: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) recordDiscoveryAutoDecision(ctx context.Context, decision DiscoveryAutoDecision) error {
+	rules, err := json.Marshal(decision.Rules)
+	if err != nil {
+		return fmt.Errorf("marshal discovery rules: %w", err)
+	}
+
+	var discoveryID pgtype.UUID
+	if decision.DiscoveryID != "" {
+		discoveryID = toUUID(decision.DiscoveryID)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO discovery_auto_decisions (discovery_id, username, action, rules)
+		VALUES ($1, $2, $3, $4)
+	`, discoveryID, normalizeUsername(decision.Username), decision.Action, rules); err != nil {
+		return fmt.Errorf("record discovery auto decision: %w", err)
+	}
+
+	return nil
+}
+
+// endExpiredChannelTrialsQuery ends the discovery trials that have expired.
+// Only channels still at the trial weight match: other channels with a
+// trial_until, e.g. ones whose weight an admin changed during the trial, are
+// left as they are.
+const endExpiredChannelTrialsQuery = `
+		UPDATE channels
+		SET trial_until = NULL
+		WHERE trial_until IS NOT NULL AND trial_until <= $1
+		  AND weight_override_reason = $2
+		RETURNING id, COALESCE(username, '')
+	`
+
+// EndExpiredChannelTrials restores the default weight of channels whose auto-approval
+// trial has ended and returns their usernames. Channels whose weight was changed by an
+// admin during the trial keep that weight.
+func (db *DB) EndExpiredChannelTrials(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := db.Pool.Query(ctx, endExpiredChannelTrialsQuery, now, DiscoveryTrialReason)
+	if err != nil {
+		return nil, fmt.Errorf("end channel trials: %w", err)
+	}
+
+	var ids, usernames []string
+
+	for rows.Next() {
+		var (
+			id       pgtype.UUID
+			username string
+		)
+
+		if err := rows.Scan(&id, &username); err != nil {
+			rows.Close()
+
+			return nil, fmt.Errorf("scan ended trial: %w", err)
+		}
+
+		ids = append(ids, fromUUID(id))
+		usernames = append(usernames, username)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate ended trials: %w", err)
+	}
+
+	for i, id := range ids {
+		if err := db.restoreTrialWeight(ctx, id); err != nil {
+			return usernames[:i], err
+		}
+	}
+
+	return usernames, nil
+}
+
+func (db *DB) restoreTrialWeight(ctx context.Context, channelID string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE channels
+		SET importance_weight = $2,
+		    auto_weight_enabled = TRUE,
+		    weight_override = FALSE,
+		    weight_override_reason = $3,
+		    weight_updated_at = now(),
+		    weight_updated_by = NULL
+		WHERE id = $1
+	`, toUUID(channelID), DefaultImportanceWeight, discoveryTrialEndedReason); err != nil {
+		return fmt.Errorf("restore trial weight: %w", err)
+	}
+
+	if err := db.insertChannelWeightHistory(ctx, channelID, DefaultImportanceWeight, true, false, discoveryTrialEndedReason, nil); err != nil {
+		return fmt.Errorf(errInsertChannelWeightHistory, err)
+	}
+
+	return nil
+}
+
+// GetDiscoveryAutoDecisions returns the most recent auto-policy decisions.
+func (db *DB) GetDiscoveryAutoDecisions(ctx context.Context, limit int) ([]DiscoveryAutoDecisionEntry, error) {
+	if limit <= 0 {
+		limit = defaultDiscoveryAutoLogLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT username, action, rules, decided_at
+		FROM discovery_auto_decisions
+		ORDER BY decided_at DESC
+		LIMIT $1
+	`, safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get discovery auto decisions: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []DiscoveryAutoDecisionEntry{}
+
+	for rows.Next() {
+		var (
+			entry DiscoveryAutoDecisionEntry
+			rules []byte
+		)
+
+		if err := rows.Scan(&entry.Username, &entry.Action, &rules, &entry.DecidedAt); err != nil {
+			return nil, fmt.Errorf("scan discovery auto decision: %w", err)
+		}
+
+		if err := json.Unmarshal(rules, &entry.Rules); err != nil {
+			return nil, fmt.Errorf("unmarshal discovery rules: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate discovery auto decisions: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+func autoCandidate(seen int, engagement float64, category, language string) DiscoveryAutoCandidate {
+	return DiscoveryAutoCandidate{
+		DiscoveredChannel: DiscoveredChannel{Username: "candidate", DiscoveryCount: seen, EngagementScore: engagement},
+		SourceCategory:    category,
+		Language:          language,
+	}
+}
+
+func TestEvaluateDiscoveryAutoPolicy(t *testing.T) {
+	policy := DiscoveryAutoPolicy{
+		Enabled:               true,
+		ApproveMinSeen:        5,
+		ApproveMinEngagement:  200,
+		Categories:            []string{"Tech", "science"},
+		Languages:             []string{"en"},
+		RejectBelowEngagement: 20,
+		RejectMinSeen:         10,
+	}
+
+	tests := []struct {
+		name       string
+		policy     DiscoveryAutoPolicy
+		candidate  DiscoveryAutoCandidate
+		wantAction string
+	}{
+		{"all rules pass", policy, autoCandidate(6, 300, "tech", "en"), DiscoveryAutoActionApprove},
+		{"below approve thresholds", policy, autoCandidate(3, 300, "tech", "en"), DiscoveryAutoActionHold},
+		{"category mismatch holds", policy, autoCandidate(6, 300, "sports", "en"), DiscoveryAutoActionHold},
+		{"low engagement after many sightings", policy, autoCandidate(12, 10, "tech", "en"), DiscoveryAutoActionReject},
+		{"low engagement too early to judge", policy, autoCandidate(4, 10, "tech", "en"), DiscoveryAutoActionHold},
+		{
+			"mismatch rejects when configured",
+			func() DiscoveryAutoPolicy { p := policy; p.RejectMismatched = true; return p }(),
+			autoCandidate(2, 50, "tech", "ru"),
+			DiscoveryAutoActionReject,
+		},
+		{
+			"unknown language is not a mismatch",
+			func() DiscoveryAutoPolicy { p := policy; p.RejectMismatched = true; return p }(),
+			autoCandidate(2, 50, "tech", ""),
+			DiscoveryAutoActionHold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateDiscoveryAutoPolicy(tt.policy, tt.candidate)
+			if got.Action != tt.wantAction {
+				t.Errorf("action = %q, want %q (rules: %+v)", got.Action, tt.wantAction, got.Rules)
+			}
+		})
+	}
+}
+
+func TestEvaluateDiscoveryAutoPolicyRecordsEveryRule(t *testing.T) {
+	policy := DiscoveryAutoPolicy{
+		ApproveMinSeen:        1,
+		Categories:            []string{"tech"},
+		Languages:             []string{"en"},
+		RejectBelowEngagement: 1,
+	}
+
+	got := EvaluateDiscoveryAutoPolicy(policy, autoCandidate(1, 5, "tech", "en"))
+
+	want := []string{DiscoveryRuleLowEngagement, DiscoveryRuleMinSeen, DiscoveryRuleMinEngagement, DiscoveryRuleCategory, DiscoveryRuleLanguage}
+	if len(got.Rules) != len(want) {
+		t.Fatalf("got %d rules, want %d: %+v", len(got.Rules), len(want), got.Rules)
+	}
+
+	for i, rule := range want {
+		if got.Rules[i].Rule != rule {
+			t.Errorf("rule[%d] = %q, want %q", i, got.Rules[i].Rule, rule)
+		}
+
+		if got.Rules[i].Detail == "" {
+			t.Errorf("rule %q has no detail", rule)
+		}
+	}
+}
+
+func TestEndExpiredChannelTrialsKeepsNonDiscoveryTrials(t *testing.T) {
+	where, _, ok := strings.Cut(endExpiredChannelTrialsQuery, "RETURNING")
+	if !ok {
+		t.Fatalf("query has no RETURNING clause:\n%s", endExpiredChannelTrialsQuery)
+	}
+
+	// Without the reason in the WHERE clause, a channel with a trial_until
+	// that is not a discovery trial would have it cleared too.
+	if !strings.Contains(where, "AND weight_override_reason = $2") {
+		t.Errorf("query ends trials regardless of the weight override reason:\n%s", endExpiredChannelTrialsQuery)
+	}
+}
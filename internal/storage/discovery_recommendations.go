@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// recommendQualityLookbackDays is the quality history window used to rank tracked channels.
+	recommendQualityLookbackDays = 30
+	// recommendTopPerformers is how many of the best tracked channels define "similar".
+	recommendTopPerformers = 10
+	// recommendCandidatePool caps how many pending discoveries are scored.
+	recommendCandidatePool = 200
+	// recommendDiscoveryCountCap and recommendEngagementCap are the values at which
+	// discovery count and engagement stop adding to the score.
+	recommendDiscoveryCountCap = 20
+	recommendEngagementCap     = 1000
+
+	// Score weights for channel recommendations; they sum to 1.
+	recommendWeightQuality    = 0.4
+	recommendWeightSimilarity = 0.25
+	recommendWeightDiscovery  = 0.2
+	recommendWeightEngagement = 0.15
+)
+
+// ChannelRecommendation is a pending discovery ranked by how similar it is to the
+// best-performing tracked channels.
+type ChannelRecommendation struct {
+	Username        string
+	Title           string
+	Description     string
+	DiscoveryCount  int
+	EngagementScore float64
+
+	// SourceUsername and SourceTitle identify the tracked channel the discovery came from.
+	SourceUsername string
+	SourceTitle    string
+	// SourceInclusionRate is the share of the source channel's items that reached digests.
+	SourceInclusionRate float64
+	// SourceOverlap is the source channel's highest Jaccard overlap with a top performer,
+	// or 1 when the source is itself a top performer.
+	SourceOverlap float64
+	// SourceIsTopPerformer reports whether the source is among the best tracked channels.
+	SourceIsTopPerformer bool
+
+	Score float64
+}
+
+// ScoreChannelRecommendation combines source quality, similarity to top performers,
+// discovery count and engagement into a 0-1 recommendation score.
+func ScoreChannelRecommendation(r ChannelRecommendation) float64 {
+	quality := clampUnitFloat(r.SourceInclusionRate)
+	similarity := clampUnitFloat(r.SourceOverlap)
+	discovery := logScale(float64(r.DiscoveryCount), recommendDiscoveryCountCap)
+	engagement := logScale(r.EngagementScore, recommendEngagementCap)
+
+	return recommendWeightQuality*quality +
+		recommendWeightSimilarity*similarity +
+		recommendWeightDiscovery*discovery +
+		recommendWeightEngagement*engagement
+}
+
+// logScale maps v onto 0-1 logarithmically, reaching 1 at limit.
+func logScale(v, limit float64) float64 {
+	if v <= 0 {
+		return 0
+	}
+
+	return math.Min(math.Log1p(v)/math.Log1p(limit), 1)
+}
+
+func clampUnitFloat(v float64) float64 {
+	return math.Min(math.Max(v, 0), 1)
+}
+
+// GetChannelRecommendations returns pending discoveries surfaced by tracked channels,
+// scored by source quality, overlap with top performers, discovery count and engagement.
+func (db *DB) GetChannelRecommendations(ctx context.Context, limit int) ([]ChannelRecommendation, error) {
+	since := time.Now().AddDate(0, 0, -recommendQualityLookbackDays)
+
+	rows, err := db.Pool.Query(ctx, `
+		WITH quality AS (
+			SELECT channel_id, AVG(inclusion_rate) AS inclusion_rate, AVG(avg_importance) AS avg_importance
+			FROM channel_quality_history
+			WHERE period_start >= $1
+			GROUP BY channel_id
+		),
+		top_performers AS (
+			SELECT q.channel_id
+			FROM quality q
+			JOIN channels c ON c.id = q.channel_id AND c.is_active = TRUE
+			ORDER BY q.inclusion_rate DESC, q.avg_importance DESC
+			LIMIT $2
+		),
+		overlap AS (
+			SELECT o.channel_id, MAX(o.jaccard) AS jaccard
+			FROM (
+				SELECT channel_a AS channel_id, channel_b AS other_id, jaccard FROM mv_channel_overlap
+				UNION ALL
+				SELECT channel_b AS channel_id, channel_a AS other_id, jaccard FROM mv_channel_overlap
+			) o
+			WHERE o.other_id IN (SELECT channel_id FROM top_performers)
+			GROUP BY o.channel_id
+		),
+		candidates AS (
+			SELECT DISTINCT ON (dc.username)
+			       dc.username, COALESCE(dc.title, '') AS title, COALESCE(dc.description, '') AS description,
+			       dc.discovery_count, COALESCE(dc.engagement_score, 0) AS engagement_score,
+			       dc.discovered_from_channel_id
+			FROM discovered_channels dc
+			WHERE dc.status = 'pending'
+			  AND dc.username IS NOT NULL AND dc.username != ''
+			  AND dc.matched_channel_id IS NULL
+			  AND dc.discovered_from_channel_id IS NOT NULL
+			  AND NOT EXISTS (
+			    SELECT 1
+			    FROM channels c
+			    WHERE c.is_active = TRUE AND (
+			      (c.username != '' AND lower(c.username) = lower(dc.username)) OR
+			      (c.tg_peer_id = dc.tg_peer_id AND dc.tg_peer_id != 0 AND c.tg_peer_id != 0)
+			    )
+			  )
+			ORDER BY dc.username, dc.engagement_score DESC
+		)
+		SELECT cand.username, cand.title, cand.description, cand.discovery_count, cand.engagement_score,
+		       COALESCE(src.username, ''), COALESCE(src.title, ''),
+		       COALESCE(q.inclusion_rate, 0),
+		       COALESCE(o.jaccard, 0),
+		       src.id IN (SELECT channel_id FROM top_performers)
+		FROM candidates cand
+		JOIN channels src ON src.id = cand.discovered_from_channel_id AND src.is_active = TRUE
+		LEFT JOIN quality q ON q.channel_id = src.id
+		LEFT JOIN overlap o ON o.channel_id = src.id
+		ORDER BY cand.engagement_score DESC, cand.discovery_count DESC
+		LIMIT $3
+	`, since, recommendTopPerformers, recommendCandidatePool)
+	if err != nil {
+		return nil, fmt.Errorf("get channel recommendations: %w", err)
+	}
+	defer rows.Close()
+
+	var recs []ChannelRecommendation
+
+	for rows.Next() {
+		var (
+			r          ChannelRecommendation
+			count      int32
+			engagement float32
+		)
+
+		if err := rows.Scan(&r.Username, &r.Title, &r.Description, &count, &engagement,
+			&r.SourceUsername, &r.SourceTitle, &r.SourceInclusionRate, &r.SourceOverlap, &r.SourceIsTopPerformer); err != nil {
+			return nil, fmt.Errorf("scan channel recommendation: %w", err)
+		}
+
+		r.DiscoveryCount = int(count)
+		r.EngagementScore = float64(engagement)
+
+		if r.SourceIsTopPerformer {
+			r.SourceOverlap = 1
+		}
+
+		r.Score = ScoreChannelRecommendation(r)
+		recs = append(recs, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel recommendations: %w", err)
+	}
+
+	return rankChannelRecommendations(recs, limit), nil
+}
+
+// rankChannelRecommendations sorts recommendations by score and keeps the top limit.
+func rankChannelRecommendations(recs []ChannelRecommendation, limit int) []ChannelRecommendation {
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+
+		return recs[i].Username < recs[j].Username
+	})
+
+	if limit > 0 && len(recs) > limit {
+		recs = recs[:limit]
+	}
+
+	return recs
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreChannelRecommendation(t *testing.T) {
+	const eps = 1e-9
+
+	empty := ScoreChannelRecommendation(ChannelRecommendation{})
+	if empty != 0 {
+		t.Errorf("empty recommendation score = %v, want 0", empty)
+	}
+
+	full := ScoreChannelRecommendation(ChannelRecommendation{
+		SourceInclusionRate: 1.5,
+		SourceOverlap:       1,
+		DiscoveryCount:      recommendDiscoveryCountCap * 2,
+		EngagementScore:     recommendEngagementCap * 2,
+	})
+	if math.Abs(full-1) > eps {
+		t.Errorf("saturated recommendation score = %v, want 1", full)
+	}
+
+	weak := ScoreChannelRecommendation(ChannelRecommendation{SourceInclusionRate: 0.1, DiscoveryCount: 2, EngagementScore: 60})
+	strong := ScoreChannelRecommendation(ChannelRecommendation{SourceInclusionRate: 0.7, SourceOverlap: 0.4, DiscoveryCount: 2, EngagementScore: 60})
+
+	if strong <= weak {
+		t.Errorf("better source should score higher: strong=%v weak=%v", strong, weak)
+	}
+}
+
+func TestRankChannelRecommendations(t *testing.T) {
+	recs := []ChannelRecommendation{
+		{Username: "b", Score: 0.5},
+		{Username: "c", Score: 0.9},
+		{Username: "a", Score: 0.5},
+	}
+
+	ranked := rankChannelRecommendations(recs, 2)
+	if len(ranked) != 2 {
+		t.Fatalf("expected 2 recommendations, got %d", len(ranked))
+	}
+
+	if ranked[0].Username != "c" || ranked[1].Username != "a" {
+		t.Errorf("unexpected order: %s, %s", ranked[0].Username, ranked[1].Username)
+	}
+}
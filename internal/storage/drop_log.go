@@ -9,17 +9,43 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
 	"github.com/lueurxax/telegram-digest-bot/internal/storage/sqlc"
 )
 
 // ErrDropLogNotFound is returned when no drop log exists for a message.
 var ErrDropLogNotFound = errors.New("drop log not found")
 
+// ErrUnknownDropGroup is returned for an unsupported drop reason grouping.
+var ErrUnknownDropGroup = errors.New("unknown drop reason grouping")
+
 type DropReasonStat struct {
 	Reason string
 	Count  int
 }
 
+// Drop reason breakdown groupings.
+const (
+	DropGroupNone    = ""
+	DropGroupChannel = "channel"
+	DropGroupDay     = "day"
+)
+
+// DropReasonBreakdownRow counts the drops of one reason within a group: a
+// channel label, a YYYY-MM-DD day, or empty when not grouped.
+type DropReasonBreakdownRow struct {
+	Group  string
+	Reason string
+	Count  int
+}
+
+// dropGroupExprs are the SQL expressions of the drop reason groupings.
+var dropGroupExprs = map[string]string{
+	DropGroupNone:    "''",
+	DropGroupChannel: "COALESCE(NULLIF('@' || c.username, '@'), NULLIF(c.title, ''), c.id::text)",
+	DropGroupDay:     "to_char(d.tg_date, 'YYYY-MM-DD')",
+}
+
 type RawMessageDropInfo struct {
 	Reason    string
 	Detail    string
@@ -90,3 +116,56 @@ func (db *DB) GetDropReasonStats(ctx context.Context, since time.Time, limit int
 
 	return stats, nil
 }
+
+// GetDropReasonBreakdown counts the drop reasons of messages posted since the
+// given time, grouped by channel or day. Ready items held back by a channel
+// snooze are counted under the channel_snoozed reason, since they never reach
+// a digest either.
+func (db *DB) GetDropReasonBreakdown(ctx context.Context, since time.Time, group string) ([]DropReasonBreakdownRow, error) {
+	groupExpr, ok := dropGroupExprs[group]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDropGroup, group)
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		WITH drops AS (
+			SELECT rm.channel_id, rm.tg_date, l.reason
+			FROM raw_message_drop_log l
+			JOIN raw_messages rm ON rm.id = l.raw_message_id
+			WHERE rm.tg_date >= $1
+			UNION ALL
+			SELECT rm.channel_id, rm.tg_date, $2::text
+			FROM items i
+			JOIN raw_messages rm ON rm.id = i.raw_message_id
+			JOIN channels c ON c.id = rm.channel_id
+			WHERE rm.tg_date >= $1 AND i.status = 'ready'
+			  AND rm.tg_date >= c.snoozed_at AND rm.tg_date < c.snoozed_until
+		)
+		SELECT `+groupExpr+` AS grp, d.reason, COUNT(*)::int
+		FROM drops d
+		LEFT JOIN channels c ON c.id = d.channel_id
+		GROUP BY grp, d.reason
+		ORDER BY grp, COUNT(*) DESC, d.reason
+	`, toTimestamptz(since), domain.DropReasonChannelSnoozed)
+	if err != nil {
+		return nil, fmt.Errorf("query drop reason breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	var breakdown []DropReasonBreakdownRow
+
+	for rows.Next() {
+		var row DropReasonBreakdownRow
+		if err := rows.Scan(&row.Group, &row.Reason, &row.Count); err != nil {
+			return nil, fmt.Errorf("scan drop reason breakdown: %w", err)
+		}
+
+		breakdown = append(breakdown, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate drop reason breakdown: %w", err)
+	}
+
+	return breakdown, nil
+}
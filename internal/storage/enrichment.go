@@ -12,6 +12,8 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pgvector/pgvector-go"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
 )
 
 const (
@@ -39,6 +41,7 @@ type EnrichmentQueueItem struct {
 	ChannelDescription string
 	ChannelID          string
 	AttemptCount       int
+	TGDate             time.Time
 	// Telegram identifiers for Solr language update
 	TGPeerID    int64
 	TGMessageID int64
@@ -83,6 +86,9 @@ type ItemEvidence struct {
 	IsContradiction   bool
 	MatchedClaimsJSON []byte
 	MatchedAt         time.Time
+	Stance            string
+	StanceConfidence  float32
+	StanceQuotesJSON  []byte
 }
 
 type ItemEvidenceWithSource struct {
@@ -137,7 +143,7 @@ func (db *DB) ClaimNextEnrichment(ctx context.Context) (*EnrichmentQueueItem, er
 			RETURNING eq.id, eq.item_id, eq.summary, eq.attempt_count
 		)
 		SELECT u.id, u.item_id, i.raw_message_id, u.summary, u.attempt_count, i.topic, rm.text,
-		       c.title, c.username, c.description, c.id, c.tg_peer_id, rm.tg_message_id
+		       c.title, c.username, c.description, c.id, c.tg_peer_id, rm.tg_message_id, rm.tg_date
 		FROM updated u
 		JOIN items i ON i.id = u.item_id
 		JOIN raw_messages rm ON rm.id = i.raw_message_id
@@ -156,6 +162,7 @@ func (db *DB) ClaimNextEnrichment(ctx context.Context) (*EnrichmentQueueItem, er
 		&channelUUID,
 		&tgPeerID,
 		&tgMessageID,
+		&item.TGDate,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -357,15 +364,20 @@ func (db *DB) SaveEvidenceClaim(ctx context.Context, claim *EvidenceClaim) (stri
 
 func (db *DB) SaveItemEvidence(ctx context.Context, ie *ItemEvidence) error {
 	_, err := db.Pool.Exec(ctx, `
-		INSERT INTO item_evidence (item_id, evidence_id, agreement_score, is_contradiction, matched_claims_json, matched_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO item_evidence (item_id, evidence_id, agreement_score, is_contradiction, matched_claims_json, matched_at,
+			stance, stance_confidence, stance_quotes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (item_id, evidence_id) DO UPDATE
 		SET agreement_score = EXCLUDED.agreement_score,
 			is_contradiction = EXCLUDED.is_contradiction,
 			matched_claims_json = EXCLUDED.matched_claims_json,
-			matched_at = EXCLUDED.matched_at
+			matched_at = EXCLUDED.matched_at,
+			stance = COALESCE(EXCLUDED.stance, item_evidence.stance),
+			stance_confidence = COALESCE(EXCLUDED.stance_confidence, item_evidence.stance_confidence),
+			stance_quotes = COALESCE(EXCLUDED.stance_quotes, item_evidence.stance_quotes)
 	`, toUUID(ie.ItemID), toUUID(ie.EvidenceID), ie.AgreementScore, ie.IsContradiction,
-		ie.MatchedClaimsJSON, ie.MatchedAt)
+		ie.MatchedClaimsJSON, ie.MatchedAt, toText(ie.Stance),
+		pgtype.Float4{Float32: ie.StanceConfidence, Valid: ie.Stance != ""}, ie.StanceQuotesJSON)
 	if err != nil {
 		return fmt.Errorf("save item evidence: %w", err)
 	}
@@ -388,6 +400,52 @@ func (db *DB) UpdateItemFactCheckScore(ctx context.Context, itemID string, score
 	return nil
 }
 
+// RefreshItemEvidenceVerdict recomputes the item verdict from all of its
+// stance-classified evidence, the way EvidenceVerdict does for the digest
+// badge, and stores it. Items without classified evidence are left unchanged.
+func (db *DB) RefreshItemEvidenceVerdict(ctx context.Context, itemID string) error {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT stance, COALESCE(stance_confidence, 0)
+		FROM item_evidence
+		WHERE item_id = $1 AND COALESCE(stance, '') <> ''
+	`, toUUID(itemID))
+	if err != nil {
+		return fmt.Errorf("get item evidence stances: %w", err)
+	}
+
+	defer rows.Close()
+
+	var votes []domain.StanceVote
+
+	for rows.Next() {
+		var v domain.StanceVote
+		if err := rows.Scan(&v.Stance, &v.Confidence); err != nil {
+			return fmt.Errorf("scan item evidence stance: %w", err)
+		}
+
+		votes = append(votes, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate item evidence stances: %w", err)
+	}
+
+	if len(votes) == 0 {
+		return nil
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE items
+		SET evidence_verdict = $2,
+			evidence_verdict_at = now()
+		WHERE id = $1
+	`, toUUID(itemID), domain.AggregateStanceVerdict(votes)); err != nil {
+		return fmt.Errorf("update item evidence verdict: %w", err)
+	}
+
+	return nil
+}
+
 func (db *DB) GetEvidenceForItems(ctx context.Context, itemIDs []string) (map[string][]ItemEvidenceWithSource, error) {
 	if len(itemIDs) == 0 {
 		return map[string][]ItemEvidenceWithSource{}, nil
@@ -400,7 +458,7 @@ func (db *DB) GetEvidenceForItems(ctx context.Context, itemIDs []string) (map[st
 
 	rows, err := db.Pool.Query(ctx, `
 		SELECT ie.id, ie.item_id, ie.evidence_id, ie.agreement_score, ie.is_contradiction,
-		       ie.matched_claims_json, ie.matched_at,
+		       ie.matched_claims_json, ie.matched_at, ie.stance, ie.stance_confidence, ie.stance_quotes,
 		       es.url, es.domain, es.title, es.description, es.author, es.published_at, es.language, es.provider
 		FROM item_evidence ie
 		JOIN evidence_sources es ON es.id = ie.evidence_id
@@ -430,6 +488,26 @@ func (db *DB) GetEvidenceForItems(ctx context.Context, itemIDs []string) (map[st
 	return results, nil
 }
 
+// EvidenceVerdict aggregates evidence stances into an item verdict.
+// Returns an empty string when none of the evidence has been stance-classified.
+func EvidenceVerdict(evidence []ItemEvidenceWithSource) string {
+	votes := make([]domain.StanceVote, 0, len(evidence))
+
+	for _, entry := range evidence {
+		if entry.Stance == "" {
+			continue
+		}
+
+		votes = append(votes, domain.StanceVote{Stance: entry.Stance, Confidence: entry.StanceConfidence})
+	}
+
+	if len(votes) == 0 {
+		return ""
+	}
+
+	return domain.AggregateStanceVerdict(votes)
+}
+
 func parseUUIDs(ids []string) []uuid.UUID {
 	result := make([]uuid.UUID, 0, len(ids))
 
@@ -456,6 +534,9 @@ func scanItemEvidenceWithSource(row evidenceRowScanner) (ItemEvidenceWithSource,
 		itemID      uuid.UUID
 		evidenceID  uuid.UUID
 		matchedJSON pgtype.Text
+		stance      pgtype.Text
+		stanceConf  pgtype.Float4
+		quotesJSON  pgtype.Text
 		title       pgtype.Text
 		description pgtype.Text
 		author      pgtype.Text
@@ -465,7 +546,7 @@ func scanItemEvidenceWithSource(row evidenceRowScanner) (ItemEvidenceWithSource,
 
 	if err := row.Scan(
 		&ieID, &itemID, &evidenceID, &ies.AgreementScore, &ies.IsContradiction,
-		&matchedJSON, &ies.MatchedAt,
+		&matchedJSON, &ies.MatchedAt, &stance, &stanceConf, &quotesJSON,
 		&ies.Source.URL, &ies.Source.Domain, &title, &description, &author,
 		&publishedAt, &language, &ies.Source.Provider,
 	); err != nil {
@@ -480,11 +561,17 @@ func scanItemEvidenceWithSource(row evidenceRowScanner) (ItemEvidenceWithSource,
 	ies.Source.Description = description.String
 	ies.Source.Author = author.String
 	ies.Source.Language = language.String
+	ies.Stance = stance.String
+	ies.StanceConfidence = fromFloat4(stanceConf)
 
 	if matchedJSON.Valid {
 		ies.MatchedClaimsJSON = []byte(matchedJSON.String)
 	}
 
+	if quotesJSON.Valid {
+		ies.StanceQuotesJSON = []byte(quotesJSON.String)
+	}
+
 	if publishedAt.Valid {
 		ies.Source.PublishedAt = &publishedAt.Time
 	}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Routes for items the ensemble scorers disagree on.
+const (
+	EnsembleRouteNone     = "none"
+	EnsembleRouteSmart    = "smart"
+	EnsembleRouteAnnotate = "annotate"
+	EnsembleRouteBoth     = "both"
+)
+
+const defaultEnsembleMaxDisagreement = 0.3
+
+// EnsembleVariant is an extra scorer: a model (empty for the summarize task
+// default) and an optional summarize prompt version.
+type EnsembleVariant struct {
+	Model  string `json:"model,omitempty"`
+	Prompt string `json:"prompt,omitempty"`
+}
+
+// String renders the variant as model[@prompt].
+func (v EnsembleVariant) String() string {
+	model := v.Model
+	if model == "" {
+		model = defaultScoringModel
+	}
+
+	if v.Prompt == "" {
+		return model
+	}
+
+	return model + "@" + v.Prompt
+}
+
+// EnsembleScoringPolicy configures scoring items with several scorers. The
+// regular summarize call is the first scorer and each variant adds one more;
+// relevance and importance are averaged over all of them.
+type EnsembleScoringPolicy struct {
+	Enabled  bool              `json:"enabled"`
+	Variants []EnsembleVariant `json:"variants,omitempty"`
+	// MaxDisagreement is the spread of relevance or importance scores at or
+	// above which an item is low confidence.
+	MaxDisagreement float32 `json:"max_disagreement"`
+	// Route decides what happens to low-confidence items: re-scoring with
+	// SmartModel, the annotation queue, both, or nothing.
+	Route      string `json:"route"`
+	SmartModel string `json:"smart_model,omitempty"`
+	// Channels limits ensemble scoring to these channel IDs. Empty means
+	// every channel.
+	Channels []string `json:"channels,omitempty"`
+}
+
+// DefaultEnsembleScoringPolicy returns a disabled policy that re-scores
+// low-confidence items with the smart model.
+func DefaultEnsembleScoringPolicy() EnsembleScoringPolicy {
+	return EnsembleScoringPolicy{
+		MaxDisagreement: defaultEnsembleMaxDisagreement,
+		Route:           EnsembleRouteSmart,
+	}
+}
+
+// Active reports whether the policy is enabled with at least one variant.
+func (p EnsembleScoringPolicy) Active() bool {
+	return p.Enabled && len(p.Variants) > 0
+}
+
+// AppliesTo reports whether items of the channel are ensemble scored.
+func (p EnsembleScoringPolicy) AppliesTo(channelID string) bool {
+	return len(p.Channels) == 0 || slices.Contains(p.Channels, channelID)
+}
+
+// RoutesToSmart reports whether low-confidence items are re-scored with the smart model.
+func (p EnsembleScoringPolicy) RoutesToSmart() bool {
+	return p.Route == EnsembleRouteSmart || p.Route == EnsembleRouteBoth
+}
+
+// RoutesToAnnotation reports whether low-confidence items are queued for annotation.
+func (p EnsembleScoringPolicy) RoutesToAnnotation() bool {
+	return p.Route == EnsembleRouteAnnotate || p.Route == EnsembleRouteBoth
+}
+
+// EnsembleScore is one scorer's scores for an item.
+type EnsembleScore struct {
+	Scorer     string  `json:"scorer"`
+	Relevance  float32 `json:"relevance"`
+	Importance float32 `json:"importance"`
+}
+
+// AverageEnsembleScores returns the mean relevance and importance.
+func AverageEnsembleScores(scores []EnsembleScore) (relevance, importance float32) {
+	if len(scores) == 0 {
+		return 0, 0
+	}
+
+	for _, s := range scores {
+		relevance += s.Relevance
+		importance += s.Importance
+	}
+
+	n := float32(len(scores))
+
+	return relevance / n, importance / n
+}
+
+// EnsembleDisagreement is the larger of the relevance and importance spreads
+// (maximum minus minimum) across scorers.
+func EnsembleDisagreement(scores []EnsembleScore) float32 {
+	if len(scores) < 2 {
+		return 0
+	}
+
+	minRel, maxRel := scores[0].Relevance, scores[0].Relevance
+	minImp, maxImp := scores[0].Importance, scores[0].Importance
+
+	for _, s := range scores[1:] {
+		minRel, maxRel = min(minRel, s.Relevance), max(maxRel, s.Relevance)
+		minImp, maxImp = min(minImp, s.Importance), max(maxImp, s.Importance)
+	}
+
+	return max(maxRel-minRel, maxImp-minImp)
+}
+
+// ItemScoreEnsemble records how an item was ensemble scored.
+type ItemScoreEnsemble struct {
+	ItemID        string
+	Scores        []EnsembleScore
+	Disagreement  float32
+	LowConfidence bool
+	// Route is where the low-confidence item was sent, empty otherwise.
+	Route string
+}
+
+// EnsembleStats summarizes ensemble scoring over a period.
+type EnsembleStats struct {
+	Items           int
+	LowConfidence   int
+	Rescored        int
+	Annotated       int
+	AvgDisagreement float64
+}
+
+// SaveItemScoreEnsemble records an item's ensemble scores and adds it to the
+// annotation queue when it was routed there.
+func (db *DB) SaveItemScoreEnsemble(ctx context.Context, e ItemScoreEnsemble) error {
+	scores, err := json.Marshal(e.Scores)
+	if err != nil {
+		return fmt.Errorf("marshal ensemble scores: %w", err)
+	}
+
+	annotate := e.Route == EnsembleRouteAnnotate || e.Route == EnsembleRouteBoth
+
+	if _, err := db.Pool.Exec(ctx, `
+		WITH saved AS (
+			INSERT INTO item_score_ensembles (item_id, scores, disagreement, low_confidence, route)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (item_id) DO UPDATE
+			SET scores = EXCLUDED.scores,
+			    disagreement = EXCLUDED.disagreement,
+			    low_confidence = EXCLUDED.low_confidence,
+			    route = EXCLUDED.route,
+			    created_at = now()
+			RETURNING item_id
+		)
+		INSERT INTO annotation_queue (item_id)
+		SELECT item_id FROM saved WHERE $6::boolean
+		ON CONFLICT (item_id) DO NOTHING
+	`, toUUID(e.ItemID), scores, e.Disagreement, e.LowConfidence, e.Route, annotate); err != nil {
+		return fmt.Errorf("save item score ensemble: %w", err)
+	}
+
+	return nil
+}
+
+// GetEnsembleStats summarizes the items ensemble scored since the given time.
+func (db *DB) GetEnsembleStats(ctx context.Context, since time.Time) (EnsembleStats, error) {
+	var stats EnsembleStats
+
+	if err := db.Pool.QueryRow(ctx, `
+		SELECT count(*),
+		       count(*) FILTER (WHERE low_confidence),
+		       count(*) FILTER (WHERE route IN ('smart', 'both')),
+		       count(*) FILTER (WHERE route IN ('annotate', 'both')),
+		       COALESCE(avg(disagreement), 0)
+		FROM item_score_ensembles
+		WHERE created_at >= $1
+	`, since).Scan(&stats.Items, &stats.LowConfidence, &stats.Rescored, &stats.Annotated, &stats.AvgDisagreement); err != nil {
+		return stats, fmt.Errorf("get ensemble stats: %w", err)
+	}
+
+	return stats, nil
+}
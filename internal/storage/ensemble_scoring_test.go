@@ -0,0 +1,97 @@
+package db
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEnsembleScoringPolicyAppliesTo(t *testing.T) {
+	all := EnsembleScoringPolicy{}
+	if !all.AppliesTo("anything") {
+		t.Error("AppliesTo() without channels = false, want true")
+	}
+
+	scoped := EnsembleScoringPolicy{Channels: []string{"ch-1"}}
+	if !scoped.AppliesTo("ch-1") {
+		t.Error("AppliesTo(ch-1) = false, want true")
+	}
+
+	if scoped.AppliesTo("ch-2") {
+		t.Error("AppliesTo(ch-2) = true, want false")
+	}
+}
+
+func TestEnsembleScoringPolicyActiveAndRoutes(t *testing.T) {
+	p := DefaultEnsembleScoringPolicy()
+	if p.Active() {
+		t.Error("default policy is active")
+	}
+
+	p.Enabled = true
+	if p.Active() {
+		t.Error("policy without variants is active")
+	}
+
+	p.Variants = []EnsembleVariant{{Model: "gpt-4o-mini"}}
+	if !p.Active() {
+		t.Error("enabled policy with a variant is not active")
+	}
+
+	tests := []struct {
+		route             string
+		smart, annotation bool
+	}{
+		{EnsembleRouteSmart, true, false},
+		{EnsembleRouteAnnotate, false, true},
+		{EnsembleRouteBoth, true, true},
+		{EnsembleRouteNone, false, false},
+	}
+
+	for _, tt := range tests {
+		p.Route = tt.route
+		if p.RoutesToSmart() != tt.smart || p.RoutesToAnnotation() != tt.annotation {
+			t.Errorf("route %s: smart=%v annotation=%v", tt.route, p.RoutesToSmart(), p.RoutesToAnnotation())
+		}
+	}
+}
+
+func TestEnsembleVariantString(t *testing.T) {
+	tests := []struct {
+		variant EnsembleVariant
+		want    string
+	}{
+		{EnsembleVariant{Model: "gpt-4o-mini"}, "gpt-4o-mini"},
+		{EnsembleVariant{Prompt: "v2"}, "default@v2"},
+		{EnsembleVariant{Model: "gemini", Prompt: "v3"}, "gemini@v3"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.variant.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestEnsembleScoreAggregation(t *testing.T) {
+	scores := []EnsembleScore{
+		{Scorer: "a", Relevance: 0.8, Importance: 0.2},
+		{Scorer: "b", Relevance: 0.6, Importance: 0.7},
+	}
+
+	rel, imp := AverageEnsembleScores(scores)
+	if math.Abs(float64(rel)-0.7) > 1e-6 || math.Abs(float64(imp)-0.45) > 1e-6 {
+		t.Errorf("AverageEnsembleScores() = %v, %v, want 0.7, 0.45", rel, imp)
+	}
+
+	if got := EnsembleDisagreement(scores); math.Abs(float64(got)-0.5) > 1e-6 {
+		t.Errorf("EnsembleDisagreement() = %v, want 0.5", got)
+	}
+
+	if got := EnsembleDisagreement(scores[:1]); got != 0 {
+		t.Errorf("EnsembleDisagreement() single scorer = %v, want 0", got)
+	}
+
+	if rel, imp := AverageEnsembleScores(nil); rel != 0 || imp != 0 {
+		t.Errorf("AverageEnsembleScores(nil) = %v, %v, want 0, 0", rel, imp)
+	}
+}
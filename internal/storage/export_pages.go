@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrExportPageNotFound is returned when a digest or story has not been
+// exported to a target yet.
+var ErrExportPageNotFound = errors.New("export page not found")
+
+// Export page kinds.
+const (
+	ExportKindDigest = "digest"
+	ExportKindStory  = "story"
+)
+
+// ExportPage maps a digest or story to the page it was exported to.
+type ExportPage struct {
+	Target   string
+	Kind     string
+	EntityID string
+	// PageID identifies the page in the target, e.g. a Notion page ID or a
+	// path in an Obsidian vault.
+	PageID string
+	// ContentHash is the hash of the exported content, to skip unchanged pages.
+	ContentHash string
+	ExportedAt  time.Time
+}
+
+// GetExportPage returns the page a digest or story was exported to.
+func (db *DB) GetExportPage(ctx context.Context, target, kind, entityID string) (ExportPage, error) {
+	page := ExportPage{Target: target, Kind: kind, EntityID: entityID}
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT page_id, content_hash, exported_at
+		FROM export_pages
+		WHERE target = $1 AND kind = $2 AND entity_id = $3
+	`, target, kind, toUUID(entityID)).Scan(&page.PageID, &page.ContentHash, &page.ExportedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ExportPage{}, ErrExportPageNotFound
+	}
+
+	if err != nil {
+		return ExportPage{}, fmt.Errorf("get export page: %w", err)
+	}
+
+	return page, nil
+}
+
+// SaveExportPage records or updates the page a digest or story was exported to.
+func (db *DB) SaveExportPage(ctx context.Context, page ExportPage) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO export_pages (target, kind, entity_id, page_id, content_hash, exported_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		ON CONFLICT (target, kind, entity_id) DO UPDATE
+		SET page_id = EXCLUDED.page_id, content_hash = EXCLUDED.content_hash, exported_at = now()
+	`, page.Target, page.Kind, toUUID(page.EntityID), page.PageID, page.ContentHash); err != nil {
+		return fmt.Errorf("save export page: %w", err)
+	}
+
+	return nil
+}
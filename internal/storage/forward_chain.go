@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+)
+
+// ForwardHop is an alias for the domain type.
+type ForwardHop = domain.ForwardHop
+
+// encodeForwardChain returns the JSON chain and the origin post columns of a raw message.
+func encodeForwardChain(chain []ForwardHop) ([]byte, pgtype.Int8, pgtype.Int8) {
+	if len(chain) == 0 {
+		return nil, pgtype.Int8{}, pgtype.Int8{}
+	}
+
+	data, err := json.Marshal(chain)
+	if err != nil {
+		return nil, pgtype.Int8{}, pgtype.Int8{}
+	}
+
+	origin := chain[0]
+
+	var originMsgID pgtype.Int8
+	if origin.MessageID != 0 {
+		originMsgID = toInt8(origin.MessageID)
+	}
+
+	return data, toInt8(origin.PeerID), originMsgID
+}
+
+func decodeForwardChain(data []byte) []ForwardHop {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var chain []ForwardHop
+	if err := json.Unmarshal(data, &chain); err != nil {
+		return nil
+	}
+
+	return chain
+}
+
+// findForwardOriginQuery looks up a kept copy of an original post. Each branch
+// is a separate lookup so both can use their index; an OR across the joined
+// tables would scan raw_messages. Only messages that became an item which was
+// not rejected count as kept.
+const findForwardOriginQuery = `
+		SELECT id
+		FROM (
+			(SELECT rm.id, rm.tg_date
+			 FROM channels c
+			 JOIN raw_messages rm ON rm.channel_id = c.id
+			 WHERE c.tg_peer_id = $1 AND rm.tg_message_id = $2 AND rm.id <> $3
+			   AND EXISTS (SELECT 1 FROM items i WHERE i.raw_message_id = rm.id AND i.status <> 'rejected'))
+			UNION ALL
+			(SELECT rm.id, rm.tg_date
+			 FROM raw_messages rm
+			 WHERE rm.fwd_origin_peer_id = $1 AND rm.fwd_origin_msg_id = $2
+			   AND rm.processed_at IS NOT NULL AND rm.id <> $3
+			   AND EXISTS (SELECT 1 FROM items i WHERE i.raw_message_id = rm.id AND i.status <> 'rejected')
+			 ORDER BY rm.tg_date
+			 LIMIT 1)
+		) m
+		ORDER BY tg_date
+		LIMIT 1
+	`
+
+// FindRawMessageByForwardOrigin returns the ID of a message already kept for the
+// given original post: the origin channel's own message when it is tracked, or an
+// earlier forward of the same post. Copies that were dropped, rejected or are not
+// processed yet are ignored. It returns an empty ID when none exists.
+func (db *DB) FindRawMessageByForwardOrigin(ctx context.Context, originPeerID, originMsgID int64, excludeID string) (string, error) {
+	var id pgtype.UUID
+
+	err := db.Pool.QueryRow(ctx, findForwardOriginQuery, originPeerID, originMsgID, toUUID(excludeID)).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("find raw message by forward origin: %w", err)
+	}
+
+	return fromUUID(id), nil
+}
+
+// GetForwardOrigins returns the original post of forwarded raw messages keyed by raw message ID.
+func (db *DB) GetForwardOrigins(ctx context.Context, rawMessageIDs []string) (map[string]ForwardHop, error) {
+	origins := make(map[string]ForwardHop)
+	if len(rawMessageIDs) == 0 {
+		return origins, nil
+	}
+
+	uuids := make([]pgtype.UUID, 0, len(rawMessageIDs))
+	for _, id := range rawMessageIDs {
+		uuids = append(uuids, toUUID(id))
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, forward_chain
+		FROM raw_messages
+		WHERE id = ANY($1) AND forward_chain IS NOT NULL
+	`, uuids)
+	if err != nil {
+		return nil, fmt.Errorf("get forward origins: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   pgtype.UUID
+			data []byte
+		)
+
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("scan forward origin: %w", err)
+		}
+
+		if chain := decodeForwardChain(data); len(chain) > 0 {
+			origins[fromUUID(id)] = chain[0]
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate forward origins: %w", err)
+	}
+
+	return origins, nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestForwardChainRoundTrip(t *testing.T) {
+	chain := []ForwardHop{
+		{PeerID: 100, MessageID: 42, Username: "original", Title: "Original", Date: time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC)},
+		{PeerID: 200, MessageID: 7},
+	}
+
+	data, peerID, msgID := encodeForwardChain(chain)
+	if !peerID.Valid || peerID.Int64 != 100 || !msgID.Valid || msgID.Int64 != 42 {
+		t.Fatalf("origin columns = %v, %v; want 100, 42", peerID, msgID)
+	}
+
+	got := decodeForwardChain(data)
+	if len(got) != len(chain) || got[0] != chain[0] || got[1] != chain[1] {
+		t.Errorf("decodeForwardChain() = %+v, want %+v", got, chain)
+	}
+
+	if data, peerID, _ := encodeForwardChain(nil); data != nil || peerID.Valid {
+		t.Errorf("encodeForwardChain(nil) = %s, %v; want empty", data, peerID)
+	}
+
+	if _, _, msgID := encodeForwardChain([]ForwardHop{{PeerID: 1}}); msgID.Valid {
+		t.Errorf("origin without message ID should leave fwd_origin_msg_id NULL")
+	}
+}
+
+func TestFindForwardOriginQueryMatchesKeptCopiesOnly(t *testing.T) {
+	const kept = "EXISTS (SELECT 1 FROM items i WHERE i.raw_message_id = rm.id AND i.status <> 'rejected')"
+
+	// Both the origin channel's own message and earlier forwards must have
+	// been kept; rejected and unprocessed copies have no such item.
+	branches := strings.Split(findForwardOriginQuery, "UNION ALL")
+	if len(branches) != 2 {
+		t.Fatalf("query has %d branches, want 2:\n%s", len(branches), findForwardOriginQuery)
+	}
+
+	for i, branch := range branches {
+		if !strings.Contains(branch, kept) {
+			t.Errorf("branch %d matches copies that were not kept:\n%s", i, branch)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// clickImportanceBands is the number of equal-width importance bands used by
+// GetClickThroughByImportance.
+const clickImportanceBands = 5
+
+// ChannelClickStats counts the digested items of a channel and how many of
+// them were clicked.
+type ChannelClickStats struct {
+	ChannelID     string
+	Username      string
+	Title         string
+	DigestedItems int
+	ClickedItems  int
+	Clicks        int
+}
+
+// ImportanceClickStats counts digested and clicked items in an importance band.
+type ImportanceClickStats struct {
+	// MinImportance is the lower bound of the band; the band spans
+	// 1/clickImportanceBands above it.
+	MinImportance float64
+	DigestedItems int
+	ClickedItems  int
+}
+
+// ItemClickTarget is the item behind a tracked digest link and the source
+// message the link redirects to.
+type ItemClickTarget struct {
+	ItemID          string
+	ChannelUsername string
+	ChannelPeerID   int64
+	MessageID       int64
+}
+
+// ResolveItemClickTarget returns the item and source message behind a
+// deep-link code, or ErrDeepLinkNotFound.
+func (db *DB) ResolveItemClickTarget(ctx context.Context, code string) (*ItemClickTarget, error) {
+	if !IsValidItemDeepLinkCode(code) {
+		return nil, fmt.Errorf("%w: %s", ErrDeepLinkNotFound, code)
+	}
+
+	var (
+		itemID pgtype.UUID
+		target ItemClickTarget
+	)
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT l.item_id, COALESCE(c.username, ''), c.tg_peer_id, rm.tg_message_id
+		FROM item_deep_links l
+		JOIN items i ON l.item_id = i.id
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels c ON rm.channel_id = c.id
+		WHERE l.code = $1
+	`, code).Scan(&itemID, &target.ChannelUsername, &target.ChannelPeerID, &target.MessageID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrDeepLinkNotFound, code)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("resolve item click target: %w", err)
+	}
+
+	target.ItemID = fromUUID(itemID)
+
+	return &target, nil
+}
+
+// RecordItemClick counts a click on a tracked digest link of an item.
+func (db *DB) RecordItemClick(ctx context.Context, itemID string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO item_clicks (item_id, clicks) VALUES ($1, 1)
+		ON CONFLICT (item_id) DO UPDATE SET clicks = item_clicks.clicks + 1, last_clicked_at = now()
+	`, toUUID(itemID)); err != nil {
+		return fmt.Errorf("record item click: %w", err)
+	}
+
+	return nil
+}
+
+// GetChannelClickStats returns click counts of the items digested since the
+// given time, grouped by source channel.
+func (db *DB) GetChannelClickStats(ctx context.Context, since time.Time) ([]ChannelClickStats, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT c.id, COALESCE(c.username, ''), COALESCE(c.title, ''),
+		       COUNT(*) AS digested_items,
+		       COUNT(ic.item_id) AS clicked_items,
+		       COALESCE(SUM(ic.clicks), 0) AS clicks
+		FROM items i
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels c ON rm.channel_id = c.id
+		LEFT JOIN item_clicks ic ON ic.item_id = i.id
+		WHERE i.digested_at >= $1
+		GROUP BY c.id, c.username, c.title
+		ORDER BY clicked_items DESC, digested_items DESC
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("get channel click stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []ChannelClickStats
+
+	for rows.Next() {
+		var (
+			channelID                     pgtype.UUID
+			st                            ChannelClickStats
+			digested, clicked, clickCount int64
+		)
+
+		if err := rows.Scan(&channelID, &st.Username, &st.Title, &digested, &clicked, &clickCount); err != nil {
+			return nil, fmt.Errorf("scan channel click stats: %w", err)
+		}
+
+		st.ChannelID = fromUUID(channelID)
+		st.DigestedItems = int(digested)
+		st.ClickedItems = int(clicked)
+		st.Clicks = int(clickCount)
+		stats = append(stats, st)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate channel click stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetClickThroughByImportance returns how many items digested since the given
+// time were clicked, per importance band, lowest band first.
+func (db *DB) GetClickThroughByImportance(ctx context.Context, since time.Time) ([]ImportanceClickStats, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT LEAST(FLOOR(i.importance_score * $2)::int, $2 - 1) AS band,
+		       COUNT(*) AS digested_items,
+		       COUNT(ic.item_id) AS clicked_items
+		FROM items i
+		LEFT JOIN item_clicks ic ON ic.item_id = i.id
+		WHERE i.digested_at >= $1
+		GROUP BY band
+		ORDER BY band
+	`, since, clickImportanceBands)
+	if err != nil {
+		return nil, fmt.Errorf("get click-through by importance: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []ImportanceClickStats
+
+	for rows.Next() {
+		var (
+			band              int32
+			digested, clicked int64
+		)
+
+		if err := rows.Scan(&band, &digested, &clicked); err != nil {
+			return nil, fmt.Errorf("scan click-through by importance: %w", err)
+		}
+
+		stats = append(stats, ImportanceClickStats{
+			MinImportance: float64(max(band, 0)) / clickImportanceBands,
+			DigestedItems: int(digested),
+			ClickedItems:  int(clicked),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate click-through by importance: %w", err)
+	}
+
+	return stats, nil
+}
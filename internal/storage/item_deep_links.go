@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Item deep-link modes for the digest_item_links setting.
+const (
+	ItemLinksOff      = "off"
+	ItemLinksBot      = "bot"
+	ItemLinksResearch = "research"
+)
+
+// ItemDeepLinkStartPrefix prefixes deep-link codes in bot /start payloads.
+const ItemDeepLinkStartPrefix = "item_"
+
+const (
+	itemDeepLinkCodeLength = 8
+	itemDeepLinkAlphabet   = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// ErrDeepLinkNotFound is returned when a deep-link code does not resolve to an item.
+var ErrDeepLinkNotFound = errors.New("deep link not found")
+
+// IsValidItemLinkMode reports whether mode is a known digest_item_links value.
+func IsValidItemLinkMode(mode string) bool {
+	switch mode {
+	case ItemLinksOff, ItemLinksBot, ItemLinksResearch:
+		return true
+	default:
+		return false
+	}
+}
+
+// GenerateItemDeepLinkCode returns a short random base62 code.
+func GenerateItemDeepLinkCode() (string, error) {
+	buf := make([]byte, itemDeepLinkCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate deep link code: %w", err)
+	}
+
+	var sb strings.Builder
+
+	for _, b := range buf {
+		sb.WriteByte(itemDeepLinkAlphabet[int(b)%len(itemDeepLinkAlphabet)])
+	}
+
+	return sb.String(), nil
+}
+
+// IsValidItemDeepLinkCode reports whether code has the shape of a generated deep-link code.
+func IsValidItemDeepLinkCode(code string) bool {
+	if len(code) != itemDeepLinkCodeLength {
+		return false
+	}
+
+	for _, r := range code {
+		if !strings.ContainsRune(itemDeepLinkAlphabet, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetOrCreateItemDeepLinks returns deep-link codes keyed by item ID, creating
+// codes for items that do not have one yet. Each item keeps a stable code.
+func (db *DB) GetOrCreateItemDeepLinks(ctx context.Context, itemIDs []string) (map[string]string, error) {
+	if len(itemIDs) == 0 {
+		return map[string]string{}, nil
+	}
+
+	codes := make([]string, 0, len(itemIDs))
+	uuids := make([]pgtype.UUID, 0, len(itemIDs))
+
+	for _, id := range itemIDs {
+		code, err := GenerateItemDeepLinkCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+		uuids = append(uuids, toUUID(id))
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		WITH inserted AS (
+			INSERT INTO item_deep_links (code, item_id)
+			SELECT code, item_id FROM unnest($1::text[], $2::uuid[]) AS t(code, item_id)
+			ON CONFLICT DO NOTHING
+			RETURNING item_id, code
+		)
+		SELECT item_id, code FROM inserted
+		UNION ALL
+		SELECT item_id, code FROM item_deep_links WHERE item_id = ANY($2::uuid[])
+	`, codes, uuids)
+	if err != nil {
+		return nil, fmt.Errorf("get or create item deep links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make(map[string]string, len(itemIDs))
+
+	for rows.Next() {
+		var (
+			itemID pgtype.UUID
+			code   string
+		)
+
+		if err := rows.Scan(&itemID, &code); err != nil {
+			return nil, fmt.Errorf("scan item deep link: %w", err)
+		}
+
+		links[fromUUID(itemID)] = code
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate item deep links: %w", err)
+	}
+
+	return links, nil
+}
+
+// ResolveItemDeepLink returns the item ID behind a deep-link code, or ErrDeepLinkNotFound.
+func (db *DB) ResolveItemDeepLink(ctx context.Context, code string) (string, error) {
+	if !IsValidItemDeepLinkCode(code) {
+		return "", fmt.Errorf("%w: %s", ErrDeepLinkNotFound, code)
+	}
+
+	var itemID pgtype.UUID
+
+	err := db.Pool.QueryRow(ctx, `SELECT item_id FROM item_deep_links WHERE code = $1`, code).Scan(&itemID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("%w: %s", ErrDeepLinkNotFound, code)
+		}
+
+		return "", fmt.Errorf("resolve item deep link: %w", err)
+	}
+
+	return fromUUID(itemID), nil
+}
@@ -0,0 +1,40 @@
+package db
+
+import "testing"
+
+func TestGenerateItemDeepLinkCode(t *testing.T) {
+	seen := make(map[string]bool)
+
+	for range 100 {
+		code, err := GenerateItemDeepLinkCode()
+		if err != nil {
+			t.Fatalf("GenerateItemDeepLinkCode() error = %v", err)
+		}
+
+		if !IsValidItemDeepLinkCode(code) {
+			t.Fatalf("generated code %q is not valid", code)
+		}
+
+		seen[code] = true
+	}
+
+	if len(seen) < 100 {
+		t.Errorf("expected 100 distinct codes, got %d", len(seen))
+	}
+}
+
+func TestIsValidItemDeepLinkCode(t *testing.T) {
+	tests := map[string]bool{
+		"aB3dE6gH":  true,
+		"aB3dE6g":   false,
+		"aB3dE6gHi": false,
+		"aB3dE6g_":  false,
+		"":          false,
+	}
+
+	for code, want := range tests {
+		if got := IsValidItemDeepLinkCode(code); got != want {
+			t.Errorf("IsValidItemDeepLinkCode(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrRawScoresNotFound is returned when no raw scores were recorded for an item.
+var ErrRawScoresNotFound = errors.New("raw scores not found")
+
+// ItemRawScores are the scores an item got from the LLM before normalization.
+type ItemRawScores struct {
+	ScoringVersion  string
+	RelevanceScore  float32
+	ImportanceScore float32
+	ScoredAt        time.Time
+}
+
+// GetItemRawScores returns the raw LLM scores of an item and the scoring
+// version that produced them.
+func (db *DB) GetItemRawScores(ctx context.Context, itemID string) (*ItemRawScores, error) {
+	var scores ItemRawScores
+
+	if err := db.Pool.QueryRow(ctx, `
+		SELECT scoring_version, relevance_score, importance_score, created_at
+		FROM item_raw_scores
+		WHERE item_id = $1
+	`, toUUID(itemID)).Scan(&scores.ScoringVersion, &scores.RelevanceScore, &scores.ImportanceScore, &scores.ScoredAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRawScoresNotFound
+		}
+
+		return nil, fmt.Errorf("get item raw scores: %w", err)
+	}
+
+	return &scores, nil
+}
+
+// GetModelPromptStateAt returns, for each task, the last model and prompt
+// activation at or before the given time. Tasks that were never changed are
+// omitted; they ran on their built-in defaults.
+func (db *DB) GetModelPromptStateAt(ctx context.Context, at time.Time) ([]ModelPromptEvent, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT DISTINCT ON (kind, target) id, changed_at, kind, target, old_value, new_value, changed_by
+		FROM model_prompt_events
+		WHERE changed_at <= $1
+		ORDER BY kind, target, changed_at DESC, id DESC
+	`, at)
+	if err != nil {
+		return nil, fmt.Errorf("get model/prompt state: %w", err)
+	}
+	defer rows.Close()
+
+	var events []ModelPromptEvent
+
+	for rows.Next() {
+		var e ModelPromptEvent
+		if err := rows.Scan(&e.ID, &e.ChangedAt, &e.Kind, &e.Target, &e.OldValue, &e.NewValue, &e.ChangedBy); err != nil {
+			return nil, fmt.Errorf("scan model/prompt state: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate model/prompt state: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultQuoteSearchLimit = 50
+
+// ItemQuote is a direct quote extracted from an item, attributed to a speaker.
+type ItemQuote struct {
+	ItemID  string `json:"item_id"`
+	Speaker string `json:"speaker"`
+	Quote   string `json:"quote"`
+
+	// Read-only context filled by SearchQuotes.
+	Summary         string    `json:"summary,omitempty"`
+	ChannelUsername string    `json:"channel_username,omitempty"`
+	TGDate          time.Time `json:"tg_date,omitempty"`
+}
+
+// QuoteSearchFilter narrows SearchQuotes. Speaker and Query are
+// case-insensitive substring matches on the speaker and the quote text.
+type QuoteSearchFilter struct {
+	From    *time.Time
+	To      *time.Time
+	Speaker string
+	Query   string
+	Limit   int
+}
+
+// SaveItemQuotes replaces the quotes stored for an item.
+func (db *DB) SaveItemQuotes(ctx context.Context, itemID string, quotes []ItemQuote) error {
+	speakers := make([]string, len(quotes))
+	texts := make([]string, len(quotes))
+
+	for i, q := range quotes {
+		speakers[i] = SanitizeUTF8(q.Speaker)
+		texts[i] = SanitizeUTF8(q.Quote)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		WITH deleted AS (
+			DELETE FROM item_quotes WHERE item_id = $1
+		)
+		INSERT INTO item_quotes (item_id, position, speaker, quote)
+		SELECT $1, q.ord, q.speaker, q.quote
+		FROM unnest($2::text[], $3::text[]) WITH ORDINALITY AS q(speaker, quote, ord)
+	`, toUUID(itemID), speakers, texts); err != nil {
+		return fmt.Errorf("save item quotes: %w", err)
+	}
+
+	return nil
+}
+
+// GetQuotesForItems returns quotes keyed by item ID, in extraction order.
+func (db *DB) GetQuotesForItems(ctx context.Context, itemIDs []string) (map[string][]ItemQuote, error) {
+	result := map[string][]ItemQuote{}
+
+	ids := parseUUIDs(itemIDs)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT item_id::text, speaker, quote
+		FROM item_quotes
+		WHERE item_id = ANY($1)
+		ORDER BY item_id, position
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get item quotes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var q ItemQuote
+		if err := rows.Scan(&q.ItemID, &q.Speaker, &q.Quote); err != nil {
+			return nil, fmt.Errorf("scan item quote: %w", err)
+		}
+
+		result[q.ItemID] = append(result[q.ItemID], q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate item quotes: %w", err)
+	}
+
+	return result, nil
+}
+
+// SearchQuotes returns quotes matching the filter, newest messages first.
+func (db *DB) SearchQuotes(ctx context.Context, filter QuoteSearchFilter) ([]ItemQuote, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultQuoteSearchLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT q.item_id::text, q.speaker, q.quote, i.summary, ch.username, rm.tg_date
+		FROM item_quotes q
+		JOIN items i ON i.id = q.item_id
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		JOIN channels ch ON ch.id = rm.channel_id
+		WHERE ($1::timestamptz IS NULL OR rm.tg_date >= $1)
+		  AND ($2::timestamptz IS NULL OR rm.tg_date <= $2)
+		  AND ($3 = '' OR q.speaker ILIKE '%' || $3 || '%')
+		  AND ($4 = '' OR q.quote ILIKE '%' || $4 || '%')
+		ORDER BY rm.tg_date DESC, q.position
+		LIMIT $5
+	`, toTimestamptzPtr(filter.From), toTimestamptzPtr(filter.To), strings.TrimSpace(filter.Speaker),
+		strings.TrimSpace(filter.Query), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("search quotes: %w", err)
+	}
+	defer rows.Close()
+
+	quotes := []ItemQuote{}
+
+	for rows.Next() {
+		var (
+			q        ItemQuote
+			summary  pgtype.Text
+			username pgtype.Text
+		)
+
+		if err := rows.Scan(&q.ItemID, &q.Speaker, &q.Quote, &summary, &username, &q.TGDate); err != nil {
+			return nil, fmt.Errorf("scan quote: %w", err)
+		}
+
+		q.Summary = summary.String
+		q.ChannelUsername = username.String
+		quotes = append(quotes, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate quotes: %w", err)
+	}
+
+	return quotes, nil
+}
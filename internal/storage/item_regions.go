@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultRegionBreakdownLimit = 50
+
+// RegionCount aggregates ready items tagged with one region.
+type RegionCount struct {
+	Region        string  `json:"region"`
+	Items         int     `json:"items"`
+	AvgImportance float64 `json:"avg_importance"`
+}
+
+// GetItemRegions returns the region codes of the given items, keyed by item
+// ID. Items without regions are omitted.
+func (db *DB) GetItemRegions(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	result := map[string][]string{}
+
+	ids := parseUUIDs(itemIDs)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id::text, regions
+		FROM items
+		WHERE id = ANY($1) AND cardinality(regions) > 0
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get item regions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id      string
+			regions []string
+		)
+
+		if err := rows.Scan(&id, &regions); err != nil {
+			return nil, fmt.Errorf("scan item regions: %w", err)
+		}
+
+		result[id] = regions
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate item regions: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetRegionBreakdown counts ready items per region for messages posted in
+// the given range, most covered regions first.
+func (db *DB) GetRegionBreakdown(ctx context.Context, from, to *time.Time, limit int) ([]RegionCount, error) {
+	if limit <= 0 {
+		limit = defaultRegionBreakdownLimit
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT r.region, COUNT(*), AVG(i.importance_score)
+		FROM items i
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		CROSS JOIN LATERAL unnest(i.regions) AS r(region)
+		WHERE i.status = 'ready'
+		  AND ($1::timestamptz IS NULL OR rm.tg_date >= $1)
+		  AND ($2::timestamptz IS NULL OR rm.tg_date <= $2)
+		GROUP BY r.region
+		ORDER BY COUNT(*) DESC, r.region
+		LIMIT $3
+	`, toTimestamptzPtr(from), toTimestamptzPtr(to), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get region breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []RegionCount{}
+
+	for rows.Next() {
+		var c RegionCount
+		if err := rows.Scan(&c.Region, &c.Items, &c.AvgImportance); err != nil {
+			return nil, fmt.Errorf("scan region count: %w", err)
+		}
+
+		counts = append(counts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate region counts: %w", err)
+	}
+
+	return counts, nil
+}
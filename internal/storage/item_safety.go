@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetItemSafetyFlags returns the content safety flags of the given items,
+// keyed by item ID. Items without flags are omitted.
+func (db *DB) GetItemSafetyFlags(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	result := map[string][]string{}
+
+	ids := parseUUIDs(itemIDs)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id::text, safety_flags
+		FROM items
+		WHERE id = ANY($1) AND cardinality(safety_flags) > 0
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get item safety flags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    string
+			flags []string
+		)
+
+		if err := rows.Scan(&id, &flags); err != nil {
+			return nil, fmt.Errorf("scan item safety flags: %w", err)
+		}
+
+		result[id] = flags
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate item safety flags: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetSafetyFlagStats counts ready items per content safety flag for messages
+// posted since the given time. Blocked items are counted by their drop reason
+// instead.
+func (db *DB) GetSafetyFlagStats(ctx context.Context, since time.Time) ([]DropReasonStat, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT f.flag, COUNT(*)::int
+		FROM items i
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		CROSS JOIN LATERAL unnest(i.safety_flags) AS f(flag)
+		WHERE i.status = 'ready' AND rm.tg_date >= $1
+		GROUP BY f.flag
+		ORDER BY COUNT(*) DESC, f.flag
+	`, toTimestamptz(since))
+	if err != nil {
+		return nil, fmt.Errorf("get safety flag stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []DropReasonStat
+
+	for rows.Next() {
+		var stat DropReasonStat
+		if err := rows.Scan(&stat.Reason, &stat.Count); err != nil {
+			return nil, fmt.Errorf("scan safety flag stats: %w", err)
+		}
+
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate safety flag stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ItemTicket is the issue tracker ticket created for an item.
+type ItemTicket struct {
+	ItemID string
+	// Tracker names the issue tracker, e.g. "jira".
+	Tracker   string
+	Key       string
+	URL       string
+	CreatedBy int64
+	CreatedAt time.Time
+}
+
+// SaveItemTicket stores the ticket reference of an item, replacing an
+// earlier one.
+func (db *DB) SaveItemTicket(ctx context.Context, ticket ItemTicket) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO item_tickets (item_id, tracker, ticket_key, ticket_url, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (item_id) DO UPDATE
+		SET tracker = EXCLUDED.tracker, ticket_key = EXCLUDED.ticket_key, ticket_url = EXCLUDED.ticket_url,
+		    created_by = EXCLUDED.created_by, created_at = now()
+	`, toUUID(ticket.ItemID), ticket.Tracker, ticket.Key, ticket.URL, ticket.CreatedBy); err != nil {
+		return fmt.Errorf("save item ticket: %w", err)
+	}
+
+	return nil
+}
@@ -38,6 +38,9 @@ type CanonicalItem struct {
 type ItemWithMedia struct {
 	Item
 	MediaData []byte
+	// VideoDuration is the length of a video post in seconds; MediaData then
+	// holds its thumbnail.
+	VideoDuration int
 }
 
 type ItemRating struct {
@@ -73,6 +76,24 @@ func (db *DB) SaveItem(ctx context.Context, item *Item) error {
 
 	item.ID = fromUUID(id)
 
+	if len(item.Regions) > 0 {
+		if _, err := db.Pool.Exec(ctx, `UPDATE items SET regions = $2 WHERE id = $1`, id, item.Regions); err != nil {
+			return fmt.Errorf("save item regions: %w", err)
+		}
+	}
+
+	if item.AltText != "" {
+		if _, err := db.Pool.Exec(ctx, `UPDATE items SET alt_text = $2 WHERE id = $1`, id, SanitizeUTF8(item.AltText)); err != nil {
+			return fmt.Errorf("save item alt text: %w", err)
+		}
+	}
+
+	if len(item.SafetyFlags) > 0 {
+		if _, err := db.Pool.Exec(ctx, `UPDATE items SET safety_flags = $2 WHERE id = $1`, id, item.SafetyFlags); err != nil {
+			return fmt.Errorf("save item safety flags: %w", err)
+		}
+	}
+
 	return nil
 }
 
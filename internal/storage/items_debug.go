@@ -35,6 +35,7 @@ type ItemDebugDetail struct {
 	Status          string
 	RelevanceScore  float32
 	ImportanceScore float32
+	EvidenceVerdict string
 	Text            string
 	PreviewText     string
 	TGDate          time.Time
@@ -47,6 +48,9 @@ type ItemDebugDetail struct {
 	MediaData       []byte // Image data from the message (JPEG/PNG)
 	EntitiesJSON    []byte // Telegram message entities (contains URLs, mentions, etc.)
 	MediaJSON       []byte // Telegram media metadata (contains webpage URLs, etc.)
+	TicketKey       string // Issue tracker ticket created with /item track
+	TicketURL       string
+	ProcessedAt     time.Time // When the pipeline created the item
 }
 
 // SearchItemsByText looks for items with matching summary or raw text.
@@ -134,6 +138,7 @@ func (db *DB) GetItemDebugDetail(ctx context.Context, id string) (*ItemDebugDeta
 		       i.status,
 		       i.relevance_score,
 		       i.importance_score,
+		       i.evidence_verdict,
 		       rm.text,
 		       rm.preview_text,
 		       rm.tg_date,
@@ -145,10 +150,14 @@ func (db *DB) GetItemDebugDetail(ctx context.Context, id string) (*ItemDebugDeta
 		       c.username,
 		       c.title,
 		       c.description,
-		       c.tg_peer_id
+		       c.tg_peer_id,
+		       COALESCE(t.ticket_key, ''),
+		       COALESCE(t.ticket_url, ''),
+		       i.created_at
 		FROM items i
 		JOIN raw_messages rm ON i.raw_message_id = rm.id
 		JOIN channels c ON rm.channel_id = c.id
+		LEFT JOIN item_tickets t ON t.item_id = i.id
 		WHERE i.id = $1
 	`, toUUID(id))
 
@@ -159,6 +168,7 @@ func (db *DB) GetItemDebugDetail(ctx context.Context, id string) (*ItemDebugDeta
 		topic        pgtype.Text
 		language     pgtype.Text
 		langSource   pgtype.Text
+		verdict      pgtype.Text
 		text         pgtype.Text
 		previewText  pgtype.Text
 		channelID    pgtype.UUID
@@ -179,6 +189,7 @@ func (db *DB) GetItemDebugDetail(ctx context.Context, id string) (*ItemDebugDeta
 		&item.Status,
 		&item.RelevanceScore,
 		&item.ImportanceScore,
+		&verdict,
 		&text,
 		&previewText,
 		&item.TGDate,
@@ -191,6 +202,9 @@ func (db *DB) GetItemDebugDetail(ctx context.Context, id string) (*ItemDebugDeta
 		&title,
 		&desc,
 		&item.ChannelPeerID,
+		&item.TicketKey,
+		&item.TicketURL,
+		&item.ProcessedAt,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil //nolint:nilnil // not found
@@ -205,6 +219,7 @@ func (db *DB) GetItemDebugDetail(ctx context.Context, id string) (*ItemDebugDeta
 	item.Topic = topic.String
 	item.Language = language.String
 	item.LanguageSource = langSource.String
+	item.EvidenceVerdict = verdict.String
 	item.Text = text.String
 	item.PreviewText = previewText.String
 	item.ChannelID = fromUUID(channelID)
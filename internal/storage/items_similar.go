@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pgvector/pgvector-go"
+)
+
+// SimilarItem is an item ranked by embedding similarity to an example, with
+// the digest cluster it landed in, if any.
+type SimilarItem struct {
+	ItemSearchResult
+	Similarity   float64
+	ClusterID    string
+	ClusterTopic string
+	DigestedAt   *time.Time
+}
+
+// FindNearestItems returns the items whose embeddings are closest to the given
+// vector by cosine similarity, excluding excludeItemID when it is set.
+func (db *DB) FindNearestItems(ctx context.Context, embedding []float32, excludeItemID string, limit int) ([]SimilarItem, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT i.id,
+		       i.summary,
+		       i.topic,
+		       i.status,
+		       rm.text,
+		       rm.tg_date,
+		       rm.tg_message_id,
+		       c.username,
+		       c.title,
+		       c.tg_peer_id,
+		       1 - (e.embedding <=> $1::vector) AS similarity,
+		       cl.id,
+		       cl.topic,
+		       i.digested_at
+		FROM embeddings e
+		JOIN items i ON i.id = e.item_id
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		JOIN channels c ON c.id = rm.channel_id
+		LEFT JOIN LATERAL (
+			SELECT cs.id, cs.topic
+			FROM cluster_items ci
+			JOIN clusters cs ON cs.id = ci.cluster_id AND cs.source = 'digest'
+			WHERE ci.item_id = i.id
+			ORDER BY cs.created_at DESC
+			LIMIT 1
+		) cl ON true
+		WHERE $2::uuid IS NULL OR i.id <> $2::uuid
+		ORDER BY e.embedding <=> $1::vector
+		LIMIT $3
+	`, pgvector.NewVector(embedding), toUUID(excludeItemID), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("find nearest items: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]SimilarItem, 0, limit)
+
+	for rows.Next() {
+		var (
+			itemID       pgtype.UUID
+			summary      pgtype.Text
+			topic        pgtype.Text
+			text         pgtype.Text
+			user         pgtype.Text
+			title        pgtype.Text
+			clusterID    pgtype.UUID
+			clusterTopic pgtype.Text
+			digestedAt   pgtype.Timestamptz
+		)
+
+		res := SimilarItem{}
+
+		if err := rows.Scan(
+			&itemID,
+			&summary,
+			&topic,
+			&res.Status,
+			&text,
+			&res.TGDate,
+			&res.MessageID,
+			&user,
+			&title,
+			&res.ChannelPeerID,
+			&res.Similarity,
+			&clusterID,
+			&clusterTopic,
+			&digestedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan nearest item: %w", err)
+		}
+
+		res.ID = fromUUID(itemID)
+		res.Summary = summary.String
+		res.Topic = topic.String
+		res.Text = text.String
+		res.ChannelUsername = user.String
+		res.ChannelTitle = title.String
+		res.ClusterID = fromUUID(clusterID)
+		res.ClusterTopic = clusterTopic.String
+
+		if digestedAt.Valid {
+			t := digestedAt.Time
+			res.DigestedAt = &t
+		}
+
+		results = append(results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate nearest items: %w", err)
+	}
+
+	return results, nil
+}
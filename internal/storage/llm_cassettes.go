@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrCassetteNotFound is returned when no response was recorded for an LLM request.
+var ErrCassetteNotFound = errors.New("llm cassette not found")
+
+// SaveLLMCassette records the response of an LLM request with the raw
+// messages it was built from. A request recorded again keeps the latest
+// response.
+func (db *DB) SaveLLMCassette(ctx context.Context, key, method string, sources []string, response []byte) error {
+	if sources == nil {
+		sources = []string{}
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO llm_cassettes (request_key, method, raw_message_ids, response)
+		VALUES ($1, $2, $3::text[]::uuid[], $4)
+		ON CONFLICT (request_key) DO UPDATE
+		SET response = EXCLUDED.response,
+		    raw_message_ids = EXCLUDED.raw_message_ids,
+		    recorded_at = now()
+	`, key, method, sources, response); err != nil {
+		return fmt.Errorf("save llm cassette: %w", err)
+	}
+
+	return nil
+}
+
+// GetLLMCassette returns the recorded response of an LLM request, or
+// ErrCassetteNotFound when none was recorded.
+func (db *DB) GetLLMCassette(ctx context.Context, key string) ([]byte, error) {
+	var response []byte
+
+	if err := db.Pool.QueryRow(ctx, `
+		SELECT response FROM llm_cassettes WHERE request_key = $1
+	`, key).Scan(&response); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCassetteNotFound
+		}
+
+		return nil, fmt.Errorf("get llm cassette: %w", err)
+	}
+
+	return response, nil
+}
+
+// DeleteLLMCassettesBefore deletes the LLM responses recorded before the
+// given time and returns how many were deleted.
+func (db *DB) DeleteLLMCassettesBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := db.Pool.Exec(ctx, `DELETE FROM llm_cassettes WHERE recorded_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete llm cassettes: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TakeRateLimitToken takes a token from a shared bucket if one is available
+// now. Buckets refill at ratePerSecond up to capacity, measured with the
+// database clock so that all replicas agree; new buckets start full.
+func (db *DB) TakeRateLimitToken(ctx context.Context, bucket string, capacity, ratePerSecond float64) (bool, error) {
+	var tokens float64
+
+	err := db.Pool.QueryRow(ctx, `
+		INSERT INTO llm_rate_buckets AS b (bucket, tokens, updated_at)
+		VALUES ($1, $2::float8 - 1, now())
+		ON CONFLICT (bucket) DO UPDATE SET
+			tokens = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8) - 1,
+			updated_at = now()
+		WHERE LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8) >= 1
+		RETURNING tokens
+	`, bucket, capacity, ratePerSecond).Scan(&tokens)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("take rate limit token: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReserveRateLimitToken reserves a token from a shared bucket and returns how
+// long the caller must wait before using it. An empty bucket goes into debt,
+// so concurrent callers are queued behind each other.
+func (db *DB) ReserveRateLimitToken(ctx context.Context, bucket string, capacity, ratePerSecond float64) (time.Duration, error) {
+	var tokens float64
+
+	if err := db.Pool.QueryRow(ctx, `
+		INSERT INTO llm_rate_buckets AS b (bucket, tokens, updated_at)
+		VALUES ($1, $2::float8 - 1, now())
+		ON CONFLICT (bucket) DO UPDATE SET
+			tokens = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8) - 1,
+			updated_at = now()
+		RETURNING tokens
+	`, bucket, capacity, ratePerSecond).Scan(&tokens); err != nil {
+		return 0, fmt.Errorf("reserve rate limit token: %w", err)
+	}
+
+	if tokens >= 0 || ratePerSecond <= 0 {
+		return 0, nil
+	}
+
+	return time.Duration(-tokens / ratePerSecond * float64(time.Second)), nil
+}
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var errUnknownDerivedTable = errors.New("unknown derived table")
+
+// VacuumAnalyzeTable runs VACUUM (ANALYZE) on the table, optionally
+// schema-qualified. On a partitioned table this covers all of its partitions.
+func (db *DB) VacuumAnalyzeTable(ctx context.Context, table string) error {
+	if err := db.execWithoutStatementTimeout(ctx, "VACUUM (ANALYZE) "+pgx.Identifier(strings.Split(table, ".")).Sanitize()); err != nil {
+		return fmt.Errorf("vacuum %s: %w", table, err)
+	}
+
+	return nil
+}
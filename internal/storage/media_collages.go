@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// mediaCollageRetention is how long composed collages stay cached.
+const mediaCollageRetention = 7 * 24 * time.Hour
+
+// GetCachedMediaCollage returns the collage cached under key; found is false
+// when there is none.
+func (db *DB) GetCachedMediaCollage(ctx context.Context, key string) (image []byte, found bool, err error) {
+	err = db.Pool.QueryRow(ctx, `SELECT image FROM media_collage_cache WHERE cache_key = $1`, key).Scan(&image)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("get cached media collage: %w", err)
+	}
+
+	return image, true, nil
+}
+
+// SaveCachedMediaCollage caches a composed collage under key with the items
+// its images came from, and drops collages older than the retention period.
+func (db *DB) SaveCachedMediaCollage(ctx context.Context, key string, itemIDs []string, image []byte) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO media_collage_cache (cache_key, item_ids, image) VALUES ($1, $2::text[]::uuid[], $3)
+		ON CONFLICT (cache_key) DO UPDATE SET
+			image = EXCLUDED.image,
+			item_ids = ARRAY(SELECT DISTINCT unnest(media_collage_cache.item_ids || EXCLUDED.item_ids)),
+			created_at = now()
+	`, key, itemIDs, image); err != nil {
+		return fmt.Errorf("save cached media collage: %w", err)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `DELETE FROM media_collage_cache WHERE created_at < $1`, time.Now().Add(-mediaCollageRetention)); err != nil {
+		return fmt.Errorf("prune media collage cache: %w", err)
+	}
+
+	return nil
+}
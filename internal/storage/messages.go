@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
 	"github.com/lueurxax/telegram-digest-bot/internal/storage/sqlc"
 )
@@ -13,18 +15,26 @@ import (
 type RawMessage = domain.RawMessage
 
 func (db *DB) SaveRawMessage(ctx context.Context, msg *RawMessage) error {
+	chainJSON, originPeerID, originMsgID := encodeForwardChain(msg.ForwardChain)
+
 	if err := db.Queries.SaveRawMessage(ctx, sqlc.SaveRawMessageParams{
-		ChannelID:         toUUID(msg.ChannelID),
-		TgMessageID:       msg.TGMessageID,
-		TgDate:            toTimestamptz(msg.TGDate),
-		Text:              toText(msg.Text),
-		PreviewText:       toText(msg.PreviewText),
-		EntitiesJson:      msg.EntitiesJSON,
-		MediaJson:         msg.MediaJSON,
-		MediaData:         msg.MediaData,
-		CanonicalHash:     msg.CanonicalHash,
-		IsForward:         msg.IsForward,
-		HasCommentsThread: msg.HasCommentsThread,
+		ChannelID:            toUUID(msg.ChannelID),
+		TgMessageID:          msg.TGMessageID,
+		TgDate:               toTimestamptz(msg.TGDate),
+		Text:                 toText(msg.Text),
+		PreviewText:          toText(msg.PreviewText),
+		EntitiesJson:         msg.EntitiesJSON,
+		MediaJson:            msg.MediaJSON,
+		MediaData:            msg.MediaData,
+		CanonicalHash:        msg.CanonicalHash,
+		IsForward:            msg.IsForward,
+		HasCommentsThread:    msg.HasCommentsThread,
+		Views:                safeIntToInt32(msg.Views),
+		Forwards:             safeIntToInt32(msg.Forwards),
+		ForwardChain:         chainJSON,
+		FwdOriginPeerID:      originPeerID,
+		FwdOriginMsgID:       originMsgID,
+		VideoDurationSeconds: pgtype.Int4{Int32: safeIntToInt32(msg.VideoDuration), Valid: msg.VideoDuration > 0},
 	}); err != nil {
 		return fmt.Errorf("save raw message: %w", err)
 	}
@@ -72,6 +82,10 @@ func (db *DB) GetUnprocessedMessages(ctx context.Context, limit int) ([]RawMessa
 			CanonicalHash:           m.CanonicalHash,
 			IsForward:               m.IsForward,
 			HasCommentsThread:       m.HasCommentsThread,
+			Views:                   int(m.Views),
+			Forwards:                int(m.Forwards),
+			ForwardChain:            decodeForwardChain(m.ForwardChain),
+			VideoDuration:           int(m.VideoDurationSeconds.Int32),
 		}
 	}
 
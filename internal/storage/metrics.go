@@ -0,0 +1,24 @@
+package db
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "digest_db_query_duration_seconds",
+		Help:    "Duration of database queries by SQL operation",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 30, 120},
+	}, []string{"operation"})
+
+	dbSlowQueriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_db_slow_queries_total",
+		Help: "Total number of queries slower than DB_SLOW_QUERY_THRESHOLD",
+	}, []string{"query"})
+
+	dbQueryTimeoutsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "digest_db_query_timeouts_total",
+		Help: "Total number of queries stopped by a context deadline or statement_timeout",
+	}, []string{"query", "reason"})
+)
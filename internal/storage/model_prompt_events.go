@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Model/prompt event kinds.
+const (
+	ModelPromptEventModel  = "model"
+	ModelPromptEventPrompt = "prompt"
+)
+
+const (
+	modelOverrideKeyPrefix = "llm_override_"
+	promptActiveKeyPrefix  = "prompt:"
+	promptActiveKeySuffix  = ":active"
+
+	// modelPromptDefaultValue names the value of an unset override or prompt.
+	modelPromptDefaultValue = "default"
+)
+
+// ModelPromptEvent records the activation of a model override or prompt
+// version for one LLM task, e.g. prompt summarize switching from v6 to v7.
+// An empty value means the built-in default.
+type ModelPromptEvent struct {
+	ID        int64     `json:"id"`
+	ChangedAt time.Time `json:"changed_at"`
+	Kind      string    `json:"kind"`
+	Target    string    `json:"target"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	ChangedBy int64     `json:"changed_by"`
+}
+
+// Label describes the activation, e.g. "prompt summarize:v7".
+func (e ModelPromptEvent) Label() string {
+	value := e.NewValue
+	if value == "" {
+		value = modelPromptDefaultValue
+	}
+
+	return e.Kind + " " + e.Target + ":" + value
+}
+
+// ModelPromptEventTarget reports whether a setting key activates a model or
+// prompt, and for which task: llm_override_<task> selects a model and
+// prompt:<base>:active a prompt version.
+func ModelPromptEventTarget(key string) (kind, target string, ok bool) {
+	if task, found := strings.CutPrefix(key, modelOverrideKeyPrefix); found && task != "" {
+		return ModelPromptEventModel, task, true
+	}
+
+	if !strings.HasPrefix(key, promptActiveKeyPrefix) || !strings.HasSuffix(key, promptActiveKeySuffix) {
+		return "", "", false
+	}
+
+	base := strings.TrimSuffix(strings.TrimPrefix(key, promptActiveKeyPrefix), promptActiveKeySuffix)
+	if base == "" || strings.Contains(base, ":") {
+		return "", "", false
+	}
+
+	return ModelPromptEventPrompt, base, true
+}
+
+// settingEventValue decodes a JSON setting value for the changelog; an
+// unset or empty value is returned as "".
+func settingEventValue(raw string) string {
+	var s string
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		return strings.TrimSpace(raw)
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// recordModelPromptEvent logs a change of key to the model/prompt changelog
+// when key selects a model or prompt and its value changed. oldValue and
+// newValue hold JSON setting values. Like the setting history, the changelog
+// is best-effort and never fails the setting change itself.
+func (db *DB) recordModelPromptEvent(ctx context.Context, key, oldValue, newValue string, changedBy int64) {
+	kind, target, ok := ModelPromptEventTarget(key)
+	if !ok {
+		return
+	}
+
+	oldValue, newValue = settingEventValue(oldValue), settingEventValue(newValue)
+	if oldValue == newValue {
+		return
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO model_prompt_events (kind, target, old_value, new_value, changed_by)
+		VALUES ($1, $2, $3, $4, $5)
+	`, kind, SanitizeUTF8(target), SanitizeUTF8(oldValue), SanitizeUTF8(newValue), changedBy); err != nil {
+		db.Logger.Warn().Err(err).Str("key", key).Msg("failed to record model/prompt event")
+	}
+}
+
+// GetModelPromptEvents returns the model and prompt activations in [since, until), oldest first.
+func (db *DB) GetModelPromptEvents(ctx context.Context, since, until time.Time) ([]ModelPromptEvent, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, changed_at, kind, target, old_value, new_value, changed_by
+		FROM model_prompt_events
+		WHERE changed_at >= $1 AND changed_at < $2
+		ORDER BY changed_at, id
+	`, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("get model/prompt events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []ModelPromptEvent
+
+	for rows.Next() {
+		var e ModelPromptEvent
+		if err := rows.Scan(&e.ID, &e.ChangedAt, &e.Kind, &e.Target, &e.OldValue, &e.NewValue, &e.ChangedBy); err != nil {
+			return nil, fmt.Errorf("scan model/prompt event: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate model/prompt events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,44 @@
+package db
+
+import "testing"
+
+func TestModelPromptEventTarget(t *testing.T) {
+	tests := []struct {
+		key    string
+		kind   string
+		target string
+		ok     bool
+	}{
+		{key: "llm_override_summarize", kind: ModelPromptEventModel, target: "summarize", ok: true},
+		{key: "prompt:summarize:active", kind: ModelPromptEventPrompt, target: "summarize", ok: true},
+		{key: "prompt:summarize:v7", ok: false},
+		{key: "prompt::active", ok: false},
+		{key: "llm_override_", ok: false},
+		{key: "relevance_threshold", ok: false},
+	}
+
+	for _, tt := range tests {
+		kind, target, ok := ModelPromptEventTarget(tt.key)
+		if kind != tt.kind || target != tt.target || ok != tt.ok {
+			t.Errorf("ModelPromptEventTarget(%q) = %q, %q, %v, want %q, %q, %v", tt.key, kind, target, ok, tt.kind, tt.target, tt.ok)
+		}
+	}
+}
+
+func TestModelPromptEventLabel(t *testing.T) {
+	if got := (ModelPromptEvent{Kind: ModelPromptEventPrompt, Target: "summarize", NewValue: "v7"}).Label(); got != "prompt summarize:v7" {
+		t.Errorf("Label() = %q, want prompt summarize:v7", got)
+	}
+
+	if got := (ModelPromptEvent{Kind: ModelPromptEventModel, Target: "summarize"}).Label(); got != "model summarize:default" {
+		t.Errorf("Label() = %q, want model summarize:default", got)
+	}
+}
+
+func TestSettingEventValue(t *testing.T) {
+	for raw, want := range map[string]string{`"v7"`: "v7", `" gpt-4o "`: "gpt-4o", "": "", `null`: ""} {
+		if got := settingEventValue(raw); got != want {
+			t.Errorf("settingEventValue(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// Numeric fact kinds.
+const (
+	NumericFactPercent = "percent"
+	NumericFactMoney   = "money"
+	NumericFactCount   = "count"
+)
+
+// NumericFact is a key figure extracted from an item, with the sentence it
+// appeared in.
+type NumericFact struct {
+	ItemID  string
+	Value   string
+	Kind    string
+	Context string
+}
+
+// SaveItemNumericFacts replaces the numeric facts stored for an item.
+func (db *DB) SaveItemNumericFacts(ctx context.Context, itemID string, facts []NumericFact) error {
+	values := make([]string, len(facts))
+	kinds := make([]string, len(facts))
+	contexts := make([]string, len(facts))
+
+	for i, f := range facts {
+		values[i] = SanitizeUTF8(f.Value)
+		kinds[i] = f.Kind
+		contexts[i] = SanitizeUTF8(f.Context)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		WITH deleted AS (
+			DELETE FROM item_numeric_facts WHERE item_id = $1
+		)
+		INSERT INTO item_numeric_facts (item_id, position, value, kind, context)
+		SELECT $1, f.ord, f.value, f.kind, f.context
+		FROM unnest($2::text[], $3::text[], $4::text[]) WITH ORDINALITY AS f(value, kind, context, ord)
+	`, toUUID(itemID), values, kinds, contexts); err != nil {
+		return fmt.Errorf("save item numeric facts: %w", err)
+	}
+
+	return nil
+}
+
+// GetNumericFactsForItems returns numeric facts keyed by item ID, in extraction order.
+func (db *DB) GetNumericFactsForItems(ctx context.Context, itemIDs []string) (map[string][]NumericFact, error) {
+	result := map[string][]NumericFact{}
+
+	ids := parseUUIDs(itemIDs)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT item_id::text, value, kind, context
+		FROM item_numeric_facts
+		WHERE item_id = ANY($1)
+		ORDER BY item_id, position
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get numeric facts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var f NumericFact
+		if err := rows.Scan(&f.ItemID, &f.Value, &f.Kind, &f.Context); err != nil {
+			return nil, fmt.Errorf("scan numeric fact: %w", err)
+		}
+
+		result[f.ItemID] = append(result[f.ItemID], f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate numeric facts: %w", err)
+	}
+
+	return result, nil
+}
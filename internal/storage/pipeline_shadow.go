@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PipelineShadowDecision records whether a shadow stage and the live stage
+// it shadows dropped the same sampled message.
+type PipelineShadowDecision struct {
+	RawMessageID  string
+	Stage         string
+	ShadowStage   string
+	LiveDropped   bool
+	ShadowDropped bool
+	ShadowReason  string
+}
+
+// PipelineShadowAgreement summarizes the decisions of one shadow stage.
+type PipelineShadowAgreement struct {
+	Stage       string
+	ShadowStage string
+	Sampled     int
+	Agreed      int
+	// ShadowOnly counts messages only the shadow stage dropped, LiveOnly
+	// messages only the live stage dropped.
+	ShadowOnly int
+	LiveOnly   int
+}
+
+// SavePipelineShadowDecisions stores the decisions of shadow stages.
+func (db *DB) SavePipelineShadowDecisions(ctx context.Context, decisions []PipelineShadowDecision) error {
+	if len(decisions) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(decisions))
+	stages := make([]string, len(decisions))
+	shadows := make([]string, len(decisions))
+	live := make([]bool, len(decisions))
+	shadow := make([]bool, len(decisions))
+	reasons := make([]string, len(decisions))
+
+	for i, d := range decisions {
+		ids[i] = d.RawMessageID
+		stages[i] = d.Stage
+		shadows[i] = d.ShadowStage
+		live[i] = d.LiveDropped
+		shadow[i] = d.ShadowDropped
+		reasons[i] = SanitizeUTF8(d.ShadowReason)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO pipeline_shadow_decisions (raw_message_id, stage, shadow_stage, live_dropped, shadow_dropped, shadow_reason)
+		SELECT id::uuid, stage, shadow_stage, live_dropped, shadow_dropped, shadow_reason
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::bool[], $5::bool[], $6::text[])
+		     AS d(id, stage, shadow_stage, live_dropped, shadow_dropped, shadow_reason)
+	`, ids, stages, shadows, live, shadow, reasons); err != nil {
+		return fmt.Errorf("save pipeline shadow decisions: %w", err)
+	}
+
+	return nil
+}
+
+// GetPipelineShadowAgreement returns the agreement of each shadow stage with
+// its live stage since the given time.
+func (db *DB) GetPipelineShadowAgreement(ctx context.Context, since time.Time) ([]PipelineShadowAgreement, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT stage, shadow_stage, COUNT(*),
+		       COUNT(*) FILTER (WHERE live_dropped = shadow_dropped),
+		       COUNT(*) FILTER (WHERE shadow_dropped AND NOT live_dropped),
+		       COUNT(*) FILTER (WHERE live_dropped AND NOT shadow_dropped)
+		FROM pipeline_shadow_decisions
+		WHERE created_at >= $1
+		GROUP BY stage, shadow_stage
+		ORDER BY stage, shadow_stage
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("get pipeline shadow agreement: %w", err)
+	}
+	defer rows.Close()
+
+	var out []PipelineShadowAgreement
+
+	for rows.Next() {
+		var a PipelineShadowAgreement
+		if err := rows.Scan(&a.Stage, &a.ShadowStage, &a.Sampled, &a.Agreed, &a.ShadowOnly, &a.LiveOnly); err != nil {
+			return nil, fmt.Errorf("scan pipeline shadow agreement: %w", err)
+		}
+
+		out = append(out, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pipeline shadow agreement: %w", err)
+	}
+
+	return out, nil
+}
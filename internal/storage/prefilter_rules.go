@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PrefilterRuleHit counts how often a pre-filter rule took effect.
+type PrefilterRuleHit struct {
+	Hits      int64
+	LastHitAt time.Time
+}
+
+// IncrementPrefilterRuleHits adds hit counts keyed by rule source.
+func (db *DB) IncrementPrefilterRuleHits(ctx context.Context, hits map[string]int) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	rules := make([]string, 0, len(hits))
+	counts := make([]int64, 0, len(hits))
+
+	for rule, n := range hits {
+		rules = append(rules, SanitizeUTF8(rule))
+		counts = append(counts, int64(n))
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO prefilter_rule_hits (rule, hits, last_hit_at)
+		SELECT rule, hits, now()
+		FROM unnest($1::text[], $2::bigint[]) AS h(rule, hits)
+		ON CONFLICT (rule) DO UPDATE
+		SET hits = prefilter_rule_hits.hits + EXCLUDED.hits,
+		    last_hit_at = EXCLUDED.last_hit_at
+	`, rules, counts); err != nil {
+		return fmt.Errorf("increment prefilter rule hits: %w", err)
+	}
+
+	return nil
+}
+
+// GetPrefilterRuleHits returns hit counts keyed by rule source.
+func (db *DB) GetPrefilterRuleHits(ctx context.Context) (map[string]PrefilterRuleHit, error) {
+	rows, err := db.Pool.Query(ctx, `SELECT rule, hits, last_hit_at FROM prefilter_rule_hits`)
+	if err != nil {
+		return nil, fmt.Errorf("get prefilter rule hits: %w", err)
+	}
+	defer rows.Close()
+
+	hits := make(map[string]PrefilterRuleHit)
+
+	for rows.Next() {
+		var (
+			rule string
+			hit  PrefilterRuleHit
+		)
+
+		if err := rows.Scan(&rule, &hit.Hits, &hit.LastHitAt); err != nil {
+			return nil, fmt.Errorf("scan prefilter rule hit: %w", err)
+		}
+
+		hits[rule] = hit
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate prefilter rule hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+// ResetPrefilterRuleHits deletes the hit counters of the given rules, or of
+// all rules when none are given.
+func (db *DB) ResetPrefilterRuleHits(ctx context.Context, rules []string) error {
+	if rules == nil {
+		rules = []string{}
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		DELETE FROM prefilter_rule_hits
+		WHERE cardinality($1::text[]) = 0 OR rule = ANY($1::text[])
+	`, rules); err != nil {
+		return fmt.Errorf("reset prefilter rule hits: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const qualityRatingGood = "good"
+
+// QualityEvaluation compares live filter decisions with item ratings over a
+// window: an item is predicted relevant when it passes the thresholds and is
+// actually relevant when rated good.
+type QualityEvaluation struct {
+	ID          int64     `json:"id"`
+	EvaluatedAt time.Time `json:"evaluated_at"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	Relevance   float32   `json:"relevance_threshold"`
+	Importance  float32   `json:"importance_threshold"`
+	Rated       int       `json:"rated"`
+	TP          int       `json:"tp"`
+	FP          int       `json:"fp"`
+	FN          int       `json:"fn"`
+	TN          int       `json:"tn"`
+	Precision   float64   `json:"precision"`
+	Recall      float64   `json:"recall"`
+	NoiseRate   float64   `json:"noise_rate"`
+	// Events are the model and prompt activations since the previous
+	// evaluation, set by AnnotateQualityEvaluations.
+	Events []ModelPromptEvent `json:"events,omitempty"`
+}
+
+// EvaluateQuality builds the confusion matrix of the rated items against
+// thresholds t. Unrated items and unknown ratings are ignored.
+func EvaluateQuality(items []WhatIfItem, t WhatIfThresholds) QualityEvaluation {
+	eval := QualityEvaluation{Relevance: t.Relevance, Importance: t.Importance}
+
+	for _, it := range items {
+		good := it.Rating == qualityRatingGood
+		if !good && !it.IsNoise() {
+			continue
+		}
+
+		eval.Rated++
+
+		switch predicted := it.Passes(t); {
+		case predicted && good:
+			eval.TP++
+		case predicted:
+			eval.FP++
+		case good:
+			eval.FN++
+		default:
+			eval.TN++
+		}
+	}
+
+	eval.Precision = qualityRatio(eval.TP, eval.TP+eval.FP)
+	eval.Recall = qualityRatio(eval.TP, eval.TP+eval.FN)
+	eval.NoiseRate = qualityRatio(eval.FP, eval.TP+eval.FP)
+
+	return eval
+}
+
+// AnnotateQualityEvaluations attaches each model or prompt activation to the
+// first evaluation made after it, so a change in the metrics can be attributed
+// to it. Both slices must be sorted oldest first. It returns the activations
+// newer than the last evaluation.
+func AnnotateQualityEvaluations(evals []QualityEvaluation, events []ModelPromptEvent) []ModelPromptEvent {
+	next := 0
+
+	for i := range evals {
+		start := next
+		for next < len(events) && !events[next].ChangedAt.After(evals[i].EvaluatedAt) {
+			next++
+		}
+
+		if next > start {
+			evals[i].Events = events[start:next]
+		}
+	}
+
+	return events[next:]
+}
+
+func qualityRatio(numerator, denominator int) float64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	return float64(numerator) / float64(denominator)
+}
+
+// InsertQualityEvaluation stores one point of the quality time series.
+func (db *DB) InsertQualityEvaluation(ctx context.Context, eval *QualityEvaluation) error {
+	err := db.Pool.QueryRow(ctx, `
+		INSERT INTO quality_evaluations (evaluated_at, window_start, window_end, relevance_threshold, importance_threshold,
+		                                 rated, true_positives, false_positives, false_negatives, true_negatives,
+		                                 precision, recall, noise_rate)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`, eval.EvaluatedAt, eval.WindowStart, eval.WindowEnd, eval.Relevance, eval.Importance,
+		eval.Rated, eval.TP, eval.FP, eval.FN, eval.TN,
+		eval.Precision, eval.Recall, eval.NoiseRate).Scan(&eval.ID)
+	if err != nil {
+		return fmt.Errorf("insert quality evaluation: %w", err)
+	}
+
+	return nil
+}
+
+// GetQualityEvaluations returns the evaluations made in [since, until), oldest first.
+func (db *DB) GetQualityEvaluations(ctx context.Context, since, until time.Time) ([]QualityEvaluation, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, evaluated_at, window_start, window_end, relevance_threshold, importance_threshold,
+		       rated, true_positives, false_positives, false_negatives, true_negatives,
+		       precision, recall, noise_rate
+		FROM quality_evaluations
+		WHERE evaluated_at >= $1 AND evaluated_at < $2
+		ORDER BY evaluated_at
+	`, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("get quality evaluations: %w", err)
+	}
+	defer rows.Close()
+
+	var evals []QualityEvaluation
+
+	for rows.Next() {
+		var e QualityEvaluation
+		if err := rows.Scan(&e.ID, &e.EvaluatedAt, &e.WindowStart, &e.WindowEnd, &e.Relevance, &e.Importance,
+			&e.Rated, &e.TP, &e.FP, &e.FN, &e.TN,
+			&e.Precision, &e.Recall, &e.NoiseRate); err != nil {
+			return nil, fmt.Errorf("scan quality evaluation: %w", err)
+		}
+
+		evals = append(evals, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate quality evaluations: %w", err)
+	}
+
+	return evals, nil
+}
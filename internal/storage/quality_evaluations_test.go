@@ -0,0 +1,61 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateQuality(t *testing.T) {
+	items := []WhatIfItem{
+		{RelevanceScore: 0.9, ImportanceScore: 0.9, Rating: "good"},
+		{RelevanceScore: 0.8, ImportanceScore: 0.5, Rating: "bad"},
+		{RelevanceScore: 0.7, ImportanceScore: 0.6, Rating: "good"},
+		{RelevanceScore: 0.2, ImportanceScore: 0.9, Rating: "good"},
+		{RelevanceScore: 0.3, ImportanceScore: 0.1, Rating: "irrelevant"},
+		{RelevanceScore: 0.9, ImportanceScore: 0.9},
+		{RelevanceScore: 0.9, ImportanceScore: 0.9, Rating: "unknown"},
+	}
+
+	got := EvaluateQuality(items, WhatIfThresholds{Relevance: 0.5, Importance: 0.3})
+
+	if got.Rated != 5 || got.TP != 2 || got.FP != 1 || got.FN != 1 || got.TN != 1 {
+		t.Fatalf("EvaluateQuality() = %+v, want rated 5, TP 2, FP 1, FN 1, TN 1", got)
+	}
+
+	if got.Precision != 2.0/3 || got.Recall != 2.0/3 || got.NoiseRate != 1.0/3 {
+		t.Errorf("precision, recall, noise = %v, %v, %v, want 2/3, 2/3, 1/3", got.Precision, got.Recall, got.NoiseRate)
+	}
+
+	if empty := EvaluateQuality(nil, WhatIfThresholds{}); empty.Precision != 0 || empty.NoiseRate != 0 {
+		t.Errorf("EvaluateQuality(nil) = %+v, want zero rates", empty)
+	}
+}
+
+func TestAnnotateQualityEvaluations(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	evals := []QualityEvaluation{{EvaluatedAt: day}, {EvaluatedAt: day.AddDate(0, 0, 1)}, {EvaluatedAt: day.AddDate(0, 0, 2)}}
+	events := []ModelPromptEvent{
+		{ID: 1, ChangedAt: day.Add(-time.Hour)},
+		{ID: 2, ChangedAt: day.AddDate(0, 0, 1).Add(-time.Hour)},
+		{ID: 3, ChangedAt: day.AddDate(0, 0, 1)},
+		{ID: 4, ChangedAt: day.AddDate(0, 0, 2).Add(time.Hour)},
+	}
+
+	pending := AnnotateQualityEvaluations(evals, events)
+
+	if len(evals[0].Events) != 1 || evals[0].Events[0].ID != 1 {
+		t.Errorf("first evaluation events = %+v, want event 1", evals[0].Events)
+	}
+
+	if len(evals[1].Events) != 2 || evals[1].Events[0].ID != 2 || evals[1].Events[1].ID != 3 {
+		t.Errorf("second evaluation events = %+v, want events 2 and 3", evals[1].Events)
+	}
+
+	if evals[2].Events != nil {
+		t.Errorf("third evaluation events = %+v, want none", evals[2].Events)
+	}
+
+	if len(pending) != 1 || pending[0].ID != 4 {
+		t.Errorf("pending events = %+v, want event 4", pending)
+	}
+}
@@ -2,8 +2,8 @@
 SELECT id, tg_peer_id, username, title, is_active, access_hash, invite_link, context, description, last_tg_message_id, category, tone, update_freq, relevance_threshold, importance_threshold, importance_weight, auto_weight_enabled, weight_override, auto_relevance_enabled, relevance_threshold_delta FROM channels WHERE is_active = TRUE;
 
 -- name: SaveRawMessage :exec
-INSERT INTO raw_messages (channel_id, tg_message_id, tg_date, text, entities_json, media_json, media_data, preview_text, canonical_hash, is_forward, has_comments_thread)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+INSERT INTO raw_messages (channel_id, tg_message_id, tg_date, text, entities_json, media_json, media_data, preview_text, canonical_hash, is_forward, has_comments_thread, views, forwards, forward_chain, fwd_origin_peer_id, fwd_origin_msg_id, video_duration_seconds)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 ON CONFLICT (channel_id, tg_message_id) DO UPDATE SET
     media_data = COALESCE(raw_messages.media_data, EXCLUDED.media_data),
     preview_text = COALESCE(raw_messages.preview_text, EXCLUDED.preview_text),
@@ -49,7 +49,7 @@ WITH eligible AS (
     SELECT rm.id
     FROM raw_messages rm
     LEFT JOIN items i ON rm.id = i.raw_message_id
-    WHERE (rm.processed_at IS NULL AND rm.processing_started_at IS NULL)
+    WHERE (rm.processed_at IS NULL AND rm.processing_started_at IS NULL AND (rm.deferred_until IS NULL OR rm.deferred_until <= now()))
        OR (i.status IN ('error', 'retry') AND i.retry_count < 5 AND (i.next_retry_at IS NULL OR i.next_retry_at < now()))
     ORDER BY rm.tg_date ASC
     LIMIT $1
@@ -63,15 +63,18 @@ claimed AS (
     RETURNING rm.id
 )
 SELECT rm.id, rm.channel_id, rm.tg_message_id, rm.tg_date, rm.text, rm.preview_text, rm.entities_json, rm.media_json, rm.media_data, rm.canonical_hash, rm.is_forward, rm.has_comments_thread,
+       rm.views, rm.forwards, rm.forward_chain, rm.video_duration_seconds,
        c.title as channel_title, c.context as channel_context, c.description as channel_description,
        c.category as channel_category, c.tone as channel_tone, c.update_freq as channel_update_freq,
-       c.relevance_threshold as channel_relevance_threshold, c.importance_threshold as channel_importance_threshold,
+       COALESCE(c.relevance_threshold, g.relevance_threshold) as channel_relevance_threshold,
+       COALESCE(c.importance_threshold, g.importance_threshold) as channel_importance_threshold,
        c.importance_weight as channel_importance_weight,
        c.auto_relevance_enabled as channel_auto_relevance_enabled,
        c.relevance_threshold_delta as channel_relevance_threshold_delta,
        c.tg_peer_id as channel_tg_peer_id
 FROM raw_messages rm
 JOIN channels c ON rm.channel_id = c.id
+LEFT JOIN channel_groups g ON c.group_id = g.id
 WHERE rm.id IN (SELECT id FROM claimed)
 ORDER BY rm.tg_date ASC;
 
@@ -148,23 +151,29 @@ SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i
 FROM items i
 JOIN raw_messages rm ON i.raw_message_id = rm.id
 JOIN channels c ON rm.channel_id = c.id
+LEFT JOIN channel_groups g ON c.group_id = g.id
 LEFT JOIN embeddings e ON i.id = e.item_id
 WHERE rm.tg_date >= $1 AND rm.tg_date < $2
   AND i.status = 'ready'
-  AND i.importance_score >= COALESCE(c.importance_threshold, $3)
+  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+  AND channel_in_digests(c, rm.tg_date)
+  AND g.target_chat_id IS NULL
   AND i.digested_at IS NULL
 ORDER BY i.importance_score DESC, i.relevance_score DESC
 LIMIT $4;
 
 -- name: GetItemsForWindowWithMedia :many
-SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i.summary, i.language, i.status, i.first_seen_at, rm.tg_date, rm.media_data, c.username as source_channel, c.title as source_channel_title, c.tg_peer_id as source_channel_id, rm.tg_message_id as source_msg_id, e.embedding
+SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i.summary, i.language, i.status, i.first_seen_at, rm.tg_date, rm.media_data, rm.video_duration_seconds, i.alt_text, i.safety_flags, c.username as source_channel, c.title as source_channel_title, c.tg_peer_id as source_channel_id, rm.tg_message_id as source_msg_id, e.embedding
 FROM items i
 JOIN raw_messages rm ON i.raw_message_id = rm.id
 JOIN channels c ON rm.channel_id = c.id
+LEFT JOIN channel_groups g ON c.group_id = g.id
 LEFT JOIN embeddings e ON i.id = e.item_id
 WHERE rm.tg_date >= $1 AND rm.tg_date < $2
   AND i.status = 'ready'
-  AND i.importance_score >= COALESCE(c.importance_threshold, $3)
+  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+  AND channel_in_digests(c, rm.tg_date)
+  AND g.target_chat_id IS NULL
   AND i.digested_at IS NULL
 ORDER BY i.importance_score DESC, i.relevance_score DESC
 LIMIT $4;
@@ -333,7 +342,7 @@ LIMIT $3;
 SELECT window_start, window_end, posted_at FROM digests WHERE status = 'posted' ORDER BY posted_at DESC LIMIT 1;
 
 -- name: CountActiveChannels :one
-SELECT COUNT(*) FROM channels WHERE is_active = TRUE;
+SELECT COUNT(*) FROM channels WHERE is_active = TRUE AND health_status <> 'dead';
 
 -- name: CountRecentlyActiveChannels :one
 SELECT COUNT(DISTINCT channel_id) FROM raw_messages WHERE tg_date > now() - interval '24 hours';
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// pgCodeQueryCanceled is raised both for statement_timeout and for
+	// cancel requests sent when a context is done.
+	pgCodeQueryCanceled = "57014"
+
+	sqlcNamePrefix = "-- name: "
+
+	queryLabelRaw          = "raw"
+	timeoutReasonDeadline  = "deadline"
+	timeoutReasonStatement = "statement_timeout"
+
+	slowQueryLogMaxLen = 300
+	argsHashLen        = 8
+
+	statementTimeoutResetTimeout = 5 * time.Second
+)
+
+// queryOperations are the SQL verbs used as the operation metric label.
+var queryOperations = map[string]bool{
+	"select": true, "insert": true, "update": true, "delete": true, "with": true,
+}
+
+type queryTraceKey struct{}
+
+type noQueryTimeoutKey struct{}
+
+type queryTrace struct {
+	sql    string
+	args   []any
+	start  time.Time
+	cancel context.CancelFunc
+}
+
+// queryTracer applies the default query timeout to queries whose context has
+// no deadline, and records durations, slow queries and timeouts.
+type queryTracer struct {
+	queryTimeout  time.Duration
+	slowThreshold time.Duration
+	logger        *zerolog.Logger
+}
+
+// WithoutQueryTimeout marks the context so queries run with it do not get
+// the default query timeout. The context's own deadline still applies.
+func WithoutQueryTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noQueryTimeoutKey{}, true)
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	trace := &queryTrace{sql: data.SQL, args: data.Args, start: time.Now()}
+
+	if _, ok := ctx.Deadline(); !ok && t.queryTimeout > 0 && ctx.Value(noQueryTimeoutKey{}) == nil {
+		ctx, trace.cancel = context.WithTimeout(ctx, t.queryTimeout)
+	}
+
+	return context.WithValue(ctx, queryTraceKey{}, trace)
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(queryTraceKey{}).(*queryTrace)
+	if !ok {
+		return
+	}
+
+	if trace.cancel != nil {
+		trace.cancel()
+	}
+
+	duration := time.Since(trace.start)
+	dbQueryDuration.WithLabelValues(queryOperation(trace.sql)).Observe(duration.Seconds())
+
+	if reason := queryTimeoutReason(data.Err); reason != "" {
+		label := queryLabel(trace.sql)
+		dbQueryTimeoutsTotal.WithLabelValues(label, reason).Inc()
+		t.logger.Warn().Err(data.Err).Str("query", label).Str("reason", reason).Dur("duration", duration).
+			Str("args_hash", hashQueryArgs(trace.args)).Str("sql", compactSQL(trace.sql)).Msg("query timed out")
+
+		return
+	}
+
+	if t.slowThreshold > 0 && duration >= t.slowThreshold {
+		label := queryLabel(trace.sql)
+		dbSlowQueriesTotal.WithLabelValues(label).Inc()
+		t.logger.Warn().Str("query", label).Dur("duration", duration).
+			Str("args_hash", hashQueryArgs(trace.args)).Str("sql", compactSQL(trace.sql)).Msg("slow query")
+	}
+}
+
+// queryTimeoutReason tells a client deadline from a server statement_timeout.
+// It is empty for other errors, including cancellations.
+func queryTimeoutReason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return timeoutReasonDeadline
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgCodeQueryCanceled && strings.Contains(pgErr.Message, "statement timeout") {
+		return timeoutReasonStatement
+	}
+
+	return ""
+}
+
+// queryLabel names a query for metrics: the sqlc query name, or the SQL
+// operation of raw queries.
+func queryLabel(sql string) string {
+	sql = strings.TrimSpace(sql)
+
+	if name, ok := strings.CutPrefix(sql, sqlcNamePrefix); ok {
+		if fields := strings.Fields(name); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+
+	return queryLabelRaw + "_" + queryOperation(sql)
+}
+
+// queryOperation returns the lower-cased leading SQL verb, skipping comment
+// lines, or "other".
+func queryOperation(sql string) string {
+	for _, line := range strings.Split(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+
+		verb := strings.ToLower(strings.Fields(line)[0])
+		verb = strings.TrimRight(verb, "(;")
+
+		if queryOperations[verb] {
+			return verb
+		}
+
+		break
+	}
+
+	return "other"
+}
+
+// hashQueryArgs fingerprints query arguments so slow queries with the same
+// parameters can be correlated without logging their values.
+func hashQueryArgs(args []any) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v", args)))
+
+	return hex.EncodeToString(sum[:argsHashLen])
+}
+
+// compactSQL collapses whitespace and truncates the SQL for logging.
+func compactSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+
+	if len(sql) > slowQueryLogMaxLen {
+		return sql[:slowQueryLogMaxLen] + "…"
+	}
+
+	return sql
+}
+
+// execWithoutStatementTimeout runs a long maintenance statement (view
+// refreshes, vacuums) on a dedicated connection with statement_timeout and the
+// default query timeout lifted; only the caller's context bounds it.
+func (db *DB) execWithoutStatementTimeout(ctx context.Context, sql string) error {
+	ctx = WithoutQueryTimeout(ctx)
+
+	conn, err := db.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SET statement_timeout = 0"); err != nil {
+		return fmt.Errorf("lift statement timeout: %w", err)
+	}
+
+	defer func() {
+		resetCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statementTimeoutResetTimeout)
+		defer cancel()
+
+		// A connection that kept the lifted timeout must not return to the pool.
+		if _, err := conn.Exec(resetCtx, "RESET statement_timeout"); err != nil {
+			_ = conn.Conn().Close(resetCtx)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+
+	return nil
+}
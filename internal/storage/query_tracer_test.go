@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+func TestQueryLabel(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "-- name: GetItemsForWindow :many\nSELECT 1", want: "GetItemsForWindow"},
+		{sql: "\n\t\tSELECT id FROM items", want: "raw_select"},
+		{sql: "WITH x AS (SELECT 1) DELETE FROM items", want: "raw_with"},
+		{sql: "-- comment\nUPDATE channels SET x = 1", want: "raw_update"},
+		{sql: "VACUUM (ANALYZE) items", want: "raw_other"},
+	}
+
+	for _, tt := range tests {
+		if got := queryLabel(tt.sql); got != tt.want {
+			t.Errorf("queryLabel(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestQueryTimeoutReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "no error", want: ""},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: timeoutReasonDeadline},
+		{name: "statement timeout", err: &pgconn.PgError{Code: pgCodeQueryCanceled, Message: "canceling statement due to statement timeout"}, want: timeoutReasonStatement},
+		{name: "user cancel", err: &pgconn.PgError{Code: pgCodeQueryCanceled, Message: "canceling statement due to user request"}, want: ""},
+		{name: "canceled context", err: context.Canceled, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryTimeoutReason(tt.err); got != tt.want {
+				t.Errorf("queryTimeoutReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryTracerDefaultTimeout(t *testing.T) {
+	logger := zerolog.Nop()
+	tracer := &queryTracer{queryTimeout: time.Minute, logger: &logger}
+	data := pgx.TraceQueryStartData{SQL: "SELECT 1"}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, data)
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("query without deadline did not get the default timeout")
+	}
+
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	if ctx.Err() == nil {
+		t.Error("default timeout context was not released at query end")
+	}
+
+	ctx = tracer.TraceQueryStart(WithoutQueryTimeout(context.Background()), nil, data)
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("WithoutQueryTimeout context got a deadline")
+	}
+
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	want, _ := parent.Deadline()
+
+	ctx = tracer.TraceQueryStart(parent, nil, data)
+	if got, _ := ctx.Deadline(); !got.Equal(want) {
+		t.Errorf("deadline = %v, want the caller's %v", got, want)
+	}
+}
+
+func TestSlowQueryLogHelpers(t *testing.T) {
+	if got := compactSQL("SELECT  id\n\t FROM items"); got != "SELECT id FROM items" {
+		t.Errorf("compactSQL() = %q", got)
+	}
+
+	if got := compactSQL(strings.Repeat("x ", slowQueryLogMaxLen)); len(got) > slowQueryLogMaxLen+len("…") {
+		t.Errorf("compactSQL() length = %d, want at most %d", len(got), slowQueryLogMaxLen)
+	}
+
+	a, b := hashQueryArgs([]any{1, "x"}), hashQueryArgs([]any{1, "y"})
+	if a == "" || a == b || a != hashQueryArgs([]any{1, "x"}) {
+		t.Errorf("hashQueryArgs() = %q, %q; want stable distinct hashes", a, b)
+	}
+
+	if hashQueryArgs(nil) != "" {
+		t.Error("hashQueryArgs(nil) is not empty")
+	}
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// derivedRebuildOverlap re-processes clusters created shortly before the
+	// watermark, covering clustering runs that committed after a rebuild
+	// started.
+	derivedRebuildOverlap = time.Hour
+	// derivedFullRebuildInterval is how often an incremental table is
+	// truncated and rebuilt from scratch, picking up item changes (topics,
+	// languages, embeddings) that do not create new clusters.
+	derivedFullRebuildInterval = 24 * time.Hour
+)
+
+// incrementalRebuildFunc rebuilds the rows of research clusters created at
+// or after since, or the whole table when since is zero.
+type incrementalRebuildFunc func(ctx context.Context, tx pgx.Tx, since time.Time) error
+
+// withRebuildState wraps an incremental rebuild with the table's watermark
+// from rebuild_state. The watermark is advanced in the same transaction as
+// the rebuild, so a failed rebuild is retried from the same point.
+func (db *DB) withRebuildState(name string, fn incrementalRebuildFunc) func(context.Context, pgx.Tx) error {
+	return func(ctx context.Context, tx pgx.Tx) error {
+		var watermark, lastFull pgtype.Timestamptz
+
+		err := tx.QueryRow(ctx, `
+			SELECT watermark, last_full_rebuild_at FROM rebuild_state WHERE name = $1 FOR UPDATE
+		`, name).Scan(&watermark, &lastFull)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("get rebuild state of %s: %w", name, err)
+		}
+
+		since := incrementalRebuildSince(watermark, lastFull, time.Now())
+		full := since.IsZero()
+
+		db.Logger.Info().Str(logFieldTable, name).Bool("full", full).Time("since", since).Msg("rebuilding derived table")
+
+		if err := fn(ctx, tx, since); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO rebuild_state (name, watermark, last_full_rebuild_at, updated_at)
+			VALUES ($1, now(), now(), now())
+			ON CONFLICT (name) DO UPDATE SET
+				watermark = EXCLUDED.watermark,
+				last_full_rebuild_at = CASE WHEN $2 THEN EXCLUDED.last_full_rebuild_at ELSE rebuild_state.last_full_rebuild_at END,
+				updated_at = now()
+		`, name, full); err != nil {
+			return fmt.Errorf("save rebuild state of %s: %w", name, err)
+		}
+
+		return nil
+	}
+}
+
+// incrementalRebuildSince returns the creation time from which clusters are
+// rebuilt, or zero when the table has no state yet or a full rebuild is due.
+func incrementalRebuildSince(watermark, lastFull pgtype.Timestamptz, now time.Time) time.Time {
+	if !watermark.Valid || !lastFull.Valid || now.Sub(lastFull.Time) >= derivedFullRebuildInterval {
+		return time.Time{}
+	}
+
+	return watermark.Time.Add(-derivedRebuildOverlap)
+}
+
+// ResetRebuildState drops the watermarks of all incremental derived tables,
+// so their next rebuild is a full one.
+func (db *DB) ResetRebuildState(ctx context.Context) error {
+	if _, err := db.Pool.Exec(ctx, `DELETE FROM rebuild_state`); err != nil {
+		return fmt.Errorf("reset rebuild state: %w", err)
+	}
+
+	return nil
+}
+
+// clearChangedClusterRows deletes the rows of research clusters created at or
+// after since from a table keyed by cluster_id, or truncates the table when
+// since is zero.
+func clearChangedClusterRows(ctx context.Context, tx pgx.Tx, table string, since time.Time) error {
+	if since.IsZero() {
+		if _, err := tx.Exec(ctx, "TRUNCATE "+table); err != nil {
+			return fmt.Errorf("truncate %s: %w", table, err)
+		}
+
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM `+table+`
+		WHERE cluster_id IN (SELECT id FROM clusters WHERE source = $1 AND created_at >= $2)
+	`, ClusterSourceResearch, since); err != nil {
+		return fmt.Errorf("delete changed rows of %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// clearChangedLanguageLinks deletes the language links from or to research
+// clusters created at or after since, or all links when since is zero.
+func clearChangedLanguageLinks(ctx context.Context, tx pgx.Tx, since time.Time) error {
+	if since.IsZero() {
+		return clearChangedClusterRows(ctx, tx, "cluster_language_links", since)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		WITH changed AS (SELECT id FROM clusters WHERE source = $1 AND created_at >= $2)
+		DELETE FROM cluster_language_links
+		WHERE cluster_id IN (SELECT id FROM changed) OR linked_cluster_id IN (SELECT id FROM changed)
+	`, ClusterSourceResearch, since); err != nil {
+		return fmt.Errorf("delete changed rows of cluster_language_links: %w", err)
+	}
+
+	return nil
+}
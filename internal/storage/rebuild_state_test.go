@@ -0,0 +1,32 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestIncrementalRebuildSince(t *testing.T) {
+	now := time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC)
+	watermark := pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}
+
+	tests := []struct {
+		name      string
+		watermark pgtype.Timestamptz
+		lastFull  pgtype.Timestamptz
+		want      time.Time
+	}{
+		{name: "no state", want: time.Time{}},
+		{name: "full rebuild due", watermark: watermark, lastFull: pgtype.Timestamptz{Time: now.Add(-derivedFullRebuildInterval), Valid: true}, want: time.Time{}},
+		{name: "incremental with overlap", watermark: watermark, lastFull: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}, want: watermark.Time.Add(-derivedRebuildOverlap)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := incrementalRebuildSince(tt.watermark, tt.lastFull, now); !got.Equal(tt.want) {
+				t.Errorf("incrementalRebuildSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
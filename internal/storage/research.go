@@ -12,6 +12,9 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pgvector/pgvector-go"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/domain"
+	"github.com/lueurxax/telegram-digest-bot/internal/core/synonyms"
 )
 
 // Sentinel errors for research queries.
@@ -53,6 +56,7 @@ const (
 	maxSearchLimit         = 200
 	defaultTopicDriftLimit = 100
 	recencyHalfLifeDays    = 14.0
+	minFullTextQueryRunes  = 3
 	maxOverlapChannels     = 200
 	topicDriftMinJaccard   = 0.6
 	topicDriftMinEmbedding = 0.6
@@ -77,9 +81,9 @@ const (
 
 	// SQL format patterns for building dynamic queries.
 	fmtScoreExpr       = "(0.5 * i.importance_score + 0.5 * exp(-extract(epoch from ($%d - rm.tg_date)) / 86400 / %.1f))"
-	fmtTextIlike       = "(i.summary ILIKE $%d OR rm.text ILIKE $%d OR c.title ILIKE $%d OR c.username ILIKE $%d)"
-	fmtSearchTS        = "(i.search_vector @@ plainto_tsquery('simple', $%d) OR c.title ILIKE $%d OR c.username ILIKE $%d)"
-	fmtEvidenceIlike   = "(es.title ILIKE $%d OR es.description ILIKE $%d)"
+	fmtTextIlike       = "(i.summary ILIKE ANY($%d::text[]) OR rm.text ILIKE ANY($%d::text[]) OR c.title ILIKE ANY($%d::text[]) OR c.username ILIKE ANY($%d::text[]))"
+	fmtSearchTS        = "(i.search_vector @@ %s OR c.title ILIKE ANY($%d::text[]) OR c.username ILIKE ANY($%d::text[]))"
+	fmtEvidenceIlike   = "(es.title ILIKE ANY($%d::text[]) OR es.description ILIKE ANY($%d::text[]))"
 	fmtDateFrom        = "rm.tg_date >= $%d"
 	fmtDateTo          = "rm.tg_date <= $%d"
 	fmtEvidenceDateGte = "es.crawled_at >= $%d"
@@ -98,6 +102,7 @@ const (
 			       i.status,
 			       i.relevance_score,
 			       i.importance_score,
+			       i.regions,
 			       rm.text,
 			       rm.tg_date,
 			       rm.tg_message_id,
@@ -134,7 +139,7 @@ const (
 				LIMIT 1
 			) ir ON true
 			WHERE %s
-			ORDER BY score DESC, rm.tg_date DESC
+			ORDER BY score DESC, rm.tg_date DESC, i.id DESC
 			LIMIT %d OFFSET %d
 		`
 
@@ -186,9 +191,20 @@ type ResearchSearchParams struct {
 	Topic        string
 	Lang         string
 	Provider     string
+	Regions      []string
 	Limit        int
 	Offset       int
 	IncludeCount bool
+	// EstimateCount returns the planner's estimate instead of an exact count
+	// when IncludeCount is set.
+	EstimateCount bool
+	// ItemCursor and EvidenceCursor page with keysets instead of Offset.
+	ItemCursor     *ResearchSearchCursor
+	EvidenceCursor *ResearchSearchCursor
+
+	// expansion holds the synonym and transliteration variants of Query,
+	// set by the search methods when the DB has a search expander.
+	expansion *synonyms.Expansion
 }
 
 // ResearchItemSearchResult is a lightweight item search result.
@@ -200,6 +216,7 @@ type ResearchItemSearchResult struct {
 	Status          string
 	RelevanceScore  float32
 	ImportanceScore float32
+	Regions         []string
 	TGDate          time.Time
 	MessageID       int64
 	ChannelUsername string
@@ -234,7 +251,8 @@ type ResearchEvidenceSearchResult struct {
 
 // ResearchSearchResultCount holds total count.
 type ResearchSearchResultCount struct {
-	Total int
+	Total     int
+	Estimated bool
 }
 
 // ResearchChannelRef holds basic channel identity info.
@@ -310,51 +328,32 @@ func scanChannelRef(row pgx.Row) (*ResearchChannelRef, error) {
 
 // buildItemSearchQuery builds the SQL query and args for item search.
 func buildItemSearchQuery(params ResearchSearchParams, where []string, args []any, limit int) (string, []any) {
-	if params.Query != "" && len([]rune(params.Query)) >= 3 {
-		args = append(args, params.Query)
-		tsQueryIdx := len(args)
-		rankExpr := fmt.Sprintf("ts_rank_cd(i.search_vector, plainto_tsquery('simple', $%d))", tsQueryIdx)
-
-		pattern := "%" + SanitizeUTF8(params.Query) + "%"
-		args = append(args, pattern)
-		patternIdx := len(args)
+	where, args, scoreExpr := applyItemSearchText(params, where, args)
+	where, args = applyItemSearchCursor(params.ItemCursor, scoreExpr, where, args)
 
-		where = append(where, fmt.Sprintf(
-			fmtSearchTS,
-			tsQueryIdx,
-			patternIdx,
-			patternIdx,
-		))
-
-		args = append(args, toTimestamptz(params.SearchAt))
-		scoreIdx := len(args)
-		scoreExpr := fmt.Sprintf("(0.5 * %s + 0.3 * i.importance_score + 0.2 * exp(-extract(epoch from ($%d - rm.tg_date)) / 86400 / %.1f))", rankExpr, scoreIdx, recencyHalfLifeDays)
+	return fmt.Sprintf(sqlSearchItems, scoreExpr, strings.Join(where, sqlAndJoin), limit, searchOffset(params.Offset, params.ItemCursor)), args
+}
 
-		return fmt.Sprintf(sqlSearchItems, scoreExpr, strings.Join(where, sqlAndJoin), limit, params.Offset), args
-	}
+// applyItemSearchText adds the text filter of the query and returns the
+// score expression: FTS rank, importance and recency for queries of three
+// or more characters, importance and recency otherwise.
+func applyItemSearchText(params ResearchSearchParams, where []string, args []any) ([]string, []any, string) {
+	where, args, tsQuery := applyItemTextFilter(params, where, args)
 
-	if params.Query != "" {
-		pattern := "%" + SanitizeUTF8(params.Query) + "%"
-		args = append(args, pattern)
-		patternIdx := len(args)
-		where = append(where, fmt.Sprintf(fmtTextIlike, patternIdx, patternIdx, patternIdx, patternIdx))
+	args = append(args, toTimestamptz(params.scoreTime()))
 
-		args = append(args, toTimestamptz(params.SearchAt))
-		scoreIdx := len(args)
-		scoreExpr := fmt.Sprintf(fmtScoreExpr, scoreIdx, recencyHalfLifeDays)
+	if tsQuery != "" {
+		rankExpr := fmt.Sprintf("ts_rank_cd(i.search_vector, %s)", tsQuery)
 
-		return fmt.Sprintf(sqlSearchItems, scoreExpr, strings.Join(where, sqlAndJoin), limit, params.Offset), args
+		return where, args, fmt.Sprintf("(0.5 * %s + 0.3 * i.importance_score + 0.2 * exp(-extract(epoch from ($%d - rm.tg_date)) / 86400 / %.1f))", rankExpr, len(args), recencyHalfLifeDays)
 	}
 
-	args = append(args, toTimestamptz(params.SearchAt))
-	scoreIdx := len(args)
-	scoreExpr := fmt.Sprintf(fmtScoreExpr, scoreIdx, recencyHalfLifeDays)
-
-	return fmt.Sprintf(sqlSearchItems, scoreExpr, strings.Join(where, sqlAndJoin), limit, params.Offset), args
+	return where, args, fmt.Sprintf(fmtScoreExpr, len(args), recencyHalfLifeDays)
 }
 
 // SearchResearchItems searches items using full-text search with filters.
 func (db *DB) SearchResearchItems(ctx context.Context, params ResearchSearchParams) ([]ResearchItemSearchResult, *ResearchSearchResultCount, error) {
+	params = db.withQueryExpansion(params)
 	limit := normalizeSearchLimit(params.Limit)
 	normalizedChannel := normalizeUsername(strings.TrimSpace(params.Channel))
 	where, args := buildResearchItemFilters(params, normalizedChannel)
@@ -394,6 +393,7 @@ func (db *DB) SearchResearchItems(ctx context.Context, params ResearchSearchPara
 			&res.Status,
 			&res.RelevanceScore,
 			&res.ImportanceScore,
+			&res.Regions,
 			&text,
 			&res.TGDate,
 			&res.MessageID,
@@ -443,7 +443,7 @@ func (db *DB) SearchResearchItems(ctx context.Context, params ResearchSearchPara
 			return nil, nil, err
 		}
 
-		count = &ResearchSearchResultCount{Total: total}
+		count = &ResearchSearchResultCount{Total: total, Estimated: params.EstimateCount}
 	}
 
 	return results, count, nil
@@ -451,28 +451,15 @@ func (db *DB) SearchResearchItems(ctx context.Context, params ResearchSearchPara
 
 // buildEvidenceSearchQuery builds the SQL query and args for evidence search.
 func buildEvidenceSearchQuery(params ResearchSearchParams, where []string, args []any, limit int) (string, []any) {
-	if params.Query != "" && len([]rune(params.Query)) >= 3 {
-		args = append(args, params.Query)
-		tsQueryIdx := len(args)
-		where = append(where, fmt.Sprintf("es.search_vector @@ plainto_tsquery('simple', $%d)", tsQueryIdx))
-
-		return fmt.Sprintf(sqlSearchEvidence, strings.Join(where, sqlAndJoin), limit, params.Offset), args
-	}
+	where, args = applyResearchQueryFilter(params, where, args, "es.search_vector", fmtEvidenceIlike)
+	where, args = applyEvidenceSearchCursor(params.EvidenceCursor, where, args)
 
-	if params.Query != "" {
-		pattern := "%" + SanitizeUTF8(params.Query) + "%"
-		args = append(args, pattern)
-		patternIdx := len(args)
-		where = append(where, fmt.Sprintf(fmtEvidenceIlike, patternIdx, patternIdx))
-
-		return fmt.Sprintf(sqlSearchEvidence, strings.Join(where, sqlAndJoin), limit, params.Offset), args
-	}
-
-	return fmt.Sprintf(sqlSearchEvidence, strings.Join(where, sqlAndJoin), limit, params.Offset), args
+	return fmt.Sprintf(sqlSearchEvidence, strings.Join(where, sqlAndJoin), limit, searchOffset(params.Offset, params.EvidenceCursor)), args
 }
 
 // SearchResearchEvidence searches evidence sources with filters.
 func (db *DB) SearchResearchEvidence(ctx context.Context, params ResearchSearchParams) ([]ResearchEvidenceSearchResult, *ResearchSearchResultCount, error) {
+	params = db.withQueryExpansion(params)
 	limit := normalizeSearchLimit(params.Limit)
 	normalizedChannel := normalizeUsername(strings.TrimSpace(params.Channel))
 	where, args := buildResearchEvidenceFilters(params, normalizedChannel)
@@ -561,7 +548,7 @@ func (db *DB) SearchResearchEvidence(ctx context.Context, params ResearchSearchP
 			return nil, nil, err
 		}
 
-		count = &ResearchSearchResultCount{Total: total}
+		count = &ResearchSearchResultCount{Total: total, Estimated: params.EstimateCount}
 	}
 
 	return results, count, nil
@@ -569,70 +556,84 @@ func (db *DB) SearchResearchEvidence(ctx context.Context, params ResearchSearchP
 
 func (db *DB) countResearchItems(ctx context.Context, params ResearchSearchParams, channel string) (int, error) {
 	where, args := buildResearchItemFilters(params, channel)
+	where, args, _ = applyItemTextFilter(params, where, args)
 
-	if params.Query != "" && len([]rune(params.Query)) >= 3 {
-		args = append(args, params.Query)
-		tsQueryIdx := len(args)
-		pattern := "%" + SanitizeUTF8(params.Query) + "%"
-		args = append(args, pattern)
-		patternIdx := len(args)
-
-		where = append(where, fmt.Sprintf(
-			fmtSearchTS,
-			tsQueryIdx,
-			patternIdx,
-			patternIdx,
-		))
-	} else if params.Query != "" {
-		pattern := "%" + SanitizeUTF8(params.Query) + "%"
-		args = append(args, pattern)
-		patternIdx := len(args)
-		where = append(where, fmt.Sprintf(fmtTextIlike, patternIdx, patternIdx, patternIdx, patternIdx))
-	}
-
-	return db.executeCountQuery(ctx, `
-		SELECT COUNT(*)
+	const from = `
 		FROM items i
 		JOIN raw_messages rm ON i.raw_message_id = rm.id
 		JOIN channels c ON rm.channel_id = c.id
 		WHERE %s
-	`, where, args, "count research items")
+	`
+
+	if params.EstimateCount {
+		return db.estimateCountQuery(ctx, "SELECT 1"+from, where, args, "estimate research items")
+	}
+
+	return db.executeCountQuery(ctx, "SELECT COUNT(*)"+from, where, args, "count research items")
 }
 
 func (db *DB) countResearchEvidence(ctx context.Context, params ResearchSearchParams, channel string) (int, error) {
 	where, args := buildResearchEvidenceFilters(params, channel)
-	where, args = applyResearchQueryFilter(params.Query, where, args,
-		"es.search_vector", "(es.title ILIKE $%d OR es.description ILIKE $%d)")
+	where, args = applyResearchQueryFilter(params, where, args, "es.search_vector", fmtEvidenceIlike)
 
-	return db.executeCountQuery(ctx, `
-		SELECT COUNT(DISTINCT es.id)
+	const from = `
 		FROM evidence_sources es
 		LEFT JOIN item_evidence ie ON ie.evidence_id = es.id
 		LEFT JOIN items i ON i.id = ie.item_id
 		LEFT JOIN raw_messages rm ON i.raw_message_id = rm.id
 		LEFT JOIN channels c ON rm.channel_id = c.id
 		WHERE %s
-	`, where, args, "count research evidence")
+	`
+
+	if params.EstimateCount {
+		return db.estimateCountQuery(ctx, "SELECT DISTINCT es.id"+from, where, args, "estimate research evidence")
+	}
+
+	return db.executeCountQuery(ctx, "SELECT COUNT(DISTINCT es.id)"+from, where, args, "count research evidence")
 }
 
 // applyResearchQueryFilter adds FTS or ILIKE filter based on query length.
-func applyResearchQueryFilter(query string, where []string, args []any, ftsColumn, ilikePattern string) ([]string, []any) {
-	if query == "" {
+func applyResearchQueryFilter(params ResearchSearchParams, where []string, args []any, ftsColumn, ilikePattern string) ([]string, []any) {
+	if params.Query == "" {
 		return where, args
 	}
 
-	if len([]rune(query)) >= 3 {
-		args = append(args, query)
-		where = append(where, fmt.Sprintf("%s @@ plainto_tsquery('simple', $%d)", ftsColumn, len(args)))
+	if len([]rune(params.Query)) >= minFullTextQueryRunes {
+		var tsQuery string
+
+		args, tsQuery = params.appendTSQuery(args)
+		where = append(where, ftsColumn+" @@ "+tsQuery)
 	} else {
-		pattern := "%" + SanitizeUTF8(query) + "%"
-		args = append(args, pattern)
-		where = append(where, fmt.Sprintf(ilikePattern, len(args), len(args)))
+		var patternIdx int
+
+		args, patternIdx = params.appendILikePatterns(args)
+		where = append(where, fmt.Sprintf(ilikePattern, patternIdx, patternIdx))
 	}
 
 	return where, args
 }
 
+// applyItemTextFilter adds the text filter of an item search: FTS on items
+// and ILIKE on channel names for queries of three or more characters, ILIKE
+// on text and channel names otherwise. It returns the tsquery expression,
+// or "" when the query is empty or short.
+func applyItemTextFilter(params ResearchSearchParams, where []string, args []any) ([]string, []any, string) {
+	if params.Query == "" {
+		return where, args, ""
+	}
+
+	if len([]rune(params.Query)) < minFullTextQueryRunes {
+		args, patternIdx := params.appendILikePatterns(args)
+
+		return append(where, fmt.Sprintf(fmtTextIlike, patternIdx, patternIdx, patternIdx, patternIdx)), args, ""
+	}
+
+	args, tsQuery := params.appendTSQuery(args)
+	args, patternIdx := params.appendILikePatterns(args)
+
+	return append(where, fmt.Sprintf(fmtSearchTS, tsQuery, patternIdx, patternIdx)), args, tsQuery
+}
+
 // executeCountQuery runs a COUNT query and returns the result.
 func (db *DB) executeCountQuery(ctx context.Context, queryTemplate string, where []string, args []any, errContext string) (int, error) {
 	row := db.Pool.QueryRow(ctx, fmt.Sprintf(queryTemplate, strings.Join(where, sqlAndJoin)), args...)
@@ -648,6 +649,11 @@ func (db *DB) executeCountQuery(ctx context.Context, queryTemplate string, where
 func buildResearchItemFilters(params ResearchSearchParams, channel string) ([]string, []any) {
 	where, args := buildResearchCommonFilters(params, channel)
 
+	if len(params.Regions) > 0 {
+		args = append(args, params.Regions)
+		where = append(where, fmt.Sprintf("i.regions && $%d::text[]", len(args)))
+	}
+
 	if params.Provider != "" {
 		args = append(args, params.Provider)
 		where = append(where, fmt.Sprintf(`
@@ -2590,10 +2596,13 @@ func (db *DB) GetChannelWeightHistory(ctx context.Context, channelID string, fro
 type ResearchClaimEntry struct {
 	ID              string
 	ClaimText       string
+	CanonicalText   string
+	Aliases         []string
 	FirstSeenAt     time.Time
 	OriginClusterID string
 	ClusterIDs      []string
 	ContradictedBy  []string
+	State           string
 }
 
 // GetClaims returns claim ledger entries.
@@ -2603,25 +2612,27 @@ func (db *DB) GetClaims(ctx context.Context, from, to *time.Time, limit int) ([]
 	}
 
 	args := []any{}
-	where := []string{"1=1"}
+	where := []string{"c.merged_into IS NULL"}
 
 	if from != nil {
 		args = append(args, *from)
-		where = append(where, fmt.Sprintf("first_seen_at >= $%d", len(args)))
+		where = append(where, fmt.Sprintf("c.first_seen_at >= $%d", len(args)))
 	}
 
 	if to != nil {
 		args = append(args, *to)
-		where = append(where, fmt.Sprintf("first_seen_at <= $%d", len(args)))
+		where = append(where, fmt.Sprintf("c.first_seen_at <= $%d", len(args)))
 	}
 
 	rows, err := db.Pool.Query(ctx, fmt.Sprintf(`
-		SELECT id, claim_text, first_seen_at, origin_cluster_id, cluster_ids, contradicted_by
-		FROM claims
+		SELECT c.id, c.claim_text, c.canonical_text, c.aliases, c.first_seen_at, c.origin_cluster_id, c.cluster_ids, c.contradicted_by,
+		       COALESCE(s.state, '%s')
+		FROM claims c
+		LEFT JOIN claim_states s ON s.claim_text = c.claim_text
 		WHERE %s
-		ORDER BY first_seen_at DESC
+		ORDER BY c.first_seen_at DESC
 		LIMIT %d
-	`, strings.Join(where, sqlAndJoin), limit), args...)
+	`, domain.ClaimUnverified, strings.Join(where, sqlAndJoin), limit), args...)
 	if err != nil {
 		return nil, fmt.Errorf("get claims: %w", err)
 	}
@@ -2633,18 +2644,24 @@ func (db *DB) GetClaims(ctx context.Context, from, to *time.Time, limit int) ([]
 		var (
 			id           pgtype.UUID
 			text         pgtype.Text
+			canonical    pgtype.Text
+			aliases      []string
 			first        pgtype.Timestamptz
 			origin       pgtype.UUID
 			clusterIDs   pgtype.Array[pgtype.UUID]
 			contradicted pgtype.Array[pgtype.UUID]
+			state        string
 		)
-		if err := rows.Scan(&id, &text, &first, &origin, &clusterIDs, &contradicted); err != nil {
+		if err := rows.Scan(&id, &text, &canonical, &aliases, &first, &origin, &clusterIDs, &contradicted, &state); err != nil {
 			return nil, fmt.Errorf("scan claims: %w", err)
 		}
 
 		entry := ResearchClaimEntry{
-			ID:        fromUUID(id),
-			ClaimText: text.String,
+			ID:            fromUUID(id),
+			ClaimText:     text.String,
+			CanonicalText: canonical.String,
+			Aliases:       aliases,
+			State:         state,
 		}
 		if first.Valid {
 			entry.FirstSeenAt = first.Time
@@ -3078,12 +3095,13 @@ func (db *DB) CreateResearchSession(ctx context.Context, token string, userID in
 	return nil
 }
 
-// InsertResearchAuditLog stores a lightweight audit log entry.
-func (db *DB) InsertResearchAuditLog(ctx context.Context, userID int64, route string, status int, ip, queryHash string) error {
+// InsertResearchAuditLog stores a lightweight audit log entry. apiKeyID is
+// zero for requests authenticated with a session.
+func (db *DB) InsertResearchAuditLog(ctx context.Context, userID, apiKeyID int64, route string, status int, ip, queryHash string) error {
 	_, err := db.Pool.Exec(ctx, `
-		INSERT INTO research_audit_log (user_id, route, status_code, ip_address, query_hash)
-		VALUES ($1, $2, $3, $4, $5)
-	`, userID, route, status, ip, queryHash)
+		INSERT INTO research_audit_log (user_id, api_key_id, route, status_code, ip_address, query_hash)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6)
+	`, userID, apiKeyID, route, status, ip, queryHash)
 	if err != nil {
 		return fmt.Errorf("insert research audit log: %w", err)
 	}
@@ -3187,17 +3205,30 @@ func (db *DB) CleanupResearchRetention(ctx context.Context) (ResearchRetentionCo
 	return counts, nil
 }
 
+// ResearchMaterializedViews are the materialized views behind the research UI.
+var ResearchMaterializedViews = []string{
+	"mv_topic_timeline",
+	"mv_channel_overlap",
+	"mv_cluster_stats",
+}
+
+// ResearchDerivedTables are the research tables rebuilt from clusters, items
+// and evidence, in rebuild order.
+var ResearchDerivedTables = []string{
+	"cluster_first_appearance",
+	"cluster_topic_history",
+	"evidence_claims",
+	"claim_merges",
+	"claim_states",
+	"channel_coordination",
+	"cluster_language_links",
+}
+
 // RefreshResearchMaterializedViews refreshes research materialized views and derived caches.
 // Operations are staggered with delays to reduce database contention and avoid timeout cascades.
 func (db *DB) RefreshResearchMaterializedViews(ctx context.Context) error {
 	const viewRefreshDelay = 2 * time.Second // Delay between view refreshes to reduce contention
 
-	views := []string{
-		"mv_topic_timeline",
-		"mv_channel_overlap",
-		"mv_cluster_stats",
-	}
-
 	if err := db.rebuildResearchDerivedTables(ctx); err != nil {
 		// Log the error but continue to refresh views if possible,
 		// as views don't depend on the derived tables.
@@ -3209,7 +3240,7 @@ func (db *DB) RefreshResearchMaterializedViews(ctx context.Context) error {
 		return fmt.Errorf("context canceled before view refresh: %w", err)
 	}
 
-	for i, view := range views {
+	for i, view := range ResearchMaterializedViews {
 		// Add delay between operations to reduce database contention
 		if i > 0 {
 			select {
@@ -3219,42 +3250,66 @@ func (db *DB) RefreshResearchMaterializedViews(ctx context.Context) error {
 			}
 		}
 
-		db.Logger.Info().Str(logFieldView, view).Msg("refreshing materialized view")
+		if err := db.RefreshMaterializedView(ctx, view); err != nil {
+			db.Logger.Error().Err(err).Str(logFieldView, view).Msg("failed to refresh materialized view")
+		}
+	}
+
+	return nil
+}
+
+// RefreshMaterializedView refreshes the view concurrently, falling back to a
+// blocking refresh when that fails (e.g. when the view was never populated).
+func (db *DB) RefreshMaterializedView(ctx context.Context, view string) error {
+	db.Logger.Info().Str(logFieldView, view).Msg("refreshing materialized view")
 
-		if _, err := db.Pool.Exec(ctx, fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", view)); err != nil {
-			db.Logger.Warn().Err(err).Str(logFieldView, view).Msg("failed to refresh materialized view concurrently (trying non-concurrently)")
-			// Fallback to non-concurrent refresh if concurrent fails (e.g. if it was never populated)
-			if _, err := db.Pool.Exec(ctx, fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", view)); err != nil {
-				db.Logger.Error().Err(err).Str(logFieldView, view).Msg("failed to refresh materialized view")
-			}
+	name := pgx.Identifier{view}.Sanitize()
+
+	if err := db.execWithoutStatementTimeout(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+name); err != nil {
+		db.Logger.Warn().Err(err).Str(logFieldView, view).Msg("failed to refresh materialized view concurrently (trying non-concurrently)")
+
+		if err := db.execWithoutStatementTimeout(ctx, "REFRESH MATERIALIZED VIEW "+name); err != nil {
+			return fmt.Errorf("refresh materialized view %s: %w", view, err)
 		}
 	}
 
 	return nil
 }
 
-func (db *DB) rebuildResearchDerivedTables(ctx context.Context) error {
-	type rebuildFunc func(context.Context, pgx.Tx) error
+// researchDerivedTableRebuilds maps derived tables to their rebuild functions.
+func (db *DB) researchDerivedTableRebuilds() map[string]func(context.Context, pgx.Tx) error {
+	return map[string]func(context.Context, pgx.Tx) error{
+		"cluster_first_appearance": db.withRebuildState("cluster_first_appearance", db.rebuildClusterFirstAppearance),
+		"cluster_topic_history":    db.withRebuildState("cluster_topic_history", db.rebuildClusterTopicHistory),
+		"evidence_claims":          db.rebuildEvidenceClaims,
+		"claim_merges":             db.rebuildClaimMerges,
+		"claim_states":             db.rebuildClaimStates,
+		"channel_coordination":     db.rebuildChannelCoordination,
+		"cluster_language_links":   db.withRebuildState("cluster_language_links", db.rebuildClusterLanguageLinks),
+	}
+}
 
-	rebuilds := []struct {
-		name string
-		fn   rebuildFunc
-	}{
-		{"cluster_first_appearance", db.rebuildClusterFirstAppearance},
-		{"cluster_topic_history", db.rebuildClusterTopicHistory},
-		{"evidence_claims", db.rebuildEvidenceClaims},
-		{"cluster_language_links", db.rebuildClusterLanguageLinks},
+// RebuildResearchDerivedTable rebuilds one of ResearchDerivedTables in its
+// own transaction.
+func (db *DB) RebuildResearchDerivedTable(ctx context.Context, name string) error {
+	fn, ok := db.researchDerivedTableRebuilds()[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownDerivedTable, name)
 	}
 
+	return db.rebuildSingleDerivedTable(ctx, name, fn)
+}
+
+func (db *DB) rebuildResearchDerivedTables(ctx context.Context) error {
 	var firstErr error
 
-	for _, r := range rebuilds {
+	for _, name := range ResearchDerivedTables {
 		if err := ctx.Err(); err != nil {
-			return fmt.Errorf("context canceled before %s: %w", r.name, err)
+			return fmt.Errorf("context canceled before %s: %w", name, err)
 		}
 
-		if err := db.rebuildSingleDerivedTable(ctx, r.name, r.fn); err != nil {
-			db.Logger.Error().Err(err).Str(logFieldTable, r.name).Msg("derived table rebuild failed")
+		if err := db.RebuildResearchDerivedTable(ctx, name); err != nil {
+			db.Logger.Error().Err(err).Str(logFieldTable, name).Msg("derived table rebuild failed")
 
 			if firstErr == nil {
 				firstErr = err
@@ -3265,7 +3320,11 @@ func (db *DB) rebuildResearchDerivedTables(ctx context.Context) error {
 	return firstErr
 }
 
+// rebuildSingleDerivedTable runs a rebuild in its own transaction without the
+// statement and query timeouts; only the caller's context bounds it.
 func (db *DB) rebuildSingleDerivedTable(ctx context.Context, name string, fn func(context.Context, pgx.Tx) error) error {
+	ctx = WithoutQueryTimeout(ctx)
+
 	tx, err := db.Pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction for %s: %w", name, err)
@@ -3275,6 +3334,10 @@ func (db *DB) rebuildSingleDerivedTable(ctx context.Context, name string, fn fun
 		_ = tx.Rollback(ctx) //nolint:errcheck
 	}()
 
+	if _, err := tx.Exec(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+		return fmt.Errorf("lift statement timeout for %s: %w", name, err)
+	}
+
 	if err := fn(ctx, tx); err != nil {
 		return err
 	}
@@ -3288,11 +3351,9 @@ func (db *DB) rebuildSingleDerivedTable(ctx context.Context, name string, fn fun
 	return nil
 }
 
-func (db *DB) rebuildClusterFirstAppearance(ctx context.Context, tx pgx.Tx) error {
-	db.Logger.Info().Msg("rebuilding cluster_first_appearance")
-
-	if _, err := tx.Exec(ctx, "TRUNCATE cluster_first_appearance"); err != nil {
-		return fmt.Errorf("truncate cluster_first_appearance: %w", err)
+func (db *DB) rebuildClusterFirstAppearance(ctx context.Context, tx pgx.Tx, since time.Time) error {
+	if err := clearChangedClusterRows(ctx, tx, "cluster_first_appearance", since); err != nil {
+		return err
 	}
 
 	if _, err := tx.Exec(ctx, `
@@ -3307,22 +3368,21 @@ func (db *DB) rebuildClusterFirstAppearance(ctx context.Context, tx pgx.Tx) erro
 			JOIN clusters c ON ci.cluster_id = c.id AND c.source = $1
 			JOIN items i ON ci.item_id = i.id
 			JOIN raw_messages rm ON i.raw_message_id = rm.id
+			WHERE $2::timestamptz IS NULL OR c.created_at >= $2
 		)
 		SELECT cluster_id, channel_id, item_id, tg_date
 		FROM ranked
 		WHERE rn = 1
-	`, ClusterSourceResearch); err != nil {
+	`, ClusterSourceResearch, toTimestamptz(since)); err != nil {
 		return fmt.Errorf("populate cluster_first_appearance: %w", err)
 	}
 
 	return nil
 }
 
-func (db *DB) rebuildClusterTopicHistory(ctx context.Context, tx pgx.Tx) error {
-	db.Logger.Info().Msg("rebuilding cluster_topic_history")
-
-	if _, err := tx.Exec(ctx, "TRUNCATE cluster_topic_history"); err != nil {
-		return fmt.Errorf("truncate cluster_topic_history: %w", err)
+func (db *DB) rebuildClusterTopicHistory(ctx context.Context, tx pgx.Tx, since time.Time) error {
+	if err := clearChangedClusterRows(ctx, tx, "cluster_topic_history", since); err != nil {
+		return err
 	}
 
 	if _, err := tx.Exec(ctx, `
@@ -3336,8 +3396,9 @@ func (db *DB) rebuildClusterTopicHistory(ctx context.Context, tx pgx.Tx) error {
 		JOIN items i ON ci.item_id = i.id
 		JOIN raw_messages rm ON i.raw_message_id = rm.id
 		WHERE i.topic IS NOT NULL AND i.topic <> ''
+		  AND ($2::timestamptz IS NULL OR c.created_at >= $2)
 		GROUP BY ci.cluster_id, i.topic, date_trunc('week', rm.tg_date)
-	`, ClusterSourceResearch); err != nil {
+	`, ClusterSourceResearch, toTimestamptz(since)); err != nil {
 		return fmt.Errorf("populate cluster_topic_history: %w", err)
 	}
 
@@ -3347,6 +3408,10 @@ func (db *DB) rebuildClusterTopicHistory(ctx context.Context, tx pgx.Tx) error {
 func (db *DB) rebuildEvidenceClaims(ctx context.Context, tx pgx.Tx) error {
 	db.Logger.Info().Msg("rebuilding claims (evidence-based)")
 
+	// Evidence claims are recreated on every rebuild and carry no embedding,
+	// so claim merging never picks them: a merge into a claim that the next
+	// rebuild deletes would be undone and logged again each time.
+
 	if _, err := tx.Exec(ctx, "DELETE FROM claims WHERE normalized_hash IS NULL"); err != nil {
 		return fmt.Errorf("delete old evidence claims: %w", err)
 	}
@@ -3390,11 +3455,12 @@ func (db *DB) rebuildEvidenceClaims(ctx context.Context, tx pgx.Tx) error {
 	return nil
 }
 
-func (db *DB) rebuildClusterLanguageLinks(ctx context.Context, tx pgx.Tx) error {
-	db.Logger.Info().Msg("rebuilding cluster_language_links")
-
-	if _, err := tx.Exec(ctx, "TRUNCATE cluster_language_links"); err != nil {
-		return fmt.Errorf("truncate cluster_language_links: %w", err)
+// rebuildClusterLanguageLinks links clusters created since the watermark to
+// their cross-language neighbors. All recent clusters remain candidates, so
+// new clusters link to old ones and the reverse links are added too.
+func (db *DB) rebuildClusterLanguageLinks(ctx context.Context, tx pgx.Tx, since time.Time) error {
+	if err := clearChangedLanguageLinks(ctx, tx, since); err != nil {
+		return err
 	}
 
 	// Materialize representative clusters into a temp table so we can build
@@ -3403,6 +3469,7 @@ func (db *DB) rebuildClusterLanguageLinks(ctx context.Context, tx pgx.Tx) error
 		CREATE TEMP TABLE _lang_link_rep ON COMMIT DROP AS
 		WITH ranked AS (
 			SELECT ci.cluster_id,
+			       c.created_at,
 			       i.language,
 			       e.embedding,
 			       rm.tg_date,
@@ -3418,20 +3485,22 @@ func (db *DB) rebuildClusterLanguageLinks(ctx context.Context, tx pgx.Tx) error
 			WHERE i.language IS NOT NULL AND i.language <> ''
 			  AND rm.tg_date >= NOW() - INTERVAL '%d days'
 		)
-		SELECT cluster_id, language, embedding, tg_date
+		SELECT cluster_id, created_at, language, embedding, tg_date
 		FROM ranked WHERE rn = 1
 	`, ClusterSourceResearch, langLinkLookbackDays)); err != nil {
 		return fmt.Errorf("create rep temp table: %w", err)
 	}
 
-	var repCount int
-	if err := tx.QueryRow(ctx, "SELECT count(*) FROM _lang_link_rep").Scan(&repCount); err != nil {
+	var repCount, changedCount int
+	if err := tx.QueryRow(ctx, `
+		SELECT count(*), count(*) FILTER (WHERE $1::timestamptz IS NULL OR created_at >= $1) FROM _lang_link_rep
+	`, toTimestamptz(since)).Scan(&repCount, &changedCount); err != nil {
 		return fmt.Errorf("count rep clusters: %w", err)
 	}
 
-	db.Logger.Info().Int("rep_clusters", repCount).Msg("language link candidates")
+	db.Logger.Info().Int("rep_clusters", repCount).Int("changed_clusters", changedCount).Msg("language link candidates")
 
-	if repCount < 2 {
+	if repCount < 2 || changedCount == 0 {
 		return nil
 	}
 
@@ -3474,10 +3543,29 @@ func (db *DB) rebuildClusterLanguageLinks(ctx context.Context, tx pgx.Tx) error
 			LIMIT %d
 		) nn
 		WHERE nn.similarity >= %0.2f
-	`, langLinkMaxLagSeconds, langLinkMaxNeighbors, langLinkMinSimilarity)); err != nil {
+		  AND ($1::timestamptz IS NULL OR a.created_at >= $1)
+	`, langLinkMaxLagSeconds, langLinkMaxNeighbors, langLinkMinSimilarity), toTimestamptz(since)); err != nil {
 		return fmt.Errorf("populate cluster_language_links: %w", err)
 	}
 
+	if since.IsZero() {
+		return nil
+	}
+
+	// Unchanged clusters do not search for neighbors in an incremental run,
+	// so mirror the new links to them. The next full rebuild trims them back
+	// to their top neighbors.
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO cluster_language_links (cluster_id, language, linked_cluster_id, confidence)
+		SELECT l.linked_cluster_id, a.language, l.cluster_id, l.confidence
+		FROM cluster_language_links l
+		JOIN _lang_link_rep a ON a.cluster_id = l.cluster_id
+		WHERE a.created_at >= $1
+		ON CONFLICT DO NOTHING
+	`, since); err != nil {
+		return fmt.Errorf("mirror cluster_language_links: %w", err)
+	}
+
 	return nil
 }
 
@@ -3641,6 +3729,8 @@ type SimilarClaim struct {
 
 // InsertHeuristicClaims inserts claims extracted using heuristic methods.
 // It deduplicates by normalized_hash and merges cluster_ids for existing claims.
+// A claim whose existing row was merged into another claim updates that
+// canonical claim instead.
 func (db *DB) InsertHeuristicClaims(ctx context.Context, claims []HeuristicClaimInput) (int64, error) {
 	if len(claims) == 0 {
 		return 0, nil
@@ -3654,10 +3744,18 @@ func (db *DB) InsertHeuristicClaims(ctx context.Context, claims []HeuristicClaim
 			clusterIDs[i] = toUUID(id)
 		}
 
-		var (
-			result pgconn.CommandTag
-			err    error
-		)
+		folded, err := db.addToCanonicalClaim(ctx, claim.NormalizedHash, claim.FirstSeenAt, clusterIDs)
+		if err != nil {
+			return inserted, err
+		}
+
+		if folded {
+			inserted++
+
+			continue
+		}
+
+		var result pgconn.CommandTag
 
 		if len(claim.Embedding) > 0 {
 			result, err = db.Pool.Exec(ctx, `
@@ -3733,6 +3831,7 @@ func (db *DB) FindSimilarClaimsByEmbedding(ctx context.Context, embedding []floa
 		SELECT id, claim_text, 1.0 - (embedding <=> $1::vector) as similarity
 		FROM claims
 		WHERE embedding IS NOT NULL
+		  AND merged_into IS NULL
 		  AND (embedding <=> $1::vector) < $2
 		ORDER BY embedding <=> $1::vector
 		LIMIT $3
@@ -0,0 +1,211 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Research API key scopes.
+const (
+	// ResearchAPIKeyScopeRead allows read-only (GET) research requests.
+	ResearchAPIKeyScopeRead = "read"
+	// ResearchAPIKeyScopeLabel also allows annotation and labeling writes.
+	ResearchAPIKeyScopeLabel = "label"
+)
+
+// ErrResearchAPIKeyNotFound is returned when an API key does not exist.
+var ErrResearchAPIKeyNotFound = errors.New("research API key not found")
+
+// ResearchAPIKey is a named key for programmatic research API access.
+type ResearchAPIKey struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	Scope         string     `json:"scope"`
+	RatePerMinute int        `json:"rate_per_minute"`
+	CreatedBy     int64      `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty"`
+}
+
+// ResearchAPIKeyUsage aggregates the audit log entries of one API key.
+type ResearchAPIKeyUsage struct {
+	KeyID       int64      `json:"key_id"`
+	Name        string     `json:"name"`
+	Scope       string     `json:"scope"`
+	Requests    int        `json:"requests"`
+	Errors      int        `json:"errors"`
+	RateLimited int        `json:"rate_limited"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+}
+
+const researchAPIKeyColumns = `id, name, scope, rate_per_minute, created_by, created_at, last_used_at`
+
+func scanResearchAPIKey(row pgx.Row) (*ResearchAPIKey, error) {
+	var (
+		key      ResearchAPIKey
+		rate     int32
+		lastUsed pgtype.Timestamptz
+	)
+
+	if err := row.Scan(&key.ID, &key.Name, &key.Scope, &rate, &key.CreatedBy, &key.CreatedAt, &lastUsed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrResearchAPIKeyNotFound
+		}
+
+		return nil, fmt.Errorf("scan research API key: %w", err)
+	}
+
+	key.RatePerMinute = int(rate)
+
+	if lastUsed.Valid {
+		t := lastUsed.Time
+		key.LastUsedAt = &t
+	}
+
+	return &key, nil
+}
+
+// CreateResearchAPIKey stores a key by the hash of its secret and returns its ID.
+func (db *DB) CreateResearchAPIKey(ctx context.Context, name, keyHash, scope string, ratePerMinute int, createdBy int64) (int64, error) {
+	var id int64
+
+	if err := db.Pool.QueryRow(ctx, `
+		INSERT INTO research_api_keys (name, key_hash, scope, rate_per_minute, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, SanitizeUTF8(name), keyHash, scope, safeIntToInt32(ratePerMinute), createdBy).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create research API key: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListResearchAPIKeys returns all API keys, oldest first.
+func (db *DB) ListResearchAPIKeys(ctx context.Context) ([]ResearchAPIKey, error) {
+	rows, err := db.Pool.Query(ctx, `SELECT `+researchAPIKeyColumns+` FROM research_api_keys ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("list research API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []ResearchAPIKey
+
+	for rows.Next() {
+		key, err := scanResearchAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate research API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// DeleteResearchAPIKey revokes a key by name. Its audit log entries are kept.
+func (db *DB) DeleteResearchAPIKey(ctx context.Context, name string) error {
+	tag, err := db.Pool.Exec(ctx, `DELETE FROM research_api_keys WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("delete research API key: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrResearchAPIKeyNotFound, name)
+	}
+
+	return nil
+}
+
+// SetResearchAPIKeyRate changes the per-minute rate limit of a key by name.
+func (db *DB) SetResearchAPIKeyRate(ctx context.Context, name string, ratePerMinute int) error {
+	tag, err := db.Pool.Exec(ctx, `UPDATE research_api_keys SET rate_per_minute = $2 WHERE name = $1`, name, safeIntToInt32(ratePerMinute))
+	if err != nil {
+		return fmt.Errorf("set research API key rate: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrResearchAPIKeyNotFound, name)
+	}
+
+	return nil
+}
+
+// UseResearchAPIKey looks up a key by the hash of its secret and records that
+// it was used. last_used_at is only written when it is more than a minute
+// old, so busy keys do not cost a write per request. It returns
+// ErrResearchAPIKeyNotFound for unknown keys.
+func (db *DB) UseResearchAPIKey(ctx context.Context, keyHash string) (*ResearchAPIKey, error) {
+	return scanResearchAPIKey(db.Pool.QueryRow(ctx, `
+		WITH k AS (
+			SELECT `+researchAPIKeyColumns+`
+			FROM research_api_keys
+			WHERE key_hash = $1
+		), touched AS (
+			UPDATE research_api_keys r SET last_used_at = now()
+			FROM k
+			WHERE r.id = k.id
+			  AND (k.last_used_at IS NULL OR k.last_used_at < now() - interval '1 minute')
+		)
+		SELECT `+researchAPIKeyColumns+` FROM k`, keyHash))
+}
+
+// GetResearchAPIKeyUsage aggregates audit log entries per API key since from.
+// A non-zero keyID limits the result to that key.
+func (db *DB) GetResearchAPIKeyUsage(ctx context.Context, from time.Time, keyID int64) ([]ResearchAPIKeyUsage, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT k.id, k.name, k.scope,
+		       COUNT(l.id),
+		       COUNT(l.id) FILTER (WHERE l.status_code >= 400),
+		       COUNT(l.id) FILTER (WHERE l.status_code = 429),
+		       k.last_used_at
+		FROM research_api_keys k
+		LEFT JOIN research_audit_log l ON l.api_key_id = k.id AND l.created_at >= $1
+		WHERE $2 = 0 OR k.id = $2
+		GROUP BY k.id, k.name, k.scope, k.last_used_at
+		ORDER BY COUNT(l.id) DESC, k.id
+	`, toTimestamptz(from), keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get research API key usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := []ResearchAPIKeyUsage{}
+
+	for rows.Next() {
+		var (
+			u                           ResearchAPIKeyUsage
+			requests, errs, rateLimited int64
+			lastUsed                    pgtype.Timestamptz
+		)
+
+		if err := rows.Scan(&u.KeyID, &u.Name, &u.Scope, &requests, &errs, &rateLimited, &lastUsed); err != nil {
+			return nil, fmt.Errorf("scan research API key usage: %w", err)
+		}
+
+		u.Requests = int(requests)
+		u.Errors = int(errs)
+		u.RateLimited = int(rateLimited)
+
+		if lastUsed.Valid {
+			t := lastUsed.Time
+			u.LastUsedAt = &t
+		}
+
+		usage = append(usage, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate research API key usage: %w", err)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidResearchCursor is returned for cursors that do not decode.
+	ErrInvalidResearchCursor = errors.New("invalid research search cursor")
+
+	errEmptyQueryPlan = errors.New("query plan has no row estimate")
+)
+
+// ResearchSearchCursor is a keyset position in research search results.
+// Item searches resume after (Score, TGDate, ID) and keep the SearchAt of
+// the first page so scores stay comparable; evidence searches resume after ID.
+type ResearchSearchCursor struct {
+	Score    float64   `json:"score,omitempty"`
+	TGDate   time.Time `json:"tg_date,omitzero"`
+	ID       string    `json:"id"`
+	SearchAt time.Time `json:"search_at,omitzero"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c ResearchSearchCursor) Encode() string {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// ParseResearchSearchCursor decodes a cursor produced by Encode.
+func ParseResearchSearchCursor(value string) (*ResearchSearchCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidResearchCursor, value)
+	}
+
+	var c ResearchSearchCursor
+	if err := json.Unmarshal(payload, &c); err != nil || !toUUID(c.ID).Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidResearchCursor, value)
+	}
+
+	return &c, nil
+}
+
+// scoreTime is the time item scores decay from: the first page's SearchAt
+// when paging with a cursor.
+func (p ResearchSearchParams) scoreTime() time.Time {
+	if p.ItemCursor != nil && !p.ItemCursor.SearchAt.IsZero() {
+		return p.ItemCursor.SearchAt
+	}
+
+	return p.SearchAt
+}
+
+// NextResearchItemCursor returns the cursor of the page after results, or
+// nil when results is not a full page.
+func NextResearchItemCursor(params ResearchSearchParams, results []ResearchItemSearchResult) *ResearchSearchCursor {
+	if len(results) == 0 || len(results) < normalizeSearchLimit(params.Limit) {
+		return nil
+	}
+
+	last := results[len(results)-1]
+
+	return &ResearchSearchCursor{Score: last.Score, TGDate: last.TGDate, ID: last.ID, SearchAt: params.scoreTime()}
+}
+
+// NextResearchEvidenceCursor returns the cursor of the page after results,
+// or nil when results is not a full page.
+func NextResearchEvidenceCursor(params ResearchSearchParams, results []ResearchEvidenceSearchResult) *ResearchSearchCursor {
+	if len(results) == 0 || len(results) < normalizeSearchLimit(params.Limit) {
+		return nil
+	}
+
+	return &ResearchSearchCursor{ID: results[len(results)-1].EvidenceID}
+}
+
+// applyItemSearchCursor restricts an item search to rows after the cursor in
+// (score, tg_date, id) descending order.
+func applyItemSearchCursor(cursor *ResearchSearchCursor, scoreExpr string, where []string, args []any) ([]string, []any) {
+	if cursor == nil {
+		return where, args
+	}
+
+	args = append(args, cursor.Score, cursor.TGDate, toUUID(cursor.ID))
+	n := len(args)
+	where = append(where, fmt.Sprintf("(%s, rm.tg_date, i.id) < ($%d::float8, $%d::timestamptz, $%d::uuid)", scoreExpr, n-2, n-1, n))
+
+	return where, args
+}
+
+// applyEvidenceSearchCursor restricts an evidence search to sources after
+// the cursor in id order.
+func applyEvidenceSearchCursor(cursor *ResearchSearchCursor, where []string, args []any) ([]string, []any) {
+	if cursor == nil {
+		return where, args
+	}
+
+	args = append(args, toUUID(cursor.ID))
+	where = append(where, fmt.Sprintf("es.id > $%d::uuid", len(args)))
+
+	return where, args
+}
+
+// searchOffset ignores the offset when paging with a cursor.
+func searchOffset(offset int, cursor *ResearchSearchCursor) int {
+	if cursor != nil {
+		return 0
+	}
+
+	return offset
+}
+
+// estimateCountQuery returns the planner's row estimate for the query
+// instead of counting: cheap on large filters, but only as good as the
+// table statistics.
+func (db *DB) estimateCountQuery(ctx context.Context, queryTemplate string, where []string, args []any, errContext string) (int, error) {
+	var plan []byte
+
+	if err := db.Pool.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+fmt.Sprintf(queryTemplate, strings.Join(where, sqlAndJoin)), args...).Scan(&plan); err != nil {
+		return 0, fmt.Errorf("%s: %w", errContext, err)
+	}
+
+	rows, err := planRows(plan)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", errContext, err)
+	}
+
+	return rows, nil
+}
+
+// planRows reads the top-level "Plan Rows" estimate of an EXPLAIN (FORMAT JSON)
+// result.
+func planRows(plan []byte) (int, error) {
+	var explain []map[string]map[string]any
+	if err := json.Unmarshal(plan, &explain); err != nil {
+		return 0, fmt.Errorf("decode plan: %w", err)
+	}
+
+	if len(explain) == 0 {
+		return 0, errEmptyQueryPlan
+	}
+
+	rows, ok := explain[0]["Plan"]["Plan Rows"].(float64)
+	if !ok {
+		return 0, errEmptyQueryPlan
+	}
+
+	return int(rows), nil
+}
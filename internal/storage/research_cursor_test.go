@@ -0,0 +1,84 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestResearchSearchCursorRoundTrip(t *testing.T) {
+	want := ResearchSearchCursor{
+		Score:    0.1 + 0.2,
+		TGDate:   time.Date(2026, 3, 1, 10, 30, 0, 123456000, time.UTC),
+		ID:       uuid.NewString(),
+		SearchAt: time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC),
+	}
+
+	got, err := ParseResearchSearchCursor(want.Encode())
+	if err != nil {
+		t.Fatalf("ParseResearchSearchCursor() error = %v", err)
+	}
+
+	if got.Score != want.Score || !got.TGDate.Equal(want.TGDate) || got.ID != want.ID || !got.SearchAt.Equal(want.SearchAt) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+
+	for _, value := range []string{"", "not base64!", ResearchSearchCursor{ID: "not-a-uuid"}.Encode()} {
+		if _, err := ParseResearchSearchCursor(value); !errors.Is(err, ErrInvalidResearchCursor) {
+			t.Errorf("ParseResearchSearchCursor(%q) error = %v, want %v", value, err, ErrInvalidResearchCursor)
+		}
+	}
+}
+
+func TestNextResearchItemCursor(t *testing.T) {
+	searchAt := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+	first := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	params := ResearchSearchParams{Limit: 2, SearchAt: time.Now(), ItemCursor: &ResearchSearchCursor{SearchAt: searchAt}}
+	results := []ResearchItemSearchResult{{ID: "a", Score: 0.9}, {ID: "b", Score: 0.5, TGDate: first}}
+
+	next := NextResearchItemCursor(params, results)
+	if next == nil || next.ID != "b" || next.Score != 0.5 || !next.TGDate.Equal(first) || !next.SearchAt.Equal(searchAt) {
+		t.Errorf("NextResearchItemCursor() = %+v, want the last result with the first page's search time", next)
+	}
+
+	if next := NextResearchItemCursor(params, results[:1]); next != nil {
+		t.Errorf("NextResearchItemCursor() on a partial page = %+v, want nil", next)
+	}
+}
+
+func TestBuildItemSearchQueryWithCursor(t *testing.T) {
+	params := ResearchSearchParams{
+		Query:      "war",
+		Offset:     100,
+		SearchAt:   time.Now(),
+		ItemCursor: &ResearchSearchCursor{Score: 0.5, TGDate: time.Now(), ID: uuid.NewString()},
+	}
+
+	query, args := buildItemSearchQuery(params, []string{"1=1"}, nil, 10)
+
+	if !strings.Contains(query, "rm.tg_date, i.id) < ($4::float8, $5::timestamptz, $6::uuid)") {
+		t.Errorf("query has no keyset condition:\n%s", query)
+	}
+
+	if !strings.Contains(query, "OFFSET 0") {
+		t.Errorf("query keeps the offset with a cursor:\n%s", query)
+	}
+
+	if len(args) != 6 {
+		t.Errorf("args = %d, want 6", len(args))
+	}
+}
+
+func TestPlanRows(t *testing.T) {
+	rows, err := planRows([]byte(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 1234}}]`))
+	if err != nil || rows != 1234 {
+		t.Errorf("planRows() = %d, %v; want 1234", rows, err)
+	}
+
+	if _, err := planRows([]byte(`[]`)); !errors.Is(err, errEmptyQueryPlan) {
+		t.Errorf("planRows([]) error = %v, want %v", err, errEmptyQueryPlan)
+	}
+}
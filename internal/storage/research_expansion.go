@@ -0,0 +1,58 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/synonyms"
+)
+
+// SetSearchExpander sets the synonym and transliteration expander applied
+// to research search queries. Without one, queries match as typed.
+func (db *DB) SetSearchExpander(expander *synonyms.Expander) {
+	db.searchExpander = expander
+}
+
+// withQueryExpansion returns params with the expansion of its query.
+func (db *DB) withQueryExpansion(params ResearchSearchParams) ResearchSearchParams {
+	if db.searchExpander == nil || params.Query == "" {
+		return params
+	}
+
+	expansion := db.searchExpander.Expand(params.Query)
+	params.expansion = &expansion
+
+	return params
+}
+
+// appendTSQuery appends the full-text query argument and returns its
+// tsquery expression: the expanded query when any word has variants, the
+// query as typed otherwise.
+func (p ResearchSearchParams) appendTSQuery(args []any) ([]any, string) {
+	if p.expansion != nil && p.expansion.TSQuery != "" {
+		args = append(args, p.expansion.TSQuery)
+
+		return args, fmt.Sprintf("to_tsquery('simple', $%d)", len(args))
+	}
+
+	args = append(args, p.Query)
+
+	return args, fmt.Sprintf("plainto_tsquery('simple', $%d)", len(args))
+}
+
+// appendILikePatterns appends the ILIKE patterns of the query and its
+// variants as one text[] argument and returns its index.
+func (p ResearchSearchParams) appendILikePatterns(args []any) ([]any, int) {
+	phrases := []string{p.Query}
+	if p.expansion != nil {
+		phrases = p.expansion.Phrases
+	}
+
+	patterns := make([]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		patterns = append(patterns, "%"+SanitizeUTF8(phrase)+"%")
+	}
+
+	args = append(args, patterns)
+
+	return args, len(args)
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lueurxax/telegram-digest-bot/internal/core/synonyms"
+)
+
+func TestApplyItemTextFilterExpansion(t *testing.T) {
+	database := &DB{}
+	database.SetSearchExpander(synonyms.New(nil, true))
+
+	params := database.withQueryExpansion(ResearchSearchParams{Query: "Kiev"})
+
+	where, args, tsQuery := applyItemTextFilter(params, nil, nil)
+
+	if tsQuery != "to_tsquery('simple', $1)" {
+		t.Errorf("tsQuery = %q, want to_tsquery of the expansion", tsQuery)
+	}
+
+	if len(where) != 1 || !strings.Contains(where[0], "c.title ILIKE ANY($2::text[])") {
+		t.Errorf("where = %v", where)
+	}
+
+	if got, want := args[0], "('kyiv' | 'kiev' | 'киев' | 'київ')"; got != want {
+		t.Errorf("tsquery arg = %v, want %v", got, want)
+	}
+
+	if got, want := args[1], []string{"%Kiev%", "%kyiv%", "%kiev%", "%киев%", "%київ%"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("patterns = %v, want %v", got, want)
+	}
+
+	_, args, tsQuery = applyItemTextFilter(ResearchSearchParams{Query: "Kiev"}, nil, nil)
+	if tsQuery != "plainto_tsquery('simple', $1)" || !reflect.DeepEqual(args[1], []string{"%Kiev%"}) {
+		t.Errorf("without expander: tsQuery = %q, args = %v", tsQuery, args)
+	}
+}
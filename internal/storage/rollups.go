@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RollupStory aggregates the clusters of one story across a roll-up period.
+type RollupStory struct {
+	Topic         string
+	Summary       string
+	ItemCount     int
+	ChannelCount  int
+	MaxImportance float32
+}
+
+// RatedItem is an item with its reader rating counts.
+type RatedItem struct {
+	ItemID          string
+	Summary         string
+	Topic           string
+	ChannelUsername string
+	ChannelPeerID   int64
+	MessageID       int64
+	Good            int
+	Bad             int
+}
+
+// GetRollupStories returns the largest stories clustered in digest windows
+// within [start, end). Clusters from different windows are merged by topic and
+// represented by their most important summary.
+func (db *DB) GetRollupStories(ctx context.Context, start, end time.Time, limit int) ([]RollupStory, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT c.topic,
+		       COALESCE((ARRAY_AGG(i.summary ORDER BY i.importance_score DESC))[1], ''),
+		       COUNT(DISTINCT i.id),
+		       COUNT(DISTINCT rm.channel_id),
+		       MAX(i.importance_score)
+		FROM clusters c
+		JOIN cluster_items ci ON ci.cluster_id = c.id
+		JOIN items i ON i.id = ci.item_id
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		WHERE c.window_start >= $1 AND c.window_end <= $2
+		  AND c.topic IS NOT NULL AND c.topic <> ''
+		GROUP BY c.topic
+		ORDER BY COUNT(DISTINCT i.id) DESC, MAX(i.importance_score) DESC
+		LIMIT $3
+	`, toTimestamptz(start), toTimestamptz(end), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get rollup stories: %w", err)
+	}
+	defer rows.Close()
+
+	var stories []RollupStory
+
+	for rows.Next() {
+		var (
+			story                RollupStory
+			itemCount, chanCount int64
+			maxImportance        pgtype.Float4
+		)
+
+		if err := rows.Scan(&story.Topic, &story.Summary, &itemCount, &chanCount, &maxImportance); err != nil {
+			return nil, fmt.Errorf("scan rollup story: %w", err)
+		}
+
+		story.ItemCount = int(itemCount)
+		story.ChannelCount = int(chanCount)
+		story.MaxImportance = maxImportance.Float32
+		stories = append(stories, story)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rollup stories: %w", err)
+	}
+
+	return stories, nil
+}
+
+// GetTopRatedItems returns items posted within [start, end) with at least one
+// positive rating, ordered by net rating (good minus bad and irrelevant).
+func (db *DB) GetTopRatedItems(ctx context.Context, start, end time.Time, limit int) ([]RatedItem, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT i.id, COALESCE(i.summary, ''), COALESCE(i.topic, ''), COALESCE(ch.username, ''),
+		       ch.tg_peer_id, rm.tg_message_id,
+		       COUNT(*) FILTER (WHERE r.rating = 'good'),
+		       COUNT(*) FILTER (WHERE r.rating IN ('bad', 'irrelevant'))
+		FROM item_ratings r
+		JOIN items i ON i.id = r.item_id
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		JOIN channels ch ON ch.id = rm.channel_id
+		WHERE rm.tg_date >= $1 AND rm.tg_date < $2
+		GROUP BY i.id, i.summary, i.topic, ch.username, ch.tg_peer_id, rm.tg_message_id
+		HAVING COUNT(*) FILTER (WHERE r.rating = 'good') > 0
+		ORDER BY COUNT(*) FILTER (WHERE r.rating = 'good')
+		         - COUNT(*) FILTER (WHERE r.rating IN ('bad', 'irrelevant')) DESC,
+		         COUNT(*) FILTER (WHERE r.rating = 'good') DESC
+		LIMIT $3
+	`, toTimestamptz(start), toTimestamptz(end), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get top rated items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []RatedItem
+
+	for rows.Next() {
+		var (
+			itemID    pgtype.UUID
+			good, bad int64
+			item      RatedItem
+		)
+
+		if err := rows.Scan(&itemID, &item.Summary, &item.Topic, &item.ChannelUsername, &item.ChannelPeerID, &item.MessageID, &good, &bad); err != nil {
+			return nil, fmt.Errorf("scan top rated item: %w", err)
+		}
+
+		item.ItemID = fromUUID(itemID)
+		item.Good = int(good)
+		item.Bad = int(bad)
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate top rated items: %w", err)
+	}
+
+	return items, nil
+}
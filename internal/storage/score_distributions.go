@@ -0,0 +1,224 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ScoreQuantileGrid lists the probabilities at which score distributions are sampled.
+var ScoreQuantileGrid = []float64{0, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 1}
+
+const (
+	// scoreDistributionSampleLimit is how many of a version's most recent
+	// scores its quantiles are computed from.
+	scoreDistributionSampleLimit = 2000
+
+	// MinScoreDistributionSamples is the number of scores a version needs before
+	// its distribution is used for normalization or drift checks.
+	MinScoreDistributionSamples = 100
+
+	// defaultScoringModel names the model when no summarize model is configured.
+	defaultScoringModel = "default"
+)
+
+// ScoreDistribution summarizes the raw LLM scores produced by one scoring
+// version (summarize model and prompt version).
+type ScoreDistribution struct {
+	Version   string
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+	// Relevance and Importance are the score quantiles at ScoreQuantileGrid.
+	Relevance  []float64
+	Importance []float64
+}
+
+// ScoringVersion identifies the model and prompt version that scored an item.
+func ScoringVersion(model, promptVersion string) string {
+	if model == "" {
+		model = defaultScoringModel
+	}
+
+	return model + "@" + promptVersion
+}
+
+// SaveItemRawScores records the scores an item got from the LLM before any
+// normalization, with the scoring version that produced them.
+func (db *DB) SaveItemRawScores(ctx context.Context, itemID, version string, relevance, importance float32) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO item_raw_scores (item_id, scoring_version, relevance_score, importance_score)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (item_id) DO UPDATE
+		SET scoring_version = EXCLUDED.scoring_version,
+		    relevance_score = EXCLUDED.relevance_score,
+		    importance_score = EXCLUDED.importance_score,
+		    created_at = now()
+	`, toUUID(itemID), SanitizeUTF8(version), relevance, importance); err != nil {
+		return fmt.Errorf("save item raw scores: %w", err)
+	}
+
+	return nil
+}
+
+// GetScoreDistributions returns the raw score distribution of each scoring
+// version, oldest version first. A nil versions slice returns all versions.
+func (db *DB) GetScoreDistributions(ctx context.Context, versions []string) ([]ScoreDistribution, error) {
+	rows, err := db.Pool.Query(ctx, `
+		WITH ranked AS (
+			SELECT scoring_version, relevance_score, importance_score, created_at,
+			       row_number() OVER (PARTITION BY scoring_version ORDER BY created_at DESC) AS rn,
+			       count(*) OVER (PARTITION BY scoring_version) AS total,
+			       min(created_at) OVER (PARTITION BY scoring_version) AS first_seen
+			FROM item_raw_scores
+			WHERE $3::text[] IS NULL OR scoring_version = ANY($3)
+		)
+		SELECT scoring_version, max(total), min(first_seen), max(created_at),
+		       percentile_cont($2::float8[]) WITHIN GROUP (ORDER BY relevance_score::float8),
+		       percentile_cont($2::float8[]) WITHIN GROUP (ORDER BY importance_score::float8)
+		FROM ranked
+		WHERE rn <= $1
+		GROUP BY scoring_version
+		ORDER BY min(first_seen), scoring_version
+	`, scoreDistributionSampleLimit, ScoreQuantileGrid, versions)
+	if err != nil {
+		return nil, fmt.Errorf("get score distributions: %w", err)
+	}
+	defer rows.Close()
+
+	var dists []ScoreDistribution
+
+	for rows.Next() {
+		var (
+			d     ScoreDistribution
+			total int64
+		)
+
+		if err := rows.Scan(&d.Version, &total, &d.FirstSeen, &d.LastSeen, &d.Relevance, &d.Importance); err != nil {
+			return nil, fmt.Errorf("scan score distribution: %w", err)
+		}
+
+		d.Count = int(total)
+		dists = append(dists, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate score distributions: %w", err)
+	}
+
+	return dists, nil
+}
+
+// FindScoreDistribution returns the distribution of version.
+func FindScoreDistribution(dists []ScoreDistribution, version string) (ScoreDistribution, bool) {
+	for _, d := range dists {
+		if d.Version == version {
+			return d, true
+		}
+	}
+
+	return ScoreDistribution{}, false
+}
+
+// ReferenceScoreDistribution returns the distribution other versions are
+// compared with: the configured reference version, or the oldest version
+// when none is configured.
+func ReferenceScoreDistribution(dists []ScoreDistribution, reference string) (ScoreDistribution, bool) {
+	if reference != "" {
+		return FindScoreDistribution(dists, reference)
+	}
+
+	if len(dists) == 0 {
+		return ScoreDistribution{}, false
+	}
+
+	return dists[0], true
+}
+
+// HasEnoughSamples reports whether the distribution is reliable enough for
+// normalization and drift checks.
+func (d ScoreDistribution) HasEnoughSamples() bool {
+	return d.Count >= MinScoreDistributionSamples &&
+		len(d.Relevance) == len(ScoreQuantileGrid) && len(d.Importance) == len(ScoreQuantileGrid)
+}
+
+// MapScoreQuantile maps a score to the same percentile of another
+// distribution: the score's percentile in from becomes the score at that
+// percentile in to. Both are quantiles at ScoreQuantileGrid.
+func MapScoreQuantile(score float32, from, to []float64) float32 {
+	if len(from) != len(ScoreQuantileGrid) || len(to) != len(ScoreQuantileGrid) {
+		return score
+	}
+
+	return float32(quantileAt(to, percentileOf(from, float64(score))))
+}
+
+// ScoreQuantileShift is the largest difference between two distributions at
+// the inner quantiles of ScoreQuantileGrid. The minimum and maximum are
+// skipped because single outliers move them.
+func ScoreQuantileShift(a, b []float64) float64 {
+	if len(a) != len(ScoreQuantileGrid) || len(b) != len(ScoreQuantileGrid) {
+		return 0
+	}
+
+	var shift float64
+
+	for i := 1; i < len(ScoreQuantileGrid)-1; i++ {
+		shift = math.Max(shift, math.Abs(a[i]-b[i]))
+	}
+
+	return shift
+}
+
+// percentileOf returns the interpolated percentile of v among quantiles q.
+// Values tied across several quantiles get the middle of their range.
+func percentileOf(q []float64, v float64) float64 {
+	lo, hi := -1, -1
+
+	for i, x := range q {
+		if x == v {
+			if lo < 0 {
+				lo = i
+			}
+
+			hi = i
+		}
+	}
+
+	last := len(q) - 1
+
+	switch {
+	case lo >= 0:
+		return (ScoreQuantileGrid[lo] + ScoreQuantileGrid[hi]) / 2
+	case v < q[0]:
+		return ScoreQuantileGrid[0]
+	case v > q[last]:
+		return ScoreQuantileGrid[last]
+	}
+
+	for i := 1; i <= last; i++ {
+		if v < q[i] {
+			return interpolate(ScoreQuantileGrid[i-1], ScoreQuantileGrid[i], (v-q[i-1])/(q[i]-q[i-1]))
+		}
+	}
+
+	return ScoreQuantileGrid[last]
+}
+
+// quantileAt returns the interpolated value at percentile p of quantiles q.
+func quantileAt(q []float64, p float64) float64 {
+	for i := 1; i < len(ScoreQuantileGrid); i++ {
+		if p <= ScoreQuantileGrid[i] {
+			lower, upper := ScoreQuantileGrid[i-1], ScoreQuantileGrid[i]
+
+			return interpolate(q[i-1], q[i], (p-lower)/(upper-lower))
+		}
+	}
+
+	return q[len(q)-1]
+}
+
+func interpolate(from, to, fraction float64) float64 {
+	return from + (to-from)*fraction
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"math"
+	"testing"
+)
+
+const scoreTolerance = 1e-6
+
+// Quantiles at ScoreQuantileGrid.
+var (
+	uniformScores = []float64{0, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 1}
+	shiftedScores = []float64{0.2, 0.24, 0.28, 0.4, 0.6, 0.8, 0.92, 0.96, 1}
+)
+
+func TestScoringVersion(t *testing.T) {
+	if got := ScoringVersion("gpt-4o", "v2"); got != "gpt-4o@v2" {
+		t.Errorf("ScoringVersion() = %q, want gpt-4o@v2", got)
+	}
+
+	if got := ScoringVersion("", "v1"); got != "default@v1" {
+		t.Errorf("ScoringVersion() = %q, want default@v1", got)
+	}
+}
+
+func TestMapScoreQuantile(t *testing.T) {
+	tests := []struct {
+		name  string
+		score float32
+		from  []float64
+		to    []float64
+		want  float64
+	}{
+		{name: "identity", score: 0.42, from: uniformScores, to: uniformScores, want: 0.42},
+		{name: "median maps to median", score: 0.6, from: shiftedScores, to: uniformScores, want: 0.5},
+		{name: "between quantiles", score: 0.7, from: shiftedScores, to: uniformScores, want: 0.625},
+		{name: "below minimum", score: 0.1, from: shiftedScores, to: uniformScores, want: 0},
+		{name: "mismatched grid", score: 0.3, from: []float64{0, 1}, to: uniformScores, want: 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MapScoreQuantile(tt.score, tt.from, tt.to)
+			if math.Abs(float64(got)-tt.want) > scoreTolerance {
+				t.Errorf("MapScoreQuantile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentileOfTies(t *testing.T) {
+	q := []float64{0, 0.5, 0.5, 0.5, 0.5, 0.6, 0.7, 0.8, 0.9}
+
+	// 0.5 spans the 0.05 to 0.5 quantiles.
+	if got := percentileOf(q, 0.5); math.Abs(got-0.275) > scoreTolerance {
+		t.Errorf("percentileOf() = %v, want 0.275", got)
+	}
+
+	if got := percentileOf(q, 1); got != 1 {
+		t.Errorf("percentileOf() above maximum = %v, want 1", got)
+	}
+}
+
+func TestScoreQuantileShift(t *testing.T) {
+	if got := ScoreQuantileShift(uniformScores, uniformScores); got != 0 {
+		t.Errorf("ScoreQuantileShift() identical = %v, want 0", got)
+	}
+
+	// The minimum differs by 0.2 but is ignored; the largest inner gap is 0.19.
+	if got := ScoreQuantileShift(uniformScores, shiftedScores); math.Abs(got-0.19) > scoreTolerance {
+		t.Errorf("ScoreQuantileShift() = %v, want 0.19", got)
+	}
+
+	if got := ScoreQuantileShift(uniformScores, nil); got != 0 {
+		t.Errorf("ScoreQuantileShift() missing = %v, want 0", got)
+	}
+}
+
+func TestReferenceScoreDistribution(t *testing.T) {
+	dists := []ScoreDistribution{{Version: "a@v1"}, {Version: "b@v1"}}
+
+	if got, ok := ReferenceScoreDistribution(dists, ""); !ok || got.Version != "a@v1" {
+		t.Errorf("ReferenceScoreDistribution() default = %q, %v, want oldest version", got.Version, ok)
+	}
+
+	if got, ok := ReferenceScoreDistribution(dists, "b@v1"); !ok || got.Version != "b@v1" {
+		t.Errorf("ReferenceScoreDistribution() configured = %q, %v, want b@v1", got.Version, ok)
+	}
+
+	if _, ok := ReferenceScoreDistribution(dists, "c@v1"); ok {
+		t.Error("ReferenceScoreDistribution() unknown version found")
+	}
+
+	if _, ok := ReferenceScoreDistribution(nil, ""); ok {
+		t.Error("ReferenceScoreDistribution() empty found")
+	}
+}
+
+func TestScoreDistributionHasEnoughSamples(t *testing.T) {
+	d := ScoreDistribution{Count: MinScoreDistributionSamples, Relevance: uniformScores, Importance: uniformScores}
+	if !d.HasEnoughSamples() {
+		t.Error("HasEnoughSamples() = false, want true")
+	}
+
+	d.Count--
+	if d.HasEnoughSamples() {
+		t.Error("HasEnoughSamples() below minimum = true, want false")
+	}
+}
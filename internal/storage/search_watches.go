@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrSearchWatchNotFound is returned when a saved search watch does not exist.
+var ErrSearchWatchNotFound = errors.New("search watch not found")
+
+// SearchWatch is a saved research search that is re-run periodically to notify
+// admins about new matching items.
+type SearchWatch struct {
+	ID            int64
+	Query         string
+	Channel       string
+	Topic         string
+	CreatedBy     int64
+	CreatedAt     time.Time
+	LastCheckedAt *time.Time
+	LastMatchAt   *time.Time
+}
+
+// CreateSearchWatch saves a search watch and returns its ID.
+func (db *DB) CreateSearchWatch(ctx context.Context, query, channel, topic string, createdBy int64) (int64, error) {
+	var id int64
+
+	if err := db.Pool.QueryRow(ctx, `
+		INSERT INTO search_watches (query, channel, topic, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, SanitizeUTF8(query), channel, topic, createdBy).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create search watch: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListSearchWatches returns all saved search watches, oldest first.
+func (db *DB) ListSearchWatches(ctx context.Context) ([]SearchWatch, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, query, channel, topic, created_by, created_at, last_checked_at, last_match_at
+		FROM search_watches
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list search watches: %w", err)
+	}
+	defer rows.Close()
+
+	var watches []SearchWatch
+
+	for rows.Next() {
+		var (
+			w           SearchWatch
+			lastChecked pgtype.Timestamptz
+			lastMatch   pgtype.Timestamptz
+		)
+
+		if err := rows.Scan(&w.ID, &w.Query, &w.Channel, &w.Topic, &w.CreatedBy, &w.CreatedAt, &lastChecked, &lastMatch); err != nil {
+			return nil, fmt.Errorf("scan search watch: %w", err)
+		}
+
+		if lastChecked.Valid {
+			t := lastChecked.Time
+			w.LastCheckedAt = &t
+		}
+
+		if lastMatch.Valid {
+			t := lastMatch.Time
+			w.LastMatchAt = &t
+		}
+
+		watches = append(watches, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate search watches: %w", err)
+	}
+
+	return watches, nil
+}
+
+// DeleteSearchWatch removes a search watch and its recorded matches.
+func (db *DB) DeleteSearchWatch(ctx context.Context, id int64) error {
+	tag, err := db.Pool.Exec(ctx, `DELETE FROM search_watches WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete search watch: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %d", ErrSearchWatchNotFound, id)
+	}
+
+	return nil
+}
+
+// RecordSearchWatchMatches records items as notified for a watch and returns
+// only the IDs that were not recorded before, so each item is reported once.
+func (db *DB) RecordSearchWatchMatches(ctx context.Context, watchID int64, itemIDs []string) ([]string, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+
+	uuids := make([]pgtype.UUID, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		uuids = append(uuids, toUUID(id))
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		INSERT INTO search_watch_matches (watch_id, item_id)
+		SELECT $1, UNNEST($2::uuid[])
+		ON CONFLICT DO NOTHING
+		RETURNING item_id
+	`, watchID, uuids)
+	if err != nil {
+		return nil, fmt.Errorf("record search watch matches: %w", err)
+	}
+	defer rows.Close()
+
+	var fresh []string
+
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan search watch match: %w", err)
+		}
+
+		fresh = append(fresh, fromUUID(id))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate search watch matches: %w", err)
+	}
+
+	return fresh, nil
+}
+
+// MarkSearchWatchChecked stores when a watch last ran and, if it matched, when
+// it last found new items.
+func (db *DB) MarkSearchWatchChecked(ctx context.Context, id int64, checkedAt time.Time, matched bool) error {
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE search_watches
+		SET last_checked_at = $2,
+		    last_match_at = CASE WHEN $3 THEN $2 ELSE last_match_at END
+		WHERE id = $1
+	`, id, toTimestamptz(checkedAt), matched); err != nil {
+		return fmt.Errorf("mark search watch checked: %w", err)
+	}
+
+	return nil
+}
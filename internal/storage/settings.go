@@ -37,6 +37,8 @@ func (db *DB) SaveSettingWithHistory(ctx context.Context, key string, value inte
 		return fmt.Errorf("failed to save setting to DB: %w", err)
 	}
 
+	db.recordModelPromptEvent(ctx, key, string(oldVal), string(val), changedBy)
+
 	// Only add history if changedBy is provided
 	if changedBy != 0 {
 		//nolint:errcheck // history logging is best-effort, should not fail the main operation
@@ -85,6 +87,8 @@ func (db *DB) DeleteSettingWithHistory(ctx context.Context, key string, changedB
 		return fmt.Errorf("failed to delete setting from DB: %w", err)
 	}
 
+	db.recordModelPromptEvent(ctx, key, string(oldVal), "", changedBy)
+
 	// Only add history if changedBy is provided
 	if changedBy != 0 {
 		//nolint:errcheck // history logging is best-effort, should not fail the main operation
@@ -100,29 +104,41 @@ func (db *DB) DeleteSettingWithHistory(ctx context.Context, key string, changedB
 }
 
 type SettingHistory struct {
+	ID        int64
 	Key       string
 	OldValue  string
 	NewValue  string
 	ChangedBy int64
 	ChangedAt time.Time
+	// RollbackOf is the ID of the history entry this change rolled back, or 0.
+	RollbackOf int64
 }
 
 func (db *DB) GetRecentSettingHistory(ctx context.Context, limit int) ([]SettingHistory, error) {
-	rows, err := db.Queries.GetRecentSettingHistory(ctx, safeIntToInt32(limit))
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, key, COALESCE(old_value, ''), COALESCE(new_value, ''), changed_by, changed_at, COALESCE(rollback_of, 0)
+		FROM setting_history
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $1
+	`, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get setting history: %w", err)
 	}
+	defer rows.Close()
 
-	res := make([]SettingHistory, len(rows))
+	var res []SettingHistory
 
-	for i, row := range rows {
-		res[i] = SettingHistory{
-			Key:       row.Key,
-			OldValue:  row.OldValue.String,
-			NewValue:  row.NewValue.String,
-			ChangedBy: row.ChangedBy,
-			ChangedAt: row.ChangedAt.Time,
+	for rows.Next() {
+		var h SettingHistory
+		if err := rows.Scan(&h.ID, &h.Key, &h.OldValue, &h.NewValue, &h.ChangedBy, &h.ChangedAt, &h.RollbackOf); err != nil {
+			return nil, fmt.Errorf("scan setting history: %w", err)
 		}
+
+		res = append(res, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate setting history: %w", err)
 	}
 
 	return res, nil
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSettingHistoryNotFound is returned when no history entry matches a rollback point.
+var ErrSettingHistoryNotFound = errors.New("setting history entry not found")
+
+// RollbackScope selects the settings a rollback applies to: a single key, or
+// every key starting with Key when Prefix is set (e.g. all versions of a prompt).
+type RollbackScope struct {
+	Key    string
+	Prefix bool
+}
+
+// SettingRollback describes how one key changes when rolled back. Current and
+// Restored hold JSON values; an empty string means the setting is unset.
+type SettingRollback struct {
+	Key      string
+	Current  string
+	Restored string
+	// EntryID is the oldest history entry undone for the key.
+	EntryID   int64
+	ChangedAt time.Time
+}
+
+const rollbackScopeCondition = `CASE WHEN $2 THEN left(key, length($1)) = $1 ELSE key = $1 END`
+
+// GetSettingHistoryEntry returns a single history entry.
+func (db *DB) GetSettingHistoryEntry(ctx context.Context, id int64) (*SettingHistory, error) {
+	var h SettingHistory
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT id, key, COALESCE(old_value, ''), COALESCE(new_value, ''), changed_by, changed_at, COALESCE(rollback_of, 0)
+		FROM setting_history
+		WHERE id = $1
+	`, id).Scan(&h.ID, &h.Key, &h.OldValue, &h.NewValue, &h.ChangedBy, &h.ChangedAt, &h.RollbackOf)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrSettingHistoryNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get setting history entry: %w", err)
+	}
+
+	return &h, nil
+}
+
+// GetRollbackPointBySteps returns the ID of the history entry steps changes
+// back in scope, so rolling back to it undoes the last steps changes.
+func (db *DB) GetRollbackPointBySteps(ctx context.Context, scope RollbackScope, steps int) (int64, error) {
+	var id int64
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT id FROM setting_history
+		WHERE `+rollbackScopeCondition+`
+		ORDER BY id DESC
+		OFFSET $3 LIMIT 1
+	`, scope.Key, scope.Prefix, steps-1).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: %s", ErrSettingHistoryNotFound, scope.Key)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("get rollback point: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetRollbackPointAt returns the ID of the first history entry in scope made
+// after at, so rolling back to it restores the values in effect at that time.
+func (db *DB) GetRollbackPointAt(ctx context.Context, scope RollbackScope, at time.Time) (int64, error) {
+	var id int64
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT id FROM setting_history
+		WHERE `+rollbackScopeCondition+` AND changed_at > $3
+		ORDER BY id
+		LIMIT 1
+	`, scope.Key, scope.Prefix, at).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: %s", ErrSettingHistoryNotFound, scope.Key)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("get rollback point: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetRollbackPlan previews RollbackSettings: for each key in scope changed at
+// or after fromID it returns the current value and the value to restore.
+func (db *DB) GetRollbackPlan(ctx context.Context, scope RollbackScope, fromID int64) ([]SettingRollback, error) {
+	rows, err := db.Pool.Query(ctx, `
+		WITH undone AS (
+			SELECT DISTINCT ON (key) id, key, old_value, changed_at
+			FROM setting_history
+			WHERE `+rollbackScopeCondition+` AND id >= $3
+			ORDER BY key, id
+		)
+		SELECT u.key, COALESCE(s.value::text, ''), COALESCE(u.old_value, ''), u.id, u.changed_at
+		FROM undone u
+		LEFT JOIN settings s ON s.key = u.key
+		ORDER BY u.key
+	`, scope.Key, scope.Prefix, fromID)
+	if err != nil {
+		return nil, fmt.Errorf("get rollback plan: %w", err)
+	}
+
+	return scanSettingRollbacks(rows)
+}
+
+// RollbackSettings atomically restores every key in scope to its value before
+// history entry fromID and records each restore in the history, annotated with
+// the entry it rolled back. It returns the applied changes.
+func (db *DB) RollbackSettings(ctx context.Context, scope RollbackScope, fromID, changedBy int64) ([]SettingRollback, error) {
+	rows, err := db.Pool.Query(ctx, `
+		WITH undone AS (
+			SELECT DISTINCT ON (key) id, key, old_value, changed_at
+			FROM setting_history
+			WHERE `+rollbackScopeCondition+` AND id >= $3
+			ORDER BY key, id
+		),
+		previous AS (
+			SELECT u.key, s.value::text AS value
+			FROM undone u
+			LEFT JOIN settings s ON s.key = u.key
+		),
+		deleted AS (
+			DELETE FROM settings s
+			USING undone u
+			WHERE s.key = u.key AND u.old_value IS NULL
+		),
+		restored AS (
+			INSERT INTO settings (key, value, updated_at)
+			SELECT key, old_value::jsonb, now()
+			FROM undone
+			WHERE old_value IS NOT NULL
+			ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = now()
+		),
+		logged AS (
+			INSERT INTO setting_history (key, old_value, new_value, changed_by, rollback_of)
+			SELECT u.key, c.value, u.old_value, $4, u.id
+			FROM undone u
+			JOIN previous c ON c.key = u.key
+		)
+		SELECT u.key, COALESCE(c.value, ''), COALESCE(u.old_value, ''), u.id, u.changed_at
+		FROM undone u
+		JOIN previous c ON c.key = u.key
+		ORDER BY u.key
+	`, scope.Key, scope.Prefix, fromID, changedBy)
+	if err != nil {
+		return nil, fmt.Errorf("rollback settings: %w", err)
+	}
+
+	changes, err := scanSettingRollbacks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrSettingHistoryNotFound, fromID)
+	}
+
+	for _, c := range changes {
+		db.recordModelPromptEvent(ctx, c.Key, c.Current, c.Restored, changedBy)
+	}
+
+	return changes, nil
+}
+
+func scanSettingRollbacks(rows pgx.Rows) ([]SettingRollback, error) {
+	defer rows.Close()
+
+	var res []SettingRollback
+
+	for rows.Next() {
+		var r SettingRollback
+		if err := rows.Scan(&r.Key, &r.Current, &r.Restored, &r.EntryID, &r.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan setting rollback: %w", err)
+		}
+
+		res = append(res, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate setting rollbacks: %w", err)
+	}
+
+	return res, nil
+}
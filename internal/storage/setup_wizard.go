@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SetupWizardState is an admin's progress through the /setup wizard.
+type SetupWizardState struct {
+	Step      string
+	UpdatedAt time.Time
+}
+
+// GetSetupWizardState returns the admin's wizard state. Step is empty when the
+// admin has no wizard running.
+func (db *DB) GetSetupWizardState(ctx context.Context, adminID int64) (SetupWizardState, error) {
+	var state SetupWizardState
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT step, updated_at FROM setup_wizard_states WHERE admin_id = $1
+	`, adminID).Scan(&state.Step, &state.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return SetupWizardState{}, nil
+	}
+
+	if err != nil {
+		return SetupWizardState{}, fmt.Errorf("get setup wizard state: %w", err)
+	}
+
+	return state, nil
+}
+
+// SaveSetupWizardStep records the admin's current wizard step.
+func (db *DB) SaveSetupWizardStep(ctx context.Context, adminID int64, step string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO setup_wizard_states (admin_id, step, updated_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (admin_id) DO UPDATE SET step = EXCLUDED.step, updated_at = now()
+	`, adminID, step); err != nil {
+		return fmt.Errorf("save setup wizard step: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSetupWizardState ends the admin's wizard.
+func (db *DB) DeleteSetupWizardState(ctx context.Context, adminID int64) error {
+	if _, err := db.Pool.Exec(ctx, `DELETE FROM setup_wizard_states WHERE admin_id = $1`, adminID); err != nil {
+		return fmt.Errorf("delete setup wizard state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Digest variants compared by shadow testing.
+const (
+	DigestVariantProduction = "production"
+	DigestVariantShadow     = "shadow"
+)
+
+// ShadowDigest is a digest rendered with experimental settings and posted to
+// the shadow target alongside the production digest of the same window.
+type ShadowDigest struct {
+	ID        string
+	DigestID  string
+	Start     time.Time
+	End       time.Time
+	ChatID    int64
+	MsgID     int64
+	Overrides map[string]json.RawMessage
+}
+
+// VariantRatingStats summarizes the ratings of one digest variant.
+type VariantRatingStats struct {
+	Variant string `json:"variant"`
+	Digests int    `json:"digests"`
+	Ratings int    `json:"ratings"`
+	Up      int    `json:"up"`
+	Down    int    `json:"down"`
+}
+
+// SaveShadowDigest records a posted shadow digest.
+func (db *DB) SaveShadowDigest(ctx context.Context, sd ShadowDigest) error {
+	overrides, err := json.Marshal(sd.Overrides)
+	if err != nil {
+		return fmt.Errorf("marshal shadow overrides: %w", err)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		INSERT INTO shadow_digests (id, digest_id, window_start, window_end, posted_chat_id, posted_msg_id, overrides)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, toUUID(sd.ID), toUUID(sd.DigestID), toTimestamptz(sd.Start), toTimestamptz(sd.End), sd.ChatID, sd.MsgID, overrides); err != nil {
+		return fmt.Errorf("save shadow digest: %w", err)
+	}
+
+	return nil
+}
+
+// SaveShadowRating records a rating for a shadow digest. It reports false,
+// without error, when digestID is not a shadow digest.
+func (db *DB) SaveShadowRating(ctx context.Context, digestID string, userID int64, rating int16) (bool, error) {
+	tag, err := db.Pool.Exec(ctx, `
+		INSERT INTO shadow_digest_ratings (shadow_digest_id, user_id, rating)
+		SELECT id, $2, $3 FROM shadow_digests WHERE id = $1
+		ON CONFLICT (shadow_digest_id, user_id) DO UPDATE SET rating = EXCLUDED.rating, created_at = now()
+	`, toUUID(digestID), userID, rating)
+	if err != nil {
+		return false, fmt.Errorf("save shadow rating: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetShadowComparison returns rating totals for shadow digests created since
+// the given time and for the production digests of the same windows.
+func (db *DB) GetShadowComparison(ctx context.Context, since time.Time) ([]VariantRatingStats, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT $2::text, COUNT(DISTINCT sd.digest_id), COUNT(r.id),
+		       COUNT(r.id) FILTER (WHERE r.rating > 0), COUNT(r.id) FILTER (WHERE r.rating < 0)
+		FROM shadow_digests sd
+		LEFT JOIN digest_ratings r ON r.digest_id = sd.digest_id
+		WHERE sd.created_at >= $1
+		UNION ALL
+		SELECT $3::text, COUNT(DISTINCT sd.id), COUNT(r.id),
+		       COUNT(r.id) FILTER (WHERE r.rating > 0), COUNT(r.id) FILTER (WHERE r.rating < 0)
+		FROM shadow_digests sd
+		LEFT JOIN shadow_digest_ratings r ON r.shadow_digest_id = sd.id
+		WHERE sd.created_at >= $1
+	`, toTimestamptz(since), DigestVariantProduction, DigestVariantShadow)
+	if err != nil {
+		return nil, fmt.Errorf("get shadow comparison: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []VariantRatingStats
+
+	for rows.Next() {
+		var s VariantRatingStats
+		if err := rows.Scan(&s.Variant, &s.Digests, &s.Ratings, &s.Up, &s.Down); err != nil {
+			return nil, fmt.Errorf("scan shadow comparison: %w", err)
+		}
+
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate shadow comparison: %w", err)
+	}
+
+	return stats, nil
+}
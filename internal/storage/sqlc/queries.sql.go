@@ -206,7 +206,7 @@ func (q *Queries) ClearDigestErrors(ctx context.Context) error {
 }
 
 const countActiveChannels = `-- name: CountActiveChannels :one
-SELECT COUNT(*) FROM channels WHERE is_active = TRUE
+SELECT COUNT(*) FROM channels WHERE is_active = TRUE AND health_status <> 'dead'
 `
 
 func (q *Queries) CountActiveChannels(ctx context.Context) (int64, error) {
@@ -1729,10 +1729,13 @@ SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i
 FROM items i
 JOIN raw_messages rm ON i.raw_message_id = rm.id
 JOIN channels c ON rm.channel_id = c.id
+LEFT JOIN channel_groups g ON c.group_id = g.id
 LEFT JOIN embeddings e ON i.id = e.item_id
 WHERE rm.tg_date >= $1 AND rm.tg_date < $2
   AND i.status = 'ready'
-  AND i.importance_score >= COALESCE(c.importance_threshold, $3)
+  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+  AND channel_in_digests(c, rm.tg_date)
+  AND g.target_chat_id IS NULL
   AND i.digested_at IS NULL
 ORDER BY i.importance_score DESC, i.relevance_score DESC
 LIMIT $4
@@ -1805,14 +1808,17 @@ func (q *Queries) GetItemsForWindow(ctx context.Context, arg GetItemsForWindowPa
 }
 
 const getItemsForWindowWithMedia = `-- name: GetItemsForWindowWithMedia :many
-SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i.summary, i.language, i.status, i.first_seen_at, rm.tg_date, rm.media_data, c.username as source_channel, c.title as source_channel_title, c.tg_peer_id as source_channel_id, rm.tg_message_id as source_msg_id, e.embedding
+SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i.summary, i.language, i.status, i.first_seen_at, rm.tg_date, rm.media_data, rm.video_duration_seconds, i.alt_text, i.safety_flags, c.username as source_channel, c.title as source_channel_title, c.tg_peer_id as source_channel_id, rm.tg_message_id as source_msg_id, e.embedding
 FROM items i
 JOIN raw_messages rm ON i.raw_message_id = rm.id
 JOIN channels c ON rm.channel_id = c.id
+LEFT JOIN channel_groups g ON c.group_id = g.id
 LEFT JOIN embeddings e ON i.id = e.item_id
 WHERE rm.tg_date >= $1 AND rm.tg_date < $2
   AND i.status = 'ready'
-  AND i.importance_score >= COALESCE(c.importance_threshold, $3)
+  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+  AND channel_in_digests(c, rm.tg_date)
+  AND g.target_chat_id IS NULL
   AND i.digested_at IS NULL
 ORDER BY i.importance_score DESC, i.relevance_score DESC
 LIMIT $4
@@ -1826,22 +1832,25 @@ type GetItemsForWindowWithMediaParams struct {
 }
 
 type GetItemsForWindowWithMediaRow struct {
-	ID                 pgtype.UUID        `json:"id"`
-	RawMessageID       pgtype.UUID        `json:"raw_message_id"`
-	RelevanceScore     float32            `json:"relevance_score"`
-	ImportanceScore    float32            `json:"importance_score"`
-	Topic              pgtype.Text        `json:"topic"`
-	Summary            pgtype.Text        `json:"summary"`
-	Language           pgtype.Text        `json:"language"`
-	Status             string             `json:"status"`
-	FirstSeenAt        pgtype.Timestamptz `json:"first_seen_at"`
-	TgDate             pgtype.Timestamptz `json:"tg_date"`
-	MediaData          []byte             `json:"media_data"`
-	SourceChannel      pgtype.Text        `json:"source_channel"`
-	SourceChannelTitle pgtype.Text        `json:"source_channel_title"`
-	SourceChannelID    int64              `json:"source_channel_id"`
-	SourceMsgID        int64              `json:"source_msg_id"`
-	Embedding          pgvector.Vector    `json:"embedding"`
+	ID                   pgtype.UUID        `json:"id"`
+	RawMessageID         pgtype.UUID        `json:"raw_message_id"`
+	RelevanceScore       float32            `json:"relevance_score"`
+	ImportanceScore      float32            `json:"importance_score"`
+	Topic                pgtype.Text        `json:"topic"`
+	Summary              pgtype.Text        `json:"summary"`
+	Language             pgtype.Text        `json:"language"`
+	Status               string             `json:"status"`
+	FirstSeenAt          pgtype.Timestamptz `json:"first_seen_at"`
+	TgDate               pgtype.Timestamptz `json:"tg_date"`
+	MediaData            []byte             `json:"media_data"`
+	VideoDurationSeconds pgtype.Int4        `json:"video_duration_seconds"`
+	AltText              pgtype.Text        `json:"alt_text"`
+	SafetyFlags          []string           `json:"safety_flags"`
+	SourceChannel        pgtype.Text        `json:"source_channel"`
+	SourceChannelTitle   pgtype.Text        `json:"source_channel_title"`
+	SourceChannelID      int64              `json:"source_channel_id"`
+	SourceMsgID          int64              `json:"source_msg_id"`
+	Embedding            pgvector.Vector    `json:"embedding"`
 }
 
 func (q *Queries) GetItemsForWindowWithMedia(ctx context.Context, arg GetItemsForWindowWithMediaParams) ([]GetItemsForWindowWithMediaRow, error) {
@@ -1870,6 +1879,9 @@ func (q *Queries) GetItemsForWindowWithMedia(ctx context.Context, arg GetItemsFo
 			&i.FirstSeenAt,
 			&i.TgDate,
 			&i.MediaData,
+			&i.VideoDurationSeconds,
+			&i.AltText,
+			&i.SafetyFlags,
 			&i.SourceChannel,
 			&i.SourceChannelTitle,
 			&i.SourceChannelID,
@@ -2569,7 +2581,7 @@ WITH eligible AS (
     SELECT rm.id
     FROM raw_messages rm
     LEFT JOIN items i ON rm.id = i.raw_message_id
-    WHERE (rm.processed_at IS NULL AND rm.processing_started_at IS NULL)
+    WHERE (rm.processed_at IS NULL AND rm.processing_started_at IS NULL AND (rm.deferred_until IS NULL OR rm.deferred_until <= now()))
        OR (i.status IN ('error', 'retry') AND i.retry_count < 5 AND (i.next_retry_at IS NULL OR i.next_retry_at < now()))
     ORDER BY rm.tg_date ASC
     LIMIT $1
@@ -2583,15 +2595,18 @@ claimed AS (
     RETURNING rm.id
 )
 SELECT rm.id, rm.channel_id, rm.tg_message_id, rm.tg_date, rm.text, rm.preview_text, rm.entities_json, rm.media_json, rm.media_data, rm.canonical_hash, rm.is_forward, rm.has_comments_thread,
+       rm.views, rm.forwards, rm.forward_chain, rm.video_duration_seconds,
        c.title as channel_title, c.context as channel_context, c.description as channel_description,
        c.category as channel_category, c.tone as channel_tone, c.update_freq as channel_update_freq,
-       c.relevance_threshold as channel_relevance_threshold, c.importance_threshold as channel_importance_threshold,
+       COALESCE(c.relevance_threshold, g.relevance_threshold) as channel_relevance_threshold,
+       COALESCE(c.importance_threshold, g.importance_threshold) as channel_importance_threshold,
        c.importance_weight as channel_importance_weight,
        c.auto_relevance_enabled as channel_auto_relevance_enabled,
        c.relevance_threshold_delta as channel_relevance_threshold_delta,
        c.tg_peer_id as channel_tg_peer_id
 FROM raw_messages rm
 JOIN channels c ON rm.channel_id = c.id
+LEFT JOIN channel_groups g ON c.group_id = g.id
 WHERE rm.id IN (SELECT id FROM claimed)
 ORDER BY rm.tg_date ASC
 `
@@ -2609,6 +2624,10 @@ type GetUnprocessedMessagesRow struct {
 	CanonicalHash                  string             `json:"canonical_hash"`
 	IsForward                      bool               `json:"is_forward"`
 	HasCommentsThread              bool               `json:"has_comments_thread"`
+	Views                          int32              `json:"views"`
+	Forwards                       int32              `json:"forwards"`
+	ForwardChain                   []byte             `json:"forward_chain"`
+	VideoDurationSeconds           pgtype.Int4        `json:"video_duration_seconds"`
 	ChannelTitle                   pgtype.Text        `json:"channel_title"`
 	ChannelContext                 pgtype.Text        `json:"channel_context"`
 	ChannelDescription             pgtype.Text        `json:"channel_description"`
@@ -2647,6 +2666,10 @@ func (q *Queries) GetUnprocessedMessages(ctx context.Context, limit int32) ([]Ge
 			&i.CanonicalHash,
 			&i.IsForward,
 			&i.HasCommentsThread,
+			&i.Views,
+			&i.Forwards,
+			&i.ForwardChain,
+			&i.VideoDurationSeconds,
 			&i.ChannelTitle,
 			&i.ChannelContext,
 			&i.ChannelDescription,
@@ -3257,8 +3280,8 @@ func (q *Queries) SaveRating(ctx context.Context, arg SaveRatingParams) error {
 }
 
 const saveRawMessage = `-- name: SaveRawMessage :exec
-INSERT INTO raw_messages (channel_id, tg_message_id, tg_date, text, entities_json, media_json, media_data, preview_text, canonical_hash, is_forward, has_comments_thread)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+INSERT INTO raw_messages (channel_id, tg_message_id, tg_date, text, entities_json, media_json, media_data, preview_text, canonical_hash, is_forward, has_comments_thread, views, forwards, forward_chain, fwd_origin_peer_id, fwd_origin_msg_id, video_duration_seconds)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 ON CONFLICT (channel_id, tg_message_id) DO UPDATE SET
     media_data = COALESCE(raw_messages.media_data, EXCLUDED.media_data),
     preview_text = COALESCE(raw_messages.preview_text, EXCLUDED.preview_text),
@@ -3267,17 +3290,23 @@ WHERE raw_messages.media_data IS NULL OR raw_messages.preview_text IS NULL OR EX
 `
 
 type SaveRawMessageParams struct {
-	ChannelID         pgtype.UUID        `json:"channel_id"`
-	TgMessageID       int64              `json:"tg_message_id"`
-	TgDate            pgtype.Timestamptz `json:"tg_date"`
-	Text              pgtype.Text        `json:"text"`
-	EntitiesJson      []byte             `json:"entities_json"`
-	MediaJson         []byte             `json:"media_json"`
-	MediaData         []byte             `json:"media_data"`
-	PreviewText       pgtype.Text        `json:"preview_text"`
-	CanonicalHash     string             `json:"canonical_hash"`
-	IsForward         bool               `json:"is_forward"`
-	HasCommentsThread bool               `json:"has_comments_thread"`
+	ChannelID            pgtype.UUID        `json:"channel_id"`
+	TgMessageID          int64              `json:"tg_message_id"`
+	TgDate               pgtype.Timestamptz `json:"tg_date"`
+	Text                 pgtype.Text        `json:"text"`
+	EntitiesJson         []byte             `json:"entities_json"`
+	MediaJson            []byte             `json:"media_json"`
+	MediaData            []byte             `json:"media_data"`
+	PreviewText          pgtype.Text        `json:"preview_text"`
+	CanonicalHash        string             `json:"canonical_hash"`
+	IsForward            bool               `json:"is_forward"`
+	HasCommentsThread    bool               `json:"has_comments_thread"`
+	Views                int32              `json:"views"`
+	Forwards             int32              `json:"forwards"`
+	ForwardChain         []byte             `json:"forward_chain"`
+	FwdOriginPeerID      pgtype.Int8        `json:"fwd_origin_peer_id"`
+	FwdOriginMsgID       pgtype.Int8        `json:"fwd_origin_msg_id"`
+	VideoDurationSeconds pgtype.Int4        `json:"video_duration_seconds"`
 }
 
 func (q *Queries) SaveRawMessage(ctx context.Context, arg SaveRawMessageParams) error {
@@ -3293,6 +3322,12 @@ func (q *Queries) SaveRawMessage(ctx context.Context, arg SaveRawMessageParams)
 		arg.CanonicalHash,
 		arg.IsForward,
 		arg.HasCommentsThread,
+		arg.Views,
+		arg.Forwards,
+		arg.ForwardChain,
+		arg.FwdOriginPeerID,
+		arg.FwdOriginMsgID,
+		arg.VideoDurationSeconds,
 	)
 	return err
 }
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pgvector/pgvector-go"
+)
+
+// Stale item modes.
+const (
+	StaleItemsOff     = "off"
+	StaleItemsSkip    = "skip"
+	StaleItemsSection = "section"
+)
+
+const (
+	defaultStaleMaxMissed     = 2
+	defaultStaleLookbackHours = 24
+)
+
+// StaleItemPolicy configures carrying undigested items over into later
+// digests. Items from windows before the digest window that were ready too
+// late or lost the selection compete again, with the freshness decay applied.
+// Once an item missed MaxMissed digests it is stale: the skip mode stops
+// carrying it over, the section mode lists it under "Previously missed".
+type StaleItemPolicy struct {
+	Mode string `json:"mode"`
+	// MaxMissed is the number of missed digests that makes an item stale.
+	MaxMissed int `json:"max_missed"`
+	// LookbackHours is how far before the digest window items are carried over.
+	LookbackHours int `json:"lookback_hours"`
+}
+
+// DefaultStaleItemPolicy returns a disabled policy.
+func DefaultStaleItemPolicy() StaleItemPolicy {
+	return StaleItemPolicy{
+		Mode:          StaleItemsOff,
+		MaxMissed:     defaultStaleMaxMissed,
+		LookbackHours: defaultStaleLookbackHours,
+	}
+}
+
+// Active reports whether undigested items are carried over.
+func (p StaleItemPolicy) Active() bool {
+	return (p.Mode == StaleItemsSkip || p.Mode == StaleItemsSection) && p.LookbackHours > 0
+}
+
+// Lookback returns the carry-over lookback as a duration.
+func (p StaleItemPolicy) Lookback() time.Duration {
+	return time.Duration(p.LookbackHours) * time.Hour
+}
+
+// IsStale reports whether the item missed enough digests to be stale.
+func (p StaleItemPolicy) IsStale(item Item) bool {
+	return p.MaxMissed > 0 && item.MissedDigests >= p.MaxMissed
+}
+
+// GetCarryOverItems returns ready, undigested items above the threshold from
+// before the digest window, with their missed digest counts.
+func (db *DB) GetCarryOverItems(ctx context.Context, since, before time.Time, importanceThreshold float32, limit int) ([]Item, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT i.id, i.raw_message_id, i.relevance_score, i.importance_score, i.topic, i.summary, i.language,
+		       i.status, i.first_seen_at, rm.tg_date, c.username, c.title, c.tg_peer_id, rm.tg_message_id,
+		       e.embedding, i.missed_digests
+		FROM items i
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels c ON rm.channel_id = c.id
+		LEFT JOIN channel_groups g ON c.group_id = g.id
+		LEFT JOIN embeddings e ON i.id = e.item_id
+		WHERE rm.tg_date >= $1 AND rm.tg_date < $2
+		  AND i.status = 'ready'
+		  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+		  AND channel_in_digests(c, rm.tg_date)
+		  AND g.target_chat_id IS NULL
+		  AND i.digested_at IS NULL
+		ORDER BY i.importance_score DESC, i.relevance_score DESC
+		LIMIT $4
+	`, toTimestamptz(since), toTimestamptz(before), importanceThreshold, safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get carry-over items: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := scanSelectionItems(rows)
+	if err != nil {
+		return nil, fmt.Errorf("get carry-over items: %w", err)
+	}
+
+	return items, nil
+}
+
+// scanSelectionItems scans digest candidate rows: the GetItemsForWindow
+// columns followed by missed_digests.
+func scanSelectionItems(rows pgx.Rows) ([]Item, error) {
+	var items []Item
+
+	for rows.Next() {
+		var (
+			id, rawMessageID      pgtype.UUID
+			topic, summary, lang  pgtype.Text
+			channel, channelTitle pgtype.Text
+			firstSeenAt, tgDate   pgtype.Timestamptz
+			embedding             pgvector.Vector
+			item                  Item
+			missedDigests         int32
+		)
+
+		if err := rows.Scan(&id, &rawMessageID, &item.RelevanceScore, &item.ImportanceScore, &topic, &summary, &lang,
+			&item.Status, &firstSeenAt, &tgDate, &channel, &channelTitle, &item.SourceChannelID, &item.SourceMsgID,
+			&embedding, &missedDigests); err != nil {
+			return nil, fmt.Errorf("scan item: %w", err)
+		}
+
+		item.ID = fromUUID(id)
+		item.RawMessageID = fromUUID(rawMessageID)
+		item.Topic, item.Summary, item.Language = topic.String, summary.String, lang.String
+		item.SourceChannel, item.SourceChannelTitle = channel.String, channelTitle.String
+		item.FirstSeenAt, item.TGDate = firstSeenAt.Time, tgDate.Time
+		item.Embedding = embedding.Slice()
+		item.MissedDigests = int(missedDigests)
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate items: %w", err)
+	}
+
+	return items, nil
+}
+
+// RecordMissedDigests increments the missed digest count of the ready items
+// above the threshold from [since, before) that a posted digest left out.
+// Call it after the digest's items were marked as digested.
+func (db *DB) RecordMissedDigests(ctx context.Context, since, before time.Time, importanceThreshold float32) (int64, error) {
+	tag, err := db.Pool.Exec(ctx, `
+		UPDATE items i
+		SET missed_digests = i.missed_digests + 1
+		FROM raw_messages rm
+		JOIN channels c ON rm.channel_id = c.id
+		LEFT JOIN channel_groups g ON c.group_id = g.id
+		WHERE i.raw_message_id = rm.id
+		  AND rm.tg_date >= $1 AND rm.tg_date < $2
+		  AND i.status = 'ready'
+		  AND i.importance_score >= COALESCE(c.importance_threshold, g.importance_threshold, $3)
+		  AND channel_in_digests(c, rm.tg_date)
+		  AND g.target_chat_id IS NULL
+		  AND i.digested_at IS NULL
+	`, toTimestamptz(since), toTimestamptz(before), importanceThreshold)
+	if err != nil {
+		return 0, fmt.Errorf("record missed digests: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
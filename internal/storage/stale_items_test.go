@@ -0,0 +1,24 @@
+package db
+
+import "testing"
+
+func TestStaleItemPolicy(t *testing.T) {
+	p := DefaultStaleItemPolicy()
+	if p.Active() {
+		t.Error("default policy is active")
+	}
+
+	p.Mode = StaleItemsSection
+	if !p.Active() {
+		t.Error("section policy is not active")
+	}
+
+	p.LookbackHours = 0
+	if p.Active() {
+		t.Error("policy without lookback is active")
+	}
+
+	if p.IsStale(Item{MissedDigests: 1}) || !p.IsStale(Item{MissedDigests: 2}) {
+		t.Errorf("IsStale disagrees with MaxMissed %d", p.MaxMissed)
+	}
+}
@@ -73,6 +73,19 @@ func (db *DB) CountActiveChannels(ctx context.Context) (int, error) {
 	return int(count), nil
 }
 
+// CountDeadChannels counts active channels that stopped posting and are left out of stats.
+func (db *DB) CountDeadChannels(ctx context.Context) (int, error) {
+	var count int
+
+	if err := db.Pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM channels WHERE is_active = TRUE AND health_status = $1
+	`, ChannelHealthDead).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count dead channels: %w", err)
+	}
+
+	return count, nil
+}
+
 func (db *DB) CountRecentlyActiveChannels(ctx context.Context) (int, error) {
 	count, err := db.Queries.CountRecentlyActiveChannels(ctx)
 	if err != nil {
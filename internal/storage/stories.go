@@ -0,0 +1,356 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultStoryListLimit = 20
+
+var (
+	// ErrNoStoryCandidate is returned when no earlier cluster can continue a story.
+	ErrNoStoryCandidate = errors.New("no story candidate")
+	// ErrStoryNotFound is returned when a story does not exist.
+	ErrStoryNotFound = errors.New("story not found")
+)
+
+// Story is a persistent story: digest clusters from consecutive windows
+// linked by embedding similarity.
+type Story struct {
+	ID                string     `json:"id"`
+	Title             string     `json:"title"`
+	FirstSeenAt       time.Time  `json:"first_seen_at"`
+	LastSeenAt        time.Time  `json:"last_seen_at"`
+	TimelineUpdatedAt *time.Time `json:"timeline_updated_at,omitempty"`
+	ClusterCount      int        `json:"cluster_count"`
+	EventCount        int        `json:"event_count"`
+}
+
+// StoryCandidate is the earlier digest cluster closest to a new cluster, with
+// the story it already belongs to, if any.
+type StoryCandidate struct {
+	ClusterID  string
+	StoryID    string
+	Similarity float32
+}
+
+// StoryItem is an item of one of a story's clusters.
+type StoryItem struct {
+	ItemID          string
+	ClusterID       string
+	Summary         string
+	ImportanceScore float32
+	TGDate          time.Time
+	ChannelUsername string
+	ChannelPeerID   int64
+	MsgID           int64
+}
+
+// StoryEvent is one dated development on a story timeline, with the item it
+// came from.
+type StoryEvent struct {
+	Date            time.Time `json:"date"`
+	Text            string    `json:"text"`
+	ItemID          string    `json:"item_id,omitempty"`
+	ChannelUsername string    `json:"channel_username,omitempty"`
+	ChannelPeerID   int64     `json:"channel_peer_id,omitempty"`
+	MsgID           int64     `json:"msg_id,omitempty"`
+}
+
+// FindStoryCandidate returns the digest cluster from an earlier window, ending
+// no earlier than since, whose embedding centroid is closest to the cluster's.
+// It returns ErrNoStoryCandidate when no earlier cluster has embeddings.
+func (db *DB) FindStoryCandidate(ctx context.Context, clusterID string, since time.Time) (StoryCandidate, error) {
+	var (
+		candidateID pgtype.UUID
+		storyID     pgtype.UUID
+		similarity  float64
+	)
+
+	err := db.Pool.QueryRow(ctx, `
+		WITH target AS (
+			SELECT c.window_start, AVG(e.embedding) AS centroid
+			FROM clusters c
+			JOIN cluster_items ci ON ci.cluster_id = c.id
+			JOIN embeddings e ON e.item_id = ci.item_id
+			WHERE c.id = $1
+			GROUP BY c.window_start
+		),
+		candidates AS (
+			SELECT c.id, AVG(e.embedding) AS centroid
+			FROM clusters c
+			JOIN target t ON c.window_end <= t.window_start
+			JOIN cluster_items ci ON ci.cluster_id = c.id
+			JOIN embeddings e ON e.item_id = ci.item_id
+			WHERE c.source = $3 AND c.id <> $1 AND c.window_end >= $2
+			GROUP BY c.id
+		)
+		SELECT cand.id, sc.story_id, 1 - (cand.centroid <=> t.centroid)
+		FROM candidates cand
+		CROSS JOIN target t
+		LEFT JOIN story_clusters sc ON sc.cluster_id = cand.id
+		ORDER BY cand.centroid <=> t.centroid
+		LIMIT 1
+	`, toUUID(clusterID), toTimestamptz(since), ClusterSourceDigest).Scan(&candidateID, &storyID, &similarity)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StoryCandidate{}, ErrNoStoryCandidate
+		}
+
+		return StoryCandidate{}, fmt.Errorf("find story candidate: %w", err)
+	}
+
+	return StoryCandidate{
+		ClusterID:  fromUUID(candidateID),
+		StoryID:    fromUUID(storyID),
+		Similarity: float32(similarity),
+	}, nil
+}
+
+// LinkClustersToStory adds clusters to a story, creating the story when
+// storyID is empty, and refreshes its title and seen range. Clusters that
+// already belong to a story keep it. It returns the story ID.
+func (db *DB) LinkClustersToStory(ctx context.Context, storyID string, clusterIDs []string, similarity float32, title string) (string, error) {
+	var id pgtype.UUID
+
+	if err := db.Pool.QueryRow(ctx, `
+		WITH new_story AS (
+			INSERT INTO stories (title, first_seen_at, last_seen_at)
+			SELECT $3, now(), now()
+			WHERE $1::uuid IS NULL
+			RETURNING id
+		),
+		target AS (
+			SELECT id FROM new_story
+			UNION ALL
+			SELECT $1::uuid WHERE $1::uuid IS NOT NULL
+		),
+		linked AS (
+			INSERT INTO story_clusters (cluster_id, story_id, similarity)
+			SELECT c, t.id, $4
+			FROM target t, unnest($2::uuid[]) AS c
+			ON CONFLICT (cluster_id) DO NOTHING
+		)
+		SELECT id FROM target
+	`, toUUID(storyID), parseUUIDs(clusterIDs), SanitizeUTF8(title), similarity).Scan(&id); err != nil {
+		return "", fmt.Errorf("link clusters to story: %w", err)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE stories s
+		SET title = CASE WHEN $2 = '' THEN s.title ELSE $2 END,
+		    first_seen_at = COALESCE(seen.first_seen, s.first_seen_at),
+		    last_seen_at = COALESCE(seen.last_seen, s.last_seen_at)
+		FROM (
+			SELECT MIN(rm.tg_date) AS first_seen, MAX(rm.tg_date) AS last_seen
+			FROM story_clusters sc
+			JOIN cluster_items ci ON ci.cluster_id = sc.cluster_id
+			JOIN items i ON i.id = ci.item_id
+			JOIN raw_messages rm ON rm.id = i.raw_message_id
+			WHERE sc.story_id = $1
+		) seen
+		WHERE s.id = $1
+	`, id, SanitizeUTF8(title)); err != nil {
+		return "", fmt.Errorf("update story: %w", err)
+	}
+
+	return fromUUID(id), nil
+}
+
+// GetStoryItems returns up to limit of the most important items of a story's
+// clusters, oldest first.
+func (db *DB) GetStoryItems(ctx context.Context, storyID string, limit int) ([]StoryItem, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT item_id, cluster_id, summary, importance_score, tg_date, username, tg_peer_id, tg_message_id
+		FROM (
+			SELECT DISTINCT ON (i.id)
+			       i.id AS item_id, sc.cluster_id, COALESCE(i.summary, '') AS summary, i.importance_score,
+			       rm.tg_date, COALESCE(ch.username, '') AS username, ch.tg_peer_id, rm.tg_message_id
+			FROM story_clusters sc
+			JOIN cluster_items ci ON ci.cluster_id = sc.cluster_id
+			JOIN items i ON i.id = ci.item_id
+			JOIN raw_messages rm ON rm.id = i.raw_message_id
+			JOIN channels ch ON ch.id = rm.channel_id
+			WHERE sc.story_id = $1
+			ORDER BY i.id
+		) story_items
+		ORDER BY importance_score DESC
+		LIMIT $2
+	`, toUUID(storyID), safeIntToInt32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("get story items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []StoryItem
+
+	for rows.Next() {
+		var (
+			it        StoryItem
+			itemID    pgtype.UUID
+			clusterID pgtype.UUID
+		)
+
+		if err := rows.Scan(&itemID, &clusterID, &it.Summary, &it.ImportanceScore, &it.TGDate,
+			&it.ChannelUsername, &it.ChannelPeerID, &it.MsgID); err != nil {
+			return nil, fmt.Errorf("scan story item: %w", err)
+		}
+
+		it.ItemID = fromUUID(itemID)
+		it.ClusterID = fromUUID(clusterID)
+		items = append(items, it)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate story items: %w", err)
+	}
+
+	sortStoryItemsByDate(items)
+
+	return items, nil
+}
+
+// SaveStoryTimeline replaces the timeline of a story.
+func (db *DB) SaveStoryTimeline(ctx context.Context, storyID string, events []StoryEvent) error {
+	dates := make([]time.Time, len(events))
+	texts := make([]string, len(events))
+	itemIDs := make([]pgtype.UUID, len(events))
+
+	for i, e := range events {
+		dates[i] = e.Date
+		texts[i] = SanitizeUTF8(e.Text)
+		itemIDs[i] = toUUID(e.ItemID)
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		WITH deleted AS (
+			DELETE FROM story_timeline_events WHERE story_id = $1
+		),
+		touched AS (
+			UPDATE stories SET timeline_updated_at = now() WHERE id = $1
+		)
+		INSERT INTO story_timeline_events (story_id, position, event_date, text, item_id)
+		SELECT $1, e.ord, e.event_date, e.text, e.item_id
+		FROM unnest($2::timestamptz[], $3::text[], $4::uuid[]) WITH ORDINALITY AS e(event_date, text, item_id, ord)
+	`, toUUID(storyID), dates, texts, itemIDs); err != nil {
+		return fmt.Errorf("save story timeline: %w", err)
+	}
+
+	return nil
+}
+
+// GetStory returns a story with its cluster and event counts.
+func (db *DB) GetStory(ctx context.Context, storyID string) (*Story, error) {
+	stories, err := db.queryStories(ctx, `WHERE s.id = $1`, toUUID(storyID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stories) == 0 {
+		return nil, ErrStoryNotFound
+	}
+
+	return &stories[0], nil
+}
+
+// ListStories returns the most recently active stories.
+func (db *DB) ListStories(ctx context.Context, limit int) ([]Story, error) {
+	if limit <= 0 {
+		limit = defaultStoryListLimit
+	}
+
+	return db.queryStories(ctx, `ORDER BY s.last_seen_at DESC LIMIT $1`, safeIntToInt32(limit))
+}
+
+func (db *DB) queryStories(ctx context.Context, clause string, args ...any) ([]Story, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT s.id, s.title, s.first_seen_at, s.last_seen_at, s.timeline_updated_at,
+		       (SELECT COUNT(*) FROM story_clusters sc WHERE sc.story_id = s.id),
+		       (SELECT COUNT(*) FROM story_timeline_events te WHERE te.story_id = s.id)
+		FROM stories s
+		`+clause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get stories: %w", err)
+	}
+	defer rows.Close()
+
+	stories := []Story{}
+
+	for rows.Next() {
+		var (
+			s         Story
+			id        pgtype.UUID
+			updatedAt pgtype.Timestamptz
+		)
+
+		if err := rows.Scan(&id, &s.Title, &s.FirstSeenAt, &s.LastSeenAt, &updatedAt, &s.ClusterCount, &s.EventCount); err != nil {
+			return nil, fmt.Errorf("scan story: %w", err)
+		}
+
+		s.ID = fromUUID(id)
+
+		if updatedAt.Valid {
+			t := updatedAt.Time
+			s.TimelineUpdatedAt = &t
+		}
+
+		stories = append(stories, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stories: %w", err)
+	}
+
+	return stories, nil
+}
+
+// GetStoryTimeline returns the timeline of a story in chronological order.
+func (db *DB) GetStoryTimeline(ctx context.Context, storyID string) ([]StoryEvent, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT te.event_date, te.text, te.item_id, COALESCE(ch.username, ''), COALESCE(ch.tg_peer_id, 0), COALESCE(rm.tg_message_id, 0)
+		FROM story_timeline_events te
+		LEFT JOIN items i ON i.id = te.item_id
+		LEFT JOIN raw_messages rm ON rm.id = i.raw_message_id
+		LEFT JOIN channels ch ON ch.id = rm.channel_id
+		WHERE te.story_id = $1
+		ORDER BY te.position
+	`, toUUID(storyID))
+	if err != nil {
+		return nil, fmt.Errorf("get story timeline: %w", err)
+	}
+	defer rows.Close()
+
+	events := []StoryEvent{}
+
+	for rows.Next() {
+		var (
+			e      StoryEvent
+			itemID pgtype.UUID
+		)
+
+		if err := rows.Scan(&e.Date, &e.Text, &itemID, &e.ChannelUsername, &e.ChannelPeerID, &e.MsgID); err != nil {
+			return nil, fmt.Errorf("scan story event: %w", err)
+		}
+
+		e.ItemID = fromUUID(itemID)
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate story events: %w", err)
+	}
+
+	return events, nil
+}
+
+func sortStoryItemsByDate(items []StoryItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].TGDate.Before(items[j].TGDate)
+	})
+}
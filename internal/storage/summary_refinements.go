@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const summarizeTask = "summarize"
+
+// SummaryRefinementStats compares the summarize spend of two-pass
+// summarization with summarizing every item with the refine model.
+type SummaryRefinementStats struct {
+	// Drafted is the number of items summarized by the pipeline.
+	Drafted int
+	// Refined is the number of items re-summarized with the refine model.
+	Refined int
+	// DraftCostUSD is the summarize spend of every other model.
+	DraftCostUSD float64
+	// RefineCostUSD is the summarize spend of the refine model.
+	RefineCostUSD float64
+}
+
+// RefineCostPerItem is the average refine model spend per refined item.
+func (s SummaryRefinementStats) RefineCostPerItem() float64 {
+	if s.Refined == 0 {
+		return 0
+	}
+
+	return s.RefineCostUSD / float64(s.Refined)
+}
+
+// EstimatedSavingsUSD estimates what two-pass summarization saved compared
+// with summarizing every drafted item with the refine model.
+func (s SummaryRefinementStats) EstimatedSavingsUSD() float64 {
+	if s.Refined == 0 {
+		return 0
+	}
+
+	return float64(s.Drafted-s.Refined)*s.RefineCostPerItem() - s.DraftCostUSD
+}
+
+// GetUnrefinedItemSources returns the source messages of the items that were
+// not refined yet, keyed by item ID.
+func (db *DB) GetUnrefinedItemSources(ctx context.Context, itemIDs []string) (map[string]RawMessage, error) {
+	uuids := make([]pgtype.UUID, len(itemIDs))
+	for i, id := range itemIDs {
+		uuids[i] = toUUID(id)
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		SELECT i.id, rm.id, rm.channel_id, rm.tg_message_id, rm.tg_date,
+		       COALESCE(rm.text, ''), COALESCE(rm.preview_text, ''),
+		       COALESCE(c.title, ''), COALESCE(c.context, ''), COALESCE(c.description, '')
+		FROM items i
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		JOIN channels c ON c.id = rm.channel_id
+		LEFT JOIN item_summary_refinements r ON r.item_id = i.id
+		WHERE i.id = ANY($1) AND r.item_id IS NULL
+	`, uuids)
+	if err != nil {
+		return nil, fmt.Errorf("get unrefined item sources: %w", err)
+	}
+	defer rows.Close()
+
+	sources := make(map[string]RawMessage)
+
+	for rows.Next() {
+		var (
+			itemID, rawID, channelID pgtype.UUID
+			m                        RawMessage
+		)
+
+		if err := rows.Scan(&itemID, &rawID, &channelID, &m.TGMessageID, &m.TGDate, &m.Text, &m.PreviewText,
+			&m.ChannelTitle, &m.ChannelContext, &m.ChannelDescription); err != nil {
+			return nil, fmt.Errorf("scan unrefined item source: %w", err)
+		}
+
+		m.ID = fromUUID(rawID)
+		m.ChannelID = fromUUID(channelID)
+		sources[fromUUID(itemID)] = m
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate unrefined item sources: %w", err)
+	}
+
+	return sources, nil
+}
+
+// SaveSummaryRefinement replaces an item's summary with the refine model's
+// and keeps the draft summary it replaced.
+func (db *DB) SaveSummaryRefinement(ctx context.Context, itemID, summary, model string) error {
+	if _, err := db.Pool.Exec(ctx, `
+		WITH draft AS (
+			INSERT INTO item_summary_refinements (item_id, draft_summary, model)
+			SELECT id, COALESCE(summary, ''), $3 FROM items WHERE id = $1
+			ON CONFLICT (item_id) DO NOTHING
+		)
+		UPDATE items SET summary = $2 WHERE id = $1
+	`, toUUID(itemID), SanitizeUTF8(summary), SanitizeUTF8(model)); err != nil {
+		return fmt.Errorf("save summary refinement: %w", err)
+	}
+
+	return nil
+}
+
+// GetSummaryRefinementStats returns the two-pass summarization counts and
+// summarize spend since the given time. Spend is tracked per day, so since
+// is rounded down to its date.
+func (db *DB) GetSummaryRefinementStats(ctx context.Context, since time.Time, refineModel string) (SummaryRefinementStats, error) {
+	var s SummaryRefinementStats
+
+	err := db.Pool.QueryRow(ctx, `
+		SELECT
+			(SELECT count(*) FROM items WHERE created_at >= $1),
+			(SELECT count(*) FROM item_summary_refinements WHERE created_at >= $1),
+			(SELECT COALESCE(sum(cost_usd), 0)::float8 FROM llm_usage
+			 WHERE date >= $1::date AND task = $2 AND model <> $3),
+			(SELECT COALESCE(sum(cost_usd), 0)::float8 FROM llm_usage
+			 WHERE date >= $1::date AND task = $2 AND model = $3)
+	`, since, summarizeTask, refineModel).Scan(&s.Drafted, &s.Refined, &s.DraftCostUSD, &s.RefineCostUSD)
+	if err != nil {
+		return SummaryRefinementStats{}, fmt.Errorf("get summary refinement stats: %w", err)
+	}
+
+	return s, nil
+}
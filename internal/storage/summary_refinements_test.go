@@ -0,0 +1,22 @@
+package db
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummaryRefinementStats(t *testing.T) {
+	if got := (SummaryRefinementStats{Drafted: 40}).EstimatedSavingsUSD(); got != 0 {
+		t.Fatalf("savings without refinements = %v, want 0", got)
+	}
+
+	s := SummaryRefinementStats{Drafted: 100, Refined: 20, DraftCostUSD: 0.1, RefineCostUSD: 0.4}
+
+	if got := s.RefineCostPerItem(); math.Abs(got-0.02) > 1e-9 {
+		t.Fatalf("refine cost per item = %v, want 0.02", got)
+	}
+
+	if got := s.EstimatedSavingsUSD(); math.Abs(got-1.5) > 1e-9 {
+		t.Fatalf("savings = %v, want 1.5", got)
+	}
+}
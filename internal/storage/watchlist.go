@@ -0,0 +1,327 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrWatchlistEntityNotFound is returned when a watchlist entry does not exist.
+var ErrWatchlistEntityNotFound = errors.New("watchlist entity not found")
+
+// ItemEntity is a named person or organization mentioned in an item.
+type ItemEntity struct {
+	Text string
+	Type string
+}
+
+// WatchlistEntity is a person or organization whose mentions are tracked.
+// EntityType is empty when mentions of any entity type count.
+type WatchlistEntity struct {
+	ID          int64
+	Name        string
+	Aliases     []string
+	EntityType  string
+	CreatedBy   int64
+	CreatedAt   time.Time
+	LastAlertAt *time.Time
+}
+
+// WatchlistMention is a ready item that mentions a watchlist entity.
+type WatchlistMention struct {
+	ItemID          string
+	Summary         string
+	ChannelUsername string
+	ChannelTitle    string
+	ChannelPeerID   int64
+	MessageID       int64
+	Importance      float32
+	TGDate          time.Time
+}
+
+// WatchlistStat summarizes the mentions of a watchlist entity in a period.
+type WatchlistStat struct {
+	Name          string
+	Mentions      int
+	Channels      int
+	MaxImportance float32
+}
+
+// NormalizeEntityName lowercases a name and collapses its whitespace, the form
+// used to match watchlist names against extracted entities.
+func NormalizeEntityName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// watchlistPattern returns a PostgreSQL regular expression matching any of the
+// entity's names as whole words, so "Musk" matches "elon musk".
+func watchlistPattern(e WatchlistEntity) string {
+	names := make([]string, 0, len(e.Aliases)+1)
+
+	for _, n := range append([]string{e.Name}, e.Aliases...) {
+		if n = NormalizeEntityName(n); n != "" {
+			names = append(names, regexp.QuoteMeta(n))
+		}
+	}
+
+	return `\m(` + strings.Join(names, "|") + `)\M`
+}
+
+// SaveItemEntities replaces the entities stored for an item.
+func (db *DB) SaveItemEntities(ctx context.Context, itemID string, entities []ItemEntity) error {
+	texts := make([]string, len(entities))
+	normalized := make([]string, len(entities))
+	types := make([]string, len(entities))
+
+	for i, e := range entities {
+		texts[i] = SanitizeUTF8(e.Text)
+		normalized[i] = NormalizeEntityName(texts[i])
+		types[i] = e.Type
+	}
+
+	if _, err := db.Pool.Exec(ctx, `
+		WITH deleted AS (
+			DELETE FROM item_entities WHERE item_id = $1
+		)
+		INSERT INTO item_entities (item_id, position, entity, normalized, entity_type)
+		SELECT $1, e.ord, e.entity, e.normalized, e.entity_type
+		FROM unnest($2::text[], $3::text[], $4::text[]) WITH ORDINALITY AS e(entity, normalized, entity_type, ord)
+	`, toUUID(itemID), texts, normalized, types); err != nil {
+		return fmt.Errorf("save item entities: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWatchlistEntity saves a watchlist entry and returns its ID.
+func (db *DB) CreateWatchlistEntity(ctx context.Context, name string, aliases []string, entityType string, createdBy int64) (int64, error) {
+	clean := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		clean = append(clean, SanitizeUTF8(a))
+	}
+
+	var id int64
+
+	if err := db.Pool.QueryRow(ctx, `
+		INSERT INTO watchlist_entities (name, aliases, entity_type, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, SanitizeUTF8(name), clean, entityType, createdBy).Scan(&id); err != nil {
+		return 0, fmt.Errorf("create watchlist entity: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListWatchlistEntities returns all watchlist entries, oldest first.
+func (db *DB) ListWatchlistEntities(ctx context.Context) ([]WatchlistEntity, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT id, name, aliases, entity_type, created_by, created_at, last_alert_at
+		FROM watchlist_entities
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list watchlist entities: %w", err)
+	}
+	defer rows.Close()
+
+	var entities []WatchlistEntity
+
+	for rows.Next() {
+		var (
+			e         WatchlistEntity
+			lastAlert pgtype.Timestamptz
+		)
+
+		if err := rows.Scan(&e.ID, &e.Name, &e.Aliases, &e.EntityType, &e.CreatedBy, &e.CreatedAt, &lastAlert); err != nil {
+			return nil, fmt.Errorf("scan watchlist entity: %w", err)
+		}
+
+		if lastAlert.Valid {
+			t := lastAlert.Time
+			e.LastAlertAt = &t
+		}
+
+		entities = append(entities, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate watchlist entities: %w", err)
+	}
+
+	return entities, nil
+}
+
+// DeleteWatchlistEntity removes a watchlist entry and its recorded alerts.
+func (db *DB) DeleteWatchlistEntity(ctx context.Context, id int64) error {
+	tag, err := db.Pool.Exec(ctx, `DELETE FROM watchlist_entities WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete watchlist entity: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %d", ErrWatchlistEntityNotFound, id)
+	}
+
+	return nil
+}
+
+// GetWatchlistMentions returns up to limit ready items posted within
+// [from, to) that mention the entity, most important first, together with the
+// total number of mentioning items.
+func (db *DB) GetWatchlistMentions(ctx context.Context, e WatchlistEntity, from, to time.Time, limit int) ([]WatchlistMention, int, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT i.id, COALESCE(i.summary, ''), COALESCE(c.username, ''), COALESCE(c.title, ''),
+		       c.tg_peer_id, rm.tg_message_id, i.importance_score, rm.tg_date,
+		       COUNT(*) OVER ()
+		FROM items i
+		JOIN raw_messages rm ON rm.id = i.raw_message_id
+		JOIN channels c ON c.id = rm.channel_id
+		WHERE i.status = 'ready'
+		  AND rm.tg_date >= $1 AND rm.tg_date < $2
+		  AND EXISTS (
+			SELECT 1 FROM item_entities ie
+			WHERE ie.item_id = i.id
+			  AND ie.normalized ~ $3
+			  AND ($4 = '' OR ie.entity_type = $4)
+		  )
+		ORDER BY i.importance_score DESC, rm.tg_date DESC
+		LIMIT $5
+	`, toTimestamptz(from), toTimestamptz(to), watchlistPattern(e), e.EntityType, safeIntToInt32(limit))
+	if err != nil {
+		return nil, 0, fmt.Errorf("get watchlist mentions: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		mentions []WatchlistMention
+		total    int64
+	)
+
+	for rows.Next() {
+		var (
+			m      WatchlistMention
+			itemID pgtype.UUID
+		)
+
+		if err := rows.Scan(&itemID, &m.Summary, &m.ChannelUsername, &m.ChannelTitle,
+			&m.ChannelPeerID, &m.MessageID, &m.Importance, &m.TGDate, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan watchlist mention: %w", err)
+		}
+
+		m.ItemID = fromUUID(itemID)
+		mentions = append(mentions, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate watchlist mentions: %w", err)
+	}
+
+	return mentions, int(total), nil
+}
+
+// RecordWatchlistAlerts records items as alerted for a watchlist entry and
+// returns only the IDs that were not recorded before.
+func (db *DB) RecordWatchlistAlerts(ctx context.Context, watchID int64, itemIDs []string) ([]string, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+
+	uuids := make([]pgtype.UUID, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		uuids = append(uuids, toUUID(id))
+	}
+
+	rows, err := db.Pool.Query(ctx, `
+		INSERT INTO watchlist_alerts (watch_id, item_id)
+		SELECT $1, UNNEST($2::uuid[])
+		ON CONFLICT DO NOTHING
+		RETURNING item_id
+	`, watchID, uuids)
+	if err != nil {
+		return nil, fmt.Errorf("record watchlist alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var fresh []string
+
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan watchlist alert: %w", err)
+		}
+
+		fresh = append(fresh, fromUUID(id))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate watchlist alerts: %w", err)
+	}
+
+	return fresh, nil
+}
+
+// MarkWatchlistAlerted stores when a watchlist entry last triggered an alert.
+func (db *DB) MarkWatchlistAlerted(ctx context.Context, id int64, alertedAt time.Time) error {
+	if _, err := db.Pool.Exec(ctx, `
+		UPDATE watchlist_entities SET last_alert_at = $2 WHERE id = $1
+	`, id, toTimestamptz(alertedAt)); err != nil {
+		return fmt.Errorf("mark watchlist alerted: %w", err)
+	}
+
+	return nil
+}
+
+// GetWatchlistStats returns mention counts of every watchlist entry within
+// [start, end), most mentioned first. Entries without mentions are omitted.
+func (db *DB) GetWatchlistStats(ctx context.Context, start, end time.Time) ([]WatchlistStat, error) {
+	entities, err := db.ListWatchlistEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []WatchlistStat
+
+	for _, e := range entities {
+		var (
+			mentions, channels int64
+			maxImportance      pgtype.Float4
+		)
+
+		if err := db.Pool.QueryRow(ctx, `
+			SELECT COUNT(DISTINCT i.id), COUNT(DISTINCT rm.channel_id), MAX(i.importance_score)
+			FROM items i
+			JOIN raw_messages rm ON rm.id = i.raw_message_id
+			JOIN item_entities ie ON ie.item_id = i.id
+			WHERE i.status = 'ready'
+			  AND rm.tg_date >= $1 AND rm.tg_date < $2
+			  AND ie.normalized ~ $3
+			  AND ($4 = '' OR ie.entity_type = $4)
+		`, toTimestamptz(start), toTimestamptz(end), watchlistPattern(e), e.EntityType).Scan(&mentions, &channels, &maxImportance); err != nil {
+			return nil, fmt.Errorf("get watchlist stats: %w", err)
+		}
+
+		if mentions == 0 {
+			continue
+		}
+
+		stats = append(stats, WatchlistStat{
+			Name:          e.Name,
+			Mentions:      int(mentions),
+			Channels:      int(channels),
+			MaxImportance: maxImportance.Float32,
+		})
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Mentions > stats[j].Mentions
+	})
+
+	return stats, nil
+}
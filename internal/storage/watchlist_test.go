@@ -0,0 +1,11 @@
+package db
+
+import "testing"
+
+func TestWatchlistPattern(t *testing.T) {
+	e := WatchlistEntity{Name: "  Elon   Musk ", Aliases: []string{"Маск", "", "S.P.A.C.E.X"}}
+
+	if got, want := watchlistPattern(e), `\m(elon musk|маск|s\.p\.a\.c\.e\.x)\M`; got != want {
+		t.Errorf("watchlistPattern() = %q, want %q", got, want)
+	}
+}
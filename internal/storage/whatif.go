@@ -0,0 +1,248 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Item ratings counted as noise by the what-if evaluator.
+const (
+	whatIfRatingBad        = "bad"
+	whatIfRatingIrrelevant = "irrelevant"
+)
+
+// Channel weight bounds, as applied by the pipeline to importance scores.
+const (
+	whatIfMinChannelWeight = 0.1
+	whatIfMaxChannelWeight = 2.0
+)
+
+// WhatIfItem is a scored item with the per-channel inputs of the relevance
+// and importance filters, used to replay those filters with other thresholds.
+type WhatIfItem struct {
+	ItemID          string  `json:"item_id"`
+	ChannelID       string  `json:"channel_id"`
+	ChannelUsername string  `json:"channel_username"`
+	Status          string  `json:"status"`
+	Topic           string  `json:"topic"`
+	RelevanceScore  float32 `json:"relevance_score"`
+	ImportanceScore float32 `json:"importance_score"`
+	// ChannelRelevanceThreshold and ChannelImportanceThreshold override the
+	// global thresholds when positive.
+	ChannelRelevanceThreshold  float32 `json:"channel_relevance_threshold"`
+	ChannelImportanceThreshold float32 `json:"channel_importance_threshold"`
+	// RelevanceDelta is the auto-relevance adjustment, 0 when disabled.
+	RelevanceDelta float32 `json:"relevance_delta"`
+	// ChannelWeight is the importance weight already applied to ImportanceScore.
+	ChannelWeight float32 `json:"channel_weight"`
+	// Rating is the latest admin rating of the item, empty when unrated.
+	Rating string `json:"rating,omitempty"`
+}
+
+// WhatIfThresholds are the global thresholds and channel weights to evaluate.
+type WhatIfThresholds struct {
+	Relevance  float32 `json:"relevance"`
+	Importance float32 `json:"importance"`
+	// ChannelWeights overrides channel importance weights, keyed by lowercase
+	// channel username without the @.
+	ChannelWeights map[string]float32 `json:"channel_weights,omitempty"`
+}
+
+// WhatIfResult summarizes how many scored items would reach the digest.
+type WhatIfResult struct {
+	Scored   int `json:"scored"`
+	Included int `json:"included"`
+	// Rated and Noisy count the included items with a rating and with a bad
+	// or irrelevant rating.
+	Rated int `json:"rated"`
+	Noisy int `json:"noisy"`
+}
+
+// NoiseRate is the share of rated included items rated bad or irrelevant.
+func (r WhatIfResult) NoiseRate() float64 {
+	if r.Rated == 0 {
+		return 0
+	}
+
+	return float64(r.Noisy) / float64(r.Rated)
+}
+
+// WhatIfSimulation compares a scenario with the baseline thresholds.
+type WhatIfSimulation struct {
+	Baseline WhatIfResult `json:"baseline"`
+	Scenario WhatIfResult `json:"scenario"`
+	// Added and Removed are the items the scenario includes or excludes
+	// compared to the baseline.
+	Added   []WhatIfItem `json:"added"`
+	Removed []WhatIfItem `json:"removed"`
+}
+
+// GetWhatIfItems returns the items scored in [since, until), including items
+// rejected by the relevance threshold.
+func (db *DB) GetWhatIfItems(ctx context.Context, since, until time.Time) ([]WhatIfItem, error) {
+	rows, err := db.Pool.Query(ctx, `
+		SELECT i.id::text, c.id::text, COALESCE(c.username, ''), i.status, COALESCE(i.topic, ''),
+		       i.relevance_score, i.importance_score,
+		       COALESCE(c.relevance_threshold, g.relevance_threshold, 0), COALESCE(c.importance_threshold, g.importance_threshold, 0),
+		       CASE WHEN COALESCE(c.auto_relevance_enabled, FALSE) THEN COALESCE(c.relevance_threshold_delta, 0) ELSE 0 END,
+		       COALESCE(c.importance_weight, 1), COALESCE(ir.rating, '')
+		FROM items i
+		JOIN raw_messages rm ON i.raw_message_id = rm.id
+		JOIN channels c ON rm.channel_id = c.id
+		LEFT JOIN channel_groups g ON c.group_id = g.id
+		LEFT JOIN LATERAL (
+			SELECT r.rating FROM item_ratings r
+			WHERE r.item_id = i.id
+			ORDER BY r.created_at DESC
+			LIMIT 1
+		) ir ON TRUE
+		WHERE rm.tg_date >= $1 AND rm.tg_date < $2
+		  AND i.status IN ('ready', 'rejected')
+		ORDER BY rm.tg_date
+	`, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("get what-if items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []WhatIfItem
+
+	for rows.Next() {
+		var it WhatIfItem
+		if err := rows.Scan(&it.ItemID, &it.ChannelID, &it.ChannelUsername, &it.Status, &it.Topic,
+			&it.RelevanceScore, &it.ImportanceScore,
+			&it.ChannelRelevanceThreshold, &it.ChannelImportanceThreshold, &it.RelevanceDelta,
+			&it.ChannelWeight, &it.Rating); err != nil {
+			return nil, fmt.Errorf("scan what-if item: %w", err)
+		}
+
+		items = append(items, it)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate what-if items: %w", err)
+	}
+
+	return items, nil
+}
+
+// Passes reports whether the item would pass the relevance filter of the
+// pipeline and the importance filter of the digest with thresholds t.
+func (it WhatIfItem) Passes(t WhatIfThresholds) bool {
+	relevance := t.Relevance
+	if it.ChannelRelevanceThreshold > 0 {
+		relevance = it.ChannelRelevanceThreshold
+	}
+
+	relevance = clampUnitFloat32(relevance + it.RelevanceDelta)
+
+	importance := t.Importance
+	if it.ChannelImportanceThreshold > 0 {
+		importance = it.ChannelImportanceThreshold
+	}
+
+	return it.RelevanceScore >= relevance && it.importanceWith(t.ChannelWeights) >= importance
+}
+
+// importanceWith rescales the stored importance from the channel's current
+// weight to an overridden one.
+func (it WhatIfItem) importanceWith(weights map[string]float32) float32 {
+	weight, ok := weights[strings.ToLower(it.ChannelUsername)]
+	if !ok || it.ChannelUsername == "" {
+		return it.ImportanceScore
+	}
+
+	return clampUnitFloat32(it.ImportanceScore * effectiveChannelWeight(weight) / effectiveChannelWeight(it.ChannelWeight))
+}
+
+// IsNoise reports whether the item was rated bad or irrelevant.
+func (it WhatIfItem) IsNoise() bool {
+	return it.Rating == whatIfRatingBad || it.Rating == whatIfRatingIrrelevant
+}
+
+// EvaluateWhatIf counts the items that would reach the digest with thresholds t.
+func EvaluateWhatIf(items []WhatIfItem, t WhatIfThresholds) WhatIfResult {
+	res := WhatIfResult{Scored: len(items)}
+
+	for _, it := range items {
+		if it.Passes(t) {
+			res.add(it)
+		}
+	}
+
+	return res
+}
+
+// SimulateWhatIf evaluates scenario against baseline and lists the items
+// whose inclusion changes.
+func SimulateWhatIf(items []WhatIfItem, baseline, scenario WhatIfThresholds) WhatIfSimulation {
+	sim := WhatIfSimulation{
+		Baseline: WhatIfResult{Scored: len(items)},
+		Scenario: WhatIfResult{Scored: len(items)},
+		Added:    []WhatIfItem{},
+		Removed:  []WhatIfItem{},
+	}
+
+	for _, it := range items {
+		inBaseline, inScenario := it.Passes(baseline), it.Passes(scenario)
+
+		if inBaseline {
+			sim.Baseline.add(it)
+		}
+
+		if inScenario {
+			sim.Scenario.add(it)
+		}
+
+		switch {
+		case inScenario && !inBaseline:
+			sim.Added = append(sim.Added, it)
+		case inBaseline && !inScenario:
+			sim.Removed = append(sim.Removed, it)
+		}
+	}
+
+	return sim
+}
+
+func (r *WhatIfResult) add(it WhatIfItem) {
+	r.Included++
+
+	if it.Rating == "" {
+		return
+	}
+
+	r.Rated++
+
+	if it.IsNoise() {
+		r.Noisy++
+	}
+}
+
+// effectiveChannelWeight bounds a weight the way the pipeline does: weights
+// below the minimum count as neutral.
+func effectiveChannelWeight(w float32) float32 {
+	if w < whatIfMinChannelWeight {
+		return 1
+	}
+
+	if w > whatIfMaxChannelWeight {
+		return whatIfMaxChannelWeight
+	}
+
+	return w
+}
+
+func clampUnitFloat32(v float32) float32 {
+	if v < 0 {
+		return 0
+	}
+
+	if v > 1 {
+		return 1
+	}
+
+	return v
+}
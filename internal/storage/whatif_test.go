@@ -0,0 +1,89 @@
+package db
+
+import "testing"
+
+func TestWhatIfItemPasses(t *testing.T) {
+	thresholds := WhatIfThresholds{Relevance: 0.5, Importance: 0.3}
+
+	tests := []struct {
+		name string
+		item WhatIfItem
+		want bool
+	}{
+		{name: "passes both", item: WhatIfItem{RelevanceScore: 0.6, ImportanceScore: 0.4}, want: true},
+		{name: "below relevance", item: WhatIfItem{RelevanceScore: 0.4, ImportanceScore: 0.9}, want: false},
+		{name: "below importance", item: WhatIfItem{RelevanceScore: 0.9, ImportanceScore: 0.2}, want: false},
+		{name: "channel relevance override", item: WhatIfItem{RelevanceScore: 0.6, ImportanceScore: 0.4, ChannelRelevanceThreshold: 0.7}, want: false},
+		{name: "channel importance override", item: WhatIfItem{RelevanceScore: 0.6, ImportanceScore: 0.2, ChannelImportanceThreshold: 0.1}, want: true},
+		{name: "auto relevance delta", item: WhatIfItem{RelevanceScore: 0.55, ImportanceScore: 0.4, RelevanceDelta: 0.1}, want: false},
+		{name: "delta clamped to zero", item: WhatIfItem{RelevanceScore: 0, ImportanceScore: 0.4, RelevanceDelta: -0.9}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.Passes(thresholds); got != tt.want {
+				t.Errorf("Passes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateWhatIf(t *testing.T) {
+	items := []WhatIfItem{
+		{RelevanceScore: 0.9, ImportanceScore: 0.9},
+		{RelevanceScore: 0.6, ImportanceScore: 0.5},
+		{RelevanceScore: 0.3, ImportanceScore: 0.5},
+	}
+
+	got := EvaluateWhatIf(items, WhatIfThresholds{Relevance: 0.7, Importance: 0.3})
+	if got.Scored != 3 || got.Included != 1 {
+		t.Errorf("EvaluateWhatIf() = %+v, want 3 scored, 1 included", got)
+	}
+
+	got = EvaluateWhatIf(items, WhatIfThresholds{Relevance: 0.5, Importance: 0.3})
+	if got.Included != 2 {
+		t.Errorf("EvaluateWhatIf() included = %d, want 2", got.Included)
+	}
+}
+
+func TestWhatIfChannelWeights(t *testing.T) {
+	item := WhatIfItem{ChannelUsername: "News", RelevanceScore: 0.9, ImportanceScore: 0.4, ChannelWeight: 2}
+	thresholds := WhatIfThresholds{Relevance: 0.5, Importance: 0.3}
+
+	if !item.Passes(thresholds) {
+		t.Fatal("item should pass with its current weight")
+	}
+
+	thresholds.ChannelWeights = map[string]float32{"news": 1}
+	if item.Passes(thresholds) {
+		t.Error("halving the weight should drop importance to 0.2 and exclude the item")
+	}
+
+	thresholds.ChannelWeights = map[string]float32{"other": 0.5}
+	if !item.Passes(thresholds) {
+		t.Error("weights of other channels should not apply")
+	}
+}
+
+func TestSimulateWhatIf(t *testing.T) {
+	items := []WhatIfItem{
+		{ItemID: "a", RelevanceScore: 0.9, ImportanceScore: 0.9, Rating: "good"},
+		{ItemID: "b", RelevanceScore: 0.6, ImportanceScore: 0.5, Rating: "irrelevant"},
+		{ItemID: "c", RelevanceScore: 0.45, ImportanceScore: 0.5, Rating: "bad"},
+		{ItemID: "d", RelevanceScore: 0.8, ImportanceScore: 0.5},
+	}
+
+	sim := SimulateWhatIf(items, WhatIfThresholds{Relevance: 0.5, Importance: 0.3}, WhatIfThresholds{Relevance: 0.7, Importance: 0.3})
+
+	if sim.Baseline.Included != 3 || sim.Scenario.Included != 2 {
+		t.Errorf("included = %d/%d, want 3/2", sim.Baseline.Included, sim.Scenario.Included)
+	}
+
+	if sim.Baseline.NoiseRate() != 0.5 || sim.Scenario.NoiseRate() != 0 {
+		t.Errorf("noise rate = %v/%v, want 0.5/0", sim.Baseline.NoiseRate(), sim.Scenario.NoiseRate())
+	}
+
+	if len(sim.Added) != 0 || len(sim.Removed) != 1 || sim.Removed[0].ItemID != "b" {
+		t.Errorf("added %v, removed %v, want only b removed", sim.Added, sim.Removed)
+	}
+}
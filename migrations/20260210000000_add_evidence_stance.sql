@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE item_evidence
+  ADD COLUMN IF NOT EXISTS stance TEXT,
+  ADD COLUMN IF NOT EXISTS stance_confidence REAL,
+  ADD COLUMN IF NOT EXISTS stance_quotes JSONB;
+
+ALTER TABLE items
+  ADD COLUMN IF NOT EXISTS evidence_verdict TEXT,
+  ADD COLUMN IF NOT EXISTS evidence_verdict_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS items_evidence_verdict_idx
+  ON items (evidence_verdict)
+  WHERE evidence_verdict IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS items_evidence_verdict_idx;
+
+ALTER TABLE items
+  DROP COLUMN IF EXISTS evidence_verdict_at,
+  DROP COLUMN IF EXISTS evidence_verdict;
+
+ALTER TABLE item_evidence
+  DROP COLUMN IF EXISTS stance_quotes,
+  DROP COLUMN IF EXISTS stance_confidence,
+  DROP COLUMN IF EXISTS stance;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE claims
+  ADD COLUMN IF NOT EXISTS canonical_text TEXT,
+  ADD COLUMN IF NOT EXISTS aliases TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS merged_into UUID REFERENCES claims(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS claims_merged_into_idx
+  ON claims (merged_into)
+  WHERE merged_into IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS claim_merge_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    canonical_claim_id UUID NOT NULL REFERENCES claims(id) ON DELETE CASCADE,
+    merged_claim_id UUID NOT NULL,
+    merged_text TEXT NOT NULL,
+    similarity REAL NOT NULL,
+    merged_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS claim_merge_log_merged_at_idx ON claim_merge_log (merged_at DESC);
+CREATE INDEX IF NOT EXISTS claim_merge_log_canonical_idx ON claim_merge_log (canonical_claim_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS claim_merge_log_canonical_idx;
+DROP INDEX IF EXISTS claim_merge_log_merged_at_idx;
+DROP TABLE IF EXISTS claim_merge_log;
+DROP INDEX IF EXISTS claims_merged_into_idx;
+
+ALTER TABLE claims
+  DROP COLUMN IF EXISTS merged_into,
+  DROP COLUMN IF EXISTS aliases,
+  DROP COLUMN IF EXISTS canonical_text;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS channel_coordination (
+    channel_a UUID NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    channel_b UUID NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    co_posts INT NOT NULL DEFAULT 0,
+    shared_clusters INT NOT NULL DEFAULT 0,
+    total_a INT NOT NULL DEFAULT 0,
+    total_b INT NOT NULL DEFAULT 0,
+    avg_lag_seconds REAL NOT NULL DEFAULT 0,
+    score REAL NOT NULL DEFAULT 0,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (channel_a, channel_b)
+);
+
+CREATE INDEX IF NOT EXISTS channel_coordination_score_idx ON channel_coordination (score DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS channel_coordination_score_idx;
+DROP TABLE IF EXISTS channel_coordination;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS trial_until TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS discovery_auto_decisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    discovery_id UUID REFERENCES discovered_channels(id) ON DELETE SET NULL,
+    username TEXT NOT NULL,
+    action TEXT NOT NULL,
+    rules JSONB NOT NULL DEFAULT '[]'::jsonb,
+    decided_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS discovery_auto_decisions_decided_at_idx ON discovery_auto_decisions (decided_at DESC);
+CREATE INDEX IF NOT EXISTS channels_trial_until_idx ON channels (trial_until) WHERE trial_until IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS channels_trial_until_idx;
+DROP INDEX IF EXISTS discovery_auto_decisions_decided_at_idx;
+DROP TABLE IF EXISTS discovery_auto_decisions;
+ALTER TABLE channels DROP COLUMN IF EXISTS trial_until;
+-- +goose StatementEnd
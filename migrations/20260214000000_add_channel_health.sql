@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS health_status TEXT NOT NULL DEFAULT 'healthy';
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS health_checked_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS channel_health_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    channel_id UUID NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    previous_status TEXT NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    notified_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS channel_health_events_pending_idx ON channel_health_events (created_at) WHERE notified_at IS NULL;
+CREATE INDEX IF NOT EXISTS channel_health_events_channel_idx ON channel_health_events (channel_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS channel_health_events_channel_idx;
+DROP INDEX IF EXISTS channel_health_events_pending_idx;
+DROP TABLE IF EXISTS channel_health_events;
+ALTER TABLE channels DROP COLUMN IF EXISTS health_checked_at;
+ALTER TABLE channels DROP COLUMN IF EXISTS health_status;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS quota_limit INT;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS quota_period TEXT;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS quota_policy TEXT;
+
+ALTER TABLE raw_messages ADD COLUMN IF NOT EXISTS views INT NOT NULL DEFAULT 0;
+ALTER TABLE raw_messages ADD COLUMN IF NOT EXISTS forwards INT NOT NULL DEFAULT 0;
+ALTER TABLE raw_messages ADD COLUMN IF NOT EXISTS deferred_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS raw_messages_channel_processed_idx ON raw_messages (channel_id, processed_at) WHERE processed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS raw_messages_deferred_idx ON raw_messages (deferred_until) WHERE deferred_until IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS raw_messages_deferred_idx;
+DROP INDEX IF EXISTS raw_messages_channel_processed_idx;
+ALTER TABLE raw_messages DROP COLUMN IF EXISTS deferred_until;
+ALTER TABLE raw_messages DROP COLUMN IF EXISTS forwards;
+ALTER TABLE raw_messages DROP COLUMN IF EXISTS views;
+ALTER TABLE channels DROP COLUMN IF EXISTS quota_policy;
+ALTER TABLE channels DROP COLUMN IF EXISTS quota_period;
+ALTER TABLE channels DROP COLUMN IF EXISTS quota_limit;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE raw_messages ADD COLUMN IF NOT EXISTS forward_chain JSONB;
+ALTER TABLE raw_messages ADD COLUMN IF NOT EXISTS fwd_origin_peer_id BIGINT;
+ALTER TABLE raw_messages ADD COLUMN IF NOT EXISTS fwd_origin_msg_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS raw_messages_fwd_origin_idx ON raw_messages (fwd_origin_peer_id, fwd_origin_msg_id) WHERE fwd_origin_peer_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS raw_messages_fwd_origin_idx;
+ALTER TABLE raw_messages DROP COLUMN IF EXISTS fwd_origin_msg_id;
+ALTER TABLE raw_messages DROP COLUMN IF EXISTS fwd_origin_peer_id;
+ALTER TABLE raw_messages DROP COLUMN IF EXISTS forward_chain;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS search_watches (
+    id BIGSERIAL PRIMARY KEY,
+    query TEXT NOT NULL,
+    channel TEXT NOT NULL DEFAULT '',
+    topic TEXT NOT NULL DEFAULT '',
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_checked_at TIMESTAMPTZ,
+    last_match_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS search_watch_matches (
+    watch_id BIGINT NOT NULL REFERENCES search_watches(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    notified_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (watch_id, item_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS search_watch_matches;
+DROP TABLE IF EXISTS search_watches;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS dedup_decisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    raw_message_id UUID NOT NULL REFERENCES raw_messages(id) ON DELETE CASCADE,
+    mode TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    matched_item_id UUID REFERENCES items(id) ON DELETE SET NULL,
+    matched_raw_message_id UUID REFERENCES raw_messages(id) ON DELETE SET NULL,
+    similarity REAL NOT NULL,
+    threshold REAL NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS dedup_decisions_created_idx ON dedup_decisions (created_at DESC);
+CREATE INDEX IF NOT EXISTS dedup_decisions_raw_message_idx ON dedup_decisions (raw_message_id);
+CREATE INDEX IF NOT EXISTS dedup_decisions_matched_item_idx ON dedup_decisions (matched_item_id) WHERE matched_item_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS dedup_decisions_matched_raw_idx ON dedup_decisions (matched_raw_message_id) WHERE matched_raw_message_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS dedup_decisions_matched_raw_idx;
+DROP INDEX IF EXISTS dedup_decisions_matched_item_idx;
+DROP INDEX IF EXISTS dedup_decisions_raw_message_idx;
+DROP INDEX IF EXISTS dedup_decisions_created_idx;
+DROP TABLE IF EXISTS dedup_decisions;
+-- +goose StatementEnd
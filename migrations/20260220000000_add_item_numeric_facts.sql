@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_numeric_facts (
+    id BIGSERIAL PRIMARY KEY,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    value TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    context TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS item_numeric_facts_item_id_idx ON item_numeric_facts (item_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS item_numeric_facts;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE items ADD COLUMN IF NOT EXISTS regions TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS items_regions_idx ON items USING GIN (regions);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS items_regions_idx;
+
+ALTER TABLE items DROP COLUMN IF EXISTS regions;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title TEXT NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    timeline_updated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS stories_last_seen_idx ON stories (last_seen_at DESC);
+
+CREATE TABLE IF NOT EXISTS story_clusters (
+    cluster_id UUID PRIMARY KEY REFERENCES clusters(id) ON DELETE CASCADE,
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    similarity REAL NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS story_clusters_story_idx ON story_clusters (story_id);
+
+CREATE TABLE IF NOT EXISTS story_timeline_events (
+    id BIGSERIAL PRIMARY KEY,
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    event_date TIMESTAMPTZ NOT NULL,
+    text TEXT NOT NULL,
+    item_id UUID REFERENCES items(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS story_timeline_events_story_idx ON story_timeline_events (story_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS story_timeline_events;
+DROP TABLE IF EXISTS story_clusters;
+DROP TABLE IF EXISTS stories;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS shadow_digests (
+    id UUID PRIMARY KEY,
+    digest_id UUID NOT NULL REFERENCES digests(id) ON DELETE CASCADE,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    posted_chat_id BIGINT NOT NULL,
+    posted_msg_id BIGINT,
+    overrides JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS shadow_digests_created_idx ON shadow_digests (created_at DESC);
+CREATE INDEX IF NOT EXISTS shadow_digests_digest_idx ON shadow_digests (digest_id);
+
+CREATE TABLE IF NOT EXISTS shadow_digest_ratings (
+    id SERIAL PRIMARY KEY,
+    shadow_digest_id UUID NOT NULL REFERENCES shadow_digests(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    rating SMALLINT NOT NULL, -- 1 for 👍, -1 for 👎
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (shadow_digest_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shadow_digest_ratings;
+DROP TABLE IF EXISTS shadow_digests;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE setting_history ADD COLUMN IF NOT EXISTS rollback_of INTEGER REFERENCES setting_history(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS setting_history_key_idx ON setting_history (key, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS setting_history_key_idx;
+ALTER TABLE setting_history DROP COLUMN IF EXISTS rollback_of;
+-- +goose StatementEnd
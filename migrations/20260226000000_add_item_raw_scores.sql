@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_raw_scores (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    scoring_version TEXT NOT NULL,
+    relevance_score REAL NOT NULL,
+    importance_score REAL NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS item_raw_scores_version_idx ON item_raw_scores (scoring_version, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS item_raw_scores;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_score_ensembles (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    scores JSONB NOT NULL,
+    disagreement REAL NOT NULL,
+    low_confidence BOOLEAN NOT NULL DEFAULT FALSE,
+    route TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS item_score_ensembles_created_idx ON item_score_ensembles (created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS item_score_ensembles;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS prefilter_rule_hits (
+    rule TEXT PRIMARY KEY,
+    hits BIGINT NOT NULL DEFAULT 0,
+    last_hit_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS prefilter_rule_hits;
+-- +goose StatementEnd
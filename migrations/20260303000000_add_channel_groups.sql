@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS channel_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL UNIQUE,
+    relevance_threshold REAL,
+    importance_threshold REAL,
+    target_chat_id BIGINT,
+    digest_window TEXT,
+    last_digest_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES channel_groups(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_channels_group_id ON channels(group_id) WHERE group_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_channels_group_id;
+ALTER TABLE channels DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS channel_groups;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS snoozed_at TIMESTAMPTZ;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMPTZ;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS snooze_ingestion BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE channels DROP COLUMN IF EXISTS snooze_ingestion;
+ALTER TABLE channels DROP COLUMN IF EXISTS snoozed_until;
+ALTER TABLE channels DROP COLUMN IF EXISTS snoozed_at;
+-- +goose StatementEnd
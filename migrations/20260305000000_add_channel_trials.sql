@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Probation of newly added channels: their items are scored but left out of
+-- digests until an admin promotes or rejects the channel. Separate from the
+-- reduced-weight discovery trial kept in trial_until.
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS probation_started_at TIMESTAMPTZ;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS probation_until TIMESTAMPTZ;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS probation_reported_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_channels_probation_until ON channels(probation_until) WHERE probation_until IS NOT NULL;
+-- +goose StatementEnd
+
+-- Whether a message the channel posted at posted_at may reach digests:
+-- the channel is not snoozed at that time and not on probation.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION channel_in_digests(c channels, posted_at TIMESTAMPTZ) RETURNS boolean AS $$
+    SELECT (c.snoozed_at IS NULL OR posted_at < c.snoozed_at OR posted_at >= c.snoozed_until)
+       AND c.probation_until IS NULL
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS channel_in_digests(channels, TIMESTAMPTZ);
+DROP INDEX IF EXISTS idx_channels_probation_until;
+ALTER TABLE channels DROP COLUMN IF EXISTS probation_reported_at;
+ALTER TABLE channels DROP COLUMN IF EXISTS probation_until;
+ALTER TABLE channels DROP COLUMN IF EXISTS probation_started_at;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS channel_purge_log (
+    id BIGSERIAL PRIMARY KEY,
+    channel_id UUID NOT NULL,
+    tg_peer_id BIGINT NOT NULL,
+    username TEXT,
+    title TEXT,
+    counts JSONB NOT NULL,
+    purged_by BIGINT NOT NULL,
+    purged_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS channel_purge_log;
+-- +goose StatementEnd
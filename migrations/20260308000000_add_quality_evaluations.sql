@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS quality_evaluations (
+    id BIGSERIAL PRIMARY KEY,
+    evaluated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    relevance_threshold REAL NOT NULL,
+    importance_threshold REAL NOT NULL,
+    rated INTEGER NOT NULL,
+    true_positives INTEGER NOT NULL,
+    false_positives INTEGER NOT NULL,
+    false_negatives INTEGER NOT NULL,
+    true_negatives INTEGER NOT NULL,
+    precision DOUBLE PRECISION NOT NULL,
+    recall DOUBLE PRECISION NOT NULL,
+    noise_rate DOUBLE PRECISION NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_quality_evaluations_evaluated_at ON quality_evaluations (evaluated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS quality_evaluations;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_summary_refinements (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    draft_summary TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_summary_refinements_created_at ON item_summary_refinements (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS item_summary_refinements;
+-- +goose StatementEnd
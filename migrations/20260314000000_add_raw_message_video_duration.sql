@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE raw_messages ADD COLUMN IF NOT EXISTS video_duration_seconds INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE raw_messages DROP COLUMN IF EXISTS video_duration_seconds;
+-- +goose StatementEnd
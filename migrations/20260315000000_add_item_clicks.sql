@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_clicks (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    clicks INTEGER NOT NULL DEFAULT 0,
+    first_clicked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_clicked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS item_clicks;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audience_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    member_count INTEGER NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audience_snapshots_chat_recorded ON audience_snapshots (chat_id, recorded_at);
+
+CREATE TABLE IF NOT EXISTS digest_post_stats (
+    digest_id UUID PRIMARY KEY REFERENCES digests(id) ON DELETE CASCADE,
+    views INTEGER NOT NULL DEFAULT 0,
+    forwards INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS digest_post_stats;
+DROP TABLE IF EXISTS audience_snapshots;
+-- +goose StatementEnd
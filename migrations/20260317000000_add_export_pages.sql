@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS export_pages (
+    target TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    page_id TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    exported_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (target, kind, entity_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS export_pages;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_tickets (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    tracker TEXT NOT NULL,
+    ticket_key TEXT NOT NULL,
+    ticket_url TEXT NOT NULL,
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS item_tickets;
+-- +goose StatementEnd
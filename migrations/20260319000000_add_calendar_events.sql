@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS calendar_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dedup_key TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+    all_day BOOLEAN NOT NULL DEFAULT false,
+    location TEXT NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_events_starts_at ON calendar_events (starts_at);
+
+-- Items announcing each event; repeated announcements add rows here
+CREATE TABLE IF NOT EXISTS calendar_event_items (
+    event_id UUID NOT NULL REFERENCES calendar_events(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    PRIMARY KEY (event_id, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_event_items_item ON calendar_event_items (item_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS calendar_event_items;
+DROP TABLE IF EXISTS calendar_events;
+-- +goose StatementEnd
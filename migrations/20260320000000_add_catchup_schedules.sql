@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS catchup_schedules (
+    user_id BIGINT PRIMARY KEY,
+    timezone TEXT NOT NULL,
+    -- Comma-separated daily HH:MM-HH:MM windows in the user's time zone
+    windows TEXT NOT NULL,
+    last_delivered_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS catchup_schedules;
+-- +goose StatementEnd
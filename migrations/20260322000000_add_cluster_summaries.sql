@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS cluster_summaries (
+    cluster_id UUID PRIMARY KEY REFERENCES clusters(id) ON DELETE CASCADE,
+    summary TEXT NOT NULL,
+    incremental_summary TEXT NOT NULL DEFAULT '',
+    previous_cluster_id UUID REFERENCES clusters(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS cluster_summaries;
+-- +goose StatementEnd
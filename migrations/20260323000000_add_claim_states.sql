@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keyed by claim text because evidence claims get new IDs on every rebuild.
+CREATE TABLE IF NOT EXISTS claim_states (
+    claim_text TEXT PRIMARY KEY,
+    state TEXT NOT NULL,
+    supports INT NOT NULL DEFAULT 0,
+    refutes INT NOT NULL DEFAULT 0,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS claim_state_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    claim_text TEXT NOT NULL,
+    from_state TEXT NOT NULL,
+    to_state TEXT NOT NULL,
+    supports INT NOT NULL DEFAULT 0,
+    refutes INT NOT NULL DEFAULT 0,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS claim_state_log_changed_at_idx ON claim_state_log (changed_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS claim_state_log_changed_at_idx;
+DROP TABLE IF EXISTS claim_state_log;
+DROP TABLE IF EXISTS claim_states;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_entities (
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    entity TEXT NOT NULL,
+    normalized TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    PRIMARY KEY (item_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS item_entities_normalized_idx ON item_entities (normalized);
+
+CREATE TABLE IF NOT EXISTS watchlist_entities (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    entity_type TEXT NOT NULL DEFAULT '',
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_alert_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS watchlist_alerts (
+    watch_id BIGINT NOT NULL REFERENCES watchlist_entities(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    alerted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (watch_id, item_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS watchlist_alerts;
+DROP TABLE IF EXISTS watchlist_entities;
+DROP INDEX IF EXISTS item_entities_normalized_idx;
+DROP TABLE IF EXISTS item_entities;
+-- +goose StatementEnd
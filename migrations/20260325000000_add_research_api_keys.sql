@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Only the SHA-256 hash of each key is stored; the key itself is shown once on creation.
+CREATE TABLE IF NOT EXISTS research_api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    key_hash TEXT NOT NULL UNIQUE,
+    scope TEXT NOT NULL,
+    rate_per_minute INT NOT NULL,
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ
+);
+
+ALTER TABLE research_audit_log
+    ADD COLUMN IF NOT EXISTS api_key_id BIGINT REFERENCES research_api_keys(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS research_audit_log_api_key_idx ON research_audit_log (api_key_id, created_at DESC)
+    WHERE api_key_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS research_audit_log_api_key_idx;
+ALTER TABLE research_audit_log DROP COLUMN IF EXISTS api_key_id;
+DROP TABLE IF EXISTS research_api_keys;
+-- +goose StatementEnd
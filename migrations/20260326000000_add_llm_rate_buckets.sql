@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Token buckets shared by all replicas. tokens may go negative while requests
+-- wait for tokens they have reserved.
+CREATE TABLE IF NOT EXISTS llm_rate_buckets (
+    bucket TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS llm_rate_buckets;
+-- +goose StatementEnd
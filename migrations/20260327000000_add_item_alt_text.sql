@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Short description of the item's image, written by the vision model.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS alt_text TEXT;
+-- +goose StatementEnd
+
+-- Make image descriptions searchable alongside the summary and message text.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_items_search_vector() RETURNS trigger AS $$
+DECLARE
+    rm_text text;
+BEGIN
+    SELECT text INTO rm_text FROM raw_messages WHERE id = NEW.raw_message_id;
+    NEW.search_vector := to_tsvector('simple', coalesce(NEW.summary, '') || ' ' || coalesce(NEW.topic, '') || ' ' || coalesce(rm_text, '') || ' ' || coalesce(NEW.alt_text, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_items_search_vector_from_raw_message() RETURNS trigger AS $$
+BEGIN
+    UPDATE items i
+    SET search_vector = to_tsvector('simple', coalesce(i.summary, '') || ' ' || coalesce(i.topic, '') || ' ' || coalesce(NEW.text, '') || ' ' || coalesce(i.alt_text, ''))
+    WHERE i.raw_message_id = NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS items_search_vector_trigger ON items;
+
+CREATE TRIGGER items_search_vector_trigger
+BEFORE INSERT OR UPDATE OF summary, topic, raw_message_id, alt_text ON items
+FOR EACH ROW EXECUTE FUNCTION update_items_search_vector();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS items_search_vector_trigger ON items;
+
+CREATE TRIGGER items_search_vector_trigger
+BEFORE INSERT OR UPDATE OF summary, topic, raw_message_id ON items
+FOR EACH ROW EXECUTE FUNCTION update_items_search_vector();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_items_search_vector() RETURNS trigger AS $$
+DECLARE
+    rm_text text;
+BEGIN
+    SELECT text INTO rm_text FROM raw_messages WHERE id = NEW.raw_message_id;
+    NEW.search_vector := to_tsvector('simple', coalesce(NEW.summary, '') || ' ' || coalesce(NEW.topic, '') || ' ' || coalesce(rm_text, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_items_search_vector_from_raw_message() RETURNS trigger AS $$
+BEGIN
+    UPDATE items i
+    SET search_vector = to_tsvector('simple', coalesce(i.summary, '') || ' ' || coalesce(i.topic, '') || ' ' || coalesce(NEW.text, ''))
+    WHERE i.raw_message_id = NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE items DROP COLUMN IF EXISTS alt_text;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Content safety flags (nsfw, graphic, profanity) set by the pipeline.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS safety_flags TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE items DROP COLUMN IF EXISTS safety_flags;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Recorded LLM responses keyed by request, replayed by digestctl replay.
+CREATE TABLE IF NOT EXISTS llm_cassettes (
+    request_key TEXT PRIMARY KEY,
+    method TEXT NOT NULL,
+    response JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS llm_cassettes_recorded_at_idx ON llm_cassettes (recorded_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS llm_cassettes;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS pipeline_shadow_decisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    raw_message_id UUID NOT NULL REFERENCES raw_messages(id) ON DELETE CASCADE,
+    stage TEXT NOT NULL,
+    shadow_stage TEXT NOT NULL,
+    live_dropped BOOLEAN NOT NULL,
+    shadow_dropped BOOLEAN NOT NULL,
+    shadow_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS pipeline_shadow_decisions_created_idx ON pipeline_shadow_decisions (created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS pipeline_shadow_decisions_created_idx;
+DROP TABLE IF EXISTS pipeline_shadow_decisions;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Collages cached before the item list cannot be matched to a purged channel.
+DELETE FROM media_collage_cache;
+
+ALTER TABLE media_collage_cache ADD COLUMN IF NOT EXISTS item_ids UUID[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_media_collage_cache_item_ids ON media_collage_cache USING GIN (item_ids);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_media_collage_cache_item_ids;
+ALTER TABLE media_collage_cache DROP COLUMN IF EXISTS item_ids;
+-- +goose StatementEnd
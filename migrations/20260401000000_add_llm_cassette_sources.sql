@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Responses recorded before sources were tracked cannot be matched to a purged channel.
+DELETE FROM llm_cassettes;
+
+ALTER TABLE llm_cassettes ADD COLUMN IF NOT EXISTS raw_message_ids UUID[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS llm_cassettes_raw_message_ids_idx ON llm_cassettes USING GIN (raw_message_ids);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS llm_cassettes_raw_message_ids_idx;
+ALTER TABLE llm_cassettes DROP COLUMN IF EXISTS raw_message_ids;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Forward dedup looks up processed forwards of a post, earliest first.
+DROP INDEX IF EXISTS raw_messages_fwd_origin_idx;
+
+CREATE INDEX IF NOT EXISTS raw_messages_fwd_origin_idx ON raw_messages (fwd_origin_peer_id, fwd_origin_msg_id, tg_date) WHERE processed_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS raw_messages_fwd_origin_idx;
+
+CREATE INDEX IF NOT EXISTS raw_messages_fwd_origin_idx ON raw_messages (fwd_origin_peer_id, fwd_origin_msg_id) WHERE fwd_origin_peer_id IS NOT NULL;
+-- +goose StatementEnd